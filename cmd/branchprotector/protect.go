@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/url"
@@ -27,6 +29,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -36,12 +39,15 @@ import (
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 )
 
 const (
 	defaultTokens = 300
 	defaultBurst  = 100
+
+	defaultFullResyncPeriod = 24 * time.Hour
 )
 
 type options struct {
@@ -52,6 +58,10 @@ type options struct {
 
 	github           flagutil.GitHubOptions
 	githubEnablement flagutil.GitHubEnablementOptions
+	storage          flagutil.StorageClientOptions
+
+	snapshotPath     string
+	fullResyncPeriod time.Duration
 }
 
 func (o *options) Validate() error {
@@ -67,6 +77,14 @@ func (o *options) Validate() error {
 		return err
 	}
 
+	if err := o.storage.Validate(!o.confirm); err != nil {
+		return err
+	}
+
+	if o.snapshotPath != "" && o.fullResyncPeriod <= 0 {
+		return errors.New("--full-resync-period must be positive")
+	}
+
 	return nil
 }
 
@@ -79,6 +97,9 @@ func gatherOptions() options {
 	o.config.AddFlags(fs)
 	o.github.AddCustomizedFlags(fs, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
 	o.githubEnablement.AddFlags(fs)
+	o.storage.AddFlags(fs)
+	fs.StringVar(&o.snapshotPath, "snapshot-path", "", "The /local/path, gs://path/to/object or s3://path/to/object to store the desired state of the synced repos. If set, only repos whose desired state changed since the last run are synced, apart from periodic full resyncs.")
+	fs.DurationVar(&o.fullResyncPeriod, "full-resync-period", defaultFullResyncPeriod, "How often to sync all repos regardless of the snapshot at --snapshot-path.")
 	fs.Parse(os.Args[1:])
 	return o
 }
@@ -135,6 +156,27 @@ func main() {
 		enabled:                o.githubEnablement.EnablementChecker(),
 	}
 
+	var opener io.Opener
+	lastFullSync := time.Now()
+	if o.snapshotPath != "" {
+		opener, err = o.storage.StorageClient(context.Background())
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener.")
+		}
+		previous, err := loadSnapshot(context.Background(), opener, o.snapshotPath)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error loading snapshot.")
+		}
+		if time.Since(previous.LastFullSync) < o.fullResyncPeriod {
+			logrus.Infof("Only syncing repos whose desired state changed since the full sync at %s", previous.LastFullSync)
+			p.previous = previous
+			lastFullSync = previous.LastFullSync
+		} else {
+			logrus.Info("Syncing all repos")
+		}
+		p.fingerprints = map[string]string{}
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	go func() {
@@ -147,6 +189,11 @@ func main() {
 	p.protect()
 	close(p.updates)
 	errors := <-p.done
+	if opener != nil && o.confirm {
+		if err := p.newSnapshot(lastFullSync).save(context.Background(), opener, o.snapshotPath); err != nil {
+			logrus.WithError(err).Error("Failed to save snapshot.")
+		}
+	}
 	if n := len(errors); n > 0 {
 		for i, err := range errors {
 			logrus.WithError(err).Error(i)
@@ -165,6 +212,11 @@ type client interface {
 	ListAppInstallationsForOrg(org string) ([]github.AppInstallation, error)
 	ListCollaborators(org, repo string) ([]github.User, error)
 	ListRepoTeams(org, repo string) ([]github.Team, error)
+	GetServerInfo(org string) (*github.ServerInfo, error)
+	ListRepoRulesets(org, repo string) ([]github.Ruleset, error)
+	GetRepoRuleset(org, repo string, id int) (*github.Ruleset, error)
+	CreateRepoRuleset(org, repo string, ruleset github.Ruleset) error
+	UpdateRepoRuleset(org, repo string, id int, ruleset github.Ruleset) error
 }
 
 type protector struct {
@@ -177,19 +229,34 @@ type protector struct {
 	verifyRestrictions     bool
 	enableAppsRestrictions bool
 	enabled                func(org, repo string) bool
+
+	// previous is the snapshot of the last run, if repos whose desired
+	// state didn't change since then are skipped.
+	previous *snapshot
+	// fingerprints holds the fingerprints of the desired state of the repos
+	// synced by this run, if a snapshot is kept.
+	fingerprints map[string]string
+	// failedRepos are the repos whose protection failed to update. Only
+	// configureBranches writes it.
+	failedRepos sets.Set[string]
 }
 
 func (p *protector) configureBranches() {
+	if p.failedRepos == nil {
+		p.failedRepos = sets.New[string]()
+	}
 	for u := range p.updates {
 		if u.Request == nil {
 			if err := p.client.RemoveBranchProtection(u.Org, u.Repo, u.Branch); err != nil {
 				p.errors.add(fmt.Errorf("remove %s/%s=%s protection failed: %w", u.Org, u.Repo, u.Branch, err))
+				p.failedRepos.Insert(u.Org + "/" + u.Repo)
 			}
 			continue
 		}
 
 		if err := p.client.UpdateBranchProtection(u.Org, u.Repo, u.Branch, *u.Request); err != nil {
 			p.errors.add(fmt.Errorf("update %s/%s=%s protection to %v failed: %w", u.Org, u.Repo, u.Branch, *u.Request, err))
+			p.failedRepos.Insert(u.Org + "/" + u.Repo)
 		}
 	}
 	p.done <- p.errors.errs
@@ -296,6 +363,25 @@ func (p *protector) UpdateRepo(orgName string, repoName string, repo config.Repo
 		return nil
 	}
 
+	// GitHub doesn't set Protected when listing all branches, so the protected
+	// branches are listed separately below.
+	allBranches, err := p.client.GetBranches(orgName, repoName, false)
+	if err != nil {
+		return fmt.Errorf("list branches: %w", err)
+	}
+
+	var fingerprint string
+	if p.fingerprints != nil {
+		if fingerprint, err = p.fingerprint(orgName, repoName, repo, allBranches); err != nil {
+			return fmt.Errorf("fingerprint desired state: %w", err)
+		}
+		if p.previous != nil && p.previous.Repos[orgName+"/"+repoName] == fingerprint {
+			logrus.Debugf("%s/%s: desired state did not change since the last sync, skipping", orgName, repoName)
+			p.fingerprints[orgName+"/"+repoName] = fingerprint
+			return nil
+		}
+	}
+
 	githubRepo, err := p.client.GetRepo(orgName, repoName)
 	if err != nil {
 		return fmt.Errorf("could not get repo to check for archival: %w", err)
@@ -325,12 +411,12 @@ func (p *protector) UpdateRepo(orgName string, repoName string, repo config.Repo
 		}
 	}
 
+	protectedBranches, err := p.client.GetBranches(orgName, repoName, true)
+	if err != nil {
+		return fmt.Errorf("list branches: %w", err)
+	}
 	branches := map[string]github.Branch{}
-	for _, onlyProtected := range []bool{false, true} { // put true second so b.Protected is set correctly
-		bs, err := p.client.GetBranches(orgName, repoName, onlyProtected)
-		if err != nil {
-			return fmt.Errorf("list branches: %w", err)
-		}
+	for _, bs := range [][]github.Branch{allBranches, protectedBranches} { // put protected second so b.Protected is set correctly
 		for _, b := range bs {
 			_, ok := repo.Branches[b.Name]
 			if !ok && branchInclusions != nil && branchInclusions.MatchString(b.Name) {
@@ -368,6 +454,11 @@ func (p *protector) UpdateRepo(orgName string, repoName string, repo config.Repo
 	}
 
 	var errs []error
+	if repo.Policy.Unmanaged == nil || !*repo.Policy.Unmanaged {
+		if err := p.UpdateRulesets(orgName, repoName, repo.Rulesets); err != nil {
+			errs = append(errs, fmt.Errorf("update rulesets: %w", err))
+		}
+	}
 	for bn, githubBranch := range branches {
 		if branch, err := repo.GetBranch(bn); err != nil {
 			errs = append(errs, fmt.Errorf("get %s: %w", bn, err))
@@ -376,6 +467,60 @@ func (p *protector) UpdateRepo(orgName string, repoName string, repo config.Repo
 		}
 	}
 
+	if len(errs) == 0 && p.fingerprints != nil {
+		p.fingerprints[orgName+"/"+repoName] = fingerprint
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// UpdateRulesets creates the configured rulesets missing from the repo and
+// updates the ones that differ from their policy. Other rulesets are left alone.
+func (p *protector) UpdateRulesets(orgName, repoName string, rulesets []config.Ruleset) error {
+	if len(rulesets) == 0 {
+		return nil
+	}
+	info, err := p.client.GetServerInfo(orgName)
+	if err != nil {
+		return fmt.Errorf("get server info: %w", err)
+	}
+	if !info.Supports(github.CapabilityRepositoryRulesets) {
+		return fmt.Errorf("GitHub Enterprise Server %s does not support repository rulesets", info.Version)
+	}
+
+	current, err := p.client.ListRepoRulesets(orgName, repoName)
+	if err != nil {
+		return fmt.Errorf("list rulesets: %w", err)
+	}
+	ids := map[string]int{}
+	for _, r := range current {
+		ids[r.Name] = r.ID
+	}
+
+	var errs []error
+	for _, r := range rulesets {
+		ruleset := makeRuleset(r)
+		id, exists := ids[r.Name]
+		if !exists {
+			logrus.Infof("%s/%s: creating ruleset %s", orgName, repoName, r.Name)
+			if err := p.client.CreateRepoRuleset(orgName, repoName, ruleset); err != nil {
+				errs = append(errs, fmt.Errorf("create ruleset %s: %w", r.Name, err))
+			}
+			continue
+		}
+		state, err := p.client.GetRepoRuleset(orgName, repoName, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("get ruleset %s: %w", r.Name, err))
+			continue
+		}
+		if equalRulesets(state, &ruleset) {
+			logrus.Debugf("%s/%s: current ruleset %s matches policy, skipping", orgName, repoName, r.Name)
+			continue
+		}
+		logrus.Infof("%s/%s: updating ruleset %s", orgName, repoName, r.Name)
+		if err := p.client.UpdateRepoRuleset(orgName, repoName, id, ruleset); err != nil {
+			errs = append(errs, fmt.Errorf("update ruleset %s: %w", r.Name, err))
+		}
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -527,7 +672,8 @@ func equalBranchProtections(state *github.BranchProtection, request *github.Bran
 			equalRestrictions(state.Restrictions, request.Restrictions) &&
 			equalAllowForcePushes(state.AllowForcePushes, request.AllowForcePushes) &&
 			equalRequiredLinearHistory(state.RequiredLinearHistory, request.RequiredLinearHistory) &&
-			equalAllowDeletions(state.AllowDeletions, request.AllowDeletions)
+			equalAllowDeletions(state.AllowDeletions, request.AllowDeletions) &&
+			equalRequiredSignatures(state.RequiredSignatures, request.RequiredSignatures)
 	default:
 		return false
 	}
@@ -578,6 +724,10 @@ func equalAllowForcePushes(state github.AllowForcePushes, request bool) bool {
 	return state.Enabled == request
 }
 
+func equalRequiredSignatures(state github.RequiredSignatures, request bool) bool {
+	return state.Enabled == request
+}
+
 func equalAdminEnforcement(state github.EnforceAdmins, request *bool) bool {
 	switch {
 	case request == nil:
@@ -694,3 +844,44 @@ func equalUsers(stateUsers []github.User, requestUsers *[]string) bool {
 	}
 	return equalStringSlices(&users, &requestUsersNorm)
 }
+
+func equalRulesets(state, request *github.Ruleset) bool {
+	var stateConditions, requestConditions github.RulesetConditions
+	if state.Conditions != nil {
+		stateConditions = *state.Conditions
+	}
+	if request.Conditions != nil {
+		requestConditions = *request.Conditions
+	}
+	return state.Enforcement == request.Enforcement &&
+		equalStringSlices(&stateConditions.RefName.Include, &requestConditions.RefName.Include) &&
+		equalStringSlices(&stateConditions.RefName.Exclude, &requestConditions.RefName.Exclude) &&
+		equalRulesetRules(state.Rules, request.Rules)
+}
+
+func equalRulesetRules(state, request []github.RulesetRule) bool {
+	if len(state) != len(request) {
+		return false
+	}
+	environments := func(rule github.RulesetRule) []string {
+		if rule.Parameters == nil {
+			return nil
+		}
+		return rule.Parameters.RequiredDeploymentEnvironments
+	}
+	stateRules := map[string][]string{}
+	for _, rule := range state {
+		stateRules[rule.Type] = environments(rule)
+	}
+	for _, rule := range request {
+		stateEnvironments, ok := stateRules[rule.Type]
+		if !ok {
+			return false
+		}
+		requestEnvironments := environments(rule)
+		if !equalStringSlices(&stateEnvironments, &requestEnvironments) {
+			return false
+		}
+	}
+	return true
+}
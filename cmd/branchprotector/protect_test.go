@@ -89,6 +89,10 @@ type fakeClient struct {
 	appInstallations  []github.AppInstallation
 	collaborators     []github.User
 	teams             []github.Team
+	serverInfo        github.ServerInfo
+	rulesets          map[string][]github.Ruleset
+	createdRulesets   map[string][]github.Ruleset
+	updatedRulesets   map[int]github.Ruleset
 }
 
 func (c fakeClient) GetRepo(org string, repo string) (github.FullRepo, error) {
@@ -177,6 +181,44 @@ func (c *fakeClient) ListRepoTeams(org, repo string) ([]github.Team, error) {
 	return c.teams, nil
 }
 
+func (c *fakeClient) GetServerInfo(org string) (*github.ServerInfo, error) {
+	return &c.serverInfo, nil
+}
+
+func (c *fakeClient) ListRepoRulesets(org, repo string) ([]github.Ruleset, error) {
+	var rulesets []github.Ruleset
+	for _, r := range c.rulesets[org+"/"+repo] {
+		// Listing doesn't return the conditions and rules.
+		rulesets = append(rulesets, github.Ruleset{ID: r.ID, Name: r.Name, Target: r.Target, Enforcement: r.Enforcement})
+	}
+	return rulesets, nil
+}
+
+func (c *fakeClient) GetRepoRuleset(org, repo string, id int) (*github.Ruleset, error) {
+	for _, r := range c.rulesets[org+"/"+repo] {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown ruleset %d of %s/%s", id, org, repo)
+}
+
+func (c *fakeClient) CreateRepoRuleset(org, repo string, ruleset github.Ruleset) error {
+	if c.createdRulesets == nil {
+		c.createdRulesets = map[string][]github.Ruleset{}
+	}
+	c.createdRulesets[org+"/"+repo] = append(c.createdRulesets[org+"/"+repo], ruleset)
+	return nil
+}
+
+func (c *fakeClient) UpdateRepoRuleset(org, repo string, id int, ruleset github.Ruleset) error {
+	if c.updatedRulesets == nil {
+		c.updatedRulesets = map[int]github.Ruleset{}
+	}
+	c.updatedRulesets[id] = ruleset
+	return nil
+}
+
 func TestConfigureBranches(t *testing.T) {
 	yes := true
 
@@ -1744,6 +1786,86 @@ branch-protection:
 	}
 }
 
+func TestUpdateRulesets(t *testing.T) {
+	yes := true
+	deployments := config.Ruleset{
+		Name:                "deployments",
+		Include:             []string{"~DEFAULT_BRANCH"},
+		RequiredDeployments: []string{"staging"},
+	}
+	signatures := config.Ruleset{
+		Name:               "signatures",
+		Include:            []string{"release-*"},
+		RequiredSignatures: &yes,
+	}
+	current := []github.Ruleset{
+		func() github.Ruleset {
+			r := makeRuleset(deployments)
+			r.ID = 1
+			return r
+		}(),
+		func() github.Ruleset {
+			r := makeRuleset(signatures)
+			r.ID = 2
+			r.Enforcement = github.RulesetEnforcementEvaluate
+			return r
+		}(),
+		{ID: 3, Name: "unmanaged", Enforcement: github.RulesetEnforcementActive},
+	}
+
+	testCases := []struct {
+		name            string
+		serverInfo      github.ServerInfo
+		rulesets        []config.Ruleset
+		expectedCreated []github.Ruleset
+		expectedUpdated map[int]github.Ruleset
+		expectedErr     bool
+	}{
+		{
+			name: "no rulesets",
+		},
+		{
+			name:     "matching ruleset is left alone",
+			rulesets: []config.Ruleset{deployments},
+		},
+		{
+			name:            "differing ruleset is updated",
+			rulesets:        []config.Ruleset{deployments, signatures},
+			expectedUpdated: map[int]github.Ruleset{2: makeRuleset(signatures)},
+		},
+		{
+			name:            "missing ruleset is created",
+			rulesets:        []config.Ruleset{{Name: "new", Include: []string{"main"}, RequiredSignatures: &yes}},
+			expectedCreated: []github.Ruleset{makeRuleset(config.Ruleset{Name: "new", Include: []string{"main"}, RequiredSignatures: &yes})},
+		},
+		{
+			name:        "old GitHub Enterprise Server lacks rulesets",
+			serverInfo:  github.ServerInfo{Enterprise: true, Version: "3.9.2"},
+			rulesets:    []config.Ruleset{deployments},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakeClient{
+				serverInfo: tc.serverInfo,
+				rulesets:   map[string][]github.Ruleset{"org/repo": current},
+			}
+			p := protector{client: &fc}
+			err := p.UpdateRulesets("org", "repo", tc.rulesets)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(tc.expectedCreated, fc.createdRulesets["org/repo"]); diff != "" {
+				t.Errorf("unexpected created rulesets (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedUpdated, fc.updatedRulesets); diff != "" {
+				t.Errorf("unexpected updated rulesets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestEqualBranchProtection(t *testing.T) {
 	yes := true
 	var testCases = []struct {
@@ -1888,6 +2010,17 @@ func TestEqualBranchProtection(t *testing.T) {
 				AllowForcePushes: true,
 			},
 		},
+		{
+			name: "RequiredSignatures is recognized",
+			state: &github.BranchProtection{
+				RequiredSignatures: github.RequiredSignatures{
+					Enabled: false,
+				},
+			},
+			request: &github.BranchProtectionRequest{
+				RequiredSignatures: true,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"strings"
+
 	branchprotection "sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 
@@ -34,6 +36,7 @@ func makeRequest(policy branchprotection.Policy, enableAppsRestrictions bool) gi
 		RequiredLinearHistory:      makeBool(policy.RequiredLinearHistory),
 		AllowForcePushes:           makeBool(policy.AllowForcePushes),
 		AllowDeletions:             makeBool(policy.AllowDeletions),
+		RequiredSignatures:         makeBool(policy.RequiredSignatures),
 	}
 
 }
@@ -152,3 +155,53 @@ func makeReviews(rp *branchprotection.ReviewPolicy) *github.RequiredPullRequestR
 	}
 	return &rprr
 }
+
+// makeRuleset renders a ruleset policy into the corresponding GitHub api object.
+//
+// Returns an active ruleset unless the enforcement is set, with non-nil
+// conditions and rules.
+func makeRuleset(rp branchprotection.Ruleset) github.Ruleset {
+	enforcement := rp.Enforcement
+	if enforcement == "" {
+		enforcement = github.RulesetEnforcementActive
+	}
+	rules := []github.RulesetRule{}
+	if len(rp.RequiredDeployments) > 0 {
+		rules = append(rules, github.RulesetRule{
+			Type: github.RulesetRuleRequiredDeployments,
+			Parameters: &github.RulesetRuleParameters{
+				RequiredDeploymentEnvironments: sets.List(sets.New[string](rp.RequiredDeployments...)),
+			},
+		})
+	}
+	if makeBool(rp.RequiredSignatures) {
+		rules = append(rules, github.RulesetRule{Type: github.RulesetRuleRequiredSignatures})
+	}
+	return github.Ruleset{
+		Name:        rp.Name,
+		Target:      "branch",
+		Enforcement: enforcement,
+		Conditions: &github.RulesetConditions{
+			RefName: github.RulesetRefNameCondition{
+				Include: makeRefPatterns(rp.Include),
+				Exclude: makeRefPatterns(rp.Exclude),
+			},
+		},
+		Rules: rules,
+	}
+}
+
+// makeRefPatterns qualifies branch name patterns with refs/heads/, except for
+// the special ~DEFAULT_BRANCH and ~ALL patterns.
+//
+// Returns an empty list when there are no patterns.
+func makeRefPatterns(patterns []string) []string {
+	refs := []string{}
+	for _, pattern := range patterns {
+		if !strings.HasPrefix(pattern, "~") {
+			pattern = "refs/heads/" + pattern
+		}
+		refs = append(refs, pattern)
+	}
+	return refs
+}
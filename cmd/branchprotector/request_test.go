@@ -223,6 +223,16 @@ func TestMakeRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "RequiredSignatures works",
+			policy: branchprotection.Policy{
+				RequiredSignatures: &yes,
+			},
+			expected: github.BranchProtectionRequest{
+				EnforceAdmins:      &no,
+				RequiredSignatures: true,
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -234,3 +244,59 @@ func TestMakeRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeRuleset(t *testing.T) {
+	yes := true
+	cases := []struct {
+		name     string
+		ruleset  branchprotection.Ruleset
+		expected github.Ruleset
+	}{
+		{
+			name:    "Empty rules are active",
+			ruleset: branchprotection.Ruleset{Name: "empty", Include: []string{"~DEFAULT_BRANCH"}},
+			expected: github.Ruleset{
+				Name:        "empty",
+				Target:      "branch",
+				Enforcement: github.RulesetEnforcementActive,
+				Conditions: &github.RulesetConditions{
+					RefName: github.RulesetRefNameCondition{Include: []string{"~DEFAULT_BRANCH"}, Exclude: []string{}},
+				},
+				Rules: []github.RulesetRule{},
+			},
+		},
+		{
+			name: "Rules and branch patterns work",
+			ruleset: branchprotection.Ruleset{
+				Name:                "release",
+				Enforcement:         github.RulesetEnforcementEvaluate,
+				Include:             []string{"release-*"},
+				Exclude:             []string{"release-0.1"},
+				RequiredDeployments: []string{"staging", "production", "staging"},
+				RequiredSignatures:  &yes,
+			},
+			expected: github.Ruleset{
+				Name:        "release",
+				Target:      "branch",
+				Enforcement: github.RulesetEnforcementEvaluate,
+				Conditions: &github.RulesetConditions{
+					RefName: github.RulesetRefNameCondition{Include: []string{"refs/heads/release-*"}, Exclude: []string{"refs/heads/release-0.1"}},
+				},
+				Rules: []github.RulesetRule{
+					{
+						Type:       github.RulesetRuleRequiredDeployments,
+						Parameters: &github.RulesetRuleParameters{RequiredDeploymentEnvironments: []string{"production", "staging"}},
+					},
+					{Type: github.RulesetRuleRequiredSignatures},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := makeRuleset(tc.ruleset); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("actual %+v != expected %+v", actual, tc.expected)
+			}
+		})
+	}
+}
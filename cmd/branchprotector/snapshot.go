@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// snapshot records the desired state of the repos that were synced, so that
+// later runs only need to sync the repos whose desired state changed.
+type snapshot struct {
+	// LastFullSync is when all repos were last synced.
+	LastFullSync time.Time `json:"last_full_sync"`
+	// Repos holds the fingerprints of the desired state of the synced repos
+	// by org/repo.
+	Repos map[string]string `json:"repos,omitempty"`
+}
+
+// loadSnapshot reads the snapshot at path, returning an empty snapshot if
+// there is none yet.
+func loadSnapshot(ctx context.Context, opener io.Opener, path string) (*snapshot, error) {
+	content, err := io.ReadContent(ctx, logrus.NewEntry(logrus.StandardLogger()), opener, path)
+	if io.IsNotExist(err) {
+		return &snapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var s snapshot
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func (s *snapshot) save(ctx context.Context, opener io.Opener, path string) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	return io.WriteContent(ctx, logrus.NewEntry(logrus.StandardLogger()), opener, path, content)
+}
+
+// presubmitRequirements are the fields of a presubmit that determine the
+// status contexts required by branch protection.
+type presubmitRequirements struct {
+	Context         string
+	Branches        []string
+	SkipBranches    []string
+	Required        bool
+	Conditional     bool
+	ExplicitTrigger bool
+}
+
+// fingerprint hashes everything that determines the desired state of the repo:
+// its policy, its branches, the presubmits that require status contexts and
+// the options that change the requests. Including the branches makes sure that
+// new branches, e.g. release branches matching the included branches, are
+// protected by the next run instead of the next full resync.
+func (p *protector) fingerprint(orgName, repoName string, repo config.Repo, branches []github.Branch) (string, error) {
+	var branchNames []string
+	for _, b := range branches {
+		branchNames = append(branchNames, b.Name)
+	}
+	sort.Strings(branchNames)
+
+	var presubmits []presubmitRequirements
+	for _, ps := range p.cfg.GetPresubmitsStatic(orgName + "/" + repoName) {
+		presubmits = append(presubmits, presubmitRequirements{
+			Context:         ps.Context,
+			Branches:        ps.Branches,
+			SkipBranches:    ps.SkipBranches,
+			Required:        ps.ContextRequired(),
+			Conditional:     ps.TriggersConditionally(),
+			ExplicitTrigger: ps.NeedsExplicitTrigger(),
+		})
+	}
+	sort.Slice(presubmits, func(i, j int) bool {
+		return presubmits[i].Context < presubmits[j].Context
+	})
+
+	bp := p.cfg.BranchProtection
+	desired, err := json.Marshal(struct {
+		Repo                         config.Repo
+		Branches                     []string
+		Presubmits                   []presubmitRequirements
+		ProtectTested                *bool
+		AllowDisabledPolicies        *bool
+		AllowDisabledJobPolicies     *bool
+		ProtectReposWithOptionalJobs *bool
+		VerifyRestrictions           bool
+		EnableAppsRestrictions       bool
+	}{
+		Repo:                         repo,
+		Branches:                     branchNames,
+		Presubmits:                   presubmits,
+		ProtectTested:                bp.ProtectTested,
+		AllowDisabledPolicies:        bp.AllowDisabledPolicies,
+		AllowDisabledJobPolicies:     bp.AllowDisabledJobPolicies,
+		ProtectReposWithOptionalJobs: bp.ProtectReposWithOptionalJobs,
+		VerifyRestrictions:           p.verifyRestrictions,
+		EnableAppsRestrictions:       p.enableAppsRestrictions,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(desired)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newSnapshot returns the snapshot of the repos synced by this run. Repos
+// whose protection failed to update are left out, so that the next run
+// syncs them again.
+func (p *protector) newSnapshot(lastFullSync time.Time) *snapshot {
+	s := &snapshot{LastFullSync: lastFullSync, Repos: map[string]string{}}
+	for repo, fingerprint := range p.fingerprints {
+		if !p.failedRepos.Has(repo) {
+			s.Repos[repo] = fingerprint
+		}
+	}
+	return s
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+)
+
+func TestSnapshotSaveAndLoad(t *testing.T) {
+	opener := &fakeopener.FakeOpener{}
+	s, err := loadSnapshot(context.Background(), opener, "gs://bucket/snapshot.json")
+	if err != nil {
+		t.Fatalf("failed to load missing snapshot: %v", err)
+	}
+	if diff := cmp.Diff(&snapshot{}, s); diff != "" {
+		t.Errorf("expected an empty snapshot (-want +got):\n%s", diff)
+	}
+
+	expected := &snapshot{
+		LastFullSync: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Repos:        map[string]string{"org/repo": "fingerprint"},
+	}
+	if err := expected.save(context.Background(), opener, "gs://bucket/snapshot.json"); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+	s, err = loadSnapshot(context.Background(), opener, "gs://bucket/snapshot.json")
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	if diff := cmp.Diff(expected, s); diff != "" {
+		t.Errorf("unexpected snapshot (-want +got):\n%s", diff)
+	}
+}
+
+func TestIncrementalSync(t *testing.T) {
+	newProtector := func(t *testing.T, cfg string, previous *snapshot) (*protector, *fakeClient) {
+		var c config.Config
+		if err := yaml.Unmarshal([]byte(cfg), &c); err != nil {
+			t.Fatalf("failed to parse config: %v", err)
+		}
+		fc := &fakeClient{
+			repos:    map[string][]github.Repo{"org": {{Name: "repo", FullName: "org/repo"}}},
+			branches: map[string][]github.Branch{"org/repo": {{Name: "main"}}},
+		}
+		return &protector{
+			client:         fc,
+			cfg:            &c,
+			updates:        make(chan requirements, 10),
+			completedRepos: map[string]bool{},
+			previous:       previous,
+			fingerprints:   map[string]string{},
+		}, fc
+	}
+	protected := `
+branch-protection:
+  orgs:
+    org:
+      repos:
+        repo:
+          protect: true
+`
+	changed := `
+branch-protection:
+  orgs:
+    org:
+      repos:
+        repo:
+          protect: true
+          enforce_admins: true
+`
+
+	// The first run syncs the repo.
+	p, fc := newProtector(t, protected, nil)
+	repo := p.cfg.BranchProtection.GetOrg("org").GetRepo("repo")
+	if err := p.UpdateRepo("org", "repo", *repo); err != nil {
+		t.Fatalf("failed to update repo: %v", err)
+	}
+	if len(p.updates) != 1 {
+		t.Errorf("expected the branch to be updated, got %d updates", len(p.updates))
+	}
+	first := p.newSnapshot(time.Now())
+	if _, ok := first.Repos["org/repo"]; !ok {
+		t.Fatalf("expected the synced repo in the snapshot, got %v", first.Repos)
+	}
+
+	// The second run skips the repo after listing its branches.
+	p, fc = newProtector(t, protected, first)
+	fc.repos = nil
+	if err := p.UpdateRepo("org", "repo", *repo); err != nil {
+		t.Fatalf("expected the unchanged repo to be skipped, got %v", err)
+	}
+	if diff := cmp.Diff(first, p.newSnapshot(first.LastFullSync)); diff != "" {
+		t.Errorf("expected the skipped repo to stay in the snapshot (-want +got):\n%s", diff)
+	}
+
+	// A new branch syncs the repo again.
+	p, fc = newProtector(t, protected, first)
+	fc.branches["org/repo"] = append(fc.branches["org/repo"], github.Branch{Name: "release-1.0"})
+	if err := p.UpdateRepo("org", "repo", *repo); err != nil {
+		t.Fatalf("failed to update repo: %v", err)
+	}
+	if len(p.updates) != 2 {
+		t.Errorf("expected both branches of the repo with a new branch to be updated, got %d updates", len(p.updates))
+	}
+
+	// A changed policy syncs the repo again.
+	p, _ = newProtector(t, changed, first)
+	repo = p.cfg.BranchProtection.GetOrg("org").GetRepo("repo")
+	if err := p.UpdateRepo("org", "repo", *repo); err != nil {
+		t.Fatalf("failed to update repo: %v", err)
+	}
+	if len(p.updates) != 1 {
+		t.Errorf("expected the branch of the changed repo to be updated, got %d updates", len(p.updates))
+	}
+	if p.fingerprints["org/repo"] == first.Repos["org/repo"] {
+		t.Error("expected the fingerprint of the changed repo to change")
+	}
+
+	// Repos whose protection failed to update are synced again next time.
+	p.failedRepos = sets.New[string]("org/repo")
+	if s := p.newSnapshot(time.Now()); len(s.Repos) != 0 {
+		t.Errorf("expected the failed repo to be left out of the snapshot, got %v", s.Repos)
+	}
+}
@@ -74,6 +74,10 @@ type options struct {
 
 	prowYAMLRepoName string
 	prowYAMLPath     string
+	jobPolicyPath    string
+
+	preflight      bool
+	hmacSecretFile string
 
 	warnings               flagutil.Strings
 	excludeWarnings        flagutil.Strings
@@ -82,8 +86,9 @@ type options struct {
 	expensive              bool
 	includeDefaultWarnings bool
 
-	github  flagutil.GitHubOptions
-	storage flagutil.StorageClientOptions
+	github     flagutil.GitHubOptions
+	storage    flagutil.StorageClientOptions
+	kubernetes flagutil.KubernetesOptions
 }
 
 func reportWarning(strict bool, errs utilerrors.Aggregate) {
@@ -123,6 +128,7 @@ const (
 	validateLabelWarning                           = "validate-label"
 	requiredJobAnnotationsWarning                  = "required-job-annotations"
 	periodicDefaultCloneWarning                    = "periodic-default-clone-config"
+	jobPolicyWarning                               = "job-policy"
 
 	defaultHourlyTokens = 3000
 	defaultAllowedBurst = 100
@@ -148,6 +154,7 @@ var defaultWarnings = []string{
 	validateLabelWarning,
 	requiredJobAnnotationsWarning,
 	periodicDefaultCloneWarning,
+	jobPolicyWarning,
 }
 
 var expensiveWarnings = []string{
@@ -175,7 +182,7 @@ func getAllWarnings() []string {
 
 func (o *options) DefaultAndValidate() error {
 	allWarnings := getAllWarnings()
-	for _, validate := range []interface{ Validate(bool) error }{&o.config, &o.pluginsConfig, &o.storage} {
+	for _, validate := range []interface{ Validate(bool) error }{&o.config, &o.pluginsConfig, &o.storage, &o.kubernetes} {
 		if err := validate.Validate(false); err != nil {
 			return err
 		}
@@ -212,17 +219,21 @@ func (o *options) gatherOptions(flag *flag.FlagSet, args []string) error {
 	o.pluginsConfig.CheckUnknownPlugins = true
 	flag.StringVar(&o.prowYAMLRepoName, "prow-yaml-repo-name", "", "Name of the repo whose .prow.yaml should be checked.")
 	flag.StringVar(&o.prowYAMLPath, "prow-yaml-path", "", "Path to the .prow.yaml file to check. Requires --prow-yaml-repo-name to be set. Omit to look for either .prow.yaml or a .prow directory in the current working directory (recommended).")
+	flag.StringVar(&o.jobPolicyPath, "job-policy-path", "", "Path to a file with policy rules that all jobs, including the ones of the checked .prow.yaml, have to follow.")
 	flag.Var(&o.warnings, "warnings", "Warnings to validate. Use repeatedly to provide a list of warnings")
 	flag.Var(&o.excludeWarnings, "exclude-warning", "Warnings to exclude. Use repeatedly to provide a list of warnings to exclude")
 	flag.Var(&o.requiredJobAnnotations, "required-job-annotations", "Required annotation names that job has to include in a definition. Use repeatedly to provide a list of required annotations")
 	flag.BoolVar(&o.expensive, "expensive-checks", false, "If set, additional expensive warnings will be enabled")
 	flag.BoolVar(&o.strict, "strict", false, "If set, consider all warnings as errors.")
 	flag.BoolVar(&o.includeDefaultWarnings, "include-default-warnings", false, "If set force inclusion of default warning set. Normally this is inferred based on a lack of '--warnings' flags.")
+	flag.BoolVar(&o.preflight, "preflight", false, "If set, check the access to GitHub, the buckets and the build clusters with the given credentials instead of validating the config.")
+	flag.StringVar(&o.hmacSecretFile, "hmac-secret-file", "", "Path to the file containing the GitHub HMAC secret to check in --preflight mode.")
 	o.github.AddCustomizedFlags(flag, throttlerDefaults)
 	o.github.AllowAnonymous = true
 	o.config.AddFlags(flag)
 	o.pluginsConfig.AddFlags(flag)
 	o.storage.AddFlags(flag)
+	o.kubernetes.AddFlags(flag)
 	if err := flag.Parse(args); err != nil {
 		return fmt.Errorf("parse flags: %w", err)
 	}
@@ -240,6 +251,14 @@ func main() {
 		logrus.Fatalf("Error parsing options - %v", err)
 	}
 
+	if o.preflight {
+		if err := runPreflight(o, os.Stdout); err != nil {
+			logrus.WithError(err).Fatal("Preflight failed")
+		}
+		logrus.Info("All preflight checks passed!")
+		return
+	}
+
 	if err := validate(o); err != nil {
 		switch e := err.(type) {
 		case utilerrors.Aggregate:
@@ -272,8 +291,15 @@ func validate(o options) error {
 	}
 	cfg := configAgent.Config()
 
+	var policy *jobPolicy
+	if o.jobPolicyPath != "" {
+		if policy, err = loadJobPolicy(o.jobPolicyPath); err != nil {
+			return err
+		}
+	}
+
 	if o.prowYAMLRepoName != "" {
-		if err := validateInRepoConfig(cfg, o.prowYAMLPath, o.prowYAMLRepoName, o.warningEnabled(unknownFieldsAllWarning)); err != nil {
+		if err := validateInRepoConfig(cfg, o.prowYAMLPath, o.prowYAMLRepoName, o.warningEnabled(unknownFieldsAllWarning), policy); err != nil {
 			return fmt.Errorf("error validating .prow.yaml: %w", err)
 		}
 	}
@@ -372,7 +398,7 @@ func validate(o options) error {
 	unknownAllEnabled := o.warningEnabled(unknownFieldsAllWarning)
 	unknownEnabled := o.warningEnabled(unknownFieldsWarning)
 	if unknownAllEnabled {
-		if _, err := config.LoadStrict(o.config.ConfigPath, o.config.JobConfigPath, nil, ""); err != nil {
+		if _, err := config.LoadStrictWithOverlays(o.config.ConfigPath, o.config.JobConfigPath, o.config.ConfigOverlays.Strings(), nil, ""); err != nil {
 			errs = append(errs, err)
 		}
 	} else if unknownEnabled {
@@ -380,6 +406,15 @@ func validate(o options) error {
 		if err != nil {
 			return fmt.Errorf("error reading Prow config for validation: %w", err)
 		}
+		for _, overlay := range o.config.ConfigOverlays.Strings() {
+			patch, err := os.ReadFile(overlay)
+			if err != nil {
+				return fmt.Errorf("error reading Prow config overlay %s for validation: %w", overlay, err)
+			}
+			if cfgBytes, err = config.ApplyOverlays(cfgBytes, patch); err != nil {
+				return fmt.Errorf("error applying Prow config overlay %s for validation: %w", overlay, err)
+			}
+		}
 		if err := validateUnknownFields(&config.Config{}, cfgBytes, o.config.ConfigPath); err != nil {
 			errs = append(errs, err)
 		}
@@ -404,7 +439,7 @@ func validate(o options) error {
 		}
 	}
 	if o.warningEnabled(validateClusterFieldWarning) {
-		opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+		opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile, o.storage.AzureCredentialsFile)
 		if err != nil {
 			logrus.WithError(err).Fatal("Error creating opener")
 		}
@@ -449,6 +484,12 @@ func validate(o options) error {
 		}
 	}
 
+	if policy != nil && o.warningEnabled(jobPolicyWarning) {
+		if err := policy.validateJobConfig(cfg.JobConfig); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// validate rerun commands match presubmit job triggering regex
 	for _, presubmits := range cfg.JobConfig.PresubmitsStatic {
 		for _, p := range presubmits {
@@ -1142,7 +1183,7 @@ func validateTriggers(cfg *config.Config, pcfg *plugins.Configuration) error {
 	return nil
 }
 
-func validateInRepoConfig(cfg *config.Config, filepath, repoIdentifier string, strict bool) error {
+func validateInRepoConfig(cfg *config.Config, filepath, repoIdentifier string, strict bool, policy *jobPolicy) error {
 	var dir string
 	var err error
 	// Unfortunately we must continue to support the filepath arg for existing uses.
@@ -1170,6 +1211,11 @@ func validateInRepoConfig(cfg *config.Config, filepath, repoIdentifier string, s
 				pre.RerunCommand, pre.Name, cfg.Gerrit.AllowedPresubmitTriggerReRawString))
 		}
 	}
+	if policy != nil {
+		if err := policy.validateProwYAML(repoIdentifier, prowYAML); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	if len(errs) > 0 {
 		return utilerrors.NewAggregate(errs)
 	}
@@ -1229,7 +1275,8 @@ func validateJobCluster(job config.JobBase, statuses map[string]plank.ClusterSta
 	if job.Cluster != "" && job.Cluster != kube.DefaultClusterAlias && agentsNotSupportingCluster.Has(job.Agent) {
 		return fmt.Errorf("%s: cannot set cluster field if agent is %s", job.Name, job.Agent)
 	}
-	if statuses != nil {
+	// A cluster selector is resolved to a cluster by the scheduler.
+	if statuses != nil && !config.IsClusterSelector(job.Cluster) {
 		status, ok := statuses[job.Cluster]
 		if !ok {
 			return fmt.Errorf("job configuration for %q specifies unknown 'cluster' value %q", job.Name, job.Cluster)
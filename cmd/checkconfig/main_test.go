@@ -1560,6 +1560,7 @@ func TestValidateInRepoConfig(t *testing.T) {
 		name         string
 		prowYAMLData []byte
 		strict       bool
+		policy       *jobPolicy
 		expectedErr  string
 	}{
 		{
@@ -1585,6 +1586,12 @@ func TestValidateInRepoConfig(t *testing.T) {
 			prowYAMLData: []byte(`presubmits: [{"name": "hans", "never_run": "true", "spec": {"containers": [{}]}}]`),
 			expectedErr:  "error unmarshaling JSON: while decoding JSON: json: unknown field \"never_run\"",
 		},
+		{
+			name:         "prowYAML violating the job policy, err",
+			prowYAMLData: []byte(`presubmits: [{"name": "hans", "spec": {"containers": [{}]}}]`),
+			policy:       &jobPolicy{Rules: []jobPolicyRule{{Name: "labels", RequiredLabels: []string{"team"}}}},
+			expectedErr:  `job "hans" violates policy rule "labels": must have the label "team"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1617,7 +1624,7 @@ func TestValidateInRepoConfig(t *testing.T) {
 		if err != nil {
 			t.Fatalf("failed to load config: %v", err)
 		}
-		err = validateInRepoConfig(cfg, prowYAMLFileName, "my/repo", tc.strict)
+		err = validateInRepoConfig(cfg, prowYAMLFileName, "my/repo", tc.strict, tc.policy)
 		var errString string
 		if err != nil {
 			errString = err.Error()
@@ -1891,6 +1898,23 @@ func TestValidateClusterField(t *testing.T) {
 			clusterStatusFile: fmt.Sprintf(`{"default": %q, "build1": %q, "build2": %q}`, plank.ClusterStatusReachable, plank.ClusterStatusReachable, plank.ClusterStatusError),
 			expectedError:     "org1/repo1: job configuration for \"my-job\" specifies unknown 'cluster' value \"build3\"",
 		},
+		{
+			name: "cluster selector is not validated against the cluster statuses",
+			cfg: &config.Config{
+				ProwConfig: config.ProwConfig{
+					Plank: config.Plank{BuildClusterStatusFile: "gs://my-bucket/build-cluster-status.json"},
+				},
+				JobConfig: config.JobConfig{
+					PresubmitsStatic: map[string][]config.Presubmit{
+						"org1/repo1": {
+							{
+								JobBase: config.JobBase{
+									Name:    "my-job",
+									Cluster: "arch=arm64",
+								},
+							}}}}},
+			clusterStatusFile: fmt.Sprintf(`{"default": %q}`, plank.ClusterStatusReachable),
+		},
 		{
 			name: "cluster validation skipped if status file does not exist yet",
 			cfg: &config.Config{
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// jobPolicy holds the rules that jobs have to follow, e.g. to enforce
+// org-wide constraints on jobs defined in inrepoconfig.
+type jobPolicy struct {
+	Rules []jobPolicyRule `json:"rules"`
+}
+
+// jobPolicyRule is a set of constraints for the jobs of some repos.
+type jobPolicyRule struct {
+	// Name identifies the rule in violation messages.
+	Name string `json:"name"`
+	// Repos are the orgs or org/repos whose presubmits and postsubmits the
+	// rule applies to. Rules without repos apply to all jobs, including
+	// periodics.
+	Repos []string `json:"repos,omitempty"`
+
+	// MaxResourceRequests are the maximal resource requests of every
+	// container of a job.
+	MaxResourceRequests corev1.ResourceList `json:"max_resource_requests,omitempty"`
+	// RequireDecoration requires jobs to be decorated.
+	RequireDecoration bool `json:"require_decoration,omitempty"`
+	// DisallowedClusters are build clusters that jobs must not run on.
+	DisallowedClusters []string `json:"disallowed_clusters,omitempty"`
+	// AllowedImageRegistries are the registries, optionally followed by a
+	// repository path, that the images of the containers of jobs must be
+	// pulled from, e.g. gcr.io/k8s-prow.
+	AllowedImageRegistries []string `json:"allowed_image_registries,omitempty"`
+	// RequiredLabels are the keys of the labels that jobs must have.
+	RequiredLabels []string `json:"required_labels,omitempty"`
+}
+
+func loadJobPolicy(path string) (*jobPolicy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job policy: %w", err)
+	}
+	policy := &jobPolicy{}
+	if err := yaml.UnmarshalStrict(b, policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job policy %q: %w", path, err)
+	}
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d of job policy %q has no name", i, path)
+		}
+	}
+	return policy, nil
+}
+
+// appliesTo returns whether the rule applies to the jobs of the repo, which is
+// empty for periodics.
+func (r *jobPolicyRule) appliesTo(repo string) bool {
+	if len(r.Repos) == 0 {
+		return true
+	}
+	orgRepo := config.NewOrgRepo(repo)
+	for _, item := range r.Repos {
+		if item == repo || (!strings.Contains(item, "/") && item == orgRepo.Org) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *jobPolicyRule) validateJob(job config.JobBase) []string {
+	var violations []string
+	if r.RequireDecoration && (job.Decorate == nil || !*job.Decorate) {
+		violations = append(violations, "must be decorated, set `decorate: true`")
+	}
+	for _, cluster := range r.DisallowedClusters {
+		if job.Cluster == cluster {
+			violations = append(violations, fmt.Sprintf("must not run on cluster %q, set `cluster` to another build cluster", cluster))
+		}
+	}
+	for _, label := range r.RequiredLabels {
+		if _, ok := job.Labels[label]; !ok {
+			violations = append(violations, fmt.Sprintf("must have the label %q", label))
+		}
+	}
+	if job.Spec == nil {
+		return violations
+	}
+
+	var resources []string
+	for resource := range r.MaxResourceRequests {
+		resources = append(resources, string(resource))
+	}
+	sort.Strings(resources)
+	containers := append(append([]corev1.Container{}, job.Spec.InitContainers...), job.Spec.Containers...)
+	for _, container := range containers {
+		if len(r.AllowedImageRegistries) > 0 && !imageAllowed(container.Image, r.AllowedImageRegistries) {
+			violations = append(violations, fmt.Sprintf("container %q uses image %q, which is not pulled from one of the allowed registries %s", container.Name, container.Image, strings.Join(r.AllowedImageRegistries, ", ")))
+		}
+		for _, resource := range resources {
+			maxRequest := r.MaxResourceRequests[corev1.ResourceName(resource)]
+			if request, ok := container.Resources.Requests[corev1.ResourceName(resource)]; ok && request.Cmp(maxRequest) > 0 {
+				violations = append(violations, fmt.Sprintf("container %q requests %s of %s, which exceeds the maximum of %s", container.Name, request.String(), resource, maxRequest.String()))
+			}
+		}
+	}
+	return violations
+}
+
+func imageAllowed(image string, registries []string) bool {
+	for _, registry := range registries {
+		if strings.HasPrefix(image, strings.TrimSuffix(registry, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateJob returns an error listing the rules that the job violates.
+func (p *jobPolicy) validateJob(repo string, job config.JobBase) error {
+	var errs []error
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !rule.appliesTo(repo) {
+			continue
+		}
+		for _, violation := range rule.validateJob(job) {
+			errs = append(errs, fmt.Errorf("job %q violates policy rule %q: %s", job.Name, rule.Name, violation))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateJobConfig checks all static jobs against the policy.
+func (p *jobPolicy) validateJobConfig(c config.JobConfig) error {
+	var errs []error
+	for repo, presubmits := range c.PresubmitsStatic {
+		for _, presubmit := range presubmits {
+			if err := p.validateJob(repo, presubmit.JobBase); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for repo, postsubmits := range c.PostsubmitsStatic {
+		for _, postsubmit := range postsubmits {
+			if err := p.validateJob(repo, postsubmit.JobBase); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for _, periodic := range c.Periodics {
+		if err := p.validateJob("", periodic.JobBase); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateProwYAML checks the jobs of the inrepoconfig of the repo against
+// the policy.
+func (p *jobPolicy) validateProwYAML(repo string, prowYAML *config.ProwYAML) error {
+	var errs []error
+	for _, presubmit := range prowYAML.Presubmits {
+		if err := p.validateJob(repo, presubmit.JobBase); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, postsubmit := range prowYAML.Postsubmits {
+		if err := p.validateJob(repo, postsubmit.JobBase); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestLoadJobPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name: "valid policy",
+			content: `rules:
+- name: limits
+  repos:
+  - org
+  max_resource_requests:
+    cpu: "4"
+    memory: 16Gi
+  allowed_image_registries:
+  - gcr.io/k8s-prow
+`,
+		},
+		{
+			name: "unknown field",
+			content: `rules:
+- name: limits
+  max_requests:
+    cpu: "4"
+`,
+			expectedErr: `unknown field "max_requests"`,
+		},
+		{
+			name: "rule without name",
+			content: `rules:
+- require_decoration: true
+`,
+			expectedErr: "has no name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("failed to write policy: %v", err)
+			}
+			_, err := loadJobPolicy(path)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected error containing %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestJobPolicyValidateJobConfig(t *testing.T) {
+	job := func(name, cluster, image, cpu string, decorate bool, labels map[string]string) config.JobBase {
+		return config.JobBase{
+			Name:          name,
+			Cluster:       cluster,
+			Labels:        labels,
+			UtilityConfig: config.UtilityConfig{Decorate: &decorate},
+			Spec: &corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "test",
+				Image: image,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}}},
+		}
+	}
+	policy := &jobPolicy{Rules: []jobPolicyRule{
+		{
+			Name:                   "org-wide",
+			Repos:                  []string{"org"},
+			MaxResourceRequests:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			RequireDecoration:      true,
+			DisallowedClusters:     []string{"trusted"},
+			AllowedImageRegistries: []string{"gcr.io/k8s-prow"},
+		},
+		{
+			Name:           "everything",
+			RequiredLabels: []string{"team"},
+		},
+	}}
+	team := map[string]string{"team": "a"}
+
+	testCases := []struct {
+		name        string
+		jobConfig   config.JobConfig
+		expectedErr []string
+	}{
+		{
+			name: "compliant jobs",
+			jobConfig: config.JobConfig{
+				PresubmitsStatic: map[string][]config.Presubmit{
+					"org/repo": {{JobBase: job("pull", "default", "gcr.io/k8s-prow/test:v1", "2", true, team)}},
+				},
+				PostsubmitsStatic: map[string][]config.Postsubmit{
+					"other/repo": {{JobBase: job("post", "trusted", "docker.io/test", "8", false, team)}},
+				},
+				Periodics: []config.Periodic{{JobBase: job("periodic", "trusted", "docker.io/test", "8", false, team)}},
+			},
+		},
+		{
+			name: "violations of the org rule",
+			jobConfig: config.JobConfig{
+				PresubmitsStatic: map[string][]config.Presubmit{
+					"org/repo": {{JobBase: job("pull", "trusted", "docker.io/test", "8", false, team)}},
+				},
+			},
+			expectedErr: []string{
+				`job "pull" violates policy rule "org-wide": must be decorated, set ` + "`decorate: true`",
+				`job "pull" violates policy rule "org-wide": must not run on cluster "trusted", set ` + "`cluster`" + ` to another build cluster`,
+				`job "pull" violates policy rule "org-wide": container "test" uses image "docker.io/test", which is not pulled from one of the allowed registries gcr.io/k8s-prow`,
+				`job "pull" violates policy rule "org-wide": container "test" requests 8 of cpu, which exceeds the maximum of 4`,
+			},
+		},
+		{
+			name: "periodic without required label",
+			jobConfig: config.JobConfig{
+				Periodics: []config.Periodic{{JobBase: job("periodic", "default", "gcr.io/k8s-prow/test:v1", "1", true, nil)}},
+			},
+			expectedErr: []string{`job "periodic" violates policy rule "everything": must have the label "team"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actualErr []string
+			if err := policy.validateJobConfig(tc.jobConfig); err != nil {
+				for _, err := range utilerrors.Flatten(err.(utilerrors.Aggregate)).Errors() {
+					actualErr = append(actualErr, err.Error())
+				}
+			}
+			if diff := cmp.Diff(tc.expectedErr, actualErr); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	stdio "io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/plank"
+)
+
+// preflightResult is the result of checking that Prow can use one of the
+// services it depends on.
+type preflightResult struct {
+	check  string
+	target string
+	err    error
+}
+
+type preflightGitHubClient interface {
+	ghAppListingClient
+	BotUser() (*github.UserData, error)
+	GetRepo(owner, name string) (github.FullRepo, error)
+}
+
+// runPreflight checks the credentials given to checkconfig against the
+// services configured in the Prow config and prints the results as a table.
+func runPreflight(o options, out stdio.Writer) error {
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		return fmt.Errorf("error loading prow config: %w", err)
+	}
+	cfg := configAgent.Config()
+
+	var results []preflightResult
+	if o.github.TokenPath != "" || o.github.AppID != "" {
+		githubClient, err := o.github.GitHubClient(false)
+		if err != nil {
+			results = append(results, preflightResult{check: "GitHub", target: "client", err: err})
+		} else {
+			results = append(results, checkGitHub(githubClient, o.github.AppID != "", cfg.AllRepos)...)
+		}
+	}
+
+	if o.hmacSecretFile != "" {
+		secret, err := os.ReadFile(o.hmacSecretFile)
+		if err != nil {
+			results = append(results, preflightResult{check: "Webhook HMAC", target: o.hmacSecretFile, err: err})
+		} else {
+			results = append(results, preflightResult{check: "Webhook HMAC", target: o.hmacSecretFile, err: checkHMAC(secret, cfg.AllRepos)})
+		}
+	}
+
+	ctx := context.Background()
+	opener, err := io.NewOpener(ctx, o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile, o.storage.AzureCredentialsFile)
+	if err != nil {
+		results = append(results, preflightResult{check: "Storage", target: "client", err: err})
+	} else {
+		results = append(results, checkBuckets(ctx, opener, decorationBuckets(cfg))...)
+	}
+
+	results = append(results, checkBuildClusters(&o.kubernetes, cfg.PodNamespace)...)
+
+	printPreflightResults(out, results)
+	var failed int
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d preflight checks failed", failed, len(results))
+	}
+	return nil
+}
+
+func checkGitHub(client preflightGitHubClient, isApp bool, repos sets.Set[string]) []preflightResult {
+	botUser, err := client.BotUser()
+	if err != nil {
+		return []preflightResult{{check: "GitHub", target: "authentication", err: err}}
+	}
+	results := []preflightResult{{check: "GitHub", target: "authentication as " + botUser.Login}}
+
+	if isApp {
+		results = append(results, preflightResult{check: "GitHub", target: "app installations", err: validateGitHubAppIsInstalled(client, repos)})
+	}
+
+	for _, orgRepo := range sets.List(repos) {
+		org, repo, err := config.SplitRepoName(orgRepo)
+		if err != nil || strings.Contains(repo, "/") {
+			continue
+		}
+		fullRepo, err := client.GetRepo(org, repo)
+		if err == nil && !fullRepo.Permissions.Push {
+			err = fmt.Errorf("missing write permission, comments, labels and merges will fail")
+		}
+		results = append(results, preflightResult{check: "GitHub", target: "access to " + orgRepo, err: err})
+	}
+	return results
+}
+
+// checkHMAC checks that the HMAC secret configures a token for every repo.
+func checkHMAC(secret []byte, repos sets.Set[string]) error {
+	if len(strings.TrimSpace(string(secret))) == 0 {
+		return fmt.Errorf("the secret is empty")
+	}
+	tokens := map[string]github.HMACsForRepo{}
+	if err := yaml.Unmarshal(secret, &tokens); err != nil {
+		// A single token for all repos, see github.ValidatePayload.
+		return nil
+	}
+
+	hasToken := func(key string) bool {
+		for _, token := range tokens[key] {
+			if token.Value != "" {
+				return true
+			}
+		}
+		return false
+	}
+	var missing []string
+	for _, repo := range sets.List(repos) {
+		if !hasToken(repo) && !hasToken(strings.Split(repo, "/")[0]) && !hasToken("*") {
+			missing = append(missing, repo)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no token for %s, webhooks of these repos will be rejected", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// decorationBuckets returns the buckets that decorated jobs upload to.
+func decorationBuckets(cfg *config.Config) []string {
+	buckets := sets.New[string]()
+	for _, entry := range cfg.Plank.DefaultDecorationConfigs {
+		if entry.Config == nil || entry.Config.GCSConfiguration == nil || entry.Config.GCSConfiguration.Bucket == "" {
+			continue
+		}
+		bucket := entry.Config.GCSConfiguration.Bucket
+		if !strings.Contains(bucket, "://") {
+			bucket = "gs://" + bucket
+		}
+		buckets.Insert(bucket)
+	}
+	return sets.List(buckets)
+}
+
+// checkBuckets checks that objects can be written to and deleted from the
+// buckets.
+func checkBuckets(ctx context.Context, opener io.Opener, buckets []string) []preflightResult {
+	var results []preflightResult
+	for _, bucket := range buckets {
+		results = append(results, preflightResult{check: "Storage", target: bucket, err: checkBucket(ctx, opener, bucket)})
+	}
+	return results
+}
+
+func checkBucket(ctx context.Context, opener io.Opener, bucket string) error {
+	path := fmt.Sprintf("%s/checkconfig-preflight-%d", strings.TrimSuffix(bucket, "/"), time.Now().UnixNano())
+	writer, err := opener.Writer(ctx, path)
+	if err != nil {
+		return fmt.Errorf("cannot write: %w", err)
+	}
+	if _, err := writer.Write([]byte("checkconfig preflight\n")); err != nil {
+		writer.Close()
+		return fmt.Errorf("cannot write: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("cannot write: %w", err)
+	}
+	if err := opener.Delete(ctx, path); err != nil {
+		return fmt.Errorf("cannot delete %s: %w", path, err)
+	}
+	return nil
+}
+
+func checkBuildClusters(o *flagutil.KubernetesOptions, namespace string) []preflightResult {
+	clusters, err := o.KnownClusters(false)
+	if err != nil {
+		return []preflightResult{{check: "Build cluster", target: "kubeconfig", err: err}}
+	}
+	var contexts []string
+	for context := range clusters {
+		contexts = append(contexts, context)
+	}
+	sort.Strings(contexts)
+
+	var results []preflightResult
+	for _, context := range contexts {
+		client, err := o.ClusterClientForContext(context, false)
+		if err == nil {
+			err = checkBuildCluster(client, namespace)
+		}
+		results = append(results, preflightResult{check: "Build cluster", target: context, err: err})
+	}
+	return results
+}
+
+// checkBuildCluster checks that the cluster is reachable and that test pods
+// can be managed in the namespace.
+func checkBuildCluster(client kubernetes.Interface, namespace string) error {
+	if _, err := client.Discovery().ServerVersion(); err != nil {
+		return fmt.Errorf("unreachable: %w", err)
+	}
+	return flagutil.CheckAuthorizations(client.AuthorizationV1().SelfSubjectAccessReviews(), namespace, plank.RequiredTestPodVerbs())
+}
+
+func printPreflightResults(out stdio.Writer, results []preflightResult) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tTARGET\tSTATUS\tDETAILS")
+	for _, result := range results {
+		status, details := "OK", ""
+		if result.err != nil {
+			status, details = "FAILED", result.err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.check, result.target, status, details)
+	}
+	w.Flush()
+}
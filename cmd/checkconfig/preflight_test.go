@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestCheckHMAC(t *testing.T) {
+	repos := sets.New("org/repo", "other/repo")
+	testCases := []struct {
+		name        string
+		secret      string
+		expectedErr string
+	}{
+		{
+			name:   "single token",
+			secret: "abcde12345",
+		},
+		{
+			name:        "empty secret",
+			secret:      "\n",
+			expectedErr: "the secret is empty",
+		},
+		{
+			name: "tokens for all repos",
+			secret: `'*':
+- value: global
+org:
+- value: org
+`,
+		},
+		{
+			name: "repo without token",
+			secret: `org/repo:
+- value: repo
+`,
+			expectedErr: "no token for other/repo, webhooks of these repos will be rejected",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkHMAC([]byte(tc.secret), repos)
+			var actualErr string
+			if err != nil {
+				actualErr = err.Error()
+			}
+			if actualErr != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, actualErr)
+			}
+		})
+	}
+}
+
+type fakePreflightGitHubClient struct {
+	installations []github.AppInstallation
+	repos         map[string]github.FullRepo
+}
+
+func (c *fakePreflightGitHubClient) ListAppInstallations() ([]github.AppInstallation, error) {
+	return c.installations, nil
+}
+
+func (c *fakePreflightGitHubClient) BotUser() (*github.UserData, error) {
+	return &github.UserData{Login: "prow-bot"}, nil
+}
+
+func (c *fakePreflightGitHubClient) GetRepo(owner, name string) (github.FullRepo, error) {
+	repo, ok := c.repos[owner+"/"+name]
+	if !ok {
+		return github.FullRepo{}, errors.New("not found")
+	}
+	return repo, nil
+}
+
+func TestCheckGitHub(t *testing.T) {
+	client := &fakePreflightGitHubClient{
+		installations: []github.AppInstallation{{Account: github.User{Login: "org"}}},
+		repos: map[string]github.FullRepo{
+			"org/repo":   {Repo: github.Repo{Permissions: github.RepoPermissions{Push: true}}},
+			"org/public": {Repo: github.Repo{Permissions: github.RepoPermissions{Pull: true}}},
+		},
+	}
+	results := checkGitHub(client, true, sets.New("org/repo", "org/public", "other/repo"))
+
+	expected := map[string]string{
+		"authentication as prow-bot": "",
+		"app installations":          `There is configuration for the GitHub org "other" but the GitHub app is not installed there`,
+		"access to org/public":       "missing write permission, comments, labels and merges will fail",
+		"access to org/repo":         "",
+		"access to other/repo":       "not found",
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d: %v", len(expected), len(results), results)
+	}
+	for _, result := range results {
+		expectedErr, ok := expected[result.target]
+		if !ok {
+			t.Errorf("unexpected result for %q", result.target)
+			continue
+		}
+		var actualErr string
+		if result.err != nil {
+			actualErr = result.err.Error()
+		}
+		if actualErr != expectedErr {
+			t.Errorf("expected error %q for %q, got %q", expectedErr, result.target, actualErr)
+		}
+	}
+}
+
+func TestCheckBuckets(t *testing.T) {
+	dir := t.TempDir()
+	readOnly := filepath.Join(dir, "read-only")
+	if err := os.Mkdir(readOnly, 0555); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	opener, err := io.NewOpener(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("failed to create opener: %v", err)
+	}
+
+	results := checkBuckets(context.Background(), opener, []string{filepath.Join(dir, "writable"), readOnly})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %v", results)
+	}
+	if results[0].err != nil {
+		t.Errorf("expected writable bucket to pass, got %v", results[0].err)
+	}
+	if os.Getuid() != 0 && results[1].err == nil {
+		t.Error("expected read-only bucket to fail")
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "writable"))
+	if err != nil {
+		t.Fatalf("failed to read bucket: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected the preflight object to be deleted, got %v", entries)
+	}
+}
+
+func TestCheckBuildCluster(t *testing.T) {
+	testCases := []struct {
+		name      string
+		allowed   sets.Set[string]
+		expectErr bool
+	}{
+		{
+			name:    "all verbs allowed",
+			allowed: sets.New("create", "delete", "list", "watch", "get", "patch"),
+		},
+		{
+			name:      "missing permissions",
+			allowed:   sets.New("get", "list"),
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			client.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				ssar := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				if ssar.Spec.ResourceAttributes.Namespace != "test-pods" {
+					return true, nil, fmt.Errorf("unexpected namespace %q", ssar.Spec.ResourceAttributes.Namespace)
+				}
+				ssar.Status.Allowed = tc.allowed.Has(ssar.Spec.ResourceAttributes.Verb)
+				return true, ssar, nil
+			})
+			err := checkBuildCluster(client, "test-pods")
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error: %t, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
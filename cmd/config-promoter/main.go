@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// config-promoter compares the effective prow configs of two environments that
+// share a base config and differ by overlays, e.g. staging and prod, and
+// promotes values from one overlay to the other.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+)
+
+type options struct {
+	configPath  string
+	fromOverlay string
+	toOverlay   string
+	paths       prowflagutil.Strings
+	confirm     bool
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	var o options
+	fs.StringVar(&o.configPath, "config-path", "", "Path to the base prowconfig shared by both environments.")
+	fs.StringVar(&o.fromOverlay, "from-overlay", "", "Path to the overlay of the environment to promote from, e.g. staging.")
+	fs.StringVar(&o.toOverlay, "to-overlay", "", "Path to the overlay of the environment to promote to, e.g. prod.")
+	fs.Var(&o.paths, "path", "Only promote values below this path, a list of keys joined by dots like 'plank.default_decoration_configs'. Can be passed multiple times, everything is promoted if unset.")
+	fs.BoolVar(&o.confirm, "confirm", false, "Rewrite --to-overlay with the promoted values. Without it, only the differences that would be promoted are printed.")
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	if o.configPath == "" {
+		return errors.New("--config-path is required")
+	}
+	if o.fromOverlay == "" {
+		return errors.New("--from-overlay is required")
+	}
+	if o.toOverlay == "" {
+		return errors.New("--to-overlay is required")
+	}
+	return nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+	if err := run(o, os.Stdout); err != nil {
+		logrus.WithError(err).Fatal("Failed to promote config")
+	}
+}
+
+func run(o options, out io.Writer) error {
+	base, err := config.ReadFileMaybeGZIP(o.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read base config: %w", err)
+	}
+	from, err := config.ReadFileMaybeGZIP(o.fromOverlay)
+	if err != nil {
+		return fmt.Errorf("failed to read overlay: %w", err)
+	}
+	to, err := config.ReadFileMaybeGZIP(o.toOverlay)
+	if err != nil {
+		return fmt.Errorf("failed to read overlay: %w", err)
+	}
+
+	diffs, err := config.DiffOverlays(base, from, to, o.paths.Strings()...)
+	if err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		fmt.Fprintf(out, "%s: %s -> %s\n", diff.PathString(), formatValue(diff.To, diff.ToSet), formatValue(diff.From, diff.FromSet))
+	}
+	if !o.confirm || len(diffs) == 0 {
+		return nil
+	}
+
+	promoted, err := config.PromoteOverlay(base, from, to, o.paths.Strings()...)
+	if err != nil {
+		return err
+	}
+	// Make sure the promoted environment still has a valid config before
+	// replacing its overlay.
+	tmp, err := os.CreateTemp(filepath.Dir(o.toOverlay), ".promoted-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary overlay: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary overlay: %w", err)
+	}
+	if _, err := tmp.Write(promoted); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary overlay: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary overlay: %w", err)
+	}
+	if _, err := config.LoadStrictWithOverlays(o.configPath, "", []string{tmp.Name()}, nil, ""); err != nil {
+		return fmt.Errorf("promoted config is invalid: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), o.toOverlay); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", o.toOverlay, err)
+	}
+	logrus.WithField("overlay", o.toOverlay).Info("Promoted config.")
+	return nil
+}
+
+func formatValue(value interface{}, set bool) string {
+	if !set {
+		return "<unset>"
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(raw)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		options     options
+		expectedErr bool
+	}{
+		{
+			name:    "all paths set",
+			options: options{configPath: "config.yaml", fromOverlay: "staging.yaml", toOverlay: "prod.yaml"},
+		},
+		{
+			name:        "config path missing",
+			options:     options{fromOverlay: "staging.yaml", toOverlay: "prod.yaml"},
+			expectedErr: true,
+		},
+		{
+			name:        "to overlay missing",
+			options:     options{configPath: "config.yaml", fromOverlay: "staging.yaml"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.options.Validate(); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+	o := options{
+		configPath:  write("config.yaml", "tide:\n  sync_period: 1m\n"),
+		fromOverlay: write("staging.yaml", "tide:\n  sync_period: 30s\n  max_goroutines: 5\n"),
+		toOverlay:   write("prod.yaml", "tide:\n  max_goroutines: 20\n"),
+	}
+
+	var out bytes.Buffer
+	if err := run(o, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "tide.max_goroutines: 20 -> 5\ntide.sync_period: \"1m\" -> \"30s\"\n"
+	if diff := cmp.Diff(expected, out.String()); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+	if content, _ := os.ReadFile(o.toOverlay); string(content) != "tide:\n  max_goroutines: 20\n" {
+		t.Errorf("overlay must not be changed without --confirm, got %q", content)
+	}
+
+	if err := o.paths.Set("tide.sync_period"); err != nil {
+		t.Fatalf("failed to set path: %v", err)
+	}
+	out.Reset()
+	if err := run(o, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("tide.sync_period: \"1m\" -> \"30s\"\n", out.String()); diff != "" {
+		t.Errorf("unexpected output for --path (-want +got):\n%s", diff)
+	}
+
+	o.confirm = true
+	if err := run(o, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(o.toOverlay)
+	if err != nil {
+		t.Fatalf("failed to read overlay: %v", err)
+	}
+	if diff := cmp.Diff("tide:\n  max_goroutines: 20\n  sync_period: 30s\n", string(content)); diff != "" {
+		t.Errorf("unexpected promoted overlay (-want +got):\n%s", diff)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"flag"
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -35,15 +36,18 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/config/secret"
 	"sigs.k8s.io/prow/pkg/crier"
+	emailreporter "sigs.k8s.io/prow/pkg/crier/reporters/email"
 	gcsreporter "sigs.k8s.io/prow/pkg/crier/reporters/gcs"
 	k8sgcsreporter "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes"
 	gerritreporter "sigs.k8s.io/prow/pkg/crier/reporters/gerrit"
 	githubreporter "sigs.k8s.io/prow/pkg/crier/reporters/github"
+	gitlabreporter "sigs.k8s.io/prow/pkg/crier/reporters/gitlab"
 	pubsubreporter "sigs.k8s.io/prow/pkg/crier/reporters/pubsub"
 	resultstorereporter "sigs.k8s.io/prow/pkg/crier/reporters/resultstore"
 	slackreporter "sigs.k8s.io/prow/pkg/crier/reporters/slack"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/gitlab"
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
@@ -66,6 +70,21 @@ type options struct {
 	blobStorageWorkers    int
 	k8sBlobStorageWorkers int
 	resultStoreWorkers    int
+	gitlabWorkers         int
+	emailWorkers          int
+
+	gitlabEndpoint  string
+	gitlabTokenPath string
+
+	smtpServer          string
+	smtpUsername        string
+	smtpPasswordFile    string
+	emailFrom           string
+	emailDigestInterval time.Duration
+
+	reportRetryBaseDelay time.Duration
+	reportRetryMaxDelay  time.Duration
+	reportRetryBudget    int
 
 	slackTokenFile            string
 	additionalSlackTokenFiles slackclient.HostsFlag
@@ -80,13 +99,23 @@ type options struct {
 	reportAgent string
 
 	resultstoreArtifactsDirOnly bool
+
+	githubReportTestResults bool
 }
 
 func (o *options) validate() error {
-	if o.gerritWorkers+o.pubsubWorkers+o.githubWorkers+o.slackWorkers+o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers <= 0 {
+	if o.gerritWorkers+o.pubsubWorkers+o.githubWorkers+o.slackWorkers+o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers+o.gitlabWorkers+o.emailWorkers <= 0 {
 		return errors.New("crier need to have at least one report worker to start")
 	}
 
+	if o.reportRetryBaseDelay <= 0 || o.reportRetryMaxDelay < o.reportRetryBaseDelay {
+		return errors.New("--report-retry-base-delay must be positive and not exceed --report-retry-max-delay")
+	}
+
+	if o.reportRetryBudget < 0 {
+		return errors.New("--report-retry-budget must not be negative")
+	}
+
 	if o.k8sReportFraction < 0 || o.k8sReportFraction > 1 {
 		return errors.New("--kubernetes-report-fraction must be a float between 0 and 1")
 	}
@@ -106,6 +135,22 @@ func (o *options) validate() error {
 		}
 	}
 
+	if o.gitlabWorkers > 0 && o.gitlabTokenPath == "" {
+		return errors.New("--gitlab-token-path must be set when --gitlab-workers is set")
+	}
+
+	if o.emailWorkers > 0 {
+		if o.smtpServer == "" || o.emailFrom == "" {
+			return errors.New("--smtp-server and --email-from must be set when --email-workers is set")
+		}
+		if o.smtpUsername != "" && o.smtpPasswordFile == "" {
+			return errors.New("--smtp-password-file must be set when --smtp-username is set")
+		}
+		if o.emailDigestInterval <= 0 {
+			return errors.New("--email-digest-interval must be positive")
+		}
+	}
+
 	if o.slackWorkers > 0 {
 		if o.slackTokenFile == "" && len(o.additionalSlackTokenFiles) == 0 {
 			return errors.New("one of --slack-token-file or --additional-slack-token-files must be set")
@@ -127,17 +172,31 @@ func (o *options) parseArgs(fs *flag.FlagSet, args []string) error {
 	fs.IntVar(&o.pubsubWorkers, "pubsub-workers", 0, "Number of pubsub report workers (0 means disabled)")
 	fs.IntVar(&o.githubWorkers, "github-workers", 0, "Number of github report workers (0 means disabled)")
 	fs.IntVar(&o.slackWorkers, "slack-workers", 0, "Number of Slack report workers (0 means disabled)")
+	fs.IntVar(&o.gitlabWorkers, "gitlab-workers", 0, "Number of GitLab report workers (0 means disabled)")
+	fs.StringVar(&o.gitlabEndpoint, "gitlab-endpoint", "", "GitLab's API endpoint, defaults to "+gitlab.DefaultAPIEndpoint)
+	fs.IntVar(&o.emailWorkers, "email-workers", 0, "Number of email report workers (0 means disabled)")
+	fs.StringVar(&o.smtpServer, "smtp-server", "", "host:port of the SMTP server emails are sent through")
+	fs.StringVar(&o.smtpUsername, "smtp-username", "", "Username to authenticate with the SMTP server, leave empty for no authentication")
+	fs.StringVar(&o.smtpPasswordFile, "smtp-password-file", "", "Path to the file containing the password of --smtp-username")
+	fs.StringVar(&o.emailFrom, "email-from", "", "Sender address of emails")
+	fs.DurationVar(&o.emailDigestInterval, "email-digest-interval", 24*time.Hour, "How often digests are sent to recipients of jobs that enable digest mode")
+	fs.StringVar(&o.gitlabTokenPath, "gitlab-token-path", "", "Path to the file containing the GitLab access token")
 	fs.Var(&o.additionalSlackTokenFiles, "additional-slack-token-files", "Map of additional slack token files. example: --additional-slack-token-files=foo=/etc/foo-slack-tokens/token, repeat flag for each host")
 	fs.IntVar(&o.blobStorageWorkers, "blob-storage-workers", 0, "Number of blob storage report workers (0 means disabled)")
 	fs.IntVar(&o.k8sBlobStorageWorkers, "kubernetes-blob-storage-workers", 0, "Number of Kubernetes-specific blob storage report workers (0 means disabled)")
 	fs.Float64Var(&o.k8sReportFraction, "kubernetes-report-fraction", 1.0, "Approximate portion of jobs to report pod information for, if kubernetes-blob-storage-workers are enabled (0 - > none, 1.0 -> all)")
 	fs.StringVar(&o.slackTokenFile, "slack-token-file", "", "Path to a Slack token file")
-	fs.StringVar(&o.reportAgent, "report-agent", "", "Only report specified agent - empty means report to all agents (effective for github and Slack only)")
+	fs.StringVar(&o.reportAgent, "report-agent", "", "Only report specified agent - empty means report to all agents (effective for github, GitLab and Slack only)")
 	fs.IntVar(&o.resultStoreWorkers, "resultstore-workers", 0, "Number of ResultStore report workers (0 means disabled)")
+	defaultRetryOptions := crier.DefaultRetryOptions()
+	fs.DurationVar(&o.reportRetryBaseDelay, "report-retry-base-delay", defaultRetryOptions.BaseDelay, "Delay before retrying a failed report, doubled with every failed attempt")
+	fs.DurationVar(&o.reportRetryMaxDelay, "report-retry-max-delay", defaultRetryOptions.MaxDelay, "Maximum delay before retrying a failed report")
+	fs.IntVar(&o.reportRetryBudget, "report-retry-budget", defaultRetryOptions.Budget, "Number of attempts to report a job state before giving up and recording the failure on the ProwJob (0 means retrying forever)")
 	fs.BoolVar(&o.resultstoreArtifactsDirOnly, "resultstore-artifacts-dir-only", false, "Report the artifacts/ dir instead of subtree files (testing)")
+	fs.BoolVar(&o.githubReportTestResults, "github-report-test-results", false, "Read the results.json of failed jobs from blob storage to name their failed tests in GitHub report comments")
 
 	// TODO(krzyzacy): implement dryrun for gerrit/pubsub
-	fs.BoolVar(&o.dryrun, "dry-run", false, "Run in dry-run mode, not doing actual report (effective for github and Slack only)")
+	fs.BoolVar(&o.dryrun, "dry-run", false, "Run in dry-run mode, not doing actual report (effective for github, GitLab, Slack and email only)")
 
 	o.config.AddFlags(fs)
 	o.github.AddFlags(fs)
@@ -152,6 +211,14 @@ func (o *options) parseArgs(fs *flag.FlagSet, args []string) error {
 	return o.validate()
 }
 
+func (o *options) retryOptions() crier.RetryOptions {
+	return crier.RetryOptions{
+		BaseDelay: o.reportRetryBaseDelay,
+		MaxDelay:  o.reportRetryMaxDelay,
+		Budget:    o.reportRetryBudget,
+	}
+}
+
 func parseOptions() options {
 	var o options
 
@@ -226,7 +293,7 @@ func main() {
 			}
 		}
 		slackReporter := slackreporter.New(slackConfig, o.dryrun, tokensMap)
-		if err := crier.New(mgr, slackReporter, o.slackWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+		if err := crier.New(mgr, slackReporter, o.slackWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
 			logrus.WithError(err).Fatal("failed to construct slack reporter controller")
 		}
 	}
@@ -241,18 +308,26 @@ func main() {
 		}
 
 		hasReporter = true
-		if err := crier.New(mgr, gerritReporter, o.gerritWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+		if err := crier.New(mgr, gerritReporter, o.gerritWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
 			logrus.WithError(err).Fatal("failed to construct gerrit reporter controller")
 		}
 	}
 
 	if o.pubsubWorkers > 0 {
 		hasReporter = true
-		if err := crier.New(mgr, pubsubreporter.NewReporter(cfg), o.pubsubWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+		if err := crier.New(mgr, pubsubreporter.NewReporter(cfg), o.pubsubWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
 			logrus.WithError(err).Fatal("failed to construct pubsub reporter controller")
 		}
 	}
 
+	var opener io.Opener
+	if o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers > 0 || (o.githubWorkers > 0 && o.githubReportTestResults) {
+		opener, err = o.storage.StorageClient(context.Background())
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener")
+		}
+	}
+
 	if o.githubWorkers > 0 {
 		if o.github.TokenPath != "" {
 			if err := secret.Add(o.github.TokenPath); err != nil {
@@ -266,24 +341,60 @@ func main() {
 		}
 
 		hasReporter = true
-		githubReporter := githubreporter.NewReporter(githubClient, cfg, prowapi.ProwJobAgent(o.reportAgent), mgr.GetCache())
-		if err := crier.New(mgr, githubReporter, o.githubWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+		var resultsOpener io.Opener
+		if o.githubReportTestResults {
+			resultsOpener = opener
+		}
+		githubReporter := githubreporter.NewReporter(githubClient, cfg, prowapi.ProwJobAgent(o.reportAgent), mgr.GetCache(), resultsOpener)
+		if err := crier.New(mgr, githubReporter, o.githubWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
 			logrus.WithError(err).Fatal("failed to construct github reporter controller")
 		}
 	}
 
-	var opener io.Opener
-	if o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers > 0 {
-		opener, err = o.storage.StorageClient(context.Background())
-		if err != nil {
-			logrus.WithError(err).Fatal("Error creating opener")
+	if o.gitlabWorkers > 0 {
+		if err := secret.Add(o.gitlabTokenPath); err != nil {
+			logrus.WithError(err).Fatal("Error reading GitLab credentials")
+		}
+
+		hasReporter = true
+		gitlabClient := gitlab.NewClient(o.gitlabEndpoint, secret.GetTokenGenerator(o.gitlabTokenPath))
+		gitlabReporter := gitlabreporter.NewReporter(gitlabClient, cfg, prowapi.ProwJobAgent(o.reportAgent), o.dryrun)
+		if err := crier.New(mgr, gitlabReporter, o.gitlabWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
+			logrus.WithError(err).Fatal("failed to construct gitlab reporter controller")
+		}
+	}
+
+	if o.emailWorkers > 0 {
+		sender := &emailreporter.SMTPSender{
+			Server:   o.smtpServer,
+			From:     o.emailFrom,
+			Username: o.smtpUsername,
+		}
+		if o.smtpPasswordFile != "" {
+			if err := secret.Add(o.smtpPasswordFile); err != nil {
+				logrus.WithError(err).Fatal("Error reading SMTP password")
+			}
+			sender.Password = secret.GetTokenGenerator(o.smtpPasswordFile)
+		}
+
+		hasReporter = true
+		emailReporter := emailreporter.New(sender, mgr.GetCache(), o.dryrun)
+		if err := crier.New(mgr, emailReporter, o.emailWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
+			logrus.WithError(err).Fatal("failed to construct email reporter controller")
+		}
+		flushDigests := func() {
+			if err := emailReporter.FlushDigests(); err != nil {
+				logrus.WithError(err).Error("Failed to send email digests")
+			}
 		}
+		interrupts.TickLiteral(flushDigests, o.emailDigestInterval)
+		interrupts.OnInterrupt(flushDigests)
 	}
 
 	if o.blobStorageWorkers > 0 || o.k8sBlobStorageWorkers > 0 {
 		hasReporter = true
 		if o.blobStorageWorkers > 0 {
-			if err := crier.New(mgr, gcsreporter.New(cfg, opener, o.dryrun), o.blobStorageWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+			if err := crier.New(mgr, gcsreporter.New(cfg, opener, o.dryrun), o.blobStorageWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
 				logrus.WithError(err).Fatal("failed to construct gcsreporter controller")
 			}
 		}
@@ -295,7 +406,7 @@ func main() {
 			}
 
 			k8sGcsReporter := k8sgcsreporter.New(cfg, opener, k8sgcsreporter.NewK8sResourceGetter(coreClients), float32(o.k8sReportFraction), o.dryrun)
-			if err := crier.New(mgr, k8sGcsReporter, o.k8sBlobStorageWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+			if err := crier.New(mgr, k8sGcsReporter, o.k8sBlobStorageWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
 				logrus.WithError(err).Fatal("failed to construct k8sgcsreporter controller")
 			}
 		}
@@ -308,7 +419,7 @@ func main() {
 			logrus.WithError(err).Fatal("Error connecting to resultstore")
 		}
 		uploader := resultstore.NewUploader(resultstore.NewClient(conn))
-		if err := crier.New(mgr, resultstorereporter.New(cfg, opener, uploader, o.resultstoreArtifactsDirOnly), o.resultStoreWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+		if err := crier.New(mgr, resultstorereporter.New(cfg, opener, uploader, o.resultstoreArtifactsDirOnly), o.resultStoreWorkers, o.githubEnablement.EnablementChecker(), o.retryOptions()); err != nil {
 			logrus.WithError(err).Fatal("failed to construct resultstorereporter controller")
 		}
 	}
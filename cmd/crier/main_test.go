@@ -20,6 +20,7 @@ import (
 	"flag"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -62,6 +63,10 @@ func TestOptions(t *testing.T) {
 				},
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
@@ -79,6 +84,10 @@ func TestOptions(t *testing.T) {
 				},
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
@@ -97,6 +106,10 @@ func TestOptions(t *testing.T) {
 				pubsubWorkers:          7,
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
@@ -120,6 +133,10 @@ func TestOptions(t *testing.T) {
 				},
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
@@ -143,9 +160,103 @@ func TestOptions(t *testing.T) {
 				dryrun:                 true,
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
+		//Retries
+		{
+			name: "retry flags are set",
+			args: []string{"--pubsub-workers=1", "--config-path=foo", "--report-retry-base-delay=10s", "--report-retry-max-delay=1h", "--report-retry-budget=0"},
+			expected: &options{
+				pubsubWorkers: 1,
+				config: configflagutil.ConfigOptions{
+					ConfigPathFlagName:                    "config-path",
+					JobConfigPathFlagName:                 "job-config-path",
+					ConfigPath:                            "foo",
+					SupplementalProwConfigsFileNameSuffix: "_prowconfig.yaml",
+					InRepoConfigCacheSize:                 200,
+				},
+				github:                 defaultGitHubOptions,
+				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   10 * time.Second,
+				reportRetryMaxDelay:    time.Hour,
+				emailDigestInterval:    24 * time.Hour,
+				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
+			},
+		},
+		{
+			name: "retry base delay exceeding max delay, rejects",
+			args: []string{"--pubsub-workers=1", "--config-path=foo", "--report-retry-base-delay=1h", "--report-retry-max-delay=1m"},
+		},
+		{
+			name: "negative retry budget, rejects",
+			args: []string{"--pubsub-workers=1", "--config-path=foo", "--report-retry-budget=-1"},
+		},
+		//Email Reporter
+		{
+			name: "email workers, sets workers",
+			args: []string{"--email-workers=2", "--smtp-server=smtp.example.com:587", "--smtp-username=prow", "--smtp-password-file=/etc/smtp/password", "--email-from=prow@example.com", "--email-digest-interval=1h", "--config-path=foo"},
+			expected: &options{
+				emailWorkers:     2,
+				smtpServer:       "smtp.example.com:587",
+				smtpUsername:     "prow",
+				smtpPasswordFile: "/etc/smtp/password",
+				emailFrom:        "prow@example.com",
+				config: configflagutil.ConfigOptions{
+					ConfigPathFlagName:                    "config-path",
+					JobConfigPathFlagName:                 "job-config-path",
+					ConfigPath:                            "foo",
+					SupplementalProwConfigsFileNameSuffix: "_prowconfig.yaml",
+					InRepoConfigCacheSize:                 200,
+				},
+				github:                 defaultGitHubOptions,
+				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    time.Hour,
+				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
+			},
+		},
+		{
+			name: "email missing --email-from, rejects",
+			args: []string{"--email-workers=1", "--smtp-server=smtp.example.com:25", "--config-path=foo"},
+		},
+		{
+			name: "email with username but without password, rejects",
+			args: []string{"--email-workers=1", "--smtp-server=smtp.example.com:25", "--email-from=prow@example.com", "--smtp-username=prow", "--config-path=foo"},
+		},
+		//GitLab Reporter
+		{
+			name: "gitlab workers, sets workers",
+			args: []string{"--gitlab-workers=3", "--gitlab-token-path=/etc/gitlab/token", "--config-path=foo"},
+			expected: &options{
+				gitlabWorkers:   3,
+				gitlabTokenPath: "/etc/gitlab/token",
+				config: configflagutil.ConfigOptions{
+					ConfigPathFlagName:                    "config-path",
+					JobConfigPathFlagName:                 "job-config-path",
+					ConfigPath:                            "foo",
+					SupplementalProwConfigsFileNameSuffix: "_prowconfig.yaml",
+					InRepoConfigCacheSize:                 200,
+				},
+				github:                 defaultGitHubOptions,
+				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
+				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
+			},
+		},
+		{
+			name: "gitlab missing --gitlab-token-path, rejects",
+			args: []string{"--gitlab-workers=1", "--config-path=foo"},
+		},
 		{
 			name: "k8s-gcs enables k8s-gcs",
 			args: []string{"--kubernetes-blob-storage-workers=3", "--config-path=foo"},
@@ -160,6 +271,10 @@ func TestOptions(t *testing.T) {
 				},
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
@@ -177,6 +292,10 @@ func TestOptions(t *testing.T) {
 				},
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      0.5,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
@@ -202,6 +321,10 @@ func TestOptions(t *testing.T) {
 				},
 				github:                 defaultGitHubOptions,
 				k8sReportFraction:      1.0,
+				reportRetryBaseDelay:   time.Second,
+				reportRetryMaxDelay:    10 * time.Minute,
+				reportRetryBudget:      0,
+				emailDigestInterval:    24 * time.Hour,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
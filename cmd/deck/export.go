@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/deck/jobs"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+// jobResult is the record exported for every ProwJob by /export.
+type jobResult struct {
+	Job     string `json:"job"`
+	BuildID string `json:"build_id"`
+	Type    string `json:"type"`
+	// Trigger is what created the job: tide, retest, schedule or webhook.
+	// It is empty if that is unknown, e.g. for jobs created with mkpj.
+	Trigger string `json:"trigger,omitempty"`
+	// EventGUID is the ID of the GitHub webhook delivery that created the job.
+	EventGUID string `json:"event_guid,omitempty"`
+	State     string `json:"state"`
+	Repo      string `json:"repo,omitempty"`
+	Pulls     string `json:"pulls,omitempty"`
+	Authors   string `json:"authors,omitempty"`
+	BaseRef   string `json:"base_ref,omitempty"`
+	BaseSHA   string `json:"base_sha,omitempty"`
+	Started   string `json:"started"`
+	Finished  string `json:"finished,omitempty"`
+	// Duration is the run time in seconds, empty for jobs that did not finish yet.
+	Duration string `json:"duration,omitempty"`
+	URL      string `json:"url"`
+
+	FailureReason string `json:"failure_reason,omitempty"`
+	// Tests and FailedTests are empty if the job reported no test results.
+	Tests       string `json:"tests,omitempty"`
+	FailedTests string `json:"failed_tests,omitempty"`
+}
+
+var jobResultColumns = []string{"job", "build_id", "type", "trigger", "event_guid", "state", "repo", "pulls", "authors", "base_ref", "base_sha", "started", "finished", "duration", "url", "failure_reason", "tests", "failed_tests"}
+
+func (r jobResult) record() []string {
+	return []string{r.Job, r.BuildID, r.Type, r.Trigger, r.EventGUID, r.State, r.Repo, r.Pulls, r.Authors, r.BaseRef, r.BaseSHA, r.Started, r.Finished, r.Duration, r.URL, r.FailureReason, r.Tests, r.FailedTests}
+}
+
+// exportFilter selects the ProwJobs to export.
+type exportFilter struct {
+	// repo is org/repo, jobs match it if their refs or any of their
+	// extra refs point to it.
+	repo string
+	// start and end bound the start time of the jobs, zero values
+	// do not bound the range.
+	start time.Time
+	end   time.Time
+}
+
+// prowJobRetention returns how long sinker keeps ProwJobs at most, zero if
+// that is unknown.
+func prowJobRetention(sinker config.Sinker) time.Duration {
+	var retention time.Duration
+	if sinker.MaxProwJobAge != nil {
+		retention = sinker.MaxProwJobAge.Duration
+	}
+	for _, o := range sinker.RetentionOverrides {
+		if o.MaxProwJobAge != nil && o.MaxProwJobAge.Duration > retention {
+			retention = o.MaxProwJobAge.Duration
+		}
+	}
+	return retention
+}
+
+func parseExportFilter(r *http.Request) (exportFilter, error) {
+	var filter exportFilter
+	query := r.URL.Query()
+	if repo := query.Get("repo"); repo != "" {
+		if parts := strings.Split(repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return filter, fmt.Errorf("repo must be of the form org/repo, got %q", repo)
+		}
+		filter.repo = repo
+	}
+	for param, t := range map[string]*time.Time{"start": &filter.start, "end": &filter.end} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC3339 timestamp: %w", param, err)
+		}
+		*t = parsed
+	}
+	if !filter.start.IsZero() && !filter.end.IsZero() && !filter.start.Before(filter.end) {
+		return filter, fmt.Errorf("start must be before end")
+	}
+	return filter, nil
+}
+
+func (f exportFilter) matches(pj prowapi.ProwJob) bool {
+	started := pj.Status.StartTime.Time
+	if !f.start.IsZero() && started.Before(f.start) {
+		return false
+	}
+	if !f.end.IsZero() && !started.Before(f.end) {
+		return false
+	}
+	if f.repo == "" {
+		return true
+	}
+	allRefs := pj.Spec.ExtraRefs
+	if pj.Spec.Refs != nil {
+		allRefs = append([]prowapi.Refs{*pj.Spec.Refs}, allRefs...)
+	}
+	for _, refs := range allRefs {
+		if refs.Org+"/"+refs.Repo == f.repo {
+			return true
+		}
+	}
+	return false
+}
+
+// jobTrigger returns what created the ProwJob.
+func jobTrigger(pj prowapi.ProwJob) string {
+	switch {
+	case pj.Labels[kube.CreatedByTideLabel] == "true":
+		return "tide"
+	case pj.Labels[kube.RetestLabel] == "true":
+		return "retest"
+	case pj.Spec.Type == prowapi.PeriodicJob:
+		return "schedule"
+	case pj.Labels[github.EventGUID] != "":
+		return "webhook"
+	}
+	return ""
+}
+
+// exportJobResults returns the results of the ProwJobs that match the
+// filter, the oldest first.
+func exportJobResults(pjs []prowapi.ProwJob, filter exportFilter) []jobResult {
+	var matching []prowapi.ProwJob
+	for _, pj := range pjs {
+		if filter.matches(pj) {
+			matching = append(matching, pj)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].Status.StartTime.Before(&matching[j].Status.StartTime)
+	})
+
+	results := []jobResult{}
+	for _, pj := range matching {
+		result := jobResult{
+			Job:     pj.Spec.Job,
+			BuildID: pj.Status.BuildID,
+			Type:    string(pj.Spec.Type),
+			Trigger: jobTrigger(pj),
+			State:   string(pj.Status.State),
+
+			EventGUID: pj.Labels[github.EventGUID],
+			Started:   pj.Status.StartTime.UTC().Format(time.RFC3339),
+			URL:       pj.Status.URL,
+
+			FailureReason: string(pj.Status.FailureReason),
+		}
+		if summary := pj.Status.TestSummary; summary != nil {
+			result.Tests = strconv.Itoa(summary.Total)
+			result.FailedTests = strconv.Itoa(summary.Failed)
+		}
+		refs := pj.Spec.Refs
+		if refs == nil && len(pj.Spec.ExtraRefs) > 0 {
+			refs = &pj.Spec.ExtraRefs[0]
+		}
+		if refs != nil {
+			result.Repo = refs.Org + "/" + refs.Repo
+			result.BaseRef = refs.BaseRef
+			result.BaseSHA = refs.BaseSHA
+			var pulls, authors []string
+			for _, pull := range refs.Pulls {
+				pulls = append(pulls, strconv.Itoa(pull.Number))
+				authors = append(authors, pull.Author)
+			}
+			result.Pulls = strings.Join(pulls, ",")
+			result.Authors = strings.Join(authors, ",")
+		}
+		if pj.Status.CompletionTime != nil {
+			result.Finished = pj.Status.CompletionTime.UTC().Format(time.RFC3339)
+			result.Duration = strconv.Itoa(int(pj.Status.CompletionTime.Sub(pj.Status.StartTime.Time).Seconds()))
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// handleExport handles requests to export the results of the ProwJobs
+// deck knows about, so they can be used as evidence of CI runs. Only the
+// ProwJobs that sinker did not garbage-collect yet are known, so requests
+// whose start is older than the ProwJob retention are rejected rather than
+// answered with incomplete results.
+// The url must look like this, where all query parameters are optional:
+//
+// /export?format=<csv|json>&repo=<org/repo>&start=<RFC3339>&end=<RFC3339>&tenant=<tenant>
+//
+// Examples:
+// - /export?format=csv&repo=kubernetes/test-infra
+// - /export?start=2026-01-01T00:00:00Z&end=2026-02-01T00:00:00Z
+func handleExport(ja *jobs.JobAgent, cfg config.Getter, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		filter, err := parseExportFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if retention := prowJobRetention(cfg().Sinker); retention > 0 && !filter.start.IsZero() && time.Since(filter.start) > retention {
+			http.Error(w, fmt.Sprintf("start must be within the last %s, older ProwJobs are garbage-collected by sinker", retention), http.StatusBadRequest)
+			return
+		}
+		pjs := ja.ProwJobs()
+		if tenant := r.URL.Query().Get("tenant"); tenant != "" {
+			pjs = filterProwJobsByTenant(pjs, tenant)
+		}
+		results := exportJobResults(pjs, filter)
+
+		switch format := r.URL.Query().Get("format"); format {
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="job-results.csv"`)
+			cw := csv.NewWriter(w)
+			cw.Write(jobResultColumns)
+			for _, result := range results {
+				cw.Write(result.record())
+			}
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				log.WithError(err).Error("Error writing job results.")
+			}
+		case "", "json":
+			jd, err := json.Marshal(results)
+			if err != nil {
+				log.WithError(err).Error("Error marshaling job results.")
+				http.Error(w, "failed to marshal job results", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(jd)
+		default:
+			http.Error(w, fmt.Sprintf("unsupported format %q, must be csv or json", format), http.StatusBadRequest)
+		}
+	}
+}
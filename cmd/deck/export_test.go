@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/deck/jobs"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func exportTestJobs() []prowapi.ProwJob {
+	day := func(d int) metav1.Time {
+		return metav1.NewTime(time.Date(2026, time.January, d, 12, 0, 0, 0, time.UTC))
+	}
+	finished := day(2)
+	finished.Time = finished.Add(90 * time.Second)
+	return []prowapi.ProwJob{
+		{
+			Spec: prowapi.ProwJobSpec{
+				Type: prowapi.PeriodicJob,
+				Job:  "periodic",
+				ExtraRefs: []prowapi.Refs{
+					{Org: "org", Repo: "other"},
+					{Org: "org", Repo: "repo"},
+				},
+			},
+			Status: prowapi.ProwJobStatus{StartTime: day(3), State: prowapi.PendingState, BuildID: "3", URL: "https://prow/view/3"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{github.EventGUID: "guid"}},
+			Spec: prowapi.ProwJobSpec{
+				Type: prowapi.PresubmitJob,
+				Job:  "presubmit",
+				Refs: &prowapi.Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseRef: "main",
+					BaseSHA: "abc",
+					Pulls:   []prowapi.Pull{{Number: 1, Author: "alice"}},
+				},
+			},
+			Status: prowapi.ProwJobStatus{StartTime: day(2), CompletionTime: &finished, State: prowapi.SuccessState, BuildID: "2", URL: "https://prow/view/2", TestSummary: &prowapi.TestSummary{Total: 3, Passed: 3}},
+		},
+		{
+			Spec: prowapi.ProwJobSpec{
+				Type: prowapi.PostsubmitJob,
+				Job:  "postsubmit",
+				Refs: &prowapi.Refs{Org: "org", Repo: "other", BaseRef: "main", BaseSHA: "def"},
+			},
+			Status: prowapi.ProwJobStatus{StartTime: day(1), State: prowapi.FailureState, BuildID: "1", URL: "https://prow/view/1", FailureReason: prowapi.CommandFailedReason},
+		},
+	}
+}
+
+func TestExportJobResults(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   exportFilter
+		expected []jobResult
+	}{
+		{
+			name: "no filter exports all jobs, oldest first",
+			expected: []jobResult{
+				{Job: "postsubmit", BuildID: "1", Type: "postsubmit", State: "failure", Repo: "org/other", BaseRef: "main", BaseSHA: "def", Started: "2026-01-01T12:00:00Z", URL: "https://prow/view/1", FailureReason: "command_failed"},
+				{Job: "presubmit", BuildID: "2", Type: "presubmit", Trigger: "webhook", EventGUID: "guid", State: "success", Repo: "org/repo", Pulls: "1", Authors: "alice", BaseRef: "main", BaseSHA: "abc", Started: "2026-01-02T12:00:00Z", Finished: "2026-01-02T12:01:30Z", Duration: "90", URL: "https://prow/view/2", Tests: "3", FailedTests: "0"},
+				{Job: "periodic", BuildID: "3", Type: "periodic", Trigger: "schedule", State: "pending", Repo: "org/other", Started: "2026-01-03T12:00:00Z", URL: "https://prow/view/3"},
+			},
+		},
+		{
+			name:   "repo matches refs and extra refs",
+			filter: exportFilter{repo: "org/repo"},
+			expected: []jobResult{
+				{Job: "presubmit", BuildID: "2", Type: "presubmit", Trigger: "webhook", EventGUID: "guid", State: "success", Repo: "org/repo", Pulls: "1", Authors: "alice", BaseRef: "main", BaseSHA: "abc", Started: "2026-01-02T12:00:00Z", Finished: "2026-01-02T12:01:30Z", Duration: "90", URL: "https://prow/view/2", Tests: "3", FailedTests: "0"},
+				{Job: "periodic", BuildID: "3", Type: "periodic", Trigger: "schedule", State: "pending", Repo: "org/other", Started: "2026-01-03T12:00:00Z", URL: "https://prow/view/3"},
+			},
+		},
+		{
+			name: "time range includes start and excludes end",
+			filter: exportFilter{
+				start: time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC),
+				end:   time.Date(2026, time.January, 2, 12, 0, 0, 0, time.UTC),
+			},
+			expected: []jobResult{
+				{Job: "postsubmit", BuildID: "1", Type: "postsubmit", State: "failure", Repo: "org/other", BaseRef: "main", BaseSHA: "def", Started: "2026-01-01T12:00:00Z", URL: "https://prow/view/1", FailureReason: "command_failed"},
+			},
+		},
+		{
+			name:     "nothing matches",
+			filter:   exportFilter{repo: "org/missing"},
+			expected: []jobResult{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := exportJobResults(exportTestJobs(), tc.filter)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected job results (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobTrigger(t *testing.T) {
+	testCases := []struct {
+		name     string
+		labels   map[string]string
+		jobType  prowapi.ProwJobType
+		expected string
+	}{
+		{
+			name:     "tide",
+			labels:   map[string]string{kube.CreatedByTideLabel: "true", github.EventGUID: "guid"},
+			jobType:  prowapi.BatchJob,
+			expected: "tide",
+		},
+		{
+			name:     "retest",
+			labels:   map[string]string{kube.RetestLabel: "true", github.EventGUID: "guid"},
+			jobType:  prowapi.PresubmitJob,
+			expected: "retest",
+		},
+		{
+			name:     "schedule",
+			jobType:  prowapi.PeriodicJob,
+			expected: "schedule",
+		},
+		{
+			name:     "webhook",
+			labels:   map[string]string{github.EventGUID: "guid"},
+			jobType:  prowapi.PostsubmitJob,
+			expected: "webhook",
+		},
+		{
+			name:    "unknown",
+			jobType: prowapi.PresubmitJob,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels}, Spec: prowapi.ProwJobSpec{Type: tc.jobType}}
+			if actual := jobTrigger(pj); actual != tc.expected {
+				t.Errorf("expected trigger %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHandleExport(t *testing.T) {
+	fakeJa := jobs.NewJobAgent(context.Background(), fkc(exportTestJobs()), false, true, []string{}, map[string]jobs.PodLogClient{}, fca{}.Config)
+	fakeJa.Start()
+	// Keep the test jobs, but not the ones from before December 2025.
+	retention := time.Since(time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC))
+	cfg := fca{c: config.Config{ProwConfig: config.ProwConfig{Sinker: config.Sinker{
+		MaxProwJobAge:      &metav1.Duration{Duration: time.Hour},
+		RetentionOverrides: []config.SinkerRetentionOverride{{MaxProwJobAge: &metav1.Duration{Duration: retention}}},
+	}}}}.Config
+	handler := handleExport(fakeJa, cfg, logrus.WithField("handler", "/export"))
+
+	testCases := []struct {
+		name         string
+		url          string
+		expectedCode int
+		expectedType string
+		expectedBody string
+	}{
+		{
+			name:         "csv",
+			url:          "/export?format=csv&repo=org/other&end=2026-01-02T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedType: "text/csv",
+			expectedBody: "job,build_id,type,trigger,event_guid,state,repo,pulls,authors,base_ref,base_sha,started,finished,duration,url,failure_reason,tests,failed_tests\n" +
+				"postsubmit,1,postsubmit,,,failure,org/other,,,main,def,2026-01-01T12:00:00Z,,,https://prow/view/1,command_failed,,\n",
+		},
+		{
+			name:         "json",
+			url:          "/export?repo=org/other&end=2026-01-02T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedType: "application/json",
+			expectedBody: `[{"job":"postsubmit","build_id":"1","type":"postsubmit","state":"failure","repo":"org/other","base_ref":"main","base_sha":"def","started":"2026-01-01T12:00:00Z","url":"https://prow/view/1","failure_reason":"command_failed"}]`,
+		},
+		{
+			name:         "bad repo",
+			url:          "/export?repo=org",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "bad start",
+			url:          "/export?start=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "start after end",
+			url:          "/export?start=2026-01-02T00:00:00Z&end=2026-01-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "start within the ProwJob retention",
+			url:          "/export?repo=org/other&start=2025-12-31T00:00:00Z&end=2026-01-02T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedType: "application/json",
+			expectedBody: `[{"job":"postsubmit","build_id":"1","type":"postsubmit","state":"failure","repo":"org/other","base_ref":"main","base_sha":"def","started":"2026-01-01T12:00:00Z","url":"https://prow/view/1","failure_reason":"command_failed"}]`,
+		},
+		{
+			name:         "start before the ProwJob retention",
+			url:          "/export?start=2025-11-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "bad format",
+			url:          "/export?format=xml",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				t.Fatalf("Error making request: %v", err)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("Bad error code: %d (expected %d)", rr.Code, tc.expectedCode)
+			}
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			if contentType := rr.Header().Get("Content-Type"); contentType != tc.expectedType {
+				t.Errorf("Bad content type: %s (expected %s)", contentType, tc.expectedType)
+			}
+			if diff := cmp.Diff(tc.expectedBody, rr.Body.String()); diff != "" {
+				t.Errorf("unexpected body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
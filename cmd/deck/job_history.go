@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"path"
 	"regexp"
@@ -50,6 +51,16 @@ const (
 	logsPrefix     = gcs.NonPRLogs
 	spyglassPrefix = "/view"
 	emptyID        = uint64(0) // indicates no build id was specified
+
+	resultParam = "result"
+	fromParam   = "from"
+	toParam     = "to"
+	shaParam    = "sha"
+	dateFormat  = "2006-01-02"
+
+	// maxScannedBuilds bounds how many builds a filtered page reads from
+	// storage while looking for matching builds.
+	maxScannedBuilds = 500
 )
 
 var (
@@ -102,6 +113,107 @@ type jobHistoryTemplate struct {
 	ResultsShown int
 	ResultsTotal int
 	Builds       []buildData
+
+	// Permalink links to this page of results, including its filters, even
+	// once newer builds have run.
+	Permalink string
+	Filter    jobHistoryFilter
+	// ResultsScanned is the number of builds that were read to find the
+	// builds shown when filtering.
+	ResultsScanned int
+}
+
+// jobHistoryFilter restricts the builds shown in the job history. The zero
+// value matches all builds.
+type jobHistoryFilter struct {
+	// Result is a comma-separated list of results, e.g. FAILURE,ERROR.
+	Result string
+	// From and To are the dates (YYYY-MM-DD, inclusive) between which the
+	// builds started.
+	From string
+	To   string
+	// SHA is a prefix of the base or pull SHA of the builds.
+	SHA string
+
+	results  []string
+	from, to time.Time
+}
+
+func parseJobHistoryFilter(query url.Values) (jobHistoryFilter, error) {
+	f := jobHistoryFilter{
+		Result: strings.TrimSpace(query.Get(resultParam)),
+		From:   strings.TrimSpace(query.Get(fromParam)),
+		To:     strings.TrimSpace(query.Get(toParam)),
+		SHA:    strings.ToLower(strings.TrimSpace(query.Get(shaParam))),
+	}
+	for _, result := range strings.Split(f.Result, ",") {
+		if result = strings.TrimSpace(result); result != "" {
+			f.results = append(f.results, strings.ToUpper(result))
+		}
+	}
+	var err error
+	if f.From != "" {
+		if f.from, err = time.Parse(dateFormat, f.From); err != nil {
+			return f, fmt.Errorf("invalid value for %s (expected YYYY-MM-DD): %w", fromParam, err)
+		}
+	}
+	if f.To != "" {
+		if f.to, err = time.Parse(dateFormat, f.To); err != nil {
+			return f, fmt.Errorf("invalid value for %s (expected YYYY-MM-DD): %w", toParam, err)
+		}
+		// The end date is inclusive.
+		f.to = f.to.AddDate(0, 0, 1)
+	}
+	if !f.from.IsZero() && !f.to.IsZero() && !f.from.Before(f.to) {
+		return f, fmt.Errorf("%s must not be after %s", fromParam, toParam)
+	}
+	return f, nil
+}
+
+// Active tells whether the filter restricts the builds at all.
+func (f jobHistoryFilter) Active() bool {
+	return len(f.results) > 0 || !f.from.IsZero() || !f.to.IsZero() || f.SHA != ""
+}
+
+func (f jobHistoryFilter) matches(b buildData) bool {
+	if len(f.results) > 0 {
+		var found bool
+		for _, result := range f.results {
+			if strings.EqualFold(b.Result, result) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if !f.from.IsZero() && b.Started.Before(f.from) {
+		return false
+	}
+	if !f.to.IsZero() && !b.Started.Before(f.to) {
+		return false
+	}
+	if f.SHA != "" {
+		shas := []string{b.commitHash}
+		if b.Refs != nil {
+			shas = append(shas, b.Refs.BaseSHA)
+			for _, pull := range b.Refs.Pulls {
+				shas = append(shas, pull.SHA)
+			}
+		}
+		var found bool
+		for _, sha := range shas {
+			if sha != "" && strings.HasPrefix(strings.ToLower(sha), f.SHA) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 func (bucket blobStorageBucket) readObject(ctx context.Context, key string) ([]byte, error) {
@@ -438,6 +550,11 @@ func getJobHistory(ctx context.Context, url *url.URL, cfg config.Getter, opener
 	if err != nil {
 		return tmpl, fmt.Errorf("invalid url %s: %w", url.String(), err)
 	}
+	filter, err := parseJobHistoryFilter(url.Query())
+	if err != nil {
+		return tmpl, httpError{error: fmt.Errorf("invalid filter: %w", err), statusCode: http.StatusBadRequest}
+	}
+	tmpl.Filter = filter
 
 	if bucketAlias, exists := cfg().Deck.Spyglass.BucketAliases[bucketName]; exists {
 		bucketName = bucketAlias
@@ -469,30 +586,86 @@ func getJobHistory(ctx context.Context, url *url.URL, cfg config.Getter, opener
 
 	sort.Sort(sort.Reverse(uint64slice(buildIDs)))
 
-	// determine which results to display on this page
-	shownIDs, firstIndex, lastIndex := cropResults(buildIDs, top)
-
-	// get links to the neighboring pages
-	if firstIndex > 0 {
-		nextIndex := firstIndex - resultsPerPage
-		// here emptyID indicates the most recent build, which will not necessarily be buildIDs[0]
-		next := emptyID
-		if nextIndex >= 0 {
-			next = buildIDs[nextIndex]
+	tmpl.ResultsTotal = len(buildIDs)
+	if filter.Active() {
+		// Newer pages of filtered builds aren't known without scanning
+		// them, so only the latest page is linked.
+		tmpl.Builds, tmpl.ResultsScanned, tmpl.OlderLink = filteredBuilds(ctx, bucket, root, url, buildIDs, top, filter)
+	} else {
+		// determine which results to display on this page
+		shownIDs, firstIndex, lastIndex := cropResults(buildIDs, top)
+
+		// get links to the neighboring pages
+		if firstIndex > 0 {
+			nextIndex := firstIndex - resultsPerPage
+			// here emptyID indicates the most recent build, which will not necessarily be buildIDs[0]
+			next := emptyID
+			if nextIndex >= 0 {
+				next = buildIDs[nextIndex]
+			}
+			tmpl.NewerLink = linkID(url, next)
 		}
-		tmpl.NewerLink = linkID(url, next)
+		if lastIndex < len(buildIDs)-1 {
+			tmpl.OlderLink = linkID(url, buildIDs[lastIndex+1])
+		}
+
+		tmpl.Builds = fetchBuilds(ctx, bucket, root, shownIDs)
 	}
-	if lastIndex < len(buildIDs)-1 {
-		tmpl.OlderLink = linkID(url, buildIDs[lastIndex+1])
+	tmpl.ResultsShown = len(tmpl.Builds)
+	if len(tmpl.Builds) > 0 {
+		if id, err := strconv.ParseUint(tmpl.Builds[0].ID, 10, 64); err == nil {
+			tmpl.Permalink = linkID(url, id)
+		}
 	}
 
-	tmpl.Builds = make([]buildData, len(shownIDs))
-	tmpl.ResultsShown = len(shownIDs)
-	tmpl.ResultsTotal = len(buildIDs)
+	elapsed := time.Since(start)
+	logrus.Infof("loaded %s in %v", url.Path, elapsed)
+	return tmpl, nil
+}
 
-	// concurrently fetch data for all of the builds to be shown
+// filteredBuilds pages through the builds with IDs up to top, newest first,
+// until it found a page of builds that match the filter. It returns these
+// builds, the number of builds it read and the link to the next page if the
+// history wasn't exhausted.
+func filteredBuilds(ctx context.Context, bucket blobStorageBucket, root string, url *url.URL, buildIDs []uint64, top uint64, filter jobHistoryFilter) ([]buildData, int, string) {
+	next := sort.Search(len(buildIDs), func(i int) bool { return buildIDs[i] <= top })
+	matched := []buildData{}
+	var scanned int
+	for next < len(buildIDs) && len(matched) < resultsPerPage && scanned < maxScannedBuilds {
+		batch := buildIDs[next:min(next+resultsPerPage, len(buildIDs))]
+		var tooOld bool
+		for _, b := range fetchBuilds(ctx, bucket, root, batch) {
+			next++
+			scanned++
+			if filter.matches(b) {
+				b.index = len(matched)
+				matched = append(matched, b)
+				if len(matched) == resultsPerPage {
+					break
+				}
+			}
+			// Build IDs increase over time, so all the remaining builds
+			// started before the range too.
+			if !filter.from.IsZero() && !b.Started.IsZero() && b.Started.Before(filter.from) {
+				tooOld = true
+				break
+			}
+		}
+		if tooOld {
+			return matched, scanned, ""
+		}
+	}
+	if next < len(buildIDs) {
+		return matched, scanned, linkID(url, buildIDs[next])
+	}
+	return matched, scanned, ""
+}
+
+// fetchBuilds concurrently reads the data of the builds, in the same order.
+func fetchBuilds(ctx context.Context, bucket blobStorageBucket, root string, buildIDs []uint64) []buildData {
+	builds := make([]buildData, len(buildIDs))
 	bch := make(chan buildData)
-	for i, buildID := range shownIDs {
+	for i, buildID := range buildIDs {
 		go func(i int, buildID uint64) {
 			id := strconv.FormatUint(buildID, 10)
 			dir, err := bucket.getPath(ctx, root, id, "")
@@ -500,7 +673,7 @@ func getJobHistory(ctx context.Context, url *url.URL, cfg config.Getter, opener
 				if !pkgio.IsNotExist(err) {
 					logrus.WithError(err).Error("Failed to get path")
 				}
-				bch <- buildData{}
+				bch <- buildData{index: i, ID: id}
 				return
 			}
 			b, err := getBuildData(ctx, bucket, dir)
@@ -520,12 +693,9 @@ func getJobHistory(ctx context.Context, url *url.URL, cfg config.Getter, opener
 			bch <- b
 		}(i, buildID)
 	}
-	for i := 0; i < len(shownIDs); i++ {
+	for range buildIDs {
 		b := <-bch
-		tmpl.Builds[b.index] = b
+		builds[b.index] = b
 	}
-
-	elapsed := time.Since(start)
-	logrus.Infof("loaded %s in %v", url.Path, elapsed)
-	return tmpl, nil
+	return builds
 }
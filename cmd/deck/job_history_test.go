@@ -26,6 +26,7 @@ import (
 
 	"github.com/fsouza/fake-gcs-server/fakestorage"
 
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/io/providers"
@@ -358,6 +359,24 @@ func Test_getJobHistory(t *testing.T) {
 		},
 	})
 
+	withPermalink := func(tmpl jobHistoryTemplate, permalink string) jobHistoryTemplate {
+		tmpl.Permalink = permalink
+		return tmpl
+	}
+	filtered := func(tmpl jobHistoryTemplate, filter jobHistoryFilter, scanned int, builds ...int) jobHistoryTemplate {
+		all := tmpl.Builds
+		tmpl.Builds = []buildData{}
+		for i, build := range builds {
+			b := all[build]
+			b.index = i
+			tmpl.Builds = append(tmpl.Builds, b)
+		}
+		tmpl.ResultsShown = len(builds)
+		tmpl.ResultsScanned = scanned
+		tmpl.Filter = filter
+		return tmpl
+	}
+
 	tests := []struct {
 		name    string
 		url     string
@@ -367,27 +386,50 @@ func Test_getJobHistory(t *testing.T) {
 		{
 			name: "get job history pr-logs (old format)",
 			url:  "https://prow.k8s.io/job-history/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel",
-			want: wantedPRLogsJobHistoryTemplate,
+			want: withPermalink(wantedPRLogsJobHistoryTemplate, "https://prow.k8s.io/job-history/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?buildId=1254406011708510210"),
 		},
 		{
 			name: "get job history pr-logs (new format)",
 			url:  "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel",
-			want: wantedPRLogsJobHistoryTemplate,
+			want: withPermalink(wantedPRLogsJobHistoryTemplate, "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?buildId=1254406011708510210"),
 		},
 		{
 			name: "get job history logs (old format)",
 			url:  "https://prow.k8s.io/job-history/kubernetes-jenkins/logs/post-cluster-api-provider-openstack-push-images",
-			want: wantedLogsJobHistoryTemplate,
+			want: withPermalink(wantedLogsJobHistoryTemplate, "https://prow.k8s.io/job-history/kubernetes-jenkins/logs/post-cluster-api-provider-openstack-push-images?buildId=1253687771944456193"),
 		},
 		{
 			name: "get job history logs (new format)",
 			url:  "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/logs/post-cluster-api-provider-openstack-push-images",
-			want: wantedLogsJobHistoryTemplate,
+			want: withPermalink(wantedLogsJobHistoryTemplate, "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/logs/post-cluster-api-provider-openstack-push-images?buildId=1253687771944456193"),
 		},
 		{
 			name: "get job history logs through a bucket alias (new format)",
 			url:  "https://prow.k8s.io/job-history/gs/kubernetes-jenkins-old/logs/post-cluster-api-provider-openstack-push-images",
-			want: wantedLogsJobHistoryTemplate,
+			want: withPermalink(wantedLogsJobHistoryTemplate, "https://prow.k8s.io/job-history/gs/kubernetes-jenkins-old/logs/post-cluster-api-provider-openstack-push-images?buildId=1253687771944456193"),
+		},
+		{
+			name: "filter job history by SHA",
+			url:  "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?sha=EC9156",
+			want: withPermalink(filtered(wantedPRLogsJobHistoryTemplate, jobHistoryFilter{SHA: "ec9156"}, 2, 1),
+				"https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?buildId=1221704015146913792&sha=EC9156"),
+		},
+		{
+			name: "filter job history by result",
+			url:  "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?result=failure",
+			want: filtered(wantedPRLogsJobHistoryTemplate, jobHistoryFilter{Result: "failure", results: []string{"FAILURE"}}, 2),
+		},
+		{
+			name: "filter job history by date stops at older builds",
+			url:  "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?from=2020-04-26&to=2020-04-26",
+			want: withPermalink(filtered(wantedPRLogsJobHistoryTemplate, jobHistoryFilter{From: "2020-04-26", To: "2020-04-26", from: time.Date(2020, 4, 26, 0, 0, 0, 0, time.UTC), to: time.Date(2020, 4, 27, 0, 0, 0, 0, time.UTC)}, 2, 0),
+				"https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?buildId=1254406011708510210&from=2020-04-26&to=2020-04-26"),
+		},
+		{
+			name:    "invalid filter",
+			url:     "https://prow.k8s.io/job-history/gs/kubernetes-jenkins/pr-logs/directory/pull-test-infra-bazel?from=yesterday",
+			want:    jobHistoryTemplate{},
+			wantErr: `invalid filter: invalid value for from (expected YYYY-MM-DD): parsing time "yesterday" as "2006-01-02": cannot parse "yesterday" as "2006"`,
 		},
 	}
 
@@ -410,6 +452,79 @@ func Test_getJobHistory(t *testing.T) {
 	}
 }
 
+func TestJobHistoryFilterMatches(t *testing.T) {
+	build := buildData{
+		Result:     "FAILURE",
+		Started:    time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC),
+		commitHash: "Unknown",
+		Refs: &prowv1.Refs{
+			BaseSHA: "0123456789abcdef",
+			Pulls:   []prowv1.Pull{{Number: 1, SHA: "fedcba9876543210"}},
+		},
+	}
+	testCases := []struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		{
+			name:     "no filter",
+			expected: true,
+		},
+		{
+			name:     "one of several results",
+			query:    "result=success,failure",
+			expected: true,
+		},
+		{
+			name:  "other result",
+			query: "result=SUCCESS",
+		},
+		{
+			name:     "within the dates",
+			query:    "from=2024-05-01&to=2024-05-01",
+			expected: true,
+		},
+		{
+			name:  "before the dates",
+			query: "from=2024-05-02",
+		},
+		{
+			name:  "after the dates",
+			query: "to=2024-04-30",
+		},
+		{
+			name:     "base SHA",
+			query:    "sha=0123",
+			expected: true,
+		},
+		{
+			name:     "pull SHA",
+			query:    "sha=FEDCBA",
+			expected: true,
+		},
+		{
+			name:  "other SHA",
+			query: "sha=abc",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tc.query)
+			if err != nil {
+				t.Fatalf("failed to parse query: %v", err)
+			}
+			filter, err := parseJobHistoryFilter(query)
+			if err != nil {
+				t.Fatalf("failed to parse filter: %v", err)
+			}
+			if actual := filter.matches(build); actual != tc.expected {
+				t.Errorf("expected match to be %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
 // TestListBuildIDsReturnsResultsOnError verifies that we get results even when there was an error,
 // mostly important so we can timeout it and still get some results.
 func TestListBuildIDsReturnsResultsOnError(t *testing.T) {
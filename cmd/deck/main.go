@@ -82,6 +82,7 @@ import (
 	"sigs.k8s.io/prow/pkg/spyglass"
 	spyglassapi "sigs.k8s.io/prow/pkg/spyglass/api"
 	"sigs.k8s.io/prow/pkg/spyglass/lenses/common"
+	"sigs.k8s.io/prow/pkg/version"
 
 	// Import standard spyglass viewers
 
@@ -93,6 +94,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/links"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/metadata"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/podinfo"
+	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/resourceusage"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/restcoverage"
 )
 
@@ -136,10 +138,15 @@ type options struct {
 	storage               prowflagutil.StorageClientOptions
 	gcsCookieAuth         bool
 	rerunCreatesJob       bool
+	rerunAllowsOverrides  bool
 	allowInsecure         bool
 	controllerManager     prowflagutil.ControllerManagerOptions
 	dryRun                bool
 	tenantIDs             prowflagutil.Strings
+	userSettingsPath      string
+	statusPage            bool
+	componentHealthURLs   prowflagutil.Strings
+	serviceWorker         bool
 }
 
 func (o *options) Validate() error {
@@ -158,6 +165,21 @@ func (o *options) Validate() error {
 		}
 	}
 
+	if o.rerunAllowsOverrides && !o.rerunCreatesJob {
+		return errors.New("--rerun-allows-overrides requires --rerun-creates-job")
+	}
+
+	if o.userSettingsPath != "" && o.oauthURL == "" {
+		return errors.New("--user-settings-path requires --oauth-url to identify users")
+	}
+
+	if _, err := parseComponentHealthURLs(o.componentHealthURLs.Strings()); err != nil {
+		return err
+	}
+	if len(o.componentHealthURLs.Strings()) > 0 && !o.statusPage {
+		return errors.New("--component-health-url requires --status-page")
+	}
+
 	if (o.hiddenOnly && o.showHidden) || (o.tenantIDs.Strings() != nil && (o.hiddenOnly || o.showHidden)) {
 		return errors.New("'--hidden-only', '--tenant-id', and '--show-hidden' are mutually exclusive, 'hidden-only' shows only hidden job, '--tenant-id' shows all jobs with matching ID and 'show-hidden' shows both hidden and non-hidden jobs")
 	}
@@ -183,9 +205,14 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.StringVar(&o.templateFilesLocation, "template-files-location", fmt.Sprintf("%s%s", os.Getenv("KO_DATA_PATH"), defaultTemplateFilesLocation), "Path to the template files")
 	fs.BoolVar(&o.gcsCookieAuth, "gcs-cookie-auth", false, "Use storage.cloud.google.com instead of signed URLs")
 	fs.BoolVar(&o.rerunCreatesJob, "rerun-creates-job", false, "Change the re-run option in Deck to actually create the job. **WARNING:** Only use this with non-public deck instances, otherwise strangers can DOS your Prow instance")
+	fs.BoolVar(&o.rerunAllowsOverrides, "rerun-allows-overrides", false, "Allow rerunning jobs in Deck with a different base SHA and different values of the environment variables of their containers. Requires --rerun-creates-job.")
 	fs.BoolVar(&o.allowInsecure, "allow-insecure", false, "Allows insecure requests for CSRF and GitHub oauth.")
 	fs.BoolVar(&o.dryRun, "dry-run", false, "Whether or not to make mutating API calls to GitHub.")
 	fs.Var(&o.tenantIDs, "tenant-id", "The tenantID(s) used by the ProwJobs that should be displayed by this instance of Deck. This flag can be repeated.")
+	fs.StringVar(&o.userSettingsPath, "user-settings-path", "", "Blob storage path (e.g. gs://bucket/deck/user-settings) under which per-user display settings are persisted. Requires --oauth-url. If empty, /user/settings is not served.")
+	fs.BoolVar(&o.statusPage, "status-page", false, "Serve the /status page with the health of the Prow components, the build clusters and the config. It exposes internal URLs and errors, so only enable it on non-public Deck instances or behind an authenticating proxy.")
+	fs.Var(&o.componentHealthURLs, "component-health-url", "NAME=URL of the health endpoint of a Prow component to show on the /status page, e.g. tide=http://tide:8081/healthz/ready. This flag can be repeated.")
+	fs.BoolVar(&o.serviceWorker, "service-worker", false, "Serve a service worker that caches static assets and pages in browsers, so that repeat visits load instantly and visited pages remain available offline.")
 	o.config.AddFlags(fs)
 	o.instrumentation.AddFlags(fs)
 	o.controllerManager.TimeoutListingProwJobsDefault = 30 * time.Second
@@ -223,6 +250,7 @@ var simplifier = simplifypath.NewSimplifier(l("", // shadow element mimicking th
 	l("badge.svg"),
 	l("command-help"),
 	l("config"),
+	l("asset-manifest.json"),
 	l("data.js"),
 	l("favicon.ico"),
 	l("github-login",
@@ -250,10 +278,16 @@ var simplifier = simplifypath.NewSimplifier(l("", // shadow element mimicking th
 		)),
 	l("static",
 		simplifypath.VGreedy("path")),
+	l("status"),
+	l("status.js"),
+	l("sw.js"),
 	l("tide"),
 	l("tide-history"),
 	l("tide-history.js"),
 	l("tide.js"),
+	l("user",
+		l("settings")),
+	l("version"),
 	l("view",
 		v("job"),
 		l("gs", v("bucket", l("logs", v("job", v("build"))))),
@@ -286,7 +320,13 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Error starting config agent.")
 	}
-	cfg := configAgent.Config
+	// Remote lenses can describe themselves, so Deck sees the configured
+	// lenses plus the discovered ones.
+	lensDiscoverer := spyglass.NewLensDiscoverer(configAgent.Config)
+	interrupts.Tick(lensDiscoverer.Sync, func() time.Duration {
+		return configAgent.Config().Deck.Spyglass.LensDiscovery.GetRefreshInterval()
+	})
+	cfg := lensDiscoverer.Config
 	disableClustersSet := sets.New[string](cfg().DisabledClusters...)
 	o.kubernetes.SetDisabledClusters(disableClustersSet)
 
@@ -312,6 +352,15 @@ func main() {
 	mux.Handle("/config", gziphandler.GzipHandler(handleConfig(cfg, logrus.WithField("handler", "/config"))))
 	mux.Handle("/plugin-config", gziphandler.GzipHandler(handlePluginConfig(pluginAgent, logrus.WithField("handler", "/plugin-config"))))
 	mux.Handle("/favicon.ico", gziphandler.GzipHandler(handleFavicon(o.staticFilesLocation, cfg)))
+	mux.Handle("/version", handleVersion(version.Version))
+	if o.serviceWorker {
+		manifest, err := buildAssetManifest(o.staticFilesLocation, version.Version)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error building asset manifest.")
+		}
+		mux.Handle("/asset-manifest.json", gziphandler.GzipHandler(handleAssetManifest(manifest, logrus.WithField("handler", "/asset-manifest.json"))))
+		mux.Handle("/sw.js", gziphandler.GzipHandler(handleServiceWorker(o.staticFilesLocation, version.Version, logrus.WithField("handler", "/sw.js"))))
+	}
 
 	// Set up handlers for template pages.
 	mux.Handle("/pr", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "pr.html", nil)))
@@ -432,11 +481,13 @@ func main() {
 			return
 		}
 		indexHandler := handleSimpleTemplate(o, cfg, "index.html", struct {
-			SpyglassEnabled bool
-			ReRunCreatesJob bool
+			SpyglassEnabled      bool
+			ReRunCreatesJob      bool
+			ReRunAllowsOverrides bool
 		}{
-			SpyglassEnabled: o.spyglass,
-			ReRunCreatesJob: o.rerunCreatesJob})
+			SpyglassEnabled:      o.spyglass,
+			ReRunCreatesJob:      o.rerunCreatesJob,
+			ReRunAllowsOverrides: o.rerunAllowsOverrides})
 		indexHandler(w, r)
 	})
 
@@ -448,6 +499,7 @@ func main() {
 	mux.Handle("/data.js", gziphandler.GzipHandler(handleData(ja, logrus.WithField("handler", "/data.js"))))
 	mux.Handle("/prowjobs.js", gziphandler.GzipHandler(handleProwJobs(ja, logrus.WithField("handler", "/prowjobs.js"))))
 	mux.Handle("/badge.svg", gziphandler.GzipHandler(handleBadge(ja)))
+	mux.Handle("/export", gziphandler.GzipHandler(handleExport(ja, cfg, logrus.WithField("handler", "/export"))))
 	mux.Handle("/log", gziphandler.GzipHandler(handleLog(ja, logrus.WithField("handler", "/log"))))
 
 	if o.spyglass {
@@ -457,7 +509,7 @@ func main() {
 	if runLocal {
 		mux = localOnlyMain(cfg, o, mux)
 	} else {
-		mux = prodOnlyMain(cfg, pluginAgent, authCfgGetter, githubClient, o, mux)
+		mux = prodOnlyMain(cfg, configAgent.LoadStatus, pluginAgent, authCfgGetter, githubClient, o, mux)
 	}
 
 	// cookie secret will be used for CSRF protection and should be exactly 32 bytes
@@ -552,7 +604,7 @@ func (fjc *fakePjListingClientWrapper) List(ctx context.Context, pjl *prowapi.Pr
 }
 
 // prodOnlyMain contains logic only used when running deployed, not locally
-func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGetter authCfgGetter, githubClient deckGitHubClient, o options, mux *http.ServeMux) *http.ServeMux {
+func prodOnlyMain(cfg config.Getter, configLoadStatus func() config.LoadStatus, pluginAgent *plugins.ConfigAgent, authCfgGetter authCfgGetter, githubClient deckGitHubClient, o options, mux *http.ServeMux) *http.ServeMux {
 	prowJobClient, err := o.kubernetes.ProwJobClient(cfg().ProwJobNamespace, false)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting ProwJob client for infrastructure cluster.")
@@ -589,6 +641,26 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 		}()
 	}
 
+	if o.statusPage {
+		components, err := parseComponentHealthURLs(o.componentHealthURLs.Strings())
+		if err != nil {
+			logrus.WithError(err).Fatal("Invalid --component-health-url.")
+		}
+		statusOpener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile, o.storage.AzureCredentialsFile)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener for build cluster statuses")
+		}
+		sa := &statusAgent{
+			components: components,
+			httpClient: &http.Client{},
+			opener:     statusOpener,
+			cfg:        cfg,
+			loadStatus: configLoadStatus,
+		}
+		mux.Handle("/status", gziphandler.GzipHandler(handleStatusPage(o, cfg, sa)))
+		mux.Handle("/status.js", gziphandler.GzipHandler(handleStatus(sa, logrus.WithField("handler", "/status.js"))))
+	}
+
 	secure := !o.allowInsecure
 
 	// Handles link to github
@@ -651,9 +723,18 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 		mux.Handle("/github-login", goa.HandleLogin(oauthClient, secure))
 		// Handles redirect from GitHub OAuth server.
 		mux.Handle("/github-login/redirect", goa.HandleRedirect(oauthClient, githuboauth.NewAuthenticatedUserIdentifier(&o.github), secure))
+
+		if o.userSettingsPath != "" {
+			opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile, o.storage.AzureCredentialsFile)
+			if err != nil {
+				logrus.WithError(err).Fatal("Error creating opener for user settings")
+			}
+			store := &userSettingsStore{opener: opener, basePath: o.userSettingsPath}
+			mux.Handle("/user/settings", handleNotCached(handleUserSettings(store, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), logrus.WithField("handler", "/user/settings"))))
+		}
 	}
 
-	mux.Handle("/rerun", gziphandler.GzipHandler(handleRerun(cfg, prowJobClient, o.rerunCreatesJob, authCfgGetter, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/rerun"))))
+	mux.Handle("/rerun", gziphandler.GzipHandler(handleRerun(cfg, prowJobClient, o.rerunCreatesJob, o.rerunAllowsOverrides, authCfgGetter, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/rerun"))))
 	mux.Handle("/abort", gziphandler.GzipHandler(handleAbort(prowJobClient, authCfgGetter, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/abort"))))
 
 	// optionally inject http->https redirect handler when behind loadbalancer
@@ -684,7 +765,7 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 
 func initSpyglass(cfg config.Getter, o options, mux *http.ServeMux, ja *jobs.JobAgent, gitHubClient deckGitHubClient, gitClient git.ClientFactory) {
 	ctx := context.TODO()
-	opener, err := io.NewOpener(ctx, o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+	opener, err := io.NewOpener(ctx, o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile, o.storage.AzureCredentialsFile)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error creating opener")
 	}
@@ -693,6 +774,7 @@ func initSpyglass(cfg config.Getter, o options, mux *http.ServeMux, ja *jobs.Job
 
 	mux.Handle("/spyglass/static/", http.StripPrefix("/spyglass/static", staticHandlerFromDir(o.spyglassFilesLocation)))
 	mux.Handle("/spyglass/lens/", gziphandler.GzipHandler(http.StripPrefix("/spyglass/lens/", handleArtifactView(o, sg, cfg))))
+	mux.Handle("/view/compare", gziphandler.GzipHandler(handleCompareView(sg, cfg, o, logrus.WithField("handler", "/view/compare"))))
 	mux.Handle("/view/", gziphandler.GzipHandler(handleRequestJobViews(sg, cfg, o, logrus.WithField("handler", "/view"))))
 	mux.Handle("/job-history/", gziphandler.GzipHandler(handleJobHistory(o, cfg, opener, logrus.WithField("handler", "/job-history"))))
 	mux.Handle("/pr-history/", gziphandler.GzipHandler(handlePRHistory(o, cfg, opener, gitHubClient, gitClient, logrus.WithField("handler", "/pr-history"))))
@@ -780,6 +862,9 @@ func handleProwJobs(ja *jobs.JobAgent, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		setHeadersNoCaching(w)
 		jobs := ja.ProwJobs()
+		if tenant := r.URL.Query().Get("tenant"); tenant != "" {
+			jobs = filterProwJobsByTenant(jobs, tenant)
+		}
 		omit := r.URL.Query().Get("omit")
 
 		if set := sets.New[string](strings.Split(omit, ",")...); set.Len() > 0 {
@@ -823,6 +908,22 @@ func handleProwJobs(ja *jobs.JobAgent, log *logrus.Entry) http.HandlerFunc {
 	}
 }
 
+// filterProwJobsByTenant returns the ProwJobs of the tenant. ProwJobs without
+// a tenant ID belong to the default tenant.
+func filterProwJobsByTenant(pjs []prowapi.ProwJob, tenant string) []prowapi.ProwJob {
+	filtered := []prowapi.ProwJob{}
+	for _, pj := range pjs {
+		id := config.DefaultTenantID
+		if pj.Spec.ProwJobDefault != nil && pj.Spec.ProwJobDefault.TenantID != "" {
+			id = pj.Spec.ProwJobDefault.TenantID
+		}
+		if id == tenant {
+			filtered = append(filtered, pj)
+		}
+	}
+	return filtered
+}
+
 func handleData(ja *jobs.JobAgent, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		setHeadersNoCaching(w)
@@ -943,6 +1044,11 @@ func handleRequestJobViews(sg *spyglass.Spyglass, cfg config.Getter, o options,
 
 		csrfToken := csrf.Token(r)
 		page, err := renderSpyglass(r.Context(), sg, cfg, src, o, csrfToken, log)
+		var expired *spyglass.ArtifactsExpiredError
+		if errors.As(err, &expired) {
+			renderArtifactsExpired(w, sg, cfg, src, expired, o, csrfToken, log)
+			return
+		}
 		if err != nil {
 			msg := fmt.Sprintf("error rendering spyglass page: %v", err)
 			if shouldLogHTTPErrors(err) {
@@ -962,6 +1068,96 @@ func handleRequestJobViews(sg *spyglass.Spyglass, cfg config.Getter, o options,
 	}
 }
 
+// handleCompareView renders the differences between the artifacts of two runs.
+// The runs are passed in the same format as for /view/.
+//
+// /view/compare?a=<key-type>/<key>&b=<key-type>/<key>
+func handleCompareView(sg *spyglass.Spyglass, cfg config.Getter, o options, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		srcA, srcB := r.URL.Query().Get("a"), r.URL.Query().Get("b")
+		if srcA == "" || srcB == "" {
+			http.Error(w, "the runs to compare must be passed in the 'a' and 'b' query parameters", http.StatusBadRequest)
+			return
+		}
+
+		comparisons, err := sg.Compare(r.Context(), srcA, srcB, o.spyglassFilesLocation)
+		if err != nil {
+			msg := fmt.Sprintf("error comparing runs: %v", err)
+			if shouldLogHTTPErrors(err) {
+				log.WithError(err).Debug(msg)
+			}
+			http.Error(w, msg, httpStatusForError(err))
+			return
+		}
+
+		t := template.New("compare.html")
+		if _, err := prepareBaseTemplate(o, cfg, csrf.Token(r), t); err != nil {
+			log.WithError(err).Error("error preparing base template")
+			http.Error(w, "error preparing base template", http.StatusInternalServerError)
+			return
+		}
+		t, err = t.ParseFiles(path.Join(o.templateFilesLocation, "compare.html"))
+		if err != nil {
+			log.WithError(err).Error("error parsing template")
+			http.Error(w, "error parsing template", http.StatusInternalServerError)
+			return
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, struct {
+			SourceA     string
+			SourceB     string
+			Comparisons []spyglass.Comparison
+		}{
+			SourceA:     strings.TrimSuffix(srcA, "/"),
+			SourceB:     strings.TrimSuffix(srcB, "/"),
+			Comparisons: comparisons,
+		}); err != nil {
+			log.WithError(err).Error("error rendering template")
+			http.Error(w, "error rendering template", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, buf.String())
+	}
+}
+
+// renderArtifactsExpired renders the page shown instead of the Spyglass page
+// of runs whose artifacts were deleted by the retention policy of their bucket.
+func renderArtifactsExpired(w http.ResponseWriter, sg *spyglass.Spyglass, cfg config.Getter, src string, expired *spyglass.ArtifactsExpiredError, o options, csrfToken string, log *logrus.Entry) {
+	t := template.New("artifacts-expired.html")
+	if _, err := prepareBaseTemplate(o, cfg, csrfToken, t); err != nil {
+		log.WithError(err).Error("error preparing base template")
+		http.Error(w, "error preparing base template", http.StatusInternalServerError)
+		return
+	}
+	t, err := t.ParseFiles(path.Join(o.templateFilesLocation, "artifacts-expired.html"))
+	if err != nil {
+		log.WithError(err).Error("error parsing template")
+		http.Error(w, "error parsing template", http.StatusInternalServerError)
+		return
+	}
+	var jobHistLink string
+	if jobPath, err := sg.JobPath(strings.TrimSuffix(src, "/")); err == nil {
+		jobHistLink = path.Join("/job-history", jobPath)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct {
+		Bucket      string
+		Policy      string
+		JobHistLink string
+	}{
+		Bucket:      expired.Bucket,
+		Policy:      expired.Policy,
+		JobHistLink: jobHistLink,
+	}); err != nil {
+		log.WithError(err).Error("error rendering template")
+		http.Error(w, "error rendering template", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusGone)
+	fmt.Fprint(w, buf.String())
+}
+
 // renderSpyglass returns a pre-rendered Spyglass page from the given source string
 func renderSpyglass(ctx context.Context, sg *spyglass.Spyglass, cfg config.Getter, src string, o options, csrfToken string, log *logrus.Entry) (string, error) {
 	renderStart := time.Now()
@@ -976,38 +1172,32 @@ func renderSpyglass(ctx context.Context, sg *spyglass.Spyglass, cfg config.Gette
 	if err != nil {
 		return "", fmt.Errorf("error listing artifacts: %w", err)
 	}
+	if len(artifactNames) == 0 {
+		// The artifacts of the run may have been archived or deleted.
+		archived, err := sg.ResolveArchived(ctx, src)
+		if err != nil {
+			return "", err
+		}
+		if archived != src {
+			src = archived
+			if artifactNames, err = sg.ListArtifacts(ctx, src); err != nil {
+				return "", fmt.Errorf("error listing artifacts: %w", err)
+			}
+		}
+	}
 	if len(artifactNames) == 0 {
 		log.Infof("found no artifacts for %s", src)
 	}
 
-	regexCache := cfg().Deck.Spyglass.RegexCache
+	spyglassConfig := cfg().Deck.Spyglass
 	lensCache := map[int][]string{}
 	var lensIndexes []int
-lensesLoop:
-	for i, lfc := range cfg().Deck.Spyglass.Lenses {
-		matches := sets.Set[string]{}
-		for _, re := range lfc.RequiredFiles {
-			found := false
-			for _, a := range artifactNames {
-				if regexCache[re].MatchString(a) {
-					matches.Insert(a)
-					found = true
-				}
-			}
-			if !found {
-				continue lensesLoop
-			}
-		}
-
-		for _, re := range lfc.OptionalFiles {
-			for _, a := range artifactNames {
-				if regexCache[re].MatchString(a) {
-					matches.Insert(a)
-				}
-			}
+	for i, lfc := range spyglassConfig.Lenses {
+		matches, ok := spyglass.LensArtifacts(lfc, spyglassConfig.RegexCache, artifactNames)
+		if !ok {
+			continue
 		}
-
-		lensCache[i] = sets.List(matches)
+		lensCache[i] = matches
 		lensIndexes = append(lensIndexes, i)
 	}
 
@@ -1019,6 +1209,11 @@ lensesLoop:
 		jobHistLink = path.Join("/job-history", jobPath)
 	}
 
+	failureClassification, err := sg.FailureClassification(src)
+	if err != nil {
+		log.WithError(err).Warningf("Error getting failure classification for source %q.", src)
+	}
+
 	var prowJobLink string
 	prowJob, prowJobName, prowJobState, err := sg.ProwJob(src)
 	if err == nil {
@@ -1051,7 +1246,7 @@ lensesLoop:
 
 	artifactsLink := ""
 	bucket := ""
-	if jobPath != "" && (strings.HasPrefix(jobPath, providers.GS) || strings.HasPrefix(jobPath, providers.S3)) {
+	if jobPath != "" && (strings.HasPrefix(jobPath, providers.GS) || strings.HasPrefix(jobPath, providers.S3) || strings.HasPrefix(jobPath, providers.ABS)) {
 		bucket = strings.Split(jobPath, "/")[1] // The provider (gs) will be in index 0, followed by the bucket name
 	}
 	gcswebPrefix := cfg().Deck.Spyglass.GetGCSBrowserPrefix(org, repo, bucket)
@@ -1132,6 +1327,9 @@ lensesLoop:
 		ProwJob         string
 		ProwJobName     string
 		ProwJobState    string
+
+		ReRunAllowsOverrides  bool
+		FailureClassification *pjutil.Classification
 	}
 	sTmpl := spyglassTemplate{
 		Lenses:          ls,
@@ -1152,6 +1350,9 @@ lensesLoop:
 		ProwJob:         prowJob,
 		ProwJobName:     prowJobName,
 		ProwJobState:    string(prowJobState),
+
+		ReRunAllowsOverrides:  o.rerunAllowsOverrides,
+		FailureClassification: failureClassification,
 	}
 	t := template.New("spyglass.html")
 
@@ -136,6 +136,17 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "user settings path requires oauth",
+			input: options{
+				config: configflagutil.ConfigOptions{ConfigPath: "test"},
+				controllerManager: flagutil.ControllerManagerOptions{
+					TimeoutListingProwJobsDefault: 30 * time.Second,
+				},
+				userSettingsPath: "gs://bucket/settings",
+			},
+			expectedErr: true,
+		},
 		{
 			name: "hidden only and show hidden are mutually exclusive",
 			input: options{
@@ -375,6 +386,31 @@ func TestHandleProwJobs(t *testing.T) {
 	}
 }
 
+func TestFilterProwJobsByTenant(t *testing.T) {
+	pjs := []prowapi.ProwJob{
+		{Spec: prowapi.ProwJobSpec{Job: "no-default"}},
+		{Spec: prowapi.ProwJobSpec{Job: "default", ProwJobDefault: &prowapi.ProwJobDefault{TenantID: config.DefaultTenantID}}},
+		{Spec: prowapi.ProwJobSpec{Job: "tenant", ProwJobDefault: &prowapi.ProwJobDefault{TenantID: "tenant"}}},
+	}
+	testCases := []struct {
+		tenant   string
+		expected []string
+	}{
+		{tenant: config.DefaultTenantID, expected: []string{"no-default", "default"}},
+		{tenant: "tenant", expected: []string{"tenant"}},
+		{tenant: "other", expected: nil},
+	}
+	for _, tc := range testCases {
+		var actual []string
+		for _, pj := range filterProwJobsByTenant(pjs, tc.tenant) {
+			actual = append(actual, pj.Spec.Job)
+		}
+		if diff := cmp.Diff(tc.expected, actual); diff != "" {
+			t.Errorf("Unexpected jobs of tenant %q (-want +got):\n%s", tc.tenant, diff)
+		}
+	}
+}
+
 // TestProwJob just checks that the result can be unmarshaled properly, has
 // the same status, and has equal spec.
 func TestProwJob(t *testing.T) {
@@ -1023,6 +1059,11 @@ func TestSpyglassConfigDefaulting(t *testing.T) {
 			in:     cfgWithLensNamed("podinfo"),
 			verify: verifyCfgHasRemoteForLens("podinfo"),
 		},
+		{
+			name:   "resourceusage lens gets defaulted",
+			in:     cfgWithLensNamed("resourceusage"),
+			verify: verifyCfgHasRemoteForLens("resourceusage"),
+		},
 		{
 			name:   "restcoverage lens gets defaulted",
 			in:     cfgWithLensNamed("restcoverage"),
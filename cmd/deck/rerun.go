@@ -21,8 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/sirupsen/logrus"
+	coreapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -37,6 +40,7 @@ import (
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/plugins"
 	"sigs.k8s.io/prow/pkg/plugins/trigger"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -239,10 +243,76 @@ const (
 	LATEST = "latest"
 )
 
+// editedProwJobFormKey is the form field of a rerun request that holds the
+// ProwJob to rerun with overrides, as edited by the user.
+const editedProwJobFormKey = "edited_prowjob"
+
+// mutableRerunFields are the fields of the spec that may be changed when
+// rerunning a job with overrides. Environment variables can only be given a
+// different value, adding them or sourcing them from secrets or other
+// references would let anyone who can rerun a job read those.
+var mutableRerunFields = []string{"refs.base_sha", "extra_refs[].base_sha", "pod_spec.containers[].env[].value"}
+
+// overrideRerunSpec replaces the spec with the one of the edited ProwJob if
+// they only differ in mutable fields.
+func overrideRerunSpec(spec *prowapi.ProwJobSpec, editedProwJob []byte) error {
+	var edited prowapi.ProwJob
+	if err := yaml.Unmarshal(editedProwJob, &edited); err != nil {
+		return fmt.Errorf("invalid ProwJob: %w", err)
+	}
+
+	// Any difference left after restoring the mutable fields is to an
+	// immutable one.
+	restored := edited.Spec.DeepCopy()
+	if restored.Refs != nil && spec.Refs != nil {
+		restored.Refs.BaseSHA = spec.Refs.BaseSHA
+	}
+	if len(restored.ExtraRefs) == len(spec.ExtraRefs) {
+		for i := range restored.ExtraRefs {
+			restored.ExtraRefs[i].BaseSHA = spec.ExtraRefs[i].BaseSHA
+		}
+	}
+	if restored.PodSpec != nil && spec.PodSpec != nil && len(restored.PodSpec.Containers) == len(spec.PodSpec.Containers) {
+		for i := range restored.PodSpec.Containers {
+			restoreEnvValues(restored.PodSpec.Containers[i].Env, spec.PodSpec.Containers[i].Env)
+		}
+	}
+	if !equality.Semantic.DeepEqual(restored, spec) {
+		return fmt.Errorf("only %s can be changed", strings.Join(mutableRerunFields, ", "))
+	}
+	// The SHAs are passed to git by clonerefs, so they must not be able to
+	// look like options.
+	if edited.Spec.Refs != nil && edited.Spec.Refs.BaseSHA != spec.Refs.BaseSHA && !config.IsCommitSHA(edited.Spec.Refs.BaseSHA) {
+		return fmt.Errorf("refs.base_sha %q is not a full commit SHA", edited.Spec.Refs.BaseSHA)
+	}
+	for i, refs := range edited.Spec.ExtraRefs {
+		if refs.BaseSHA != spec.ExtraRefs[i].BaseSHA && !config.IsCommitSHA(refs.BaseSHA) {
+			return fmt.Errorf("extra_refs[%d].base_sha %q is not a full commit SHA", i, refs.BaseSHA)
+		}
+	}
+
+	*spec = edited.Spec
+	return nil
+}
+
+// restoreEnvValues restores the original values of plain environment
+// variables that are still at the same position under the same name.
+func restoreEnvValues(edited, original []coreapi.EnvVar) {
+	if len(edited) != len(original) {
+		return
+	}
+	for i := range edited {
+		if edited[i].Name != original[i].Name || edited[i].ValueFrom != nil || original[i].ValueFrom != nil {
+			continue
+		}
+		edited[i].Value = original[i].Value
+	}
+}
+
 // handleRerun triggers a rerun of the given job if that features is enabled, it receives a
 // POST request, and the user has the necessary permissions. Otherwise, it writes the config
 // for a new job but does not trigger it.
-func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, createProwJob bool, acfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
+func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, createProwJob, allowOverrides bool, acfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		name := r.URL.Query().Get("prowjob")
 		mode := r.URL.Query().Get("mode")
@@ -303,6 +373,20 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 				http.Error(w, "Direct rerun feature is not enabled. Enable with the '--rerun-creates-job' flag.", http.StatusMethodNotAllowed)
 				return
 			}
+			overridden := false
+			if editedProwJob := r.PostFormValue(editedProwJobFormKey); editedProwJob != "" {
+				if !allowOverrides {
+					http.Error(w, "Rerunning with overrides is not enabled. Enable with the '--rerun-allows-overrides' flag.", http.StatusMethodNotAllowed)
+					return
+				}
+				if err := overrideRerunSpec(&newPJ.Spec, []byte(editedProwJob)); err != nil {
+					http.Error(w, fmt.Sprintf("Could not rerun with overrides: %v.", err), http.StatusBadRequest)
+					l.WithError(err).Debug("Could not rerun with overrides.")
+					return
+				}
+				overridden = true
+				l = l.WithField("overridden", true)
+			}
 			allowed, user, err, code := isAllowedToRerun(r, acfg, goa, ghc, newPJ, cli, pluginAgent, l)
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Could not verify if allowed to rerun: %v.", err), code)
@@ -318,10 +402,14 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 			}
 			var rerunDescription string
 			if len(user) > 0 {
-				rerunDescription = fmt.Sprintf("%v successfully reran %v.", user, name)
+				rerunDescription = fmt.Sprintf("%v successfully reran %v", user, name)
 			} else {
-				rerunDescription = fmt.Sprintf("Successfully reran %v.", name)
+				rerunDescription = fmt.Sprintf("Successfully reran %v", name)
+			}
+			if overridden {
+				rerunDescription += " with overrides"
 			}
+			rerunDescription += "."
 			newPJ.Status.Description = rerunDescription
 			created, err := prowJobClient.Create(context.TODO(), &newPJ, metav1.CreateOptions{})
 			if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/gorilla/sessions"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+	coreapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
@@ -273,7 +274,7 @@ func TestRerun(t *testing.T) {
 			cfg := func() *config.Config {
 				return &config.Config{ProwConfig: config.ProwConfig{Scheduler: config.Scheduler{Enabled: tc.enableScheduling}}}
 			}
-			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, authCfgGetter, goa, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
+			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, false, authCfgGetter, goa, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
 			handler.ServeHTTP(rr, req)
 			if rr.Code != tc.httpCode {
 				t.Fatalf("Bad error code: %d", rr.Code)
@@ -563,7 +564,7 @@ func TestLatestRerun(t *testing.T) {
 				cfg.Scheduler.Enabled = tc.enableScheduling
 				return cfg
 			}
-			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, authCfgGetter, goa, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
+			handler := handleRerun(cfg, fakeProwJobClient.ProwV1().ProwJobs("prowjobs"), tc.rerunCreatesJob, false, authCfgGetter, goa, ghc, rc, &pca, logrus.WithField("handler", "/rerun"))
 			handler.ServeHTTP(rr, req)
 			if rr.Code != tc.httpCode {
 				t.Fatalf("Bad error code: %d", rr.Code)
@@ -709,3 +710,146 @@ func TestCanTriggerJob(t *testing.T) {
 		}
 	}
 }
+
+func TestOverrideRerunSpec(t *testing.T) {
+	original := prowapi.ProwJobSpec{
+		Type: prowapi.PostsubmitJob,
+		Job:  "whoa",
+		Refs: &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abc"},
+		ExtraRefs: []prowapi.Refs{
+			{Org: "org", Repo: "other", BaseRef: "main", BaseSHA: "def"},
+		},
+		PodSpec: &coreapi.PodSpec{
+			Containers: []coreapi.Container{{
+				Image: "golang",
+				Env: []coreapi.EnvVar{
+					{Name: "FOO", Value: "foo"},
+					{Name: "TOKEN", ValueFrom: &coreapi.EnvVarSource{SecretKeyRef: &coreapi.SecretKeySelector{Key: "token"}}},
+				},
+			}},
+		},
+	}
+	testCases := []struct {
+		name      string
+		edit      func(*prowapi.ProwJobSpec)
+		expectErr bool
+	}{
+		{
+			name: "no changes",
+			edit: func(*prowapi.ProwJobSpec) {},
+		},
+		{
+			name: "base SHAs and environment variable values can be changed",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.Refs.BaseSHA = "0123456789abcdef0123456789abcdef01234567"
+				spec.ExtraRefs[0].BaseSHA = "89abcdef0123456789abcdef0123456789abcdef"
+				spec.PodSpec.Containers[0].Env[0].Value = "bar"
+			},
+		},
+		{
+			name: "base SHA cannot be an option",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.Refs.BaseSHA = "--upload-pack=touch /tmp/pwned"
+			},
+			expectErr: true,
+		},
+		{
+			name: "extra refs base SHA cannot be an option",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.ExtraRefs[0].BaseSHA = "--upload-pack=touch /tmp/pwned"
+			},
+			expectErr: true,
+		},
+		{
+			name: "base SHA must be a full commit SHA",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.Refs.BaseSHA = "main"
+			},
+			expectErr: true,
+		},
+		{
+			name: "environment variables cannot be added",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.PodSpec.Containers[0].Env = append(spec.PodSpec.Containers[0].Env, coreapi.EnvVar{Name: "BAR", Value: "bar"})
+			},
+			expectErr: true,
+		},
+		{
+			name: "environment variables cannot be sourced from secrets",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.PodSpec.Containers[0].Env[0] = coreapi.EnvVar{Name: "FOO", ValueFrom: &coreapi.EnvVarSource{SecretKeyRef: &coreapi.SecretKeySelector{Key: "other"}}}
+			},
+			expectErr: true,
+		},
+		{
+			name: "environment variables sourced from secrets cannot be changed",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.PodSpec.Containers[0].Env[1].ValueFrom.SecretKeyRef.Key = "other"
+			},
+			expectErr: true,
+		},
+		{
+			name: "environment variables cannot be renamed",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.PodSpec.Containers[0].Env[0].Name = "LD_PRELOAD"
+			},
+			expectErr: true,
+		},
+		{
+			name: "image cannot be changed",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.PodSpec.Containers[0].Image = "evil"
+			},
+			expectErr: true,
+		},
+		{
+			name: "base ref cannot be changed",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.Refs.BaseRef = "release"
+			},
+			expectErr: true,
+		},
+		{
+			name: "extra refs cannot be added",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.ExtraRefs = append(spec.ExtraRefs, prowapi.Refs{Org: "org", Repo: "third", BaseSHA: "789"})
+			},
+			expectErr: true,
+		},
+		{
+			name: "containers cannot be removed",
+			edit: func(spec *prowapi.ProwJobSpec) {
+				spec.PodSpec.Containers = nil
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			edited := prowapi.ProwJob{Spec: *original.DeepCopy()}
+			tc.edit(&edited.Spec)
+			editedYAML, err := yaml.Marshal(edited)
+			if err != nil {
+				t.Fatalf("failed to marshal ProwJob: %v", err)
+			}
+
+			spec := original.DeepCopy()
+			err = overrideRerunSpec(spec, editedYAML)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				if diff := cmp.Diff(&original, spec); diff != "" {
+					t.Errorf("expected the spec not to change (-want +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(&edited.Spec, spec); diff != "" {
+				t.Errorf("unexpected spec (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// serviceWorkerBundle is the rolled up service worker in the static files.
+const serviceWorkerBundle = "service_worker_bundle.min.js"
+
+// cachedAssetExtensions are the extensions of the static files that the
+// service worker caches when it is installed.
+var cachedAssetExtensions = map[string]bool{
+	".css": true,
+	".ico": true,
+	".js":  true,
+	".png": true,
+	".svg": true,
+}
+
+// assetManifest lists the static assets of a version of Deck, which the
+// service worker fetches ahead of time and keeps until Deck is updated.
+type assetManifest struct {
+	Version string   `json:"version"`
+	Assets  []string `json:"assets"`
+}
+
+// buildAssetManifest lists the static assets in the static files directory
+// with the cache busting parameter of the given version.
+func buildAssetManifest(staticFilesLocation, version string) (*assetManifest, error) {
+	manifest := &assetManifest{Version: version, Assets: []string{}}
+	err := filepath.WalkDir(staticFilesLocation, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !cachedAssetExtensions[filepath.Ext(p)] || d.Name() == serviceWorkerBundle {
+			return nil
+		}
+		rel, err := filepath.Rel(staticFilesLocation, p)
+		if err != nil {
+			return err
+		}
+		manifest.Assets = append(manifest.Assets, fmt.Sprintf("/static/%s?v=%s", filepath.ToSlash(rel), version))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list static assets in %s: %w", staticFilesLocation, err)
+	}
+	return manifest, nil
+}
+
+func handleAssetManifest(manifest *assetManifest, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		b, err := json.Marshal(manifest)
+		if err != nil {
+			log.WithError(err).Error("Error marshaling asset manifest.")
+			http.Error(w, "Failed to marshal asset manifest.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(b))
+	}
+}
+
+// handleVersion serves the version of Deck. The service worker compares it to
+// its own version to find out that Deck was updated.
+func handleVersion(version string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		b, _ := json.Marshal(map[string]string{"version": version})
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(b))
+	}
+}
+
+// handleServiceWorker serves the service worker from the root of Deck, so that
+// it controls all of its pages. The version of Deck is prepended to the
+// worker, which makes browsers install a new worker once Deck is updated.
+func handleServiceWorker(staticFilesLocation, version string, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		worker, err := os.ReadFile(path.Join(staticFilesLocation, serviceWorkerBundle))
+		if err != nil {
+			log.WithError(err).Error("Error reading service worker.")
+			http.Error(w, "Failed to read service worker.", http.StatusInternalServerError)
+			return
+		}
+		quotedVersion, _ := json.Marshal(version)
+		w.Header().Set("Content-Type", "application/javascript")
+		fmt.Fprintf(w, "var deckVersion = %s;\n%s\n", quotedVersion, strings.TrimSpace(string(worker)))
+	}
+}
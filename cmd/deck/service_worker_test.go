@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestBuildAssetManifest(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"style.css",
+		"favicon.ico",
+		"prow_bundle.min.js",
+		serviceWorkerBundle,
+		"prow/prow.ts",
+		"prow/tsconfig.json",
+		"prow/main.go",
+		"extensions/script.js",
+	} {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(name), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	manifest, err := buildAssetManifest(dir, "v1")
+	if err != nil {
+		t.Fatalf("failed to build asset manifest: %v", err)
+	}
+	expected := &assetManifest{
+		Version: "v1",
+		Assets: []string{
+			"/static/extensions/script.js?v=v1",
+			"/static/favicon.ico?v=v1",
+			"/static/prow_bundle.min.js?v=v1",
+			"/static/style.css?v=v1",
+		},
+	}
+	if diff := cmp.Diff(expected, manifest); diff != "" {
+		t.Errorf("asset manifest differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleServiceWorker(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, serviceWorkerBundle), []byte("self.addEventListener();\n"), 0644); err != nil {
+		t.Fatalf("failed to write service worker: %v", err)
+	}
+	log := logrus.WithField("handler", "/sw.js")
+
+	rr := httptest.NewRecorder()
+	handleServiceWorker(dir, "v1", log).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/sw.js", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if expected, actual := "var deckVersion = \"v1\";\nself.addEventListener();\n", rr.Body.String(); expected != actual {
+		t.Errorf("expected service worker %q, got %q", expected, actual)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/javascript" {
+		t.Errorf("expected javascript content type, got %q", contentType)
+	}
+	if cacheControl := rr.Header().Get("Cache-Control"); !strings.Contains(cacheControl, "no-store") {
+		t.Errorf("expected the service worker not to be cached, got Cache-Control %q", cacheControl)
+	}
+
+	rr = httptest.NewRecorder()
+	handleServiceWorker(t.TempDir(), "v1", log).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/sw.js", nil))
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d without a service worker, got %d", http.StatusInternalServerError, rr.Code)
+	}
+}
+
+func TestHandleVersion(t *testing.T) {
+	rr := httptest.NewRecorder()
+	handleVersion("v1").ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/version", nil))
+	var resp struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal version: %v", err)
+	}
+	if resp.Version != "v1" {
+		t.Errorf("expected version v1, got %q", resp.Version)
+	}
+}
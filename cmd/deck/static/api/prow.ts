@@ -108,6 +108,14 @@ export interface ProwJobSpec {
   rerun_auth_config?: object;
   hidden?: boolean;
   prowjob_default?: object;
+  retry?: RetryPolicy;
+}
+
+// RetryPolicy configures the automatic retries of a ProwJob.
+// RetryPolicy mirrors the RetryPolicy struct defined in prow/apis/prowjobs/v1/types.go.
+export interface RetryPolicy {
+  attempts?: number;
+  on?: ProwJobState[];
 }
 
 // ProwJobStatus provides runtime metadata, such as when it finished, whether it is running, etc.
@@ -122,6 +130,8 @@ export interface ProwJobStatus {
   build_id?: string;
   jenkins_build_id?: string;
   prev_report_states?: { [key: string]: ProwJobState };
+  retries?: number;
+  retry_time?: string;
 }
 
 // PodSpec is a description of a pod.
@@ -1,7 +1,7 @@
 import {copyToClipboard, icon, showAlert, showToast} from "./common";
 import {relativeURL} from "./urls";
 
-export function createRerunProwJobIcon(modal: HTMLElement, parentEl: Element, prowjob: string, showRerunButton: boolean, csrfToken: string): HTMLElement {
+export function createRerunProwJobIcon(modal: HTMLElement, parentEl: Element, prowjob: string, showRerunButton: boolean, allowOverrides: boolean, csrfToken: string): HTMLElement {
   const LATEST_JOB = 'latest';
   const ORIGINAL_JOB = 'original';
   const inrepoconfigURL = 'https://docs.prow.k8s.io/docs/inrepoconfig/';
@@ -124,36 +124,71 @@ export function createRerunProwJobIcon(modal: HTMLElement, parentEl: Element, pr
       }
     });
 
+    const rerun = async (body?: URLSearchParams) => {
+      gtag("event", "rerun", {
+        event_category: "engagement",
+        transport_type: "beacon",
+      });
+      try {
+        const result = await fetch(commandURL, {
+          body,
+          headers: {
+            "Content-type": "application/x-www-form-urlencoded; charset=UTF-8",
+            "X-CSRF-Token": csrfToken,
+          },
+          method: 'post',
+        });
+        if (result.status === 401) {
+          window.location.href = `${window.location.origin  }/github-login?dest=${relativeURL({rerun: "gh_redirect"})}`;
+        }
+        const data = await result.text();
+        if (result.status >= 400) {
+          showAlert(data);
+        } else {
+          showToast(data);
+        }
+      } catch (e) {
+        showAlert(`Could not send request to rerun job: ${e}`);
+      }
+    };
+
     if (showRerunButton) {
       const runButton = document.createElement('a');
       runButton.innerHTML = "<button class='mdl-button mdl-js-button mdl-button--raised mdl-button--colored'>Rerun</button>";
-      runButton.onclick = async () => {
-        gtag("event", "rerun", {
-          event_category: "engagement",
-          transport_type: "beacon",
-        });
+      runButton.onclick = () => rerun();
+      parentEl.appendChild(runButton);
+    }
+
+    if (showRerunButton && allowOverrides) {
+      // The edited ProwJob is validated by the backend, which only accepts
+      // changes to the base SHAs and the environment variables of the containers.
+      const overrides = document.createElement('div');
+      overrides.className = 'rerunModal-overrides';
+      overrides.innerHTML = `
+        <p class="rerunModal-description">
+          Alternatively, edit the base SHAs or the values of the environment variables of the containers of the
+          ProwJob below and rerun it with these overrides. Other fields cannot be changed.
+        </p>
+        <textarea class="rerunModal-overridesEditor" rows="20" spellcheck="false"></textarea>
+        <a class="rerunModal-overridesButton">
+          <button class='mdl-button mdl-js-button mdl-button--raised mdl-button--colored'>Rerun with overrides</button>
+        </a>
+      `;
+      parentEl.appendChild(overrides);
+      const editor = overrides.querySelector('.rerunModal-overridesEditor') as HTMLTextAreaElement;
+      const loadProwJob = async () => {
         try {
-          const result = await fetch(commandURL, {
-            headers: {
-              "Content-type": "application/x-www-form-urlencoded; charset=UTF-8",
-              "X-CSRF-Token": csrfToken,
-            },
-            method: 'post',
-          });
-          if (result.status === 401) {
-            window.location.href = `${window.location.origin  }/github-login?dest=${relativeURL({rerun: "gh_redirect"})}`;
-          }
-          const data = await result.text();
-          if (result.status >= 400) {
-            showAlert(data);
-          } else {
-            showToast(data);
-          }
+          const result = await fetch(commandURL);
+          editor.value = await result.text();
         } catch (e) {
-          showAlert(`Could not send request to rerun job: ${e}`);
+          showAlert(`Could not load the ProwJob: ${e}`);
         }
       };
-      parentEl.appendChild(runButton);
+      latestOption.addEventListener('click', loadProwJob);
+      originalOption.addEventListener('click', loadProwJob);
+      loadProwJob();
+      const overridesButton = overrides.querySelector('.rerunModal-overridesButton') as HTMLElement;
+      overridesButton.onclick = () => rerun(new URLSearchParams({edited_prowjob: editor.value}));
     }
   };
 
@@ -0,0 +1,86 @@
+// UserSettings mirrors the userSettings type served by /user/settings.
+export interface UserSettings {
+  theme?: "light" | "dark";
+  repo_filters?: string[];
+  hidden_columns?: string[];
+}
+
+const settingsURL = "/user/settings";
+
+// loadUserSettings fetches the settings of the logged in user. It returns null
+// for anonymous users and Deck instances that don't store user settings.
+export async function loadUserSettings(): Promise<UserSettings | null> {
+  try {
+    const result = await fetch(settingsURL, {credentials: "same-origin"});
+    if (!result.ok) {
+      return null;
+    }
+    return await result.json() as UserSettings;
+  } catch (e) {
+    return null;
+  }
+}
+
+// saveUserSettings persists the settings for the logged in user.
+export async function saveUserSettings(settings: UserSettings, csrfToken: string): Promise<boolean> {
+  const result = await fetch(settingsURL, {
+    body: JSON.stringify(settings),
+    credentials: "same-origin",
+    headers: {
+      "Content-type": "application/json",
+      "X-CSRF-Token": csrfToken,
+    },
+    method: "post",
+  });
+  return result.ok;
+}
+
+// applyTheme toggles the dark theme on the page body.
+export function applyTheme(settings: UserSettings): void {
+  document.body.classList.toggle("dark-theme", settings.theme === "dark");
+}
+
+// setupThemeToggle shows the dark theme checkbox, which applies and saves the
+// theme of the user when it is toggled.
+export function setupThemeToggle(settings: UserSettings, csrfToken: string): void {
+  const container = document.getElementById("theme-setting");
+  const toggle = document.getElementById("dark-theme") as HTMLInputElement | null;
+  if (!container || !toggle) {
+    return;
+  }
+  toggle.checked = settings.theme === "dark";
+  toggle.onchange = async () => {
+    const previous = settings.theme;
+    settings.theme = toggle.checked ? "dark" : "light";
+    applyTheme(settings);
+    if (!await saveUserSettings(settings, csrfToken)) {
+      settings.theme = previous;
+      toggle.checked = previous === "dark";
+      applyTheme(settings);
+    }
+  };
+  container.classList.remove("hidden");
+}
+
+// applyHiddenColumns hides the table columns whose header text matches one of
+// the user's hidden columns.
+export function applyHiddenColumns(table: HTMLTableElement, settings: UserSettings): void {
+  const hidden = new Set((settings.hidden_columns || []).map((c) => c.toLowerCase()));
+  if (hidden.size === 0 || !table.tHead) {
+    return;
+  }
+  const headers = table.tHead.rows[0].cells;
+  const indices: number[] = [];
+  for (let i = 0; i < headers.length; i++) {
+    if (hidden.has((headers[i].textContent || "").trim().toLowerCase())) {
+      indices.push(i);
+    }
+  }
+  for (const row of Array.from(table.rows)) {
+    for (const i of indices) {
+      if (row.cells[i]) {
+        row.cells[i].classList.add("hidden");
+      }
+    }
+  }
+}
@@ -4,14 +4,18 @@ import {createAbortProwJobIcon} from "../common/abort";
 import {cell, formatDuration, icon} from "../common/common";
 import {createRerunProwJobIcon} from "../common/rerun";
 import {getParameterByName} from "../common/urls";
+import {applyHiddenColumns, applyTheme, loadUserSettings, setupThemeToggle, UserSettings} from "../common/user_settings";
 import {FuzzySearch} from './fuzzy-search';
 import {JobHistogram, JobSample} from './histogram';
 
 declare const allBuilds: ProwJobList;
 declare const spyglass: boolean;
 declare const rerunCreatesJob: boolean;
+declare const rerunAllowsOverrides: boolean;
 declare const csrfToken: string;
 
+let userSettings: UserSettings = {};
+
 function genShortRefKey(baseRef: string, pulls: Pull[] = []) {
   return [baseRef, ...pulls.map((p) => p.number)].filter((n) => n).join(",");
 }
@@ -194,7 +198,13 @@ function handleUpKey(): void {
   adjustScroll(previousSibling);
 }
 
-window.onload = (): void => {
+window.onload = async (): Promise<void> => {
+  const loadedSettings = await loadUserSettings();
+  if (loadedSettings) {
+    userSettings = loadedSettings;
+    setupThemeToggle(userSettings, csrfToken);
+  }
+  applyTheme(userSettings);
   const topNavigator = document.getElementById("top-navigator")!;
   let navigatorTimeOut: any;
   const main = document.querySelector("main")! ;
@@ -282,9 +292,28 @@ window.onload = (): void => {
     "job-list",
     Object.keys(opts.jobs).sort());
   redrawOptions(fz, opts);
+  applyDefaultRepoFilter(opts);
   redraw(fz);
 };
 
+// applyDefaultRepoFilter selects the user's first preferred repo when the URL
+// does not already select one.
+function applyDefaultRepoFilter(opts: RepoOptions): void {
+  if (getParameterByName("repo")) {
+    return;
+  }
+  const preferred = (userSettings.repo_filters || []).find((r) => opts.repos[r]);
+  if (!preferred) {
+    return;
+  }
+  const sel = document.getElementById("repo") as HTMLSelectElement;
+  for (const option of Array.from(sel.options)) {
+    if (option.text === preferred) {
+      option.selected = true;
+    }
+  }
+}
+
 function displayFuzzySearchResult(el: HTMLElement, inputContainer: ClientRect | DOMRect): void {
   el.classList.add("active-fuzzy-search");
   el.style.top = `${inputContainer.height - 1  }px`;
@@ -513,7 +542,7 @@ function redraw(fz: FuzzySearch, pushState = true): void {
         refs: {repo_link = "", base_sha = "", base_link = "", pulls = [], base_ref = ""} = {},
         pod_spec,
       },
-      status: {startTime, completionTime = "", state = "", pod_name, build_id = "", url = ""},
+      status: {startTime, completionTime = "", state = "", pod_name, build_id = "", url = "", retries = 0},
     } = build;
 
     let buildUrl = url;
@@ -667,10 +696,11 @@ function redraw(fz: FuzzySearch, pushState = true): void {
       r.appendChild(cell.text(''));
     }
     // Results column
+    const jobText = retries > 0 ? `${job} (retry ${retries})` : job;
     if (buildUrl === "") {
-      r.appendChild(cell.text(job));
+      r.appendChild(cell.text(jobText));
     } else {
-      r.appendChild(cell.link(job, buildUrl));
+      r.appendChild(cell.link(jobText, buildUrl));
     }
     // Started column
     r.appendChild(cell.time(i.toString(), moment.unix(started)));
@@ -722,6 +752,7 @@ function redraw(fz: FuzzySearch, pushState = true): void {
     modal.style.display = "block";
     modalContent.innerHTML = "Rerunning that job requires GitHub login. Now that you're logged in, try again";
   }
+  applyHiddenColumns(document.getElementById("builds") as HTMLTableElement, userSettings);
   // we need to upgrade DOM for new created dynamic elements
   // see https://getmdl.io/started/index.html#dynamic
   componentHandler.upgradeDom();
@@ -735,7 +766,7 @@ function createAbortCell(modal: HTMLElement, modalContent: Element, job: string,
 
 function createRerunCell(modal: HTMLElement, rerunElement: Element, prowjob: string): HTMLTableDataCellElement {
   const c = document.createElement("td");
-  c.appendChild(createRerunProwJobIcon(modal, rerunElement, prowjob, rerunCreatesJob, csrfToken));
+  c.appendChild(createRerunProwJobIcon(modal, rerunElement, prowjob, rerunCreatesJob, rerunAllowsOverrides, csrfToken));
   return c;
 }
 
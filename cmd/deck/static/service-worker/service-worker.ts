@@ -0,0 +1,120 @@
+// The service worker caches the static assets of Deck ahead of time and the
+// pages and data it fetched, so that repeat visits load instantly and visited
+// pages remain available while offline. The caches are tied to the version of
+// Deck, which is prepended to the worker when it is served.
+declare const self: ServiceWorkerGlobalScope;
+declare const deckVersion: string;
+
+const cachePrefix = "deck-";
+const cacheName = `${cachePrefix}${deckVersion}`;
+
+// How often to check whether Deck was updated while its pages stay open.
+const versionCheckIntervalMs = 5 * 60 * 1000;
+let lastVersionCheck = 0;
+
+// Requests that are never cached, like those specific to the logged in user.
+const uncachedPaths = ["/sw.js", "/asset-manifest.json", "/version", "/pr-data.js", "/user/settings", "/github-login"];
+
+interface AssetManifest {
+  version: string;
+  assets: string[];
+}
+
+self.addEventListener("install", (event) => {
+  event.waitUntil((async () => {
+    const response = await fetch("/asset-manifest.json", {cache: "no-store"});
+    const manifest = await response.json() as AssetManifest;
+    const cache = await caches.open(cacheName);
+    await cache.addAll(manifest.assets);
+    await self.skipWaiting();
+  })());
+});
+
+self.addEventListener("activate", (event) => {
+  event.waitUntil((async () => {
+    const names = await caches.keys();
+    await Promise.all(names
+      .filter((name) => name.startsWith(cachePrefix) && name !== cacheName)
+      .map((name) => caches.delete(name)));
+    await self.clients.claim();
+  })());
+});
+
+self.addEventListener("fetch", (event) => {
+  const request = event.request;
+  const url = new URL(request.url);
+  if (request.method !== "GET" || url.origin !== self.location.origin) {
+    return;
+  }
+  if (url.pathname.startsWith("/static/") || url.pathname.startsWith("/spyglass/static/")) {
+    // Static assets carry a cache busting parameter, so they never change.
+    event.respondWith(cacheFirst(request));
+    return;
+  }
+  if (uncachedPaths.some((p) => url.pathname.startsWith(p))) {
+    return;
+  }
+  if (request.mode === "navigate") {
+    event.waitUntil(checkVersion());
+  }
+  event.respondWith(networkFirst(request));
+});
+
+async function cacheFirst(request: Request): Promise<Response> {
+  const cached = await caches.match(request);
+  if (cached) {
+    return cached;
+  }
+  const response = await fetch(request);
+  if (response.ok) {
+    const cache = await caches.open(cacheName);
+    await cache.put(request, response.clone());
+  }
+  return response;
+}
+
+// networkFirst only serves cached responses when Deck is unreachable, as a
+// slow Deck still has more recent job state than the cache.
+async function networkFirst(request: Request): Promise<Response> {
+  const cache = await caches.open(cacheName);
+  try {
+    const response = await fetch(request);
+    if (response.ok) {
+      await cache.put(request, response.clone());
+    }
+    return response;
+  } catch (e) {
+    // The network is unavailable, fall back to the cache.
+  }
+  const cached = await cache.match(request);
+  return cached || offlineResponse(request);
+}
+
+function offlineResponse(request: Request): Response {
+  if (request.mode === "navigate") {
+    return new Response(
+      "<!DOCTYPE html><title>Prow</title><p>Deck is unreachable and this page was not visited before. " +
+      "Reload the page once the connection is back.</p>",
+      {headers: {"Content-Type": "text/html; charset=utf-8"}, status: 503});
+  }
+  return new Response("Deck is unreachable.", {status: 503});
+}
+
+// checkVersion makes the browser fetch the worker again once Deck was
+// updated, which installs a new worker that replaces the outdated caches.
+async function checkVersion(): Promise<void> {
+  const now = Date.now();
+  if (now - lastVersionCheck < versionCheckIntervalMs) {
+    return;
+  }
+  lastVersionCheck = now;
+  try {
+    const response = await fetch("/version", {cache: "no-store"});
+    const {version} = await response.json() as {version: string};
+    if (version !== deckVersion) {
+      await self.registration.update();
+    }
+  } catch (e) {
+    // Deck is unreachable, try again on the next navigation.
+  }
+}
@@ -9,6 +9,7 @@ declare const lensArtifacts: {[index: string]: string[]};
 declare const lensIndexes: number[];
 declare const csrfToken: string;
 declare const rerunCreatesJob: boolean;
+declare const rerunAllowsOverrides: boolean;
 declare const prowJob: string;
 declare const prowJobName: string;
 declare const prowJobState: ProwJobState;
@@ -184,7 +185,7 @@ function handleRerunButton() {
 
   const r = document.getElementById("header-title")!;
   const c = document.createElement("div");
-  c.appendChild(createRerunProwJobIcon(modal, modalContent, prowJobName, rerunCreatesJob, csrfToken));
+  c.appendChild(createRerunProwJobIcon(modal, modalContent, prowJobName, rerunCreatesJob, rerunAllowsOverrides, csrfToken));
   r.appendChild(c);
 
   if (rerunStatus === "gh_redirect") {
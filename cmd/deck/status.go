@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/plank"
+)
+
+// componentHealthTimeout bounds how long a component may take to answer its
+// health check.
+const componentHealthTimeout = 5 * time.Second
+
+// statusCacheTTL is how long the status is served from the cache, so that
+// requests don't check the health of every component each.
+const statusCacheTTL = 30 * time.Second
+
+// component is a Prow component whose health endpoint is checked.
+type component struct {
+	Name string
+	URL  string
+}
+
+// parseComponentHealthURLs parses --component-health-url values of the form
+// NAME=URL.
+func parseComponentHealthURLs(values []string) ([]component, error) {
+	var components []component
+	for _, value := range values {
+		name, rawURL, ok := strings.Cut(value, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("--component-health-url %q is not of the form NAME=URL", value)
+		}
+		if _, err := url.ParseRequestURI(rawURL); err != nil {
+			return nil, fmt.Errorf("--component-health-url %q has an invalid URL: %w", value, err)
+		}
+		components = append(components, component{Name: name, URL: rawURL})
+	}
+	return components, nil
+}
+
+// ComponentStatus is the health of a Prow component.
+type ComponentStatus struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BuildClusterStatus is the reachability of a build cluster as reported by
+// plank.
+type BuildClusterStatus struct {
+	Name   string              `json:"name"`
+	Status plank.ClusterStatus `json:"status"`
+}
+
+// StatusPage aggregates the health of a Prow instance.
+type StatusPage struct {
+	Components []ComponentStatus `json:"components"`
+	// BuildClusters is empty if plank does not report build cluster
+	// statuses, see plank.build_cluster_status_file.
+	BuildClusters      []BuildClusterStatus `json:"build_clusters"`
+	BuildClustersError string               `json:"build_clusters_error,omitempty"`
+	Config             config.LoadStatus    `json:"config"`
+}
+
+type statusAgent struct {
+	components []component
+	httpClient *http.Client
+	opener     io.Opener
+	cfg        config.Getter
+	loadStatus func() config.LoadStatus
+
+	// cacheLock is held while the status is determined, so that concurrent
+	// requests wait for the same result.
+	cacheLock sync.Mutex
+	cached    *StatusPage
+	cachedAt  time.Time
+}
+
+// cachedStatus returns the status determined within statusCacheTTL, or
+// determines it again.
+func (sa *statusAgent) cachedStatus(ctx context.Context) StatusPage {
+	sa.cacheLock.Lock()
+	defer sa.cacheLock.Unlock()
+	if sa.cached == nil || time.Since(sa.cachedAt) > statusCacheTTL {
+		// The status is shared by all requests, so it is not canceled
+		// with the request that determines it.
+		page := sa.status(context.WithoutCancel(ctx))
+		sa.cached, sa.cachedAt = &page, time.Now()
+	}
+	return *sa.cached
+}
+
+func (sa *statusAgent) status(ctx context.Context) StatusPage {
+	var page StatusPage
+	page.Components = make([]ComponentStatus, len(sa.components))
+	wg := sync.WaitGroup{}
+	for i, c := range sa.components {
+		wg.Add(1)
+		go func(i int, c component) {
+			defer wg.Done()
+			page.Components[i] = sa.checkComponent(ctx, c)
+		}(i, c)
+	}
+
+	if location := sa.cfg().Plank.BuildClusterStatusFile; location != "" {
+		clusters, err := sa.buildClusterStatuses(ctx, location)
+		if err != nil {
+			page.BuildClustersError = err.Error()
+		}
+		page.BuildClusters = clusters
+	}
+	if sa.loadStatus != nil {
+		page.Config = sa.loadStatus()
+	}
+	wg.Wait()
+	return page
+}
+
+func (sa *statusAgent) checkComponent(ctx context.Context, c component) ComponentStatus {
+	status := ComponentStatus{Name: c.Name, URL: c.URL}
+	ctx, cancel := context.WithTimeout(ctx, componentHealthTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	resp, err := sa.httpClient.Do(req)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		status.Error = fmt.Sprintf("health check responded with %d", resp.StatusCode)
+		return status
+	}
+	status.Healthy = true
+	return status
+}
+
+// buildClusterStatuses reads the build cluster status file plank writes
+// periodically.
+func (sa *statusAgent) buildClusterStatuses(ctx context.Context, location string) ([]BuildClusterStatus, error) {
+	reader, err := sa.opener.Reader(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open build cluster status file %s: %w", location, err)
+	}
+	defer reader.Close()
+	b, err := stdio.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build cluster status file %s: %w", location, err)
+	}
+	statuses := map[string]plank.ClusterStatus{}
+	if err := json.Unmarshal(b, &statuses); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal build cluster status file %s: %w", location, err)
+	}
+	var clusters []BuildClusterStatus
+	for name, status := range statuses {
+		clusters = append(clusters, BuildClusterStatus{Name: name, Status: status})
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
+
+// handleStatus serves the aggregated health of the Prow instance as JSON.
+func handleStatus(sa *statusAgent, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		b, err := json.Marshal(sa.cachedStatus(r.Context()))
+		if err != nil {
+			log.WithError(err).Error("Error marshaling status.")
+			http.Error(w, "Failed to marshal status.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(b))
+	}
+}
+
+// handleStatusPage serves the aggregated health of the Prow instance as a
+// page.
+func handleStatusPage(o options, cfg config.Getter, sa *statusAgent) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		handleSimpleTemplate(o, cfg, "status.html", sa.cachedStatus(r.Context()))(w, r)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	stdio "io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+	"sigs.k8s.io/prow/pkg/plank"
+)
+
+func TestParseComponentHealthURLs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		values      []string
+		expected    []component
+		expectedErr bool
+	}{
+		{
+			name: "no components",
+		},
+		{
+			name:   "components",
+			values: []string{"tide=http://tide:8081/healthz/ready", "hook=http://hook:8081/healthz?x=a=b"},
+			expected: []component{
+				{Name: "tide", URL: "http://tide:8081/healthz/ready"},
+				{Name: "hook", URL: "http://hook:8081/healthz?x=a=b"},
+			},
+		},
+		{
+			name:        "missing name",
+			values:      []string{"http://tide:8081/healthz/ready"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid URL",
+			values:      []string{"tide=tide"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			components, err := parseComponentHealthURLs(tc.values)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(tc.expected, components); diff != "" {
+				t.Errorf("unexpected components (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	lastSuccess := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name             string
+		statusFile       string
+		statusFileExists bool
+		expected         StatusPage
+	}{
+		{
+			name: "build cluster statuses are not reported",
+			expected: StatusPage{
+				Components: []ComponentStatus{
+					{Name: "tide", URL: healthy.URL, Healthy: true},
+					{Name: "hook", URL: unhealthy.URL, Error: "health check responded with 503"},
+				},
+				Config: config.LoadStatus{LastSuccess: lastSuccess},
+			},
+		},
+		{
+			name:             "build cluster statuses",
+			statusFile:       "gs://bucket/cluster-status.json",
+			statusFileExists: true,
+			expected: StatusPage{
+				Components: []ComponentStatus{
+					{Name: "tide", URL: healthy.URL, Healthy: true},
+					{Name: "hook", URL: unhealthy.URL, Error: "health check responded with 503"},
+				},
+				BuildClusters: []BuildClusterStatus{
+					{Name: "build01", Status: plank.ClusterStatusError},
+					{Name: "default", Status: plank.ClusterStatusReachable},
+				},
+				Config: config.LoadStatus{LastSuccess: lastSuccess},
+			},
+		},
+		{
+			name:       "build cluster status file is missing",
+			statusFile: "gs://bucket/cluster-status.json",
+			expected: StatusPage{
+				Components: []ComponentStatus{
+					{Name: "tide", URL: healthy.URL, Healthy: true},
+					{Name: "hook", URL: unhealthy.URL, Error: "health check responded with 503"},
+				},
+				BuildClustersError: "failed to open build cluster status file gs://bucket/cluster-status.json: file does not exist",
+				Config:             config.LoadStatus{LastSuccess: lastSuccess},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opener := &fakeopener.FakeOpener{}
+			if tc.statusFileExists {
+				opener.Buffer = map[string]*bytes.Buffer{
+					tc.statusFile: bytes.NewBufferString(`{"default": "Reachable", "build01": "Error"}`),
+				}
+			}
+			cfg := &config.Config{}
+			cfg.Plank.BuildClusterStatusFile = tc.statusFile
+			sa := &statusAgent{
+				components: []component{{Name: "tide", URL: healthy.URL}, {Name: "hook", URL: unhealthy.URL}},
+				httpClient: &http.Client{},
+				opener:     opener,
+				cfg:        func() *config.Config { return cfg },
+				loadStatus: func() config.LoadStatus { return config.LoadStatus{LastSuccess: lastSuccess} },
+			}
+			if diff := cmp.Diff(tc.expected, sa.status(context.Background())); diff != "" {
+				t.Errorf("unexpected status (-want +got):\n%s", diff)
+			}
+
+			rr := httptest.NewRecorder()
+			handleStatusPage(options{templateFilesLocation: "template"}, sa.cfg, sa)(rr, httptest.NewRequest(http.MethodGet, "/status", nil))
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status page to render, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), "health check responded with 503") {
+				t.Errorf("expected status page to show the unhealthy component, got %s", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestCachedStatus(t *testing.T) {
+	var checks int
+	sa := &statusAgent{
+		components: []component{{Name: "tide", URL: "http://tide:8081/healthz"}},
+		httpClient: &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			checks++
+			return &http.Response{StatusCode: http.StatusOK, Body: stdio.NopCloser(strings.NewReader(""))}, nil
+		})},
+		cfg:        func() *config.Config { return &config.Config{} },
+		loadStatus: func() config.LoadStatus { return config.LoadStatus{} },
+	}
+	sa.cachedStatus(context.Background())
+	sa.cachedStatus(context.Background())
+	if checks != 1 {
+		t.Errorf("expected the cached status to be reused, got %d health checks", checks)
+	}
+	sa.cachedAt = sa.cachedAt.Add(-2 * statusCacheTTL)
+	sa.cachedStatus(context.Background())
+	if checks != 2 {
+		t.Errorf("expected an expired status to be determined again, got %d health checks", checks)
+	}
+}
+
+func TestCheckComponentUnreachable(t *testing.T) {
+	sa := &statusAgent{httpClient: &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})}}
+	status := sa.checkComponent(context.Background(), component{Name: "crier", URL: "http://crier:8081/healthz"})
+	if status.Healthy || !strings.Contains(status.Error, "connection refused") {
+		t.Errorf("expected unreachable component to be unhealthy, got %+v", status)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -49,15 +49,17 @@ func getConcreteBrandingFunction(cfg config.Getter) func() config.Branding {
 }
 
 type baseTemplateSections struct {
-	PR   bool
-	Tide bool
+	PR     bool
+	Tide   bool
+	Status bool
 }
 
 func getConcreteSectionFunction(o options) func() baseTemplateSections {
 	return func() baseTemplateSections {
 		return baseTemplateSections{
-			PR:   o.oauthURL != "" || o.pregeneratedData != "",
-			Tide: o.tideURL != "" || o.pregeneratedData != "",
+			PR:     o.oauthURL != "" || o.pregeneratedData != "",
+			Tide:   o.tideURL != "" || o.pregeneratedData != "",
+			Status: o.statusPage && o.pregeneratedData == "",
 		}
 	}
 }
@@ -74,6 +76,7 @@ func prepareBaseTemplate(o options, cfg config.Getter, csrfToken string, t *temp
 		"deckVersion":      func() string { return version.Version },
 		"googleAnalytics":  func() string { return cfg().Deck.GoogleAnalytics },
 		"csrfToken":        func() string { return csrfToken },
+		"serviceWorker":    func() bool { return o.serviceWorker },
 	}).ParseFiles(path.Join(o.templateFilesLocation, "base.html"))
 }
 
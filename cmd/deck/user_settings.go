@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/githuboauth"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+const (
+	themeLight = "light"
+	themeDark  = "dark"
+
+	// maxUserSettingsSize bounds the request body accepted by /user/settings.
+	maxUserSettingsSize = 64 * 1024
+)
+
+// userSettings are the display preferences of a single Deck user.
+type userSettings struct {
+	// Theme is either "light" or "dark". Empty means the Deck default.
+	Theme string `json:"theme,omitempty"`
+	// RepoFilters are the repos pre-selected in the job list filter.
+	RepoFilters []string `json:"repo_filters,omitempty"`
+	// HiddenColumns are the job list columns the user chose to hide.
+	HiddenColumns []string `json:"hidden_columns,omitempty"`
+}
+
+func (s userSettings) validate() error {
+	switch s.Theme {
+	case "", themeLight, themeDark:
+	default:
+		return fmt.Errorf("invalid theme %q, must be one of %q or %q", s.Theme, themeLight, themeDark)
+	}
+	for _, repo := range s.RepoFilters {
+		if parts := strings.Split(repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid repo filter %q, must be of the form org/repo", repo)
+		}
+	}
+	for _, column := range s.HiddenColumns {
+		if column == "" {
+			return fmt.Errorf("hidden columns must not be empty")
+		}
+	}
+	return nil
+}
+
+// userSettingsStore persists user settings in blob storage, one object per
+// GitHub login below the configured base path.
+type userSettingsStore struct {
+	opener   io.Opener
+	basePath string
+}
+
+func (s *userSettingsStore) path(login string) string {
+	return strings.TrimSuffix(s.basePath, "/") + "/" + strings.ToLower(login) + ".json"
+}
+
+func (s *userSettingsStore) get(r *http.Request, login string, log *logrus.Entry) (userSettings, error) {
+	var settings userSettings
+	raw, err := io.ReadContent(r.Context(), log, s.opener, s.path(login))
+	if err != nil {
+		if io.IsNotExist(err) {
+			return settings, nil
+		}
+		return settings, err
+	}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal stored settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *userSettingsStore) put(r *http.Request, login string, settings userSettings, log *logrus.Entry) error {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return io.WriteContent(r.Context(), log, s.opener, s.path(login), raw)
+}
+
+// handleUserSettings serves and stores the display settings of the user
+// identified through GitHub OAuth, so that they follow the user across machines.
+func handleUserSettings(store *userSettingsStore, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		login, err := goa.GetLogin(r, ghc)
+		if err != nil {
+			http.Error(w, "You must be logged in to manage settings.", http.StatusUnauthorized)
+			return
+		}
+		l := log.WithField("user", login)
+		switch r.Method {
+		case http.MethodGet:
+			settings, err := store.get(r, login, l)
+			if err != nil {
+				http.Error(w, "Could not load settings.", http.StatusInternalServerError)
+				l.WithError(err).Error("Could not load user settings.")
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(settings); err != nil {
+				l.WithError(err).Debug("Error writing user settings response.")
+			}
+		case http.MethodPost:
+			body, err := stdio.ReadAll(stdio.LimitReader(r.Body, maxUserSettingsSize+1))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Could not read request body: %v.", err), http.StatusBadRequest)
+				return
+			}
+			if len(body) > maxUserSettingsSize {
+				http.Error(w, "Settings are too large.", http.StatusRequestEntityTooLarge)
+				return
+			}
+			var settings userSettings
+			if err := json.Unmarshal(body, &settings); err != nil {
+				http.Error(w, fmt.Sprintf("Could not parse settings: %v.", err), http.StatusBadRequest)
+				return
+			}
+			if err := settings.validate(); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid settings: %v.", err), http.StatusBadRequest)
+				return
+			}
+			if err := store.put(r, login, settings, l); err != nil {
+				http.Error(w, "Could not save settings.", http.StatusInternalServerError)
+				l.WithError(err).Error("Could not save user settings.")
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, fmt.Sprintf("bad verb %v", r.Method), http.StatusMethodNotAllowed)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/sessions"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/prow/pkg/githuboauth"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+)
+
+func TestHandleUserSettings(t *testing.T) {
+	testCases := []struct {
+		name         string
+		loggedIn     bool
+		method       string
+		body         string
+		stored       map[string]string
+		expectedCode int
+		expectedBody string
+		expectStored map[string]string
+	}{
+		{
+			name:         "anonymous users are rejected",
+			method:       http.MethodGet,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "missing settings return empty settings",
+			loggedIn:     true,
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			expectedBody: "{}\n",
+		},
+		{
+			name:         "stored settings are returned",
+			loggedIn:     true,
+			method:       http.MethodGet,
+			stored:       map[string]string{"gs://bucket/settings/octocat.json": `{"theme":"dark","hidden_columns":["Job YML"]}`},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"theme":"dark","hidden_columns":["Job YML"]}` + "\n",
+		},
+		{
+			name:         "valid settings are stored",
+			loggedIn:     true,
+			method:       http.MethodPost,
+			body:         `{"theme":"dark","repo_filters":["org/repo"]}`,
+			expectedCode: http.StatusNoContent,
+			expectStored: map[string]string{"gs://bucket/settings/octocat.json": `{"theme":"dark","repo_filters":["org/repo"]}`},
+		},
+		{
+			name:         "unknown theme is rejected",
+			loggedIn:     true,
+			method:       http.MethodPost,
+			body:         `{"theme":"solarized"}`,
+			expectedCode: http.StatusBadRequest,
+			expectStored: map[string]string{},
+		},
+		{
+			name:         "malformed repo filter is rejected",
+			loggedIn:     true,
+			method:       http.MethodPost,
+			body:         `{"repo_filters":["org"]}`,
+			expectedCode: http.StatusBadRequest,
+			expectStored: map[string]string{},
+		},
+		{
+			name:         "unsupported verb",
+			loggedIn:     true,
+			method:       http.MethodDelete,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opener := &fakeopener.FakeOpener{Buffer: map[string]*bytes.Buffer{}}
+			for path, content := range tc.stored {
+				opener.Buffer[path] = bytes.NewBufferString(content)
+			}
+			store := &userSettingsStore{opener: opener, basePath: "gs://bucket/settings/"}
+
+			req := httptest.NewRequest(tc.method, "/user/settings", strings.NewReader(tc.body))
+			mockCookieStore := sessions.NewCookieStore([]byte("secret-key"))
+			if tc.loggedIn {
+				session, err := sessions.GetRegistry(req).Get(mockCookieStore, "access-token-session")
+				if err != nil {
+					t.Fatalf("Error making access token session: %v", err)
+				}
+				session.Values["access-token"] = &oauth2.Token{AccessToken: "validtoken"}
+			}
+			goa := githuboauth.NewAgent(&githuboauth.Config{CookieStore: mockCookieStore}, logrus.NewEntry(logrus.New()))
+			ghc := &fakeAuthenticatedUserIdentifier{login: "OctoCat"}
+
+			rr := httptest.NewRecorder()
+			handleUserSettings(store, goa, ghc, logrus.WithField("handler", "/user/settings")).ServeHTTP(rr, req)
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("expected code %d, got %d: %s", tc.expectedCode, rr.Code, rr.Body.String())
+			}
+			if tc.expectedBody != "" {
+				if diff := cmp.Diff(tc.expectedBody, rr.Body.String()); diff != "" {
+					t.Errorf("unexpected body (-want +got):\n%s", diff)
+				}
+			}
+			if tc.expectStored != nil {
+				stored := map[string]string{}
+				for path, buf := range opener.Buffer {
+					stored[path] = buf.String()
+				}
+				if diff := cmp.Diff(tc.expectStored, stored); diff != "" {
+					t.Errorf("unexpected stored settings (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -103,6 +103,8 @@ func main() {
 
 	registry := mustRegister("exporter", pjLister)
 	registry.MustRegister(prowjobs.NewProwJobLifecycleHistogramVec(informerFactory.Prow().V1().ProwJobs().Informer()))
+	registry.MustRegister(prowjobs.NewProwJobFailureCounterVec(informerFactory.Prow().V1().ProwJobs().Informer()))
+	registry.MustRegister(prowjobs.NewProwJobCostCollector(informerFactory.Prow().V1().ProwJobs().Informer()))
 
 	// Expose prometheus metrics
 	metrics.ExposeMetricsWithRegistry("exporter", cfg().PushGateway, o.instrumentationOptions.MetricsPort, registry, nil)
@@ -18,11 +18,14 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"regexp"
 	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -43,6 +46,7 @@ const pluginName = "cherrypick"
 const defaultLabelPrefix = "cherrypick/"
 
 var cherryPickRe = regexp.MustCompile(`(?m)^(?:/cherrypick|/cherry-pick)\s+(.+)$`)
+var cherryPickAllRe = regexp.MustCompile(`(?m)^(?:/cherrypick-all|/cherry-pick-all)\s+(.+)$`)
 var releaseNoteRe = regexp.MustCompile(`(?s)(?:Release note\*\*:\s*(?:<!--[^<>]*-->\s*)?` + "```(?:release-note)?|```release-note)(.+?)```")
 var titleTargetBranchIndicatorTemplate = `[%s] `
 
@@ -54,6 +58,7 @@ type githubClient interface {
 	CreateComment(org, repo string, number int, comment string) error
 	CreateFork(org, repo string) (string, error)
 	CreatePullRequest(org, repo, title, body, head, base string, canModify bool) (int, error)
+	CreateDraftPullRequest(org, repo, title, body, head, base string, canModify bool) (int, error)
 	CreateIssue(org, repo, title, body string, milestone int, labels, assignees []string) (int, error)
 	EnsureFork(forkingUser, org, repo string) (string, error)
 	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
@@ -63,13 +68,14 @@ type githubClient interface {
 	IsMember(org, user string) (bool, error)
 	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
 	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	GetBranches(org, repo string, onlyProtected bool) ([]github.Branch, error)
 	ListOrgMembers(org, role string) ([]github.TeamMember, error)
 }
 
 // HelpProvider construct the pluginhelp.PluginHelp for this plugin.
 func HelpProvider(_ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
 	pluginHelp := &pluginhelp.PluginHelp{
-		Description: `The cherrypick plugin is used for cherrypicking PRs across branches. For every successful cherrypick invocation a new PR is opened against the target branch and assigned to the requester. If the parent PR contains a release note, it is copied to the cherrypick PR.`,
+		Description: `The cherrypick plugin is used for cherrypicking PRs across branches. For every successful cherrypick invocation a new PR is opened against the target branch and assigned to the requester. If the parent PR contains a release note, it is copied to the cherrypick PR. PRs merged with a merge commit are cherrypicked as a whole relative to their base branch. If the cherrypick has conflicts, the conflicts can be pushed with their conflict markers to a draft PR that explains how to resolve them.`,
 	}
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/cherrypick [branch]",
@@ -79,6 +85,12 @@ func HelpProvider(_ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
 		WhoCanUse: "Members of the trusted organization for the repo.",
 		Examples:  []string{"/cherrypick release-3.9", "/cherry-pick release-1.15", "/cherrypick release-1.6 release-1.5 release-1.4"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/cherrypick-all [branch|glob] ...",
+		Description: "Cherrypick a PR to multiple branches at once. Unlike chained cherrypicks, a cherrypick PR is opened for every branch right away. Branches can be given as globs like release-1.*, which match the branches of the repo except the base branch of the PR. Branches which already have a cherrypick PR are skipped.",
+		WhoCanUse:   "Members of the trusted organization for the repo.",
+		Examples:    []string{"/cherrypick-all release-1.28 release-1.27 release-1.26", "/cherry-pick-all release-1.2*"},
+	})
 	return pluginHelp, nil
 }
 
@@ -103,6 +115,8 @@ type Server struct {
 	allowAll bool
 	// Create an issue on cherrypick conflict.
 	issueOnConflict bool
+	// Push the conflicts and open a draft PR on cherrypick conflict.
+	draftOnConflict bool
 	// Set a custom label prefix.
 	labelPrefix string
 
@@ -192,6 +206,10 @@ func (s *Server) handleIssueComment(log logrus.FieldLogger, ic github.IssueComme
 	baseBranch := pr.Base.Ref
 	title := pr.Title
 	body := pr.Body
+	var mergeSHA string
+	if pr.MergeSHA != nil {
+		mergeSHA = *pr.MergeSHA
+	}
 
 	// Collect all branches for which a PR should be created as an immediate response
 	// to it. This excludes all subsequent branches in chained cherrypicks.
@@ -202,6 +220,25 @@ func (s *Server) handleIssueComment(log logrus.FieldLogger, ic github.IssueComme
 		return log, nil
 	}
 
+	unmatched, err := s.expandBranches(org, repo, baseBranch, commands)
+	if err != nil {
+		return log, err
+	}
+	if len(unmatched) > 0 {
+		var patterns []string
+		for _, pattern := range unmatched {
+			patterns = append(patterns, fmt.Sprintf("`%s`", pattern))
+		}
+		resp := fmt.Sprintf("no branches other than the base branch match %s.", strings.Join(patterns, ", "))
+		log.Info(resp)
+		if err := s.ghc.CreateComment(org, repo, num, plugins.FormatICResponse(ic.Comment, resp)); err != nil {
+			return log, err
+		}
+		if len(commands) == 0 {
+			return log, nil
+		}
+	}
+
 	_, hasInvalidBranch := commands[baseBranch]
 	delete(commands, baseBranch)
 
@@ -285,7 +322,7 @@ func (s *Server) handleIssueComment(log logrus.FieldLogger, ic github.IssueComme
 		})
 		branchLog.Debug("Cherrypick request.")
 
-		if err := s.handle(branchLog, ic.Comment.User.Login, &ic.Comment, org, repo, targetBranch, baseBranch, commands[targetBranch], title, body, num); err != nil {
+		if err := s.handle(branchLog, ic.Comment.User.Login, &ic.Comment, org, repo, targetBranch, baseBranch, commands[targetBranch], title, body, mergeSHA, num); err != nil {
 			return log, fmt.Errorf("failed to handle cherrypick for %s: %w", targetBranch, err)
 		}
 	}
@@ -305,9 +342,64 @@ func parseComment(comment github.IssueComment) cherrypickCommands {
 		cmds[targetBranch] = targetBranches[1:]
 	}
 
+	// Branches of /cherrypick-all are independent of each other, so they are
+	// not chained. Chains of /cherrypick for the same branch take precedence.
+	for _, match := range cherryPickAllRe.FindAllStringSubmatch(comment.Body, -1) {
+		for _, targetBranch := range strings.Fields(match[1]) {
+			if _, ok := cmds[targetBranch]; !ok {
+				cmds[targetBranch] = nil
+			}
+		}
+	}
+
 	return cmds
 }
 
+// isBranchGlob determines if the target branch is a glob. The characters
+// used by globs are not allowed in git branch names.
+func isBranchGlob(branch string) bool {
+	return strings.ContainsAny(branch, "*?[")
+}
+
+// expandBranches replaces the globs among the target branches of the commands
+// with the branches of the repo they match, except for the base branch of the
+// PR. It returns the globs which don't match any branch.
+func (s *Server) expandBranches(org, repo, baseBranch string, commands cherrypickCommands) ([]string, error) {
+	var globs []string
+	for targetBranch := range commands {
+		if isBranchGlob(targetBranch) {
+			globs = append(globs, targetBranch)
+		}
+	}
+	if len(globs) == 0 {
+		return nil, nil
+	}
+	sort.Strings(globs)
+
+	branches, err := s.ghc.GetBranches(org, repo, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches of %s/%s: %w", org, repo, err)
+	}
+	var unmatched []string
+	for _, glob := range globs {
+		delete(commands, glob)
+		var matched bool
+		for _, branch := range branches {
+			if ok, _ := path.Match(glob, branch.Name); !ok || branch.Name == baseBranch {
+				continue
+			}
+			matched = true
+			if _, ok := commands[branch.Name]; !ok {
+				commands[branch.Name] = nil
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, glob)
+		}
+	}
+	return unmatched, nil
+}
+
 func (s *Server) handlePullRequest(log logrus.FieldLogger, pre github.PullRequestEvent) (logrus.FieldLogger, error) {
 	// Only consider newly merged PRs
 	if pre.Action != github.PullRequestActionClosed && pre.Action != github.PullRequestActionLabeled && pre.Action != github.PullRequestActionOpened {
@@ -351,6 +443,13 @@ func (s *Server) handlePullRequest(log logrus.FieldLogger, pre github.PullReques
 	// first look for our special comments
 	for _, comment := range comments {
 		commands := parseComment(comment)
+		unmatched, err := s.expandBranches(org, repo, baseBranch, commands)
+		if err != nil {
+			return log, err
+		}
+		if len(unmatched) > 0 {
+			log.WithField("globs", unmatched).Info("Cherrypick globs match no branches.")
+		}
 
 		for targetBranch, chainedBranches := range commands {
 			if requesterToComments[comment.User.Login] == nil {
@@ -433,7 +532,7 @@ func (s *Server) handlePullRequest(log logrus.FieldLogger, pre github.PullReques
 				"target_branch": targetBranch,
 			})
 			branchLog.Debug("Cherrypick request.")
-			err := s.handle(branchLog, requester, ic, org, repo, targetBranch, baseBranch, targetBranchToChainBranches[targetBranch], title, body, num)
+			err := s.handle(branchLog, requester, ic, org, repo, targetBranch, baseBranch, targetBranchToChainBranches[targetBranch], title, body, *pr.MergeSHA, num)
 			if err != nil {
 				errs = append(errs, fmt.Errorf("failed to create cherrypick: %w", err))
 			}
@@ -444,7 +543,7 @@ func (s *Server) handlePullRequest(log logrus.FieldLogger, pre github.PullReques
 
 var cherryPickBranchFmt = "cherry-pick-%d-to-%s"
 
-func (s *Server) handle(logger logrus.FieldLogger, requester string, comment *github.IssueComment, org, repo, targetBranch, baseBranch string, chainBranches []string, title, body string, num int) error {
+func (s *Server) handle(logger logrus.FieldLogger, requester string, comment *github.IssueComment, org, repo, targetBranch, baseBranch string, chainBranches []string, title, body, mergeSHA string, num int) error {
 	var lock *sync.Mutex
 	func() {
 		s.mapLock.Lock()
@@ -485,11 +584,25 @@ func (s *Server) handle(logger logrus.FieldLogger, requester string, comment *gi
 	}
 	logger.WithField("duration", time.Since(startClone)).Info("Cloned and checked out target branch.")
 
-	// Fetch the patch from GitHub
-	localPath, err := s.getPatch(org, repo, targetBranch, num)
-	if err != nil {
-		logger.WithError(err).Errorf("Failed to get patch for %s/%s#%d", org, repo, num)
-		return s.createComment(logger, org, repo, num, comment, fmt.Sprintf("Failed to get PR patch from GitHub. This PR will need to be manually cherrypicked.\n<details><summary>Error message</summary>%v</details>", err))
+	// PRs merged with a merge commit are cherry-picked relative to the base
+	// branch as a whole, as the patch of a PR fails to apply if the PR
+	// contains merge commits itself.
+	var apply func(opts ...git.ApplyOpt) error
+	if isMergeCommit(logger, r, mergeSHA) {
+		logger.WithField("merge_sha", mergeSHA).Info("Cherry-picking the merge commit of the PR.")
+		apply = func(opts ...git.ApplyOpt) error {
+			return r.CherryPick(mergeSHA, append(opts, git.ApplyOpt{Mainline: 1})...)
+		}
+	} else {
+		// Fetch the patch from GitHub
+		localPath, err := s.getPatch(org, repo, targetBranch, num)
+		if err != nil {
+			logger.WithError(err).Errorf("Failed to get patch for %s/%s#%d", org, repo, num)
+			return s.createComment(logger, org, repo, num, comment, fmt.Sprintf("Failed to get PR patch from GitHub. This PR will need to be manually cherrypicked.\n<details><summary>Error message</summary>%v</details>", err))
+		}
+		apply = func(opts ...git.ApplyOpt) error {
+			return r.Am(localPath, opts...)
+		}
 	}
 
 	if err := r.Config("user.name", s.botUser.Login); err != nil {
@@ -531,9 +644,17 @@ func (s *Server) handle(logger logrus.FieldLogger, requester string, comment *gi
 	titleTargetBranchIndicator := fmt.Sprintf(titleTargetBranchIndicatorTemplate, targetBranch)
 	title = fmt.Sprintf("%s%s", titleTargetBranchIndicator, omitBaseBranchFromTitle(title, baseBranch))
 
-	// Apply the patch.
-	if err := r.Am(localPath); err != nil {
-		errs := []error{fmt.Errorf("failed to `git am`: %w", err)}
+	// Apply the changes.
+	err = apply()
+	var conflictErr *git.ConflictError
+	if err != nil && s.draftOnConflict {
+		logger.WithError(err).Info("failed to apply PR on top of target branch, keeping the conflicts for a draft PR")
+		if keepErr := apply(git.ApplyOpt{KeepConflicts: true}); keepErr == nil || errors.As(keepErr, &conflictErr) {
+			err = nil
+		}
+	}
+	if err != nil {
+		errs := []error{fmt.Errorf("failed to apply changes: %w", err)}
 		logger.WithError(err).Warn("failed to apply PR on top of target branch")
 		resp := fmt.Sprintf("#%d failed to apply on top of branch %q:\n```\n%v\n```", num, targetBranch, err)
 		if err := s.createComment(logger, org, repo, num, comment, resp); err != nil {
@@ -569,7 +690,12 @@ func (s *Server) handle(logger logrus.FieldLogger, requester string, comment *gi
 		cherryPickBody = cherrypicker.CreateCherrypickBody(num, "", releaseNoteFromParentPR(body), chainBranches)
 	}
 	head := fmt.Sprintf("%s:%s", s.botUser.Login, newBranch)
-	createdNum, err := s.ghc.CreatePullRequest(org, repo, title, cherryPickBody, head, targetBranch, true)
+	createPullRequest := s.ghc.CreatePullRequest
+	if conflictErr != nil {
+		cherryPickBody = conflictInstructions(conflictErr.Files, s.botUser.Login, forkName, newBranch) + "\n\n" + cherryPickBody
+		createPullRequest = s.ghc.CreateDraftPullRequest
+	}
+	createdNum, err := createPullRequest(org, repo, title, cherryPickBody, head, targetBranch, true)
 	if err != nil {
 		logger.WithError(err).Warn("failed to create new pull request")
 		resp := fmt.Sprintf("new pull request could not be created: %v", err)
@@ -577,6 +703,9 @@ func (s *Server) handle(logger logrus.FieldLogger, requester string, comment *gi
 	}
 	logger = logger.WithField("new_pull_request_number", createdNum)
 	resp := fmt.Sprintf("new pull request created: #%d", createdNum)
+	if conflictErr != nil {
+		resp = fmt.Sprintf("#%d has conflicts with branch %q, new draft pull request created with the conflicts to resolve: #%d", num, targetBranch, createdNum)
+	}
 	logger.Info("new pull request created")
 	if err := s.createComment(logger, org, repo, num, comment, resp); err != nil {
 		return fmt.Errorf("failed to create comment: %w", err)
@@ -598,6 +727,40 @@ func (s *Server) handle(logger logrus.FieldLogger, requester string, comment *gi
 	return nil
 }
 
+// isMergeCommit determines if the PR was merged with the given merge commit,
+// as opposed to being squashed or rebased.
+func isMergeCommit(logger logrus.FieldLogger, r git.RepoClient, mergeSHA string) bool {
+	if mergeSHA == "" {
+		return false
+	}
+	if exists, _ := r.ObjectExists(mergeSHA); !exists {
+		if err := r.Fetch(mergeSHA); err != nil {
+			logger.WithError(err).Info("Failed to fetch the merge commit, falling back to the patch of the PR.")
+			return false
+		}
+	}
+	isMerge, err := r.IsMergeCommit(mergeSHA)
+	if err != nil {
+		logger.WithError(err).Info("Failed to determine if the PR was merged with a merge commit, falling back to the patch of the PR.")
+		return false
+	}
+	return isMerge
+}
+
+// conflictInstructions explains how to resolve the conflicts that were
+// pushed to a cherry-pick PR.
+func conflictInstructions(conflicts []string, botLogin, forkName, branch string) string {
+	var files []string
+	for _, file := range conflicts {
+		files = append(files, fmt.Sprintf("- `%s`", file))
+	}
+	return fmt.Sprintf("> [!WARNING]\n> This cherry-pick has conflicts that need to be resolved manually.\n\n"+
+		"The conflicting changes were committed including their conflict markers in:\n\n%s\n\n"+
+		"To resolve them, check out the `%s` branch of `%s/%s`, resolve the conflict markers, commit the result and push it to that branch. "+
+		"Maintainers of this repository are allowed to push to it. Mark the PR as ready for review once the conflicts are resolved.",
+		strings.Join(files, "\n"), branch, botLogin, forkName)
+}
+
 // omitBaseBranchFromTitle returns the title without the base branch's
 // indicator, if there is one. We do this to avoid long cherry-pick titles when
 // doing a backport of a backport.
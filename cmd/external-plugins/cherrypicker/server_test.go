@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
@@ -48,6 +49,7 @@ type fghc struct {
 	prs        []github.PullRequest
 	prComments []github.IssueComment
 	prLabels   []github.Label
+	branches   []github.Branch
 	orgMembers []github.TeamMember
 	issues     []github.Issue
 }
@@ -196,6 +198,17 @@ func (f *fghc) CreatePullRequest(org, repo, title, body, head, base string, canM
 	return num, nil
 }
 
+func (f *fghc) CreateDraftPullRequest(org, repo, title, body, head, base string, canModify bool) (int, error) {
+	num, err := f.CreatePullRequest(org, repo, title, body, head, base, canModify)
+	if err != nil {
+		return 0, err
+	}
+	f.Lock()
+	defer f.Unlock()
+	f.prs[len(f.prs)-1].Draft = true
+	return num, nil
+}
+
 func (f *fghc) ListIssueComments(org, repo string, number int) ([]github.IssueComment, error) {
 	f.Lock()
 	defer f.Unlock()
@@ -208,6 +221,12 @@ func (f *fghc) GetIssueLabels(org, repo string, number int) ([]github.Label, err
 	return f.prLabels, nil
 }
 
+func (f *fghc) GetBranches(org, repo string, onlyProtected bool) ([]github.Branch, error) {
+	f.Lock()
+	defer f.Unlock()
+	return f.branches, nil
+}
+
 func (f *fghc) ListOrgMembers(org, role string) ([]github.TeamMember, error) {
 	f.Lock()
 	defer f.Unlock()
@@ -358,6 +377,287 @@ func testCherryPickIC(clients localgit.Clients, t *testing.T) {
 	}
 }
 
+func TestCherryPickAllV2(t *testing.T) {
+	t.Parallel()
+	testCherryPickAll(localgit.NewV2, t)
+}
+
+func testCherryPickAll(clients localgit.Clients, t *testing.T) {
+	iNumber := fakePR.GetPRNumber()
+	lg, c := makeFakeRepoWithCommit(clients, t)
+	branches := []github.Branch{{Name: "master"}, {Name: "feature"}}
+	for _, branch := range []string{"release-1.26", "release-1.27", "release-1.28"} {
+		if err := lg.CheckoutNewBranch("foo", "bar", branch); err != nil {
+			t.Fatalf("Checking out branch: %v", err)
+		}
+		branches = append(branches, github.Branch{Name: branch})
+	}
+	if err := lg.CheckoutNewBranch("foo", "bar", fmt.Sprintf(cherryPickBranchFmt, iNumber, "release-1.27")); err != nil {
+		t.Fatalf("Checking out existing PR branch: %v", err)
+	}
+
+	ghc := &fghc{
+		pr: &github.PullRequest{
+			Base: github.PullRequestBranch{
+				Ref: "master",
+			},
+			Merged: true,
+			Title:  "This is a fix for X",
+			Body:   body,
+		},
+		prs: []github.PullRequest{
+			{
+				Number: 1,
+				Title:  "[release-1.27] This is a fix for X",
+				Base:   github.PullRequestBranch{Ref: "release-1.27"},
+				Head:   github.PullRequestBranch{Ref: fmt.Sprintf("ci-robot:"+cherryPickBranchFmt, iNumber, "release-1.27")},
+			},
+		},
+		branches: branches,
+		isMember: true,
+		patch:    patch,
+	}
+	ic := github.IssueCommentEvent{
+		Action: github.IssueCommentActionCreated,
+		Repo: github.Repo{
+			Owner: github.User{
+				Login: "foo",
+			},
+			Name:     "bar",
+			FullName: "foo/bar",
+		},
+		Issue: github.Issue{
+			Number:      iNumber,
+			State:       "closed",
+			PullRequest: &struct{}{},
+		},
+		Comment: github.IssueComment{
+			User: github.User{
+				Login: "wiseguy",
+			},
+			Body: "/cherrypick-all release-1.2* release-2.*",
+		},
+	}
+
+	botUser := &github.UserData{Login: "ci-robot", Email: "ci-robot@users.noreply.github.com"}
+	s := &Server{
+		botUser: botUser,
+		gc:      c,
+		push:    func(forkName, newBranch string, force bool) error { return nil },
+		ghc:     ghc,
+		log:     logrus.StandardLogger().WithField("client", "cherrypicker"),
+		repos:   []github.Repo{{Fork: true, FullName: "ci-robot/bar"}},
+	}
+
+	if _, err := s.handleIssueComment(logrus.NewEntry(logrus.StandardLogger()), ic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var bases []string
+	for _, pr := range ghc.prs[1:] {
+		bases = append(bases, pr.Base.Ref)
+	}
+	if diff := cmp.Diff([]string{"release-1.26", "release-1.28"}, bases); diff != "" {
+		t.Errorf("unexpected cherry-pick PRs (-want +got):\n%s", diff)
+	}
+	expectedComments := []string{
+		"no branches other than the base branch match `release-2.*`.",
+		"new pull request created: #2",
+		fmt.Sprintf("Looks like #%d has already been cherry picked in", iNumber),
+		"new pull request created: #3",
+	}
+	if len(ghc.comments) != len(expectedComments) {
+		t.Fatalf("expected %d comments, got %d: %v", len(expectedComments), len(ghc.comments), ghc.comments)
+	}
+	for i, expected := range expectedComments {
+		if !strings.Contains(ghc.comments[i], expected) {
+			t.Errorf("expected comment %d to contain %q, got %q", i, expected, ghc.comments[i])
+		}
+	}
+}
+
+func TestCherryPickMergeCommitV2(t *testing.T) {
+	t.Parallel()
+	testCherryPickMergeCommit(localgit.NewV2, t)
+}
+
+func testCherryPickMergeCommit(clients localgit.Clients, t *testing.T) {
+	iNumber := fakePR.GetPRNumber()
+	lg, c := makeFakeRepoWithCommit(clients, t)
+	if err := lg.CheckoutNewBranch("foo", "bar", "stage"); err != nil {
+		t.Fatalf("Checking out target branch: %v", err)
+	}
+	// The PR contains a merge of master itself, so its patch can't be applied.
+	if err := lg.Checkout("foo", "bar", "master"); err != nil {
+		t.Fatalf("Checking out master: %v", err)
+	}
+	if err := lg.CheckoutNewBranch("foo", "bar", "feature"); err != nil {
+		t.Fatalf("Checking out feature branch: %v", err)
+	}
+	if err := lg.AddCommit("foo", "bar", map[string][]byte{"bar.go": []byte("package bar\n\n// Foo does a thing.\nfunc Foo(wow int) int {\n\treturn 49 + wow\n}\n")}); err != nil {
+		t.Fatalf("Adding feature commit: %v", err)
+	}
+	if err := lg.Checkout("foo", "bar", "master"); err != nil {
+		t.Fatalf("Checking out master: %v", err)
+	}
+	if err := lg.AddCommit("foo", "bar", map[string][]byte{"baz.go": []byte("package bar\n")}); err != nil {
+		t.Fatalf("Adding master commit: %v", err)
+	}
+	if _, err := lg.Merge("foo", "bar", "feature"); err != nil {
+		t.Fatalf("Merging feature branch: %v", err)
+	}
+	mergeSHA, err := lg.RevParse("foo", "bar", "HEAD")
+	if err != nil {
+		t.Fatalf("Getting merge commit: %v", err)
+	}
+	mergeSHA = strings.TrimSpace(mergeSHA)
+
+	ghc := &fghc{
+		pr: &github.PullRequest{
+			Base: github.PullRequestBranch{
+				Ref: "master",
+			},
+			Merged:   true,
+			MergeSHA: &mergeSHA,
+			Title:    "This is a fix for X",
+			Body:     body,
+		},
+		isMember: true,
+		patch:    []byte("not a patch"),
+	}
+	ic := github.IssueCommentEvent{
+		Action: github.IssueCommentActionCreated,
+		Repo: github.Repo{
+			Owner: github.User{
+				Login: "foo",
+			},
+			Name:     "bar",
+			FullName: "foo/bar",
+		},
+		Issue: github.Issue{
+			Number:      iNumber,
+			State:       "closed",
+			PullRequest: &struct{}{},
+		},
+		Comment: github.IssueComment{
+			User: github.User{
+				Login: "wiseguy",
+			},
+			Body: "/cherrypick stage",
+		},
+	}
+
+	botUser := &github.UserData{Login: "ci-robot", Email: "ci-robot@users.noreply.github.com"}
+	s := &Server{
+		botUser: botUser,
+		gc:      c,
+		push:    func(forkName, newBranch string, force bool) error { return nil },
+		ghc:     ghc,
+		log:     logrus.StandardLogger().WithField("client", "cherrypicker"),
+		repos:   []github.Repo{{Fork: true, FullName: "ci-robot/bar"}},
+	}
+
+	if _, err := s.handleIssueComment(logrus.NewEntry(logrus.StandardLogger()), ic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ghc.prs) != 1 {
+		t.Fatalf("Expected a cherry-pick PR to be created, got comments: %v", ghc.comments)
+	}
+	if ghc.prs[0].Draft {
+		t.Errorf("Expected the cherry-pick PR not to be a draft")
+	}
+}
+
+func TestCherryPickConflictDraftV2(t *testing.T) {
+	t.Parallel()
+	testCherryPickConflictDraft(localgit.NewV2, t)
+}
+
+func testCherryPickConflictDraft(clients localgit.Clients, t *testing.T) {
+	iNumber := fakePR.GetPRNumber()
+	lg, c := makeFakeRepoWithCommit(clients, t)
+	if err := lg.CheckoutNewBranch("foo", "bar", "stage"); err != nil {
+		t.Fatalf("Checking out target branch: %v", err)
+	}
+	if err := lg.AddCommit("foo", "bar", map[string][]byte{"bar.go": []byte("package bar\n\n// Foo does a thing.\nfunc Foo(wow int) int {\n\treturn 43 + wow\n}\n")}); err != nil {
+		t.Fatalf("Adding conflicting commit: %v", err)
+	}
+
+	ghc := &fghc{
+		pr: &github.PullRequest{
+			Base: github.PullRequestBranch{
+				Ref: "master",
+			},
+			Merged: true,
+			Title:  "This is a fix for X",
+			Body:   body,
+		},
+		isMember: true,
+		patch:    patch,
+	}
+	ic := github.IssueCommentEvent{
+		Action: github.IssueCommentActionCreated,
+		Repo: github.Repo{
+			Owner: github.User{
+				Login: "foo",
+			},
+			Name:     "bar",
+			FullName: "foo/bar",
+		},
+		Issue: github.Issue{
+			Number:      iNumber,
+			State:       "closed",
+			PullRequest: &struct{}{},
+		},
+		Comment: github.IssueComment{
+			User: github.User{
+				Login: "wiseguy",
+			},
+			Body: "/cherrypick stage",
+		},
+	}
+
+	botUser := &github.UserData{Login: "ci-robot", Email: "ci-robot@users.noreply.github.com"}
+	var pushed string
+	s := &Server{
+		botUser: botUser,
+		gc:      c,
+		push: func(forkName, newBranch string, force bool) error {
+			pushed = newBranch
+			return nil
+		},
+		ghc:   ghc,
+		log:   logrus.StandardLogger().WithField("client", "cherrypicker"),
+		repos: []github.Repo{{Fork: true, FullName: "ci-robot/bar"}},
+
+		draftOnConflict: true,
+	}
+
+	if _, err := s.handleIssueComment(logrus.NewEntry(logrus.StandardLogger()), ic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedBranch := fmt.Sprintf(cherryPickBranchFmt, iNumber, "stage")
+	if pushed != expectedBranch {
+		t.Errorf("Expected branch %q to be pushed, got %q", expectedBranch, pushed)
+	}
+	if len(ghc.prs) != 1 {
+		t.Fatalf("Expected a cherry-pick PR to be created, got comments: %v", ghc.comments)
+	}
+	pr := ghc.prs[0]
+	if !pr.Draft {
+		t.Errorf("Expected the cherry-pick PR to be a draft")
+	}
+	if !strings.Contains(pr.Body, "- `bar.go`") {
+		t.Errorf("Expected the PR body to list the conflicting file, got %q", pr.Body)
+	}
+	if !strings.Contains(pr.Body, fmt.Sprintf("This is an automated cherry-pick of #%d", iNumber)) {
+		t.Errorf("Expected the PR body to reference the original PR, got %q", pr.Body)
+	}
+	if len(ghc.comments) != 1 || !strings.Contains(ghc.comments[0], "new draft pull request created with the conflicts to resolve: #1") {
+		t.Errorf("Expected a comment linking the draft PR, got %v", ghc.comments)
+	}
+}
+
 func TestCherryPickPRV2(t *testing.T) {
 	t.Parallel()
 	testCherryPickPR(localgit.NewV2, t)
@@ -1143,13 +1443,13 @@ func TestHandleLocks(t *testing.T) {
 
 	go func() {
 		defer close(routine1Done)
-		if err := s.handle(l, "", &github.IssueComment{}, "org", "repo", "targetBranch", "baseBranch", []string{}, "title", "body", 0); err != nil {
+		if err := s.handle(l, "", &github.IssueComment{}, "org", "repo", "targetBranch", "baseBranch", []string{}, "title", "body", "", 0); err != nil {
 			t.Errorf("routine failed: %v", err)
 		}
 	}()
 	go func() {
 		defer close(routine2Done)
-		if err := s.handle(l, "", &github.IssueComment{}, "org", "repo", "targetBranch", "baseBranch", []string{}, "title", "body", 0); err != nil {
+		if err := s.handle(l, "", &github.IssueComment{}, "org", "repo", "targetBranch", "baseBranch", []string{}, "title", "body", "", 0); err != nil {
 			t.Errorf("routine failed: %v", err)
 		}
 	}()
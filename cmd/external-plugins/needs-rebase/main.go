@@ -50,6 +50,9 @@ type options struct {
 
 	updatePeriod time.Duration
 
+	pushBatchSize     int
+	pushBatchInterval time.Duration
+
 	webhookSecretFile string
 
 	cacheValidTime int
@@ -63,6 +66,12 @@ func (o *options) Validate() error {
 			return fmt.Errorf("%d: %w", idx, err)
 		}
 	}
+	if o.pushBatchSize < 1 {
+		return fmt.Errorf("--push-batch-size must be at least 1, got %d", o.pushBatchSize)
+	}
+	if o.pushBatchInterval < 0 {
+		return fmt.Errorf("--push-batch-interval must not be negative, got %s", o.pushBatchInterval)
+	}
 
 	return nil
 }
@@ -75,6 +84,8 @@ func gatherOptions() options {
 	fs.DurationVar(&o.updatePeriod, "update-period", time.Hour*24, "Period duration for periodic scans of all PRs.")
 	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
 	fs.StringVar(&o.logLevel, "log-level", "debug", fmt.Sprintf("Log level is one of %v.", logrus.AllLevels))
+	fs.IntVar(&o.pushBatchSize, "push-batch-size", 20, "Number of PRs whose mergeability is checked at once after a push to their base branch.")
+	fs.DurationVar(&o.pushBatchInterval, "push-batch-interval", time.Minute, "Duration to wait between batches of PRs checked after a push to their base branch.")
 	fs.IntVar(&o.cacheValidTime, "cache-valid-time", 0, "Do not re-check PR mergeability for comment events within this time (seconds)")
 
 	o.github.AddCustomizedFlags(fs, prowflagutil.ThrottlerDefaults(defaultHourlyTokens, defaultHourlyTokens))
@@ -122,6 +133,7 @@ func main() {
 		ghc:            githubClient,
 		log:            log,
 		issueCache:     issueCache,
+		pushHandler:    plugin.NewPushHandler(githubClient, o.pushBatchSize, o.pushBatchInterval),
 	}
 
 	defer interrupts.WaitForGracefulShutdown()
@@ -151,6 +163,7 @@ type Server struct {
 	ghc            github.Client
 	log            *logrus.Entry
 	issueCache     *plugin.Cache
+	pushHandler    *plugin.PushHandler
 }
 
 // ServeHTTP validates an incoming webhook and puts it into the event channel.
@@ -196,6 +209,16 @@ func (s *Server) handleEvent(eventType, eventGUID string, payload []byte) error
 				l.WithField("event-type", eventType).WithError(err).Info("Error handling event.")
 			}
 		}()
+	case "push":
+		var pe github.PushEvent
+		if err := json.Unmarshal(payload, &pe); err != nil {
+			return err
+		}
+		go func() {
+			if err := s.pushHandler.HandlePushEvent(l, &pe); err != nil {
+				l.WithField("event-type", eventType).WithError(err).Info("Error handling event.")
+			}
+		}()
 	default:
 		s.log.Debugf("received an event of type %q but didn't ask for it", eventType)
 	}
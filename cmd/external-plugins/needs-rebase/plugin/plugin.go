@@ -62,7 +62,8 @@ type githubClient interface {
 func HelpProvider(_ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
 	return &pluginhelp.PluginHelp{
 			Description: `The needs-rebase plugin manages the '` + labels.NeedsRebase + `' label by removing it from Pull Requests that are mergeable and adding it to those which are not.
-The plugin reacts to commit changes on PRs in addition to periodically scanning all open PRs for any changes to mergeability that could have resulted from changes in other PRs.`,
+The plugin reacts to commit changes on PRs in addition to periodically scanning all open PRs for any changes to mergeability that could have resulted from changes in other PRs.
+If the plugin receives push events, it also re-evaluates the open PRs of a branch in rate limited batches whenever the branch is pushed to.`,
 		},
 		nil
 }
@@ -210,6 +211,113 @@ func HandleAll(log *logrus.Entry, ghc githubClient, config *plugins.Configuratio
 	return nil
 }
 
+// PushHandler re-evaluates the mergeability of the open PRs of a branch when
+// the branch is pushed to, so the label doesn't lag behind until the next
+// periodic scan. Pushes to a branch whose PRs are still being re-evaluated
+// are coalesced into a single additional run.
+type PushHandler struct {
+	ghc           githubClient
+	batchSize     int
+	batchInterval time.Duration
+	sleep         func(time.Duration)
+
+	lock sync.Mutex
+	// running holds the branches whose PRs are being re-evaluated and whether
+	// they were pushed to again in the meantime.
+	running map[string]bool
+}
+
+// NewPushHandler returns a PushHandler that checks the mergeability of at most
+// batchSize PRs and then waits for batchInterval before checking the next
+// batch.
+func NewPushHandler(ghc githubClient, batchSize int, batchInterval time.Duration) *PushHandler {
+	return &PushHandler{
+		ghc:           ghc,
+		batchSize:     batchSize,
+		batchInterval: batchInterval,
+		sleep:         sleep,
+		running:       map[string]bool{},
+	}
+}
+
+// HandlePushEvent re-evaluates the open PRs of the branch the push event is
+// for. It blocks until the PRs are re-evaluated, or returns right away if they
+// are already being re-evaluated.
+func (h *PushHandler) HandlePushEvent(log *logrus.Entry, pe *github.PushEvent) error {
+	if pe.Deleted || !strings.HasPrefix(pe.Ref, "refs/heads/") {
+		return nil
+	}
+	org := pe.Repo.Owner.Login
+	repo := pe.Repo.Name
+	branch := pe.Branch()
+	key := fmt.Sprintf("%s/%s@%s", org, repo, branch)
+
+	h.lock.Lock()
+	if _, running := h.running[key]; running {
+		h.running[key] = true
+		h.lock.Unlock()
+		log.WithField("branch", key).Debug("PRs of the branch are already being re-evaluated, re-evaluating them again afterwards.")
+		return nil
+	}
+	h.running[key] = false
+	h.lock.Unlock()
+
+	var errs []error
+	for {
+		errs = append(errs, h.reevaluate(log.WithField("branch", key), org, repo, branch))
+
+		h.lock.Lock()
+		if !h.running[key] {
+			delete(h.running, key)
+			h.lock.Unlock()
+			return utilerrors.NewAggregate(errs)
+		}
+		h.running[key] = false
+		h.lock.Unlock()
+	}
+}
+
+// reevaluate checks the mergeability of all open PRs against the branch in
+// batches.
+func (h *PushHandler) reevaluate(log *logrus.Entry, org, repo, branch string) error {
+	// Give GitHub a chance to start recalculating mergeability.
+	h.sleep(time.Second * 5)
+
+	query := fmt.Sprintf(`%s repo:"%s/%s" base:"%s"`, searchQueryPrefix, org, repo, branch)
+	prs, err := search(context.Background(), log, h.ghc, query, org)
+	if err != nil {
+		return err
+	}
+	log.WithField("prs_found_count", len(prs)).Info("Re-evaluating PRs after a push to their base branch.")
+
+	var errs []error
+	for i, pr := range prs {
+		if i > 0 && i%h.batchSize == 0 {
+			h.sleep(h.batchInterval)
+		}
+		num := int(pr.Number)
+		l := log.WithField(github.PrLogField, num)
+		mergeable, err := h.ghc.IsMergeable(org, repo, num, string(pr.HeadRefOID))
+		if err != nil {
+			l.WithError(err).Error("Failed to determine mergeability.")
+			errs = append(errs, fmt.Errorf("failed to determine mergeability of %s/%s#%d: %w", org, repo, num, err))
+			continue
+		}
+		var hasLabel bool
+		for _, label := range pr.Labels.Nodes {
+			if label.Name == labels.NeedsRebase {
+				hasLabel = true
+				break
+			}
+		}
+		if err := takeAction(h.ghc, org, repo, num, string(pr.Author.Login), hasLabel, mergeable); err != nil {
+			l.WithError(err).Error("Error handling PR.")
+			errs = append(errs, fmt.Errorf("failed to handle %s/%s#%d: %w", org, repo, num, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 // takeAction adds or removes the "needs-rebase" label based on the current
 // state of the PR (hasLabel and mergeable). It also handles adding and
 // removing GitHub comments notifying the PR author that a rebase is needed.
@@ -318,8 +426,9 @@ type pullRequest struct {
 			Name githubql.String
 		}
 	} `graphql:"labels(first:100)"`
-	Mergeable githubql.MergeableState
-	State     githubql.PullRequestState
+	HeadRefOID githubql.String `graphql:"headRefOid"`
+	Mergeable  githubql.MergeableState
+	State      githubql.PullRequestState
 }
 
 // See: https://developer.github.com/v4/query/.
@@ -43,7 +43,8 @@ type fghc struct {
 	allPRs []struct {
 		PullRequest pullRequest `graphql:"... on PullRequest"`
 	}
-	pr *github.PullRequest
+	pr      *github.PullRequest
+	queries []string
 
 	initialLabels []github.Label
 	mergeable     bool
@@ -108,11 +109,12 @@ func (f *fghc) DeleteStaleCommentsWithContext(_ context.Context, org, repo strin
 	return nil
 }
 
-func (f *fghc) QueryWithGitHubAppsSupport(_ context.Context, q interface{}, _ map[string]interface{}, _ string) error {
+func (f *fghc) QueryWithGitHubAppsSupport(_ context.Context, q interface{}, vars map[string]interface{}, _ string) error {
 	query, ok := q.(*searchQuery)
 	if !ok {
 		return errors.New("invalid query format")
 	}
+	f.queries = append(f.queries, fmt.Sprint(vars["query"]))
 	query.Search.Nodes = f.allPRs
 	return nil
 }
@@ -425,6 +427,98 @@ func TestHandleAll(t *testing.T) {
 	}
 }
 
+func TestHandlePushEvent(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		ref       string
+		deleted   bool
+		running   bool
+		prCount   int
+		mergeable bool
+
+		expectedQueries []string
+		expectedAdded   []string
+		expectedSleeps  []time.Duration
+		expectRerun     bool
+	}{
+		{
+			name:    "tag push is ignored",
+			ref:     "refs/tags/v1.0.0",
+			prCount: 1,
+		},
+		{
+			name:    "branch deletion is ignored",
+			ref:     "refs/heads/feature",
+			deleted: true,
+			prCount: 1,
+		},
+		{
+			name:            "PRs of the branch are re-evaluated in batches",
+			ref:             "refs/heads/main",
+			prCount:         5,
+			expectedQueries: []string{`archived:false is:pr is:open repo:"org/repo" base:"main"`},
+			expectedAdded:   []string{labels.NeedsRebase},
+			expectedSleeps:  []time.Duration{5 * time.Second, time.Minute, time.Minute},
+		},
+		{
+			name:      "mergeable PRs are left alone",
+			ref:       "refs/heads/main",
+			prCount:   1,
+			mergeable: true,
+			expectedQueries: []string{
+				`archived:false is:pr is:open repo:"org/repo" base:"main"`,
+			},
+			expectedSleeps: []time.Duration{5 * time.Second},
+		},
+		{
+			name:        "push while the branch is re-evaluated is coalesced",
+			ref:         "refs/heads/main",
+			running:     true,
+			prCount:     1,
+			expectRerun: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var prs []pullRequest
+			for i := 0; i < tc.prCount; i++ {
+				pr := pullRequest{Number: githubql.Int(i), State: githubql.PullRequestStateOpen}
+				pr.Repository.Name = "repo"
+				pr.Repository.Owner.Login = "org"
+				prs = append(prs, pr)
+			}
+			fake := newFakeClient(prs, nil, tc.mergeable, nil)
+			h := NewPushHandler(fake, 2, time.Minute)
+			var sleeps []time.Duration
+			h.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+			const key = "org/repo@main"
+			if tc.running {
+				h.running[key] = false
+			}
+
+			pe := &github.PushEvent{Ref: tc.ref, Deleted: tc.deleted, Repo: github.Repo{Name: "repo", Owner: github.User{Login: "org"}}}
+			if err := h.HandlePushEvent(logrus.WithField("plugin", PluginName), pe); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expectedQueries, fake.queries); diff != "" {
+				t.Errorf("Unexpected queries (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedSleeps, sleeps); diff != "" {
+				t.Errorf("Unexpected sleeps (-want +got):\n%s", diff)
+			}
+			for i := 0; i < tc.prCount && len(tc.expectedQueries) > 0; i++ {
+				fake.compareExpected(t, "org", "repo", i, tc.expectedAdded, nil, len(tc.expectedAdded) > 0, false)
+			}
+			if rerun, running := h.running[key]; running != tc.running || rerun != tc.expectRerun {
+				t.Errorf("Expected branch running %t with rerun %t, got running %t with rerun %t", tc.running, tc.expectRerun, running, rerun)
+			}
+		})
+	}
+}
+
 func TestConstructQueries(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -24,6 +24,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"strings"
@@ -33,12 +34,15 @@ import (
 	"sigs.k8s.io/prow/cmd/generic-autobumper/updater"
 	"sigs.k8s.io/prow/pkg/config/secret"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/gitlab"
 )
 
 const (
 	forkRemoteName = "bumper-fork-remote"
 
-	defaultHeadBranchName = "autobump"
+	// DefaultHeadBranchName is the name of the branch the pull request is
+	// created from if HeadBranchName is unset.
+	DefaultHeadBranchName = "autobump"
 
 	gitCmd = "git"
 )
@@ -67,6 +71,8 @@ type Options struct {
 	Signoff bool `json:"signoff"`
 	// Information needed to do a gerrit bump. Do not include if doing github bump
 	Gerrit *Gerrit `json:"gerrit"`
+	// Information needed to do a GitLab bump. Do not include if doing github bump
+	GitLab *GitLab `json:"gitlab"`
 	// The name used in the address when creating remote. This should be the same name as the fork. If fork does not exist this will be the name of the fork that is created.
 	// If it is not the same as the fork, the robot will change the name of the fork to this. Format will be git@github.com:{GitLogin}/{RemoteName}.git
 	RemoteName string `json:"remoteName"`
@@ -90,6 +96,18 @@ type Gerrit struct {
 	HostRepo string `json:"hostRepo"`
 }
 
+// Information needed for GitLab bump
+type GitLab struct {
+	// The full path of the GitLab project the merge request is opened in, e.g. group/subgroup/repo. Required if using GitLab
+	Project string `json:"project"`
+	// The path to the GitLab access token file. The token needs the api and write_repository scopes. Required if using GitLab
+	Token string `json:"token"`
+	// The base URL of the GitLab API. If not specified, https://gitlab.com/api/v4 is used.
+	APIBaseURL string `json:"apiBaseURL"`
+	// The name of the branch the merge request targets. If not specified, will be autodetected via GitLab API.
+	TargetBranch string `json:"targetBranch"`
+}
+
 // PRHandler is the interface implemented by consumer of prcreator, for
 // manipulating the repo, and provides commit messages, PR title and body.
 type PRHandler interface {
@@ -147,7 +165,10 @@ func (gc GitCommand) getCommand() string {
 }
 
 func validateOptions(o *Options) error {
-	if !o.SkipPullRequest && o.Gerrit == nil {
+	if o.Gerrit != nil && o.GitLab != nil {
+		return fmt.Errorf("gerrit and gitlab can't be used together")
+	}
+	if !o.SkipPullRequest && o.Gerrit == nil && o.GitLab == nil {
 		if o.GitHubToken == "" {
 			return fmt.Errorf("gitHubToken is mandatory when skipPullRequest is false or unspecified")
 		}
@@ -175,9 +196,17 @@ func validateOptions(o *Options) error {
 			return fmt.Errorf("GerritCookieFile is required when skipPullRequest is false and Gerrit is true")
 		}
 	}
+	if !o.SkipPullRequest && o.GitLab != nil {
+		if o.GitLab.Project == "" {
+			return fmt.Errorf("GitLabProject is required when skipPullRequest is false and GitLab is used")
+		}
+		if o.GitLab.Token == "" {
+			return fmt.Errorf("GitLabToken is required when skipPullRequest is false and GitLab is used")
+		}
+	}
 	if !o.SkipPullRequest {
 		if o.HeadBranchName == "" {
-			o.HeadBranchName = defaultHeadBranchName
+			o.HeadBranchName = DefaultHeadBranchName
 		}
 	}
 
@@ -196,10 +225,42 @@ func Run(ctx context.Context, o *Options, prh PRHandler) error {
 	if o.SkipPullRequest {
 		logrus.Debugf("--skip-pull-request is set to true, won't create a pull request.")
 	}
-	if o.Gerrit == nil {
+	switch {
+	case o.Gerrit != nil:
+		return processGerrit(ctx, o, prh)
+	case o.GitLab != nil:
+		return processGitLab(ctx, o, prh)
+	default:
 		return processGitHub(ctx, o, prh)
 	}
-	return processGerrit(ctx, o, prh)
+}
+
+// commitChanges makes the changes and commits each of them. It returns
+// whether anything changed.
+func commitChanges(ctx context.Context, o *Options, prh PRHandler, stdout, stderr io.Writer) (bool, error) {
+	var anyChange bool
+	for i, changeFunc := range prh.Changes() {
+		msg, err := changeFunc(ctx)
+		if err != nil {
+			return false, fmt.Errorf("process function %d: %w", i, err)
+		}
+
+		changed, err := HasChanges()
+		if err != nil {
+			return false, fmt.Errorf("checking changes: %w", err)
+		}
+
+		if !changed {
+			logrus.WithField("function", i).Info("Nothing changed, skip commit ...")
+			continue
+		}
+
+		anyChange = true
+		if err := gitCommit(o.GitName, o.GitEmail, msg, stdout, stderr, o.Signoff); err != nil {
+			return false, fmt.Errorf("git commit: %w", err)
+		}
+	}
+	return anyChange, nil
 }
 
 func processGitHub(ctx context.Context, o *Options, prh PRHandler) error {
@@ -231,27 +292,9 @@ func processGitHub(ctx context.Context, o *Options, prh PRHandler) error {
 	}
 
 	// Make change, commit and push
-	var anyChange bool
-	for i, changeFunc := range prh.Changes() {
-		msg, err := changeFunc(ctx)
-		if err != nil {
-			return fmt.Errorf("process function %d: %w", i, err)
-		}
-
-		changed, err := HasChanges()
-		if err != nil {
-			return fmt.Errorf("checking changes: %w", err)
-		}
-
-		if !changed {
-			logrus.WithField("function", i).Info("Nothing changed, skip commit ...")
-			continue
-		}
-
-		anyChange = true
-		if err := gitCommit(o.GitName, o.GitEmail, msg, stdout, stderr, o.Signoff); err != nil {
-			return fmt.Errorf("git commit: %w", err)
-		}
+	anyChange, err := commitChanges(ctx, o, prh, stdout, stderr)
+	if err != nil {
+		return err
 	}
 	if !anyChange {
 		logrus.Info("Nothing changed from all functions, skip PR ...")
@@ -276,6 +319,93 @@ func processGitHub(ctx context.Context, o *Options, prh PRHandler) error {
 	return nil
 }
 
+func processGitLab(ctx context.Context, o *Options, prh PRHandler) error {
+	stdout := HideSecretsWriter{Delegate: os.Stdout, Censor: secret.Censor}
+	stderr := HideSecretsWriter{Delegate: os.Stderr, Censor: secret.Censor}
+	if err := secret.Add(o.GitLab.Token); err != nil {
+		return fmt.Errorf("start secrets agent: %w", err)
+	}
+	token := secret.GetTokenGenerator(o.GitLab.Token)
+	gc := gitlab.NewClient(o.GitLab.APIBaseURL, token)
+
+	// Make change, commit and push
+	anyChange, err := commitChanges(ctx, o, prh, stdout, stderr)
+	if err != nil {
+		return err
+	}
+	if !anyChange {
+		logrus.Info("Nothing changed from all functions, skip MR ...")
+		return nil
+	}
+
+	project, err := gc.GetProject(ctx, o.GitLab.Project)
+	if err != nil {
+		return fmt.Errorf("get GitLab project %s: %w", o.GitLab.Project, err)
+	}
+	remote, err := url.Parse(project.HTTPURLToRepo)
+	if err != nil {
+		return fmt.Errorf("parse the git URL of GitLab project %s: %w", o.GitLab.Project, err)
+	}
+	// GitLab accepts access tokens as the password of any user.
+	remote.User = url.UserPassword("oauth2", string(token()))
+	if err := MinimalGitPush(remote.String(), o.HeadBranchName, stdout, stderr, o.SkipPullRequest); err != nil {
+		return fmt.Errorf("push changes to the remote branch: %w", err)
+	}
+
+	summary, body := prh.PRTitleBody()
+	targetBranch := o.GitLab.TargetBranch
+	if targetBranch == "" {
+		targetBranch = project.DefaultBranch
+	}
+	if err := EnsureMergeRequest(ctx, gc, o.GitLab.Project, summary, generatePRBody(body, getAssignment(o.AssignTo)), o.HeadBranchName, targetBranch, o.Labels, o.SkipPullRequest); err != nil {
+		return fmt.Errorf("to create the MR: %w", err)
+	}
+	return nil
+}
+
+// MergeRequestClient is the part of the GitLab client used to ensure a
+// merge request.
+type MergeRequestClient interface {
+	ListOpenMergeRequests(ctx context.Context, project, sourceBranch, targetBranch string) ([]gitlab.MergeRequest, error)
+	CreateMergeRequest(ctx context.Context, project string, opts gitlab.MergeRequestOptions) (*gitlab.MergeRequest, error)
+	UpdateMergeRequest(ctx context.Context, project string, iid int, opts gitlab.MergeRequestOptions) (*gitlab.MergeRequest, error)
+}
+
+// EnsureMergeRequest updates the open merge request of GitLab project from
+// sourceBranch to targetBranch with "title", "body" and "labels", or creates
+// it if there is none.
+func EnsureMergeRequest(ctx context.Context, gc MergeRequestClient, project, title, body, sourceBranch, targetBranch string, labels []string, dryrun bool) error {
+	logrus.Info("Creating or updating MR...")
+	if dryrun {
+		logrus.Info("[Dryrun] ensure MR with:")
+		logrus.Info(project, title, body, sourceBranch, targetBranch, labels)
+		return nil
+	}
+	opts := gitlab.MergeRequestOptions{
+		Title:       title,
+		Description: body,
+		Labels:      strings.Join(labels, ","),
+	}
+	mrs, err := gc.ListOpenMergeRequests(ctx, project, sourceBranch, targetBranch)
+	if err != nil {
+		return fmt.Errorf("list merge requests: %w", err)
+	}
+	var mr *gitlab.MergeRequest
+	if len(mrs) > 0 {
+		if mr, err = gc.UpdateMergeRequest(ctx, project, mrs[0].IID, opts); err != nil {
+			return fmt.Errorf("update merge request !%d: %w", mrs[0].IID, err)
+		}
+	} else {
+		opts.SourceBranch = sourceBranch
+		opts.TargetBranch = targetBranch
+		if mr, err = gc.CreateMergeRequest(ctx, project, opts); err != nil {
+			return fmt.Errorf("create merge request: %w", err)
+		}
+	}
+	logrus.Infof("MR %s!%d will merge %s into %s: %s", project, mr.IID, sourceBranch, targetBranch, title)
+	return nil
+}
+
 func processGerrit(ctx context.Context, o *Options, prh PRHandler) error {
 	stdout := HideSecretsWriter{Delegate: os.Stdout, Censor: secret.Censor}
 	stderr := HideSecretsWriter{Delegate: os.Stderr, Censor: secret.Censor}
@@ -528,6 +658,13 @@ func MinimalGitPush(remote, remoteBranch string, stdout, stderr io.Writer, dryru
 	if err := Call(stdout, stderr, gitCmd, []string{"remote", "add", forkRemoteName, remote}, opts...); err != nil {
 		return fmt.Errorf("add remote: %w", err)
 	}
+	// The remote is removed again, so the same repository can be pushed to
+	// multiple branches.
+	defer func() {
+		if err := Call(stdout, stderr, gitCmd, []string{"remote", "remove", forkRemoteName}, opts...); err != nil {
+			logrus.WithError(err).Warn("Failed to remove remote.")
+		}
+	}()
 	fetchStderr := &bytes.Buffer{}
 	var remoteTreeRef string
 	if err := Call(stdout, fetchStderr, gitCmd, []string{"fetch", forkRemoteName, remoteBranch}, opts...); err != nil {
@@ -17,13 +17,17 @@ limitations under the License.
 package bumper
 
 import (
+	"context"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/gitlab"
 )
 
 func TestValidateOptions(t *testing.T) {
@@ -39,6 +43,9 @@ func TestValidateOptions(t *testing.T) {
 		gerritPRIdentifier  *string
 		gerritHostRepo      *string
 		gerritCookieFile    *string
+		gitLab              *bool
+		gitLabProject       *string
+		gitLabToken         *string
 		remoteName          *string
 		skipPullRequest     *bool
 		signoff             *bool
@@ -93,6 +100,30 @@ func TestValidateOptions(t *testing.T) {
 			gerritPRIdentifier: &emptyStr,
 			err:                true,
 		},
+		{
+			name:        "GitHubToken can be empty when gitLab is used",
+			gitLab:      &trueVar,
+			githubToken: &emptyStr,
+			err:         false,
+		},
+		{
+			name:          "gitLabProject cannot be empty when SkipPullRequest is false and gitLab is used",
+			gitLab:        &trueVar,
+			gitLabProject: &emptyStr,
+			err:           true,
+		},
+		{
+			name:        "gitLabToken cannot be empty when SkipPullRequest is false and gitLab is used",
+			gitLab:      &trueVar,
+			gitLabToken: &emptyStr,
+			err:         true,
+		},
+		{
+			name:   "gerrit and gitLab cannot be used together",
+			gerrit: &trueVar,
+			gitLab: &trueVar,
+			err:    true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -136,6 +167,18 @@ func TestValidateOptions(t *testing.T) {
 			if tc.gerrit != nil {
 				defaultOption.Gerrit = gerrit
 			}
+			if tc.gitLab != nil {
+				defaultOption.GitLab = &GitLab{
+					Project: "whatever-group/whatever-project",
+					Token:   "whatever-token",
+				}
+			}
+			if tc.gitLabProject != nil {
+				defaultOption.GitLab.Project = *tc.gitLabProject
+			}
+			if tc.gitLabToken != nil {
+				defaultOption.GitLab.Token = *tc.gitLabToken
+			}
 			if tc.gerritAuthor != nil {
 				defaultOption.Gerrit.Author = *tc.gerritAuthor
 			}
@@ -337,3 +380,82 @@ func TestCDToRootDir(t *testing.T) {
 		})
 	}
 }
+
+type fakeMergeRequestClient struct {
+	mrs     []gitlab.MergeRequest
+	created []gitlab.MergeRequestOptions
+	updated map[int]gitlab.MergeRequestOptions
+}
+
+func (f *fakeMergeRequestClient) ListOpenMergeRequests(_ context.Context, _, sourceBranch, targetBranch string) ([]gitlab.MergeRequest, error) {
+	var mrs []gitlab.MergeRequest
+	for _, mr := range f.mrs {
+		if mr.SourceBranch == sourceBranch && mr.TargetBranch == targetBranch {
+			mrs = append(mrs, mr)
+		}
+	}
+	return mrs, nil
+}
+
+func (f *fakeMergeRequestClient) CreateMergeRequest(_ context.Context, _ string, opts gitlab.MergeRequestOptions) (*gitlab.MergeRequest, error) {
+	f.created = append(f.created, opts)
+	return &gitlab.MergeRequest{IID: len(f.mrs) + len(f.created)}, nil
+}
+
+func (f *fakeMergeRequestClient) UpdateMergeRequest(_ context.Context, _ string, iid int, opts gitlab.MergeRequestOptions) (*gitlab.MergeRequest, error) {
+	if f.updated == nil {
+		f.updated = map[int]gitlab.MergeRequestOptions{}
+	}
+	f.updated[iid] = opts
+	return &gitlab.MergeRequest{IID: iid}, nil
+}
+
+func TestEnsureMergeRequest(t *testing.T) {
+	testCases := []struct {
+		name            string
+		mrs             []gitlab.MergeRequest
+		dryrun          bool
+		expectedCreated []gitlab.MergeRequestOptions
+		expectedUpdated map[int]gitlab.MergeRequestOptions
+	}{
+		{
+			name: "merge request is created",
+			mrs:  []gitlab.MergeRequest{{IID: 1, SourceBranch: "autobump", TargetBranch: "release"}},
+			expectedCreated: []gitlab.MergeRequestOptions{{
+				SourceBranch: "autobump",
+				TargetBranch: "main",
+				Title:        "Bump images",
+				Description:  "body",
+				Labels:       "area/config,skip-review",
+			}},
+		},
+		{
+			name: "open merge request is updated",
+			mrs:  []gitlab.MergeRequest{{IID: 2, SourceBranch: "autobump", TargetBranch: "main"}},
+			expectedUpdated: map[int]gitlab.MergeRequestOptions{2: {
+				Title:       "Bump images",
+				Description: "body",
+				Labels:      "area/config,skip-review",
+			}},
+		},
+		{
+			name:   "dryrun does nothing",
+			dryrun: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := &fakeMergeRequestClient{mrs: tc.mrs}
+			if err := EnsureMergeRequest(context.Background(), gc, "group/project", "Bump images", "body", "autobump", "main", []string{"area/config", "skip-review"}, tc.dryrun); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedCreated, gc.created); diff != "" {
+				t.Errorf("unexpected created merge requests (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedUpdated, gc.updated); diff != "" {
+				t.Errorf("unexpected updated merge requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -41,6 +41,11 @@ func tagFromName(name string) string {
 	return parts[1]
 }
 
+// Strip the digest from a pinned tag, i.e. tag@sha256:...
+func tagWithoutDigest(tag string) string {
+	return strings.SplitN(tag, "@", 2)[0]
+}
+
 // Extract prow component name from image
 func componentFromName(name string) string {
 	s := strings.SplitN(strings.Split(name, ":")[0], "/", 3)
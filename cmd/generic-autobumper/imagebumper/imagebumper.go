@@ -17,28 +17,36 @@ limitations under the License.
 package imagebumper
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 var (
-	imageRegexp = regexp.MustCompile(`\b((?:[a-z0-9]+\.)?gcr\.io|(?:[a-z0-9-]+)?docker\.pkg\.dev)/([a-z][a-z0-9-]{5,29}/[a-zA-Z0-9][a-zA-Z0-9_./-]+):([a-zA-Z0-9_.-]+)\b`)
-	tagRegexp   = regexp.MustCompile(`(v?\d{8}-(?:v\d(?:[.-]\d+)*-g)?[0-9a-f]{6,10}|latest)(-.+)?`)
+	imageRegexp  = regexp.MustCompile(`\b((?:[a-z0-9]+\.)?gcr\.io|(?:[a-z0-9-]+)?docker\.pkg\.dev)/([a-z][a-z0-9-]{5,29}/[a-zA-Z0-9][a-zA-Z0-9_./-]+):([a-zA-Z0-9_.-]+)\b(?:@(sha256:[a-f0-9]{64})\b)?`)
+	digestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+	tagRegexp    = regexp.MustCompile(`(v?\d{8}-(?:v\d(?:[.-]\d+)*-g)?[0-9a-f]{6,10}|latest)(-.+)?`)
 )
 
 const (
-	imageHostPart  = 1
-	imageImagePart = 2
-	imageTagPart   = 3
-	tagVersionPart = 1
-	tagExtraPart   = 2
+	imageHostPart   = 1
+	imageImagePart  = 2
+	imageTagPart    = 3
+	imageDigestPart = 4
+	tagVersionPart  = 1
+	tagExtraPart    = 2
 )
 
 type Client struct {
@@ -220,12 +228,46 @@ func pickBestTag(currentTagParts []string, manifest manifest) (string, error) {
 	return latestTag, nil
 }
 
+// manifestMediaTypes are the media types of the manifests that are accepted
+// when resolving digests. Manifest lists and indexes are preferred, so the
+// digest is the same for all platforms.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ResolveDigest returns the digest of the manifest the given tag of the image
+// points at.
+func (cli *Client) ResolveDigest(imageHost, imageName, tag string) (string, error) {
+	req, err := http.NewRequest(http.MethodHead, "https://"+imageHost+"/v2/"+imageName+"/manifests/"+tag, nil)
+	if err != nil {
+		return "", fmt.Errorf("couldn't create manifest request: %w", err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	resp, err := cli.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("couldn't fetch manifest: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("couldn't fetch manifest of %s:%s: %s", imageName, tag, resp.Status)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !digestRegexp.MatchString(digest) {
+		return "", fmt.Errorf("registry returned invalid digest %q for %s:%s", digest, imageName, tag)
+	}
+	return digest, nil
+}
+
 // AddToCache keeps track of changed tags
 func (cli *Client) AddToCache(image, newTag string) {
 	cli.tagCache[image] = newTag
 }
 
 func updateAllTags(tagPicker func(host, image, tag string) (string, error), content []byte, imageFilter *regexp.Regexp) []byte {
+	content = updateSplitTags(tagPicker, content, imageFilter)
 	indexes := imageRegexp.FindAllSubmatchIndex(content, -1)
 	// Not finding any images is not an error.
 	if indexes == nil {
@@ -252,6 +294,12 @@ func updateAllTags(tagPicker func(host, image, tag string) (string, error), cont
 			newContent = append(newContent, content[m[imageTagPart*2]:m[1]]...)
 			continue
 		}
+		// A pinned digest only stays if the tag is unchanged, the digest of
+		// another tag is stale.
+		if latest == tag {
+			newContent = append(newContent, content[m[imageTagPart*2]:m[1]]...)
+			continue
+		}
 		newContent = append(newContent, []byte(latest)...)
 	}
 	newContent = append(newContent, content[lastIndex:]...)
@@ -259,6 +307,135 @@ func updateAllTags(tagPicker func(host, image, tag string) (string, error), cont
 	return newContent
 }
 
+// splitTag is the tag of an image which is set in a different field than
+// the image itself.
+type splitTag struct {
+	image string
+	tag   *yaml.Node
+}
+
+// findSplitTags finds the tags of images which are split across fields of
+// the same YAML mapping, like in Helm values:
+//
+//	image:
+//	  registry: gcr.io
+//	  repository: k8s-prow/hook
+//	  tag: v20190404-12345678
+//
+// and in kustomize image overrides:
+//
+//	images:
+//	- name: hook
+//	  newName: gcr.io/k8s-prow/hook
+//	  newTag: v20190404-12345678
+func findSplitTags(node *yaml.Node) []splitTag {
+	var tags []splitTag
+	if node.Kind == yaml.MappingNode {
+		fields := map[string]*yaml.Node{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if value := node.Content[i+1]; value.Kind == yaml.ScalarNode {
+				fields[node.Content[i].Value] = value
+			}
+		}
+		value := func(key string) string {
+			if field, ok := fields[key]; ok {
+				return field.Value
+			}
+			return ""
+		}
+		switch {
+		case fields["repository"] != nil && fields["tag"] != nil:
+			image := value("repository")
+			if registry := value("registry"); registry != "" {
+				image = registry + "/" + image
+			}
+			tags = append(tags, splitTag{image: image, tag: fields["tag"]})
+		case fields["newTag"] != nil && (fields["newName"] != nil || fields["name"] != nil):
+			image := value("newName")
+			if image == "" {
+				image = value("name")
+			}
+			tags = append(tags, splitTag{image: image, tag: fields["newTag"]})
+		}
+	}
+	for _, child := range node.Content {
+		tags = append(tags, findSplitTags(child)...)
+	}
+	return tags
+}
+
+// updateSplitTags updates the tags of images which are split across fields.
+// Content which is not YAML is returned as is.
+func updateSplitTags(tagPicker func(host, image, tag string) (string, error), content []byte, imageFilter *regexp.Regexp) []byte {
+	var tags []splitTag
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if !errors.Is(err, io.EOF) {
+				// Not finding any images is not an error.
+				return content
+			}
+			break
+		}
+		tags = append(tags, findSplitTags(&document)...)
+	}
+	if len(tags) == 0 {
+		return content
+	}
+
+	lineOffsets := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			lineOffsets = append(lineOffsets, i+1)
+		}
+	}
+	type replacement struct {
+		offset int
+		old    string
+		new    string
+	}
+	var replacements []replacement
+	for _, t := range tags {
+		m := imageRegexp.FindStringSubmatch(t.image + ":" + t.tag.Value)
+		if m == nil || m[0] != t.image+":"+t.tag.Value {
+			continue
+		}
+		host, image, tag := m[imageHostPart], m[imageImagePart], m[imageTagPart]
+		old := tag
+		if digest := m[imageDigestPart]; digest != "" {
+			old += "@" + digest
+		}
+		if imageFilter != nil && !imageFilter.MatchString(host+"/"+image+":"+tag) {
+			continue
+		}
+		latest, err := tagPicker(host, image, tag)
+		if err != nil {
+			log.Printf("Failed to update %s/%s:%s: %v.\n", host, image, tag, err)
+			continue
+		}
+		if latest == tag {
+			continue
+		}
+		// The column of a quoted scalar points at the quote, so the tag is
+		// searched from there.
+		start := lineOffsets[t.tag.Line-1] + t.tag.Column - 1
+		index := bytes.Index(content[start:], []byte(old))
+		if index < 0 {
+			continue
+		}
+		replacements = append(replacements, replacement{offset: start + index, old: old, new: latest})
+	}
+
+	// Replace from the end, so the offsets of earlier tags stay valid.
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].offset > replacements[j].offset })
+	newContent := append([]byte{}, content...)
+	for _, r := range replacements {
+		newContent = append(newContent[:r.offset], append([]byte(r.new), newContent[r.offset+len(r.old):]...)...)
+	}
+	return newContent
+}
+
 // UpdateFile updates a file in place.
 func (cli *Client) UpdateFile(tagPicker func(imageHost, imageName, currentTag string) (string, error),
 	path string, imageFilter *regexp.Regexp) error {
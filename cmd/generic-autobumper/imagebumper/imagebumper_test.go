@@ -18,7 +18,10 @@ package imagebumper
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -355,6 +358,80 @@ func TestUpdateAllTags(t *testing.T) {
 			},
 			imageFilter: regexp.MustCompile("gcr.io/k8s-testimages"),
 		},
+		{
+			name:           "helm values with the tag in a separate field are updated",
+			content:        "image:\n  repository: gcr.io/k8s-testimages/some-image\n  tag: v20190404-12345678\n",
+			expectedResult: "image:\n  repository: gcr.io/k8s-testimages/some-image\n  tag: v20190405-123456789\n",
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190405-123456789",
+			},
+		},
+		{
+			name:           "helm values with a separate registry and a quoted tag are updated",
+			content:        "hook:\n  image:\n    tag: \"v20190404-12345678\" # keep me\n    registry: gcr.io\n    repository: k8s-testimages/some-image\n",
+			expectedResult: "hook:\n  image:\n    tag: \"v20190405-123456789\" # keep me\n    registry: gcr.io\n    repository: k8s-testimages/some-image\n",
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190405-123456789",
+			},
+		},
+		{
+			name:           "kustomize image overrides are updated",
+			content:        "images:\n- name: gcr.io/k8s-testimages/some-image\n  newTag: v20190404-12345678\n- name: hook\n  newName: gcr.io/k8s-testimages/other-image\n  newTag: v20190404-12345678\n",
+			expectedResult: "images:\n- name: gcr.io/k8s-testimages/some-image\n  newTag: v20190405-123456789\n- name: hook\n  newName: gcr.io/k8s-testimages/other-image\n  newTag: v20190406-abcdef123\n",
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678":  "v20190405-123456789",
+				"gcr.io/k8s-testimages/other-image:v20190404-12345678": "v20190406-abcdef123",
+			},
+		},
+		{
+			name:           "split tags not matching the filter regex are not updated",
+			content:        "image:\n  repository: gcr.io/k8s-prow/pkg-thing\n  tag: v20190404-12345678\n",
+			expectedResult: "image:\n  repository: gcr.io/k8s-prow/pkg-thing\n  tag: v20190404-12345678\n",
+			newTags: map[string]string{
+				"gcr.io/k8s-prow/pkg-thing:v20190404-12345678": "v20190405-123456789",
+			},
+			imageFilter: regexp.MustCompile("gcr.io/k8s-testimages"),
+		},
+		{
+			name:           "image is pinned to a digest",
+			content:        `{"image": "gcr.io/k8s-testimages/some-image:v20190404-12345678"}`,
+			expectedResult: `{"image": "gcr.io/k8s-testimages/some-image:v20190405-123456789@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}`,
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190405-123456789@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			},
+		},
+		{
+			name:           "pinned digest is replaced",
+			content:        `{"image": "gcr.io/k8s-testimages/some-image:v20190404-12345678@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}`,
+			expectedResult: `{"image": "gcr.io/k8s-testimages/some-image:v20190405-123456789@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}`,
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190405-123456789@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			},
+		},
+		{
+			name:           "pinned digest stays if the tag is unchanged",
+			content:        `{"image": "gcr.io/k8s-testimages/some-image:v20190404-12345678@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}`,
+			expectedResult: `{"image": "gcr.io/k8s-testimages/some-image:v20190404-12345678@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}`,
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190404-12345678",
+			},
+		},
+		{
+			name:           "pinned digest of a split tag is replaced",
+			content:        "image:\n  repository: gcr.io/k8s-testimages/some-image\n  tag: \"v20190404-12345678@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"\n",
+			expectedResult: "image:\n  repository: gcr.io/k8s-testimages/some-image\n  tag: \"v20190405-123456789@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb\"\n",
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190405-123456789@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			},
+		},
+		{
+			name:           "split tags and full image references in the same file are updated",
+			content:        "---\nimage:\n  repository: gcr.io/k8s-testimages/some-image\n  tag: v20190404-12345678\n---\nimage: gcr.io/k8s-testimages/some-image:v20190404-12345678\n",
+			expectedResult: "---\nimage:\n  repository: gcr.io/k8s-testimages/some-image\n  tag: v20190405-123456789\n---\nimage: gcr.io/k8s-testimages/some-image:v20190405-123456789\n",
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190405-123456789",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -374,3 +451,61 @@ func TestUpdateAllTags(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveDigest(t *testing.T) {
+	const digest = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("Expected a HEAD request, got %s", r.Method)
+		}
+		if !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+			t.Errorf("Expected image indexes to be accepted, got %q", r.Header.Get("Accept"))
+		}
+		switch r.URL.Path {
+		case "/v2/k8s-prow/hook/manifests/v20190404-12345678":
+			w.Header().Set("Docker-Content-Digest", digest)
+		case "/v2/k8s-prow/hook/manifests/invalid":
+			w.Header().Set("Docker-Content-Digest", "md5:abc")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		tag            string
+		expectedDigest string
+		expectError    bool
+	}{
+		{
+			name:           "digest is resolved",
+			tag:            "v20190404-12345678",
+			expectedDigest: digest,
+		},
+		{
+			name:        "unknown tag is an error",
+			tag:         "v20190405-12345678",
+			expectError: true,
+		},
+		{
+			name:        "invalid digest is an error",
+			tag:         "invalid",
+			expectError: true,
+		},
+	}
+
+	cli := NewClient(server.Client())
+	host := strings.TrimPrefix(server.URL, "https://")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			digest, err := cli.ResolveDigest(host, "k8s-prow/hook", test.tag)
+			if test.expectError != (err != nil) {
+				t.Fatalf("Expected error to be %t, but got %v", test.expectError, err)
+			}
+			if digest != test.expectedDigest {
+				t.Errorf("Expected digest %q, got %q", test.expectedDigest, digest)
+			}
+		})
+	}
+}
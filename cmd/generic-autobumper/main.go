@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -34,6 +35,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/prow/cmd/generic-autobumper/bumper"
 	"sigs.k8s.io/prow/cmd/generic-autobumper/imagebumper"
@@ -45,6 +47,7 @@ const (
 	latestVersion           = "latest"
 	upstreamVersion         = "upstream"
 	upstreamStagingVersion  = "upstream-staging"
+	digestVersion           = "digest"
 	tagVersion              = "vYYYYMMDD-deadbeef"
 	defaultUpstreamURLBase  = "https://raw.githubusercontent.com/kubernetes/test-infra/master"
 	googleImageRegistryAuth = "google"
@@ -56,8 +59,9 @@ const (
 )
 
 var (
-	tagRegexp    = regexp.MustCompile("v[0-9]{8}-[a-f0-9]{6,9}")
-	imageMatcher = regexp.MustCompile(`(?s)^.+image:(.+):(v[a-zA-Z0-9_.-]+)`)
+	tagRegexp           = regexp.MustCompile("v[0-9]{8}-[a-f0-9]{6,9}")
+	imageMatcher        = regexp.MustCompile(`(?s)^.+image:(.+):(v[a-zA-Z0-9_.-]+)`)
+	branchNameSanitizer = regexp.MustCompile(`[^a-z0-9]+`)
 )
 
 var _ bumper.PRHandler = (*client)(nil)
@@ -123,8 +127,12 @@ type options struct {
 	ExcludedConfigPaths []string `yaml:"excludedConfigPaths"`
 	// The extra non-yaml file to be considered in this bump.
 	ExtraFiles []string `yaml:"extraFiles"`
-	// The target version to bump images version to, which can be one of latest, upstream, upstream-staging and vYYYYMMDD-deadbeef.
+	// The target version to bump images version to, which can be one of latest, upstream, upstream-staging, digest and vYYYYMMDD-deadbeef.
+	// digest bumps images like latest, but also pins the references to the digest of the tag, i.e. image:tag@sha256:...
 	TargetVersion string `yaml:"targetVersion"`
+	// Cosign verifies the signatures of images with cosign before they are pinned. Requires targetVersion to be digest
+	// and the cosign binary to be on the PATH.
+	Cosign *cosignOptions `yaml:"cosign"`
 	// List of prefixes that the autobumped is looking for, and other information needed to bump them. Must have at least 1 prefix.
 	Prefixes []prefix `yaml:"prefixes"`
 	// The oncall address where we can get the JSON file that stores the current oncall information.
@@ -147,6 +155,21 @@ type options struct {
 	ImageRegistryAuth string `yaml:"imageRegistryAuth"`
 	// AdditionalPRBody allows for generic, additional content in the body of the PR
 	AdditionalPRBody string `yaml:"additionalPRBody"`
+	// SplitPRsPerPrefix opens one PR per prefix rather than a single PR for all of them, so the bumps
+	// can be merged and reverted separately. The PR of a prefix is created from the headBranchName
+	// suffixed with the name of the prefix, e.g. autobump-boskos.
+	SplitPRsPerPrefix bool `yaml:"splitPRsPerPrefix"`
+}
+
+// cosignOptions is the information needed to verify image signatures with cosign.
+// Either Key, or CertificateIdentityRegexp and CertificateOIDCIssuer for keyless signatures, must be set.
+type cosignOptions struct {
+	// The path or KMS URI of the public key the images are signed with.
+	Key string `yaml:"key"`
+	// A regexp the identity in the certificate of keyless signatures must match.
+	CertificateIdentityRegexp string `yaml:"certificateIdentityRegexp"`
+	// The OIDC issuer of the certificate of keyless signatures, e.g. https://accounts.google.com.
+	CertificateOIDCIssuer string `yaml:"certificateOIDCIssuer"`
 }
 
 // prefix is the information needed for each prefix being bumped.
@@ -174,12 +197,16 @@ func parseOptions() (*options, *bumper.Options, error) {
 	var labelsOverride []string
 	var skipPullRequest bool
 	var signoff bool
+	var gitLabToken string
+	var gitLabAPIBaseURL string
 
 	var o options
 	flag.StringVar(&config, "config", "", "The path to the config file for the autobumber.")
 	flag.StringSliceVar(&labelsOverride, "labels-override", nil, "Override labels to be added to PR.")
 	flag.BoolVar(&skipPullRequest, "skip-pullrequest", false, "")
 	flag.BoolVar(&signoff, "signoff", false, "Signoff the commits.")
+	flag.StringVar(&gitLabToken, "gitlab-token-path", "", "The path to the GitLab token file. Opens a GitLab merge request instead of a GitHub pull request if set.")
+	flag.StringVar(&gitLabAPIBaseURL, "gitlab-api-base-url", "", "The base URL of the GitLab API, e.g. https://gitlab.example.com/api/v4. Defaults to https://gitlab.com/api/v4.")
 	flag.BoolVar(&o.SkipIfNoOncall, "skip-if-no-oncall", false, "Don't run anything if no oncall is discovered")
 	flag.Parse()
 
@@ -200,6 +227,17 @@ func parseOptions() (*options, *bumper.Options, error) {
 	if labelsOverride != nil {
 		pro.Labels = labelsOverride
 	}
+	if gitLabToken != "" || gitLabAPIBaseURL != "" {
+		if pro.GitLab == nil {
+			pro.GitLab = &bumper.GitLab{}
+		}
+		if gitLabToken != "" {
+			pro.GitLab.Token = gitLabToken
+		}
+		if gitLabAPIBaseURL != "" {
+			pro.GitLab.APIBaseURL = gitLabAPIBaseURL
+		}
+	}
 	if o.OncallGroup == "" {
 		o.OncallGroup = defaultOncallGroup
 	}
@@ -221,8 +259,8 @@ func validateOptions(o *options) error {
 		return errors.New("includedConfigPaths is mandatory")
 	}
 	if o.TargetVersion != latestVersion && o.TargetVersion != upstreamVersion &&
-		o.TargetVersion != upstreamStagingVersion && !tagRegexp.MatchString(o.TargetVersion) {
-		logrus.WithField("allowed", []string{latestVersion, upstreamVersion, upstreamStagingVersion, tagVersion}).Warn(
+		o.TargetVersion != upstreamStagingVersion && o.TargetVersion != digestVersion && !tagRegexp.MatchString(o.TargetVersion) {
+		logrus.WithField("allowed", []string{latestVersion, upstreamVersion, upstreamStagingVersion, digestVersion, tagVersion}).Warn(
 			"Warning: targetVersion mot in allowed so it might not work properly.")
 	}
 	if o.TargetVersion == upstreamVersion {
@@ -244,6 +282,30 @@ func validateOptions(o *options) error {
 		logrus.Warnf("targetVersion can't be 'upstream' or 'upstreamStaging` without upstreamURLBase set. Default upstreamURLBase is %q", defaultUpstreamURLBase)
 	}
 
+	if o.SplitPRsPerPrefix {
+		seen := map[string]string{}
+		for _, prefix := range o.Prefixes {
+			branch := prefixBranchName("", prefix.Name)
+			if other, ok := seen[branch]; ok {
+				return fmt.Errorf("splitPRsPerPrefix requires distinct prefix names, %q and %q would use the same branch", other, prefix.Name)
+			}
+			seen[branch] = prefix.Name
+		}
+	}
+
+	if o.Cosign != nil {
+		if o.TargetVersion != digestVersion {
+			return fmt.Errorf("cosign requires targetVersion to be %q", digestVersion)
+		}
+		keyless := o.Cosign.CertificateIdentityRegexp != "" || o.Cosign.CertificateOIDCIssuer != ""
+		if o.Cosign.Key != "" && keyless {
+			return errors.New("cosign.key can't be used together with cosign.certificateIdentityRegexp and cosign.certificateOIDCIssuer")
+		}
+		if o.Cosign.Key == "" && (o.Cosign.CertificateIdentityRegexp == "" || o.Cosign.CertificateOIDCIssuer == "") {
+			return errors.New("cosign requires either key, or certificateIdentityRegexp and certificateOIDCIssuer")
+		}
+	}
+
 	if o.ImageRegistryAuth != "" && o.ImageRegistryAuth != googleImageRegistryAuth {
 		return fmt.Errorf("imageRegistryAuth has incorrect value: %q. Only \"\" and %q are allowed", o.ImageRegistryAuth, googleImageRegistryAuth)
 	}
@@ -340,6 +402,7 @@ type imageBumper interface {
 	GetReplacements() map[string]string
 	AddToCache(image, newTag string)
 	TagExists(imageHost, imageName, currentTag string) (bool, error)
+	ResolveDigest(imageHost, imageName, tag string) (string, error)
 }
 
 func updateReferences(imageBumperCli imageBumper, filterRegexp *regexp.Regexp, o *options) (map[string]string, error) {
@@ -353,6 +416,8 @@ func updateReferences(imageBumperCli imageBumper, filterRegexp *regexp.Regexp, o
 		if tagPicker, err = upstreamImageVersionResolver(o, o.TargetVersion, parseUpstreamImageVersion, imageBumperCli); err != nil {
 			return nil, fmt.Errorf("failed to resolve the %s image version: %w", o.TargetVersion, err)
 		}
+	case digestVersion:
+		tagPicker = digestResolver(imageBumperCli, cosignVerifier(o.Cosign))
 	default:
 		tagPicker = func(imageHost, imageName, currentTag string) (string, error) { return o.TargetVersion, nil }
 	}
@@ -428,6 +493,68 @@ func upstreamImageVersionResolver(
 	}, nil
 }
 
+// used by updateReferences
+func digestResolver(imageBumperCli imageBumper, verify func(image string) error) func(imageHost, imageName, currentTag string) (string, error) {
+	// FindLatestTag caches the latest tag, which is replaced by the pinned
+	// reference, so the references are resolved only once here.
+	resolved := map[string]string{}
+	return func(imageHost, imageName, currentTag string) (string, error) {
+		imageFullPath := imageHost + "/" + imageName + ":" + currentTag
+		if ref, ok := resolved[imageFullPath]; ok {
+			return ref, nil
+		}
+		ref, err := func() (string, error) {
+			tag, err := imageBumperCli.FindLatestTag(imageHost, imageName, currentTag)
+			if err != nil {
+				return "", err
+			}
+			digest, err := imageBumperCli.ResolveDigest(imageHost, imageName, tag)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve the digest of %s/%s:%s: %w", imageHost, imageName, tag, err)
+			}
+			if verify != nil {
+				if err := verify(imageHost + "/" + imageName + "@" + digest); err != nil {
+					return "", fmt.Errorf("failed to verify the signature of %s/%s:%s: %w", imageHost, imageName, tag, err)
+				}
+			}
+			return tag + "@" + digest, nil
+		}()
+		if err != nil {
+			// The reference stays as is.
+			imageBumperCli.AddToCache(imageFullPath, currentTag)
+			return "", err
+		}
+		imageBumperCli.AddToCache(imageFullPath, ref)
+		resolved[imageFullPath] = ref
+		return ref, nil
+	}
+}
+
+// cosignVerifier returns a function verifying the signature of an image
+// with cosign, or nil if signatures aren't verified.
+func cosignVerifier(c *cosignOptions) func(image string) error {
+	if c == nil {
+		return nil
+	}
+	return func(image string) error {
+		logrus.WithField("image", image).Info("Verifying signature")
+		if out, err := exec.Command("cosign", cosignVerifyArgs(c, image)...).CombinedOutput(); err != nil {
+			return fmt.Errorf("cosign verify: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+}
+
+func cosignVerifyArgs(c *cosignOptions, image string) []string {
+	args := []string{"verify"}
+	if c.Key != "" {
+		args = append(args, "--key", c.Key)
+	} else {
+		args = append(args, "--certificate-identity-regexp", c.CertificateIdentityRegexp, "--certificate-oidc-issuer", c.CertificateOIDCIssuer)
+	}
+	return append(args, image)
+}
+
 // used by upstreamImageVersionResolver
 func upstreamConfigVersions(upstreamVersionType string, o *options, parse func(upstreamAddress, prefix string) (string, error)) (versions map[string]string, err error) {
 	versions = make(map[string]string)
@@ -486,6 +613,9 @@ func getVersionsAndCheckConsistency(prefixes []prefix, images map[string]string)
 		exceptions := sets.NewString(prefix.ConsistentImageExceptions...)
 		var consistencyVersion, consistencySourceImage string
 		for k, v := range images {
+			// The digests of pinned references differ between images, only
+			// the tags need to be consistent.
+			v = tagWithoutDigest(v)
 			if strings.HasPrefix(k, prefix.Prefix) {
 				image := imageFromName(k)
 				if prefix.ConsistentImages && !exceptions.Has(image) {
@@ -556,6 +686,7 @@ func generateSummary(name, repo, prefix string, summarise bool, images map[strin
 		if !strings.HasPrefix(image, prefix) {
 			continue
 		}
+		newTag = tagWithoutDigest(newTag)
 		if strings.HasSuffix(image, ":"+newTag) {
 			continue
 		}
@@ -620,7 +751,53 @@ func main() {
 		logrus.WithError(err).Fatalf("Failed validating flags")
 	}
 
-	if err := bumper.Run(ctx, pro, &client{o: o}); err != nil {
+	if o.SplitPRsPerPrefix {
+		err = runPerPrefix(ctx, o, pro)
+	} else {
+		err = bumper.Run(ctx, pro, &client{o: o})
+	}
+	if err != nil {
 		logrus.WithError(err).Fatalf("failed to run the bumper tool")
 	}
 }
+
+// runPerPrefix runs the bumper for each prefix separately, so each prefix is
+// bumped in its own PR from its own branch.
+func runPerPrefix(ctx context.Context, o *options, pro *bumper.Options) error {
+	if pro.Gerrit != nil {
+		return errors.New("splitPRsPerPrefix is not supported with gerrit")
+	}
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get the current commit: %w", err)
+	}
+	start := strings.TrimSpace(string(out))
+	headBranch := pro.HeadBranchName
+	if headBranch == "" {
+		headBranch = bumper.DefaultHeadBranchName
+	}
+
+	var errs []error
+	for _, p := range o.Prefixes {
+		prefixOptions := *o
+		prefixOptions.Prefixes = []prefix{p}
+		prefixBumperOptions := *pro
+		prefixBumperOptions.HeadBranchName = prefixBranchName(headBranch, p.Name)
+		logrus.WithFields(logrus.Fields{"prefix": p.Name, "branch": prefixBumperOptions.HeadBranchName}).Info("Bumping prefix")
+		if err := bumper.Run(ctx, &prefixBumperOptions, &client{o: &prefixOptions}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to bump %s: %w", p.Name, err))
+		}
+		// Every prefix is bumped on top of the same commit, so the PRs
+		// don't contain the bumps of each other.
+		if err := bumper.Call(os.Stdout, os.Stderr, "git", []string{"reset", "--hard", start}); err != nil {
+			return fmt.Errorf("failed to reset to %s: %w", start, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// prefixBranchName returns the name of the branch the PR of the prefix with
+// the given name is created from.
+func prefixBranchName(headBranch, name string) string {
+	return headBranch + "-" + strings.Trim(branchNameSanitizer.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
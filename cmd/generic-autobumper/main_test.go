@@ -187,14 +187,21 @@ func TestValidateOptions(t *testing.T) {
 		RefConfigFile:        "ref",
 		StagingRefConfigFile: "stagingRef",
 	}}
+	duplicateNamePrefixes := []prefix{
+		{Name: "Prow", Prefix: "gcr.io/k8s-prow/"},
+		{Name: "prow", Prefix: "gcr.io/k8s-staging-prow/"},
+	}
 	upstreamVersion := "upstream"
 	stagingVersion := "upstream-staging"
+	digestVersion := "digest"
 	cases := []struct {
 		name                string
 		targetVersion       *string
 		includeConfigPaths  *[]string
 		prefixes            *[]prefix
 		upstreamURLBase     *string
+		cosign              *cosignOptions
+		splitPRsPerPrefix   bool
 		err                 bool
 		upstreamBaseChanged bool
 	}{
@@ -271,6 +278,46 @@ func TestValidateOptions(t *testing.T) {
 			err:                 false,
 			upstreamBaseChanged: false,
 		},
+		{
+			name:              "PRs can be split per prefix",
+			splitPRsPerPrefix: true,
+			err:               false,
+		},
+		{
+			name:              "PRs can't be split per prefix if the prefixes would use the same branch",
+			prefixes:          &duplicateNamePrefixes,
+			splitPRsPerPrefix: true,
+			err:               true,
+		},
+		{
+			name:          "cosign can verify signatures with a key",
+			targetVersion: &digestVersion,
+			cosign:        &cosignOptions{Key: "cosign.pub"},
+			err:           false,
+		},
+		{
+			name:          "cosign can verify keyless signatures",
+			targetVersion: &digestVersion,
+			cosign:        &cosignOptions{CertificateIdentityRegexp: ".*@example.com", CertificateOIDCIssuer: "https://accounts.google.com"},
+			err:           false,
+		},
+		{
+			name:   "cosign requires digest target version",
+			cosign: &cosignOptions{Key: "cosign.pub"},
+			err:    true,
+		},
+		{
+			name:          "cosign keyless signatures require an issuer",
+			targetVersion: &digestVersion,
+			cosign:        &cosignOptions{CertificateIdentityRegexp: ".*@example.com"},
+			err:           true,
+		},
+		{
+			name:          "cosign key can't be used with keyless signatures",
+			targetVersion: &digestVersion,
+			cosign:        &cosignOptions{Key: "cosign.pub", CertificateIdentityRegexp: ".*@example.com", CertificateOIDCIssuer: "https://accounts.google.com"},
+			err:           true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -293,6 +340,8 @@ func TestValidateOptions(t *testing.T) {
 			if tc.upstreamURLBase != nil {
 				defaultOption.UpstreamURLBase = *tc.upstreamURLBase
 			}
+			defaultOption.Cosign = tc.cosign
+			defaultOption.SplitPRsPerPrefix = tc.splitPRsPerPrefix
 
 			err := validateOptions(defaultOption)
 			t.Logf("err is: %v", err)
@@ -343,6 +392,13 @@ func (cli *fakeImageBumperCli) TagExists(imageHost, imageName, currentTag string
 	return true, nil
 }
 
+func (cli *fakeImageBumperCli) ResolveDigest(imageHost, imageName, tag string) (string, error) {
+	if strings.HasSuffix(imageName, "DNE") {
+		return "", errors.New("manifest unknown")
+	}
+	return "sha256:fake-digest-of-" + tag, nil
+}
+
 func TestUpdateReferences(t *testing.T) {
 	tmpDir := t.TempDir()
 	for dir, fps := range map[string][]string{
@@ -438,6 +494,17 @@ func TestUpdateReferences(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			description:   "update the images to the digest of the latest version",
+			targetVersion: digestVersion,
+			includeConfigPaths: []string{
+				path.Join(tmpDir, "testdata/dir/subdir2"),
+			},
+			expectedRes: map[string]string{
+				path.Join(tmpDir, "testdata/dir/subdir2/test2-1.yaml"): "fake-latest@sha256:fake-digest-of-fake-latest",
+			},
+			expectError: false,
+		},
 		{
 			description:   "updating non-existed files will return an error",
 			targetVersion: latestVersion,
@@ -456,7 +523,7 @@ func TestUpdateReferences(t *testing.T) {
 				ExtraFiles:          tc.extraFiles,
 				ExcludedConfigPaths: tc.excludeConfigPaths,
 			}
-			cli := &fakeImageBumperCli{replacements: map[string]string{}}
+			cli := &fakeImageBumperCli{replacements: map[string]string{}, tagCache: map[string]string{}}
 			res, err := updateReferences(cli, nil, option)
 			if tc.expectError && err == nil {
 				t.Errorf("Expected to get an error but the result is nil")
@@ -723,6 +790,123 @@ func TestUpstreamImageVersionResolver(t *testing.T) {
 	}
 }
 
+func TestPrefixBranchName(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{
+			name:     "Boskos",
+			expected: "autobump-boskos",
+		},
+		{
+			name:     "Prow-Test-Images",
+			expected: "autobump-prow-test-images",
+		},
+		{
+			name:     " Prow (staging) ",
+			expected: "autobump-prow-staging",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if branch := prefixBranchName("autobump", tc.name); branch != tc.expected {
+				t.Errorf("Expected branch %q but got %q", tc.expected, branch)
+			}
+		})
+	}
+}
+
+func TestDigestResolver(t *testing.T) {
+	cases := []struct {
+		description      string
+		imageName        string
+		verifyErr        error
+		expectedRef      string
+		expectedCache    string
+		expectError      bool
+		expectedVerified []string
+	}{
+		{
+			description:      "the latest tag is pinned to its digest",
+			imageName:        "hook",
+			expectedRef:      "fake-latest@sha256:fake-digest-of-fake-latest",
+			expectedCache:    "fake-latest@sha256:fake-digest-of-fake-latest",
+			expectedVerified: []string{"gcr.io/k8s-prow/hook@sha256:fake-digest-of-fake-latest"},
+		},
+		{
+			description:   "the reference stays if the digest can't be resolved",
+			imageName:     "DNE",
+			expectedCache: "v20200101-deadbeef",
+			expectError:   true,
+		},
+		{
+			description:      "the reference stays if the signature can't be verified",
+			imageName:        "hook",
+			verifyErr:        errors.New("no matching signatures"),
+			expectedCache:    "v20200101-deadbeef",
+			expectError:      true,
+			expectedVerified: []string{"gcr.io/k8s-prow/hook@sha256:fake-digest-of-fake-latest"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cli := &fakeImageBumperCli{replacements: map[string]string{}, tagCache: map[string]string{}}
+			var verified []string
+			resolver := digestResolver(cli, func(image string) error {
+				verified = append(verified, image)
+				return tc.verifyErr
+			})
+			// The second call is answered from the cache.
+			for i := 0; i < 2; i++ {
+				ref, err := resolver("gcr.io", "k8s-prow/"+tc.imageName, "v20200101-deadbeef")
+				if tc.expectError != (err != nil) {
+					t.Fatalf("Expected error to be %t, but got %v", tc.expectError, err)
+				}
+				if ref != tc.expectedRef {
+					t.Errorf("Expected to get reference %q but got %q", tc.expectedRef, ref)
+				}
+			}
+			if cached := cli.tagCache["gcr.io/k8s-prow/"+tc.imageName+":v20200101-deadbeef"]; cached != tc.expectedCache {
+				t.Errorf("Expected the cache to have %q but got %q", tc.expectedCache, cached)
+			}
+			if tc.expectError {
+				// Failures are retried for every reference.
+				tc.expectedVerified = append(tc.expectedVerified, tc.expectedVerified...)
+			}
+			if diff := cmp.Diff(tc.expectedVerified, verified); diff != "" {
+				t.Errorf("Unexpected verified images (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCosignVerifyArgs(t *testing.T) {
+	cases := []struct {
+		description  string
+		cosign       *cosignOptions
+		expectedArgs []string
+	}{
+		{
+			description:  "key",
+			cosign:       &cosignOptions{Key: "gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k"},
+			expectedArgs: []string{"verify", "--key", "gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k", "gcr.io/k8s-prow/hook@sha256:abc"},
+		},
+		{
+			description:  "keyless",
+			cosign:       &cosignOptions{CertificateIdentityRegexp: ".*@example.com", CertificateOIDCIssuer: "https://accounts.google.com"},
+			expectedArgs: []string{"verify", "--certificate-identity-regexp", ".*@example.com", "--certificate-oidc-issuer", "https://accounts.google.com", "gcr.io/k8s-prow/hook@sha256:abc"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expectedArgs, cosignVerifyArgs(tc.cosign, "gcr.io/k8s-prow/hook@sha256:abc")); diff != "" {
+				t.Errorf("Unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUpstreamConfigVersions(t *testing.T) {
 	prowProdFakeVersion := "v-prow-prod-version"
 	prowStagingFakeVersion := "v-prow-staging-version"
@@ -120,6 +120,9 @@ func (o *options) validate() error {
 	if strings.HasPrefix(o.lastSyncFallback, "s3://") && !o.storage.HasS3Credentials() {
 		logrus.WithField("last-sync-fallback", o.lastSyncFallback).Info("--s3-credentials-file unset, will try and access with auto-discovered credentials")
 	}
+	if strings.HasPrefix(o.lastSyncFallback, "abs://") && !o.storage.HasAzureCredentials() {
+		logrus.WithField("last-sync-fallback", o.lastSyncFallback).Info("--azure-credentials-file unset, will try and access with default Azure credentials")
+	}
 	if o.changeWorkerPoolSize < 1 {
 		return errors.New("change-worker-pool-size must be at least 1")
 	}
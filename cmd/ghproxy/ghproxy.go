@@ -93,7 +93,7 @@ type options struct {
 	sizeGB                                 int
 	diskCacheDisableAuthHeaderPartitioning bool
 
-	redisAddress string
+	redisAddresses flagutil.Strings
 
 	port           int
 	upstream       string
@@ -142,7 +142,7 @@ func flagOptions() *options {
 	flag.StringVar(&o.dir, "cache-dir", "", "Directory to cache to if using a disk cache.")
 	flag.IntVar(&o.sizeGB, "cache-sizeGB", 0, "Cache size in GB per unique token if using a disk cache.")
 	flag.BoolVar(&o.diskCacheDisableAuthHeaderPartitioning, "legacy-disable-disk-cache-partitions-by-auth-header", true, "Whether to disable partitioning a disk cache by auth header. Disabling this will start a new cache at $cache_dir/$sha256sum_of_authorization_header for each unique authorization header. Bigger setups are advise to manually warm this up from an existing cache. This option will be removed and set to `false` in the future")
-	flag.StringVar(&o.redisAddress, "redis-address", "", "Redis address if using a redis cache e.g. localhost:6379.")
+	flag.Var(&o.redisAddresses, "redis-address", "Redis address if using a redis cache e.g. localhost:6379. Can be passed multiple times to distribute the cache over several Redis servers.")
 	flag.IntVar(&o.port, "port", 8888, "Port to listen on.")
 	flag.StringVar(&o.upstream, "upstream", "https://api.github.com", "Scheme, host, and base path of reverse proxy upstream.")
 	flag.IntVar(&o.maxConcurrency, "concurrency", 25, "Maximum number of concurrent in-flight requests to GitHub.")
@@ -201,8 +201,8 @@ func main() {
 func proxy(o *options, upstreamTransport http.RoundTripper, diskCachePruneInterval time.Duration) http.Handler {
 	var cache http.RoundTripper
 	throttlingTimes := ghcache.NewRequestThrottlingTimes(o.requestThrottlingTime, o.requestThrottlingTimeV4, o.requestThrottlingTimeForGET, o.requestThrottlingMaxDelayTime, o.requestThrottlingMaxDelayTimeV4)
-	if o.redisAddress != "" {
-		cache = ghcache.NewRedisCache(apptokenequalizer.New(upstreamTransport), o.redisAddress, o.maxConcurrency, throttlingTimes)
+	if redisAddresses := o.redisAddresses.Strings(); len(redisAddresses) > 0 {
+		cache = ghcache.NewRedisCache(apptokenequalizer.New(upstreamTransport), redisAddresses, o.maxConcurrency, throttlingTimes)
 	} else if o.dir == "" {
 		cache = ghcache.NewMemCache(apptokenequalizer.New(upstreamTransport), o.maxConcurrency, throttlingTimes)
 	} else {
@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+	"sigs.k8s.io/prow/pkg/secretstore"
+)
+
+// The reasons why a webhook drifted from the managed_webhooks config.
+const (
+	driftMissing       = "missing"
+	driftInactive      = "inactive"
+	driftEvents        = "events"
+	driftSecretRemoved = "secret_removed"
+	driftTokenChanged  = "token_changed"
+)
+
+var hmacMetrics = struct {
+	managedWebhooks  prometheus.Gauge
+	drifts           *prometheus.CounterVec
+	repairErrors     prometheus.Counter
+	syncErrors       prometheus.Counter
+	syncDuration     prometheus.Gauge
+	lastSuccessfulAt prometheus.Gauge
+}{
+	managedWebhooks: prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hmac_managed_webhooks",
+		Help: "Number of orgs and repos in the managed_webhooks config.",
+	}),
+	drifts: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hmac_webhook_drifts_total",
+		Help: "Number of webhooks found to have drifted from the managed_webhooks config, by reason.",
+	}, []string{"reason"}),
+	repairErrors: prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hmac_webhook_repair_errors_total",
+		Help: "Number of drifted webhooks that failed to be repaired.",
+	}),
+	syncErrors: prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hmac_sync_errors_total",
+		Help: "Number of syncs that failed.",
+	}),
+	syncDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hmac_sync_duration_seconds",
+		Help: "Time used by the latest sync.",
+	}),
+	lastSuccessfulAt: prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hmac_last_successful_sync_timestamp_seconds",
+		Help: "Unix timestamp of the latest successful sync.",
+	}),
+}
+
+func init() {
+	prometheus.MustRegister(hmacMetrics.managedWebhooks)
+	prometheus.MustRegister(hmacMetrics.drifts)
+	prometheus.MustRegister(hmacMetrics.repairErrors)
+	prometheus.MustRegister(hmacMetrics.syncErrors)
+	prometheus.MustRegister(hmacMetrics.syncDuration)
+	prometheus.MustRegister(hmacMetrics.lastSuccessfulAt)
+}
+
+// controller keeps reconciling the hmac tokens and webhooks with the
+// managed_webhooks config, and repairs the webhooks that drifted from it,
+// e.g. because they were deleted or their secret was changed on GitHub or in
+// the cluster.
+type controller struct {
+	options          options
+	secretStore      secretstore.Store
+	githubHookClient github.HookClient
+	config           config.Getter
+
+	// appliedTokens are the tokens that the webhooks were last set up with by
+	// this controller, by org or org/repo. Webhooks whose token is unknown are
+	// set up again, so all of them are once the controller starts.
+	appliedTokens map[string]string
+}
+
+func runController(o options, store secretstore.Store, gc github.HookClient, configAgent *config.Agent) {
+	defer interrupts.WaitForGracefulShutdown()
+
+	pprof.Instrument(o.instrumentationOptions)
+	metrics.ExposeMetrics("hmac", configAgent.Config().PushGateway, o.instrumentationOptions.MetricsPort)
+
+	c := &controller{
+		options:          o,
+		secretStore:      store,
+		githubHookClient: gc,
+		config:           configAgent.Config,
+		appliedTokens:    map[string]string{},
+	}
+	changes := make(chan config.Delta)
+	configAgent.Subscribe(changes)
+	interrupts.Run(func(ctx context.Context) {
+		c.run(ctx, changes)
+	})
+}
+
+// run syncs once the controller starts, on every change of the
+// managed_webhooks config and every resync period.
+func (c *controller) run(ctx context.Context, changes <-chan config.Delta) {
+	ticker := time.NewTicker(c.options.resyncPeriod)
+	defer ticker.Stop()
+	c.syncAndRecord()
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Info("Stop signal received, quitting.")
+			return
+		case <-ticker.C:
+		case delta := <-changes:
+			if reflect.DeepEqual(delta.Before.ManagedWebhooks, delta.After.ManagedWebhooks) {
+				continue
+			}
+			logrus.Info("The managed_webhooks config changed, syncing.")
+		}
+		c.syncAndRecord()
+	}
+}
+
+func (c *controller) syncAndRecord() {
+	start := time.Now()
+	err := c.sync()
+	hmacMetrics.syncDuration.Set(time.Since(start).Seconds())
+	if err != nil {
+		hmacMetrics.syncErrors.Inc()
+		logrus.WithError(err).Error("Error syncing hmac tokens and webhooks.")
+		return
+	}
+	hmacMetrics.lastSuccessfulAt.SetToCurrentTime()
+	logrus.Infof("Sync time: %v", time.Since(start))
+}
+
+// sync reconciles the hmac tokens and webhooks with the current config, then
+// repairs the webhooks that drifted from it.
+func (c *controller) sync() error {
+	newHMACConfig := c.config().ManagedWebhooks
+	hmacMetrics.managedWebhooks.Set(float64(len(newHMACConfig.OrgRepoConfig)))
+
+	cl, err := newClient(c.options, c.secretStore, c.githubHookClient, newHMACConfig)
+	if err != nil {
+		return err
+	}
+	if err := cl.handleInvitation(); err != nil {
+		return fmt.Errorf("error accepting invitations: %w", err)
+	}
+	if err := cl.handleConfigUpdate(); err != nil {
+		// The tokens in memory may not match the secret anymore, so they
+		// can't be used to repair webhooks.
+		return fmt.Errorf("error handling hmac config update: %w", err)
+	}
+	return c.repairDrift(cl)
+}
+
+// repairDrift sets up the webhooks that drifted from the config again, with
+// the latest token of their org or repo.
+func (c *controller) repairDrift(cl *client) error {
+	var orgRepos []string
+	for orgRepo := range cl.newHMACConfig.OrgRepoConfig {
+		orgRepos = append(orgRepos, orgRepo)
+	}
+	sort.Strings(orgRepos)
+
+	var errs []error
+	for _, orgRepo := range orgRepos {
+		token := latestToken(cl.currentHMACMap[orgRepo])
+		if token == "" {
+			continue
+		}
+		// The webhook was just set up with a new token.
+		if generated, ok := cl.hmacMapForBatchUpdate[orgRepo]; ok && generated == token {
+			c.appliedTokens[orgRepo] = token
+			continue
+		}
+
+		log := logrus.WithField("org-repo", orgRepo)
+		reason, err := c.drift(orgRepo, token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error checking the webhook of %q for drift: %w", orgRepo, err))
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+		hmacMetrics.drifts.WithLabelValues(reason).Inc()
+		log.WithField("reason", reason).Info("Repairing webhook that drifted from the config.")
+		if err := cl.onboardNewTokenForRepo(orgRepo, token); err != nil {
+			hmacMetrics.repairErrors.Inc()
+			errs = append(errs, fmt.Errorf("error repairing the webhook of %q: %w", orgRepo, err))
+			continue
+		}
+		c.appliedTokens[orgRepo] = token
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// drift returns why the webhook of the org or repo drifted from the config, or
+// an empty string if it didn't.
+func (c *controller) drift(orgRepo, token string) (string, error) {
+	var hooks []github.Hook
+	var err error
+	if org, repo, isRepo := strings.Cut(orgRepo, "/"); isRepo {
+		hooks, err = c.githubHookClient.ListRepoHooks(org, repo)
+	} else {
+		hooks, err = c.githubHookClient.ListOrgHooks(org)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var hook *github.Hook
+	for i := range hooks {
+		if hooks[i].Config.URL == c.options.hookUrl {
+			hook = &hooks[i]
+			break
+		}
+	}
+	switch {
+	case hook == nil:
+		return driftMissing, nil
+	case !hook.Active:
+		return driftInactive, nil
+	case !sets.New[string](hook.Events...).Equal(sets.New[string](github.AllHookEvents...)):
+		return driftEvents, nil
+	// GitHub masks the secret, so it can only tell whether one is set.
+	case hook.Config.Secret == nil || *hook.Config.Secret == "":
+		return driftSecretRemoved, nil
+	case c.appliedTokens[orgRepo] != token:
+		return driftTokenChanged, nil
+	}
+	return "", nil
+}
+
+// latestToken returns the value of the most recently created token.
+func latestToken(tokens github.HMACsForRepo) string {
+	var latest *github.HMACToken
+	for i := range tokens {
+		if latest == nil || tokens[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &tokens[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Value
+}
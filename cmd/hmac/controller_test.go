@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/cmd/hmac/fakeghhook"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestRepairDrift(t *testing.T) {
+	const hookURL = "http://whatever-hook-url"
+	contentType := "json"
+	hook := func(secret string, active bool, events ...string) github.Hook {
+		return github.Hook{
+			Name:   "web",
+			Active: active,
+			Events: events,
+			Config: github.HookConfig{
+				URL:         hookURL,
+				ContentType: &contentType,
+				Secret:      &secret,
+			},
+		}
+	}
+	now := time.Now()
+	tokens := func(values ...string) github.HMACsForRepo {
+		var hmacs github.HMACsForRepo
+		for i, value := range values {
+			hmacs = append(hmacs, github.HMACToken{Value: value, CreatedAt: now.Add(time.Duration(i) * time.Minute)})
+		}
+		return hmacs
+	}
+
+	cases := []struct {
+		name                  string
+		currentHMACMap        map[string]github.HMACsForRepo
+		hmacMapForBatchUpdate map[string]string
+		appliedTokens         map[string]string
+		currentOrgHooks       map[string][]github.Hook
+		currentRepoHooks      map[string][]github.Hook
+		expectedOrgHooks      map[string][]github.Hook
+		expectedRepoHooks     map[string][]github.Hook
+		expectedAppliedTokens map[string]string
+	}{
+		{
+			name:                  "webhook in sync is left alone",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org/repo": tokens("token")},
+			appliedTokens:         map[string]string{"org/repo": "token"},
+			currentRepoHooks:      map[string][]github.Hook{"org/repo": {hook("token", true, github.AllHookEvents...)}},
+			expectedRepoHooks:     map[string][]github.Hook{"org/repo": {hook("token", true, github.AllHookEvents...)}},
+			expectedAppliedTokens: map[string]string{"org/repo": "token"},
+		},
+		{
+			name:                  "deleted webhook is created again",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org": tokens("token")},
+			appliedTokens:         map[string]string{"org": "token"},
+			currentOrgHooks:       map[string][]github.Hook{},
+			expectedOrgHooks:      map[string][]github.Hook{"org": {hook("token", true, github.AllHookEvents...)}},
+			expectedAppliedTokens: map[string]string{"org": "token"},
+		},
+		{
+			name:                  "inactive webhook is activated",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org/repo": tokens("token")},
+			appliedTokens:         map[string]string{"org/repo": "token"},
+			currentRepoHooks:      map[string][]github.Hook{"org/repo": {hook("token", false, github.AllHookEvents...)}},
+			expectedRepoHooks:     map[string][]github.Hook{"org/repo": {hook("token", true, github.AllHookEvents...)}},
+			expectedAppliedTokens: map[string]string{"org/repo": "token"},
+		},
+		{
+			name:                  "webhook missing events receives all of them again",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org/repo": tokens("token")},
+			appliedTokens:         map[string]string{"org/repo": "token"},
+			currentRepoHooks:      map[string][]github.Hook{"org/repo": {hook("token", true, "push")}},
+			expectedRepoHooks:     map[string][]github.Hook{"org/repo": {hook("token", true, github.AllHookEvents...)}},
+			expectedAppliedTokens: map[string]string{"org/repo": "token"},
+		},
+		{
+			name:                  "webhook without secret gets the token again",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org/repo": tokens("token")},
+			appliedTokens:         map[string]string{"org/repo": "token"},
+			currentRepoHooks:      map[string][]github.Hook{"org/repo": {hook("", true, github.AllHookEvents...)}},
+			expectedRepoHooks:     map[string][]github.Hook{"org/repo": {hook("token", true, github.AllHookEvents...)}},
+			expectedAppliedTokens: map[string]string{"org/repo": "token"},
+		},
+		{
+			name:                  "webhook gets the latest token if it changed",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org/repo": tokens("old-token", "new-token")},
+			appliedTokens:         map[string]string{"org/repo": "old-token"},
+			currentRepoHooks:      map[string][]github.Hook{"org/repo": {hook("old-token", true, github.AllHookEvents...)}},
+			expectedRepoHooks:     map[string][]github.Hook{"org/repo": {hook("new-token", true, github.AllHookEvents...)}},
+			expectedAppliedTokens: map[string]string{"org/repo": "new-token"},
+		},
+		{
+			name:                  "webhook with unknown token gets the token again",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org/repo": tokens("token")},
+			appliedTokens:         map[string]string{},
+			currentRepoHooks:      map[string][]github.Hook{"org/repo": {hook("rotated-token", true, github.AllHookEvents...)}},
+			expectedRepoHooks:     map[string][]github.Hook{"org/repo": {hook("token", true, github.AllHookEvents...)}},
+			expectedAppliedTokens: map[string]string{"org/repo": "token"},
+		},
+		{
+			name:                  "webhook that was just set up is not checked",
+			currentHMACMap:        map[string]github.HMACsForRepo{"org/repo": tokens("token")},
+			hmacMapForBatchUpdate: map[string]string{"org/repo": "token"},
+			appliedTokens:         map[string]string{},
+			expectedAppliedTokens: map[string]string{"org/repo": "token"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fakeghhook.FakeClient{
+				OrgHooks:  tc.currentOrgHooks,
+				RepoHooks: tc.currentRepoHooks,
+			}
+			newHMACConfig := config.ManagedWebhooks{OrgRepoConfig: map[string]config.ManagedWebhookInfo{}}
+			for orgRepo := range tc.currentHMACMap {
+				newHMACConfig.OrgRepoConfig[orgRepo] = config.ManagedWebhookInfo{}
+			}
+			o := options{hookUrl: hookURL}
+			cl := &client{
+				options:               o,
+				githubHookClient:      fakeClient,
+				currentHMACMap:        tc.currentHMACMap,
+				newHMACConfig:         newHMACConfig,
+				hmacMapForBatchUpdate: tc.hmacMapForBatchUpdate,
+			}
+			c := &controller{
+				options:          o,
+				githubHookClient: fakeClient,
+				appliedTokens:    tc.appliedTokens,
+			}
+			if err := c.repairDrift(cl); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedOrgHooks, fakeClient.OrgHooks); diff != "" {
+				t.Errorf("unexpected org hooks (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedRepoHooks, fakeClient.RepoHooks); diff != "" {
+				t.Errorf("unexpected repo hooks (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedAppliedTokens, c.appliedTokens); diff != "" {
+				t.Errorf("unexpected applied tokens (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
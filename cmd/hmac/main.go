@@ -29,11 +29,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
-	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/prow/pkg/config"
@@ -43,6 +39,7 @@ import (
 	"sigs.k8s.io/prow/pkg/ghhook"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/secretstore"
 )
 
 type options struct {
@@ -57,16 +54,22 @@ type options struct {
 	hmacTokenSecretNamespace string
 	hmacTokenSecretName      string
 	hmacTokenKey             string
+	secretStore              secretstore.Options
+
+	runAsController        bool
+	resyncPeriod           time.Duration
+	instrumentationOptions prowflagutil.InstrumentationOptions
 }
 
 func (o *options) validate() error {
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.config} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.config, &o.secretStore} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
 	}
 
-	if o.kubeconfigCtx == "" {
+	backend := secretstore.Backend(o.secretStore.Backend)
+	if o.kubeconfigCtx == "" && backend == secretstore.BackendKubernetes {
 		return errors.New("required flag --kubeconfig-context was unset")
 	}
 	if o.hookUrl == "" {
@@ -75,9 +78,12 @@ func (o *options) validate() error {
 	if o.hmacTokenSecretName == "" {
 		return errors.New("required flag --hmac-token-secret-name was unset")
 	}
-	if o.hmacTokenKey == "" {
+	if o.hmacTokenKey == "" && (backend == secretstore.BackendKubernetes || backend == secretstore.BackendVault) {
 		return errors.New("required flag --hmac-token-key was unset")
 	}
+	if o.resyncPeriod <= 0 {
+		return errors.New("--resync-period must be positive")
+	}
 
 	return nil
 }
@@ -88,14 +94,18 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	o.config.AddFlags(fs)
 	o.github.AddFlags(fs)
 	o.kubernetes.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
+	o.secretStore.AddFlags(fs)
 
 	fs.StringVar(&o.kubeconfigCtx, "kubeconfig-context", "", "Context of the Prow component cluster and namespace in the kubeconfig.")
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
 
 	fs.StringVar(&o.hookUrl, "hook-url", "", "Prow hook external webhook URL (e.g. https://prow.k8s.io/hook).")
 	fs.StringVar(&o.hmacTokenSecretNamespace, "hmac-token-secret-namespace", "default", "Name of the namespace on the cluster where the hmac-token secret is in.")
-	fs.StringVar(&o.hmacTokenSecretName, "hmac-token-secret-name", "", "Name of the secret on the cluster containing the GitHub HMAC secret.")
-	fs.StringVar(&o.hmacTokenKey, "hmac-token-key", "", "Key of the hmac token in the secret.")
+	fs.StringVar(&o.hmacTokenSecretName, "hmac-token-secret-name", "", "Name of the secret containing the GitHub HMAC secret, on the cluster or in the secret manager of --secret-backend.")
+	fs.StringVar(&o.hmacTokenKey, "hmac-token-key", "", "Key of the hmac token in the secret. Only used by the kubernetes and vault secret backends.")
+	fs.BoolVar(&o.runAsController, "run-as-controller", false, "If true, keep running and reconcile the webhooks on every change of the managed_webhooks config and every resync period, repairing webhooks that drifted from the config.")
+	fs.DurationVar(&o.resyncPeriod, "resync-period", time.Hour, "How often to check the webhooks for drift when running as a controller.")
 	fs.Parse(args)
 	return o
 }
@@ -103,7 +113,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 type client struct {
 	options options
 
-	kubernetesClient kubernetes.Interface
+	secretStore      secretstore.Store
 	githubHookClient github.HookClient
 
 	currentHMACMap map[string]github.HMACsForRepo
@@ -121,25 +131,58 @@ func main() {
 		logrus.WithError(err).Fatal("Invalid options")
 	}
 
-	kc, err := o.kubernetes.ClusterClientForContext(o.kubeconfigCtx, o.dryRun)
+	store, err := o.hmacTokenStore()
 	if err != nil {
-		logrus.WithError(err).Fatalf("Error creating Kubernetes client for cluster %q.", o.kubeconfigCtx)
+		logrus.WithError(err).Fatal("Error opening the hmac token secret.")
 	}
 
 	configAgent, err := o.config.ConfigAgent()
 	if err != nil {
 		logrus.WithError(err).Fatal("Error starting config agent.")
 	}
-	newHMACConfig := configAgent.Config().ManagedWebhooks
 
 	gc, err := o.github.GitHubClient(o.dryRun)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error creating github client")
 	}
 
-	currentHMACYaml, err := getCurrentHMACTokens(kc, o.hmacTokenSecretNamespace, o.hmacTokenSecretName, o.hmacTokenKey)
+	if o.runAsController {
+		runController(o, store, gc, configAgent)
+		return
+	}
+
+	c, err := newClient(o, store, gc, configAgent.Config().ManagedWebhooks)
 	if err != nil {
-		logrus.WithError(err).Fatal("Error getting the current hmac yaml.")
+		logrus.WithError(err).Fatal("Error getting the current hmac tokens.")
+	}
+
+	if err := c.handleInvitation(); err != nil {
+		logrus.WithError(err).Fatal("Error accepting invitations.")
+	}
+
+	if err := c.handleConfigUpdate(); err != nil {
+		logrus.WithError(err).Fatal("Error handling hmac config update.")
+	}
+}
+
+// hmacTokenStore returns the store of the secret holding the hmac tokens.
+func (o *options) hmacTokenStore() (secretstore.Store, error) {
+	if secretstore.Backend(o.secretStore.Backend) != secretstore.BackendKubernetes {
+		return o.secretStore.Store(context.Background(), o.hmacTokenSecretName, o.hmacTokenKey)
+	}
+	kc, err := o.kubernetes.ClusterClientForContext(o.kubeconfigCtx, o.dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Kubernetes client for cluster %q: %w", o.kubeconfigCtx, err)
+	}
+	return secretstore.NewKubernetesStore(kc, o.hmacTokenSecretNamespace, o.hmacTokenSecretName, o.hmacTokenKey), nil
+}
+
+// newClient returns a client that reconciles the hmac tokens currently in the
+// secret with the given config.
+func newClient(o options, store secretstore.Store, gc github.HookClient, newHMACConfig config.ManagedWebhooks) (*client, error) {
+	currentHMACYaml, err := store.Get(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("error getting the current hmac yaml: %w", err)
 	}
 
 	currentHMACMap := map[string]github.HMACsForRepo{}
@@ -147,7 +190,7 @@ func main() {
 		// When the token is still a single global token, respect_legacy_global_token must be set to true before running this tool.
 		// This can prevent the global token from being deleted by mistake before users migrate all repos/orgs to use auto-generated private tokens.
 		if !newHMACConfig.RespectLegacyGlobalToken {
-			logrus.Fatal("respect_legacy_global_token must be set to true before the hmac tool is run for the first time.")
+			return nil, errors.New("respect_legacy_global_token must be set to true before the hmac tool is run for the first time")
 		}
 
 		logrus.WithError(err).Error("Couldn't unmarshal the hmac secret as hierarchical file. Parsing as a single global token and writing it back to the secret.")
@@ -158,8 +201,8 @@ func main() {
 		}
 	}
 
-	c := client{
-		kubernetesClient: kc,
+	return &client{
+		secretStore:      store,
 		githubHookClient: gc,
 		options:          o,
 
@@ -167,15 +210,7 @@ func main() {
 		newHMACConfig:         newHMACConfig,
 		hmacMapForBatchUpdate: map[string]string{},
 		hmacMapForRecovery:    map[string]github.HMACsForRepo{},
-	}
-
-	if err := c.handleInvitation(); err != nil {
-		logrus.WithError(err).Fatal("Error accepting invitations.")
-	}
-
-	if err := c.handleConfigUpdate(); err != nil {
-		logrus.WithError(err).Fatal("Error handling hmac config update.")
-	}
+	}, nil
 }
 
 func (c *client) handleInvitation() error {
@@ -277,7 +312,9 @@ func (c *client) handleConfigUpdate() error {
 	}
 	// HACK: waiting for the hmac k8s secret update to propagate to the pods that are using the secret,
 	// so that components like hook can start respecting the new hmac values.
-	time.Sleep(20 * time.Second)
+	if len(c.hmacMapForBatchUpdate) != 0 {
+		time.Sleep(20 * time.Second)
+	}
 	errs := c.batchOnboardNewTokenForRepos()
 
 	// Do necessary cleanups after the token and webhook updates are done.
@@ -449,14 +486,7 @@ func (c *client) updateHMACTokenSecret() error {
 	if err != nil {
 		return fmt.Errorf("error converting hmac map to yaml: %w", err)
 	}
-	sec := &corev1.Secret{}
-	sec.Name = c.options.hmacTokenSecretName
-	sec.Namespace = c.options.hmacTokenSecretNamespace
-	sec.StringData = map[string]string{c.options.hmacTokenKey: string(secretContent)}
-	if _, err = c.kubernetesClient.CoreV1().Secrets(c.options.hmacTokenSecretNamespace).Update(context.TODO(), sec, metav1.UpdateOptions{}); err != nil {
-		return fmt.Errorf("error updating the secret: %w", err)
-	}
-	return nil
+	return c.secretStore.Update(context.TODO(), secretContent)
 }
 
 // pruneOldTokens removes all but most recent token from token config.
@@ -482,19 +512,3 @@ func generateNewHMACToken() (string, error) {
 	}
 	return hex.EncodeToString(bytes), nil
 }
-
-// getCurrentHMACTokens returns the hmac tokens currently configured in the cluster.
-func getCurrentHMACTokens(kc kubernetes.Interface, ns, secName, key string) ([]byte, error) {
-	sec, err := kc.CoreV1().Secrets(ns).Get(context.TODO(), secName, metav1.GetOptions{})
-	if err != nil && !apierrors.IsNotFound(err) {
-		return nil, fmt.Errorf("error getting hmac secret %q: %w", secName, err)
-	}
-	if err == nil {
-		buf, ok := sec.Data[key]
-		if ok {
-			return buf, nil
-		}
-		return nil, fmt.Errorf("error getting key %q from the hmac secret %q", key, secName)
-	}
-	return nil, fmt.Errorf("error getting hmac token values: %w", err)
-}
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/secretstore"
 )
 
 func TestGatherOptions(t *testing.T) {
@@ -62,6 +63,48 @@ func TestGatherOptions(t *testing.T) {
 				o.dryRun = false
 			},
 		},
+		{
+			name: "run as controller",
+			args: map[string]string{
+				"--run-as-controller": "true",
+				"--resync-period":     "10m",
+			},
+			expected: func(o *options) {
+				o.runAsController = true
+				o.resyncPeriod = 10 * time.Minute
+			},
+		},
+		{
+			name: "hmac tokens in a secret manager don't need a cluster",
+			args: map[string]string{
+				"--secret-backend": "aws",
+				"--aws-region":     "us-east-1",
+			},
+			del: sets.New[string]("--kubeconfig-context", "--hmac-token-key"),
+			expected: func(o *options) {
+				o.secretStore.Backend = "aws"
+				o.secretStore.AWSRegion = "us-east-1"
+				o.kubeconfigCtx = ""
+				o.hmacTokenKey = ""
+			},
+		},
+		{
+			name: "hmac tokens in Vault need a key",
+			args: map[string]string{
+				"--secret-backend":   "vault",
+				"--vault-address":    "https://vault.example.com",
+				"--vault-token-path": "/etc/vault/token",
+			},
+			del: sets.New[string]("--hmac-token-key"),
+			err: true,
+		},
+		{
+			name: "reject a non-positive resync period",
+			args: map[string]string{
+				"--resync-period": "0s",
+			},
+			err: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -83,6 +126,12 @@ func TestGatherOptions(t *testing.T) {
 				hmacTokenSecretNamespace: "default",
 				hmacTokenSecretName:      "hmac-token",
 				hmacTokenKey:             "hmac",
+				resyncPeriod:             time.Hour,
+				instrumentationOptions:   flagutil.DefaultInstrumentationOptions(),
+				secretStore: secretstore.Options{
+					Backend:    "kubernetes",
+					VaultMount: "secret",
+				},
 			}
 			if tc.expected != nil {
 				tc.expected(expected)
@@ -17,9 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"net/http"
 	"os"
+	"path"
 	"strconv"
 	"time"
 
@@ -40,6 +43,7 @@ import (
 	jiraclient "sigs.k8s.io/prow/pkg/jira"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/moonraker"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	pluginhelp "sigs.k8s.io/prow/pkg/pluginhelp/hook"
 	"sigs.k8s.io/prow/pkg/plugins"
@@ -47,6 +51,7 @@ import (
 	"sigs.k8s.io/prow/pkg/plugins/jira"
 	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
 	"sigs.k8s.io/prow/pkg/repoowners"
+	"sigs.k8s.io/prow/pkg/secretstore"
 	"sigs.k8s.io/prow/pkg/slack"
 
 	_ "sigs.k8s.io/prow/pkg/version"
@@ -71,17 +76,44 @@ type options struct {
 	bugzilla               prowflagutil.BugzillaOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	jira                   prowflagutil.JiraOptions
+	storage                prowflagutil.StorageClientOptions
+	runtimeFlags           prowflagutil.RuntimeFlagsOptions
 
 	webhookSecretFile string
 	slackTokenFile    string
+
+	// hmacSecretStore selects where the HMAC secret is stored, it is read
+	// from webhookSecretFile for the kubernetes backend.
+	hmacSecretStore secretstore.Options
+	hmacSecretName  string
+	hmacSecretKey   string
+
+	// eventStorePath is the /local/path, gs://, s3:// or abs:// prefix to persist
+	// webhook deliveries to, so they can be replayed.
+	eventStorePath  string
+	replayTokenFile string
+
+	// periodicPluginsInterval is how often the periodic handlers of plugins
+	// are run, they are disabled if it is zero.
+	periodicPluginsInterval time.Duration
 }
 
 func (o *options) Validate() error {
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig, &o.hmacSecretStore, &o.runtimeFlags} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
 	}
+	if secretstore.Backend(o.hmacSecretStore.Backend) != secretstore.BackendKubernetes && o.hmacSecretName == "" {
+		return errors.New("--hmac-secret-name is required unless --secret-backend=kubernetes")
+	}
+
+	if o.replayTokenFile != "" && o.eventStorePath == "" {
+		return errors.New("--replay-token-file requires --event-store-path")
+	}
+	if o.periodicPluginsInterval < 0 {
+		return errors.New("--periodic-plugins-interval must not be negative")
+	}
 
 	return nil
 }
@@ -94,12 +126,17 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
 	fs.DurationVar(&o.gracePeriod, "grace-period", 180*time.Second, "On shutdown, try to handle remaining events for the specified duration. ")
 	o.pluginsConfig.PluginConfigPathDefault = "/etc/plugins/plugins.yaml"
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.instrumentationOptions, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.instrumentationOptions, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig, &o.storage, &o.hmacSecretStore, &o.runtimeFlags} {
 		group.AddFlags(fs)
 	}
 
-	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
+	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret, used with --secret-backend=kubernetes.")
+	fs.StringVar(&o.hmacSecretName, "hmac-secret-name", "", "Name of the GitHub HMAC secret in the secret manager of --secret-backend.")
+	fs.StringVar(&o.hmacSecretKey, "hmac-secret-key", "", "Key of the GitHub HMAC secret in the Vault secret, used with --secret-backend=vault.")
 	fs.StringVar(&o.slackTokenFile, "slack-token-file", "", "Path to the file containing the Slack token to use.")
+	fs.StringVar(&o.eventStorePath, "event-store-path", "", "The /local/path, gs://path/to/prefix or s3://path/to/prefix to persist the raw webhook deliveries to. Disabled if unset.")
+	fs.StringVar(&o.replayTokenFile, "replay-token-file", "", "Path to the file containing the token required to replay stored deliveries with POST <webhook-path>/replay?id=<delivery-id>. The endpoint is disabled if unset.")
+	fs.DurationVar(&o.periodicPluginsInterval, "periodic-plugins-interval", 0, "How often to run the periodic handlers of plugins like lifecycle-manager. They are not limited by the org and repo enablement flags. Disabled if zero, only enable it on one replica.")
 	fs.Parse(args)
 	return o
 }
@@ -107,7 +144,8 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 func main() {
 	logrusutil.ComponentInit()
 
-	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	o := gatherOptions(fs, os.Args[1:]...)
 	if err := o.Validate(); err != nil {
 		logrus.WithError(err).Fatal("Invalid options")
 	}
@@ -127,7 +165,9 @@ func main() {
 	if o.github.AppPrivateKeyPath != "" {
 		tokens = append(tokens, o.github.AppPrivateKeyPath)
 	}
-	tokens = append(tokens, o.webhookSecretFile)
+	if secretstore.Backend(o.hmacSecretStore.Backend) == secretstore.BackendKubernetes {
+		tokens = append(tokens, o.webhookSecretFile)
+	}
 
 	// This is necessary since slack token is optional.
 	if o.slackTokenFile != "" {
@@ -138,10 +178,19 @@ func main() {
 		tokens = append(tokens, o.bugzilla.ApiKeyPath)
 	}
 
+	if o.replayTokenFile != "" {
+		tokens = append(tokens, o.replayTokenFile)
+	}
+
 	if err := secret.Add(tokens...); err != nil {
 		logrus.WithError(err).Fatal("Error starting secrets agent.")
 	}
 
+	webhookTokenGenerator, err := o.webhookTokenGenerator()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error loading the HMAC secret.")
+	}
+
 	pluginAgent, err := o.pluginsConfig.PluginAgent()
 	if err != nil {
 		logrus.WithError(err).Fatal("Error starting plugins.")
@@ -151,6 +200,13 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting GitHub client.")
 	}
+	runtimeFlags := o.runtimeFlags.RuntimeFlags(fs)
+	if err := o.github.AddRuntimeThrottling(runtimeFlags, githubClient); err != nil {
+		logrus.WithError(err).Fatal("Error making GitHub throttling changeable at runtime.")
+	}
+	if err := runtimeFlags.Start(); err != nil {
+		logrus.WithError(err).Fatal("Error watching runtime flags.")
+	}
 	gitClient, err := o.github.GitClientFactory("", &o.config.InRepoConfigCacheDirBase, o.dryRun, false)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting Git client.")
@@ -221,7 +277,25 @@ func main() {
 	resolver := func(org, repo string) ownersconfig.Filenames {
 		return pluginAgent.Config().OwnersFilenames(org, repo)
 	}
-	ownersClient := repoowners.NewClient(gitClient, githubClient, mdYAMLEnabled, skipCollaborators, ownersDirDenylist, resolver)
+	codeOwnersEnabled := func(org, repo string) bool {
+		return pluginAgent.Config().CodeOwnersEnabled(org, repo)
+	}
+	// OWNERS getter.
+	var ownersClient repoowners.Interface
+	if o.config.MoonrakerAddress != "" {
+		moonrakerClient, err := moonraker.NewClient(o.config.MoonrakerAddress, configAgent)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting Moonraker client.")
+		}
+		ownersClient = repoowners.NewRemoteClient(moonrakerClient, githubClient, skipCollaborators)
+	} else {
+		ownersClient = repoowners.NewClient(gitClient, githubClient, mdYAMLEnabled, skipCollaborators, ownersDirDenylist, resolver, codeOwnersEnabled)
+	}
+
+	opener, err := o.storage.StorageClient(context.Background())
+	if err != nil {
+		logrus.WithError(err).Fatal("Error creating opener.")
+	}
 
 	clientAgent := &plugins.ClientAgent{
 		GitHubClient:              githubClient,
@@ -233,6 +307,7 @@ func main() {
 		OwnersClient:              ownersClient,
 		BugzillaClient:            bugzillaClient,
 		JiraClient:                jiraClient,
+		Opener:                    opener,
 	}
 
 	promMetrics := githubeventserver.NewMetrics()
@@ -249,7 +324,13 @@ func main() {
 		Plugins:        pluginAgent,
 		Metrics:        promMetrics,
 		RepoEnabled:    o.githubEnablement.EnablementChecker(),
-		TokenGenerator: secret.GetTokenGenerator(o.webhookSecretFile),
+		TokenGenerator: webhookTokenGenerator,
+	}
+	if o.eventStorePath != "" {
+		server.EventStore = hook.NewEventStore(opener, o.eventStorePath)
+	}
+	if o.periodicPluginsInterval > 0 {
+		interrupts.TickLiteral(server.RunPeriodicHandlers, o.periodicPluginsInterval)
 	}
 	interrupts.OnInterrupt(func() {
 		server.GracefulShutdown()
@@ -267,6 +348,10 @@ func main() {
 
 	// For /hook, handle a webhook normally.
 	hookMux.Handle(o.webhookPath, server)
+	// Replay stored deliveries from /hook/replay.
+	if o.replayTokenFile != "" {
+		hookMux.Handle(path.Join(o.webhookPath, "replay"), server.ReplayHandler(secret.GetTokenGenerator(o.replayTokenFile)))
+	}
 	// Serve plugin help information from /plugin-help.
 	hookMux.Handle("/plugin-help", pluginhelp.NewHelpAgent(pluginAgent, githubClient))
 
@@ -276,3 +361,17 @@ func main() {
 
 	interrupts.ListenAndServe(httpServer, o.gracePeriod)
 }
+
+// webhookTokenGenerator returns the GitHub HMAC secret, which is reloaded
+// from the secret manager of the backend every minute unless it is mounted
+// from a Kubernetes Secret.
+func (o *options) webhookTokenGenerator() (func() []byte, error) {
+	if secretstore.Backend(o.hmacSecretStore.Backend) == secretstore.BackendKubernetes {
+		return secret.GetTokenGenerator(o.webhookSecretFile), nil
+	}
+	store, err := o.hmacSecretStore.Store(context.Background(), o.hmacSecretName, o.hmacSecretKey)
+	if err != nil {
+		return nil, err
+	}
+	return secretstore.Poll(context.Background(), store, time.Minute)
+}
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	pluginsflagutil "sigs.k8s.io/prow/pkg/flagutil/plugins"
+	"sigs.k8s.io/prow/pkg/secretstore"
 )
 
 func Test_gatherOptions(t *testing.T) {
@@ -77,6 +78,61 @@ func Test_gatherOptions(t *testing.T) {
 				o.webhookPath = "/random/hook"
 			},
 		},
+		{
+			name: "explicitly set --event-store-path and --replay-token-file",
+			args: map[string]string{
+				"--event-store-path":  "gs://bucket/hook-events",
+				"--replay-token-file": "/etc/replay/token",
+			},
+			expected: func(o *options) {
+				o.eventStorePath = "gs://bucket/hook-events"
+				o.replayTokenFile = "/etc/replay/token"
+			},
+		},
+		{
+			name: "--replay-token-file without --event-store-path is invalid",
+			args: map[string]string{
+				"--replay-token-file": "/etc/replay/token",
+			},
+			err: true,
+		},
+		{
+			name: "explicitly set --periodic-plugins-interval",
+			args: map[string]string{
+				"--periodic-plugins-interval": "1h",
+			},
+			expected: func(o *options) {
+				o.periodicPluginsInterval = time.Hour
+			},
+		},
+		{
+			name: "HMAC secret in a secret manager",
+			args: map[string]string{
+				"--secret-backend":   "gcp",
+				"--gcp-project":      "project",
+				"--hmac-secret-name": "hmac",
+			},
+			expected: func(o *options) {
+				o.hmacSecretStore.Backend = "gcp"
+				o.hmacSecretStore.GCPProject = "project"
+				o.hmacSecretName = "hmac"
+			},
+		},
+		{
+			name: "HMAC secret in a secret manager requires its name",
+			args: map[string]string{
+				"--secret-backend": "gcp",
+				"--gcp-project":    "project",
+			},
+			err: true,
+		},
+		{
+			name: "negative --periodic-plugins-interval is invalid",
+			args: map[string]string{
+				"--periodic-plugins-interval": "-1h",
+			},
+			err: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -99,6 +155,10 @@ func Test_gatherOptions(t *testing.T) {
 				gracePeriod:            180 * time.Second,
 				webhookSecretFile:      "/etc/webhook/hmac",
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
+				hmacSecretStore: secretstore.Options{
+					Backend:    "kubernetes",
+					VaultMount: "secret",
+				},
 			}
 			expectedfs := flag.NewFlagSet("fake-flags", flag.PanicOnError)
 			expected.github.AddFlags(expectedfs)
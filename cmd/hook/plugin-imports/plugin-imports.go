@@ -24,6 +24,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/plugins/blockade"
 	_ "sigs.k8s.io/prow/pkg/plugins/blunderbuss"
 	_ "sigs.k8s.io/prow/pkg/plugins/branchcleaner"
+	_ "sigs.k8s.io/prow/pkg/plugins/branchff"
 	_ "sigs.k8s.io/prow/pkg/plugins/bugzilla"
 	_ "sigs.k8s.io/prow/pkg/plugins/buildifier"
 	_ "sigs.k8s.io/prow/pkg/plugins/cat"
@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"time"
 
@@ -50,6 +51,7 @@ type options struct {
 	config configflagutil.ConfigOptions
 
 	kubernetes             prowflagutil.KubernetesOptions
+	github                 prowflagutil.GitHubOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	controllerManager      prowflagutil.ControllerManagerOptions
 	dryRun                 bool
@@ -61,6 +63,8 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to Kubernetes.")
 	o.config.AddFlags(fs)
 	o.kubernetes.AddFlags(fs)
+	o.github.AddFlags(fs)
+	o.github.AllowAnonymous = true
 	o.instrumentationOptions.AddFlags(fs)
 	o.controllerManager.TimeoutListingProwJobsDefault = 60 * time.Second
 	o.controllerManager.AddFlags(fs)
@@ -70,7 +74,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 }
 
 func (o *options) Validate() error {
-	for _, group := range []pkgflagutil.OptionGroup{&o.kubernetes, &o.config, &o.controllerManager} {
+	for _, group := range []pkgflagutil.OptionGroup{&o.kubernetes, &o.github, &o.config, &o.controllerManager} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
@@ -96,6 +100,13 @@ func main() {
 		logrus.WithError(err).Fatal("Error starting config agent.")
 	}
 
+	// The GitHub client is only used to resolve the base refs of periodics
+	// with skip_if_unchanged.
+	githubClient, err := o.github.GitHubClient(o.dryRun)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error getting GitHub client.")
+	}
+
 	cfg, err := o.kubernetes.InfrastructureClusterConfig(o.dryRun)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to get prowjob kubeconfig")
@@ -136,9 +147,11 @@ func main() {
 	if configAgent.Config().Horologium.TickInterval != nil {
 		tickInterval = configAgent.Config().Horologium.TickInterval.Duration
 	}
+	delayed := delayedJobs{}
+	skipped := skippedJobs{}
 	interrupts.TickLiteral(func() {
 		start := time.Now()
-		if err := sync(cluster.GetClient(), configAgent.Config(), cr, start); err != nil {
+		if err := sync(cluster.GetClient(), configAgent.Config(), cr, githubClient, delayed, skipped, start); err != nil {
 			logrus.WithError(err).Error("Error syncing periodic jobs.")
 		}
 		logrus.WithField("duration", time.Since(start)).Info("Synced periodic jobs")
@@ -150,12 +163,61 @@ type cronClient interface {
 	QueuedJobs() []string
 }
 
-func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronClient, now time.Time) error {
+type githubClient interface {
+	GetRef(org, repo, ref string) (string, error)
+}
+
+// delayedJobs are the cron periodics whose trigger is delayed by their
+// jitter. Values are the times the periodics are triggered at.
+type delayedJobs map[string]time.Time
+
+// skippedJobs are the interval periodics whose last run was skipped because
+// their base ref was unchanged. Values are the times of the skips, which are
+// the reference of the next interval instead of the last run, so the ref is
+// not resolved again on every sync.
+type skippedJobs map[string]time.Time
+
+// jitter returns the delay of the run of the periodic which is due at the
+// given time.
+func jitter(cfg *config.Config, p *config.Periodic, due time.Time) time.Duration {
+	maxJitter := p.GetMaxJitter()
+	if p.MaxJitter == "" && cfg.Horologium.MaxJitter != nil {
+		maxJitter = cfg.Horologium.MaxJitter.Duration
+	}
+	if maxJitter <= 0 {
+		return 0
+	}
+	// The delay is derived from a hash rather than picked randomly, so it
+	// stays the same on every sync until the run is triggered.
+	h := fnv.New64a()
+	h.Write([]byte(p.Name))
+	if cfg.Horologium.JitterStrategy != config.JitterStrategySpread {
+		h.Write([]byte(due.UTC().Format(time.RFC3339)))
+	}
+	return time.Duration(h.Sum64() % uint64(maxJitter))
+}
+
+// latestSuccessfulJobs returns the latest successful run of each periodic.
+func latestSuccessfulJobs(jobs []prowapi.ProwJob) map[string]prowapi.ProwJob {
+	latest := map[string]prowapi.ProwJob{}
+	for _, j := range jobs {
+		if j.Spec.Type != prowapi.PeriodicJob || j.Status.State != prowapi.SuccessState {
+			continue
+		}
+		if existing, ok := latest[j.Spec.Job]; !ok || existing.Status.StartTime.Before(&j.Status.StartTime) {
+			latest[j.Spec.Job] = j
+		}
+	}
+	return latest
+}
+
+func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronClient, ghc githubClient, delayed delayedJobs, skipped skippedJobs, now time.Time) error {
 	jobs := &prowapi.ProwJobList{}
 	if err := prowJobClient.List(context.TODO(), jobs, ctrlruntimeclient.InNamespace(cfg.ProwJobNamespace)); err != nil {
 		return fmt.Errorf("error listing prow jobs: %w", err)
 	}
 	latestJobs := pjutil.GetLatestProwJobs(jobs.Items, prowapi.PeriodicJob)
+	successfulJobs := latestSuccessfulJobs(jobs.Items)
 
 	if err := cr.SyncConfig(cfg); err != nil {
 		logrus.WithError(err).Error("Error syncing cron jobs.")
@@ -166,6 +228,28 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 		cronTriggers.Insert(job)
 	}
 
+	// Forget the delayed jobs which are not cron periodics anymore and the
+	// skipped jobs which are not interval periodics skipping unchanged refs.
+	cronPeriodics := sets.New[string]()
+	skippingPeriodics := sets.New[string]()
+	for _, p := range cfg.Periodics {
+		if p.Cron != "" {
+			cronPeriodics.Insert(p.Name)
+		} else if p.SkipIfUnchanged {
+			skippingPeriodics.Insert(p.Name)
+		}
+	}
+	for name := range delayed {
+		if !cronPeriodics.Has(name) {
+			delete(delayed, name)
+		}
+	}
+	for name := range skipped {
+		if !skippingPeriodics.Has(name) {
+			delete(skipped, name)
+		}
+	}
+
 	var errs []error
 	for _, p := range cfg.Periodics {
 		j, previousFound := latestJobs[p.Name]
@@ -175,6 +259,7 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 		})
 
 		var shouldTrigger = false
+		triggerTime, isDelayed := delayed[p.Name]
 		switch {
 		case p.Cron == "": // no cron expression is set, we use interval to trigger
 			if j.Complete() {
@@ -184,9 +269,21 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 					intervalRef = j.Status.CompletionTime.Time
 					intervalDuration = p.GetMinimumInterval()
 				}
-				shouldTrigger = now.Sub(intervalRef) > intervalDuration
+				if skippedAt, ok := skipped[p.Name]; ok && skippedAt.After(intervalRef) {
+					intervalRef = skippedAt
+				}
+				shouldTrigger = now.Sub(intervalRef) > intervalDuration+jitter(cfg, &p, intervalRef.Add(intervalDuration))
+			}
+		case cronTriggers.Has(p.Name) || isDelayed:
+			if !isDelayed {
+				triggerTime = now.Add(jitter(cfg, &p, now))
+				delayed[p.Name] = triggerTime
 			}
-		case cronTriggers.Has(p.Name):
+			if now.Before(triggerTime) {
+				logger.WithField("trigger-time", triggerTime).Debug("Trigger is delayed by jitter.")
+				continue
+			}
+			delete(delayed, p.Name)
 			shouldTrigger = j.Complete()
 		default:
 			if !cronTriggers.Has(p.Name) {
@@ -207,9 +304,29 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 			}).Debug("Trigger time has not yet been reached.")
 		}
 		if !previousFound || shouldTrigger {
+			var baseSHA string
+			if p.SkipIfUnchanged && len(p.ExtraRefs) > 0 {
+				ref := p.ExtraRefs[0]
+				sha, err := ghc.GetRef(ref.Org, ref.Repo, "heads/"+ref.BaseRef)
+				if err != nil {
+					logger.WithError(err).Warnf("Failed to resolve %s/%s@%s, triggering new run anyway.", ref.Org, ref.Repo, ref.BaseRef)
+				} else if last, ok := successfulJobs[p.Name]; ok && len(last.Spec.ExtraRefs) > 0 && last.Spec.ExtraRefs[0].BaseSHA == sha {
+					logger.WithField("base-sha", sha).Info("Skipping periodic, its base ref is unchanged since its last successful run.")
+					if p.Cron == "" {
+						skipped[p.Name] = now
+					}
+					continue
+				}
+				baseSHA = sha
+			}
 			prowJob := pjutil.NewProwJob(pjutil.PeriodicSpec(p), p.Labels, p.Annotations,
 				pjutil.RequireScheduling(cfg.Scheduler.Enabled))
 			prowJob.Namespace = cfg.ProwJobNamespace
+			if baseSHA != "" {
+				// The extra refs are shared with the config.
+				prowJob.Spec.ExtraRefs = append([]prowapi.Refs{}, prowJob.Spec.ExtraRefs...)
+				prowJob.Spec.ExtraRefs[0].BaseSHA = baseSHA
+			}
 			logger.WithFields(logrus.Fields{
 				"should-trigger": shouldTrigger,
 				"previous-found": previousFound,
@@ -218,7 +335,9 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 			).Info("Triggering new run.")
 			if err := prowJobClient.Create(context.TODO(), &prowJob); err != nil {
 				errs = append(errs, err)
+				continue
 			}
+			delete(skipped, p.Name)
 		}
 	}
 
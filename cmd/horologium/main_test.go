@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -141,7 +142,7 @@ func TestSync(t *testing.T) {
 		}
 		fakeProwJobClient := newCreateTrackingClient(jobs)
 		fc := &fakeCron{}
-		if err := sync(fakeProwJobClient, &cfg, fc, now); err != nil {
+		if err := sync(fakeProwJobClient, &cfg, fc, nil, delayedJobs{}, skippedJobs{}, now); err != nil {
 			t.Fatalf("For case %s, didn't expect error: %v", tc.testName, err)
 		}
 
@@ -253,7 +254,7 @@ func TestSyncMinimumInterval(t *testing.T) {
 		}
 		fakeProwJobClient := newCreateTrackingClient(jobs)
 		fc := &fakeCron{}
-		if err := sync(fakeProwJobClient, &cfg, fc, now); err != nil {
+		if err := sync(fakeProwJobClient, &cfg, fc, nil, delayedJobs{}, skippedJobs{}, now); err != nil {
 			t.Fatalf("For case %s, didn't expect error: %v", tc.testName, err)
 		}
 
@@ -336,7 +337,7 @@ func TestSyncCron(t *testing.T) {
 		}
 		fakeProwJobClient := newCreateTrackingClient(jobs)
 		fc := &fakeCron{}
-		if err := sync(fakeProwJobClient, &cfg, fc, now); err != nil {
+		if err := sync(fakeProwJobClient, &cfg, fc, nil, delayedJobs{}, skippedJobs{}, now); err != nil {
 			t.Fatalf("For case %s, didn't expect error: %v", tc.testName, err)
 		}
 
@@ -356,6 +357,347 @@ func TestSyncCron(t *testing.T) {
 	}
 }
 
+func TestJitter(t *testing.T) {
+	due := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		name         string
+		maxJitter    string
+		horologium   config.Horologium
+		expectJitter bool
+		expectSame   bool
+	}{
+		{
+			name: "no jitter by default",
+		},
+		{
+			name:         "periodic max_jitter",
+			maxJitter:    "10m",
+			expectJitter: true,
+		},
+		{
+			name:         "global max_jitter",
+			horologium:   config.Horologium{MaxJitter: &metav1.Duration{Duration: 10 * time.Minute}},
+			expectJitter: true,
+		},
+		{
+			name:       "periodic max_jitter overrides global max_jitter",
+			maxJitter:  "0s",
+			horologium: config.Horologium{MaxJitter: &metav1.Duration{Duration: 10 * time.Minute}},
+		},
+		{
+			name:         "spread strategy picks the same delay for every run",
+			maxJitter:    "10m",
+			horologium:   config.Horologium{JitterStrategy: config.JitterStrategySpread},
+			expectJitter: true,
+			expectSame:   true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{ProwConfig: config.ProwConfig{Horologium: tc.horologium}}
+			p := &config.Periodic{JobBase: config.JobBase{Name: "j"}, MaxJitter: tc.maxJitter}
+			if tc.maxJitter != "" {
+				d, err := time.ParseDuration(tc.maxJitter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				p.SetMaxJitter(d)
+			}
+
+			delays := sets.New[time.Duration]()
+			for i := 0; i < 10; i++ {
+				d := jitter(cfg, p, due.Add(time.Duration(i)*time.Hour))
+				if d < 0 || d >= 10*time.Minute {
+					t.Errorf("delay %s is out of bounds", d)
+				}
+				delays.Insert(d)
+			}
+			if !tc.expectJitter {
+				if !delays.Equal(sets.New[time.Duration](0)) {
+					t.Errorf("expected no delay, got %v", sets.List(delays))
+				}
+				return
+			}
+			if tc.expectSame != (delays.Len() == 1) {
+				t.Errorf("expected the same delay for every run to be %t, got %v", tc.expectSame, sets.List(delays))
+			}
+		})
+	}
+}
+
+func TestSyncJitter(t *testing.T) {
+	now := time.Now()
+	cfg := config.Config{
+		ProwConfig: config.ProwConfig{
+			ProwJobNamespace: "prowjobs",
+			Horologium:       config.Horologium{JitterStrategy: config.JitterStrategySpread},
+		},
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{
+				{JobBase: config.JobBase{Name: "cron"}, Cron: "0 * * * *", MaxJitter: "30m"},
+				{JobBase: config.JobBase{Name: "interval"}, Interval: "1h", MaxJitter: "30m"},
+			},
+		},
+	}
+	for i := range cfg.Periodics {
+		cfg.Periodics[i].SetMaxJitter(30 * time.Minute)
+	}
+	cfg.Periodics[1].SetInterval(time.Hour)
+	cronDelay := jitter(&cfg, &cfg.Periodics[0], now)
+	intervalDelay := jitter(&cfg, &cfg.Periodics[1], now)
+
+	var jobs []client.Object
+	for _, name := range []string{"cron", "interval"} {
+		completion := metav1.NewTime(now.Add(-time.Hour))
+		jobs = append(jobs, &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prowjobs"},
+			Spec:       prowapi.ProwJobSpec{Type: prowapi.PeriodicJob, Job: name},
+			Status: prowapi.ProwJobStatus{
+				// The interval periodic is due now.
+				StartTime:      metav1.NewTime(now.Add(-time.Hour)),
+				CompletionTime: &completion,
+			},
+		})
+	}
+	fakeProwJobClient := newCreateTrackingClient(jobs)
+	delayed := delayedJobs{}
+	triggered := func() sets.Set[string] {
+		res := sets.New[string]()
+		for _, obj := range fakeProwJobClient.created {
+			res.Insert(obj.(*prowapi.ProwJob).Spec.Job)
+		}
+		return res
+	}
+
+	if err := sync(fakeProwJobClient, &cfg, &fakeCron{}, nil, delayed, skippedJobs{}, now); err != nil {
+		t.Fatalf("didn't expect error: %v", err)
+	}
+	if got := triggered(); got.Len() != 0 {
+		t.Errorf("expected no jobs to be triggered before their delay, got %v", sets.List(got))
+	}
+	if trigger, ok := delayed["cron"]; !ok || !trigger.Equal(now.Add(cronDelay)) {
+		t.Errorf("expected the cron periodic to be delayed until %s, got %s", now.Add(cronDelay), trigger)
+	}
+
+	// The cron isn't queued anymore, but the delayed trigger is remembered.
+	later := now.Add(30 * time.Minute)
+	if err := sync(fakeProwJobClient, &cfg, &fakeCron{}, nil, delayed, skippedJobs{}, later); err != nil {
+		t.Fatalf("didn't expect error: %v", err)
+	}
+	expected := sets.New[string]("cron")
+	if intervalDelay < 30*time.Minute {
+		expected.Insert("interval")
+	}
+	if got := triggered(); !got.Equal(expected) {
+		t.Errorf("expected %v to be triggered, got %v", sets.List(expected), sets.List(got))
+	}
+	if len(delayed) != 0 {
+		t.Errorf("expected no delayed jobs, got %v", delayed)
+	}
+}
+
+type fakeGitHubClient struct {
+	refs  map[string]string
+	calls int
+}
+
+func (f *fakeGitHubClient) GetRef(org, repo, ref string) (string, error) {
+	f.calls++
+	sha, ok := f.refs[org+"/"+repo+"/"+ref]
+	if !ok {
+		return "", fmt.Errorf("ref %s not found in %s/%s", ref, org, repo)
+	}
+	return sha, nil
+}
+
+func TestSyncSkipIfUnchanged(t *testing.T) {
+	now := time.Now()
+	ghc := &fakeGitHubClient{refs: map[string]string{"org/repo/heads/main": "new"}}
+	run := func(name string, start time.Time, state prowapi.ProwJobState, sha string) *prowapi.ProwJob {
+		completion := metav1.NewTime(start.Add(time.Minute))
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Type:      prowapi.PeriodicJob,
+				Job:       "j",
+				ExtraRefs: []prowapi.Refs{{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: sha}},
+			},
+			Status: prowapi.ProwJobStatus{
+				StartTime:      metav1.NewTime(start),
+				CompletionTime: &completion,
+				State:          state,
+			},
+		}
+	}
+
+	testcases := []struct {
+		name            string
+		skipIfUnchanged bool
+		baseRef         string
+		jobs            []client.Object
+		expectedSHA     string
+		expectTrigger   bool
+	}{
+		{
+			name:            "no previous run triggers with the resolved SHA",
+			skipIfUnchanged: true,
+			baseRef:         "main",
+			expectedSHA:     "new",
+			expectTrigger:   true,
+		},
+		{
+			name:            "last successful run tested the same SHA",
+			skipIfUnchanged: true,
+			baseRef:         "main",
+			jobs:            []client.Object{run("a", now.Add(-2*time.Hour), prowapi.SuccessState, "new")},
+		},
+		{
+			name:            "last successful run tested an older SHA",
+			skipIfUnchanged: true,
+			baseRef:         "main",
+			jobs:            []client.Object{run("a", now.Add(-2*time.Hour), prowapi.SuccessState, "old")},
+			expectedSHA:     "new",
+			expectTrigger:   true,
+		},
+		{
+			name:            "failed runs are ignored",
+			skipIfUnchanged: true,
+			baseRef:         "main",
+			jobs: []client.Object{
+				run("a", now.Add(-3*time.Hour), prowapi.SuccessState, "old"),
+				run("b", now.Add(-2*time.Hour), prowapi.FailureState, "new"),
+			},
+			expectedSHA:   "new",
+			expectTrigger: true,
+		},
+		{
+			name:            "only the latest successful run counts",
+			skipIfUnchanged: true,
+			baseRef:         "main",
+			jobs: []client.Object{
+				run("a", now.Add(-3*time.Hour), prowapi.SuccessState, "new"),
+				run("b", now.Add(-2*time.Hour), prowapi.SuccessState, "old"),
+			},
+			expectedSHA:   "new",
+			expectTrigger: true,
+		},
+		{
+			name:            "unresolvable ref triggers anyway",
+			skipIfUnchanged: true,
+			baseRef:         "missing",
+			jobs:            []client.Object{run("a", now.Add(-2*time.Hour), prowapi.SuccessState, "")},
+			expectTrigger:   true,
+		},
+		{
+			name:          "unset skip_if_unchanged always triggers",
+			baseRef:       "main",
+			jobs:          []client.Object{run("a", now.Add(-2*time.Hour), prowapi.SuccessState, "new")},
+			expectTrigger: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Config{
+				ProwConfig: config.ProwConfig{ProwJobNamespace: "prowjobs"},
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{{
+						JobBase: config.JobBase{
+							Name: "j",
+							UtilityConfig: config.UtilityConfig{
+								ExtraRefs: []prowapi.Refs{{Org: "org", Repo: "repo", BaseRef: tc.baseRef}},
+							},
+						},
+						Interval:        "1h",
+						SkipIfUnchanged: tc.skipIfUnchanged,
+					}},
+				},
+			}
+			cfg.Periodics[0].SetInterval(time.Hour)
+			fakeProwJobClient := newCreateTrackingClient(tc.jobs)
+			if err := sync(fakeProwJobClient, &cfg, &fakeCron{}, ghc, delayedJobs{}, skippedJobs{}, now); err != nil {
+				t.Fatalf("didn't expect error: %v", err)
+			}
+			if triggered := len(fakeProwJobClient.created) != 0; triggered != tc.expectTrigger {
+				t.Fatalf("expected trigger: %t, got: %t", tc.expectTrigger, triggered)
+			}
+			if !tc.expectTrigger {
+				return
+			}
+			pj := fakeProwJobClient.created[0].(*prowapi.ProwJob)
+			if sha := pj.Spec.ExtraRefs[0].BaseSHA; sha != tc.expectedSHA {
+				t.Errorf("expected base SHA %q, got %q", tc.expectedSHA, sha)
+			}
+			if sha := cfg.Periodics[0].ExtraRefs[0].BaseSHA; sha != "" {
+				t.Errorf("expected the config to be left alone, got base SHA %q", sha)
+			}
+		})
+	}
+}
+
+func TestSyncSkipIfUnchangedWaitsForInterval(t *testing.T) {
+	now := time.Now()
+	start := metav1.NewTime(now.Add(-2 * time.Hour))
+	completion := metav1.NewTime(start.Add(time.Minute))
+	last := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "prowjobs"},
+		Spec: prowapi.ProwJobSpec{
+			Type:      prowapi.PeriodicJob,
+			Job:       "j",
+			ExtraRefs: []prowapi.Refs{{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "old"}},
+		},
+		Status: prowapi.ProwJobStatus{StartTime: start, CompletionTime: &completion, State: prowapi.SuccessState},
+	}
+	cfg := config.Config{
+		ProwConfig: config.ProwConfig{ProwJobNamespace: "prowjobs"},
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{{
+				JobBase: config.JobBase{
+					Name: "j",
+					UtilityConfig: config.UtilityConfig{
+						ExtraRefs: []prowapi.Refs{{Org: "org", Repo: "repo", BaseRef: "main"}},
+					},
+				},
+				Interval:        "1h",
+				SkipIfUnchanged: true,
+			}},
+		},
+	}
+	cfg.Periodics[0].SetInterval(time.Hour)
+	ghc := &fakeGitHubClient{refs: map[string]string{"org/repo/heads/main": "old"}}
+	fakeProwJobClient := newCreateTrackingClient([]client.Object{last})
+	skipped := skippedJobs{}
+
+	if err := sync(fakeProwJobClient, &cfg, &fakeCron{}, ghc, delayedJobs{}, skipped, now); err != nil {
+		t.Fatalf("didn't expect error: %v", err)
+	}
+	if len(fakeProwJobClient.created) != 0 {
+		t.Fatalf("expected the run to be skipped, got %d created", len(fakeProwJobClient.created))
+	}
+	if !skipped["j"].Equal(now) {
+		t.Fatalf("expected the skip to be recorded at %v, got %v", now, skipped["j"])
+	}
+
+	// The ref moved, but the interval since the skip hasn't passed yet.
+	ghc.refs["org/repo/heads/main"] = "new"
+	if err := sync(fakeProwJobClient, &cfg, &fakeCron{}, ghc, delayedJobs{}, skipped, now.Add(30*time.Minute)); err != nil {
+		t.Fatalf("didn't expect error: %v", err)
+	}
+	if ghc.calls != 1 || len(fakeProwJobClient.created) != 0 {
+		t.Fatalf("expected no ref lookup or run before the interval passed, got %d lookups and %d runs", ghc.calls, len(fakeProwJobClient.created))
+	}
+
+	if err := sync(fakeProwJobClient, &cfg, &fakeCron{}, ghc, delayedJobs{}, skipped, now.Add(61*time.Minute)); err != nil {
+		t.Fatalf("didn't expect error: %v", err)
+	}
+	if len(fakeProwJobClient.created) != 1 {
+		t.Fatalf("expected a run after the interval passed, got %d", len(fakeProwJobClient.created))
+	}
+	if _, ok := skipped["j"]; ok {
+		t.Errorf("expected the skip to be forgotten after triggering a run")
+	}
+}
+
 func TestFlags(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -415,6 +757,9 @@ func TestFlags(t *testing.T) {
 	}
 
 	for _, tc := range cases {
+		ghoptions := flagutil.GitHubOptions{}
+		ghoptions.AddFlags(flag.NewFlagSet("fake-github-flags", flag.PanicOnError))
+		ghoptions.AllowAnonymous = true
 		t.Run(tc.name, func(t *testing.T) {
 			expected := &options{
 				config: configflagutil.ConfigOptions{
@@ -424,6 +769,7 @@ func TestFlags(t *testing.T) {
 					SupplementalProwConfigsFileNameSuffix: "_prowconfig.yaml",
 					InRepoConfigCacheSize:                 200,
 				},
+				github:                 ghoptions,
 				dryRun:                 true,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			}
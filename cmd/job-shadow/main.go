@@ -0,0 +1,407 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// job-shadow runs the jobs that a config change adds or modifies as shadow
+// jobs before the change merges. Shadow jobs run the changed job definition
+// against a recent ref, are clearly labeled and are not reported, so broken
+// job changes are caught on the config change instead of on everyone's PRs.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/crier/reporters/pubsub"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+// shadowPrefix is prepended to the name of shadow jobs so they can not be
+// mistaken for runs of the job that is already in the config.
+const shadowPrefix = "shadow-"
+
+type options struct {
+	config            configflagutil.ConfigOptions
+	baseConfigPath    string
+	baseJobConfigPath string
+
+	source       string
+	jobs         prowflagutil.Strings
+	maxJobs      int
+	trigger      bool
+	failWithJobs bool
+
+	kubeOptions prowflagutil.KubernetesOptions
+	github      prowflagutil.GitHubOptions
+}
+
+type githubClient interface {
+	GetPullRequests(org, repo string) ([]github.PullRequest, error)
+	GetRef(org, repo, ref string) (string, error)
+	GetRepo(owner, name string) (github.FullRepo, error)
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	var o options
+	fs.StringVar(&o.baseConfigPath, "base-config-path", "", "Path to the prow config before the change.")
+	fs.StringVar(&o.baseJobConfigPath, "base-job-config-path", "", "Path to the job config before the change.")
+	fs.StringVar(&o.source, "source", "", "The config change the jobs are shadowed for, e.g. org/repo#123. Added to the shadow jobs as an annotation.")
+	fs.Var(&o.jobs, "job", "Only shadow this job if it changed. Can be passed multiple times, all changed jobs are shadowed if unset.")
+	fs.IntVar(&o.maxJobs, "max-jobs", 10, "Maximum number of jobs to shadow, 0 means no limit.")
+	fs.BoolVar(&o.trigger, "trigger", false, "Submit the shadow jobs to Prow and wait for their results. Without it, the shadow jobs are only printed.")
+	fs.BoolVar(&o.failWithJobs, "fail-with-jobs", false, "Exit with a non-zero exit code if any shadow job fails.")
+	o.config.AddFlags(fs)
+	o.kubeOptions.AddFlags(fs)
+	o.github.AddFlags(fs)
+	o.github.AllowAnonymous = true
+	o.github.AllowDirectAccess = true
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	if o.baseConfigPath == "" {
+		return errors.New("--base-config-path is required")
+	}
+	if o.maxJobs < 0 {
+		return errors.New("--max-jobs must not be negative")
+	}
+	if err := o.config.Validate(false); err != nil {
+		return err
+	}
+	if err := o.github.Validate(false); err != nil {
+		return err
+	}
+	if o.trigger {
+		if err := o.kubeOptions.Validate(false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changedJob is a job that only exists in the changed config or whose
+// definition differs from the one in the base config.
+type changedJob struct {
+	repo       string
+	presubmit  *config.Presubmit
+	postsubmit *config.Postsubmit
+	periodic   *config.Periodic
+}
+
+func (j changedJob) jobBase() config.JobBase {
+	switch {
+	case j.presubmit != nil:
+		return j.presubmit.JobBase
+	case j.postsubmit != nil:
+		return j.postsubmit.JobBase
+	default:
+		return j.periodic.JobBase
+	}
+}
+
+// differs reports whether a job definition is new or changed.
+func differs[T any](base T, inBase bool, head T) (bool, error) {
+	if !inBase {
+		return true, nil
+	}
+	b, err := json.Marshal(base)
+	if err != nil {
+		return false, err
+	}
+	h, err := json.Marshal(head)
+	if err != nil {
+		return false, err
+	}
+	return string(b) != string(h), nil
+}
+
+// changedJobs returns the jobs of head that are new or changed compared to
+// base. If names is not empty, only the jobs with these names are returned.
+func changedJobs(base, head *config.Config, names sets.Set[string]) ([]changedJob, error) {
+	var changed []changedJob
+	for _, repo := range sets.List(sets.KeySet(head.PresubmitsStatic)) {
+		previous := map[string]config.Presubmit{}
+		for _, p := range base.PresubmitsStatic[repo] {
+			previous[p.Name] = p
+		}
+		for i := range head.PresubmitsStatic[repo] {
+			p := &head.PresubmitsStatic[repo][i]
+			if names.Len() > 0 && !names.Has(p.Name) {
+				continue
+			}
+			old, inBase := previous[p.Name]
+			if diff, err := differs(old, inBase, *p); err != nil {
+				return nil, fmt.Errorf("failed to compare presubmit %s: %w", p.Name, err)
+			} else if diff {
+				changed = append(changed, changedJob{repo: repo, presubmit: p})
+			}
+		}
+	}
+	for _, repo := range sets.List(sets.KeySet(head.PostsubmitsStatic)) {
+		previous := map[string]config.Postsubmit{}
+		for _, p := range base.PostsubmitsStatic[repo] {
+			previous[p.Name] = p
+		}
+		for i := range head.PostsubmitsStatic[repo] {
+			p := &head.PostsubmitsStatic[repo][i]
+			if names.Len() > 0 && !names.Has(p.Name) {
+				continue
+			}
+			old, inBase := previous[p.Name]
+			if diff, err := differs(old, inBase, *p); err != nil {
+				return nil, fmt.Errorf("failed to compare postsubmit %s: %w", p.Name, err)
+			} else if diff {
+				changed = append(changed, changedJob{repo: repo, postsubmit: p})
+			}
+		}
+	}
+	previous := map[string]config.Periodic{}
+	for _, p := range base.Periodics {
+		previous[p.Name] = p
+	}
+	for i := range head.Periodics {
+		p := &head.Periodics[i]
+		if names.Len() > 0 && !names.Has(p.Name) {
+			continue
+		}
+		old, inBase := previous[p.Name]
+		if diff, err := differs(old, inBase, *p); err != nil {
+			return nil, fmt.Errorf("failed to compare periodic %s: %w", p.Name, err)
+		} else if diff {
+			changed = append(changed, changedJob{periodic: p})
+		}
+	}
+	return changed, nil
+}
+
+// recentPullRequest returns the most recently updated open PR that the
+// presubmit could run on, or nil if there is none.
+func recentPullRequest(prs []github.PullRequest, p *config.Presubmit) *github.PullRequest {
+	var recent *github.PullRequest
+	for i := range prs {
+		pr := &prs[i]
+		if pr.State != github.PullRequestStateOpen || pr.Draft || !p.CouldRun(pr.Base.Ref) {
+			continue
+		}
+		if recent == nil || pr.UpdatedAt.After(recent.UpdatedAt) {
+			recent = pr
+		}
+	}
+	return recent
+}
+
+// shadowSpec returns the spec the changed job is shadowed with. Presubmits
+// run against the most recently updated open PR, postsubmits against the
+// head of the default branch. A nil spec means that the repo has no ref
+// the job could run on.
+func shadowSpec(gc githubClient, job changedJob) (*prowapi.ProwJobSpec, error) {
+	if job.periodic != nil {
+		spec := pjutil.PeriodicSpec(*job.periodic)
+		return &spec, nil
+	}
+	org, repo, err := config.SplitRepoName(job.repo)
+	if err != nil {
+		return nil, err
+	}
+	if job.postsubmit != nil {
+		fullRepo, err := gc.GetRepo(org, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repo %s: %w", job.repo, err)
+		}
+		branch := fullRepo.DefaultBranch
+		if !job.postsubmit.CouldRun(branch) {
+			return nil, nil
+		}
+		sha, err := gc.GetRef(org, repo, "heads/"+branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the head of %s in %s: %w", branch, job.repo, err)
+		}
+		spec := pjutil.PostsubmitSpec(*job.postsubmit, prowapi.Refs{
+			Org:      org,
+			Repo:     repo,
+			RepoLink: fullRepo.HTMLURL,
+			BaseRef:  branch,
+			BaseSHA:  sha,
+		})
+		return &spec, nil
+	}
+	prs, err := gc.GetPullRequests(org, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests of %s: %w", job.repo, err)
+	}
+	pr := recentPullRequest(prs, job.presubmit)
+	if pr == nil {
+		return nil, nil
+	}
+	spec := pjutil.PresubmitSpec(*job.presubmit, prowapi.Refs{
+		Org:      org,
+		Repo:     repo,
+		RepoLink: pr.Base.Repo.HTMLURL,
+		BaseRef:  pr.Base.Ref,
+		BaseSHA:  pr.Base.SHA,
+		Pulls: []prowapi.Pull{{
+			Number:  pr.Number,
+			Author:  pr.User.Login,
+			SHA:     pr.Head.SHA,
+			HeadRef: pr.Head.Ref,
+			Title:   pr.Title,
+			Link:    pr.HTMLURL,
+		}},
+	})
+	return &spec, nil
+}
+
+// shadowProwJob turns the spec of a changed job into a shadow ProwJob. Shadow
+// jobs are renamed, labeled, never reported and neither retried nor waited on
+// by other jobs.
+func shadowProwJob(job config.JobBase, spec prowapi.ProwJobSpec, source string, scheduling bool) prowapi.ProwJob {
+	spec.Job = shadowPrefix + job.Name
+	spec.Report = false
+	spec.ReporterConfig = nil
+	spec.Retry = nil
+	spec.DependsOn = nil
+
+	labels := map[string]string{}
+	for k, v := range job.Labels {
+		if k == pubsub.PubSubProjectLabel || k == pubsub.PubSubTopicLabel || k == pubsub.PubSubRunIDLabel {
+			continue
+		}
+		labels[k] = v
+	}
+	labels[kube.ShadowLabel] = "true"
+	annotations := map[string]string{}
+	for k, v := range job.Annotations {
+		annotations[k] = v
+	}
+	annotations[kube.ShadowOfAnnotation] = job.Name
+	if source != "" {
+		annotations[kube.ShadowSourceAnnotation] = source
+	}
+	return pjutil.NewProwJob(spec, labels, annotations, pjutil.RequireScheduling(scheduling))
+}
+
+// shadowJobs returns the shadow ProwJobs of the changed jobs, skipping the
+// jobs that have no ref to run against.
+func (o *options) shadowJobs(gc githubClient, base, head *config.Config) ([]prowapi.ProwJob, error) {
+	changed, err := changedJobs(base, head, sets.New[string](o.jobs.Strings()...))
+	if err != nil {
+		return nil, err
+	}
+	if o.maxJobs > 0 && len(changed) > o.maxJobs {
+		logrus.Warnf("The change modifies %d jobs, only shadowing the first %d.", len(changed), o.maxJobs)
+		changed = changed[:o.maxJobs]
+	}
+	var pjs []prowapi.ProwJob
+	for _, job := range changed {
+		jb := job.jobBase()
+		spec, err := shadowSpec(gc, job)
+		if err != nil {
+			return nil, fmt.Errorf("failed to shadow job %s: %w", jb.Name, err)
+		}
+		if spec == nil {
+			logrus.WithField("job", jb.Name).Warn("No ref to shadow the job against, skipping it.")
+			continue
+		}
+		pjs = append(pjs, shadowProwJob(jb, *spec, o.source, head.Scheduler.Enabled))
+	}
+	return pjs, nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	base, err := config.Load(o.baseConfigPath, o.baseJobConfigPath, nil, "")
+	if err != nil {
+		logrus.WithError(err).Fatal("Error loading base config")
+	}
+	ca, err := o.config.ConfigAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error loading config")
+	}
+	head := ca.Config()
+	gc, err := o.github.GitHubClient(false)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to get GitHub client")
+	}
+
+	pjs, err := o.shadowJobs(gc, base, head)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to shadow changed jobs")
+	}
+	if len(pjs) == 0 {
+		logrus.Info("No changed jobs to shadow.")
+		return
+	}
+	if !o.trigger {
+		var docs []string
+		for i := range pjs {
+			b, err := yaml.Marshal(&pjs[i])
+			if err != nil {
+				logrus.WithError(err).Fatal("Error marshalling YAML.")
+			}
+			docs = append(docs, string(b))
+		}
+		fmt.Print(strings.Join(docs, "---\n"))
+		return
+	}
+
+	failed := sets.New[string]()
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for i := range pjs {
+		wg.Add(1)
+		go func(pj *prowapi.ProwJob) {
+			defer wg.Done()
+			succeeded, err := pjutil.TriggerAndWatchProwJob(o.kubeOptions, pj, head, nil, false)
+			if err != nil {
+				logrus.WithError(err).WithField("job", pj.Spec.Job).Error("Failed while submitting shadow job or watching its result.")
+			}
+			if err != nil || !succeeded {
+				lock.Lock()
+				failed.Insert(pj.Spec.Job)
+				lock.Unlock()
+			}
+		}(&pjs[i])
+	}
+	wg.Wait()
+	if failed.Len() > 0 {
+		logrus.WithField("jobs", sets.List(failed)).Error("Shadow jobs did not succeed.")
+		if o.failWithJobs {
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+type fakeGitHub struct {
+	prs []github.PullRequest
+}
+
+func (f *fakeGitHub) GetPullRequests(org, repo string) ([]github.PullRequest, error) {
+	return f.prs, nil
+}
+
+func (f *fakeGitHub) GetRef(org, repo, ref string) (string, error) {
+	return "sha-of-" + ref, nil
+}
+
+func (f *fakeGitHub) GetRepo(owner, name string) (github.FullRepo, error) {
+	return github.FullRepo{Repo: github.Repo{DefaultBranch: "main"}}, nil
+}
+
+func presubmit(name, command string) config.Presubmit {
+	return config.Presubmit{JobBase: config.JobBase{Name: name, Annotations: map[string]string{"command": command}}}
+}
+
+func TestChangedJobs(t *testing.T) {
+	base := &config.Config{JobConfig: config.JobConfig{
+		PresubmitsStatic: map[string][]config.Presubmit{
+			"org/repo": {presubmit("unchanged", "make"), presubmit("changed", "make")},
+		},
+		PostsubmitsStatic: map[string][]config.Postsubmit{
+			"org/repo": {{JobBase: config.JobBase{Name: "post"}}},
+		},
+		Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic"}, Interval: "1h"}},
+	}}
+	head := &config.Config{JobConfig: config.JobConfig{
+		PresubmitsStatic: map[string][]config.Presubmit{
+			"org/repo":  {presubmit("unchanged", "make"), presubmit("changed", "make test")},
+			"org/other": {presubmit("added", "make")},
+		},
+		PostsubmitsStatic: map[string][]config.Postsubmit{
+			"org/repo": {{JobBase: config.JobBase{Name: "post"}}},
+		},
+		Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic"}, Interval: "2h"}},
+	}}
+
+	testCases := []struct {
+		name     string
+		names    sets.Set[string]
+		expected []string
+	}{
+		{
+			name:     "all new and changed jobs",
+			expected: []string{"added", "changed", "periodic"},
+		},
+		{
+			name:     "only the given jobs",
+			names:    sets.New[string]("changed", "unchanged"),
+			expected: []string{"changed"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, err := changedJobs(base, head, tc.names)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, job := range changed {
+				names = append(names, job.jobBase().Name)
+			}
+			if diff := cmp.Diff(tc.expected, names); diff != "" {
+				t.Errorf("changed jobs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShadowSpec(t *testing.T) {
+	now := time.Now()
+	gc := &fakeGitHub{prs: []github.PullRequest{
+		{Number: 1, State: github.PullRequestStateOpen, UpdatedAt: now.Add(-time.Hour), Base: github.PullRequestBranch{Ref: "main"}},
+		{Number: 2, State: github.PullRequestStateOpen, UpdatedAt: now, Base: github.PullRequestBranch{Ref: "main"}, Draft: true},
+		{Number: 3, State: github.PullRequestStateOpen, UpdatedAt: now.Add(-time.Minute), Base: github.PullRequestBranch{Ref: "main"}},
+		{Number: 4, State: github.PullRequestStateOpen, UpdatedAt: now, Base: github.PullRequestBranch{Ref: "release"}},
+	}}
+	presubmits := []config.Presubmit{
+		{JobBase: config.JobBase{Name: "pull"}, Brancher: config.Brancher{Branches: []string{"main"}}},
+		{JobBase: config.JobBase{Name: "pull"}, Brancher: config.Brancher{Branches: []string{"release-1.0"}}},
+	}
+	if err := config.SetPresubmitRegexes(presubmits); err != nil {
+		t.Fatalf("failed to compile branches: %v", err)
+	}
+	postsubmits := []config.Postsubmit{
+		{JobBase: config.JobBase{Name: "post"}},
+		{JobBase: config.JobBase{Name: "post"}, Brancher: config.Brancher{Branches: []string{"release-1.0"}}},
+	}
+	if err := config.SetPostsubmitRegexes(postsubmits); err != nil {
+		t.Fatalf("failed to compile branches: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		job          changedJob
+		expectedRefs *prowapi.Refs
+		expectedNil  bool
+	}{
+		{
+			name: "presubmit runs against the most recently updated PR it could run on",
+			job:  changedJob{repo: "org/repo", presubmit: &presubmits[0]},
+			expectedRefs: &prowapi.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "main",
+				Pulls:   []prowapi.Pull{{Number: 3}},
+			},
+		},
+		{
+			name:        "presubmit without PR to run on is skipped",
+			job:         changedJob{repo: "org/repo", presubmit: &presubmits[1]},
+			expectedNil: true,
+		},
+		{
+			name: "postsubmit runs against the head of the default branch",
+			job:  changedJob{repo: "org/repo", postsubmit: &postsubmits[0]},
+			expectedRefs: &prowapi.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "main",
+				BaseSHA: "sha-of-heads/main",
+			},
+		},
+		{
+			name:        "postsubmit that does not run on the default branch is skipped",
+			job:         changedJob{repo: "org/repo", postsubmit: &postsubmits[1]},
+			expectedNil: true,
+		},
+		{
+			name: "periodic runs without refs",
+			job:  changedJob{periodic: &config.Periodic{JobBase: config.JobBase{Name: "periodic"}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := shadowSpec(gc, tc.job)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedNil {
+				if spec != nil {
+					t.Errorf("expected no spec, got %v", spec)
+				}
+				return
+			}
+			if spec == nil {
+				t.Fatal("expected a spec, got none")
+			}
+			if diff := cmp.Diff(tc.expectedRefs, spec.Refs); diff != "" {
+				t.Errorf("refs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShadowProwJob(t *testing.T) {
+	job := config.JobBase{
+		Name: "pull-repo-test",
+		Labels: map[string]string{
+			"team":                       "infra",
+			"prow.k8s.io/pubsub.project": "project",
+			"prow.k8s.io/pubsub.topic":   "topic",
+		},
+		Annotations: map[string]string{"testgrid-dashboards": "repo"},
+	}
+	spec := prowapi.ProwJobSpec{
+		Type:           prowapi.PresubmitJob,
+		Job:            job.Name,
+		Report:         true,
+		ReporterConfig: &prowapi.ReporterConfig{Slack: &prowapi.SlackReporterConfig{Channel: "team"}},
+		DependsOn:      []string{"build"},
+		Retry:          &prowapi.RetryPolicy{},
+	}
+
+	pj := shadowProwJob(job, spec, "org/config#123", false)
+	if pj.Spec.Job != "shadow-pull-repo-test" {
+		t.Errorf("expected job to be renamed, got %q", pj.Spec.Job)
+	}
+	if pj.Spec.Report || pj.Spec.ReporterConfig != nil {
+		t.Errorf("expected shadow job not to be reported, got report %t and reporter config %v", pj.Spec.Report, pj.Spec.ReporterConfig)
+	}
+	if pj.Spec.DependsOn != nil || pj.Spec.Retry != nil {
+		t.Errorf("expected shadow job to have no dependencies or retries, got %v and %v", pj.Spec.DependsOn, pj.Spec.Retry)
+	}
+	if pj.Labels[kube.ShadowLabel] != "true" || pj.Labels["team"] != "infra" {
+		t.Errorf("expected shadow label and job labels, got %v", pj.Labels)
+	}
+	if _, ok := pj.Labels["prow.k8s.io/pubsub.topic"]; ok {
+		t.Errorf("expected pubsub labels to be dropped, got %v", pj.Labels)
+	}
+	if pj.Annotations[kube.ShadowOfAnnotation] != job.Name || pj.Annotations[kube.ShadowSourceAnnotation] != "org/config#123" {
+		t.Errorf("expected shadow annotations, got %v", pj.Annotations)
+	}
+	if pj.Annotations["testgrid-dashboards"] != "repo" {
+		t.Errorf("expected job annotations to be kept, got %v", pj.Annotations)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/moonraker"
 	"sigs.k8s.io/prow/pkg/pjutil"
 )
 
@@ -48,6 +49,9 @@ type options struct {
 	org         string
 	repo        string
 
+	inRepoConfig   bool
+	cookiefilePath string
+
 	github       prowflagutil.GitHubOptions
 	githubClient githubClient
 	pullRequest  *github.PullRequest
@@ -102,6 +106,51 @@ func (o *options) genJobSpec(conf *config.Config) (config.JobBase, prowapi.ProwJ
 	return config.JobBase{}, prowapi.ProwJobSpec{}
 }
 
+// genInRepoConfigJobSpec resolves the job from the in-repo config of the repo
+// at the base SHA, with the pull merged in if one was given.
+func (o *options) genInRepoConfigJobSpec(getter config.InRepoConfigGetter) (config.JobBase, prowapi.ProwJobSpec, error) {
+	pjs := prowapi.ProwJobSpec{Refs: &prowapi.Refs{
+		Org:     o.org,
+		Repo:    o.repo,
+		BaseRef: o.baseRef,
+		BaseSHA: o.baseSha,
+	}}
+	if o.pullNumber != 0 {
+		pjs.Refs.Pulls = []prowapi.Pull{{
+			Author:  o.pullAuthor,
+			Number:  o.pullNumber,
+			SHA:     o.pullSha,
+			HeadRef: o.pullHeadRef,
+		}}
+		if err := o.defaultPR(&pjs); err != nil {
+			return config.JobBase{}, prowapi.ProwJobSpec{}, err
+		}
+	}
+	if err := o.defaultBaseRef(&pjs); err != nil {
+		return config.JobBase{}, prowapi.ProwJobSpec{}, err
+	}
+	return pjutil.InRepoConfigSpec(getter, o.jobName, *pjs.Refs)
+}
+
+func (o *options) inRepoConfigGetter(ca *config.Agent) (config.InRepoConfigGetter, error) {
+	if o.config.MoonrakerAddress != "" {
+		moonrakerClient, err := moonraker.NewClient(o.config.MoonrakerAddress, ca)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Moonraker client: %w", err)
+		}
+		return moonrakerClient, nil
+	}
+	gitClient, err := o.github.GitClientFactory(o.cookiefilePath, &o.config.InRepoConfigCacheDirBase, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Git client: %w", err)
+	}
+	ircc, err := config.NewInRepoConfigCache(o.config.InRepoConfigCacheSize, ca, gitClient)
+	if err != nil {
+		return nil, fmt.Errorf("error creating InRepoConfigCache: %w", err)
+	}
+	return ircc, nil
+}
+
 func (o *options) getPullRequest() (*github.PullRequest, error) {
 	if o.pullRequest != nil {
 		return o.pullRequest, nil
@@ -195,6 +244,10 @@ func (o *options) Validate() error {
 		}
 	}
 
+	if o.inRepoConfig && (o.org == "" || o.repo == "") {
+		return errors.New("--in-repo-config requires --org and --repo")
+	}
+
 	return nil
 }
 
@@ -210,6 +263,10 @@ func gatherOptions() options {
 	fs.StringVar(&o.pullHeadRef, "pull-head-ref", "", "Git branch name of the proposed change")
 	fs.BoolVar(&o.triggerJob, "trigger-job", false, "Submit the job to Prow and wait for results")
 	fs.BoolVar(&o.failWithJob, "fail-with-job", false, "Exit with a non-zero exit code if the triggered job fails")
+	fs.BoolVar(&o.inRepoConfig, "in-repo-config", false, "Resolve presubmits and postsubmits of --org/--repo from their in-repo config at the base SHA (and the pull SHA) instead of the central config")
+	fs.StringVar(&o.org, "org", "", "Org of the repo to read the in-repo config of. Only used with --in-repo-config")
+	fs.StringVar(&o.repo, "repo", "", "Repo to read the in-repo config of. Only used with --in-repo-config")
+	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile, leave empty for github or anonymous. Only used with --in-repo-config")
 	o.config.AddFlags(fs)
 	o.kubeOptions.AddFlags(fs)
 	o.github.AddFlags(fs)
@@ -235,11 +292,24 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to get GitHub client")
 	}
-	job, pjs := o.genJobSpec(conf)
+	var job config.JobBase
+	var pjs prowapi.ProwJobSpec
+	if o.inRepoConfig {
+		getter, err := o.inRepoConfigGetter(ca)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create in-repo config getter")
+		}
+		job, pjs, err = o.genInRepoConfigJobSpec(getter)
+		if err != nil {
+			logrus.WithError(err).Fatalf("Failed to resolve job %s from in-repo config", o.jobName)
+		}
+	} else {
+		job, pjs = o.genJobSpec(conf)
+	}
 	if job.Name == "" {
 		logrus.Fatalf("Job %s not found.", o.jobName)
 	}
-	if pjs.Refs != nil {
+	if pjs.Refs != nil && !o.inRepoConfig {
 		o.org = pjs.Refs.Org
 		o.repo = pjs.Refs.Repo
 		if len(pjs.Refs.Pulls) != 0 {
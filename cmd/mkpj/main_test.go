@@ -19,7 +19,10 @@ package main
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
@@ -53,6 +56,27 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "in-repo config",
+			input: options{
+				jobName:      "job",
+				config:       configflagutil.ConfigOptions{ConfigPath: "somewhere"},
+				inRepoConfig: true,
+				org:          "org",
+				repo:         "repo",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "in-repo config without repo",
+			input: options{
+				jobName:      "job",
+				config:       configflagutil.ConfigOptions{ConfigPath: "somewhere"},
+				inRepoConfig: true,
+				org:          "org",
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -126,3 +150,46 @@ func TestDefaultBaseRef(t *testing.T) {
 		})
 	}
 }
+
+type fakeInRepoConfigGetter struct {
+	config.InRepoConfigGetter
+	baseSHA string
+	headSHA string
+}
+
+func (f *fakeInRepoConfigGetter) GetPresubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Presubmit, error) {
+	f.baseSHA, _ = baseSHAGetter()
+	f.headSHA, _ = headSHAGetters[0]()
+	return []config.Presubmit{{JobBase: config.JobBase{Name: "pull-in-repo"}}}, nil
+}
+
+func TestGenInRepoConfigJobSpec(t *testing.T) {
+	fakeGitHubClient := fakegithub.NewFakeClient()
+	fakeGitHubClient.PullRequests = map[int]*github.PullRequest{2: {
+		User: github.User{Login: "author"},
+		Base: github.PullRequestBranch{Ref: "main", SHA: "base-sha"},
+		Head: github.PullRequestBranch{SHA: "head-sha"},
+	}}
+	o := &options{jobName: "pull-in-repo", org: "org", repo: "repo", pullNumber: 2, githubClient: fakeGitHubClient}
+	getter := &fakeInRepoConfigGetter{}
+	job, pjs, err := o.genInRepoConfigJobSpec(getter)
+	if err != nil {
+		t.Fatalf("Error when generating job spec: %v", err)
+	}
+	if job.Name != "pull-in-repo" {
+		t.Errorf("Expected job pull-in-repo, got %q", job.Name)
+	}
+	if getter.baseSHA != "base-sha" || getter.headSHA != "head-sha" {
+		t.Errorf("Expected in-repo config to be read at the defaulted SHAs, got base %q and head %q", getter.baseSHA, getter.headSHA)
+	}
+	expected := prowapi.Refs{
+		Org:     "org",
+		Repo:    "repo",
+		BaseRef: "main",
+		BaseSHA: "base-sha",
+		Pulls:   []prowapi.Pull{{Author: "author", Number: 2, SHA: "head-sha"}},
+	}
+	if diff := cmp.Diff(&expected, pjs.Refs); diff != "" {
+		t.Errorf("Unexpected refs (-want +got):\n%s", diff)
+	}
+}
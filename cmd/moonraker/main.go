@@ -19,6 +19,9 @@ limitations under the License.
 // out of it, as well as caching the result in an in-memory LRU cache. Other
 // Prow components in the same service cluster can go through Moonraker to save
 // the trouble of trying to perform inrepoconfig lookups themselves.
+//
+// If a plugin config is given, Moonraker also resolves and caches the OWNERS
+// files of repos, so that every Hook replica does not have to clone them.
 
 package main
 
@@ -40,12 +43,15 @@ import (
 	"sigs.k8s.io/prow/pkg/flagutil"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	pluginsflagutil "sigs.k8s.io/prow/pkg/flagutil/plugins"
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
 	"sigs.k8s.io/prow/pkg/moonraker"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+	"sigs.k8s.io/prow/pkg/repoowners"
 )
 
 var (
@@ -89,7 +95,8 @@ type options struct {
 	port           int
 	cookiefilePath string
 
-	config configflagutil.ConfigOptions
+	config        configflagutil.ConfigOptions
+	pluginsConfig pluginsflagutil.PluginOptions
 
 	dryRun                 bool
 	gracePeriod            time.Duration
@@ -106,7 +113,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.DurationVar(&o.gracePeriod, "grace-period", 25*time.Second, "On shutdown, try to handle remaining events for the specified duration. Cannot be larger than 30s.")
 	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile, leave empty for github or anonymous")
 	fs.DurationVar(&o.pushGatewayInterval, "push-gateway-interval", time.Minute, "Interval at which prometheus metrics for disk space are pushed.")
-	for _, group := range []flagutil.OptionGroup{&o.github, &o.instrumentationOptions, &o.config} {
+	for _, group := range []flagutil.OptionGroup{&o.github, &o.instrumentationOptions, &o.config, &o.pluginsConfig} {
 		group.AddFlags(fs)
 	}
 
@@ -117,7 +124,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 
 func (o *options) validate() error {
 	var errs []error
-	for _, group := range []flagutil.OptionGroup{&o.github, &o.instrumentationOptions, &o.config} {
+	for _, group := range []flagutil.OptionGroup{&o.github, &o.instrumentationOptions, &o.config, &o.pluginsConfig} {
 		if err := group.Validate(o.dryRun); err != nil {
 			errs = append(errs, err)
 		}
@@ -170,6 +177,37 @@ func main() {
 		InRepoConfigCache: cacheGetter,
 	}
 
+	// OWNERS are only served if the plugin config is known, as it determines
+	// how they are read.
+	if o.pluginsConfig.PluginConfigPath != "" {
+		pluginAgent, err := o.pluginsConfig.PluginAgent()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error starting plugins.")
+		}
+		mdYAMLEnabled := func(org, repo string) bool {
+			return pluginAgent.Config().MDYAMLEnabled(org, repo)
+		}
+		// Collaborators are filtered out by the clients, which have a GitHub
+		// client at hand.
+		skipCollaborators := func(org, repo string) bool {
+			return true
+		}
+		ownersDirDenylist := func() *config.OwnersDirDenylist {
+			res := &config.OwnersDirDenylist{}
+			if l := configAgent.Config().OwnersDirDenylist; l != nil {
+				res = l
+			}
+			return res
+		}
+		resolver := func(org, repo string) ownersconfig.Filenames {
+			return pluginAgent.Config().OwnersFilenames(org, repo)
+		}
+		codeOwnersEnabled := func(org, repo string) bool {
+			return pluginAgent.Config().CodeOwnersEnabled(org, repo)
+		}
+		mr.RepoOwners = repoowners.NewClient(gitClient, nil, mdYAMLEnabled, skipCollaborators, ownersDirDenylist, resolver, codeOwnersEnabled)
+	}
+
 	// If the main config changes (an update to the ConfigMap holding the main
 	// config), we have to reload it because the "in_repo_config" setting which
 	// allowlists repositories may have changed (a repository may have been
@@ -185,6 +223,7 @@ func main() {
 	mux := http.NewServeMux()
 	mux.HandleFunc(fmt.Sprintf("/%s", moonraker.PathPing), mr.ServePing)
 	mux.HandleFunc(fmt.Sprintf("/%s", moonraker.PathGetInrepoconfig), mr.ServeGetInrepoconfig)
+	mux.HandleFunc(fmt.Sprintf("/%s", moonraker.PathGetOwners), mr.ServeGetOwners)
 	server := &http.Server{
 		Addr:    ":" + strconv.Itoa(o.port),
 		Handler: mux,
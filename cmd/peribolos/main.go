@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -40,6 +41,10 @@ const (
 	defaultDelta     = 0.25
 	defaultTokens    = 300
 	defaultBurst     = 100
+
+	// securityManagerRole is the predefined org role that is configured
+	// with security_managers rather than org_role_assignments.
+	securityManagerRole = "security_manager"
 )
 
 type options struct {
@@ -57,12 +62,21 @@ type options struct {
 	fixTeams          bool
 	fixTeamRepos      bool
 	fixRepos          bool
+	fixOrgRoles       bool
 	ignoreInvitees    bool
 	ignoreSecretTeams bool
 	allowRepoArchival bool
 	allowRepoPublish  bool
+	allowRepoTransfer bool
 	github            flagutil.GitHubOptions
 
+	// changeTicketThreshold is the number of org members a run may remove
+	// without a change ticket, 0 disables the requirement.
+	changeTicketThreshold int
+	changeTicket          string
+	changeTicketPattern   string
+	changeTicketRe        *regexp.Regexp
+
 	logLevel string
 }
 
@@ -80,6 +94,9 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 	flags.IntVar(&o.minAdmins, "min-admins", defaultMinAdmins, "Ensure config specifies at least this many admins")
 	flags.BoolVar(&o.requireSelf, "require-self", true, "Ensure --github-token-path user is an admin")
 	flags.Float64Var(&o.maximumDelta, "maximum-removal-delta", defaultDelta, "Fail if config removes more than this fraction of current members")
+	flags.IntVar(&o.changeTicketThreshold, "change-ticket-threshold", 0, "Fail if config removes more than this many org members without --change-ticket (0 disables)")
+	flags.StringVar(&o.changeTicket, "change-ticket", "", "Reference to the ticket approving this change, recorded in the run summary")
+	flags.StringVar(&o.changeTicketPattern, "change-ticket-pattern", "", "If set, --change-ticket must match this regexp, e.g. the URL pattern of the issue tracker")
 	flags.StringVar(&o.config, "config-path", "", "Path to org config.yaml")
 	flags.BoolVar(&o.confirm, "confirm", false, "Mutate github if set")
 	flags.StringVar(&o.dump, "dump", "", "Output current config of this org if set")
@@ -92,8 +109,10 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 	flags.BoolVar(&o.fixTeamMembers, "fix-team-members", false, "Add/remove team members if set")
 	flags.BoolVar(&o.fixTeamRepos, "fix-team-repos", false, "Add/remove team permissions on repos if set")
 	flags.BoolVar(&o.fixRepos, "fix-repos", false, "Create/update repositories if set")
+	flags.BoolVar(&o.fixOrgRoles, "fix-org-roles", false, "Add/remove security manager teams and org role assignments if set")
 	flags.BoolVar(&o.allowRepoArchival, "allow-repo-archival", false, "If set, archiving repos is allowed while updating repos")
 	flags.BoolVar(&o.allowRepoPublish, "allow-repo-publish", false, "If set, making private repos public is allowed while updating repos")
+	flags.BoolVar(&o.allowRepoTransfer, "allow-repo-transfer", false, "If set, repos that moved to another org of the config are transferred while updating repos")
 	flags.StringVar(&o.logLevel, "log-level", logrus.InfoLevel.String(), fmt.Sprintf("Logging level, one of %v", logrus.AllLevels))
 	o.github.AddCustomizedFlags(flags, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
 	if err := flags.Parse(args); err != nil {
@@ -118,6 +137,18 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 		return fmt.Errorf("--maximum-removal-delta=%f must be a non-negative number less than 1.0", o.maximumDelta)
 	}
 
+	if o.changeTicketThreshold < 0 {
+		return fmt.Errorf("--change-ticket-threshold=%d must be non-negative", o.changeTicketThreshold)
+	}
+	if o.changeTicketPattern != "" {
+		if o.changeTicketRe, err = regexp.Compile(o.changeTicketPattern); err != nil {
+			return fmt.Errorf("--change-ticket-pattern invalid: %w", err)
+		}
+		if o.changeTicket != "" && !o.changeTicketRe.MatchString(o.changeTicket) {
+			return fmt.Errorf("--change-ticket=%s does not match --change-ticket-pattern=%s", o.changeTicket, o.changeTicketPattern)
+		}
+	}
+
 	if o.confirm && o.dump != "" && o.github.AppID == "" {
 		return fmt.Errorf("--confirm cannot be used with --dump=%s", o.dump)
 	}
@@ -145,6 +176,10 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 		return fmt.Errorf("--fix-team-repos requires --fix-teams")
 	}
 
+	if o.allowRepoTransfer && !o.fixRepos {
+		return fmt.Errorf("--allow-repo-transfer requires --fix-repos")
+	}
+
 	return nil
 }
 
@@ -189,13 +224,20 @@ func main() {
 	if err := yaml.Unmarshal(raw, &cfg); err != nil {
 		logrus.WithError(err).Fatal("Failed to load configuration")
 	}
+	if err := cfg.ExpandTemplates(); err != nil {
+		logrus.WithError(err).Fatal("Failed to expand member lists and repo templates")
+	}
 
 	for name, orgcfg := range cfg.Orgs {
-		if err := configureOrg(o, githubClient, name, orgcfg); err != nil {
+		if err := configureOrg(o, githubClient, name, orgcfg, cfg.Orgs); err != nil {
 			logrus.Fatalf("Configuration failed: %v", err)
 		}
 	}
-	logrus.Info("Finished syncing configuration.")
+	logger := logrus.NewEntry(logrus.StandardLogger())
+	if o.changeTicket != "" {
+		logger = logger.WithField("change-ticket", o.changeTicket)
+	}
+	logger.Info("Finished syncing configuration.")
 }
 
 type dumpClient interface {
@@ -207,6 +249,10 @@ type dumpClient interface {
 	GetRepo(owner, name string) (github.FullRepo, error)
 	GetRepos(org string, isUser bool) ([]github.Repo, error)
 	BotUser() (*github.UserData, error)
+	ListSecurityManagerTeams(org string) ([]github.Team, error)
+	ListOrgRoles(org string) ([]github.OrgRole, error)
+	ListOrgRoleTeams(org string, roleID int) ([]github.OrgRoleTeam, error)
+	ListOrgRoleUsers(org string, roleID int) ([]github.OrgRoleUser, error)
 }
 
 func dumpOrgConfig(client dumpClient, orgName string, ignoreSecretTeams bool, appID string) (*org.Config, error) {
@@ -372,6 +418,60 @@ func dumpOrgConfig(client dumpClient, orgName string, ignoreSecretTeams bool, ap
 		})
 	}
 
+	securityManagers, err := client.ListSecurityManagerTeams(orgName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security manager teams: %w", err)
+	}
+	logrus.Debugf("Found %d security manager teams", len(securityManagers))
+	for _, t := range securityManagers {
+		if ignoreSecretTeams && org.Privacy(t.Privacy) == org.Secret {
+			continue
+		}
+		out.SecurityManagers = append(out.SecurityManagers, t.Name)
+	}
+
+	roles, err := client.ListOrgRoles(orgName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org roles: %w", err)
+	}
+	logrus.Debugf("Found %d org roles", len(roles))
+	for _, role := range roles {
+		if role.Name == securityManagerRole {
+			continue
+		}
+		logger := logrus.WithFields(logrus.Fields{"id": role.ID, "role": role.Name})
+		teams, err := client.ListOrgRoleTeams(orgName, role.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams of org role %d(%s): %w", role.ID, role.Name, err)
+		}
+		users, err := client.ListOrgRoleUsers(orgName, role.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users of org role %d(%s): %w", role.ID, role.Name, err)
+		}
+		var assignment org.OrgRoleAssignment
+		for _, t := range teams {
+			if t.Assignment == github.OrgRoleAssignmentIndirect || ignoreSecretTeams && org.Privacy(t.Privacy) == org.Secret {
+				continue
+			}
+			logger.WithField("team", t.Name).Debug("Recording team.")
+			assignment.Teams = append(assignment.Teams, t.Name)
+		}
+		for _, u := range users {
+			if u.Assignment == github.OrgRoleAssignmentIndirect {
+				continue
+			}
+			logger.WithField("login", u.Login).Debug("Recording user.")
+			assignment.Users = append(assignment.Users, u.Login)
+		}
+		if len(assignment.Teams) == 0 && len(assignment.Users) == 0 {
+			continue
+		}
+		if out.OrgRoleAssignments == nil {
+			out.OrgRoleAssignments = map[string]org.OrgRoleAssignment{}
+		}
+		out.OrgRoleAssignments[role.Name] = assignment
+	}
+
 	return &out, nil
 }
 
@@ -436,6 +536,16 @@ func configureOrgMembers(opt options, client orgClient, orgName string, orgConfi
 	if d := float64(len(remove)) / float64(len(have.all())); d > opt.maximumDelta {
 		return fmt.Errorf("cannot delete %d memberships or %.3f of %s (exceeds limit of %.3f)", len(remove), d, orgName, opt.maximumDelta)
 	}
+	if opt.changeTicketThreshold > 0 && len(remove) > opt.changeTicketThreshold {
+		if opt.changeTicket == "" {
+			return fmt.Errorf("cannot delete %d memberships of %s without --change-ticket (exceeds threshold of %d)", len(remove), orgName, opt.changeTicketThreshold)
+		}
+		logrus.WithFields(logrus.Fields{
+			"org":           orgName,
+			"removals":      len(remove),
+			"change-ticket": opt.changeTicket,
+		}).Info("Deleting memberships approved by change ticket.")
+	}
 
 	teamMembers := sets.Set[string]{}
 	teamNames := sets.Set[string]{}
@@ -781,6 +891,119 @@ func configureOrgMeta(client orgMetadataClient, orgName string, want org.Metadat
 	return nil
 }
 
+type orgActionsClient interface {
+	GetOrgActionsPermissions(org string) (*github.ActionsPermissions, error)
+	UpdateOrgActionsPermissions(org string, permissions github.ActionsPermissions) error
+	GetOrgSelectedActions(org string) (*github.SelectedActions, error)
+	UpdateOrgSelectedActions(org string, actions github.SelectedActions) error
+	GetOrgWorkflowPermissions(org string) (*github.WorkflowPermissions, error)
+	UpdateOrgWorkflowPermissions(org string, permissions github.WorkflowPermissions) error
+	GetOrgForkPRApprovalPolicy(org string) (github.ForkPRApprovalPolicy, error)
+	UpdateOrgForkPRApprovalPolicy(org string, policy github.ForkPRApprovalPolicy) error
+}
+
+func validateActions(want org.Actions) error {
+	if want.AllowedActions != nil {
+		switch *want.AllowedActions {
+		case github.AllowedActionsAll, github.AllowedActionsLocalOnly, github.AllowedActionsSelected:
+		default:
+			return fmt.Errorf("invalid allowed_actions: %s", *want.AllowedActions)
+		}
+		if want.SelectedActions != nil && *want.AllowedActions != github.AllowedActionsSelected {
+			return fmt.Errorf("selected_actions requires allowed_actions: %s", github.AllowedActionsSelected)
+		}
+	}
+	if want.DefaultWorkflowPermissions != nil {
+		switch *want.DefaultWorkflowPermissions {
+		case github.WorkflowPermissionRead, github.WorkflowPermissionWrite:
+		default:
+			return fmt.Errorf("invalid default_workflow_permissions: %s", *want.DefaultWorkflowPermissions)
+		}
+	}
+	if want.ForkPRApprovalPolicy != nil {
+		switch *want.ForkPRApprovalPolicy {
+		case github.ForkPRApprovalFirstTimeContributorsNewToGitHub, github.ForkPRApprovalFirstTimeContributors, github.ForkPRApprovalAllExternalContributors:
+		default:
+			return fmt.Errorf("invalid fork_pr_approval_policy: %s", *want.ForkPRApprovalPolicy)
+		}
+	}
+	return nil
+}
+
+// configureOrgActions updates the GitHub Actions settings of the org that
+// are declared and differ from the current ones.
+func configureOrgActions(client orgActionsClient, orgName string, want *org.Actions) error {
+	if want == nil {
+		return nil
+	}
+	if err := validateActions(*want); err != nil {
+		return fmt.Errorf("invalid %s actions settings: %w", orgName, err)
+	}
+
+	if want.AllowedActions != nil {
+		cur, err := client.GetOrgActionsPermissions(orgName)
+		if err != nil {
+			return fmt.Errorf("failed to get %s actions permissions: %w", orgName, err)
+		}
+		if cur.AllowedActions != *want.AllowedActions {
+			cur.AllowedActions = *want.AllowedActions
+			if err := client.UpdateOrgActionsPermissions(orgName, *cur); err != nil {
+				return fmt.Errorf("failed to update %s actions permissions: %w", orgName, err)
+			}
+		}
+	}
+
+	if want.SelectedActions != nil {
+		cur, err := client.GetOrgSelectedActions(orgName)
+		if err != nil {
+			return fmt.Errorf("failed to get %s selected actions: %w", orgName, err)
+		}
+		change := false
+		change = updateBool(&cur.GitHubOwnedAllowed, want.SelectedActions.GitHubOwnedAllowed) || change
+		change = updateBool(&cur.VerifiedAllowed, want.SelectedActions.VerifiedAllowed) || change
+		if want.SelectedActions.PatternsAllowed != nil && !sets.New(cur.PatternsAllowed...).Equal(sets.New(want.SelectedActions.PatternsAllowed...)) {
+			cur.PatternsAllowed = want.SelectedActions.PatternsAllowed
+			change = true
+		}
+		if change {
+			if err := client.UpdateOrgSelectedActions(orgName, *cur); err != nil {
+				return fmt.Errorf("failed to update %s selected actions: %w", orgName, err)
+			}
+		}
+	}
+
+	if want.DefaultWorkflowPermissions != nil || want.CanApprovePullRequestReviews != nil {
+		cur, err := client.GetOrgWorkflowPermissions(orgName)
+		if err != nil {
+			return fmt.Errorf("failed to get %s workflow permissions: %w", orgName, err)
+		}
+		change := false
+		if want.DefaultWorkflowPermissions != nil && cur.DefaultWorkflowPermissions != *want.DefaultWorkflowPermissions {
+			cur.DefaultWorkflowPermissions = *want.DefaultWorkflowPermissions
+			change = true
+		}
+		change = updateBool(&cur.CanApprovePullRequestReviews, want.CanApprovePullRequestReviews) || change
+		if change {
+			if err := client.UpdateOrgWorkflowPermissions(orgName, *cur); err != nil {
+				return fmt.Errorf("failed to update %s workflow permissions: %w", orgName, err)
+			}
+		}
+	}
+
+	if want.ForkPRApprovalPolicy != nil {
+		cur, err := client.GetOrgForkPRApprovalPolicy(orgName)
+		if err != nil {
+			return fmt.Errorf("failed to get %s fork pull request approval policy: %w", orgName, err)
+		}
+		if cur != *want.ForkPRApprovalPolicy {
+			if err := client.UpdateOrgForkPRApprovalPolicy(orgName, *want.ForkPRApprovalPolicy); err != nil {
+				return fmt.Errorf("failed to update %s fork pull request approval policy: %w", orgName, err)
+			}
+		}
+	}
+	return nil
+}
+
 type inviteClient interface {
 	ListOrgInvitations(org string) ([]github.OrgInvitation, error)
 }
@@ -803,12 +1026,14 @@ func orgInvitations(opt options, client inviteClient, orgName string) (sets.Set[
 	return invitees, nil
 }
 
-func configureOrg(opt options, client github.Client, orgName string, orgConfig org.Config) error {
+func configureOrg(opt options, client github.Client, orgName string, orgConfig org.Config, managedOrgs map[string]org.Config) error {
 	// Ensure that metadata is configured correctly.
 	if !opt.fixOrg {
 		logrus.Infof("Skipping org metadata configuration")
 	} else if err := configureOrgMeta(client, orgName, orgConfig.Metadata); err != nil {
 		return err
+	} else if err := configureOrgActions(client, orgName, orgConfig.Actions); err != nil {
+		return err
 	}
 
 	invitees, err := orgInvitations(opt, client, orgName)
@@ -826,15 +1051,26 @@ func configureOrg(opt options, client github.Client, orgName string, orgConfig o
 	// Create repositories in the org
 	if !opt.fixRepos {
 		logrus.Info("Skipping org repositories configuration")
-	} else if err := configureRepos(opt, client, orgName, orgConfig); err != nil {
+	} else if err := configureRepos(opt, client, orgName, orgConfig, managedOrgs); err != nil {
 		return fmt.Errorf("failed to configure %s repos: %w", orgName, err)
 	}
 
 	if !opt.fixTeams {
 		logrus.Infof("Skipping team and team member configuration")
-		return nil
+	} else if err := configureOrgTeams(opt, client, orgName, orgConfig); err != nil {
+		return err
+	}
+
+	// Assign the security manager and org roles, after the teams were created
+	if !opt.fixOrgRoles {
+		logrus.Infof("Skipping org role configuration")
+	} else if err := configureOrgRoles(client, orgName, orgConfig, opt.ignoreSecretTeams); err != nil {
+		return fmt.Errorf("failed to configure %s org roles: %w", orgName, err)
 	}
+	return nil
+}
 
+func configureOrgTeams(opt options, client github.Client, orgName string, orgConfig org.Config) error {
 	// Find the id and current state of each declared team (create/delete as necessary)
 	githubTeams, err := configureTeams(client, orgName, orgConfig, opt.maximumDelta, opt.ignoreSecretTeams)
 	if err != nil {
@@ -863,6 +1099,7 @@ type repoClient interface {
 	GetRepos(orgName string, isUser bool) ([]github.Repo, error)
 	CreateRepo(owner string, isUser bool, repo github.RepoCreateRequest) (*github.FullRepo, error)
 	UpdateRepo(owner, name string, repo github.RepoUpdateRequest) (*github.FullRepo, error)
+	TransferRepo(owner, name, newOwner, newName string) error
 }
 
 func newRepoCreateRequest(name string, definition org.Repo) github.RepoCreateRequest {
@@ -974,7 +1211,46 @@ func sanitizeRepoDelta(opt options, delta *github.RepoUpdateRequest) []error {
 	return errs
 }
 
-func configureRepos(opt options, client repoClient, orgName string, orgConfig org.Config) error {
+// movedRepo returns the repo that wantRepo was moved from if it still exists.
+// Moves are declared with previous names of the form org/repo, the org must
+// be configured in managedOrgs and must not declare the repo anymore.
+func movedRepo(client repoClient, orgName, wantName string, wantRepo org.Repo, managedOrgs map[string]org.Config) (*github.FullRepo, error) {
+	var moved *github.FullRepo
+	for _, previous := range wantRepo.Previously {
+		fromOrg, fromName, isMove := strings.Cut(previous, "/")
+		if !isMove {
+			continue
+		}
+		fromConfig, managed := managedOrgs[fromOrg]
+		if !managed || strings.EqualFold(fromOrg, orgName) {
+			return nil, fmt.Errorf("repo %s was moved from %s, but org %s is not another org of the config", wantName, previous, fromOrg)
+		}
+		for name, repo := range fromConfig.Repos {
+			for _, declared := range append([]string{name}, repo.Previously...) {
+				if strings.EqualFold(declared, fromName) {
+					return nil, fmt.Errorf("repo %s was moved from %s, but org %s still declares it", wantName, previous, fromOrg)
+				}
+			}
+		}
+
+		full, err := client.GetRepo(fromOrg, fromName)
+		switch {
+		case github.IsNotFound(err):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to get repo %s: %w", previous, err)
+		case !strings.EqualFold(full.Owner.Login, fromOrg):
+			// GitHub redirects to repos that were transferred already
+			continue
+		case moved != nil:
+			return nil, fmt.Errorf("repo %s was moved from both %s and %s", wantName, moved.FullName, full.FullName)
+		}
+		moved = &full
+	}
+	return moved, nil
+}
+
+func configureRepos(opt options, client repoClient, orgName string, orgConfig org.Config, managedOrgs map[string]org.Config) error {
 	if err := validateRepos(orgConfig.Repos); err != nil {
 		return err
 	}
@@ -1017,6 +1293,26 @@ func configureRepos(opt options, client repoClient, orgName string, orgConfig or
 		}
 
 		if existing == nil {
+			moved, err := movedRepo(client, orgName, wantName, wantRepo, managedOrgs)
+			switch {
+			case err != nil:
+				repoLogger.WithError(err).Error("failed to find the repo this repo was moved from")
+				allErrors = append(allErrors, err)
+				continue
+			case moved != nil && !opt.allowRepoTransfer:
+				repoLogger.WithField("from", moved.FullName).Error("repo was moved from another org: not transferring without --allow-repo-transfer")
+				allErrors = append(allErrors, fmt.Errorf("repo %s was moved from %s, transferring requires --allow-repo-transfer", wantName, moved.FullName))
+				continue
+			case moved != nil:
+				repoLogger.WithField("from", moved.FullName).Info("repo was moved from another org, transferring")
+				if err := client.TransferRepo(moved.Owner.Login, moved.Name, orgName, wantName); err != nil {
+					repoLogger.WithError(err).Error("failed to transfer repository")
+					allErrors = append(allErrors, err)
+				}
+				// GitHub transfers repos asynchronously, the next run updates the repo
+				continue
+			}
+
 			if wantRepo.Archived != nil && *wantRepo.Archived {
 				repoLogger.Error("repo does not exist but is configured as archived: not creating")
 				allErrors = append(allErrors, fmt.Errorf("nonexistent repo configured as archived: %s", wantName))
@@ -1217,6 +1513,148 @@ func configureTeamRepos(client teamRepoClient, githubTeams map[string]github.Tea
 	return utilerrors.NewAggregate(updateErrors)
 }
 
+type orgRolesClient interface {
+	ListTeams(org string) ([]github.Team, error)
+	ListSecurityManagerTeams(org string) ([]github.Team, error)
+	AddSecurityManagerTeam(org, teamSlug string) error
+	RemoveSecurityManagerTeam(org, teamSlug string) error
+	ListOrgRoles(org string) ([]github.OrgRole, error)
+	ListOrgRoleTeams(org string, roleID int) ([]github.OrgRoleTeam, error)
+	ListOrgRoleUsers(org string, roleID int) ([]github.OrgRoleUser, error)
+	AssignOrgRoleToTeam(org, teamSlug string, roleID int) error
+	RemoveOrgRoleFromTeam(org, teamSlug string, roleID int) error
+	AssignOrgRoleToUser(org, user string, roleID int) error
+	RemoveOrgRoleFromUser(org, user string, roleID int) error
+}
+
+// configureOrgRoles grants the security manager role and the configured org
+// roles to the declared teams and users and revokes them from everyone else.
+func configureOrgRoles(client orgRolesClient, orgName string, orgConfig org.Config, ignoreSecretTeams bool) error {
+	if _, ok := orgConfig.OrgRoleAssignments[securityManagerRole]; ok {
+		return fmt.Errorf("the %s role must be configured with security_managers", securityManagerRole)
+	}
+
+	teams, err := client.ListTeams(orgName)
+	if err != nil {
+		return fmt.Errorf("failed to list teams: %w", err)
+	}
+	slugs := map[string]string{}
+	for _, t := range teams {
+		slugs[t.Name] = t.Slug
+	}
+
+	var updateErrors []error
+	haveManagers, err := client.ListSecurityManagerTeams(orgName)
+	if err != nil {
+		return fmt.Errorf("failed to list security manager teams: %w", err)
+	}
+	updateErrors = append(updateErrors, configureRoleTeams(
+		"security manager", orgConfig.SecurityManagers, haveManagers, slugs, ignoreSecretTeams,
+		func(slug string) error { return client.AddSecurityManagerTeam(orgName, slug) },
+		func(slug string) error { return client.RemoveSecurityManagerTeam(orgName, slug) },
+	)...)
+
+	if len(orgConfig.OrgRoleAssignments) == 0 {
+		return utilerrors.NewAggregate(updateErrors)
+	}
+	roles, err := client.ListOrgRoles(orgName)
+	if err != nil {
+		return fmt.Errorf("failed to list org roles: %w", err)
+	}
+	roleIDs := map[string]int{}
+	for _, role := range roles {
+		roleIDs[role.Name] = role.ID
+	}
+	for name, assignment := range orgConfig.OrgRoleAssignments {
+		id, ok := roleIDs[name]
+		if !ok {
+			updateErrors = append(updateErrors, fmt.Errorf("org role %s does not exist", name))
+			continue
+		}
+
+		roleTeams, err := client.ListOrgRoleTeams(orgName, id)
+		if err != nil {
+			updateErrors = append(updateErrors, fmt.Errorf("failed to list teams of org role %d(%s): %w", id, name, err))
+			continue
+		}
+		var haveTeams []github.Team
+		for _, t := range roleTeams {
+			if t.Assignment != github.OrgRoleAssignmentIndirect {
+				haveTeams = append(haveTeams, t.Team)
+			}
+		}
+		updateErrors = append(updateErrors, configureRoleTeams(
+			name, assignment.Teams, haveTeams, slugs, ignoreSecretTeams,
+			func(slug string) error { return client.AssignOrgRoleToTeam(orgName, slug, id) },
+			func(slug string) error { return client.RemoveOrgRoleFromTeam(orgName, slug, id) },
+		)...)
+
+		roleUsers, err := client.ListOrgRoleUsers(orgName, id)
+		if err != nil {
+			updateErrors = append(updateErrors, fmt.Errorf("failed to list users of org role %d(%s): %w", id, name, err))
+			continue
+		}
+		wantUsers := sets.Set[string]{}
+		for _, login := range assignment.Users {
+			wantUsers.Insert(github.NormLogin(login))
+		}
+		haveUsers := sets.Set[string]{}
+		for _, u := range roleUsers {
+			if u.Assignment == github.OrgRoleAssignmentIndirect {
+				continue
+			}
+			haveUsers.Insert(github.NormLogin(u.Login))
+			if !wantUsers.Has(github.NormLogin(u.Login)) {
+				logrus.WithFields(logrus.Fields{"role": name, "login": u.Login}).Info("Removing org role from user.")
+				if err := client.RemoveOrgRoleFromUser(orgName, u.Login, id); err != nil {
+					updateErrors = append(updateErrors, fmt.Errorf("failed to remove org role %s from %s: %w", name, u.Login, err))
+				}
+			}
+		}
+		for _, login := range assignment.Users {
+			if haveUsers.Has(github.NormLogin(login)) {
+				continue
+			}
+			logrus.WithFields(logrus.Fields{"role": name, "login": login}).Info("Assigning org role to user.")
+			if err := client.AssignOrgRoleToUser(orgName, login, id); err != nil {
+				updateErrors = append(updateErrors, fmt.Errorf("failed to assign org role %s to %s: %w", name, login, err))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(updateErrors)
+}
+
+// configureRoleTeams adds the role to the wanted teams that don't have it yet
+// and removes it from the other teams. Secret teams keep the role if
+// ignoreSecretTeams is set.
+func configureRoleTeams(role string, want []string, have []github.Team, slugs map[string]string, ignoreSecretTeams bool, add, remove func(slug string) error) []error {
+	var updateErrors []error
+	wantTeams := sets.New[string](want...)
+	haveTeams := sets.Set[string]{}
+	for _, t := range have {
+		haveTeams.Insert(t.Name)
+		if wantTeams.Has(t.Name) || ignoreSecretTeams && org.Privacy(t.Privacy) == org.Secret {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{"role": role, "team": t.Name}).Info("Removing role from team.")
+		if err := remove(t.Slug); err != nil {
+			updateErrors = append(updateErrors, fmt.Errorf("failed to remove %s role from team %s: %w", role, t.Name, err))
+		}
+	}
+	for _, name := range sets.List(wantTeams.Difference(haveTeams)) {
+		slug, ok := slugs[name]
+		if !ok {
+			updateErrors = append(updateErrors, fmt.Errorf("cannot assign %s role to team %s: team does not exist", role, name))
+			continue
+		}
+		logrus.WithFields(logrus.Fields{"role": role, "team": name}).Info("Assigning role to team.")
+		if err := add(slug); err != nil {
+			updateErrors = append(updateErrors, fmt.Errorf("failed to assign %s role to team %s: %w", role, name, err))
+		}
+	}
+	return updateErrors
+}
+
 // teamMembersClient can list/remove/update people to a team.
 type teamMembersClient interface {
 	ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error)
@@ -62,6 +62,31 @@ func TestOptions(t *testing.T) {
 			name: "reject --dump-full-config without --dump",
 			args: []string{"--config-path=foo", "--dump-full-config"},
 		},
+		{
+			name: "negative --change-ticket-threshold",
+			args: []string{"--config-path=foo", "--change-ticket-threshold=-1"},
+		},
+		{
+			name: "invalid --change-ticket-pattern",
+			args: []string{"--config-path=foo", "--change-ticket-pattern=("},
+		},
+		{
+			name: "--change-ticket does not match --change-ticket-pattern",
+			args: []string{"--config-path=foo", "--change-ticket=JIRA-1", `--change-ticket-pattern=^https://github\.com/org/repo/issues/\d+$`},
+		},
+		{
+			name: "change ticket",
+			args: []string{"--config-path=foo", "--change-ticket-threshold=10", "--change-ticket=https://github.com/org/repo/issues/1"},
+			expected: &options{
+				config:                "foo",
+				minAdmins:             defaultMinAdmins,
+				requireSelf:           true,
+				maximumDelta:          defaultDelta,
+				changeTicketThreshold: 10,
+				changeTicket:          "https://github.com/org/repo/issues/1",
+				logLevel:              "info",
+			},
+		},
 		{
 			name: "maximal delta",
 			args: []string{"--config-path=foo", "--maximum-removal-delta=1"},
@@ -107,6 +132,10 @@ func TestOptions(t *testing.T) {
 			name: "reject --fix-team-members without --fix-teams",
 			args: []string{"--config-path=foo", "--fix-team-members"},
 		},
+		{
+			name: "reject --allow-repo-transfer without --fix-repos",
+			args: []string{"--config-path=foo", "--allow-repo-transfer"},
+		},
 		{
 			name: "allow dump without config",
 			args: []string{"--dump=frogger"},
@@ -508,6 +537,33 @@ func TestConfigureOrgMembers(t *testing.T) {
 			admins: []string{"a", "b", "c", "keep"},
 			err:    true,
 		},
+		{
+			name: "remove too many members without change ticket",
+			opt: options{
+				maximumDelta:          1,
+				changeTicketThreshold: 1,
+			},
+			config: org.Config{
+				Admins: []string{"keep"},
+			},
+			admins:  []string{"keep"},
+			members: []string{"a", "b"},
+			err:     true,
+		},
+		{
+			name: "remove many members with change ticket",
+			opt: options{
+				maximumDelta:          1,
+				changeTicketThreshold: 1,
+				changeTicket:          "https://github.com/org/repo/issues/1",
+			},
+			config: org.Config{
+				Admins: []string{"keep"},
+			},
+			admins:  []string{"keep"},
+			members: []string{"a", "b"},
+			remove:  []string{"a", "b"},
+		},
 		{
 			name: "forgot to add self",
 			opt: options{
@@ -1712,6 +1768,140 @@ func TestConfigureOrgMeta(t *testing.T) {
 	}
 }
 
+type fakeOrgActionsClient struct {
+	permissions         github.ActionsPermissions
+	selectedActions     github.SelectedActions
+	workflowPermissions github.WorkflowPermissions
+	forkPRApproval      github.ForkPRApprovalPolicy
+
+	updated []string
+}
+
+func (c *fakeOrgActionsClient) GetOrgActionsPermissions(org string) (*github.ActionsPermissions, error) {
+	permissions := c.permissions
+	return &permissions, nil
+}
+
+func (c *fakeOrgActionsClient) UpdateOrgActionsPermissions(org string, permissions github.ActionsPermissions) error {
+	c.permissions = permissions
+	c.updated = append(c.updated, "permissions")
+	return nil
+}
+
+func (c *fakeOrgActionsClient) GetOrgSelectedActions(org string) (*github.SelectedActions, error) {
+	actions := c.selectedActions
+	return &actions, nil
+}
+
+func (c *fakeOrgActionsClient) UpdateOrgSelectedActions(org string, actions github.SelectedActions) error {
+	c.selectedActions = actions
+	c.updated = append(c.updated, "selected actions")
+	return nil
+}
+
+func (c *fakeOrgActionsClient) GetOrgWorkflowPermissions(org string) (*github.WorkflowPermissions, error) {
+	permissions := c.workflowPermissions
+	return &permissions, nil
+}
+
+func (c *fakeOrgActionsClient) UpdateOrgWorkflowPermissions(org string, permissions github.WorkflowPermissions) error {
+	c.workflowPermissions = permissions
+	c.updated = append(c.updated, "workflow permissions")
+	return nil
+}
+
+func (c *fakeOrgActionsClient) GetOrgForkPRApprovalPolicy(org string) (github.ForkPRApprovalPolicy, error) {
+	return c.forkPRApproval, nil
+}
+
+func (c *fakeOrgActionsClient) UpdateOrgForkPRApprovalPolicy(org string, policy github.ForkPRApprovalPolicy) error {
+	c.forkPRApproval = policy
+	c.updated = append(c.updated, "fork pr approval")
+	return nil
+}
+
+func TestConfigureOrgActions(t *testing.T) {
+	yes := true
+	selected := github.AllowedActionsSelected
+	localOnly := github.AllowedActionsLocalOnly
+	invalid := github.AllowedActions("some")
+	read := github.WorkflowPermissionRead
+	allExternal := github.ForkPRApprovalAllExternalContributors
+	current := fakeOrgActionsClient{
+		permissions:         github.ActionsPermissions{EnabledRepositories: "all", AllowedActions: github.AllowedActionsAll},
+		selectedActions:     github.SelectedActions{GitHubOwnedAllowed: true, PatternsAllowed: []string{"kubernetes/*"}},
+		workflowPermissions: github.WorkflowPermissions{DefaultWorkflowPermissions: github.WorkflowPermissionWrite},
+		forkPRApproval:      github.ForkPRApprovalFirstTimeContributors,
+	}
+	cases := []struct {
+		name     string
+		want     *org.Actions
+		expected fakeOrgActionsClient
+		err      bool
+	}{
+		{
+			name:     "nothing is changed without actions settings",
+			expected: current,
+		},
+		{
+			name: "nothing is changed if the settings match",
+			want: &org.Actions{
+				SelectedActions:      &org.SelectedActions{GitHubOwnedAllowed: &yes, PatternsAllowed: []string{"kubernetes/*"}},
+				ForkPRApprovalPolicy: &current.forkPRApproval,
+			},
+			expected: current,
+		},
+		{
+			name: "settings that differ are updated",
+			want: &org.Actions{
+				AllowedActions:               &selected,
+				SelectedActions:              &org.SelectedActions{VerifiedAllowed: &yes, PatternsAllowed: []string{"kubernetes-sigs/*"}},
+				DefaultWorkflowPermissions:   &read,
+				CanApprovePullRequestReviews: &yes,
+				ForkPRApprovalPolicy:         &allExternal,
+			},
+			expected: fakeOrgActionsClient{
+				permissions:         github.ActionsPermissions{EnabledRepositories: "all", AllowedActions: github.AllowedActionsSelected},
+				selectedActions:     github.SelectedActions{GitHubOwnedAllowed: true, VerifiedAllowed: true, PatternsAllowed: []string{"kubernetes-sigs/*"}},
+				workflowPermissions: github.WorkflowPermissions{DefaultWorkflowPermissions: github.WorkflowPermissionRead, CanApprovePullRequestReviews: true},
+				forkPRApproval:      github.ForkPRApprovalAllExternalContributors,
+				updated:             []string{"permissions", "selected actions", "workflow permissions", "fork pr approval"},
+			},
+		},
+		{
+			name:     "invalid allowed actions are rejected",
+			want:     &org.Actions{AllowedActions: &invalid},
+			expected: current,
+			err:      true,
+		},
+		{
+			name: "selected actions require allowed actions to be selected",
+			want: &org.Actions{
+				AllowedActions:  &localOnly,
+				SelectedActions: &org.SelectedActions{VerifiedAllowed: &yes},
+			},
+			expected: current,
+			err:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := current
+			err := configureOrgActions(&fc, "org", tc.want)
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("failed to receive error")
+			}
+			if diff := cmp.Diff(tc.expected, fc, cmp.AllowUnexported(fakeOrgActionsClient{})); diff != "" {
+				t.Errorf("settings differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDumpOrgConfig(t *testing.T) {
 	empty := ""
 	hello := "Hello"
@@ -1719,6 +1909,7 @@ func TestDumpOrgConfig(t *testing.T) {
 	yes := true
 	no := false
 	perm := github.Write
+	noPerm := github.RepoPermissionLevel("")
 	pub := org.Privacy("")
 	secret := org.Secret
 	closed := org.Closed
@@ -1738,6 +1929,10 @@ func TestDumpOrgConfig(t *testing.T) {
 		maintainers       map[string][]string
 		repoPermissions   map[string][]github.Repo
 		repos             []github.FullRepo
+		securityManagers  []github.Team
+		orgRoles          []github.OrgRole
+		orgRoleTeams      map[int][]github.OrgRoleTeam
+		orgRoleUsers      map[int][]github.OrgRoleUser
 		expected          org.Config
 		err               bool
 	}{
@@ -2024,6 +2219,58 @@ func TestDumpOrgConfig(t *testing.T) {
 				Repos:   map[string]org.Repo{},
 			},
 		},
+		{
+			name:              "dumps security managers and org roles",
+			ignoreSecretTeams: true,
+			meta: github.Organization{
+				Name: hello,
+			},
+			admins: []string{"admin"},
+			securityManagers: []github.Team{
+				{Name: "security", Slug: "security"},
+				{Name: "hidden", Slug: "hidden", Privacy: string(secret)},
+			},
+			orgRoles: []github.OrgRole{
+				{ID: 1, Name: securityManagerRole},
+				{ID: 2, Name: "auditor"},
+				{ID: 3, Name: "all_repo_read"},
+			},
+			orgRoleTeams: map[int][]github.OrgRoleTeam{
+				1: {{Team: github.Team{Name: "security"}, Assignment: github.OrgRoleAssignmentDirect}},
+				2: {
+					{Team: github.Team{Name: "audit"}, Assignment: github.OrgRoleAssignmentDirect},
+					{Team: github.Team{Name: "audit-child"}, Assignment: github.OrgRoleAssignmentIndirect},
+					{Team: github.Team{Name: "hidden", Privacy: string(secret)}, Assignment: github.OrgRoleAssignmentDirect},
+				},
+			},
+			orgRoleUsers: map[int][]github.OrgRoleUser{
+				2: {
+					{Login: "alice", Assignment: github.OrgRoleAssignmentMixed},
+					{Login: "bob", Assignment: github.OrgRoleAssignmentIndirect},
+				},
+			},
+			expected: org.Config{
+				Metadata: org.Metadata{
+					Name:                         &hello,
+					BillingEmail:                 &empty,
+					Company:                      &empty,
+					Email:                        &empty,
+					Description:                  &empty,
+					Location:                     &empty,
+					HasOrganizationProjects:      &no,
+					HasRepositoryProjects:        &no,
+					DefaultRepositoryPermission:  &noPerm,
+					MembersCanCreateRepositories: &no,
+				},
+				Admins:           []string{"admin"},
+				Teams:            map[string]org.Team{},
+				Repos:            map[string]org.Repo{},
+				SecurityManagers: []string{"security"},
+				OrgRoleAssignments: map[string]org.OrgRoleAssignment{
+					"auditor": {Teams: []string{"audit"}, Users: []string{"alice"}},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -2042,6 +2289,11 @@ func TestDumpOrgConfig(t *testing.T) {
 				maintainers:     tc.maintainers,
 				repoPermissions: tc.repoPermissions,
 				repos:           tc.repos,
+
+				securityManagers: tc.securityManagers,
+				orgRoles:         tc.orgRoles,
+				orgRoleTeams:     tc.orgRoleTeams,
+				orgRoleUsers:     tc.orgRoleUsers,
 			}
 			actual, err := dumpOrgConfig(fc, orgName, tc.ignoreSecretTeams, "")
 			switch {
@@ -2073,6 +2325,11 @@ type fakeDumpClient struct {
 	maintainers     map[string][]string
 	repoPermissions map[string][]github.Repo
 	repos           []github.FullRepo
+
+	securityManagers []github.Team
+	orgRoles         []github.OrgRole
+	orgRoleTeams     map[int][]github.OrgRoleTeam
+	orgRoleUsers     map[int][]github.OrgRoleUser
 }
 
 func (c fakeDumpClient) GetOrg(name string) (*github.Organization, error) {
@@ -2177,6 +2434,22 @@ func (c fakeDumpClient) BotUser() (*github.UserData, error) {
 	return &github.UserData{Login: "admin"}, nil
 }
 
+func (c fakeDumpClient) ListSecurityManagerTeams(org string) ([]github.Team, error) {
+	return c.securityManagers, nil
+}
+
+func (c fakeDumpClient) ListOrgRoles(org string) ([]github.OrgRole, error) {
+	return c.orgRoles, nil
+}
+
+func (c fakeDumpClient) ListOrgRoleTeams(org string, roleID int) ([]github.OrgRoleTeam, error) {
+	return c.orgRoleTeams[roleID], nil
+}
+
+func (c fakeDumpClient) ListOrgRoleUsers(org string, roleID int) ([]github.OrgRoleUser, error) {
+	return c.orgRoleUsers[roleID], nil
+}
+
 func fixup(ret *org.Config) {
 	if ret == nil {
 		return
@@ -2530,12 +2803,197 @@ func TestConfigureTeamRepos(t *testing.T) {
 	}
 }
 
+type fakeOrgRolesClient struct {
+	teams            []github.Team
+	securityManagers []github.Team
+	roles            []github.OrgRole
+	roleTeams        map[int][]github.OrgRoleTeam
+	roleUsers        map[int][]github.OrgRoleUser
+
+	actions []string
+}
+
+func (c *fakeOrgRolesClient) ListTeams(org string) ([]github.Team, error) {
+	return c.teams, nil
+}
+
+func (c *fakeOrgRolesClient) ListSecurityManagerTeams(org string) ([]github.Team, error) {
+	return c.securityManagers, nil
+}
+
+func (c *fakeOrgRolesClient) AddSecurityManagerTeam(org, teamSlug string) error {
+	c.actions = append(c.actions, "add security manager "+teamSlug)
+	return nil
+}
+
+func (c *fakeOrgRolesClient) RemoveSecurityManagerTeam(org, teamSlug string) error {
+	c.actions = append(c.actions, "remove security manager "+teamSlug)
+	return nil
+}
+
+func (c *fakeOrgRolesClient) ListOrgRoles(org string) ([]github.OrgRole, error) {
+	return c.roles, nil
+}
+
+func (c *fakeOrgRolesClient) ListOrgRoleTeams(org string, roleID int) ([]github.OrgRoleTeam, error) {
+	return c.roleTeams[roleID], nil
+}
+
+func (c *fakeOrgRolesClient) ListOrgRoleUsers(org string, roleID int) ([]github.OrgRoleUser, error) {
+	return c.roleUsers[roleID], nil
+}
+
+func (c *fakeOrgRolesClient) AssignOrgRoleToTeam(org, teamSlug string, roleID int) error {
+	c.actions = append(c.actions, fmt.Sprintf("assign %d to team %s", roleID, teamSlug))
+	return nil
+}
+
+func (c *fakeOrgRolesClient) RemoveOrgRoleFromTeam(org, teamSlug string, roleID int) error {
+	c.actions = append(c.actions, fmt.Sprintf("remove %d from team %s", roleID, teamSlug))
+	return nil
+}
+
+func (c *fakeOrgRolesClient) AssignOrgRoleToUser(org, user string, roleID int) error {
+	c.actions = append(c.actions, fmt.Sprintf("assign %d to user %s", roleID, user))
+	return nil
+}
+
+func (c *fakeOrgRolesClient) RemoveOrgRoleFromUser(org, user string, roleID int) error {
+	c.actions = append(c.actions, fmt.Sprintf("remove %d from user %s", roleID, user))
+	return nil
+}
+
+func TestConfigureOrgRoles(t *testing.T) {
+	teams := []github.Team{
+		{Name: "Security", Slug: "security"},
+		{Name: "Old Security", Slug: "old-security"},
+		{Name: "Hidden", Slug: "hidden", Privacy: string(org.Secret)},
+		{Name: "Audit", Slug: "audit"},
+		{Name: "Old Audit", Slug: "old-audit"},
+	}
+	roles := []github.OrgRole{
+		{ID: 1, Name: securityManagerRole},
+		{ID: 2, Name: "auditor"},
+		{ID: 3, Name: "all_repo_read"},
+	}
+	cases := []struct {
+		name              string
+		config            org.Config
+		ignoreSecretTeams bool
+		securityManagers  []github.Team
+		roleTeams         map[int][]github.OrgRoleTeam
+		roleUsers         map[int][]github.OrgRoleUser
+		expected          []string
+		err               bool
+	}{
+		{
+			name:              "reconciles security managers and configured org roles",
+			ignoreSecretTeams: true,
+			config: org.Config{
+				SecurityManagers: []string{"Security"},
+				OrgRoleAssignments: map[string]org.OrgRoleAssignment{
+					"auditor": {Teams: []string{"Audit"}, Users: []string{"bob", "dave"}},
+				},
+			},
+			securityManagers: []github.Team{teams[1], teams[2]},
+			roleTeams: map[int][]github.OrgRoleTeam{
+				2: {
+					{Team: teams[4], Assignment: github.OrgRoleAssignmentDirect},
+					{Team: github.Team{Name: "Audit Child", Slug: "audit-child"}, Assignment: github.OrgRoleAssignmentIndirect},
+				},
+				3: {{Team: teams[0], Assignment: github.OrgRoleAssignmentDirect}},
+			},
+			roleUsers: map[int][]github.OrgRoleUser{
+				2: {
+					{Login: "alice", Assignment: github.OrgRoleAssignmentDirect},
+					{Login: "Bob", Assignment: github.OrgRoleAssignmentMixed},
+					{Login: "carol", Assignment: github.OrgRoleAssignmentIndirect},
+				},
+			},
+			expected: []string{
+				"add security manager security",
+				"assign 2 to team audit",
+				"assign 2 to user dave",
+				"remove 2 from team old-audit",
+				"remove 2 from user alice",
+				"remove security manager old-security",
+			},
+		},
+		{
+			name:             "removes secret security manager teams unless ignored",
+			securityManagers: []github.Team{teams[2]},
+			expected:         []string{"remove security manager hidden"},
+		},
+		{
+			name: "fails for teams that don't exist",
+			config: org.Config{
+				SecurityManagers: []string{"Security", "Missing"},
+			},
+			expected: []string{"add security manager security"},
+			err:      true,
+		},
+		{
+			name: "fails for org roles that don't exist",
+			config: org.Config{
+				OrgRoleAssignments: map[string]org.OrgRoleAssignment{
+					"missing": {Users: []string{"alice"}},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "rejects the security manager role in org role assignments",
+			config: org.Config{
+				OrgRoleAssignments: map[string]org.OrgRoleAssignment{
+					securityManagerRole: {Teams: []string{"Security"}},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := &fakeOrgRolesClient{
+				teams:            teams,
+				securityManagers: tc.securityManagers,
+				roles:            roles,
+				roleTeams:        tc.roleTeams,
+				roleUsers:        tc.roleUsers,
+			}
+			err := configureOrgRoles(fc, "org", tc.config, tc.ignoreSecretTeams)
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("failed to receive error")
+			}
+			sort.Strings(fc.actions)
+			if diff := cmp.Diff(tc.expected, fc.actions); diff != "" {
+				t.Errorf("actions differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 type fakeRepoClient struct {
 	t     *testing.T
 	repos map[string]github.FullRepo
+
+	// otherOrgs holds the repos of other orgs by org and name
+	otherOrgs map[string]map[string]github.FullRepo
+	// transferred records the transfers by the source and target repo
+	transferred map[string]string
 }
 
 func (f fakeRepoClient) GetRepo(owner, name string) (github.FullRepo, error) {
+	if repos, ok := f.otherOrgs[owner]; ok {
+		repo, ok := repos[name]
+		if !ok {
+			return repo, github.NewNotFound()
+		}
+		return repo, nil
+	}
 	repo, ok := f.repos[name]
 	if !ok {
 		return repo, fmt.Errorf("repo not found")
@@ -2627,10 +3085,20 @@ func (f fakeRepoClient) UpdateRepo(owner, name string, want github.RepoUpdateReq
 	return &have, nil
 }
 
+func (f fakeRepoClient) TransferRepo(owner, name, newOwner, newName string) error {
+	if _, ok := f.otherOrgs[owner][name]; !ok {
+		f.t.Errorf("TransferRepo() called on repo that does not exist")
+		return fmt.Errorf("TransferRepo() called on repo that does not exist")
+	}
+	f.transferred[owner+"/"+name] = newOwner + "/" + newName
+	return nil
+}
+
 func makeFakeRepoClient(t *testing.T, repos ...github.FullRepo) fakeRepoClient {
 	fc := fakeRepoClient{
-		repos: make(map[string]github.FullRepo, len(repos)),
-		t:     t,
+		repos:       make(map[string]github.FullRepo, len(repos)),
+		t:           t,
+		transferred: map[string]string{},
 	}
 	for _, repo := range repos {
 		fc.repos[repo.Name] = repo
@@ -2955,9 +3423,9 @@ func TestConfigureRepos(t *testing.T) {
 			fc := makeFakeRepoClient(t, tc.repos...)
 			var err error
 			if len(tc.orgNameOverride) > 0 {
-				err = configureRepos(tc.opts, fc, tc.orgNameOverride, tc.orgConfig)
+				err = configureRepos(tc.opts, fc, tc.orgNameOverride, tc.orgConfig, nil)
 			} else {
-				err = configureRepos(tc.opts, fc, orgName, tc.orgConfig)
+				err = configureRepos(tc.opts, fc, orgName, tc.orgConfig, nil)
 			}
 			if err != nil && !tc.expectError {
 				t.Errorf("%s: unexpected error: %v", tc.description, err)
@@ -2977,6 +3445,105 @@ func TestConfigureRepos(t *testing.T) {
 	}
 }
 
+func TestConfigureRepoTransfers(t *testing.T) {
+	orgName := "new-org"
+	movedConfig := org.Config{
+		Repos: map[string]org.Repo{
+			"moved": {Previously: []string{"old-org/project"}},
+		},
+	}
+	project := github.FullRepo{Repo: github.Repo{
+		Name:     "project",
+		FullName: "old-org/project",
+		Owner:    github.User{Login: "old-org"},
+	}}
+
+	testCases := []struct {
+		description string
+		opts        options
+		managedOrgs map[string]org.Config
+		otherOrgs   map[string]map[string]github.FullRepo
+
+		expectError         bool
+		expectedTransferred map[string]string
+		expectedRepos       []github.Repo
+	}{
+		{
+			description: "moved repo is transferred",
+			opts:        options{allowRepoTransfer: true},
+			managedOrgs: map[string]org.Config{orgName: movedConfig, "old-org": {}},
+			otherOrgs:   map[string]map[string]github.FullRepo{"old-org": {"project": project}},
+
+			expectedTransferred: map[string]string{"old-org/project": "new-org/moved"},
+			expectedRepos:       []github.Repo{},
+		},
+		{
+			description: "moved repo is not transferred without --allow-repo-transfer",
+			managedOrgs: map[string]org.Config{orgName: movedConfig, "old-org": {}},
+			otherOrgs:   map[string]map[string]github.FullRepo{"old-org": {"project": project}},
+
+			expectError:         true,
+			expectedTransferred: map[string]string{},
+			expectedRepos:       []github.Repo{},
+		},
+		{
+			description: "repo is created if the repo it was moved from does not exist",
+			opts:        options{allowRepoTransfer: true},
+			managedOrgs: map[string]org.Config{orgName: movedConfig, "old-org": {}},
+			otherOrgs:   map[string]map[string]github.FullRepo{"old-org": {}},
+
+			expectedTransferred: map[string]string{},
+			expectedRepos:       []github.Repo{{Name: "moved"}},
+		},
+		{
+			description: "repo is not transferred if the org still declares it",
+			opts:        options{allowRepoTransfer: true},
+			managedOrgs: map[string]org.Config{orgName: movedConfig, "old-org": {
+				Repos: map[string]org.Repo{"project": {}},
+			}},
+			otherOrgs: map[string]map[string]github.FullRepo{"old-org": {"project": project}},
+
+			expectError:         true,
+			expectedTransferred: map[string]string{},
+			expectedRepos:       []github.Repo{},
+		},
+		{
+			description: "repo is not transferred from orgs that are not configured",
+			opts:        options{allowRepoTransfer: true},
+			managedOrgs: map[string]org.Config{orgName: movedConfig},
+			otherOrgs:   map[string]map[string]github.FullRepo{"old-org": {"project": project}},
+
+			expectError:         true,
+			expectedTransferred: map[string]string{},
+			expectedRepos:       []github.Repo{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			fc := makeFakeRepoClient(t)
+			fc.otherOrgs = tc.otherOrgs
+			err := configureRepos(tc.opts, fc, orgName, movedConfig, tc.managedOrgs)
+			if err != nil && !tc.expectError {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err == nil && tc.expectError {
+				t.Error("expected error, got none")
+			}
+			if diff := cmp.Diff(tc.expectedTransferred, fc.transferred); diff != "" {
+				t.Errorf("unexpected transfers (-want +got):\n%s", diff)
+			}
+
+			reposAfter, err := fc.GetRepos(orgName, false)
+			if err != nil {
+				t.Fatalf("unexpected GetRepos error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedRepos, reposAfter); diff != "" {
+				t.Errorf("unexpected repos after configureRepos() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateRepos(t *testing.T) {
 	description := "cool repo"
 	testCases := []struct {
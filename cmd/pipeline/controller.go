@@ -678,7 +678,7 @@ func makePipelineGitTask(name string, refs prowjobv1.Refs, pj prowjobv1.ProwJob)
 		revision = refs.BaseRef
 	}
 
-	return pipelinev1.PipelineTask{
+	task := pipelinev1.PipelineTask{
 		TaskRef: &pipelinev1.TaskRef{
 			Name: "git-clone",
 		},
@@ -693,6 +693,39 @@ func makePipelineGitTask(name string, refs prowjobv1.Refs, pj prowjobv1.ProwJob)
 			},
 		},
 	}
+
+	// Clone into the shared source workspace, one sub path per repository.
+	if workspace := pj.Spec.TektonPipelineRunSpec.GetSourceWorkspace(); workspace != "" {
+		subPath := refs.PathAlias
+		if subPath == "" {
+			subPath = fmt.Sprintf("%s/%s", refs.Org, refs.Repo)
+		}
+		task.Workspaces = []pipelinev1.WorkspacePipelineTaskBinding{{
+			Name:      "output",
+			Workspace: workspace,
+			SubPath:   subPath,
+		}}
+	}
+	return task
+}
+
+// setParam sets the string param name to val, replacing an existing param of
+// the same name.
+func setParam(params []pipelinev1.Param, name, val string) []pipelinev1.Param {
+	param := pipelinev1.Param{
+		Name: name,
+		Value: pipelinev1.ParamValue{
+			Type:      pipelinev1.ParamTypeString,
+			StringVal: val,
+		},
+	}
+	for i := range params {
+		if params[i].Name == name {
+			params[i] = param
+			return params
+		}
+	}
+	return append(params, param)
 }
 
 // makePipelineRun creates a pipeline run from prow job
@@ -723,20 +756,49 @@ func makePipelineRun(pj prowjobv1.ProwJob) (*pipelinev1.PipelineRun, error) {
 	if err != nil {
 		return nil, err
 	}
-	for _, key := range sets.List(sets.KeySet[string](env)) {
-		val := env[key]
-		// TODO: make this handle existing values/substitutions.
-		p.Spec.Params = append(p.Spec.Params, pipelinev1.Param{
-			Name: key,
-			Value: pipelinev1.ParamValue{
-				Type:      pipelinev1.ParamTypeString,
-				StringVal: val,
-			},
-		})
+	if mappings := pj.Spec.TektonPipelineRunSpec.GetParamMappings(); len(mappings) > 0 {
+		// Only pass the explicitly mapped params, overriding any static
+		// value set for the same param in the spec.
+		for _, name := range sets.List(sets.KeySet[string](mappings)) {
+			p.Spec.Params = setParam(p.Spec.Params, name, env[mappings[name]])
+		}
+	} else {
+		for _, key := range sets.List(sets.KeySet[string](env)) {
+			val := env[key]
+			// TODO: make this handle existing values/substitutions.
+			p.Spec.Params = append(p.Spec.Params, pipelinev1.Param{
+				Name: key,
+				Value: pipelinev1.ParamValue{
+					Type:      pipelinev1.ParamTypeString,
+					StringVal: val,
+				},
+			})
+		}
+	}
+
+	// Provision the source workspace from the template unless the
+	// PipelineRun already binds it.
+	if tektonSpec := pj.Spec.TektonPipelineRunSpec; tektonSpec != nil && tektonSpec.WorkspaceTemplate != nil {
+		var bound bool
+		for _, ws := range p.Spec.Workspaces {
+			if ws.Name == tektonSpec.SourceWorkspace {
+				bound = true
+				break
+			}
+		}
+		if !bound {
+			p.Spec.Workspaces = append(p.Spec.Workspaces, pipelinev1.WorkspaceBinding{
+				Name:                tektonSpec.SourceWorkspace,
+				VolumeClaimTemplate: tektonSpec.WorkspaceTemplate.DeepCopy(),
+			})
+		}
 	}
 
 	if p.Spec.PipelineSpec != nil {
 		for i, task := range p.Spec.PipelineSpec.Tasks {
+			if task.TaskRef == nil {
+				continue
+			}
 			taskName := task.TaskRef.Name
 			var refs prowjobv1.Refs
 			var suffix string
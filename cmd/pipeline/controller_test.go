@@ -1076,6 +1076,121 @@ func TestMakeResourcesBeta1(t *testing.T) {
 	}
 }
 
+func TestMakeResourcesV1(t *testing.T) {
+	const buildID = "so-many-pipelines"
+	refs := &prowjobv1.Refs{
+		Org:     "org",
+		Repo:    "repo",
+		BaseRef: "main",
+		BaseSHA: "abcdef",
+		Pulls:   []prowjobv1.Pull{{Number: 1, SHA: "123456"}},
+	}
+	template := &corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+	}
+	stringParam := func(name, val string) pipelinev1.Param {
+		return pipelinev1.Param{Name: name, Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: val}}
+	}
+	cases := []struct {
+		name     string
+		spec     prowjobv1.TektonPipelineRunSpec
+		expected pipelinev1.PipelineRunSpec
+	}{
+		{
+			name: "only mapped params are passed",
+			spec: prowjobv1.TektonPipelineRunSpec{
+				V1: &pipelinev1.PipelineRunSpec{
+					Params: []pipelinev1.Param{stringParam("revision", "static"), stringParam("other", "value")},
+				},
+				ParamMappings: map[string]string{
+					"revision":  "PULL_PULL_SHA",
+					"pr-number": "PULL_NUMBER",
+					"missing":   "NOT_SET",
+				},
+			},
+			expected: pipelinev1.PipelineRunSpec{
+				Params: []pipelinev1.Param{
+					stringParam("revision", "123456"),
+					stringParam("other", "value"),
+					stringParam("missing", ""),
+					stringParam("pr-number", "1"),
+				},
+			},
+		},
+		{
+			name: "git tasks clone into the source workspace provisioned from the template",
+			spec: prowjobv1.TektonPipelineRunSpec{
+				V1: &pipelinev1.PipelineRunSpec{
+					PipelineSpec: &pipelinev1.PipelineSpec{
+						Tasks: []pipelinev1.PipelineTask{
+							{Name: "clone", TaskRef: &pipelinev1.TaskRef{Name: config.ProwImplicitGitResource}},
+							{Name: "inline", TaskSpec: &pipelinev1.EmbeddedTask{}},
+						},
+					},
+				},
+				ParamMappings:     map[string]string{"revision": "PULL_BASE_SHA"},
+				SourceWorkspace:   "source",
+				WorkspaceTemplate: template,
+			},
+			expected: pipelinev1.PipelineRunSpec{
+				Params: []pipelinev1.Param{stringParam("revision", "abcdef")},
+				PipelineSpec: &pipelinev1.PipelineSpec{
+					Tasks: []pipelinev1.PipelineTask{
+						{
+							TaskRef: &pipelinev1.TaskRef{Name: "git-clone"},
+							Params: []pipelinev1.Param{
+								{Name: "url", Value: pipelinev1.ParamValue{StringVal: "https://github.com/org/repo.git"}},
+								{Name: "revision", Value: pipelinev1.ParamValue{StringVal: "123456"}},
+							},
+							Workspaces: []pipelinev1.WorkspacePipelineTaskBinding{{Name: "output", Workspace: "source", SubPath: "org/repo"}},
+						},
+						{Name: "inline", TaskSpec: &pipelinev1.EmbeddedTask{}},
+					},
+				},
+				Workspaces: []pipelinev1.WorkspaceBinding{{Name: "source", VolumeClaimTemplate: template}},
+			},
+		},
+		{
+			name: "workspace bound by the pipeline run is not provisioned from the template",
+			spec: prowjobv1.TektonPipelineRunSpec{
+				V1: &pipelinev1.PipelineRunSpec{
+					Workspaces: []pipelinev1.WorkspaceBinding{{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				},
+				ParamMappings:     map[string]string{"revision": "PULL_BASE_SHA"},
+				SourceWorkspace:   "source",
+				WorkspaceTemplate: template,
+			},
+			expected: pipelinev1.PipelineRunSpec{
+				Params:     []pipelinev1.Param{stringParam("revision", "abcdef")},
+				Workspaces: []pipelinev1.WorkspaceBinding{{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowjobv1.ProwJob{}
+			pj.Name = "world"
+			pj.Namespace = "hello"
+			pj.Spec.Type = prowjobv1.PresubmitJob
+			pj.Spec.Job = "ci-job"
+			pj.Spec.Refs = refs
+			pj.Spec.TektonPipelineRunSpec = &tc.spec
+			pj.Status.BuildID = buildID
+
+			actualRun, err := makePipelineRun(pj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actualRun.Spec); diff != "" {
+				t.Errorf("unexpected pipeline run spec (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDescription(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -21,14 +21,19 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -37,6 +42,7 @@ import (
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 	"sigs.k8s.io/prow/pkg/scheduler"
 
+	"sigs.k8s.io/prow/pkg/buildid"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
@@ -54,6 +60,9 @@ var allControllers = sets.New(plank.ControllerName, scheduler.ControllerName)
 type options struct {
 	totURL string
 
+	buildIDConfigMap string
+	buildIDPort      int
+
 	config             configflagutil.ConfigOptions
 	selector           string
 	enabledControllers prowflagutil.Strings
@@ -63,18 +72,26 @@ type options struct {
 	github                 prowflagutil.GitHubOptions // TODO(fejta): remove
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	storage                prowflagutil.StorageClientOptions
+	runtimeFlags           prowflagutil.RuntimeFlagsOptions
+
+	// controllerRuntimeLogLevel is the log level of controller-runtime,
+	// it follows the log level of the Prow config if unset.
+	controllerRuntimeLogLevel string
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	var o options
 	o.enabledControllers = prowflagutil.NewStrings(plank.ControllerName)
 	fs.StringVar(&o.totURL, "tot-url", "", "Tot URL")
+	fs.StringVar(&o.buildIDConfigMap, "build-id-configmap", "", fmt.Sprintf("Name of the ConfigMap in the ProwJob namespace to vend build IDs from instead of tot, e.g. %s.", buildid.DefaultConfigMapName))
+	fs.IntVar(&o.buildIDPort, "build-id-port", 0, "Port to serve the tot API for the build IDs of --build-id-configmap on, for components that still need a tot URL. Disabled if 0.")
 
 	fs.StringVar(&o.selector, "label-selector", labels.Everything().String(), "Label selector to be applied in prowjobs. See https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors for constructing a label selector.")
 	fs.Var(&o.enabledControllers, "enable-controller", fmt.Sprintf("Controllers to enable. Can be passed multiple times. Defaults to controllers: %s", plank.ControllerName))
 
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to GitHub.")
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.instrumentationOptions, &o.config, &o.storage} {
+	fs.StringVar(&o.controllerRuntimeLogLevel, "controller-runtime-log-level", "", "Log level of controller-runtime, e.g. debug. Follows log_level of the Prow config when it is started if unset. Can be changed at runtime with --runtime-flags-dir.")
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.instrumentationOptions, &o.config, &o.storage, &o.runtimeFlags} {
 		group.AddFlags(fs)
 	}
 
@@ -86,7 +103,7 @@ func (o *options) Validate() error {
 	o.github.AllowAnonymous = true
 
 	var errs []error
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.instrumentationOptions, &o.config, &o.storage} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.instrumentationOptions, &o.config, &o.storage, &o.runtimeFlags} {
 		if err := group.Validate(o.dryRun); err != nil {
 			errs = append(errs, err)
 		}
@@ -102,17 +119,31 @@ func (o *options) Validate() error {
 		errs = append(errs, errors.New("no controllers configured"))
 	}
 
+	if o.totURL != "" && o.buildIDConfigMap != "" {
+		errs = append(errs, errors.New("--tot-url and --build-id-configmap are mutually exclusive"))
+	}
+	if o.buildIDPort != 0 && o.buildIDConfigMap == "" {
+		errs = append(errs, errors.New("--build-id-port requires --build-id-configmap"))
+	}
+
 	if _, err := labels.Parse(o.selector); err != nil {
 		errs = append(errs, fmt.Errorf("parse label selector: %w", err))
 	}
 
+	if o.controllerRuntimeLogLevel != "" {
+		if _, err := zapcore.ParseLevel(o.controllerRuntimeLogLevel); err != nil {
+			errs = append(errs, fmt.Errorf("--controller-runtime-log-level: %w", err))
+		}
+	}
+
 	return utilerrors.NewAggregate(errs)
 }
 
 func main() {
 	logrusutil.ComponentInit()
 
-	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	o := gatherOptions(fs, os.Args[1:]...)
 	if err := o.Validate(); err != nil {
 		logrus.WithError(err).Fatal("Invalid options")
 	}
@@ -129,14 +160,37 @@ func main() {
 	cfg := configAgent.Config
 	o.kubernetes.SetDisabledClusters(sets.New(cfg().DisabledClusters...))
 
-	var logOpts []zap.Opts
+	controllerRuntimeLogLevel := uberzap.NewAtomicLevel()
 	if cfg().LogLevel == "debug" {
-		logOpts = append(logOpts, func(o *zap.Options) {
-			lvl := uberzap.NewAtomicLevelAt(uberzap.DebugLevel)
-			o.Level = &lvl
-		})
+		controllerRuntimeLogLevel.SetLevel(uberzap.DebugLevel)
+	}
+	defaultControllerRuntimeLogLevel := controllerRuntimeLogLevel.Level()
+	setControllerRuntimeLogLevel := func() error {
+		if o.controllerRuntimeLogLevel == "" {
+			controllerRuntimeLogLevel.SetLevel(defaultControllerRuntimeLogLevel)
+			return nil
+		}
+		lvl, err := zapcore.ParseLevel(o.controllerRuntimeLogLevel)
+		if err != nil {
+			return err
+		}
+		controllerRuntimeLogLevel.SetLevel(lvl)
+		return nil
+	}
+	if err := setControllerRuntimeLogLevel(); err != nil {
+		logrus.WithError(err).Fatal("Error setting controller-runtime log level.")
+	}
+	ctrlruntimelog.SetLogger(zap.New(func(o *zap.Options) {
+		o.Level = &controllerRuntimeLogLevel
+	}))
+
+	runtimeFlags := o.runtimeFlags.RuntimeFlags(fs)
+	if err := runtimeFlags.OnChange(setControllerRuntimeLogLevel, "controller-runtime-log-level"); err != nil {
+		logrus.WithError(err).Fatal("Error making controller-runtime log level changeable at runtime.")
+	}
+	if err := runtimeFlags.Start(); err != nil {
+		logrus.WithError(err).Fatal("Error watching runtime flags.")
 	}
-	ctrlruntimelog.SetLogger(zap.New(logOpts...))
 
 	infrastructureClusterConfig, err := o.kubernetes.InfrastructureClusterConfig(o.dryRun)
 	if err != nil {
@@ -184,7 +238,7 @@ func main() {
 		}
 	}
 
-	opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+	opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile, o.storage.AzureCredentialsFile)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error creating opener")
 	}
@@ -204,14 +258,30 @@ func main() {
 		logrus.WithError(err).Fatal("Failed to resolve known clusters in kubeconfig.")
 	}
 
+	var buildIDAllocator *buildid.Allocator
+	if o.buildIDConfigMap != "" {
+		// Build IDs must not be read from the cache of the manager, as
+		// stale reads would make most updates conflict.
+		client, err := ctrlruntimeclient.New(infrastructureClusterConfig, ctrlruntimeclient.Options{})
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to construct client for build IDs.")
+		}
+		buildIDAllocator = buildid.NewAllocator(client, cfg().ProwJobNamespace, o.buildIDConfigMap)
+		if o.buildIDPort != 0 {
+			mux := http.NewServeMux()
+			mux.Handle("/vend/", buildIDAllocator)
+			interrupts.ListenAndServe(&http.Server{Addr: ":" + strconv.Itoa(o.buildIDPort), Handler: mux}, 5*time.Second)
+		}
+	}
+
 	if enabledControllersSet.Has(plank.ControllerName) {
-		if err := plank.Add(mgr, buildClusters, knownClusters, cfg, opener, o.totURL, o.selector); err != nil {
+		if err := plank.Add(mgr, buildClusters, knownClusters, cfg, opener, o.totURL, buildIDAllocator, o.selector); err != nil {
 			logrus.WithError(err).Fatal("Failed to add plank to manager")
 		}
 	}
 
 	if enabledControllersSet.Has(scheduler.ControllerName) {
-		if err := scheduler.Add(mgr, cfg, 1); err != nil {
+		if err := scheduler.Add(mgr, cfg, opener, 1); err != nil {
 			logrus.WithError(err).Fatal("Failed to add scheduler to manager")
 		}
 	}
@@ -0,0 +1,540 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+)
+
+var linkRe = regexp.MustCompile(`^([0-9]+)\.txt$`)
+
+// Prometheus Metrics
+var (
+	jobGCMetrics = struct {
+		runsDeleted  *prometheus.CounterVec
+		bytesDeleted *prometheus.CounterVec
+		errors       *prometheus.CounterVec
+		timeUsed     prometheus.Gauge
+	}{
+		runsDeleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_gc_runs_deleted",
+			Help: "Number of job runs whose artifacts were deleted, or would have been in dry-run mode.",
+		}, []string{
+			"repo",
+			"result",
+			"dry_run",
+		}),
+		bytesDeleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_gc_bytes_deleted",
+			Help: "Size of the artifacts that were deleted, or would have been in dry-run mode.",
+		}, []string{
+			"repo",
+			"dry_run",
+		}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_gc_errors",
+			Help: "Number of errors which occurred while collecting the artifacts of a job.",
+		}, []string{
+			"repo",
+		}),
+		timeUsed: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "prow_job_gc_loop_duration_seconds",
+			Help: "Time used in each garbage collection.",
+		}),
+	}
+)
+
+func init() {
+	prometheus.MustRegister(jobGCMetrics.runsDeleted)
+	prometheus.MustRegister(jobGCMetrics.bytesDeleted)
+	prometheus.MustRegister(jobGCMetrics.errors)
+	prometheus.MustRegister(jobGCMetrics.timeUsed)
+}
+
+type collector struct {
+	logger *logrus.Entry
+	opener pkgio.Opener
+	config config.Getter
+	dryRun bool
+}
+
+// jobArtifacts is where the artifacts of the runs of a job are stored
+// and the policies they are retained by.
+type jobArtifacts struct {
+	job    string
+	bucket string
+	// roots are the prefixes the runs of the job are listed under.
+	roots []string
+	// policies holds the policies of the repos that statically configure
+	// a job of this name by org/repo. Jobs of the same name share their
+	// artifacts, so if more than one repo configures the job, the runs are
+	// attributed to their repo from their started.json.
+	policies map[string]*config.JobGCPolicy
+	// inRepo is set for the jobs that are not statically configured, but
+	// found in the bucket of a repo that enables in-repo config. Their runs
+	// are attributed to their repo from their started.json as well, and
+	// retained by its policy if it enables in-repo config.
+	inRepo bool
+}
+
+// repo returns the repo of the runs of the job if they are all retained by
+// the policy of the same repo.
+func (j jobArtifacts) repo() (string, bool) {
+	if j.inRepo || len(j.policies) != 1 {
+		return "", false
+	}
+	for repo := range j.policies {
+		return repo, true
+	}
+	return "", false
+}
+
+// run is a single run of a job found in the bucket.
+type run struct {
+	id uint64
+	// dir is the prefix of the artifacts of the run, empty until
+	// resolved from link.
+	dir string
+	// link is the key of the object linking to dir, if any.
+	link string
+}
+
+// collect deletes the artifacts of all jobs that are past their retention.
+func (c *collector) collect(ctx context.Context, now time.Time) {
+	start := time.Now()
+	cfg := c.config()
+	jobs := jobsToCollect(cfg)
+	inRepoJobs, err := c.inRepoJobsToCollect(ctx, cfg, jobs)
+	if err != nil {
+		c.logger.WithError(err).Warn("Failed to find all jobs configured in-repo.")
+		jobGCMetrics.errors.WithLabelValues("").Inc()
+	}
+	jobs = append(jobs, inRepoJobs...)
+	c.logger.WithField("jobs", len(jobs)).Info("Collecting job artifacts.")
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		log := c.logger.WithFields(logrus.Fields{"job": job.job, "bucket": job.bucket})
+		if err := c.collectJob(ctx, log, cfg, job, now); err != nil {
+			log.WithError(err).Warn("Failed to collect job artifacts.")
+			repo, _ := job.repo()
+			jobGCMetrics.errors.WithLabelValues(repo).Inc()
+		}
+	}
+	jobGCMetrics.timeUsed.Set(time.Since(start).Seconds())
+	c.logger.WithField("duration", time.Since(start).String()).Info("Collected job artifacts.")
+}
+
+// jobsToCollect returns the artifacts of all the statically configured
+// jobs that a retention policy applies to.
+func jobsToCollect(cfg *config.Config) []jobArtifacts {
+	var jobs []jobArtifacts
+	byKey := map[string]int{}
+	add := func(base config.JobBase, jobType prowapi.ProwJobType, org, repo string) {
+		policy := cfg.JobGC.RetentionFor(org, repo)
+		if policy == nil || base.DecorationConfig == nil || base.DecorationConfig.GCSConfiguration == nil || base.DecorationConfig.GCSConfiguration.Bucket == "" {
+			return
+		}
+		bucket, err := prowapi.ParsePath(base.DecorationConfig.GCSConfiguration.Bucket)
+		if err != nil {
+			logrus.WithError(err).WithField("job", base.Name).Warn("Invalid bucket, skipping job.")
+			return
+		}
+		roots := []string{gcs.RootForSpec(&downwardapi.JobSpec{Type: jobType, Job: base.Name})}
+		if jobType == prowapi.PresubmitJob {
+			// Batch runs are not linked from the directory of the job.
+			roots = append(roots, path.Join(gcs.PRLogs, "pull", "batch", base.Name))
+		}
+		orgRepo := org
+		if repo != "" {
+			orgRepo = org + "/" + repo
+		}
+		key := bucket.BucketWithScheme() + "/" + roots[0]
+		if i, seen := byKey[key]; seen {
+			jobs[i].policies[orgRepo] = policy
+			return
+		}
+		byKey[key] = len(jobs)
+		jobs = append(jobs, jobArtifacts{
+			job:      base.Name,
+			bucket:   bucket.BucketWithScheme(),
+			roots:    roots,
+			policies: map[string]*config.JobGCPolicy{orgRepo: policy},
+		})
+	}
+
+	for _, orgRepo := range sets.List(sets.KeySet(cfg.PresubmitsStatic)) {
+		org, repo, err := config.SplitRepoName(orgRepo)
+		if err != nil {
+			continue
+		}
+		for _, job := range cfg.PresubmitsStatic[orgRepo] {
+			add(job.JobBase, prowapi.PresubmitJob, org, repo)
+		}
+	}
+	for _, orgRepo := range sets.List(sets.KeySet(cfg.PostsubmitsStatic)) {
+		org, repo, err := config.SplitRepoName(orgRepo)
+		if err != nil {
+			continue
+		}
+		for _, job := range cfg.PostsubmitsStatic[orgRepo] {
+			add(job.JobBase, prowapi.PostsubmitJob, org, repo)
+		}
+	}
+	for _, job := range cfg.AllPeriodics() {
+		var org, repo string
+		if len(job.ExtraRefs) > 0 {
+			org, repo = job.ExtraRefs[0].Org, job.ExtraRefs[0].Repo
+		}
+		add(job.JobBase, prowapi.PeriodicJob, org, repo)
+	}
+	return jobs
+}
+
+// inRepoJobsToCollect returns the artifacts of the jobs found in the buckets
+// of the repos that enable in-repo config, which are not statically
+// configured, if a retention policy applies to any repo.
+func (c *collector) inRepoJobsToCollect(ctx context.Context, cfg *config.Config, static []jobArtifacts) ([]jobArtifacts, error) {
+	if cfg.JobGC.Default == nil && len(cfg.JobGC.Repos) == 0 {
+		return nil, nil
+	}
+	buckets := sets.New[string]()
+	for _, identifier := range sets.List(sets.KeySet(cfg.InRepoConfig.Enabled)) {
+		if !*cfg.InRepoConfig.Enabled[identifier] {
+			continue
+		}
+		dc := cfg.Plank.GuessDefaultDecorationConfig(identifier, prowapi.DefaultClusterAlias)
+		if dc == nil || dc.GCSConfiguration == nil || dc.GCSConfiguration.Bucket == "" {
+			continue
+		}
+		bucket, err := prowapi.ParsePath(dc.GCSConfiguration.Bucket)
+		if err != nil {
+			logrus.WithError(err).WithField("identifier", identifier).Warn("Invalid default bucket, skipping in-repo config.")
+			continue
+		}
+		buckets.Insert(bucket.BucketWithScheme())
+	}
+	seen := sets.New[string]()
+	for _, job := range static {
+		seen.Insert(job.bucket + "/" + job.roots[0])
+	}
+
+	var jobs []jobArtifacts
+	var errs []error
+	for _, bucket := range sets.List(buckets) {
+		for _, jobType := range []prowapi.ProwJobType{prowapi.PresubmitJob, prowapi.PostsubmitJob} {
+			// The directory of a job is the root of its runs without its name.
+			dir := path.Dir(gcs.RootForSpec(&downwardapi.JobSpec{Type: jobType, Job: "job"}))
+			names, err := c.listDirs(ctx, bucket, dir)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to list jobs under %s/%s: %w", bucket, dir, err))
+				continue
+			}
+			for _, name := range names {
+				roots := []string{path.Join(dir, name)}
+				if seen.Has(bucket + "/" + roots[0]) {
+					continue
+				}
+				if jobType == prowapi.PresubmitJob {
+					roots = append(roots, path.Join(gcs.PRLogs, "pull", "batch", name))
+				}
+				jobs = append(jobs, jobArtifacts{job: name, bucket: bucket, roots: roots, inRepo: true})
+			}
+		}
+	}
+	return jobs, utilerrors.NewAggregate(errs)
+}
+
+// listDirs lists the names of the directories under dir.
+func (c *collector) listDirs(ctx context.Context, bucket, dir string) ([]string, error) {
+	it, err := c.opener.Iterator(ctx, fmt.Sprintf("%s/%s/", bucket, dir), "/")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		attrs, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return names, err
+		}
+		if attrs.IsDir {
+			names = append(names, path.Base(strings.TrimSuffix(attrs.Name, "/")))
+		}
+	}
+	return names, nil
+}
+
+func (c *collector) collectJob(ctx context.Context, log *logrus.Entry, cfg *config.Config, job jobArtifacts, now time.Time) error {
+	var errs []error
+	for _, root := range job.roots {
+		runs, err := c.listRuns(ctx, job.bucket, root)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list runs under %s: %w", root, err))
+			continue
+		}
+		// Newest runs first.
+		sort.Slice(runs, func(i, j int) bool { return runs[i].id > runs[j].id })
+		// The latest runs are kept per repo.
+		kept := map[string]int{}
+		for _, r := range runs {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			repo, policy, err := c.runPolicy(ctx, log, cfg, job, &r)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to find the policy of run %d: %w", r.id, err))
+				continue
+			}
+			if policy == nil {
+				continue
+			}
+			if kept[repo] < policy.KeepRuns {
+				kept[repo]++
+				continue
+			}
+			if err := c.collectRun(ctx, log, job.bucket, repo, policy, r, now); err != nil {
+				errs = append(errs, fmt.Errorf("failed to collect run %d: %w", r.id, err))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// runPolicy returns the repo of the run and the policy it is retained by.
+// Runs that can't be attributed to exactly one repo with a policy are left
+// alone, they have no policy.
+func (c *collector) runPolicy(ctx context.Context, log *logrus.Entry, cfg *config.Config, job jobArtifacts, r *run) (string, *config.JobGCPolicy, error) {
+	if repo, ok := job.repo(); ok {
+		return repo, job.policies[repo], nil
+	}
+	if r.dir == "" {
+		dir, err := c.resolveLink(ctx, job.bucket, r.link)
+		if err != nil {
+			return "", nil, err
+		}
+		r.dir = dir
+	}
+	var started metadata.Started
+	if err := c.readJSON(ctx, job.bucket+"/"+r.dir+prowapi.StartedStatusFile, &started); err != nil {
+		if pkgio.IsNotExist(err) {
+			log.WithField("dir", r.dir).Debug("Run has no started metadata to find its repo, skipping.")
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+	// The repos of a run include its extra refs, the policies of the repos
+	// that statically configure the job take precedence.
+	static, inRepo := map[string]*config.JobGCPolicy{}, map[string]*config.JobGCPolicy{}
+	for repo := range started.Repos {
+		if policy, ok := job.policies[repo]; ok {
+			static[repo] = policy
+			continue
+		}
+		org, name, err := config.SplitRepoName(repo)
+		if err != nil || !cfg.InRepoConfigEnabled(repo) {
+			continue
+		}
+		if policy := cfg.JobGC.RetentionFor(org, name); policy != nil {
+			inRepo[repo] = policy
+		}
+	}
+	policies := static
+	if len(policies) == 0 {
+		policies = inRepo
+	}
+	repos := sets.List(sets.KeySet(policies))
+	if len(repos) != 1 {
+		log.WithFields(logrus.Fields{"dir": r.dir, "repos": repos}).Debug("Run can't be attributed to exactly one repo with a policy, skipping.")
+		return "", nil, nil
+	}
+	return repos[0], policies[repos[0]], nil
+}
+
+// listRuns lists the runs under root, which either holds a directory per
+// run or a link per run to its directory.
+func (c *collector) listRuns(ctx context.Context, bucket, root string) ([]run, error) {
+	it, err := c.opener.Iterator(ctx, fmt.Sprintf("%s/%s/", bucket, root), "/")
+	if err != nil {
+		return nil, err
+	}
+	var runs []run
+	for {
+		attrs, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return runs, err
+		}
+		if attrs.IsDir {
+			if id, err := strconv.ParseUint(path.Base(attrs.Name), 10, 64); err == nil {
+				runs = append(runs, run{id: id, dir: strings.TrimSuffix(attrs.Name, "/") + "/"})
+			}
+			continue
+		}
+		if match := linkRe.FindStringSubmatch(attrs.ObjName); match != nil {
+			if id, err := strconv.ParseUint(match[1], 10, 64); err == nil {
+				runs = append(runs, run{id: id, link: attrs.Name})
+			}
+		}
+	}
+	return runs, nil
+}
+
+func (c *collector) collectRun(ctx context.Context, log *logrus.Entry, bucket, repo string, policy *config.JobGCPolicy, r run, now time.Time) error {
+	if r.dir == "" {
+		dir, err := c.resolveLink(ctx, bucket, r.link)
+		if err != nil {
+			return err
+		}
+		r.dir = dir
+	}
+
+	var finished metadata.Finished
+	var started metadata.Started
+	var timestamp int64
+	finishedErr := c.readJSON(ctx, bucket+"/"+r.dir+prowapi.FinishedStatusFile, &finished)
+	switch {
+	case finishedErr == nil && finished.Timestamp != nil:
+		timestamp = *finished.Timestamp
+	case finishedErr != nil && !pkgio.IsNotExist(finishedErr):
+		return finishedErr
+	default:
+		// Runs that never finished are aged from their start.
+		if err := c.readJSON(ctx, bucket+"/"+r.dir+prowapi.StartedStatusFile, &started); err != nil {
+			if pkgio.IsNotExist(err) {
+				log.WithField("dir", r.dir).Debug("Run has neither started nor finished metadata, skipping.")
+				return nil
+			}
+			return err
+		}
+		timestamp = started.Timestamp
+	}
+
+	passed := finishedErr == nil && ((finished.Passed != nil && *finished.Passed) || finished.Result == "SUCCESS")
+	maxAge, result := policy.GetMaxAge().Duration, "success"
+	if !passed {
+		maxAge, result = policy.GetMaxFailureAge().Duration, "failure"
+	}
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age <= maxAge {
+		return nil
+	}
+
+	log = log.WithFields(logrus.Fields{"repo": repo, "dir": r.dir, "result": result, "age": age.String(), "dry-run": c.dryRun})
+	size, err := c.deleteRun(ctx, bucket, r)
+	if err != nil {
+		return err
+	}
+	log.WithField("bytes", size).Info("Deleted run artifacts.")
+	dryRun := strconv.FormatBool(c.dryRun)
+	jobGCMetrics.runsDeleted.WithLabelValues(repo, result, dryRun).Inc()
+	jobGCMetrics.bytesDeleted.WithLabelValues(repo, dryRun).Add(float64(size))
+	return nil
+}
+
+// resolveLink returns the directory of the run the link points to, e.g.
+// pr-logs/pull/org_repo/123/job/456/ for gs://bucket/pr-logs/pull/org_repo/123/job/456.
+func (c *collector) resolveLink(ctx context.Context, bucket, link string) (string, error) {
+	content, err := pkgio.ReadContent(ctx, c.logger, c.opener, bucket+"/"+link)
+	if err != nil {
+		return "", fmt.Errorf("failed to read link %s: %w", link, err)
+	}
+	u, err := url.Parse(strings.TrimSpace(string(content)))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse link %s: %w", link, err)
+	}
+	dir := strings.Trim(u.Path, "/")
+	if dir == "" {
+		return "", fmt.Errorf("link %s does not point to a directory", link)
+	}
+	return dir + "/", nil
+}
+
+func (c *collector) readJSON(ctx context.Context, p string, v interface{}) error {
+	content, err := pkgio.ReadContent(ctx, c.logger, c.opener, p)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
+
+// deleteRun deletes all artifacts of the run and returns their size. The
+// metadata files and the link are deleted last so that a run whose deletion
+// failed is retried on the next collection.
+func (c *collector) deleteRun(ctx context.Context, bucket string, r run) (int64, error) {
+	it, err := c.opener.Iterator(ctx, bucket+"/"+r.dir, "")
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	var objects, last []string
+	for {
+		attrs, err := it.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		size += attrs.Size
+		switch attrs.Name {
+		case r.dir + prowapi.StartedStatusFile, r.dir + prowapi.FinishedStatusFile:
+			last = append(last, attrs.Name)
+		default:
+			objects = append(objects, attrs.Name)
+		}
+	}
+	if r.link != "" {
+		last = append(last, r.link)
+	}
+	if c.dryRun {
+		return size, nil
+	}
+	for _, object := range append(objects, last...) {
+		if err := c.opener.Delete(ctx, bucket+"/"+object); err != nil && !pkgio.IsNotExist(err) {
+			return 0, fmt.Errorf("failed to delete %s: %w", object, err)
+		}
+	}
+	return size, nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestJobsToCollect(t *testing.T) {
+	decorated := func(name, bucket string) config.JobBase {
+		return config.JobBase{
+			Name: name,
+			UtilityConfig: config.UtilityConfig{
+				DecorationConfig: &prowapi.DecorationConfig{
+					GCSConfiguration: &prowapi.GCSConfiguration{Bucket: bucket},
+				},
+			},
+		}
+	}
+	orgPolicy := config.JobGCPolicy{KeepRuns: 10}
+	repoPolicy := config.JobGCPolicy{KeepRuns: 1}
+	defaultPolicy := config.JobGCPolicy{KeepRuns: 100}
+
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {
+					{JobBase: decorated("pull-job", "gs://bucket")},
+					{JobBase: config.JobBase{Name: "undecorated"}},
+				},
+				"other/repo": {{JobBase: decorated("pull-other", "bucket")}},
+				"org/fork":   {{JobBase: decorated("pull-job", "gs://bucket")}},
+			},
+			PostsubmitsStatic: map[string][]config.Postsubmit{
+				"org/another": {{JobBase: decorated("post-job", "s3://bucket")}},
+			},
+			Periodics: []config.Periodic{
+				{JobBase: decorated("ci-job", "gs://bucket")},
+				{JobBase: decorated("ci-repo-job", "gs://bucket")},
+			},
+		},
+		ProwConfig: config.ProwConfig{
+			JobGC: config.JobGC{
+				Repos: map[string]config.JobGCPolicy{
+					"org":      orgPolicy,
+					"org/repo": repoPolicy,
+				},
+			},
+		},
+	}
+	cfg.Periodics[1].ExtraRefs = []prowapi.Refs{{Org: "org", Repo: "repo"}}
+
+	expected := []jobArtifacts{
+		{job: "pull-job", bucket: "gs://bucket", roots: []string{"pr-logs/directory/pull-job", "pr-logs/pull/batch/pull-job"}, policies: map[string]*config.JobGCPolicy{"org/fork": &orgPolicy, "org/repo": &repoPolicy}},
+		{job: "post-job", bucket: "s3://bucket", roots: []string{"logs/post-job"}, policies: map[string]*config.JobGCPolicy{"org/another": &orgPolicy}},
+		{job: "ci-repo-job", bucket: "gs://bucket", roots: []string{"logs/ci-repo-job"}, policies: map[string]*config.JobGCPolicy{"org/repo": &repoPolicy}},
+	}
+	if diff := cmp.Diff(expected, jobsToCollect(cfg), cmp.AllowUnexported(jobArtifacts{})); diff != "" {
+		t.Errorf("unexpected jobs without default policy (-want +got):\n%s", diff)
+	}
+
+	cfg.JobGC.Default = &defaultPolicy
+	expected = append(expected[:1],
+		jobArtifacts{job: "pull-other", bucket: "gs://bucket", roots: []string{"pr-logs/directory/pull-other", "pr-logs/pull/batch/pull-other"}, policies: map[string]*config.JobGCPolicy{"other/repo": &defaultPolicy}},
+		expected[1],
+		jobArtifacts{job: "ci-job", bucket: "gs://bucket", roots: []string{"logs/ci-job"}, policies: map[string]*config.JobGCPolicy{"": &defaultPolicy}},
+		expected[2],
+	)
+	if diff := cmp.Diff(expected, jobsToCollect(cfg), cmp.AllowUnexported(jobArtifacts{})); diff != "" {
+		t.Errorf("unexpected jobs with default policy (-want +got):\n%s", diff)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	day := int64(24 * 60 * 60)
+	finished := func(dir string, age int64, passed bool) []fakestorage.Object {
+		return []fakestorage.Object{
+			{BucketName: "bucket", Name: dir + "/started.json", Content: []byte(fmt.Sprintf(`{"timestamp": %d}`, now.Unix()-age-60))},
+			{BucketName: "bucket", Name: dir + "/finished.json", Content: []byte(fmt.Sprintf(`{"timestamp": %d, "passed": %t}`, now.Unix()-age, passed))},
+			{BucketName: "bucket", Name: dir + "/build-log.txt", Content: []byte("log")},
+			{BucketName: "bucket", Name: dir + "/artifacts/junit.xml", Content: []byte("<testsuites/>")},
+		}
+	}
+	finishedIn := func(repo, dir string, age int64, passed bool) []fakestorage.Object {
+		objects := finished(dir, age, passed)
+		objects[0].Content = []byte(fmt.Sprintf(`{"timestamp": %d, "repos": {%q: "main", "org/extra": "main"}}`, now.Unix()-age-60, repo))
+		return objects
+	}
+	var objects []fakestorage.Object
+	// Periodic runs, the latest one is always kept.
+	objects = append(objects, finished("logs/ci-job/1010", 9*day, true)...) // kept, latest run
+	objects = append(objects, finished("logs/ci-job/105", 3*day, false)...) // kept, failed 3 days ago
+	objects = append(objects, finished("logs/ci-job/104", 3*day, true)...)  // deleted, passed 3 days ago
+	objects = append(objects, finished("logs/ci-job/103", day/2, true)...)  // kept, passed half a day ago
+	objects = append(objects, finished("logs/ci-job/102", 8*day, false)...) // deleted, failed 8 days ago
+	objects = append(objects,
+		// deleted, started 8 days ago and never finished
+		fakestorage.Object{BucketName: "bucket", Name: "logs/ci-job/101/started.json", Content: []byte(fmt.Sprintf(`{"timestamp": %d}`, now.Unix()-8*day))},
+		fakestorage.Object{BucketName: "bucket", Name: "logs/ci-job/latest-build.txt", Content: []byte("1010")},
+	)
+	// Presubmit runs linked from the directory of the job.
+	objects = append(objects, finished("pr-logs/pull/org_repo/1/pull-job/202", day/2, true)...)
+	objects = append(objects, finished("pr-logs/pull/org_repo/1/pull-job/201", 2*day, true)...)
+	objects = append(objects, finished("pr-logs/pull/batch/pull-job/204", day/2, true)...)
+	objects = append(objects, finished("pr-logs/pull/batch/pull-job/203", 2*day, true)...)
+	objects = append(objects,
+		fakestorage.Object{BucketName: "bucket", Name: "pr-logs/directory/pull-job/202.txt", Content: []byte("gs://bucket/pr-logs/pull/org_repo/1/pull-job/202")},
+		fakestorage.Object{BucketName: "bucket", Name: "pr-logs/directory/pull-job/201.txt", Content: []byte("gs://bucket/pr-logs/pull/org_repo/1/pull-job/201")},
+		fakestorage.Object{BucketName: "bucket", Name: "pr-logs/directory/pull-job/latest-build.txt", Content: []byte("202")},
+	)
+	// Presubmit runs of a job configured by two repos, each repo keeps its latest run.
+	objects = append(objects, finishedIn("org/repo", "pr-logs/pull/org_repo/2/pull-shared/303", 2*day, true)...)     // kept, latest run of org/repo
+	objects = append(objects, finishedIn("other/repo", "pr-logs/pull/other_repo/3/pull-shared/302", 2*day, true)...) // kept, latest run of other/repo
+	objects = append(objects, finishedIn("org/repo", "pr-logs/pull/org_repo/2/pull-shared/301", 2*day, true)...)     // deleted
+	objects = append(objects, finishedIn("other/repo", "pr-logs/pull/other_repo/3/pull-shared/300", 2*day, true)...) // kept, other/repo keeps runs for 10 days
+	for _, dir := range []string{"org_repo/2/pull-shared/303", "other_repo/3/pull-shared/302", "org_repo/2/pull-shared/301", "other_repo/3/pull-shared/300"} {
+		objects = append(objects, fakestorage.Object{BucketName: "bucket", Name: "pr-logs/directory/pull-shared/" + dir[len(dir)-3:] + ".txt", Content: []byte("gs://bucket/pr-logs/pull/" + dir)})
+	}
+	// Postsubmit runs of jobs configured in-repo.
+	objects = append(objects, finishedIn("org/inrepo", "logs/post-inrepo/402", 2*day, true)...)  // kept, latest run
+	objects = append(objects, finishedIn("org/inrepo", "logs/post-inrepo/401", 2*day, true)...)  // deleted
+	objects = append(objects, finishedIn("org/static", "logs/post-unknown/501", 2*day, true)...) // kept, the repo doesn't enable in-repo config
+	objects = append(objects, finishedIn("org/static", "logs/post-unknown/500", 2*day, true)...) // kept
+
+	enabled := true
+	decoration := config.UtilityConfig{
+		DecorationConfig: &prowapi.DecorationConfig{
+			GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "gs://bucket"},
+		},
+	}
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {
+					{JobBase: config.JobBase{Name: "pull-job", UtilityConfig: decoration}},
+					{JobBase: config.JobBase{Name: "pull-shared", UtilityConfig: decoration}},
+				},
+				"other/repo": {{JobBase: config.JobBase{Name: "pull-shared", UtilityConfig: decoration}}},
+			},
+			Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "ci-job", UtilityConfig: decoration}}},
+		},
+		ProwConfig: config.ProwConfig{
+			JobGC: config.JobGC{
+				Default: &config.JobGCPolicy{
+					KeepRuns:      1,
+					MaxAge:        &metav1.Duration{Duration: 24 * time.Hour},
+					MaxFailureAge: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+				},
+				Repos: map[string]config.JobGCPolicy{
+					"other/repo": {KeepRuns: 1, MaxAge: &metav1.Duration{Duration: 10 * 24 * time.Hour}},
+				},
+			},
+			InRepoConfig: config.InRepoConfig{
+				Enabled: map[string]*bool{"org/inrepo": &enabled},
+			},
+			Plank: config.Plank{
+				DefaultDecorationConfigs: []*config.DefaultDecorationConfigEntry{
+					{OrgRepo: "*", Cluster: "*", Config: decoration.DecorationConfig},
+				},
+			},
+		},
+	}
+
+	all := func() []string {
+		var names []string
+		for _, object := range objects {
+			names = append(names, object.Name)
+		}
+		return names
+	}
+	without := func(dirs ...string) []string {
+		var names []string
+	objects:
+		for _, name := range all() {
+			for _, dir := range dirs {
+				if name == dir || len(name) > len(dir) && name[:len(dir)+1] == dir+"/" {
+					continue objects
+				}
+			}
+			names = append(names, name)
+		}
+		return names
+	}
+
+	testCases := []struct {
+		name     string
+		dryRun   bool
+		expected []string
+	}{
+		{
+			name:     "dry run deletes nothing",
+			dryRun:   true,
+			expected: all(),
+		},
+		{
+			name: "runs past their retention are deleted",
+			expected: without(
+				"logs/ci-job/104",
+				"logs/ci-job/102",
+				"logs/ci-job/101",
+				"pr-logs/pull/org_repo/1/pull-job/201",
+				"pr-logs/directory/pull-job/201.txt",
+				"pr-logs/pull/batch/pull-job/203",
+				"pr-logs/pull/org_repo/2/pull-shared/301",
+				"pr-logs/directory/pull-shared/301.txt",
+				"logs/post-inrepo/401",
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gcsServer := fakestorage.NewServer(objects)
+			defer gcsServer.Stop()
+
+			c := &collector{
+				logger: logrus.WithField("test", tc.name),
+				opener: io.NewGCSOpener(gcsServer.Client()),
+				config: func() *config.Config { return cfg },
+				dryRun: tc.dryRun,
+			}
+			c.collect(context.Background(), now)
+
+			remaining, _, err := gcsServer.ListObjects("bucket", "", "", false)
+			if err != nil {
+				t.Fatalf("failed to list objects: %v", err)
+			}
+			var actual []string
+			for _, object := range remaining {
+				actual = append(actual, object.Name)
+			}
+			sort.Strings(actual)
+			sort.Strings(tc.expected)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected remaining objects (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// prow-job-gc deletes the artifacts of old job runs from the storage
+// buckets according to the retention policies of the job_gc config.
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+	_ "sigs.k8s.io/prow/pkg/version"
+)
+
+type options struct {
+	runOnce                bool
+	dryRun                 bool
+	config                 configflagutil.ConfigOptions
+	storage                flagutil.StorageClientOptions
+	instrumentationOptions flagutil.InstrumentationOptions
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{}
+	fs.BoolVar(&o.runOnce, "run-once", false, "If true, run only once then quit.")
+	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to delete artifacts. In dry-run mode the artifacts that would be deleted are only logged and counted.")
+
+	o.config.AddFlags(fs)
+	o.storage.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	for _, group := range []flagutil.OptionGroup{&o.config, &o.storage} {
+		if err := group.Validate(o.dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	pprof.Instrument(o.instrumentationOptions)
+
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error starting config agent.")
+	}
+	cfg := configAgent.Config
+
+	metrics.ExposeMetrics("prow-job-gc", cfg().PushGateway, o.instrumentationOptions.MetricsPort)
+
+	opener, err := o.storage.StorageClient(interrupts.Context())
+	if err != nil {
+		logrus.WithError(err).Fatal("Error creating opener.")
+	}
+
+	c := &collector{
+		logger: logrus.NewEntry(logrus.StandardLogger()),
+		opener: opener,
+		config: cfg,
+		dryRun: o.dryRun,
+	}
+
+	if o.runOnce {
+		c.collect(interrupts.Context(), time.Now())
+		return
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+	interrupts.Tick(func() {
+		c.collect(interrupts.Context(), time.Now())
+	}, func() time.Duration {
+		return cfg().JobGC.ResyncPeriod.Duration
+	})
+}
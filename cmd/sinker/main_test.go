@@ -838,6 +838,84 @@ func TestNotClean(t *testing.T) {
 	assertSetsEqual(sets.Set[string]{}, podClientExcluded.deletedPods, t, "did not delete correct Pods")
 }
 
+func TestCleanRetentionOverrides(t *testing.T) {
+	completed := metav1.NewTime(time.Now().Add(-time.Hour))
+	prowJob := func(name, org string, state prowv1.ProwJobState) *prowv1.ProwJob {
+		return &prowv1.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+			},
+			Spec: prowv1.ProwJobSpec{
+				Type: prowv1.PostsubmitJob,
+				Refs: &prowv1.Refs{Org: org, Repo: "repo"},
+			},
+			Status: prowv1.ProwJobStatus{
+				State:          state,
+				StartTime:      metav1.NewTime(time.Now().Add(-maxProwJobAge).Add(-time.Second)),
+				CompletionTime: &completed,
+			},
+		}
+	}
+	pod := func(name string) *corev1api.Pod {
+		return &corev1api.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels: map[string]string{
+					kube.CreatedByProw:  "true",
+					kube.ProwJobIDLabel: name,
+				},
+			},
+			Status: corev1api.PodStatus{
+				Phase:     corev1api.PodSucceeded,
+				StartTime: startTime(time.Now().Add(-maxPodAge).Add(-time.Second)),
+			},
+		}
+	}
+	prowJobs := []runtime.Object{
+		prowJob("succeeded", "org", prowv1.SuccessState),
+		prowJob("failed", "org", prowv1.FailureState),
+		prowJob("failed-other-org", "other", prowv1.FailureState),
+	}
+	pods := []runtime.Object{
+		pod("succeeded"),
+		pod("failed"),
+		pod("failed-other-org"),
+	}
+	deleted := sets.New[string]("succeeded", "failed-other-org")
+
+	fpjc := &clientWrapper{
+		Client: fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(prowJobs...).Build(),
+	}
+	fkc := &podClientWrapper{t: t, Client: fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pods...).Build()}
+	sinkerConfig := newDefaultFakeSinkerConfig()
+	sinkerConfig.RetentionOverrides = []config.SinkerRetentionOverride{{
+		Repos:         []string{"org"},
+		States:        []prowv1.ProwJobState{prowv1.FailureState, prowv1.ErrorState},
+		MaxProwJobAge: &metav1.Duration{Duration: 2 * maxProwJobAge},
+		MaxPodAge:     &metav1.Duration{Duration: 2 * maxPodAge},
+	}}
+	c := controller{
+		logger:        logrus.WithField("component", "sinker"),
+		prowJobClient: fpjc,
+		podClients:    map[string]ctrlruntimeclient.Client{"default": fkc},
+		config:        newFakeConfigAgent(sinkerConfig).Config,
+	}
+	c.clean()
+	assertSetsEqual(deleted, fkc.deletedPods, t, "did not delete correct Pods")
+
+	remainingProwJobs := &prowv1.ProwJobList{}
+	if err := fpjc.List(context.Background(), remainingProwJobs); err != nil {
+		t.Fatalf("failed to get remaining prowjobs: %v", err)
+	}
+	actuallyDeletedProwJobs := sets.New[string]("succeeded", "failed", "failed-other-org")
+	for _, remainingProwJob := range remainingProwJobs.Items {
+		actuallyDeletedProwJobs.Delete(remainingProwJob.Name)
+	}
+	assertSetsEqual(deleted, actuallyDeletedProwJobs, t, "did not delete correct ProwJobs")
+}
+
 func assertSetsEqual(expected, actual sets.Set[string], t *testing.T, prefix string) {
 	if expected.Equal(actual) {
 		return
@@ -47,6 +47,7 @@ type options struct {
 	continueOnError           bool
 	addedPresubmitDenylist    prowflagutil.Strings
 	addedPresubmitDenylistAll prowflagutil.Strings
+	allowlist                 prowflagutil.Strings
 	dryRun                    bool
 	kubernetes                prowflagutil.KubernetesOptions
 	github                    prowflagutil.GitHubOptions
@@ -59,6 +60,11 @@ type options struct {
 	// a) the gcs credentials can write to this bucket
 	// b) the default acls do not expose any private info
 	statusURI string
+
+	// reportURI where Status-reconciler writes the report of the statuses the
+	// latest config change affects.
+	reportURI  string
+	reportOnly bool
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
@@ -69,6 +75,9 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.continueOnError, "continue-on-error", false, "Indicates that the migration should continue if context migration fails for an individual PR.")
 	fs.Var(&o.addedPresubmitDenylist, "denylist", "Org or org/repo to ignore new added presubmits for, set more than once to add more.")
 	fs.Var(&o.addedPresubmitDenylistAll, "denylist-all", "Org or org/repo to ignore reconciling, set more than once to add more.")
+	fs.Var(&o.allowlist, "allowlist", "Org or org/repo to restrict reconciling to, set more than once to add more. If unset, all orgs and repos that aren't denylisted are reconciled.")
+	fs.StringVar(&o.reportURI, "report-path", "", "The /local/path, gs://path/to/object or s3://path/to/object to write a report of the statuses the latest config change affects per repo to.")
+	fs.BoolVar(&o.reportOnly, "report-only", false, "Only report the statuses config changes affect instead of reconciling them. The state is not stored either, so that a later run without this flag reconciles the reported changes.")
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to GitHub.")
 	o.github.AddCustomizedFlags(fs, prowflagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
 	o.pluginsConfig.PluginConfigPathDefault = "/etc/plugins/plugins.yaml"
@@ -100,6 +109,10 @@ func (o *options) getDenyListAll() sets.Set[string] {
 	return sets.New[string](denyListAll...)
 }
 
+func (o *options) getAllowList() sets.Set[string] {
+	return sets.New[string](o.allowlist.Strings()...)
+}
+
 func main() {
 	logrusutil.ComponentInit()
 
@@ -139,7 +152,7 @@ func main() {
 		logrus.WithError(err).Fatal("Cannot create opener")
 	}
 
-	c := statusreconciler.NewController(o.continueOnError, o.getDenyList(), o.getDenyListAll(), opener, o.config, o.statusURI, prowJobClient, githubClient, pluginAgent)
+	c := statusreconciler.NewController(o.continueOnError, o.getDenyList(), o.getDenyListAll(), o.getAllowList(), opener, o.config, o.statusURI, o.reportURI, o.reportOnly, prowJobClient, githubClient, pluginAgent)
 	interrupts.Run(func(ctx context.Context) {
 		c.Run(ctx)
 	})
@@ -78,6 +78,20 @@ func TestGatherOptions(t *testing.T) {
 				o.addedPresubmitDenylist = newSetStringsFlagForTest("a", "b")
 			},
 		},
+		{
+			name: "support allowlist and report",
+			args: []string{
+				"-allowlist=a",
+				"-allowlist=b/c",
+				"-report-path=gs://bucket/report.json",
+				"-report-only",
+			},
+			expected: func(o *options) {
+				o.allowlist = newSetStringsFlagForTest("a", "b/c")
+				o.reportURI = "gs://bucket/report.json"
+				o.reportOnly = true
+			},
+		},
 	}
 
 	for _, tc := range cases {
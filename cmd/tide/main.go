@@ -20,13 +20,19 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -63,8 +69,12 @@ type options struct {
 	storage                prowflagutil.StorageClientOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	controllerManager      prowflagutil.ControllerManagerOptions
+	runtimeFlags           prowflagutil.RuntimeFlagsOptions
 
 	maxRecordsPerPool int
+	// maxRecordAge is how long history records are retained, zero retains
+	// them until maxRecordsPerPool newer records exist.
+	maxRecordAge time.Duration
 	// historyURI where Tide should store its action history.
 	// Can be /local/path, gs://path/to/object or s3://path/to/object.
 	// GCS writes will use the bucket's default acl for new objects. Ensure both that
@@ -79,15 +89,25 @@ type options struct {
 	// b) the default acls do not expose any private info
 	statusURI string
 
+	// poolsURI where Tide stores a snapshot of its pools after every sync,
+	// which is served until the first sync finishes after a restart.
+	// Can be a /local/path, gs://path/to/object or s3://path/to/object.
+	poolsURI string
+
 	// providerName is
 	providerName string
 
 	// Gerrit-related options
 	cookiefilePath string
+
+	// Sharding options, see tide.Shard.
+	shardName  string
+	shardIndex int
+	shardCount int
 }
 
 func (o *options) Validate() error {
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.storage, &o.config, &o.controllerManager} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.storage, &o.config, &o.controllerManager, &o.runtimeFlags} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
@@ -102,26 +122,54 @@ func (o *options) Validate() error {
 	if err := providerFlagGroup.Validate(o.dryRun); err != nil {
 		return err
 	}
+	if o.shardName != "" && o.shardCount != 0 {
+		return errors.New("--shard-name and --shard-count are mutually exclusive")
+	}
+	if o.maxRecordAge < 0 {
+		return errors.New("--max-record-age must not be negative")
+	}
+	if o.shardCount < 0 {
+		return errors.New("--shard-count must not be negative")
+	}
+	if o.shardCount > 0 && (o.shardIndex < 0 || o.shardIndex >= o.shardCount) {
+		return fmt.Errorf("--shard-index must be in [0, %d)", o.shardCount)
+	}
+	if o.shardCount == 0 && o.shardIndex != 0 {
+		return errors.New("--shard-index requires --shard-count")
+	}
+	if o.shard().Enabled() && o.providerName == gerritProviderName {
+		return errors.New("sharding is only supported for the github provider")
+	}
 	return nil
 }
 
+func (o *options) shard() tide.Shard {
+	return tide.Shard{Name: o.shardName, Index: o.shardIndex, Count: o.shardCount}
+}
+
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	var o options
 	fs.IntVar(&o.port, "port", 8888, "Port to listen on.")
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether to mutate any real-world state.")
 	fs.BoolVar(&o.runOnce, "run-once", false, "If true, run only once then quit.")
 	o.github.AddCustomizedFlags(fs, prowflagutil.DisableThrottlerOptions())
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.storage, &o.instrumentationOptions, &o.config, &o.gerrit} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.storage, &o.instrumentationOptions, &o.config, &o.gerrit, &o.runtimeFlags} {
 		group.AddFlags(fs)
 	}
-	fs.IntVar(&o.syncThrottle, "sync-hourly-tokens", 800, "The maximum number of tokens per hour to be used by the sync controller.")
-	fs.IntVar(&o.statusThrottle, "status-hourly-tokens", 400, "The maximum number of tokens per hour to be used by the status controller.")
+	fs.IntVar(&o.syncThrottle, "sync-hourly-tokens", 800, "The maximum number of tokens per hour to be used by the sync controller. Can be changed at runtime with --runtime-flags-dir.")
+	fs.IntVar(&o.statusThrottle, "status-hourly-tokens", 400, "The maximum number of tokens per hour to be used by the status controller. Can be changed at runtime with --runtime-flags-dir.")
 	fs.IntVar(&o.maxRecordsPerPool, "max-records-per-pool", 1000, "The maximum number of history records stored for an individual Tide pool.")
 	fs.StringVar(&o.historyURI, "history-uri", "", "The /local/path,gs://path/to/object or s3://path/to/object to store tide action history. GCS writes will use the default object ACL for the bucket")
 	fs.StringVar(&o.statusURI, "status-path", "", "The /local/path, gs://path/to/object or s3://path/to/object to store status controller state. GCS writes will use the default object ACL for the bucket.")
+	fs.DurationVar(&o.maxRecordAge, "max-record-age", 0, "The maximum age of the history records stored for a Tide pool, zero means no limit.")
+	fs.StringVar(&o.poolsURI, "pools-uri", "", "The /local/path, gs://path/to/object or s3://path/to/object to store a snapshot of the tide pools, which is served after a restart until the first sync finishes. GCS writes will use the default object ACL for the bucket.")
 	// Gerrit-related flags
 	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile; leave empty for anonymous access or if you are using GitHub")
 
+	fs.StringVar(&o.shardName, "shard-name", "", "Only merge the orgs and repos assigned to this shard in tide.shards. Mutually exclusive with --shard-count.")
+	fs.IntVar(&o.shardIndex, "shard-index", 0, "Index of this replica when distributing orgs across --shard-count replicas.")
+	fs.IntVar(&o.shardCount, "shard-count", 0, "Number of replicas to distribute orgs across by hashing the org name. Zero disables hash based sharding.")
+
 	fs.StringVar(&o.providerName, "provider", "", "The source code provider, only supported providers are github and gerrit, this should be set only when both GitHub and Gerrit configs are set for tide. By default provider is auto-detected as github if `tide.queries` is set, and gerrit if `tide.gerrit` is set.")
 	o.controllerManager.TimeoutListingProwJobsDefault = 30 * time.Second
 	o.controllerManager.AddFlags(fs)
@@ -134,7 +182,8 @@ func main() {
 
 	defer interrupts.WaitForGracefulShutdown()
 
-	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	o := gatherOptions(fs, os.Args[1:]...)
 	if err := o.Validate(); err != nil {
 		logrus.WithError(err).Fatal("Invalid options")
 	}
@@ -151,11 +200,28 @@ func main() {
 		logrus.WithError(err).Fatal("Error starting config agent.")
 	}
 	cfg := configAgent.Config
+	shard := o.shard()
+	if shard.Name != "" {
+		if _, ok := cfg().Tide.Shard(shard.Name); !ok {
+			logrus.Fatalf("Tide shard %q is not defined in tide.shards.", shard.Name)
+		}
+	}
+	cfg = tide.ShardedConfig(cfg, shard)
 
 	kubeCfg, err := o.kubernetes.InfrastructureClusterConfig(o.dryRun)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting kubeconfig.")
 	}
+	// Hold the shard lease before starting any controller, the status
+	// controller starts updating contexts right away.
+	if shard.Enabled() && !o.runOnce {
+		logrus.WithField("shard", shard.ID()).Info("Waiting for tide shard lease.")
+		if err := acquireShardLease(kubeCfg, cfg().ProwJobNamespace, shard); err != nil {
+			logrus.WithError(err).Fatal("Error acquiring tide shard lease.")
+		}
+		logrus.WithField("shard", shard.ID()).Info("Acquired tide shard lease.")
+	}
+
 	// Do not activate leader election here, as we do not use the `mgr` to control the lifecylcle of our cotrollers,
 	// this would just be a no-op.
 	mgr, err := manager.New(kubeCfg, manager.Options{
@@ -177,6 +243,7 @@ func main() {
 	}
 
 	var c *tide.Controller
+	runtimeFlags := o.runtimeFlags.RuntimeFlags(fs)
 	gitClient, err := o.github.GitClientFactory(o.cookiefilePath, &o.config.InRepoConfigCacheDirBase, o.dryRun, false)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting Git client.")
@@ -200,8 +267,24 @@ func main() {
 		// The sync loop should have a much lower burst allowance than the status
 		// loop which may need to update many statuses upon restarting Tide after
 		// changing the context format or starting Tide on a new repo.
-		githubSync.Throttle(o.syncThrottle, 3*tokensPerIteration(o.syncThrottle, cfg().Tide.SyncPeriod.Duration))
-		githubStatus.Throttle(o.statusThrottle, o.statusThrottle/2)
+		throttleSync := func() error {
+			return githubSync.Throttle(o.syncThrottle, 3*tokensPerIteration(o.syncThrottle, cfg().Tide.SyncPeriod.Duration))
+		}
+		throttleStatus := func() error {
+			return githubStatus.Throttle(o.statusThrottle, o.statusThrottle/2)
+		}
+		if err := throttleSync(); err != nil {
+			logrus.WithError(err).Fatal("Error throttling GitHub client for sync.")
+		}
+		if err := throttleStatus(); err != nil {
+			logrus.WithError(err).Fatal("Error throttling GitHub client for status.")
+		}
+		if err := runtimeFlags.OnChange(throttleSync, "sync-hourly-tokens"); err != nil {
+			logrus.WithError(err).Fatal("Error making sync throttling changeable at runtime.")
+		}
+		if err := runtimeFlags.OnChange(throttleStatus, "status-hourly-tokens"); err != nil {
+			logrus.WithError(err).Fatal("Error making status throttling changeable at runtime.")
+		}
 
 		c, err = tide.NewController(
 			githubSync,
@@ -210,9 +293,11 @@ func main() {
 			cfg,
 			gitClient,
 			o.maxRecordsPerPool,
+			o.maxRecordAge,
 			opener,
 			o.historyURI,
 			o.statusURI,
+			o.poolsURI,
 			nil,
 			o.github.AppPrivateKeyPath != "",
 		)
@@ -225,9 +310,11 @@ func main() {
 			configAgent,
 			gitClient,
 			o.maxRecordsPerPool,
+			o.maxRecordAge,
 			opener,
 			o.historyURI,
 			o.statusURI,
+			o.poolsURI,
 			nil,
 			o.config,
 			o.cookiefilePath,
@@ -240,6 +327,9 @@ func main() {
 	default:
 		logrus.Fatalf("Unsupported provider type '%s', this should not happen", provider)
 	}
+	if err := runtimeFlags.Start(); err != nil {
+		logrus.WithError(err).Fatal("Error watching runtime flags.")
+	}
 
 	interrupts.Run(func(ctx context.Context) {
 		if err := mgr.Start(ctx); err != nil {
@@ -288,6 +378,59 @@ func main() {
 	})
 }
 
+// acquireShardLease blocks until this replica holds the lease of its shard, so
+// that at most one replica syncs a shard at any time. Losing the lease later on
+// is fatal, the replica restarts and competes for the lease again.
+func acquireShardLease(kubeCfg *rest.Config, namespace string, shard tide.Shard) error {
+	client, err := kubernetes.NewForConfig(kubeCfg)
+	if err != nil {
+		return fmt.Errorf("failed to construct kubernetes client: %w", err)
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to get hostname: %w", err)
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      "tide-shard-" + shard.ID(),
+			Namespace: namespace,
+		},
+		Client:     client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupts.OnInterrupt(cancel)
+	leading := make(chan struct{})
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   30 * time.Second,
+		RenewDeadline:   20 * time.Second,
+		RetryPeriod:     5 * time.Second,
+		ReleaseOnCancel: true,
+		Name:            "tide-shard-" + shard.ID(),
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) { close(leading) },
+			OnStoppedLeading: func() {
+				if ctx.Err() == nil {
+					logrus.WithField("shard", shard.ID()).Fatal("Lost tide shard lease.")
+				}
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	go elector.Run(ctx)
+
+	select {
+	case <-leading:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func sync(c *tide.Controller) {
 	if err := c.Sync(); err != nil {
 		logrus.WithError(err).Error("Error syncing.")
@@ -92,6 +92,62 @@ func Test_gatherOptions(t *testing.T) {
 				o.controllerManager.TimeoutListingProwJobsDefault = 30 * time.Second
 			},
 		},
+		{
+			name: "hash based sharding",
+			args: map[string]string{
+				"--shard-count": "3",
+				"--shard-index": "2",
+			},
+			expected: func(o *options) {
+				o.shardCount = 3
+				o.shardIndex = 2
+				o.controllerManager.TimeoutListingProwJobs = 30 * time.Second
+				o.controllerManager.TimeoutListingProwJobsDefault = 30 * time.Second
+			},
+		},
+		{
+			name: "history retention and pools snapshot",
+			args: map[string]string{
+				"--max-record-age": "168h",
+				"--pools-uri":      "gs://bucket/tide/pools.json",
+			},
+			expected: func(o *options) {
+				o.maxRecordAge = 7 * 24 * time.Hour
+				o.poolsURI = "gs://bucket/tide/pools.json"
+				o.controllerManager.TimeoutListingProwJobs = 30 * time.Second
+				o.controllerManager.TimeoutListingProwJobsDefault = 30 * time.Second
+			},
+		},
+		{
+			name: "negative history retention",
+			args: map[string]string{
+				"--max-record-age": "-1h",
+			},
+			err: true,
+		},
+		{
+			name: "shard index out of range",
+			args: map[string]string{
+				"--shard-count": "3",
+				"--shard-index": "3",
+			},
+			err: true,
+		},
+		{
+			name: "shard name and shard count are mutually exclusive",
+			args: map[string]string{
+				"--shard-count": "3",
+				"--shard-name":  "kubernetes",
+			},
+			err: true,
+		},
+		{
+			name: "shard index without shard count",
+			args: map[string]string{
+				"--shard-index": "1",
+			},
+			err: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -437,7 +437,7 @@ func (wa *webhookAgent) fetchClusters(d time.Duration, ctx context.Context, stat
 	ticker := time.NewTicker(d)
 	defer ticker.Stop()
 	cfg := configAgent.Config()
-	opener, err := io.NewOpener(context.Background(), wa.storage.GCSCredentialsFile, wa.storage.S3CredentialsFile)
+	opener, err := io.NewOpener(context.Background(), wa.storage.GCSCredentialsFile, wa.storage.S3CredentialsFile, wa.storage.AzureCredentialsFile)
 	if err != nil {
 		return err
 	}
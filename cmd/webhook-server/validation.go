@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/plank"
 )
@@ -88,7 +89,8 @@ func validateProwJobClusterOnCreate(prowJob v1.ProwJob, statuses map[string]plan
 	if prowJob.Spec.Cluster != "" && prowJob.Spec.Cluster != kube.DefaultClusterAlias && agentsNotSupportingCluster.Has(string(prowJob.Spec.Agent)) {
 		return fmt.Errorf("%s: cannot set cluster field if agent is %s", prowJob.Name, prowJob.Spec.Agent)
 	}
-	if prowJob.Spec.Agent == v1.KubernetesAgent {
+	// A cluster selector is resolved to a cluster by the scheduler.
+	if prowJob.Spec.Agent == v1.KubernetesAgent && !config.IsClusterSelector(prowJob.Spec.Cluster) {
 		_, ok := statuses[prowJob.ClusterAlias()]
 		if !ok {
 			return fmt.Errorf("job configuration for %q specifies unknown 'cluster' value %q", prowJob.Name, prowJob.ClusterAlias())
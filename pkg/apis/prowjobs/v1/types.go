@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"mime"
 	"net/url"
+	"path"
 	"strings"
 	"time"
 
@@ -80,6 +81,61 @@ func GetAllProwJobStates() []ProwJobState {
 		ErrorState}
 }
 
+// FailureClassification tells who is likely responsible for a failed or
+// errored job.
+type FailureClassification string
+
+// Various failure classifications.
+const (
+	// InfraFailure means the job failed because of the infrastructure it ran on,
+	// e.g. the pod was evicted or a utility container failed.
+	InfraFailure FailureClassification = "infra"
+	// TestFailure means the job failed because of the code under test.
+	TestFailure FailureClassification = "test"
+	// ConfigFailure means the job failed because of its configuration, e.g. its
+	// image can not be pulled or its pod spec is invalid.
+	ConfigFailure FailureClassification = "config"
+)
+
+// GetAllFailureClassifications returns all possible failure classifications.
+func GetAllFailureClassifications() []FailureClassification {
+	return []FailureClassification{InfraFailure, TestFailure, ConfigFailure}
+}
+
+// FailureReason is a machine-readable reason for the failure of a job.
+type FailureReason string
+
+// Various failure reasons.
+const (
+	// TestsFailedReason means tests of the job failed according to its junit
+	// or go test artifacts.
+	TestsFailedReason FailureReason = "tests_failed"
+	// CommandFailedReason means a command of the job exited with a non-zero
+	// code without reporting failed tests.
+	CommandFailedReason FailureReason = "command_failed"
+	// OOMKilledReason means a container of the job ran out of memory.
+	OOMKilledReason FailureReason = "oom_killed"
+	// TimedOutReason means the pod of the job ran longer than allowed.
+	TimedOutReason FailureReason = "timed_out"
+	// PodErrorReason means the pod of the job could not be created, scheduled
+	// or started, or it was evicted or deleted before it finished.
+	PodErrorReason FailureReason = "pod_error"
+)
+
+// GetAllFailureReasons returns all possible failure reasons.
+func GetAllFailureReasons() []FailureReason {
+	return []FailureReason{TestsFailedReason, CommandFailedReason, OOMKilledReason, TimedOutReason, PodErrorReason}
+}
+
+// TestSummary counts the test results a job reported in its junit or go test
+// artifacts.
+type TestSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
 // ProwJobAgent specifies the controller (such as plank or jenkins-agent) that runs the job.
 type ProwJobAgent string
 
@@ -106,6 +162,14 @@ const (
 	// after its completion. See testgrid/metadata/job.go for more details.
 	FinishedStatusFile = "finished.json"
 
+	// ResultsSummaryFile is the JSON file that summarizes the test results of
+	// the build. See sigs.k8s.io/prow/pkg/pod-utils/results for the format.
+	ResultsSummaryFile = "results.json"
+
+	// ResourceUsageFile is the JSON file with the resource usage of the test
+	// containers. See sigs.k8s.io/prow/pkg/pod-utils/usage for the format.
+	ResourceUsageFile = "usage.json"
+
 	// ProwJobFile is the JSON file that stores the prowjob information.
 	ProwJobFile = "prowjob.json"
 
@@ -233,10 +297,47 @@ type ProwJobSpec struct {
 	// This behaviour may be superseded by MaxConcurrency field, if it
 	// is set to a constraining value.
 	JobQueueName string `json:"job_queue_name,omitempty"`
+
+	// DependsOn lists the names of the jobs that must have succeeded
+	// for the same refs before this job is started. Dependencies are
+	// only supported for jobs run by the kubernetes agent.
+	DependsOn []string `json:"depends_on,omitempty"`
+
+	// Retry configures whether the job is run again when it does not
+	// complete because of the infrastructure it runs on, e.g. because
+	// its pod was evicted or its node was shut down.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+}
+
+// RetryPolicy configures the automatic retries of a ProwJob.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times the job is retried.
+	Attempts int `json:"attempts,omitempty"`
+	// On lists the states the job is retried in, out of error and aborted.
+	// Only the jobs plank itself errors or aborts are retried: a job aborted
+	// by a user or by a newer run is never retried.
+	// Defaults to error.
+	On []ProwJobState `json:"on,omitempty"`
+}
+
+// RetriesOn tells whether a job ending up in the given state is retried.
+func (rp *RetryPolicy) RetriesOn(state ProwJobState) bool {
+	if rp == nil || rp.Attempts <= 0 {
+		return false
+	}
+	if len(rp.On) == 0 {
+		return state == ErrorState
+	}
+	for _, on := range rp.On {
+		if on == state {
+			return true
+		}
+	}
+	return false
 }
 
 func (pjs ProwJobSpec) HasPipelineRunSpec() bool {
-	if pjs.TektonPipelineRunSpec != nil && pjs.TektonPipelineRunSpec.V1Beta1 != nil {
+	if pjs.TektonPipelineRunSpec.GetPipelineRunSpec() != nil {
 		return true
 	}
 	if pjs.PipelineRunSpec != nil {
@@ -246,10 +347,7 @@ func (pjs ProwJobSpec) HasPipelineRunSpec() bool {
 }
 
 func (pjs ProwJobSpec) GetPipelineRunSpec() (*pipelinev1.PipelineRunSpec, error) {
-	var found *pipelinev1.PipelineRunSpec
-	if pjs.TektonPipelineRunSpec != nil {
-		found = pjs.TektonPipelineRunSpec.V1Beta1
-	}
+	found := pjs.TektonPipelineRunSpec.GetPipelineRunSpec()
 	if found == nil && pjs.PipelineRunSpec != nil {
 		found = pjs.PipelineRunSpec
 	}
@@ -357,6 +455,21 @@ func (rac *RerunAuthConfig) IsAllowAnyone() bool {
 
 type ReporterConfig struct {
 	Slack *SlackReporterConfig `json:"slack,omitempty"`
+	Email *EmailReporterConfig `json:"email,omitempty"`
+}
+
+// EmailReporterConfig configures the emails crier sends about failed runs of
+// the job.
+type EmailReporterConfig struct {
+	// Recipients are the email addresses that are notified.
+	Recipients []string `json:"recipients,omitempty"`
+	// FailureThreshold is the number of consecutive failed or errored runs of
+	// the job after which the recipients are notified about every further
+	// failed run. Defaults to 1.
+	FailureThreshold int `json:"failure_threshold,omitempty"`
+	// Digest batches the notifications into one email per digest interval of
+	// crier instead of sending one per failed run.
+	Digest bool `json:"digest,omitempty"`
 }
 
 type SlackReporterConfig struct {
@@ -473,6 +586,22 @@ type DecorationConfig struct {
 	// after sending SIGINT to send SIGKILL when aborting
 	// a job. Only applicable if decorating the PodSpec.
 	GracePeriod *Duration `json:"grace_period,omitempty"`
+	// LogUploadInterval is how often the sidecar uploads the build
+	// logs of the test containers to blob storage while they run,
+	// so that the logs of running jobs can be viewed without access
+	// to the logs of their pods. If unset, build logs are only
+	// uploaded once the test containers finished.
+	LogUploadInterval *Duration `json:"log_upload_interval,omitempty"`
+	// Steps is an ordered list of commands that the entrypoint runs in
+	// place of the command of the test container, e.g. to set up, run
+	// and tear down a test. Once a step failed or timed out, only the
+	// remaining steps that are marked to always run are executed.
+	Steps []EntrypointStep `json:"steps,omitempty"`
+	// ResourceUsage configures sampling the cpu and memory usage of the
+	// test containers while they run. The samples are uploaded as
+	// usage.json, so that job owners can right-size the resource
+	// requests of their jobs. If unset, usage is not sampled.
+	ResourceUsage *ResourceUsageConfig `json:"resource_usage,omitempty"`
 
 	// UtilityImages holds pull specs for utility container
 	// images used to decorate a PodSpec.
@@ -489,6 +618,9 @@ type DecorationConfig struct {
 	// S3CredentialsSecret is the name of the Kubernetes secret
 	// that holds blob storage push credentials.
 	S3CredentialsSecret *string `json:"s3_credentials_secret,omitempty"`
+	// AzureCredentialsSecret is the name of the Kubernetes secret
+	// that holds Azure Blob Storage push credentials.
+	AzureCredentialsSecret *string `json:"azure_credentials_secret,omitempty"`
 	// DefaultServiceAccountName is the name of the Kubernetes service account
 	// that should be used by the pod if one is not specified in the podspec.
 	DefaultServiceAccountName *string `json:"default_service_account_name,omitempty"`
@@ -502,9 +634,23 @@ type DecorationConfig struct {
 	// BloblessFetch tells Prow to avoid fetching objects when cloning using
 	// the --filter=blob:none flag.
 	BloblessFetch *bool `json:"blobless_fetch,omitempty"`
+	// SparseCheckout is the list of directories of the primary repository
+	// that are checked out in cone mode, e.g. `git sparse-checkout set`.
+	// Files at the root of the repository are always checked out. If unset,
+	// the whole repository is checked out.
+	SparseCheckout []string `json:"sparse_checkout,omitempty"`
+	// SubmoduleDepth is the depth submodules are cloned with.
+	// A depth of zero will do a full clone.
+	SubmoduleDepth *int `json:"submodule_depth,omitempty"`
+	// SubmoduleDepths overrides SubmoduleDepth for the submodules of the
+	// primary repository at the given paths.
+	SubmoduleDepths map[string]int `json:"submodule_depths,omitempty"`
 	// SkipCloning determines if we should clone source code in the
 	// initcontainers for jobs that specify refs
 	SkipCloning *bool `json:"skip_cloning,omitempty"`
+	// Cache configures directories of the test containers that are restored
+	// from and saved to blob storage, so that runs of the job can reuse them.
+	Cache *CacheConfiguration `json:"cache,omitempty"`
 	// CookieFileSecret is the name of a kubernetes secret that contains
 	// a git http.cookiefile, which should be used during the cloning process.
 	CookiefileSecret *string `json:"cookiefile_secret,omitempty"`
@@ -768,12 +914,18 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.GracePeriod == nil {
 		merged.GracePeriod = def.GracePeriod
 	}
+	if merged.LogUploadInterval == nil {
+		merged.LogUploadInterval = def.LogUploadInterval
+	}
 	if merged.GCSCredentialsSecret == nil {
 		merged.GCSCredentialsSecret = def.GCSCredentialsSecret
 	}
 	if merged.S3CredentialsSecret == nil {
 		merged.S3CredentialsSecret = def.S3CredentialsSecret
 	}
+	if merged.AzureCredentialsSecret == nil {
+		merged.AzureCredentialsSecret = def.AzureCredentialsSecret
+	}
 	if merged.DefaultServiceAccountName == nil {
 		merged.DefaultServiceAccountName = def.DefaultServiceAccountName
 	}
@@ -786,6 +938,9 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.SkipCloning == nil {
 		merged.SkipCloning = def.SkipCloning
 	}
+	if merged.Cache == nil {
+		merged.Cache = def.Cache
+	}
 	if merged.CookiefileSecret == nil {
 		merged.CookiefileSecret = def.CookiefileSecret
 	}
@@ -844,6 +999,21 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.BloblessFetch == nil {
 		merged.BloblessFetch = def.BloblessFetch
 	}
+	if merged.SparseCheckout == nil {
+		merged.SparseCheckout = def.SparseCheckout
+	}
+	if merged.Steps == nil {
+		merged.Steps = def.Steps
+	}
+	if merged.ResourceUsage == nil {
+		merged.ResourceUsage = def.ResourceUsage
+	}
+	if merged.SubmoduleDepth == nil {
+		merged.SubmoduleDepth = def.SubmoduleDepth
+	}
+	if merged.SubmoduleDepths == nil {
+		merged.SubmoduleDepths = def.SubmoduleDepths
+	}
 	if merged.SchedulingOptions == nil {
 		merged.SchedulingOptions = def.SchedulingOptions
 	}
@@ -875,16 +1045,124 @@ func (d *DecorationConfig) Validate() error {
 	if d.GCSConfiguration == nil {
 		return errors.New("GCS upload configuration is not specified")
 	}
-	// Intentionally allow d.GCSCredentialsSecret and d.S3CredentialsSecret to
+	// Intentionally allow d.GCSCredentialsSecret, d.S3CredentialsSecret and d.AzureCredentialsSecret to
 	// be unset in which case we assume GCS permissions are provided by GKE
 	// Workload Identity: https://cloud.google.com/kubernetes-engine/docs/how-to/workload-identity
 
 	if err := d.GCSConfiguration.Validate(); err != nil {
 		return fmt.Errorf("GCS configuration is invalid: %w", err)
 	}
+	if d.LogUploadInterval.Get() < 0 {
+		return fmt.Errorf("log upload interval %s is negative", d.LogUploadInterval.Get())
+	}
 	if d.OauthTokenSecret != nil && len(d.SSHKeySecrets) > 0 {
 		return errors.New("both OAuth token and SSH key secrets are specified")
 	}
+	for _, dir := range d.SparseCheckout {
+		if clean := path.Clean(dir); dir == "" || path.IsAbs(dir) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("sparse checkout directory %q is not a relative path inside the repository", dir)
+		}
+	}
+	if d.SubmoduleDepth != nil && *d.SubmoduleDepth < 0 {
+		return fmt.Errorf("submodule depth %d is negative", *d.SubmoduleDepth)
+	}
+	for p, depth := range d.SubmoduleDepths {
+		if depth < 0 {
+			return fmt.Errorf("depth %d of submodule %q is negative", depth, p)
+		}
+	}
+	if d.Cache != nil {
+		if err := d.Cache.Validate(); err != nil {
+			return fmt.Errorf("cache configuration is invalid: %w", err)
+		}
+	}
+	if d.ResourceUsage != nil && d.ResourceUsage.Interval.Get() < 0 {
+		return fmt.Errorf("resource usage interval %s is negative", d.ResourceUsage.Interval.Get())
+	}
+	names := map[string]bool{}
+	for i, step := range d.Steps {
+		if step.Name == "" {
+			return fmt.Errorf("step %d has no name", i)
+		}
+		if names[step.Name] {
+			return fmt.Errorf("step %q is specified more than once", step.Name)
+		}
+		names[step.Name] = true
+		if len(step.Command) == 0 || step.Command[0] == "" {
+			return fmt.Errorf("step %q has no command", step.Name)
+		}
+		if step.Timeout.Get() < 0 {
+			return fmt.Errorf("timeout %s of step %q is negative", step.Timeout.Get(), step.Name)
+		}
+	}
+	return nil
+}
+
+// EntrypointStep is one of the commands the entrypoint runs in order in
+// the test container.
+type EntrypointStep struct {
+	// Name identifies the step in the logs and the recorded results.
+	Name string `json:"name"`
+	// Command is the command line of the step.
+	Command []string `json:"command"`
+	// Timeout is how long the step may run before it is interrupted.
+	// The step is always bound by the timeout of the job.
+	Timeout *Duration `json:"timeout,omitempty"`
+	// AlwaysRun makes the step run even if a previous step failed or
+	// timed out, or the job was aborted, e.g. to tear down resources.
+	AlwaysRun bool `json:"always_run,omitempty"`
+}
+
+// ResourceUsageConfig holds options for sampling the resource usage of the
+// test containers. The entrypoint samples the cgroup of its container, as
+// the sidecar can not see it, and the sidecar uploads the samples.
+type ResourceUsageConfig struct {
+	// Interval is how often usage is sampled. Defaults to 10s.
+	Interval *Duration `json:"interval,omitempty"`
+	// GPU samples the usage of the GPUs visible to the test containers as
+	// well. It requires nvidia-smi in the images of the test containers.
+	GPU bool `json:"gpu,omitempty"`
+}
+
+// CacheConfiguration holds options for caching directories of the test
+// containers across runs of a job. initupload restores the cache before the
+// test starts and sidecar saves it after the test passed if it wasn't
+// restored, so a cache is never overwritten once saved. Caches are stored per
+// job type and base ref, so presubmits, which run unreviewed code, never
+// save caches that postsubmits or periodics restore.
+type CacheConfiguration struct {
+	// KeyTemplate is a Go template rendering the key the cache is stored under.
+	// It is executed with the job spec, and the hashFiles function hashes the
+	// content of the files matching the given globs relative to the working
+	// directory of the job, e.g. '{{.Job}}-{{hashFiles "go.sum"}}'.
+	KeyTemplate string `json:"key_template,omitempty"`
+	// Paths are the absolute paths of the cached directories in the test
+	// containers.
+	Paths []string `json:"paths,omitempty"`
+	// Storage is the blob storage path caches are stored under, e.g.
+	// gs://bucket/caches. Defaults to the caches directory of the bucket of
+	// the GCS configuration.
+	Storage string `json:"storage,omitempty"`
+}
+
+// Validate ensures all the values set in the CacheConfiguration are valid.
+func (c *CacheConfiguration) Validate() error {
+	if c.KeyTemplate == "" {
+		return errors.New("key_template is not specified")
+	}
+	if len(c.Paths) == 0 {
+		return errors.New("paths are not specified")
+	}
+	seen := map[string]bool{}
+	for _, p := range c.Paths {
+		if !path.IsAbs(p) {
+			return fmt.Errorf("path %q is not absolute", p)
+		}
+		if seen[path.Clean(p)] {
+			return fmt.Errorf("path %q is specified more than once", p)
+		}
+		seen[path.Clean(p)] = true
+	}
 	return nil
 }
 
@@ -947,6 +1225,7 @@ type GCSConfiguration struct {
 	// Bucket is the bucket to upload to, it can be:
 	// * a GCS bucket: with gs:// prefix
 	// * a S3 bucket: with s3:// prefix
+	// * an Azure Blob Storage container: with abs:// prefix
 	// * a GCS bucket: without a prefix (deprecated, it's discouraged to use Bucket without prefix please add the gs:// prefix)
 	Bucket string `json:"bucket,omitempty"`
 	// PathPrefix is an optional path that follows the
@@ -1126,6 +1405,21 @@ type ProwJobStatus struct {
 	// PrevReportStates stores the previous reported prowjob state per reporter
 	// So crier won't make duplicated report attempt
 	PrevReportStates map[string]ProwJobState `json:"prev_report_states,omitempty"`
+
+	// Retries is the number of times the job has been retried according
+	// to its retry policy.
+	Retries int `json:"retries,omitempty"`
+	// RetryTime is when the job was last set up to be retried.
+	RetryTime *metav1.Time `json:"retry_time,omitempty"`
+
+	// FailureReason is why the job failed, aborted or errored, if known.
+	// +kubebuilder:validation:Enum=tests_failed;command_failed;oom_killed;timed_out;pod_error
+	FailureReason FailureReason `json:"failure_reason,omitempty"`
+	// TestSummary counts the test results the job reported, if any.
+	TestSummary *TestSummary `json:"test_summary,omitempty"`
+	// ErrorSnippet is a short excerpt of the most relevant error of a job
+	// that did not succeed, e.g. the message of its first failed test.
+	ErrorSnippet string `json:"error_snippet,omitempty"`
 }
 
 // Complete returns true if the prow job has finished
@@ -1219,6 +1513,17 @@ type Refs struct {
 	// using the --filter=blob:none flag. If unspecified, defaults to
 	// DecorationConfig.BloblessFetch.
 	BloblessFetch *bool `json:"blobless_fetch,omitempty"`
+	// SparseCheckout is the list of directories that are checked out in
+	// cone mode. If unspecified, defaults to DecorationConfig.SparseCheckout
+	// for the primary refs of the job.
+	SparseCheckout []string `json:"sparse_checkout,omitempty"`
+	// SubmoduleDepth is the depth submodules are cloned with.
+	// If unspecified, defaults to DecorationConfig.SubmoduleDepth.
+	SubmoduleDepth int `json:"submodule_depth,omitempty"`
+	// SubmoduleDepths overrides SubmoduleDepth for the submodules at the
+	// given paths. If unspecified, defaults to
+	// DecorationConfig.SubmoduleDepths for the primary refs of the job.
+	SubmoduleDepths map[string]int `json:"submodule_depths,omitempty"`
 }
 
 func (r Refs) String() string {
@@ -1257,11 +1562,89 @@ type JenkinsSpec struct {
 
 // TektonPipelineRunSpec is optional parameters for Tekton pipeline jobs.
 type TektonPipelineRunSpec struct {
+	// V1Beta1 is kept for compatibility and is read as a Tekton v1
+	// PipelineRunSpec. Use V1 instead.
 	// +kubebuilder:validation:Type=object
 	// +kubebuilder:validation:XPreserveUnknownFields
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	V1Beta1 *pipelinev1.PipelineRunSpec `json:"v1beta1,omitempty"`
+	// V1 is the Tekton v1 PipelineRunSpec of the job.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	V1 *pipelinev1.PipelineRunSpec `json:"v1,omitempty"`
+
+	// ParamMappings maps the names of PipelineRun params to the names of
+	// the Prow job environment variables, e.g. PULL_PULL_SHA, they are
+	// set to. When it is set, only these params are added to the
+	// PipelineRun, instead of one param per environment variable.
+	ParamMappings map[string]string `json:"param_mappings,omitempty"`
+	// SourceWorkspace is the name of the pipeline workspace the code
+	// of the PROW_IMPLICIT_GIT_REF and PROW_EXTRA_GIT_REF_* tasks is
+	// cloned into, in a directory named after the path alias of the
+	// refs, or their org/repo.
+	SourceWorkspace string `json:"source_workspace,omitempty"`
+	// WorkspaceTemplate is the template of a PersistentVolumeClaim
+	// created for every run of the job and bound to SourceWorkspace,
+	// unless the PipelineRun binds that workspace itself.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	WorkspaceTemplate *corev1.PersistentVolumeClaim `json:"workspace_template,omitempty"`
+}
+
+// GetPipelineRunSpec returns the PipelineRunSpec, preferring V1 over V1Beta1.
+func (t *TektonPipelineRunSpec) GetPipelineRunSpec() *pipelinev1.PipelineRunSpec {
+	if t == nil {
+		return nil
+	}
+	if t.V1 != nil {
+		return t.V1
+	}
+	return t.V1Beta1
+}
+
+// GetParamMappings returns the param mappings, nil if unset.
+func (t *TektonPipelineRunSpec) GetParamMappings() map[string]string {
+	if t == nil {
+		return nil
+	}
+	return t.ParamMappings
+}
+
+// GetSourceWorkspace returns the source workspace, empty if unset.
+func (t *TektonPipelineRunSpec) GetSourceWorkspace() string {
+	if t == nil {
+		return ""
+	}
+	return t.SourceWorkspace
+}
+
+// Validate validates the Tekton specific configuration of a job.
+func (t *TektonPipelineRunSpec) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if t.V1 != nil && t.V1Beta1 != nil {
+		return errors.New("only one of v1 and v1beta1 can be set")
+	}
+	if t.WorkspaceTemplate != nil && t.SourceWorkspace == "" {
+		return errors.New("workspace_template requires source_workspace to be set")
+	}
+	if t.SourceWorkspace == "" || t.WorkspaceTemplate != nil {
+		return nil
+	}
+	if spec := t.GetPipelineRunSpec(); spec != nil {
+		for _, binding := range spec.Workspaces {
+			if binding.Name == t.SourceWorkspace {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("source_workspace %q must either be bound by the PipelineRun or have a workspace_template", t.SourceWorkspace)
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	fuzz "github.com/google/gofuzz"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func pStr(str string) *string {
@@ -272,6 +273,16 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 				return def
 			},
 		},
+		{
+			name: "azure secret name provided",
+			provided: &DecorationConfig{
+				AzureCredentialsSecret: pStr("overwritten"),
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.AzureCredentialsSecret = orig.AzureCredentialsSecret
+				return def
+			},
+		},
 		{
 			name: "default service account name provided",
 			provided: &DecorationConfig{
@@ -339,6 +350,16 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 				return def
 			},
 		},
+		{
+			name: "steps provided",
+			provided: &DecorationConfig{
+				Steps: []EntrypointStep{{Name: "test", Command: []string{"make", "test"}}},
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.Steps = orig.Steps
+				return def
+			},
+		},
 		{
 			name: "ignore interrupts set",
 			provided: &DecorationConfig{
@@ -381,11 +402,12 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 					DefaultOrg:   "org",
 					DefaultRepo:  "repo",
 				},
-				GCSCredentialsSecret: pStr("secretName"),
-				S3CredentialsSecret:  pStr("s3-secret"),
-				SSHKeySecrets:        []string{"first", "second"},
-				SSHHostFingerprints:  []string{"primero", "segundo"},
-				SkipCloning:          &truth,
+				GCSCredentialsSecret:   pStr("secretName"),
+				S3CredentialsSecret:    pStr("s3-secret"),
+				AzureCredentialsSecret: pStr("azure-secret"),
+				SSHKeySecrets:          []string{"first", "second"},
+				SSHHostFingerprints:    []string{"primero", "segundo"},
+				SkipCloning:            &truth,
 			}
 
 			expected := tc.expected(tc.provided, defaults)
@@ -738,6 +760,92 @@ func TestParsePath(t *testing.T) {
 	}
 }
 
+func TestRetryPolicyRetriesOn(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *RetryPolicy
+		state  ProwJobState
+		want   bool
+	}{{
+		name:  "no policy",
+		state: ErrorState,
+		want:  false,
+	}, {
+		name:   "no attempts",
+		policy: &RetryPolicy{On: []ProwJobState{ErrorState}},
+		state:  ErrorState,
+		want:   false,
+	}, {
+		name:   "error by default",
+		policy: &RetryPolicy{Attempts: 1},
+		state:  ErrorState,
+		want:   true,
+	}, {
+		name:   "not aborted by default",
+		policy: &RetryPolicy{Attempts: 1},
+		state:  AbortedState,
+		want:   false,
+	}, {
+		name:   "aborted",
+		policy: &RetryPolicy{Attempts: 1, On: []ProwJobState{AbortedState}},
+		state:  AbortedState,
+		want:   true,
+	}, {
+		name:   "only aborted",
+		policy: &RetryPolicy{Attempts: 1, On: []ProwJobState{AbortedState}},
+		state:  ErrorState,
+		want:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.RetriesOn(tt.state); got != tt.want {
+				t.Errorf("RetryPolicy.RetriesOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTektonPipelineRunSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *TektonPipelineRunSpec
+		wantErr bool
+	}{{
+		name: "nil",
+	}, {
+		name: "v1",
+		spec: &TektonPipelineRunSpec{V1: &pipelinev1.PipelineRunSpec{}},
+	}, {
+		name:    "v1 and v1beta1",
+		spec:    &TektonPipelineRunSpec{V1: &pipelinev1.PipelineRunSpec{}, V1Beta1: &pipelinev1.PipelineRunSpec{}},
+		wantErr: true,
+	}, {
+		name:    "workspace template without source workspace",
+		spec:    &TektonPipelineRunSpec{V1: &pipelinev1.PipelineRunSpec{}, WorkspaceTemplate: &corev1.PersistentVolumeClaim{}},
+		wantErr: true,
+	}, {
+		name: "source workspace with template",
+		spec: &TektonPipelineRunSpec{V1: &pipelinev1.PipelineRunSpec{}, SourceWorkspace: "source", WorkspaceTemplate: &corev1.PersistentVolumeClaim{}},
+	}, {
+		name: "source workspace bound by the pipeline run",
+		spec: &TektonPipelineRunSpec{
+			V1:              &pipelinev1.PipelineRunSpec{Workspaces: []pipelinev1.WorkspaceBinding{{Name: "source"}}},
+			SourceWorkspace: "source",
+		},
+	}, {
+		name:    "unbound source workspace",
+		spec:    &TektonPipelineRunSpec{V1: &pipelinev1.PipelineRunSpec{}, SourceWorkspace: "source"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("TektonPipelineRunSpec.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestProwJobSpec_HasPipelineRunSpec(t *testing.T) {
 	type fields struct {
 		PipelineRunSpec       *pipelinev1.PipelineRunSpec
@@ -858,6 +966,22 @@ func TestProwJobSpec_GetPipelineRunSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TektonPipelineRunSpec v1 preferred over v1beta1",
+			fields: fields{
+				TektonPipelineRunSpec: &TektonPipelineRunSpec{
+					V1Beta1: &pipelinev1.PipelineRunSpec{
+						TaskRunTemplate: pipelinev1.PipelineTaskRunTemplate{ServiceAccountName: "beta"},
+					},
+					V1: &pipelinev1.PipelineRunSpec{
+						TaskRunTemplate: pipelinev1.PipelineTaskRunTemplate{ServiceAccountName: "robot"},
+					},
+				},
+			},
+			want: &pipelinev1.PipelineRunSpec{
+				TaskRunTemplate: pipelinev1.PipelineTaskRunTemplate{ServiceAccountName: "robot"},
+			},
+		},
 		{
 			name: "PipelineRunSpec and TektonPipelineRunSpec set",
 			fields: fields{
@@ -30,6 +30,27 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheConfiguration) DeepCopyInto(out *CacheConfiguration) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheConfiguration.
+func (in *CacheConfiguration) DeepCopy() *CacheConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CensoringOptions) DeepCopyInto(out *CensoringOptions) {
 	*out = *in
@@ -79,6 +100,23 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(Duration)
 		**out = **in
 	}
+	if in.LogUploadInterval != nil {
+		in, out := &in.LogUploadInterval, &out.LogUploadInterval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]EntrypointStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ResourceUsageConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UtilityImages != nil {
 		in, out := &in.UtilityImages, &out.UtilityImages
 		*out = new(UtilityImages)
@@ -104,6 +142,11 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AzureCredentialsSecret != nil {
+		in, out := &in.AzureCredentialsSecret, &out.AzureCredentialsSecret
+		*out = new(string)
+		**out = **in
+	}
 	if in.DefaultServiceAccountName != nil {
 		in, out := &in.DefaultServiceAccountName, &out.DefaultServiceAccountName
 		*out = new(string)
@@ -124,11 +167,33 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubmoduleDepth != nil {
+		in, out := &in.SubmoduleDepth, &out.SubmoduleDepth
+		*out = new(int)
+		**out = **in
+	}
+	if in.SubmoduleDepths != nil {
+		in, out := &in.SubmoduleDepths, &out.SubmoduleDepths
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.SkipCloning != nil {
 		in, out := &in.SkipCloning, &out.SkipCloning
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(CacheConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CookiefileSecret != nil {
 		in, out := &in.CookiefileSecret, &out.CookiefileSecret
 		*out = new(string)
@@ -238,6 +303,53 @@ func (in *Duration) DeepCopy() *Duration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailReporterConfig) DeepCopyInto(out *EmailReporterConfig) {
+	*out = *in
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailReporterConfig.
+func (in *EmailReporterConfig) DeepCopy() *EmailReporterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailReporterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntrypointStep) DeepCopyInto(out *EntrypointStep) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntrypointStep.
+func (in *EntrypointStep) DeepCopy() *EntrypointStep {
+	if in == nil {
+		return nil
+	}
+	out := new(EntrypointStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCSConfiguration) DeepCopyInto(out *GCSConfiguration) {
 	*out = *in
@@ -467,6 +579,16 @@ func (in *ProwJobSpec) DeepCopyInto(out *ProwJobSpec) {
 		*out = new(ProwJobDefault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -499,6 +621,15 @@ func (in *ProwJobStatus) DeepCopyInto(out *ProwJobStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.RetryTime != nil {
+		in, out := &in.RetryTime, &out.RetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TestSummary != nil {
+		in, out := &in.TestSummary, &out.TestSummary
+		*out = new(TestSummary)
+		**out = **in
+	}
 	return
 }
 
@@ -562,6 +693,18 @@ func (in *Refs) DeepCopyInto(out *Refs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubmoduleDepths != nil {
+		in, out := &in.SubmoduleDepths, &out.SubmoduleDepths
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -583,6 +726,11 @@ func (in *ReporterConfig) DeepCopyInto(out *ReporterConfig) {
 		*out = new(SlackReporterConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailReporterConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -632,6 +780,27 @@ func (in *RerunAuthConfig) DeepCopy() *RerunAuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageConfig) DeepCopyInto(out *ResourceUsageConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageConfig.
+func (in *ResourceUsageConfig) DeepCopy() *ResourceUsageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
@@ -684,6 +853,27 @@ func (in *ResultStoreConfig) DeepCopy() *ResultStoreConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.On != nil {
+		in, out := &in.On, &out.On
+		*out = make([]ProwJobState, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulingOptions) DeepCopyInto(out *SchedulingOptions) {
 	*out = *in
@@ -746,6 +936,23 @@ func (in *TektonPipelineRunSpec) DeepCopyInto(out *TektonPipelineRunSpec) {
 		*out = new(pipelinev1.PipelineRunSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.V1 != nil {
+		in, out := &in.V1, &out.V1
+		*out = new(pipelinev1.PipelineRunSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParamMappings != nil {
+		in, out := &in.ParamMappings, &out.ParamMappings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WorkspaceTemplate != nil {
+		in, out := &in.WorkspaceTemplate, &out.WorkspaceTemplate
+		*out = new(corev1.PersistentVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -759,6 +966,22 @@ func (in *TektonPipelineRunSpec) DeepCopy() *TektonPipelineRunSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestSummary) DeepCopyInto(out *TestSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSummary.
+func (in *TestSummary) DeepCopy() *TestSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TestSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UtilityImages) DeepCopyInto(out *UtilityImages) {
 	*out = *in
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package buildid vends monotonically increasing build IDs per job from a
+// ConfigMap, so installations don't need to run tot.
+package buildid
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultConfigMapName is the conventional name of the ConfigMap that holds
+// the last vended build ID of every job.
+const DefaultConfigMapName = "build-ids"
+
+// Allocator vends build IDs. The last vended ID of every job is stored as an
+// entry of a ConfigMap, which is updated with optimistic concurrency so that
+// several processes can share it.
+type Allocator struct {
+	client    ctrlruntimeclient.Client
+	namespace string
+	name      string
+
+	// lock avoids needless conflicts between vends of the same process.
+	lock sync.Mutex
+}
+
+// NewAllocator returns an Allocator that stores build IDs in the named
+// ConfigMap, which is created on the first vend if it doesn't exist. The
+// client must not read from a cache, or most updates will conflict.
+func NewAllocator(client ctrlruntimeclient.Client, namespace, name string) *Allocator {
+	return &Allocator{client: client, namespace: namespace, name: name}
+}
+
+// Vend returns the next build ID of the job.
+func (a *Allocator) Vend(ctx context.Context, job string) (int, error) {
+	return a.update(ctx, job, func(n int) int { return n + 1 })
+}
+
+// BuildID vends the next build ID of the job as a string.
+func (a *Allocator) BuildID(ctx context.Context, job string) (string, error) {
+	n, err := a.Vend(ctx, job)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(n), nil
+}
+
+// Set overrides the last vended build ID of the job, e.g. to continue the
+// sequence of a job that was migrated from tot.
+func (a *Allocator) Set(ctx context.Context, job string, n int) error {
+	_, err := a.update(ctx, job, func(int) int { return n })
+	return err
+}
+
+// Peek returns the last vended build ID of the job without vending a new one.
+func (a *Allocator) Peek(ctx context.Context, job string) (int, error) {
+	if err := validateJob(job); err != nil {
+		return 0, err
+	}
+	cm := &corev1.ConfigMap{}
+	if err := a.client.Get(ctx, types.NamespacedName{Namespace: a.namespace, Name: a.name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get ConfigMap %s/%s: %w", a.namespace, a.name, err)
+	}
+	return lastBuildID(cm, job)
+}
+
+func (a *Allocator) update(ctx context.Context, job string, next func(int) int) (int, error) {
+	if err := validateJob(job); err != nil {
+		return 0, err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var n int
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := a.client.Get(ctx, types.NamespacedName{Namespace: a.namespace, Name: a.name}, cm)
+		notFound := apierrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return fmt.Errorf("failed to get ConfigMap %s/%s: %w", a.namespace, a.name, err)
+		}
+		last, err := lastBuildID(cm, job)
+		if err != nil {
+			return err
+		}
+		n = next(last)
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[job] = strconv.Itoa(n)
+
+		if !notFound {
+			return a.client.Update(ctx, cm)
+		}
+		cm.Namespace, cm.Name = a.namespace, a.name
+		if err := a.client.Create(ctx, cm); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Somebody else created it first, retry with theirs.
+				return apierrors.NewConflict(corev1.Resource("configmaps"), a.name, err)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update the build ID of %s: %w", job, err)
+	}
+	return n, nil
+}
+
+func validateJob(job string) error {
+	if errs := validation.IsConfigMapKey(job); len(errs) > 0 {
+		return fmt.Errorf("job name %q can't be used as a ConfigMap key: %s", job, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func lastBuildID(cm *corev1.ConfigMap, job string) (int, error) {
+	raw, ok := cm.Data[job]
+	if !ok {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid build ID %q for %s in ConfigMap %s/%s: %w", raw, job, cm.Namespace, cm.Name, err)
+	}
+	return n, nil
+}
+
+// ServeHTTP implements the API of tot under /vend/ for components that are
+// still configured with a tot URL: GET vends the next build ID of a job, HEAD
+// peeks at the last one and POST sets it.
+func (a *Allocator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	job := strings.TrimPrefix(r.URL.Path, "/vend/")
+	log := logrus.WithFields(logrus.Fields{"job": job, "client": r.RemoteAddr})
+	switch r.Method {
+	case http.MethodGet:
+		n, err := a.Vend(r.Context(), job)
+		if err != nil {
+			log.WithError(err).Error("Failed to vend build ID.")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.WithField("build-id", n).Info("Vended build ID.")
+		fmt.Fprintf(w, "%d", n)
+	case http.MethodHead:
+		n, err := a.Peek(r.Context(), job)
+		if err != nil {
+			log.WithError(err).Error("Failed to peek at build ID.")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "%d", n)
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read body: %v", err), http.StatusBadRequest)
+			return
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(string(body)))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid build ID: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := a.Set(r.Context(), job, n); err != nil {
+			log.WithError(err).Error("Failed to set build ID.")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.WithField("build-id", n).Info("Set build ID.")
+	default:
+		http.Error(w, fmt.Sprintf("method %s is not supported", r.Method), http.StatusMethodNotAllowed)
+	}
+}
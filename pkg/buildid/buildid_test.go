@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildid
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVend(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing map[string]string
+		job      string
+		expected []int
+		err      bool
+	}{
+		{
+			name:     "ConfigMap is created on the first vend",
+			job:      "job",
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "numbers continue from the ConfigMap",
+			existing: map[string]string{"job": "41", "other": "7"},
+			job:      "job",
+			expected: []int{42, 43},
+		},
+		{
+			name:     "new jobs start at one",
+			existing: map[string]string{"other": "7"},
+			job:      "job",
+			expected: []int{1},
+		},
+		{
+			name:     "invalid numbers are an error",
+			existing: map[string]string{"job": "nan"},
+			job:      "job",
+			err:      true,
+		},
+		{
+			name: "job names that can't be keys are an error",
+			job:  "folder/job",
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fakectrlruntimeclient.NewClientBuilder()
+			if tc.existing != nil {
+				builder = builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "prow", Name: DefaultConfigMapName},
+					Data:       tc.existing,
+				})
+			}
+			client := builder.Build()
+			a := NewAllocator(client, "prow", DefaultConfigMapName)
+
+			var vended []int
+			for range tc.expected {
+				n, err := a.Vend(context.Background(), tc.job)
+				if err != nil {
+					t.Fatalf("Vend failed: %v", err)
+				}
+				vended = append(vended, n)
+			}
+			if tc.err {
+				if _, err := a.Vend(context.Background(), tc.job); err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			for i := range tc.expected {
+				if vended[i] != tc.expected[i] {
+					t.Fatalf("expected %v, got %v", tc.expected, vended)
+				}
+			}
+
+			cm := &corev1.ConfigMap{}
+			if err := client.Get(context.Background(), types.NamespacedName{Namespace: "prow", Name: DefaultConfigMapName}, cm); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			for job, n := range tc.existing {
+				if job != tc.job && cm.Data[job] != n {
+					t.Errorf("build ID of %s changed from %s to %s", job, n, cm.Data[job])
+				}
+			}
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	a := NewAllocator(fakectrlruntimeclient.NewClientBuilder().Build(), "prow", DefaultConfigMapName)
+	server := httptest.NewServer(a)
+	defer server.Close()
+
+	do := func(method, body string) (int, string) {
+		req, err := http.NewRequest(method, server.URL+"/vend/job", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s failed: %v", method, err)
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read response: %v", err)
+		}
+		return resp.StatusCode, string(b)
+	}
+
+	if code, body := do(http.MethodGet, ""); code != http.StatusOK || body != "1" {
+		t.Errorf("expected first GET to vend 1, got %d %q", code, body)
+	}
+	if code, _ := do(http.MethodPost, "100"); code != http.StatusOK {
+		t.Errorf("expected POST to succeed, got %d", code)
+	}
+	if code, body := do(http.MethodGet, ""); code != http.StatusOK || body != "101" {
+		t.Errorf("expected GET after POST to vend 101, got %d %q", code, body)
+	}
+	if code, _ := do(http.MethodPost, "nan"); code != http.StatusBadRequest {
+		t.Errorf("expected POST of an invalid number to fail, got %d", code)
+	}
+	if n, err := a.Peek(context.Background(), "job"); err != nil || n != 101 {
+		t.Errorf("expected to peek 101, got %d: %v", n, err)
+	}
+}
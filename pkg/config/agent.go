@@ -44,6 +44,48 @@ type Agent struct {
 	mut           sync.RWMutex // do not export Lock, etc methods
 	c             *Config
 	subscriptions []DeltaChan
+	overlays      []string
+	loadStatus    LoadStatus
+}
+
+// LoadStatus describes how the latest attempts of the Agent to load the
+// config went.
+type LoadStatus struct {
+	// LastSuccess is when the config was last loaded successfully.
+	LastSuccess time.Time `json:"last_success"`
+	// LastFailure is when the config last failed to load.
+	LastFailure time.Time `json:"last_failure"`
+	// Error is why the config failed to load, if it failed after the last
+	// success.
+	Error string `json:"error,omitempty"`
+}
+
+// SetOverlays configures files that are merged into the prow config, in order,
+// whenever it is loaded. See ApplyOverlays for their semantics. It must be called
+// before the agent is started.
+func (ca *Agent) SetOverlays(overlays ...string) {
+	ca.overlays = overlays
+}
+
+func (ca *Agent) load(prowConfig, jobConfig string, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) (*Config, error) {
+	c, err := LoadWithOverlays(prowConfig, jobConfig, ca.overlays, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+	ca.mut.Lock()
+	defer ca.mut.Unlock()
+	if err != nil {
+		ca.loadStatus.LastFailure = time.Now()
+		ca.loadStatus.Error = err.Error()
+	} else {
+		ca.loadStatus.LastSuccess = time.Now()
+		ca.loadStatus.Error = ""
+	}
+	return c, err
+}
+
+// LoadStatus returns how the latest attempts to load the config went.
+func (ca *Agent) LoadStatus() LoadStatus {
+	ca.mut.RLock()
+	defer ca.mut.RUnlock()
+	return ca.loadStatus
 }
 
 // IsConfigMapMount determines whether the provided directory is a configmap mounted directory
@@ -202,7 +244,7 @@ func ListCMsAndDirs(path string) (cms sets.Set[string], dirs sets.Set[string], e
 
 func watchConfigs(ca *Agent, prowConfig, jobConfig string, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) error {
 	cmEventFunc := func() error {
-		c, err := Load(prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+		c, err := ca.load(prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
 		if err != nil {
 			return err
 		}
@@ -211,7 +253,7 @@ func watchConfigs(ca *Agent, prowConfig, jobConfig string, supplementalProwConfi
 	}
 	// We may need to add more directories to be watched
 	dirsEventFunc := func(w *fsnotify.Watcher) error {
-		c, err := Load(prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+		c, err := ca.load(prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
 		if err != nil {
 			return err
 		}
@@ -268,13 +310,15 @@ func watchConfigs(ca *Agent, prowConfig, jobConfig string, supplementalProwConfi
 			}
 		}
 	}
-	// The prow config is always a single file
-	if prowIsCMMounted, err := IsConfigMapMount(filepath.Dir(prowConfig)); err != nil {
-		return err
-	} else if prowIsCMMounted {
-		cms.Insert(filepath.Dir(prowConfig))
-	} else {
-		dirs.Insert(prowConfig)
+	// The prow config and its overlays are always single files
+	for _, file := range append([]string{prowConfig}, ca.overlays...) {
+		if isCMMounted, err := IsConfigMapMount(filepath.Dir(file)); err != nil {
+			return err
+		} else if isCMMounted {
+			cms.Insert(filepath.Dir(file))
+		} else {
+			dirs.Insert(file)
+		}
 	}
 	var runFuncs []func(context.Context)
 	for cm := range cms {
@@ -302,7 +346,7 @@ func watchConfigs(ca *Agent, prowConfig, jobConfig string, supplementalProwConfi
 // will log the failure message but continue attempting to load.
 // This function will replace Start in a future release.
 func (ca *Agent) StartWatch(prowConfig, jobConfig string, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) error {
-	c, err := Load(prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+	c, err := ca.load(prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
 	if err != nil {
 		return err
 	}
@@ -311,7 +355,7 @@ func (ca *Agent) StartWatch(prowConfig, jobConfig string, supplementalProwConfig
 	return nil
 }
 
-func lastConfigModTime(prowConfig, jobConfig string) (time.Time, error) {
+func lastConfigModTime(prowConfig, jobConfig string, overlays ...string) (time.Time, error) {
 	// Check if the file changed to see if it needs to be re-read.
 	// os.Stat follows symbolic links, which is how ConfigMaps work.
 	prowStat, err := os.Stat(prowConfig)
@@ -332,6 +376,16 @@ func lastConfigModTime(prowConfig, jobConfig string) (time.Time, error) {
 			recentModTime = jobConfigStat.ModTime()
 		}
 	}
+	for _, overlay := range overlays {
+		overlayStat, err := os.Stat(overlay)
+		if err != nil {
+			logrus.WithField("overlay", overlay).WithError(err).Error("Error loading prow config overlay.")
+			return time.Time{}, err
+		}
+		if overlayStat.ModTime().After(recentModTime) {
+			recentModTime = overlayStat.ModTime()
+		}
+	}
 	return recentModTime, nil
 }
 
@@ -339,11 +393,11 @@ func lastConfigModTime(prowConfig, jobConfig string) (time.Time, error) {
 // fails, Start will return the error and abort. Future load failures will log
 // the failure message but continue attempting to load.
 func (ca *Agent) Start(prowConfig, jobConfig string, additionalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) error {
-	lastModTime, err := lastConfigModTime(prowConfig, jobConfig)
+	lastModTime, err := lastConfigModTime(prowConfig, jobConfig, ca.overlays...)
 	if err != nil {
 		lastModTime = time.Time{}
 	}
-	c, err := Load(prowConfig, jobConfig, additionalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+	c, err := ca.load(prowConfig, jobConfig, additionalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
 	if err != nil {
 		return err
 	}
@@ -355,7 +409,7 @@ func (ca *Agent) Start(prowConfig, jobConfig string, additionalProwConfigDirs []
 		skips := 0
 		for range time.Tick(1 * time.Second) {
 			if skips < 600 {
-				recentModTime, err := lastConfigModTime(prowConfig, jobConfig)
+				recentModTime, err := lastConfigModTime(prowConfig, jobConfig, ca.overlays...)
 				if err != nil {
 					continue
 				}
@@ -365,7 +419,7 @@ func (ca *Agent) Start(prowConfig, jobConfig string, additionalProwConfigDirs []
 				}
 				lastModTime = recentModTime
 			}
-			if c, err := Load(prowConfig, jobConfig, additionalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...); err != nil {
+			if c, err := ca.load(prowConfig, jobConfig, additionalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...); err != nil {
 				logrus.WithField("prowConfig", prowConfig).
 					WithField("jobConfig", jobConfig).
 					WithError(err).Error("Error loading config.")
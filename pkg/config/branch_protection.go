@@ -25,6 +25,8 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/github"
 )
 
 // Policy for the config/org/repo/branch.
@@ -51,6 +53,13 @@ type Policy struct {
 	AllowForcePushes *bool `json:"allow_force_pushes,omitempty"`
 	// AllowDeletions allows deletion of the protected branch by anyone with write access to the repository.
 	AllowDeletions *bool `json:"allow_deletions,omitempty"`
+	// RequiredSignatures requires commits pushed to the protected branch to have verified signatures.
+	RequiredSignatures *bool `json:"required_signatures,omitempty"`
+	// Rulesets configures repository rulesets, which can express protections that
+	// branch protection can't. They are managed for each repository, so they can't
+	// be configured for branches. A ruleset replaces the one of the parent policy
+	// with the same name.
+	Rulesets []Ruleset `json:"rulesets,omitempty"`
 	// Exclude specifies a set of regular expressions which identify branches
 	// that should be excluded from the protection policy, mutually exclusive with Include
 	Exclude []string `json:"exclude,omitempty"`
@@ -66,7 +75,8 @@ func (p Policy) Managed() bool {
 
 func (p Policy) defined() bool {
 	return p.Protect != nil || p.RequiredStatusChecks != nil || p.Admins != nil || p.Restrictions != nil || p.RequireManuallyTriggeredJobs != nil ||
-		p.RequiredPullRequestReviews != nil || p.RequiredLinearHistory != nil || p.AllowForcePushes != nil || p.AllowDeletions != nil
+		p.RequiredPullRequestReviews != nil || p.RequiredLinearHistory != nil || p.AllowForcePushes != nil || p.AllowDeletions != nil ||
+		p.RequiredSignatures != nil
 }
 
 // ContextPolicy configures required github contexts.
@@ -117,6 +127,24 @@ type Restrictions struct {
 	Teams []string `json:"teams,omitempty"`
 }
 
+// Ruleset configures a repository ruleset by name.
+type Ruleset struct {
+	// Name identifies the ruleset in the repository.
+	Name string `json:"name"`
+	// Enforcement is either active, evaluate or disabled, defaulting to active.
+	Enforcement string `json:"enforcement,omitempty"`
+	// Include specifies the branches the ruleset applies to, by name patterns like
+	// release-*, ~DEFAULT_BRANCH for the default branch or ~ALL for all branches.
+	Include []string `json:"include,omitempty"`
+	// Exclude specifies the branches the ruleset does not apply to, by name patterns.
+	Exclude []string `json:"exclude,omitempty"`
+	// RequiredDeployments lists the environments that commits must be deployed to
+	// successfully before they can be pushed to the branches.
+	RequiredDeployments []string `json:"required_deployments,omitempty"`
+	// RequiredSignatures requires commits pushed to the branches to have verified signatures.
+	RequiredSignatures *bool `json:"required_signatures,omitempty"`
+}
+
 // selectInt returns the child if set, else parent
 func selectInt(parent, child *int) *int {
 	if child != nil {
@@ -215,6 +243,35 @@ func mergeRestrictions(parent, child *Restrictions) *Restrictions {
 	}
 }
 
+// mergeRulesets replaces the parent rulesets with the child ones of the same
+// name and appends the other child rulesets
+func mergeRulesets(parent, child []Ruleset) []Ruleset {
+	if child == nil {
+		return parent
+	}
+	if parent == nil {
+		return child
+	}
+	children := map[string]Ruleset{}
+	for _, r := range child {
+		children[r.Name] = r
+	}
+	var merged []Ruleset
+	for _, r := range parent {
+		if c, ok := children[r.Name]; ok {
+			r = c
+			delete(children, r.Name)
+		}
+		merged = append(merged, r)
+	}
+	for _, r := range child {
+		if _, ok := children[r.Name]; ok {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
 // Apply returns a policy that merges the child into the parent
 func (p Policy) Apply(child Policy) Policy {
 	return Policy{
@@ -225,11 +282,13 @@ func (p Policy) Apply(child Policy) Policy {
 		RequiredLinearHistory:        selectBool(p.RequiredLinearHistory, child.RequiredLinearHistory),
 		AllowForcePushes:             selectBool(p.AllowForcePushes, child.AllowForcePushes),
 		AllowDeletions:               selectBool(p.AllowDeletions, child.AllowDeletions),
+		RequiredSignatures:           selectBool(p.RequiredSignatures, child.RequiredSignatures),
 		RequireManuallyTriggeredJobs: selectBool(p.RequireManuallyTriggeredJobs, child.RequireManuallyTriggeredJobs),
 		Restrictions:                 mergeRestrictions(p.Restrictions, child.Restrictions),
 		RequiredPullRequestReviews:   mergeReviewPolicy(p.RequiredPullRequestReviews, child.RequiredPullRequestReviews),
 		Exclude:                      unionStrings(p.Exclude, child.Exclude),
 		Include:                      unionStrings(p.Include, child.Include),
+		Rulesets:                     mergeRulesets(p.Rulesets, child.Rulesets),
 	}
 }
 
@@ -363,6 +422,46 @@ func (bp *BranchProtection) merge(additional *BranchProtection) error {
 	return utilerrors.NewAggregate(errs)
 }
 
+// validateRulesets checks the rulesets of all policies.
+func (bp BranchProtection) validateRulesets() error {
+	errs := validateRulesets("branch-protection", bp.Rulesets)
+	for orgName, org := range bp.Orgs {
+		errs = append(errs, validateRulesets(orgName, org.Rulesets)...)
+		for repoName, repo := range org.Repos {
+			errs = append(errs, validateRulesets(orgName+"/"+repoName, repo.Rulesets)...)
+			for branchName, branch := range repo.Branches {
+				if len(branch.Rulesets) > 0 {
+					errs = append(errs, fmt.Errorf("%s/%s=%s: rulesets can not be configured for branches", orgName, repoName, branchName))
+				}
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateRulesets(scope string, rulesets []Ruleset) []error {
+	var errs []error
+	names := sets.New[string]()
+	for _, r := range rulesets {
+		if r.Name == "" {
+			errs = append(errs, fmt.Errorf("%s: rulesets must have a name", scope))
+		} else if names.Has(r.Name) {
+			errs = append(errs, fmt.Errorf("%s: duplicate ruleset %q", scope, r.Name))
+		}
+		names.Insert(r.Name)
+		switch r.Enforcement {
+		case "", github.RulesetEnforcementActive, github.RulesetEnforcementEvaluate, github.RulesetEnforcementDisabled:
+		default:
+			errs = append(errs, fmt.Errorf("%s: ruleset %q has invalid enforcement %q, must be one of %s, %s or %s", scope, r.Name, r.Enforcement,
+				github.RulesetEnforcementActive, github.RulesetEnforcementEvaluate, github.RulesetEnforcementDisabled))
+		}
+		if len(r.Include) == 0 {
+			errs = append(errs, fmt.Errorf("%s: ruleset %q must include branches", scope, r.Name))
+		}
+	}
+	return errs
+}
+
 // GetOrg returns the org config after merging in any global policies.
 func (bp BranchProtection) GetOrg(name string) *Org {
 	o, ok := bp.Orgs[name]
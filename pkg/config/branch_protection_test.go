@@ -302,6 +302,30 @@ func TestApply(test *testing.T) {
 				Include: []string{"bar*", "foo*"},
 			},
 		},
+		{
+			name: "child rulesets replace parent rulesets with the same name",
+			parent: Policy{
+				Rulesets: []Ruleset{
+					{Name: "deployments", RequiredDeployments: []string{"staging"}},
+					{Name: "signatures", RequiredSignatures: &t},
+				},
+			},
+			child: Policy{
+				RequiredSignatures: &t,
+				Rulesets: []Ruleset{
+					{Name: "new", Include: []string{"main"}},
+					{Name: "deployments", RequiredDeployments: []string{"production"}},
+				},
+			},
+			expected: Policy{
+				RequiredSignatures: &t,
+				Rulesets: []Ruleset{
+					{Name: "deployments", RequiredDeployments: []string{"production"}},
+					{Name: "signatures", RequiredSignatures: &t},
+					{Name: "new", Include: []string{"main"}},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -321,6 +345,62 @@ func TestApply(test *testing.T) {
 	}
 }
 
+func TestValidateRulesets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      BranchProtection
+		expectedErr string
+	}{
+		{
+			name: "valid rulesets",
+			config: BranchProtection{
+				Policy: Policy{Rulesets: []Ruleset{{Name: "signatures", Include: []string{"~ALL"}, RequiredSignatures: yes}}},
+				Orgs: map[string]Org{"org": {
+					Policy: Policy{Rulesets: []Ruleset{{Name: "signatures", Enforcement: "evaluate", Include: []string{"~ALL"}}}},
+					Repos: map[string]Repo{"repo": {
+						Policy: Policy{Rulesets: []Ruleset{{Name: "deployments", Include: []string{"~DEFAULT_BRANCH"}, RequiredDeployments: []string{"staging"}}}},
+					}},
+				}},
+			},
+		},
+		{
+			name: "invalid rulesets",
+			config: BranchProtection{
+				Orgs: map[string]Org{"org": {
+					Policy: Policy{Rulesets: []Ruleset{
+						{Name: "signatures", Include: []string{"~ALL"}},
+						{Name: "signatures", Enforcement: "enforced"},
+						{Include: []string{"~ALL"}},
+					}},
+				}},
+			},
+			expectedErr: `[org: duplicate ruleset "signatures", org: ruleset "signatures" has invalid enforcement "enforced", must be one of active, evaluate or disabled, org: ruleset "signatures" must include branches, org: rulesets must have a name]`,
+		},
+		{
+			name: "branch rulesets",
+			config: BranchProtection{
+				Orgs: map[string]Org{"org": {
+					Repos: map[string]Repo{"repo": {
+						Branches: map[string]Branch{"main": {Policy: Policy{Rulesets: []Ruleset{{Name: "signatures", Include: []string{"~ALL"}}}}}},
+					}},
+				}},
+			},
+			expectedErr: "org/repo=main: rulesets can not be configured for branches",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := tc.config.validateRulesets(); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
 func TestBranchRequirements(t *testing.T) {
 	cases := []struct {
 		name                            string
@@ -24,12 +24,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/mail"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -57,6 +59,7 @@ import (
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 )
@@ -144,14 +147,20 @@ type ProwConfig struct {
 	Tide                 Tide                 `json:"tide,omitempty"`
 	Plank                Plank                `json:"plank,omitempty"`
 	Sinker               Sinker               `json:"sinker,omitempty"`
+	JobGC                JobGC                `json:"job_gc,omitempty"`
 	Deck                 Deck                 `json:"deck,omitempty"`
 	BranchProtection     BranchProtection     `json:"branch-protection"`
 	Gerrit               Gerrit               `json:"gerrit"`
 	GitHubReporter       GitHubReporter       `json:"github_reporter"`
+	GitLabReporter       *GitLabReporter      `json:"gitlab_reporter,omitempty"`
 	Horologium           Horologium           `json:"horologium"`
 	SlackReporterConfigs SlackReporterConfigs `json:"slack_reporter_configs,omitempty"`
 	InRepoConfig         InRepoConfig         `json:"in_repo_config"`
 
+	// PubSubReporter configures the messages that crier publishes for jobs
+	// with Pub/Sub annotations.
+	PubSubReporter *PubSubReporter `json:"pubsub_reporter,omitempty"`
+
 	// Gangway contains configurations needed by the the Prow API server of the
 	// same name. It encodes an allowlist of API clients and what kinds of Prow
 	// Jobs they are authorized to trigger.
@@ -204,6 +213,13 @@ type ProwConfig struct {
 	// can be used to restrict build cluster on a topic.
 	PubSubTriggers PubSubTriggers `json:"pubsub_triggers,omitempty"`
 
+	// SQSTriggers defines AWS SQS queues that we want to listen to. Messages
+	// published to AWS SNS topics are received through queues subscribed to them.
+	SQSTriggers SQSTriggers `json:"sqs_triggers,omitempty"`
+
+	// NATSTriggers defines NATS subjects that we want to listen to.
+	NATSTriggers NATSTriggers `json:"nats_triggers,omitempty"`
+
 	// GitHubOptions allows users to control how prow applications display GitHub website links.
 	GitHubOptions GitHubOptions `json:"github,omitempty"`
 
@@ -227,6 +243,12 @@ type ProwConfig struct {
 	// matching entries.
 	ProwJobDefaultEntries []*ProwJobDefaultEntry `json:"prowjob_default_entries,omitempty"`
 
+	// Tenants maps tenant IDs to the orgs, repos, buckets and build clusters
+	// of the tenants sharing this Prow instance. Jobs of the repos of a tenant
+	// get its ID unless a ProwJobDefault sets another one, and jobs may not use
+	// the buckets or build clusters of other tenants.
+	Tenants map[string]Tenant `json:"tenants,omitempty"`
+
 	// DisabledClusters holds a list of disabled build cluster names. The same context names will be ignored while
 	// Prow components load the kubeconfig files.
 	DisabledClusters []string `json:"disabled_clusters,omitempty"`
@@ -241,6 +263,17 @@ type InRepoConfig struct {
 	// a given repo. All clusters that are allowed for the specific repo, its org or
 	// globally can be used.
 	AllowedClusters map[string][]string `json:"allowed_clusters,omitempty"`
+	// AllowedIncludeRepos is a list of repos whose jobs can be included by the
+	// inrepoconfig of a given repo. All repos that are allowed for the specific
+	// repo, its org or globally can be included.
+	AllowedIncludeRepos map[string][]string `json:"allowed_include_repos,omitempty"`
+	// RequireApproval makes the changes of a PR to the inrepoconfig only take
+	// effect at head commits that the approve plugin reported as approved by
+	// the prow config approvers, see its in_repo_config_ownership option.
+	// Until then the inrepoconfig of the base branch is used. This can be set
+	// globally, per org or per repo using '*', 'org' or 'org/repo' as key. The
+	// narrowest match always takes precedence.
+	RequireApproval map[string]*bool `json:"require_approval,omitempty"`
 }
 
 func SplitRepoName(fullRepoName string) (string, string, error) {
@@ -267,6 +300,17 @@ func (c *Config) InRepoConfigEnabled(identifier string) bool {
 	return false
 }
 
+// InRepoConfigRequiresApproval returns whether changes of PRs to the
+// InRepoConfig of a given repository need to be approved.
+func (c *Config) InRepoConfigRequiresApproval(identifier string) bool {
+	for _, key := range keysForIdentifier(identifier) {
+		if c.InRepoConfig.RequireApproval[key] != nil {
+			return *c.InRepoConfig.RequireApproval[key]
+		}
+	}
+	return false
+}
+
 // InRepoConfigAllowsCluster determines if a given cluster may be used for a given repository
 // Assumes that config will not include http:// or https://
 func (c *Config) InRepoConfigAllowsCluster(clusterName, identifier string) bool {
@@ -280,6 +324,19 @@ func (c *Config) InRepoConfigAllowsCluster(clusterName, identifier string) bool
 	return false
 }
 
+// InRepoConfigAllowsInclude determines if a given repo can include the jobs of
+// includedRepo in its inrepoconfig.
+func (c *Config) InRepoConfigAllowsInclude(includedRepo, identifier string) bool {
+	for _, key := range keysForIdentifier(identifier) {
+		for _, allowedRepo := range c.InRepoConfig.AllowedIncludeRepos[key] {
+			if allowedRepo == includedRepo {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // keysForIdentifier returns all possible identifiers for given keys. In
 // consideration of Gerrit identifiers that contain `https://` prefix, it
 // returns keys contain both `https://foo/bar` and `foo/bar` for identifier
@@ -626,17 +683,23 @@ type Controller struct {
 // If the repository doesn't exist in the report_templates configuration it will
 // inherit the values from its organization, otherwise the default values will be used.
 func (c *Controller) ReportTemplateForRepo(refs *prowapi.Refs) *template.Template {
-	def := c.ReportTemplates["*"]
+	return templateForRepo(c.ReportTemplates, refs)
+}
+
+// templateForRepo returns the template of the repository, falling back to the
+// one of its organization and then to the default one keyed by `*`.
+func templateForRepo(templates map[string]*template.Template, refs *prowapi.Refs) *template.Template {
+	def := templates["*"]
 
 	if refs == nil {
 		return def
 	}
 
 	orgRepo := fmt.Sprintf("%s/%s", refs.Org, refs.Repo)
-	if tmplByRepo, ok := c.ReportTemplates[orgRepo]; ok {
+	if tmplByRepo, ok := templates[orgRepo]; ok {
 		return tmplByRepo
 	}
-	if tmplByOrg, ok := c.ReportTemplates[refs.Org]; ok {
+	if tmplByOrg, ok := templates[refs.Org]; ok {
 		return tmplByOrg
 	}
 	return def
@@ -654,6 +717,10 @@ type Plank struct {
 	// PodUnscheduledTimeout defines how long the controller will wait to abort a prowjob
 	// stuck in an unscheduled state. Defaults to 5 minutes.
 	PodUnscheduledTimeout *metav1.Duration `json:"pod_unscheduled_timeout,omitempty"`
+	// DependencyGracePeriod defines how long the controller will wait for the
+	// dependencies of a job to be triggered for the same refs before it aborts
+	// the job. Defaults to 5 minutes.
+	DependencyGracePeriod *metav1.Duration `json:"dependency_grace_period,omitempty"`
 
 	// DefaultDecorationConfigs holds the default decoration config for specific values.
 	//
@@ -708,6 +775,26 @@ type Plank struct {
 	// limit. An example use case would be easier scheduling of jobs using boskos resources.
 	// This mechanism is separate from ProwJob's MaxConcurrency setting.
 	JobQueueCapacities map[string]int `json:"job_queue_capacities,omitempty"`
+
+	// FailureClassifiers classify failed and errored jobs whose description or
+	// container termination messages match their pattern. They take precedence
+	// over the classification based on the status of the pod. Decorated
+	// containers fall back to the tail of their logs as termination message.
+	FailureClassifiers []FailureClassifier `json:"failure_classifiers,omitempty"`
+}
+
+// FailureClassifier classifies the failures matching Pattern.
+type FailureClassifier struct {
+	// Pattern is the regular expression to look for.
+	Pattern string `json:"pattern"`
+	// Classification is one of infra, test or config.
+	Classification prowapi.FailureClassification `json:"classification"`
+	// Message is the explanation shown along with the classification. Defaults
+	// to the matched text.
+	Message string `json:"message,omitempty"`
+
+	// Re is the compiled Pattern.
+	Re *regexp.Regexp `json:"-"`
 }
 
 type ProwJobDefaultEntry struct {
@@ -792,6 +879,100 @@ func (d *DefaultRerunAuthConfigEntry) matches(repo, cluster string) bool {
 	return matches(d.OrgRepo, d.Cluster, repo, cluster)
 }
 
+// Tenant holds the resources of a tenant of the Prow instance.
+type Tenant struct {
+	// Repos are the orgs and repos of the tenant, in the form "org" or
+	// "org/repo". A repo may only belong to one tenant; an entry for a repo
+	// takes precedence over an entry for its org in another tenant.
+	Repos []string `json:"repos,omitempty"`
+	// Buckets are the buckets the jobs of the tenant upload their artifacts
+	// to. If set, jobs of the tenant may only use these buckets. Buckets of a
+	// tenant may not be used by jobs of other tenants.
+	Buckets []string `json:"buckets,omitempty"`
+	// Clusters are the build clusters the jobs of the tenant run in. If set,
+	// jobs of the tenant may only run in these clusters. Clusters of a tenant
+	// may not be used by jobs of other tenants.
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// TenantForRepo returns the ID of the tenant the repo, in the form
+// "org/repo", belongs to, or an empty string if it belongs to no tenant.
+func (pc *ProwConfig) TenantForRepo(repo string) string {
+	org, _, _ := strings.Cut(repo, "/")
+	var orgTenant string
+	for id, tenant := range pc.Tenants {
+		for _, r := range tenant.Repos {
+			switch r {
+			case repo:
+				return id
+			case org:
+				orgTenant = id
+			}
+		}
+	}
+	return orgTenant
+}
+
+// validateTenants makes sure that no org or repo belongs to several tenants.
+func (pc *ProwConfig) validateTenants() error {
+	var errs []error
+	owners := map[string]string{}
+	for _, id := range sets.List(sets.KeySet(pc.Tenants)) {
+		for _, repo := range pc.Tenants[id].Repos {
+			if owner, ok := owners[repo]; ok {
+				errs = append(errs, fmt.Errorf("tenants %q and %q both contain %q", owner, id, repo))
+				continue
+			}
+			owners[repo] = id
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateTenantIsolation makes sure that a job only uses the buckets and
+// build clusters its tenant may use.
+func (c Config) validateTenantIsolation(v JobBase) error {
+	if len(c.Tenants) == 0 || v.ProwJobDefault == nil {
+		return nil
+	}
+	id := v.ProwJobDefault.TenantID
+	var errs []error
+	if err := c.checkTenantResource(id, "cluster", v.Cluster, func(t Tenant) []string { return t.Clusters }); err != nil {
+		errs = append(errs, err)
+	}
+	if v.DecorationConfig != nil && v.DecorationConfig.GCSConfiguration != nil && v.DecorationConfig.GCSConfiguration.Bucket != "" {
+		bucket := stripProviderPrefixFromBucket(v.DecorationConfig.GCSConfiguration.Bucket)
+		if err := c.checkTenantResource(id, "bucket", bucket, func(t Tenant) []string {
+			var buckets []string
+			for _, b := range t.Buckets {
+				buckets = append(buckets, stripProviderPrefixFromBucket(b))
+			}
+			return buckets
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c Config) checkTenantResource(id, kind, name string, resources func(Tenant) []string) error {
+	if name == "" {
+		return nil
+	}
+	if tenant, ok := c.Tenants[id]; ok && len(resources(tenant)) > 0 {
+		if !slices.Contains(resources(tenant), name) {
+			return fmt.Errorf("%s %q is not one of the %ss of tenant %q", kind, name, kind, id)
+		}
+		return nil
+	}
+	for _, other := range sets.List(sets.KeySet(c.Tenants)) {
+		if other != id && slices.Contains(resources(c.Tenants[other]), name) {
+			return fmt.Errorf("%s %q belongs to tenant %q", kind, name, other)
+		}
+	}
+	return nil
+}
+
 // mergeProwJobDefault finds all matching ProwJobDefaultEntry
 // for a job and merges them sequentially before merging into the job's own
 // PrwoJobDefault. Configs merged later override values from earlier configs.
@@ -806,6 +987,9 @@ func (pc *ProwConfig) mergeProwJobDefault(repo, cluster string, jobDefault *prow
 	if merged == nil {
 		merged = &prowapi.ProwJobDefault{}
 	}
+	if merged.TenantID == "" {
+		merged.TenantID = pc.TenantForRepo(repo)
+	}
 	if merged.TenantID == "" {
 		merged.TenantID = DefaultTenantID
 	}
@@ -993,6 +1177,23 @@ type GerritOrgRepoConfig struct {
 	// the help message with comments like `/test ?`, `/retest ?`, `/test
 	// job-not-exist`, `/test job-only-available-from-another-prow`.
 	OptOutHelp bool `json:"opt_out_help,omitempty"`
+	// IgnoreCommandsUntilReady is the flag for determining whether comment
+	// commands like `/test` are ignored on work in progress and private changes.
+	// Jobs never run automatically on such changes, if this is true they don't
+	// run at all until the change is marked ready for review and made public.
+	IgnoreCommandsUntilReady bool `json:"ignore_commands_until_ready,omitempty"`
+	// TrustedGroups are the names or UUIDs of the Gerrit groups whose members
+	// are trusted to run jobs, like the members of the org on GitHub. If set,
+	// jobs only run on changes owned by a member whose current revision was
+	// uploaded by a member, or on whose current revision a member commented
+	// `/ok-to-test`, and commands on other changes like `/test` and `/retest`
+	// are only honored when a member comments them.
+	TrustedGroups []string `json:"trusted_groups,omitempty"`
+	// ReportChecks is the flag for determining whether the results of jobs
+	// are reported as individual checks of the Gerrit checks plugin instead
+	// of an aggregated review message and vote. Crier creates a checker for
+	// each job of the repo, which submit requirements can be based on.
+	ReportChecks bool `json:"report_checks,omitempty"`
 	// Filters are used for limiting the scope of querying the Gerrit server.
 	// Currently supports branches and excluded branches.
 	Filters *GerritQueryFilter `json:"filters,omitempty"`
@@ -1036,13 +1237,86 @@ func (goc *GerritOrgRepoConfigs) OptOutHelpRepos() map[string]sets.Set[string] {
 	return res
 }
 
+// IgnoreCommandsUntilReady returns whether comment commands are ignored on
+// work in progress and private changes of the repo.
+func (goc *GerritOrgRepoConfigs) IgnoreCommandsUntilReady(org, repo string) bool {
+	if goc == nil {
+		return false
+	}
+	for _, orgConfig := range *goc {
+		if orgConfig.Org == org && orgConfig.IgnoreCommandsUntilReady && slices.Contains(orgConfig.Repos, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportChecks returns whether the results of the jobs of the repo are reported
+// as checks of the Gerrit checks plugin.
+func (goc *GerritOrgRepoConfigs) ReportChecks(org, repo string) bool {
+	if goc == nil {
+		return false
+	}
+	for _, orgConfig := range *goc {
+		if orgConfig.Org == org && orgConfig.ReportChecks && slices.Contains(orgConfig.Repos, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrustedGroups returns the Gerrit groups trusted to run jobs on the changes
+// of the repo, or nil if everyone is trusted.
+func (goc *GerritOrgRepoConfigs) TrustedGroups(org, repo string) []string {
+	if goc == nil {
+		return nil
+	}
+	var groups []string
+	for _, orgConfig := range *goc {
+		if orgConfig.Org == org && slices.Contains(orgConfig.Repos, repo) {
+			groups = append(groups, orgConfig.TrustedGroups...)
+		}
+	}
+	return groups
+}
+
 // Horologium is config for the Horologium.
 type Horologium struct {
 	// TickInterval is the interval in which we check if new jobs need to be
 	// created. Defaults to one minute.
 	TickInterval *metav1.Duration `json:"tick_interval,omitempty"`
+	// MaxJitter is the max_jitter of periodics that don't set one. Runs of
+	// periodics are delayed by up to their max_jitter, so periodics sharing a
+	// schedule don't all start at once. Defaults to no jitter.
+	MaxJitter *metav1.Duration `json:"max_jitter,omitempty"`
+	// JitterStrategy is how the delay of a run is picked within the
+	// max_jitter of its periodic. "random" (the default) picks another delay
+	// for every run. "spread" always picks the same delay for a periodic, so
+	// its runs stay evenly spaced while the periodics sharing a schedule are
+	// spread across the max_jitter.
+	JitterStrategy string `json:"jitter_strategy,omitempty"`
+}
+
+// Validate validates the horologium config.
+func (h *Horologium) Validate() error {
+	if h.MaxJitter != nil && h.MaxJitter.Duration < 0 {
+		return errors.New("horologium.max_jitter must not be negative")
+	}
+	switch h.JitterStrategy {
+	case "", JitterStrategyRandom, JitterStrategySpread:
+	default:
+		return fmt.Errorf("invalid value %q for horologium.jitter_strategy, must be one of %q and %q", h.JitterStrategy, JitterStrategyRandom, JitterStrategySpread)
+	}
+	return nil
 }
 
+const (
+	// JitterStrategyRandom picks another delay for every run of a periodic.
+	JitterStrategyRandom = "random"
+	// JitterStrategySpread picks the same delay for every run of a periodic.
+	JitterStrategySpread = "spread"
+)
+
 // JenkinsOperator is config for the jenkins-operator controller.
 type JenkinsOperator struct {
 	Controller `json:",inline"`
@@ -1073,6 +1347,90 @@ type GitHubReporter struct {
 	// comments is only sent when all jobs from current SHA are finished. Status
 	// contexts will still be written.
 	SummaryCommentRepos []string `json:"summary_comment_repos,omitempty"`
+
+	// FailureCommentTemplateStrings is a mapping of templates for the text
+	// above the table of failed tests in failure report comments, e.g. to
+	// link to a runbook or ping the owners of the repo. Use `org/repo`, `org`
+	// or `*` as a key. The template is passed the login of the author of the
+	// pull request as .Author, the number of failed tests as .Failed and the
+	// first reported job as .ProwJob. Defaults to pinging the author and
+	// explaining how to rerun the failed tests.
+	FailureCommentTemplateStrings map[string]string `json:"failure_comment_templates,omitempty"`
+	// FailureCommentTemplates is compiled at load time from
+	// FailureCommentTemplateStrings.
+	FailureCommentTemplates map[string]*template.Template `json:"-"`
+	// StatusDescriptionTemplateStrings is a mapping of templates for the
+	// description of status contexts. Use `org/repo`, `org` or `*` as a key.
+	// The template is passed the ProwJob. Defaults to the description of the
+	// job.
+	StatusDescriptionTemplateStrings map[string]string `json:"status_description_templates,omitempty"`
+	// StatusDescriptionTemplates is compiled at load time from
+	// StatusDescriptionTemplateStrings.
+	StatusDescriptionTemplates map[string]*template.Template `json:"-"`
+}
+
+// FailureCommentTemplateForRepo returns the failure comment template of the
+// repository, its organization or the default one, in that order. It returns
+// nil if none of them is configured.
+func (g *GitHubReporter) FailureCommentTemplateForRepo(refs *prowapi.Refs) *template.Template {
+	return templateForRepo(g.FailureCommentTemplates, refs)
+}
+
+// StatusDescriptionTemplateForRepo returns the status description template of
+// the repository, its organization or the default one, in that order. It
+// returns nil if none of them is configured.
+func (g *GitHubReporter) StatusDescriptionTemplateForRepo(refs *prowapi.Refs) *template.Template {
+	return templateForRepo(g.StatusDescriptionTemplates, refs)
+}
+
+// GitLabReporter holds the config for reporting the status of jobs of repos
+// that are hosted on GitLab.
+type GitLabReporter struct {
+	// Repos is a list of orgs and org/repos that are hosted on GitLab. The
+	// statuses of their jobs are reported as GitLab commit statuses instead
+	// of GitHub status contexts. For GitLab, the org is the full path of the
+	// group the repo belongs to.
+	Repos []string `json:"repos,omitempty"`
+	// JobTypesToReport is used to determine which type of prowjob
+	// should be reported to GitLab.
+	//
+	// defaults to both presubmit and postsubmit jobs.
+	JobTypesToReport []prowapi.ProwJobType `json:"job_types_to_report,omitempty"`
+}
+
+// PubSubFormatCloudEvents is the format of Pub/Sub reports that are
+// structured CloudEvents 1.0 events.
+const PubSubFormatCloudEvents = "cloudevents"
+
+// PubSubReporter holds the config for reporting the status of jobs to Pub/Sub.
+type PubSubReporter struct {
+	// Format is the format of the published messages. By default, the
+	// message is the JSON report of the job. With "cloudevents", it is a
+	// structured CloudEvents 1.0 event with the report as its data, so that
+	// consumers like Knative and Eventarc can subscribe without adapters.
+	// Jobs can override it with the prow.k8s.io/pubsub.format annotation.
+	Format string `json:"format,omitempty"`
+}
+
+// JobTypes returns the types of jobs that are reported to GitLab.
+func (g *GitLabReporter) JobTypes() []prowapi.ProwJobType {
+	if g == nil || len(g.JobTypesToReport) == 0 {
+		return []prowapi.ProwJobType{prowapi.PresubmitJob, prowapi.PostsubmitJob}
+	}
+	return g.JobTypesToReport
+}
+
+// HostsRepo returns whether the org/repo is hosted on GitLab.
+func (g *GitLabReporter) HostsRepo(org, repo string) bool {
+	if g == nil {
+		return false
+	}
+	for _, ident := range g.Repos {
+		if ident == org || ident == org+"/"+repo {
+			return true
+		}
+	}
+	return false
 }
 
 // Sinker is config for the sinker controller.
@@ -1092,6 +1450,122 @@ type Sinker struct {
 	TerminatedPodTTL *metav1.Duration `json:"terminated_pod_ttl,omitempty"`
 	// ExcludeClusters are build clusters that don't want to be managed by sinker.
 	ExcludeClusters []string `json:"exclude_clusters,omitempty"`
+	// RetentionOverrides override MaxProwJobAge, MaxPodAge and TerminatedPodTTL
+	// for the completed ProwJobs, and their pods, of some states or repositories,
+	// e.g. to keep failed jobs longer than successful ones. The first override
+	// matching a ProwJob applies to it.
+	RetentionOverrides []SinkerRetentionOverride `json:"retention_overrides,omitempty"`
+}
+
+// SinkerRetentionOverride overrides the retention of the ProwJobs it matches.
+type SinkerRetentionOverride struct {
+	// Repos are the "org" or "org/repo" of the ProwJobs the override applies
+	// to. Periodics are matched by their first extra ref. The override
+	// applies to all repositories if it is empty.
+	Repos []string `json:"repos,omitempty"`
+	// States are the states of the ProwJobs the override applies to, e.g.
+	// failure and error. The override applies to all states if it is empty.
+	States []prowapi.ProwJobState `json:"states,omitempty"`
+	// MaxProwJobAge overrides Sinker.MaxProwJobAge if set.
+	MaxProwJobAge *metav1.Duration `json:"max_prowjob_age,omitempty"`
+	// MaxPodAge overrides Sinker.MaxPodAge if set.
+	MaxPodAge *metav1.Duration `json:"max_pod_age,omitempty"`
+	// TerminatedPodTTL overrides Sinker.TerminatedPodTTL if set. Defaults
+	// to MaxPodAge if only that is set.
+	TerminatedPodTTL *metav1.Duration `json:"terminated_pod_ttl,omitempty"`
+}
+
+func (o *SinkerRetentionOverride) matches(pj *prowapi.ProwJob) bool {
+	if len(o.States) > 0 && !slices.Contains(o.States, pj.Status.State) {
+		return false
+	}
+	if len(o.Repos) == 0 {
+		return true
+	}
+	refs := pj.Spec.Refs
+	if refs == nil && len(pj.Spec.ExtraRefs) > 0 {
+		refs = &pj.Spec.ExtraRefs[0]
+	}
+	if refs == nil {
+		return false
+	}
+	return slices.Contains(o.Repos, refs.Org) || slices.Contains(o.Repos, refs.Org+"/"+refs.Repo)
+}
+
+// retentionOverride returns the first override matching the ProwJob, if any.
+func (s *Sinker) retentionOverride(pj *prowapi.ProwJob) *SinkerRetentionOverride {
+	if pj == nil {
+		return nil
+	}
+	for i := range s.RetentionOverrides {
+		if s.RetentionOverrides[i].matches(pj) {
+			return &s.RetentionOverrides[i]
+		}
+	}
+	return nil
+}
+
+// MaxProwJobAgeFor returns how old the ProwJob can be before it is
+// garbage-collected.
+func (s *Sinker) MaxProwJobAgeFor(pj *prowapi.ProwJob) time.Duration {
+	if o := s.retentionOverride(pj); o != nil && o.MaxProwJobAge != nil {
+		return o.MaxProwJobAge.Duration
+	}
+	return s.MaxProwJobAge.Duration
+}
+
+// MaxPodAgeFor returns how old a Pod of the ProwJob can be before it is
+// garbage-collected. The ProwJob may be nil if it doesn't exist anymore.
+func (s *Sinker) MaxPodAgeFor(pj *prowapi.ProwJob) time.Duration {
+	if o := s.retentionOverride(pj); o != nil && o.MaxPodAge != nil {
+		return o.MaxPodAge.Duration
+	}
+	return s.MaxPodAge.Duration
+}
+
+// TerminatedPodTTLFor returns how long a Pod of the ProwJob can live after
+// termination before it is garbage-collected. The ProwJob may be nil if it
+// doesn't exist anymore.
+func (s *Sinker) TerminatedPodTTLFor(pj *prowapi.ProwJob) time.Duration {
+	if o := s.retentionOverride(pj); o != nil {
+		if o.TerminatedPodTTL != nil {
+			return o.TerminatedPodTTL.Duration
+		}
+		if o.MaxPodAge != nil {
+			return o.MaxPodAge.Duration
+		}
+	}
+	return s.TerminatedPodTTL.Duration
+}
+
+// Validate validates the retention overrides.
+func (s *Sinker) Validate() error {
+	validStates := sets.New(prowapi.GetAllProwJobStates()...)
+	for i, o := range s.RetentionOverrides {
+		for _, repo := range o.Repos {
+			if repo == "" {
+				return fmt.Errorf("sinker.retention_overrides[%d]: repos must not be empty strings", i)
+			}
+		}
+		for _, state := range o.States {
+			if !validStates.Has(state) {
+				return fmt.Errorf("sinker.retention_overrides[%d]: invalid state %q", i, state)
+			}
+		}
+		for _, d := range []struct {
+			name     string
+			duration *metav1.Duration
+		}{
+			{"max_prowjob_age", o.MaxProwJobAge},
+			{"max_pod_age", o.MaxPodAge},
+			{"terminated_pod_ttl", o.TerminatedPodTTL},
+		} {
+			if d.duration != nil && d.duration.Duration < 0 {
+				return fmt.Errorf("sinker.retention_overrides[%d]: %s must not be negative, got %s", i, d.name, d.duration.Duration)
+			}
+		}
+	}
+	return nil
 }
 
 // LensConfig names a specific lens, and optionally provides some configuration for it.
@@ -1187,6 +1661,51 @@ type Spyglass struct {
 	// Keys represent aliases and their values are the authoritative
 	// bucket names they will be substituted with
 	BucketAliases map[string]string `json:"bucket_aliases,omitempty"`
+	// ArtifactRetention describes the retention of artifacts by bucket name.
+	// It is consulted when the artifacts of a run can't be found, to look them
+	// up in an archive bucket or to explain why they are gone.
+	ArtifactRetention map[string]ArtifactRetention `json:"artifact_retention,omitempty"`
+	// LensDiscovery configures remote lenses that describe themselves, so
+	// they don't need to be listed under Lenses.
+	LensDiscovery *LensDiscovery `json:"lens_discovery,omitempty"`
+}
+
+// LensDiscovery configures the discovery of remote lenses. Every endpoint is
+// expected to serve the metadata of its lens, i.e. its name, title, priority
+// and the files it reads, on the /metadata path. Discovered lenses are added
+// after the ones configured under Lenses; a discovered lens whose name is
+// already used by a configured lens is ignored.
+type LensDiscovery struct {
+	// Endpoints are the endpoints of remote lenses. The metadata of a lens is
+	// requested from <endpoint>/metadata and rendering requests are sent to
+	// the endpoint itself.
+	Endpoints []string `json:"endpoints,omitempty"`
+	// RefreshInterval is how often the metadata of the lenses is refreshed.
+	// Defaults to five minutes.
+	RefreshInterval *metav1.Duration `json:"refresh_interval,omitempty"`
+}
+
+// GetRefreshInterval returns the configured refresh interval or the default.
+func (d *LensDiscovery) GetRefreshInterval() time.Duration {
+	if d == nil || d.RefreshInterval == nil {
+		return 5 * time.Minute
+	}
+	return d.RefreshInterval.Duration
+}
+
+// ArtifactRetention describes what happens to the artifacts of a bucket once
+// they expire.
+type ArtifactRetention struct {
+	// ArchiveBucket is the bucket artifacts are moved to when they expire,
+	// using the same paths. Spyglass falls back to it if the artifacts of a
+	// run are missing. It is allowed to be accessed automatically. It is a
+	// bucket name like "archive" without a provider prefix, the storage
+	// provider of the expired bucket is used.
+	ArchiveBucket string `json:"archive_bucket,omitempty"`
+	// Policy is a human readable description of the retention policy, e.g.
+	// "Artifacts are deleted after 90 days.", that is shown when the
+	// artifacts of a run can't be found.
+	Policy string `json:"policy,omitempty"`
 }
 
 type GCSBrowserPrefixes map[string]string
@@ -1333,6 +1852,11 @@ func (d *Deck) shouldValidateStorageBuckets() bool {
 
 func calculateStorageBuckets(c *Config) sets.Set[string] {
 	knownBuckets := sets.New[string](c.Deck.AdditionalAllowedBuckets...)
+	for _, retention := range c.Deck.Spyglass.ArtifactRetention {
+		if retention.ArchiveBucket != "" {
+			knownBuckets.Insert(retention.ArchiveBucket)
+		}
+	}
 	for _, dc := range c.Plank.DefaultDecorationConfigs {
 		if dc.Config != nil && dc.Config.GCSConfiguration != nil && dc.Config.GCSConfiguration.Bucket != "" {
 			knownBuckets.Insert(stripProviderPrefixFromBucket(dc.Config.GCSConfiguration.Bucket))
@@ -1518,6 +2042,7 @@ func (d *Deck) FinalizeDefaultRerunAuthConfigs() error {
 
 const (
 	defaultMaxOutstandingMessages = 10
+	defaultNATSQueueGroup         = "prow-sub"
 )
 
 // PubsubSubscriptions maps GCP project IDs to a list of subscription IDs.
@@ -1535,6 +2060,45 @@ type PubSubTrigger struct {
 	MaxOutstandingMessages int `json:"max_outstanding_messages"`
 }
 
+// SQSTriggers contains AWS SQS configurations.
+type SQSTriggers []SQSTrigger
+
+// SQSTrigger contains AWS SQS configuration for queues of a single region.
+type SQSTrigger struct {
+	Region string `json:"region"`
+	// Queues are the URLs of the queues.
+	Queues          []string `json:"queues"`
+	AllowedClusters []string `json:"allowed_clusters"`
+	// CredentialsFile is the path to a file in the AWS shared credentials
+	// format to authenticate with. The default credential chain is used if
+	// it is unset.
+	CredentialsFile string `json:"credentials_file,omitempty"`
+	// MaxOutstandingMessages is the max number of messaged being processed, default is 10.
+	MaxOutstandingMessages int `json:"max_outstanding_messages"`
+}
+
+// NATSTriggers contains NATS configurations.
+type NATSTriggers []NATSTrigger
+
+// NATSTrigger contains NATS configuration for subjects of a single server.
+type NATSTrigger struct {
+	// URL of the server, e.g. nats://nats.nats.svc:4222.
+	URL      string   `json:"url"`
+	Subjects []string `json:"subjects"`
+	// QueueGroup is the queue group to subscribe to the subjects in, so that
+	// each message is only handled by one replica. Default is prow-sub.
+	QueueGroup      string   `json:"queue_group,omitempty"`
+	AllowedClusters []string `json:"allowed_clusters"`
+	// CredentialsFile is the path to a NATS user credentials file, which
+	// contains the JWT and the NKey seed of the user.
+	CredentialsFile string `json:"credentials_file,omitempty"`
+	// TokenFile is the path to a file that contains a token to authenticate
+	// with. Mutually exclusive with CredentialsFile.
+	TokenFile string `json:"token_file,omitempty"`
+	// MaxOutstandingMessages is the max number of messaged being processed, default is 10.
+	MaxOutstandingMessages int `json:"max_outstanding_messages"`
+}
+
 // GitHubOptions allows users to control how prow applications display GitHub website links.
 type GitHubOptions struct {
 	// LinkURLFromConfig is the string representation of the link_url config parameter.
@@ -1636,23 +2200,35 @@ func (cfg *SlackReporter) DefaultAndValidate() error {
 
 // Load loads and parses the config at path.
 func Load(prowConfig, jobConfig string, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) (c *Config, err error) {
-	return loadWithYamlOpts(nil, prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+	return loadWithYamlOpts(nil, prowConfig, jobConfig, nil, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
 }
 
 // LoadStrict loads and parses the config at path.
 // Unlike Load it unmarshalls yaml with strict parsing.
 func LoadStrict(prowConfig, jobConfig string, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) (c *Config, err error) {
-	return loadWithYamlOpts([]yaml.JSONOpt{yaml.DisallowUnknownFields}, prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+	return loadWithYamlOpts([]yaml.JSONOpt{yaml.DisallowUnknownFields}, prowConfig, jobConfig, nil, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+}
+
+// LoadWithOverlays is like Load, but merges the overlays into the prow config
+// before parsing it. See ApplyOverlays.
+func LoadWithOverlays(prowConfig, jobConfig string, overlays, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) (c *Config, err error) {
+	return loadWithYamlOpts(nil, prowConfig, jobConfig, overlays, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
+}
+
+// LoadStrictWithOverlays is like LoadStrict, but merges the overlays into the
+// prow config before parsing it. Unknown fields in the overlays are errors too.
+func LoadStrictWithOverlays(prowConfig, jobConfig string, overlays, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) (c *Config, err error) {
+	return loadWithYamlOpts([]yaml.JSONOpt{yaml.DisallowUnknownFields}, prowConfig, jobConfig, overlays, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, additionals...)
 }
 
-func loadWithYamlOpts(yamlOpts []yaml.JSONOpt, prowConfig, jobConfig string, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) (c *Config, err error) {
+func loadWithYamlOpts(yamlOpts []yaml.JSONOpt, prowConfig, jobConfig string, overlays, supplementalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) (c *Config, err error) {
 	// we never want config loading to take down the prow components.
 	defer func() {
 		if r := recover(); r != nil {
 			c, err = nil, fmt.Errorf("panic loading config: %v\n%s", r, string(debug.Stack()))
 		}
 	}()
-	c, err = loadConfig(prowConfig, jobConfig, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, yamlOpts...)
+	c, err = loadConfig(prowConfig, jobConfig, overlays, supplementalProwConfigDirs, supplementalProwConfigsFileNameSuffix, yamlOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1762,7 +2338,7 @@ func ReadJobConfig(jobConfig string, yamlOpts ...yaml.JSONOpt) (JobConfig, error
 }
 
 // loadConfig loads one or multiple config files and returns a config object.
-func loadConfig(prowConfig, jobConfig string, additionalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, yamlOpts ...yaml.JSONOpt) (*Config, error) {
+func loadConfig(prowConfig, jobConfig string, overlays, additionalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, yamlOpts ...yaml.JSONOpt) (*Config, error) {
 	stat, err := os.Stat(prowConfig)
 	if err != nil {
 		return nil, err
@@ -1773,8 +2349,18 @@ func loadConfig(prowConfig, jobConfig string, additionalProwConfigDirs []string,
 	}
 
 	var nc Config
-	if err := yamlToConfig(prowConfig, &nc, yamlOpts...); err != nil {
-		return nil, err
+	if len(overlays) == 0 {
+		if err := yamlToConfig(prowConfig, &nc, yamlOpts...); err != nil {
+			return nil, err
+		}
+	} else {
+		b, err := readOverlaid(prowConfig, overlays)
+		if err != nil {
+			return nil, err
+		}
+		if err := bytesToConfig(prowConfig, b, &nc, yamlOpts...); err != nil {
+			return nil, err
+		}
 	}
 
 	prowConfigCount := 0
@@ -1883,6 +2469,19 @@ func loadConfig(prowConfig, jobConfig string, additionalProwConfigDirs []string,
 			nc.PubSubTriggers[i].MaxOutstandingMessages = defaultMaxOutstandingMessages
 		}
 	}
+	for i, trigger := range nc.SQSTriggers {
+		if trigger.MaxOutstandingMessages == 0 {
+			nc.SQSTriggers[i].MaxOutstandingMessages = defaultMaxOutstandingMessages
+		}
+	}
+	for i, trigger := range nc.NATSTriggers {
+		if trigger.MaxOutstandingMessages == 0 {
+			nc.NATSTriggers[i].MaxOutstandingMessages = defaultMaxOutstandingMessages
+		}
+		if trigger.QueueGroup == "" {
+			nc.NATSTriggers[i].QueueGroup = defaultNATSQueueGroup
+		}
+	}
 
 	// TODO(krzyzacy): temporary allow empty jobconfig
 	//                 also temporary allow job config in prow config.
@@ -1907,6 +2506,11 @@ func yamlToConfig(path string, nc interface{}, opts ...yaml.JSONOpt) error {
 	if err != nil {
 		return fmt.Errorf("error reading %s: %w", path, err)
 	}
+	return bytesToConfig(path, b, nc, opts...)
+}
+
+// bytesToConfig converts the yaml content of the file at path into a Config object.
+func bytesToConfig(path string, b []byte, nc interface{}, opts ...yaml.JSONOpt) error {
 	if err := yaml.Unmarshal(b, nc, opts...); err != nil {
 		return fmt.Errorf("error unmarshalling %s: %w", path, err)
 	}
@@ -2184,6 +2788,10 @@ func (c *Config) validateComponentConfig() error {
 		}
 	}
 
+	if err := c.validateTenants(); err != nil {
+		return fmt.Errorf("invalid tenants: %w", err)
+	}
+
 	if c.SlackReporterConfigs != nil {
 		for k, config := range c.SlackReporterConfigs {
 			if err := config.DefaultAndValidate(); err != nil {
@@ -2209,9 +2817,54 @@ func (c *Config) validateComponentConfig() error {
 		return err
 	}
 
+	if err := c.Horologium.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Scheduler.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Sinker.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.JobGC.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.validateTriggers(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// validateTriggers validates the SQS and NATS triggers.
+func (c *Config) validateTriggers() error {
+	var errs []error
+	for i, trigger := range c.SQSTriggers {
+		if trigger.Region == "" {
+			errs = append(errs, fmt.Errorf("sqs_triggers[%d]: region is required", i))
+		}
+		if len(trigger.Queues) == 0 {
+			errs = append(errs, fmt.Errorf("sqs_triggers[%d]: at least one queue is required", i))
+		}
+	}
+	for i, trigger := range c.NATSTriggers {
+		if trigger.URL == "" {
+			errs = append(errs, fmt.Errorf("nats_triggers[%d]: url is required", i))
+		}
+		if len(trigger.Subjects) == 0 {
+			errs = append(errs, fmt.Errorf("nats_triggers[%d]: at least one subject is required", i))
+		}
+		if trigger.CredentialsFile != "" && trigger.TokenFile != "" {
+			errs = append(errs, fmt.Errorf("nats_triggers[%d]: credentials_file and token_file are mutually exclusive", i))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 var (
 	jobNameRegex        = regexp.MustCompile(`^[A-Za-z0-9-._]+$`)
 	jobNameRegexJenkins = regexp.MustCompile(`^[A-Za-z0-9-._]([A-Za-z0-9-._/]*[A-Za-z0-9-_])?$`)
@@ -2246,6 +2899,9 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 		return err
 	}
 	if v.Agent == prowapi.TektonAgent {
+		if err := validateTektonPipelineRunSpec(jobType, v.TektonPipelineRunSpec); err != nil {
+			return err
+		}
 		pipelineRunSpec, err := v.GetPipelineRunSpec()
 		if err != nil {
 			return err
@@ -2264,6 +2920,21 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 	if err := validateJobQueueName(v.JobQueueName, validJobQueueNames); err != nil {
 		return err
 	}
+	if err := validateDependsOn(v, jobType); err != nil {
+		return err
+	}
+	if err := validateClusterSelector(v, c.Scheduler); err != nil {
+		return err
+	}
+	if err := validateRetry(v); err != nil {
+		return err
+	}
+	if err := validateEmailReporterConfig(v); err != nil {
+		return err
+	}
+	if err := c.validateTenantIsolation(v); err != nil {
+		return err
+	}
 	if v.Spec == nil || len(v.Spec.Containers) == 0 {
 		return nil // jenkins jobs have no spec.
 	}
@@ -2273,6 +2944,9 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 	if err := v.UtilityConfig.Validate(); err != nil {
 		return err
 	}
+	if v.DecorationConfig != nil && len(v.DecorationConfig.Steps) > 0 && len(v.Spec.Containers) > 1 {
+		return errors.New("steps can only be run by jobs with a single test container")
+	}
 	for i := range v.Spec.Containers {
 		if err := validateDecoration(v.Spec.Containers[i], v.DecorationConfig); err != nil {
 			return err
@@ -2285,6 +2959,7 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 func (c Config) validatePresubmits(presubmits []Presubmit) error {
 	validPresubmits := map[string][]Presubmit{}
 	duplicatePresubmits := sets.New[string]()
+	dependencies := map[string]sets.Set[string]{}
 	var errs []error
 	for _, ps := range presubmits {
 		// Checking that no duplicate job in prow config exists on the same branch.
@@ -2312,10 +2987,15 @@ func (c Config) validatePresubmits(presubmits []Presubmit) error {
 			errs = append(errs, fmt.Errorf("invalid presubmit job %s: %w", ps.Name, err))
 		}
 		validPresubmits[ps.Name] = append(validPresubmits[ps.Name], ps)
+		if dependencies[ps.Name] == nil {
+			dependencies[ps.Name] = sets.New[string]()
+		}
+		dependencies[ps.Name].Insert(ps.DependsOn...)
 	}
 	if duplicatePresubmits.Len() > 0 {
 		errs = append(errs, fmt.Errorf("duplicated presubmit jobs (consider both inrepo and central config): %v", sortStringSlice(duplicatePresubmits.UnsortedList())))
 	}
+	errs = append(errs, validateJobDependencies(dependencies)...)
 
 	return utilerrors.NewAggregate(errs)
 }
@@ -2347,6 +3027,7 @@ func ValidateRefs(repo string, jobBase JobBase) error {
 func (c Config) validatePostsubmits(postsubmits []Postsubmit) error {
 	validPostsubmits := map[string][]Postsubmit{}
 	duplicatePostsubmits := sets.New[string]()
+	dependencies := map[string]sets.Set[string]{}
 
 	var errs []error
 	for _, ps := range postsubmits {
@@ -2375,10 +3056,15 @@ func (c Config) validatePostsubmits(postsubmits []Postsubmit) error {
 			errs = append(errs, fmt.Errorf("invalid postsubmit job %s: %w", ps.Name, err))
 		}
 		validPostsubmits[ps.Name] = append(validPostsubmits[ps.Name], ps)
+		if dependencies[ps.Name] == nil {
+			dependencies[ps.Name] = sets.New[string]()
+		}
+		dependencies[ps.Name].Insert(ps.DependsOn...)
 	}
 	if duplicatePostsubmits.Len() > 0 {
 		errs = append(errs, fmt.Errorf("duplicated postsubmit jobs (consider both inrepo and central config): %v", sortStringSlice(duplicatePostsubmits.UnsortedList())))
 	}
+	errs = append(errs, validateJobDependencies(dependencies)...)
 
 	return utilerrors.NewAggregate(errs)
 }
@@ -2425,6 +3111,10 @@ func (c Config) validatePeriodics(periodics []Periodic) error {
 			}
 		}
 
+		if p.SkipIfUnchanged && len(p.ExtraRefs) == 0 {
+			errs = append(errs, fmt.Errorf("skip_if_unchanged requires extra_refs in periodic %s", p.Name))
+		}
+
 		// Set the interval on the periodic jobs. It doesn't make sense to do this
 		// for child jobs.
 		if p.Interval != "" {
@@ -2443,6 +3133,16 @@ func (c Config) validatePeriodics(periodics []Periodic) error {
 			periodics[j].minimum_interval = d
 		}
 
+		if p.MaxJitter != "" {
+			d, err := time.ParseDuration(periodics[j].MaxJitter)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cannot parse max_jitter for %s: %w", periodics[j].Name, err))
+			} else if d < 0 {
+				errs = append(errs, fmt.Errorf("max_jitter must not be negative in periodic %s", periodics[j].Name))
+			}
+			periodics[j].max_jitter = d
+		}
+
 	}
 
 	return utilerrors.NewAggregate(errs)
@@ -2495,6 +3195,21 @@ func parseProwConfig(c *Config) error {
 		c.Plank.PodUnscheduledTimeout = &metav1.Duration{Duration: 5 * time.Minute}
 	}
 
+	if c.Plank.DependencyGracePeriod == nil {
+		c.Plank.DependencyGracePeriod = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+
+	for i, classifier := range c.Plank.FailureClassifiers {
+		re, err := regexp.Compile(classifier.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid plank.failure_classifiers[%d].pattern: %w", i, err)
+		}
+		c.Plank.FailureClassifiers[i].Re = re
+		if !slices.Contains(prowapi.GetAllFailureClassifications(), classifier.Classification) {
+			return fmt.Errorf("invalid plank.failure_classifiers[%d].classification %q, must be one of %v", i, classifier.Classification, prowapi.GetAllFailureClassifications())
+		}
+	}
+
 	if err := c.Gerrit.DefaultAndValidate(); err != nil {
 		return fmt.Errorf("validating gerrit config: %w", err)
 	}
@@ -2517,6 +3232,31 @@ func parseProwConfig(c *Config) error {
 		}
 	}
 
+	failureCommentTemplates, err := parseTemplates("failure comment", c.GitHubReporter.FailureCommentTemplateStrings)
+	if err != nil {
+		return err
+	}
+	c.GitHubReporter.FailureCommentTemplates = failureCommentTemplates
+	statusDescriptionTemplates, err := parseTemplates("status description", c.GitHubReporter.StatusDescriptionTemplateStrings)
+	if err != nil {
+		return err
+	}
+	c.GitHubReporter.StatusDescriptionTemplates = statusDescriptionTemplates
+
+	if c.PubSubReporter != nil {
+		if f := c.PubSubReporter.Format; f != "" && f != PubSubFormatCloudEvents {
+			return fmt.Errorf("invalid pubsub_reporter.format %q, only %q is supported", f, PubSubFormatCloudEvents)
+		}
+	}
+
+	if c.GitLabReporter != nil {
+		for _, t := range c.GitLabReporter.JobTypesToReport {
+			if t != prowapi.PresubmitJob && t != prowapi.PostsubmitJob {
+				return fmt.Errorf("invalid gitlab_reporter.job_types_to_report: %v", t)
+			}
+		}
+	}
+
 	// jenkins operator controller template functions.
 	// reference:
 	// 	- https://helm.sh/docs/chart_template_guide/function_list/#string-functions
@@ -2572,6 +3312,27 @@ func parseProwConfig(c *Config) error {
 		return fmt.Errorf("invalid value for deck.spyglass.size_limit, must be >=0")
 	}
 
+	if d := c.Deck.Spyglass.LensDiscovery; d != nil {
+		for _, endpoint := range d.Endpoints {
+			if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("invalid endpoint %q in deck.spyglass.lens_discovery.endpoints", endpoint)
+			}
+		}
+		if d.RefreshInterval != nil && d.RefreshInterval.Duration <= 0 {
+			return fmt.Errorf("invalid value for deck.spyglass.lens_discovery.refresh_interval, must be >0")
+		}
+	}
+
+	for bucket, retention := range c.Deck.Spyglass.ArtifactRetention {
+		// The archive bucket is accessed with the storage provider of the bucket.
+		if strings.Contains(retention.ArchiveBucket, "/") {
+			return fmt.Errorf("invalid value %q for deck.spyglass.artifact_retention[%s].archive_bucket, must be a bucket name without a storage provider prefix or path", retention.ArchiveBucket, bucket)
+		}
+		if retention.ArchiveBucket == bucket {
+			return fmt.Errorf("invalid value for deck.spyglass.artifact_retention[%s].archive_bucket, must differ from the bucket", bucket)
+		}
+	}
+
 	// Migrate the old `viewers` format to the new `lenses` format.
 	var oldLenses []LensFileConfig
 	for regex, viewers := range c.Deck.Spyglass.Viewers {
@@ -2669,6 +3430,10 @@ func parseProwConfig(c *Config) error {
 		c.Sinker.TerminatedPodTTL = &metav1.Duration{Duration: c.Sinker.MaxPodAge.Duration}
 	}
 
+	if c.JobGC.ResyncPeriod == nil {
+		c.JobGC.ResyncPeriod = &metav1.Duration{Duration: 24 * time.Hour}
+	}
+
 	if c.Tide.SyncPeriod == nil {
 		c.Tide.SyncPeriod = &metav1.Duration{Duration: time.Minute}
 	}
@@ -2748,6 +3513,14 @@ func parseProwConfig(c *Config) error {
 		}
 	}
 
+	if err := c.Tide.validateShards(); err != nil {
+		return err
+	}
+
+	if err := c.Tide.validateRetestBudgets(); err != nil {
+		return err
+	}
+
 	if c.ProwJobNamespace == "" {
 		c.ProwJobNamespace = "default"
 	}
@@ -2791,6 +3564,10 @@ func parseProwConfig(c *Config) error {
 		return fmt.Errorf("Forbidden to set both Policy.Include and Policy.Exclude, Please use either Include or Exclude!")
 	}
 
+	if err := c.BranchProtection.validateRulesets(); err != nil {
+		return err
+	}
+
 	// Avoid using a Moonraker client timeout of infinity (default behavior of
 	// https://pkg.go.dev/net/http#Client) by setting a default value.
 	if c.Moonraker.ClientTimeout == nil {
@@ -2871,6 +3648,119 @@ func validateJobQueueName(name string, validNames sets.Set[string]) error {
 	return nil
 }
 
+func validateDependsOn(v JobBase, jobType prowapi.ProwJobType) error {
+	if len(v.DependsOn) == 0 {
+		return nil
+	}
+	if jobType != prowapi.PresubmitJob && jobType != prowapi.PostsubmitJob {
+		return fmt.Errorf("depends_on: only presubmits and postsubmits can depend on other jobs, not %s jobs", jobType)
+	}
+	if v.Agent != string(prowapi.KubernetesAgent) {
+		return fmt.Errorf("depends_on: only jobs run by the %s agent can depend on other jobs", prowapi.KubernetesAgent)
+	}
+	return nil
+}
+
+func validateRetry(v JobBase) error {
+	if v.Retry == nil {
+		return nil
+	}
+	if v.Agent != string(prowapi.KubernetesAgent) {
+		return fmt.Errorf("retry: only jobs run by the %s agent can be retried", prowapi.KubernetesAgent)
+	}
+	if v.Retry.Attempts < 0 {
+		return fmt.Errorf("retry.attempts: %d must be a non-negative number", v.Retry.Attempts)
+	}
+	for _, state := range v.Retry.On {
+		if state != prowapi.ErrorState && state != prowapi.AbortedState {
+			return fmt.Errorf("retry.on: jobs can only be retried on %s or %s, not %s", prowapi.ErrorState, prowapi.AbortedState, state)
+		}
+	}
+	return nil
+}
+
+// validateEmailReporterConfig ensures that the recipients are plain email
+// addresses, as they are written into the headers of the emails.
+func validateEmailReporterConfig(v JobBase) error {
+	if v.ReporterConfig == nil || v.ReporterConfig.Email == nil {
+		return nil
+	}
+	for _, recipient := range v.ReporterConfig.Email.Recipients {
+		addr, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return fmt.Errorf("reporter_config.email.recipients: invalid email address %q: %w", recipient, err)
+		}
+		if addr.Address != recipient {
+			return fmt.Errorf("reporter_config.email.recipients: %q must be a plain email address like %q", recipient, addr.Address)
+		}
+	}
+	if v.ReporterConfig.Email.FailureThreshold < 0 {
+		return fmt.Errorf("reporter_config.email.failure_threshold: %d must be a non-negative number", v.ReporterConfig.Email.FailureThreshold)
+	}
+	return nil
+}
+
+func validateClusterSelector(v JobBase, scheduler Scheduler) error {
+	if !IsClusterSelector(v.Cluster) {
+		return nil
+	}
+	if v.Agent != string(prowapi.KubernetesAgent) && v.Agent != string(prowapi.TektonAgent) {
+		return fmt.Errorf("cluster: only jobs run by the %s or %s agent can select a cluster by labels", prowapi.KubernetesAgent, prowapi.TektonAgent)
+	}
+	if !scheduler.Enabled || scheduler.ClusterSelection == nil {
+		return fmt.Errorf("cluster: selecting a cluster by labels requires the scheduler to be enabled and scheduler.cluster_selection to be configured")
+	}
+	if _, err := labels.Parse(v.Cluster); err != nil {
+		return fmt.Errorf("cluster: invalid label selector %q: %w", v.Cluster, err)
+	}
+	return nil
+}
+
+// validateJobDependencies validates the dependencies between the jobs of one
+// repo, which are keyed by job name. Every dependency must be a job of the
+// repo and the dependencies must not form a cycle.
+func validateJobDependencies(dependencies map[string]sets.Set[string]) []error {
+	var errs []error
+	for _, name := range sets.List(sets.KeySet(dependencies)) {
+		for _, dependency := range sets.List(dependencies[name]) {
+			if _, exists := dependencies[dependency]; !exists {
+				errs = append(errs, fmt.Errorf("job %s depends on job %s which does not exist", name, dependency))
+			}
+		}
+	}
+
+	visited := sets.New[string]()
+	var visit func(path []string) error
+	visit = func(path []string) error {
+		name := path[len(path)-1]
+		for _, dependency := range sets.List(dependencies[name]) {
+			for i := range path {
+				if path[i] == dependency {
+					return fmt.Errorf("jobs have cyclic dependencies: %s", strings.Join(append(path[i:], dependency), " -> "))
+				}
+			}
+			if visited.Has(dependency) {
+				continue
+			}
+			if err := visit(append(path, dependency)); err != nil {
+				return err
+			}
+		}
+		visited.Insert(name)
+		return nil
+	}
+	for _, name := range sets.List(sets.KeySet(dependencies)) {
+		if visited.Has(name) {
+			continue
+		}
+		if err := visit([]string{name}); err != nil {
+			errs = append(errs, err)
+			break
+		}
+	}
+	return errs
+}
+
 func validateAgent(v JobBase, podNamespace string) error {
 	k := string(prowapi.KubernetesAgent)
 	j := string(prowapi.JenkinsAgent)
@@ -2911,8 +3801,26 @@ func validateDecoration(container v1.Container, config *prowapi.DecorationConfig
 	if err := config.Validate(); err != nil {
 		return fmt.Errorf("invalid decoration config: %w", err)
 	}
+	if config.Cache != nil {
+		if _, err := cache.ParseKeyTemplate(config.Cache.KeyTemplate, ""); err != nil {
+			return fmt.Errorf("invalid cache key_template: %w", err)
+		}
+		for _, p := range config.Cache.Paths {
+			for _, mountPath := range sets.List(decorate.VolumeMountPathsOnTestContainer()) {
+				if p == mountPath || strings.HasPrefix(p, mountPath+"/") || strings.HasPrefix(mountPath, p+"/") {
+					return fmt.Errorf("cache path %s conflicts with the decoration mount %s", p, mountPath)
+				}
+			}
+		}
+	}
 	var args []string
 	args = append(append(args, container.Command...), container.Args...)
+	if len(config.Steps) > 0 {
+		if len(args) > 0 {
+			return errors.New("decorated job containers must not specify command and/or args when running steps")
+		}
+		return nil
+	}
 	if len(args) == 0 || args[0] == "" {
 		return errors.New("decorated job containers must specify command and/or args")
 	}
@@ -2933,6 +3841,22 @@ func resolvePresets(name string, labels map[string]string, spec *v1.PodSpec, pre
 
 var ReProwExtraRef = regexp.MustCompile(`PROW_EXTRA_GIT_REF_(\d+)`)
 
+func validateTektonPipelineRunSpec(jobType prowapi.ProwJobType, spec *prowapi.TektonPipelineRunSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if err := spec.Validate(); err != nil {
+		return fmt.Errorf("tekton_pipeline_run_spec: %w", err)
+	}
+	validEnv := sets.New(downwardapi.EnvForType(jobType)...)
+	for param, env := range spec.ParamMappings {
+		if !validEnv.Has(env) {
+			return fmt.Errorf("tekton_pipeline_run_spec.param_mappings: param %s is mapped to %s which is not set for %s jobs", param, env, jobType)
+		}
+	}
+	return nil
+}
+
 func ValidatePipelineRunSpec(jobType prowapi.ProwJobType, extraRefs []prowapi.Refs, spec *pipelinev1.PipelineRunSpec) error {
 	if spec == nil {
 		return nil
@@ -2944,6 +3868,10 @@ func ValidatePipelineRunSpec(jobType prowapi.ProwJobType, extraRefs []prowapi.Re
 	extraIndexes := sets.NewInt()
 	if spec.PipelineSpec != nil {
 		for _, task := range spec.PipelineSpec.Tasks {
+			// Tasks may be defined inline rather than referenced.
+			if task.TaskRef == nil {
+				continue
+			}
 			// Validate that periodic jobs don't request an implicit git ref.
 			if jobType == prowapi.PeriodicJob && task.TaskRef.Name == ProwImplicitGitResource {
 				return fmt.Errorf("periodic jobs do not have an implicit git ref to replace %s", ProwImplicitGitResource)
@@ -3165,6 +4093,22 @@ func defaultAndValidateReportTemplate(c *Controller) error {
 	return nil
 }
 
+// parseTemplates parses the templates keyed by `org/repo`, `org` or `*`.
+func parseTemplates(kind string, templateStrings map[string]string) (map[string]*template.Template, error) {
+	if len(templateStrings) == 0 {
+		return nil, nil
+	}
+	templates := make(map[string]*template.Template, len(templateStrings))
+	for orgRepo, value := range templateStrings {
+		tmpl, err := template.New(kind).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing %s template for %s: %w", kind, orgRepo, err)
+		}
+		templates[orgRepo] = tmpl
+	}
+	return templates, nil
+}
+
 // DefaultTriggerFor returns the default regexp string used to match comments
 // that should trigger the job with this name.
 func DefaultTriggerFor(name string) string {
@@ -3504,6 +4448,9 @@ func (tm tideQueryMap) queries() (TideQueries, error) {
 			MissingLabels:          queryConfig.MissingLabels,
 			Milestone:              queryConfig.Milestone,
 			ReviewApprovedRequired: queryConfig.ReviewApprovedRequired,
+
+			RequireResolvedConversations:  queryConfig.RequireResolvedConversations,
+			RequiredDeploymentEnvironment: queryConfig.RequiredDeploymentEnvironment,
 		})
 
 	}
@@ -3545,6 +4492,9 @@ func (c *Config) deduplicateTideQueries(queries TideQueries) (TideQueries, error
 			Milestone:              query.Milestone,
 			ReviewApprovedRequired: query.ReviewApprovedRequired,
 			TenantIDs:              query.TenantIDs(*c),
+
+			RequireResolvedConversations:  query.RequireResolvedConversations,
+			RequiredDeploymentEnvironment: query.RequiredDeploymentEnvironment,
 		}
 		keyRaw, err := json.Marshal(key)
 		if err != nil {
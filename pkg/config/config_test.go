@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -273,6 +274,29 @@ deck:
     gcs_browser_prefix: https://gcsweb.k8s.io/gcs/
     gcs_browser_prefixes:
       '*': https://gcsweb.k8s.io/gcs/
+`,
+			expectError: true,
+		},
+		{
+			name: "Spyglass archive bucket",
+			spyglassConfig: `
+deck:
+  spyglass:
+    size_limit: 5
+    artifact_retention:
+      logs:
+        archive_bucket: archive
+`,
+			expectedSizeLimit: 5,
+		},
+		{
+			name: "Invalid Spyglass archive bucket with a provider prefix",
+			spyglassConfig: `
+deck:
+  spyglass:
+    artifact_retention:
+      logs:
+        archive_bucket: s3://archive
 `,
 			expectError: true,
 		},
@@ -1533,7 +1557,7 @@ func TestValidatePodSpec(t *testing.T) {
 			name: "reject reserved mount name",
 			spec: func(s *v1.PodSpec) {
 				s.Containers[0].VolumeMounts = append(s.Containers[0].VolumeMounts, v1.VolumeMount{
-					Name:      sets.List(decorate.VolumeMounts(nil))[0],
+					Name:      sets.List(decorate.VolumeMountsOnTestContainer())[0],
 					MountPath: "/whatever",
 				})
 			},
@@ -1767,6 +1791,14 @@ func TestValidatePipelineRunSpec(t *testing.T) {
 			extraRefs: []prowapi.Refs{{Org: "o", Repo: "r"}},
 			pass:      false,
 		},
+		{
+			name: "allow inline tasks",
+			spec: func(s *pipelinev1.PipelineRunSpec) {
+				s.PipelineSpec = &pipelinev1.PipelineSpec{
+					Tasks: []pipelinev1.PipelineTask{{Name: "inline", TaskSpec: &pipelinev1.EmbeddedTask{}}}}
+			},
+			pass: true,
+		},
 	}
 
 	spec := pipelinev1.PipelineRunSpec{}
@@ -1793,6 +1825,58 @@ func TestValidatePipelineRunSpec(t *testing.T) {
 	}
 }
 
+func TestValidateTektonPipelineRunSpec(t *testing.T) {
+	cases := []struct {
+		name    string
+		jobType prowapi.ProwJobType
+		spec    *prowapi.TektonPipelineRunSpec
+		wantErr string
+	}{
+		{
+			name:    "nil spec",
+			jobType: prowapi.PresubmitJob,
+		},
+		{
+			name:    "param mapped to a presubmit variable",
+			jobType: prowapi.PresubmitJob,
+			spec: &prowapi.TektonPipelineRunSpec{
+				V1:            &pipelinev1.PipelineRunSpec{},
+				ParamMappings: map[string]string{"revision": "PULL_PULL_SHA"},
+			},
+		},
+		{
+			name:    "param mapped to a variable not set for periodics",
+			jobType: prowapi.PeriodicJob,
+			spec: &prowapi.TektonPipelineRunSpec{
+				V1:            &pipelinev1.PipelineRunSpec{},
+				ParamMappings: map[string]string{"revision": "PULL_PULL_SHA"},
+			},
+			wantErr: "tekton_pipeline_run_spec.param_mappings: param revision is mapped to PULL_PULL_SHA which is not set for periodic jobs",
+		},
+		{
+			name:    "invalid spec",
+			jobType: prowapi.PresubmitJob,
+			spec: &prowapi.TektonPipelineRunSpec{
+				V1:      &pipelinev1.PipelineRunSpec{},
+				V1Beta1: &pipelinev1.PipelineRunSpec{},
+			},
+			wantErr: "tekton_pipeline_run_spec: only one of v1 and v1beta1 can be set",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErr string
+			if err := validateTektonPipelineRunSpec(tc.jobType, tc.spec); err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("expected error %q, got %q", tc.wantErr, gotErr)
+			}
+		})
+	}
+}
+
 func TestValidateDecoration(t *testing.T) {
 	defCfg := prowapi.DecorationConfig{
 		UtilityImages: &prowapi.UtilityImages{
@@ -1808,6 +1892,11 @@ func TestValidateDecoration(t *testing.T) {
 			DefaultRepo:  "very-repo",
 		},
 	}
+	withCache := func(keyTemplate string, paths ...string) *prowapi.DecorationConfig {
+		cfg := defCfg.DeepCopy()
+		cfg.Cache = &prowapi.CacheConfiguration{KeyTemplate: keyTemplate, Paths: paths}
+		return cfg
+	}
 	cases := []struct {
 		name      string
 		container v1.Container
@@ -1845,6 +1934,120 @@ func TestValidateDecoration(t *testing.T) {
 			name:   "reject container that has no cmd, no args",
 			config: &defCfg,
 		},
+		{
+			name:   "happy case with cache",
+			config: withCache(`{{.Job}}-{{hashFiles "go.sum"}}`, "/root/go/pkg/mod"),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+			pass: true,
+		},
+		{
+			name:   "reject invalid cache key template",
+			config: withCache("{{.Job", "/root/go/pkg/mod"),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+		},
+		{
+			name:   "reject relative cache path",
+			config: withCache("{{.Job}}", "go/pkg/mod"),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+		},
+		{
+			name:   "reject cache path in decoration mount",
+			config: withCache("{{.Job}}", "/home/prow/go/pkg/mod"),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+		},
+		{
+			name: "happy case with sparse checkout and submodule depths",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.SparseCheckout = []string{"cmd", "pkg/foo"}
+				cfg.SubmoduleDepths = map[string]int{"third_party/lib": 1}
+				return cfg
+			}(),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+			pass: true,
+		},
+		{
+			name: "reject sparse checkout outside of the repository",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.SparseCheckout = []string{"../other"}
+				return cfg
+			}(),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+		},
+		{
+			name: "reject negative submodule depth",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.SubmoduleDepths = map[string]int{"third_party/lib": -1}
+				return cfg
+			}(),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+		},
+		{
+			name: "happy case with steps",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.Steps = []prowapi.EntrypointStep{
+					{Name: "test", Command: []string{"make", "test"}, Timeout: &prowapi.Duration{Duration: time.Minute}},
+					{Name: "teardown", Command: []string{"make", "teardown"}, AlwaysRun: true},
+				}
+				return cfg
+			}(),
+			pass: true,
+		},
+		{
+			name: "reject container with cmd when running steps",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.Steps = []prowapi.EntrypointStep{{Name: "test", Command: []string{"make", "test"}}}
+				return cfg
+			}(),
+			container: v1.Container{
+				Command: []string{"hello", "world"},
+			},
+		},
+		{
+			name: "reject duplicate step names",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.Steps = []prowapi.EntrypointStep{
+					{Name: "test", Command: []string{"make", "test"}},
+					{Name: "test", Command: []string{"make", "teardown"}},
+				}
+				return cfg
+			}(),
+		},
+		{
+			name: "reject step without command",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.Steps = []prowapi.EntrypointStep{{Name: "test"}}
+				return cfg
+			}(),
+		},
+		{
+			name: "reject negative step timeout",
+			config: func() *prowapi.DecorationConfig {
+				cfg := defCfg.DeepCopy()
+				cfg.Steps = []prowapi.EntrypointStep{{Name: "test", Command: []string{"make", "test"}, Timeout: &prowapi.Duration{Duration: -time.Minute}}}
+				return cfg
+			}(),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2174,6 +2377,48 @@ func TestValidateJobBase(t *testing.T) {
 			},
 			pass: false,
 		},
+		{
+			name: "valid retry",
+			base: JobBase{
+				Name:      "name",
+				Agent:     ka,
+				Spec:      &goodSpec,
+				Namespace: &cfg.PodNamespace,
+				Retry:     &prowapi.RetryPolicy{Attempts: 3, On: []prowapi.ProwJobState{prowapi.ErrorState, prowapi.AbortedState}},
+			},
+			pass: true,
+		},
+		{
+			name: "retry on failure",
+			base: JobBase{
+				Name:      "name",
+				Agent:     ka,
+				Spec:      &goodSpec,
+				Namespace: &cfg.PodNamespace,
+				Retry:     &prowapi.RetryPolicy{Attempts: 3, On: []prowapi.ProwJobState{prowapi.FailureState}},
+			},
+			pass: false,
+		},
+		{
+			name: "negative retry attempts",
+			base: JobBase{
+				Name:      "name",
+				Agent:     ka,
+				Spec:      &goodSpec,
+				Namespace: &cfg.PodNamespace,
+				Retry:     &prowapi.RetryPolicy{Attempts: -1},
+			},
+			pass: false,
+		},
+		{
+			name: "retry jenkins job",
+			base: JobBase{
+				Name:  "name",
+				Agent: ja,
+				Retry: &prowapi.RetryPolicy{Attempts: 3},
+			},
+			pass: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -2188,6 +2433,295 @@ func TestValidateJobBase(t *testing.T) {
 	}
 }
 
+func TestValidateClusterSelector(t *testing.T) {
+	enabled := Scheduler{
+		Enabled: true,
+		ClusterSelection: &ClusterSelectionScheduling{Clusters: map[string]SelectableCluster{
+			"arm64": {Labels: map[string]string{"arch": "arm64"}},
+		}},
+	}
+	for _, tc := range []struct {
+		name      string
+		base      JobBase
+		scheduler Scheduler
+		wantErr   string
+	}{
+		{
+			name:      "cluster name",
+			base:      JobBase{Agent: string(prowapi.KubernetesAgent), Cluster: "default"},
+			scheduler: Scheduler{},
+		},
+		{
+			name:      "cluster selector",
+			base:      JobBase{Agent: string(prowapi.KubernetesAgent), Cluster: "arch=arm64,gpu!=true"},
+			scheduler: enabled,
+		},
+		{
+			name:      "cluster selector without cluster selection",
+			base:      JobBase{Agent: string(prowapi.KubernetesAgent), Cluster: "arch=arm64"},
+			scheduler: Scheduler{Enabled: true},
+			wantErr:   "cluster: selecting a cluster by labels requires the scheduler to be enabled and scheduler.cluster_selection to be configured",
+		},
+		{
+			name:      "cluster selector with the scheduler disabled",
+			base:      JobBase{Agent: string(prowapi.KubernetesAgent), Cluster: "arch=arm64"},
+			scheduler: Scheduler{ClusterSelection: enabled.ClusterSelection},
+			wantErr:   "cluster: selecting a cluster by labels requires the scheduler to be enabled and scheduler.cluster_selection to be configured",
+		},
+		{
+			name:      "cluster selector with an unsupported agent",
+			base:      JobBase{Agent: string(prowapi.JenkinsAgent), Cluster: "arch=arm64"},
+			scheduler: enabled,
+			wantErr:   "cluster: only jobs run by the kubernetes or tekton-pipeline agent can select a cluster by labels",
+		},
+		{
+			name:      "invalid cluster selector",
+			base:      JobBase{Agent: string(prowapi.KubernetesAgent), Cluster: "arch in (arm64"},
+			scheduler: enabled,
+			wantErr:   `cluster: invalid label selector "arch in (arm64"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateClusterSelector(tc.base, tc.scheduler)
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.wantErr)) {
+				t.Errorf("Expected error %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateEmailReporterConfig(t *testing.T) {
+	email := func(threshold int, recipients ...string) JobBase {
+		return JobBase{ReporterConfig: &prowapi.ReporterConfig{Email: &prowapi.EmailReporterConfig{Recipients: recipients, FailureThreshold: threshold}}}
+	}
+	for _, tc := range []struct {
+		name    string
+		base    JobBase
+		wantErr string
+	}{
+		{
+			name: "no reporter config",
+			base: JobBase{},
+		},
+		{
+			name: "valid recipients",
+			base: email(2, "alice@example.com", "team+ci@example.com"),
+		},
+		{
+			name:    "recipient with a header injection",
+			base:    email(0, "alice@example.com\r\nBcc: mallory@example.com"),
+			wantErr: `reporter_config.email.recipients: invalid email address "alice@example.com\r\nBcc: mallory@example.com"`,
+		},
+		{
+			name:    "recipient with a display name",
+			base:    email(0, "Alice <alice@example.com>"),
+			wantErr: `reporter_config.email.recipients: "Alice <alice@example.com>" must be a plain email address like "alice@example.com"`,
+		},
+		{
+			name:    "multiple recipients in one entry",
+			base:    email(0, "alice@example.com, bob@example.com"),
+			wantErr: `reporter_config.email.recipients: invalid email address "alice@example.com, bob@example.com"`,
+		},
+		{
+			name:    "negative failure threshold",
+			base:    email(-1, "alice@example.com"),
+			wantErr: "reporter_config.email.failure_threshold: -1 must be a non-negative number",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEmailReporterConfig(tc.base)
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.wantErr)) {
+				t.Errorf("Expected error %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSinkerRetentionOverrides(t *testing.T) {
+	hours := func(h int) *metav1.Duration { return &metav1.Duration{Duration: time.Duration(h) * time.Hour} }
+	sinker := Sinker{
+		MaxProwJobAge:    hours(1),
+		MaxPodAge:        hours(2),
+		TerminatedPodTTL: hours(3),
+		RetentionOverrides: []SinkerRetentionOverride{
+			{Repos: []string{"org/repo"}, States: []prowapi.ProwJobState{prowapi.FailureState}, MaxProwJobAge: hours(10), TerminatedPodTTL: hours(30)},
+			{States: []prowapi.ProwJobState{prowapi.FailureState, prowapi.ErrorState}, MaxProwJobAge: hours(100), MaxPodAge: hours(200)},
+			{Repos: []string{"org"}, MaxPodAge: hours(2000)},
+		},
+	}
+	prowJob := func(org string, state prowapi.ProwJobState, periodic bool) *prowapi.ProwJob {
+		pj := &prowapi.ProwJob{Status: prowapi.ProwJobStatus{State: state}}
+		if org == "" {
+			return pj
+		}
+		refs := prowapi.Refs{Org: org, Repo: "repo"}
+		if periodic {
+			pj.Spec.ExtraRefs = []prowapi.Refs{refs}
+		} else {
+			pj.Spec.Refs = &refs
+		}
+		return pj
+	}
+	testCases := []struct {
+		name                                       string
+		pj                                         *prowapi.ProwJob
+		maxProwJobAge, maxPodAge, terminatedPodTTL int
+	}{
+		{
+			name:             "no prowjob",
+			maxProwJobAge:    1,
+			maxPodAge:        2,
+			terminatedPodTTL: 3,
+		},
+		{
+			name:             "failed job of repo",
+			pj:               prowJob("org", prowapi.FailureState, false),
+			maxProwJobAge:    10,
+			maxPodAge:        2,
+			terminatedPodTTL: 30,
+		},
+		{
+			name:             "errored periodic of repo",
+			pj:               prowJob("org", prowapi.ErrorState, true),
+			maxProwJobAge:    100,
+			maxPodAge:        200,
+			terminatedPodTTL: 200,
+		},
+		{
+			name:             "failed job without refs",
+			pj:               prowJob("", prowapi.FailureState, false),
+			maxProwJobAge:    100,
+			maxPodAge:        200,
+			terminatedPodTTL: 200,
+		},
+		{
+			name:             "successful job of org",
+			pj:               prowJob("org", prowapi.SuccessState, false),
+			maxProwJobAge:    1,
+			maxPodAge:        2000,
+			terminatedPodTTL: 2000,
+		},
+		{
+			name:             "successful job of other org",
+			pj:               prowJob("other", prowapi.SuccessState, false),
+			maxProwJobAge:    1,
+			maxPodAge:        2,
+			terminatedPodTTL: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.pj != nil {
+				if got, want := sinker.MaxProwJobAgeFor(tc.pj), time.Duration(tc.maxProwJobAge)*time.Hour; got != want {
+					t.Errorf("expected max prowjob age %s, got %s", want, got)
+				}
+			}
+			if got, want := sinker.MaxPodAgeFor(tc.pj), time.Duration(tc.maxPodAge)*time.Hour; got != want {
+				t.Errorf("expected max pod age %s, got %s", want, got)
+			}
+			if got, want := sinker.TerminatedPodTTLFor(tc.pj), time.Duration(tc.terminatedPodTTL)*time.Hour; got != want {
+				t.Errorf("expected terminated pod TTL %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestValidateSinker(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sinker  Sinker
+		wantErr string
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name: "valid override",
+			sinker: Sinker{RetentionOverrides: []SinkerRetentionOverride{{
+				Repos:         []string{"org", "org/repo"},
+				States:        []prowapi.ProwJobState{prowapi.FailureState},
+				MaxProwJobAge: &metav1.Duration{Duration: time.Hour},
+			}}},
+		},
+		{
+			name:    "invalid state",
+			sinker:  Sinker{RetentionOverrides: []SinkerRetentionOverride{{States: []prowapi.ProwJobState{"failed"}}}},
+			wantErr: `sinker.retention_overrides[0]: invalid state "failed"`,
+		},
+		{
+			name:    "empty repo",
+			sinker:  Sinker{RetentionOverrides: []SinkerRetentionOverride{{}, {Repos: []string{""}}}},
+			wantErr: "sinker.retention_overrides[1]: repos must not be empty strings",
+		},
+		{
+			name:    "negative age",
+			sinker:  Sinker{RetentionOverrides: []SinkerRetentionOverride{{MaxPodAge: &metav1.Duration{Duration: -time.Hour}}}},
+			wantErr: "sinker.retention_overrides[0]: max_pod_age must not be negative, got -1h0m0s",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErr string
+			if err := tc.sinker.Validate(); err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("expected error %q, got %q", tc.wantErr, gotErr)
+			}
+		})
+	}
+}
+
+func TestValidateScheduler(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		scheduler Scheduler
+		wantErr   string
+	}{
+		{
+			name: "no cluster selection",
+		},
+		{
+			name: "valid cluster selection",
+			scheduler: Scheduler{ClusterSelection: &ClusterSelectionScheduling{Clusters: map[string]SelectableCluster{
+				"arm64": {Labels: map[string]string{"arch": "arm64", "example.com/gpu": "true"}, Capacity: 2},
+			}}},
+		},
+		{
+			name: "negative capacity",
+			scheduler: Scheduler{ClusterSelection: &ClusterSelectionScheduling{Clusters: map[string]SelectableCluster{
+				"arm64": {Capacity: -1},
+			}}},
+			wantErr: "scheduler.cluster_selection: capacity of cluster arm64 must be a non-negative number",
+		},
+		{
+			name: "invalid label value",
+			scheduler: Scheduler{ClusterSelection: &ClusterSelectionScheduling{Clusters: map[string]SelectableCluster{
+				"arm64": {Labels: map[string]string{"arch": "arm 64"}},
+			}}},
+			wantErr: `scheduler.cluster_selection: invalid label value "arm 64" of cluster arm64`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.scheduler.Validate()
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.wantErr)) {
+				t.Errorf("Expected error %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestValidateDeck(t *testing.T) {
 	boolTrue := true
 	boolFalse := false
@@ -4722,6 +5256,57 @@ func TestValidateComponentConfig(t *testing.T) {
 			}}},
 			errExpected: false,
 		},
+		{
+			name: "Valid horologium jitter, no err",
+			config: &Config{ProwConfig: ProwConfig{Horologium: Horologium{
+				MaxJitter:      &metav1.Duration{Duration: time.Minute},
+				JitterStrategy: JitterStrategySpread,
+			}}},
+			errExpected: false,
+		},
+		{
+			name: "Invalid horologium jitter strategy, err",
+			config: &Config{ProwConfig: ProwConfig{Horologium: Horologium{
+				JitterStrategy: "sometimes",
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Negative horologium max jitter, err",
+			config: &Config{ProwConfig: ProwConfig{Horologium: Horologium{
+				MaxJitter: &metav1.Duration{Duration: -time.Minute},
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Valid SQS and NATS triggers, no err",
+			config: &Config{ProwConfig: ProwConfig{
+				SQSTriggers:  SQSTriggers{{Region: "us-east-1", Queues: []string{"https://sqs.us-east-1.amazonaws.com/123456789012/prow"}}},
+				NATSTriggers: NATSTriggers{{URL: "nats://nats:4222", Subjects: []string{"prow"}, TokenFile: "/etc/nats/token"}},
+			}},
+			errExpected: false,
+		},
+		{
+			name: "SQS trigger without region, err",
+			config: &Config{ProwConfig: ProwConfig{
+				SQSTriggers: SQSTriggers{{Queues: []string{"https://sqs.us-east-1.amazonaws.com/123456789012/prow"}}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "NATS trigger without subjects, err",
+			config: &Config{ProwConfig: ProwConfig{
+				NATSTriggers: NATSTriggers{{URL: "nats://nats:4222"}},
+			}},
+			errExpected: true,
+		},
+		{
+			name: "NATS trigger with credentials and token files, err",
+			config: &Config{ProwConfig: ProwConfig{
+				NATSTriggers: NATSTriggers{{URL: "nats://nats:4222", Subjects: []string{"prow"}, CredentialsFile: "/etc/nats/creds", TokenFile: "/etc/nats/token"}},
+			}},
+			errExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -5573,6 +6158,25 @@ func TestSetPeriodicProwJobDefaults(t *testing.T) {
 			config:   &Config{ProwConfig: ProwConfig{}},
 			expected: &prowapi.ProwJobDefault{TenantID: DefaultTenantID},
 		},
+		{
+			id: "no default in job or in config, repo belongs to a tenant, expect tenant's ID",
+			utilityConfig: UtilityConfig{
+				ExtraRefs: []prowapi.Refs{
+					{
+						Org:  "org",
+						Repo: "repo",
+					},
+				},
+			},
+			config: &Config{
+				ProwConfig: ProwConfig{
+					Tenants: map[string]Tenant{
+						"tenant": {Repos: []string{"org"}},
+					},
+				},
+			},
+			expected: &prowapi.ProwJobDefault{TenantID: "tenant"},
+		},
 		{
 			id: "no default in job or in config's by repo config, expect default entry",
 			config: &Config{
@@ -7976,6 +8580,44 @@ func TestDefaultAndValidateReportTemplate(t *testing.T) {
 	}
 }
 
+func TestGitHubReporterTemplatesForRepo(t *testing.T) {
+	templates, err := parseTemplates("failure comment", map[string]string{
+		"*":        "default",
+		"org":      "org",
+		"org/repo": "repo",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g := GitHubReporter{FailureCommentTemplates: templates}
+
+	testCases := []struct {
+		refs     *prowapi.Refs
+		expected string
+	}{
+		{expected: "default"},
+		{refs: &prowapi.Refs{Org: "other-org", Repo: "repo"}, expected: "default"},
+		{refs: &prowapi.Refs{Org: "org", Repo: "other-repo"}, expected: "org"},
+		{refs: &prowapi.Refs{Org: "org", Repo: "repo"}, expected: "repo"},
+	}
+	for _, tc := range testCases {
+		var b bytes.Buffer
+		if err := g.FailureCommentTemplateForRepo(tc.refs).Execute(&b, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b.String() != tc.expected {
+			t.Errorf("expected template %q for %v, got %q", tc.expected, tc.refs, b.String())
+		}
+	}
+	if tmpl := g.StatusDescriptionTemplateForRepo(&prowapi.Refs{Org: "org", Repo: "repo"}); tmpl != nil {
+		t.Errorf("expected no status description template, got %v", tmpl)
+	}
+
+	if _, err := parseTemplates("status description", map[string]string{"org": "{{.Spec.Job"}); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
 func TestValidatePresubmits(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -8060,6 +8702,14 @@ func TestValidatePresubmits(t *testing.T) {
 			}},
 			expectedError: "job a declares run_if_changed and skip_if_only_changed, which are mutually exclusive",
 		},
+		{
+			name: "Dependencies of jobs not run by the kubernetes agent cause error",
+			presubmits: []Presubmit{
+				{JobBase: JobBase{Name: "a", Agent: "custom", DependsOn: []string{"b"}}, Reporter: Reporter{Context: "a"}},
+				{JobBase: JobBase{Name: "b", Agent: "custom"}, Reporter: Reporter{Context: "b"}},
+			},
+			expectedError: "invalid presubmit job a: depends_on: only jobs run by the kubernetes agent can depend on other jobs",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8161,6 +8811,66 @@ func TestValidatePostsubmits(t *testing.T) {
 	}
 }
 
+func TestValidateJobDependencies(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		dependencies   map[string]sets.Set[string]
+		expectedErrors []string
+	}{
+		{
+			name: "no dependencies",
+			dependencies: map[string]sets.Set[string]{
+				"a": sets.New[string](),
+				"b": sets.New[string](),
+			},
+		},
+		{
+			name: "valid graph",
+			dependencies: map[string]sets.Set[string]{
+				"build":       sets.New[string](),
+				"unit":        sets.New[string]("build"),
+				"integration": sets.New[string]("build"),
+				"e2e":         sets.New[string]("unit", "integration"),
+			},
+		},
+		{
+			name: "unknown dependency",
+			dependencies: map[string]sets.Set[string]{
+				"a": sets.New[string]("b", "c"),
+				"b": sets.New[string](),
+			},
+			expectedErrors: []string{"job a depends on job c which does not exist"},
+		},
+		{
+			name: "job depends on itself",
+			dependencies: map[string]sets.Set[string]{
+				"a": sets.New[string]("a"),
+			},
+			expectedErrors: []string{"jobs have cyclic dependencies: a -> a"},
+		},
+		{
+			name: "cycle",
+			dependencies: map[string]sets.Set[string]{
+				"a": sets.New[string]("b"),
+				"b": sets.New[string]("c"),
+				"c": sets.New[string]("b"),
+			},
+			expectedErrors: []string{"jobs have cyclic dependencies: b -> c -> b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var errMsgs []string
+		for _, err := range validateJobDependencies(tc.dependencies) {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		if diff := cmp.Diff(tc.expectedErrors, errMsgs); diff != "" {
+			t.Errorf("%s: unexpected errors (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
 func TestValidatePeriodics(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -8191,6 +8901,14 @@ func TestValidatePeriodics(t *testing.T) {
 			},
 			expectedError: "at least one of cron, interval, or minimum_interval must be set in periodic a",
 		},
+		{
+			name: "Dependencies cause error",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a", DependsOn: []string{"b"}}, Interval: "6h"},
+				{JobBase: JobBase{Name: "b"}, Interval: "6h"},
+			},
+			expectedError: "invalid periodic job a: depends_on: only presubmits and postsubmits can depend on other jobs, not periodic jobs",
+		},
 		{
 			name: "Invalid cron string",
 			periodics: []Periodic{
@@ -8230,6 +8948,36 @@ func TestValidatePeriodics(t *testing.T) {
 				{JobBase: JobBase{Name: "a"}, MinimumInterval: "10ns", minimum_interval: time.Duration(10)},
 			},
 		},
+		{
+			name: "Invalid max_jitter",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Interval: "1h", MaxJitter: "hello"},
+			},
+			expectedError: "cannot parse max_jitter for a: time: invalid duration \"hello\"",
+		},
+		{
+			name: "Negative max_jitter",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Interval: "1h", MaxJitter: "-1m"},
+			},
+			expectedError: "max_jitter must not be negative in periodic a",
+		},
+		{
+			name: "skip_if_unchanged without extra_refs",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Interval: "1h", SkipIfUnchanged: true},
+			},
+			expectedError: "skip_if_unchanged requires extra_refs in periodic a",
+		},
+		{
+			name: "Sets max_jitter",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "0 * * * *", MaxJitter: "10ns"},
+			},
+			expected: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "0 * * * *", MaxJitter: "10ns", max_jitter: time.Duration(10)},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8402,6 +9150,8 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+job_gc:
+  resync_period: 24h0m0s
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -8409,6 +9159,7 @@ managed_webhooks:
 moonraker:
   client_timeout: 10m0s
 plank:
+  dependency_grace_period: 5m0s
   max_goroutines: 20
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
@@ -8486,6 +9237,8 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+job_gc:
+  resync_period: 24h0m0s
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -8493,6 +9246,7 @@ managed_webhooks:
 moonraker:
   client_timeout: 10m0s
 plank:
+  dependency_grace_period: 5m0s
   max_goroutines: 20
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
@@ -8563,6 +9317,8 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+job_gc:
+  resync_period: 24h0m0s
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -8570,6 +9326,7 @@ managed_webhooks:
 moonraker:
   client_timeout: 10m0s
 plank:
+  dependency_grace_period: 5m0s
   max_goroutines: 20
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
@@ -8645,6 +9402,8 @@ in_repo_config:
   allowed_clusters:
     '*':
     - default
+job_gc:
+  resync_period: 24h0m0s
 log_level: info
 managed_webhooks:
   auto_accept_invitation: false
@@ -8652,6 +9411,7 @@ managed_webhooks:
 moonraker:
   client_timeout: 10m0s
 plank:
+  dependency_grace_period: 5m0s
   max_goroutines: 20
   pod_pending_timeout: 10m0s
   pod_running_timeout: 48h0m0s
@@ -9567,3 +10327,134 @@ func TestSplitRepoName(t *testing.T) {
 		})
 	}
 }
+
+func TestTenantForRepo(t *testing.T) {
+	pc := ProwConfig{
+		Tenants: map[string]Tenant{
+			"a": {Repos: []string{"org"}},
+			"b": {Repos: []string{"org/special", "other/repo"}},
+		},
+	}
+	testCases := []struct {
+		repo     string
+		expected string
+	}{
+		{repo: "org/repo", expected: "a"},
+		{repo: "org/special", expected: "b"},
+		{repo: "other/repo", expected: "b"},
+		{repo: "other/different", expected: ""},
+		{repo: "", expected: ""},
+	}
+	for _, tc := range testCases {
+		if actual := pc.TenantForRepo(tc.repo); actual != tc.expected {
+			t.Errorf("Expected tenant %q for repo %q, got %q", tc.expected, tc.repo, actual)
+		}
+	}
+}
+
+func TestValidateTenants(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tenants     map[string]Tenant
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			tenants: map[string]Tenant{
+				"a": {Repos: []string{"org"}},
+				"b": {Repos: []string{"org/repo"}},
+			},
+		},
+		{
+			name: "repo in two tenants",
+			tenants: map[string]Tenant{
+				"a": {Repos: []string{"org/repo"}},
+				"b": {Repos: []string{"org/repo"}},
+			},
+			expectedErr: `tenants "a" and "b" both contain "org/repo"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := ProwConfig{Tenants: tc.tenants}
+			var actualErr string
+			if err := pc.validateTenants(); err != nil {
+				actualErr = err.Error()
+			}
+			if actualErr != tc.expectedErr {
+				t.Errorf("Expected error %q, got %q", tc.expectedErr, actualErr)
+			}
+		})
+	}
+}
+
+func TestValidateTenantIsolation(t *testing.T) {
+	c := Config{
+		ProwConfig: ProwConfig{
+			Tenants: map[string]Tenant{
+				"a": {Buckets: []string{"gs://bucket-a"}, Clusters: []string{"cluster-a"}},
+				"b": {Clusters: []string{"cluster-b"}},
+			},
+		},
+	}
+	job := func(tenant, cluster, bucket string) JobBase {
+		return JobBase{
+			Cluster:        cluster,
+			ProwJobDefault: &prowapi.ProwJobDefault{TenantID: tenant},
+			UtilityConfig: UtilityConfig{
+				DecorationConfig: &prowapi.DecorationConfig{
+					GCSConfiguration: &prowapi.GCSConfiguration{Bucket: bucket},
+				},
+			},
+		}
+	}
+	testCases := []struct {
+		name        string
+		job         JobBase
+		expectedErr string
+	}{
+		{
+			name: "tenant uses its resources",
+			job:  job("a", "cluster-a", "bucket-a"),
+		},
+		{
+			name:        "tenant uses a cluster outside of its clusters",
+			job:         job("a", "default", "bucket-a"),
+			expectedErr: `cluster "default" is not one of the clusters of tenant "a"`,
+		},
+		{
+			name:        "tenant uses a bucket outside of its buckets",
+			job:         job("a", "cluster-a", "gs://shared"),
+			expectedErr: `bucket "shared" is not one of the buckets of tenant "a"`,
+		},
+		{
+			name: "tenant without buckets uses a shared bucket",
+			job:  job("b", "cluster-b", "shared"),
+		},
+		{
+			name:        "tenant uses the bucket of another tenant",
+			job:         job("b", "cluster-b", "bucket-a"),
+			expectedErr: `bucket "bucket-a" belongs to tenant "a"`,
+		},
+		{
+			name:        "default tenant uses the cluster of a tenant",
+			job:         job(DefaultTenantID, "cluster-b", "shared"),
+			expectedErr: `cluster "cluster-b" belongs to tenant "b"`,
+		},
+		{
+			name: "default tenant uses shared resources",
+			job:  job(DefaultTenantID, "default", "shared"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actualErr string
+			if err := c.validateTenantIsolation(tc.job); err != nil {
+				actualErr = err.Error()
+			}
+			if actualErr != tc.expectedErr {
+				t.Errorf("Expected error %q, got %q", tc.expectedErr, actualErr)
+			}
+		})
+	}
+}
@@ -20,16 +20,67 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"time"
 
 	"google.golang.org/grpc/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const defaultGangwayAuthorizationWebhookTimeout = 10 * time.Second
+
 type Gangway struct {
 	// AllowedApiClients encodes identifying information about API clients
 	// (AllowedApiClient). An AllowedApiClient has authority to trigger a subset
 	// of Prow Jobs.
 	AllowedApiClients []AllowedApiClient `json:"allowed_api_clients,omitempty"`
+
+	// AuthorizationWebhook is an external policy endpoint that is asked
+	// whether each request to trigger a Prow Job is allowed, on top of the
+	// allowed_api_clients. Requests are only checked against
+	// allowed_api_clients if unset.
+	AuthorizationWebhook *GangwayAuthorizationWebhook `json:"authorization_webhook,omitempty"`
+}
+
+// GangwayAuthorizationWebhook configures the endpoint that authorizes
+// requests to trigger Prow Jobs. The endpoint receives the request as the
+// "input" of an Open Policy Agent (OPA) Data API query, so it can be the URL
+// of an OPA policy decision, e.g.
+// http://opa.default.svc:8181/v1/data/prow/gangway/authz.
+type GangwayAuthorizationWebhook struct {
+	// URL of the endpoint.
+	URL string `json:"url"`
+	// Timeout is how long to wait for a decision. Defaults to 10s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailOpen allows requests if no decision could be made, e.g. because
+	// the endpoint is unreachable. Requests are denied in that case by
+	// default.
+	FailOpen bool `json:"fail_open,omitempty"`
+}
+
+// GetTimeout returns the timeout of the webhook, or the default if unset.
+func (w *GangwayAuthorizationWebhook) GetTimeout() time.Duration {
+	if w.Timeout == nil {
+		return defaultGangwayAuthorizationWebhookTimeout
+	}
+	return w.Timeout.Duration
+}
+
+func (w *GangwayAuthorizationWebhook) Validate() error {
+	if w == nil {
+		return nil
+	}
+	if w.URL == "" {
+		return errors.New("authorization_webhook.url cannot be empty")
+	}
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("authorization_webhook.url must be a http(s) URL, got %q", w.URL)
+	}
+	if w.Timeout != nil && w.Timeout.Duration <= 0 {
+		return fmt.Errorf("authorization_webhook.timeout must be positive, got %s", w.Timeout.Duration)
+	}
+	return nil
 }
 
 type AllowedApiClient struct {
@@ -228,5 +279,5 @@ func (g *Gangway) Validate() error {
 		}
 	}
 
-	return nil
+	return g.AuthorizationWebhook.Validate()
 }
@@ -113,6 +113,34 @@ gangway:
       endpoint_api_consumer_number: "123"
     allowed_jobs_filters:
     - tenant_id: "another-client"
+`,
+			expectError: true,
+		},
+		{
+			name: "authorization webhook",
+			gangwayConfig: `
+gangway:
+  authorization_webhook:
+    url: "http://opa.default.svc:8181/v1/data/prow/gangway/authz"
+    timeout: 5s
+`,
+			expectError: false,
+		},
+		{
+			name: "authorization webhook without URL",
+			gangwayConfig: `
+gangway:
+  authorization_webhook:
+    fail_open: true
+`,
+			expectError: true,
+		},
+		{
+			name: "authorization webhook with invalid URL",
+			gangwayConfig: `
+gangway:
+  authorization_webhook:
+    url: "opa.default.svc:8181"
 `,
 			expectError: true,
 		},
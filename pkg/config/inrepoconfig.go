@@ -23,6 +23,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -31,18 +32,86 @@ import (
 	"github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/prow/pkg/cache"
 	gerritsource "sigs.k8s.io/prow/pkg/gerrit/source"
 
 	"sigs.k8s.io/prow/pkg/git/types"
 	"sigs.k8s.io/prow/pkg/git/v2"
+	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/yaml"
 )
 
 const (
 	inRepoConfigFileName = ".prow.yaml"
 	inRepoConfigDirName  = ".prow"
+
+	// inRepoConfigIncludeCacheSize is the number of included configs that are
+	// kept in memory.
+	inRepoConfigIncludeCacheSize = 100
 )
 
+var commitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// IsCommitSHA returns whether s is a full, lowercase commit SHA.
+func IsCommitSHA(s string) bool {
+	return commitSHARegex.MatchString(s)
+}
+
+// IsInRepoConfigFile returns true if the file at the given path of a repo is
+// part of its inrepoconfig, i.e. the .prow.yaml file or a file in the .prow
+// directory.
+func IsInRepoConfigFile(file string) bool {
+	return file == inRepoConfigFileName || strings.HasPrefix(file, inRepoConfigDirName+"/")
+}
+
+// InRepoConfigApprovedContext is the context of the status that the approve
+// plugin reports on the head commit of a PR once the changes of the PR to the
+// inrepoconfig are approved at that commit.
+const InRepoConfigApprovedContext = "approve/inrepoconfig"
+
+type inRepoConfigApprovalClient interface {
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
+}
+
+// InRepoConfigApproved returns whether the inrepoconfig at the head commit of
+// a PR can be used. That is the case unless the repo requires approval of
+// inrepoconfig changes, the PR changes the inrepoconfig and the head commit
+// has no successful InRepoConfigApprovedContext status.
+func (c *Config) InRepoConfigApproved(ghc inRepoConfigApprovalClient, org, repo string, number int, headSHA string) (bool, error) {
+	if !c.InRepoConfigRequiresApproval(org + "/" + repo) {
+		return true, nil
+	}
+	changes, err := ghc.GetPullRequestChanges(org, repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to get changes of PR: %w", err)
+	}
+	changesInRepoConfig := false
+	for _, change := range changes {
+		if IsInRepoConfigFile(change.Filename) || IsInRepoConfigFile(change.PreviousFilename) {
+			changesInRepoConfig = true
+			break
+		}
+	}
+	if !changesInRepoConfig {
+		return true, nil
+	}
+	status, err := ghc.GetCombinedStatus(org, repo, headSHA)
+	if err != nil {
+		return false, fmt.Errorf("failed to get status of %s: %w", headSHA, err)
+	}
+	for _, s := range status.Statuses {
+		if s.Context == InRepoConfigApprovedContext {
+			return s.State == github.StatusSuccess, nil
+		}
+	}
+	return false, nil
+}
+
+// inRepoConfigIncludeCache caches the ProwYAMLs of included configs. They are
+// pinned to commits, so they never need to be invalidated.
+var inRepoConfigIncludeCache *cache.LRUCache
+
 var inrepoconfigRepoOpts = git.RepoOpts{
 	// Technically we only need inRepoConfigDirName (".prow") because the
 	// default "cone mode" of sparse checkouts already include files at the
@@ -78,6 +147,12 @@ var inrepoconfigMetrics = struct {
 func init() {
 	prometheus.MustRegister(inrepoconfigMetrics.gitCloneDuration)
 	prometheus.MustRegister(inrepoconfigMetrics.gitOtherDuration)
+
+	var err error
+	inRepoConfigIncludeCache, err = cache.NewLRUCache(inRepoConfigIncludeCacheSize, cache.Callbacks{})
+	if err != nil {
+		panic(fmt.Sprintf("failed to create cache for included inrepoconfigs: %v", err))
+	}
 }
 
 // +k8s:deepcopy-gen=true
@@ -89,11 +164,31 @@ type ProwYAML struct {
 	Presubmits  []Presubmit  `json:"presubmits"`
 	Postsubmits []Postsubmit `json:"postsubmits"`
 
+	// Include lists shared configs whose presets, presubmits and postsubmits
+	// are added to the ones of this ProwYAML. They are resolved when the
+	// ProwYAML is retrieved, so retrieved ProwYAMLs never have includes.
+	Include []InRepoConfigInclude `json:"include,omitempty"`
+
 	// ProwIgnored is a well known, unparsed field where non-Prow fields can
 	// be defined without conflicting with unknown field validation.
 	ProwIgnored *json.RawMessage `json:"prow_ignored,omitempty"`
 }
 
+// InRepoConfigInclude references the inrepoconfig of another repo, usually a
+// central repo holding job definitions that are shared by many repos.
+type InRepoConfigInclude struct {
+	// Repo is the repo holding the shared config, in org/repo format. It must
+	// be allowed by in_repo_config.allowed_include_repos.
+	Repo string `json:"repo"`
+	// Ref is the full SHA of the commit the shared config is read from.
+	// Pinning the commit makes sure that the included jobs only change when
+	// the including repo is changed.
+	Ref string `json:"ref"`
+	// Path is the directory of the repo holding the .prow.yaml file or .prow
+	// directory. Defaults to the root of the repo.
+	Path string `json:"path,omitempty"`
+}
+
 // ProwYAMLGetter is used to retrieve a ProwYAML. Tests should provide
 // their own implementation and set that on the Config.
 type ProwYAMLGetter func(c *Config, gc git.ClientFactory, identifier, baseBranch, baseSHA string, headSHAs ...string) (*ProwYAML, error)
@@ -188,7 +283,97 @@ func prowYAMLGetter(
 		return nil, fmt.Errorf("failed to merge: %w", err)
 	}
 
-	return ReadProwYAML(log, repo.Directory(), false)
+	prowYAML, err := ReadProwYAML(log, repo.Directory(), false)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveIncludes(c, gc, identifier, prowYAML); err != nil {
+		return nil, err
+	}
+	return prowYAML, nil
+}
+
+// resolveIncludes adds the presets, presubmits and postsubmits of the configs
+// included by prowYAML to it.
+func resolveIncludes(c *Config, gc git.ClientFactory, identifier string, prowYAML *ProwYAML) error {
+	var errs []error
+	for _, include := range prowYAML.Include {
+		if !c.InRepoConfigAllowsInclude(include.Repo, identifier) {
+			errs = append(errs, fmt.Errorf("repository %q is not allowed to be included by repository %q", include.Repo, identifier))
+			continue
+		}
+		included, err := getIncludedProwYAML(gc, include)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// The included ProwYAML is cached, so it must not be modified by
+		// defaulting later on.
+		included = included.DeepCopy()
+		prowYAML.Presets = append(prowYAML.Presets, included.Presets...)
+		prowYAML.Presubmits = append(prowYAML.Presubmits, included.Presubmits...)
+		prowYAML.Postsubmits = append(prowYAML.Postsubmits, included.Postsubmits...)
+	}
+	prowYAML.Include = nil
+	return utilerrors.NewAggregate(errs)
+}
+
+// getIncludedProwYAML returns the ProwYAML of an included config from the
+// cache, reading it from the included repo on a cache miss.
+func getIncludedProwYAML(gc git.ClientFactory, include InRepoConfigInclude) (*ProwYAML, error) {
+	if !commitSHARegex.MatchString(include.Ref) {
+		return nil, fmt.Errorf("ref %q of included repository %q is not a full commit SHA", include.Ref, include.Repo)
+	}
+	if include.Path != "" && !filepath.IsLocal(include.Path) {
+		return nil, fmt.Errorf("path %q of included repository %q must be relative to the repository root", include.Path, include.Repo)
+	}
+
+	val, _, err := inRepoConfigIncludeCache.GetOrAdd(include, func() (interface{}, error) {
+		return readIncludedProwYAML(gc, include)
+	})
+	if err != nil {
+		return nil, err
+	}
+	prowYAML, ok := val.(*ProwYAML)
+	if !ok {
+		return nil, fmt.Errorf("Programmer error: expected value type '*config.ProwYAML', got '%T'", val)
+	}
+	return prowYAML, nil
+}
+
+func readIncludedProwYAML(gc git.ClientFactory, include InRepoConfigInclude) (*ProwYAML, error) {
+	log := logrus.WithFields(logrus.Fields{"repo": include.Repo, "ref": include.Ref, "path": include.Path})
+
+	orgRepo := *NewOrgRepo(include.Repo)
+	if orgRepo.Repo == "" {
+		return nil, fmt.Errorf("didn't get two results when splitting included repo identifier %q", include.Repo)
+	}
+
+	repoOpts := inrepoconfigRepoOpts
+	repoOpts.SparseCheckoutDirs = []string{path.Join(include.Path, inRepoConfigDirName)}
+	repoOpts.NeededCommits = sets.New(include.Ref)
+	repo, err := gc.ClientForWithRepoOpts(orgRepo.Org, orgRepo.Repo, repoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone included repo %q: %w", include.Repo, err)
+	}
+	defer func() {
+		if err := repo.Clean(); err != nil {
+			log.WithError(err).Error("Failed to clean up repo.")
+		}
+	}()
+
+	if err := repo.Checkout(include.Ref); err != nil {
+		return nil, fmt.Errorf("failed to checkout %s of included repo %q: %w", include.Ref, include.Repo, err)
+	}
+
+	prowYAML, err := ReadProwYAML(log, filepath.Join(repo.Directory(), include.Path), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config of included repo %q: %w", include.Repo, err)
+	}
+	if len(prowYAML.Include) > 0 {
+		return nil, fmt.Errorf("config of included repo %q must not include other configs", include.Repo)
+	}
+	return prowYAML, nil
 }
 
 // ReadProwYAML parses the .prow.yaml file or .prow directory, no commit checkout or defaulting is included.
@@ -26,6 +26,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"sigs.k8s.io/prow/pkg/git/localgit"
 	"sigs.k8s.io/prow/pkg/git/v2"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
 	"sigs.k8s.io/prow/pkg/kube"
 )
 
@@ -665,6 +667,142 @@ func testDefaultProwYAMLGetter_RejectsJustOrg(clients localgit.Clients, t *testi
 	}
 }
 
+func TestProwYAMLGetterIncludesV2(t *testing.T) {
+	testProwYAMLGetterIncludes(localgit.NewV2, t)
+}
+
+func testProwYAMLGetterIncludes(clients localgit.Clients, t *testing.T) {
+	testCases := []struct {
+		name           string
+		sharedContent  map[string][]byte
+		includeRef     string
+		includePath    string
+		allowedRepos   []string
+		wantPresubmits []string
+		wantErr        string
+	}{
+		{
+			name: "Jobs of included config are added",
+			sharedContent: map[string][]byte{
+				"jobs/.prow.yaml": []byte(`presubmits: [{"name": "shared"}]`),
+			},
+			includePath:    "jobs",
+			allowedRepos:   []string{"org/shared"},
+			wantPresubmits: []string{"local", "shared"},
+		},
+		{
+			name: "Jobs of included .prow directory are added",
+			sharedContent: map[string][]byte{
+				".prow/a.yaml": []byte(`presubmits: [{"name": "shared-a"}]`),
+				".prow/b.yaml": []byte(`presubmits: [{"name": "shared-b"}]`),
+			},
+			allowedRepos:   []string{"org/shared"},
+			wantPresubmits: []string{"local", "shared-a", "shared-b"},
+		},
+		{
+			name: "Repo that is not allowed is rejected",
+			sharedContent: map[string][]byte{
+				".prow.yaml": []byte(`presubmits: [{"name": "shared"}]`),
+			},
+			allowedRepos: []string{"org/other"},
+			wantErr:      `repository "org/shared" is not allowed to be included by repository "org/repo"`,
+		},
+		{
+			name: "Ref that is not a commit SHA is rejected",
+			sharedContent: map[string][]byte{
+				".prow.yaml": []byte(`presubmits: [{"name": "shared"}]`),
+			},
+			includeRef:   "main",
+			allowedRepos: []string{"org/shared"},
+			wantErr:      `ref "main" of included repository "org/shared" is not a full commit SHA`,
+		},
+		{
+			name: "Path outside of the repo is rejected",
+			sharedContent: map[string][]byte{
+				".prow.yaml": []byte(`presubmits: [{"name": "shared"}]`),
+			},
+			includePath:  "../other",
+			allowedRepos: []string{"org/shared"},
+			wantErr:      `path "../other" of included repository "org/shared" must be relative to the repository root`,
+		},
+		{
+			name: "Nested includes are rejected",
+			sharedContent: map[string][]byte{
+				".prow.yaml": []byte(`include: [{"repo": "org/other", "ref": "0123456789012345678901234567890123456789"}]`),
+			},
+			allowedRepos: []string{"org/shared"},
+			wantErr:      `config of included repo "org/shared" must not include other configs`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lg, gc, err := clients()
+			if err != nil {
+				t.Fatalf("Making local git repo: %v", err)
+			}
+			defer func() {
+				if err := lg.Clean(); err != nil {
+					t.Errorf("Error cleaning LocalGit: %v", err)
+				}
+				if err := gc.Clean(); err != nil {
+					t.Errorf("Error cleaning Client: %v", err)
+				}
+			}()
+
+			if err := lg.MakeFakeRepo("org", "shared"); err != nil {
+				t.Fatalf("Making fake repo: %v", err)
+			}
+			if err := lg.AddCommit("org", "shared", tc.sharedContent); err != nil {
+				t.Fatalf("failed to commit shared content: %v", err)
+			}
+			ref := tc.includeRef
+			if ref == "" {
+				if ref, err = lg.RevParse("org", "shared", "HEAD"); err != nil {
+					t.Fatalf("failed to get SHA of shared repo: %v", err)
+				}
+			}
+
+			if err := lg.MakeFakeRepo("org", "repo"); err != nil {
+				t.Fatalf("Making fake repo: %v", err)
+			}
+			prowYAML := fmt.Sprintf(`{"presubmits": [{"name": "local"}], "include": [{"repo": "org/shared", "ref": %q, "path": %q}]}`, ref, tc.includePath)
+			if err := lg.AddCommit("org", "repo", map[string][]byte{".prow.yaml": []byte(prowYAML)}); err != nil {
+				t.Fatalf("failed to commit .prow.yaml: %v", err)
+			}
+			baseSHA, err := lg.RevParse("org", "repo", "HEAD")
+			if err != nil {
+				t.Fatalf("failed to get baseSHA: %v", err)
+			}
+
+			c := &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+				AllowedIncludeRepos: map[string][]string{"org": tc.allowedRepos},
+			}}}
+			p, err := prowYAMLGetter(c, gc, "org/repo", "main", baseSHA)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var presubmits []string
+			for _, presubmit := range p.Presubmits {
+				presubmits = append(presubmits, presubmit.Name)
+			}
+			if diff := cmp.Diff(tc.wantPresubmits, presubmits); diff != "" {
+				t.Errorf("unexpected presubmits (-want +got):\n%s", diff)
+			}
+			if len(p.Include) != 0 {
+				t.Errorf("expected includes to be resolved, got %v", p.Include)
+			}
+		})
+	}
+}
+
 type testClientFactory struct {
 	git.ClientFactory // This will be nil during testing, we override the functions that are used.
 	rcMap             map[string]git.RepoClient
@@ -743,3 +881,64 @@ func TestInRepoConfigClean(t *testing.T) {
 		t.Fatalf("%s should have been deleted", f)
 	}
 }
+
+func TestInRepoConfigApproved(t *testing.T) {
+	yes := true
+	testCases := []struct {
+		name            string
+		requireApproval map[string]*bool
+		changes         []github.PullRequestChange
+		statuses        []github.Status
+		expected        bool
+	}{
+		{
+			name:     "approval is not required",
+			changes:  []github.PullRequestChange{{Filename: ".prow/jobs.yaml"}},
+			expected: true,
+		},
+		{
+			name:            "inrepoconfig is unchanged",
+			requireApproval: map[string]*bool{"org": &yes},
+			changes:         []github.PullRequestChange{{Filename: "main.go"}},
+			expected:        true,
+		},
+		{
+			name:            "changed inrepoconfig is not approved",
+			requireApproval: map[string]*bool{"org": &yes},
+			changes:         []github.PullRequestChange{{Filename: "main.go"}, {Filename: ".prow/jobs.yaml"}},
+		},
+		{
+			name:            "moved inrepoconfig is not approved",
+			requireApproval: map[string]*bool{"*": &yes},
+			changes:         []github.PullRequestChange{{Filename: "jobs.yaml", PreviousFilename: ".prow.yaml"}},
+		},
+		{
+			name:            "approval of the changed inrepoconfig is pending",
+			requireApproval: map[string]*bool{"org/repo": &yes},
+			changes:         []github.PullRequestChange{{Filename: ".prow.yaml"}},
+			statuses:        []github.Status{{Context: InRepoConfigApprovedContext, State: github.StatusPending}},
+		},
+		{
+			name:            "changed inrepoconfig is approved",
+			requireApproval: map[string]*bool{"org/repo": &yes},
+			changes:         []github.PullRequestChange{{Filename: ".prow.yaml"}},
+			statuses:        []github.Status{{Context: "ci", State: github.StatusFailure}, {Context: InRepoConfigApprovedContext, State: github.StatusSuccess}},
+			expected:        true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fghc := fakegithub.NewFakeClient()
+			fghc.PullRequestChanges = map[int][]github.PullRequestChange{1: tc.changes}
+			fghc.CombinedStatuses = map[string]*github.CombinedStatus{"head": {SHA: "head", Statuses: tc.statuses}}
+			c := &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{RequireApproval: tc.requireApproval}}}
+			approved, err := c.InRepoConfigApproved(fghc, "org", "repo", 1, "head")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if approved != tc.expected {
+				t.Errorf("expected approved to be %t, got %t", tc.expected, approved)
+			}
+		})
+	}
+}
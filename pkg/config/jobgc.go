@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobGC is config for the prow-job-gc component, which deletes the
+// artifacts of old job runs from the storage buckets.
+type JobGC struct {
+	// ResyncPeriod is how often the artifacts are garbage collected.
+	// Defaults to one day.
+	ResyncPeriod *metav1.Duration `json:"resync_period,omitempty"`
+	// Default is the retention policy for the artifacts of jobs that
+	// no policy in Repos applies to. The artifacts of these jobs are
+	// kept forever if it is unset.
+	Default *JobGCPolicy `json:"default,omitempty"`
+	// Repos maps "org" or "org/repo" to the retention policy for the
+	// artifacts of the jobs of the repository. Periodics use the
+	// policy of their first extra ref. A policy for "org/repo" takes
+	// precedence over one for "org".
+	Repos map[string]JobGCPolicy `json:"repos,omitempty"`
+}
+
+// JobGCPolicy is a retention policy for the artifacts of job runs.
+// The artifacts of a run are deleted once the run is not one of the
+// latest KeepRuns runs of its job and is older than MaxAge, or
+// MaxFailureAge if it did not succeed.
+type JobGCPolicy struct {
+	// KeepRuns is the number of latest runs of each job whose artifacts
+	// are kept regardless of their age.
+	KeepRuns int `json:"keep_runs,omitempty"`
+	// MaxAge is how long the artifacts of successful runs are kept.
+	// Runs beyond KeepRuns are deleted right away if it is unset.
+	MaxAge *metav1.Duration `json:"max_age,omitempty"`
+	// MaxFailureAge is how long the artifacts of failed runs are kept.
+	// Defaults to MaxAge.
+	MaxFailureAge *metav1.Duration `json:"max_failure_age,omitempty"`
+}
+
+// GetMaxAge returns the max age of successful runs, zero if unset.
+func (r *JobGCPolicy) GetMaxAge() metav1.Duration {
+	if r.MaxAge == nil {
+		return metav1.Duration{}
+	}
+	return *r.MaxAge
+}
+
+// GetMaxFailureAge returns the max age of failed runs.
+func (r *JobGCPolicy) GetMaxFailureAge() metav1.Duration {
+	if r.MaxFailureAge == nil {
+		return r.GetMaxAge()
+	}
+	return *r.MaxFailureAge
+}
+
+func (r *JobGCPolicy) validate() error {
+	if r.KeepRuns < 0 {
+		return fmt.Errorf("keep_runs must not be negative, got %d", r.KeepRuns)
+	}
+	if r.MaxAge != nil && r.MaxAge.Duration < 0 {
+		return fmt.Errorf("max_age must not be negative, got %s", r.MaxAge.Duration)
+	}
+	if r.MaxFailureAge != nil && r.MaxFailureAge.Duration < 0 {
+		return fmt.Errorf("max_failure_age must not be negative, got %s", r.MaxFailureAge.Duration)
+	}
+	if r.KeepRuns == 0 && r.MaxAge == nil {
+		return errors.New("at least one of keep_runs and max_age must be set")
+	}
+	return nil
+}
+
+// Validate validates the retention policies.
+func (g *JobGC) Validate() error {
+	if g.Default != nil {
+		if err := g.Default.validate(); err != nil {
+			return fmt.Errorf("job_gc.default: %w", err)
+		}
+	}
+	for orgRepo, policy := range g.Repos {
+		if orgRepo == "" {
+			return errors.New("job_gc.repos: keys must not be empty")
+		}
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("job_gc.repos[%s]: %w", orgRepo, err)
+		}
+	}
+	return nil
+}
+
+// RetentionFor returns the retention policy for the artifacts of the jobs
+// of org/repo, or nil if they are kept forever. Both org and repo may be
+// empty for periodics without extra refs.
+func (g *JobGC) RetentionFor(org, repo string) *JobGCPolicy {
+	if policy, ok := g.Repos[org+"/"+repo]; ok && repo != "" {
+		return &policy
+	}
+	if policy, ok := g.Repos[org]; ok && org != "" {
+		return &policy
+	}
+	return g.Default
+}
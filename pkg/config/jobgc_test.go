@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobGCValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		jobGC   JobGC
+		wantErr string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid policies",
+			jobGC: JobGC{
+				Default: &JobGCPolicy{KeepRuns: 10},
+				Repos: map[string]JobGCPolicy{
+					"org":      {MaxAge: &metav1.Duration{Duration: time.Hour}},
+					"org/repo": {KeepRuns: 1, MaxAge: &metav1.Duration{Duration: time.Hour}, MaxFailureAge: &metav1.Duration{Duration: 2 * time.Hour}},
+				},
+			},
+		},
+		{
+			name:    "default keeps everything forever",
+			jobGC:   JobGC{Default: &JobGCPolicy{}},
+			wantErr: "job_gc.default: at least one of keep_runs and max_age must be set",
+		},
+		{
+			name:    "negative keep_runs",
+			jobGC:   JobGC{Repos: map[string]JobGCPolicy{"org": {KeepRuns: -1}}},
+			wantErr: "job_gc.repos[org]: keep_runs must not be negative, got -1",
+		},
+		{
+			name:    "negative max_failure_age",
+			jobGC:   JobGC{Repos: map[string]JobGCPolicy{"org/repo": {KeepRuns: 1, MaxFailureAge: &metav1.Duration{Duration: -time.Hour}}}},
+			wantErr: "job_gc.repos[org/repo]: max_failure_age must not be negative, got -1h0m0s",
+		},
+		{
+			name:    "empty key",
+			jobGC:   JobGC{Repos: map[string]JobGCPolicy{"": {KeepRuns: 1}}},
+			wantErr: "job_gc.repos: keys must not be empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErr string
+			if err := tc.jobGC.Validate(); err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("expected error %q, got %q", tc.wantErr, gotErr)
+			}
+		})
+	}
+}
+
+func TestJobGCRetentionFor(t *testing.T) {
+	defaultPolicy := &JobGCPolicy{KeepRuns: 100}
+	jobGC := JobGC{
+		Default: defaultPolicy,
+		Repos: map[string]JobGCPolicy{
+			"org":      {KeepRuns: 10},
+			"org/repo": {KeepRuns: 1},
+		},
+	}
+	testCases := []struct {
+		name      string
+		org, repo string
+		expected  *JobGCPolicy
+	}{
+		{
+			name:     "repo policy",
+			org:      "org",
+			repo:     "repo",
+			expected: &JobGCPolicy{KeepRuns: 1},
+		},
+		{
+			name:     "org policy",
+			org:      "org",
+			repo:     "other",
+			expected: &JobGCPolicy{KeepRuns: 10},
+		},
+		{
+			name:     "default policy",
+			org:      "other",
+			repo:     "repo",
+			expected: defaultPolicy,
+		},
+		{
+			name:     "no repo",
+			expected: defaultPolicy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, jobGC.RetentionFor(tc.org, tc.repo)); diff != "" {
+				t.Errorf("unexpected policy (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobGCPolicyMaxFailureAge(t *testing.T) {
+	policy := JobGCPolicy{MaxAge: &metav1.Duration{Duration: time.Hour}}
+	if got := policy.GetMaxFailureAge().Duration; got != time.Hour {
+		t.Errorf("expected max failure age to default to max age, got %s", got)
+	}
+	policy.MaxFailureAge = &metav1.Duration{Duration: 2 * time.Hour}
+	if got := policy.GetMaxFailureAge().Duration; got != 2*time.Hour {
+		t.Errorf("expected max failure age of 2h, got %s", got)
+	}
+}
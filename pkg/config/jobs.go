@@ -97,7 +97,9 @@ type JobBase struct {
 	MaxConcurrency int `json:"max_concurrency,omitempty"`
 	// Agent that will take care of running this job. Defaults to "kubernetes"
 	Agent string `json:"agent,omitempty"`
-	// Cluster is the alias of the cluster to run this job in.
+	// Cluster is the alias of the cluster to run this job in, or a label
+	// selector the scheduler resolves to one of the clusters configured in
+	// scheduler.cluster_selection.
 	// (Default: kube.DefaultClusterAlias)
 	Cluster string `json:"cluster,omitempty"`
 	// Namespace is the namespace in which pods schedule.
@@ -135,6 +137,14 @@ type JobBase struct {
 	// Works in parallel with MaxConcurrency and the limit is selected from the
 	// minimal setting of those two fields.
 	JobQueueName string `json:"job_queue_name,omitempty"`
+	// DependsOn lists the names of other presubmits or postsubmits of the same
+	// repository that must succeed for the same refs before this job is started.
+	// The storage paths of the artifacts of the dependencies are passed to the
+	// test containers in the DEPENDENCY_ARTIFACTS environment variable.
+	DependsOn []string `json:"depends_on,omitempty"`
+	// Retry configures how many times the job is run again when it errors,
+	// or is aborted by plank, because of the infrastructure it runs on.
+	Retry *prowapi.RetryPolicy `json:"retry,omitempty"`
 
 	UtilityConfig
 }
@@ -152,7 +162,7 @@ func (jb JobBase) GetAnnotations() map[string]string {
 }
 
 func (jb JobBase) HasPipelineRunSpec() bool {
-	if jb.TektonPipelineRunSpec != nil && jb.TektonPipelineRunSpec.V1Beta1 != nil {
+	if jb.TektonPipelineRunSpec.GetPipelineRunSpec() != nil {
 		return true
 	}
 	if jb.PipelineRunSpec != nil {
@@ -162,10 +172,7 @@ func (jb JobBase) HasPipelineRunSpec() bool {
 }
 
 func (jb JobBase) GetPipelineRunSpec() (*pipelinev1.PipelineRunSpec, error) {
-	var found *pipelinev1.PipelineRunSpec
-	if jb.TektonPipelineRunSpec != nil {
-		found = jb.TektonPipelineRunSpec.V1Beta1
-	}
+	found := jb.TektonPipelineRunSpec.GetPipelineRunSpec()
 	if found == nil && jb.PipelineRunSpec != nil {
 		found = jb.PipelineRunSpec
 	}
@@ -279,11 +286,22 @@ type Periodic struct {
 	MinimumInterval string `json:"minimum_interval,omitempty"`
 	// Cron representation of job trigger time
 	Cron string `json:"cron,omitempty"`
+	// MaxJitter delays the runs of the job by up to this duration, so the
+	// runs of periodics sharing a schedule don't all start at once.
+	// Defaults to horologium.max_jitter.
+	MaxJitter string `json:"max_jitter,omitempty"`
+	// SkipIfUnchanged skips runs if the base_ref of the first extra_ref still
+	// points at the commit the last successful run tested. Runs test the
+	// commit the base_ref pointed at when they were triggered. A skipped run
+	// of an interval periodic counts as a run for the next interval. Only
+	// supported for repos on GitHub.
+	SkipIfUnchanged bool `json:"skip_if_unchanged,omitempty"`
 	// Tags for config entries
 	Tags []string `json:"tags,omitempty"`
 
 	interval         time.Duration
 	minimum_interval time.Duration
+	max_jitter       time.Duration
 }
 
 // JenkinsSpec holds optional Jenkins job config
@@ -313,6 +331,16 @@ func (p *Periodic) GetMinimumInterval() time.Duration {
 	return p.minimum_interval
 }
 
+// SetMaxJitter updates max_jitter, the maximum delay of its runs.
+func (p *Periodic) SetMaxJitter(d time.Duration) {
+	p.max_jitter = d
+}
+
+// GetMaxJitter returns max_jitter, the maximum delay of its runs.
+func (p *Periodic) GetMaxJitter() time.Duration {
+	return p.max_jitter
+}
+
 // +k8s:deepcopy-gen=true
 
 // Brancher is for shared code between jobs that only run against certain
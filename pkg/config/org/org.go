@@ -26,6 +26,14 @@ import (
 // orgs to their configuration at the top level under an `orgs` key.
 type FullConfig struct {
 	Orgs map[string]Config `json:"orgs,omitempty"`
+
+	// MemberLists are named lists of users, which the members and admins
+	// of orgs, the members and maintainers of teams and the users of org
+	// roles can include with a $name entry. Lists can include other lists.
+	MemberLists map[string][]string `json:"member_lists,omitempty"`
+	// RepoTemplates are named repo settings, which repos apply by setting
+	// their template to the name. Templates can apply other templates.
+	RepoTemplates map[string]Repo `json:"repo_templates,omitempty"`
 }
 
 // Metadata declares metadata about the GitHub org.
@@ -42,6 +50,37 @@ type Metadata struct {
 	HasRepositoryProjects        *bool                       `json:"has_repository_projects,omitempty"`
 	DefaultRepositoryPermission  *github.RepoPermissionLevel `json:"default_repository_permission,omitempty"`
 	MembersCanCreateRepositories *bool                       `json:"members_can_create_repositories,omitempty"`
+
+	Actions *Actions `json:"actions,omitempty"`
+}
+
+// Actions declares the GitHub Actions settings of the org.
+//
+// See https://docs.github.com/en/rest/actions/permissions
+type Actions struct {
+	// AllowedActions is all, local_only or selected.
+	AllowedActions *github.AllowedActions `json:"allowed_actions,omitempty"`
+	// SelectedActions declares the actions that are allowed if
+	// AllowedActions is selected.
+	SelectedActions *SelectedActions `json:"selected_actions,omitempty"`
+	// DefaultWorkflowPermissions is the default permission of the
+	// GITHUB_TOKEN of workflows, read or write.
+	DefaultWorkflowPermissions *github.WorkflowPermission `json:"default_workflow_permissions,omitempty"`
+	// CanApprovePullRequestReviews allows workflows to approve pull requests.
+	CanApprovePullRequestReviews *bool `json:"can_approve_pull_request_reviews,omitempty"`
+	// ForkPRApprovalPolicy decides which contributors need an approval before
+	// workflows run on their pull requests from forks. It is one of
+	// first_time_contributors_new_to_github, first_time_contributors or
+	// all_external_contributors.
+	ForkPRApprovalPolicy *github.ForkPRApprovalPolicy `json:"fork_pr_approval_policy,omitempty"`
+}
+
+// SelectedActions declares the actions that are allowed in addition to the
+// actions defined in the org.
+type SelectedActions struct {
+	GitHubOwnedAllowed *bool    `json:"github_owned_allowed,omitempty"`
+	VerifiedAllowed    *bool    `json:"verified_allowed,omitempty"`
+	PatternsAllowed    []string `json:"patterns_allowed,omitempty"`
 }
 
 // RepoCreateOptions declares options for creating new repos
@@ -74,6 +113,10 @@ type Repo struct {
 	Previously []string `json:"previously,omitempty"`
 
 	OnCreate *RepoCreateOptions `json:"on_create,omitempty"`
+
+	// Template is the name of the repo template whose settings apply
+	// to the repo, unless the repo sets them itself.
+	Template string `json:"template,omitempty"`
 }
 
 // Config declares org metadata as well as its people and teams.
@@ -83,6 +126,21 @@ type Config struct {
 	Members []string        `json:"members,omitempty"`
 	Admins  []string        `json:"admins,omitempty"`
 	Repos   map[string]Repo `json:"repos,omitempty"`
+
+	// SecurityManagers lists the teams that have the security manager role.
+	SecurityManagers []string `json:"security_managers,omitempty"`
+	// OrgRoleAssignments maps the names of predefined or custom org roles
+	// to the teams and users they are assigned to. Roles that are not
+	// listed are left alone.
+	OrgRoleAssignments map[string]OrgRoleAssignment `json:"org_role_assignments,omitempty"`
+}
+
+// OrgRoleAssignment declares the teams and users an org role is assigned to.
+//
+// See https://docs.github.com/en/organizations/managing-peoples-access-to-your-organization-with-roles/about-custom-organization-roles
+type OrgRoleAssignment struct {
+	Teams []string `json:"teams,omitempty"`
+	Users []string `json:"users,omitempty"`
 }
 
 // TeamMetadata declares metadata about the github team.
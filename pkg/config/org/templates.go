@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package org
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// memberListPrefix marks the entries of user lists that include a member
+// list. GitHub logins cannot contain it.
+const memberListPrefix = "$"
+
+// ExpandTemplates replaces the member lists included by the orgs, teams and
+// org roles with their users, and applies the repo templates to the repos
+// that reference them. It fails on unknown lists and templates and on lists
+// or templates that include themselves.
+func (fc *FullConfig) ExpandTemplates() error {
+	e := &expander{
+		config:    fc,
+		lists:     map[string][]string{},
+		templates: map[string]Repo{},
+	}
+	for _, orgName := range sets.List(sets.KeySet(fc.Orgs)) {
+		cfg, err := e.expandOrg(fc.Orgs[orgName])
+		if err != nil {
+			return fmt.Errorf("org %s: %w", orgName, err)
+		}
+		fc.Orgs[orgName] = cfg
+	}
+	return nil
+}
+
+type expander struct {
+	config *FullConfig
+	// lists and templates hold the expanded member lists and repo templates.
+	lists     map[string][]string
+	templates map[string]Repo
+}
+
+func (e *expander) expandOrg(cfg Config) (Config, error) {
+	var err error
+	if cfg.Members, err = e.expandUsers(cfg.Members); err != nil {
+		return cfg, fmt.Errorf("members: %w", err)
+	}
+	if cfg.Admins, err = e.expandUsers(cfg.Admins); err != nil {
+		return cfg, fmt.Errorf("admins: %w", err)
+	}
+	if cfg.Teams, err = e.expandTeams(cfg.Teams); err != nil {
+		return cfg, err
+	}
+	for _, roleName := range sets.List(sets.KeySet(cfg.OrgRoleAssignments)) {
+		assignment := cfg.OrgRoleAssignments[roleName]
+		if assignment.Users, err = e.expandUsers(assignment.Users); err != nil {
+			return cfg, fmt.Errorf("org role %s: users: %w", roleName, err)
+		}
+		cfg.OrgRoleAssignments[roleName] = assignment
+	}
+	for _, repoName := range sets.List(sets.KeySet(cfg.Repos)) {
+		repo, err := e.expandRepo(cfg.Repos[repoName], nil)
+		if err != nil {
+			return cfg, fmt.Errorf("repo %s: %w", repoName, err)
+		}
+		cfg.Repos[repoName] = repo
+	}
+	return cfg, nil
+}
+
+func (e *expander) expandTeams(teams map[string]Team) (map[string]Team, error) {
+	for _, teamName := range sets.List(sets.KeySet(teams)) {
+		team := teams[teamName]
+		var err error
+		if team.Members, err = e.expandUsers(team.Members); err != nil {
+			return nil, fmt.Errorf("team %s: members: %w", teamName, err)
+		}
+		if team.Maintainers, err = e.expandUsers(team.Maintainers); err != nil {
+			return nil, fmt.Errorf("team %s: maintainers: %w", teamName, err)
+		}
+		if team.Children, err = e.expandTeams(team.Children); err != nil {
+			return nil, fmt.Errorf("team %s: %w", teamName, err)
+		}
+		teams[teamName] = team
+	}
+	return teams, nil
+}
+
+// expandUsers replaces the included member lists with their users, keeping
+// the first occurrence of users that are listed more than once.
+func (e *expander) expandUsers(users []string) ([]string, error) {
+	return e.expandUsersVisiting(users, nil)
+}
+
+func (e *expander) expandUsersVisiting(users []string, visiting []string) ([]string, error) {
+	if users == nil {
+		return nil, nil
+	}
+	expanded := []string{}
+	seen := map[string]bool{}
+	for _, user := range users {
+		included := []string{user}
+		if strings.HasPrefix(user, memberListPrefix) {
+			var err error
+			if included, err = e.memberList(strings.TrimPrefix(user, memberListPrefix), visiting); err != nil {
+				return nil, err
+			}
+		}
+		for _, u := range included {
+			if !seen[u] {
+				seen[u] = true
+				expanded = append(expanded, u)
+			}
+		}
+	}
+	return expanded, nil
+}
+
+func (e *expander) memberList(name string, visiting []string) ([]string, error) {
+	if users, ok := e.lists[name]; ok {
+		return users, nil
+	}
+	if err := checkCycle("member list", name, visiting); err != nil {
+		return nil, err
+	}
+	users, ok := e.config.MemberLists[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown member list %q", name)
+	}
+	expanded, err := e.expandUsersVisiting(users, append(visiting, name))
+	if err != nil {
+		return nil, err
+	}
+	e.lists[name] = expanded
+	return expanded, nil
+}
+
+// expandRepo applies the template of the repo, and the templates it applies
+// in turn.
+func (e *expander) expandRepo(repo Repo, visiting []string) (Repo, error) {
+	if repo.Template == "" {
+		return repo, nil
+	}
+	template, err := e.repoTemplate(repo.Template, visiting)
+	if err != nil {
+		return repo, err
+	}
+	return applyRepoTemplate(repo, template), nil
+}
+
+func (e *expander) repoTemplate(name string, visiting []string) (Repo, error) {
+	if template, ok := e.templates[name]; ok {
+		return template, nil
+	}
+	if err := checkCycle("repo template", name, visiting); err != nil {
+		return Repo{}, err
+	}
+	template, ok := e.config.RepoTemplates[name]
+	if !ok {
+		return Repo{}, fmt.Errorf("unknown repo template %q", name)
+	}
+	expanded, err := e.expandRepo(template, append(visiting, name))
+	if err != nil {
+		return Repo{}, err
+	}
+	e.templates[name] = expanded
+	return expanded, nil
+}
+
+// applyRepoTemplate fills in the settings the repo does not set from the
+// template. The previous names of the repo are never templated.
+func applyRepoTemplate(repo, template Repo) Repo {
+	applyString := func(p **string, def *string) {
+		if *p == nil {
+			*p = def
+		}
+	}
+	applyBool := func(p **bool, def *bool) {
+		if *p == nil {
+			*p = def
+		}
+	}
+
+	applyString(&repo.Description, template.Description)
+	applyString(&repo.HomePage, template.HomePage)
+	applyBool(&repo.Private, template.Private)
+	applyBool(&repo.HasIssues, template.HasIssues)
+	applyBool(&repo.HasProjects, template.HasProjects)
+	applyBool(&repo.HasWiki, template.HasWiki)
+	applyBool(&repo.AllowSquashMerge, template.AllowSquashMerge)
+	applyBool(&repo.AllowMergeCommit, template.AllowMergeCommit)
+	applyBool(&repo.AllowRebaseMerge, template.AllowRebaseMerge)
+	applyString(&repo.SquashMergeCommitTitle, template.SquashMergeCommitTitle)
+	applyString(&repo.SquashMergeCommitMessage, template.SquashMergeCommitMessage)
+	applyString(&repo.DefaultBranch, template.DefaultBranch)
+	applyBool(&repo.Archived, template.Archived)
+	if repo.OnCreate == nil {
+		repo.OnCreate = template.OnCreate
+	}
+	repo.Template = ""
+
+	return repo
+}
+
+func checkCycle(kind, name string, visiting []string) error {
+	for i, v := range visiting {
+		if v == name {
+			return fmt.Errorf("%s %q includes itself: %s", kind, name, strings.Join(append(visiting[i:], name), " -> "))
+		}
+	}
+	return nil
+}
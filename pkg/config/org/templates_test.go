@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package org
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+)
+
+func TestExpandTemplates(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        string
+		expected      string
+		expectedError string
+	}{
+		{
+			name: "nothing to expand",
+			config: `
+orgs:
+  org:
+    members: [alice]
+    repos:
+      repo:
+        description: a repo
+`,
+			expected: `
+orgs:
+  org:
+    members: [alice]
+    repos:
+      repo:
+        description: a repo
+`,
+		},
+		{
+			name: "member lists are expanded everywhere",
+			config: `
+member_lists:
+  leads: [alice, bob]
+  maintainers: [$leads, carol]
+orgs:
+  org:
+    admins: [$leads]
+    members: [$maintainers, dave, alice]
+    org_role_assignments:
+      security_manager:
+        users: [$leads]
+    teams:
+      parent:
+        maintainers: [$leads]
+        teams:
+          child:
+            members: [$maintainers]
+`,
+			expected: `
+member_lists:
+  leads: [alice, bob]
+  maintainers: [$leads, carol]
+orgs:
+  org:
+    admins: [alice, bob]
+    members: [alice, bob, carol, dave]
+    org_role_assignments:
+      security_manager:
+        users: [alice, bob]
+    teams:
+      parent:
+        maintainers: [alice, bob]
+        teams:
+          child:
+            members: [alice, bob, carol]
+`,
+		},
+		{
+			name: "repo templates fill in unset settings",
+			config: `
+repo_templates:
+  base:
+    has_wiki: false
+    allow_merge_commit: false
+    default_branch: main
+  library:
+    template: base
+    has_projects: false
+    default_branch: release
+orgs:
+  org:
+    repos:
+      lib:
+        template: library
+        description: a library
+        has_wiki: true
+      other:
+        template: base
+`,
+			expected: `
+repo_templates:
+  base:
+    has_wiki: false
+    allow_merge_commit: false
+    default_branch: main
+  library:
+    template: base
+    has_projects: false
+    default_branch: release
+orgs:
+  org:
+    repos:
+      lib:
+        description: a library
+        has_wiki: true
+        has_projects: false
+        allow_merge_commit: false
+        default_branch: release
+      other:
+        has_wiki: false
+        allow_merge_commit: false
+        default_branch: main
+`,
+		},
+		{
+			name: "unknown member list",
+			config: `
+orgs:
+  org:
+    teams:
+      team:
+        members: [$missing]
+`,
+			expectedError: `org org: team team: members: unknown member list "missing"`,
+		},
+		{
+			name: "member lists that include each other",
+			config: `
+member_lists:
+  a: [$b]
+  b: [alice, $c]
+  c: [$a]
+orgs:
+  org:
+    members: [$a]
+`,
+			expectedError: `org org: members: member list "a" includes itself: a -> b -> c -> a`,
+		},
+		{
+			name: "unknown repo template",
+			config: `
+orgs:
+  org:
+    repos:
+      repo:
+        template: missing
+`,
+			expectedError: `org org: repo repo: unknown repo template "missing"`,
+		},
+		{
+			name: "repo template that applies itself",
+			config: `
+repo_templates:
+  base:
+    template: base
+orgs:
+  org:
+    repos:
+      repo:
+        template: base
+`,
+			expectedError: `org org: repo repo: repo template "base" includes itself: base -> base`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg FullConfig
+			if err := yaml.Unmarshal([]byte(tc.config), &cfg); err != nil {
+				t.Fatalf("failed to unmarshal config: %v", err)
+			}
+			err := cfg.ExpandTemplates()
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var expected FullConfig
+			if err := yaml.Unmarshal([]byte(tc.expected), &expected); err != nil {
+				t.Fatalf("failed to unmarshal expected config: %v", err)
+			}
+			if diff := cmp.Diff(expected, cfg); diff != "" {
+				t.Errorf("expanded config differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"sigs.k8s.io/yaml"
+)
+
+// ApplyOverlays merges the overlays into the base prow config, in order, and
+// returns the result as JSON. Overlays are YAML documents with JSON merge patch
+// (RFC 7386) semantics: maps are merged recursively, any other value including
+// lists replaces the value in the base and null removes a key.
+// This allows to keep a single base config for all environments (e.g. staging
+// and prod) and only describe the differences in an overlay per environment.
+func ApplyOverlays(base []byte, overlays ...[]byte) ([]byte, error) {
+	merged, err := yaml.YAMLToJSON(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert base to JSON: %w", err)
+	}
+	if isEmptyDocument(merged) {
+		merged = []byte("{}")
+	}
+	for i, overlay := range overlays {
+		patch, err := yaml.YAMLToJSON(overlay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert overlay %d to JSON: %w", i, err)
+		}
+		if isEmptyDocument(patch) {
+			// An empty overlay is a no-op rather than a patch removing everything.
+			continue
+		}
+		if merged, err = jsonpatch.MergePatch(merged, patch); err != nil {
+			return nil, fmt.Errorf("failed to apply overlay %d: %w", i, err)
+		}
+	}
+	return merged, nil
+}
+
+func isEmptyDocument(doc []byte) bool {
+	doc = bytes.TrimSpace(doc)
+	return len(doc) == 0 || bytes.Equal(doc, []byte("null"))
+}
+
+// readOverlaid reads the prow config at path and merges the overlay files
+// into it.
+func readOverlaid(path string, overlays []string) ([]byte, error) {
+	merged, err := ReadFileMaybeGZIP(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	for _, overlay := range overlays {
+		patch, err := ReadFileMaybeGZIP(overlay)
+		if err != nil {
+			return nil, fmt.Errorf("error reading overlay %s: %w", overlay, err)
+		}
+		if merged, err = ApplyOverlays(merged, patch); err != nil {
+			return nil, fmt.Errorf("error applying overlay %s: %w", overlay, err)
+		}
+	}
+	return merged, nil
+}
+
+// OverlayDifference is a value that differs between two environments.
+type OverlayDifference struct {
+	// Path is the list of keys leading to the value.
+	Path []string
+	// From is the value in the environment that is promoted from, if FromSet.
+	From    interface{}
+	FromSet bool
+	// To is the value in the environment that is promoted to, if ToSet.
+	To    interface{}
+	ToSet bool
+}
+
+// PathString joins the Path with dots, e.g. plank.default_decoration_configs.
+func (d OverlayDifference) PathString() string {
+	return strings.Join(d.Path, ".")
+}
+
+// DiffOverlays compares the effective configs of two environments sharing the
+// same base config, e.g. staging and prod, and returns the values below the
+// given paths that differ, sorted by path. Paths are keys joined by dots, all
+// differences are returned if no paths are given. Lists are compared as a whole,
+// so selecting a path below a value that differs, e.g. an item of a list, is an
+// error.
+func DiffOverlays(base, from, to []byte, paths ...string) ([]OverlayDifference, error) {
+	fromConfig, err := overlaidTree(base, from)
+	if err != nil {
+		return nil, err
+	}
+	toConfig, err := overlaidTree(base, to)
+	if err != nil {
+		return nil, err
+	}
+	var diffs, selectedDiffs []OverlayDifference
+	diffTrees(nil, fromConfig, toConfig, &diffs)
+	if err := checkSelectors(diffs, paths); err != nil {
+		return nil, err
+	}
+	for _, diff := range diffs {
+		if selected(diff.Path, paths) {
+			selectedDiffs = append(selectedDiffs, diff)
+		}
+	}
+	return selectedDiffs, nil
+}
+
+// PromoteOverlay returns the `to` overlay updated such that the effective
+// config of its environment matches the one of the `from` environment for all
+// values below the given paths. Paths are keys joined by dots, all differences
+// are promoted if no paths are given. The result is YAML, comments and the
+// order of keys in the `to` overlay are not preserved.
+func PromoteOverlay(base, from, to []byte, paths ...string) ([]byte, error) {
+	diffs, err := DiffOverlays(base, from, to, paths...)
+	if err != nil {
+		return nil, err
+	}
+	baseConfig, err := overlaidTree(base)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := overlaidTree(to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse overlay: %w", err)
+	}
+	if overlay == nil {
+		overlay = map[string]interface{}{}
+	}
+	for _, diff := range diffs {
+		switch {
+		case diff.FromSet:
+			setPath(overlay, diff.Path, diff.From)
+		case hasPath(baseConfig, diff.Path):
+			// Null removes the value from the base when merged.
+			setPath(overlay, diff.Path, nil)
+		default:
+			deletePath(overlay, diff.Path)
+		}
+	}
+	raw, err := json.Marshal(overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal overlay: %w", err)
+	}
+	return yaml.JSONToYAML(raw)
+}
+
+func overlaidTree(base []byte, overlays ...[]byte) (map[string]interface{}, error) {
+	merged, err := ApplyOverlays(base, overlays...)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]interface{}
+	if err := json.Unmarshal(merged, &tree); err != nil {
+		return nil, fmt.Errorf("config is not a map: %w", err)
+	}
+	return tree, nil
+}
+
+func diffTrees(path []string, from, to map[string]interface{}, diffs *[]OverlayDifference) {
+	keys := map[string]bool{}
+	for key := range from {
+		keys[key] = true
+	}
+	for key := range to {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		keyPath := append(append([]string{}, path...), key)
+		fromValue, fromSet := from[key]
+		toValue, toSet := to[key]
+		fromMap, fromIsMap := fromValue.(map[string]interface{})
+		toMap, toIsMap := toValue.(map[string]interface{})
+		if fromIsMap && toIsMap {
+			diffTrees(keyPath, fromMap, toMap, diffs)
+			continue
+		}
+		if fromSet == toSet && reflect.DeepEqual(fromValue, toValue) {
+			continue
+		}
+		*diffs = append(*diffs, OverlayDifference{Path: keyPath, From: fromValue, FromSet: fromSet, To: toValue, ToSet: toSet})
+	}
+}
+
+func selected(path []string, selectors []string) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+	for _, selector := range selectors {
+		prefix := strings.Split(selector, ".")
+		if len(prefix) <= len(path) && reflect.DeepEqual(prefix, path[:len(prefix)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSelectors returns an error for selectors below a difference, as they
+// would never be matched.
+func checkSelectors(diffs []OverlayDifference, selectors []string) error {
+	for _, selector := range selectors {
+		path := strings.Split(selector, ".")
+		for _, diff := range diffs {
+			if len(diff.Path) < len(path) && reflect.DeepEqual(diff.Path, path[:len(diff.Path)]) {
+				return fmt.Errorf("path %q is below %q, which is compared as a whole, select %q instead", selector, diff.PathString(), diff.PathString())
+			}
+		}
+	}
+	return nil
+}
+
+func hasPath(tree map[string]interface{}, path []string) bool {
+	for i, key := range path {
+		value, ok := tree[key]
+		if !ok {
+			return false
+		}
+		if i == len(path)-1 {
+			return true
+		}
+		if tree, ok = value.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return false
+}
+
+func setPath(tree map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := tree[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			tree[key] = child
+		}
+		tree = child
+	}
+	tree[path[len(path)-1]] = value
+}
+
+func deletePath(tree map[string]interface{}, path []string) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := tree[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		tree = child
+	}
+	delete(tree, path[len(path)-1])
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+)
+
+const overlayBase = `
+plank:
+  default_decoration_configs:
+    '*':
+      gcs_configuration:
+        bucket: prow-artifacts
+      utility_images:
+        clonerefs: gcr.io/k8s-prow/clonerefs:v1
+        sidecar: gcr.io/k8s-prow/sidecar:v1
+tide:
+  sync_period: 1m
+`
+
+func TestApplyOverlays(t *testing.T) {
+	testCases := []struct {
+		name     string
+		base     string
+		overlays []string
+		expected string
+	}{
+		{
+			name:     "no overlays",
+			base:     "a: 1",
+			expected: `{"a":1}`,
+		},
+		{
+			name:     "maps are merged and null removes keys",
+			base:     "a: {b: 1, c: 2, d: [1, 2]}",
+			overlays: []string{"a: {b: 3, c: null, d: [3]}"},
+			expected: `{"a":{"b":3,"d":[3]}}`,
+		},
+		{
+			name:     "overlays are applied in order",
+			base:     "a: 1",
+			overlays: []string{"a: 2\nb: 2", "a: 3"},
+			expected: `{"a":3,"b":2}`,
+		},
+		{
+			name:     "empty documents are no-ops",
+			base:     "",
+			overlays: []string{"", "a: 1", "# only a comment"},
+			expected: `{"a":1}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var overlays [][]byte
+			for _, overlay := range tc.overlays {
+				overlays = append(overlays, []byte(overlay))
+			}
+			actual, err := ApplyOverlays([]byte(tc.base), overlays...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, string(actual)); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadWithOverlays(t *testing.T) {
+	dir := t.TempDir()
+	prowConfig := filepath.Join(dir, "config.yaml")
+	staging := filepath.Join(dir, "staging.yaml")
+	if err := os.WriteFile(prowConfig, []byte(overlayBase), 0666); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(staging, []byte(`
+plank:
+  default_decoration_configs:
+    '*':
+      utility_images:
+        clonerefs: gcr.io/k8s-prow/clonerefs:canary
+`), 0666); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+
+	cfg, err := LoadWithOverlays(prowConfig, "", []string{staging}, nil, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	dc := cfg.Plank.DefaultDecorationConfigs[0].Config
+	if dc.UtilityImages.CloneRefs != "gcr.io/k8s-prow/clonerefs:canary" {
+		t.Errorf("expected the overlay to set the clonerefs image, got %q", dc.UtilityImages.CloneRefs)
+	}
+	if dc.UtilityImages.Sidecar != "gcr.io/k8s-prow/sidecar:v1" {
+		t.Errorf("expected the sidecar image from the base, got %q", dc.UtilityImages.Sidecar)
+	}
+
+	if err := os.WriteFile(staging, []byte("tide: {unknown_field: true}"), 0666); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+	if _, err := LoadStrictWithOverlays(prowConfig, "", []string{staging}, nil, ""); err == nil {
+		t.Error("expected unknown fields in an overlay to fail strict loading")
+	}
+}
+
+func TestDiffAndPromoteOverlays(t *testing.T) {
+	staging := `
+plank:
+  default_decoration_configs:
+    '*':
+      utility_images:
+        clonerefs: gcr.io/k8s-prow/clonerefs:v2
+        sidecar: gcr.io/k8s-prow/sidecar:v2
+tide:
+  sync_period: null
+  max_goroutines: 5
+`
+	prod := `
+tide:
+  sync_period: 2m
+deck:
+  branding:
+    header_color: red
+`
+	diffs, err := DiffOverlays([]byte(overlayBase), []byte(staging), []byte(prod))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var paths []string
+	for _, diff := range diffs {
+		paths = append(paths, diff.PathString())
+	}
+	expectedPaths := []string{
+		"deck",
+		"plank.default_decoration_configs.*.utility_images.clonerefs",
+		"plank.default_decoration_configs.*.utility_images.sidecar",
+		"tide.max_goroutines",
+		"tide.sync_period",
+	}
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Errorf("unexpected differences (-want +got):\n%s", diff)
+	}
+	diffs, err = DiffOverlays([]byte(overlayBase), []byte(staging), []byte(prod), "tide", "plank.default_decoration_configs.*.utility_images.sidecar")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paths = nil
+	for _, diff := range diffs {
+		paths = append(paths, diff.PathString())
+	}
+	expectedPaths = []string{
+		"plank.default_decoration_configs.*.utility_images.sidecar",
+		"tide.max_goroutines",
+		"tide.sync_period",
+	}
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Errorf("unexpected selected differences (-want +got):\n%s", diff)
+	}
+	// deck is only set in prod, so it is compared as a whole.
+	if _, err := DiffOverlays([]byte(overlayBase), []byte(staging), []byte(prod), "deck.branding.header_color"); err == nil {
+		t.Error("expected an error selecting a path below a difference")
+	}
+	if _, err := DiffOverlays([]byte(overlayBase), []byte(staging), []byte(prod), "tide.sync_period", "sinker.resync_period"); err != nil {
+		t.Errorf("unexpected error selecting paths that don't differ: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		paths    []string
+		expected string
+	}{
+		{
+			name: "promote everything",
+			expected: `
+plank:
+  default_decoration_configs:
+    '*':
+      utility_images:
+        clonerefs: gcr.io/k8s-prow/clonerefs:v2
+        sidecar: gcr.io/k8s-prow/sidecar:v2
+tide:
+  sync_period: null
+  max_goroutines: 5
+`,
+		},
+		{
+			name:  "promote a single path",
+			paths: []string{"plank.default_decoration_configs.*.utility_images.clonerefs"},
+			expected: `
+plank:
+  default_decoration_configs:
+    '*':
+      utility_images:
+        clonerefs: gcr.io/k8s-prow/clonerefs:v2
+tide:
+  sync_period: 2m
+deck:
+  branding:
+    header_color: red
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			promoted, err := PromoteOverlay([]byte(overlayBase), []byte(staging), []byte(prod), tc.paths...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var actual, expected interface{}
+			if err := yaml.Unmarshal(promoted, &actual); err != nil {
+				t.Fatalf("failed to unmarshal promoted overlay: %v", err)
+			}
+			if err := yaml.Unmarshal([]byte(tc.expected), &expected); err != nil {
+				t.Fatalf("failed to unmarshal expected overlay: %v", err)
+			}
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("unexpected overlay (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	promoted, err := PromoteOverlay([]byte(overlayBase), []byte(staging), []byte(prod))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diffs, err := DiffOverlays([]byte(overlayBase), []byte(staging), promoted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(diffs) != 0 {
+		t.Errorf("expected no differences after promoting everything, got %v", diffs)
+	}
+}
@@ -16,7 +16,13 @@ limitations under the License.
 
 package config
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
 
 type Scheduler struct {
 	Enabled bool `json:"enabled,omitempty"`
@@ -24,6 +30,32 @@ type Scheduler struct {
 	// Scheduling strategies
 	Failover *FailoverScheduling `json:"failover,omitempty"`
 	External *ExternalScheduling `json:"external,omitempty"`
+
+	// ClusterSelection configures the build clusters a ProwJob can be
+	// assigned to when its cluster field holds a label selector, such as
+	// `arch=arm64,gpu=true`, rather than the name of a build cluster.
+	ClusterSelection *ClusterSelectionScheduling `json:"cluster_selection,omitempty"`
+}
+
+// Validate validates the scheduler configuration.
+func (s *Scheduler) Validate() error {
+	if s.ClusterSelection == nil {
+		return nil
+	}
+	for name, cluster := range s.ClusterSelection.Clusters {
+		if cluster.Capacity < 0 {
+			return fmt.Errorf("scheduler.cluster_selection: capacity of cluster %s must be a non-negative number", name)
+		}
+		for key, value := range cluster.Labels {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("scheduler.cluster_selection: invalid label key %q of cluster %s: %s", key, name, strings.Join(errs, "; "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return fmt.Errorf("scheduler.cluster_selection: invalid label value %q of cluster %s: %s", value, name, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
 }
 
 // FailoverScheduling is a configuration for the Failover scheduling strategy
@@ -51,3 +83,36 @@ type ExternalScheduling struct {
 	// Cache is the cache configuration for the external scheduling strategy
 	Cache ExternalSchedulingCache `json:"cache,omitempty"`
 }
+
+// ClusterSelectionScheduling is a configuration for selecting a build cluster
+// at runtime, out of the clusters matching the label selector a job uses in
+// place of a cluster name.
+type ClusterSelectionScheduling struct {
+	// Clusters maps the name of a build cluster to its labels and capacity.
+	// Only the clusters listed here are considered by the selection.
+	Clusters map[string]SelectableCluster `json:"clusters,omitempty"`
+}
+
+// SelectableCluster describes a build cluster that can be selected by labels.
+type SelectableCluster struct {
+	// Labels are matched against the label selector of a job.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Capacity is the relative amount of jobs the cluster can run. Among the
+	// matching clusters, the one with the lowest number of running jobs
+	// per capacity unit is selected. Defaults to 1.
+	Capacity int `json:"capacity,omitempty"`
+}
+
+// GetCapacity returns the capacity of the cluster, defaulting to 1.
+func (sc SelectableCluster) GetCapacity() int {
+	if sc.Capacity <= 0 {
+		return 1
+	}
+	return sc.Capacity
+}
+
+// IsClusterSelector tells whether the cluster field of a job holds a label
+// selector rather than the name of a build cluster.
+func IsClusterSelector(cluster string) bool {
+	return strings.ContainsAny(cluster, "=!(")
+}
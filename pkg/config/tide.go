@@ -25,6 +25,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -227,6 +228,11 @@ type Tide struct {
 	// starting a new one requires to start new instances of all tests.
 	// Use '*' as key to set this globally. Defaults to true.
 	PrioritizeExistingBatchesMap map[string]bool `json:"prioritize_existing_batches,omitempty"`
+	// RetestBudgetMap configures on org or org/repo level how many times Tide
+	// re-triggers the tests of a PR after they failed, to stop flaky tests from
+	// causing retest storms. Use '*' as key to set this globally. Defaults to
+	// an unlimited budget.
+	RetestBudgetMap map[string]TideRetestBudget `json:"retest_budget,omitempty"`
 
 	TideGitHubConfig `json:",inline"`
 }
@@ -246,7 +252,9 @@ type TideGitHubConfig struct {
 
 	// A key/value pair of an org/repo as the key and Go template to override
 	// the default merge commit title and/or message. Template is passed the
-	// PullRequest struct (prow/github/types.go#PullRequest)
+	// PullRequest struct (prow/github/types.go#PullRequest), as well as
+	// .Approvers and .ReleaseNote with the logins of the approvers and the
+	// content of the release-note block.
 	MergeTemplate map[string]TideMergeCommitTemplate `json:"merge_commit_template,omitempty"`
 
 	// URL for tide status contexts.
@@ -311,6 +319,59 @@ type TideGitHubConfig struct {
 	// creates. The default is to only mention the one to which we are closest (Calculated
 	// by total number of requirements - fulfilled number of requirements).
 	DisplayAllQueriesInStatus bool `json:"display_all_tide_queries_in_status,omitempty"`
+
+	// Shards explicitly assigns orgs and repos to Tide replicas started with
+	// --shard-name. A repo listed in a shard takes precedence over its org being
+	// listed in another shard. When shards are configured, every org and repo
+	// covered by the queries must be assigned to exactly one shard.
+	Shards []TideShard `json:"shards,omitempty"`
+}
+
+// TideRetestBudget limits how many times Tide re-triggers the tests of a PR.
+type TideRetestBudget struct {
+	// MaxRetests is how many times Tide re-triggers the tests of a PR after
+	// they failed at the same head commit within the window. Once it is
+	// exhausted, Tide stops triggering tests for the PR until the failing
+	// contexts are overridden with /override, the PR gets the label or a new
+	// commit is pushed. -1 means unlimited, e.g. to exempt a repo from a
+	// global budget.
+	MaxRetests int `json:"max_retests"`
+	// Window is how far back failed tests are counted. Defaults to 24h.
+	Window *metav1.Duration `json:"window,omitempty"`
+	// Label lifts the budget of PRs that have it. Defaults to
+	// "tide/ignore-retest-budget".
+	Label string `json:"label,omitempty"`
+}
+
+const (
+	defaultTideRetestBudgetWindow = 24 * time.Hour
+	defaultTideRetestBudgetLabel  = "tide/ignore-retest-budget"
+)
+
+// GetWindow returns the window of the budget, or the default if unset.
+func (b TideRetestBudget) GetWindow() time.Duration {
+	if b.Window == nil {
+		return defaultTideRetestBudgetWindow
+	}
+	return b.Window.Duration
+}
+
+// GetLabel returns the label that lifts the budget, or the default if unset.
+func (b TideRetestBudget) GetLabel() string {
+	if b.Label == "" {
+		return defaultTideRetestBudgetLabel
+	}
+	return b.Label
+}
+
+// TideShard is a named, disjoint subset of the orgs and repos Tide merges.
+type TideShard struct {
+	// Name identifies the shard. Tide replicas select it with --shard-name.
+	Name string `json:"name"`
+	// Orgs owned by this shard, except for repos explicitly listed in another shard.
+	Orgs []string `json:"orgs,omitempty"`
+	// Repos owned by this shard, in org/repo format.
+	Repos []string `json:"repos,omitempty"`
 }
 
 // TideGerritConfig contains all Gerrit related configurations for tide.
@@ -360,6 +421,33 @@ func (t *Tide) PrioritizeExistingBatches(repo OrgRepo) bool {
 	return true
 }
 
+// RetestBudget returns the retest budget of a repo, if it is limited.
+func (t *Tide) RetestBudget(repo OrgRepo) (TideRetestBudget, bool) {
+	budget, set := t.RetestBudgetMap[repo.String()]
+	if !set {
+		budget, set = t.RetestBudgetMap[repo.Org]
+	}
+	if !set {
+		budget, set = t.RetestBudgetMap["*"]
+	}
+	if !set || budget.MaxRetests < 0 {
+		return TideRetestBudget{}, false
+	}
+	return budget, true
+}
+
+func (t *Tide) validateRetestBudgets() error {
+	for orgRepo, budget := range t.RetestBudgetMap {
+		if budget.MaxRetests < -1 {
+			return fmt.Errorf("tide retest_budget for %q: max_retests must be -1 (unlimited) or more, got %d", orgRepo, budget.MaxRetests)
+		}
+		if budget.Window != nil && budget.Window.Duration <= 0 {
+			return fmt.Errorf("tide retest_budget for %q: window must be positive, got %s", orgRepo, budget.Window.Duration)
+		}
+	}
+	return nil
+}
+
 func (t *Tide) BatchSizeLimit(repo OrgRepo) int {
 	if limit, ok := t.BatchSizeLimitMap[repo.String()]; ok {
 		return limit
@@ -514,6 +602,19 @@ type TideQuery struct {
 
 	ReviewApprovedRequired bool `json:"reviewApprovedRequired,omitempty"`
 
+	// RequireResolvedConversations excludes PRs with unresolved review
+	// threads from the pool, like the branch protection setting of the
+	// same name. GitHub search can't express it, so Tide checks the PRs
+	// it found.
+	RequireResolvedConversations bool `json:"requireResolvedConversations,omitempty"`
+
+	// RequiredDeploymentEnvironment excludes PRs from the pool until the
+	// most recent GitHub deployment of their head commit to this
+	// environment (e.g. staging) succeeded. Tide polls the Deployments API
+	// for every PR matched by the query, so only set it on queries for
+	// repos that gate merges on progressive delivery.
+	RequiredDeploymentEnvironment string `json:"requiredDeploymentEnvironment,omitempty"`
+
 	Orgs          []string `json:"orgs,omitempty"`
 	Repos         []string `json:"repos,omitempty"`
 	ExcludedRepos []string `json:"excludedRepos,omitempty"`
@@ -542,6 +643,9 @@ type tideQueryConfig struct {
 	Milestone              string
 	ReviewApprovedRequired bool
 	TenantIDs              []string
+
+	RequireResolvedConversations  bool
+	RequiredDeploymentEnvironment string
 }
 
 type tideQueryTarget struct {
@@ -696,6 +800,81 @@ func (tqs TideQueries) OrgExceptionsAndRepos() (map[string]sets.Set[string], set
 	return orgs, repos
 }
 
+// Shard returns the shard with the given name.
+func (t *Tide) Shard(name string) (TideShard, bool) {
+	for _, shard := range t.Shards {
+		if shard.Name == name {
+			return shard, true
+		}
+	}
+	return TideShard{}, false
+}
+
+// ShardFor returns the name of the shard that owns the repo, or the empty
+// string if none does.
+func (t *Tide) ShardFor(repo OrgRepo) string {
+	orgOwner := ""
+	for _, shard := range t.Shards {
+		for _, r := range shard.Repos {
+			if r == repo.String() {
+				return shard.Name
+			}
+		}
+		for _, org := range shard.Orgs {
+			if org == repo.Org {
+				orgOwner = shard.Name
+			}
+		}
+	}
+	return orgOwner
+}
+
+func (t *Tide) validateShards() error {
+	if len(t.Shards) == 0 {
+		return nil
+	}
+	names := sets.New[string]()
+	orgs := map[string]string{}
+	repos := map[string]string{}
+	for _, shard := range t.Shards {
+		if shard.Name == "" {
+			return errors.New("tide shards must have a name")
+		}
+		if names.Has(shard.Name) {
+			return fmt.Errorf("tide shard %q is defined more than once", shard.Name)
+		}
+		names.Insert(shard.Name)
+		for _, org := range shard.Orgs {
+			if other, ok := orgs[org]; ok {
+				return fmt.Errorf("org %q is assigned to both tide shards %q and %q", org, other, shard.Name)
+			}
+			orgs[org] = shard.Name
+		}
+		for _, repo := range shard.Repos {
+			if _, _, ok := splitOrgRepoString(repo); !ok {
+				return fmt.Errorf("tide shard %q has invalid repo %q, must be of the form org/repo", shard.Name, repo)
+			}
+			if other, ok := repos[repo]; ok {
+				return fmt.Errorf("repo %q is assigned to both tide shards %q and %q", repo, other, shard.Name)
+			}
+			repos[repo] = shard.Name
+		}
+	}
+	for _, query := range t.Queries {
+		for _, org := range query.Orgs {
+			if _, ok := orgs[org]; !ok {
+				return fmt.Errorf("org %q is used in a tide query but not assigned to any tide shard", org)
+			}
+		}
+		for _, repo := range query.Repos {
+			if _, _, ok := splitOrgRepoString(repo); ok && t.ShardFor(*NewOrgRepo(repo)) == "" {
+				return fmt.Errorf("repo %q is used in a tide query but not assigned to any tide shard", repo)
+			}
+		}
+	}
+	return nil
+}
+
 // QueryMap is a struct mapping from "org/repo" -> TideQueries that
 // apply to that org or repo. It is lazily populated, but threadsafe.
 type QueryMap struct {
@@ -22,8 +22,10 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/diff"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
@@ -1881,6 +1883,173 @@ func TestTideQuery_Validate(t *testing.T) {
 	}
 }
 
+func TestTide_validateShards(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tide        Tide
+		expectError bool
+	}{
+		{
+			name: "no shards",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{Queries: TideQueries{{Orgs: []string{"org"}}}}},
+		},
+		{
+			name: "all queried orgs and repos are assigned",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Queries: TideQueries{{Orgs: []string{"org"}, Repos: []string{"other/repo", "org/special"}}},
+				Shards: []TideShard{
+					{Name: "a", Orgs: []string{"org"}},
+					{Name: "b", Repos: []string{"other/repo", "org/special"}},
+				},
+			}},
+		},
+		{
+			name: "unnamed shard",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Shards: []TideShard{{Orgs: []string{"org"}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "duplicate shard name",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Shards: []TideShard{{Name: "a", Orgs: []string{"org"}}, {Name: "a", Orgs: []string{"other"}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "org assigned twice",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Shards: []TideShard{{Name: "a", Orgs: []string{"org"}}, {Name: "b", Orgs: []string{"org"}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "repo assigned twice",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Shards: []TideShard{{Name: "a", Repos: []string{"org/repo"}}, {Name: "b", Repos: []string{"org/repo"}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "invalid repo",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Shards: []TideShard{{Name: "a", Repos: []string{"repo"}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "queried org is not assigned",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Queries: TideQueries{{Orgs: []string{"org", "other"}}},
+				Shards:  []TideShard{{Name: "a", Orgs: []string{"org"}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "queried repo is not assigned",
+			tide: Tide{TideGitHubConfig: TideGitHubConfig{
+				Queries: TideQueries{{Repos: []string{"other/repo"}}},
+				Shards:  []TideShard{{Name: "a", Orgs: []string{"org"}}},
+			}},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tide.validateShards()
+			if err != nil && !tc.expectError {
+				t.Errorf("Unexpected error: %v.", err)
+			} else if err == nil && tc.expectError {
+				t.Error("Expected a validation error, but didn't get one.")
+			}
+		})
+	}
+}
+
+func TestTide_ShardFor(t *testing.T) {
+	tide := Tide{TideGitHubConfig: TideGitHubConfig{Shards: []TideShard{
+		{Name: "a", Orgs: []string{"org"}},
+		{Name: "b", Repos: []string{"org/special"}},
+	}}}
+	testCases := []struct {
+		repo     OrgRepo
+		expected string
+	}{
+		{repo: OrgRepo{Org: "org", Repo: "repo"}, expected: "a"},
+		{repo: OrgRepo{Org: "org", Repo: "special"}, expected: "b"},
+		{repo: OrgRepo{Org: "other", Repo: "repo"}, expected: ""},
+	}
+	for _, tc := range testCases {
+		if actual := tide.ShardFor(tc.repo); actual != tc.expected {
+			t.Errorf("%s: expected shard %q, got %q", tc.repo, tc.expected, actual)
+		}
+	}
+}
+
+func TestTide_RetestBudget(t *testing.T) {
+	tide := Tide{RetestBudgetMap: map[string]TideRetestBudget{
+		"*":            {MaxRetests: 3},
+		"org":          {MaxRetests: 2},
+		"org/special":  {MaxRetests: 1},
+		"org/exempted": {MaxRetests: -1},
+	}}
+	testCases := []struct {
+		repo            OrgRepo
+		expectedRetests int
+		expectedLimited bool
+	}{
+		{repo: OrgRepo{Org: "org", Repo: "repo"}, expectedRetests: 2, expectedLimited: true},
+		{repo: OrgRepo{Org: "org", Repo: "special"}, expectedRetests: 1, expectedLimited: true},
+		{repo: OrgRepo{Org: "org", Repo: "exempted"}},
+		{repo: OrgRepo{Org: "other", Repo: "repo"}, expectedRetests: 3, expectedLimited: true},
+	}
+	for _, tc := range testCases {
+		budget, limited := tide.RetestBudget(tc.repo)
+		if budget.MaxRetests != tc.expectedRetests || limited != tc.expectedLimited {
+			t.Errorf("%s: expected %d retests and limited %t, got %d retests and limited %t", tc.repo, tc.expectedRetests, tc.expectedLimited, budget.MaxRetests, limited)
+		}
+	}
+}
+
+func TestTide_validateRetestBudgets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		budget      TideRetestBudget
+		expectError bool
+	}{
+		{
+			name:   "valid budget",
+			budget: TideRetestBudget{MaxRetests: 3, Window: &metav1.Duration{Duration: time.Hour}},
+		},
+		{
+			name:   "unlimited budget",
+			budget: TideRetestBudget{MaxRetests: -1},
+		},
+		{
+			name:        "negative max_retests",
+			budget:      TideRetestBudget{MaxRetests: -2},
+			expectError: true,
+		},
+		{
+			name:        "zero window",
+			budget:      TideRetestBudget{MaxRetests: 3, Window: &metav1.Duration{}},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tide := Tide{RetestBudgetMap: map[string]TideRetestBudget{"*": tc.budget}}
+			err := tide.validateRetestBudgets()
+			if err != nil && !tc.expectError {
+				t.Errorf("Unexpected error: %v.", err)
+			} else if err == nil && tc.expectError {
+				t.Error("Expected a validation error, but didn't get one.")
+			}
+		})
+	}
+}
+
 func TestTideContextPolicy_Validate(t *testing.T) {
 	testCases := []struct {
 		name   string
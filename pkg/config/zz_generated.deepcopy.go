@@ -125,6 +125,16 @@ func (in *JobBase) DeepCopyInto(out *JobBase) {
 		*out = new(prowjobsv1.ProwJobDefault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(prowjobsv1.RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	in.UtilityConfig.DeepCopyInto(&out.UtilityConfig)
 	return
 }
@@ -266,6 +276,11 @@ func (in *ProwYAML) DeepCopyInto(out *ProwYAML) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]InRepoConfigInclude, len(*in))
+		copy(*out, *in)
+	}
 	if in.ProwIgnored != nil {
 		in, out := &in.ProwIgnored, &out.ProwIgnored
 		*out = new(json.RawMessage)
@@ -20,10 +20,15 @@ package crier
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,9 +37,50 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/crier/reporters/criercommonlib"
+	"sigs.k8s.io/prow/pkg/kube"
 )
 
+// maxReportErrorLength is the maximum length of the error recorded on
+// ProwJobs whose report was given up.
+const maxReportErrorLength = 1024
+
+// RetryOptions configures how failed reports are retried.
+type RetryOptions struct {
+	// BaseDelay is the delay before the first retry of a failed report. It is
+	// doubled with every failed attempt until it reaches MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Budget is the number of attempts to report a state of a ProwJob before
+	// the report is given up and recorded on the ProwJob. 0 means reports are
+	// retried forever.
+	Budget int
+}
+
+// DefaultRetryOptions returns the options used if none are configured.
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		BaseDelay: time.Second,
+		MaxDelay:  10 * time.Minute,
+	}
+}
+
+// rateLimiter backs off exponentially per ProwJob, bounded by an overall
+// limit for all of them.
+func (o RetryOptions) rateLimiter() workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(o.BaseDelay, o.MaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
+// reportFailures counts the failed attempts to report the state of a ProwJob.
+type reportFailures struct {
+	state    prowv1.ProwJobState
+	attempts int
+}
+
 type ReportClient interface {
 	// Report reports a Prowjob. The provided logger is already populated with the
 	// prowjob name and the reporter name.
@@ -53,6 +99,10 @@ type reconciler struct {
 	pjclientset       ctrlruntimeclient.Client
 	reporter          ReportClient
 	enablementChecker func(org, repo string) bool
+	retryBudget       int
+
+	failuresLock sync.Mutex
+	failures     map[types.NamespacedName]*reportFailures
 }
 
 // New constructs a new instance of the crier reconciler.
@@ -61,6 +111,7 @@ func New(
 	reporter ReportClient,
 	numWorkers int,
 	enablementChecker func(org, repo string) bool,
+	retryOptions RetryOptions,
 ) error {
 	if err := builder.
 		ControllerManagedBy(mgr).
@@ -68,11 +119,12 @@ func New(
 		Named(fmt.Sprintf("crier_%s", reporter.GetName())).
 		For(&prowv1.ProwJob{}).
 		WithOptions(controller.Options{MaxConcurrentReconciles: numWorkers,
-			RateLimiter: workqueue.DefaultControllerRateLimiter()}).
+			RateLimiter: retryOptions.rateLimiter()}).
 		Complete(&reconciler{
 			pjclientset:       mgr.GetClient(),
 			reporter:          reporter,
 			enablementChecker: enablementChecker,
+			retryBudget:       retryOptions.Budget,
 		}); err != nil {
 		return fmt.Errorf("failed to construct controller: %w", err)
 	}
@@ -110,6 +162,7 @@ func (r *reconciler) reconcile(ctx context.Context, log *logrus.Entry, req recon
 	if err := r.pjclientset.Get(ctx, req.NamespacedName, &pj); err != nil {
 		if errors.IsNotFound(err) {
 			log.Debug("object no longer exist")
+			r.resetFailures(req.NamespacedName)
 			return nil, nil
 		}
 
@@ -120,7 +173,11 @@ func (r *reconciler) reconcile(ctx context.Context, log *logrus.Entry, req recon
 		return nil, nil
 	}
 
-	log = log.WithField("jobName", pj.Spec.Job)
+	tenantID := config.DefaultTenantID
+	if pj.Spec.ProwJobDefault != nil && pj.Spec.ProwJobDefault.TenantID != "" {
+		tenantID = pj.Spec.ProwJobDefault.TenantID
+	}
+	log = log.WithField("jobName", pj.Spec.Job).WithField("tenant", tenantID)
 
 	if !r.reporter.ShouldReport(ctx, log, &pj) {
 		return nil, nil
@@ -137,6 +194,12 @@ func (r *reconciler) reconcile(ctx context.Context, log *logrus.Entry, req recon
 		return nil, nil
 	}
 
+	// already gave up reporting current state
+	if strings.HasPrefix(pj.Annotations[kube.ReportFailedAnnotationPrefix+r.reporter.GetName()], string(pj.Status.State)+":") {
+		log.Trace("Already gave up reporting")
+		return nil, nil
+	}
+
 	log = log.WithField("jobStatus", pj.Status.State)
 	log.Info("Will report state")
 	pjs, requeue, err := r.reporter.Report(ctx, log, &pj)
@@ -146,14 +209,20 @@ func (r *reconciler) reconcile(ctx context.Context, log *logrus.Entry, req recon
 		} else {
 			log.WithError(err).Error("Failed to report job.")
 		}
-		crierMetrics.reportingResults.WithLabelValues(r.reporter.GetName(), ResultError).Inc()
+		crierMetrics.reportingResults.WithLabelValues(r.reporter.GetName(), ResultError, tenantID).Inc()
+		if r.exhaustedRetryBudget(req.NamespacedName, pj.Status.State) {
+			log.WithError(err).Error("Giving up reporting job after exhausting the retry budget.")
+			crierMetrics.deadLetters.WithLabelValues(r.reporter.GetName()).Inc()
+			return nil, r.recordDeadLetter(ctx, &pj, err)
+		}
 		return nil, fmt.Errorf("failed to report job: %w", err)
 	}
+	r.resetFailures(req.NamespacedName)
 	if requeue != nil {
 		return requeue, nil
 	}
 
-	crierMetrics.reportingResults.WithLabelValues(r.reporter.GetName(), ResultSuccess).Inc()
+	crierMetrics.reportingResults.WithLabelValues(r.reporter.GetName(), ResultSuccess, tenantID).Inc()
 	log.WithField("job-count", len(pjs)).Info("Reported job(s), now will update pj(s).")
 	var lastErr error
 	for _, pjob := range pjs {
@@ -175,6 +244,61 @@ func (r *reconciler) reconcile(ctx context.Context, log *logrus.Entry, req recon
 	return nil, lastErr
 }
 
+// exhaustedRetryBudget records a failed attempt to report the state of the
+// ProwJob and returns whether the retry budget is exhausted.
+func (r *reconciler) exhaustedRetryBudget(name types.NamespacedName, state prowv1.ProwJobState) bool {
+	if r.retryBudget <= 0 {
+		return false
+	}
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+	if r.failures == nil {
+		r.failures = map[types.NamespacedName]*reportFailures{}
+	}
+	failures, ok := r.failures[name]
+	if !ok || failures.state != state {
+		failures = &reportFailures{state: state}
+		r.failures[name] = failures
+	}
+	failures.attempts++
+	if failures.attempts < r.retryBudget {
+		return false
+	}
+	delete(r.failures, name)
+	return true
+}
+
+func (r *reconciler) resetFailures(name types.NamespacedName) {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+	delete(r.failures, name)
+}
+
+// recordDeadLetter records on the ProwJob that reporting its current state was
+// given up, so that it is neither retried nor reported again.
+func (r *reconciler) recordDeadLetter(ctx context.Context, pj *prowv1.ProwJob, reportErr error) error {
+	state := pj.Status.State
+	message := reportErr.Error()
+	if len(message) > maxReportErrorLength {
+		message = message[:maxReportErrorLength]
+	}
+	name := types.NamespacedName{Namespace: pj.Namespace, Name: pj.Name}
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := r.pjclientset.Get(ctx, name, pj); err != nil {
+			return err
+		}
+		newpj := pj.DeepCopy()
+		if newpj.Annotations == nil {
+			newpj.Annotations = map[string]string{}
+		}
+		newpj.Annotations[kube.ReportFailedAnnotationPrefix+r.reporter.GetName()] = fmt.Sprintf("%s: %s", state, message)
+		return r.pjclientset.Patch(ctx, newpj, ctrlruntimeclient.MergeFromWithOptions(pj, ctrlruntimeclient.MergeFromWithOptimisticLock{}))
+	}); err != nil {
+		return fmt.Errorf("failed to record failed report on prowjob: %w", err)
+	}
+	return nil
+}
+
 func (r *reconciler) shouldHandle(pj *prowv1.ProwJob) bool {
 	refs := pj.Spec.ExtraRefs
 	if pj.Spec.Refs != nil {
@@ -328,3 +328,82 @@ func (c *patchTrackingClient) Patch(ctx context.Context, obj ctrlruntimeclient.O
 	c.patches++
 	return c.Client.Patch(ctx, obj, patch, opts...)
 }
+
+func TestReconcileRetryBudget(t *testing.T) {
+	const toReconcile = "foo"
+	rp := fakeReporter{
+		shouldReportFunc: func(*prowv1.ProwJob) bool { return true },
+		err:              errors.New("token revoked"),
+	}
+	pj := &prowv1.ProwJob{
+		ObjectMeta: v1.ObjectMeta{Name: toReconcile},
+		Spec:       prowv1.ProwJobSpec{Job: "foo", Report: true},
+		Status:     prowv1.ProwJobStatus{State: prowv1.PendingState},
+	}
+	cs := &patchTrackingClient{Client: fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(pj).Build()}
+	r := &reconciler{
+		pjclientset: cs,
+		reporter:    &rp,
+		retryBudget: 3,
+	}
+	req := ctrlruntime.Request{NamespacedName: types.NamespacedName{Name: toReconcile}}
+
+	reconcile := func(expectErr bool) {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); (err != nil) != expectErr {
+			t.Fatalf("expected error: %t, got %v", expectErr, err)
+		}
+	}
+
+	reconcile(true)
+	reconcile(true)
+	// The state changed, so the budget starts over.
+	pj.Status.State = prowv1.SuccessState
+	if err := cs.Update(context.Background(), pj); err != nil {
+		t.Fatalf("failed to update prowjob: %v", err)
+	}
+	reconcile(true)
+	reconcile(true)
+	if cs.patches != 0 {
+		t.Fatalf("expected no patch before the budget is exhausted, got %d", cs.patches)
+	}
+	reconcile(false)
+	if cs.patches != 1 {
+		t.Fatalf("expected the failed report to be recorded, got %d patches", cs.patches)
+	}
+
+	var got prowv1.ProwJob
+	if err := cs.Get(context.Background(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("failed to get prowjob: %v", err)
+	}
+	expected := "success: token revoked"
+	if diff := cmp.Diff(expected, got.Annotations["crier.prow.k8s.io/report-failed-"+reporterName]); diff != "" {
+		t.Errorf("unexpected annotation (-want +got):\n%s", diff)
+	}
+
+	// The job isn't reported again once the report was given up.
+	reconcile(false)
+	if len(rp.reported) != 5 {
+		t.Errorf("expected 5 attempts to report, got %d", len(rp.reported))
+	}
+
+	// The failures of deleted jobs are forgotten.
+	if err := cs.Get(context.Background(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("failed to get prowjob: %v", err)
+	}
+	got.Status.State = prowv1.FailureState
+	if err := cs.Update(context.Background(), &got); err != nil {
+		t.Fatalf("failed to update prowjob: %v", err)
+	}
+	reconcile(true)
+	if len(r.failures) != 1 {
+		t.Fatalf("expected the failed report to be counted, got %d counted jobs", len(r.failures))
+	}
+	if err := cs.Delete(context.Background(), &got); err != nil {
+		t.Fatalf("failed to delete prowjob: %v", err)
+	}
+	reconcile(false)
+	if len(r.failures) != 0 {
+		t.Errorf("expected the failures of the deleted job to be forgotten, got %d counted jobs", len(r.failures))
+	}
+}
@@ -30,6 +30,8 @@ var (
 		latency *prometheus.HistogramVec
 		// Count success/failures of reporting attempts.
 		reportingResults *prometheus.CounterVec
+		// Count reports that were given up after exhausting the retry budget.
+		deadLetters *prometheus.CounterVec
 	}{
 		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Name:    "crier_report_latency",
@@ -40,10 +42,17 @@ var (
 		}),
 		reportingResults: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Name: "crier_reporting_results",
-			Help: "Count of successful and failed reporting attempts by reporter.",
+			Help: "Count of successful and failed reporting attempts by reporter and tenant.",
 		}, []string{
 			"reporter",
 			"result",
+			"tenant",
+		}),
+		deadLetters: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "crier_dead_letter_reports_total",
+			Help: "Count of reports that were given up after exhausting the retry budget by reporter.",
+		}, []string{
+			"reporter",
 		}),
 	}
 )
@@ -51,4 +60,5 @@ var (
 func init() {
 	prometheus.MustRegister(crierMetrics.latency)
 	prometheus.MustRegister(crierMetrics.reportingResults)
+	prometheus.MustRegister(crierMetrics.deadLetters)
 }
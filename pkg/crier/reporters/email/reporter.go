@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package email implements a reporter that notifies the recipients configured
+// in the reporter_config of jobs about their failed runs by email.
+package email
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+const reporterName = "emailreporter"
+
+// Sender sends emails.
+type Sender interface {
+	Send(to []string, subject, body string) error
+}
+
+// SMTPSender sends emails through an SMTP server.
+type SMTPSender struct {
+	// Server is the host:port of the SMTP server.
+	Server string
+	From   string
+	// Username and Password are used to authenticate if Username is set.
+	Username string
+	Password func() []byte
+}
+
+// Send sends a plain text email.
+func (s *SMTPSender) Send(to []string, subject, body string) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Server)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP server %q: %w", s.Server, err)
+		}
+		auth = smtp.PlainAuth("", s.Username, string(s.Password()), host)
+	}
+	return smtp.SendMail(s.Server, auth, s.From, to, message(s.From, to, subject, body))
+}
+
+func message(from string, to []string, subject, body string) []byte {
+	// The subject contains the names of jobs, which must not be able to add headers.
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+	headers := []string{
+		"From: " + from,
+		"To: " + strings.Join(to, ", "),
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	return []byte(strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(body, "\n", "\r\n"))
+}
+
+// digest collects the notifications for a set of recipients.
+type digest struct {
+	recipients []string
+	entries    []string
+}
+
+type emailReporter struct {
+	sender Sender
+	lister ctrlruntimeclient.Reader
+	dryRun bool
+
+	digestsLock sync.Mutex
+	// digests are keyed by the sorted recipients.
+	digests map[string]*digest
+}
+
+// New returns a reporter that sends emails with sender. lister is used to
+// find the previous runs of jobs.
+func New(sender Sender, lister ctrlruntimeclient.Reader, dryRun bool) *emailReporter {
+	return &emailReporter{
+		sender:  sender,
+		lister:  lister,
+		dryRun:  dryRun,
+		digests: map[string]*digest{},
+	}
+}
+
+func (r *emailReporter) GetName() string {
+	return reporterName
+}
+
+func emailConfig(pj *prowapi.ProwJob) *prowapi.EmailReporterConfig {
+	if pj.Spec.ReporterConfig == nil {
+		return nil
+	}
+	return pj.Spec.ReporterConfig.Email
+}
+
+func failed(state prowapi.ProwJobState) bool {
+	return state == prowapi.FailureState || state == prowapi.ErrorState
+}
+
+// ShouldReport returns whether the job has recipients and failed.
+func (r *emailReporter) ShouldReport(_ context.Context, _ *logrus.Entry, pj *prowapi.ProwJob) bool {
+	cfg := emailConfig(pj)
+	return cfg != nil && len(cfg.Recipients) > 0 && failed(pj.Status.State)
+}
+
+// Report notifies the recipients if the job failed at least as many times in
+// a row as its failure threshold. In digest mode the notification is sent with
+// the next digest.
+func (r *emailReporter) Report(ctx context.Context, log *logrus.Entry, pj *prowapi.ProwJob) ([]*prowapi.ProwJob, *reconcile.Result, error) {
+	cfg := emailConfig(pj)
+	threshold := cfg.FailureThreshold
+	if threshold < 1 {
+		threshold = 1
+	}
+	failures, err := r.consecutiveFailures(ctx, pj)
+	if err != nil {
+		return nil, nil, err
+	}
+	log = log.WithField("consecutive-failures", failures)
+	if failures < threshold {
+		log.Debug("Failure threshold not reached, not sending email.")
+		return []*prowapi.ProwJob{pj}, nil, nil
+	}
+
+	entry := entry(pj, failures)
+	if cfg.Digest {
+		r.addToDigest(cfg.Recipients, entry)
+		log.Debug("Added failure to digest.")
+		return []*prowapi.ProwJob{pj}, nil, nil
+	}
+	subject := fmt.Sprintf("[prow] %s %s", pj.Spec.Job, pj.Status.State)
+	if err := r.send(log, cfg.Recipients, subject, entry); err != nil {
+		return nil, nil, err
+	}
+	return []*prowapi.ProwJob{pj}, nil, nil
+}
+
+func entry(pj *prowapi.ProwJob, failures int) string {
+	lines := []string{
+		fmt.Sprintf("Job %s ended with state %s, it failed %d time(s) in a row.", pj.Spec.Job, pj.Status.State, failures),
+	}
+	if pj.Status.Description != "" {
+		lines = append(lines, "Description: "+pj.Status.Description)
+	}
+	if pj.Status.URL != "" {
+		lines = append(lines, "Logs: "+pj.Status.URL)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (r *emailReporter) send(log *logrus.Entry, recipients []string, subject, body string) error {
+	if r.dryRun {
+		log.WithField("recipients", recipients).WithField("subject", subject).Debug("Skipping sending email because dry-run is enabled")
+		return nil
+	}
+	if err := r.sender.Send(recipients, subject, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+func (r *emailReporter) addToDigest(recipients []string, entry string) {
+	sorted := append([]string{}, recipients...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, ",")
+
+	r.digestsLock.Lock()
+	defer r.digestsLock.Unlock()
+	d, ok := r.digests[key]
+	if !ok {
+		d = &digest{recipients: sorted}
+		r.digests[key] = d
+	}
+	d.entries = append(d.entries, entry)
+}
+
+// FlushDigests sends the collected digests. Digests that fail to be sent are
+// dropped to not grow without bound while the SMTP server is unavailable.
+func (r *emailReporter) FlushDigests() error {
+	r.digestsLock.Lock()
+	digests := r.digests
+	r.digests = map[string]*digest{}
+	r.digestsLock.Unlock()
+
+	log := logrus.WithField("reporter", reporterName)
+	var errs []error
+	for _, d := range digests {
+		subject := fmt.Sprintf("[prow] Digest of %d failed job run(s)", len(d.entries))
+		if err := r.send(log, d.recipients, subject, strings.Join(d.entries, "\n")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// consecutiveFailures returns how many runs of the job in a row failed,
+// counting back from pj. Aborted runs are ignored.
+func (r *emailReporter) consecutiveFailures(ctx context.Context, pj *prowapi.ProwJob) (int, error) {
+	jobLabel, ok := pj.Labels[kube.ProwJobAnnotation]
+	if !ok {
+		return 1, nil
+	}
+	var pjs prowapi.ProwJobList
+	if err := r.lister.List(ctx, &pjs, ctrlruntimeclient.InNamespace(pj.Namespace), ctrlruntimeclient.MatchingLabels{kube.ProwJobAnnotation: jobLabel}); err != nil {
+		return 0, fmt.Errorf("failed to list previous runs of %s: %w", pj.Spec.Job, err)
+	}
+	var runs []prowapi.ProwJob
+	for _, run := range pjs.Items {
+		if run.Spec.Job != pj.Spec.Job || run.Spec.Type != pj.Spec.Type || run.Name == pj.Name || !run.Complete() {
+			continue
+		}
+		if run.Status.StartTime.After(pj.Status.StartTime.Time) {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Status.StartTime.After(runs[j].Status.StartTime.Time) })
+
+	failures := 1
+	for _, run := range runs {
+		if run.Status.State == prowapi.AbortedState {
+			continue
+		}
+		if !failed(run.Status.State) {
+			break
+		}
+		failures++
+	}
+	return failures, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+type email struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+type fakeSender struct {
+	sent []email
+}
+
+func (f *fakeSender) Send(to []string, subject, body string) error {
+	f.sent = append(f.sent, email{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+var now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func run(name string, minutesAgo int, state prowapi.ProwJobState, cfg *prowapi.EmailReporterConfig) *prowapi.ProwJob {
+	return &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "prowjobs",
+			Labels:    map[string]string{kube.ProwJobAnnotation: "periodic-e2e"},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Type:           prowapi.PeriodicJob,
+			Job:            "periodic-e2e",
+			Report:         true,
+			ReporterConfig: &prowapi.ReporterConfig{Email: cfg},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:          state,
+			StartTime:      metav1.NewTime(now.Add(-time.Duration(minutesAgo) * time.Minute)),
+			CompletionTime: &metav1.Time{Time: now.Add(-time.Duration(minutesAgo-1) * time.Minute)},
+			URL:            "https://prow.example.com/view/" + name,
+		},
+	}
+}
+
+func TestShouldReport(t *testing.T) {
+	cfg := &prowapi.EmailReporterConfig{Recipients: []string{"team@example.com"}}
+	testCases := []struct {
+		name     string
+		pj       *prowapi.ProwJob
+		expected bool
+	}{
+		{
+			name:     "failed job with recipients is reported",
+			pj:       run("a", 1, prowapi.FailureState, cfg),
+			expected: true,
+		},
+		{
+			name:     "errored job with recipients is reported",
+			pj:       run("a", 1, prowapi.ErrorState, cfg),
+			expected: true,
+		},
+		{
+			name: "successful job is not reported",
+			pj:   run("a", 1, prowapi.SuccessState, cfg),
+		},
+		{
+			name: "job without recipients is not reported",
+			pj:   run("a", 1, prowapi.FailureState, &prowapi.EmailReporterConfig{}),
+		},
+		{
+			name: "job without email config is not reported",
+			pj:   run("a", 1, prowapi.FailureState, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(&fakeSender{}, nil, false)
+			if got := r.ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), tc.pj); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	recipients := []string{"team@example.com"}
+	threshold := &prowapi.EmailReporterConfig{Recipients: recipients, FailureThreshold: 3}
+
+	testCases := []struct {
+		name          string
+		pj            *prowapi.ProwJob
+		previous      []*prowapi.ProwJob
+		expected      []email
+		expectedFlush []email
+	}{
+		{
+			name: "every failure is sent by default",
+			pj:   run("current", 1, prowapi.FailureState, &prowapi.EmailReporterConfig{Recipients: recipients}),
+			expected: []email{{
+				To:      recipients,
+				Subject: "[prow] periodic-e2e failure",
+				Body:    "Job periodic-e2e ended with state failure, it failed 1 time(s) in a row.\nLogs: https://prow.example.com/view/current\n",
+			}},
+		},
+		{
+			name: "threshold not reached",
+			pj:   run("current", 1, prowapi.FailureState, threshold),
+			previous: []*prowapi.ProwJob{
+				run("previous", 10, prowapi.ErrorState, threshold),
+				run("passed", 20, prowapi.SuccessState, threshold),
+				run("older", 30, prowapi.FailureState, threshold),
+			},
+		},
+		{
+			name: "threshold reached, aborted and newer runs are ignored",
+			pj:   run("current", 10, prowapi.FailureState, threshold),
+			previous: []*prowapi.ProwJob{
+				run("newer", 1, prowapi.FailureState, threshold),
+				run("previous", 20, prowapi.ErrorState, threshold),
+				run("aborted", 30, prowapi.AbortedState, threshold),
+				run("older", 40, prowapi.FailureState, threshold),
+				run("passed", 50, prowapi.SuccessState, threshold),
+			},
+			expected: []email{{
+				To:      recipients,
+				Subject: "[prow] periodic-e2e failure",
+				Body:    "Job periodic-e2e ended with state failure, it failed 3 time(s) in a row.\nLogs: https://prow.example.com/view/current\n",
+			}},
+		},
+		{
+			name: "digest is sent on flush",
+			pj:   run("current", 1, prowapi.FailureState, &prowapi.EmailReporterConfig{Recipients: recipients, Digest: true}),
+			expectedFlush: []email{{
+				To:      recipients,
+				Subject: "[prow] Digest of 1 failed job run(s)",
+				Body:    "Job periodic-e2e ended with state failure, it failed 1 time(s) in a row.\nLogs: https://prow.example.com/view/current\n",
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(tc.pj)
+			for _, pj := range tc.previous {
+				builder.WithRuntimeObjects(pj)
+			}
+			sender := &fakeSender{}
+			r := New(sender, builder.Build(), false)
+
+			pjs, _, err := r.Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), tc.pj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pjs) != 1 {
+				t.Errorf("expected the job to be returned, got %d jobs", len(pjs))
+			}
+			if diff := cmp.Diff(tc.expected, sender.sent); diff != "" {
+				t.Errorf("unexpected emails (-want +got):\n%s", diff)
+			}
+
+			sender.sent = nil
+			if err := r.FlushDigests(); err != nil {
+				t.Fatalf("unexpected error flushing digests: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedFlush, sender.sent); diff != "" {
+				t.Errorf("unexpected digests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	got := string(message("prow@example.com", []string{"a@example.com", "b@example.com"}, "job\r\nBcc: evil@example.com", "line 1\nline 2"))
+	expected := "From: prow@example.com\r\nTo: a@example.com, b@example.com\r\nSubject: job  Bcc: evil@example.com\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\nline 1\r\nline 2"
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected message (-want +got):\n%s", diff)
+	}
+}
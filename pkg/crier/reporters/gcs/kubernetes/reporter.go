@@ -25,10 +25,12 @@ import (
 	"math"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -52,16 +54,31 @@ type gcsK8sReporter struct {
 	opener         io.Opener
 	rg             resourceGetter
 	reportFraction float32
+	// nodesForbidden holds the clusters in which crier is not allowed to get
+	// nodes, so it doesn't keep trying on every report.
+	nodesForbidden sync.Map
 }
 
 type PodReport struct {
 	Pod    *v1.Pod    `json:"pod,omitempty"`
 	Events []v1.Event `json:"events,omitempty"`
+	// Node describes the node the pod was scheduled to, if any.
+	Node *NodeReport `json:"node,omitempty"`
+}
+
+// NodeReport holds the topology of a node. Only the name is known if the node
+// could not be fetched, e.g. because it was scaled down in the meantime.
+type NodeReport struct {
+	Name         string `json:"name"`
+	Zone         string `json:"zone,omitempty"`
+	Region       string `json:"region,omitempty"`
+	InstanceType string `json:"instance_type,omitempty"`
 }
 
 type resourceGetter interface {
 	GetPod(ctx context.Context, cluster, namespace, name string) (*v1.Pod, error)
 	GetEvents(cluster, namespace string, pod *v1.Pod) ([]v1.Event, error)
+	GetNode(ctx context.Context, cluster, name string) (*v1.Node, error)
 	PatchPod(ctx context.Context, cluster, namespace, name string, pt types.PatchType, data []byte) error
 }
 
@@ -89,6 +106,13 @@ func (rg k8sResourceGetter) PatchPod(ctx context.Context, cluster, namespace, na
 	return err
 }
 
+func (rg k8sResourceGetter) GetNode(ctx context.Context, cluster, name string) (*v1.Node, error) {
+	if _, ok := rg.podClientSets[cluster]; !ok {
+		return nil, fmt.Errorf("couldn't find cluster %q", cluster)
+	}
+	return rg.podClientSets[cluster].Nodes().Get(ctx, name, metav1.GetOptions{})
+}
+
 func (rg k8sResourceGetter) GetEvents(cluster, namespace string, pod *v1.Pod) ([]v1.Event, error) {
 	if _, ok := rg.podClientSets[cluster]; !ok {
 		return nil, fmt.Errorf("couldn't find cluster %q", cluster)
@@ -199,6 +223,21 @@ func (gr *gcsK8sReporter) reportPodInfo(ctx context.Context, log *logrus.Entry,
 		Pod:    pod,
 		Events: events,
 	}
+	if pod != nil && pod.Spec.NodeName != "" {
+		report.Node = &NodeReport{Name: pod.Spec.NodeName}
+		if _, forbidden := gr.nodesForbidden.Load(pj.Spec.Cluster); !forbidden {
+			if node, err := gr.rg.GetNode(ctx, pj.Spec.Cluster, pod.Spec.NodeName); kerrors.IsForbidden(err) {
+				log.WithError(err).Warn("Not allowed to get nodes, grant crier the permission to get nodes to report their topology.")
+				gr.nodesForbidden.Store(pj.Spec.Cluster, true)
+			} else if err != nil {
+				log.WithError(err).Info("Couldn't fetch node for pod")
+			} else {
+				report.Node.Zone = node.Labels[v1.LabelTopologyZone]
+				report.Node.Region = node.Labels[v1.LabelTopologyRegion]
+				report.Node.InstanceType = node.Labels[v1.LabelInstanceTypeStable]
+			}
+		}
+	}
 
 	output, err := json.MarshalIndent(report, "", "\t")
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -136,6 +137,7 @@ type testResourceGetter struct {
 	cluster   string
 	pod       *v1.Pod
 	events    []v1.Event
+	node      *v1.Node
 	patchData string
 	patchType types.PatchType
 	patchErr  error
@@ -173,6 +175,16 @@ func (rg testResourceGetter) GetEvents(cluster, namespace string, pod *v1.Pod) (
 	return rg.events, nil
 }
 
+func (rg testResourceGetter) GetNode(_ context.Context, cluster, name string) (*v1.Node, error) {
+	if rg.cluster != cluster {
+		return nil, fmt.Errorf("expected cluster %q but got cluster %q", rg.cluster, cluster)
+	}
+	if rg.node == nil || rg.node.Name != name {
+		return nil, errors.New("no such node")
+	}
+	return rg.node, nil
+}
+
 func (rg testResourceGetter) PatchPod(ctx context.Context, cluster, namespace, name string, pt types.PatchType, data []byte) error {
 	if rg.patchErr != nil {
 		return rg.patchErr
@@ -200,6 +212,8 @@ func TestReportPodInfo(t *testing.T) {
 		pod                     *v1.Pod
 		patchErr                error
 		events                  []v1.Event
+		node                    *v1.Node
+		expectedNode            *NodeReport
 		dryRun                  bool
 		expectReport            bool
 		expectErr               bool
@@ -225,6 +239,46 @@ func TestReportPodInfo(t *testing.T) {
 			},
 			expectReport: true,
 		},
+		{
+			name:       "prowjob reports the topology of the node",
+			pjName:     "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjComplete: true,
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace: "test-pods",
+					Labels:    map[string]string{"created-by-prow": "true"},
+				},
+				Spec: v1.PodSpec{NodeName: "node-1"},
+			},
+			node: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+					Labels: map[string]string{
+						v1.LabelTopologyZone:       "us-central1-b",
+						v1.LabelTopologyRegion:     "us-central1",
+						v1.LabelInstanceTypeStable: "n2-standard-8",
+					},
+				},
+			},
+			expectedNode: &NodeReport{Name: "node-1", Zone: "us-central1-b", Region: "us-central1", InstanceType: "n2-standard-8"},
+			expectReport: true,
+		},
+		{
+			name:       "prowjob reports the node name if the node is gone",
+			pjName:     "ba123965-4fd4-421f-8509-7590c129ab69",
+			pjComplete: true,
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ba123965-4fd4-421f-8509-7590c129ab69",
+					Namespace: "test-pods",
+					Labels:    map[string]string{"created-by-prow": "true"},
+				},
+				Spec: v1.PodSpec{NodeName: "node-1"},
+			},
+			expectedNode: &NodeReport{Name: "node-1"},
+			expectReport: true,
+		},
 		{
 			name:       "prowjob with no events reports pod",
 			pjName:     "ba123965-4fd4-421f-8509-7590c129ab69",
@@ -399,6 +453,7 @@ func TestReportPodInfo(t *testing.T) {
 				cluster:   "the-build-cluster",
 				pod:       tc.pod,
 				events:    tc.events,
+				node:      tc.node,
 				patchErr:  tc.patchErr,
 				patchData: tc.expectedPatch,
 				patchType: types.MergePatchType,
@@ -449,6 +504,78 @@ func TestReportPodInfo(t *testing.T) {
 			if !cmp.Equal(result.Events, tc.events) {
 				t.Errorf("Got mismatching events:\n%s", cmp.Diff(tc.events, result.Events))
 			}
+			if diff := cmp.Diff(tc.expectedNode, result.Node); diff != "" {
+				t.Errorf("Got mismatching node:\n%s", diff)
+			}
 		})
 	}
 }
+
+type forbiddenNodesResourceGetter struct {
+	testResourceGetter
+	nodeGets *int
+}
+
+func (rg forbiddenNodesResourceGetter) GetNode(_ context.Context, _, name string) (*v1.Node, error) {
+	*rg.nodeGets++
+	return nil, kerrors.NewForbidden(v1.Resource("nodes"), name, errors.New("crier can't get nodes"))
+}
+
+func TestReportPodInfoRemembersForbiddenNodes(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-prowjob", Namespace: "test-pods"},
+		Spec:       v1.PodSpec{NodeName: "node-1"},
+	}
+	pj := &prowv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-prowjob"},
+		Spec: prowv1.ProwJobSpec{
+			Agent:   prowv1.KubernetesAgent,
+			Cluster: "the-build-cluster",
+			Type:    prowv1.PeriodicJob,
+		},
+		Status: prowv1.ProwJobStatus{
+			State:          prowv1.SuccessState,
+			StartTime:      metav1.Time{Time: time.Now()},
+			CompletionTime: &metav1.Time{Time: time.Now()},
+			BuildID:        "12345",
+		},
+	}
+	fca := fca{c: config.Config{ProwConfig: config.ProwConfig{
+		PodNamespace: "test-pods",
+		Plank: config.Plank{
+			DefaultDecorationConfigs: config.DefaultDecorationMapToSliceTesting(
+				map[string]*prowv1.DecorationConfig{"*": {
+					GCSConfiguration: &prowv1.GCSConfiguration{
+						Bucket:       "kubernetes-jenkins",
+						PathPrefix:   "some-prefix",
+						PathStrategy: prowv1.PathStrategyLegacy,
+						DefaultOrg:   "kubernetes",
+						DefaultRepo:  "kubernetes",
+					},
+				}}),
+		},
+	}}}
+	var nodeGets int
+	rg := forbiddenNodesResourceGetter{
+		testResourceGetter: testResourceGetter{namespace: "test-pods", cluster: "the-build-cluster", pod: pod},
+		nodeGets:           &nodeGets,
+	}
+	fakeOpener := &fakeopener.FakeOpener{}
+	reporter := New(fca.Config, fakeOpener, rg, 1.0, false)
+
+	for i := 0; i < 2; i++ {
+		if err := reporter.reportPodInfo(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj); err != nil {
+			t.Fatalf("Unexpected error on report %d: %v", i, err)
+		}
+		var result PodReport
+		if err := json.Unmarshal(fakeOpener.Buffer["gs://kubernetes-jenkins/some-prefix/logs/12345/podinfo.json"].Bytes(), &result); err != nil {
+			t.Fatalf("Couldn't unmarshal reported JSON: %v", err)
+		}
+		if diff := cmp.Diff(&NodeReport{Name: "node-1"}, result.Node); diff != "" {
+			t.Errorf("Got mismatching node on report %d:\n%s", i, diff)
+		}
+	}
+	if nodeGets != 1 {
+		t.Errorf("Expected the node to be fetched once as the first get was forbidden, got %d gets", nodeGets)
+	}
+}
@@ -99,19 +99,31 @@ var (
 		v1.FailureState:   cross,
 		v1.AbortedState:   prohibited,
 	}
+
+	checkState = map[v1.ProwJobState]string{
+		v1.TriggeredState: client.CheckStateScheduled,
+		v1.PendingState:   client.CheckStateRunning,
+		v1.SuccessState:   client.CheckStateSuccessful,
+		v1.FailureState:   client.CheckStateFailed,
+		v1.ErrorState:     client.CheckStateFailed,
+		v1.AbortedState:   client.CheckStateNotRelevant,
+	}
 )
 
 type gerritClient interface {
 	SetReview(instance, id, revision, message string, labels map[string]string) error
 	GetChange(instance, id string, additionalFields ...string) (*gerrit.ChangeInfo, error)
 	ChangeExist(instance, id string) (bool, error)
+	EnsureChecker(instance string, checker client.CheckerInput) error
+	SetCheck(instance, id, revision string, check client.CheckInput) error
 }
 
 // Client is a gerrit reporter client
 type Client struct {
-	gc          gerritClient
-	pjclientset ctrlruntimeclient.Client
-	prLocks     *criercommonlib.ShardedLock
+	gc                  gerritClient
+	pjclientset         ctrlruntimeclient.Client
+	prLocks             *criercommonlib.ShardedLock
+	orgRepoConfigGetter func() *config.GerritOrgRepoConfigs
 }
 
 // Job is the view of a prowjob scoped for a report
@@ -152,9 +164,10 @@ func NewReporter(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, cookie
 	gc.Authenticate(cookiefilePath, "")
 
 	c := &Client{
-		gc:          gc,
-		pjclientset: pjclientset,
-		prLocks:     criercommonlib.NewShardedLock(),
+		gc:                  gc,
+		pjclientset:         pjclientset,
+		prLocks:             criercommonlib.NewShardedLock(),
+		orgRepoConfigGetter: orgRepoConfigGetter,
 	}
 
 	c.prLocks.RunCleanup()
@@ -172,6 +185,12 @@ func (c *Client) ShouldReport(ctx context.Context, log *logrus.Entry, pj *v1.Pro
 		return false
 	}
 
+	if c.reportsChecks(pj) {
+		// Checks are independent of each other, every state of the job is
+		// reported right away.
+		return true
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -271,6 +290,10 @@ func (c *Client) ShouldReport(ctx context.Context, log *logrus.Entry, pj *v1.Pro
 func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJob) ([]*v1.ProwJob, *reconcile.Result, error) {
 	logger = logger.WithFields(logrus.Fields{"job": pj.Spec.Job, "name": pj.Name})
 
+	if c.reportsChecks(pj) {
+		return c.reportCheck(logger, pj)
+	}
+
 	// Gerrit reporter hasn't learned how to deduplicate itself from report yet,
 	// will need to block here. Unfortunately need to check after this section
 	// to ensure that the job was not already marked reported by other threads
@@ -380,7 +403,23 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 	var reviewLabels map[string]string
 	var change *gerrit.ChangeInfo
 	var err error
-	if reportLabel != "" {
+	if reportLabel != "" && pj.Spec.Type == v1.PresubmitJob {
+		change, err = c.gc.GetChange(gerritInstance, gerritID)
+		if err != nil {
+			exist, existErr := c.gc.ChangeExist(gerritInstance, gerritID)
+			if existErr == nil && !exist {
+				// PR was deleted, no reason to report or retry
+				logger.WithError(err).Info("Change doesn't exist any more, skip reporting.")
+				return nil, nil, nil
+			}
+			logger.WithError(err).Warn("Unable to get change")
+		}
+	}
+	if change != nil && (change.WorkInProgress || change.IsPrivate) {
+		// Jobs only run on work in progress and private changes when requested,
+		// report their results without voting on the half-finished change.
+		logger.Info("Not voting on work in progress or private change.")
+	} else if reportLabel != "" {
 		var vote string
 		// Can only vote below zero before merge
 		// TODO(fejta): cannot vote below previous vote after merge
@@ -391,17 +430,7 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 			//https://gerrit-documentation.storage.googleapis.com/Documentation/3.1.4/config-labels.html#label_allowPostSubmit
 			// If presubmit and failure vote -1...
 			vote = lbtm
-
-			change, err = c.gc.GetChange(gerritInstance, gerritID)
-			if err != nil {
-				exist, existErr := c.gc.ChangeExist(gerritInstance, gerritID)
-				if existErr == nil && !exist {
-					// PR was deleted, no reason to report or retry
-					logger.WithError(err).Info("Change doesn't exist any more, skip reporting.")
-					return nil, nil, nil
-				}
-				logger.WithError(err).Warn("Unable to get change")
-			} else if change.Status == client.Merged {
+			if change != nil && change.Status == client.Merged {
 				// Unless change is already merged. Merged changes should not be voted <0
 				vote = lztm
 			}
@@ -448,7 +477,7 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 		}
 
 		if err != nil {
-			if reportLabel == "" {
+			if reviewLabels == nil {
 				return nil, nil, err
 			}
 			// Retry without voting on a label
@@ -491,6 +520,70 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 	return nil, nil, err
 }
 
+// reportsChecks returns true if the job was scheduled by the Gerrit adapter for
+// a repo whose results are reported as checks of the Gerrit checks plugin.
+func (c *Client) reportsChecks(pj *v1.ProwJob) bool {
+	if c.orgRepoConfigGetter == nil || pj.Spec.Refs == nil {
+		return false
+	}
+	if pj.ObjectMeta.Annotations[kube.GerritID] == "" ||
+		pj.ObjectMeta.Annotations[kube.GerritInstance] == "" ||
+		pj.ObjectMeta.Labels[kube.GerritRevision] == "" {
+		return false
+	}
+	return c.orgRepoConfigGetter().ReportChecks(pj.ObjectMeta.Annotations[kube.GerritInstance], pj.Spec.Refs.Repo)
+}
+
+// reportCheck reports the state of the job as the check of its checker on the
+// revision, creating the checker first if needed.
+func (c *Client) reportCheck(logger *logrus.Entry, pj *v1.ProwJob) ([]*v1.ProwJob, *reconcile.Result, error) {
+	gerritID := pj.ObjectMeta.Annotations[kube.GerritID]
+	gerritInstance := pj.ObjectMeta.Annotations[kube.GerritInstance]
+	gerritRevision := pj.ObjectMeta.Labels[kube.GerritRevision]
+	logger = logger.WithFields(logrus.Fields{
+		"instance": gerritInstance,
+		"id":       gerritID,
+	})
+
+	checker := client.CheckerInput{
+		UUID:        client.CheckerUUID(pj.Spec.Refs.Repo, pj.Spec.Job),
+		Name:        pj.Spec.Job,
+		Description: fmt.Sprintf("Prow job %s", pj.Spec.Job),
+		Repository:  pj.Spec.Refs.Repo,
+	}
+	if err := c.gc.EnsureChecker(gerritInstance, checker); err != nil {
+		return nil, nil, err
+	}
+
+	state, ok := checkState[pj.Status.State]
+	if !ok {
+		state = client.CheckStateNotStarted
+	}
+	check := client.CheckInput{
+		CheckerUUID: checker.UUID,
+		State:       state,
+		Message:     pj.Status.Description,
+		URL:         pj.Status.URL,
+	}
+	if !pj.Status.StartTime.IsZero() {
+		check.Started = &gerrit.Timestamp{Time: pj.Status.StartTime.Time}
+	}
+	if pj.Status.CompletionTime != nil {
+		check.Finished = &gerrit.Timestamp{Time: pj.Status.CompletionTime.Time}
+	}
+
+	logger.WithField("state", state).Info("Reporting check.")
+	if err := c.gc.SetCheck(gerritInstance, gerritID, gerritRevision, check); err != nil {
+		// It could be that the change is deleted by the time we want to report.
+		if exist, existErr := c.gc.ChangeExist(gerritInstance, gerritID); existErr == nil && !exist {
+			logger.WithError(err).Info("Change doesn't exist any more, skip reporting.")
+			return []*v1.ProwJob{pj}, nil, nil
+		}
+		return nil, nil, err
+	}
+	return []*v1.ProwJob{pj}, nil, nil
+}
+
 func jobNames(jobs []*v1.ProwJob) []string {
 	names := make([]string, len(jobs))
 	for i, job := range jobs {
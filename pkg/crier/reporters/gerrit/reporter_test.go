@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/andygrunwald/go-gerrit"
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -36,7 +37,9 @@ import (
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/crier/reporters/criercommonlib"
+	"sigs.k8s.io/prow/pkg/gerrit/client"
 	"sigs.k8s.io/prow/pkg/kube"
 )
 
@@ -53,6 +56,31 @@ type fgc struct {
 	instance      string
 	changes       map[string][]*gerrit.ChangeInfo
 	count         int
+	checkers      []client.CheckerInput
+	checks        []client.CheckInput
+}
+
+func (f *fgc) EnsureChecker(instance string, checker client.CheckerInput) error {
+	if instance != f.instance {
+		return fmt.Errorf("wrong instance: %s", instance)
+	}
+	f.checkers = append(f.checkers, checker)
+	return nil
+}
+
+func (f *fgc) SetCheck(instance, id, revision string, check client.CheckInput) error {
+	change, err := f.GetChange(instance, id)
+	if err != nil {
+		return err
+	}
+	if change == nil {
+		return errors.New("change not exist: 404")
+	}
+	if _, ok := change.Revisions[revision]; !ok {
+		return errors.New("revision doesn't exist")
+	}
+	f.checks = append(f.checks, check)
+	return nil
 }
 
 func (f *fgc) SetReview(instance, id, revision, message string, labels map[string]string) error {
@@ -125,6 +153,7 @@ func TestReport(t *testing.T) {
 		"gerrit": {
 			{ID: "123-abc", Status: "NEW", Revisions: map[string]gerrit.RevisionInfo{"abc": {}}},
 			{ID: "merged", Status: "MERGED", Revisions: map[string]gerrit.RevisionInfo{"abc": {}}},
+			{ID: "wip", Status: "NEW", WorkInProgress: true, Revisions: map[string]gerrit.RevisionInfo{"abc": {}}},
 		},
 	}
 	var testcases = []struct {
@@ -546,6 +575,44 @@ func TestReport(t *testing.T) {
 			expectLabel:       map[string]string{codeReview: lbtm},
 			numExpectedReport: 0,
 		},
+		{
+			name: "1 job, failed on WorkInProgress change, should report, but not vote",
+			pj: &v1.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						kube.GerritRevision:    "abc",
+						kube.ProwJobTypeLabel:  presubmit,
+						kube.GerritReportLabel: "Code-Review",
+					},
+					Annotations: map[string]string{
+						kube.GerritID:       "wip",
+						kube.GerritInstance: "gerrit",
+					},
+					Name:      "ci-foo",
+					Namespace: "test-pods",
+				},
+				Status: v1.ProwJobStatus{
+					State: v1.FailureState,
+					URL:   "guber/foo",
+				},
+				Spec: v1.ProwJobSpec{
+					Type: v1.PresubmitJob,
+					Refs: &v1.Refs{
+						Repo: "foo",
+						Pulls: []v1.Pull{
+							{
+								Number: 0,
+							},
+						},
+					},
+					Job:    "ci-foo",
+					Report: true,
+				},
+			},
+			expectReport:      true,
+			reportInclude:     []string{"0 out of 1", "ci-foo", "FAILURE", "guber/foo"},
+			numExpectedReport: 0,
+		},
 		{
 			name: "1 job, passed, has slash in repo name, should report and handle slash properly",
 			pj: &v1.ProwJob{
@@ -2064,6 +2131,152 @@ func TestReport(t *testing.T) {
 	}
 }
 
+func TestReportChecks(t *testing.T) {
+	startTime := metav1.NewTime(timeNow)
+	completionTime := metav1.NewTime(timeNow.Add(time.Hour))
+	changes := map[string][]*gerrit.ChangeInfo{
+		"gerrit": {
+			{ID: "123-abc", Status: "NEW", Revisions: map[string]gerrit.RevisionInfo{"abc": {}}},
+		},
+	}
+	orgRepoConfigs := &config.GerritOrgRepoConfigs{
+		{Org: "gerrit", Repos: []string{"checks-repo"}, ReportChecks: true},
+	}
+	makePJ := func(repo, id string, state v1.ProwJobState) *v1.ProwJob {
+		pj := &v1.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pj",
+				Labels: map[string]string{
+					kube.GerritRevision:    "abc",
+					kube.ProwJobTypeLabel:  presubmit,
+					kube.GerritReportLabel: "Verified",
+				},
+				Annotations: map[string]string{
+					kube.GerritID:       id,
+					kube.GerritInstance: "gerrit",
+				},
+			},
+			Spec: v1.ProwJobSpec{
+				Type:   v1.PresubmitJob,
+				Job:    "ci-foo",
+				Report: true,
+				Refs:   &v1.Refs{Repo: repo, Pulls: []v1.Pull{{Number: 123}}},
+			},
+			Status: v1.ProwJobStatus{
+				State:       state,
+				StartTime:   startTime,
+				URL:         "guber/foo",
+				Description: "Job running.",
+			},
+		}
+		if state != v1.TriggeredState && state != v1.PendingState {
+			pj.Status.CompletionTime = &completionTime
+			pj.Status.Description = "Job finished."
+		}
+		return pj
+	}
+	checker := client.CheckerInput{
+		UUID:        "prow:checks-repo/ci-foo",
+		Name:        "ci-foo",
+		Description: "Prow job ci-foo",
+		Repository:  "checks-repo",
+	}
+
+	testcases := []struct {
+		name          string
+		pj            *v1.ProwJob
+		expectReport  bool
+		expectChecks  []client.CheckInput
+		expectReview  bool
+		expectReports int
+	}{
+		{
+			name:          "pending job is reported as running check",
+			pj:            makePJ("checks-repo", "123-abc", v1.PendingState),
+			expectReport:  true,
+			expectReports: 1,
+			expectChecks: []client.CheckInput{{
+				CheckerUUID: checker.UUID,
+				State:       client.CheckStateRunning,
+				Message:     "Job running.",
+				URL:         "guber/foo",
+				Started:     &gerrit.Timestamp{Time: timeNow},
+			}},
+		},
+		{
+			name:          "failed job is reported as failed check",
+			pj:            makePJ("checks-repo", "123-abc", v1.FailureState),
+			expectReport:  true,
+			expectReports: 1,
+			expectChecks: []client.CheckInput{{
+				CheckerUUID: checker.UUID,
+				State:       client.CheckStateFailed,
+				Message:     "Job finished.",
+				URL:         "guber/foo",
+				Started:     &gerrit.Timestamp{Time: timeNow},
+				Finished:    &gerrit.Timestamp{Time: timeNow.Add(time.Hour)},
+			}},
+		},
+		{
+			name:          "job of deleted change is not retried",
+			pj:            makePJ("checks-repo", "deleted", v1.SuccessState),
+			expectReport:  true,
+			expectReports: 1,
+		},
+		{
+			name:         "pending job of other repo is not reported",
+			pj:           makePJ("other-repo", "123-abc", v1.PendingState),
+			expectReport: false,
+		},
+		{
+			name:          "finished job of other repo is reported as review",
+			pj:            makePJ("other-repo", "123-abc", v1.SuccessState),
+			expectReport:  true,
+			expectReview:  true,
+			expectReports: 0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fgc := &fgc{instance: "gerrit", changes: changes}
+			reporter := &Client{
+				gc:                  fgc,
+				pjclientset:         fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(tc.pj).Build(),
+				prLocks:             criercommonlib.NewShardedLock(),
+				orgRepoConfigGetter: func() *config.GerritOrgRepoConfigs { return orgRepoConfigs },
+			}
+
+			shouldReport := reporter.ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), tc.pj)
+			if shouldReport != tc.expectReport {
+				t.Fatalf("shouldReport: %v, expectReport: %v", shouldReport, tc.expectReport)
+			}
+			if !shouldReport {
+				return
+			}
+
+			reportedJobs, _, err := reporter.Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), tc.pj)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(reportedJobs) != tc.expectReports {
+				t.Errorf("report count: got %d, want %d", len(reportedJobs), tc.expectReports)
+			}
+			if diff := cmp.Diff(tc.expectChecks, fgc.checks); diff != "" {
+				t.Errorf("Unexpected checks (-want +got):\n%s", diff)
+			}
+			if len(tc.expectChecks) > 0 {
+				if diff := cmp.Diff([]client.CheckerInput{checker}, fgc.checkers); diff != "" {
+					t.Errorf("Unexpected checkers (-want +got):\n%s", diff)
+				}
+			}
+			if reviewed := fgc.count > 0; reviewed != tc.expectReview {
+				t.Errorf("reviewed: got %t, want %t", reviewed, tc.expectReview)
+			}
+		})
+	}
+}
+
 func TestMultipleWorks(t *testing.T) {
 	samplePJ := v1.ProwJob{
 		ObjectMeta: metav1.ObjectMeta{
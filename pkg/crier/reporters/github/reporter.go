@@ -20,8 +20,10 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
@@ -32,8 +34,12 @@ import (
 	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/crier/reporters/criercommonlib"
+	"sigs.k8s.io/prow/pkg/crier/reporters/gcs/util"
 	"sigs.k8s.io/prow/pkg/github/report"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/io/providers"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
 )
 
 const (
@@ -48,16 +54,19 @@ type Client struct {
 	reportAgent v1.ProwJobAgent
 	prLocks     *criercommonlib.ShardedLock
 	lister      ctrlruntimeclient.Reader
+	opener      io.Opener
 }
 
-// NewReporter returns a reporter client
-func NewReporter(gc report.GitHubClient, cfg config.Getter, reportAgent v1.ProwJobAgent, lister ctrlruntimeclient.Reader) *Client {
+// NewReporter returns a reporter client. If opener is not nil, the results.json
+// of failed jobs is read through it to name their failed tests in the report.
+func NewReporter(gc report.GitHubClient, cfg config.Getter, reportAgent v1.ProwJobAgent, lister ctrlruntimeclient.Reader, opener io.Opener) *Client {
 	c := &Client{
 		gc:          gc,
 		config:      cfg,
 		reportAgent: reportAgent,
 		prLocks:     criercommonlib.NewShardedLock(),
 		lister:      lister,
+		opener:      opener,
 	}
 	c.prLocks.RunCleanup()
 	return c
@@ -81,6 +90,8 @@ func (c *Client) ShouldReport(_ context.Context, _ *logrus.Entry, pj *v1.ProwJob
 		return false // Report presubmit and postsubmit github jobs for github reporter
 	case c.reportAgent != "" && pj.Spec.Agent != c.reportAgent:
 		return false // Only report for specified agent
+	case pj.Spec.Refs != nil && c.config().GitLabReporter.HostsRepo(pj.Spec.Refs.Org, pj.Spec.Refs.Repo):
+		return false // Repos hosted on GitLab are reported by the gitlab reporter
 	}
 
 	return true
@@ -147,11 +158,39 @@ func (c *Client) Report(ctx context.Context, log *logrus.Entry, pj *v1.ProwJob)
 			}
 		}
 	}
-	err = report.ReportComment(ctx, c.gc, c.config().Plank.ReportTemplateForRepo(pj.Spec.Refs), toReport, c.config().GitHubReporter, mustCreateComment)
+	var getResults report.ResultsGetter
+	if c.opener != nil {
+		getResults = c.getResults
+	}
+	err = report.ReportComment(ctx, c.gc, c.config().Plank.ReportTemplateForRepo(pj.Spec.Refs), toReport, c.config().GitHubReporter, mustCreateComment, getResults)
 
 	return []*v1.ProwJob{pj}, nil, err
 }
 
+// getResults reads the results.json the sidecar uploaded for the job, if any.
+func (c *Client) getResults(ctx context.Context, pj v1.ProwJob) (*results.Summary, error) {
+	bucket, dir, err := util.GetJobDestination(c.config, &pj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job destination: %w", err)
+	}
+	summaryPath, err := providers.StoragePath(bucket, path.Join(dir, v1.ResultsSummaryFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s path: %w", v1.ResultsSummaryFile, err)
+	}
+	content, err := io.ReadContent(ctx, logrus.WithField("prowjob", pj.Name), c.opener, summaryPath)
+	if err != nil {
+		if io.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", v1.ResultsSummaryFile, err)
+	}
+	var summary results.Summary
+	if err := json.Unmarshal(content, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", v1.ResultsSummaryFile, err)
+	}
+	return &summary, nil
+}
+
 func pjsToReport(ctx context.Context, log *logrus.Entry, lister ctrlruntimeclient.Reader, pj *v1.ProwJob) ([]v1.ProwJob, error) {
 	if len(pj.Spec.Refs.Pulls) != 1 {
 		return nil, nil
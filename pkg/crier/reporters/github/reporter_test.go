@@ -17,6 +17,7 @@ limitations under the License.
 package github
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"sync"
@@ -31,7 +32,9 @@ import (
 	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
 
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -97,11 +100,39 @@ func TestShouldReport(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "github should not report jobs of repos hosted on gitlab",
+			pj: v1.ProwJob{
+				Spec: v1.ProwJobSpec{
+					Type:   v1.PresubmitJob,
+					Report: true,
+					Refs:   &v1.Refs{Org: "gitlab-org", Repo: "repo"},
+				},
+			},
+		},
+		{
+			name: "should report presubmit job of repo hosted on github",
+			pj: v1.ProwJob{
+				Spec: v1.ProwJobSpec{
+					Type:   v1.PresubmitJob,
+					Report: true,
+					Refs:   &v1.Refs{Org: "github-org", Repo: "repo"},
+				},
+			},
+			report: true,
+		},
 	}
 
+	cfg := func() *config.Config {
+		return &config.Config{
+			ProwConfig: config.ProwConfig{
+				GitLabReporter: &config.GitLabReporter{Repos: []string{"gitlab-org"}},
+			},
+		}
+	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			c := NewReporter(nil, nil, tc.reportAgent, nil)
+			c := NewReporter(nil, cfg, tc.reportAgent, nil, nil)
 			if r := c.ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), &tc.pj); r == tc.report {
 				return
 			}
@@ -132,6 +163,7 @@ func TestPresumitReportingLocks(t *testing.T) {
 		},
 		v1.ProwJobAgent(""),
 		nil,
+		nil,
 	)
 
 	pj := &v1.ProwJob{
@@ -1016,3 +1048,56 @@ func TestPjsToReport(t *testing.T) {
 		})
 	}
 }
+
+func TestGetResults(t *testing.T) {
+	const summaryPath = "gs://bucket/logs/my-job/1/results.json"
+	testCases := []struct {
+		name          string
+		content       map[string]*bytes.Buffer
+		expected      *results.Summary
+		expectedError bool
+	}{
+		{
+			name: "summary is read",
+			content: map[string]*bytes.Buffer{
+				summaryPath: bytes.NewBufferString(`{"total":2,"passed":1,"failed":1,"failures":[{"name":"TestFoo","duration_seconds":1}]}`),
+			},
+			expected: &results.Summary{Total: 2, Passed: 1, Failed: 1, Failures: []results.TestCase{{Name: "TestFoo", DurationSeconds: 1}}},
+		},
+		{
+			name: "missing summary is not an error",
+		},
+		{
+			name: "malformed summary is an error",
+			content: map[string]*bytes.Buffer{
+				summaryPath: bytes.NewBufferString(`{`),
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewReporter(nil, func() *config.Config { return &config.Config{} }, v1.ProwJobAgent(""), nil, &fakeopener.FakeOpener{Buffer: tc.content})
+			pj := v1.ProwJob{
+				Spec: v1.ProwJobSpec{
+					Type: v1.PostsubmitJob,
+					Job:  "my-job",
+					DecorationConfig: &v1.DecorationConfig{
+						GCSConfiguration: &v1.GCSConfiguration{
+							Bucket:       "bucket",
+							PathStrategy: v1.PathStrategyExplicit,
+						},
+					},
+				},
+				Status: v1.ProwJobStatus{BuildID: "1"},
+			}
+			summary, err := c.getResults(context.Background(), pj)
+			if tc.expectedError != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedError, err)
+			}
+			if diff := cmp.Diff(tc.expected, summary); diff != "" {
+				t.Errorf("summary differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
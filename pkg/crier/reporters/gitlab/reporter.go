@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitlab implements a reporter that sets the commit statuses of jobs
+// of repos hosted on GitLab.
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gitlab"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+const (
+	// GitLabReporterName is the name for gitlab reporter
+	GitLabReporterName = "gitlab-reporter"
+)
+
+// gitLabClient is the part of the GitLab client the reporter uses.
+type gitLabClient interface {
+	SetCommitStatus(ctx context.Context, project, sha string, status gitlab.CommitStatus) error
+}
+
+// Client is a gitlab reporter client
+type Client struct {
+	gc          gitLabClient
+	config      config.Getter
+	reportAgent v1.ProwJobAgent
+	dryRun      bool
+}
+
+// NewReporter returns a reporter client
+func NewReporter(gc gitLabClient, cfg config.Getter, reportAgent v1.ProwJobAgent, dryRun bool) *Client {
+	return &Client{
+		gc:          gc,
+		config:      cfg,
+		reportAgent: reportAgent,
+		dryRun:      dryRun,
+	}
+}
+
+// GetName returns the name of the reporter
+func (c *Client) GetName() string {
+	return GitLabReporterName
+}
+
+// ShouldReport returns if this prowjob should be reported by the gitlab reporter
+func (c *Client) ShouldReport(_ context.Context, _ *logrus.Entry, pj *v1.ProwJob) bool {
+	if !pj.Spec.Report || pj.Spec.Refs == nil {
+		return false
+	}
+
+	gitLabConfig := c.config().GitLabReporter
+	switch {
+	case pj.Labels[kube.GerritReportLabel] != "":
+		return false
+	case !gitLabConfig.HostsRepo(pj.Spec.Refs.Org, pj.Spec.Refs.Repo):
+		return false // Repos hosted on GitHub are reported by the github reporter
+	case !jobTypeShouldReport(pj.Spec.Type, gitLabConfig.JobTypes()):
+		return false
+	case c.reportAgent != "" && pj.Spec.Agent != c.reportAgent:
+		return false // Only report for specified agent
+	case len(pj.Spec.Refs.Pulls) > 1:
+		return false // Batch jobs are not reported
+	}
+
+	return true
+}
+
+func jobTypeShouldReport(jobType v1.ProwJobType, validTypes []v1.ProwJobType) bool {
+	for _, t := range validTypes {
+		if jobType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Report sets the commit status of the job.
+func (c *Client) Report(ctx context.Context, log *logrus.Entry, pj *v1.ProwJob) ([]*v1.ProwJob, *reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	state, err := prowjobStateToGitLabStatus(pj.Status.State)
+	if err != nil {
+		return []*v1.ProwJob{pj}, nil, err
+	}
+	refs := pj.Spec.Refs
+	project := refs.Org + "/" + refs.Repo
+	sha := refs.BaseSHA
+	ref := refs.BaseRef
+	if len(refs.Pulls) > 0 {
+		sha = refs.Pulls[0].SHA
+		// The commits of merge requests belong to their source branch.
+		ref = refs.Pulls[0].HeadRef
+	}
+	status := gitlab.CommitStatus{
+		State:       state,
+		Ref:         ref,
+		Name:        pj.Spec.Context,
+		TargetURL:   pj.Status.URL,
+		Description: pj.Status.Description,
+	}
+	if c.dryRun {
+		log.WithField("status", status).Debug("Skipping reporting because dry-run is enabled")
+		return []*v1.ProwJob{pj}, nil, nil
+	}
+
+	if err := c.gc.SetCommitStatus(ctx, project, sha, status); err != nil {
+		var requestErr *gitlab.RequestError
+		if errors.As(err, &requestErr) {
+			switch {
+			case requestErr.StatusCode == http.StatusNotFound:
+				// The commit is gone if someone force pushed, which is not a crier error.
+				log.WithError(err).Debug("Could not find commit, skipping retries")
+				return []*v1.ProwJob{pj}, nil, nil
+			case strings.HasPrefix(requestErr.Message, "Cannot transition status"):
+				// GitLab rejects setting a status to its current state, which
+				// happens when the job is reported again, e.g. after a restart.
+				log.WithError(err).Debug("Commit status is already up to date, skipping retries")
+				return []*v1.ProwJob{pj}, nil, nil
+			}
+		}
+		return []*v1.ProwJob{pj}, nil, fmt.Errorf("error setting commit status: %w", err)
+	}
+	return []*v1.ProwJob{pj}, nil, nil
+}
+
+// prowjobStateToGitLabStatus maps prowjob status to gitlab states.
+func prowjobStateToGitLabStatus(pjState v1.ProwJobState) (string, error) {
+	switch pjState {
+	case v1.TriggeredState:
+		return gitlab.StatusPending, nil
+	case v1.PendingState:
+		return gitlab.StatusRunning, nil
+	case v1.SuccessState:
+		return gitlab.StatusSuccess, nil
+	case v1.ErrorState, v1.FailureState:
+		return gitlab.StatusFailed, nil
+	case v1.AbortedState:
+		return gitlab.StatusCanceled, nil
+	}
+	return "", fmt.Errorf("Unknown prowjob state: %s", pjState)
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gitlab"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+type fakeGitLabClient struct {
+	err      error
+	statuses map[string][]gitlab.CommitStatus
+}
+
+func (f *fakeGitLabClient) SetCommitStatus(_ context.Context, project, sha string, status gitlab.CommitStatus) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.statuses == nil {
+		f.statuses = map[string][]gitlab.CommitStatus{}
+	}
+	key := project + "@" + sha
+	f.statuses[key] = append(f.statuses[key], status)
+	return nil
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		ProwConfig: config.ProwConfig{
+			GitLabReporter: &config.GitLabReporter{
+				Repos: []string{"group/subgroup", "other-group/repo"},
+			},
+		},
+	}
+}
+
+func TestShouldReport(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pj          v1.ProwJob
+		reportAgent v1.ProwJobAgent
+		expected    bool
+	}{
+		{
+			name: "presubmit of repo in gitlab group is reported",
+			pj: v1.ProwJob{Spec: v1.ProwJobSpec{
+				Type:   v1.PresubmitJob,
+				Report: true,
+				Refs:   &v1.Refs{Org: "group/subgroup", Repo: "repo", Pulls: []v1.Pull{{Number: 1}}},
+			}},
+			expected: true,
+		},
+		{
+			name: "postsubmit of gitlab repo is reported",
+			pj: v1.ProwJob{Spec: v1.ProwJobSpec{
+				Type:   v1.PostsubmitJob,
+				Report: true,
+				Refs:   &v1.Refs{Org: "other-group", Repo: "repo"},
+			}},
+			expected: true,
+		},
+		{
+			name: "job of repo hosted on github is not reported",
+			pj: v1.ProwJob{Spec: v1.ProwJobSpec{
+				Type:   v1.PresubmitJob,
+				Report: true,
+				Refs:   &v1.Refs{Org: "other-group", Repo: "other-repo", Pulls: []v1.Pull{{Number: 1}}},
+			}},
+		},
+		{
+			name: "job with report disabled is not reported",
+			pj: v1.ProwJob{Spec: v1.ProwJobSpec{
+				Type: v1.PresubmitJob,
+				Refs: &v1.Refs{Org: "group/subgroup", Repo: "repo", Pulls: []v1.Pull{{Number: 1}}},
+			}},
+		},
+		{
+			name: "periodic is not reported",
+			pj: v1.ProwJob{Spec: v1.ProwJobSpec{
+				Type:      v1.PeriodicJob,
+				Report:    true,
+				ExtraRefs: []v1.Refs{{Org: "group/subgroup", Repo: "repo"}},
+			}},
+		},
+		{
+			name: "batch is not reported",
+			pj: v1.ProwJob{Spec: v1.ProwJobSpec{
+				Type:   v1.BatchJob,
+				Report: true,
+				Refs:   &v1.Refs{Org: "group/subgroup", Repo: "repo", Pulls: []v1.Pull{{Number: 1}, {Number: 2}}},
+			}},
+		},
+		{
+			name: "gerrit job is not reported",
+			pj: v1.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{kube.GerritReportLabel: "Verified"}},
+				Spec: v1.ProwJobSpec{
+					Type:   v1.PresubmitJob,
+					Report: true,
+					Refs:   &v1.Refs{Org: "group/subgroup", Repo: "repo", Pulls: []v1.Pull{{Number: 1}}},
+				},
+			},
+		},
+		{
+			name: "job of other agent is not reported",
+			pj: v1.ProwJob{Spec: v1.ProwJobSpec{
+				Type:   v1.PresubmitJob,
+				Agent:  v1.JenkinsAgent,
+				Report: true,
+				Refs:   &v1.Refs{Org: "group/subgroup", Repo: "repo", Pulls: []v1.Pull{{Number: 1}}},
+			}},
+			reportAgent: v1.KubernetesAgent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewReporter(&fakeGitLabClient{}, testConfig, tc.reportAgent, false)
+			if got := c.ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), &tc.pj); got != tc.expected {
+				t.Errorf("expected ShouldReport to return %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	presubmit := v1.ProwJob{
+		Spec: v1.ProwJobSpec{
+			Type:    v1.PresubmitJob,
+			Context: "pull-unit",
+			Report:  true,
+			Refs: &v1.Refs{
+				Org:     "group/subgroup",
+				Repo:    "repo",
+				BaseRef: "main",
+				BaseSHA: "1234567890",
+				Pulls:   []v1.Pull{{Number: 1, SHA: "abcdef", HeadRef: "feature"}},
+			},
+		},
+		Status: v1.ProwJobStatus{
+			State:       v1.FailureState,
+			Description: "Job failed.",
+			URL:         "https://prow.example.com/view/1",
+		},
+	}
+	postsubmit := v1.ProwJob{
+		Spec: v1.ProwJobSpec{
+			Type:    v1.PostsubmitJob,
+			Context: "post-unit",
+			Report:  true,
+			Refs:    &v1.Refs{Org: "other-group", Repo: "repo", BaseRef: "main", BaseSHA: "1234567890"},
+		},
+		Status: v1.ProwJobStatus{State: v1.PendingState, Description: "Job triggered."},
+	}
+
+	testCases := []struct {
+		name             string
+		pj               v1.ProwJob
+		err              error
+		dryRun           bool
+		expectedStatuses map[string][]gitlab.CommitStatus
+		expectedErr      bool
+	}{
+		{
+			name: "presubmit status is set on head of merge request",
+			pj:   presubmit,
+			expectedStatuses: map[string][]gitlab.CommitStatus{
+				"group/subgroup/repo@abcdef": {{
+					State:       gitlab.StatusFailed,
+					Ref:         "feature",
+					Name:        "pull-unit",
+					TargetURL:   "https://prow.example.com/view/1",
+					Description: "Job failed.",
+				}},
+			},
+		},
+		{
+			name: "postsubmit status is set on base",
+			pj:   postsubmit,
+			expectedStatuses: map[string][]gitlab.CommitStatus{
+				"other-group/repo@1234567890": {{
+					State:       gitlab.StatusRunning,
+					Ref:         "main",
+					Name:        "post-unit",
+					Description: "Job triggered.",
+				}},
+			},
+		},
+		{
+			name:   "nothing is set in dry run",
+			pj:     presubmit,
+			dryRun: true,
+		},
+		{
+			name: "missing commit is not retried",
+			pj:   presubmit,
+			err:  &gitlab.RequestError{StatusCode: http.StatusNotFound, Message: "404 Commit Not Found"},
+		},
+		{
+			name: "rejected transition is not retried",
+			pj:   presubmit,
+			err:  &gitlab.RequestError{StatusCode: http.StatusBadRequest, Message: "Cannot transition status via :drop from :failed"},
+		},
+		{
+			name:        "other errors are returned",
+			pj:          presubmit,
+			err:         errors.New("connection refused"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := &fakeGitLabClient{err: tc.err}
+			c := NewReporter(gc, testConfig, "", tc.dryRun)
+			pjs, _, err := c.Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), &tc.pj)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if len(pjs) != 1 {
+				t.Errorf("expected the job to be returned, got %d jobs", len(pjs))
+			}
+			if diff := cmp.Diff(tc.expectedStatuses, gc.statuses); diff != "" {
+				t.Errorf("unexpected commit statuses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
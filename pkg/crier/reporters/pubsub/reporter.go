@@ -43,6 +43,15 @@ const (
 	PubSubTopicLabel = "prow.k8s.io/pubsub.topic"
 	// PubSubRunIDLabel annotation
 	PubSubRunIDLabel = "prow.k8s.io/pubsub.runID"
+	// PubSubFormatLabel annotation overrides the configured message format.
+	PubSubFormatLabel = "prow.k8s.io/pubsub.format"
+
+	// CloudEventsContentType is the content-type attribute of messages that
+	// are structured CloudEvents.
+	CloudEventsContentType = "application/cloudevents+json; charset=UTF-8"
+	// CloudEventTypePrefix is the prefix of the type of CloudEvents, which
+	// ends with the state of the job, e.g. io.k8s.prow.job.success.
+	CloudEventTypePrefix = "io.k8s.prow.job."
 )
 
 // ReportMessage is a message structure used to pass a prowjob status to Pub/Sub topic.s
@@ -59,6 +68,27 @@ type ReportMessage struct {
 	Message string               `json:"message,omitempty"`
 }
 
+// CloudEvent is a structured CloudEvents 1.0 event that carries a
+// ReportMessage as its data and the metadata of the job as extension
+// attributes.
+// See https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md
+type CloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	ID              string         `json:"id"`
+	Source          string         `json:"source"`
+	Type            string         `json:"type"`
+	Subject         string         `json:"subject,omitempty"`
+	Time            *time.Time     `json:"time,omitempty"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            *ReportMessage `json:"data"`
+
+	ProwJobName  string `json:"prowjobname"`
+	ProwJobType  string `json:"prowjobtype"`
+	ProwJobState string `json:"prowjobstate"`
+	ProwBuildID  string `json:"prowbuildid,omitempty"`
+	ProwRunID    string `json:"prowrunid,omitempty"`
+}
+
 // Client is a reporter client fed to crier controller
 type Client struct {
 	config config.Getter
@@ -116,14 +146,21 @@ func (c *Client) Report(ctx context.Context, l *logrus.Entry, pj *prowapi.ProwJo
 	topic := client.Topic(message.Topic)
 	defer topic.Stop() // Sends remaining messages then stops goroutines.
 
-	d, err := json.Marshal(message)
+	var payload interface{} = message
+	var attributes map[string]string
+	if c.format(pj) == config.PubSubFormatCloudEvents {
+		payload = cloudEventFromPJ(pj, message)
+		attributes = map[string]string{"content-type": CloudEventsContentType}
+	}
+	d, err := json.Marshal(payload)
 	if err != nil {
 		l.WithError(err).Debug("Failed marshalling pubsub message.")
 		return nil, nil, fmt.Errorf("could not marshal pubsub report: %w", err)
 	}
 
 	res := topic.Publish(ctx, &pubsub.Message{
-		Data: d,
+		Data:       d,
+		Attributes: attributes,
 	})
 
 	_, err = res.Get(ctx)
@@ -147,6 +184,43 @@ func (c *Client) Report(ctx context.Context, l *logrus.Entry, pj *prowapi.ProwJo
 	return []*prowapi.ProwJob{pj}, nil, nil
 }
 
+// format returns the format of the messages of the job.
+func (c *Client) format(pj *prowapi.ProwJob) string {
+	if format := findLabels(pj, PubSubFormatLabel)[PubSubFormatLabel]; format != "" {
+		return format
+	}
+	if r := c.config().PubSubReporter; r != nil {
+		return r.Format
+	}
+	return ""
+}
+
+// cloudEventFromPJ wraps the report of the job in a CloudEvent. Reports of
+// the same job in the same state have the same ID, so that consumers can
+// drop duplicates.
+func cloudEventFromPJ(pj *prowapi.ProwJob, message *ReportMessage) *CloudEvent {
+	event := &CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              fmt.Sprintf("%s/%s", pj.Name, pj.Status.State),
+		Source:          fmt.Sprintf("/prowjobs/%s", pj.Spec.Job),
+		Type:            CloudEventTypePrefix + string(pj.Status.State),
+		Subject:         pj.Name,
+		DataContentType: "application/json",
+		Data:            message,
+		ProwJobName:     pj.Spec.Job,
+		ProwJobType:     string(pj.Spec.Type),
+		ProwJobState:    string(pj.Status.State),
+		ProwBuildID:     pj.Status.BuildID,
+		ProwRunID:       message.RunID,
+	}
+	if pj.Status.CompletionTime != nil {
+		event.Time = &pj.Status.CompletionTime.Time
+	} else if !pj.Status.StartTime.IsZero() {
+		event.Time = &pj.Status.StartTime.Time
+	}
+	return event
+}
+
 func (c *Client) generateMessageFromPJ(pj *prowapi.ProwJob) *ReportMessage {
 	pubSubMap := findLabels(pj, PubSubProjectLabel, PubSubTopicLabel, PubSubRunIDLabel)
 	var refs []prowapi.Refs
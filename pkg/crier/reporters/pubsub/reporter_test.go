@@ -18,10 +18,13 @@ package pubsub
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -467,3 +470,100 @@ func TestShouldReport(t *testing.T) {
 		}
 	}
 }
+
+func TestFormat(t *testing.T) {
+	testcases := []struct {
+		name        string
+		format      string
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			name: "report messages by default",
+		},
+		{
+			name:     "configured format",
+			format:   config.PubSubFormatCloudEvents,
+			expected: config.PubSubFormatCloudEvents,
+		},
+		{
+			name:        "annotation overrides the configured format",
+			annotations: map[string]string{PubSubFormatLabel: config.PubSubFormatCloudEvents},
+			expected:    config.PubSubFormatCloudEvents,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fca := &fca{c: &config.Config{ProwConfig: config.ProwConfig{PubSubReporter: &config.PubSubReporter{Format: tc.format}}}}
+			c := NewReporter(fca.Config)
+			pj := &prowapi.ProwJob{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if actual := c.format(pj); actual != tc.expected {
+				t.Errorf("expected format %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCloudEventFromPJ(t *testing.T) {
+	start := metav1.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	completion := metav1.NewTime(time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC))
+	pj := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "6d3f2e1c",
+			Annotations: map[string]string{
+				PubSubProjectLabel: testPubSubProjectName,
+				PubSubTopicLabel:   testPubSubTopicName,
+				PubSubRunIDLabel:   testPubSubRunID,
+			},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Type: prowapi.PeriodicJob,
+			Job:  "ci-job",
+		},
+		Status: prowapi.ProwJobStatus{
+			State:          prowapi.FailureState,
+			StartTime:      start,
+			CompletionTime: &completion,
+			BuildID:        "42",
+		},
+	}
+	fca := &fca{c: &config.Config{}}
+	c := NewReporter(fca.Config)
+
+	raw, err := json.Marshal(cloudEventFromPJ(pj, c.generateMessageFromPJ(pj)))
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	var actual map[string]interface{}
+	if err := json.Unmarshal(raw, &actual); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+	expected := map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              "6d3f2e1c/failure",
+		"source":          "/prowjobs/ci-job",
+		"type":            "io.k8s.prow.job.failure",
+		"subject":         "6d3f2e1c",
+		"time":            "2024-05-01T10:30:00Z",
+		"datacontenttype": "application/json",
+		"data": map[string]interface{}{
+			"project":  testPubSubProjectName,
+			"topic":    testPubSubTopicName,
+			"runid":    testPubSubRunID,
+			"status":   "failure",
+			"url":      "",
+			"gcs_path": "",
+			"job_type": "periodic",
+			"job_name": "ci-job",
+		},
+		"prowjobname":  "ci-job",
+		"prowjobtype":  "periodic",
+		"prowjobstate": "failure",
+		"prowbuildid":  "42",
+		"prowrunid":    testPubSubRunID,
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected event (-want +got):\n%s", diff)
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
 	slackclient "sigs.k8s.io/prow/pkg/slack"
 )
 
@@ -111,6 +112,13 @@ func (sr *slackReporter) GetName() string {
 }
 
 func (sr *slackReporter) ShouldReport(_ context.Context, logger *logrus.Entry, pj *prowapi.ProwJob) bool {
+	// Shadow jobs run job definitions that have not merged yet, nobody
+	// subscribed to the channels of the job wants to hear about them.
+	if pj.Labels[kube.ShadowLabel] == "true" {
+		logger.Debug("Skip slack reporting of shadow job.")
+		return false
+	}
+
 	globalSlackConfig, jobSlackConfig := sr.getConfig(pj)
 
 	var typeShouldReport bool
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
@@ -54,6 +55,27 @@ func TestShouldReport(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "Shadow job should not report",
+			config: config.SlackReporter{
+				JobTypesToReport: []v1.ProwJobType{v1.PresubmitJob},
+				SlackReporterConfig: v1.SlackReporterConfig{
+					JobStatesToReport: []v1.ProwJobState{v1.SuccessState},
+				},
+			},
+			pj: &v1.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"prow.k8s.io/shadow": "true"},
+				},
+				Spec: v1.ProwJobSpec{
+					Type: v1.PresubmitJob,
+				},
+				Status: v1.ProwJobStatus{
+					State: v1.SuccessState,
+				},
+			},
+			expected: false,
+		},
 		{
 			name: "Wrong job type  should not report",
 			config: config.SlackReporter{
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"time"
 
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
@@ -69,15 +70,53 @@ type Options struct {
 	CopyModeOnly bool   `json:"copy_mode_only,omitempty"`
 	CopyDst      string `json:"copy_dst,omitempty"`
 
+	// Steps are run in order instead of Args when set.
+	// Once a step fails or times out, or the entrypoint is
+	// interrupted, only the remaining steps that always run
+	// are executed.
+	Steps []Step `json:"steps,omitempty"`
+
+	// UsageInterval is how often the resource usage of the container is
+	// sampled into the usage file. Defaults to usage.DefaultInterval.
+	UsageInterval time.Duration `json:"usage_interval,omitempty"`
+	// UsageGPU also samples the usage of the GPUs visible to the container.
+	UsageGPU bool `json:"usage_gpu,omitempty"`
+
 	*wrapper.Options
 }
 
+// Step is one of the processes that entrypoint runs in order.
+type Step struct {
+	// Name identifies the step in the log and in the step results.
+	Name string `json:"name"`
+	// Args is the process and args to run.
+	Args []string `json:"args"`
+	// Timeout determines how long to wait before sending
+	// SIGINT to the process. The step is bound by the
+	// timeout of the entrypoint regardless.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// AlwaysRun causes the step to run even after a previous
+	// step failed or timed out, or the entrypoint was interrupted.
+	AlwaysRun bool `json:"always_run,omitempty"`
+}
+
 // Validate ensures that the set of options are
 // self-consistent and valid
 func (o *Options) Validate() error {
-	if len(o.Args) == 0 {
+	if len(o.Args) == 0 && len(o.Steps) == 0 {
 		return errors.New("no process to wrap specified")
 	}
+	if len(o.Args) > 0 && len(o.Steps) > 0 {
+		return errors.New("cannot wrap both a process and steps")
+	}
+	for i, step := range o.Steps {
+		if step.Name == "" {
+			return fmt.Errorf("step %d has no name", i)
+		}
+		if len(step.Args) == 0 {
+			return fmt.Errorf("step %q has no process to wrap", step.Name)
+		}
+	}
 	if o.PropagateErrorCode && o.AlwaysZero {
 		return errors.New("cannot propagate error code and always exit zero")
 	}
@@ -49,6 +49,51 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "steps ok",
+			input: Options{
+				Steps: []Step{{Name: "test", Args: []string{"/usr/bin/true"}}},
+				Options: &wrapper.Options{
+					ProcessLog: "output.txt",
+					MarkerFile: "marker.txt",
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "both args and steps",
+			input: Options{
+				Steps: []Step{{Name: "test", Args: []string{"/usr/bin/true"}}},
+				Options: &wrapper.Options{
+					Args:       []string{"/usr/bin/true"},
+					ProcessLog: "output.txt",
+					MarkerFile: "marker.txt",
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "step without args",
+			input: Options{
+				Steps: []Step{{Name: "test"}},
+				Options: &wrapper.Options{
+					ProcessLog: "output.txt",
+					MarkerFile: "marker.txt",
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "step without name",
+			input: Options{
+				Steps: []Step{{Args: []string{"/usr/bin/true"}}},
+				Options: &wrapper.Options{
+					ProcessLog: "output.txt",
+					MarkerFile: "marker.txt",
+				},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
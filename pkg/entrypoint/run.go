@@ -32,6 +32,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/prow/pkg/pod-utils/usage"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 )
 
@@ -138,24 +139,56 @@ func (o Options) ExecuteProcess(signaledInterrupt chan os.Signal) (int, error) {
 		}
 	}
 
-	executable := o.Args[0]
+	if o.UsageFile != "" {
+		defer o.sampleUsage()()
+	}
+
+	if len(o.Steps) > 0 {
+		return o.executeSteps(output, processLogFile, interrupt)
+	}
+	timeout := optionOrDefault(o.Timeout, DefaultTimeout)
+	gracePeriod := optionOrDefault(o.GracePeriod, DefaultGracePeriod)
+	return o.runCommand(o.Args, output, processLogFile, timeout, gracePeriod, interrupt)
+}
+
+// sampleUsage samples the resource usage of the container until the returned
+// function is called, which writes the samples to the usage file. The usage
+// is sampled here rather than in the sidecar, which runs in a container of
+// its own.
+func (o Options) sampleUsage() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	sampled := make(chan usage.Usage)
+	go func() {
+		sampler := usage.NewSampler(usage.DefaultCgroupRoot, o.UsageGPU)
+		sampled <- sampler.Run(ctx, optionOrDefault(o.UsageInterval, usage.DefaultInterval))
+	}()
+	return func() {
+		cancel()
+		if err := usage.Write(o.UsageFile, <-sampled); err != nil {
+			logrus.WithError(err).Warn("Failed to write resource usage.")
+		}
+	}
+}
+
+// runCommand executes the process, terminating it if it does not finish
+// before the timeout or the entrypoint is interrupted.
+func (o Options) runCommand(args []string, output, processLog io.Writer, timeout, gracePeriod time.Duration, interrupt <-chan os.Signal) (int, error) {
+	executable := args[0]
 	var arguments []string
-	if len(o.Args) > 1 {
-		arguments = o.Args[1:]
+	if len(args) > 1 {
+		arguments = args[1:]
 	}
 	command := exec.Command(executable, arguments...)
 	command.Stderr = output
 	command.Stdout = output
 	if err := command.Start(); err != nil {
 		errs := []error{fmt.Errorf("could not start the process: %w", err)}
-		if _, err := processLogFile.Write([]byte(errs[0].Error())); err != nil {
+		if _, err := processLog.Write([]byte(errs[0].Error())); err != nil {
 			errs = append(errs, err)
 		}
 		return InternalErrorCode, utilerrors.NewAggregate(errs)
 	}
 
-	timeout := optionOrDefault(o.Timeout, DefaultTimeout)
-	gracePeriod := optionOrDefault(o.GracePeriod, DefaultGracePeriod)
 	var commandErr error
 	cancelled, aborted := false, false
 	done := make(chan error)
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/pod-utils/usage"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 )
 
@@ -246,6 +247,27 @@ wait`},
 	}
 }
 
+func TestOptions_RunWritesUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+	options := Options{
+		Options: &wrapper.Options{
+			Args:       []string{"true"},
+			ProcessLog: path.Join(tmpDir, "process-log.txt"),
+			MarkerFile: path.Join(tmpDir, "marker-file.txt"),
+			UsageFile:  path.Join(tmpDir, "usage.json"),
+		},
+		UsageInterval: 10 * time.Millisecond,
+	}
+	if code := options.internalRun(make(chan os.Signal, 1)); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	// Whether samples were taken depends on the cgroup the test runs in, but
+	// the usage file must be written before the marker file either way.
+	if u, err := usage.Read(options.UsageFile); err != nil || u == nil {
+		t.Errorf("expected usage to be written, got %v and error %v", u, err)
+	}
+}
+
 func compareFileContents(name, file, expected string, t *testing.T) {
 	data, err := os.ReadFile(file)
 	if err != nil {
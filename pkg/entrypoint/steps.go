@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StepResult records how a step ran.
+type StepResult struct {
+	Name     string `json:"name"`
+	ExitCode int    `json:"exit_code"`
+	// Skipped is set when the step did not run because a previous
+	// step failed or timed out, or the entrypoint was interrupted.
+	Skipped bool `json:"skipped,omitempty"`
+	// TimedOut is set when the step was terminated after its timeout
+	// or the timeout of the entrypoint was reached.
+	TimedOut bool `json:"timed_out,omitempty"`
+	// Aborted is set when the step was terminated because the
+	// entrypoint was interrupted.
+	Aborted bool `json:"aborted,omitempty"`
+	// DurationSeconds is how long the step ran.
+	DurationSeconds int `json:"duration_seconds"`
+}
+
+// executeSteps runs the steps in order and returns the exit code and error of
+// the first step that did not succeed. Once a step fails or times out, or the
+// entrypoint is interrupted, only the steps that always run are executed. The
+// steps are bound by the timeout of the entrypoint, except for the steps that
+// always run once it was reached or the entrypoint was interrupted: those are
+// bound by their own timeout or, without one, the grace period.
+func (o Options) executeSteps(output, processLog io.Writer, interrupt <-chan os.Signal) (int, error) {
+	timeout := optionOrDefault(o.Timeout, DefaultTimeout)
+	gracePeriod := optionOrDefault(o.GracePeriod, DefaultGracePeriod)
+	deadline := time.Now().Add(timeout)
+
+	var (
+		code    int
+		err     error
+		results []StepResult
+		// failed is set once a step did not succeed.
+		failed bool
+		// terminating is set once the timeout of the entrypoint
+		// was reached or the entrypoint was interrupted.
+		terminating bool
+	)
+	for _, step := range o.Steps {
+		remaining := time.Until(deadline)
+		if rounded := remaining.Round(time.Second); rounded > 0 {
+			// keep the timeouts of the steps legible in the log
+			remaining = rounded
+		}
+		if remaining <= 0 && !terminating {
+			logrus.Errorf("Steps did not finish before %s timeout", timeout)
+			terminating = true
+			if !failed {
+				failed = true
+				code, err = InternalErrorCode, errTimedOut
+			}
+		}
+		if failed && !step.AlwaysRun {
+			logrus.Infof("Skipping step %q", step.Name)
+			results = append(results, StepResult{Name: step.Name, ExitCode: PreviousErrorCode, Skipped: true})
+			continue
+		}
+
+		stepTimeout := step.Timeout
+		switch {
+		case terminating && stepTimeout == 0:
+			stepTimeout = gracePeriod
+		case !terminating && (stepTimeout == 0 || stepTimeout > remaining):
+			stepTimeout = remaining
+		}
+
+		logrus.Infof("Running step %q", step.Name)
+		start := time.Now()
+		stepCode, stepErr := o.runCommand(step.Args, output, processLog, stepTimeout, gracePeriod, interrupt)
+		result := StepResult{
+			Name:            step.Name,
+			ExitCode:        stepCode,
+			TimedOut:        stepErr == errTimedOut,
+			Aborted:         stepErr == errAborted,
+			DurationSeconds: int(time.Since(start).Seconds()),
+		}
+		results = append(results, result)
+		if result.Aborted {
+			terminating = true
+		}
+		if (stepCode != 0 || stepErr != nil) && !failed {
+			failed = true
+			code, err = stepCode, fmt.Errorf("step %q: %w", step.Name, stepErr)
+		}
+	}
+
+	if writeErr := o.writeStepResults(results); writeErr != nil {
+		logrus.WithError(writeErr).Error("Error writing step results")
+	}
+	return code, err
+}
+
+func (o Options) writeStepResults(results []StepResult) error {
+	if o.StepsFile == "" {
+		return nil
+	}
+	content, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("could not marshal step results: %w", err)
+	}
+	if err := os.WriteFile(o.StepsFile, content, 0644); err != nil {
+		return fmt.Errorf("could not write step results (%s): %w", o.StepsFile, err)
+	}
+	return nil
+}
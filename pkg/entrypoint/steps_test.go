@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
+)
+
+func TestOptions_RunSteps(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		steps           []Step
+		interrupt       bool
+		timeout         time.Duration
+		gracePeriod     time.Duration
+		expectedLog     string
+		expectedMarker  string
+		expectedCode    int
+		expectedResults []StepResult
+	}{
+		{
+			name: "all steps pass",
+			steps: []Step{
+				{Name: "setup", Args: []string{"echo", "setup"}},
+				{Name: "test", Args: []string{"echo", "test"}},
+			},
+			expectedLog:    "level=info msg=\"Running step \\\"setup\\\"\"\nsetup\nlevel=info msg=\"Running step \\\"test\\\"\"\ntest\n",
+			expectedMarker: "0",
+			expectedCode:   0,
+			expectedResults: []StepResult{
+				{Name: "setup"},
+				{Name: "test"},
+			},
+		},
+		{
+			name: "failing step skips the following steps unless they always run",
+			steps: []Step{
+				{Name: "setup", Args: []string{"sh", "-c", "exit 3"}},
+				{Name: "test", Args: []string{"echo", "test"}},
+				{Name: "teardown", Args: []string{"sh", "-c", "echo teardown; exit 4"}, AlwaysRun: true},
+			},
+			expectedLog:    "level=info msg=\"Running step \\\"setup\\\"\"\nlevel=info msg=\"Skipping step \\\"test\\\"\"\nlevel=info msg=\"Running step \\\"teardown\\\"\"\nteardown\n",
+			expectedMarker: "3",
+			expectedCode:   3,
+			expectedResults: []StepResult{
+				{Name: "setup", ExitCode: 3},
+				{Name: "test", ExitCode: PreviousErrorCode, Skipped: true},
+				{Name: "teardown", ExitCode: 4},
+			},
+		},
+		{
+			name: "step times out",
+			steps: []Step{
+				{Name: "test", Args: []string{"sleep", "10"}, Timeout: time.Second},
+				{Name: "teardown", Args: []string{"echo", "teardown"}, AlwaysRun: true},
+			},
+			gracePeriod:    time.Second,
+			expectedLog:    "level=info msg=\"Running step \\\"test\\\"\"\nlevel=error msg=\"Process did not finish before 1s timeout\"\nlevel=error msg=\"Process gracefully exited before 1s grace period\"\nlevel=info msg=\"Running step \\\"teardown\\\"\"\nteardown\n",
+			expectedMarker: strconv.Itoa(InternalErrorCode),
+			expectedCode:   InternalErrorCode,
+			expectedResults: []StepResult{
+				{Name: "test", ExitCode: InternalErrorCode, TimedOut: true},
+				{Name: "teardown"},
+			},
+		},
+		{
+			name: "steps that always run still run after the timeout",
+			steps: []Step{
+				{Name: "test", Args: []string{"sleep", "10"}},
+				{Name: "report", Args: []string{"echo", "report"}},
+				{Name: "teardown", Args: []string{"echo", "teardown"}, AlwaysRun: true},
+			},
+			timeout:        time.Second,
+			gracePeriod:    time.Second,
+			expectedLog:    "level=info msg=\"Running step \\\"test\\\"\"\nlevel=error msg=\"Process did not finish before 1s timeout\"\nlevel=error msg=\"Process gracefully exited before 1s grace period\"\nlevel=error msg=\"Steps did not finish before 1s timeout\"\nlevel=info msg=\"Skipping step \\\"report\\\"\"\nlevel=info msg=\"Running step \\\"teardown\\\"\"\nteardown\n",
+			expectedMarker: strconv.Itoa(InternalErrorCode),
+			expectedCode:   InternalErrorCode,
+			expectedResults: []StepResult{
+				{Name: "test", ExitCode: InternalErrorCode, TimedOut: true},
+				{Name: "report", ExitCode: PreviousErrorCode, Skipped: true},
+				{Name: "teardown"},
+			},
+		},
+		{
+			name: "steps that always run still run after an interrupt",
+			steps: []Step{
+				{Name: "test", Args: []string{"sleep", "10"}},
+				{Name: "teardown", Args: []string{"echo", "teardown"}, AlwaysRun: true},
+			},
+			interrupt:      true,
+			gracePeriod:    time.Second,
+			expectedLog:    "level=info msg=\"Running step \\\"test\\\"\"\nlevel=error msg=\"Entrypoint received interrupt: terminated\"\nlevel=error msg=\"Process gracefully exited before 1s grace period\"\nlevel=info msg=\"Running step \\\"teardown\\\"\"\nteardown\n",
+			expectedMarker: strconv.Itoa(AbortedErrorCode),
+			expectedCode:   AbortedErrorCode,
+			expectedResults: []StepResult{
+				{Name: "test", ExitCode: AbortedErrorCode, Aborted: true},
+				{Name: "teardown"},
+			},
+		},
+	}
+
+	// we cannot match text with timestamps
+	logrus.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			interrupt := make(chan os.Signal, 1)
+
+			options := Options{
+				Timeout:     testCase.timeout,
+				GracePeriod: testCase.gracePeriod,
+				Steps:       testCase.steps,
+				Options: &wrapper.Options{
+					ProcessLog: path.Join(tmpDir, "process-log.txt"),
+					MarkerFile: path.Join(tmpDir, "marker-file.txt"),
+					StepsFile:  path.Join(tmpDir, "steps.json"),
+				},
+			}
+
+			if testCase.interrupt {
+				go func() {
+					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					defer cancel()
+					// sync with the first step to ensure that its process has already started
+					if err := waitForFileToBeWritten(ctx, options.ProcessLog); err != nil {
+						t.Errorf("failed to wait for file: %v", err)
+					}
+					time.Sleep(500 * time.Millisecond)
+					interrupt <- syscall.SIGTERM
+				}()
+			}
+
+			if code := options.internalRun(interrupt); code != testCase.expectedCode {
+				t.Errorf("expected exit code %d != actual %d", testCase.expectedCode, code)
+			}
+
+			compareFileContents(testCase.name, options.ProcessLog, testCase.expectedLog, t)
+			compareFileContents(testCase.name, options.MarkerFile, testCase.expectedMarker, t)
+
+			raw, err := os.ReadFile(options.StepsFile)
+			if err != nil {
+				t.Fatalf("could not read step results: %v", err)
+			}
+			var results []StepResult
+			if err := json.Unmarshal(raw, &results); err != nil {
+				t.Fatalf("could not unmarshal step results: %v", err)
+			}
+			if diff := cmp.Diff(testCase.expectedResults, results, cmpopts.IgnoreFields(StepResult{}, "DurationSeconds")); diff != "" {
+				t.Errorf("step results differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -40,6 +40,9 @@ type ConfigOptions struct {
 	JobConfigPathFlagName                 string
 	SupplementalProwConfigDirs            flagutil.Strings
 	SupplementalProwConfigsFileNameSuffix string
+	// ConfigOverlays are merged into the prow config, in order. They allow to
+	// describe the differences between environments like staging and prod.
+	ConfigOverlays flagutil.Strings
 	// Inrepoconfig related flags
 	InRepoConfigCacheSize    int
 	InRepoConfigCacheDirBase string
@@ -60,6 +63,7 @@ func (o *ConfigOptions) AddFlags(fs *flag.FlagSet) {
 	fs.Var(&o.SupplementalProwConfigDirs, "supplemental-prow-config-dir", "An additional directory from which to load prow configs. Can be used for config sharding but only supports a subset of the config. The flag can be passed multiple times.")
 	fs.StringVar(&o.SupplementalProwConfigsFileNameSuffix, "supplemental-prow-configs-filename", "_prowconfig.yaml", "Suffix for additional prow configs. Only files with this name will be considered. Deprecated and mutually exclusive with --supplemental-prow-configs-filename-suffix")
 	fs.StringVar(&o.SupplementalProwConfigsFileNameSuffix, "supplemental-prow-configs-filename-suffix", "_prowconfig.yaml", "Suffix for additional prow configs. Only files with this name will be considered")
+	fs.Var(&o.ConfigOverlays, "config-overlay", "Path to an overlay that is merged into the prowconfig with JSON merge patch semantics: maps are merged, other values replaced and null removes a key. The flag can be passed multiple times, overlays are applied in order.")
 	fs.IntVar(&o.InRepoConfigCacheSize, "in-repo-config-cache-size", 200, "Cache size for ProwYAMLs read from in-repo configs.")
 	fs.StringVar(&o.InRepoConfigCacheDirBase, "cache-dir-base", "", "Directory where the repo cache should be mounted.")
 	fs.StringVar(&o.MoonrakerAddress, "moonraker-address", "", "full HTTP address (domain and port) of moonraker service")
@@ -76,6 +80,9 @@ func (o *ConfigOptions) ValidateConfigOptional() error {
 	if o.JobConfigPath != "" && o.ConfigPath == "" {
 		return fmt.Errorf("if --%s is given, --%s must be given as well", o.JobConfigPathFlagName, o.ConfigPathFlagName)
 	}
+	if len(o.ConfigOverlays.Strings()) > 0 && o.ConfigPath == "" {
+		return fmt.Errorf("if --config-overlay is given, --%s must be given as well", o.ConfigPathFlagName)
+	}
 	return nil
 }
 
@@ -92,5 +99,6 @@ func (o *ConfigOptions) ConfigAgent(reuse ...*config.Agent) (*config.Agent, erro
 }
 
 func (o *ConfigOptions) ConfigAgentWithAdditionals(ca *config.Agent, additionals []func(*config.Config) error) (*config.Agent, error) {
+	ca.SetOverlays(o.ConfigOverlays.Strings()...)
 	return ca, ca.Start(o.ConfigPath, o.JobConfigPath, o.SupplementalProwConfigDirs.Strings(), o.SupplementalProwConfigsFileNameSuffix, additionals...)
 }
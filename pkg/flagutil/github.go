@@ -29,6 +29,8 @@ import (
 	"github.com/dgrijalva/jwt-go/v4"
 	"github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
 
 	"sigs.k8s.io/prow/pkg/config/secret"
 	gitv2 "sigs.k8s.io/prow/pkg/git/v2"
@@ -55,6 +57,9 @@ type GitHubOptions struct {
 	OrgThrottlers       Strings
 	parsedOrgThrottlers map[string]throttlerSettings
 
+	OrgApps       Strings
+	parsedOrgApps []orgAppSettings
+
 	// These will only be set after a github client was retrieved for the first time
 	tokenGenerator github.TokenGenerator
 	userGenerator  github.UserGenerator
@@ -65,6 +70,14 @@ type GitHubOptions struct {
 	max404Retries  int
 	initialDelay   time.Duration
 	maxSleepTime   time.Duration
+
+	// etagCacheSize is the number of responses the client keeps to make
+	// conditional requests with, zero disables it
+	etagCacheSize int
+
+	// enterpriseVersion is the version of GitHub Enterprise Server, which is
+	// detected if unset
+	enterpriseVersion string
 }
 
 type throttlerSettings struct {
@@ -72,6 +85,12 @@ type throttlerSettings struct {
 	burst        int
 }
 
+type orgAppSettings struct {
+	appID          string
+	privateKeyPath string
+	orgs           []string
+}
+
 // flagParams struct is used indirectly by users of this package to customize
 // the common flags behavior, such as providing their own default values
 // or suppressing presence of certain flags.
@@ -135,6 +154,7 @@ func (o *GitHubOptions) addFlags(fs *flag.FlagSet, paramFuncs ...FlagParameter)
 	fs.StringVar(&o.TokenPath, "github-token-path", defaults.TokenPath, "Path to the file containing the GitHub OAuth secret.")
 	fs.StringVar(&o.AppID, "github-app-id", defaults.AppID, "ID of the GitHub app. If set, requires --github-app-private-key-path to be set and --github-token-path to be unset.")
 	fs.StringVar(&o.AppPrivateKeyPath, "github-app-private-key-path", defaults.AppPrivateKeyPath, "Path to the private key of the github app. If set, requires --github-app-id to bet set and --github-token-path to be unset")
+	fs.Var(&o.OrgApps, "github-app-org", "GitHub app to use for a specific org instead of the one of --github-app-id, in org:appID:privateKeyPath format. Can be passed multiple times. Only valid when using github apps auth.")
 
 	if !params.disableThrottlerOptions {
 		fs.IntVar(&o.ThrottleHourlyTokens, "github-hourly-tokens", defaults.ThrottleHourlyTokens, "If set to a value larger than zero, enable client-side throttling to limit hourly token consumption. If set, --github-allowed-burst must be positive too.")
@@ -147,6 +167,8 @@ func (o *GitHubOptions) addFlags(fs *flag.FlagSet, paramFuncs ...FlagParameter)
 	fs.IntVar(&o.max404Retries, "github-client.max-404-retries", github.DefaultMax404Retries, "Maximum number of retries that will be used for a 404-ing request to the GitHub API.")
 	fs.DurationVar(&o.maxSleepTime, "github-client.backoff-timeout", github.DefaultMaxSleepTime, "Largest allowable Retry-After time for requests to the GitHub API.")
 	fs.DurationVar(&o.initialDelay, "github-client.initial-delay", github.DefaultInitialDelay, "Initial delay before retries begin for requests to the GitHub API.")
+	fs.StringVar(&o.enterpriseVersion, "github-client.enterprise-version", "", "Version of the GitHub Enterprise Server, e.g. 3.10. APIs it lacks aren't used. Detected if unset.")
+	fs.IntVar(&o.etagCacheSize, "github-client.etag-cache-size", 0, "Number of responses to keep in memory to make conditional requests to the GitHub API with their ETags. Useful when ghproxy can't be used, zero disables it.")
 }
 
 func (o *GitHubOptions) parseOrgThrottlers() error {
@@ -199,6 +221,52 @@ func (o *GitHubOptions) parseOrgThrottlers() error {
 	return utilerrors.NewAggregate(errs)
 }
 
+func (o *GitHubOptions) parseOrgApps() error {
+	if len(o.OrgApps.vals) == 0 {
+		return nil
+	}
+
+	if o.AppID == "" {
+		return errors.New("--github-app-org was passed, but client doesn't use apps auth")
+	}
+
+	o.parsedOrgApps = nil
+	apps := map[string]int{}
+	orgs := sets.New[string]()
+	var errs []error
+	for _, orgApp := range o.OrgApps.vals {
+		// The path may contain colons
+		colonSplit := strings.SplitN(orgApp, ":", 3)
+		if len(colonSplit) != 3 || colonSplit[0] == "" || colonSplit[1] == "" || colonSplit[2] == "" {
+			errs = append(errs, fmt.Errorf("-github-app-org=%s is not in org:appID:privateKeyPath format", orgApp))
+			continue
+		}
+		org, appID, privateKeyPath := colonSplit[0], colonSplit[1], colonSplit[2]
+		if orgs.Has(strings.ToLower(org)) {
+			errs = append(errs, fmt.Errorf("got multiple -github-app-org for the %s org", org))
+			continue
+		}
+		orgs.Insert(strings.ToLower(org))
+		if appID == o.AppID {
+			errs = append(errs, fmt.Errorf("-github-app-org=%s: app %s is the one of --github-app-id already", orgApp, appID))
+			continue
+		}
+		i, found := apps[appID]
+		if !found {
+			apps[appID] = len(o.parsedOrgApps)
+			o.parsedOrgApps = append(o.parsedOrgApps, orgAppSettings{appID: appID, privateKeyPath: privateKeyPath, orgs: []string{org}})
+			continue
+		}
+		if o.parsedOrgApps[i].privateKeyPath != privateKeyPath {
+			errs = append(errs, fmt.Errorf("-github-app-org=%s: got multiple private key paths for app %s", orgApp, appID))
+			continue
+		}
+		o.parsedOrgApps[i].orgs = append(o.parsedOrgApps[i].orgs, org)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
 // Validate validates GitHub options. Note that validate updates the GitHubOptions
 // to add default values for TokenPath and graphqlEndpoint.
 func (o *GitHubOptions) Validate(bool) error {
@@ -228,6 +296,25 @@ func (o *GitHubOptions) Validate(bool) error {
 		return fmt.Errorf("invalid -github-graphql-endpoint URI: %q", o.graphqlEndpoint)
 	}
 
+	if err := o.validateThrottling(); err != nil {
+		return err
+	}
+	if o.enterpriseVersion != "" {
+		if _, err := version.ParseGeneric(o.enterpriseVersion); err != nil {
+			return fmt.Errorf("invalid --github-client.enterprise-version: %w", err)
+		}
+	}
+	if o.etagCacheSize < 0 {
+		return errors.New("--github-client.etag-cache-size must not be negative")
+	}
+
+	if err := o.parseOrgThrottlers(); err != nil {
+		return err
+	}
+	return o.parseOrgApps()
+}
+
+func (o *GitHubOptions) validateThrottling() error {
 	if (o.ThrottleHourlyTokens > 0) != (o.ThrottleAllowBurst > 0) {
 		if o.ThrottleHourlyTokens == 0 {
 			// Tolerate `--github-hourly-tokens=0` alone to disable throttling
@@ -239,8 +326,19 @@ func (o *GitHubOptions) Validate(bool) error {
 	if o.ThrottleAllowBurst > o.ThrottleHourlyTokens {
 		return errors.New("--github-allowed-burst must not be larger than --github-hourly-tokens")
 	}
+	return nil
+}
 
-	return o.parseOrgThrottlers()
+// AddRuntimeThrottling allows to change --github-hourly-tokens and
+// --github-allowed-burst of the given client at runtime. The throttlers
+// of --github-throttle-org are not affected.
+func (o *GitHubOptions) AddRuntimeThrottling(r *RuntimeFlags, client github.Client) error {
+	return r.OnChange(func() error {
+		if err := o.validateThrottling(); err != nil {
+			return err
+		}
+		return client.Throttle(o.ThrottleHourlyTokens, o.ThrottleAllowBurst)
+	}, "github-hourly-tokens", "github-allowed-burst")
 }
 
 // GitHubClientWithLogFields returns a GitHub client with extra logging fields
@@ -273,12 +371,27 @@ func (o *GitHubOptions) githubClient(dryRun bool) (github.Client, error) {
 	}
 
 	if o.AppPrivateKeyPath != "" {
-		apk, err := o.appPrivateKeyGenerator()
+		apk, err := appPrivateKeyGenerator(o.AppPrivateKeyPath)
 		if err != nil {
 			return nil, err
 		}
 		options.AppPrivateKey = apk
 	}
+	for _, app := range o.parsedOrgApps {
+		apk, err := appPrivateKeyGenerator(app.privateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		options.OrgApps = append(options.OrgApps, github.OrgApp{ID: app.appID, PrivateKey: apk, Orgs: app.orgs})
+	}
+
+	if o.etagCacheSize > 0 {
+		store, err := github.NewMemoryETagStore(o.etagCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ETag store: %w", err)
+		}
+		options.ETagStore = store
+	}
 
 	optionallyThrottled := func(c github.Client) (github.Client, error) {
 		// Throttle handles zeros as "disable throttling" so we do not need to call it conditionally
@@ -305,15 +418,16 @@ func (o *GitHubOptions) githubClient(dryRun bool) (github.Client, error) {
 // baseClientOptions populates client options that are derived from flags without processing
 func (o *GitHubOptions) baseClientOptions() github.ClientOptions {
 	return github.ClientOptions{
-		Censor:          secret.Censor,
-		AppID:           o.AppID,
-		GraphqlEndpoint: o.graphqlEndpoint,
-		Bases:           o.endpoint.Strings(),
-		MaxRequestTime:  o.maxRequestTime,
-		InitialDelay:    o.initialDelay,
-		MaxSleepTime:    o.maxSleepTime,
-		MaxRetries:      o.maxRetries,
-		Max404Retries:   o.max404Retries,
+		Censor:            secret.Censor,
+		AppID:             o.AppID,
+		GraphqlEndpoint:   o.graphqlEndpoint,
+		Bases:             o.endpoint.Strings(),
+		MaxRequestTime:    o.maxRequestTime,
+		InitialDelay:      o.initialDelay,
+		MaxSleepTime:      o.maxSleepTime,
+		MaxRetries:        o.maxRetries,
+		Max404Retries:     o.max404Retries,
+		EnterpriseVersion: o.enterpriseVersion,
 	}
 }
 
@@ -379,9 +493,9 @@ func (o *GitHubOptions) getGitHubAuthentication(dryRun bool) (string, gitv2.Toke
 	return login, gitv2.TokenGetter(o.tokenGenerator), nil
 }
 
-func (o *GitHubOptions) appPrivateKeyGenerator() (func() *rsa.PrivateKey, error) {
+func appPrivateKeyGenerator(path string) (func() *rsa.PrivateKey, error) {
 	generator, err := secret.AddWithParser(
-		o.AppPrivateKeyPath,
+		path,
 		func(raw []byte) (*rsa.PrivateKey, error) {
 			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(raw)
 			if err != nil {
@@ -391,7 +505,7 @@ func (o *GitHubOptions) appPrivateKeyGenerator() (func() *rsa.PrivateKey, error)
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add the key from --app-private-key-path to secret agent: %w", err)
+		return nil, fmt.Errorf("failed to add the app private key from %s to secret agent: %w", path, err)
 	}
 
 	return generator, nil
@@ -98,6 +98,14 @@ func TestGitHubOptions_Validate(t *testing.T) {
 			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
 			expectedErr:             false,
 		},
+		{
+			name: "negative --github-client.etag-cache-size: error",
+			in: &GitHubOptions{
+				etagCacheSize: -1,
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+			expectedErr:             true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -289,3 +297,93 @@ func TestOrgThottlerOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestOrgAppOptions(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name       string
+		parameters []string
+
+		expectedErrorMsg      string
+		expectedParsedOrgApps []orgAppSettings
+	}{
+		{
+			name: "No org app, success",
+		},
+		{
+			name:             "Invalid format, a colon too little",
+			parameters:       []string{"--github-app-org=kubernetes:11"},
+			expectedErrorMsg: "-github-app-org=kubernetes:11 is not in org:appID:privateKeyPath format",
+		},
+		{
+			name:             "Invalid format, empty app ID",
+			parameters:       []string{"--github-app-org=kubernetes::/path"},
+			expectedErrorMsg: "-github-app-org=kubernetes::/path is not in org:appID:privateKeyPath format",
+		},
+		{
+			name:             "Invalid, app of --github-app-id",
+			parameters:       []string{"--github-app-org=kubernetes:10:/path"},
+			expectedErrorMsg: "-github-app-org=kubernetes:10:/path: app 10 is the one of --github-app-id already",
+		},
+		{
+			name: "Invalid, multiple apps for same org",
+			parameters: []string{
+				"--github-app-org=kubernetes:11:/path",
+				"--github-app-org=Kubernetes:12:/other/path",
+			},
+			expectedErrorMsg: "got multiple -github-app-org for the Kubernetes org",
+		},
+		{
+			name: "Invalid, multiple private keys for same app",
+			parameters: []string{
+				"--github-app-org=kubernetes:11:/path",
+				"--github-app-org=kubernetes-sigs:11:/other/path",
+			},
+			expectedErrorMsg: "-github-app-org=kubernetes-sigs:11:/other/path: got multiple private key paths for app 11",
+		},
+		{
+			name: "Valid settings for multiple orgs, success",
+			parameters: []string{
+				"--github-app-org=kubernetes:11:/path",
+				"--github-app-org=kubernetes-sigs:11:/path",
+				"--github-app-org=ghe:12:/other/path:with:colons",
+			},
+			expectedParsedOrgApps: []orgAppSettings{
+				{appID: "11", privateKeyPath: "/path", orgs: []string{"kubernetes", "kubernetes-sigs"}},
+				{appID: "12", privateKeyPath: "/other/path:with:colons", orgs: []string{"ghe"}},
+			},
+		},
+	}
+
+	exportOrgAppSettings := cmp.Exporter(func(t reflect.Type) bool {
+		return t == reflect.TypeOf(orgAppSettings{})
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet(tc.name, flag.ContinueOnError)
+			opts := &GitHubOptions{}
+			opts.AddFlags(fs)
+			if err := fs.Parse(tc.parameters); err != nil {
+				t.Fatalf("flag parsing failed: %v", err)
+			}
+			opts.AppID = "10"
+			opts.AppPrivateKeyPath = "/test/path"
+
+			var actualErrMsg string
+			if actualErr := opts.Validate(false); actualErr != nil {
+				actualErrMsg = actualErr.Error()
+			}
+			if actualErrMsg != tc.expectedErrorMsg {
+				t.Fatalf("actual error %s does not match expected error %s", actualErrMsg, tc.expectedErrorMsg)
+			}
+			if actualErrMsg != "" {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expectedParsedOrgApps, opts.parsedOrgApps, exportOrgAppSettings); diff != "" {
+				t.Errorf("expected org apps differ from actual: %s", diff)
+			}
+		})
+	}
+}
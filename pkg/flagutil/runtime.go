@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/interrupts"
+)
+
+// RuntimeFlagsOptions holds the directory of a mounted ConfigMap whose
+// entries override some flags of a component while it is running.
+type RuntimeFlagsOptions struct {
+	// Dir is the directory the ConfigMap is mounted at. The name of
+	// every file in it is a flag name and its content the flag value.
+	Dir string
+}
+
+// AddFlags injects runtime flags options into the given FlagSet.
+func (o *RuntimeFlagsOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Dir, "runtime-flags-dir", "", "Directory of a mounted ConfigMap whose keys are names of flags that can be changed at runtime, e.g. github-hourly-tokens, and whose values override them. Disabled if unset.")
+}
+
+// Validate validates runtime flags options.
+func (o *RuntimeFlagsOptions) Validate(_ bool) error {
+	if o.Dir == "" {
+		return nil
+	}
+	info, err := os.Stat(o.Dir)
+	if err != nil {
+		return fmt.Errorf("--runtime-flags-dir: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("--runtime-flags-dir: %s is not a directory", o.Dir)
+	}
+	return nil
+}
+
+// RuntimeFlags returns the runtime flags of the given, already parsed FlagSet.
+func (o *RuntimeFlagsOptions) RuntimeFlags(fs *flag.FlagSet) *RuntimeFlags {
+	return &RuntimeFlags{
+		dir:      o.Dir,
+		fs:       fs,
+		defaults: map[string]string{},
+	}
+}
+
+// RuntimeFlags applies the entries of a mounted ConfigMap to the flags that
+// were registered as reloadable with OnChange. Flags whose entry is removed
+// are reset to the value they had when they were registered. Flags that can
+// be passed multiple times are not supported.
+type RuntimeFlags struct {
+	dir string
+	fs  *flag.FlagSet
+
+	lock     sync.Mutex
+	groups   []runtimeFlagGroup
+	defaults map[string]string
+}
+
+type runtimeFlagGroup struct {
+	names    []string
+	onChange func() error
+}
+
+// OnChange registers the named flags as reloadable. onChange is called
+// whenever any of them changed, after all of them were updated. If it
+// fails, the flags are reset to their previous values.
+func (r *RuntimeFlags) OnChange(onChange func() error, names ...string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, name := range names {
+		f := r.fs.Lookup(name)
+		if f == nil {
+			return fmt.Errorf("flag %s is not defined", name)
+		}
+		if _, registered := r.defaults[name]; registered {
+			return fmt.Errorf("flag %s is already reloadable", name)
+		}
+		r.defaults[name] = f.Value.String()
+	}
+	r.groups = append(r.groups, runtimeFlagGroup{names: names, onChange: onChange})
+	return nil
+}
+
+// Load applies the current content of the ConfigMap. Entries that fail to
+// apply are reported without affecting the others.
+func (r *RuntimeFlags) Load() error {
+	entries, err := readRuntimeFlags(r.dir)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var errs []error
+	var names []string
+	for name := range entries {
+		if _, reloadable := r.defaults[name]; !reloadable {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("flag %s cannot be changed at runtime", name))
+	}
+	for _, group := range r.groups {
+		previous := map[string]string{}
+		for _, name := range group.names {
+			value, ok := entries[name]
+			if !ok {
+				value = r.defaults[name]
+			}
+			current := r.fs.Lookup(name).Value.String()
+			if value == current {
+				continue
+			}
+			if err := r.fs.Set(name, value); err != nil {
+				errs = append(errs, fmt.Errorf("failed to set flag %s: %w", name, err))
+				continue
+			}
+			previous[name] = current
+		}
+		if len(previous) == 0 {
+			continue
+		}
+		if err := group.onChange(); err != nil {
+			for name, value := range previous {
+				// The value was accepted before, so this can't fail.
+				_ = r.fs.Set(name, value)
+			}
+			errs = append(errs, fmt.Errorf("failed to change flags %s: %w", strings.Join(group.names, ", "), err))
+			continue
+		}
+		for name := range previous {
+			logrus.WithField("flag", name).WithField("value", r.fs.Lookup(name).Value.String()).Info("Changed flag at runtime.")
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// Start applies the ConfigMap once and then whenever it is updated, until
+// the process is interrupted. It is a no-op if no directory was configured.
+func (r *RuntimeFlags) Start() error {
+	if r.dir == "" {
+		return nil
+	}
+	if err := r.Load(); err != nil {
+		logrus.WithError(err).Error("Failed to apply runtime flags.")
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := w.Add(r.dir); err != nil {
+		return err
+	}
+	interrupts.Run(func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				if err := w.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close fsnotify watcher for runtime flags.")
+				}
+				return
+			case event := <-w.Events:
+				// A ConfigMap update atomically swaps the ..data symlink, but
+				// plain directories are supported as well. Loading is idempotent
+				// so reacting to every event is fine.
+				if event.Op == fsnotify.Chmod {
+					continue
+				}
+				if err := r.Load(); err != nil {
+					logrus.WithError(err).Error("Failed to apply runtime flags.")
+				}
+			case err := <-w.Errors:
+				logrus.WithError(err).Warn("Received fsnotify error for runtime flags.")
+			}
+		}
+	})
+	return nil
+}
+
+func readRuntimeFlags(dir string) (map[string]string, error) {
+	entries := map[string]string{}
+	if dir == "" {
+		return entries, nil
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runtime flags: %w", err)
+	}
+	for _, file := range files {
+		// ConfigMap mounts contain ..data and timestamped directories next
+		// to the symlinks of the keys.
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read runtime flag %s: %w", file.Name(), err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read runtime flag %s: %w", file.Name(), err)
+		}
+		entries[file.Name()] = strings.TrimSpace(string(value))
+	}
+	return entries, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRuntimeFlagsLoad(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		args      []string
+		entries   []map[string]string
+		failBurst bool
+
+		expectedTokens  int
+		expectedBurst   int
+		expectedLevel   string
+		expectedChanges int
+		expectedErr     bool
+	}{
+		{
+			name:           "no entries keeps the flags",
+			args:           []string{"--tokens=100", "--burst=10"},
+			entries:        []map[string]string{{}},
+			expectedTokens: 100,
+			expectedBurst:  10,
+			expectedLevel:  "info",
+		},
+		{
+			name:            "entries override the flags",
+			args:            []string{"--tokens=100", "--burst=10"},
+			entries:         []map[string]string{{"tokens": "200", "burst": "20\n"}},
+			expectedTokens:  200,
+			expectedBurst:   20,
+			expectedLevel:   "info",
+			expectedChanges: 1,
+		},
+		{
+			name:            "unchanged entries don't call the handler again",
+			args:            []string{"--tokens=100"},
+			entries:         []map[string]string{{"tokens": "200"}, {"tokens": "200"}},
+			expectedTokens:  200,
+			expectedLevel:   "info",
+			expectedChanges: 1,
+		},
+		{
+			name:            "removed entries reset the flags",
+			args:            []string{"--tokens=100", "--level=warn"},
+			entries:         []map[string]string{{"tokens": "200", "level": "debug"}, {}},
+			expectedTokens:  100,
+			expectedLevel:   "warn",
+			expectedChanges: 2,
+		},
+		{
+			name:           "invalid entry is reported",
+			entries:        []map[string]string{{"tokens": "many", "level": "debug"}},
+			expectedLevel:  "debug",
+			expectedErr:    true,
+			expectedTokens: 0,
+		},
+		{
+			name:          "entry of a flag that isn't reloadable is reported",
+			entries:       []map[string]string{{"dry-run": "false", "level": "debug"}},
+			expectedLevel: "debug",
+			expectedErr:   true,
+		},
+		{
+			name:          "failed handler resets the flags",
+			args:          []string{"--tokens=100", "--burst=10"},
+			entries:       []map[string]string{{"tokens": "200", "burst": "20", "level": "debug"}},
+			failBurst:     true,
+			expectedLevel: "debug",
+			// The burst handler is called but fails.
+			expectedTokens:  100,
+			expectedBurst:   10,
+			expectedChanges: 1,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fs := flag.NewFlagSet(tc.name, flag.ContinueOnError)
+			var tokens, burst int
+			var level string
+			var dryRun bool
+			fs.IntVar(&tokens, "tokens", 0, "")
+			fs.IntVar(&burst, "burst", 0, "")
+			fs.StringVar(&level, "level", "info", "")
+			fs.BoolVar(&dryRun, "dry-run", true, "")
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			dir := t.TempDir()
+			o := RuntimeFlagsOptions{Dir: dir}
+			r := o.RuntimeFlags(fs)
+			var changes int
+			if err := r.OnChange(func() error {
+				changes++
+				if tc.failBurst {
+					return errors.New("injected error")
+				}
+				return nil
+			}, "tokens", "burst"); err != nil {
+				t.Fatalf("failed to register flags: %v", err)
+			}
+			if err := r.OnChange(func() error { return nil }, "level"); err != nil {
+				t.Fatalf("failed to register flags: %v", err)
+			}
+
+			var err error
+			for _, entries := range tc.entries {
+				files, readErr := os.ReadDir(dir)
+				if readErr != nil {
+					t.Fatalf("failed to read dir: %v", readErr)
+				}
+				for _, file := range files {
+					if err := os.Remove(filepath.Join(dir, file.Name())); err != nil {
+						t.Fatalf("failed to remove entry: %v", err)
+					}
+				}
+				for key, value := range entries {
+					if err := os.WriteFile(filepath.Join(dir, key), []byte(value), 0644); err != nil {
+						t.Fatalf("failed to write entry: %v", err)
+					}
+				}
+				err = r.Load()
+			}
+
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff([]interface{}{tc.expectedTokens, tc.expectedBurst, tc.expectedLevel, tc.expectedChanges}, []interface{}{tokens, burst, level, changes}); diff != "" {
+				t.Errorf("tokens, burst, level and changes differ from expected: %s", diff)
+			}
+			if !dryRun {
+				t.Error("dry-run was changed although it isn't reloadable")
+			}
+		})
+	}
+}
+
+func TestRuntimeFlagsOnChange(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("tokens", 0, "")
+	r := (&RuntimeFlagsOptions{}).RuntimeFlags(fs)
+	if err := r.OnChange(func() error { return nil }, "missing"); err == nil {
+		t.Error("expected an error for an undefined flag")
+	}
+	if err := r.OnChange(func() error { return nil }, "tokens"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.OnChange(func() error { return nil }, "tokens"); err == nil {
+		t.Error("expected an error for a flag that is already reloadable")
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// sensitiveHeaders are the request headers that are never sent to the
+// authorization webhook.
+var sensitiveHeaders = sets.New[string]("authorization", "cookie", "x-api-key")
+
+// AuthorizationQuery is sent to the authorization webhook. It follows the
+// Open Policy Agent (OPA) Data API, so that the webhook can be an OPA server.
+type AuthorizationQuery struct {
+	Input AuthorizationInput `json:"input"`
+}
+
+// AuthorizationInput describes a request to trigger a Prow Job.
+type AuthorizationInput struct {
+	// Client is the allowed API client the request was identified as.
+	Client *config.AllowedApiClient `json:"client,omitempty"`
+	// Headers are the request headers, without credentials.
+	Headers map[string][]string `json:"headers,omitempty"`
+	// Request is the CreateJobExecutionRequest in its JSON form, e.g.
+	// {"job_name": "my-job", "job_execution_type": "PERIODIC"}.
+	Request json.RawMessage `json:"request"`
+}
+
+// AuthorizationResponse is the response of the authorization webhook.
+type AuthorizationResponse struct {
+	// Result is the decision. An OPA server leaves it unset if the policy
+	// is undefined for the input, which is a denial.
+	Result *AuthorizationResult `json:"result,omitempty"`
+}
+
+// AuthorizationResult is the decision of the authorization webhook.
+type AuthorizationResult struct {
+	Allowed bool `json:"allowed"`
+	// Reason is shown to the client if the request is denied.
+	Reason string `json:"reason,omitempty"`
+}
+
+// authorize asks the authorization webhook, if any, whether the request to
+// trigger a Prow Job is allowed. It returns a gRPC status error if it is not.
+func authorize(ctx context.Context, httpClient *http.Client, webhook *config.GangwayAuthorizationWebhook, allowedApiClient *config.AllowedApiClient, md *metadata.MD, cjer *CreateJobExecutionRequest) error {
+	if webhook == nil {
+		return nil
+	}
+
+	result, err := queryAuthorizationWebhook(ctx, httpClient, webhook, allowedApiClient, md, cjer)
+	if err != nil {
+		if webhook.FailOpen {
+			logrus.WithError(err).WithField("job", cjer.GetJobName()).Warn("Could not authorize request, allowing it because the webhook fails open.")
+			return nil
+		}
+		return status.Errorf(codes.Unavailable, "could not authorize request: %v", err)
+	}
+	if result == nil {
+		return status.Error(codes.PermissionDenied, "request denied by authorization webhook")
+	}
+	if !result.Allowed {
+		if result.Reason != "" {
+			return status.Errorf(codes.PermissionDenied, "request denied by authorization webhook: %s", result.Reason)
+		}
+		return status.Error(codes.PermissionDenied, "request denied by authorization webhook")
+	}
+	return nil
+}
+
+func queryAuthorizationWebhook(ctx context.Context, httpClient *http.Client, webhook *config.GangwayAuthorizationWebhook, allowedApiClient *config.AllowedApiClient, md *metadata.MD, cjer *CreateJobExecutionRequest) (*AuthorizationResult, error) {
+	request, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(cjer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	query := AuthorizationQuery{Input: AuthorizationInput{
+		Client:  allowedApiClient,
+		Request: request,
+	}}
+	if md != nil {
+		query.Input.Headers = make(map[string][]string)
+		for header, values := range *md {
+			if !sensitiveHeaders.Has(header) {
+				query.Input.Headers[header] = values
+			}
+		}
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhook.GetTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webhook responded with %d: %s", resp.StatusCode, respBody)
+	}
+	var response AuthorizationResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return response.Result, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestAuthorize(t *testing.T) {
+	testCases := []struct {
+		name         string
+		webhook      *config.GangwayAuthorizationWebhook
+		response     string
+		statusCode   int
+		delay        time.Duration
+		expectedCode codes.Code
+	}{
+		{
+			name:         "no webhook",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "allowed",
+			webhook:      &config.GangwayAuthorizationWebhook{},
+			response:     `{"result": {"allowed": true}}`,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "denied",
+			webhook:      &config.GangwayAuthorizationWebhook{},
+			response:     `{"result": {"allowed": false, "reason": "no overrides allowed"}}`,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "undefined policy",
+			webhook:      &config.GangwayAuthorizationWebhook{},
+			response:     `{}`,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "webhook error",
+			webhook:      &config.GangwayAuthorizationWebhook{},
+			statusCode:   http.StatusInternalServerError,
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name:         "webhook error with fail open",
+			webhook:      &config.GangwayAuthorizationWebhook{FailOpen: true},
+			statusCode:   http.StatusInternalServerError,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "webhook timeout",
+			webhook:      &config.GangwayAuthorizationWebhook{Timeout: &metav1.Duration{Duration: 10 * time.Millisecond}},
+			response:     `{"result": {"allowed": true}}`,
+			delay:        time.Second,
+			expectedCode: codes.Unavailable,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queries := make(chan AuthorizationQuery, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var query AuthorizationQuery
+				if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+					t.Errorf("failed to decode query: %v", err)
+				}
+				queries <- query
+				if tc.delay > 0 {
+					select {
+					case <-time.After(tc.delay):
+					case <-r.Context().Done():
+					}
+				}
+				if tc.statusCode != 0 {
+					w.WriteHeader(tc.statusCode)
+					return
+				}
+				w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+			if tc.webhook != nil {
+				tc.webhook.URL = server.URL
+			}
+
+			client := &config.AllowedApiClient{GCP: &config.ApiClientGcp{EndpointApiConsumerType: "PROJECT", EndpointApiConsumerNumber: "123"}}
+			md := metadata.Pairs("x-endpoint-api-consumer-number", "123", "authorization", "Bearer secret")
+			cjer := &CreateJobExecutionRequest{JobName: "my-job", JobExecutionType: JobExecutionType_PERIODIC}
+			err := authorize(context.Background(), server.Client(), tc.webhook, client, &md, cjer)
+			if code := status.Code(err); code != tc.expectedCode {
+				t.Fatalf("expected code %s, got %s (%v)", tc.expectedCode, code, err)
+			}
+			if tc.webhook == nil {
+				return
+			}
+			query := <-queries
+
+			if diff := cmp.Diff(client, query.Input.Client); diff != "" {
+				t.Errorf("unexpected client (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(map[string][]string{"x-endpoint-api-consumer-number": {"123"}}, query.Input.Headers); diff != "" {
+				t.Errorf("unexpected headers (-want +got):\n%s", diff)
+			}
+			var request map[string]interface{}
+			if err := json.Unmarshal(query.Input.Request, &request); err != nil {
+				t.Fatalf("failed to unmarshal request: %v", err)
+			}
+			if diff := cmp.Diff(map[string]interface{}{"job_name": "my-job", "job_execution_type": "PERIODIC"}, request); diff != "" {
+				t.Errorf("unexpected request (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	context "context"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"time"
@@ -30,9 +31,12 @@ import (
 	status "google.golang.org/grpc/status"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/watch"
 	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/kube"
@@ -45,6 +49,7 @@ const (
 	HEADER_API_CONSUMER_ID   = "x-endpoint-api-consumer-number"
 	CONTEXT_TIMEOUT          = 10 * time.Minute
 	LIST_TIMEOUT             = 60
+	MAX_BATCH_SIZE           = 100
 )
 
 type Gangway struct {
@@ -52,15 +57,20 @@ type Gangway struct {
 	ConfigAgent        *config.Agent
 	ProwJobClient      ProwJobClient
 	InRepoConfigGetter config.InRepoConfigGetter
+	// HTTPClient is used to query the authorization webhook. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
 }
 
 // ProwJobClient describes a Kubernetes client for the Prow Job CR. Unlike a
-// general-purpose client, it only expects 4 methods, Create(), Get(), List() and Update().
+// general-purpose client, it only expects 5 methods, Create(), Get(), List(),
+// Update() and Watch().
 type ProwJobClient interface {
 	Create(context.Context, *prowcrd.ProwJob, metav1.CreateOptions) (*prowcrd.ProwJob, error)
 	Get(context.Context, string, metav1.GetOptions) (*prowcrd.ProwJob, error)
 	List(context.Context, metav1.ListOptions) (*prowcrd.ProwJobList, error)
 	Update(context.Context, *prowcrd.ProwJob, metav1.UpdateOptions) (*prowcrd.ProwJob, error)
+	Watch(context.Context, metav1.ListOptions) (watch.Interface, error)
 }
 
 // CreateJobExecution triggers a new Prow job.
@@ -98,6 +108,11 @@ func (gw *Gangway) CreateJobExecution(ctx context.Context, cjer *CreateJobExecut
 		l = logrus.NewEntry(logrus.New())
 	}
 
+	if err := authorize(ctx, gw.HTTPClient, mainConfig.Gangway.AuthorizationWebhook, allowedApiClient, md, cjer); err != nil {
+		l.WithError(err).Debugf("request to create job %q is not authorized", cjer.GetJobName())
+		return nil, err
+	}
+
 	allowedClusters := []string{"*"}
 	var reporterFunc ReporterFunc = nil
 	requireTenantID := true
@@ -111,6 +126,62 @@ func (gw *Gangway) CreateJobExecution(ctx context.Context, cjer *CreateJobExecut
 	return jobExec, nil
 }
 
+// BatchCreateJobExecutions triggers several Prow jobs. A job that cannot be
+// triggered does not prevent the others from being triggered; instead its
+// result carries the error.
+func (gw *Gangway) BatchCreateJobExecutions(ctx context.Context, bcjer *BatchCreateJobExecutionsRequest) (*BatchCreateJobExecutionsResponse, error) {
+	err, md := getHttpRequestHeaders(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find request HTTP headers")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := bcjer.Validate(); err != nil {
+		logrus.WithError(err).Debug("could not validate request fields")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	mainConfig := ProwCfgAdapter{gw.ConfigAgent.Config()}
+	allowedApiClient, err := mainConfig.IdentifyAllowedClient(md)
+	if err != nil {
+		logrus.WithError(err).Debug("could not find client in allowlist")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	l, err := getDecoratedLoggerEntry(allowedApiClient, md)
+	if err != nil {
+		l = logrus.NewEntry(logrus.New())
+	}
+
+	allowedClusters := []string{"*"}
+	var reporterFunc ReporterFunc = nil
+	requireTenantID := true
+
+	var results []*BatchCreateJobExecutionResult
+	for _, cjer := range bcjer.GetRequests() {
+		if err := cjer.Validate(); err != nil {
+			results = append(results, &BatchCreateJobExecutionResult{Code: int32(codes.InvalidArgument), Message: err.Error()})
+			continue
+		}
+		if err := authorize(ctx, gw.HTTPClient, mainConfig.Gangway.AuthorizationWebhook, allowedApiClient, md, cjer); err != nil {
+			l.WithError(err).Debugf("request to create job %q is not authorized", cjer.GetJobName())
+			s := status.Convert(err)
+			results = append(results, &BatchCreateJobExecutionResult{Code: int32(s.Code()), Message: s.Message()})
+			continue
+		}
+		jobExec, err := HandleProwJob(l, reporterFunc, cjer, gw.ProwJobClient, &mainConfig, gw.InRepoConfigGetter, allowedApiClient, requireTenantID, allowedClusters)
+		if err != nil {
+			logrus.WithError(err).Debugf("failed to create job %q", cjer.GetJobName())
+			s := status.Convert(err)
+			results = append(results, &BatchCreateJobExecutionResult{Code: int32(s.Code()), Message: s.Message()})
+			continue
+		}
+		results = append(results, &BatchCreateJobExecutionResult{JobExecution: jobExec})
+	}
+
+	return &BatchCreateJobExecutionsResponse{Results: results}, nil
+}
+
 // GetJobExecution returns a Prow job execution. It currently does this by
 // looking at all of the existing Prow Job CR (custom resource) objects to find
 // a match, and then does a translation from the CR into our JobExecution type.
@@ -130,6 +201,103 @@ func (gw *Gangway) GetJobExecution(ctx context.Context, gjer *GetJobExecutionReq
 	return jobExec, nil
 }
 
+// WatchJobExecution sends the Prow job execution once and then again whenever
+// its status changes, until the Prow job reaches a final status. It watches
+// the Prow Job CR (custom resource), and starts watching it again if the watch
+// ends before that.
+func (gw *Gangway) WatchJobExecution(wjer *WatchJobExecutionRequest, stream Prow_WatchJobExecutionServer) error {
+	ctx := stream.Context()
+	lastStatus := JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
+	send := func(pj *prowcrd.ProwJob) error {
+		jobStatus := TranslateProwJobStatus(&pj.Status)
+		if jobStatus == lastStatus {
+			return nil
+		}
+		lastStatus = jobStatus
+		return stream.Send(&JobExecution{
+			Id:        pj.Name,
+			JobName:   pj.Spec.Job,
+			JobType:   TranslateProwJobType(pj.Spec.Type),
+			JobStatus: jobStatus,
+		})
+	}
+
+	for {
+		prowJobCR, err := gw.ProwJobClient.Get(ctx, wjer.Id, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return status.Error(codes.NotFound, err.Error())
+			}
+			return err
+		}
+		if err := send(prowJobCR); err != nil {
+			return err
+		}
+		if isFinalJobExecutionStatus(lastStatus) {
+			return nil
+		}
+
+		watcher, err := gw.ProwJobClient.Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", wjer.Id).String(),
+			ResourceVersion: prowJobCR.ResourceVersion,
+		})
+		if err != nil {
+			return err
+		}
+		done, err := watchProwJob(ctx, watcher, wjer.Id, send)
+		watcher.Stop()
+		if done || err != nil {
+			return err
+		}
+		logrus.WithField("name", wjer.Id).Debug("watch ended before the ProwJob reached a final status, watching it again")
+	}
+}
+
+// watchProwJob calls send for every update of the Prow job with the given
+// name, until the Prow job reaches a final status or is deleted (in which case done is
+// true), or the watch ends.
+func watchProwJob(ctx context.Context, watcher watch.Interface, name string, send func(*prowcrd.ProwJob) error) (done bool, err error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				pj, ok := event.Object.(*prowcrd.ProwJob)
+				if !ok || pj.Name != name {
+					continue
+				}
+				if err := send(pj); err != nil {
+					return true, err
+				}
+				if isFinalJobExecutionStatus(TranslateProwJobStatus(&pj.Status)) {
+					return true, nil
+				}
+			case watch.Deleted:
+				return true, status.Errorf(codes.NotFound, "job execution %q was deleted", name)
+			case watch.Error:
+				// The watch cannot continue, e.g. because the resource version
+				// is too old. Start over from the current state.
+				return false, nil
+			}
+		}
+	}
+}
+
+// isFinalJobExecutionStatus returns whether the status of a job execution can
+// no longer change.
+func isFinalJobExecutionStatus(jobStatus JobExecutionStatus) bool {
+	switch jobStatus {
+	case JobExecutionStatus_SUCCESS, JobExecutionStatus_FAILURE, JobExecutionStatus_ABORTED, JobExecutionStatus_ERROR:
+		return true
+	}
+	return false
+}
+
 // Translate ProwJobStatus.State in the Prow Job CR into a JobExecutionStatus.
 func TranslateProwJobStatus(prowJobStatus *prowcrd.ProwJobStatus) JobExecutionStatus {
 	var jobStatus JobExecutionStatus
@@ -541,6 +709,16 @@ func (cjer *CreateJobExecutionRequest) Validate() error {
 	return nil
 }
 
+func (bcjer *BatchCreateJobExecutionsRequest) Validate() error {
+	if len(bcjer.GetRequests()) == 0 {
+		return errors.New("requests field cannot be empty")
+	}
+	if len(bcjer.GetRequests()) > MAX_BATCH_SIZE {
+		return fmt.Errorf("at most %d requests can be made at once, got %d", MAX_BATCH_SIZE, len(bcjer.GetRequests()))
+	}
+	return nil
+}
+
 func (bjscr *BulkJobStatusChangeRequest) Validate() error {
 
 	if bjscr.GetJobStatusChange().GetCurrent() == JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED {
@@ -610,6 +788,7 @@ type prowCfgClient interface {
 	GetPostsubmitsStatic(identifier string) []config.Postsubmit
 	GetProwJobDefault(repo, cluster string) *prowcrd.ProwJobDefault
 	GetScheduler() config.Scheduler
+	InRepoConfigRequiresApproval(identifier string) bool
 }
 
 type ProwCfgAdapter struct {
@@ -900,6 +1079,11 @@ func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg co
 			return pull.SHA, nil
 		})
 	}
+	// Gangway can't check whether changes of the pulls to the inrepoconfig
+	// are approved, so it only uses the inrepoconfig of the base then.
+	if mainConfig.InRepoConfigRequiresApproval(orgRepo) {
+		headSHAGetters = nil
+	}
 
 	logger := logrus.WithFields(logrus.Fields{"org": org, "repo": repo, "branch": branch, "orgRepo": orgRepo})
 	// Get presubmits from Config alone.
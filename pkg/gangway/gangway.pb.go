@@ -931,6 +931,213 @@ func (x *JobStatusChange) GetDesired() JobExecutionStatus {
 	return JobExecutionStatus_JOB_EXECUTION_STATUS_UNSPECIFIED
 }
 
+// Watch a single Prow Job execution.
+type WatchJobExecutionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *WatchJobExecutionRequest) Reset() {
+	*x = WatchJobExecutionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchJobExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJobExecutionRequest) ProtoMessage() {}
+
+func (x *WatchJobExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJobExecutionRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchJobExecutionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BatchCreateJobExecutionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*CreateJobExecutionRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *BatchCreateJobExecutionsRequest) Reset() {
+	*x = BatchCreateJobExecutionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCreateJobExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateJobExecutionsRequest) ProtoMessage() {}
+
+func (x *BatchCreateJobExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateJobExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateJobExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchCreateJobExecutionsRequest) GetRequests() []*CreateJobExecutionRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type BatchCreateJobExecutionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*BatchCreateJobExecutionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchCreateJobExecutionsResponse) Reset() {
+	*x = BatchCreateJobExecutionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCreateJobExecutionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateJobExecutionsResponse) ProtoMessage() {}
+
+func (x *BatchCreateJobExecutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateJobExecutionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateJobExecutionsResponse) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchCreateJobExecutionsResponse) GetResults() []*BatchCreateJobExecutionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// The result of triggering a single Prow job of a batch. Either job_execution
+// is set, or code and message describe why the job could not be triggered.
+type BatchCreateJobExecutionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobExecution *JobExecution `protobuf:"bytes,1,opt,name=job_execution,json=jobExecution,proto3" json:"job_execution,omitempty"`
+	Code         int32         `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"` // A google.rpc.Code value.
+	Message      string        `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *BatchCreateJobExecutionResult) Reset() {
+	*x = BatchCreateJobExecutionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gangway_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCreateJobExecutionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateJobExecutionResult) ProtoMessage() {}
+
+func (x *BatchCreateJobExecutionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_gangway_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateJobExecutionResult.ProtoReflect.Descriptor instead.
+func (*BatchCreateJobExecutionResult) Descriptor() ([]byte, []int) {
+	return file_gangway_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchCreateJobExecutionResult) GetJobExecution() *JobExecution {
+	if x != nil {
+		return x.JobExecution
+	}
+	return nil
+}
+
+func (x *BatchCreateJobExecutionResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchCreateJobExecutionResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_gangway_proto protoreflect.FileDescriptor
 
 var file_gangway_proto_rawDesc = []byte{
@@ -1079,50 +1286,88 @@ var file_gangway_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
 	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x07, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65,
-	0x64, 0x2a, 0x88, 0x01, 0x0a, 0x12, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x20, 0x4a, 0x4f, 0x42, 0x5f,
-	0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
-	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d,
-	0x0a, 0x09, 0x54, 0x52, 0x49, 0x47, 0x47, 0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a,
-	0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55,
-	0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x41, 0x49, 0x4c, 0x55,
-	0x52, 0x45, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10,
-	0x05, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x06, 0x2a, 0x6e, 0x0a, 0x10,
-	0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x22, 0x0a, 0x1e, 0x4a, 0x4f, 0x42, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f,
-	0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
-	0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x45, 0x52, 0x49, 0x4f, 0x44, 0x49, 0x43,
-	0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x4f, 0x53, 0x54, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54,
-	0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x52, 0x45, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54, 0x10,
-	0x03, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10, 0x04, 0x32, 0x95, 0x03, 0x0a,
-	0x04, 0x50, 0x72, 0x6f, 0x77, 0x12, 0x62, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a,
-	0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65,
-	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01,
-	0x2a, 0x42, 0x16, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x56, 0x0a, 0x0f, 0x47, 0x65, 0x74,
-	0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x47,
-	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76,
-	0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64,
-	0x7d, 0x12, 0x56, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62,
-	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0e, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x79, 0x0a, 0x13, 0x42, 0x75, 0x6c,
-	0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x12, 0x1b, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a,
-	0x42, 0x22, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x75,
-	0x6c, 0x6b, 0x2d, 0x6a, 0x6f, 0x62, 0x2d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2d, 0x75, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x42, 0x1e, 0x5a, 0x1c, 0x73, 0x69, 0x67, 0x73, 0x2e, 0x6b, 0x38, 0x73,
-	0x2e, 0x69, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x77, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x61, 0x6e,
-	0x67, 0x77, 0x61, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x22, 0x2a, 0x0a, 0x18, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x59, 0x0a,
+	0x1f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x36, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x5c, 0x0a, 0x20, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x1d, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x32, 0x0a, 0x0d, 0x6a, 0x6f, 0x62, 0x5f,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
+	0x6a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x88, 0x01, 0x0a, 0x12, 0x4a,
+	0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x24, 0x0a, 0x20, 0x4a, 0x4f, 0x42, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x52, 0x49, 0x47, 0x47,
+	0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e,
+	0x47, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03,
+	0x12, 0x0b, 0x0a, 0x07, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x04, 0x12, 0x0b, 0x0a,
+	0x07, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x10, 0x06, 0x2a, 0x6e, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x4a, 0x4f, 0x42,
+	0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a,
+	0x08, 0x50, 0x45, 0x52, 0x49, 0x4f, 0x44, 0x49, 0x43, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x50,
+	0x4f, 0x53, 0x54, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50,
+	0x52, 0x45, 0x53, 0x55, 0x42, 0x4d, 0x49, 0x54, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x41,
+	0x54, 0x43, 0x48, 0x10, 0x04, 0x32, 0x8a, 0x05, 0x0a, 0x04, 0x50, 0x72, 0x6f, 0x77, 0x12, 0x62,
+	0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01, 0x2a, 0x42, 0x16, 0x0a, 0x04, 0x50, 0x4f,
+	0x53, 0x54, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x56, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d,
+	0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1b, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x56, 0x0a, 0x11, 0x4c, 0x69,
+	0x73, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x19, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x4a, 0x6f, 0x62,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x79, 0x0a, 0x13, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2d,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x42, 0x22, 0x0a, 0x04, 0x50, 0x4f, 0x53,
+	0x54, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x2d, 0x6a, 0x6f, 0x62, 0x2d,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x62, 0x0a,
+	0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e,
+	0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x3a, 0x77, 0x61, 0x74, 0x63, 0x68, 0x30,
+	0x01, 0x12, 0x8e, 0x01, 0x0a, 0x18, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20,
+	0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f,
+	0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x42, 0x22,
+	0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x42, 0x1e, 0x5a, 0x1c, 0x73, 0x69, 0x67, 0x73, 0x2e, 0x6b, 0x38, 0x73, 0x2e, 0x69,
+	0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x77, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x61, 0x6e, 0x67, 0x77,
+	0x61, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1138,62 +1383,73 @@ func file_gangway_proto_rawDescGZIP() []byte {
 }
 
 var file_gangway_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_gangway_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_gangway_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_gangway_proto_goTypes = []interface{}{
-	(JobExecutionStatus)(0),            // 0: JobExecutionStatus
-	(JobExecutionType)(0),              // 1: JobExecutionType
-	(*CreateJobExecutionRequest)(nil),  // 2: CreateJobExecutionRequest
-	(*PodSpecOptions)(nil),             // 3: PodSpecOptions
-	(*GetJobExecutionRequest)(nil),     // 4: GetJobExecutionRequest
-	(*ListJobExecutionsRequest)(nil),   // 5: ListJobExecutionsRequest
-	(*JobExecutions)(nil),              // 6: JobExecutions
-	(*JobExecution)(nil),               // 7: JobExecution
-	(*Refs)(nil),                       // 8: Refs
-	(*Pull)(nil),                       // 9: Pull
-	(*BulkJobStatusChangeRequest)(nil), // 10: BulkJobStatusChangeRequest
-	(*JobStatusChange)(nil),            // 11: JobStatusChange
-	nil,                                // 12: PodSpecOptions.EnvsEntry
-	nil,                                // 13: PodSpecOptions.LabelsEntry
-	nil,                                // 14: PodSpecOptions.AnnotationsEntry
-	(*timestamppb.Timestamp)(nil),      // 15: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),              // 16: google.protobuf.Empty
+	(JobExecutionStatus)(0),                  // 0: JobExecutionStatus
+	(JobExecutionType)(0),                    // 1: JobExecutionType
+	(*CreateJobExecutionRequest)(nil),        // 2: CreateJobExecutionRequest
+	(*PodSpecOptions)(nil),                   // 3: PodSpecOptions
+	(*GetJobExecutionRequest)(nil),           // 4: GetJobExecutionRequest
+	(*ListJobExecutionsRequest)(nil),         // 5: ListJobExecutionsRequest
+	(*JobExecutions)(nil),                    // 6: JobExecutions
+	(*JobExecution)(nil),                     // 7: JobExecution
+	(*Refs)(nil),                             // 8: Refs
+	(*Pull)(nil),                             // 9: Pull
+	(*BulkJobStatusChangeRequest)(nil),       // 10: BulkJobStatusChangeRequest
+	(*JobStatusChange)(nil),                  // 11: JobStatusChange
+	(*WatchJobExecutionRequest)(nil),         // 12: WatchJobExecutionRequest
+	(*BatchCreateJobExecutionsRequest)(nil),  // 13: BatchCreateJobExecutionsRequest
+	(*BatchCreateJobExecutionsResponse)(nil), // 14: BatchCreateJobExecutionsResponse
+	(*BatchCreateJobExecutionResult)(nil),    // 15: BatchCreateJobExecutionResult
+	nil,                                      // 16: PodSpecOptions.EnvsEntry
+	nil,                                      // 17: PodSpecOptions.LabelsEntry
+	nil,                                      // 18: PodSpecOptions.AnnotationsEntry
+	(*timestamppb.Timestamp)(nil),            // 19: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                    // 20: google.protobuf.Empty
 }
 var file_gangway_proto_depIdxs = []int32{
 	1,  // 0: CreateJobExecutionRequest.job_execution_type:type_name -> JobExecutionType
 	8,  // 1: CreateJobExecutionRequest.refs:type_name -> Refs
 	3,  // 2: CreateJobExecutionRequest.pod_spec_options:type_name -> PodSpecOptions
-	12, // 3: PodSpecOptions.envs:type_name -> PodSpecOptions.EnvsEntry
-	13, // 4: PodSpecOptions.labels:type_name -> PodSpecOptions.LabelsEntry
-	14, // 5: PodSpecOptions.annotations:type_name -> PodSpecOptions.AnnotationsEntry
+	16, // 3: PodSpecOptions.envs:type_name -> PodSpecOptions.EnvsEntry
+	17, // 4: PodSpecOptions.labels:type_name -> PodSpecOptions.LabelsEntry
+	18, // 5: PodSpecOptions.annotations:type_name -> PodSpecOptions.AnnotationsEntry
 	0,  // 6: ListJobExecutionsRequest.status:type_name -> JobExecutionStatus
 	7,  // 7: JobExecutions.job_execution:type_name -> JobExecution
 	1,  // 8: JobExecution.job_type:type_name -> JobExecutionType
 	0,  // 9: JobExecution.job_status:type_name -> JobExecutionStatus
 	8,  // 10: JobExecution.refs:type_name -> Refs
 	3,  // 11: JobExecution.pod_spec_options:type_name -> PodSpecOptions
-	15, // 12: JobExecution.create_time:type_name -> google.protobuf.Timestamp
-	15, // 13: JobExecution.completion_time:type_name -> google.protobuf.Timestamp
+	19, // 12: JobExecution.create_time:type_name -> google.protobuf.Timestamp
+	19, // 13: JobExecution.completion_time:type_name -> google.protobuf.Timestamp
 	9,  // 14: Refs.pulls:type_name -> Pull
 	11, // 15: BulkJobStatusChangeRequest.job_status_change:type_name -> JobStatusChange
-	15, // 16: BulkJobStatusChangeRequest.started_before:type_name -> google.protobuf.Timestamp
-	15, // 17: BulkJobStatusChangeRequest.started_after:type_name -> google.protobuf.Timestamp
+	19, // 16: BulkJobStatusChangeRequest.started_before:type_name -> google.protobuf.Timestamp
+	19, // 17: BulkJobStatusChangeRequest.started_after:type_name -> google.protobuf.Timestamp
 	1,  // 18: BulkJobStatusChangeRequest.job_type:type_name -> JobExecutionType
 	8,  // 19: BulkJobStatusChangeRequest.refs:type_name -> Refs
 	0,  // 20: JobStatusChange.current:type_name -> JobExecutionStatus
 	0,  // 21: JobStatusChange.desired:type_name -> JobExecutionStatus
-	2,  // 22: Prow.CreateJobExecution:input_type -> CreateJobExecutionRequest
-	4,  // 23: Prow.GetJobExecution:input_type -> GetJobExecutionRequest
-	5,  // 24: Prow.ListJobExecutions:input_type -> ListJobExecutionsRequest
-	10, // 25: Prow.BulkJobStatusChange:input_type -> BulkJobStatusChangeRequest
-	7,  // 26: Prow.CreateJobExecution:output_type -> JobExecution
-	7,  // 27: Prow.GetJobExecution:output_type -> JobExecution
-	6,  // 28: Prow.ListJobExecutions:output_type -> JobExecutions
-	16, // 29: Prow.BulkJobStatusChange:output_type -> google.protobuf.Empty
-	26, // [26:30] is the sub-list for method output_type
-	22, // [22:26] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	2,  // 22: BatchCreateJobExecutionsRequest.requests:type_name -> CreateJobExecutionRequest
+	15, // 23: BatchCreateJobExecutionsResponse.results:type_name -> BatchCreateJobExecutionResult
+	7,  // 24: BatchCreateJobExecutionResult.job_execution:type_name -> JobExecution
+	2,  // 25: Prow.CreateJobExecution:input_type -> CreateJobExecutionRequest
+	4,  // 26: Prow.GetJobExecution:input_type -> GetJobExecutionRequest
+	5,  // 27: Prow.ListJobExecutions:input_type -> ListJobExecutionsRequest
+	10, // 28: Prow.BulkJobStatusChange:input_type -> BulkJobStatusChangeRequest
+	12, // 29: Prow.WatchJobExecution:input_type -> WatchJobExecutionRequest
+	13, // 30: Prow.BatchCreateJobExecutions:input_type -> BatchCreateJobExecutionsRequest
+	7,  // 31: Prow.CreateJobExecution:output_type -> JobExecution
+	7,  // 32: Prow.GetJobExecution:output_type -> JobExecution
+	6,  // 33: Prow.ListJobExecutions:output_type -> JobExecutions
+	20, // 34: Prow.BulkJobStatusChange:output_type -> google.protobuf.Empty
+	7,  // 35: Prow.WatchJobExecution:output_type -> JobExecution
+	14, // 36: Prow.BatchCreateJobExecutions:output_type -> BatchCreateJobExecutionsResponse
+	31, // [31:37] is the sub-list for method output_type
+	25, // [25:31] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_gangway_proto_init() }
@@ -1322,6 +1578,54 @@ func file_gangway_proto_init() {
 				return nil
 			}
 		}
+		file_gangway_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchJobExecutionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateJobExecutionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateJobExecutionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gangway_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateJobExecutionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1329,7 +1633,7 @@ func file_gangway_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gangway_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   13,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,10 +20,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Prow_CreateJobExecution_FullMethodName  = "/Prow/CreateJobExecution"
-	Prow_GetJobExecution_FullMethodName     = "/Prow/GetJobExecution"
-	Prow_ListJobExecutions_FullMethodName   = "/Prow/ListJobExecutions"
-	Prow_BulkJobStatusChange_FullMethodName = "/Prow/BulkJobStatusChange"
+	Prow_CreateJobExecution_FullMethodName       = "/Prow/CreateJobExecution"
+	Prow_GetJobExecution_FullMethodName          = "/Prow/GetJobExecution"
+	Prow_ListJobExecutions_FullMethodName        = "/Prow/ListJobExecutions"
+	Prow_BulkJobStatusChange_FullMethodName      = "/Prow/BulkJobStatusChange"
+	Prow_WatchJobExecution_FullMethodName        = "/Prow/WatchJobExecution"
+	Prow_BatchCreateJobExecutions_FullMethodName = "/Prow/BatchCreateJobExecutions"
 )
 
 // ProwClient is the client API for Prow service.
@@ -39,6 +41,13 @@ type ProwClient interface {
 	GetJobExecution(ctx context.Context, in *GetJobExecutionRequest, opts ...grpc.CallOption) (*JobExecution, error)
 	ListJobExecutions(ctx context.Context, in *ListJobExecutionsRequest, opts ...grpc.CallOption) (*JobExecutions, error)
 	BulkJobStatusChange(ctx context.Context, in *BulkJobStatusChangeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Streams the Prow job execution once right away and then again whenever
+	// its status changes, until it reaches a final status.
+	WatchJobExecution(ctx context.Context, in *WatchJobExecutionRequest, opts ...grpc.CallOption) (Prow_WatchJobExecutionClient, error)
+	// Triggers several Prow jobs. Each job is triggered independently of the
+	// others, and the result of each one is returned in the same order as the
+	// requests.
+	BatchCreateJobExecutions(ctx context.Context, in *BatchCreateJobExecutionsRequest, opts ...grpc.CallOption) (*BatchCreateJobExecutionsResponse, error)
 }
 
 type prowClient struct {
@@ -85,6 +94,47 @@ func (c *prowClient) BulkJobStatusChange(ctx context.Context, in *BulkJobStatusC
 	return out, nil
 }
 
+func (c *prowClient) WatchJobExecution(ctx context.Context, in *WatchJobExecutionRequest, opts ...grpc.CallOption) (Prow_WatchJobExecutionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Prow_ServiceDesc.Streams[0], Prow_WatchJobExecution_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &prowWatchJobExecutionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Prow_WatchJobExecutionClient interface {
+	Recv() (*JobExecution, error)
+	grpc.ClientStream
+}
+
+type prowWatchJobExecutionClient struct {
+	grpc.ClientStream
+}
+
+func (x *prowWatchJobExecutionClient) Recv() (*JobExecution, error) {
+	m := new(JobExecution)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *prowClient) BatchCreateJobExecutions(ctx context.Context, in *BatchCreateJobExecutionsRequest, opts ...grpc.CallOption) (*BatchCreateJobExecutionsResponse, error) {
+	out := new(BatchCreateJobExecutionsResponse)
+	err := c.cc.Invoke(ctx, Prow_BatchCreateJobExecutions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProwServer is the server API for Prow service.
 // All implementations must embed UnimplementedProwServer
 // for forward compatibility
@@ -98,6 +148,13 @@ type ProwServer interface {
 	GetJobExecution(context.Context, *GetJobExecutionRequest) (*JobExecution, error)
 	ListJobExecutions(context.Context, *ListJobExecutionsRequest) (*JobExecutions, error)
 	BulkJobStatusChange(context.Context, *BulkJobStatusChangeRequest) (*emptypb.Empty, error)
+	// Streams the Prow job execution once right away and then again whenever
+	// its status changes, until it reaches a final status.
+	WatchJobExecution(*WatchJobExecutionRequest, Prow_WatchJobExecutionServer) error
+	// Triggers several Prow jobs. Each job is triggered independently of the
+	// others, and the result of each one is returned in the same order as the
+	// requests.
+	BatchCreateJobExecutions(context.Context, *BatchCreateJobExecutionsRequest) (*BatchCreateJobExecutionsResponse, error)
 	mustEmbedUnimplementedProwServer()
 }
 
@@ -117,6 +174,12 @@ func (UnimplementedProwServer) ListJobExecutions(context.Context, *ListJobExecut
 func (UnimplementedProwServer) BulkJobStatusChange(context.Context, *BulkJobStatusChangeRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BulkJobStatusChange not implemented")
 }
+func (UnimplementedProwServer) WatchJobExecution(*WatchJobExecutionRequest, Prow_WatchJobExecutionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJobExecution not implemented")
+}
+func (UnimplementedProwServer) BatchCreateJobExecutions(context.Context, *BatchCreateJobExecutionsRequest) (*BatchCreateJobExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateJobExecutions not implemented")
+}
 func (UnimplementedProwServer) mustEmbedUnimplementedProwServer() {}
 
 // UnsafeProwServer may be embedded to opt out of forward compatibility for this service.
@@ -202,6 +265,45 @@ func _Prow_BulkJobStatusChange_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Prow_WatchJobExecution_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobExecutionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProwServer).WatchJobExecution(m, &prowWatchJobExecutionServer{stream})
+}
+
+type Prow_WatchJobExecutionServer interface {
+	Send(*JobExecution) error
+	grpc.ServerStream
+}
+
+type prowWatchJobExecutionServer struct {
+	grpc.ServerStream
+}
+
+func (x *prowWatchJobExecutionServer) Send(m *JobExecution) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Prow_BatchCreateJobExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateJobExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProwServer).BatchCreateJobExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prow_BatchCreateJobExecutions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProwServer).BatchCreateJobExecutions(ctx, req.(*BatchCreateJobExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Prow_ServiceDesc is the grpc.ServiceDesc for Prow service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -225,7 +327,17 @@ var Prow_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BulkJobStatusChange",
 			Handler:    _Prow_BulkJobStatusChange_Handler,
 		},
+		{
+			MethodName: "BatchCreateJobExecutions",
+			Handler:    _Prow_BatchCreateJobExecutions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJobExecution",
+			Handler:       _Prow_WatchJobExecution_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gangway.proto",
 }
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	prowcrd "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *JobExecution
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(jobExec *JobExecution) error {
+	s.sent <- jobExec
+	return nil
+}
+
+// watchSignalingClient signals every time a watch is started.
+type watchSignalingClient struct {
+	ProwJobClient
+	watching chan struct{}
+}
+
+func (c *watchSignalingClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	w, err := c.ProwJobClient.Watch(ctx, opts)
+	c.watching <- struct{}{}
+	return w, err
+}
+
+func TestWatchJobExecution(t *testing.T) {
+	testCases := []struct {
+		name             string
+		initialState     prowcrd.ProwJobState
+		updates          []prowcrd.ProwJobState
+		delete           bool
+		expectedStatuses []JobExecutionStatus
+		expectedCode     codes.Code
+	}{
+		{
+			name:         "status changes are sent until the job completes",
+			initialState: prowcrd.TriggeredState,
+			updates:      []prowcrd.ProwJobState{prowcrd.PendingState, prowcrd.PendingState, prowcrd.SuccessState},
+			expectedStatuses: []JobExecutionStatus{
+				JobExecutionStatus_TRIGGERED,
+				JobExecutionStatus_PENDING,
+				JobExecutionStatus_SUCCESS,
+			},
+		},
+		{
+			name:             "completed job is sent once",
+			initialState:     prowcrd.FailureState,
+			expectedStatuses: []JobExecutionStatus{JobExecutionStatus_FAILURE},
+		},
+		{
+			name:             "deleted job",
+			initialState:     prowcrd.PendingState,
+			delete:           true,
+			expectedStatuses: []JobExecutionStatus{JobExecutionStatus_PENDING},
+			expectedCode:     codes.NotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowcrd.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "job-execution", Namespace: "prowjobs"},
+				Spec:       prowcrd.ProwJobSpec{Job: "my-job", Type: prowcrd.PeriodicJob},
+				Status:     prowcrd.ProwJobStatus{State: tc.initialState},
+			}
+			pjClient := fake.NewSimpleClientset(pj).ProwV1().ProwJobs("prowjobs")
+			client := &watchSignalingClient{ProwJobClient: pjClient, watching: make(chan struct{}, 1)}
+			gw := &Gangway{ProwJobClient: client}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			stream := &fakeWatchStream{ctx: ctx, sent: make(chan *JobExecution, 10)}
+			errs := make(chan error, 1)
+			go func() {
+				errs <- gw.WatchJobExecution(&WatchJobExecutionRequest{Id: pj.Name}, stream)
+			}()
+
+			if len(tc.updates) > 0 || tc.delete {
+				select {
+				case <-client.watching:
+				case <-ctx.Done():
+					t.Fatal("timed out waiting for the watch to start")
+				}
+			}
+			for _, state := range tc.updates {
+				pj.Status.State = state
+				if _, err := pjClient.Update(ctx, pj, metav1.UpdateOptions{}); err != nil {
+					t.Fatalf("failed to update ProwJob: %v", err)
+				}
+			}
+			if tc.delete {
+				if err := pjClient.Delete(ctx, pj.Name, metav1.DeleteOptions{}); err != nil {
+					t.Fatalf("failed to delete ProwJob: %v", err)
+				}
+			}
+
+			var err error
+			select {
+			case err = <-errs:
+			case <-ctx.Done():
+				t.Fatal("timed out waiting for the watch to end")
+			}
+			if code := status.Code(err); code != tc.expectedCode {
+				t.Errorf("expected code %s, got %s (%v)", tc.expectedCode, code, err)
+			}
+			close(stream.sent)
+			var statuses []JobExecutionStatus
+			for jobExec := range stream.sent {
+				if jobExec.JobName != "my-job" || jobExec.JobType != JobExecutionType_PERIODIC {
+					t.Errorf("unexpected job execution: %v", jobExec)
+				}
+				statuses = append(statuses, jobExec.JobStatus)
+			}
+			if diff := cmp.Diff(tc.expectedStatuses, statuses); diff != "" {
+				t.Errorf("unexpected statuses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBatchCreateJobExecutionsRequestValidate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		requests  int
+		expectErr bool
+	}{
+		{
+			name:      "no requests",
+			expectErr: true,
+		},
+		{
+			name:     "some requests",
+			requests: 2,
+		},
+		{
+			name:      "too many requests",
+			requests:  MAX_BATCH_SIZE + 1,
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bcjer := &BatchCreateJobExecutionsRequest{}
+			for i := 0; i < tc.requests; i++ {
+				bcjer.Requests = append(bcjer.Requests, &CreateJobExecutionRequest{})
+			}
+			if err := bcjer.Validate(); (err != nil) != tc.expectErr {
+				t.Errorf("expected error: %t, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+// fakeInRepoConfigGetter returns a presubmit for every head it is asked to
+// load the inrepoconfig of.
+type fakeInRepoConfigGetter struct {
+	config.InRepoConfigGetter
+}
+
+func (fakeInRepoConfigGetter) GetInRepoConfig(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) (*config.ProwYAML, error) {
+	prowYAML := &config.ProwYAML{}
+	for _, headSHAGetter := range headSHAGetters {
+		headSHA, err := headSHAGetter()
+		if err != nil {
+			return nil, err
+		}
+		prowYAML.Presubmits = append(prowYAML.Presubmits, config.Presubmit{JobBase: config.JobBase{Name: "job-of-" + headSHA}})
+	}
+	return prowYAML, nil
+}
+
+func TestPresubmitJobHandlerInRepoConfigApproval(t *testing.T) {
+	yes := true
+	testCases := []struct {
+		name            string
+		requireApproval map[string]*bool
+		expectErr       bool
+	}{
+		{
+			name: "inrepoconfig of the pull is used",
+		},
+		{
+			name:            "inrepoconfig of the pull is not used if its changes need approval",
+			requireApproval: map[string]*bool{"org/repo": &yes},
+			expectErr:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &ProwCfgAdapter{Config: &config.Config{ProwConfig: config.ProwConfig{InRepoConfig: config.InRepoConfig{RequireApproval: tc.requireApproval}}}}
+			cjer := &CreateJobExecutionRequest{
+				JobName:          "job-of-head",
+				JobExecutionType: JobExecutionType_PRESUBMIT,
+				Refs: &Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseRef: "main",
+					BaseSha: "base",
+					Pulls:   []*Pull{{Number: 1, Sha: "head"}},
+				},
+			}
+			_, _, _, err := (&presubmitJobHandler{}).getProwJobSpec(cfg, fakeInRepoConfigGetter{}, cjer)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error: %t, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
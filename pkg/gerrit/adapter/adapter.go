@@ -45,6 +45,7 @@ import (
 
 const (
 	inRepoConfigRetries = 2
+	untrustedCommand    = "Cannot trigger testing until a trusted user reviews the change and leaves an `/ok-to-test` message."
 	inRepoConfigFailed  = "Unable to get inRepoConfig. This could be due to a merge conflict (please resolve them), an inRepoConfig parsing error (incorrect formatting) in the .prow directory or .prow.yaml file, or a flake. For possible flakes, try again with /test all"
 )
 
@@ -158,6 +159,8 @@ type gerritClient interface {
 	SetReview(instance, id, revision, message string, labels map[string]string) error
 	Account(instance string) (*gerrit.AccountInfo, error)
 	HasRelatedChanges(instance, id, revision string) (bool, error)
+	AccountGroups(instance string, accountID int) ([]gerrit.GroupInfo, error)
+	ListChecks(instance, id, revision string) ([]client.CheckInfo, error)
 }
 
 // Controller manages gerrit changes.
@@ -510,6 +513,22 @@ func failedJobs(account int, revision int, messages ...gerrit.ChangeMessageInfo)
 	return failures
 }
 
+// failedChecks find jobs whose checks are currently failing (used for retesting
+// repos whose results are reported as checks instead of messages).
+func failedChecks(project string, checks []client.CheckInfo) sets.Set[string] {
+	prefix := client.CheckerUUID(project, "")
+	failures := sets.Set[string]{}
+	for _, check := range checks {
+		job, ok := strings.CutPrefix(check.CheckerUUID, prefix)
+		// Job names never contain slashes, such checks belong to a subproject.
+		if !ok || strings.Contains(job, "/") || check.State != client.CheckStateFailed {
+			continue
+		}
+		failures.Insert(job)
+	}
+	return failures
+}
+
 func (c *Controller) handleInRepoConfigError(err error, instance string, change gerrit.ChangeInfo) error {
 	key := fmt.Sprintf("%s%s%s", instance, change.ID, change.CurrentRevision)
 	if err != nil {
@@ -567,7 +586,9 @@ func (c *Controller) shouldTriggerJobs(change client.ChangeInfo, lastProjectSync
 
 func (c *Controller) messageContainsJobTriggeringCommand(message gerrit.ChangeMessageInfo) bool {
 	return pjutil.RetestRe.MatchString(message.Message) ||
+		pjutil.RetestRequiredRe.MatchString(message.Message) ||
 		pjutil.TestAllRe.MatchString(message.Message) ||
+		pjutil.OkToTestRe.MatchString(message.Message) ||
 		c.configAgent.Config().Gerrit.IsAllowedPresubmitTrigger(message.Message)
 }
 
@@ -678,6 +699,13 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 		}
 	case client.New:
 		var presubmits []config.Presubmit
+		// Changes in Gerrit are not approved by the approve plugin, so their
+		// changes to the inrepoconfig are never used if that needs approval.
+		if c.config().InRepoConfigRequiresApproval(cloneURI) {
+			headSHAGetter = func() (string, error) {
+				return "", nil
+			}
+		}
 		// Gerrit server might be unavailable intermittently, retry inrepoconfig
 		// processing for increased reliability.
 		for attempt := 0; attempt < inRepoConfigRetries; attempt++ {
@@ -724,15 +752,45 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 
 		revision := change.Revisions[change.CurrentRevision]
 		failedJobs := failedJobs(account.AccountID, revision.Number, change.Messages...)
+		if c.config().Gerrit.OrgReposConfig.ReportChecks(instance, change.Project) {
+			checks, err := c.gc.ListChecks(instance, change.ID, change.CurrentRevision)
+			if err != nil {
+				return fmt.Errorf("ListChecks: %w", err)
+			}
+			failedJobs = failedChecks(change.Project, checks)
+		}
 		failed, all := presubmitContexts(failedJobs, presubmits, logger)
 		messages := currentMessages(change, lastUpdate)
 		logger.WithField("failed", len(failed)).Debug("Failed jobs parsed from previous comments.")
-		filters := []pjutil.Filter{
-			messageFilter(messages, failed, all, triggerTimes, logger),
+
+		// Only honor the commands of trusted users, unless the change itself
+		// is trusted, like on GitHub.
+		trust := newTrustChecker(c.gc, instance, c.config().Gerrit.OrgReposConfig.TrustedGroups(instance, change.Project))
+		changeTrusted, err := trust.changeTrusted(change)
+		if err != nil {
+			return err
+		}
+		messages, rejected, err := trust.trustedMessages(messages, changeTrusted, account.AccountID)
+		if err != nil {
+			return err
+		}
+		if rejected {
+			logger.Info("Ignoring commands of untrusted users on untrusted change.")
+			if err := c.gc.SetReview(instance, change.ID, change.CurrentRevision, untrustedCommand, nil); err != nil {
+				return err
+			}
+		}
+
+		ready := isReady(change)
+		var filters []pjutil.Filter
+		if ready || !c.config().Gerrit.OrgReposConfig.IgnoreCommandsUntilReady(instance, change.Project) {
+			filters = append(filters, messageFilter(messages, ready, trust.enabled(), failed, all, triggerTimes, logger))
+		} else {
+			logger.Debug("Ignoring commands on work in progress or private change.")
 		}
-		// Automatically trigger the Prow jobs if the revision is new and the
-		// change is not in WorkInProgress.
-		if revision.Created.Time.After(lastUpdate) && !change.WorkInProgress {
+		// Automatically trigger the Prow jobs if the revision is new, the
+		// change is neither in WorkInProgress nor private and it is trusted.
+		if revision.Created.Time.After(lastUpdate) && ready && changeTrusted {
 			filters = append(filters, &timeAnnotationFilter{
 				Filter:       pjutil.NewTestAllFilter(),
 				eventTime:    revision.Created.Time,
@@ -767,7 +825,7 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 			}
 		}
 
-		for _, presubmit := range toTrigger {
+		for _, presubmit := range pjutil.AddDependencies(toTrigger, presubmits, change.Branch) {
 			jobSpecs = append(jobSpecs, jobSpec{
 				spec:        pjutil.PresubmitSpec(presubmit, refs),
 				labels:      presubmit.Labels,
@@ -71,6 +71,16 @@ func (f *fca) Config() *config.Config {
 type fgc struct {
 	reviews     int
 	instanceMap map[string]*gerrit.AccountInfo
+	groups      map[int][]gerrit.GroupInfo
+	checks      []client.CheckInfo
+}
+
+func (f *fgc) ListChecks(instance, id, revision string) ([]client.CheckInfo, error) {
+	return f.checks, nil
+}
+
+func (f *fgc) AccountGroups(instance string, accountID int) ([]gerrit.GroupInfo, error) {
+	return f.groups[accountID], nil
 }
 
 func (f *fgc) HasRelatedChanges(instance, id, revision string) (bool, error) {
@@ -622,8 +632,69 @@ func createTestRepoCache(t *testing.T, ca *fca) (*config.InRepoConfigCache, erro
 	return cache, nil
 }
 
+func TestFailedChecks(t *testing.T) {
+	checks := []client.CheckInfo{
+		{CheckerUUID: "prow:project/failed", State: client.CheckStateFailed},
+		{CheckerUUID: "prow:project/passed", State: client.CheckStateSuccessful},
+		{CheckerUUID: "prow:project/running", State: client.CheckStateRunning},
+		{CheckerUUID: "prow:project/sub/failed-in-subproject", State: client.CheckStateFailed},
+		{CheckerUUID: "prow:other-project/failed-elsewhere", State: client.CheckStateFailed},
+		{CheckerUUID: "other:project/failed-other-checker", State: client.CheckStateFailed},
+	}
+	expected := sets.New[string]("failed")
+	if got := failedChecks("project", checks); !got.Equal(expected) {
+		t.Errorf("expected failed jobs %v, got %v", sets.List(expected), sets.List(got))
+	}
+}
+
 func TestTriggerJobs(t *testing.T) {
 	testInstance := "https://gerrit"
+	trustedGroups := map[int][]gerrit.GroupInfo{
+		1: {{ID: "uuid-other", Name: "other"}, {ID: "uuid-trusted", Name: "trusted"}},
+		2: {{ID: "uuid-other", Name: "other"}},
+	}
+	trustedRepoJob := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"prow.k8s.io/context":             "trusted-test",
+				"prow.k8s.io/refs.pull":           "0",
+				"created-by-prow":                 "true",
+				"prow.k8s.io/gerrit-revision":     "1",
+				"prow.k8s.io/type":                "presubmit",
+				"prow.k8s.io/refs.base_ref":       "",
+				"prow.k8s.io/gerrit-patchset":     "1",
+				"prow.k8s.io/gerrit-report-label": "Code-Review",
+				"prow.k8s.io/refs.repo":           "trusted-repo",
+				"prow.k8s.io/job":                 "trusted-test",
+				"prow.k8s.io/refs.org":            "gerrit",
+			},
+			Annotations: map[string]string{
+				"prow.k8s.io/job":             "trusted-test",
+				"prow.k8s.io/context":         "trusted-test",
+				"prow.k8s.io/gerrit-instance": "https://gerrit",
+				"prow.k8s.io/gerrit-id":       "",
+			},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Refs: &prowapi.Refs{
+				Org:      "https://gerrit",
+				Repo:     "trusted-repo",
+				RepoLink: "https://gerrit/trusted-repo",
+				BaseSHA:  "abc",
+				BaseLink: "https://gerrit/trusted-repo/+/abc",
+				CloneURI: "https://gerrit/trusted-repo",
+				Pulls: []prowapi.Pull{
+					{
+						Ref:        "refs/changes/00/1/1",
+						SHA:        "1",
+						Link:       "https://gerrit/c/trusted-repo/+/0",
+						CommitLink: "https://gerrit/trusted-repo/+/1",
+						AuthorLink: "https://gerrit/q/",
+					},
+				},
+			},
+		},
+	}
 	var testcases = []struct {
 		name           string
 		change         client.ChangeInfo
@@ -631,6 +702,7 @@ func TestTriggerJobs(t *testing.T) {
 		instance       string
 		wantError      bool
 		wantSkipReport bool
+		wantReviews    int
 		wantPjs        []*prowapi.ProwJob
 	}{
 		{
@@ -1455,6 +1527,244 @@ func TestTriggerJobs(t *testing.T) {
 			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
 			instance:     testInstance,
 		},
+		{
+			name: "presubmit does not run when a file matches run_if_changed but the change is private",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "test-infra",
+				Status:          "NEW",
+				IsPrivate:       true,
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Files: map[string]client.FileInfo{
+							"important-code.go": {},
+						},
+						Created: stampNow,
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+		},
+		{
+			name: "test all is ignored on private change of repo ignoring commands until ready",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "other-repo",
+				Status:          "NEW",
+				IsPrivate:       true,
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Message:        "/test all",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+		},
+		{
+			name: "making a change public does not trigger jobs while it is still WorkInProgress",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "other-repo",
+				Status:          "NEW",
+				WorkInProgress:  true,
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Message:        client.UnsetPrivateMessage,
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+		},
+		{
+			name: "new revision of change owned by trusted user triggers jobs",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "trusted-repo",
+				Status:          "NEW",
+				Owner:           gerrit.AccountInfo{AccountID: 1},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Ref:     "refs/changes/00/1/1",
+						Created: stampNow,
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantPjs:      []*prowapi.ProwJob{trustedRepoJob},
+		},
+		{
+			name: "new revision of change owned by untrusted user triggers no jobs",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "trusted-repo",
+				Status:          "NEW",
+				Owner:           gerrit.AccountInfo{AccountID: 2},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Ref:     "refs/changes/00/1/1",
+						Created: stampNow,
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+		},
+		{
+			name: "new revision uploaded by untrusted user to change owned by trusted user triggers no jobs",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "trusted-repo",
+				Status:          "NEW",
+				Owner:           gerrit.AccountInfo{AccountID: 1},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:   1,
+						Ref:      "refs/changes/00/1/1",
+						Created:  stampNow,
+						Uploader: gerrit.AccountInfo{AccountID: 2},
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+		},
+		{
+			name: "ok-to-test on an earlier revision does not trust a new revision",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "trusted-repo",
+				Status:          "NEW",
+				Owner:           gerrit.AccountInfo{AccountID: 2},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  2,
+						Ref:     "refs/changes/00/1/1",
+						Created: stampNow,
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Author:         gerrit.AccountInfo{AccountID: 1},
+						Message:        "/ok-to-test",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+		},
+		{
+			name: "test all of untrusted user on untrusted change is rejected",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "trusted-repo",
+				Status:          "NEW",
+				Owner:           gerrit.AccountInfo{AccountID: 2},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Ref:     "refs/changes/00/1/1",
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Author:         gerrit.AccountInfo{AccountID: 2},
+						Message:        "/test all",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantReviews:  1,
+		},
+		{
+			name: "ok-to-test of trusted user triggers jobs on untrusted change",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "trusted-repo",
+				Status:          "NEW",
+				Owner:           gerrit.AccountInfo{AccountID: 2},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Ref:     "refs/changes/00/1/1",
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Author:         gerrit.AccountInfo{AccountID: 1},
+						Message:        "/ok-to-test",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantReviews:  1,
+			wantPjs:      []*prowapi.ProwJob{trustedRepoJob},
+		},
+		{
+			name: "test all of untrusted user is honored on change with earlier ok-to-test",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "trusted-repo",
+				Status:          "NEW",
+				Owner:           gerrit.AccountInfo{AccountID: 2},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Ref:     "refs/changes/00/1/1",
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Author:         gerrit.AccountInfo{AccountID: 1},
+						Message:        "/ok-to-test",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(-time.Hour)),
+					},
+					{
+						Author:         gerrit.AccountInfo{AccountID: 2},
+						Message:        "/test all",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantReviews:  1,
+			wantPjs:      []*prowapi.ProwJob{trustedRepoJob},
+		},
 		{
 			name: "presubmit doesn't run when no files match run_if_changed",
 			change: client.ChangeInfo{
@@ -3179,6 +3489,17 @@ func TestTriggerJobs(t *testing.T) {
 						},
 					},
 				},
+				"https://gerrit/trusted-repo": {
+					{
+						JobBase: config.JobBase{
+							Name: "trusted-test",
+						},
+						AlwaysRun: true,
+						Reporter: config.Reporter{
+							Context: "trusted-test",
+						},
+					},
+				},
 			},
 			PostsubmitsStatic: map[string][]config.Postsubmit{
 				"https://gerrit/postsubmits-project": {
@@ -3200,6 +3521,12 @@ func TestTriggerJobs(t *testing.T) {
 				Enabled:         map[string]*bool{"*": &trueBool},
 				AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
 			},
+			Gerrit: config.Gerrit{
+				OrgReposConfig: &config.GerritOrgRepoConfigs{
+					{Org: "https://gerrit", Repos: []string{"other-repo"}, IgnoreCommandsUntilReady: true},
+					{Org: "https://gerrit", Repos: []string{"trusted-repo"}, TrustedGroups: []string{"uuid-trusted"}},
+				},
+			},
 		},
 	}
 	fca := &fca{
@@ -3221,6 +3548,7 @@ func TestTriggerJobs(t *testing.T) {
 
 			var gc fgc
 			gc.instanceMap = tc.instancesMap
+			gc.groups = trustedGroups
 			c := &Controller{
 				config:                      fca.Config,
 				prowJobClient:               fakeProwJobClient.ProwV1().ProwJobs("prowjobs"),
@@ -3282,6 +3610,9 @@ func TestTriggerJobs(t *testing.T) {
 					t.Errorf("expected no comments, got: %d", gc.reviews)
 				}
 			}
+			if tc.wantReviews > 0 && gc.reviews != tc.wantReviews {
+				t.Errorf("expected %d comments, got: %d", tc.wantReviews, gc.reviews)
+			}
 		})
 	}
 }
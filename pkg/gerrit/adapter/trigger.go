@@ -17,6 +17,7 @@ limitations under the License.
 package adapter
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -55,22 +56,34 @@ func currentMessages(change gerrit.ChangeInfo, since time.Time) []gerrit.ChangeM
 	return messages
 }
 
+// indicatesChangeFromDraftToActiveState returns true if the message is posted
+// when a change is marked ready for review or made public.
 func indicatesChangeFromDraftToActiveState(s string) bool {
 	return strings.HasSuffix(s, client.ReadyForReviewMessageFixed) ||
-		strings.HasSuffix(s, client.ReadyForReviewMessageCustomizable)
+		strings.HasSuffix(s, client.ReadyForReviewMessageCustomizable) ||
+		strings.HasSuffix(s, client.UnsetPrivateMessage)
+}
+
+// isReady returns true if the change is neither work in progress nor private.
+// Jobs only run automatically on ready changes, like on non-draft pull
+// requests on GitHub.
+func isReady(change gerrit.ChangeInfo) bool {
+	return !change.WorkInProgress && !change.IsPrivate
 }
 
 // messageFilter returns filter that matches all /test all, /test foo, /retest comments since lastUpdate.
 //
 // The behavior of each message matches the behavior of pjutil.PresubmitFilter.
-func messageFilter(messages []gerrit.ChangeMessageInfo, failingContexts, allContexts sets.Set[string], triggerTimes map[string]time.Time, logger logrus.FieldLogger) pjutil.Filter {
+// All presubmits are triggered when a ready change was made ready by one of the messages.
+// /ok-to-test comments trigger all presubmits too if honorOkToTest is true.
+func messageFilter(messages []gerrit.ChangeMessageInfo, ready, honorOkToTest bool, failingContexts, allContexts sets.Set[string], triggerTimes map[string]time.Time, logger logrus.FieldLogger) pjutil.Filter {
 	var filters []pjutil.Filter
 	contextGetter := func() (sets.Set[string], sets.Set[string], error) {
 		return failingContexts, allContexts, nil
 	}
 	for _, message := range messages {
 		// Use the PresubmitFilter before possibly adding the /test-all filter to ensure explicitly requested presubmits are forced to run.
-		filter, err := pjutil.PresubmitFilter(false, contextGetter, message.Message, logger)
+		filter, err := pjutil.PresubmitFilter(honorOkToTest, contextGetter, message.Message, logger)
 		if err != nil {
 			logger.WithError(err).WithField("message", message).Warn("failed to create presubmit filter")
 			continue
@@ -82,7 +95,7 @@ func messageFilter(messages []gerrit.ChangeMessageInfo, failingContexts, allCont
 		})
 		// If the Gerrit Change changed from draft to active state, trigger all
 		// presubmit Prow jobs.
-		if indicatesChangeFromDraftToActiveState(message.Message) {
+		if ready && indicatesChangeFromDraftToActiveState(message.Message) {
 			filters = append(filters, &timeAnnotationFilter{
 				Filter:       pjutil.NewTestAllFilter(),
 				eventTime:    message.Date.Time,
@@ -110,3 +123,118 @@ func (taf *timeAnnotationFilter) ShouldRun(p config.Presubmit) (shouldRun bool,
 	}
 	return shouldRun, forced, def
 }
+
+// trustChecker determines whether the users and changes of a repo are trusted
+// to run jobs, the equivalent of the org membership and the ok-to-test label on
+// GitHub. Everyone is trusted if the repo has no trusted groups.
+type trustChecker struct {
+	gc       gerritClient
+	instance string
+	groups   sets.Set[string]
+	// trusted caches whether accounts are trusted, by account ID.
+	trusted map[int]bool
+}
+
+func newTrustChecker(gc gerritClient, instance string, groups []string) *trustChecker {
+	return &trustChecker{
+		gc:       gc,
+		instance: instance,
+		groups:   sets.New[string](groups...),
+		trusted:  map[int]bool{},
+	}
+}
+
+// enabled returns true if not everyone is trusted.
+func (t *trustChecker) enabled() bool {
+	return t.groups.Len() > 0
+}
+
+// accountTrusted returns true if the account is a member of a trusted group.
+func (t *trustChecker) accountTrusted(accountID int) (bool, error) {
+	if !t.enabled() {
+		return true, nil
+	}
+	if trusted, ok := t.trusted[accountID]; ok {
+		return trusted, nil
+	}
+	groups, err := t.gc.AccountGroups(t.instance, accountID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get groups of account %d: %w", accountID, err)
+	}
+	var trusted bool
+	for _, group := range groups {
+		if t.groups.Has(group.Name) || t.groups.Has(group.ID) {
+			trusted = true
+			break
+		}
+	}
+	t.trusted[accountID] = trusted
+	return trusted, nil
+}
+
+// changeTrusted returns true if both the owner of the change and the uploader
+// of its current revision are trusted, or if a trusted user commented
+// /ok-to-test on the current revision. Gerrit lets users upload revisions to
+// the changes of others, so the trust of the owner alone does not vouch for
+// the code of the current revision.
+func (t *trustChecker) changeTrusted(change gerrit.ChangeInfo) (bool, error) {
+	revision := change.Revisions[change.CurrentRevision]
+	ownerTrusted, err := t.accountTrusted(change.Owner.AccountID)
+	if err != nil {
+		return false, err
+	}
+	if ownerTrusted {
+		uploaderTrusted := true
+		if revision.Uploader.AccountID != 0 && revision.Uploader.AccountID != change.Owner.AccountID {
+			if uploaderTrusted, err = t.accountTrusted(revision.Uploader.AccountID); err != nil {
+				return false, err
+			}
+		}
+		if uploaderTrusted {
+			return true, nil
+		}
+	}
+	for _, message := range change.Messages {
+		if message.RevisionNumber < revision.Number || !pjutil.OkToTestRe.MatchString(message.Message) {
+			continue
+		}
+		if trusted, err := t.accountTrusted(message.Author.AccountID); err != nil || trusted {
+			return trusted, err
+		}
+	}
+	return false, nil
+}
+
+// trustedMessages returns the messages whose commands are honored: all of them
+// on trusted changes, only the ones of trusted users otherwise. It also returns
+// whether a command of an untrusted user was ignored. Messages of the bot
+// account itself never count as ignored commands.
+func (t *trustChecker) trustedMessages(messages []gerrit.ChangeMessageInfo, changeTrusted bool, botAccountID int) ([]gerrit.ChangeMessageInfo, bool, error) {
+	if changeTrusted {
+		return messages, false, nil
+	}
+	var trusted []gerrit.ChangeMessageInfo
+	var rejected bool
+	for _, message := range messages {
+		ok, err := t.accountTrusted(message.Author.AccountID)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			trusted = append(trusted, message)
+		} else if message.Author.AccountID != botAccountID && containsCommand(message.Message) {
+			rejected = true
+		}
+	}
+	return trusted, rejected, nil
+}
+
+// containsCommand returns true if the message contains a command that triggers
+// jobs.
+func containsCommand(message string) bool {
+	return pjutil.RetestRe.MatchString(message) ||
+		pjutil.RetestRequiredRe.MatchString(message) ||
+		pjutil.TestAllRe.MatchString(message) ||
+		pjutil.TestWithAnyTargetRe.MatchString(message) ||
+		pjutil.OkToTestRe.MatchString(message)
+}
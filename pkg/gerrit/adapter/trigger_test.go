@@ -336,7 +336,7 @@ func TestMessageFilter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			logger := logrus.WithField("case", tc.name)
 			triggerTimes := map[string]time.Time{}
-			filt := messageFilter(tc.messages, tc.failed, tc.all, triggerTimes, logger)
+			filt := messageFilter(tc.messages, true, false, tc.failed, tc.all, triggerTimes, logger)
 			for _, check := range tc.checks {
 				t.Run(check.job.Name, func(t *testing.T) {
 					fixed := []config.Presubmit{check.job}
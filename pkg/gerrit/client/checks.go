@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	gerrit "github.com/andygrunwald/go-gerrit"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// States of checks of the Gerrit checks plugin, see
+// https://gerrit.googlesource.com/plugins/checks/+/refs/heads/master/resources/Documentation/rest-api-checks.md#check-state
+const (
+	CheckStateNotStarted  = "NOT_STARTED"
+	CheckStateScheduled   = "SCHEDULED"
+	CheckStateRunning     = "RUNNING"
+	CheckStateSuccessful  = "SUCCESSFUL"
+	CheckStateFailed      = "FAILED"
+	CheckStateNotRelevant = "NOT_RELEVANT"
+)
+
+// CheckerInput is the input for creating a checker of the Gerrit checks plugin.
+type CheckerInput struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Repository  string `json:"repository"`
+	Status      string `json:"status,omitempty"`
+	Query       string `json:"query,omitempty"`
+}
+
+// CheckerInfo is a checker of the Gerrit checks plugin.
+type CheckerInfo struct {
+	UUID       string `json:"uuid"`
+	Name       string `json:"name"`
+	Repository string `json:"repository"`
+	Status     string `json:"status"`
+}
+
+// CheckInput is the input for creating or updating the check of a checker on
+// a revision.
+type CheckInput struct {
+	CheckerUUID string            `json:"checker_uuid"`
+	State       string            `json:"state,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Started     *gerrit.Timestamp `json:"started,omitempty"`
+	Finished    *gerrit.Timestamp `json:"finished,omitempty"`
+}
+
+// CheckInfo is the check of a checker on a revision.
+type CheckInfo struct {
+	CheckerUUID string `json:"checker_uuid"`
+	State       string `json:"state"`
+	URL         string `json:"url,omitempty"`
+}
+
+// CheckerUUID returns the UUID of the checker of a job. Checkers belong to a
+// single repo, so the UUID includes the repo.
+func CheckerUUID(project, job string) string {
+	return fmt.Sprintf("prow:%s/%s", project, job)
+}
+
+// checksService calls the REST API of the Gerrit checks plugin, which the
+// go-gerrit client doesn't support.
+type checksService struct {
+	client *gerrit.Client
+}
+
+func (s *checksService) GetChecker(uuid string) (*CheckerInfo, *gerrit.Response, error) {
+	v := new(CheckerInfo)
+	resp, err := s.client.Call("GET", "plugins/checks/checkers/"+url.PathEscape(uuid), nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+func (s *checksService) CreateChecker(input *CheckerInput) (*CheckerInfo, *gerrit.Response, error) {
+	v := new(CheckerInfo)
+	resp, err := s.client.Call("POST", "plugins/checks/checkers/", input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+func (s *checksService) ListChecks(changeID, revisionID string) (*[]CheckInfo, *gerrit.Response, error) {
+	v := new([]CheckInfo)
+	u := fmt.Sprintf("changes/%s/revisions/%s/checks/", changeID, revisionID)
+	resp, err := s.client.Call("GET", u, nil, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+func (s *checksService) UpdateCheck(changeID, revisionID string, input *CheckInput) (*CheckInfo, *gerrit.Response, error) {
+	v := new(CheckInfo)
+	u := fmt.Sprintf("changes/%s/revisions/%s/checks/", changeID, revisionID)
+	resp, err := s.client.Call("POST", u, input, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// EnsureChecker creates the checker on the instance unless it already exists.
+// Checkers known to exist are cached.
+func (c *Client) EnsureChecker(instance string, checker CheckerInput) error {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	known := c.checkers[instance].Has(checker.UUID)
+	c.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+	if known {
+		return nil
+	}
+
+	if _, resp, err := h.checksService.GetChecker(checker.UUID); err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("cannot get checker %s: %w", checker.UUID, responseBodyError(err, resp))
+		}
+		// A concurrent reporter might have created the checker in the meantime.
+		if _, resp, err := h.checksService.CreateChecker(&checker); err != nil && (resp == nil || resp.StatusCode != http.StatusConflict) {
+			return fmt.Errorf("cannot create checker %s: %w", checker.UUID, responseBodyError(err, resp))
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.checkers == nil {
+		c.checkers = map[string]sets.Set[string]{}
+	}
+	if c.checkers[instance] == nil {
+		c.checkers[instance] = sets.New[string]()
+	}
+	c.checkers[instance].Insert(checker.UUID)
+	return nil
+}
+
+// ListChecks returns the checks on the revision of the change.
+func (c *Client) ListChecks(instance, id, revision string) ([]CheckInfo, error) {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	c.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+
+	checks, resp, err := h.checksService.ListChecks(id, revision)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list checks: %w", responseBodyError(err, resp))
+	}
+	return *checks, nil
+}
+
+// SetCheck creates or updates the check of a checker on the revision of the
+// change.
+func (c *Client) SetCheck(instance, id, revision string, check CheckInput) error {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	c.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+
+	if _, resp, err := h.checksService.UpdateCheck(id, revision, &check); err != nil {
+		return fmt.Errorf("cannot set check %s: %w", check.CheckerUUID, responseBodyError(err, resp))
+	}
+	return nil
+}
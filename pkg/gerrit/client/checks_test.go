@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gerrit "github.com/andygrunwald/go-gerrit"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChecks(t *testing.T) {
+	checkers := map[string]CheckerInput{}
+	var requests []string
+	var checks []CheckInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/plugins/checks/checkers/prow:repo/job":
+			checker, ok := checkers["prow:repo/job"]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(CheckerInfo{UUID: checker.UUID, Repository: checker.Repository})
+		case r.Method == http.MethodPost && r.URL.Path == "/plugins/checks/checkers/":
+			var checker CheckerInput
+			if err := json.Unmarshal(body, &checker); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			checkers[checker.UUID] = checker
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(CheckerInfo{UUID: checker.UUID, Repository: checker.Repository})
+		case r.Method == http.MethodPost && r.URL.Path == "/changes/change-id/revisions/abc/checks/":
+			var check CheckInput
+			if err := json.Unmarshal(body, &check); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			checks = append(checks, check)
+			json.NewEncoder(w).Encode(CheckInfo{CheckerUUID: check.CheckerUUID, State: check.State})
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	gc, err := gerrit.NewClient(server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create gerrit client: %v", err)
+	}
+	c := &Client{handlers: map[string]*gerritInstanceHandler{
+		"instance": {checksService: &checksService{client: gc}},
+	}}
+
+	checker := CheckerInput{UUID: "prow:repo/job", Name: "job", Repository: "repo"}
+	for i := 0; i < 2; i++ {
+		if err := c.EnsureChecker("instance", checker); err != nil {
+			t.Fatalf("Failed to ensure checker: %v", err)
+		}
+	}
+	check := CheckInput{CheckerUUID: checker.UUID, State: CheckStateRunning, URL: "https://prow/job"}
+	if err := c.SetCheck("instance", "change-id", "abc", check); err != nil {
+		t.Fatalf("Failed to set check: %v", err)
+	}
+	if err := c.EnsureChecker("other-instance", checker); err == nil {
+		t.Error("Expected an error for an unknown instance.")
+	}
+
+	expectedRequests := []string{
+		"GET /plugins/checks/checkers/prow:repo%2Fjob",
+		"POST /plugins/checks/checkers/",
+		"POST /changes/change-id/revisions/abc/checks/",
+	}
+	if diff := cmp.Diff(expectedRequests, requests); diff != "" {
+		t.Errorf("Unexpected requests (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]CheckerInput{checker.UUID: checker}, checkers); diff != "" {
+		t.Errorf("Unexpected checkers (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]CheckInput{check}, checks); diff != "" {
+		t.Errorf("Unexpected checks (-want +got):\n%s", diff)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -56,6 +57,9 @@ const (
 	ReadyForReviewMessageFixed = "Set Ready For Review"
 	// This message will be sent if users press the `SEND AND START REVIEW` button.
 	ReadyForReviewMessageCustomizable = "This change is ready for review."
+	// UnsetPrivateMessage is the message for a Gerrit change if it's changed
+	// from private to public.
+	UnsetPrivateMessage = "Unset private"
 
 	ResultError   = "ERROR"
 	ResultSuccess = "SUCCESS"
@@ -85,6 +89,7 @@ type gerritAuthentication interface {
 type gerritAccount interface {
 	GetAccount(name string) (*gerrit.AccountInfo, *gerrit.Response, error)
 	SetUsername(accountID string, input *gerrit.UsernameInput) (*string, *gerrit.Response, error)
+	ListGroups(accountID string) (*[]gerrit.GroupInfo, *gerrit.Response, error)
 }
 
 type gerritChange interface {
@@ -100,6 +105,13 @@ type gerritProjects interface {
 	GetBranch(projectName, branchID string) (*gerrit.BranchInfo, *gerrit.Response, error)
 }
 
+type gerritChecks interface {
+	GetChecker(uuid string) (*CheckerInfo, *gerrit.Response, error)
+	CreateChecker(input *CheckerInput) (*CheckerInfo, *gerrit.Response, error)
+	ListChecks(changeID, revisionID string) (*[]CheckInfo, *gerrit.Response, error)
+	UpdateCheck(changeID, revisionID string, input *CheckInput) (*CheckInfo, *gerrit.Response, error)
+}
+
 type gerritRevision interface {
 	GetMergeable(changeID, revisionID string, opt *gerrit.MergableOptions) (*gerrit.MergeableInfo, *gerrit.Response, error)
 }
@@ -114,6 +126,7 @@ type gerritInstanceHandler struct {
 	changeService   gerritChange
 	projectService  gerritProjects
 	revisionService gerritRevision
+	checksService   gerritChecks
 
 	log logrus.FieldLogger
 }
@@ -123,6 +136,8 @@ type Client struct {
 	handlers map[string]*gerritInstanceHandler
 	// map of instance to gerrit account
 	accounts map[string]*gerrit.AccountInfo
+	// map of instance to the UUIDs of the checkers known to exist
+	checkers map[string]sets.Set[string]
 
 	httpClient http.Client
 
@@ -176,6 +191,7 @@ func NewClient(instances map[string]map[string]*config.GerritQueryFilter, maxQPS
 	c := &Client{
 		handlers: map[string]*gerritInstanceHandler{},
 		accounts: map[string]*gerrit.AccountInfo{},
+		checkers: map[string]sets.Set[string]{},
 
 		httpClient: http.Client{
 			Transport: roundTripper,
@@ -328,6 +344,7 @@ func (c *Client) newInstanceHandler(instance string, projects map[string]*config
 		accountService: gc.Accounts,
 		changeService:  gc.Changes,
 		projectService: gc.Projects,
+		checksService:  &checksService{client: gc},
 		log:            logrus.WithField("host", instance),
 	}, nil
 }
@@ -548,6 +565,25 @@ func (c *Client) Account(instance string) (*gerrit.AccountInfo, error) {
 	return c.accounts[instance], nil
 }
 
+// AccountGroups returns the groups the account is a member of on the instance.
+func (c *Client) AccountGroups(instance string, accountID int) ([]gerrit.GroupInfo, error) {
+	c.lock.RLock()
+	h, ok := c.handlers[instance]
+	c.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("not activated gerrit instance: %s", instance)
+	}
+
+	groups, resp, err := h.accountService.ListGroups(strconv.Itoa(accountID))
+	if err != nil {
+		return nil, responseBodyError(err, resp)
+	}
+	if groups == nil {
+		return nil, nil
+	}
+	return *groups, nil
+}
+
 func (c *Client) GetMergeableInfo(instance, changeID, revisionID string) (*gerrit.MergeableInfo, error) {
 	c.lock.RLock()
 	h, ok := c.handlers[instance]
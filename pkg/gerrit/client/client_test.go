@@ -68,7 +68,7 @@ func TestApplyGlobalConfigOnce(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "value.txt")
 	// Empty opener so *syncTime won't panic.
-	opener, err := io.NewOpener(context.Background(), "", "")
+	opener, err := io.NewOpener(context.Background(), "", "", "")
 	if err != nil {
 		t.Fatalf("Failed to create opener: %v", err)
 	}
@@ -49,7 +49,7 @@ func TestSyncTime(t *testing.T) {
 	path := filepath.Join(dir, "value.txt")
 	var noCreds string
 	ctx := context.Background()
-	open, err := io.NewOpener(ctx, noCreds, noCreds)
+	open, err := io.NewOpener(ctx, noCreds, noCreds, noCreds)
 	if err != nil {
 		t.Fatalf("Failed to create opener: %v", err)
 	}
@@ -135,7 +135,7 @@ func TestSyncTimeThreadSafe(t *testing.T) {
 	path := filepath.Join(dir, "value.txt")
 	var noCreds string
 	ctx := context.Background()
-	open, err := io.NewOpener(ctx, noCreds, noCreds)
+	open, err := io.NewOpener(ctx, noCreds, noCreds, noCreds)
 	if err != nil {
 		t.Fatalf("Failed to create opener: %v", err)
 	}
@@ -212,7 +212,7 @@ func TestNewProjectAddition(t *testing.T) {
 
 	var noCreds string
 	ctx := context.Background()
-	open, err := io.NewOpener(ctx, noCreds, noCreds)
+	open, err := io.NewOpener(ctx, noCreds, noCreds, noCreds)
 	if err != nil {
 		t.Fatalf("Failed to create opener: %v", err)
 	}
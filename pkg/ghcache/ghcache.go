@@ -43,7 +43,6 @@ import (
 
 	"github.com/cjwagner/httpcache"
 	"github.com/cjwagner/httpcache/diskcache"
-	rediscache "github.com/cjwagner/httpcache/redis"
 	"github.com/gomodule/redigo/redis"
 	"github.com/peterbourgon/diskv"
 	"github.com/prometheus/client_golang/prometheus"
@@ -522,29 +521,49 @@ type CachePartitionCreator func(partitionKey string, expiresAt *time.Time) httpc
 func NewFromCache(roundTripper http.RoundTripper, cache CachePartitionCreator, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
 	hasher := ghmetrics.NewCachingHasher()
 	return newPartitioningRoundTripper(func(partitionKey string, expiresAt *time.Time) http.RoundTripper {
-		cacheTransport := httpcache.NewTransport(cache(partitionKey, expiresAt))
-		cacheTransport.Transport = newThrottlingTransport(maxConcurrency, upstreamTransport{roundTripper: roundTripper, hasher: hasher}, hasher, throttlingTimes)
 		return &requestCoalescer{
 			cache:           make(map[string]*firstRequest),
-			requestExecutor: cacheTransport,
+			requestExecutor: newCacheTransport(roundTripper, cache(partitionKey, expiresAt), hasher, maxConcurrency, throttlingTimes),
 			hasher:          hasher,
 		}
 	})
 }
 
+func newCacheTransport(roundTripper http.RoundTripper, cache httpcache.Cache, hasher ghmetrics.Hasher, maxConcurrency int, throttlingTimes RequestThrottlingTimes) *httpcache.Transport {
+	cacheTransport := httpcache.NewTransport(cache)
+	cacheTransport.Transport = newThrottlingTransport(maxConcurrency, upstreamTransport{roundTripper: roundTripper, hasher: hasher}, hasher, throttlingTimes)
+	return cacheTransport
+}
+
 // NewRedisCache creates a GitHub cache RoundTripper that is backed by a Redis
-// cache.
-// Important note: The redis implementation does not support partitioning the cache
-// which means that requests to the same path from different tokens will invalidate
-// each other.
-func NewRedisCache(roundTripper http.RoundTripper, redisAddress string, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
-	conn, err := redis.Dial("tcp", redisAddress)
-	if err != nil {
+// cache, which can be shared by several ghproxy replicas.
+// It supports a partitioned cache. Keys are distributed over the Redis servers
+// with consistent hashing, and concurrent requests for the same URI are
+// coalesced across replicas.
+func NewRedisCache(roundTripper http.RoundTripper, redisAddresses []string, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
+	shards := newRedisShards(redisAddresses, func(address string) (redis.Conn, error) {
+		return redis.Dial("tcp", address)
+	})
+	if err := shards.ping(); err != nil {
 		logrus.WithError(err).Fatal("Error connecting to Redis")
 	}
-	redisCache := rediscache.NewWithClient(conn)
-	return NewFromCache(roundTripper,
-		func(_ string, _ *time.Time) httpcache.Cache { return redisCache },
-		maxConcurrency,
-		throttlingTimes)
+	return newRedisCache(roundTripper, shards, maxConcurrency, throttlingTimes)
+}
+
+func newRedisCache(roundTripper http.RoundTripper, shards *redisShards, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
+	hasher := ghmetrics.NewCachingHasher()
+	return newPartitioningRoundTripper(func(partitionKey string, expiresAt *time.Time) http.RoundTripper {
+		cache := &redisCache{shards: shards, partitionKey: partitionKey, expiresAt: expiresAt}
+		return &requestCoalescer{
+			cache: make(map[string]*firstRequest),
+			requestExecutor: &redisCoalescer{
+				shards:          shards,
+				partitionKey:    partitionKey,
+				requestExecutor: newCacheTransport(roundTripper, cache, hasher, maxConcurrency, throttlingTimes),
+				lockTTL:         redisLockTTL,
+				pollInterval:    redisLockPollInterval,
+			},
+			hasher: hasher,
+		}
+	})
 }
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	redisKeyPrefix  = "ghcache:"
+	redisLockPrefix = "ghcache-lock:"
+
+	// redisLockTTL is the longest time a request waits for the same request
+	// of another replica to finish.
+	redisLockTTL          = 30 * time.Second
+	redisLockPollInterval = 100 * time.Millisecond
+)
+
+// redisUnlockScript deletes a lock only if it is still held by its owner, so
+// that a request whose lock expired doesn't release the lock of another one.
+var redisUnlockScript = redis.NewScript(1, `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`)
+
+// redisKey returns the key of a cache entry or lock in Redis. The partition and
+// request keys are hashed so that every replica computes the same key, keys have
+// a bounded length and neither credentials nor URLs end up in Redis.
+func redisKey(prefix, partitionKey, key string) string {
+	sum := sha256.Sum256([]byte(partitionKey + "\n" + key))
+	return prefix + hex.EncodeToString(sum[:])
+}
+
+// redisShards distributes keys over one or more Redis servers with rendezvous
+// hashing: every replica looks up a key on the same server, and adding or
+// removing a server only moves the keys that it takes over or held.
+type redisShards struct {
+	addresses []string
+	pools     []*redis.Pool
+}
+
+func newRedisShards(addresses []string, dial func(address string) (redis.Conn, error)) *redisShards {
+	shards := &redisShards{addresses: addresses}
+	for _, address := range addresses {
+		address := address
+		shards.pools = append(shards.pools, &redis.Pool{
+			Dial:        func() (redis.Conn, error) { return dial(address) },
+			MaxIdle:     10,
+			IdleTimeout: 5 * time.Minute,
+		})
+	}
+	return shards
+}
+
+func (s *redisShards) pool(key string) *redis.Pool {
+	var pool *redis.Pool
+	var highest uint64
+	for i, address := range s.addresses {
+		sum := sha256.Sum256([]byte(address + "\n" + key))
+		if score := binary.BigEndian.Uint64(sum[:8]); pool == nil || score > highest {
+			pool, highest = s.pools[i], score
+		}
+	}
+	return pool
+}
+
+// do runs a command whose first argument is the key on the server of the key.
+func (s *redisShards) do(command, key string, args ...interface{}) (interface{}, error) {
+	conn := s.pool(key).Get()
+	defer conn.Close()
+	return conn.Do(command, append([]interface{}{key}, args...)...)
+}
+
+// ping checks that all servers are reachable.
+func (s *redisShards) ping() error {
+	for i, pool := range s.pools {
+		conn := pool.Get()
+		_, err := conn.Do("PING")
+		conn.Close()
+		if err != nil {
+			return fmt.Errorf("failed to ping Redis at %s: %w", s.addresses[i], err)
+		}
+	}
+	return nil
+}
+
+// redisCache is a partition of the cache stored in Redis. It implements
+// httpcache.Cache.
+type redisCache struct {
+	shards       *redisShards
+	partitionKey string
+	// expiresAt is when the token of the partition expires, if it does. Its
+	// entries are useless afterwards, so Redis evicts them then.
+	expiresAt *time.Time
+}
+
+func (c *redisCache) Get(key string) ([]byte, bool) {
+	resp, err := redis.Bytes(c.shards.do("GET", redisKey(redisKeyPrefix, c.partitionKey, key)))
+	if err != nil {
+		if !errors.Is(err, redis.ErrNil) {
+			logrus.WithField("cache-key", key).WithError(err).Warn("Failed to get response from Redis.")
+		}
+		return nil, false
+	}
+	return resp, true
+}
+
+func (c *redisCache) Set(key string, resp []byte) {
+	args := []interface{}{resp}
+	if c.expiresAt != nil {
+		ttl := time.Until(*c.expiresAt)
+		if ttl <= 0 {
+			return
+		}
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	if _, err := c.shards.do("SET", redisKey(redisKeyPrefix, c.partitionKey, key), args...); err != nil {
+		logrus.WithField("cache-key", key).WithError(err).Warn("Failed to store response in Redis.")
+	}
+}
+
+func (c *redisCache) Delete(key string) {
+	if _, err := c.shards.do("DEL", redisKey(redisKeyPrefix, c.partitionKey, key)); err != nil {
+		logrus.WithField("cache-key", key).WithError(err).Warn("Failed to delete response from Redis.")
+	}
+}
+
+// redisCoalescer coalesces concurrent GET requests for the same URI across the
+// replicas sharing a Redis cache, complementing the requestCoalescer of each
+// replica. The replica that gets the lock of a URI makes the request, while the
+// others wait for it to finish and then revalidate the response it stored,
+// which doesn't cost any API tokens if the resource didn't change.
+// Redis errors don't fail requests, they just aren't coalesced then.
+type redisCoalescer struct {
+	shards       *redisShards
+	partitionKey string
+
+	requestExecutor http.RoundTripper
+
+	lockTTL      time.Duration
+	pollInterval time.Duration
+}
+
+func (c *redisCoalescer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.requestExecutor.RoundTrip(req)
+	}
+
+	lock := redisKey(redisLockPrefix, c.partitionKey, req.URL.String())
+	token, err := redisLockToken()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to create Redis lock token.")
+		return c.requestExecutor.RoundTrip(req)
+	}
+	log := logrus.WithField("cache-key", req.URL.String())
+	deadline := time.Now().Add(c.lockTTL)
+	for {
+		_, err := redis.String(c.shards.do("SET", lock, token, "NX", "PX", c.lockTTL.Milliseconds()))
+		if err == nil {
+			defer func() {
+				conn := c.shards.pool(lock).Get()
+				defer conn.Close()
+				if _, err := redisUnlockScript.Do(conn, lock, token); err != nil {
+					log.WithError(err).Warn("Failed to release Redis lock.")
+				}
+			}()
+			break
+		}
+		if !errors.Is(err, redis.ErrNil) {
+			log.WithError(err).Warn("Failed to acquire Redis lock.")
+			break
+		}
+		// Another replica is making the same request.
+		if time.Now().After(deadline) {
+			break
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(c.pollInterval):
+		}
+	}
+	return c.requestExecutor.RoundTrip(req)
+}
+
+func redisLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
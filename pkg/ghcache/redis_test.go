@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// fakeRedis is an in-memory Redis server supporting the commands used by the
+// cache.
+type fakeRedis struct {
+	lock sync.Mutex
+	data map[string][]byte
+	ttls map[string]int64
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{data: map[string][]byte{}, ttls: map[string]int64{}}
+}
+
+func (r *fakeRedis) dial(string) (redis.Conn, error) {
+	return &fakeRedisConn{redis: r}, nil
+}
+
+type fakeRedisConn struct {
+	redis *fakeRedis
+}
+
+func (c *fakeRedisConn) Close() error { return nil }
+func (c *fakeRedisConn) Err() error   { return nil }
+func (c *fakeRedisConn) Send(string, ...interface{}) error {
+	return fmt.Errorf("not implemented")
+}
+func (c *fakeRedisConn) Flush() error { return fmt.Errorf("not implemented") }
+func (c *fakeRedisConn) Receive() (interface{}, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (c *fakeRedisConn) Do(command string, args ...interface{}) (interface{}, error) {
+	r := c.redis
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	switch strings.ToUpper(command) {
+	case "PING":
+		return "PONG", nil
+	case "GET":
+		value, ok := r.data[args[0].(string)]
+		if !ok {
+			return nil, nil
+		}
+		return value, nil
+	case "SET":
+		key := args[0].(string)
+		var nx bool
+		for i := 2; i < len(args); i++ {
+			switch args[i] {
+			case "NX":
+				nx = true
+			case "PX":
+				i++
+				r.ttls[key] = args[i].(int64)
+			}
+		}
+		if _, exists := r.data[key]; nx && exists {
+			return nil, nil
+		}
+		switch value := args[1].(type) {
+		case []byte:
+			r.data[key] = value
+		case string:
+			r.data[key] = []byte(value)
+		}
+		return "OK", nil
+	case "DEL":
+		delete(r.data, args[0].(string))
+		return int64(1), nil
+	case "EVALSHA":
+		// The only script is redisUnlockScript.
+		key, token := args[2].(string), args[3].(string)
+		if string(r.data[key]) != token {
+			return int64(0), nil
+		}
+		delete(r.data, key)
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unsupported command %s", command)
+}
+
+func TestRedisCache(t *testing.T) {
+	fake := newFakeRedis()
+	shards := newRedisShards([]string{"a", "b", "c"}, fake.dial)
+	expiresAt := time.Now().Add(time.Hour)
+	cache := &redisCache{shards: shards, partitionKey: "partition", expiresAt: &expiresAt}
+	otherPartition := &redisCache{shards: shards, partitionKey: "other"}
+
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Expected no response before storing one")
+	}
+	cache.Set("key", []byte("response"))
+	if resp, ok := cache.Get("key"); !ok || string(resp) != "response" {
+		t.Errorf("Expected the stored response, got %q", string(resp))
+	}
+	if _, ok := otherPartition.Get("key"); ok {
+		t.Error("Expected no response in another partition")
+	}
+	if ttl := fake.ttls[redisKey(redisKeyPrefix, "partition", "key")]; ttl <= 0 || ttl > time.Hour.Milliseconds() {
+		t.Errorf("Expected the response to expire with the token of the partition, got TTL %dms", ttl)
+	}
+	cache.Delete("key")
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Expected no response after deleting it")
+	}
+
+	expired := time.Now().Add(-time.Minute)
+	cache.expiresAt = &expired
+	cache.Set("key", []byte("response"))
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Expected no response to be stored for an expired partition")
+	}
+}
+
+func TestRedisShards(t *testing.T) {
+	fake := newFakeRedis()
+	addresses := []string{"a", "b", "c"}
+	shards := newRedisShards(addresses, fake.dial)
+	moreShards := newRedisShards(append(addresses, "d"), fake.dial)
+
+	used := map[*redis.Pool]bool{}
+	var moved int
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%d", i)
+		pool := shards.pool(key)
+		if pool != shards.pool(key) {
+			t.Fatalf("Expected key %s to always be on the same server", key)
+		}
+		used[pool] = true
+
+		index := func(s *redisShards, pool *redis.Pool) int {
+			for i := range s.pools {
+				if s.pools[i] == pool {
+					return i
+				}
+			}
+			return -1
+		}
+		before, after := index(shards, pool), index(moreShards, moreShards.pool(key))
+		if before != after {
+			if after != 3 {
+				t.Errorf("Expected key %s to move only to the added server, moved from %d to %d", key, before, after)
+			}
+			moved++
+		}
+	}
+	if len(used) != len(addresses) {
+		t.Errorf("Expected keys on all %d servers, got %d", len(addresses), len(used))
+	}
+	if moved == 0 || moved > 400 {
+		t.Errorf("Expected about a quarter of the keys to move to the added server, got %d of 1000", moved)
+	}
+}
+
+type countingRoundTripper struct {
+	lock     sync.Mutex
+	requests int
+	release  chan struct{}
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.lock.Lock()
+	rt.requests++
+	rt.lock.Unlock()
+	if rt.release != nil {
+		<-rt.release
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestRedisCoalescer(t *testing.T) {
+	fake := newFakeRedis()
+	shards := newRedisShards([]string{"a"}, fake.dial)
+	first := &countingRoundTripper{release: make(chan struct{})}
+	second := &countingRoundTripper{}
+	newCoalescer := func(rt http.RoundTripper) *redisCoalescer {
+		return &redisCoalescer{
+			shards:          shards,
+			partitionKey:    "partition",
+			requestExecutor: rt,
+			lockTTL:         time.Minute,
+			pollInterval:    time.Millisecond,
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://api.github.com/repos/org/repo", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		if _, err := newCoalescer(first).RoundTrip(req); err != nil {
+			t.Errorf("First request failed: %v", err)
+		}
+	}()
+	lock := redisKey(redisLockPrefix, "partition", req.URL.String())
+	for {
+		fake.lock.Lock()
+		_, locked := fake.data[lock]
+		fake.lock.Unlock()
+		if locked {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+		if _, err := newCoalescer(second).RoundTrip(req); err != nil {
+			t.Errorf("Second request failed: %v", err)
+		}
+	}()
+	select {
+	case <-secondDone:
+		t.Fatal("Expected the second request to wait for the first one")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(first.release)
+	<-firstDone
+	<-secondDone
+	if first.requests != 1 || second.requests != 1 {
+		t.Errorf("Expected the requests to be made one after the other, got %d and %d", first.requests, second.requests)
+	}
+	if _, locked := fake.data[lock]; locked {
+		t.Error("Expected the lock to be released")
+	}
+}
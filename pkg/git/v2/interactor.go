@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -59,7 +61,11 @@ type Interactor interface {
 	// MergeAndCheckout merges all commitlikes into the current HEAD with the appropriate strategy
 	MergeAndCheckout(baseSHA string, mergeStrategy string, headSHAs ...string) error
 	// Am calls `git am`
-	Am(path string) error
+	Am(path string, opts ...ApplyOpt) error
+	// CherryPick calls `git cherry-pick`
+	CherryPick(commitlike string, opts ...ApplyOpt) error
+	// IsMergeCommit determines if the commitlike has more than one parent
+	IsMergeCommit(commitlike string) (bool, error)
 	// Fetch calls `git fetch arg...`
 	Fetch(arg ...string) error
 	// FetchRef fetches the refspec
@@ -103,6 +109,28 @@ type MergeOpt struct {
 	CommitMessage string
 }
 
+// ApplyOpt holds options for applying changes with `git am` and
+// `git cherry-pick`.
+type ApplyOpt struct {
+	// Mainline is the parent number passed to `git cherry-pick -m` to pick
+	// a merge commit relative to that parent. It is ignored by `git am`.
+	Mainline int
+	// KeepConflicts commits conflicting changes including their conflict
+	// markers instead of aborting, so they can be resolved manually later.
+	// A *ConflictError listing the conflicting files is returned then.
+	KeepConflicts bool
+}
+
+// ConflictError is returned when changes were applied with conflicts that
+// were committed including their conflict markers.
+type ConflictError struct {
+	Files []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("changes were applied with conflicts in %s", strings.Join(e.Files, ", "))
+}
+
 type interactor struct {
 	executor executor
 	remote   RemoteResolver
@@ -419,12 +447,15 @@ func (i *interactor) MergeAndCheckout(baseSHA string, mergeStrategy string, head
 // Am tries to apply the patch in the given path into the current branch
 // by performing a three-way merge (similar to git cherry-pick). It returns
 // an error if the patch cannot be applied.
-func (i *interactor) Am(path string) error {
+func (i *interactor) Am(path string, opts ...ApplyOpt) error {
 	i.logger.Infof("Applying patch at %s", path)
 	out, err := i.executor.Run("am", "--3way", path)
 	if err == nil {
 		return nil
 	}
+	if applyOpts(opts).KeepConflicts {
+		return i.keepConflicts("am", out)
+	}
 	i.logger.WithError(err).Infof("Patch apply failed with output: %s", string(out))
 	if abortOut, abortErr := i.executor.Run("am", "--abort"); abortErr != nil {
 		i.logger.WithError(abortErr).Warningf("Aborting patch apply failed with output: %s", string(abortOut))
@@ -432,6 +463,98 @@ func (i *interactor) Am(path string) error {
 	return errors.New(string(bytes.TrimPrefix(out, []byte("The copy of the patch that failed is found in: .git/rebase-apply/patch"))))
 }
 
+// CherryPick tries to apply the changes of the given commit into the current
+// branch. It returns an error if the commit cannot be applied.
+func (i *interactor) CherryPick(commitlike string, opts ...ApplyOpt) error {
+	opt := applyOpts(opts)
+	i.logger.Infof("Cherry-picking %q", commitlike)
+	args := []string{"cherry-pick"}
+	if opt.Mainline > 0 {
+		args = append(args, "-m", strconv.Itoa(opt.Mainline))
+	}
+	out, err := i.executor.Run(append(args, commitlike)...)
+	if err == nil {
+		return nil
+	}
+	if opt.KeepConflicts {
+		return i.keepConflicts("cherry-pick", out)
+	}
+	i.logger.WithError(err).Infof("Cherry-pick failed with output: %s", string(out))
+	if abortOut, abortErr := i.executor.Run("cherry-pick", "--abort"); abortErr != nil {
+		i.logger.WithError(abortErr).Warningf("Aborting cherry-pick failed with output: %s", string(abortOut))
+	}
+	return errors.New(string(out))
+}
+
+func applyOpts(opts []ApplyOpt) ApplyOpt {
+	var opt ApplyOpt
+	for _, o := range opts {
+		if o.Mainline > 0 {
+			opt.Mainline = o.Mainline
+		}
+		opt.KeepConflicts = opt.KeepConflicts || o.KeepConflicts
+	}
+	return opt
+}
+
+// keepConflicts commits the conflicting files of a stopped `git am` or
+// `git cherry-pick` including their conflict markers and continues until
+// all changes are applied. Failures other than conflicts abort the command.
+func (i *interactor) keepConflicts(command string, out []byte) error {
+	conflicts := map[string]bool{}
+	for {
+		files, err := i.unmergedFiles()
+		if err != nil || len(files) == 0 {
+			i.logger.WithError(err).Infof("Applying changes failed without conflicts, output: %s", string(out))
+			if abortOut, abortErr := i.executor.Run(command, "--abort"); abortErr != nil {
+				i.logger.WithError(abortErr).Warningf("Aborting %s failed with output: %s", command, string(abortOut))
+			}
+			return errors.New(string(out))
+		}
+		i.logger.Infof("Keeping conflicts in %s", strings.Join(files, ", "))
+		for _, file := range files {
+			conflicts[file] = true
+		}
+		if addOut, err := i.executor.Run("add", "--all"); err != nil {
+			return fmt.Errorf("error staging conflicts: %w %v", err, string(addOut))
+		}
+		// The editor is disabled so the original commit message is kept.
+		if out, err = i.executor.Run("-c", "core.editor=true", command, "--continue"); err == nil {
+			break
+		}
+	}
+	var files []string
+	for file := range conflicts {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return &ConflictError{Files: files}
+}
+
+func (i *interactor) unmergedFiles() ([]string, error) {
+	out, err := i.executor.Run("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, fmt.Errorf("error listing unmerged files: %w %v", err, string(out))
+	}
+	var files []string
+	scan := bufio.NewScanner(bytes.NewReader(out))
+	for scan.Scan() {
+		files = append(files, scan.Text())
+	}
+	return files, nil
+}
+
+// IsMergeCommit determines if the commitlike has more than one parent.
+func (i *interactor) IsMergeCommit(commitlike string) (bool, error) {
+	i.logger.Infof("Determining if %q is a merge commit", commitlike)
+	out, err := i.executor.Run("rev-list", "--parents", "-n", "1", commitlike)
+	if err != nil {
+		return false, fmt.Errorf("error listing parents of %q: %w %v", commitlike, err, string(out))
+	}
+	// The commit is listed first, followed by its parents.
+	return len(strings.Fields(string(out))) > 2, nil
+}
+
 // FetchCommits only fetches those commits which we want, and only if they are
 // missing.
 func (i *interactor) FetchCommits(commitSHAs []string) error {
@@ -1114,10 +1114,13 @@ func TestInteractor_Am(t *testing.T) {
 	var testCases = []struct {
 		name          string
 		path          string
+		opts          []ApplyOpt
 		remote        RemoteResolver
 		responses     map[string]execResponse
 		expectedCalls [][]string
-		expectedErr   bool
+		// expectedConflicts are the files of the expected *ConflictError
+		expectedConflicts []string
+		expectedErr       bool
 	}{
 		{
 			name: "happy case",
@@ -1166,6 +1169,55 @@ func TestInteractor_Am(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "am fails with conflicts that are kept",
+			path: "my/changes.patch",
+			opts: []ApplyOpt{{KeepConflicts: true}},
+			responses: map[string]execResponse{
+				"am --3way my/changes.patch": {
+					err: errors.New("oops"),
+				},
+				"diff --name-only --diff-filter=U": {
+					out: []byte("b.go\na.go\n"),
+				},
+				"add --all": {
+					out: []byte(`ok`),
+				},
+				"-c core.editor=true am --continue": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"am", "--3way", "my/changes.patch"},
+				{"diff", "--name-only", "--diff-filter=U"},
+				{"add", "--all"},
+				{"-c", "core.editor=true", "am", "--continue"},
+			},
+			expectedConflicts: []string{"a.go", "b.go"},
+			expectedErr:       true,
+		},
+		{
+			name: "am fails without conflicts to keep",
+			path: "my/changes.patch",
+			opts: []ApplyOpt{{KeepConflicts: true}},
+			responses: map[string]execResponse{
+				"am --3way my/changes.patch": {
+					err: errors.New("oops"),
+				},
+				"diff --name-only --diff-filter=U": {
+					out: []byte(``),
+				},
+				"am --abort": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"am", "--3way", "my/changes.patch"},
+				{"diff", "--name-only", "--diff-filter=U"},
+				{"am", "--abort"},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1179,13 +1231,137 @@ func TestInteractor_Am(t *testing.T) {
 				remote:   testCase.remote,
 				logger:   logrus.WithField("test", testCase.name),
 			}
-			actualErr := i.Am(testCase.path)
+			actualErr := i.Am(testCase.path, testCase.opts...)
+			if testCase.expectedErr && actualErr == nil {
+				t.Errorf("%s: expected an error but got none", testCase.name)
+			}
+			if !testCase.expectedErr && actualErr != nil {
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
+			}
+			var conflictErr *ConflictError
+			var actualConflicts []string
+			if errors.As(actualErr, &conflictErr) {
+				actualConflicts = conflictErr.Files
+			}
+			if !reflect.DeepEqual(actualConflicts, testCase.expectedConflicts) {
+				t.Errorf("%s: got incorrect conflicts: expected %v, got %v", testCase.name, testCase.expectedConflicts, actualConflicts)
+			}
+			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
+
+func TestInteractor_CherryPick(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		commitlike    string
+		opts          []ApplyOpt
+		responses     map[string]execResponse
+		expectedCalls [][]string
+		// expectedConflicts are the files of the expected *ConflictError
+		expectedConflicts []string
+		expectedErr       bool
+	}{
+		{
+			name:       "happy case",
+			commitlike: "shasum",
+			responses: map[string]execResponse{
+				"cherry-pick shasum": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"cherry-pick", "shasum"},
+			},
+			expectedErr: false,
+		},
+		{
+			name:       "merge commit is picked relative to the mainline",
+			commitlike: "shasum",
+			opts:       []ApplyOpt{{Mainline: 1}},
+			responses: map[string]execResponse{
+				"cherry-pick -m 1 shasum": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"cherry-pick", "-m", "1", "shasum"},
+			},
+			expectedErr: false,
+		},
+		{
+			name:       "cherry-pick fails and is aborted",
+			commitlike: "shasum",
+			responses: map[string]execResponse{
+				"cherry-pick shasum": {
+					err: errors.New("oops"),
+				},
+				"cherry-pick --abort": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"cherry-pick", "shasum"},
+				{"cherry-pick", "--abort"},
+			},
+			expectedErr: true,
+		},
+		{
+			name:       "cherry-pick fails with conflicts that are kept",
+			commitlike: "shasum",
+			opts:       []ApplyOpt{{Mainline: 1, KeepConflicts: true}},
+			responses: map[string]execResponse{
+				"cherry-pick -m 1 shasum": {
+					err: errors.New("oops"),
+				},
+				"diff --name-only --diff-filter=U": {
+					out: []byte("a.go\n"),
+				},
+				"add --all": {
+					out: []byte(`ok`),
+				},
+				"-c core.editor=true cherry-pick --continue": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"cherry-pick", "-m", "1", "shasum"},
+				{"diff", "--name-only", "--diff-filter=U"},
+				{"add", "--all"},
+				{"-c", "core.editor=true", "cherry-pick", "--continue"},
+			},
+			expectedConflicts: []string{"a.go"},
+			expectedErr:       true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			i := interactor{
+				executor: &e,
+				logger:   logrus.WithField("test", testCase.name),
+			}
+			actualErr := i.CherryPick(testCase.commitlike, testCase.opts...)
 			if testCase.expectedErr && actualErr == nil {
 				t.Errorf("%s: expected an error but got none", testCase.name)
 			}
 			if !testCase.expectedErr && actualErr != nil {
 				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
 			}
+			var conflictErr *ConflictError
+			var actualConflicts []string
+			if errors.As(actualErr, &conflictErr) {
+				actualConflicts = conflictErr.Files
+			}
+			if !reflect.DeepEqual(actualConflicts, testCase.expectedConflicts) {
+				t.Errorf("%s: got incorrect conflicts: expected %v, got %v", testCase.name, testCase.expectedConflicts, actualConflicts)
+			}
 			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
 				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
 			}
@@ -1912,6 +2088,86 @@ func TestInteractor_MergeCommitsExistBetween(t *testing.T) {
 	}
 }
 
+func TestInteractor_IsMergeCommit(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		commitlike    string
+		responses     map[string]execResponse
+		expectedCalls [][]string
+		expectedOut   bool
+		expectedErr   bool
+	}{
+		{
+			name:       "merge commit",
+			commitlike: "shasum",
+			responses: map[string]execResponse{
+				"rev-list --parents -n 1 shasum": {
+					out: []byte("shasum parent1 parent2\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-list", "--parents", "-n", "1", "shasum"},
+			},
+			expectedOut: true,
+			expectedErr: false,
+		},
+		{
+			name:       "regular commit",
+			commitlike: "shasum",
+			responses: map[string]execResponse{
+				"rev-list --parents -n 1 shasum": {
+					out: []byte("shasum parent1\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-list", "--parents", "-n", "1", "shasum"},
+			},
+			expectedOut: false,
+			expectedErr: false,
+		},
+		{
+			name:       "rev-list fails",
+			commitlike: "shasum",
+			responses: map[string]execResponse{
+				"rev-list --parents -n 1 shasum": {
+					err: errors.New("oops"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-list", "--parents", "-n", "1", "shasum"},
+			},
+			expectedOut: false,
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			i := interactor{
+				executor: &e,
+				logger:   logrus.WithField("test", testCase.name),
+			}
+			actualOut, actualErr := i.IsMergeCommit(testCase.commitlike)
+			if testCase.expectedOut != actualOut {
+				t.Errorf("%s: got incorrect output: expected %v, got %v", testCase.name, testCase.expectedOut, actualOut)
+			}
+			if testCase.expectedErr && actualErr == nil {
+				t.Errorf("%s: expected an error but got none", testCase.name)
+			}
+			if !testCase.expectedErr && actualErr != nil {
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
+			}
+			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
+
 func TestInteractor_ShowRef(t *testing.T) {
 	const target = "some-branch"
 	var testCases = []struct {
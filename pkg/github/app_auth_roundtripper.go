@@ -30,6 +30,7 @@ import (
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go/v4"
+	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/prow/pkg/ghcache"
 )
@@ -278,3 +279,62 @@ func (arr *appsRoundTripper) getSlug() (string, error) {
 	arr.appSlug = response.Slug
 	return arr.appSlug, nil
 }
+
+// installationTokenRoundTripper authenticates requests as the installations of
+// GitHub apps in their orgs.
+type installationTokenRoundTripper interface {
+	http.RoundTripper
+	installationTokenFor(org string) (string, time.Time, error)
+}
+
+// orgAppsRoundTripper routes requests to the appsRoundTripper of the app of
+// their org. Requests for other orgs and requests without an org, like the ones
+// to /app endpoints, use the default app.
+type orgAppsRoundTripper struct {
+	defaultApp *appsRoundTripper
+	apps       map[string]*appsRoundTripper
+}
+
+func newOrgAppsRoundTripper(fields logrus.Fields, options ClientOptions, defaultApp *appsRoundTripper) (*orgAppsRoundTripper, error) {
+	roundTripper := &orgAppsRoundTripper{
+		defaultApp: defaultApp,
+		apps:       map[string]*appsRoundTripper{},
+	}
+	for _, app := range options.OrgApps {
+		// Every app needs a client of its own to list its installations and
+		// get their tokens.
+		appOptions := options
+		appOptions.AppID, appOptions.AppPrivateKey, appOptions.OrgApps = app.ID, app.PrivateKey, nil
+		appClient, httpClient, graphQLTransport := newClient(fields, appOptions)
+		appTransport, err := newAppsRoundTripper(app.ID, app.PrivateKey, options.BaseRoundTripper, appClient, options.Bases)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct apps auth roundtripper for app %s: %w", app.ID, err)
+		}
+		httpClient.Transport = appTransport
+		graphQLTransport.upstream = appTransport
+
+		for _, org := range app.Orgs {
+			org = strings.ToLower(org)
+			if _, exists := roundTripper.apps[org]; exists {
+				return nil, fmt.Errorf("multiple apps configured for org %s", org)
+			}
+			roundTripper.apps[org] = appTransport
+		}
+	}
+	return roundTripper, nil
+}
+
+func (oarr *orgAppsRoundTripper) appFor(org string) *appsRoundTripper {
+	if app, ok := oarr.apps[strings.ToLower(org)]; ok {
+		return app
+	}
+	return oarr.defaultApp
+}
+
+func (oarr *orgAppsRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return oarr.appFor(extractOrgFromContext(r.Context())).RoundTrip(r)
+}
+
+func (oarr *orgAppsRoundTripper) installationTokenFor(org string) (string, time.Time, error) {
+	return oarr.appFor(org).installationTokenFor(org)
+}
@@ -510,6 +510,81 @@ func TestAppsRoundTripperThreadSafety(t *testing.T) {
 	<-req2Done
 }
 
+func TestOrgAppsAuth(t *testing.T) {
+	// Can not be smaller, otherwise the JWT signature generation
+	// fails with "message too long for RSA public key size"
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	upstream := &fakeRoundTripper{
+		responses: map[string]*http.Response{
+			"/app":            {StatusCode: 200, Body: serializeOrDie(App{})},
+			"/orgs/org":       {StatusCode: 200, Body: serializeOrDie(Organization{})},
+			"/orgs/other-org": {StatusCode: 200, Body: serializeOrDie(Organization{})},
+		},
+	}
+	tokenGenerator, _, ghClient, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{
+		AppID:         "13",
+		AppPrivateKey: func() *rsa.PrivateKey { return rsaKey },
+		OrgApps: []OrgApp{{
+			ID:         "14",
+			PrivateKey: func() *rsa.PrivateKey { return rsaKey },
+			Orgs:       []string{"Other-Org"},
+		}},
+		Bases:            []string{"https://api.github.com"},
+		BaseRoundTripper: upstream,
+	})
+	if err != nil {
+		t.Fatalf("failed to construct github client: %v", err)
+	}
+
+	transport := ghClient.(*client).client.(*ghThrottler).http.(*http.Client).Transport
+	orgApps, ok := transport.(*orgAppsRoundTripper)
+	if !ok {
+		t.Fatalf("the ghclients didn't get configured to use the orgAppsRoundTripper, found %T instead", transport)
+	}
+	orgApps.defaultApp.appSlug = "ci-app"
+	orgApps.defaultApp.installations = map[string]AppInstallation{"org": {ID: 1}}
+	orgApps.defaultApp.tokens = map[int64]*AppInstallationToken{1: {Token: "the-token", ExpiresAt: time.Now().Add(time.Hour)}}
+	otherApp := orgApps.appFor("other-org")
+	if otherApp.appID != "14" {
+		t.Fatalf("expected app 14 for other-org, got %s", otherApp.appID)
+	}
+	otherApp.appSlug = "other-app"
+	otherApp.installations = map[string]AppInstallation{"other-org": {ID: 2}}
+	otherApp.tokens = map[int64]*AppInstallationToken{2: {Token: "the-other-token", ExpiresAt: time.Now().Add(time.Hour)}}
+
+	if _, err := ghClient.GetOrg("org"); err != nil {
+		t.Fatalf("failed to get org org: %v", err)
+	}
+	if _, err := ghClient.GetOrg("other-org"); err != nil {
+		t.Fatalf("failed to get org other-org: %v", err)
+	}
+	if _, err := ghClient.GetApp(); err != nil {
+		t.Fatalf("failed to get app: %v", err)
+	}
+	if n := len(upstream.requests); n != 3 {
+		t.Fatalf("expected exactly three requests, got %d", n)
+	}
+	if val := upstream.requests[0].Header.Get("Authorization"); val != "Bearer the-token" {
+		t.Errorf("expected the Authorization header %q of the request for org to be 'Bearer the-token'", val)
+	}
+	if val := upstream.requests[1].Header.Get("Authorization"); val != "Bearer the-other-token" {
+		t.Errorf("expected the Authorization header %q of the request for other-org to be 'Bearer the-other-token'", val)
+	}
+	if val := upstream.requests[1].Header.Get("X-PROW-GHCACHE-TOKEN-BUDGET-IDENTIFIER"); val != "other-app - other-org" {
+		t.Errorf("expected X-PROW-GHCACHE-TOKEN-BUDGET-IDENTIFIER header %q of the request for other-org to have value 'other-app - other-org'", val)
+	}
+	if val := upstream.requests[2].Header.Get("X-PROW-GHCACHE-TOKEN-BUDGET-IDENTIFIER"); val != "13" {
+		t.Errorf("expected X-PROW-GHCACHE-TOKEN-BUDGET-IDENTIFIER header %q of the request without org to have value 13", val)
+	}
+
+	if token, err := tokenGenerator("other-org"); err != nil || token != "the-other-token" {
+		t.Errorf("expected the token of the other app for git actions in other-org, got %q, err: %v", token, err)
+	}
+}
+
 func serializeOrDie(in interface{}) io.ReadCloser {
 	rawData, err := json.Marshal(in)
 	if err != nil {
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// PullRequestsDataBatchSize is the number of pull requests that
+// GetPullRequestsData fetches per GraphQL query.
+const PullRequestsDataBatchSize = 50
+
+// PullRequestData holds the data about a pull request that is commonly
+// needed by Prow components, fetched in bulk by GetPullRequestsData.
+type PullRequestData struct {
+	Number  int
+	Author  string
+	HeadSHA string
+	BaseRef string
+	// Files are the names of the files changed by the pull request.
+	Files []string
+	// Reviews are the last reviews of the pull request.
+	Reviews []Review
+	// Statuses are the statuses of the head commit of the pull request.
+	Statuses []Status
+}
+
+// bulkPullRequest is the part of the GraphQL query of GetPullRequestsData for
+// a single pull request.
+type bulkPullRequest struct {
+	Number      githubql.Int
+	HeadRefOID  githubql.String `graphql:"headRefOid"`
+	BaseRefName githubql.String
+	Author      struct {
+		Login githubql.String
+	}
+	Files struct {
+		Nodes []struct {
+			Path githubql.String
+		}
+		PageInfo struct {
+			HasNextPage githubql.Boolean
+		}
+	} `graphql:"files(first: 100)"`
+	Reviews struct {
+		Nodes []struct {
+			Author struct {
+				Login githubql.String
+			}
+			State       githubql.String
+			SubmittedAt githubql.DateTime
+		}
+	} `graphql:"reviews(last: 100)"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				Status *struct {
+					Contexts []struct {
+						Context     githubql.String
+						State       githubql.String
+						Description githubql.String
+						TargetURL   githubql.String `graphql:"targetUrl"`
+					}
+				}
+			}
+		}
+	} `graphql:"commits(last: 1)"`
+}
+
+// pullRequestsDataQuery builds a GraphQL query for the pull requests with the
+// numbers. githubql builds queries from struct types, so we build a struct with
+// an aliased field per pull request.
+func pullRequestsDataQuery(numbers []int) reflect.Value {
+	fields := make([]reflect.StructField, 0, len(numbers))
+	for i, number := range numbers {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("PR%d", i),
+			Type: reflect.TypeOf(&bulkPullRequest{}),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"pr%d: pullRequest(number: %d)"`, i, number)),
+		})
+	}
+	return reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Repository",
+		Type: reflect.StructOf(fields),
+		Tag:  `graphql:"repository(owner: $owner, name: $name)"`,
+	}}))
+}
+
+// GetPullRequestsData fetches the pull requests with the numbers, together with
+// their changed files, reviews and the statuses of their head commits, using a
+// GraphQL query per PullRequestsDataBatchSize pull requests instead of several
+// REST API requests per pull request. The files of pull requests changing more
+// files than fit in a query are listed with the REST API.
+func (c *client) GetPullRequestsData(org, repo string, numbers []int) (map[int]PullRequestData, error) {
+	durationLogger := c.log("GetPullRequestsData", org, repo, numbers)
+	defer durationLogger()
+
+	data := make(map[int]PullRequestData, len(numbers))
+	if c.fake {
+		return data, nil
+	}
+	vars := map[string]interface{}{
+		"owner": githubql.String(org),
+		"name":  githubql.String(repo),
+	}
+	for start := 0; start < len(numbers); start += PullRequestsDataBatchSize {
+		batch := numbers[start:min(start+PullRequestsDataBatchSize, len(numbers))]
+		query := pullRequestsDataQuery(batch)
+		if err := c.QueryWithGitHubAppsSupport(context.Background(), query.Interface(), vars, org); err != nil {
+			return nil, fmt.Errorf("failed to query pull requests of %s/%s: %w", org, repo, err)
+		}
+		prs := query.Elem().Field(0)
+		for i := range batch {
+			pr, _ := prs.Field(i).Interface().(*bulkPullRequest)
+			if pr == nil {
+				return nil, fmt.Errorf("pull request %s/%s#%d not found", org, repo, batch[i])
+			}
+			prData, err := c.pullRequestData(org, repo, pr)
+			if err != nil {
+				return nil, err
+			}
+			data[prData.Number] = prData
+		}
+	}
+	return data, nil
+}
+
+func (c *client) pullRequestData(org, repo string, pr *bulkPullRequest) (PullRequestData, error) {
+	data := PullRequestData{
+		Number:  int(pr.Number),
+		Author:  string(pr.Author.Login),
+		HeadSHA: string(pr.HeadRefOID),
+		BaseRef: string(pr.BaseRefName),
+	}
+	if pr.Files.PageInfo.HasNextPage {
+		changes, err := c.GetPullRequestChanges(org, repo, data.Number)
+		if err != nil {
+			return data, fmt.Errorf("failed to get changes of %s/%s#%d: %w", org, repo, data.Number, err)
+		}
+		for _, change := range changes {
+			data.Files = append(data.Files, change.Filename)
+		}
+	} else {
+		for _, node := range pr.Files.Nodes {
+			data.Files = append(data.Files, string(node.Path))
+		}
+	}
+	for _, node := range pr.Reviews.Nodes {
+		data.Reviews = append(data.Reviews, Review{
+			User:        User{Login: string(node.Author.Login)},
+			State:       ReviewState(node.State),
+			SubmittedAt: node.SubmittedAt.Time,
+		})
+	}
+	for _, node := range pr.Commits.Nodes {
+		if node.Commit.Status == nil {
+			continue
+		}
+		for _, status := range node.Commit.Status.Contexts {
+			// They are uppercase in the V4 api and lowercase in the V3 api
+			data.Statuses = append(data.Statuses, Status{
+				Context:     string(status.Context),
+				State:       strings.ToLower(string(status.State)),
+				Description: string(status.Description),
+				TargetURL:   string(status.TargetURL),
+			})
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+func TestGetPullRequestsData(t *testing.T) {
+	aliasRegex := regexp.MustCompile(`(pr\d+): pullRequest\(number: (\d+)\)`)
+	var queries int
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			queries++
+			var body struct {
+				Query     string
+				Variables map[string]string
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if body.Variables["owner"] != "org" || body.Variables["name"] != "repo" {
+				http.Error(w, fmt.Sprintf("unexpected variables %v", body.Variables), http.StatusBadRequest)
+				return
+			}
+			repo := map[string]interface{}{}
+			for _, match := range aliasRegex.FindAllStringSubmatch(body.Query, -1) {
+				pr := map[string]interface{}{
+					"number":      json.Number(match[2]),
+					"headRefOid":  "sha" + match[2],
+					"baseRefName": "main",
+					"author":      map[string]string{"login": "author"},
+					"files": map[string]interface{}{
+						"nodes":    []map[string]string{{"path": "file" + match[2]}},
+						"pageInfo": map[string]bool{"hasNextPage": match[2] == "2"},
+					},
+					"reviews": map[string]interface{}{
+						"nodes": []map[string]interface{}{{
+							"author":      map[string]string{"login": "reviewer"},
+							"state":       "APPROVED",
+							"submittedAt": "2026-01-02T03:04:05Z",
+						}},
+					},
+					"commits": map[string]interface{}{
+						"nodes": []map[string]interface{}{{
+							"commit": map[string]interface{}{
+								"status": map[string]interface{}{
+									"contexts": []map[string]string{{"context": "job", "state": "SUCCESS"}},
+								},
+							},
+						}},
+					},
+				}
+				repo[match[1]] = pr
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"repository": repo}})
+		case "/repos/org/repo/pulls/2/files":
+			json.NewEncoder(w).Encode([]PullRequestChange{{Filename: "file2"}, {Filename: "other"}})
+		default:
+			http.Error(w, "unexpected request "+r.URL.Path, http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	c := getClient(ts.URL)
+	c.gqlc = &graphQLGitHubAppsAuthClientWrapper{Client: githubql.NewEnterpriseClient(ts.URL+"/graphql", ts.Client())}
+
+	var numbers []int
+	for i := 1; i <= PullRequestsDataBatchSize+1; i++ {
+		numbers = append(numbers, i)
+	}
+	data, err := c.GetPullRequestsData("org", "repo", numbers)
+	if err != nil {
+		t.Fatalf("Failed to get pull requests data: %v", err)
+	}
+	if queries != 2 {
+		t.Errorf("Expected 2 queries, got %d", queries)
+	}
+	if len(data) != len(numbers) {
+		t.Errorf("Expected data of %d pull requests, got %d", len(numbers), len(data))
+	}
+
+	expected := PullRequestData{
+		Number:   1,
+		Author:   "author",
+		HeadSHA:  "sha1",
+		BaseRef:  "main",
+		Files:    []string{"file1"},
+		Reviews:  []Review{{User: User{Login: "reviewer"}, State: ReviewStateApproved, SubmittedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}},
+		Statuses: []Status{{Context: "job", State: "success"}},
+	}
+	if diff := cmp.Diff(expected, data[1]); diff != "" {
+		t.Errorf("Unexpected data of pull request 1 (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"file2", "other"}, data[2].Files); diff != "" {
+		t.Errorf("Unexpected files of pull request 2 with more files than fit in the query (-want +got):\n%s", diff)
+	}
+	if last := PullRequestsDataBatchSize + 1; data[last].HeadSHA != fmt.Sprintf("sha%d", last) {
+		t.Errorf("Expected data of the pull request in the second batch, got %v", data[last])
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Capability is an API that older GitHub Enterprise Server versions lack.
+type Capability string
+
+const (
+	// CapabilityRerunFailedJobs is re-running only the failed jobs of a
+	// workflow run.
+	CapabilityRerunFailedJobs Capability = "rerun failed jobs"
+	// CapabilityRepositoryRulesets is managing the rulesets of repositories.
+	CapabilityRepositoryRulesets Capability = "repository rulesets"
+)
+
+// capabilityEnterpriseVersions are the GitHub Enterprise Server versions that
+// introduced the capabilities. github.com has all of them.
+var capabilityEnterpriseVersions = map[Capability]*version.Version{
+	CapabilityRerunFailedJobs:    version.MustParseGeneric("3.5"),
+	CapabilityRepositoryRulesets: version.MustParseGeneric("3.11"),
+}
+
+// ServerInfo describes the GitHub server a client talks to.
+type ServerInfo struct {
+	// Enterprise is whether the server is a GitHub Enterprise Server.
+	Enterprise bool
+	// Version is the version of a GitHub Enterprise Server.
+	Version string
+}
+
+// Supports returns whether the server has the capability. Servers whose
+// version is unknown are assumed to have all capabilities.
+func (i *ServerInfo) Supports(capability Capability) bool {
+	if !i.Enterprise {
+		return true
+	}
+	minimum, ok := capabilityEnterpriseVersions[capability]
+	if !ok {
+		return true
+	}
+	v, err := version.ParseGeneric(i.Version)
+	if err != nil {
+		return true
+	}
+	return v.AtLeast(minimum)
+}
+
+// GetServerInfo returns whether the client talks to a GitHub Enterprise Server
+// and which version it runs. The server is only probed once, unless its
+// version was configured. The org is only used for apps auth.
+//
+// See https://docs.github.com/en/enterprise-server@latest/rest/meta/meta#get-github-enterprise-server-meta-information
+func (c *client) GetServerInfo(org string) (*ServerInfo, error) {
+	c.serverInfoMut.Lock()
+	defer c.serverInfoMut.Unlock()
+	if c.serverInfo != nil {
+		return c.serverInfo, nil
+	}
+
+	durationLogger := c.log("GetServerInfo", org)
+	defer durationLogger()
+	if c.fake {
+		return &ServerInfo{}, nil
+	}
+
+	var meta struct {
+		// Only GitHub Enterprise Server has this field
+		InstalledVersion string `json:"installed_version"`
+	}
+	if _, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      "/meta",
+		org:       org,
+		exitCodes: []int{200},
+	}, &meta); err != nil {
+		return nil, err
+	}
+	c.serverInfo = &ServerInfo{
+		Enterprise: meta.InstalledVersion != "",
+		Version:    meta.InstalledVersion,
+	}
+	return c.serverInfo, nil
+}
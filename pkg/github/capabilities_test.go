@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetServerInfo(t *testing.T) {
+	testCases := []struct {
+		name     string
+		meta     string
+		expected ServerInfo
+	}{
+		{
+			name:     "github.com",
+			meta:     `{"verifiable_password_authentication": true}`,
+			expected: ServerInfo{},
+		},
+		{
+			name:     "GitHub Enterprise Server",
+			meta:     `{"verifiable_password_authentication": true, "installed_version": "3.9.4"}`,
+			expected: ServerInfo{Enterprise: true, Version: "3.9.4"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/meta" {
+					t.Errorf("Bad request path: %s", r.URL.Path)
+				}
+				requests++
+				w.Write([]byte(tc.meta))
+			}))
+			defer ts.Close()
+			c := getClient(ts.URL)
+			for i := 0; i < 2; i++ {
+				info, err := c.GetServerInfo("org")
+				if err != nil {
+					t.Fatalf("Didn't expect error: %v", err)
+				}
+				if *info != tc.expected {
+					t.Errorf("Expected server info %+v, got %+v", tc.expected, *info)
+				}
+			}
+			if requests != 1 {
+				t.Errorf("Expected the server to be probed once, got %d requests", requests)
+			}
+		})
+	}
+}
+
+func TestServerInfoSupports(t *testing.T) {
+	testCases := []struct {
+		name     string
+		info     ServerInfo
+		expected bool
+	}{
+		{
+			name:     "github.com has all capabilities",
+			info:     ServerInfo{},
+			expected: true,
+		},
+		{
+			name:     "older GitHub Enterprise Server lacks the capability",
+			info:     ServerInfo{Enterprise: true, Version: "3.4.10"},
+			expected: false,
+		},
+		{
+			name:     "GitHub Enterprise Server introducing the capability has it",
+			info:     ServerInfo{Enterprise: true, Version: "3.5.0"},
+			expected: true,
+		},
+		{
+			name:     "newer GitHub Enterprise Server has the capability",
+			info:     ServerInfo{Enterprise: true, Version: "3.12"},
+			expected: true,
+		},
+		{
+			name:     "GitHub Enterprise Server of unknown version is assumed to have the capability",
+			info:     ServerInfo{Enterprise: true, Version: "unknown"},
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.info.Supports(CapabilityRerunFailedJobs); actual != tc.expected {
+				t.Errorf("Expected Supports to return %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -40,8 +40,10 @@ import (
 	"golang.org/x/oauth2"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"sigs.k8s.io/prow/pkg/ghcache"
+	"sigs.k8s.io/prow/pkg/github/ghmetrics"
 	"sigs.k8s.io/prow/pkg/throttle"
 	"sigs.k8s.io/prow/pkg/version"
 )
@@ -71,6 +73,24 @@ type OrganizationClient interface {
 	GetUserPermission(org, repo, user string) (string, error)
 	UpdateOrgMembership(org, user string, admin bool) (*OrgMembership, error)
 	RemoveOrgMembership(org, user string) error
+	ListSecurityManagerTeams(org string) ([]Team, error)
+	AddSecurityManagerTeam(org, teamSlug string) error
+	RemoveSecurityManagerTeam(org, teamSlug string) error
+	ListOrgRoles(org string) ([]OrgRole, error)
+	ListOrgRoleTeams(org string, roleID int) ([]OrgRoleTeam, error)
+	ListOrgRoleUsers(org string, roleID int) ([]OrgRoleUser, error)
+	AssignOrgRoleToTeam(org, teamSlug string, roleID int) error
+	RemoveOrgRoleFromTeam(org, teamSlug string, roleID int) error
+	AssignOrgRoleToUser(org, user string, roleID int) error
+	RemoveOrgRoleFromUser(org, user string, roleID int) error
+	GetOrgActionsPermissions(org string) (*ActionsPermissions, error)
+	UpdateOrgActionsPermissions(org string, permissions ActionsPermissions) error
+	GetOrgSelectedActions(org string) (*SelectedActions, error)
+	UpdateOrgSelectedActions(org string, actions SelectedActions) error
+	GetOrgWorkflowPermissions(org string) (*WorkflowPermissions, error)
+	UpdateOrgWorkflowPermissions(org string, permissions WorkflowPermissions) error
+	GetOrgForkPRApprovalPolicy(org string) (ForkPRApprovalPolicy, error)
+	UpdateOrgForkPRApprovalPolicy(org string, policy ForkPRApprovalPolicy) error
 }
 
 // HookClient interface for hook related API actions
@@ -130,8 +150,10 @@ type PullRequestClient interface {
 	GetPullRequestDiff(org, repo string, number int) ([]byte, error)
 	GetPullRequestPatch(org, repo string, number int) ([]byte, error)
 	CreatePullRequest(org, repo, title, body, head, base string, canModify bool) (int, error)
+	CreateDraftPullRequest(org, repo, title, body, head, base string, canModify bool) (int, error)
 	UpdatePullRequest(org, repo string, number int, title, body *string, open *bool, branch *string, canModify *bool) error
 	GetPullRequestChanges(org, repo string, number int) ([]PullRequestChange, error)
+	GetPullRequestsData(org, repo string, numbers []int) (map[int]PullRequestData, error)
 	ListPullRequestComments(org, repo string, number int) ([]ReviewComment, error)
 	CreatePullRequestReviewComment(org, repo string, number int, rc ReviewComment) error
 	ListReviews(org, repo string, number int) ([]Review, error)
@@ -156,6 +178,8 @@ type CommitClient interface {
 	ListCheckRuns(org, repo, ref string) (*CheckRunList, error)
 	GetRef(org, repo, ref string) (string, error)
 	DeleteRef(org, repo, ref string) error
+	UpdateRef(org, repo, ref, sha string, force bool) error
+	CompareCommits(org, repo, base, head string) (*CommitsComparison, error)
 	ListFileCommits(org, repo, path string) ([]RepositoryCommit, error)
 	CreateCheckRun(org, repo string, checkRun CheckRun) (int64, error)
 	UpdateCheckRun(org, repo string, checkRunId int64, checkRun CheckRun) error
@@ -170,6 +194,10 @@ type RepositoryClient interface {
 	GetBranchProtection(org, repo, branch string) (*BranchProtection, error)
 	RemoveBranchProtection(org, repo, branch string) error
 	UpdateBranchProtection(org, repo, branch string, config BranchProtectionRequest) error
+	ListRepoRulesets(org, repo string) ([]Ruleset, error)
+	GetRepoRuleset(org, repo string, id int) (*Ruleset, error)
+	CreateRepoRuleset(org, repo string, ruleset Ruleset) error
+	UpdateRepoRuleset(org, repo string, id int, ruleset Ruleset) error
 	AddRepoLabel(org, repo, label, description, color string) error
 	UpdateRepoLabel(org, repo, label, newName, description, color string) error
 	DeleteRepoLabel(org, repo, label string) error
@@ -190,6 +218,9 @@ type RepositoryClient interface {
 	ListRepoTeams(org, repo string) ([]Team, error)
 	CreateRepo(owner string, isUser bool, repo RepoCreateRequest) (*FullRepo, error)
 	UpdateRepo(owner, name string, repo RepoUpdateRequest) (*FullRepo, error)
+	TransferRepo(owner, name, newOwner, newName string) error
+	ListDeployments(org, repo, sha, environment string) ([]Deployment, error)
+	ListDeploymentStatuses(org, repo string, id int) ([]DeploymentStatus, error)
 }
 
 // TeamClient interface for team related API actions
@@ -271,6 +302,7 @@ type Client interface {
 	GetApp() (*App, error)
 	GetAppWithContext(ctx context.Context) (*App, error)
 	GetFailedActionRunsByHeadBranch(org, repo, branchName, headSHA string) ([]WorkflowRun, error)
+	GetServerInfo(org string) (*ServerInfo, error)
 
 	Throttle(hourlyTokens, burst int, org ...string) error
 	QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error
@@ -320,6 +352,12 @@ type delegate struct {
 
 	mut      sync.Mutex // protects botName and email
 	userData *UserData
+
+	secondaryRateLimitMut     sync.Mutex // protects secondaryRateLimitBackoff
+	secondaryRateLimitBackoff time.Duration
+
+	serverInfoMut sync.Mutex // protects serverInfo
+	serverInfo    *ServerInfo
 }
 
 type UserData struct {
@@ -390,6 +428,14 @@ const (
 	DefaultMax404Retries = 2
 	DefaultMaxSleepTime  = 2 * time.Minute
 	DefaultInitialDelay  = 2 * time.Second
+
+	// secondaryRateLimitMinBackoff is how long we wait at least after hitting
+	// a secondary rate limit if GitHub doesn't tell us how long to wait.
+	secondaryRateLimitMinBackoff = time.Minute
+	// secondaryRateLimitJitter is the maximum fraction of the wait time after
+	// hitting a secondary rate limit that is added to it, so that the clients
+	// hitting the limit at the same time don't retry at the same time.
+	secondaryRateLimitJitter = 0.1
 )
 
 // Force the compiler to check if the TokenSource is implementing correctly.
@@ -499,6 +545,9 @@ type ClientOptions struct {
 	GetToken      func() []byte
 	AppID         string
 	AppPrivateKey func() *rsa.PrivateKey
+	// OrgApps are GitHub apps used instead of the one of AppID for some orgs,
+	// e.g. for orgs that won't install the same app.
+	OrgApps []OrgApp
 
 	// the following fields determine which server we talk to
 	GraphqlEndpoint string
@@ -511,6 +560,20 @@ type ClientOptions struct {
 	DryRun bool
 	// BaseRoundTripper is the last RoundTripper to be called. Used for testing, gets defaulted to http.DefaultTransport
 	BaseRoundTripper http.RoundTripper
+	// ETagStore, if set, stores the responses to GET requests to make
+	// conditional requests with their ETags. This is useful for clients that
+	// can't use ghproxy.
+	ETagStore ETagStore
+	// EnterpriseVersion, if set, is the version of the GitHub Enterprise Server
+	// the client talks to, so that it doesn't need to be detected.
+	EnterpriseVersion string
+}
+
+// OrgApp is a GitHub app that authenticates the requests for some orgs.
+type OrgApp struct {
+	ID         string
+	PrivateKey func() *rsa.PrivateKey
+	Orgs       []string
 }
 
 func (o ClientOptions) Default() ClientOptions {
@@ -587,7 +650,58 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 	if options.BaseRoundTripper == nil {
 		options.BaseRoundTripper = http.DefaultTransport
 	}
+	if options.ETagStore != nil {
+		options.BaseRoundTripper = &etagTransport{upstream: options.BaseRoundTripper, store: options.ETagStore}
+	}
+
+	c, httpClient, graphQLTransport := newClient(fields, options)
+
+	var tokenGenerator func(_ string) (string, error)
+	var userGenerator func() (string, error)
+	if options.AppID != "" {
+		appsTransport, err := newAppsRoundTripper(options.AppID, options.AppPrivateKey, options.BaseRoundTripper, c, options.Bases)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to construct apps auth roundtripper: %w", err)
+		}
+		var transport installationTokenRoundTripper = appsTransport
+		if len(options.OrgApps) > 0 {
+			transport, err = newOrgAppsRoundTripper(fields, options, appsTransport)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		httpClient.Transport = transport
+		graphQLTransport.upstream = transport
 
+		// Use github apps auth for git actions
+		// https://docs.github.com/en/free-pro-team@latest/developers/apps/authenticating-with-github-apps#http-based-git-access-by-an-installation=
+		tokenGenerator = func(org string) (string, error) {
+			res, _, err := transport.installationTokenFor(org)
+			return res, err
+		}
+		userGenerator = func() (string, error) {
+			return "x-access-token", nil
+		}
+	} else {
+		// Use Personal Access token auth for git actions
+		tokenGenerator = func(_ string) (string, error) {
+			return string(options.GetToken()), nil
+		}
+		userGenerator = func() (string, error) {
+			user, err := c.BotUser()
+			if err != nil {
+				return "", err
+			}
+			return user.Login, nil
+		}
+	}
+
+	return tokenGenerator, userGenerator, c, nil
+}
+
+// newClient creates a client authenticating with the token of the options.
+// Its http client and GraphQL transport are returned to set up apps auth.
+func newClient(fields logrus.Fields, options ClientOptions) (*client, *http.Client, *addHeaderTransport) {
 	httpClient := &http.Client{
 		Transport: options.BaseRoundTripper,
 		Timeout:   options.MaxRequestTime,
@@ -619,45 +733,14 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 			maxSleepTime:  options.MaxSleepTime,
 		},
 	}
+	if options.EnterpriseVersion != "" {
+		c.serverInfo = &ServerInfo{Enterprise: true, Version: options.EnterpriseVersion}
+	}
 	c.gqlc = c.gqlc.forUserAgent(c.userAgent())
 
 	// Wrap clients with the throttler
 	c.wrapThrottler()
-
-	var tokenGenerator func(_ string) (string, error)
-	var userGenerator func() (string, error)
-	if options.AppID != "" {
-		appsTransport, err := newAppsRoundTripper(options.AppID, options.AppPrivateKey, options.BaseRoundTripper, c, options.Bases)
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to construct apps auth roundtripper: %w", err)
-		}
-		httpClient.Transport = appsTransport
-		graphQLTransport.upstream = appsTransport
-
-		// Use github apps auth for git actions
-		// https://docs.github.com/en/free-pro-team@latest/developers/apps/authenticating-with-github-apps#http-based-git-access-by-an-installation=
-		tokenGenerator = func(org string) (string, error) {
-			res, _, err := appsTransport.installationTokenFor(org)
-			return res, err
-		}
-		userGenerator = func() (string, error) {
-			return "x-access-token", nil
-		}
-	} else {
-		// Use Personal Access token auth for git actions
-		tokenGenerator = func(_ string) (string, error) {
-			return string(options.GetToken()), nil
-		}
-		userGenerator = func() (string, error) {
-			user, err := c.BotUser()
-			if err != nil {
-				return "", err
-			}
-			return user.Login, nil
-		}
-	}
-
-	return tokenGenerator, userGenerator, c, nil
+	return c, httpClient, graphQLTransport
 }
 
 type graphQLGitHubAppsAuthClientWrapper struct {
@@ -848,6 +931,18 @@ func NewNotFound() error {
 	}
 }
 
+// NewForbidden returns a Forbidden error which may be useful for tests
+func NewForbidden() error {
+	return requestError{StatusCode: http.StatusForbidden, ErrorString: "status code 403"}
+}
+
+// IsForbidden returns whether the request failed with status code 403, e.g.
+// because the action is not permitted on the resource.
+func IsForbidden(err error) bool {
+	var requestErr requestError
+	return errors.As(err, &requestErr) && requestErr.StatusCode == http.StatusForbidden
+}
+
 func IsNotFound(err error) bool {
 	if err == nil {
 		return false
@@ -954,7 +1049,7 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 				c.logger.WithField("backoff", backoff.String()).Debug("Retrying 404")
 				c.time.Sleep(backoff)
 				backoff *= 2
-			} else if resp.StatusCode == 403 {
+			} else if resp.StatusCode == 403 || resp.StatusCode == 429 {
 				if resp.Header.Get("X-RateLimit-Remaining") == "0" {
 					// If we are out of API tokens, sleep first. The X-RateLimit-Reset
 					// header tells us the time at which we can request again.
@@ -976,27 +1071,23 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 						resp.Body.Close()
 						break
 					}
-				} else if rawTime := resp.Header.Get("Retry-After"); rawTime != "" && rawTime != "0" {
-					// If we are getting abuse rate limited, we need to wait or
-					// else we risk continuing to make the situation worse
-					var t int
-					if t, err = strconv.Atoi(rawTime); err == nil {
-						// Sleep an extra second plus how long GitHub wants us to
-						// sleep. If it's going to take too long, then break.
-						sleepTime := time.Duration(t+1) * time.Second
-						if sleepTime < c.maxSleepTime {
-							c.logger.WithField("backoff", sleepTime.String()).WithField("path", path).Debug("Retrying after abuse ratelimit reset")
-							c.time.Sleep(sleepTime)
-						} else {
-							err = fmt.Errorf("sleep time for abuse rate limit exceeds max sleep time (%v > %v)", sleepTime, c.maxSleepTime)
-							resp.Body.Close()
-							break
-						}
-					} else {
-						err = fmt.Errorf("failed to parse abuse rate limit wait time %q: %w", rawTime, err)
+				} else if sleepTime, limited, parseErr := c.secondaryRateLimitSleepTime(resp); limited {
+					// If we are getting secondary rate limited, we need to wait or
+					// else we risk continuing to make the situation worse.
+					ghmetrics.CollectSecondaryRateLimitMetrics(c.tokenHash(), path)
+					if parseErr != nil {
+						err = parseErr
+						resp.Body.Close()
+						break
+					}
+					if sleepTime > c.maxSleepTime {
+						err = fmt.Errorf("sleep time for secondary rate limit exceeds max sleep time (%v > %v)", sleepTime, c.maxSleepTime)
 						resp.Body.Close()
 						break
 					}
+					sleepTime = wait.Jitter(sleepTime, secondaryRateLimitJitter)
+					c.logger.WithField("backoff", sleepTime.String()).WithField("path", path).Debug("Retrying after secondary rate limit")
+					c.time.Sleep(sleepTime)
 				} else {
 					acceptedScopes := resp.Header.Get("X-Accepted-OAuth-Scopes")
 					authorizedScopes := resp.Header.Get("X-OAuth-Scopes")
@@ -1016,13 +1107,14 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 						err = fmt.Errorf("the account is using %s oauth scopes, please make sure you are using at least one of the following oauth scopes: %s", authorizedScopes, acceptedScopes)
 					} else {
 						body, _ := io.ReadAll(resp.Body)
-						err = fmt.Errorf("the GitHub API request returns a 403 error: %s", string(body))
+						err = fmt.Errorf("the GitHub API request returns a %d error: %s", resp.StatusCode, string(body))
 					}
 					resp.Body.Close()
 					break
 				}
 			} else if resp.StatusCode < 500 {
 				// Normal, happy case.
+				c.resetSecondaryRateLimitBackoff()
 				break
 			} else {
 				// Retry 500 after a break.
@@ -1052,6 +1144,51 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 	return resp, err
 }
 
+// secondaryRateLimitSleepTime determines whether the response is caused by a
+// secondary rate limit, and how long to wait before retrying the request. If
+// GitHub doesn't tell us how long to wait, we wait at least a minute and
+// double the wait time as long as we keep hitting secondary rate limits.
+func (c *client) secondaryRateLimitSleepTime(resp *http.Response) (time.Duration, bool, error) {
+	if rawTime := resp.Header.Get("Retry-After"); rawTime != "" && rawTime != "0" {
+		t, err := strconv.Atoi(rawTime)
+		if err != nil {
+			return 0, true, fmt.Errorf("failed to parse secondary rate limit wait time %q: %w", rawTime, err)
+		}
+		// Sleep an extra second plus how long GitHub wants us to sleep.
+		return time.Duration(t+1) * time.Second, true, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || !strings.Contains(strings.ToLower(string(body)), "secondary rate limit") {
+		return 0, false, nil
+	}
+
+	c.secondaryRateLimitMut.Lock()
+	defer c.secondaryRateLimitMut.Unlock()
+	if c.secondaryRateLimitBackoff == 0 {
+		c.secondaryRateLimitBackoff = secondaryRateLimitMinBackoff
+	} else {
+		c.secondaryRateLimitBackoff = min(2*c.secondaryRateLimitBackoff, c.maxSleepTime)
+	}
+	return c.secondaryRateLimitBackoff, true, nil
+}
+
+// resetSecondaryRateLimitBackoff resets the wait time for secondary rate limits
+// once a request succeeds.
+func (c *client) resetSecondaryRateLimitBackoff() {
+	c.secondaryRateLimitMut.Lock()
+	defer c.secondaryRateLimitMut.Unlock()
+	c.secondaryRateLimitBackoff = 0
+}
+
+// tokenHash returns a hash of the auth header for use as a metrics label. We use
+// %x to make this a utf-8 string.
+func (c *client) tokenHash() string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(c.authHeader())))
+}
+
 func (c *client) doRequest(ctx context.Context, method, path, accept, org string, body interface{}) (*http.Response, error) {
 	var buf io.Reader
 	if body != nil {
@@ -1195,7 +1332,7 @@ func (c *client) getUserData(ctx context.Context) error {
 	// https://developer.github.com/v3/users/#get-a-single-user
 
 	// record information for the user
-	authHeaderHash := fmt.Sprintf("%x", sha256.Sum256([]byte(c.authHeader()))) // use %x to make this a utf-8 string for use as a label
+	authHeaderHash := c.tokenHash()
 	userInfo.With(prometheus.Labels{"token_hash": authHeaderHash, "login": c.userData.Login, "email": c.userData.Email}).Set(1)
 	return nil
 }
@@ -1695,6 +1832,355 @@ func (c *client) RemoveOrgMembership(org, user string) error {
 	return err
 }
 
+// ListSecurityManagerTeams lists the teams that have the security manager role in the org.
+//
+// See https://docs.github.com/en/rest/orgs/security-managers#list-security-manager-teams
+func (c *client) ListSecurityManagerTeams(org string) ([]Team, error) {
+	durationLogger := c.log("ListSecurityManagerTeams", org)
+	defer durationLogger()
+
+	if c.fake {
+		return nil, nil
+	}
+	var teams []Team
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/orgs/%s/security-managers", org),
+		org:       org,
+		exitCodes: []int{200},
+	}, &teams)
+	return teams, err
+}
+
+// AddSecurityManagerTeam grants the security manager role to the team.
+//
+// See https://docs.github.com/en/rest/orgs/security-managers#add-a-security-manager-team
+func (c *client) AddSecurityManagerTeam(org, teamSlug string) error {
+	durationLogger := c.log("AddSecurityManagerTeam", org, teamSlug)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:    http.MethodPut,
+		path:      fmt.Sprintf("/orgs/%s/security-managers/teams/%s", org, teamSlug),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
+// RemoveSecurityManagerTeam revokes the security manager role from the team.
+//
+// See https://docs.github.com/en/rest/orgs/security-managers#remove-a-security-manager-team
+func (c *client) RemoveSecurityManagerTeam(org, teamSlug string) error {
+	durationLogger := c.log("RemoveSecurityManagerTeam", org, teamSlug)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/orgs/%s/security-managers/teams/%s", org, teamSlug),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
+// ListOrgRoles lists the predefined and custom roles of the org.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles#get-all-organization-roles-for-an-organization
+func (c *client) ListOrgRoles(org string) ([]OrgRole, error) {
+	durationLogger := c.log("ListOrgRoles", org)
+	defer durationLogger()
+
+	if c.fake {
+		return nil, nil
+	}
+	var roles struct {
+		Roles []OrgRole `json:"roles"`
+	}
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/orgs/%s/organization-roles", org),
+		org:       org,
+		exitCodes: []int{200},
+	}, &roles)
+	return roles.Roles, err
+}
+
+// ListOrgRoleTeams lists the teams the org role is assigned to.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles#list-teams-that-are-assigned-to-an-organization-role
+func (c *client) ListOrgRoleTeams(org string, roleID int) ([]OrgRoleTeam, error) {
+	durationLogger := c.log("ListOrgRoleTeams", org, roleID)
+	defer durationLogger()
+
+	if c.fake {
+		return nil, nil
+	}
+	var teams []OrgRoleTeam
+	err := c.readPaginatedResults(
+		fmt.Sprintf("/orgs/%s/organization-roles/%d/teams", org, roleID),
+		"application/vnd.github+json",
+		org,
+		func() interface{} {
+			return &[]OrgRoleTeam{}
+		},
+		func(obj interface{}) {
+			teams = append(teams, *(obj.(*[]OrgRoleTeam))...)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// ListOrgRoleUsers lists the users the org role is assigned to, directly or
+// through a team.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles#list-users-that-are-assigned-to-an-organization-role
+func (c *client) ListOrgRoleUsers(org string, roleID int) ([]OrgRoleUser, error) {
+	durationLogger := c.log("ListOrgRoleUsers", org, roleID)
+	defer durationLogger()
+
+	if c.fake {
+		return nil, nil
+	}
+	var users []OrgRoleUser
+	err := c.readPaginatedResults(
+		fmt.Sprintf("/orgs/%s/organization-roles/%d/users", org, roleID),
+		"application/vnd.github+json",
+		org,
+		func() interface{} {
+			return &[]OrgRoleUser{}
+		},
+		func(obj interface{}) {
+			users = append(users, *(obj.(*[]OrgRoleUser))...)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// AssignOrgRoleToTeam assigns the org role to the team.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles#assign-an-organization-role-to-a-team
+func (c *client) AssignOrgRoleToTeam(org, teamSlug string, roleID int) error {
+	durationLogger := c.log("AssignOrgRoleToTeam", org, teamSlug, roleID)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:    http.MethodPut,
+		path:      fmt.Sprintf("/orgs/%s/organization-roles/teams/%s/%d", org, teamSlug, roleID),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
+// RemoveOrgRoleFromTeam removes the org role from the team.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles#remove-an-organization-role-from-a-team
+func (c *client) RemoveOrgRoleFromTeam(org, teamSlug string, roleID int) error {
+	durationLogger := c.log("RemoveOrgRoleFromTeam", org, teamSlug, roleID)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/orgs/%s/organization-roles/teams/%s/%d", org, teamSlug, roleID),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
+// AssignOrgRoleToUser assigns the org role to the user.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles#assign-an-organization-role-to-a-user
+func (c *client) AssignOrgRoleToUser(org, user string, roleID int) error {
+	durationLogger := c.log("AssignOrgRoleToUser", org, user, roleID)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:    http.MethodPut,
+		path:      fmt.Sprintf("/orgs/%s/organization-roles/users/%s/%d", org, user, roleID),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
+// RemoveOrgRoleFromUser removes the org role from the user.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles#remove-an-organization-role-from-a-user
+func (c *client) RemoveOrgRoleFromUser(org, user string, roleID int) error {
+	durationLogger := c.log("RemoveOrgRoleFromUser", org, user, roleID)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/orgs/%s/organization-roles/users/%s/%d", org, user, roleID),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
+// GetOrgActionsPermissions returns the GitHub Actions permissions of the org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#get-github-actions-permissions-for-an-organization
+func (c *client) GetOrgActionsPermissions(org string) (*ActionsPermissions, error) {
+	durationLogger := c.log("GetOrgActionsPermissions", org)
+	defer durationLogger()
+
+	var permissions ActionsPermissions
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/orgs/%s/actions/permissions", org),
+		org:       org,
+		exitCodes: []int{200},
+	}, &permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &permissions, nil
+}
+
+// UpdateOrgActionsPermissions sets the GitHub Actions permissions of the org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#set-github-actions-permissions-for-an-organization
+func (c *client) UpdateOrgActionsPermissions(org string, permissions ActionsPermissions) error {
+	durationLogger := c.log("UpdateOrgActionsPermissions", org, permissions)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/orgs/%s/actions/permissions", org),
+		org:         org,
+		requestBody: &permissions,
+		exitCodes:   []int{204},
+	}, nil)
+	return err
+}
+
+// GetOrgSelectedActions returns the actions that are allowed in the org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#get-allowed-actions-and-reusable-workflows-for-an-organization
+func (c *client) GetOrgSelectedActions(org string) (*SelectedActions, error) {
+	durationLogger := c.log("GetOrgSelectedActions", org)
+	defer durationLogger()
+
+	var actions SelectedActions
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/orgs/%s/actions/permissions/selected-actions", org),
+		org:       org,
+		exitCodes: []int{200},
+	}, &actions)
+	if err != nil {
+		return nil, err
+	}
+	return &actions, nil
+}
+
+// UpdateOrgSelectedActions sets the actions that are allowed in the org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#set-allowed-actions-and-reusable-workflows-for-an-organization
+func (c *client) UpdateOrgSelectedActions(org string, actions SelectedActions) error {
+	durationLogger := c.log("UpdateOrgSelectedActions", org, actions)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/orgs/%s/actions/permissions/selected-actions", org),
+		org:         org,
+		requestBody: &actions,
+		exitCodes:   []int{204},
+	}, nil)
+	return err
+}
+
+// GetOrgWorkflowPermissions returns the default workflow permissions of the org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#get-default-workflow-permissions-for-an-organization
+func (c *client) GetOrgWorkflowPermissions(org string) (*WorkflowPermissions, error) {
+	durationLogger := c.log("GetOrgWorkflowPermissions", org)
+	defer durationLogger()
+
+	var permissions WorkflowPermissions
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/orgs/%s/actions/permissions/workflow", org),
+		org:       org,
+		exitCodes: []int{200},
+	}, &permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &permissions, nil
+}
+
+// UpdateOrgWorkflowPermissions sets the default workflow permissions of the org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#set-default-workflow-permissions-for-an-organization
+func (c *client) UpdateOrgWorkflowPermissions(org string, permissions WorkflowPermissions) error {
+	durationLogger := c.log("UpdateOrgWorkflowPermissions", org, permissions)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/orgs/%s/actions/permissions/workflow", org),
+		org:         org,
+		requestBody: &permissions,
+		exitCodes:   []int{204},
+	}, nil)
+	return err
+}
+
+// GetOrgForkPRApprovalPolicy returns which contributors need an approval
+// before workflows run on their pull requests from forks.
+//
+// See https://docs.github.com/en/rest/actions/permissions#get-fork-pr-contributor-approval-permissions-for-an-organization
+func (c *client) GetOrgForkPRApprovalPolicy(org string) (ForkPRApprovalPolicy, error) {
+	durationLogger := c.log("GetOrgForkPRApprovalPolicy", org)
+	defer durationLogger()
+
+	var approval struct {
+		ApprovalPolicy ForkPRApprovalPolicy `json:"approval_policy"`
+	}
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/orgs/%s/actions/permissions/fork-pr-contributor-approval", org),
+		org:       org,
+		exitCodes: []int{200},
+	}, &approval)
+	return approval.ApprovalPolicy, err
+}
+
+// UpdateOrgForkPRApprovalPolicy sets which contributors need an approval
+// before workflows run on their pull requests from forks.
+//
+// See https://docs.github.com/en/rest/actions/permissions#set-fork-pr-contributor-approval-permissions-for-an-organization
+func (c *client) UpdateOrgForkPRApprovalPolicy(org string, policy ForkPRApprovalPolicy) error {
+	durationLogger := c.log("UpdateOrgForkPRApprovalPolicy", org, policy)
+	defer durationLogger()
+
+	approval := struct {
+		ApprovalPolicy ForkPRApprovalPolicy `json:"approval_policy"`
+	}{
+		ApprovalPolicy: policy,
+	}
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/orgs/%s/actions/permissions/fork-pr-contributor-approval", org),
+		org:         org,
+		requestBody: &approval,
+		exitCodes:   []int{204},
+	}, nil)
+	return err
+}
+
 // CreateComment creates a comment on the issue.
 //
 // See https://developer.github.com/v3/issues/comments/#create-a-comment
@@ -2242,6 +2728,21 @@ func (c *client) CreatePullRequest(org, repo, title, body, head, base string, ca
 	durationLogger := c.log("CreatePullRequest", org, repo, title)
 	defer durationLogger()
 
+	return c.createPullRequest(org, repo, title, body, head, base, canModify, false)
+}
+
+// CreateDraftPullRequest creates a new draft pull request and returns its
+// number if the creation is successful, otherwise any error that is encountered.
+//
+// See https://developer.github.com/v3/pulls/#create-a-pull-request
+func (c *client) CreateDraftPullRequest(org, repo, title, body, head, base string, canModify bool) (int, error) {
+	durationLogger := c.log("CreateDraftPullRequest", org, repo, title)
+	defer durationLogger()
+
+	return c.createPullRequest(org, repo, title, body, head, base, canModify, true)
+}
+
+func (c *client) createPullRequest(org, repo, title, body, head, base string, canModify, draft bool) (int, error) {
 	data := struct {
 		Title string `json:"title"`
 		Body  string `json:"body"`
@@ -2250,6 +2751,7 @@ func (c *client) CreatePullRequest(org, repo, title, body, head, base string, ca
 		// MaintainerCanModify allows maintainers of the repo to modify this
 		// pull request, eg. push changes to it before merging.
 		MaintainerCanModify bool `json:"maintainer_can_modify"`
+		Draft               bool `json:"draft,omitempty"`
 	}{
 		Title: title,
 		Body:  body,
@@ -2257,6 +2759,7 @@ func (c *client) CreatePullRequest(org, repo, title, body, head, base string, ca
 		Base:  base,
 
 		MaintainerCanModify: canModify,
+		Draft:               draft,
 	}
 	var resp struct {
 		Num int `json:"number"`
@@ -2499,6 +3002,86 @@ func (c *client) CreateRepo(owner string, isUser bool, repo RepoCreateRequest) (
 	return &retRepo, err
 }
 
+// TransferRepo transfers the repository to another owner and renames it to
+// newName. GitHub transfers repositories asynchronously, so the repository
+// may still belong to the previous owner when this returns.
+//
+// See https://docs.github.com/en/rest/repos/repos#transfer-a-repository
+func (c *client) TransferRepo(owner, name, newOwner, newName string) error {
+	durationLogger := c.log("TransferRepo", owner, name, newOwner, newName)
+	defer durationLogger()
+
+	transfer := struct {
+		NewOwner string `json:"new_owner"`
+		NewName  string `json:"new_name,omitempty"`
+	}{
+		NewOwner: newOwner,
+		NewName:  newName,
+	}
+	_, err := c.request(&request{
+		method:      http.MethodPost,
+		path:        fmt.Sprintf("/repos/%s/%s/transfer", owner, name),
+		org:         owner,
+		requestBody: &transfer,
+		exitCodes:   []int{202},
+	}, nil)
+	return err
+}
+
+// ListDeployments lists the deployments of a repository, most recent first.
+// The sha and environment filters are ignored when empty.
+//
+// See https://docs.github.com/en/rest/deployments/deployments#list-deployments
+func (c *client) ListDeployments(org, repo, sha, environment string) ([]Deployment, error) {
+	durationLogger := c.log("ListDeployments", org, repo, sha, environment)
+	defer durationLogger()
+
+	values := url.Values{"per_page": []string{"100"}}
+	if sha != "" {
+		values.Set("sha", sha)
+	}
+	if environment != "" {
+		values.Set("environment", environment)
+	}
+	var deployments []Deployment
+	err := c.readPaginatedResultsWithValues(
+		fmt.Sprintf("/repos/%s/%s/deployments", org, repo),
+		values,
+		acceptNone,
+		org,
+		func() interface{} {
+			return &[]Deployment{}
+		},
+		func(obj interface{}) {
+			deployments = append(deployments, *(obj.(*[]Deployment))...)
+		},
+	)
+	return deployments, err
+}
+
+// ListDeploymentStatuses lists the statuses of a deployment, most recent first.
+//
+// See https://docs.github.com/en/rest/deployments/statuses#list-deployment-statuses
+func (c *client) ListDeploymentStatuses(org, repo string, id int) ([]DeploymentStatus, error) {
+	durationLogger := c.log("ListDeploymentStatuses", org, repo, id)
+	defer durationLogger()
+
+	var statuses []DeploymentStatus
+	err := c.readPaginatedResultsWithValues(
+		fmt.Sprintf("/repos/%s/%s/deployments/%d/statuses", org, repo, id),
+		url.Values{"per_page": []string{"100"}},
+		acceptNone,
+		org,
+		func() interface{} {
+			return &[]DeploymentStatus{}
+		},
+		func(obj interface{}) {
+			statuses = append(statuses, *(obj.(*[]DeploymentStatus))...)
+		},
+	)
+	return statuses, err
+}
+
 // UpdateRepo edits an existing repository
 // See https://developer.github.com/v3/repos/#edit
 func (c *client) UpdateRepo(owner, name string, repo RepoUpdateRequest) (*FullRepo, error) {
@@ -2711,6 +3294,109 @@ func (c *client) UpdateBranchProtection(org, repo, branch string, config BranchP
 		requestBody: config,
 		exitCodes:   []int{200},
 	}, nil)
+	if err != nil {
+		return err
+	}
+
+	// See https://docs.github.com/en/rest/branches/branch-protection#create-commit-signature-protection
+	signatures := &request{
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/repos/%s/%s/branches/%s/protection/required_signatures", org, repo, branch),
+		org:       org,
+		exitCodes: []int{204},
+	}
+	if config.RequiredSignatures {
+		signatures.method = http.MethodPost
+		signatures.exitCodes = []int{200}
+	}
+	_, err = c.request(signatures, nil)
+	return err
+}
+
+// ListRepoRulesets returns the rulesets of the repo, without the ones it
+// inherits from its org. The rulesets don't include their conditions and rules.
+//
+// See https://docs.github.com/en/rest/repos/rules#get-all-repository-rulesets
+func (c *client) ListRepoRulesets(org, repo string) ([]Ruleset, error) {
+	durationLogger := c.log("ListRepoRulesets", org, repo)
+	defer durationLogger()
+
+	if c.fake {
+		return nil, nil
+	}
+	var rulesets []Ruleset
+	err := c.readPaginatedResultsWithValues(
+		fmt.Sprintf("/repos/%s/%s/rulesets", org, repo),
+		url.Values{
+			"includes_parents": []string{"false"},
+			"per_page":         []string{"100"},
+		},
+		acceptNone,
+		org,
+		func() interface{} {
+			return &[]Ruleset{}
+		},
+		func(obj interface{}) {
+			rulesets = append(rulesets, *(obj.(*[]Ruleset))...)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rulesets, nil
+}
+
+// GetRepoRuleset returns a ruleset of the repo.
+//
+// See https://docs.github.com/en/rest/repos/rules#get-a-repository-ruleset
+func (c *client) GetRepoRuleset(org, repo string, id int) (*Ruleset, error) {
+	durationLogger := c.log("GetRepoRuleset", org, repo, id)
+	defer durationLogger()
+
+	var ruleset Ruleset
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/repos/%s/%s/rulesets/%d", org, repo, id),
+		org:       org,
+		exitCodes: []int{200},
+	}, &ruleset)
+	if err != nil {
+		return nil, err
+	}
+	return &ruleset, nil
+}
+
+// CreateRepoRuleset creates a ruleset in the repo.
+//
+// See https://docs.github.com/en/rest/repos/rules#create-a-repository-ruleset
+func (c *client) CreateRepoRuleset(org, repo string, ruleset Ruleset) error {
+	durationLogger := c.log("CreateRepoRuleset", org, repo, ruleset.Name)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:      http.MethodPost,
+		path:        fmt.Sprintf("/repos/%s/%s/rulesets", org, repo),
+		org:         org,
+		requestBody: ruleset,
+		exitCodes:   []int{201},
+	}, nil)
+	return err
+}
+
+// UpdateRepoRuleset replaces a ruleset of the repo.
+//
+// See https://docs.github.com/en/rest/repos/rules#update-a-repository-ruleset
+func (c *client) UpdateRepoRuleset(org, repo string, id int, ruleset Ruleset) error {
+	durationLogger := c.log("UpdateRepoRuleset", org, repo, id, ruleset.Name)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/repos/%s/%s/rulesets/%d", org, repo, id),
+		org:         org,
+		requestBody: ruleset,
+		exitCodes:   []int{200},
+	}, nil)
 	return err
 }
 
@@ -3400,6 +4086,45 @@ func (c *client) DeleteRef(org, repo, ref string) error {
 	return err
 }
 
+// UpdateRef points the given ref, such as "heads/release-1.0", to the given
+// SHA. Unless force is set, GitHub only accepts fast-forwards of the ref.
+//
+// See https://docs.github.com/en/rest/git/refs#update-a-reference
+func (c *client) UpdateRef(org, repo, ref, sha string, force bool) error {
+	durationLogger := c.log("UpdateRef", org, repo, ref, sha, force)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:      http.MethodPatch,
+		path:        fmt.Sprintf("/repos/%s/%s/git/refs/%s", org, repo, ref),
+		org:         org,
+		requestBody: map[string]interface{}{"sha": sha, "force": force},
+		exitCodes:   []int{200},
+	}, nil)
+	return err
+}
+
+// CompareCommits compares the head commit to the base commit. The comparison
+// lists at most 250 commits.
+//
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (c *client) CompareCommits(org, repo, base, head string) (*CommitsComparison, error) {
+	durationLogger := c.log("CompareCommits", org, repo, base, head)
+	defer durationLogger()
+
+	var comparison CommitsComparison
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/repos/%s/%s/compare/%s...%s", org, repo, base, head),
+		org:       org,
+		exitCodes: []int{200},
+	}, &comparison)
+	if err != nil {
+		return nil, err
+	}
+	return &comparison, nil
+}
+
 // ListFileCommits returns the commits for this file path.
 //
 // See https://developer.github.com/v3/repos/#list-commits
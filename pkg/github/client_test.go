@@ -132,6 +132,43 @@ func TestAbuseRateLimit(t *testing.T) {
 	}
 }
 
+func TestSecondaryRateLimit(t *testing.T) {
+	tc := &testTime{now: time.Now()}
+	var requests int
+	var sleeps []time.Duration
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests > 0 {
+			sleeps = append(sleeps, tc.slept)
+		}
+		requests++
+		if requests <= 2 {
+			http.Error(w, `{"message": "You have exceeded a secondary rate limit."}`, http.StatusTooManyRequests)
+		}
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	c.time = tc
+	resp, err := c.requestRetry(http.MethodGet, "/", "", "", nil)
+	if err != nil {
+		t.Fatalf("Error from request: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("Expected status code 200, got %d", resp.StatusCode)
+	}
+	if len(sleeps) != 2 {
+		t.Fatalf("Expected to sleep twice, got %v", sleeps)
+	}
+	if sleeps[0] < time.Minute || sleeps[0] > 66*time.Second {
+		t.Errorf("Expected to sleep about a minute after the first secondary rate limit, got %v", sleeps[0])
+	}
+	if sleeps[1] < 2*time.Minute || sleeps[1] > 132*time.Second {
+		t.Errorf("Expected to sleep about two minutes after the second secondary rate limit, got %v", sleeps[1])
+	}
+	if c.secondaryRateLimitBackoff != 0 {
+		t.Errorf("Expected the backoff to be reset after a successful request, got %v", c.secondaryRateLimitBackoff)
+	}
+}
+
 func TestRetry404(t *testing.T) {
 	tc := &testTime{now: time.Now()}
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -541,6 +578,58 @@ func TestDeleteRef(t *testing.T) {
 	}
 }
 
+func TestUpdateRef(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/k8s/kuber/git/refs/heads/release-1.0" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Could not read request body: %v", err)
+		}
+		if string(b) != `{"force":false,"sha":"abcde"}` {
+			t.Errorf("Bad request body: %s", string(b))
+		}
+		fmt.Fprint(w, `{"ref": "refs/heads/release-1.0", "object": {"sha": "abcde"}}`)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	if err := c.UpdateRef("k8s", "kuber", "heads/release-1.0", "abcde", false); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
+func TestCompareCommits(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/k8s/kuber/compare/base...head" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": "ahead", "ahead_by": 1, "behind_by": 0, "total_commits": 1, "commits": [{"sha": "head"}], "html_url": "https://github.com/k8s/kuber/compare/base...head"}`)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	comparison, err := c.CompareCommits("k8s", "kuber", "base", "head")
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	expected := &CommitsComparison{
+		Status:       ComparisonStatusAhead,
+		AheadBy:      1,
+		TotalCommits: 1,
+		Commits:      []RepositoryCommit{{SHA: "head"}},
+		HTMLURL:      "https://github.com/k8s/kuber/compare/base...head",
+	}
+	if diff := cmp.Diff(expected, comparison); diff != "" {
+		t.Errorf("Unexpected comparison (-want +got):\n%s", diff)
+	}
+}
+
 func TestListFileCommits(t *testing.T) {
 	githubResponse := []byte(`
 [
@@ -2356,9 +2445,10 @@ func TestUpdateBranchProtection(t *testing.T) {
 	cases := []struct {
 		name string
 		// TODO(fejta): expand beyond contexts/pushers
-		contexts []string
-		pushers  []string
-		err      bool
+		contexts   []string
+		pushers    []string
+		signatures bool
+		err        bool
 	}{
 		{
 			name:     "both",
@@ -2366,10 +2456,28 @@ func TestUpdateBranchProtection(t *testing.T) {
 			pushers:  []string{"movers", "awesome-team", "shakers"},
 			err:      false,
 		},
+		{
+			name:       "required signatures",
+			contexts:   []string{"foo-pr-test"},
+			pushers:    []string{"movers"},
+			signatures: true,
+			err:        false,
+		},
 	}
 
 	for _, tc := range cases {
 		ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/repos/org/repo/branches/master/protection/required_signatures" {
+				method, code := http.MethodDelete, http.StatusNoContent
+				if tc.signatures {
+					method, code = http.MethodPost, http.StatusOK
+				}
+				if r.Method != method {
+					t.Errorf("%s: expected %s of required signatures, got %s", tc.name, method, r.Method)
+				}
+				w.WriteHeader(code)
+				return
+			}
 			if r.Method != http.MethodPut {
 				t.Errorf("Bad method: %s", r.Method)
 			}
@@ -2431,6 +2539,7 @@ func TestUpdateBranchProtection(t *testing.T) {
 			Restrictions: &RestrictionsRequest{
 				Teams: &tc.pushers,
 			},
+			RequiredSignatures: tc.signatures,
 		})
 		if tc.err && err == nil {
 			t.Errorf("%s: expected error failed to occur", tc.name)
@@ -2441,6 +2550,73 @@ func TestUpdateBranchProtection(t *testing.T) {
 	}
 }
 
+func TestRepoRulesets(t *testing.T) {
+	ruleset := Ruleset{
+		Name:        "deployments",
+		Target:      "branch",
+		Enforcement: RulesetEnforcementActive,
+		Conditions:  &RulesetConditions{RefName: RulesetRefNameCondition{Include: []string{"~DEFAULT_BRANCH"}, Exclude: []string{}}},
+		Rules: []RulesetRule{{
+			Type:       RulesetRuleRequiredDeployments,
+			Parameters: &RulesetRuleParameters{RequiredDeploymentEnvironments: []string{"staging"}},
+		}},
+	}
+	var requests []string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/org/repo/rulesets":
+			if r.URL.Query().Get("includes_parents") != "false" {
+				t.Errorf("Expected rulesets of the org to be excluded, got query %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode([]Ruleset{{ID: 1, Name: "deployments", Enforcement: RulesetEnforcementActive}})
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/org/repo/rulesets/1":
+			withID := ruleset
+			withID.ID = 1
+			json.NewEncoder(w).Encode(withID)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/org/repo/rulesets", r.Method == http.MethodPut && r.URL.Path == "/repos/org/repo/rulesets/1":
+			var body Ruleset
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Could not unmarshal request: %v", err)
+			}
+			if diff := cmp.Diff(ruleset, body); diff != "" {
+				t.Errorf("Unexpected ruleset (-want +got):\n%s", diff)
+			}
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+
+	rulesets, err := c.ListRepoRulesets("org", "repo")
+	if err != nil {
+		t.Fatalf("Failed to list rulesets: %v", err)
+	}
+	if len(rulesets) != 1 || rulesets[0].ID != 1 {
+		t.Errorf("Expected the ruleset with ID 1, got %v", rulesets)
+	}
+	got, err := c.GetRepoRuleset("org", "repo", 1)
+	if err != nil {
+		t.Fatalf("Failed to get ruleset: %v", err)
+	}
+	if diff := cmp.Diff(ruleset.Rules, got.Rules); diff != "" {
+		t.Errorf("Unexpected rules (-want +got):\n%s", diff)
+	}
+	if err := c.CreateRepoRuleset("org", "repo", ruleset); err != nil {
+		t.Errorf("Failed to create ruleset: %v", err)
+	}
+	if err := c.UpdateRepoRuleset("org", "repo", 1, ruleset); err != nil {
+		t.Errorf("Failed to update ruleset: %v", err)
+	}
+	if len(requests) != 4 {
+		t.Errorf("Expected 4 requests, got %v", requests)
+	}
+}
+
 func TestClearMilestone(t *testing.T) {
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPatch {
@@ -2841,6 +3017,92 @@ func TestUpdateRepo(t *testing.T) {
 	}
 }
 
+func TestTransferRepo(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/old-org/project/transfer" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Could not read request body: %v", err)
+		}
+		var transfer struct {
+			NewOwner string `json:"new_owner"`
+			NewName  string `json:"new_name"`
+		}
+		if err := json.Unmarshal(b, &transfer); err != nil {
+			t.Errorf("Could not unmarshal request: %v", err)
+		} else if transfer.NewOwner != "new-org" || transfer.NewName != "moved" {
+			t.Errorf("Bad transfer request: %+v", transfer)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	if err := c.TransferRepo("old-org", "project", "new-org", "moved"); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
+func TestListDeployments(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/org/repo/deployments" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		if sha := r.URL.Query().Get("sha"); sha != "abc" {
+			t.Errorf("Bad sha filter: %q", sha)
+		}
+		if env := r.URL.Query().Get("environment"); env != "staging" {
+			t.Errorf("Bad environment filter: %q", env)
+		}
+		b, err := json.Marshal([]Deployment{{ID: 2, SHA: "abc", Environment: "staging"}, {ID: 1, SHA: "abc", Environment: "staging"}})
+		if err != nil {
+			t.Fatalf("Didn't expect error: %v", err)
+		}
+		fmt.Fprint(w, string(b))
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	deployments, err := c.ListDeployments("org", "repo", "abc", "staging")
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	if len(deployments) != 2 || deployments[0].ID != 2 || deployments[1].ID != 1 {
+		t.Errorf("Wrong deployments: %+v", deployments)
+	}
+}
+
+func TestListDeploymentStatuses(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/org/repo/deployments/5/statuses" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := json.Marshal([]DeploymentStatus{{ID: 7, State: DeploymentStateSuccess}, {ID: 6, State: DeploymentStateInProgress}})
+		if err != nil {
+			t.Fatalf("Didn't expect error: %v", err)
+		}
+		fmt.Fprint(w, string(b))
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	statuses, err := c.ListDeploymentStatuses("org", "repo", 5)
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].State != DeploymentStateSuccess {
+		t.Errorf("Wrong statuses: %+v", statuses)
+	}
+}
+
 type fakeHttpClient struct {
 	received []*http.Request
 }
@@ -2933,6 +3195,107 @@ func TestRemoveTeamRepoBySlug(t *testing.T) {
 	}
 }
 
+func TestListSecurityManagerTeams(t *testing.T) {
+	ts := simpleTestServer(t, "/orgs/orgName/security-managers", []Team{{Name: "Security", Slug: "security"}}, http.StatusOK)
+	defer ts.Close()
+	c := getClient(ts.URL)
+	teams, err := c.ListSecurityManagerTeams("orgName")
+	if err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	} else if len(teams) != 1 || teams[0].Slug != "security" {
+		t.Errorf("Wrong teams: %v", teams)
+	}
+}
+
+func TestAddSecurityManagerTeam(t *testing.T) {
+	ts := simpleTestServer(t, "/orgs/orgName/security-managers/teams/team-name", nil, http.StatusNoContent)
+	defer ts.Close()
+	c := getClient(ts.URL)
+
+	if err := c.AddSecurityManagerTeam("orgName", "team-name"); err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+}
+
+func TestListOrgRoles(t *testing.T) {
+	ts := simpleTestServer(t, "/orgs/orgName/organization-roles", map[string]interface{}{
+		"total_count": 2,
+		"roles":       []OrgRole{{ID: 8132, Name: "all_repo_read"}, {ID: 42, Name: "auditor"}},
+	}, http.StatusOK)
+	defer ts.Close()
+	c := getClient(ts.URL)
+	roles, err := c.ListOrgRoles("orgName")
+	if err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	} else if len(roles) != 2 || roles[1].ID != 42 || roles[1].Name != "auditor" {
+		t.Errorf("Wrong roles: %v", roles)
+	}
+}
+
+func TestListOrgRoleUsers(t *testing.T) {
+	ts := simpleTestServer(t, "/orgs/orgName/organization-roles/42/users", []OrgRoleUser{
+		{Login: "alice", Assignment: OrgRoleAssignmentDirect},
+		{Login: "bob", Assignment: OrgRoleAssignmentIndirect},
+	}, http.StatusOK)
+	defer ts.Close()
+	c := getClient(ts.URL)
+	users, err := c.ListOrgRoleUsers("orgName", 42)
+	if err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	} else if len(users) != 2 || users[1].Assignment != OrgRoleAssignmentIndirect {
+		t.Errorf("Wrong users: %v", users)
+	}
+}
+
+func TestAssignOrgRoleToTeam(t *testing.T) {
+	ts := simpleTestServer(t, "/orgs/orgName/organization-roles/teams/team-name/42", nil, http.StatusNoContent)
+	defer ts.Close()
+	c := getClient(ts.URL)
+
+	if err := c.AssignOrgRoleToTeam("orgName", "team-name", 42); err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+}
+
+func TestGetOrgForkPRApprovalPolicy(t *testing.T) {
+	ts := simpleTestServer(t, "/orgs/orgName/actions/permissions/fork-pr-contributor-approval", map[string]string{
+		"approval_policy": "all_external_contributors",
+	}, http.StatusOK)
+	defer ts.Close()
+	c := getClient(ts.URL)
+	policy, err := c.GetOrgForkPRApprovalPolicy("orgName")
+	if err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	} else if policy != ForkPRApprovalAllExternalContributors {
+		t.Errorf("Wrong policy: %s", policy)
+	}
+}
+
+func TestUpdateOrgWorkflowPermissions(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/orgs/orgName/actions/permissions/workflow" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		var permissions WorkflowPermissions
+		if err := json.NewDecoder(r.Body).Decode(&permissions); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if permissions.DefaultWorkflowPermissions != WorkflowPermissionRead || !permissions.CanApprovePullRequestReviews {
+			t.Errorf("Wrong permissions: %+v", permissions)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+
+	if err := c.UpdateOrgWorkflowPermissions("orgName", WorkflowPermissions{DefaultWorkflowPermissions: WorkflowPermissionRead, CanApprovePullRequestReviews: true}); err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+}
+
 func TestListTeamInvitationsBySlug(t *testing.T) {
 	ts := simpleTestServer(t, "/orgs/orgName/teams/team-name/invitations", []OrgInvitation{
 		{
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"sigs.k8s.io/prow/pkg/github/ghmetrics"
+)
+
+// Modes of responses to GET requests of clients with an ETagStore.
+const (
+	// ETagModeMiss means that no response was stored for the request.
+	ETagModeMiss = "MISS"
+	// ETagModeChanged means that the stored response for the request was
+	// outdated and replaced.
+	ETagModeChanged = "CHANGED"
+	// ETagModeRevalidated means that the stored response for the request was
+	// still up to date and returned. Such requests don't count against the
+	// rate limit.
+	ETagModeRevalidated = "REVALIDATED"
+)
+
+// CachedResponse is a response stored in an ETagStore.
+type CachedResponse struct {
+	ETag   string
+	Header http.Header
+	Body   []byte
+}
+
+// ETagStore stores the responses to GET requests by the client, so that the
+// client can make conditional requests with their ETags and reuse them if they
+// are still up to date. Implementations must be safe for concurrent use.
+type ETagStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Put(key string, response *CachedResponse)
+}
+
+type memoryETagStore struct {
+	lock sync.Mutex
+	lru  *simplelru.LRU
+}
+
+// NewMemoryETagStore returns an ETagStore that keeps up to size responses in
+// memory, evicting the least recently used ones.
+func NewMemoryETagStore(size int) (ETagStore, error) {
+	lru, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+	return &memoryETagStore{lru: lru}, nil
+}
+
+func (s *memoryETagStore) Get(key string) (*CachedResponse, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	response, ok := s.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return response.(*CachedResponse), true
+}
+
+func (s *memoryETagStore) Put(key string, response *CachedResponse) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lru.Add(key, response)
+}
+
+// etagTransport makes GET requests conditional on the ETags of the responses
+// stored for them, and returns the stored responses if GitHub responds that
+// they are still up to date.
+type etagTransport struct {
+	upstream http.RoundTripper
+	store    ETagStore
+}
+
+// etagCacheKey identifies the response to a request. Different credentials
+// might see different responses, so they are part of the key.
+func etagCacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return fmt.Sprintf("%s %s %x", req.URL.String(), req.Header.Get("Accept"), auth)
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Leave requests that are already conditional alone.
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" {
+		return t.upstream.RoundTrip(req)
+	}
+
+	key := etagCacheKey(req)
+	cached, ok := t.store.Get(key)
+	if ok {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	resp, err := t.upstream.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		ghmetrics.CollectETagCacheMetrics(ETagModeRevalidated, req.URL.Path, req.Header.Get("User-Agent"))
+		// The headers of the 304 response, like the rate limit headers,
+		// are more recent than the stored ones.
+		header := cached.Header.Clone()
+		for k, v := range resp.Header {
+			header[k] = v
+		}
+		resp.StatusCode = http.StatusOK
+		resp.Status = http.StatusText(http.StatusOK)
+		resp.Header = header
+		resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+		resp.ContentLength = int64(len(cached.Body))
+		return resp, nil
+	}
+
+	mode := ETagModeMiss
+	if ok {
+		mode = ETagModeChanged
+	}
+	ghmetrics.CollectETagCacheMetrics(mode, req.URL.Path, req.Header.Get("User-Agent"))
+	if etag := resp.Header.Get("ETag"); resp.StatusCode == http.StatusOK && etag != "" {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		t.store.Put(key, &CachedResponse{ETag: etag, Header: resp.Header.Clone(), Body: body})
+	}
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestETagTransport(t *testing.T) {
+	body := "first"
+	etag := `"1"`
+	var conditional, notModified int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.Header().Set("X-RateLimit-Remaining", "5000")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	store, err := NewMemoryETagStore(10)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	client := &http.Client{Transport: &etagTransport{upstream: http.DefaultTransport, store: store}}
+	get := func(auth string) (string, *http.Response) {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/repos/org/repo/issues", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Authorization", auth)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Failed to read body: %v", err)
+		}
+		return string(b), resp
+	}
+	if got, _ := get("token a"); got != "first" {
+		t.Errorf("Expected body %q, got %q", "first", got)
+	}
+	got, resp := get("token a")
+	if got != "first" || resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the stored body %q with status 200, got %q with status %d", "first", got, resp.StatusCode)
+	}
+	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "5000" {
+		t.Errorf("Expected the headers of the 304 response to take precedence, got X-RateLimit-Remaining %q", remaining)
+	}
+	if notModified != 1 {
+		t.Errorf("Expected 1 revalidated request, got %d", notModified)
+	}
+
+	// Responses are stored per credentials.
+	if get("token b"); conditional != 1 {
+		t.Errorf("Expected the request with other credentials not to be conditional, got %d conditional requests", conditional)
+	}
+
+	body, etag = "second", `"2"`
+	if got, _ := get("token a"); got != "second" {
+		t.Errorf("Expected the changed body %q, got %q", "second", got)
+	}
+	if got, _ := get("token a"); got != "second" {
+		t.Errorf("Expected the stored changed body %q, got %q", "second", got)
+	}
+	if notModified != 2 {
+		t.Errorf("Expected 2 revalidated requests, got %d", notModified)
+	}
+}
@@ -58,8 +58,13 @@ type FakeClient struct {
 	Reviews                    map[int][]github.Review
 	CombinedStatuses           map[string]*github.CombinedStatus
 	CreatedStatuses            map[string][]github.Status
-	IssueEvents                map[int][]github.ListedIssueEvent
-	Commits                    map[string]github.RepositoryCommit
+	// CheckRuns maps head SHAs to the check runs created for them.
+	CheckRuns   map[string][]github.CheckRun
+	CheckRunID  int64
+	IssueEvents map[int][]github.ListedIssueEvent
+	Commits     map[string]github.RepositoryCommit
+	// CommitsComparisons maps base...head to the comparison of the commits.
+	CommitsComparisons map[string]*github.CommitsComparison
 
 	// All Labels That Exist In The Repo
 	RepoLabelsExisting []string
@@ -152,6 +157,19 @@ type FakeClient struct {
 
 	// Reviewers Requested
 	ReviewersRequested []string
+
+	// FailedActionRuns are returned by GetFailedActionRunsByHeadBranch
+	FailedActionRuns []github.WorkflowRun
+	// TriggerFailedGitHubWorkflowErrors maps run IDs to the error returned
+	// by TriggerFailedGitHubWorkflow
+	TriggerFailedGitHubWorkflowErrors map[int]error
+	// TriggeredFailedGitHubWorkflows are the IDs of the re-run workflow runs
+	TriggeredFailedGitHubWorkflows []int
+	// TriggeredGitHubWorkflows are the IDs of the workflow runs re-run entirely
+	TriggeredGitHubWorkflows []int
+
+	// ServerInfo is returned by GetServerInfo if set
+	ServerInfo *github.ServerInfo
 }
 
 type TeamWithMembers struct {
@@ -484,6 +502,32 @@ func (f *FakeClient) GetPullRequestChanges(org, repo string, number int) ([]gith
 	return f.PullRequestChanges[number], nil
 }
 
+// GetPullRequestsData returns the data of the pull requests.
+func (f *FakeClient) GetPullRequestsData(org, repo string, numbers []int) (map[int]github.PullRequestData, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	data := make(map[int]github.PullRequestData, len(numbers))
+	for _, number := range numbers {
+		pr, exists := f.PullRequests[number]
+		if !exists {
+			return nil, fmt.Errorf("pull request number %d does not exist", number)
+		}
+		prData := github.PullRequestData{
+			Number:   number,
+			Author:   pr.User.Login,
+			HeadSHA:  pr.Head.SHA,
+			BaseRef:  pr.Base.Ref,
+			Reviews:  append([]github.Review{}, f.Reviews[number]...),
+			Statuses: append([]github.Status{}, f.CreatedStatuses[pr.Head.SHA]...),
+		}
+		for _, change := range f.PullRequestChanges[number] {
+			prData.Files = append(prData.Files, change.Filename)
+		}
+		data[number] = prData
+	}
+	return data, nil
+}
+
 // GetRef returns the hash of a ref.
 func (f *FakeClient) GetRef(owner, repo, ref string) (string, error) {
 	return TestRef, nil
@@ -504,6 +548,17 @@ func (f *FakeClient) GetSingleCommit(org, repo, SHA string) (github.RepositoryCo
 	return f.Commits[SHA], nil
 }
 
+// CompareCommits returns the comparison of the head commit to the base commit.
+func (f *FakeClient) CompareCommits(org, repo, base, head string) (*github.CommitsComparison, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	comparison, ok := f.CommitsComparisons[base+"..."+head]
+	if !ok {
+		return nil, fmt.Errorf("no comparison of %s...%s", base, head)
+	}
+	return comparison, nil
+}
+
 // CreateStatus adds a status context to a commit.
 func (f *FakeClient) CreateStatus(owner, repo, SHA string, s github.Status) error {
 	return f.CreateStatusWithContext(context.Background(), owner, repo, SHA, s)
@@ -536,6 +591,49 @@ func (f *FakeClient) CreateStatusWithContext(_ context.Context, owner, repo, SHA
 	return nil
 }
 
+// ListCheckRuns lists the check runs created for a ref.
+func (f *FakeClient) ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return &github.CheckRunList{Total: len(f.CheckRuns[ref]), CheckRuns: f.CheckRuns[ref]}, nil
+}
+
+// CreateCheckRun creates a check run for the head SHA of checkRun.
+func (f *FakeClient) CreateCheckRun(org, repo string, checkRun github.CheckRun) (int64, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.Error != nil {
+		return 0, f.Error
+	}
+	if f.CheckRuns == nil {
+		f.CheckRuns = make(map[string][]github.CheckRun)
+	}
+	f.CheckRunID++
+	checkRun.ID = f.CheckRunID
+	f.CheckRuns[checkRun.HeadSHA] = append(f.CheckRuns[checkRun.HeadSHA], checkRun)
+	return checkRun.ID, nil
+}
+
+// UpdateCheckRun replaces the check run with the given ID, keeping its head SHA.
+func (f *FakeClient) UpdateCheckRun(org, repo string, checkRunId int64, checkRun github.CheckRun) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.Error != nil {
+		return f.Error
+	}
+	for sha, checkRuns := range f.CheckRuns {
+		for i := range checkRuns {
+			if checkRuns[i].ID != checkRunId {
+				continue
+			}
+			checkRun.ID, checkRun.HeadSHA = checkRunId, sha
+			checkRuns[i] = checkRun
+			return nil
+		}
+	}
+	return fmt.Errorf("check run %d not found", checkRunId)
+}
+
 // ListStatuses returns individual status contexts on a commit.
 func (f *FakeClient) ListStatuses(org, repo, ref string) ([]github.Status, error) {
 	f.lock.RLock()
@@ -1084,6 +1182,17 @@ func (f *FakeClient) CreatePullRequest(org, repo, title, body, head, base string
 	return 0, errors.New("FakeClient supports only 999 PullRequests")
 }
 
+func (f *FakeClient) CreateDraftPullRequest(org, repo, title, body, head, base string, canModify bool) (int, error) {
+	num, err := f.CreatePullRequest(org, repo, title, body, head, base, canModify)
+	if err != nil {
+		return 0, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.PullRequests[num].Draft = true
+	return num, nil
+}
+
 func (f *FakeClient) UpdatePullRequest(org, repo string, number int, title, body *string, open *bool, branch *string, canModify *bool) error {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -1186,14 +1295,32 @@ func (f *FakeClient) MutateWithGitHubAppsSupport(ctx context.Context, m interfac
 }
 
 func (f *FakeClient) GetFailedActionRunsByHeadBranch(org, repo, branchName, headSHA string) ([]github.WorkflowRun, error) {
-	return []github.WorkflowRun{}, nil
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return append([]github.WorkflowRun{}, f.FailedActionRuns...), nil
 }
 
 func (f *FakeClient) TriggerGitHubWorkflow(org, repo string, id int) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.TriggeredGitHubWorkflows = append(f.TriggeredGitHubWorkflows, id)
 	return nil
 }
 
+func (f *FakeClient) GetServerInfo(org string) (*github.ServerInfo, error) {
+	if f.ServerInfo != nil {
+		return f.ServerInfo, nil
+	}
+	return &github.ServerInfo{}, nil
+}
+
 func (f *FakeClient) TriggerFailedGitHubWorkflow(org, repo string, id int) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.TriggerFailedGitHubWorkflowErrors[id]; err != nil {
+		return err
+	}
+	f.TriggeredFailedGitHubWorkflows = append(f.TriggeredFailedGitHubWorkflows, id)
 	return nil
 }
 
@@ -101,6 +101,28 @@ var cacheEntryAge = prometheus.NewHistogramVec(
 	[]string{"token_hash", "path", "user_agent"},
 )
 
+// secondaryRateLimitCounter provides the 'github_secondary_rate_limits'
+// counter that keeps track of the requests hitting secondary rate limits by
+// API path.
+var secondaryRateLimitCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "github_secondary_rate_limits",
+		Help: "How many GitHub requests hit secondary rate limits by API path.",
+	},
+	[]string{"token_hash", "path"},
+)
+
+// etagCacheCounter provides the 'github_etag_cache_responses' counter that
+// keeps track of the responses to conditional requests of GitHub clients by
+// cache response mode.
+var etagCacheCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "github_etag_cache_responses",
+		Help: "How many responses of each ETag cache response mode GitHub clients got.",
+	},
+	[]string{"mode", "path", "user_agent"},
+)
+
 var muxTokenUsage sync.Mutex
 var lastGitHubResponse time.Time
 
@@ -112,6 +134,8 @@ func init() {
 	prometheus.MustRegister(cacheCounter)
 	prometheus.MustRegister(timeoutDuration)
 	prometheus.MustRegister(cacheEntryAge)
+	prometheus.MustRegister(secondaryRateLimitCounter)
+	prometheus.MustRegister(etagCacheCounter)
 }
 
 // CollectGitHubTokenMetrics publishes the rate limits of the github api to
@@ -194,3 +218,15 @@ func CollectRequestTimeoutMetrics(tokenHash, path, userAgent string, reqStartTim
 func CollectGitHubRequestWaitDurationMetrics(tokenHash, requestType, api string, duration time.Duration) {
 	ghRequestWaitDurationHistVec.With(prometheus.Labels{"token_hash": tokenHash, "request_type": requestType, "api": api}).Observe(duration.Seconds())
 }
+
+// CollectSecondaryRateLimitMetrics counts a request hitting a secondary rate
+// limit by API path in 'github_secondary_rate_limits' on prometheus.
+func CollectSecondaryRateLimitMetrics(tokenHash, path string) {
+	secondaryRateLimitCounter.With(prometheus.Labels{"token_hash": tokenHash, "path": simplifier.Simplify(path)}).Inc()
+}
+
+// CollectETagCacheMetrics records an ETag cache outcome of a GitHub client
+// for a specific path.
+func CollectETagCacheMetrics(mode, path, userAgent string) {
+	etagCacheCounter.With(prometheus.Labels{"mode": mode, "path": simplifier.Simplify(path), "user_agent": userAgentWithoutVersion(userAgent)}).Inc()
+}
@@ -147,6 +147,11 @@ func GeneralizeComment[E CommentLikeEventTypes](event E) (*GenericCommentEvent,
 			return nil, fmt.Errorf("failed to determine events action [%v]", string(t.Action))
 		}
 
+		var previousBody string
+		if t.Changes != nil && t.Changes.Body != nil {
+			previousBody = t.Changes.Body.From
+		}
+
 		return &GenericCommentEvent{
 			ID:           t.Issue.ID,
 			NodeID:       t.Issue.NodeID,
@@ -165,6 +170,7 @@ func GeneralizeComment[E CommentLikeEventTypes](event E) (*GenericCommentEvent,
 			IssueTitle:   t.Issue.Title,
 			IssueBody:    t.Issue.Body,
 			IssueHTMLURL: t.Issue.HTMLURL,
+			PreviousBody: previousBody,
 		}, nil
 	case PullRequestEvent:
 		action := GeneralizeCommentAction(string(t.Action))
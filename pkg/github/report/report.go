@@ -23,22 +23,44 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"text/template"
 
+	"github.com/sirupsen/logrus"
+
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
 )
 
 const (
 	// CommentTag is the tag which identifies an issue comment containing a test report
 	CommentTag = "<!-- test report -->"
+
+	// maxReportedFailures is the maximum number of failed tests named in an
+	// entry of the table of failed jobs.
+	maxReportedFailures = 3
 )
 
+// ResultsGetter returns the summary of the test results of a ProwJob, or nil
+// if the job did not upload one.
+type ResultsGetter func(ctx context.Context, pj prowapi.ProwJob) (*results.Summary, error)
+
+// tableSeparatorRe matches the line separating the header of the table of
+// failed tests from its entries. Only lines made of separator cells match, so
+// that horizontal rules in the failure comment templates aren't mistaken for
+// it.
+var tableSeparatorRe = regexp.MustCompile(`^---( \| ---)+$`)
+
+// testNameEscaper keeps test names from ending their code span, their table
+// cell or their table row.
+var testNameEscaper = strings.NewReplacer("`", "'", "|", `\|`, "\n", " ")
+
 // GitHubClient provides a client interface to report job status updates
 // through GitHub comments.
 type GitHubClient interface {
@@ -71,8 +93,19 @@ func prowjobStateToGitHubStatus(pjState prowapi.ProwJobState) (string, error) {
 	return "", fmt.Errorf("Unknown prowjob state: %s", pjState)
 }
 
-// reportStatus should be called on any prowjob status changes
-func reportStatus(ctx context.Context, ghc GitHubClient, pj prowapi.ProwJob) error {
+// FailureCommentData is passed to the failure comment templates.
+type FailureCommentData struct {
+	// Author is the login of the author of the pull request.
+	Author string
+	// Failed is the number of failed tests listed in the comment.
+	Failed int
+	// ProwJob is the first reported job.
+	ProwJob prowapi.ProwJob
+}
+
+// reportStatus should be called on any prowjob status changes. If
+// descriptionTemplate is not nil, it renders the description of the status.
+func reportStatus(ctx context.Context, ghc GitHubClient, pj prowapi.ProwJob, descriptionTemplate *template.Template) error {
 	refs := pj.Spec.Refs
 	if pj.Spec.Report {
 		contextState, err := prowjobStateToGitHubStatus(pj.Status.State)
@@ -83,9 +116,20 @@ func reportStatus(ctx context.Context, ghc GitHubClient, pj prowapi.ProwJob) err
 		if len(refs.Pulls) > 0 {
 			sha = refs.Pulls[0].SHA
 		}
+		description := pj.Status.Description
+		if descriptionTemplate != nil {
+			var b bytes.Buffer
+			// A broken template must not keep the status from being
+			// reported, so the plain description is used instead.
+			if err := descriptionTemplate.Execute(&b, &pj); err != nil {
+				logrus.WithError(err).WithField("prowjob", pj.Name).Warn("Error executing status description template, using the job's description.")
+			} else {
+				description = b.String()
+			}
+		}
 		if err := ghc.CreateStatusWithContext(ctx, refs.Org, refs.Repo, sha, github.Status{
 			State:       contextState,
-			Description: config.ContextDescriptionWithBaseSha(pj.Status.Description, refs.BaseSHA),
+			Description: config.ContextDescriptionWithBaseSha(description, refs.BaseSHA),
 			Context:     pj.Spec.Context, // consider truncating this too
 			TargetURL:   pj.Status.URL,
 		}); err != nil {
@@ -122,7 +166,7 @@ func Report(ctx context.Context, ghc GitHubClient, reportTemplate *template.Temp
 	if err := ReportStatusContext(ctx, ghc, pj, config); err != nil {
 		return err
 	}
-	return ReportComment(ctx, ghc, reportTemplate, []prowapi.ProwJob{pj}, config, false)
+	return ReportComment(ctx, ghc, reportTemplate, []prowapi.ProwJob{pj}, config, false, nil)
 }
 
 // ReportStatusContext reports prowjob status on a PR.
@@ -141,7 +185,7 @@ func ReportStatusContext(ctx context.Context, ghc GitHubClient, pj prowapi.ProwJ
 		return nil
 	}
 
-	if err := reportStatus(ctx, ghc, pj); err != nil {
+	if err := reportStatus(ctx, ghc, pj, config.StatusDescriptionTemplateForRepo(refs)); err != nil {
 		return fmt.Errorf("error setting status: %w", err)
 	}
 	return nil
@@ -149,8 +193,9 @@ func ReportStatusContext(ctx context.Context, ghc GitHubClient, pj prowapi.ProwJ
 
 // ReportComment takes multiple prowjobs as input. When there are more than one
 // prowjob, they are required to have identical refs, aka they are the same repo
-// and the same pull request.
-func ReportComment(ctx context.Context, ghc GitHubClient, reportTemplate *template.Template, pjs []prowapi.ProwJob, config config.GitHubReporter, mustCreate bool) error {
+// and the same pull request. If getResults is not nil, the entries of failed
+// jobs name the tests that failed.
+func ReportComment(ctx context.Context, ghc GitHubClient, reportTemplate *template.Template, pjs []prowapi.ProwJob, config config.GitHubReporter, mustCreate bool, getResults ResultsGetter) error {
 	if ghc == nil {
 		return errors.New("trying to report pj, but found empty github client")
 	}
@@ -184,7 +229,23 @@ func ReportComment(ctx context.Context, ghc GitHubClient, reportTemplate *templa
 	if err != nil {
 		return fmt.Errorf("error getting bot name checker: %w", err)
 	}
-	deletes, entries, updateID := parseIssueComments(validPjs, botNameChecker, ics)
+	summaries := map[string]*results.Summary{}
+	if getResults != nil {
+		for _, pj := range validPjs {
+			if string(pj.Status.State) != github.StatusFailure {
+				continue
+			}
+			// The summary only adds details to the entry, so failing to
+			// get it must not keep the job from being reported.
+			summary, err := getResults(ctx, pj)
+			if err != nil {
+				logrus.WithError(err).WithField("prowjob", pj.Name).Warn("Error getting the test results summary.")
+				continue
+			}
+			summaries[pj.Name] = summary
+		}
+	}
+	deletes, entries, updateID := parseIssueComments(validPjs, summaries, botNameChecker, ics)
 	for _, delete := range deletes {
 		if err := ghc.DeleteCommentWithContext(ctx, refs.Org, refs.Repo, delete); err != nil {
 			return fmt.Errorf("error deleting comment: %w", err)
@@ -202,7 +263,7 @@ func ReportComment(ctx context.Context, ghc GitHubClient, reportTemplate *templa
 	}
 
 	if len(entries) > 0 || (mustCreate && !aborted) {
-		comment, err := createComment(reportTemplate, validPjs, entries)
+		comment, err := createComment(reportTemplate, config.FailureCommentTemplateForRepo(refs), validPjs, entries)
 		if err != nil {
 			return fmt.Errorf("generating comment: %w", err)
 		}
@@ -222,8 +283,9 @@ func ReportComment(ctx context.Context, ghc GitHubClient, reportTemplate *templa
 // parseIssueComments returns a list of comments to delete, a list of table
 // entries, and the ID of the comment to update. If there are no table entries
 // then don't make a new comment. Otherwise, if the comment to update is 0,
-// create a new comment.
-func parseIssueComments(pjs []prowapi.ProwJob, isBot func(string) bool, ics []github.IssueComment) ([]int, []string, int) {
+// create a new comment. Summaries holds the test results of failed jobs by
+// the name of their ProwJob.
+func parseIssueComments(pjs []prowapi.ProwJob, summaries map[string]*results.Summary, isBot func(string) bool, ics []github.IssueComment) ([]int, []string, int) {
 	var delete []int
 	var previousComments []int
 	var latestComment int
@@ -243,7 +305,7 @@ func parseIssueComments(pjs []prowapi.ProwJob, isBot func(string) bool, ics []gi
 		var tracking bool
 		for _, line := range strings.Split(ic.Body, "\n") {
 			line = strings.TrimSpace(line)
-			if strings.HasPrefix(line, "---") {
+			if tableSeparatorRe.MatchString(line) {
 				tracking = true
 			} else if len(line) == 0 {
 				tracking = false
@@ -282,7 +344,7 @@ func parseIssueComments(pjs []prowapi.ProwJob, isBot func(string) bool, ics []gi
 	var createNewComment bool
 	for _, pj := range pjs {
 		if string(pj.Status.State) == github.StatusFailure {
-			newEntries = append(newEntries, createEntry(pj))
+			newEntries = append(newEntries, createEntry(pj, summaries[pj.Name]))
 			createNewComment = true
 		}
 	}
@@ -294,7 +356,7 @@ func parseIssueComments(pjs []prowapi.ProwJob, isBot func(string) bool, ics []gi
 	return delete, newEntries, latestComment
 }
 
-func createEntry(pj prowapi.ProwJob) string {
+func createEntry(pj prowapi.ProwJob, summary *results.Summary) string {
 	required := "unknown"
 
 	if pj.Spec.Type == prowapi.PresubmitJob {
@@ -305,19 +367,44 @@ func createEntry(pj prowapi.ProwJob) string {
 		}
 	}
 
+	details := fmt.Sprintf("[link](%s)", pj.Status.URL)
+	if classification, ok := pj.Annotations[kube.FailureClassificationAnnotation]; ok {
+		details += fmt.Sprintf(" (%s failure)", classification)
+	}
+	if summary != nil && summary.Failed > 0 {
+		details += fmt.Sprintf(" (%d of %d tests failed: %s)", summary.Failed, summary.Total, failedTestNames(summary))
+	}
+
 	return strings.Join([]string{
 		pj.Spec.Context,
 		pj.Spec.Refs.Pulls[0].SHA,
-		fmt.Sprintf("[link](%s)", pj.Status.URL),
+		details,
 		required,
 		fmt.Sprintf("`%s`", pj.Spec.RerunCommand),
 	}, " | ")
 }
 
+// failedTestNames lists the first failed tests of summary, escaped so that
+// they can't break the table they are written to.
+func failedTestNames(summary *results.Summary) string {
+	var names []string
+	for i, failure := range summary.Failures {
+		if i == maxReportedFailures {
+			break
+		}
+		names = append(names, fmt.Sprintf("`%s`", testNameEscaper.Replace(failure.Name)))
+	}
+	if more := summary.Failed - len(names); more > 0 {
+		names = append(names, fmt.Sprintf("and %d more", more))
+	}
+	return strings.Join(names, ", ")
+}
+
 // createComment take a ProwJob and a list of entries generated with
-// createEntry and returns a nicely formatted comment. It may fail if template
-// execution fails.
-func createComment(reportTemplate *template.Template, pjs []prowapi.ProwJob, entries []string) (string, error) {
+// createEntry and returns a nicely formatted comment. If failureTemplate is
+// not nil, it renders the text above the table of failed tests. It may fail if
+// template execution fails.
+func createComment(reportTemplate, failureTemplate *template.Template, pjs []prowapi.ProwJob, entries []string) (string, error) {
 	if len(pjs) == 0 {
 		return "", nil
 	}
@@ -336,8 +423,16 @@ func createComment(reportTemplate *template.Template, pjs []prowapi.ProwJob, ent
 			return "", err
 		}
 	}
+	header := fmt.Sprintf("@%s: The following test%s **failed**, say `/retest` to rerun all failed tests or `/retest-required` to rerun all mandatory failed tests:", pjs[0].Spec.Refs.Pulls[0].Author, plural)
+	if failureTemplate != nil && len(entries) > 0 {
+		var h bytes.Buffer
+		if err := failureTemplate.Execute(&h, FailureCommentData{Author: pjs[0].Spec.Refs.Pulls[0].Author, Failed: len(entries), ProwJob: pjs[0]}); err != nil {
+			return "", err
+		}
+		header = h.String()
+	}
 	lines := []string{
-		fmt.Sprintf("@%s: The following test%s **failed**, say `/retest` to rerun all failed tests or `/retest-required` to rerun all mandatory failed tests:", pjs[0].Spec.Refs.Pulls[0].Author, plural),
+		header,
 		"",
 		"Test name | Commit | Details | Required | Rerun command",
 		"--- | --- | --- | --- | ---",
@@ -18,6 +18,7 @@ package report
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
 )
 
 func TestParseIssueComment(t *testing.T) {
@@ -144,6 +146,21 @@ func TestParseIssueComment(t *testing.T) {
 			expectedDeletes: []int{123, 124},
 			expectedEntries: []string{"bla test", "foo test"},
 		},
+		{
+			name:    "horizontal rules of the failure comment template are not entries",
+			context: "bla test",
+			state:   github.StatusSuccess,
+			ics: []github.IssueComment{
+				{
+					User: github.User{Login: "k8s-ci-robot"},
+					Body: "Some tests failed.\n---\nSee the runbook.\n\n--- | --- | ---\nfoo test | wow | aye\n\n" + CommentTag,
+					ID:   123,
+				},
+			},
+			expectedDeletes: []int{},
+			expectedEntries: []string{"foo test"},
+			expectedUpdate:  123,
+		},
 		{
 			name:    "should update an old comment when a test passes",
 			context: "bla test",
@@ -180,7 +197,7 @@ func TestParseIssueComment(t *testing.T) {
 			isBot := func(candidate string) bool {
 				return candidate == "k8s-ci-robot"
 			}
-			deletes, entries, update := parseIssueComments([]prowapi.ProwJob{pj}, isBot, tc.ics)
+			deletes, entries, update := parseIssueComments([]prowapi.ProwJob{pj}, nil, isBot, tc.ics)
 			if len(deletes) != len(tc.expectedDeletes) {
 				t.Errorf("It %q: wrong number of deletes. Got %v, expected %v", tc.name, deletes, tc.expectedDeletes)
 			} else {
@@ -224,6 +241,72 @@ func createReportEntry(context string, isRequired bool) string {
 	return fmt.Sprintf("%s |  | [link]() | %s | ", context, strconv.FormatBool(isRequired))
 }
 
+func TestCreateEntryFailureClassification(t *testing.T) {
+	pj := prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{kube.FailureClassificationAnnotation: "infra"},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Type:    prowapi.PostsubmitJob,
+			Context: "bla test",
+			Refs:    &prowapi.Refs{Pulls: []prowapi.Pull{{SHA: "abc"}}},
+		},
+		Status: prowapi.ProwJobStatus{URL: "https://prow.k8s.io/view/1"},
+	}
+	expected := "bla test | abc | [link](https://prow.k8s.io/view/1) (infra failure) | unknown | ``"
+	if entry := createEntry(pj, nil); entry != expected {
+		t.Errorf("expected entry %q, got %q", expected, entry)
+	}
+}
+
+func TestCreateEntryTestResults(t *testing.T) {
+	pj := prowapi.ProwJob{
+		Spec: prowapi.ProwJobSpec{
+			Type:    prowapi.PostsubmitJob,
+			Context: "bla test",
+			Refs:    &prowapi.Refs{Pulls: []prowapi.Pull{{SHA: "abc"}}},
+		},
+		Status: prowapi.ProwJobStatus{URL: "https://prow.k8s.io/view/1"},
+	}
+	testCases := []struct {
+		name     string
+		summary  *results.Summary
+		expected string
+	}{
+		{
+			name:     "no summary",
+			expected: "bla test | abc | [link](https://prow.k8s.io/view/1) | unknown | ``",
+		},
+		{
+			name:     "no failed tests",
+			summary:  &results.Summary{Total: 3, Passed: 3},
+			expected: "bla test | abc | [link](https://prow.k8s.io/view/1) | unknown | ``",
+		},
+		{
+			name: "failed tests are named",
+			summary: &results.Summary{Total: 3, Passed: 1, Failed: 2, Failures: []results.TestCase{
+				{Name: "TestFoo"},
+				{Name: "TestBar/a|b"},
+			}},
+			expected: "bla test | abc | [link](https://prow.k8s.io/view/1) (2 of 3 tests failed: `TestFoo`, `TestBar/a\\|b`) | unknown | ``",
+		},
+		{
+			name: "only the first failed tests are named",
+			summary: &results.Summary{Total: 10, Failed: 5, FailuresTruncated: true, Failures: []results.TestCase{
+				{Name: "TestA"}, {Name: "TestB"}, {Name: "TestC"}, {Name: "TestD"},
+			}},
+			expected: "bla test | abc | [link](https://prow.k8s.io/view/1) (5 of 10 tests failed: `TestA`, `TestB`, `TestC`, and 2 more) | unknown | ``",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if entry := createEntry(pj, tc.summary); entry != tc.expected {
+				t.Errorf("expected entry %q, got %q", tc.expected, entry)
+			}
+		})
+	}
+}
+
 type fakeGhClient struct {
 	status   []github.Status
 	comments []string
@@ -277,6 +360,7 @@ func TestReportStatus(t *testing.T) {
 		report           bool
 		desc             string // override default msg
 		pjType           prowapi.ProwJobType
+		template         *template.Template
 		expectedStatuses []string
 		expectedDesc     string
 	}{
@@ -329,6 +413,25 @@ func TestReportStatus(t *testing.T) {
 			desc:             shout(maxLen), // resulting string will exceed maxLen
 			expectedDesc:     config.ContextDescriptionWithBaseSha(shout(maxLen), ""),
 		},
+		{
+			name: "description is rendered with the template",
+
+			state:            prowapi.FailureState,
+			report:           true,
+			pjType:           prowapi.PresubmitJob,
+			template:         mustParseTemplate(t, "{{.Status.Description}} See go/runbook#{{.Spec.Job}}"),
+			expectedStatuses: []string{"failure"},
+			expectedDesc:     config.ContextDescriptionWithBaseSha(defMsg+" See go/runbook#job-name", ""),
+		},
+		{
+			name: "description falls back to the job's when the template fails",
+
+			state:            prowapi.FailureState,
+			report:           true,
+			pjType:           prowapi.PresubmitJob,
+			template:         mustParseTemplate(t, "{{(index .Spec.Refs.Pulls 5).Author}}"),
+			expectedStatuses: []string{"failure"},
+		},
 		{
 			name: "Successful postsubmit job with report true should set success status",
 
@@ -375,7 +478,7 @@ func TestReportStatus(t *testing.T) {
 				},
 			}
 			// Run
-			if err := reportStatus(context.Background(), ghc, pj); err != nil {
+			if err := reportStatus(context.Background(), ghc, pj, tc.template); err != nil {
 				t.Error(err)
 			}
 			// Check
@@ -458,12 +561,13 @@ func TestShouldReport(t *testing.T) {
 
 func TestCreateComment(t *testing.T) {
 	tests := []struct {
-		name     string
-		template *template.Template
-		pjs      []prowapi.ProwJob
-		entries  []string
-		want     string
-		wantErr  bool
+		name            string
+		template        *template.Template
+		failureTemplate *template.Template
+		pjs             []prowapi.ProwJob
+		entries         []string
+		want            string
+		wantErr         bool
 	}{
 		{
 			name:     "single-job-single-failure",
@@ -573,6 +677,66 @@ aaa | bbb | ccc | ddd | eee
 
 job-a
 
+<details>
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository. I understand the commands that are listed [here](https://go.k8s.io/bot-commands).
+</details>
+<!-- test report -->`,
+		},
+		{
+			name:            "failure-template",
+			failureTemplate: mustParseTemplate(t, "@{{.Author}}: {{.Failed}} test(s) of {{.ProwJob.Spec.Job}} failed, see the [runbook](https://runbook.example.com) and ping @team."),
+			pjs: []prowapi.ProwJob{
+				{
+					Spec: prowapi.ProwJobSpec{
+						Job: "job-a",
+						Refs: &prowapi.Refs{
+							Pulls: []prowapi.Pull{
+								{
+									Author: "chaodaig",
+								},
+							},
+						},
+					},
+				},
+			},
+			entries: []string{
+				"aaa | bbb | ccc | ddd | eee",
+				"fff | ggg | hhh | iii | jjj",
+			},
+			want: `@chaodaig: 2 test(s) of job-a failed, see the [runbook](https://runbook.example.com) and ping @team.
+
+Test name | Commit | Details | Required | Rerun command
+--- | --- | --- | --- | ---
+aaa | bbb | ccc | ddd | eee
+fff | ggg | hhh | iii | jjj
+
+<details>
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository. I understand the commands that are listed [here](https://go.k8s.io/bot-commands).
+</details>
+<!-- test report -->`,
+		},
+		{
+			name:            "failure-template-not-used-when-all-passed",
+			failureTemplate: mustParseTemplate(t, "{{.Failed}} failed"),
+			pjs: []prowapi.ProwJob{
+				{
+					Spec: prowapi.ProwJobSpec{
+						Job: "job-a",
+						Refs: &prowapi.Refs{
+							Pulls: []prowapi.Pull{
+								{
+									Author: "chaodaig",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: `@chaodaig: all tests **passed!**
+
+
 <details>
 
 Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes-sigs/prow](https://github.com/kubernetes-sigs/prow/issues/new?title=Prow%20issue:) repository. I understand the commands that are listed [here](https://go.k8s.io/bot-commands).
@@ -621,7 +785,7 @@ Instructions for interacting with me using PR comments are available [here](http
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			gotComment, gotErr := createComment(tc.template, tc.pjs, tc.entries)
+			gotComment, gotErr := createComment(tc.template, tc.failureTemplate, tc.pjs, tc.entries)
 			if diff := cmp.Diff(gotComment, tc.want); diff != "" {
 				t.Fatalf("comment mismatch:\n%s", diff)
 			}
@@ -647,7 +811,9 @@ func TestReportComment(t *testing.T) {
 		pjs             []prowapi.ProwJob
 		reporterConfig  config.GitHubReporter
 		mustCreate      bool
+		getResults      ResultsGetter
 		expectedComment bool
+		expectedInBody  string
 	}{
 		{
 			name: "failed pj",
@@ -710,11 +876,58 @@ func TestReportComment(t *testing.T) {
 			},
 			mustCreate: true,
 		},
+		{
+			name: "failed pj names its failed tests",
+			pjs: []prowapi.ProwJob{{
+				Spec: prowapi.ProwJobSpec{
+					Type:   prowapi.PresubmitJob,
+					Report: true,
+					Refs: &prowapi.Refs{
+						Pulls: []prowapi.Pull{{}},
+					},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:          prowapi.FailureState,
+					CompletionTime: &metav1.Time{},
+				}},
+			},
+			reporterConfig: config.GitHubReporter{
+				JobTypesToReport: []prowapi.ProwJobType{prowapi.PresubmitJob},
+			},
+			getResults: func(_ context.Context, _ prowapi.ProwJob) (*results.Summary, error) {
+				return &results.Summary{Total: 2, Passed: 1, Failed: 1, Failures: []results.TestCase{{Name: "TestFoo"}}}, nil
+			},
+			expectedComment: true,
+			expectedInBody:  "(1 of 2 tests failed: `TestFoo`)",
+		},
+		{
+			name: "failed pj is reported when its results can't be read",
+			pjs: []prowapi.ProwJob{{
+				Spec: prowapi.ProwJobSpec{
+					Type:   prowapi.PresubmitJob,
+					Report: true,
+					Refs: &prowapi.Refs{
+						Pulls: []prowapi.Pull{{}},
+					},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:          prowapi.FailureState,
+					CompletionTime: &metav1.Time{},
+				}},
+			},
+			reporterConfig: config.GitHubReporter{
+				JobTypesToReport: []prowapi.ProwJobType{prowapi.PresubmitJob},
+			},
+			getResults: func(_ context.Context, _ prowapi.ProwJob) (*results.Summary, error) {
+				return nil, errors.New("injected error")
+			},
+			expectedComment: true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			fghc := &fakeGhClient{}
-			err := ReportComment(context.Background(), fghc, nil, tc.pjs, tc.reporterConfig, tc.mustCreate)
+			err := ReportComment(context.Background(), fghc, nil, tc.pjs, tc.reporterConfig, tc.mustCreate, tc.getResults)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -722,6 +935,9 @@ func TestReportComment(t *testing.T) {
 			if diff := cmp.Diff(tc.expectedComment, len(fghc.comments) == 1); diff != "" {
 				t.Fatalf("expectedComment didn't match result, diff: %s", diff)
 			}
+			if tc.expectedInBody != "" && !strings.Contains(fghc.comments[0], tc.expectedInBody) {
+				t.Errorf("expected comment to contain %q, got %q", tc.expectedInBody, fghc.comments[0])
+			}
 		})
 	}
 }
@@ -144,6 +144,40 @@ type CombinedStatus struct {
 	State    string   `json:"state"`
 }
 
+// Deployment is a request to deploy a ref to an environment.
+//
+// See https://docs.github.com/en/rest/deployments/deployments
+type Deployment struct {
+	ID          int       `json:"id"`
+	SHA         string    `json:"sha"`
+	Ref         string    `json:"ref"`
+	Task        string    `json:"task"`
+	Environment string    `json:"environment"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// These are possible State entries for a DeploymentStatus.
+const (
+	DeploymentStateError      = "error"
+	DeploymentStateFailure    = "failure"
+	DeploymentStateInactive   = "inactive"
+	DeploymentStateInProgress = "in_progress"
+	DeploymentStateQueued     = "queued"
+	DeploymentStatePending    = "pending"
+	DeploymentStateSuccess    = "success"
+)
+
+// DeploymentStatus is the state of a Deployment at some point in time.
+type DeploymentStatus struct {
+	ID             int       `json:"id"`
+	State          string    `json:"state"`
+	Description    string    `json:"description,omitempty"`
+	Environment    string    `json:"environment,omitempty"`
+	EnvironmentURL string    `json:"environment_url,omitempty"`
+	LogURL         string    `json:"log_url,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 // User is a GitHub user account.
 type User struct {
 	Login       string          `json:"login"`
@@ -226,6 +260,10 @@ type PullRequestEvent struct {
 	Repo        Repo                   `json:"repository"`
 	Label       Label                  `json:"label"`
 	Sender      User                   `json:"sender"`
+	// Before and After are the head SHAs before and after the push that
+	// triggered a synchronize event.
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
 
 	// Changes holds raw change data, which we must inspect
 	// and deserialize later as this is a polymorphic field
@@ -557,6 +595,7 @@ type BranchProtection struct {
 	AllowForcePushes           AllowForcePushes            `json:"allow_force_pushes"`
 	RequiredLinearHistory      RequiredLinearHistory       `json:"required_linear_history"`
 	AllowDeletions             AllowDeletions              `json:"allow_deletions"`
+	RequiredSignatures         RequiredSignatures          `json:"required_signatures"`
 }
 
 // AllowDeletions specifies whether to permit users with push access to delete matching branches.
@@ -574,6 +613,11 @@ type AllowForcePushes struct {
 	Enabled bool `json:"enabled"`
 }
 
+// RequiredSignatures specifies whether commits pushed to matching branches must have verified signatures.
+type RequiredSignatures struct {
+	Enabled bool `json:"enabled"`
+}
+
 // EnforceAdmins specifies whether to enforce the
 // configured branch restrictions for administrators.
 type EnforceAdmins struct {
@@ -619,6 +663,9 @@ type BranchProtectionRequest struct {
 	RequiredLinearHistory      bool                               `json:"required_linear_history"`
 	AllowForcePushes           bool                               `json:"allow_force_pushes"`
 	AllowDeletions             bool                               `json:"allow_deletions"`
+	// RequiredSignatures is not part of the request body, UpdateBranchProtection
+	// configures it with a separate call.
+	RequiredSignatures bool `json:"-"`
 }
 
 func (r BranchProtectionRequest) String() string {
@@ -681,6 +728,58 @@ type RestrictionsRequest struct {
 	Teams *[]string `json:"teams,omitempty"`
 }
 
+// Ruleset enforcement levels.
+const (
+	RulesetEnforcementActive   = "active"
+	RulesetEnforcementEvaluate = "evaluate"
+	RulesetEnforcementDisabled = "disabled"
+)
+
+// Types of ruleset rules.
+const (
+	RulesetRuleRequiredDeployments = "required_deployments"
+	RulesetRuleRequiredSignatures  = "required_signatures"
+)
+
+// Ruleset is a set of rules that apply to the refs of a repository, which can
+// express protections that branch protection can't.
+// See also: https://docs.github.com/en/rest/repos/rules#get-a-repository-ruleset
+type Ruleset struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Target      string `json:"target,omitempty"`
+	Enforcement string `json:"enforcement"`
+	// Conditions and Rules are not included when listing rulesets.
+	Conditions *RulesetConditions `json:"conditions,omitempty"`
+	Rules      []RulesetRule      `json:"rules"`
+}
+
+// RulesetConditions specifies the refs a ruleset applies to.
+type RulesetConditions struct {
+	RefName RulesetRefNameCondition `json:"ref_name"`
+}
+
+// RulesetRefNameCondition holds the patterns of the refs a ruleset applies to,
+// like refs/heads/main, or ~DEFAULT_BRANCH and ~ALL.
+type RulesetRefNameCondition struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// RulesetRule is a rule of a ruleset.
+type RulesetRule struct {
+	Type       string                 `json:"type"`
+	Parameters *RulesetRuleParameters `json:"parameters,omitempty"`
+}
+
+// RulesetRuleParameters holds the parameters of rules that have any.
+type RulesetRuleParameters struct {
+	// RequiredDeploymentEnvironments are the environments that must be
+	// deployed to successfully before refs can be updated, for
+	// required_deployments rules.
+	RequiredDeploymentEnvironments []string `json:"required_deployment_environments,omitempty"`
+}
+
 // HookConfig holds the endpoint and its secret.
 type HookConfig struct {
 	URL         string  `json:"url"`
@@ -762,10 +861,90 @@ type IssueEvent struct {
 	Label  Label `json:"label"`
 	Sender User  `json:"sender"`
 
+	// Changes is specified for IssueActionTransferred events.
+	Changes *IssueChanges `json:"changes,omitempty"`
+
+	// GUID is included in the header of the request received by GitHub.
+	GUID string
+}
+
+// IssueChanges holds the issue and repository an issue was transferred to.
+type IssueChanges struct {
+	NewIssue *Issue `json:"new_issue,omitempty"`
+	NewRepo  *Repo  `json:"new_repository,omitempty"`
+}
+
+// ProjectV2ItemEventAction enumerates the triggers for this
+// webhook payload type. See also:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#projects_v2_item
+type ProjectV2ItemEventAction string
+
+const (
+	// ProjectV2ItemActionCreated means an item was added to a project.
+	ProjectV2ItemActionCreated ProjectV2ItemEventAction = "created"
+	// ProjectV2ItemActionEdited means a field value of an item was changed.
+	ProjectV2ItemActionEdited ProjectV2ItemEventAction = "edited"
+	// ProjectV2ItemActionDeleted means an item was removed from a project.
+	ProjectV2ItemActionDeleted ProjectV2ItemEventAction = "deleted"
+	// ProjectV2ItemActionArchived means an item was archived.
+	ProjectV2ItemActionArchived ProjectV2ItemEventAction = "archived"
+	// ProjectV2ItemActionRestored means an archived item was restored.
+	ProjectV2ItemActionRestored ProjectV2ItemEventAction = "restored"
+	// ProjectV2ItemActionConverted means a draft issue was converted to an issue.
+	ProjectV2ItemActionConverted ProjectV2ItemEventAction = "converted"
+	// ProjectV2ItemActionReordered means an item was moved within a project.
+	ProjectV2ItemActionReordered ProjectV2ItemEventAction = "reordered"
+)
+
+// ProjectV2ItemContentType is the type of the content an item of a project refers to.
+type ProjectV2ItemContentType string
+
+const (
+	ProjectV2ItemContentIssue       ProjectV2ItemContentType = "Issue"
+	ProjectV2ItemContentPullRequest ProjectV2ItemContentType = "PullRequest"
+	ProjectV2ItemContentDraftIssue  ProjectV2ItemContentType = "DraftIssue"
+)
+
+// ProjectV2ItemEvent is what GitHub sends us when an item of a project
+// of an organization is changed. Projects belong to organizations rather
+// than repositories, so the event does not carry a repository.
+type ProjectV2ItemEvent struct {
+	Action  ProjectV2ItemEventAction `json:"action"`
+	Item    ProjectV2Item            `json:"projects_v2_item"`
+	Org     Organization             `json:"organization"`
+	Sender  User                     `json:"sender"`
+	Changes *ProjectV2ItemChanges    `json:"changes,omitempty"`
+
 	// GUID is included in the header of the request received by GitHub.
 	GUID string
 }
 
+// ProjectV2Item is an item of a project, i.e. an issue, a pull request or
+// a draft issue.
+type ProjectV2Item struct {
+	ID            int64                    `json:"id"`
+	NodeID        string                   `json:"node_id"`
+	ProjectNodeID string                   `json:"project_node_id"`
+	ContentNodeID string                   `json:"content_node_id"`
+	ContentType   ProjectV2ItemContentType `json:"content_type"`
+	Creator       User                     `json:"creator"`
+	CreatedAt     time.Time                `json:"created_at"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+	ArchivedAt    *time.Time               `json:"archived_at,omitempty"`
+}
+
+// ProjectV2ItemChanges describes the change of an item of a project.
+type ProjectV2ItemChanges struct {
+	// FieldValue is specified for ProjectV2ItemActionEdited events.
+	FieldValue *ProjectV2ItemFieldValueChange `json:"field_value,omitempty"`
+}
+
+// ProjectV2ItemFieldValueChange identifies the field of an item that was edited.
+type ProjectV2ItemFieldValueChange struct {
+	FieldNodeID string `json:"field_node_id"`
+	FieldType   string `json:"field_type"`
+}
+
 // ListedIssueEvent represents an issue event from the events API (not from a webhook payload).
 // https://developer.github.com/v3/issues/events/
 type ListedIssueEvent struct {
@@ -831,11 +1010,24 @@ type IssueCommentEvent struct {
 	Issue   Issue                   `json:"issue"`
 	Comment IssueComment            `json:"comment"`
 	Repo    Repo                    `json:"repository"`
+	// Changes holds the previous body of edited comments.
+	Changes *IssueCommentChanges `json:"changes,omitempty"`
 
 	// GUID is included in the header of the request received by GitHub.
 	GUID string
 }
 
+// IssueCommentChanges holds the previous values of the fields changed by an
+// edit of a comment.
+type IssueCommentChanges struct {
+	Body *ChangedFrom `json:"body,omitempty"`
+}
+
+// ChangedFrom holds the previous value of an edited field.
+type ChangedFrom struct {
+	From string `json:"from"`
+}
+
 // Issue represents general info about an issue.
 type Issue struct {
 	ID          int       `json:"id"`
@@ -1128,6 +1320,106 @@ type TeamMember struct {
 	Login string `json:"login"`
 }
 
+// OrgRole is a predefined or custom role that grants permissions in an org
+// to the teams and users it is assigned to.
+//
+// See https://docs.github.com/en/rest/orgs/organization-roles
+type OrgRole struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BaseRole    string   `json:"base_role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// OrgRoleAssignment describes how a team or user got an org role.
+type OrgRoleAssignment string
+
+const (
+	// OrgRoleAssignmentDirect means the role was assigned to the team or user.
+	OrgRoleAssignmentDirect OrgRoleAssignment = "direct"
+	// OrgRoleAssignmentIndirect means the role is inherited from a team.
+	OrgRoleAssignmentIndirect OrgRoleAssignment = "indirect"
+	// OrgRoleAssignmentMixed means the role was assigned and is also inherited.
+	OrgRoleAssignmentMixed OrgRoleAssignment = "mixed"
+)
+
+// OrgRoleTeam is a team an org role is assigned to.
+type OrgRoleTeam struct {
+	Team
+	Assignment OrgRoleAssignment `json:"assignment,omitempty"`
+}
+
+// OrgRoleUser is a user an org role is assigned to.
+type OrgRoleUser struct {
+	Login      string            `json:"login"`
+	Assignment OrgRoleAssignment `json:"assignment,omitempty"`
+}
+
+// AllowedActions is the policy for the GitHub Actions that may run in an org.
+type AllowedActions string
+
+const (
+	// AllowedActionsAll allows all actions.
+	AllowedActionsAll AllowedActions = "all"
+	// AllowedActionsLocalOnly allows the actions defined in the org only.
+	AllowedActionsLocalOnly AllowedActions = "local_only"
+	// AllowedActionsSelected allows the actions defined in the org and the
+	// SelectedActions.
+	AllowedActionsSelected AllowedActions = "selected"
+)
+
+// ActionsPermissions are the GitHub Actions permissions of an org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#get-github-actions-permissions-for-an-organization
+type ActionsPermissions struct {
+	EnabledRepositories string         `json:"enabled_repositories"`
+	AllowedActions      AllowedActions `json:"allowed_actions,omitempty"`
+}
+
+// SelectedActions are the actions that are allowed in an org whose
+// AllowedActions are AllowedActionsSelected.
+//
+// See https://docs.github.com/en/rest/actions/permissions#get-allowed-actions-and-reusable-workflows-for-an-organization
+type SelectedActions struct {
+	GitHubOwnedAllowed bool     `json:"github_owned_allowed"`
+	VerifiedAllowed    bool     `json:"verified_allowed"`
+	PatternsAllowed    []string `json:"patterns_allowed"`
+}
+
+// WorkflowPermission is the default permission granted to the GITHUB_TOKEN
+// of workflows.
+type WorkflowPermission string
+
+const (
+	WorkflowPermissionRead  WorkflowPermission = "read"
+	WorkflowPermissionWrite WorkflowPermission = "write"
+)
+
+// WorkflowPermissions are the permissions of the workflows in an org.
+//
+// See https://docs.github.com/en/rest/actions/permissions#get-default-workflow-permissions-for-an-organization
+type WorkflowPermissions struct {
+	DefaultWorkflowPermissions   WorkflowPermission `json:"default_workflow_permissions"`
+	CanApprovePullRequestReviews bool               `json:"can_approve_pull_request_reviews"`
+}
+
+// ForkPRApprovalPolicy decides which contributors need an approval before
+// workflows run on their pull requests from forks.
+type ForkPRApprovalPolicy string
+
+const (
+	// ForkPRApprovalFirstTimeContributorsNewToGitHub requires an approval
+	// for first-time contributors who recently created their account.
+	ForkPRApprovalFirstTimeContributorsNewToGitHub ForkPRApprovalPolicy = "first_time_contributors_new_to_github"
+	// ForkPRApprovalFirstTimeContributors requires an approval for all
+	// first-time contributors.
+	ForkPRApprovalFirstTimeContributors ForkPRApprovalPolicy = "first_time_contributors"
+	// ForkPRApprovalAllExternalContributors requires an approval for all
+	// contributors that are not collaborators.
+	ForkPRApprovalAllExternalContributors ForkPRApprovalPolicy = "all_external_contributors"
+)
+
 const (
 	// RoleAll lists both members and admins
 	RoleAll = "all"
@@ -1312,6 +1604,9 @@ type GenericCommentEvent struct {
 	IssueBody    string
 	IssueHTMLURL string
 	GUID         string
+	// PreviousBody is the body before the edit for edited issue comments, if
+	// GitHub sent it.
+	PreviousBody string
 }
 
 // Milestone is a milestone defined on a github repository
@@ -1342,6 +1637,33 @@ type RepositoryCommit struct {
 	Files []CommitFile `json:"files,omitempty"`
 }
 
+// ComparisonStatus is the status of the head commit relative to the base
+// commit of a CommitsComparison.
+type ComparisonStatus string
+
+const (
+	// ComparisonStatusIdentical means the head and base commits are the same.
+	ComparisonStatusIdentical ComparisonStatus = "identical"
+	// ComparisonStatusAhead means the head commit descends from the base commit.
+	ComparisonStatusAhead ComparisonStatus = "ahead"
+	// ComparisonStatusBehind means the base commit descends from the head commit.
+	ComparisonStatusBehind ComparisonStatus = "behind"
+	// ComparisonStatusDiverged means neither commit descends from the other.
+	ComparisonStatusDiverged ComparisonStatus = "diverged"
+)
+
+// CommitsComparison is the comparison of a head commit to a base commit.
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+type CommitsComparison struct {
+	Status       ComparisonStatus   `json:"status"`
+	AheadBy      int                `json:"ahead_by"`
+	BehindBy     int                `json:"behind_by"`
+	TotalCommits int                `json:"total_commits"`
+	Commits      []RepositoryCommit `json:"commits"`
+	Files        []CommitFile       `json:"files,omitempty"`
+	HTMLURL      string             `json:"html_url"`
+}
+
 // CommitStats represents the number of additions / deletions from a file in a given RepositoryCommit or GistCommit.
 type CommitStats struct {
 	Additions int `json:"additions,omitempty"`
@@ -1612,6 +1934,7 @@ type WorkflowRun struct {
 	CheckSuiteID     int64         `json:"check_suite_id"`
 	CheckSuiteNodeID string        `json:"check_suite_node_id"`
 	URL              string        `json:"url"`
+	HTMLURL          string        `json:"html_url"`
 	PullRequests     []PullRequest `json:"pull_requests"`
 	CreatedAt        time.Time     `json:"created_at"`
 	UpdatedAt        time.Time     `json:"updated_at"`
@@ -17,6 +17,7 @@ limitations under the License.
 package github
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -107,3 +108,63 @@ func TestUnmarshalClientError(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalIssueTransferredEvent(t *testing.T) {
+	payload := `{
+  "action": "transferred",
+  "issue": {"number": 1},
+  "repository": {"full_name": "org/old"},
+  "changes": {
+    "new_issue": {"number": 42},
+    "new_repository": {"full_name": "org/new"}
+  }
+}`
+	var ie IssueEvent
+	if err := json.Unmarshal([]byte(payload), &ie); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+	if ie.Action != IssueActionTransferred {
+		t.Errorf("expected action %q, got %q", IssueActionTransferred, ie.Action)
+	}
+	if ie.Changes == nil || ie.Changes.NewIssue == nil || ie.Changes.NewRepo == nil {
+		t.Fatalf("expected the new issue and repository to be set, got %+v", ie.Changes)
+	}
+	if ie.Changes.NewIssue.Number != 42 || ie.Changes.NewRepo.FullName != "org/new" {
+		t.Errorf("expected the issue to be transferred to org/new#42, got %s#%d", ie.Changes.NewRepo.FullName, ie.Changes.NewIssue.Number)
+	}
+}
+
+func TestUnmarshalProjectV2ItemEvent(t *testing.T) {
+	payload := `{
+  "action": "edited",
+  "projects_v2_item": {
+    "id": 123,
+    "node_id": "PVTI_1",
+    "project_node_id": "PVT_1",
+    "content_node_id": "I_1",
+    "content_type": "Issue",
+    "creator": {"login": "alice"},
+    "created_at": "2026-01-02T15:04:05Z",
+    "updated_at": "2026-01-03T15:04:05Z",
+    "archived_at": null
+  },
+  "changes": {
+    "field_value": {"field_node_id": "PVTSSF_1", "field_type": "single_select"}
+  },
+  "organization": {"login": "org"},
+  "sender": {"login": "bob"}
+}`
+	var pe ProjectV2ItemEvent
+	if err := json.Unmarshal([]byte(payload), &pe); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+	if pe.Action != ProjectV2ItemActionEdited || pe.Org.Login != "org" || pe.Sender.Login != "bob" {
+		t.Errorf("unexpected event: %+v", pe)
+	}
+	if pe.Item.ProjectNodeID != "PVT_1" || pe.Item.ContentNodeID != "I_1" || pe.Item.ContentType != ProjectV2ItemContentIssue || pe.Item.ArchivedAt != nil {
+		t.Errorf("unexpected item: %+v", pe.Item)
+	}
+	if pe.Changes == nil || pe.Changes.FieldValue == nil || pe.Changes.FieldValue.FieldNodeID != "PVTSSF_1" {
+		t.Errorf("expected the edited field to be set, got %+v", pe.Changes)
+	}
+}
@@ -49,6 +49,7 @@ const (
 	issuesEvent                   = "issues"
 	workflowRunEvent              = "workflow_run"
 	registryPackageEvent          = "registry_package"
+	projectV2ItemEvent            = "projects_v2_item"
 )
 
 // GitHubEventServer hold all the information needed for the
@@ -123,6 +124,9 @@ type WorkflowRunEventHandler func(*logrus.Entry, github.WorkflowRunEvent)
 // RegistryPackageEventHandler is a type of function that handles GitHub's registry package events.
 type RegistryPackageEventHandler func(*logrus.Entry, github.RegistryPackageEvent)
 
+// ProjectV2ItemEventHandler is a type of function that handles GitHub's project item events.
+type ProjectV2ItemEventHandler func(*logrus.Entry, github.ProjectV2ItemEvent)
+
 // RegisterReviewCommentEventHandler registers an ReviewCommentEventHandler function in GitHubEventServerOptions
 func (g *GitHubEventServer) RegisterReviewCommentEventHandler(fn ReviewCommentEventHandler) {
 	g.serveMuxHandler.reviewCommentEventHandlers = append(g.serveMuxHandler.reviewCommentEventHandlers, fn)
@@ -168,6 +172,11 @@ func (g *GitHubEventServer) RegisterRegistryPackageEventHandler(fn RegistryPacka
 	g.serveMuxHandler.registryPackageEventHandlers = append(g.serveMuxHandler.registryPackageEventHandlers, fn)
 }
 
+// RegisterProjectV2ItemEventHandler registers a ProjectV2ItemEventHandler function in GitHubEventServerOptions
+func (g *GitHubEventServer) RegisterProjectV2ItemEventHandler(fn ProjectV2ItemEventHandler) {
+	g.serveMuxHandler.projectV2ItemEventHandlers = append(g.serveMuxHandler.projectV2ItemEventHandlers, fn)
+}
+
 // RegisterExternalPlugins registers the external plugins in GitHubEventServerOptions
 func (g *GitHubEventServer) RegisterExternalPlugins(p map[string][]plugins.ExternalPlugin) {
 	g.serveMuxHandler.externalPlugins = p
@@ -211,6 +220,8 @@ type serveMuxHandler struct {
 	workflowRunEventHandler      []WorkflowRunEventHandler
 	registryPackageEventHandlers []RegistryPackageEventHandler
 
+	projectV2ItemEventHandlers []ProjectV2ItemEventHandler
+
 	externalPlugins map[string][]plugins.ExternalPlugin
 
 	hmacTokenGenerator func() []byte
@@ -447,6 +458,27 @@ func (s *serveMuxHandler) handleEvent(eventType, eventGUID string, payload []byt
 			}()
 		}
 
+	case projectV2ItemEvent:
+		var pe github.ProjectV2ItemEvent
+		if err := json.Unmarshal(payload, &pe); err != nil {
+			return err
+		}
+		pe.GUID = eventGUID
+		org = pe.Org.Login
+
+		for _, projectV2ItemEventHandler := range s.projectV2ItemEventHandlers {
+			fn := projectV2ItemEventHandler
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				fn(l.WithFields(logrus.Fields{
+					github.OrgLogField: pe.Org.Login,
+					"project":          pe.Item.ProjectNodeID,
+					"item":             pe.Item.NodeID,
+				}), pe)
+			}()
+		}
+
 	default:
 		l.Debug("Ignoring unhandled event type.")
 	}
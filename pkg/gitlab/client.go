@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitlab implements the small part of the GitLab API that Prow needs
+// to report the status of jobs and to open merge requests.
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultAPIEndpoint is the API endpoint of gitlab.com.
+const DefaultAPIEndpoint = "https://gitlab.com/api/v4"
+
+// These are the possible states of a commit status.
+// https://docs.gitlab.com/ee/api/commits.html#set-the-pipeline-status-of-a-commit
+const (
+	StatusPending  = "pending"
+	StatusRunning  = "running"
+	StatusSuccess  = "success"
+	StatusFailed   = "failed"
+	StatusCanceled = "canceled"
+)
+
+// CommitStatus is the status of an external job on a commit.
+type CommitStatus struct {
+	State string `json:"state"`
+	// Ref is the branch or tag the commit belongs to.
+	Ref string `json:"ref,omitempty"`
+	// Name distinguishes the status from the statuses of other jobs.
+	Name        string `json:"name,omitempty"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Project is a GitLab project.
+type Project struct {
+	ID                int    `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+}
+
+// MergeRequest is a GitLab merge request.
+type MergeRequest struct {
+	// IID is the number of the merge request in its project.
+	IID          int      `json:"iid"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	State        string   `json:"state"`
+	SourceBranch string   `json:"source_branch"`
+	TargetBranch string   `json:"target_branch"`
+	Labels       []string `json:"labels"`
+	WebURL       string   `json:"web_url"`
+}
+
+// MergeRequestOptions are the fields of a merge request that are set when
+// creating or updating it.
+type MergeRequestOptions struct {
+	SourceBranch string `json:"source_branch,omitempty"`
+	TargetBranch string `json:"target_branch,omitempty"`
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	// Labels is a comma separated list of labels.
+	Labels string `json:"labels,omitempty"`
+}
+
+// RequestError is returned when GitLab rejects a request.
+type RequestError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("status code %d: %s", e.StatusCode, e.Message)
+}
+
+// Client interacts with the GitLab API.
+type Client interface {
+	// SetCommitStatus sets the status of the commit sha of project, which is
+	// the full path of the repo, e.g. group/subgroup/repo.
+	SetCommitStatus(ctx context.Context, project, sha string, status CommitStatus) error
+	// GetProject gets the project, which is the full path of the repo.
+	GetProject(ctx context.Context, project string) (*Project, error)
+	// ListOpenMergeRequests lists the open merge requests of the project
+	// from the source branch to the target branch.
+	ListOpenMergeRequests(ctx context.Context, project, sourceBranch, targetBranch string) ([]MergeRequest, error)
+	// CreateMergeRequest creates a merge request in the project.
+	CreateMergeRequest(ctx context.Context, project string, opts MergeRequestOptions) (*MergeRequest, error)
+	// UpdateMergeRequest updates the merge request iid of the project.
+	UpdateMergeRequest(ctx context.Context, project string, iid int, opts MergeRequestOptions) (*MergeRequest, error)
+}
+
+type client struct {
+	logger         *logrus.Entry
+	endpoint       string
+	tokenGenerator func() []byte
+	httpClient     *http.Client
+}
+
+// NewClient creates a GitLab client that authenticates with the personal,
+// project or group access token returned by tokenGenerator. If endpoint is
+// empty, DefaultAPIEndpoint is used.
+func NewClient(endpoint string, tokenGenerator func() []byte) Client {
+	if endpoint == "" {
+		endpoint = DefaultAPIEndpoint
+	}
+	return &client{
+		logger:         logrus.WithField("client", "gitlab"),
+		endpoint:       strings.TrimSuffix(endpoint, "/"),
+		tokenGenerator: tokenGenerator,
+		httpClient:     &http.Client{Timeout: time.Minute},
+	}
+}
+
+// SetCommitStatus sets the status of a commit.
+func (c *client) SetCommitStatus(ctx context.Context, project, sha string, status CommitStatus) error {
+	c.logger.Debugf("SetCommitStatus(%s, %s, %+v)", project, sha, status)
+	body, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal commit status: %w", err)
+	}
+	path := fmt.Sprintf("/projects/%s/statuses/%s", url.PathEscape(project), url.PathEscape(sha))
+	return c.request(ctx, http.MethodPost, path, body, nil)
+}
+
+// GetProject gets a project.
+func (c *client) GetProject(ctx context.Context, project string) (*Project, error) {
+	c.logger.Debugf("GetProject(%s)", project)
+	var result Project
+	if err := c.request(ctx, http.MethodGet, "/projects/"+url.PathEscape(project), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListOpenMergeRequests lists the open merge requests between two branches.
+func (c *client) ListOpenMergeRequests(ctx context.Context, project, sourceBranch, targetBranch string) ([]MergeRequest, error) {
+	c.logger.Debugf("ListOpenMergeRequests(%s, %s, %s)", project, sourceBranch, targetBranch)
+	query := url.Values{
+		"state":         []string{"opened"},
+		"source_branch": []string{sourceBranch},
+		"target_branch": []string{targetBranch},
+	}
+	path := fmt.Sprintf("/projects/%s/merge_requests?%s", url.PathEscape(project), query.Encode())
+	var result []MergeRequest
+	if err := c.request(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateMergeRequest creates a merge request.
+func (c *client) CreateMergeRequest(ctx context.Context, project string, opts MergeRequestOptions) (*MergeRequest, error) {
+	c.logger.Debugf("CreateMergeRequest(%s, %+v)", project, opts)
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merge request: %w", err)
+	}
+	var result MergeRequest
+	if err := c.request(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/merge_requests", url.PathEscape(project)), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateMergeRequest updates a merge request.
+func (c *client) UpdateMergeRequest(ctx context.Context, project string, iid int, opts MergeRequestOptions) (*MergeRequest, error) {
+	c.logger.Debugf("UpdateMergeRequest(%s, %d, %+v)", project, iid, opts)
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merge request: %w", err)
+	}
+	var result MergeRequest
+	if err := c.request(ctx, http.MethodPut, fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(project), iid), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// request sends the request and decodes the response into out, unless it
+// is nil.
+func (c *client) request(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", string(c.tokenGenerator()))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if out == nil {
+			return nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	apiResponse := struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+	}{}
+	message := string(respBody)
+	if err := json.Unmarshal(respBody, &apiResponse); err == nil {
+		// The message is a string for most errors, but an object for
+		// validation errors.
+		var s string
+		switch {
+		case json.Unmarshal(apiResponse.Message, &s) == nil:
+			message = s
+		case len(apiResponse.Message) > 0:
+			message = string(apiResponse.Message)
+		case apiResponse.Error != "":
+			message = apiResponse.Error
+		}
+	}
+	return &RequestError{StatusCode: resp.StatusCode, Message: message}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSetCommitStatus(t *testing.T) {
+	status := CommitStatus{
+		State:       StatusRunning,
+		Ref:         "main",
+		Name:        "pull-unit",
+		TargetURL:   "https://prow.example.com/view/1",
+		Description: "Job triggered.",
+	}
+
+	testCases := []struct {
+		name          string
+		code          int
+		response      string
+		expectedError *RequestError
+	}{
+		{
+			name: "status is set",
+			code: http.StatusCreated,
+		},
+		{
+			name:          "transition is rejected",
+			code:          http.StatusBadRequest,
+			response:      `{"message":"Cannot transition status via :run from :running"}`,
+			expectedError: &RequestError{StatusCode: http.StatusBadRequest, Message: "Cannot transition status via :run from :running"},
+		},
+		{
+			name:          "validation error",
+			code:          http.StatusBadRequest,
+			response:      `{"message":{"name":["is too long"]}}`,
+			expectedError: &RequestError{StatusCode: http.StatusBadRequest, Message: `{"name":["is too long"]}`},
+		},
+		{
+			name:          "error without json body",
+			code:          http.StatusBadGateway,
+			response:      "bad gateway",
+			expectedError: &RequestError{StatusCode: http.StatusBadGateway, Message: "bad gateway"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("expected a POST request, got %s", r.Method)
+				}
+				if expected := "/api/v4/projects/group%2Fsubgroup%2Frepo/statuses/abcdef"; r.URL.EscapedPath() != expected {
+					t.Errorf("expected path %s, got %s", expected, r.URL.EscapedPath())
+				}
+				if token := r.Header.Get("PRIVATE-TOKEN"); token != "secret" {
+					t.Errorf("expected token secret, got %q", token)
+				}
+				var got CommitStatus
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+				if diff := cmp.Diff(status, got); diff != "" {
+					t.Errorf("unexpected commit status (-want +got):\n%s", diff)
+				}
+				w.WriteHeader(tc.code)
+				w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			c := NewClient(server.URL+"/api/v4/", func() []byte { return []byte("secret") })
+			err := c.SetCommitStatus(context.Background(), "group/subgroup/repo", "abcdef", status)
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var requestErr *RequestError
+			if !errors.As(err, &requestErr) {
+				t.Fatalf("expected a *RequestError, got %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedError, requestErr); diff != "" {
+				t.Errorf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeRequests(t *testing.T) {
+	opts := MergeRequestOptions{Title: "Bump images", Description: "body", Labels: "a,b"}
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		if r.Method != http.MethodGet {
+			var got MergeRequestOptions
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			want := opts
+			if r.Method == http.MethodPost {
+				want.SourceBranch = "autobump"
+				want.TargetBranch = "main"
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("unexpected merge request options (-want +got):\n%s", diff)
+			}
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`[{"iid":3,"source_branch":"autobump","target_branch":"main","state":"opened"}]`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"iid":4}`))
+		case http.MethodPut:
+			w.Write([]byte(`{"iid":3}`))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL+"/api/v4/", func() []byte { return []byte("secret") })
+	ctx := context.Background()
+	mrs, err := c.ListOpenMergeRequests(ctx, "group/repo", "autobump", "main")
+	if err != nil {
+		t.Fatalf("failed to list merge requests: %v", err)
+	}
+	if diff := cmp.Diff([]MergeRequest{{IID: 3, SourceBranch: "autobump", TargetBranch: "main", State: "opened"}}, mrs); diff != "" {
+		t.Errorf("unexpected merge requests (-want +got):\n%s", diff)
+	}
+	updated, err := c.UpdateMergeRequest(ctx, "group/repo", 3, opts)
+	if err != nil {
+		t.Fatalf("failed to update merge request: %v", err)
+	}
+	if updated.IID != 3 {
+		t.Errorf("expected updated merge request !3, got !%d", updated.IID)
+	}
+	createOpts := opts
+	createOpts.SourceBranch = "autobump"
+	createOpts.TargetBranch = "main"
+	created, err := c.CreateMergeRequest(ctx, "group/repo", createOpts)
+	if err != nil {
+		t.Fatalf("failed to create merge request: %v", err)
+	}
+	if created.IID != 4 {
+		t.Errorf("expected created merge request !4, got !%d", created.IID)
+	}
+
+	expectedRequests := []string{
+		"GET /api/v4/projects/group%2Frepo/merge_requests?source_branch=autobump&state=opened&target_branch=main",
+		"PUT /api/v4/projects/group%2Frepo/merge_requests/3?",
+		"POST /api/v4/projects/group%2Frepo/merge_requests?",
+	}
+	if diff := cmp.Diff(expectedRequests, requests); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
@@ -19,7 +19,10 @@ package hook
 import (
 	"fmt"
 	"runtime/debug"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -354,6 +357,32 @@ func (s *Server) handleStatusEvent(l *logrus.Entry, se github.StatusEvent) {
 	}
 }
 
+func (s *Server) handleProjectV2ItemEvent(l *logrus.Entry, pe github.ProjectV2ItemEvent) {
+	defer s.wg.Done()
+	l = l.WithFields(logrus.Fields{
+		github.OrgLogField: pe.Org.Login,
+		"project":          pe.Item.ProjectNodeID,
+		"item":             pe.Item.NodeID,
+		"content_type":     pe.Item.ContentType,
+	})
+	l.Infof("Project item %s (%s).", pe.Action, pe.Item.ContentNodeID)
+	for p, h := range s.Plugins.ProjectV2ItemEventHandlers(pe.Org.Login) {
+		s.wg.Add(1)
+		go func(p string, h plugins.ProjectV2ItemEventHandler) {
+			defer s.wg.Done()
+			agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, pe.Org.Login, s.Metrics.Metrics, l, p)
+			start := time.Now()
+			err := errorOnPanic(func() error { return h(agent, pe) })
+			labels := prometheus.Labels{"event_type": l.Data[eventTypeField].(string), "action": string(pe.Action), "plugin": p, "took_action": strconv.FormatBool(agent.TookAction())}
+			if err != nil {
+				agent.Logger.WithError(err).Error("Error handling ProjectV2ItemEvent.")
+				s.Metrics.PluginHandleErrors.With(labels).Inc()
+			}
+			s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
+		}(p, h)
+	}
+}
+
 func (s *Server) handleGenericComment(l *logrus.Entry, ce *github.GenericCommentEvent) {
 	for p, h := range s.Plugins.GenericCommentHandlers(ce.Repo.Owner.Login, ce.Repo.Name) {
 		s.wg.Add(1)
@@ -377,6 +406,41 @@ func (s *Server) handleGenericComment(l *logrus.Entry, ce *github.GenericComment
 	}
 }
 
+// RunPeriodicHandlers runs the periodic handlers of the enabled plugins once
+// for every org they are enabled on and blocks until they are done. Plugins
+// run concurrently, orgs of the same plugin one after the other so a plugin
+// does not hit the GitHub API with concurrent searches.
+func (s *Server) RunPeriodicHandlers() {
+	l := logrus.WithField(eventTypeField, "periodic")
+	var wg sync.WaitGroup
+	for p, h := range s.Plugins.PeriodicHandlers() {
+		orgs, repos, _ := s.Plugins.Config().EnabledReposForPlugin(p)
+		for _, repo := range repos {
+			if org, _, ok := strings.Cut(repo, "/"); ok && !slices.Contains(orgs, org) {
+				orgs = append(orgs, org)
+			}
+		}
+		s.wg.Add(1)
+		wg.Add(1)
+		go func(p string, h plugins.PeriodicHandler, orgs []string) {
+			defer s.wg.Done()
+			defer wg.Done()
+			for _, org := range orgs {
+				agent := plugins.NewAgent(s.ConfigAgent, s.Plugins, s.ClientAgent, org, s.Metrics.Metrics, l.WithField(github.OrgLogField, org), p)
+				start := time.Now()
+				err := errorOnPanic(func() error { return h(agent, org) })
+				labels := prometheus.Labels{"event_type": "periodic", "action": "none", "plugin": p, "took_action": strconv.FormatBool(agent.TookAction())}
+				if err != nil {
+					agent.Logger.WithError(err).Error("Error running periodic handler.")
+					s.Metrics.PluginHandleErrors.With(labels).Inc()
+				}
+				s.Metrics.PluginHandleDuration.With(labels).Observe(time.Since(start).Seconds())
+			}
+		}(p, h, orgs)
+	}
+	wg.Wait()
+}
+
 func errorOnPanic(f func() error) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -24,12 +24,15 @@ import (
 	_ "sigs.k8s.io/prow/pkg/plugins/blockade"
 	_ "sigs.k8s.io/prow/pkg/plugins/blunderbuss"
 	_ "sigs.k8s.io/prow/pkg/plugins/branchcleaner"
+	_ "sigs.k8s.io/prow/pkg/plugins/branchff"
 	_ "sigs.k8s.io/prow/pkg/plugins/bugzilla"
 	_ "sigs.k8s.io/prow/pkg/plugins/buildifier"
 	_ "sigs.k8s.io/prow/pkg/plugins/cat"
 	_ "sigs.k8s.io/prow/pkg/plugins/cherrypickapproved"
 	_ "sigs.k8s.io/prow/pkg/plugins/cherrypickunapproved"
 	_ "sigs.k8s.io/prow/pkg/plugins/cla"
+	_ "sigs.k8s.io/prow/pkg/plugins/code-annotator"
+	_ "sigs.k8s.io/prow/pkg/plugins/custom-commands"
 	_ "sigs.k8s.io/prow/pkg/plugins/dco"
 	_ "sigs.k8s.io/prow/pkg/plugins/dog"
 	_ "sigs.k8s.io/prow/pkg/plugins/golint"
@@ -42,6 +45,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/plugins/label"
 	_ "sigs.k8s.io/prow/pkg/plugins/lgtm"
 	_ "sigs.k8s.io/prow/pkg/plugins/lifecycle"
+	_ "sigs.k8s.io/prow/pkg/plugins/lifecycle-manager"
 	_ "sigs.k8s.io/prow/pkg/plugins/merge-method-comment"
 	_ "sigs.k8s.io/prow/pkg/plugins/mergecommitblocker"
 	_ "sigs.k8s.io/prow/pkg/plugins/milestone"
@@ -52,6 +56,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/plugins/pony"
 	_ "sigs.k8s.io/prow/pkg/plugins/project"
 	_ "sigs.k8s.io/prow/pkg/plugins/projectmanager"
+	_ "sigs.k8s.io/prow/pkg/plugins/release-note-check"
 	_ "sigs.k8s.io/prow/pkg/plugins/releasenote"
 	_ "sigs.k8s.io/prow/pkg/plugins/require-matching-label"
 	_ "sigs.k8s.io/prow/pkg/plugins/retitle"
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// deliveryIDRegex matches the GUIDs GitHub assigns to deliveries. Anything
+// else is rejected so that ids can't escape the event store.
+var deliveryIDRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// Delivery is a raw webhook delivery as persisted in the EventStore.
+type Delivery struct {
+	EventType string      `json:"event_type"`
+	GUID      string      `json:"guid"`
+	Header    http.Header `json:"header"`
+	Payload   []byte      `json:"payload"`
+	Received  time.Time   `json:"received"`
+}
+
+// EventStore persists webhook deliveries under a /local/path, gs://, s3:// or abs://
+// prefix, one object per delivery GUID.
+type EventStore struct {
+	opener io.Opener
+	path   string
+}
+
+// NewEventStore returns an EventStore that writes below path with opener.
+func NewEventStore(opener io.Opener, path string) *EventStore {
+	return &EventStore{opener: opener, path: strings.TrimSuffix(path, "/")}
+}
+
+func (e *EventStore) objectPath(guid string) (string, error) {
+	if !deliveryIDRegex.MatchString(guid) {
+		return "", fmt.Errorf("invalid delivery id %q", guid)
+	}
+	return e.path + "/" + guid + ".json", nil
+}
+
+// Store persists the delivery.
+func (e *EventStore) Store(ctx context.Context, d Delivery) error {
+	path, err := e.objectPath(d.GUID)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery: %w", err)
+	}
+	return io.WriteContent(ctx, logrus.WithField(github.EventGUID, d.GUID), e.opener, path, content)
+}
+
+// Load returns the delivery with the given GUID.
+func (e *EventStore) Load(ctx context.Context, guid string) (*Delivery, error) {
+	path, err := e.objectPath(guid)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadContent(ctx, logrus.WithField(github.EventGUID, guid), e.opener, path)
+	if err != nil {
+		return nil, err
+	}
+	var d Delivery
+	if err := json.Unmarshal(content, &d); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal delivery %s: %w", guid, err)
+	}
+	return &d, nil
+}
+
+// storeDelivery persists a delivery without blocking the webhook response.
+// Failures are only logged, the event is still handled.
+func (s *Server) storeDelivery(d Delivery) {
+	defer s.wg.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := s.EventStore.Store(ctx, d); err != nil {
+		logrus.WithField(github.EventGUID, d.GUID).WithError(err).Error("Failed to store webhook delivery.")
+	}
+}
+
+// ReplayHandler returns a handler that re-processes the stored delivery with
+// the GUID passed in the id query parameter, e.g. POST /hook/replay?id=<guid>.
+// Requests must carry the token as "Authorization: Bearer <token>".
+func (s *Server) ReplayHandler(token func() []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "405 Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		expected := token()
+		provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if len(expected) == 0 || subtle.ConstantTimeCompare([]byte(provided), expected) != 1 {
+			http.Error(w, "403 Forbidden", http.StatusForbidden)
+			return
+		}
+		id := r.URL.Query().Get("id")
+		if !deliveryIDRegex.MatchString(id) {
+			http.Error(w, "400 Bad Request: missing or invalid id", http.StatusBadRequest)
+			return
+		}
+
+		d, err := s.EventStore.Load(r.Context(), id)
+		if io.IsNotExist(err) {
+			http.Error(w, fmt.Sprintf("404 Not Found: no delivery with id %s", id), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			logrus.WithField(github.EventGUID, id).WithError(err).Error("Failed to load webhook delivery.")
+			http.Error(w, "500 Internal Server Error: failed to load delivery", http.StatusInternalServerError)
+			return
+		}
+		logrus.WithFields(logrus.Fields{eventTypeField: d.EventType, github.EventGUID: d.GUID}).Info("Replaying webhook delivery.")
+		if err := s.demuxEvent(d.EventType, d.GUID, d.Payload, d.Header); err != nil {
+			http.Error(w, fmt.Sprintf("400 Bad Request: failed to parse delivery: %v", err), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, "Replaying delivery %s.", id)
+	})
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/githubeventserver"
+	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestEventStore(t *testing.T) {
+	opener, err := pkgio.NewOpener(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("failed to create opener: %v", err)
+	}
+	store := NewEventStore(opener, t.TempDir()+"/")
+
+	d := Delivery{
+		EventType: "push",
+		GUID:      "3b0c6f70-1d2e-11ef-8a3e-1a2b3c4d5e6f",
+		Header:    http.Header{"X-Github-Event": []string{"push"}},
+		Payload:   []byte(`{"ref":"refs/heads/main"}`),
+	}
+	if err := store.Store(context.Background(), d); err != nil {
+		t.Fatalf("failed to store delivery: %v", err)
+	}
+	got, err := store.Load(context.Background(), d.GUID)
+	if err != nil {
+		t.Fatalf("failed to load delivery: %v", err)
+	}
+	if diff := cmp.Diff(&d, got); diff != "" {
+		t.Errorf("unexpected delivery (-want +got):\n%s", diff)
+	}
+
+	if _, err := store.Load(context.Background(), "../../etc/passwd"); err == nil {
+		t.Error("expected an error loading an invalid id")
+	}
+	if _, err := store.Load(context.Background(), "missing"); !pkgio.IsNotExist(err) {
+		t.Errorf("expected a not exist error loading a missing delivery, got %v", err)
+	}
+}
+
+func TestReplayHandler(t *testing.T) {
+	dispatched := make(chan string, 1)
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		dispatched <- string(body)
+	}))
+	defer external.Close()
+
+	opener, err := pkgio.NewOpener(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("failed to create opener: %v", err)
+	}
+	store := NewEventStore(opener, t.TempDir())
+	payload := `{"repository":{"full_name":"org/repo"}}`
+	if err := store.Store(context.Background(), Delivery{EventType: "deployment", GUID: "abc-123", Header: http.Header{}, Payload: []byte(payload)}); err != nil {
+		t.Fatalf("failed to store delivery: %v", err)
+	}
+
+	pa := &plugins.ConfigAgent{}
+	pa.Set(&plugins.Configuration{ExternalPlugins: map[string][]plugins.ExternalPlugin{
+		"org": {{Name: "external", Endpoint: external.URL}},
+	}})
+	s := &Server{
+		Metrics:     githubeventserver.NewMetrics(),
+		Plugins:     pa,
+		RepoEnabled: func(org, repo string) bool { return true },
+		EventStore:  store,
+	}
+	handler := s.ReplayHandler(func() []byte { return []byte("secret") })
+
+	testCases := []struct {
+		name         string
+		method       string
+		token        string
+		id           string
+		expectedCode int
+	}{
+		{
+			name:         "GET is not allowed",
+			method:       http.MethodGet,
+			token:        "secret",
+			id:           "abc-123",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "wrong token is rejected",
+			method:       http.MethodPost,
+			token:        "guess",
+			id:           "abc-123",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "invalid id is rejected",
+			method:       http.MethodPost,
+			token:        "secret",
+			id:           "../abc-123",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown delivery",
+			method:       http.MethodPost,
+			token:        "secret",
+			id:           "def-456",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "delivery is replayed",
+			method:       http.MethodPost,
+			token:        "secret",
+			id:           "abc-123",
+			expectedCode: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, "/hook/replay?id="+tc.id, nil)
+			r.Header.Set("Authorization", "Bearer "+tc.token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tc.expectedCode {
+				t.Fatalf("expected code %d, got %d: %s", tc.expectedCode, w.Code, strings.TrimSpace(w.Body.String()))
+			}
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			s.GracefulShutdown()
+			select {
+			case got := <-dispatched:
+				if got != payload {
+					t.Errorf("expected payload %s to be dispatched, got %s", payload, got)
+				}
+			default:
+				t.Error("expected the delivery to be dispatched to the external plugin")
+			}
+		})
+	}
+}
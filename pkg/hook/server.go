@@ -45,6 +45,8 @@ type Server struct {
 	TokenGenerator func() []byte
 	Metrics        *githubeventserver.Metrics
 	RepoEnabled    func(org, repo string) bool
+	// EventStore persists the raw deliveries so they can be replayed, if set.
+	EventStore *EventStore
 
 	// c is an http client used for dispatching events
 	// to external plugin services.
@@ -69,6 +71,17 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	fmt.Fprint(w, "Event received. Have a nice day.")
 
+	if s.EventStore != nil {
+		s.wg.Add(1)
+		go s.storeDelivery(Delivery{
+			EventType: eventType,
+			GUID:      eventGUID,
+			Header:    r.Header.Clone(),
+			Payload:   payload,
+			Received:  time.Now(),
+		})
+	}
+
 	if err := s.demuxEvent(eventType, eventGUID, payload, r.Header); err != nil {
 		logrus.WithError(err).Error("Error parsing event.")
 	}
@@ -166,6 +179,17 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 			s.wg.Add(1)
 			go s.handleStatusEvent(l, se)
 		}
+	case "projects_v2_item":
+		var pe github.ProjectV2ItemEvent
+		if err := json.Unmarshal(payload, &pe); err != nil {
+			return err
+		}
+		pe.GUID = eventGUID
+		srcRepo = pe.Org.Login
+		if s.RepoEnabled(pe.Org.Login, "") {
+			s.wg.Add(1)
+			go s.handleProjectV2ItemEvent(l, pe)
+		}
 	default:
 		var ge github.GenericEvent
 		if err := json.Unmarshal(payload, &ge); err != nil {
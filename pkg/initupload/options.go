@@ -21,6 +21,7 @@ import (
 	"flag"
 
 	"sigs.k8s.io/prow/pkg/gcsupload"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 )
 
 const (
@@ -43,6 +44,10 @@ type Options struct {
 	// Log is the log file to which clone records are written. If unspecified, no clone records
 	// are uploaded.
 	Log string `json:"log,omitempty"`
+
+	// Cache configures restoring the cache of the job. If unspecified, no
+	// cache is restored.
+	Cache *cache.Options `json:"cache,omitempty"`
 }
 
 // ConfigVar exposes the environment variable used to store serialized configuration.
@@ -27,7 +27,9 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/sirupsen/logrus"
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	pkgio "sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/pod-utils/clone"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
@@ -68,9 +70,29 @@ func (o Options) Run() error {
 		return errors.New("cloning the appropriate refs failed")
 	}
 
+	if o.Cache != nil {
+		// The cache only speeds up the job, so failing to restore it doesn't
+		// fail the job.
+		if err := o.restoreCache(ctx, spec); err != nil {
+			logrus.WithError(err).Warn("Failed to restore cache.")
+		}
+	}
+
 	return nil
 }
 
+func (o Options) restoreCache(ctx context.Context, spec *downwardapi.JobSpec) error {
+	if o.DryRun {
+		logrus.WithField("storage", o.Cache.Storage).Info("Would restore cache")
+		return nil
+	}
+	opener, err := pkgio.NewOpener(ctx, o.StorageClientOptions.GCSCredentialsFile, o.StorageClientOptions.S3CredentialsFile, o.StorageClientOptions.AzureCredentialsFile)
+	if err != nil {
+		return fmt.Errorf("new opener: %w", err)
+	}
+	return o.Cache.Restore(ctx, opener, spec)
+}
+
 // processCloneLog checks if clone operation succeeded or failed for a ref
 // and upload clone logs as build log upon failures.
 // returns: bool - clone status
@@ -93,22 +93,24 @@ type Opener interface {
 	SignedURL(ctx context.Context, path string, opts SignedURLOptions) (string, error)
 	Iterator(ctx context.Context, prefix, delimiter string) (ObjectIterator, error)
 	UpdateAttributes(context.Context, string, ObjectAttrsToUpdate) (*Attributes, error)
+	Delete(ctx context.Context, path string) error
 }
 
 type opener struct {
 	gcsCredentialsFile string
 	gcsClient          storageClient
 	s3Credentials      []byte
+	azureCredentials   []byte
 	cachedBuckets      map[string]*blob.Bucket
 	cachedBucketsMutex sync.Mutex
 }
 
-// NewOpener returns an opener that can read GCS, S3 and local paths.
+// NewOpener returns an opener that can read GCS, S3, Azure Blob Storage and local paths.
 // credentialsFile may also be empty
 // For local paths it has to be empty
 // In all other cases gocloud auto-discovery is used to detect credentials, if credentialsFile is empty.
 // For more details about the possible content of the credentialsFile see prow/io/providers.GetBucket
-func NewOpener(ctx context.Context, gcsCredentialsFile, s3CredentialsFile string) (Opener, error) {
+func NewOpener(ctx context.Context, gcsCredentialsFile, s3CredentialsFile, azureCredentialsFile string) (Opener, error) {
 	gcsClient, err := createGCSClient(ctx, gcsCredentialsFile)
 	if err != nil {
 		return nil, err
@@ -120,10 +122,18 @@ func NewOpener(ctx context.Context, gcsCredentialsFile, s3CredentialsFile string
 			return nil, err
 		}
 	}
+	var azureCredentials []byte
+	if azureCredentialsFile != "" {
+		azureCredentials, err = os.ReadFile(azureCredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &opener{
 		gcsClient:          gcsClient,
 		gcsCredentialsFile: gcsCredentialsFile,
 		s3Credentials:      s3Credentials,
+		azureCredentials:   azureCredentials,
 		cachedBuckets:      map[string]*blob.Bucket{},
 	}, nil
 }
@@ -213,7 +223,7 @@ func (o *opener) getBucket(ctx context.Context, path string) (*blob.Bucket, stri
 		return bucket, relativePath, nil
 	}
 
-	bucket, err := providers.GetBucket(ctx, o.s3Credentials, path)
+	bucket, err := providers.GetBucket(ctx, o.s3Credentials, o.azureCredentials, path)
 	if err != nil {
 		return nil, "", err
 	}
@@ -391,6 +401,26 @@ func (o *opener) UpdateAttributes(ctx context.Context, path string, attrs Object
 	}, nil
 }
 
+// Delete deletes the object at path, returning an IsNotExist() error when missing
+func (o *opener) Delete(ctx context.Context, path string) error {
+	if strings.HasPrefix(path, providers.GS+"://") {
+		g, err := o.openGCS(path)
+		if err != nil {
+			return fmt.Errorf("bad gcs path: %w", err)
+		}
+		return g.Delete(ctx)
+	}
+	if strings.HasPrefix(path, "/") {
+		return os.Remove(path)
+	}
+
+	bucket, relativePath, err := o.getBucket(ctx, path)
+	if err != nil {
+		return err
+	}
+	return bucket.Delete(ctx, relativePath)
+}
+
 const (
 	GSAnonHost   = "storage.googleapis.com"
 	GSCookieHost = "storage.cloud.google.com"
@@ -478,7 +508,7 @@ func (o *opener) Iterator(ctx context.Context, prefix, delimiter string) (Object
 		}
 		if delimiter == "" {
 			// query.SetAttrSelection cannot be used in directory-like mode (when delimiter != "").
-			if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+			if err := query.SetAttrSelection([]string{"Name", "Size"}); err != nil {
 				return nil, err
 			}
 		}
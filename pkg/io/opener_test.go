@@ -134,7 +134,7 @@ RU5EIFBSSVZBVEUgS0VZLS0tLS1cbgo=`)
 					t.Fatalf("Failed to close fake creds %s: %v", gcsCredentialsFile, err)
 				}
 			}
-			o, _ := NewOpener(context.Background(), gcsCredentialsFile, "")
+			o, _ := NewOpener(context.Background(), gcsCredentialsFile, "", "")
 			got, err := o.SignedURL(tt.args.ctx, tt.args.p, tt.args.opts)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SignedURL() error = %v, wantErr %v", err, tt.wantErr)
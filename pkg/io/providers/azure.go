@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/azureblob"
+)
+
+// AzureCredentials are credentials used to access Azure Blob Storage.
+// At most one of StorageKey and SASToken may be set, if neither is set the
+// default Azure credentials are used, e.g. workload identity.
+type AzureCredentials struct {
+	StorageAccount string `json:"storage_account"`
+	StorageKey     string `json:"storage_key"`
+	SASToken       string `json:"sas_token"`
+	// StorageDomain is the domain of the blob service of the storage account.
+	// Defaults to blob.core.windows.net, other Azure clouds use other domains.
+	StorageDomain string `json:"storage_domain"`
+}
+
+// getAzureBucket opens a gocloud blob.Bucket for the container based on given credentials
+// in the format the struct AzureCredentials defines.
+func getAzureBucket(ctx context.Context, creds []byte, containerName string) (*blob.Bucket, error) {
+	azureCreds := &AzureCredentials{}
+	if err := json.Unmarshal(creds, azureCreds); err != nil {
+		return nil, fmt.Errorf("error getting Azure credentials from JSON: %w", err)
+	}
+	client, err := newAzureContainerClient(azureCreds, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Azure container client: %w", err)
+	}
+	bkt, err := azureblob.OpenBucket(ctx, client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error opening Azure container: %w", err)
+	}
+	return bkt, nil
+}
+
+func newAzureContainerClient(creds *AzureCredentials, containerName string) (*container.Client, error) {
+	if creds.StorageAccount == "" {
+		return nil, errors.New("storage_account is not specified")
+	}
+	if creds.StorageKey != "" && creds.SASToken != "" {
+		return nil, errors.New("both storage_key and sas_token are specified")
+	}
+	serviceURL, err := azureblob.NewServiceURL(&azureblob.ServiceURLOptions{
+		AccountName:   creds.StorageAccount,
+		SASToken:      creds.SASToken,
+		StorageDomain: creds.StorageDomain,
+	})
+	if err != nil {
+		return nil, err
+	}
+	containerURL, err := url.JoinPath(string(serviceURL), containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case creds.StorageKey != "":
+		sharedKey, err := azblob.NewSharedKeyCredential(creds.StorageAccount, creds.StorageKey)
+		if err != nil {
+			return nil, fmt.Errorf("error creating shared key credential: %w", err)
+		}
+		return container.NewClientWithSharedKeyCredential(containerURL, sharedKey, nil)
+	case creds.SASToken != "":
+		// the SAS token is part of the container URL
+		return container.NewClientWithNoCredential(containerURL, nil)
+	default:
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating default Azure credential: %w", err)
+		}
+		return container.NewClient(containerURL, cred, nil)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"testing"
+)
+
+func Test_newAzureContainerClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		creds   AzureCredentials
+		wantURL string
+		wantErr bool
+	}{
+		{
+			name: "storage key",
+			creds: AzureCredentials{
+				StorageAccount: "account",
+				StorageKey:     "a2V5",
+			},
+			wantURL: "https://account.blob.core.windows.net/container",
+		},
+		{
+			name: "sas token",
+			creds: AzureCredentials{
+				StorageAccount: "account",
+				SASToken:       "sv=2021-06-08&sig=signature",
+			},
+			wantURL: "https://account.blob.core.windows.net/container?sv=2021-06-08&sig=signature",
+		},
+		{
+			name: "custom storage domain",
+			creds: AzureCredentials{
+				StorageAccount: "account",
+				StorageKey:     "a2V5",
+				StorageDomain:  "blob.core.chinacloudapi.cn",
+			},
+			wantURL: "https://account.blob.core.chinacloudapi.cn/container",
+		},
+		{
+			name: "storage account missing",
+			creds: AzureCredentials{
+				StorageKey: "a2V5",
+			},
+			wantErr: true,
+		},
+		{
+			name: "both storage key and sas token",
+			creds: AzureCredentials{
+				StorageAccount: "account",
+				StorageKey:     "a2V5",
+				SASToken:       "sv=2021-06-08&sig=signature",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newAzureContainerClient(&tt.creds, "container")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newAzureContainerClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := client.URL(); got != tt.wantURL {
+				t.Errorf("expected container URL %q, got %q", tt.wantURL, got)
+			}
+		})
+	}
+}
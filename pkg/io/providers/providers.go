@@ -23,13 +23,15 @@ import (
 	"strings"
 
 	"gocloud.dev/blob"
+	"gocloud.dev/blob/azureblob"
 	_ "gocloud.dev/blob/memblob"
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 )
 
 const (
-	S3 = "s3"
-	GS = "gs"
+	S3  = "s3"
+	GS  = "gs"
+	ABS = "abs"
 	// TODO(danilo-gemoli): complete the implementation since at this time only opener.Writer()
 	// is supported
 	File = "file"
@@ -42,6 +44,8 @@ func DisplayName(provider string) string {
 		return "GCS"
 	case S3:
 		return "S3"
+	case ABS:
+		return "Azure Blob Storage"
 	case File:
 		return "File"
 	}
@@ -72,7 +76,18 @@ func DisplayName(provider string) string {
 //     "access_key": "access_key",
 //     "secret_key": "secret_key"
 //     }
-func GetBucket(ctx context.Context, S3Credentials []byte, path string) (*blob.Bucket, error) {
+//
+// If we specify credentials and an abs:// path is used, the bucket is the name of the
+// container and credentials must be given in the following format:
+//   - Azure Blob Storage (abs://):
+//     {
+//     "storage_account": "account",
+//     "storage_key": "key"
+//     }
+//     storage_key may be replaced by a "sas_token" or omitted to authenticate with the
+//     default Azure credentials, e.g. workload identity. Storage accounts outside of the
+//     public Azure cloud additionally need a "storage_domain".
+func GetBucket(ctx context.Context, S3Credentials, AzureCredentials []byte, path string) (*blob.Bucket, error) {
 	storageProvider, bucket, _, err := ParseStoragePath(path)
 	if err != nil {
 		return nil, err
@@ -80,6 +95,13 @@ func GetBucket(ctx context.Context, S3Credentials []byte, path string) (*blob.Bu
 	if storageProvider == S3 && len(S3Credentials) > 0 {
 		return getS3Bucket(ctx, S3Credentials, bucket)
 	}
+	if storageProvider == ABS {
+		if len(AzureCredentials) > 0 {
+			return getAzureBucket(ctx, AzureCredentials, bucket)
+		}
+		// gocloud registers Azure Blob Storage under a different scheme
+		storageProvider = azureblob.Scheme
+	}
 
 	bkt, err := blob.OpenBucket(ctx, fmt.Sprintf("%s://%s", storageProvider, bucket))
 	if err != nil {
@@ -106,12 +128,12 @@ type S3Credentials struct {
 // * gs/kubernetes-jenkins returns true
 // * kubernetes-jenkins returns false
 func HasStorageProviderPrefix(path string) bool {
-	return strings.HasPrefix(path, GS+"/") || strings.HasPrefix(path, S3+"/")
+	return strings.HasPrefix(path, GS+"/") || strings.HasPrefix(path, S3+"/") || strings.HasPrefix(path, ABS+"/")
 }
 
 // ParseStoragePath parses storagePath and returns the storageProvider, bucket and relativePath
 // For example gs://prow-artifacts/test.log results in (gs, prow-artifacts, test.log)
-// Currently detected storageProviders are GS, S3 and file.
+// Currently detected storageProviders are GS, S3, ABS and file.
 // Paths with a leading / instead of a storageProvider prefix are treated as file paths for backwards
 // compatibility reasons.
 // File paths are split into a directory and a file. Directory is returned as bucket, file is returned.
@@ -38,6 +38,11 @@ func TestHasStorageProviderPrefix(t *testing.T) {
 			path: "gs/kubernetes-jenkins",
 			want: true,
 		},
+		{
+			name: "abs prefix",
+			path: "abs/kubernetes-jenkins",
+			want: true,
+		},
 		{
 			name: "no prefix",
 			path: "kubernetes-jenkins",
@@ -70,6 +75,14 @@ func TestParseStoragePath(t *testing.T) {
 		wantRelativePath    string
 		wantErr             bool
 	}{
+		{
+			name:                "parse abs path",
+			args:                args{storagePath: "abs://prow-artifacts/test"},
+			wantStorageProvider: providers.ABS,
+			wantBucket:          "prow-artifacts",
+			wantRelativePath:    "test",
+			wantErr:             false,
+		},
 		{
 			name:                "parse s3 path",
 			args:                args{storagePath: "s3://prow-artifacts/test"},
@@ -69,6 +69,27 @@ const (
 	// IsOptionalLabel is added in resources created by prow and
 	// carries the Optional from a Presubmit job.
 	IsOptionalLabel = "prow.k8s.io/is-optional"
+	// FailureClassificationAnnotation is added to failed and errored ProwJobs
+	// and carries whether the failure is caused by the infrastructure, the
+	// tests or the configuration of the job.
+	FailureClassificationAnnotation = "prow.k8s.io/failure-classification"
+	// FailureClassificationMessageAnnotation is added alongside
+	// FailureClassificationAnnotation and carries a short, human readable
+	// explanation of the classification.
+	FailureClassificationMessageAnnotation = "prow.k8s.io/failure-classification-message"
+	// ReportFailedAnnotationPrefix is followed by the name of a crier reporter
+	// and added to ProwJobs whose state that reporter gave up reporting. It
+	// carries the state and the last error.
+	ReportFailedAnnotationPrefix = "crier.prow.k8s.io/report-failed-"
+	// ShadowLabel is added to ProwJobs that run a changed job definition
+	// from a config change before it merges. Shadow jobs are not reported.
+	ShadowLabel = "prow.k8s.io/shadow"
+	// ShadowOfAnnotation is added to shadow ProwJobs and carries the name
+	// of the job that the shadow job runs a changed definition of.
+	ShadowOfAnnotation = "prow.k8s.io/shadow-of"
+	// ShadowSourceAnnotation is added to shadow ProwJobs and carries the
+	// config change the job definition comes from, eg org/repo#123.
+	ShadowSourceAnnotation = "prow.k8s.io/shadow-source"
 
 	// Gerrit related labels that are used by Prow
 
@@ -110,6 +110,42 @@ func (s String) PopRandom() string {
 	return ""
 }
 
+// PopWeighted randomly selects an element of the first non-empty layer with a
+// probability proportional to its weight and pops it. Elements are selected
+// uniformly if none of them has a positive weight.
+func (s String) PopWeighted(weight func(string) float64) string {
+	for _, layer := range s {
+		if layer.Len() > 0 {
+			list := sets.List(layer)
+			weights := make([]float64, len(list))
+			var total float64
+			for i, item := range list {
+				if w := weight(item); w > 0 {
+					weights[i] = w
+					total += w
+				}
+			}
+			sel := list[rand.Intn(len(list))]
+			if total > 0 {
+				r := rand.Float64() * total
+				for i, w := range weights {
+					if w == 0 {
+						continue
+					}
+					sel = list[i]
+					if r < w {
+						break
+					}
+					r -= w
+				}
+			}
+			s.Delete(sel)
+			return sel
+		}
+	}
+	return ""
+}
+
 // Equal returns true if and only if s1 is equal (as a set) to s2.
 func (s String) Equal(s2 String) bool {
 	if s.Len() != s2.Len() {
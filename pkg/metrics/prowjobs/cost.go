@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// costCollector approximates the resources that prowjobs consumed by the
+// resources their pods requested for as long as they ran.
+type costCollector struct {
+	runtime *prometheus.CounterVec
+	cpu     *prometheus.CounterVec
+	memory  *prometheus.CounterVec
+}
+
+func (c *costCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.runtime.Describe(ch)
+	c.cpu.Describe(ch)
+	c.memory.Describe(ch)
+}
+
+func (c *costCollector) Collect(ch chan<- prometheus.Metric) {
+	c.runtime.Collect(ch)
+	c.cpu.Collect(ch)
+	c.memory.Collect(ch)
+}
+
+func (c *costCollector) count(oldJob *prowapi.ProwJob, newJob *prowapi.ProwJob) {
+	if oldJob.Complete() || !newJob.Complete() || newJob.Spec.PodSpec == nil {
+		return
+	}
+	started := newJob.Status.StartTime
+	if newJob.Status.PendingTime != nil {
+		started = *newJob.Status.PendingTime
+	}
+	runtime := newJob.Status.CompletionTime.Sub(started.Time).Seconds()
+	if runtime <= 0 {
+		return
+	}
+
+	var org, repo string
+	if newJob.Spec.Refs != nil {
+		org, repo = newJob.Spec.Refs.Org, newJob.Spec.Refs.Repo
+	} else if len(newJob.Spec.ExtraRefs) > 0 {
+		org, repo = newJob.Spec.ExtraRefs[0].Org, newJob.Spec.ExtraRefs[0].Repo
+	}
+	labels := []string{newJob.Namespace, newJob.Spec.Job, string(newJob.Spec.Type), org, repo, newJob.ClusterAlias()}
+	requests := podRequests(newJob.Spec.PodSpec)
+	for counterVec, value := range map[*prometheus.CounterVec]float64{
+		c.runtime: runtime,
+		c.cpu:     requests.Cpu().AsApproximateFloat64() * runtime,
+		c.memory:  requests.Memory().AsApproximateFloat64() * runtime,
+	} {
+		counter, err := counterVec.GetMetricWithLabelValues(labels...)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to get a cost counter for a prowjob")
+			return
+		}
+		counter.Add(value)
+	}
+}
+
+// podRequests returns the resources that the scheduler reserves for the pod:
+// the sum of the requests of its containers, or the largest requests of its
+// init containers if they are larger.
+func podRequests(spec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			sum := requests[name]
+			sum.Add(quantity)
+			requests[name] = sum
+		}
+	}
+	for _, container := range spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+// NewProwJobCostCollector creates counters which approximate the resource
+// consumption of ProwJobs by org, repo and job, as the CPU and memory that
+// their pods requested multiplied by how long they ran.
+// Data is collected by hooking itself into the prowjob informer, so a job is
+// counted once when it completes.
+func NewProwJobCostCollector(informer cache.SharedIndexInformer) prometheus.Collector {
+	c := newCostCollector()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldJob, newJob interface{}) {
+			c.count(oldJob.(*prowapi.ProwJob), newJob.(*prowapi.ProwJob))
+		},
+	})
+	return c
+}
+
+func newCostCollector() *costCollector {
+	labels := []string{
+		// namespace of the job
+		"job_namespace",
+		// name of the job
+		"job_name",
+		// type of the prowjob: presubmit, postsubmit, periodic, batch
+		"type",
+		// the org of the prowjob's repo
+		"org",
+		// the prowjob's repo
+		"repo",
+		// the build cluster the prowjob ran in
+		"cluster",
+	}
+	return &costCollector{
+		runtime: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_pod_runtime_seconds_total",
+			Help: "Time that the pods of completed prowjobs ran for.",
+		}, labels),
+		cpu: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_cpu_request_core_seconds_total",
+			Help: "CPU cores requested by the pods of completed prowjobs multiplied by their runtime.",
+		}, labels),
+		memory: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prow_job_memory_request_byte_seconds_total",
+			Help: "Memory bytes requested by the pods of completed prowjobs multiplied by their runtime.",
+		}, labels),
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestCountCost(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	resources := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}
+	}
+	job := func(state prowapi.ProwJobState) *prowapi.ProwJob {
+		pj := &prowapi.ProwJob{
+			ObjectMeta: v1.ObjectMeta{Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Job:     "pull-test",
+				Type:    prowapi.PresubmitJob,
+				Cluster: "build",
+				Refs:    &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main"},
+				PodSpec: &corev1.PodSpec{
+					InitContainers: []corev1.Container{{Resources: resources("500m", "4Gi")}},
+					Containers: []corev1.Container{
+						{Resources: resources("1", "1Gi")},
+						{Resources: resources("1", "1Gi")},
+					},
+				},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:       state,
+				StartTime:   v1.NewTime(start),
+				PendingTime: &v1.Time{Time: start.Add(time.Minute)},
+			},
+		}
+		if state != prowapi.PendingState {
+			pj.Status.CompletionTime = &v1.Time{Time: start.Add(11 * time.Minute)}
+		}
+		return pj
+	}
+
+	c := newCostCollector()
+	c.count(job(prowapi.PendingState), job(prowapi.PendingState))
+	c.count(job(prowapi.PendingState), job(prowapi.SuccessState))
+	c.count(job(prowapi.SuccessState), job(prowapi.SuccessState))
+
+	labels := []string{"prowjobs", "pull-test", "presubmit", "org", "repo", "build"}
+	for name, tc := range map[string]struct {
+		counterVec *prometheus.CounterVec
+		expected   float64
+	}{
+		"runtime": {counterVec: c.runtime, expected: 600},
+		// The containers request more CPU than the init container.
+		"cpu": {counterVec: c.cpu, expected: 2 * 600},
+		// The init container requests more memory than the containers.
+		"memory": {counterVec: c.memory, expected: 4 * 1024 * 1024 * 1024 * 600},
+	} {
+		if got := testutil.ToFloat64(tc.counterVec.WithLabelValues(labels...)); got != tc.expected {
+			t.Errorf("expected %s to be %v, got %v", name, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/cache"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func countFailure(counterVec *prometheus.CounterVec, oldJob *prowapi.ProwJob, newJob *prowapi.ProwJob) {
+	classification, ok := newJob.Annotations[kube.FailureClassificationAnnotation]
+	if !ok {
+		return
+	}
+	if _, wasClassified := oldJob.Annotations[kube.FailureClassificationAnnotation]; wasClassified {
+		return
+	}
+
+	var org, repo, baseRef string
+	if newJob.Spec.Refs != nil {
+		org, repo, baseRef = newJob.Spec.Refs.Org, newJob.Spec.Refs.Repo, newJob.Spec.Refs.BaseRef
+	} else if len(newJob.Spec.ExtraRefs) > 0 {
+		org, repo, baseRef = newJob.Spec.ExtraRefs[0].Org, newJob.Spec.ExtraRefs[0].Repo, newJob.Spec.ExtraRefs[0].BaseRef
+	}
+	counter, err := counterVec.GetMetricWithLabelValues(newJob.Namespace, newJob.Spec.Job, string(newJob.Spec.Type), string(newJob.Status.State), classification, org, repo, baseRef)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get a failure counter for a prowjob")
+		return
+	}
+	counter.Inc()
+}
+
+// NewProwJobFailureCounterVec creates counters which track the failed and
+// errored ProwJobs by their failure classification.
+// Data is collected by hooking itself into the prowjob informer, so a job is
+// counted once when its classification is recorded.
+func NewProwJobFailureCounterVec(informer cache.SharedIndexInformer) *prometheus.CounterVec {
+	counterVec := newFailureCounterVec()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldJob, newJob interface{}) {
+			countFailure(counterVec, oldJob.(*prowapi.ProwJob), newJob.(*prowapi.ProwJob))
+		},
+	})
+	return counterVec
+}
+
+func newFailureCounterVec() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "prow_job_failures_total",
+			Help: "Number of failed and errored prowjobs by failure classification.",
+		},
+		[]string{
+			// namespace of the job
+			"job_namespace",
+			// name of the job
+			"job_name",
+			// type of the prowjob: presubmit, postsubmit, periodic, batch
+			"type",
+			// state of the prowjob: failure, error
+			"state",
+			// classification of the failure: infra, test, config
+			"classification",
+			// the org of the prowjob's repo
+			"org",
+			// the prowjob's repo
+			"repo",
+			// the base_ref of the prowjob's repo
+			"base_ref",
+		},
+	)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestCountFailure(t *testing.T) {
+	job := func(classification string) *prowapi.ProwJob {
+		pj := &prowapi.ProwJob{
+			ObjectMeta: v1.ObjectMeta{Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Job:  "pull-test",
+				Type: prowapi.PresubmitJob,
+				Refs: &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main"},
+			},
+			Status: prowapi.ProwJobStatus{State: prowapi.FailureState},
+		}
+		if classification != "" {
+			pj.Annotations = map[string]string{kube.FailureClassificationAnnotation: classification}
+		}
+		return pj
+	}
+
+	counterVec := newFailureCounterVec()
+	countFailure(counterVec, job(""), job(""))
+	countFailure(counterVec, job(""), job("infra"))
+	countFailure(counterVec, job("infra"), job("infra"))
+	countFailure(counterVec, job(""), job("test"))
+
+	for classification, expected := range map[string]float64{"infra": 1, "test": 1, "config": 0} {
+		if got := testutil.ToFloat64(counterVec.WithLabelValues("prowjobs", "pull-test", "presubmit", "failure", classification, "org", "repo", "main")); got != expected {
+			t.Errorf("expected %v %s failures, got %v", expected, classification, got)
+		}
+	}
+}
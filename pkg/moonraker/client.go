@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/repoowners"
 	"sigs.k8s.io/prow/pkg/version"
 )
 
@@ -165,6 +166,41 @@ func (c *Client) GetProwYAML(refs *prowapi.Refs) (*config.ProwYAML, error) {
 	return &prowYAML, nil
 }
 
+// GetRepoOwners returns the OWNERS of a repo at the given SHA of the base
+// branch, as resolved by Moonraker. The OWNERS are not filtered by the
+// collaborators of the repo. It implements repoowners.OwnersGetter, so that it
+// can be passed to repoowners.NewRemoteClient().
+func (c *Client) GetRepoOwners(org, repo, base, sha string, updateCache bool) (*repoowners.RepoOwners, error) {
+	payload := ownersPayload{
+		Refs: prowapi.Refs{
+			Org:     org,
+			Repo:    repo,
+			BaseRef: base,
+			BaseSHA: sha,
+		},
+		UpdateCache: updateCache,
+	}
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal %v", payload)
+	}
+
+	resp, err := c.do(http.MethodPost, PathGetOwners, buf, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("got %v response", resp.StatusCode)
+	}
+
+	var snapshot repoowners.Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal OWNERS: %w", err)
+	}
+	return snapshot.RepoOwners(logrus.WithFields(logrus.Fields{"client": "moonraker", "org": org, "repo": repo}))
+}
+
 // GetInRepoConfig just wraps around GetProwYAML(), converting the input
 // parameters into a prowapi.Refs{} type.
 //
@@ -28,16 +28,20 @@ import (
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/repoowners"
 )
 
 const (
 	PathGetInrepoconfig = "inrepoconfig"
+	PathGetOwners       = "owners"
 	PathPing            = "ping"
 )
 
 type Moonraker struct {
 	ConfigAgent       *config.Agent
 	InRepoConfigCache *config.InRepoConfigCache
+	// RepoOwners resolves OWNERS files, if set.
+	RepoOwners repoowners.OwnersGetter
 }
 
 type configSectionsToWatch struct {
@@ -50,6 +54,13 @@ type payload struct {
 	Refs prowapi.Refs `json:"refs"`
 }
 
+// ownersPayload is the message payload of OWNERS requests. The OWNERS are
+// resolved at Refs.BaseSHA of Refs.BaseRef.
+type ownersPayload struct {
+	Refs        prowapi.Refs `json:"refs"`
+	UpdateCache bool         `json:"update_cache,omitempty"`
+}
+
 type ProwYAMLGetter interface {
 	GetProwYAML(payload *payload) (*config.ProwYAML, error)
 }
@@ -104,6 +115,38 @@ func (mr *Moonraker) ServeGetInrepoconfig(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// ServeGetOwners returns the OWNERS of a repo as a repoowners.Snapshot
+// marshaled into JSON. The OWNERS are not filtered by the collaborators of the
+// repo, which is left to clients.
+func (mr *Moonraker) ServeGetOwners(w http.ResponseWriter, r *http.Request) {
+	if mr.RepoOwners == nil {
+		http.Error(w, "OWNERS are not served", http.StatusNotFound)
+		return
+	}
+
+	payload := &ownersPayload{}
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		logrus.WithError(err).Info("unable to unmarshal getOwners request")
+		http.Error(w, fmt.Sprintf("unable to unmarshal getOwners request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	refs := payload.Refs
+	owners, err := mr.RepoOwners.GetRepoOwners(refs.Org, refs.Repo, refs.BaseRef, refs.BaseSHA, payload.UpdateCache)
+	if err != nil {
+		logrus.WithError(err).WithField("refs", refs.String()).Error("unable to retrieve OWNERS")
+		http.Error(w, fmt.Sprintf("unable to retrieve OWNERS: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(owners.Snapshot()); err != nil {
+		logrus.WithError(err).Error("unable to encode OWNERS into JSON")
+		http.Error(w, fmt.Sprintf("unable to encode OWNERS into JSON: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (mr *Moonraker) RunConfigWatcher(ctx context.Context) error {
 	configEvent := make(chan config.Delta, 2)
 	mr.ConfigAgent.Subscribe(configEvent)
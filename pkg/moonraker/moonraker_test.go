@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package moonraker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+type fakeConfigAgent struct{}
+
+func (fakeConfigAgent) Config() *config.Config {
+	return &config.Config{ProwConfig: config.ProwConfig{Moonraker: config.Moonraker{ClientTimeout: &metav1.Duration{Duration: time.Minute}}}}
+}
+
+type fakeOwnersGetter struct {
+	owners *repoowners.RepoOwners
+	err    error
+	calls  []string
+}
+
+func (f *fakeOwnersGetter) GetRepoOwners(org, repo, base, sha string, updateCache bool) (*repoowners.RepoOwners, error) {
+	f.calls = append(f.calls, fmt.Sprintf("%s/%s:%s@%s update=%t", org, repo, base, sha, updateCache))
+	return f.owners, f.err
+}
+
+func TestGetRepoOwners(t *testing.T) {
+	owners, err := repoowners.Snapshot{
+		Aliases:   map[string][]string{"approvers": {"alice", "bob"}},
+		Approvers: map[string]map[string][]string{"": {"": {"alice", "bob"}}, "docs": {`\.md$`: {"carl"}}},
+		Reviewers: map[string]map[string][]string{"": {"": {"dan"}}},
+	}.RepoOwners(logrus.WithField("test", t.Name()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		getter        *fakeOwnersGetter
+		expectedErr   bool
+		expectedCalls []string
+	}{
+		{
+			name:          "OWNERS are resolved",
+			getter:        &fakeOwnersGetter{owners: owners},
+			expectedCalls: []string{"org/repo:main@sha update=true"},
+		},
+		{
+			name:          "OWNERS cannot be resolved",
+			getter:        &fakeOwnersGetter{err: errors.New("clone failed")},
+			expectedErr:   true,
+			expectedCalls: []string{"org/repo:main@sha update=true"},
+		},
+		{
+			name:        "OWNERS are not served",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := &Moonraker{}
+			if tc.getter != nil {
+				mr.RepoOwners = tc.getter
+			}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/"+PathPing, mr.ServePing)
+			mux.HandleFunc("/"+PathGetOwners, mr.ServeGetOwners)
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client, err := NewClient(server.URL, fakeConfigAgent{})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			actual, err := client.GetRepoOwners("org", "repo", "main", "sha", true)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if tc.getter != nil && fmt.Sprint(tc.getter.calls) != fmt.Sprint(tc.expectedCalls) {
+				t.Errorf("expected calls %v, got %v", tc.expectedCalls, tc.getter.calls)
+			}
+			if err != nil {
+				return
+			}
+			for path, expected := range map[string]sets.Set[string]{
+				"a.go":         sets.New[string]("alice", "bob"),
+				"docs/a.go":    sets.New[string]("alice", "bob"),
+				"docs/READ.md": sets.New[string]("alice", "bob", "carl"),
+			} {
+				if approvers := actual.Approvers(path).Set(); !approvers.Equal(expected) {
+					t.Errorf("expected approvers of %s to be %v, got %v", path, sets.List(expected), sets.List(approvers))
+				}
+			}
+			if reviewers := actual.Reviewers("a.go").Set(); !reviewers.Equal(sets.New[string]("dan")) {
+				t.Errorf("expected reviewers to be [dan], got %v", sets.List(reviewers))
+			}
+			if !actual.ExpandAlias("approvers").Equal(sets.New[string]("alice", "bob")) {
+				t.Errorf("expected aliases to be restored, got %v", actual.RepoAliases)
+			}
+		})
+	}
+}
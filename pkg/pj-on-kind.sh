@@ -13,7 +13,7 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
-# Requires go, docker, and kubectl.
+# Requires go, kubectl, and docker, podman or nerdctl.
 
 set -o errexit
 set -o nounset
@@ -26,10 +26,10 @@ function main() {
   ensureInstall
 
   # Generate PJ and Pod.
-  docker pull gcr.io/k8s-prow/mkpj:latest
-  docker run -i --rm --user "$(id -u):$(id -g)" -v "${PWD}:${PWD}" -v "${config}:${config}" ${job_config_mnt} -w "${PWD}" gcr.io/k8s-prow/mkpj:latest "--config-path=${config}" "--job=${job}" ${job_config_flag} > "${PWD}/pj.yaml"
-  docker pull gcr.io/k8s-prow/mkpod:latest
-  docker run -i --rm --user "$(id -u):$(id -g)" -v "${PWD}:${PWD}" -w "${PWD}" gcr.io/k8s-prow/mkpod:latest --build-id=snowflake "--prow-job=${PWD}/pj.yaml" --local "--out-dir=${out_dir}/${job}" > "${PWD}/pod.yaml"
+  "${runtime}" pull gcr.io/k8s-prow/mkpj:latest
+  "${runtime}" run -i --rm "${user_flags[@]}" -v "${PWD}:${PWD}" -v "${config}:${config}" ${job_config_mnt} -w "${PWD}" gcr.io/k8s-prow/mkpj:latest "--config-path=${config}" "--job=${job}" ${job_config_flag} > "${PWD}/pj.yaml"
+  "${runtime}" pull gcr.io/k8s-prow/mkpod:latest
+  "${runtime}" run -i --rm "${user_flags[@]}" -v "${PWD}:${PWD}" -w "${PWD}" gcr.io/k8s-prow/mkpod:latest --build-id=snowflake "--prow-job=${PWD}/pj.yaml" --local "--out-dir=${out_dir}/${job}" > "${PWD}/pod.yaml"
 
   # Add any k8s resources that the pod depends on to the kind cluster here. (secrets, configmaps, etc.)
 
@@ -49,6 +49,7 @@ function parseArgs() {
   out_dir="${OUT_DIR:-/mnt/disks/prowjob-out}"
   kind_config="${KIND_CONFIG:-}"
   node_dir="${NODE_DIR:-/mnt/disks/kind-node}"  # Any pod hostPath mounts should be under this dir to reach the true host via the kind node.
+  runtime="${CONTAINER_RUNTIME:-docker}"
 
   local new_only="  (Only used when creating a new kind cluster.)"
   echo "job=${job}"
@@ -57,6 +58,7 @@ function parseArgs() {
   echo "OUT_DIR=${out_dir} ${new_only}"
   echo "KIND_CONFIG=${kind_config} ${new_only}"
   echo "NODE_DIR=${node_dir} ${new_only}"
+  echo "CONTAINER_RUNTIME=${runtime}"
 
   if [[ -z "${job}" ]]; then
     echo "Must specify a job name as the first argument."
@@ -66,6 +68,28 @@ function parseArgs() {
     echo "Must specify config.yaml location via CONFIG_PATH env var."
     exit 2
   fi
+  case "${runtime}" in
+    docker|nerdctl)
+      user_flags=(--user "$(id -u):$(id -g)")
+      ;;
+    podman)
+      # Map the user into the container so that files written to the mounted
+      # working directory are owned by them, also when podman runs rootless.
+      user_flags=(--userns=keep-id --user "$(id -u):$(id -g)")
+      ;;
+    *)
+      echo "CONTAINER_RUNTIME must be one of docker, podman or nerdctl."
+      exit 2
+      ;;
+  esac
+  if ! command -v "${runtime}" >/dev/null 2>&1; then
+    echo "Container runtime ${runtime} is not installed."
+    exit 2
+  fi
+  if [[ "${runtime}" != "docker" ]]; then
+    # kind creates the cluster nodes with docker unless told otherwise.
+    export KIND_EXPERIMENTAL_PROVIDER="${runtime}"
+  fi
   job_config_flag=""
   job_config_mnt=""
   if [[ -n "${job_config_path}" ]]; then
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pjutil
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
+)
+
+const (
+	// sidecarContainerName is the name decorate gives to the sidecar container.
+	sidecarContainerName = "sidecar"
+	// entrypointInternalErrorCode is the exit code of entrypoint when the
+	// command of the container could not be started.
+	entrypointInternalErrorCode = 127
+	// evictedReason is the reason of the status of evicted pods.
+	evictedReason = "Evicted"
+)
+
+// configWaitingReasons are the reasons of waiting containers that can only be
+// fixed by changing the job.
+var configWaitingReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// Classification is the outcome of the failure classification of a job.
+type Classification struct {
+	Class prowapi.FailureClassification
+	// Message is a short, human readable explanation of Class. Unlike the
+	// machine-readable FailureReason in the status of the job, it can come
+	// from the configured rules.
+	Message string
+}
+
+// FailureClassifier classifies why a job failed or errored.
+type FailureClassifier interface {
+	// ClassifyFailure returns nil if the classifier can't tell why the job
+	// failed. pod is nil if the job has no pod.
+	ClassifyFailure(pj *prowapi.ProwJob, pod *corev1.Pod) *Classification
+}
+
+// FailureClassifierFunc adapts a function to the FailureClassifier interface.
+type FailureClassifierFunc func(pj *prowapi.ProwJob, pod *corev1.Pod) *Classification
+
+// ClassifyFailure calls f.
+func (f FailureClassifierFunc) ClassifyFailure(pj *prowapi.ProwJob, pod *corev1.Pod) *Classification {
+	return f(pj, pod)
+}
+
+var (
+	failureClassifiersLock sync.RWMutex
+	failureClassifiers     []FailureClassifier
+)
+
+// RegisterFailureClassifier registers a classifier that is consulted after the
+// configured ones but before the built-in classification of the pod status.
+// Classifiers are consulted in the order they were registered.
+func RegisterFailureClassifier(c FailureClassifier) {
+	failureClassifiersLock.Lock()
+	defer failureClassifiersLock.Unlock()
+	failureClassifiers = append(failureClassifiers, c)
+}
+
+// ClassifyFailure classifies the failure of the completed job pj using the
+// configured rules, the registered classifiers and finally the status of its
+// pod. It returns nil for jobs that neither failed nor errored.
+func ClassifyFailure(pj *prowapi.ProwJob, pod *corev1.Pod, rules []config.FailureClassifier) *Classification {
+	if pj.Status.State != prowapi.FailureState && pj.Status.State != prowapi.ErrorState {
+		return nil
+	}
+
+	classifiers := []FailureClassifier{ruleClassifier(rules)}
+	failureClassifiersLock.RLock()
+	classifiers = append(classifiers, failureClassifiers...)
+	failureClassifiersLock.RUnlock()
+	classifiers = append(classifiers, FailureClassifierFunc(classifyPod))
+	for _, classifier := range classifiers {
+		if c := classifier.ClassifyFailure(pj, pod); c != nil {
+			return c
+		}
+	}
+
+	if pj.Status.State == prowapi.ErrorState {
+		return &Classification{Class: prowapi.InfraFailure, Message: "The job errored."}
+	}
+	return &Classification{Class: prowapi.TestFailure, Message: "The job failed."}
+}
+
+// ruleClassifier matches the configured rules against the description of the
+// job and the termination messages of its containers. Decorated containers
+// fall back to the tail of their logs as termination message.
+func ruleClassifier(rules []config.FailureClassifier) FailureClassifier {
+	return FailureClassifierFunc(func(pj *prowapi.ProwJob, pod *corev1.Pod) *Classification {
+		messages := []string{pj.Status.Description}
+		if pod != nil {
+			for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+				if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+					messages = append(messages, status.State.Terminated.Message)
+				}
+			}
+		}
+		for _, rule := range rules {
+			if rule.Re == nil {
+				continue
+			}
+			for _, message := range messages {
+				if match := rule.Re.FindString(message); match != "" {
+					explanation := rule.Message
+					if explanation == "" {
+						explanation = fmt.Sprintf("Found %q.", match)
+					}
+					return &Classification{Class: rule.Classification, Message: explanation}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// classifyPod classifies the failure based on the conditions of the pod and
+// the states of its containers.
+func classifyPod(_ *prowapi.ProwJob, pod *corev1.Pod) *Classification {
+	if pod == nil {
+		return nil
+	}
+	if pod.Status.Reason == evictedReason {
+		return &Classification{Class: prowapi.InfraFailure, Message: "The pod was evicted."}
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+			return &Classification{Class: prowapi.InfraFailure, Message: "The pod could not be scheduled."}
+		}
+	}
+
+	for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		if waiting := status.State.Waiting; waiting != nil && configWaitingReasons[waiting.Reason] {
+			return &Classification{Class: prowapi.ConfigFailure, Message: fmt.Sprintf("Container %s could not be started: %s.", status.Name, waiting.Reason)}
+		}
+	}
+	for _, status := range pod.Status.InitContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return &Classification{Class: prowapi.InfraFailure, Message: fmt.Sprintf("Init container %s exited with code %d.", status.Name, terminated.ExitCode)}
+		}
+	}
+
+	var sidecarFailed bool
+	for _, status := range pod.Status.ContainerStatuses {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+		switch {
+		case status.Name == sidecarContainerName:
+			sidecarFailed = true
+		case terminated.Reason == "OOMKilled":
+			return &Classification{Class: prowapi.TestFailure, Message: fmt.Sprintf("Container %s ran out of memory.", status.Name)}
+		case terminated.ExitCode == entrypointInternalErrorCode:
+			return &Classification{Class: prowapi.ConfigFailure, Message: fmt.Sprintf("The command of container %s could not be started.", status.Name)}
+		default:
+			return &Classification{Class: prowapi.TestFailure, Message: fmt.Sprintf("Container %s exited with code %d.", status.Name, terminated.ExitCode)}
+		}
+	}
+	if sidecarFailed {
+		return &Classification{Class: prowapi.InfraFailure, Message: "The sidecar failed to report the results."}
+	}
+	return nil
+}
+
+// SetFailureDetails records the test summary of the completed job pj and, if
+// it did not succeed, why it failed in its status. The details come from the
+// termination message of the sidecar container of its pod, which may be nil,
+// and the states of the other containers. Failure reasons that are already
+// set, e.g. because the pod could not be started, are kept.
+func SetFailureDetails(pj *prowapi.ProwJob, pod *corev1.Pod) {
+	var message *results.TerminationMessage
+	var oomKilled, commandFailed bool
+	if pod != nil {
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if terminated == nil {
+				continue
+			}
+			switch {
+			case status.Name == sidecarContainerName:
+				// Sidecar writes no message if it fails before the job finished, then
+				// kubernetes uses the tail of its logs which does not parse.
+				message, _ = results.ParseTerminationMessage(terminated.Message)
+			case terminated.Reason == "OOMKilled":
+				oomKilled = true
+			case terminated.ExitCode != 0:
+				commandFailed = true
+			}
+		}
+	}
+	if message != nil && message.TestSummary != nil {
+		pj.Status.TestSummary = message.TestSummary
+	}
+	if pj.Status.State == prowapi.SuccessState {
+		return
+	}
+	if pj.Status.FailureReason == "" {
+		switch {
+		case oomKilled:
+			pj.Status.FailureReason = prowapi.OOMKilledReason
+		case message != nil && message.FailureReason != "":
+			pj.Status.FailureReason = message.FailureReason
+		case commandFailed:
+			pj.Status.FailureReason = prowapi.CommandFailedReason
+		}
+	}
+	if message != nil && message.ErrorSnippet != "" {
+		pj.Status.ErrorSnippet = message.ErrorSnippet
+	}
+}
+
+// SetFailureClassification records c in the annotations of pj.
+func SetFailureClassification(pj *prowapi.ProwJob, c Classification) {
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	pj.Annotations[kube.FailureClassificationAnnotation] = string(c.Class)
+	pj.Annotations[kube.FailureClassificationMessageAnnotation] = c.Message
+}
+
+// GetFailureClassification returns the classification recorded in the
+// annotations of pj, or nil if it has none.
+func GetFailureClassification(pj *prowapi.ProwJob) *Classification {
+	class, ok := pj.Annotations[kube.FailureClassificationAnnotation]
+	if !ok {
+		return nil
+	}
+	return &Classification{Class: prowapi.FailureClassification(class), Message: pj.Annotations[kube.FailureClassificationMessageAnnotation]}
+}
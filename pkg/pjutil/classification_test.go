@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pjutil
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	terminated := func(name string, exitCode int32, reason, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Reason: reason, Message: message}}}
+	}
+	rules := []config.FailureClassifier{
+		{Pattern: "connection reset by peer", Classification: prowapi.InfraFailure, Message: "Network flake."},
+		{Pattern: "quota exceeded", Classification: prowapi.InfraFailure},
+	}
+	for i := range rules {
+		rules[i].Re = regexp.MustCompile(rules[i].Pattern)
+	}
+
+	testCases := []struct {
+		name     string
+		state    prowapi.ProwJobState
+		pod      *corev1.Pod
+		expected *Classification
+	}{
+		{
+			name:  "successful job",
+			state: prowapi.SuccessState,
+			pod:   &corev1.Pod{},
+		},
+		{
+			name:     "failed job without pod",
+			state:    prowapi.FailureState,
+			expected: &Classification{Class: prowapi.TestFailure, Message: "The job failed."},
+		},
+		{
+			name:     "errored job without pod",
+			state:    prowapi.ErrorState,
+			expected: &Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
+		},
+		{
+			name:     "evicted pod",
+			state:    prowapi.ErrorState,
+			pod:      &corev1.Pod{Status: corev1.PodStatus{Reason: "Evicted"}},
+			expected: &Classification{Class: prowapi.InfraFailure, Message: "The pod was evicted."},
+		},
+		{
+			name:  "unschedulable pod",
+			state: prowapi.ErrorState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable},
+			}}},
+			expected: &Classification{Class: prowapi.InfraFailure, Message: "The pod could not be scheduled."},
+		},
+		{
+			name:  "image can not be pulled",
+			state: prowapi.ErrorState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "test", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+			}}},
+			expected: &Classification{Class: prowapi.ConfigFailure, Message: "Container test could not be started: ImagePullBackOff."},
+		},
+		{
+			name:  "init container failed",
+			state: prowapi.FailureState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{
+				terminated("clonerefs", 1, "Error", ""),
+			}}},
+			expected: &Classification{Class: prowapi.InfraFailure, Message: "Init container clonerefs exited with code 1."},
+		},
+		{
+			name:  "test failed",
+			state: prowapi.FailureState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				terminated("test", 2, "Error", "FAIL: TestFoo"),
+				terminated("sidecar", 1, "Error", ""),
+			}}},
+			expected: &Classification{Class: prowapi.TestFailure, Message: "Container test exited with code 2."},
+		},
+		{
+			name:  "test ran out of memory",
+			state: prowapi.FailureState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				terminated("test", 137, "OOMKilled", ""),
+			}}},
+			expected: &Classification{Class: prowapi.TestFailure, Message: "Container test ran out of memory."},
+		},
+		{
+			name:  "command not found",
+			state: prowapi.FailureState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				terminated("test", 127, "Error", ""),
+			}}},
+			expected: &Classification{Class: prowapi.ConfigFailure, Message: "The command of container test could not be started."},
+		},
+		{
+			name:  "only the sidecar failed",
+			state: prowapi.FailureState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				terminated("test", 0, "Completed", ""),
+				terminated("sidecar", 1, "Error", ""),
+			}}},
+			expected: &Classification{Class: prowapi.InfraFailure, Message: "The sidecar failed to report the results."},
+		},
+		{
+			name:  "configured rule takes precedence",
+			state: prowapi.FailureState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				terminated("test", 1, "Error", "read tcp: connection reset by peer"),
+			}}},
+			expected: &Classification{Class: prowapi.InfraFailure, Message: "Network flake."},
+		},
+		{
+			name:  "configured rule without reason",
+			state: prowapi.FailureState,
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				terminated("test", 1, "Error", "error: quota exceeded for cpus"),
+			}}},
+			expected: &Classification{Class: prowapi.InfraFailure, Message: `Found "quota exceeded".`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{Status: prowapi.ProwJobStatus{State: tc.state}}
+			got := ClassifyFailure(pj, tc.pod, rules)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("unexpected classification (-want +got):\n%s", diff)
+			}
+			if got == nil {
+				return
+			}
+			SetFailureClassification(pj, *got)
+			if diff := cmp.Diff(got, GetFailureClassification(pj)); diff != "" {
+				t.Errorf("classification didn't survive the round trip through the annotations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetFailureDetails(t *testing.T) {
+	terminated := func(name string, exitCode int32, reason, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Reason: reason, Message: message}}}
+	}
+	podWith := func(statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: statuses}}
+	}
+	failedTests := `{"failure_reason":"tests_failed","test_summary":{"total":2,"passed":1,"failed":1,"skipped":0},"error_snippet":"TestBad: boom"}`
+
+	testCases := []struct {
+		name     string
+		status   prowapi.ProwJobStatus
+		pod      *corev1.Pod
+		expected prowapi.ProwJobStatus
+	}{
+		{
+			name:   "successful job records the test summary only",
+			status: prowapi.ProwJobStatus{State: prowapi.SuccessState},
+			pod:    podWith(terminated("test", 0, "", ""), terminated("sidecar", 0, "", `{"test_summary":{"total":1,"passed":1,"failed":0,"skipped":0}}`)),
+			expected: prowapi.ProwJobStatus{
+				State:       prowapi.SuccessState,
+				TestSummary: &prowapi.TestSummary{Total: 1, Passed: 1},
+			},
+		},
+		{
+			name:   "failed tests",
+			status: prowapi.ProwJobStatus{State: prowapi.FailureState},
+			pod:    podWith(terminated("test", 1, "", ""), terminated("sidecar", 1, "", failedTests)),
+			expected: prowapi.ProwJobStatus{
+				State:         prowapi.FailureState,
+				FailureReason: prowapi.TestsFailedReason,
+				TestSummary:   &prowapi.TestSummary{Total: 2, Passed: 1, Failed: 1},
+				ErrorSnippet:  "TestBad: boom",
+			},
+		},
+		{
+			name:   "running out of memory takes precedence",
+			status: prowapi.ProwJobStatus{State: prowapi.FailureState},
+			pod:    podWith(terminated("test", 137, "OOMKilled", ""), terminated("sidecar", 1, "", failedTests)),
+			expected: prowapi.ProwJobStatus{
+				State:         prowapi.FailureState,
+				FailureReason: prowapi.OOMKilledReason,
+				TestSummary:   &prowapi.TestSummary{Total: 2, Passed: 1, Failed: 1},
+				ErrorSnippet:  "TestBad: boom",
+			},
+		},
+		{
+			name:   "failed command without termination message",
+			status: prowapi.ProwJobStatus{State: prowapi.FailureState},
+			pod:    podWith(terminated("test", 2, "", ""), terminated("sidecar", 1, "Error", `{"level":"error","msg":"upload failed"}`)),
+			expected: prowapi.ProwJobStatus{
+				State:         prowapi.FailureState,
+				FailureReason: prowapi.CommandFailedReason,
+			},
+		},
+		{
+			name:   "reason set by the controller is kept",
+			status: prowapi.ProwJobStatus{State: prowapi.AbortedState, FailureReason: prowapi.TimedOutReason},
+			pod:    podWith(terminated("test", 130, "", "")),
+			expected: prowapi.ProwJobStatus{
+				State:         prowapi.AbortedState,
+				FailureReason: prowapi.TimedOutReason,
+			},
+		},
+		{
+			name:   "errored job without pod",
+			status: prowapi.ProwJobStatus{State: prowapi.ErrorState, FailureReason: prowapi.PodErrorReason},
+			expected: prowapi.ProwJobStatus{
+				State:         prowapi.ErrorState,
+				FailureReason: prowapi.PodErrorReason,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{Status: tc.status}
+			SetFailureDetails(pj, tc.pod)
+			if diff := cmp.Diff(tc.expected, pj.Status); diff != "" {
+				t.Errorf("unexpected status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -35,6 +35,9 @@ var RetestRe = regexp.MustCompile(`(?m)^/retest\s*$`)
 // RetestRe provides the regex for `/retest-required`
 var RetestRequiredRe = regexp.MustCompile(`(?m)^/retest-required\s*$`)
 
+// TestRequiredRe provides the regex for `/test-required`
+var TestRequiredRe = regexp.MustCompile(`(?m)^/test-required\s*$`)
+
 var OkToTestRe = regexp.MustCompile(`(?m)^/ok-to-test\s*$`)
 
 // AvailablePresubmits returns 3 sets of presubmits:
@@ -217,6 +220,34 @@ func FilterPresubmits(filter Filter, changes config.ChangedFilesProvider, branch
 	return toTrigger, nil
 }
 
+// AddDependencies appends the presubmits that the presubmits in toRun depend
+// on, directly or transitively, and that are not part of toRun yet. Only the
+// dependencies that could run against the branch are added, plank aborts the
+// jobs whose dependencies are never triggered.
+func AddDependencies(toRun []config.Presubmit, presubmits []config.Presubmit, branch string) []config.Presubmit {
+	byName := map[string]config.Presubmit{}
+	for _, presubmit := range presubmits {
+		if presubmit.CouldRun(branch) {
+			byName[presubmit.Name] = presubmit
+		}
+	}
+	names := sets.New[string]()
+	for _, presubmit := range toRun {
+		names.Insert(presubmit.Name)
+	}
+	for i := 0; i < len(toRun); i++ {
+		for _, name := range toRun[i].DependsOn {
+			dependency, found := byName[name]
+			if !found || names.Has(name) {
+				continue
+			}
+			toRun = append(toRun, dependency)
+			names.Insert(name)
+		}
+	}
+	return toRun
+}
+
 // RetestFilter builds a filter for `/retest`
 type RetestFilter struct {
 	failedContexts, allContexts sets.Set[string]
@@ -260,6 +291,25 @@ func (rrf *RetestRequiredFilter) Name() string {
 	return "retest-required-filter"
 }
 
+// TestRequiredFilter builds a filter for `/test-required`
+type TestRequiredFilter struct {
+	blockingContexts sets.Set[string]
+}
+
+// NewTestRequiredFilter returns a filter matching the presubmits producing
+// the given contexts, which are required to merge and failing or missing.
+func NewTestRequiredFilter(blockingContexts sets.Set[string]) *TestRequiredFilter {
+	return &TestRequiredFilter{blockingContexts: blockingContexts}
+}
+
+func (trf *TestRequiredFilter) ShouldRun(p config.Presubmit) (bool, bool, bool) {
+	return trf.blockingContexts.Has(p.Context), false, true
+}
+
+func (trf *TestRequiredFilter) Name() string {
+	return "test-required-filter"
+}
+
 type contextGetter func() (sets.Set[string], sets.Set[string], error)
 
 // PresubmitFilter creates a filter for presubmits
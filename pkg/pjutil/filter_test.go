@@ -417,6 +417,72 @@ func TestFilterPresubmits(t *testing.T) {
 	}
 }
 
+func TestAddDependencies(t *testing.T) {
+	presubmit := func(name string, branches []string, dependsOn ...string) config.Presubmit {
+		return config.Presubmit{
+			JobBase:  config.JobBase{Name: name, DependsOn: dependsOn},
+			Brancher: config.Brancher{Branches: branches},
+		}
+	}
+	presubmits := []config.Presubmit{
+		presubmit("build", nil),
+		presubmit("image", nil, "build"),
+		presubmit("e2e", nil, "image", "build"),
+		presubmit("release-build", []string{"release"}),
+		presubmit("release-e2e", nil, "release-build"),
+	}
+	if err := config.SetPresubmitRegexes(presubmits); err != nil {
+		t.Fatalf("failed to set presubmit regexes: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		toRun    []string
+		expected []string
+	}{
+		{
+			name:     "jobs without dependencies are kept",
+			toRun:    []string{"build"},
+			expected: []string{"build"},
+		},
+		{
+			name:     "dependencies are added transitively",
+			toRun:    []string{"e2e"},
+			expected: []string{"e2e", "image", "build"},
+		},
+		{
+			name:     "dependencies that already run are not duplicated",
+			toRun:    []string{"build", "image"},
+			expected: []string{"build", "image"},
+		},
+		{
+			name:     "dependencies that could not run against the branch are not added",
+			toRun:    []string{"release-e2e"},
+			expected: []string{"release-e2e"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var toRun []config.Presubmit
+			for _, name := range tc.toRun {
+				for _, ps := range presubmits {
+					if ps.Name == name {
+						toRun = append(toRun, ps)
+					}
+				}
+			}
+			var actual []string
+			for _, ps := range AddDependencies(toRun, presubmits, "main") {
+				actual = append(actual, ps.Name)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected presubmits (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 type orgRepoRef struct {
 	org, repo, ref string
 }
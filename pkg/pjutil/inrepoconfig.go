@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pjutil
+
+import (
+	"fmt"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// InRepoConfigSpec resolves the job with the given name from the in-repo
+// config of the repo of the refs at their base SHA and returns its spec.
+// If the refs contain pulls, the job is looked up among the presubmits with
+// the pulls merged in, otherwise among the postsubmits.
+// The base SHA and the SHAs of the pulls must be set.
+func InRepoConfigSpec(getter config.InRepoConfigGetter, jobName string, refs prowapi.Refs) (config.JobBase, prowapi.ProwJobSpec, error) {
+	if refs.BaseSHA == "" {
+		return config.JobBase{}, prowapi.ProwJobSpec{}, fmt.Errorf("the base SHA of %s is required to read its in-repo config", refs.Repo)
+	}
+	identifier := refs.Org + "/" + refs.Repo
+	baseSHAGetter := func() (string, error) {
+		return refs.BaseSHA, nil
+	}
+
+	if len(refs.Pulls) == 0 {
+		postsubmits, err := getter.GetPostsubmits(identifier, refs.BaseRef, baseSHAGetter)
+		if err != nil {
+			return config.JobBase{}, prowapi.ProwJobSpec{}, fmt.Errorf("failed to get postsubmits of %s: %w", identifier, err)
+		}
+		for _, p := range postsubmits {
+			if p.Name == jobName {
+				return p.JobBase, PostsubmitSpec(p, refs), nil
+			}
+		}
+		return config.JobBase{}, prowapi.ProwJobSpec{}, fmt.Errorf("postsubmit %s not found in %s at %s", jobName, identifier, refs.BaseSHA)
+	}
+
+	var headSHAGetters []config.RefGetter
+	for _, pull := range refs.Pulls {
+		if pull.SHA == "" {
+			return config.JobBase{}, prowapi.ProwJobSpec{}, fmt.Errorf("the SHA of pull %d is required to read its in-repo config", pull.Number)
+		}
+		sha := pull.SHA
+		headSHAGetters = append(headSHAGetters, func() (string, error) {
+			return sha, nil
+		})
+	}
+	presubmits, err := getter.GetPresubmits(identifier, refs.BaseRef, baseSHAGetter, headSHAGetters...)
+	if err != nil {
+		return config.JobBase{}, prowapi.ProwJobSpec{}, fmt.Errorf("failed to get presubmits of %s: %w", identifier, err)
+	}
+	for _, p := range presubmits {
+		if p.Name == jobName {
+			return p.JobBase, PresubmitSpec(p, refs), nil
+		}
+	}
+	return config.JobBase{}, prowapi.ProwJobSpec{}, fmt.Errorf("presubmit %s not found in %s at %s", jobName, identifier, refs.BaseSHA)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pjutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+type fakeInRepoConfigGetter struct {
+	presubmits  []config.Presubmit
+	postsubmits []config.Postsubmit
+	// shas records the SHAs the in-repo config was read at.
+	shas []string
+}
+
+func (f *fakeInRepoConfigGetter) record(baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) error {
+	for _, getter := range append([]config.RefGetter{baseSHAGetter}, headSHAGetters...) {
+		sha, err := getter()
+		if err != nil {
+			return err
+		}
+		f.shas = append(f.shas, sha)
+	}
+	return nil
+}
+
+func (f *fakeInRepoConfigGetter) GetInRepoConfig(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) (*config.ProwYAML, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeInRepoConfigGetter) GetPresubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Presubmit, error) {
+	return f.presubmits, f.record(baseSHAGetter, headSHAGetters...)
+}
+
+func (f *fakeInRepoConfigGetter) GetPostsubmits(identifier, baseBranch string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) ([]config.Postsubmit, error) {
+	return f.postsubmits, f.record(baseSHAGetter, headSHAGetters...)
+}
+
+func TestInRepoConfigSpec(t *testing.T) {
+	testCases := []struct {
+		name         string
+		jobName      string
+		refs         prowapi.Refs
+		expectedType prowapi.ProwJobType
+		expectedSHAs []string
+		expectErr    bool
+	}{
+		{
+			name:         "presubmit",
+			jobName:      "pull-test",
+			refs:         prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "base", Pulls: []prowapi.Pull{{Number: 1, SHA: "head"}}},
+			expectedType: prowapi.PresubmitJob,
+			expectedSHAs: []string{"base", "head"},
+		},
+		{
+			name:         "postsubmit",
+			jobName:      "post-test",
+			refs:         prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "base"},
+			expectedType: prowapi.PostsubmitJob,
+			expectedSHAs: []string{"base"},
+		},
+		{
+			name:      "postsubmits are not looked up for pulls",
+			jobName:   "post-test",
+			refs:      prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "base", Pulls: []prowapi.Pull{{Number: 1, SHA: "head"}}},
+			expectErr: true,
+		},
+		{
+			name:      "base SHA is required",
+			jobName:   "post-test",
+			refs:      prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main"},
+			expectErr: true,
+		},
+		{
+			name:      "pull SHA is required",
+			jobName:   "pull-test",
+			refs:      prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "base", Pulls: []prowapi.Pull{{Number: 1}}},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := &fakeInRepoConfigGetter{
+				presubmits:  []config.Presubmit{{JobBase: config.JobBase{Name: "pull-test"}}},
+				postsubmits: []config.Postsubmit{{JobBase: config.JobBase{Name: "post-test"}}},
+			}
+			job, spec, err := InRepoConfigSpec(getter, tc.jobName, tc.refs)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if job.Name != tc.jobName || spec.Job != tc.jobName {
+				t.Errorf("expected job %s, got %s with spec for %s", tc.jobName, job.Name, spec.Job)
+			}
+			if spec.Type != tc.expectedType {
+				t.Errorf("expected type %s, got %s", tc.expectedType, spec.Type)
+			}
+			if diff := cmp.Diff(tc.expectedSHAs, getter.shas); diff != "" {
+				t.Errorf("unexpected SHAs the in-repo config was read at (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -247,6 +247,8 @@ func specFromJobBase(jb config.JobBase) prowapi.ProwJobSpec {
 		Hidden:          jb.Hidden,
 		ProwJobDefault:  jb.ProwJobDefault,
 		JobQueueName:    jb.JobQueueName,
+		DependsOn:       jb.DependsOn,
+		Retry:           jb.Retry,
 	}
 }
 
@@ -269,6 +271,9 @@ func DecorateRefs(refs prowapi.Refs, jb config.JobBase) *prowapi.Refs {
 	if refs.BloblessFetch == nil {
 		refs.BloblessFetch = dc.BloblessFetch
 	}
+	if refs.SubmoduleDepth == 0 && dc.SubmoduleDepth != nil {
+		refs.SubmoduleDepth = *dc.SubmoduleDepth
+	}
 	return &refs
 }
 
@@ -288,6 +293,16 @@ func CompletePrimaryRefs(refs prowapi.Refs, jb config.JobBase) *prowapi.Refs {
 	if jb.SkipFetchHead {
 		refs.SkipFetchHead = jb.SkipFetchHead
 	}
+	// Sparse checkouts and submodule paths are specific to a repository, so
+	// they are only defaulted for the primary refs.
+	if dc := jb.DecorationConfig; dc != nil {
+		if refs.SparseCheckout == nil {
+			refs.SparseCheckout = dc.SparseCheckout
+		}
+		if refs.SubmoduleDepths == nil {
+			refs.SubmoduleDepths = dc.SubmoduleDepths
+		}
+	}
 	return DecorateRefs(refs, jb)
 }
 
@@ -349,6 +349,45 @@ func TestCompletePrimaryRefs(t *testing.T) {
 				CloneDepth: 2,
 			},
 		},
+		{
+			name: "use clone options from decoration config",
+			jobBase: config.JobBase{
+				UtilityConfig: config.UtilityConfig{
+					DecorationConfig: &prowapi.DecorationConfig{
+						SparseCheckout:  []string{"pkg"},
+						SubmoduleDepth:  intPtr(1),
+						SubmoduleDepths: map[string]int{"vendor/lib": 0},
+					},
+				},
+			},
+			expected: prowapi.Refs{
+				SparseCheckout:  []string{"pkg"},
+				SubmoduleDepth:  1,
+				SubmoduleDepths: map[string]int{"vendor/lib": 0},
+			},
+		},
+		{
+			name: "prefer clone options from refs",
+			refs: prowapi.Refs{
+				SparseCheckout:  []string{"cmd"},
+				SubmoduleDepth:  5,
+				SubmoduleDepths: map[string]int{"third_party": 10},
+			},
+			jobBase: config.JobBase{
+				UtilityConfig: config.UtilityConfig{
+					DecorationConfig: &prowapi.DecorationConfig{
+						SparseCheckout:  []string{"pkg"},
+						SubmoduleDepth:  intPtr(1),
+						SubmoduleDepths: map[string]int{"vendor/lib": 0},
+					},
+				},
+			},
+			expected: prowapi.Refs{
+				SparseCheckout:  []string{"cmd"},
+				SubmoduleDepth:  5,
+				SubmoduleDepths: map[string]int{"third_party": 10},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1607,6 +1646,30 @@ func TestSpecFromJobBase(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "Verify dependencies get copied",
+			jobBase: config.JobBase{
+				DependsOn: []string{"build", "unit"},
+			},
+			verify: func(pj prowapi.ProwJobSpec) error {
+				if diff := cmp.Diff([]string{"build", "unit"}, pj.DependsOn); diff != "" {
+					return fmt.Errorf("DependsOn differs (-want +got)\n%s", diff)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Verify retry policy gets copied",
+			jobBase: config.JobBase{
+				Retry: &prowapi.RetryPolicy{Attempts: 2, On: []prowapi.ProwJobState{prowapi.AbortedState}},
+			},
+			verify: func(pj prowapi.ProwJobSpec) error {
+				if diff := cmp.Diff(&prowapi.RetryPolicy{Attempts: 2, On: []prowapi.ProwJobState{prowapi.AbortedState}}, pj.Retry); diff != "" {
+					return fmt.Errorf("Retry differs (-want +got)\n%s", diff)
+				}
+				return nil
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1643,3 +1706,7 @@ func TestPeriodicSpec(t *testing.T) {
 		}
 	}
 }
+
+func intPtr(i int) *int {
+	return &i
+}
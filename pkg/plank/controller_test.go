@@ -61,6 +61,7 @@ const (
 	podPendingTimeout     = time.Hour
 	podRunningTimeout     = time.Hour * 2
 	podUnscheduledTimeout = time.Minute * 5
+	dependencyGracePeriod = time.Minute * 10
 
 	podDeletionPreventionFinalizer = "keep-from-vanishing"
 )
@@ -106,6 +107,7 @@ func newFakeConfigAgent(t *testing.T, maxConcurrency int, queueCapacities map[st
 					PodPendingTimeout:     &metav1.Duration{Duration: podPendingTimeout},
 					PodRunningTimeout:     &metav1.Duration{Duration: podRunningTimeout},
 					PodUnscheduledTimeout: &metav1.Duration{Duration: podUnscheduledTimeout},
+					DependencyGracePeriod: &metav1.Duration{Duration: dependencyGracePeriod},
 				},
 			},
 			JobConfig: config.JobConfig{
@@ -313,6 +315,8 @@ func handleTot(w http.ResponseWriter, r *http.Request) {
 func TestSyncTriggeredJobs(t *testing.T) {
 	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(1 * time.Second))
 	pendingTime := metav1.NewTime(fakeClock.Now())
+	recentRetryTime := metav1.NewTime(fakeClock.Now().Add(-10 * time.Second))
+	pastRetryTime := metav1.NewTime(fakeClock.Now().Add(-time.Minute))
 
 	type testCase struct {
 		Name string
@@ -359,6 +363,56 @@ func TestSyncTriggeredJobs(t *testing.T) {
 			ExpectedURL:         "blabla/pending",
 			ExpectedBuildID:     "0987654321",
 		},
+		{
+			Name: "wait for the retry backoff",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					Retry:   &prowapi.RetryPolicy{Attempts: 2},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:     prowapi.TriggeredState,
+					Retries:   1,
+					RetryTime: &recentRetryTime,
+				},
+			},
+			Pods:            map[string][]v1.Pod{"default": {}},
+			ExpectedState:   prowapi.TriggeredState,
+			ExpectedNumPods: map[string]int{"default": 0},
+		},
+		{
+			Name: "start new pod after the retry backoff",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:     "boop",
+					Type:    prowapi.PeriodicJob,
+					Retry:   &prowapi.RetryPolicy{Attempts: 2},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:     prowapi.TriggeredState,
+					Retries:   1,
+					RetryTime: &pastRetryTime,
+				},
+			},
+			Pods:                map[string][]v1.Pod{"default": {}},
+			ExpectedState:       prowapi.PendingState,
+			ExpectedPendingTime: &pendingTime,
+			ExpectedPodHasName:  true,
+			ExpectedNumPods:     map[string]int{"default": 1},
+			ExpectedURL:         "blabla/pending",
+			ExpectedBuildID:     "0987654321",
+		},
 		{
 			Name: "pod with a max concurrency of 1",
 			PJ: prowapi.ProwJob{
@@ -835,6 +889,196 @@ func TestSyncTriggeredJobs(t *testing.T) {
 	}
 }
 
+func TestSyncTriggeredJobWithDependencies(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(1 * time.Second))
+	refs := &prowapi.Refs{
+		Org:     "org",
+		Repo:    "repo",
+		BaseRef: "main",
+		BaseSHA: "base",
+		Pulls:   []prowapi.Pull{{Number: 1, SHA: "head"}},
+	}
+	otherRefs := refs.DeepCopy()
+	otherRefs.Pulls[0].SHA = "other"
+	dependency := func(name string, state prowapi.ProwJobState, created time.Time, refs *prowapi.Refs) prowapi.ProwJob {
+		pj := prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "prowjobs",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: prowapi.ProwJobSpec{
+				Job:   "build",
+				Type:  prowapi.PresubmitJob,
+				Agent: prowapi.KubernetesAgent,
+				Refs:  refs,
+				DecorationConfig: &prowapi.DecorationConfig{
+					GCSConfiguration: &prowapi.GCSConfiguration{
+						Bucket:       "gs://bucket",
+						PathStrategy: prowapi.PathStrategyExplicit,
+					},
+				},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:   state,
+				BuildID: "42",
+			},
+		}
+		if state != prowapi.TriggeredState && state != prowapi.PendingState {
+			pj.SetComplete()
+		}
+		return pj
+	}
+
+	testCases := []struct {
+		name         string
+		created      time.Time
+		dependencies []prowapi.ProwJob
+
+		expectedState       prowapi.ProwJobState
+		expectedDescription string
+		expectedArtifacts   string
+	}{
+		{
+			name:          "dependency is still running",
+			created:       fakeClock.Now(),
+			dependencies:  []prowapi.ProwJob{dependency("build-1", prowapi.PendingState, fakeClock.Now(), refs)},
+			expectedState: prowapi.TriggeredState,
+		},
+		{
+			name:              "dependency succeeded",
+			created:           fakeClock.Now(),
+			dependencies:      []prowapi.ProwJob{dependency("build-1", prowapi.SuccessState, fakeClock.Now(), refs)},
+			expectedState:     prowapi.PendingState,
+			expectedArtifacts: `{"build":"gs://bucket/pr-logs/pull/org_repo/1/build/42/artifacts"}`,
+		},
+		{
+			name:                "dependency failed",
+			created:             fakeClock.Now(),
+			dependencies:        []prowapi.ProwJob{dependency("build-1", prowapi.FailureState, fakeClock.Now(), refs)},
+			expectedState:       prowapi.AbortedState,
+			expectedDescription: "Dependency build did not succeed.",
+		},
+		{
+			name:    "latest run of the dependency is used",
+			created: fakeClock.Now(),
+			dependencies: []prowapi.ProwJob{
+				dependency("build-1", prowapi.FailureState, fakeClock.Now().Add(-time.Hour), refs),
+				dependency("build-2", prowapi.SuccessState, fakeClock.Now(), refs),
+			},
+			expectedState:     prowapi.PendingState,
+			expectedArtifacts: `{"build":"gs://bucket/pr-logs/pull/org_repo/1/build/42/artifacts"}`,
+		},
+		{
+			name:          "dependency for other refs is ignored",
+			created:       fakeClock.Now(),
+			dependencies:  []prowapi.ProwJob{dependency("build-1", prowapi.SuccessState, fakeClock.Now(), otherRefs)},
+			expectedState: prowapi.TriggeredState,
+		},
+		{
+			name:          "dependency was not triggered yet",
+			created:       fakeClock.Now(),
+			expectedState: prowapi.TriggeredState,
+		},
+		{
+			name:          "dependency was not triggered within the default grace period",
+			created:       fakeClock.Now().Add(-5 * time.Minute),
+			expectedState: prowapi.TriggeredState,
+		},
+		{
+			name:                "dependency was not triggered within the grace period",
+			created:             fakeClock.Now().Add(-dependencyGracePeriod),
+			expectedState:       prowapi.AbortedState,
+			expectedDescription: "Dependency build was not triggered.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			totServ := httptest.NewServer(http.HandlerFunc(handleTot))
+			defer totServ.Close()
+
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test",
+					Namespace:         "prowjobs",
+					CreationTimestamp: metav1.NewTime(tc.created),
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:       "test",
+					Type:      prowapi.PresubmitJob,
+					Agent:     prowapi.KubernetesAgent,
+					Refs:      refs,
+					DependsOn: []string{"build"},
+					PodSpec:   &v1.PodSpec{Containers: []v1.Container{{Name: "test-name"}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			}
+			objects := []runtime.Object{&pj}
+			for i := range tc.dependencies {
+				objects = append(objects, &tc.dependencies[i])
+			}
+
+			ctx := context.Background()
+			config := newFakeConfigAgent(t, 0, nil).Config
+			fakeMgr, err := testutil.NewFakeManager(ctx, objects, func(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+				return setupIndexes(ctx, indexer, config)
+			})
+			if err != nil {
+				t.Fatalf("Failed to setup fake manager: %v", err)
+			}
+			podClient := fakectrlruntimeclient.NewClientBuilder().Build()
+			r := &reconciler{
+				pjClient:     fakeMgr.GetClient(),
+				buildClients: map[string]buildClient{prowapi.DefaultClusterAlias: {Client: podClient}},
+				log:          logrus.NewEntry(logrus.StandardLogger()),
+				config:       config,
+				totURL:       totServ.URL,
+				clock:        fakeClock,
+			}
+			if _, err := r.syncTriggeredJob(ctx, pj.DeepCopy()); err != nil {
+				t.Fatalf("syncTriggeredJob failed: %v", err)
+			}
+
+			var actual prowapi.ProwJob
+			if err := fakeMgr.GetClient().Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(&pj), &actual); err != nil {
+				t.Fatalf("failed to get prowjob from client: %v", err)
+			}
+			if actual.Status.State != tc.expectedState {
+				t.Errorf("expected state %s, got %s", tc.expectedState, actual.Status.State)
+			}
+			if tc.expectedDescription != "" && actual.Status.Description != tc.expectedDescription {
+				t.Errorf("expected description %q, got %q", tc.expectedDescription, actual.Status.Description)
+			}
+
+			pods := &v1.PodList{}
+			if err := podClient.List(ctx, pods); err != nil {
+				t.Fatalf("could not list pods: %v", err)
+			}
+			if tc.expectedState != prowapi.PendingState {
+				if len(pods.Items) != 0 {
+					t.Errorf("expected no pod, got %d", len(pods.Items))
+				}
+				return
+			}
+			if len(pods.Items) != 1 {
+				t.Fatalf("expected one pod, got %d", len(pods.Items))
+			}
+			var artifacts string
+			for _, env := range pods.Items[0].Spec.Containers[0].Env {
+				if env.Name == DependencyArtifactsEnv {
+					artifacts = env.Value
+				}
+			}
+			if artifacts != tc.expectedArtifacts {
+				t.Errorf("expected dependency artifacts %s, got %s", tc.expectedArtifacts, artifacts)
+			}
+		})
+	}
+}
+
 func startTime(s time.Time) *metav1.Time {
 	start := metav1.NewTime(s)
 	return &start
@@ -859,6 +1103,9 @@ func TestSyncPendingJob(t *testing.T) {
 		ExpectedPodRunningTimeout     *metav1.Duration
 		ExpectedPodPendingTimeout     *metav1.Duration
 		ExpectedPodUnscheduledTimeout *metav1.Duration
+		ExpectedRetries               int
+		ExpectedFailureReason         prowapi.FailureReason
+		ExpectedFailureClassification *pjutil.Classification
 	}
 	testcases := []testCase{
 		{
@@ -1006,11 +1253,13 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedComplete:   true,
-			ExpectedState:      prowapi.ErrorState,
-			ExpectedNumPods:    1,
-			ExpectedCreatedPJs: 0,
-			ExpectedURL:        "boop-42/success",
+			ExpectedComplete:              true,
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedNumPods:               1,
+			ExpectedCreatedPJs:            0,
+			ExpectedURL:                   "boop-42/success",
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "succeeded pod with unfinished initcontainers",
@@ -1041,11 +1290,13 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedComplete:   true,
-			ExpectedState:      prowapi.ErrorState,
-			ExpectedNumPods:    1,
-			ExpectedCreatedPJs: 0,
-			ExpectedURL:        "boop-42/success",
+			ExpectedComplete:              true,
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedNumPods:               1,
+			ExpectedCreatedPJs:            0,
+			ExpectedURL:                   "boop-42/success",
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "failed pod",
@@ -1079,10 +1330,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedComplete: true,
-			ExpectedState:    prowapi.FailureState,
-			ExpectedNumPods:  1,
-			ExpectedURL:      "boop-42/failure",
+			ExpectedComplete:              true,
+			ExpectedState:                 prowapi.FailureState,
+			ExpectedNumPods:               1,
+			ExpectedURL:                   "boop-42/failure",
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.TestFailure, Message: "The job failed."},
 		},
 		{
 			Name: "delete evicted pod",
@@ -1175,10 +1427,117 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedComplete: true,
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedNumPods:  1,
-			ExpectedURL:      "boop-42/error",
+			ExpectedComplete:              true,
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedNumPods:               1,
+			ExpectedURL:                   "boop-42/error",
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The pod was evicted."},
+		},
+		{
+			Name: "retry job with evicted pod w/ error_on_eviction",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					ErrorOnEviction: true,
+					Retry:           &prowapi.RetryPolicy{Attempts: 2},
+					PodSpec:         &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+					Retries: 1,
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "boop-42",
+						Namespace:  "pods",
+						Finalizers: []string{"prow.x-k8s.io/gcsk8sreporter"},
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Evicted,
+					},
+				},
+			},
+			ExpectedComplete: false,
+			ExpectedState:    prowapi.TriggeredState,
+			ExpectedNumPods:  0,
+			ExpectedRetries:  2,
+		},
+		{
+			Name: "don't retry job with evicted pod once out of attempts",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					ErrorOnEviction: true,
+					Retry:           &prowapi.RetryPolicy{Attempts: 2},
+					PodSpec:         &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+					Retries: 2,
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase:  v1.PodFailed,
+						Reason: Evicted,
+					},
+				},
+			},
+			ExpectedComplete:              true,
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedNumPods:               1,
+			ExpectedRetries:               2,
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The pod was evicted."},
+		},
+		{
+			Name: "don't retry job with failed pod",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Retry:   &prowapi.RetryPolicy{Attempts: 2, On: []prowapi.ProwJobState{prowapi.ErrorState, prowapi.AbortedState}},
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+					},
+				},
+			},
+			ExpectedComplete:              true,
+			ExpectedState:                 prowapi.FailureState,
+			ExpectedNumPods:               1,
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.TestFailure, Message: "The job failed."},
 		},
 		{
 			Name: "running pod",
@@ -1260,9 +1619,11 @@ func TestSyncPendingJob(t *testing.T) {
 				Code:   http.StatusUnprocessableEntity,
 				Reason: metav1.StatusReasonInvalid,
 			}},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedComplete: true,
-			ExpectedURL:      "jose/error",
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedComplete:              true,
+			ExpectedURL:                   "jose/error",
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.ConfigFailure, Message: "The pod of the job is invalid."},
 		},
 		{
 			Name: "stale pending prow job",
@@ -1290,10 +1651,12 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedNumPods:  0,
-			ExpectedComplete: true,
-			ExpectedURL:      "nightmare/error",
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedNumPods:               0,
+			ExpectedComplete:              true,
+			ExpectedURL:                   "nightmare/error",
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "stale pending prow job with specific podPendingTimeout",
@@ -1325,11 +1688,13 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:             prowapi.ErrorState,
-			ExpectedNumPods:           0,
-			ExpectedComplete:          true,
-			ExpectedURL:               "nightmare/error",
-			ExpectedPodPendingTimeout: &metav1.Duration{Duration: 2 * time.Hour},
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedNumPods:               0,
+			ExpectedComplete:              true,
+			ExpectedURL:                   "nightmare/error",
+			ExpectedPodPendingTimeout:     &metav1.Duration{Duration: 2 * time.Hour},
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "stale running prow job",
@@ -1357,10 +1722,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.AbortedState,
-			ExpectedNumPods:  0,
-			ExpectedComplete: true,
-			ExpectedURL:      "endless/aborted",
+			ExpectedState:         prowapi.AbortedState,
+			ExpectedFailureReason: prowapi.TimedOutReason,
+			ExpectedNumPods:       0,
+			ExpectedComplete:      true,
+			ExpectedURL:           "endless/aborted",
 		},
 		{
 			Name: "stale running prow job with specific podRunningTimeout",
@@ -1393,6 +1759,7 @@ func TestSyncPendingJob(t *testing.T) {
 				},
 			},
 			ExpectedState:             prowapi.AbortedState,
+			ExpectedFailureReason:     prowapi.TimedOutReason,
 			ExpectedNumPods:           0,
 			ExpectedComplete:          true,
 			ExpectedURL:               "endless/aborted",
@@ -1423,10 +1790,12 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedNumPods:  0,
-			ExpectedComplete: true,
-			ExpectedURL:      "homeless/error",
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedNumPods:               0,
+			ExpectedComplete:              true,
+			ExpectedURL:                   "homeless/error",
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "stale unschedulable prow job with specific podUnscheduledTimeout",
@@ -1458,10 +1827,12 @@ func TestSyncPendingJob(t *testing.T) {
 				},
 			},
 			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
 			ExpectedNumPods:               0,
 			ExpectedComplete:              true,
 			ExpectedURL:                   "homeless/error",
 			ExpectedPodUnscheduledTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "pending, created less than podPendingTimeout ago",
@@ -1549,9 +1920,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedComplete: true,
-			ExpectedNumPods:  1,
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedComplete:              true,
+			ExpectedNumPods:               1,
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "Pod deleted in unset phase, job marked as errored",
@@ -1577,9 +1950,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedComplete: true,
-			ExpectedNumPods:  1,
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedComplete:              true,
+			ExpectedNumPods:               1,
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "Pod deleted in running phase, job marked as errored",
@@ -1608,9 +1983,11 @@ func TestSyncPendingJob(t *testing.T) {
 					},
 				},
 			},
-			ExpectedState:    prowapi.ErrorState,
-			ExpectedComplete: true,
-			ExpectedNumPods:  1,
+			ExpectedState:                 prowapi.ErrorState,
+			ExpectedFailureReason:         prowapi.PodErrorReason,
+			ExpectedComplete:              true,
+			ExpectedNumPods:               1,
+			ExpectedFailureClassification: &pjutil.Classification{Class: prowapi.InfraFailure, Message: "The job errored."},
 		},
 		{
 			Name: "Pod deleted with NodeLost reason in running phase, pod finalizer gets cleaned up",
@@ -1749,6 +2126,15 @@ func TestSyncPendingJob(t *testing.T) {
 			if actual := actual.Complete(); actual != tc.ExpectedComplete {
 				t.Errorf("expected complete: %t, got complete: %t", tc.ExpectedComplete, actual)
 			}
+			if actual.Status.Retries != tc.ExpectedRetries {
+				t.Errorf("expected %d retries, got %d", tc.ExpectedRetries, actual.Status.Retries)
+			}
+			if actual.Status.FailureReason != tc.ExpectedFailureReason {
+				t.Errorf("expected failure reason %q, got %q", tc.ExpectedFailureReason, actual.Status.FailureReason)
+			}
+			if diff := cmp.Diff(tc.ExpectedFailureClassification, pjutil.GetFailureClassification(&actual)); diff != "" {
+				t.Errorf("unexpected failure classification (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
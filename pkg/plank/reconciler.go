@@ -47,14 +47,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/buildid"
 	"sigs.k8s.io/prow/pkg/config"
 	kubernetesreporterapi "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes/api"
 	"sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/gcsupload"
 	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/io/providers"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/version"
 )
 
@@ -65,6 +68,20 @@ const (
 	Evicted = "Evicted"
 )
 
+const (
+	// DependencyArtifactsEnv is the environment variable that holds the
+	// storage paths of the artifacts of the dependencies of a job, as a
+	// JSON object keyed by the names of the dependencies.
+	DependencyArtifactsEnv = "DEPENDENCY_ARTIFACTS"
+)
+
+const (
+	// retryBackoff is how long a job waits before it is retried for the
+	// first time. The wait doubles with every retry, up to maxRetryBackoff.
+	retryBackoff    = 30 * time.Second
+	maxRetryBackoff = 10 * time.Minute
+)
+
 // NodeStatus constants
 const (
 	// NodeUnreachablePodReason is the reason on a pod when its state cannot be confirmed as kubelet is unresponsive
@@ -102,9 +119,10 @@ func Add(
 	cfg config.Getter,
 	opener io.Opener,
 	totURL string,
+	buildIDAllocator *buildid.Allocator,
 	additionalSelector string,
 ) error {
-	return add(mgr, buildClusters, knownClusters, cfg, opener, totURL, additionalSelector, nil, nil, 10)
+	return add(mgr, buildClusters, knownClusters, cfg, opener, totURL, buildIDAllocator, additionalSelector, nil, nil, 10)
 }
 
 func add(
@@ -114,6 +132,7 @@ func add(
 	cfg config.Getter,
 	opener io.Opener,
 	totURL string,
+	buildIDAllocator *buildid.Allocator,
 	additionalSelector string,
 	overwriteReconcile reconcile.Func,
 	predicateCallback func(bool),
@@ -138,6 +157,7 @@ func add(
 		WithOptions(controller.Options{MaxConcurrentReconciles: numWorkers})
 
 	r := newReconciler(ctx, mgr.GetClient(), overwriteReconcile, cfg, opener, totURL)
+	r.buildIDAllocator = buildIDAllocator
 	for buildClusterName, buildCluster := range buildClusters {
 		r.log.WithFields(logrus.Fields{
 			"buildCluster": buildClusterName,
@@ -215,6 +235,7 @@ type reconciler struct {
 	config             config.Getter
 	opener             io.Opener
 	totURL             string
+	buildIDAllocator   *buildid.Allocator
 	clock              clock.WithTickerAndDelayedExecution
 	/* maxConcurrencySerializationLocks and jobQueueSerializationLocks are used to serialize
 	   reconciliation of ProwJobs that have concurrency limits that might affect eachother.
@@ -371,6 +392,7 @@ func (r *reconciler) defaultReconcile(ctx context.Context, request reconcile.Req
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = fmt.Sprintf("Terminal error: %v.", err)
+			pjutil.SetFailureClassification(pj, pjutil.Classification{Class: prowv1.ConfigFailure, Message: "The job can not be run with its configuration."})
 			if err := r.pjClient.Patch(ctx, pj, ctrlruntimeclient.MergeFrom(originalPJ)); err != nil {
 				// If we fail to complete and mark the job as errorer we will try again on the next sync loop.
 				log.Errorf("Error marking job with terminal failure as errored: %v.", err)
@@ -462,6 +484,8 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.Status.State = prowv1.ErrorState
 			pj.SetComplete()
 			pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
+			pj.Status.FailureReason = prowv1.PodErrorReason
+			pjutil.SetFailureClassification(pj, pjutil.Classification{Class: prowv1.ConfigFailure, Message: "The pod of the job is invalid."})
 			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warning("Unprocessable pod.")
 		} else {
 			pj.Status.BuildID = id
@@ -477,6 +501,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = "Job pod was evicted by the cluster."
+			pj.Status.FailureReason = prowv1.PodErrorReason
 		} else {
 			// ErrorOnEviction is disabled. Delete the pod now and recreate it in
 			// the next resync.
@@ -549,6 +574,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			} else {
 				pj.Status.State = prowv1.ErrorState
 				pj.Status.Description = "Pod was in succeeded phase but some containers didn't finish"
+				pj.Status.FailureReason = prowv1.PodErrorReason
 			}
 
 		case corev1.PodFailed:
@@ -574,6 +600,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 					pj.SetComplete()
 					pj.Status.State = prowv1.ErrorState
 					pj.Status.Description = "Pod scheduling timeout."
+					pj.Status.FailureReason = prowv1.PodErrorReason
 					r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Marked job for stale unscheduled pod as errored.")
 					if err := r.deletePod(ctx, pj); err != nil {
 						return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
@@ -591,6 +618,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 					pj.SetComplete()
 					pj.Status.State = prowv1.ErrorState
 					pj.Status.Description = "Pod pending timeout."
+					pj.Status.FailureReason = prowv1.PodErrorReason
 					r.log.WithFields(pjutil.ProwJobFields(pj)).Info("Marked job for stale pending pod as errored.")
 					if err := r.deletePod(ctx, pj); err != nil {
 						return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
@@ -625,6 +653,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.AbortedState
 			pj.Status.Description = "Pod running timeout."
+			pj.Status.FailureReason = prowv1.TimedOutReason
 			if err := r.deletePod(ctx, pj); err != nil {
 				return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
 			}
@@ -642,6 +671,15 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 		pj.SetComplete()
 		pj.Status.State = prowv1.ErrorState
 		pj.Status.Description = "Pod got deleted unexpectedly"
+		pj.Status.FailureReason = prowv1.PodErrorReason
+	}
+
+	if pj.Complete() {
+		pjutil.SetFailureDetails(pj, pod)
+		r.classifyFailure(pj, pod)
+		if err := r.retryIfNeeded(ctx, pj, pod); err != nil {
+			return nil, fmt.Errorf("retry: %w", err)
+		}
 	}
 
 	pj.Status.URL, err = pjutil.JobURL(r.config().Plank, *pj, r.log)
@@ -693,10 +731,29 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 	// updated to pending if we successfully create a new pod in a previous
 	// sync but the prowjob update fails. Simply ignore creating a new pod
 	// and rerun the prowjob update.
+	if podExists && pod.DeletionTimestamp != nil && pj.Status.Retries > 0 {
+		// The pod of the previous attempt is still going away.
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
 	if podExists {
 		id = getPodBuildID(pod)
 		pn = pod.ObjectMeta.Name
 	} else {
+		// Give the infrastructure some time to recover before a retry.
+		if wait := untilRetry(pj, r.clock.Now()); wait > 0 {
+			return &reconcile.Result{RequeueAfter: wait}, nil
+		}
+		// Do not start jobs before their dependencies succeeded.
+		dependenciesSucceeded, err := r.syncDependencies(ctx, pj)
+		if err != nil {
+			return nil, fmt.Errorf("syncDependencies: %w", err)
+		}
+		if !dependenciesSucceeded {
+			if pj.Complete() {
+				return nil, nil
+			}
+			return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		}
 		// Do not start more jobs than specified and check again later.
 		canExecuteConcurrently, err := r.canExecuteConcurrently(ctx, pj)
 		if err != nil {
@@ -714,6 +771,8 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 			pj.Status.State = prowv1.ErrorState
 			pj.SetComplete()
 			pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
+			pj.Status.FailureReason = prowv1.PodErrorReason
+			pjutil.SetFailureClassification(pj, pjutil.Classification{Class: prowv1.ConfigFailure, Message: "The pod of the job is invalid."})
 			logrus.WithField("job", pj.Spec.Job).WithError(err).Warning("Unprocessable pod.")
 		}
 	}
@@ -761,6 +820,65 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 	return nil, nil
 }
 
+// retryIfNeeded sets the completed job pj up to run again, if its retry
+// policy covers the state it completed in and has attempts left. Jobs
+// failing because of their configuration are not retried.
+func (r *reconciler) retryIfNeeded(ctx context.Context, pj *prowv1.ProwJob, pod *corev1.Pod) error {
+	if !pj.Spec.Retry.RetriesOn(pj.Status.State) || pj.Status.Retries >= pj.Spec.Retry.Attempts {
+		return nil
+	}
+	if c := pjutil.GetFailureClassification(pj); c != nil && c.Class == prowv1.ConfigFailure {
+		return nil
+	}
+
+	// The pod of the next attempt has the same name, remove this one first.
+	if pod != nil {
+		client, ok := r.buildClients[pj.ClusterAlias()]
+		if !ok {
+			return TerminalError(fmt.Errorf("unknown pod %s: unknown cluster alias %q", pod.Name, pj.ClusterAlias()))
+		}
+		if finalizers := sets.New[string](pod.Finalizers...); finalizers.Has(kubernetesreporterapi.FinalizerName) {
+			// Nothing is reported for this attempt, so the finalizer would keep the pod around forever.
+			oldPod := pod.DeepCopy()
+			pod.Finalizers = finalizers.Delete(kubernetesreporterapi.FinalizerName).UnsortedList()
+			if err := client.Patch(ctx, pod, ctrlruntimeclient.MergeFrom(oldPod)); err != nil {
+				return fmt.Errorf("failed to patch pod trying to remove %s finalizer: %w", kubernetesreporterapi.FinalizerName, err)
+			}
+		}
+		if err := ctrlruntimeclient.IgnoreNotFound(client.Delete(ctx, pod)); err != nil {
+			return fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
+		}
+	}
+
+	r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("state", pj.Status.State).Info("Retrying job.")
+	now := metav1.NewTime(r.clock.Now())
+	pj.Status.Retries++
+	pj.Status.RetryTime = &now
+	pj.Status.Description = fmt.Sprintf("Retry %d of %d after: %s", pj.Status.Retries, pj.Spec.Retry.Attempts, pj.Status.Description)
+	pj.Status.State = prowv1.TriggeredState
+	pj.Status.CompletionTime = nil
+	pj.Status.PendingTime = nil
+	pj.Status.FailureReason = ""
+	pj.Status.TestSummary = nil
+	pj.Status.ErrorSnippet = ""
+	delete(pj.Annotations, kube.FailureClassificationAnnotation)
+	delete(pj.Annotations, kube.FailureClassificationMessageAnnotation)
+	return nil
+}
+
+// untilRetry returns how long the retried job pj has to wait before
+// it is started again.
+func untilRetry(pj *prowv1.ProwJob, now time.Time) time.Duration {
+	if pj.Status.Retries == 0 || pj.Status.RetryTime == nil {
+		return 0
+	}
+	backoff := maxRetryBackoff
+	if pj.Status.Retries <= 5 {
+		backoff = min(retryBackoff<<(pj.Status.Retries-1), maxRetryBackoff)
+	}
+	return pj.Status.RetryTime.Add(backoff).Sub(now)
+}
+
 // syncAbortedJob syncs jobs that got aborted because their result isn't needed anymore,
 // for example because of a new push or because a pull request got closed.
 func (r *reconciler) syncAbortedJob(ctx context.Context, pj *prowv1.ProwJob) error {
@@ -829,13 +947,17 @@ func (r *reconciler) deletePod(ctx context.Context, pj *prowv1.ProwJob) error {
 }
 
 func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string, string, error) {
-	buildID, err := r.getBuildID(pj.Spec.Job)
+	buildID, err := r.getBuildID(ctx, pj.Spec.Job)
 	if err != nil {
 		return "", "", fmt.Errorf("error getting build ID: %w", err)
 	}
 
 	pj.Status.BuildID = buildID
-	pod, err := decorate.ProwJobToPod(*pj)
+	podPJ, err := r.withDependencyArtifacts(ctx, pj)
+	if err != nil {
+		return "", "", err
+	}
+	pod, err := decorate.ProwJobToPod(*podPJ)
 	if err != nil {
 		return "", "", err
 	}
@@ -871,7 +993,136 @@ func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string,
 	return buildID, pod.Name, nil
 }
 
-func (r *reconciler) getBuildID(name string) (string, error) {
+// syncDependencies determines whether all dependencies of the triggered job
+// pj succeeded for the same refs. If one of them didn't succeed or wasn't
+// triggered within the dependency grace period, pj is aborted.
+func (r *reconciler) syncDependencies(ctx context.Context, pj *prowv1.ProwJob) (bool, error) {
+	if len(pj.Spec.DependsOn) == 0 {
+		return true, nil
+	}
+	dependencies, err := r.dependencies(ctx, pj)
+	if err != nil {
+		return false, err
+	}
+
+	var waiting []string
+	var reason string
+	for _, name := range pj.Spec.DependsOn {
+		dependency, triggered := dependencies[name]
+		switch {
+		case !triggered && r.clock.Since(pj.CreationTimestamp.Time) < r.config().Plank.DependencyGracePeriod.Duration:
+			waiting = append(waiting, name)
+		case !triggered:
+			reason = fmt.Sprintf("Dependency %s was not triggered.", name)
+		case !dependency.Complete():
+			waiting = append(waiting, name)
+		case dependency.Status.State != prowv1.SuccessState:
+			reason = fmt.Sprintf("Dependency %s did not succeed.", name)
+		}
+		if reason != "" {
+			break
+		}
+	}
+	if reason == "" {
+		if len(waiting) > 0 {
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("dependencies", waiting).Debug("Waiting for dependencies.")
+			return false, nil
+		}
+		return true, nil
+	}
+
+	prevPJ := pj.DeepCopy()
+	pj.Status.State = prowv1.AbortedState
+	pj.Status.Description = reason
+	pj.SetComplete()
+	r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("reason", reason).Info("Aborting job because of its dependencies.")
+	if err := r.pjClient.Patch(ctx, pj.DeepCopy(), ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
+		return false, fmt.Errorf("patch prowjob: %w", err)
+	}
+	return false, nil
+}
+
+// dependencies returns the latest runs of the dependencies of pj for the same
+// refs, keyed by job name. Dependencies that weren't triggered are missing.
+func (r *reconciler) dependencies(ctx context.Context, pj *prowv1.ProwJob) (map[string]prowv1.ProwJob, error) {
+	dependencies := map[string]prowv1.ProwJob{}
+	for _, name := range pj.Spec.DependsOn {
+		pjs := &prowv1.ProwJobList{}
+		if err := r.pjClient.List(ctx, pjs, optProwJobsNamed(name)); err != nil {
+			return nil, fmt.Errorf("failed listing prowjobs named %s: %w", name, err)
+		}
+		for _, candidate := range pjs.Items {
+			if candidate.Spec.Type != pj.Spec.Type || !sameRefs(candidate.Spec.Refs, pj.Spec.Refs) {
+				continue
+			}
+			if latest, found := dependencies[name]; found && !latest.CreationTimestamp.Before(&candidate.CreationTimestamp) {
+				continue
+			}
+			dependencies[name] = candidate
+		}
+	}
+	return dependencies, nil
+}
+
+// withDependencyArtifacts returns a copy of pj whose test containers get the
+// storage paths of the artifacts of the dependencies of pj.
+func (r *reconciler) withDependencyArtifacts(ctx context.Context, pj *prowv1.ProwJob) (*prowv1.ProwJob, error) {
+	if len(pj.Spec.DependsOn) == 0 || pj.Spec.PodSpec == nil {
+		return pj, nil
+	}
+	dependencies, err := r.dependencies(ctx, pj)
+	if err != nil {
+		return nil, err
+	}
+	artifacts := map[string]string{}
+	for name, dependency := range dependencies {
+		// Only decorated jobs upload their artifacts.
+		if dependency.Spec.DecorationConfig == nil || dependency.Spec.DecorationConfig.GCSConfiguration == nil {
+			continue
+		}
+		gcsConfig := dependency.Spec.DecorationConfig.GCSConfiguration
+		spec := downwardapi.NewJobSpec(dependency.Spec, dependency.Status.BuildID, dependency.Name)
+		_, artifactsPath, _ := gcsupload.PathsForJob(gcsConfig, &spec, "artifacts")
+		artifacts[name], err = providers.StoragePath(gcsConfig.Bucket, artifactsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the artifacts of dependency %s: %w", name, err)
+		}
+	}
+	encoded, err := json.Marshal(artifacts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dependency artifacts: %w", err)
+	}
+
+	podPJ := pj.DeepCopy()
+	for i := range podPJ.Spec.PodSpec.Containers {
+		podPJ.Spec.PodSpec.Containers[i].Env = append(podPJ.Spec.PodSpec.Containers[i].Env, corev1.EnvVar{
+			Name:  DependencyArtifactsEnv,
+			Value: string(encoded),
+		})
+	}
+	return podPJ, nil
+}
+
+// sameRefs determines whether two jobs test the same refs.
+func sameRefs(a, b *prowv1.Refs) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Org != b.Org || a.Repo != b.Repo || a.BaseRef != b.BaseRef || a.BaseSHA != b.BaseSHA || len(a.Pulls) != len(b.Pulls) {
+		return false
+	}
+	for i := range a.Pulls {
+		if a.Pulls[i].Number != b.Pulls[i].Number || a.Pulls[i].SHA != b.Pulls[i].SHA {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *reconciler) getBuildID(ctx context.Context, name string) (string, error) {
+	if r.buildIDAllocator != nil {
+		return r.buildIDAllocator.BuildID(ctx, name)
+	}
 	return pjutil.GetBuildID(name, r.totURL)
 }
 
@@ -1019,6 +1270,10 @@ const (
 	prowJobIndexKeyPending = "pending"
 )
 
+func indexKeyByName(jobName string) string {
+	return fmt.Sprintf("named-%s", jobName)
+}
+
 func pendingTriggeredIndexKeyByName(jobName string) string {
 	return fmt.Sprintf("pending-triggered-named-%s", jobName)
 }
@@ -1034,7 +1289,7 @@ func prowJobIndexer(prowJobNamespace string) ctrlruntimeclient.IndexerFunc {
 			return nil
 		}
 
-		indexes := []string{prowJobIndexKeyAll}
+		indexes := []string{prowJobIndexKeyAll, indexKeyByName(pj.Spec.Job)}
 
 		if pj.Status.State == prowv1.PendingState {
 			indexes = append(indexes, prowJobIndexKeyPending)
@@ -1056,6 +1311,10 @@ func optAllProwJobs() ctrlruntimeclient.ListOption {
 	return ctrlruntimeclient.MatchingFields{prowJobIndexName: prowJobIndexKeyAll}
 }
 
+func optProwJobsNamed(name string) ctrlruntimeclient.ListOption {
+	return ctrlruntimeclient.MatchingFields{prowJobIndexName: indexKeyByName(name)}
+}
+
 func optPendingProwJobs() ctrlruntimeclient.ListOption {
 	return ctrlruntimeclient.MatchingFields{prowJobIndexName: prowJobIndexKeyPending}
 }
@@ -1068,6 +1327,17 @@ func optPendingTriggeredJobsInQueue(queueName string) ctrlruntimeclient.ListOpti
 	return ctrlruntimeclient.MatchingFields{prowJobIndexName: pendingTriggeredIndexKeyByJobQueueName(queueName)}
 }
 
+// classifyFailure records why the completed job pj failed or errored, unless
+// it was already classified.
+func (r *reconciler) classifyFailure(pj *prowv1.ProwJob, pod *corev1.Pod) {
+	if pjutil.GetFailureClassification(pj) != nil {
+		return
+	}
+	if c := pjutil.ClassifyFailure(pj, pod, r.config().Plank.FailureClassifiers); c != nil {
+		pjutil.SetFailureClassification(pj, *c)
+	}
+}
+
 func didPodSucceed(p *corev1.Pod) bool {
 	if p.Status.Phase != corev1.PodSucceeded {
 		return false
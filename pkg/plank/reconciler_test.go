@@ -53,6 +53,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/buildid"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/testutil"
@@ -179,7 +180,7 @@ func TestAdd(t *testing.T) {
 				predicateResultChan <- !b
 			}
 			var errMsg string
-			if err := add(mgr, buildMgrs, nil, cfg, nil, "", tc.additionalSelector, reconcile, predicateCallBack, 1); err != nil {
+			if err := add(mgr, buildMgrs, nil, cfg, nil, "", nil, tc.additionalSelector, reconcile, predicateCallBack, 1); err != nil {
 				errMsg = err.Error()
 			}
 			if errMsg != tc.expectedError {
@@ -324,6 +325,7 @@ func TestProwJobIndexer(t *testing.T) {
 			name: "Matches all keys",
 			expected: []string{
 				prowJobIndexKeyAll,
+				indexKeyByName(pjName),
 				prowJobIndexKeyPending,
 				pendingTriggeredIndexKeyByName(pjName),
 				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
@@ -334,6 +336,7 @@ func TestProwJobIndexer(t *testing.T) {
 			modify: func(pj *prowv1.ProwJob) { pj.Status.State = prowv1.TriggeredState },
 			expected: []string{
 				prowJobIndexKeyAll,
+				indexKeyByName(pjName),
 				pendingTriggeredIndexKeyByName(pjName),
 				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
 			},
@@ -347,15 +350,16 @@ func TestProwJobIndexer(t *testing.T) {
 			modify: func(pj *prowv1.ProwJob) { pj.Spec.Agent = prowv1.TektonAgent },
 		},
 		{
-			name:     "Success, matches only the `all` key",
+			name:     "Success, matches only the `all` and name keys",
 			modify:   func(pj *prowv1.ProwJob) { pj.Status.State = prowv1.SuccessState },
-			expected: []string{prowJobIndexKeyAll},
+			expected: []string{prowJobIndexKeyAll, indexKeyByName(pjName)},
 		},
 		{
 			name:   "Changing name changes pendingTriggeredIndexKeyByName index",
 			modify: func(pj *prowv1.ProwJob) { pj.Spec.Job = "some-name" },
 			expected: []string{
 				prowJobIndexKeyAll,
+				indexKeyByName("some-name"),
 				prowJobIndexKeyPending,
 				pendingTriggeredIndexKeyByName("some-name"),
 				pendingTriggeredIndexKeyByJobQueueName(pjJobQueue),
@@ -366,6 +370,7 @@ func TestProwJobIndexer(t *testing.T) {
 			modify: func(pj *prowv1.ProwJob) { pj.Spec.JobQueueName = "some-name" },
 			expected: []string{
 				prowJobIndexKeyAll,
+				indexKeyByName(pjName),
 				prowJobIndexKeyPending,
 				pendingTriggeredIndexKeyByName(pjName),
 				pendingTriggeredIndexKeyByJobQueueName("some-name"),
@@ -752,3 +757,21 @@ func TestSyncClusterStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBuildIDFromAllocator(t *testing.T) {
+	client := fakectrlruntimeclient.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prowjobs", Name: buildid.DefaultConfigMapName},
+		Data:       map[string]string{"job": "41"},
+	}).Build()
+	r := &reconciler{buildIDAllocator: buildid.NewAllocator(client, "prowjobs", buildid.DefaultConfigMapName)}
+
+	for _, expected := range []string{"42", "43"} {
+		buildID, err := r.getBuildID(context.Background(), "job")
+		if err != nil {
+			t.Fatalf("getBuildID failed: %v", err)
+		}
+		if buildID != expected {
+			t.Errorf("expected build ID %s, got %s", expected, buildID)
+		}
+	}
+}
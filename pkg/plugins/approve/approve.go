@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
@@ -65,10 +66,14 @@ type githubClient interface {
 	ListPullRequestComments(org, repo string, number int) ([]github.ReviewComment, error)
 	DeleteComment(org, repo string, ID int) error
 	CreateComment(org, repo string, number int, comment string) error
+	CreateStatus(org, repo, ref string, status github.Status) error
 	BotUserChecker() (func(candidate string) bool, error)
 	AddLabel(org, repo string, number int, label string) error
 	RemoveLabel(org, repo string, number int, label string) error
 	WasLabelAddedByHuman(org, repo string, num int, label string) (bool, error)
+	ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
+	CreateCheckRun(org, repo string, checkRun github.CheckRun) (int64, error)
+	UpdateCheckRun(org, repo string, checkRunId int64, checkRun github.CheckRun) error
 }
 
 type ownersClient interface {
@@ -80,6 +85,10 @@ type state struct {
 	repo   string
 	branch string
 	number int
+	// sha is the head commit of the PR, the check run is reported on it.
+	sha string
+	// approver is the user whose approval of the PR caused the event, if any.
+	approver string
 
 	body      string
 	author    string
@@ -111,6 +120,12 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 	for _, repo := range enabledRepos {
 		opts := config.ApproveFor(repo.Org, repo.Repo)
 		approveConfig[repo.String()] = fmt.Sprintf("Pull requests %s require an associated issue.<br>Pull request authors %s implicitly approve their own PRs.<br>The /lgtm [cancel] command(s) %s act as approval.<br>A GitHub approved or changes requested review %s act as approval or cancel respectively.", doNot(opts.IssueRequired), doNot(opts.HasSelfApproval()), willNot(opts.LgtmActsAsApprove), willNot(opts.ConsiderReviewState()))
+		if opts.CheckRun {
+			approveConfig[repo.String()] += fmt.Sprintf("<br>The approval status of each OWNERS file is reported in the '%s' check run.", checkRunName)
+		}
+		if opts.InRepoConfigOwnership {
+			approveConfig[repo.String()] += fmt.Sprintf("<br>The inrepoconfig in .prow.yaml and the .prow directory is approved by the members of the '%s' alias. Their approval is reported in the 'approve/inrepoconfig' status of the head commit, and needs to be given again after new commits are pushed.", prowConfigApproversAlias)
+		}
 	}
 
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
@@ -202,6 +217,8 @@ func handleGenericComment(log *logrus.Entry, ghc githubClient, oc ownersClient,
 			repo:      ce.Repo.Name,
 			branch:    pr.Base.Ref,
 			number:    ce.Number,
+			sha:       pr.Head.SHA,
+			approver:  ce.User.Login,
 			body:      ce.IssueBody,
 			author:    ce.IssueAuthor.Login,
 			assignees: ce.Assignees,
@@ -272,6 +289,8 @@ func handleReview(log *logrus.Entry, ghc githubClient, oc ownersClient, githubCo
 			repo:      re.Repo.Name,
 			branch:    re.PullRequest.Base.Ref,
 			number:    re.PullRequest.Number,
+			sha:       re.PullRequest.Head.SHA,
+			approver:  re.Review.User.Login,
 			body:      re.PullRequest.Body,
 			author:    re.PullRequest.User.Login,
 			assignees: re.PullRequest.Assignees,
@@ -331,6 +350,7 @@ func handlePullRequest(log *logrus.Entry, ghc githubClient, oc ownersClient, git
 			repo:      pre.Repo.Name,
 			branch:    pre.PullRequest.Base.Ref,
 			number:    pre.Number,
+			sha:       pre.PullRequest.Head.SHA,
 			body:      pre.PullRequest.Body,
 			author:    pre.PullRequest.User.Login,
 			assignees: pre.PullRequest.Assignees,
@@ -420,6 +440,10 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 	}
 	log.WithField("duration", time.Since(start).String()).Debug("Completed github functions in handle")
 
+	if opts.InRepoConfigOwnership {
+		repo = newInRepoConfigRepo(repo)
+	}
+
 	start = time.Now()
 	approversHandler := approvers.NewApprovers(
 		approvers.NewOwners(
@@ -446,14 +470,7 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 	log.WithField("duration", time.Since(start).String()).Debug("Completed configuring approversHandler in handle")
 
 	start = time.Now()
-	commentsFromIssueComments := commentsFromIssueComments(issueComments)
-	comments := append(commentsFromReviewComments(reviewComments), commentsFromIssueComments...)
-	comments = append(comments, commentsFromReviews(reviews)...)
-	sort.SliceStable(comments, func(i, j int) bool {
-		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
-	})
-	approveComments := filterComments(comments, approvalMatcher(botUserChecker, opts.LgtmActsAsApprove, opts.ConsiderReviewState()))
-	addApprovers(&approversHandler, approveComments, pr.author, opts.ConsiderReviewState())
+	addApprovalsFromComments(&approversHandler, botUserChecker, opts, pr.author, issueComments, reviewComments, reviews)
 	log.WithField("duration", time.Since(start).String()).Debug("Completed filtering approval comments in handle")
 
 	for _, user := range pr.assignees {
@@ -461,22 +478,35 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 	}
 
 	start = time.Now()
-	notifications := filterComments(commentsFromIssueComments, notificationMatcher(botUserChecker))
-	latestNotification := getLast(notifications)
-	newMessage := updateNotification(githubConfig.LinkURL, opts.CommandHelpLink, opts.PrProcessLink, pr.org, pr.repo, pr.branch, latestNotification, approversHandler)
-	log.WithField("duration", time.Since(start).String()).Debug("Completed getting notifications in handle")
-	start = time.Now()
-	if newMessage != nil {
-		for _, notif := range notifications {
-			if err := ghc.DeleteComment(pr.org, pr.repo, notif.ID); err != nil {
-				log.WithError(err).Errorf("Failed to delete comment from %s/%s#%d, ID: %d.", pr.org, pr.repo, pr.number, notif.ID)
+	if opts.CheckRun {
+		if err := reportCheckRun(ghc, githubConfig.LinkURL, opts.CommandHelpLink, pr, approversHandler); err != nil {
+			log.WithError(err).Errorf("Failed to report the approval check run on %s/%s#%d.", pr.org, pr.repo, pr.number)
+		}
+		log.WithField("duration", time.Since(start).String()).Debug("Completed reporting the approval check run in handle")
+	} else {
+		notifications := filterComments(commentsFromIssueComments(issueComments), notificationMatcher(botUserChecker))
+		latestNotification := getLast(notifications)
+		newMessage := updateNotification(githubConfig.LinkURL, opts.CommandHelpLink, opts.PrProcessLink, pr.org, pr.repo, pr.branch, latestNotification, approversHandler)
+		log.WithField("duration", time.Since(start).String()).Debug("Completed getting notifications in handle")
+		start = time.Now()
+		if newMessage != nil {
+			for _, notif := range notifications {
+				if err := ghc.DeleteComment(pr.org, pr.repo, notif.ID); err != nil {
+					log.WithError(err).Errorf("Failed to delete comment from %s/%s#%d, ID: %d.", pr.org, pr.repo, pr.number, notif.ID)
+				}
+			}
+			if err := ghc.CreateComment(pr.org, pr.repo, pr.number, *newMessage); err != nil {
+				log.WithError(err).Errorf("Failed to create comment on %s/%s#%d: %q.", pr.org, pr.repo, pr.number, *newMessage)
 			}
 		}
-		if err := ghc.CreateComment(pr.org, pr.repo, pr.number, *newMessage); err != nil {
-			log.WithError(err).Errorf("Failed to create comment on %s/%s#%d: %q.", pr.org, pr.repo, pr.number, *newMessage)
+		log.WithField("duration", time.Since(start).String()).Debug("Completed adding/deleting approval comments in handle")
+	}
+
+	if opts.InRepoConfigOwnership {
+		if err := reportInRepoConfigApproval(ghc, pr, changes, approversHandler); err != nil {
+			log.WithError(err).Errorf("Failed to report the approval of the inrepoconfig on %s/%s#%d.", pr.org, pr.repo, pr.number)
 		}
 	}
-	log.WithField("duration", time.Since(start).String()).Debug("Completed adding/deleting approval comments in handle")
 
 	start = time.Now()
 	if !approversHandler.IsApproved() {
@@ -494,6 +524,38 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 	return nil
 }
 
+// addApprovalsFromComments adds the approvals and their cancellations in the
+// comments, review comments and reviews of a PR to the approvers, in the order
+// they were made.
+func addApprovalsFromComments(approversHandler *approvers.Approvers, isBot func(string) bool, opts *plugins.Approve, author string, issueComments []github.IssueComment, reviewComments []github.ReviewComment, reviews []github.Review) {
+	comments := append(commentsFromReviewComments(reviewComments), commentsFromIssueComments(issueComments)...)
+	comments = append(comments, commentsFromReviews(reviews)...)
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	approveComments := filterComments(comments, approvalMatcher(isBot, opts.LgtmActsAsApprove, opts.ConsiderReviewState()))
+	addApprovers(approversHandler, approveComments, author, opts.ConsiderReviewState())
+}
+
+// OwnersApprovers returns the sorted logins of the users whose approval of a
+// PR counts for the approve plugin: they approved the PR and are approvers of
+// at least one of the changed files in OWNERS.
+func OwnersApprovers(log *logrus.Entry, repo approvers.Repo, opts *plugins.Approve, number int, author string, filenames []string, isBot func(string) bool, issueComments []github.IssueComment, reviewComments []github.ReviewComment, reviews []github.Review) []string {
+	if opts.InRepoConfigOwnership {
+		repo = newInRepoConfigRepo(repo)
+	}
+	approversHandler := approvers.NewApprovers(approvers.NewOwners(log, filenames, repo, int64(number)))
+	if opts.HasSelfApproval() {
+		approversHandler.AddAuthorSelfApprover(author, "", false)
+	}
+	addApprovalsFromComments(&approversHandler, isBot, opts, author, issueComments, reviewComments, reviews)
+	owners := sets.New[string]()
+	for _, fileApprovers := range approversHandler.GetFilesApprovers() {
+		owners = owners.Union(fileApprovers)
+	}
+	return sets.List(owners)
+}
+
 func humanAddedApproved(ghc githubClient, log *logrus.Entry, org, repo string, number int, hasLabel bool) func() bool {
 	findOut := func() bool {
 		if !hasLabel {
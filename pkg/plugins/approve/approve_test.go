@@ -1377,6 +1377,10 @@ func (fro fakeRepoOwners) AllReviewers() sets.Set[string] {
 	return sets.Set[string]{}
 }
 
+func (fro fakeRepoOwners) Metadata(login string) (repoowners.UserMetadata, bool) {
+	return repoowners.UserMetadata{}, false
+}
+
 func (fro fakeRepoOwners) FindLabelsForFile(path string) sets.Set[string] {
 	return sets.New[string]()
 }
@@ -1426,6 +1430,7 @@ func TestHandleGenericComment(t *testing.T) {
 				repo:      "repo",
 				branch:    "branch",
 				number:    1,
+				approver:  "author",
 				body:      "Fix everything",
 				author:    "P.R. Author",
 				assignees: nil,
@@ -1614,6 +1619,7 @@ func TestHandleReview(t *testing.T) {
 				repo:      "repo",
 				branch:    "branch",
 				number:    1,
+				approver:  "author",
 				body:      "Fix everything",
 				author:    "P.R. Author",
 				assignees: nil,
@@ -511,6 +511,44 @@ func (ap Approvers) GetFiles(baseURL *url.URL, branch string) []File {
 	return allOwnersFiles
 }
 
+// DirectoryApproval is the approval status of the files of a PR that are
+// owned by a single OWNERS file.
+type DirectoryApproval struct {
+	// Path is the directory of the OWNERS file.
+	Path string
+	// OwnersFile is the path of the OWNERS file.
+	OwnersFile string
+	// Approved is true if one of the approvers of the directory approved the PR.
+	Approved bool
+	// ApprovedBy are the users that approved the files of the directory.
+	ApprovedBy []string
+	// Approvers are all the users that can approve the files of the directory.
+	Approvers []string
+}
+
+// GetDirectoryApprovals returns the approval status of every OWNERS file
+// associated with the PR, sorted by path.
+func (ap Approvers) GetDirectoryApprovals() []DirectoryApproval {
+	var approvals []DirectoryApproval
+	filesApprovers := ap.GetFilesApprovers()
+	potentialApprovers := ap.owners.GetApprovers()
+	unapprovedFiles := ap.UnapprovedFiles()
+	for _, file := range sets.List(ap.owners.GetOwnersSet()) {
+		ownersFile := filepath.Join(file, ap.owners.repo.Filenames().Owners)
+		if strings.HasSuffix(file, ".md") {
+			ownersFile = file
+		}
+		approvals = append(approvals, DirectoryApproval{
+			Path:       file,
+			OwnersFile: ownersFile,
+			Approved:   !unapprovedFiles.Has(file),
+			ApprovedBy: sets.List(filesApprovers[file]),
+			Approvers:  sets.List(potentialApprovers[file]),
+		})
+	}
+	return approvals
+}
+
 // GetCCs gets the list of suggested approvers for a pull-request.  It
 // now considers current assignees as potential approvers. Here is how
 // it works:
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approve
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/plugins/approve/approvers"
+)
+
+const (
+	checkRunName = PluginName
+
+	conclusionSuccess        = "success"
+	conclusionActionRequired = "action_required"
+)
+
+// reportCheckRun reports the approval status of the PR in a check run on its
+// head commit. The check run of a previous evaluation of the same commit is
+// updated rather than adding another one.
+func reportCheckRun(ghc githubClient, linkURL *url.URL, commandHelpLink string, pr *state, ap approvers.Approvers) error {
+	if pr.sha == "" {
+		return errors.New("the head commit of the PR is unknown")
+	}
+	checkRun := github.CheckRun{
+		Name:       checkRunName,
+		HeadSHA:    pr.sha,
+		DetailsURL: pr.htmlURL,
+		Status:     "completed",
+		Conclusion: conclusionActionRequired,
+		Output:     checkRunOutput(ap, linkURL, commandHelpLink, pr.org, pr.repo, pr.branch),
+	}
+	if ap.IsApproved() {
+		checkRun.Conclusion = conclusionSuccess
+	}
+	checkRun.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+
+	existing, err := ghc.ListCheckRuns(pr.org, pr.repo, pr.sha)
+	if err != nil {
+		return fmt.Errorf("failed to list check runs: %w", err)
+	}
+	for _, cr := range existing.CheckRuns {
+		if cr.Name != checkRunName {
+			continue
+		}
+		if cr.Conclusion == checkRun.Conclusion && cr.Output.Title == checkRun.Output.Title && cr.Output.Summary == checkRun.Output.Summary {
+			return nil
+		}
+		return ghc.UpdateCheckRun(pr.org, pr.repo, cr.ID, checkRun)
+	}
+	checkRun.StartedAt = checkRun.CompletedAt
+	_, err = ghc.CreateCheckRun(pr.org, pr.repo, checkRun)
+	return err
+}
+
+// checkRunOutput lists every OWNERS file associated with the PR together
+// with who approved it or, if nobody did yet, who can approve it.
+func checkRunOutput(ap approvers.Approvers, linkURL *url.URL, commandHelpLink, org, repo, branch string) github.CheckRunOutput {
+	baseURL := *linkURL
+	baseURL.Path = org + "/" + repo
+
+	directories := ap.GetDirectoryApprovals()
+	var unapproved int
+	var summary []string
+	if !ap.RequirementsMet() && ap.ManuallyApproved() {
+		summary = append(summary, "Approval requirements bypassed by manually added approval.", "")
+	}
+	if ap.RequireIssue && ap.AssociatedIssue == 0 && len(ap.NoIssueApprovers()) == 0 {
+		summary = append(summary, "*No associated issue*. Update pull-request body to add a reference to an issue, or get approval with `/approve no-issue`.", "")
+	}
+	summary = append(summary, "| OWNERS | Status | Approved by | Approvers |", "| --- | --- | --- | --- |")
+	for _, d := range directories {
+		status := "Approved"
+		if !d.Approved {
+			status = "**Needs approval**"
+			unapproved++
+		}
+		link := fmt.Sprintf("%s/blob/%s/%s", baseURL.String(), branch, d.OwnersFile)
+		summary = append(summary, fmt.Sprintf("| [%s](%s) | %s | %s | %s |", d.OwnersFile, link, status, strings.Join(d.ApprovedBy, ", "), strings.Join(d.Approvers, ", ")))
+	}
+
+	title := "Approved"
+	switch {
+	case ap.IsApproved():
+	case unapproved > 0:
+		title = fmt.Sprintf("%d of %d OWNERS files need approval", unapproved, len(directories))
+	case len(directories) == 0:
+		title = "No OWNERS files cover the changed files"
+	default:
+		title = "Missing an associated issue"
+	}
+
+	return github.CheckRunOutput{
+		Title:   title,
+		Summary: strings.Join(summary, "\n"),
+		Text: fmt.Sprintf("Approvers can indicate their approval by writing `/approve` in a comment.\n"+
+			"Approvers can cancel approval by writing `/approve cancel` in a comment.\n\n"+
+			"The full list of commands accepted by this bot can be found [here](%s?repo=%s%%2F%s).", commandHelpLink, org, repo),
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approve
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/layeredsets"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestHandleCheckRun(t *testing.T) {
+	const sha = "0bd3ed50c88cd53a09316bf7a298f900e9371652"
+	text := "Approvers can indicate their approval by writing `/approve` in a comment.\n" +
+		"Approvers can cancel approval by writing `/approve cancel` in a comment.\n\n" +
+		"The full list of commands accepted by this bot can be found [here](https://go.k8s.io/bot-commands?repo=org%2Frepo)."
+
+	testCases := []struct {
+		name              string
+		comments          []github.IssueComment
+		existing          []github.CheckRun
+		expectedCheckRuns []github.CheckRun
+		expectedLabel     bool
+	}{
+		{
+			name:     "partially approved PR creates a check run",
+			comments: []github.IssueComment{newTestComment("Alice", "/approve")},
+			expectedCheckRuns: []github.CheckRun{{
+				ID:         1,
+				Name:       "approve",
+				HeadSHA:    sha,
+				DetailsURL: "https://github.com/org/repo/pull/1",
+				Status:     "completed",
+				Conclusion: "action_required",
+				Output: github.CheckRunOutput{
+					Title: "1 of 2 OWNERS files need approval",
+					Summary: "| OWNERS | Status | Approved by | Approvers |\n" +
+						"| --- | --- | --- | --- |\n" +
+						"| [a/OWNERS](https://github.com/org/repo/blob/master/a/OWNERS) | Approved | Alice | alice |\n" +
+						"| [c/OWNERS](https://github.com/org/repo/blob/master/c/OWNERS) | **Needs approval** |  | cblecker, cjwagner |",
+					Text: text,
+				},
+			}},
+		},
+		{
+			name:     "check run of a previous evaluation is updated",
+			comments: []github.IssueComment{newTestComment("Alice", "/approve"), newTestComment("cblecker", "/approve")},
+			existing: []github.CheckRun{
+				{ID: 7, Name: "approve", HeadSHA: sha, Status: "completed", Conclusion: "action_required"},
+				{ID: 8, Name: "unit-tests", HeadSHA: sha, Status: "completed", Conclusion: "success"},
+			},
+			expectedCheckRuns: []github.CheckRun{
+				{
+					ID:         7,
+					Name:       "approve",
+					HeadSHA:    sha,
+					DetailsURL: "https://github.com/org/repo/pull/1",
+					Status:     "completed",
+					Conclusion: "success",
+					Output: github.CheckRunOutput{
+						Title: "Approved",
+						Summary: "| OWNERS | Status | Approved by | Approvers |\n" +
+							"| --- | --- | --- | --- |\n" +
+							"| [a/OWNERS](https://github.com/org/repo/blob/master/a/OWNERS) | Approved | Alice | alice |\n" +
+							"| [c/OWNERS](https://github.com/org/repo/blob/master/c/OWNERS) | Approved | cblecker | cblecker, cjwagner |",
+						Text: text,
+					},
+				},
+				{ID: 8, Name: "unit-tests", HeadSHA: sha, Status: "completed", Conclusion: "success"},
+			},
+			expectedLabel: true,
+		},
+	}
+
+	fr := fakeRepo{
+		approvers: map[string]layeredsets.String{
+			"a": layeredsets.NewString("alice"),
+			"c": layeredsets.NewString("cblecker", "cjwagner"),
+		},
+		leafApprovers: map[string]sets.Set[string]{
+			"a": sets.New[string]("alice"),
+			"c": sets.New[string]("cblecker", "cjwagner"),
+		},
+		approverOwners: map[string]string{
+			"a/a.go": "a",
+			"c/c.go": "c",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fghc := newFakeGitHubClient(false, false, []string{"a/a.go", "c/c.go"}, tc.comments, nil)
+			if tc.existing != nil {
+				fghc.CheckRuns = map[string][]github.CheckRun{sha: tc.existing}
+			}
+			requireSelfApproval := true
+			if err := handle(
+				logrus.WithField("plugin", "approve"),
+				fghc,
+				fr,
+				config.GitHubOptions{LinkURL: &url.URL{Scheme: "https", Host: "github.com"}},
+				&plugins.Approve{
+					Repos:               []string{"org/repo"},
+					RequireSelfApproval: &requireSelfApproval,
+					CommandHelpLink:     "https://go.k8s.io/bot-commands",
+					CheckRun:            true,
+				},
+				&state{
+					org:     "org",
+					repo:    "repo",
+					branch:  "master",
+					number:  prNumber,
+					sha:     sha,
+					author:  "cjwagner",
+					htmlURL: "https://github.com/org/repo/pull/1",
+				},
+			); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCheckRuns, fghc.CheckRuns[sha], cmpopts.IgnoreFields(github.CheckRun{}, "StartedAt", "CompletedAt")); diff != "" {
+				t.Errorf("unexpected check runs (-want +got):\n%s", diff)
+			}
+			if len(fghc.IssueCommentsAdded) != 0 {
+				t.Errorf("expected no notification comment, got %v", fghc.IssueCommentsAdded)
+			}
+			labelAdded := sets.New[string](fghc.IssueLabelsAdded...).Has("org/repo#1:approved")
+			if labelAdded != tc.expectedLabel {
+				t.Errorf("expected approved label to be added: %t, got %t", tc.expectedLabel, labelAdded)
+			}
+		})
+	}
+}
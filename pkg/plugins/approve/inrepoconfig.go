@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approve
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/layeredsets"
+	"sigs.k8s.io/prow/pkg/plugins/approve/approvers"
+)
+
+const (
+	// inRepoConfigOwnersFile is the owners file that the approval of the
+	// inrepoconfig is reported under.
+	inRepoConfigOwnersFile = ".prow"
+	// prowConfigApproversAlias is the OWNERS_ALIASES alias whose members
+	// approve the inrepoconfig.
+	prowConfigApproversAlias = "prow-config-approvers"
+)
+
+// aliasExpander is implemented by repoowners.RepoOwners.
+type aliasExpander interface {
+	ExpandAlias(alias string) sets.Set[string]
+}
+
+// inRepoConfigRepo makes the inrepoconfig, i.e. the .prow.yaml file and the
+// files in the .prow directory, approvable only by the members of the
+// prow-config-approvers alias. Jobs can be defined anywhere in there and run
+// with any privileges, so no OWNERS file below the root may grant approving
+// them. If the alias is not defined, the approvers of the root OWNERS file
+// approve the inrepoconfig.
+type inRepoConfigRepo struct {
+	approvers.Repo
+	configApprovers sets.Set[string]
+}
+
+func newInRepoConfigRepo(repo approvers.Repo) approvers.Repo {
+	configApprovers := sets.New[string]()
+	if expander, ok := repo.(aliasExpander); ok {
+		configApprovers = configApprovers.Union(expander.ExpandAlias(prowConfigApproversAlias))
+	}
+	if configApprovers.Len() == 0 {
+		configApprovers = repo.LeafApprovers("")
+	}
+	return &inRepoConfigRepo{Repo: repo, configApprovers: configApprovers}
+}
+
+func (r *inRepoConfigRepo) Approvers(path string) layeredsets.String {
+	if !config.IsInRepoConfigFile(path) {
+		return r.Repo.Approvers(path)
+	}
+	return layeredsets.NewString(sets.List(r.configApprovers)...)
+}
+
+func (r *inRepoConfigRepo) LeafApprovers(path string) sets.Set[string] {
+	if !config.IsInRepoConfigFile(path) {
+		return r.Repo.LeafApprovers(path)
+	}
+	return r.configApprovers
+}
+
+func (r *inRepoConfigRepo) FindApproverOwnersForFile(path string) string {
+	if !config.IsInRepoConfigFile(path) {
+		return r.Repo.FindApproverOwnersForFile(path)
+	}
+	return inRepoConfigOwnersFile
+}
+
+// IsNoParentOwners keeps the approvers of the root from approving the
+// inrepoconfig along with the rest of a PR.
+func (r *inRepoConfigRepo) IsNoParentOwners(path string) bool {
+	return path == inRepoConfigOwnersFile || r.Repo.IsNoParentOwners(path)
+}
+
+// reportInRepoConfigApproval reports whether the changes of the PR to the
+// inrepoconfig are approved in the config.InRepoConfigApprovedContext status
+// of its head commit. The approval is only reported when a prow config
+// approver approves the PR or is its author, so that it isn't carried over to
+// commits pushed after the approval.
+func reportInRepoConfigApproval(ghc githubClient, pr *state, changes []github.PullRequestChange, approversHandler approvers.Approvers) error {
+	changesInRepoConfig := false
+	for _, change := range changes {
+		if config.IsInRepoConfigFile(change.Filename) || config.IsInRepoConfigFile(change.PreviousFilename) {
+			changesInRepoConfig = true
+			break
+		}
+	}
+	if !changesInRepoConfig {
+		return nil
+	}
+
+	approvedBy := approversHandler.GetFilesApprovers()[inRepoConfigOwnersFile]
+	status := github.Status{Context: config.InRepoConfigApprovedContext}
+	approvingUsers := sets.New(pr.author)
+	if pr.approver != "" {
+		approvingUsers.Insert(pr.approver)
+	}
+	switch {
+	case approvedBy.Len() == 0:
+		status.State = github.StatusPending
+		status.Description = "Awaiting approval of the inrepoconfig by the prow config approvers."
+	case approvers.CaseInsensitiveIntersection(approvedBy, approvingUsers).Len() > 0:
+		status.State = github.StatusSuccess
+		status.Description = "The inrepoconfig is approved at this commit."
+	default:
+		// The approval was made before the head was pushed.
+		return nil
+	}
+	return ghc.CreateStatus(pr.org, pr.repo, pr.sha, status)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approve
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/layeredsets"
+	"sigs.k8s.io/prow/pkg/plugins/approve/approvers"
+)
+
+type fakeAliasRepo struct {
+	fakeRepo
+	aliases map[string]sets.Set[string]
+}
+
+func (fr fakeAliasRepo) ExpandAlias(alias string) sets.Set[string] {
+	return fr.aliases[alias]
+}
+
+func TestInRepoConfigRepo(t *testing.T) {
+	repo := fakeRepo{
+		approvers: map[string]layeredsets.String{
+			"":        layeredsets.NewString("root"),
+			".prow":   layeredsets.NewStringFromSlices([]string{"prow-dir"}, []string{"root"}),
+			"pkg/foo": layeredsets.NewStringFromSlices([]string{"foo"}, []string{"root"}),
+		},
+		leafApprovers: map[string]sets.Set[string]{
+			"":        sets.New("root"),
+			".prow":   sets.New("prow-dir"),
+			"pkg/foo": sets.New("foo"),
+		},
+		approverOwners: map[string]string{
+			".prow/pkg/foo/jobs.yaml": ".prow",
+			".prow.yaml":              "",
+			"pkg/foo/main.go":         "pkg/foo",
+			"main.go":                 "",
+		},
+	}
+	aliasRepo := fakeAliasRepo{
+		fakeRepo: repo,
+		aliases: map[string]sets.Set[string]{
+			prowConfigApproversAlias: sets.New("config"),
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		repo         approvers.Repo
+		files        []string
+		approver     string
+		wantApproved bool
+	}{
+		{
+			name:         "member of the config approvers alias approves job file",
+			repo:         aliasRepo,
+			files:        []string{".prow/pkg/foo/jobs.yaml"},
+			approver:     "config",
+			wantApproved: true,
+		},
+		{
+			name:         "member of the config approvers alias approves .prow.yaml",
+			repo:         aliasRepo,
+			files:        []string{".prow.yaml"},
+			approver:     "config",
+			wantApproved: true,
+		},
+		{
+			name:     "approver of the path of a job file doesn't approve it",
+			repo:     aliasRepo,
+			files:    []string{".prow/pkg/foo/jobs.yaml"},
+			approver: "foo",
+		},
+		{
+			name:     "approver of the .prow directory doesn't approve job file",
+			repo:     aliasRepo,
+			files:    []string{".prow/pkg/foo/jobs.yaml"},
+			approver: "prow-dir",
+		},
+		{
+			name:     "root approver doesn't approve job file if the alias is defined",
+			repo:     aliasRepo,
+			files:    []string{".prow/pkg/foo/jobs.yaml", "main.go"},
+			approver: "root",
+		},
+		{
+			name:         "root approver approves job file if the alias is not defined",
+			repo:         repo,
+			files:        []string{".prow/pkg/foo/jobs.yaml", "main.go"},
+			approver:     "root",
+			wantApproved: true,
+		},
+		{
+			name:     "member of the config approvers alias doesn't approve code",
+			repo:     aliasRepo,
+			files:    []string{".prow/pkg/foo/jobs.yaml", "pkg/foo/main.go"},
+			approver: "config",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := approvers.NewApprovers(approvers.NewOwners(logrus.WithField("plugin", PluginName), tc.files, newInRepoConfigRepo(tc.repo), 0))
+			handler.AddApprover(tc.approver, "REFERENCE", false)
+			if approved := handler.IsApproved(); approved != tc.wantApproved {
+				t.Errorf("expected approved to be %t, got %t", tc.wantApproved, approved)
+			}
+		})
+	}
+}
+
+func TestReportInRepoConfigApproval(t *testing.T) {
+	repo := fakeAliasRepo{
+		fakeRepo: fakeRepo{
+			approvers:      map[string]layeredsets.String{"": layeredsets.NewString("root")},
+			leafApprovers:  map[string]sets.Set[string]{"": sets.New("root")},
+			approverOwners: map[string]string{"main.go": ""},
+		},
+		aliases: map[string]sets.Set[string]{
+			prowConfigApproversAlias: sets.New("config", "other-config"),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		files         []string
+		author        string
+		approver      string
+		approvals     []string
+		expectedState string
+	}{
+		{
+			name:      "PR doesn't change the inrepoconfig",
+			files:     []string{"main.go"},
+			approver:  "root",
+			approvals: []string{"root"},
+		},
+		{
+			name:          "changed inrepoconfig is not approved",
+			files:         []string{".prow.yaml"},
+			approver:      "root",
+			approvals:     []string{"root"},
+			expectedState: github.StatusPending,
+		},
+		{
+			name:          "config approver approves at the head",
+			files:         []string{".prow/jobs.yaml"},
+			approver:      "config",
+			approvals:     []string{"config"},
+			expectedState: github.StatusSuccess,
+		},
+		{
+			name:      "approval of a config approver is not carried over to a new head",
+			files:     []string{".prow/jobs.yaml"},
+			approvals: []string{"config"},
+		},
+		{
+			name:      "approval of another user doesn't renew the approval of a config approver",
+			files:     []string{".prow/jobs.yaml", "main.go"},
+			approver:  "root",
+			approvals: []string{"config", "root"},
+		},
+		{
+			name:          "config approver authored the PR",
+			files:         []string{".prow/jobs.yaml"},
+			author:        "other-config",
+			approvals:     []string{"other-config"},
+			expectedState: github.StatusSuccess,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var changes []github.PullRequestChange
+			for _, file := range tc.files {
+				changes = append(changes, github.PullRequestChange{Filename: file})
+			}
+			handler := approvers.NewApprovers(approvers.NewOwners(logrus.WithField("plugin", PluginName), tc.files, newInRepoConfigRepo(repo), 0))
+			for _, approval := range tc.approvals {
+				handler.AddApprover(approval, "REFERENCE", false)
+			}
+			fghc := fakegithub.NewFakeClient()
+			pr := &state{org: "org", repo: "repo", sha: "head", author: tc.author, approver: tc.approver}
+			if err := reportInRepoConfigApproval(fghc, pr, changes, handler); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var state string
+			for _, status := range fghc.CreatedStatuses["head"] {
+				if status.Context == config.InRepoConfigApprovedContext {
+					state = status.State
+				}
+			}
+			if state != tc.expectedState {
+				t.Errorf("expected state %q, got %q", tc.expectedState, state)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blunderbuss
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+const (
+	// recentRequestsWindow is how long review requests made by blunderbuss
+	// count towards the review load of a reviewer in addition to the open
+	// review requests found on GitHub, as the search index lags behind.
+	recentRequestsWindow = 15 * time.Minute
+	// offHoursWeight is the factor the weight of a reviewer is multiplied by
+	// outside of their working hours.
+	offHoursWeight = 0.1
+)
+
+// requestHistory records the review requests made by blunderbuss per org.
+type requestHistory struct {
+	sync.Mutex
+	requests map[string][]time.Time
+}
+
+var recentRequests = newRequestHistory()
+
+func newRequestHistory() *requestHistory {
+	return &requestHistory{requests: map[string][]time.Time{}}
+}
+
+func (h *requestHistory) record(org string, logins []string, now time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	for _, login := range logins {
+		key := org + "/" + login
+		h.requests[key] = append(h.requests[key], now)
+	}
+}
+
+// count returns the number of review requests of a user within the
+// recentRequestsWindow and forgets older ones.
+func (h *requestHistory) count(org, login string, now time.Time) int {
+	h.Lock()
+	defer h.Unlock()
+	key := org + "/" + login
+	var recent []time.Time
+	for _, t := range h.requests[key] {
+		if now.Sub(t) < recentRequestsWindow {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) == 0 {
+		delete(h.requests, key)
+	} else {
+		h.requests[key] = recent
+	}
+	return len(recent)
+}
+
+// reviewerWeigher weighs candidates for review requests by their open review
+// load and their working hours. A nil reviewerWeigher selects candidates
+// uniformly at random.
+type reviewerWeigher struct {
+	ghc     githubClient
+	oc      repoowners.RepoOwner
+	log     *logrus.Entry
+	history *requestHistory
+	org     string
+	now     time.Time
+
+	balanceReviewLoad bool
+	useWorkingHours   bool
+
+	weights map[string]float64
+}
+
+func newReviewerWeigher(ghc githubClient, oc repoowners.RepoOwner, log *logrus.Entry, org string, balanceReviewLoad, useWorkingHours bool) *reviewerWeigher {
+	if !balanceReviewLoad && !useWorkingHours {
+		return nil
+	}
+	return &reviewerWeigher{
+		ghc:               ghc,
+		oc:                oc,
+		log:               log,
+		history:           recentRequests,
+		org:               org,
+		now:               time.Now(),
+		balanceReviewLoad: balanceReviewLoad,
+		useWorkingHours:   useWorkingHours,
+		weights:           map[string]float64{},
+	}
+}
+
+// weight returns the relative likelihood of a candidate to be selected as
+// reviewer. It is inversely proportional to the number of pull requests that
+// await a review of the candidate, and lowered outside of their working hours.
+func (w *reviewerWeigher) weight(login string) float64 {
+	if weight, ok := w.weights[login]; ok {
+		return weight
+	}
+	log := w.log.WithField("user", login)
+	weight := 1.0
+	if w.balanceReviewLoad {
+		load, err := reviewLoad(w.ghc, w.org, login)
+		if err != nil {
+			log.WithError(err).Error("Error checking review load")
+		}
+		load += w.history.count(w.org, login, w.now)
+		log.Debugf("User has a review load of %d", load)
+		weight /= float64(1 + load)
+	}
+	if w.useWorkingHours {
+		if metadata, ok := w.oc.Metadata(login); ok {
+			working, err := metadata.IsWorkingHours(w.now)
+			if err != nil {
+				log.WithError(err).Warn("Invalid user metadata in OWNERS_ALIASES")
+			}
+			if !working {
+				log.Debug("User is outside of their working hours")
+				weight *= offHoursWeight
+			}
+		}
+	}
+	w.weights[login] = weight
+	return weight
+}
+
+// record records review requests so that they count towards the review load
+// before GitHub's search index catches up.
+func (w *reviewerWeigher) record(logins []string) {
+	if w == nil || !w.balanceReviewLoad {
+		return
+	}
+	w.history.record(w.org, logins, w.now)
+}
+
+type reviewLoadQuery struct {
+	Search struct {
+		IssueCount githubql.Int
+	} `graphql:"search(type: ISSUE, first: 1, query: $query)"`
+}
+
+// reviewLoad returns the number of open pull requests of an org that request
+// a review from the user.
+func reviewLoad(ghc githubClient, org, user string) (int, error) {
+	var query reviewLoadQuery
+	vars := map[string]interface{}{
+		"query": githubql.String(fmt.Sprintf("is:pr is:open archived:false org:%s review-requested:%s", org, user)),
+	}
+	err := ghc.Query(context.Background(), &query, vars)
+	return int(query.Search.IssueCount), err
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blunderbuss
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+func TestReviewerWeigherWeight(t *testing.T) {
+	// Monday 12:00 UTC, 21:00 in Tokyo.
+	now := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+	oc := &fakeOwnersClient{metadata: map[string]repoowners.UserMetadata{
+		"berlin": {TimeZone: "Europe/Berlin"},
+		"tokyo":  {TimeZone: "Asia/Tokyo"},
+		"broken": {TimeZone: "Mars/Olympus_Mons"},
+	}}
+	ghc := &fakeGitHubClient{reviewLoads: map[string]int{"busy": 3, "tokyo": 1}}
+
+	testCases := []struct {
+		name              string
+		balanceReviewLoad bool
+		useWorkingHours   bool
+		recent            []string
+		expected          map[string]float64
+	}{
+		{
+			name:              "weights are inversely proportional to review load",
+			balanceReviewLoad: true,
+			expected:          map[string]float64{"idle": 1, "busy": 0.25, "tokyo": 0.5, "berlin": 1},
+		},
+		{
+			name:              "recent requests count towards review load",
+			balanceReviewLoad: true,
+			recent:            []string{"idle", "busy"},
+			expected:          map[string]float64{"idle": 0.5, "busy": 0.2},
+		},
+		{
+			name:            "users outside of working hours are less likely",
+			useWorkingHours: true,
+			expected:        map[string]float64{"idle": 1, "berlin": 1, "tokyo": offHoursWeight, "broken": 1},
+		},
+		{
+			name:              "load and working hours are combined",
+			balanceReviewLoad: true,
+			useWorkingHours:   true,
+			expected:          map[string]float64{"tokyo": 0.5 * offHoursWeight},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newReviewerWeigher(ghc, oc, logrus.WithField("plugin", PluginName), "org", tc.balanceReviewLoad, tc.useWorkingHours)
+			w.history = newRequestHistory()
+			w.now = now
+			w.history.record("org", tc.recent, now.Add(-time.Minute))
+			for login, expected := range tc.expected {
+				if actual := w.weight(login); actual != expected {
+					t.Errorf("expected weight of %s to be %v, got %v", login, expected, actual)
+				}
+			}
+		})
+	}
+
+	if w := newReviewerWeigher(ghc, oc, logrus.WithField("plugin", PluginName), "org", false, false); w != nil {
+		t.Errorf("expected no weigher if balancing is disabled, got %v", w)
+	}
+}
+
+func TestRequestHistory(t *testing.T) {
+	now := time.Now()
+	h := newRequestHistory()
+	h.record("org", []string{"alice", "bob"}, now.Add(-recentRequestsWindow))
+	h.record("org", []string{"alice"}, now.Add(-time.Minute))
+	h.record("other-org", []string{"alice"}, now)
+
+	if count := h.count("org", "alice", now); count != 1 {
+		t.Errorf("expected 1 recent request of alice, got %d", count)
+	}
+	if count := h.count("org", "bob", now); count != 0 {
+		t.Errorf("expected no recent request of bob, got %d", count)
+	}
+	if _, ok := h.requests["org/bob"]; ok {
+		t.Error("expected expired requests to be forgotten")
+	}
+}
+
+func TestHandleRecordsBalancedRequests(t *testing.T) {
+	froc := &fakeRepoownersClient{foc: &fakeOwnersClient{owners: owners, reviewers: reviewers, leafReviewers: leafReviewers}}
+	pr := github.PullRequest{Number: 5, User: github.User{Login: "author"}}
+	repo := github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}
+	fghc := newFakeGitHubClient(&pr, []string{"e.go"})
+	fghc.reviewLoads = map[string]int{"erick": 100}
+
+	old := recentRequests
+	recentRequests = newRequestHistory()
+	defer func() { recentRequests = old }()
+
+	reviewerCount := 2
+	if err := handle(fghc, froc, logrus.WithField("plugin", PluginName), &reviewerCount, 0, true, false, true, false, &repo, &pr); err != nil {
+		t.Fatalf("unexpected error from handle: %v", err)
+	}
+	if requested := sets.New[string](fghc.requested...); !requested.Equal(sets.New[string]("erick", "ellen")) {
+		t.Errorf("expected erick and ellen to be requested, got %v", fghc.requested)
+	}
+	for _, login := range []string{"erick", "ellen"} {
+		if count := recentRequests.count("org", login, time.Now()); count != 1 {
+			t.Errorf("expected the request of %s to be recorded, got %d", login, count)
+		}
+	}
+}
@@ -69,6 +69,8 @@ func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhel
 			ExcludeApprovers:      true,
 			UseStatusAvailability: true,
 			IgnoreAuthors:         []string{},
+			BalanceReviewLoad:     true,
+			UseWorkingHours:       true,
 		},
 	})
 	if err != nil {
@@ -166,6 +168,8 @@ func handlePullRequest(ghc githubClient, roc repoownersClient, log *logrus.Entry
 		config.MaxReviewerCount,
 		config.ExcludeApprovers,
 		config.UseStatusAvailability,
+		config.BalanceReviewLoad,
+		config.UseWorkingHours,
 		repo,
 		pr,
 	)
@@ -208,12 +212,14 @@ func handleGenericComment(ghc githubClient, roc repoownersClient, log *logrus.En
 		config.MaxReviewerCount,
 		config.ExcludeApprovers,
 		config.UseStatusAvailability,
+		config.BalanceReviewLoad,
+		config.UseWorkingHours,
 		repo,
 		pr,
 	)
 }
 
-func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerCount *int, maxReviewers int, excludeApprovers bool, useStatusAvailability bool, repo *github.Repo, pr *github.PullRequest) error {
+func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerCount *int, maxReviewers int, excludeApprovers bool, useStatusAvailability, balanceReviewLoad, useWorkingHours bool, repo *github.Repo, pr *github.PullRequest) error {
 	oc, err := roc.LoadRepoOwners(repo.Owner.Login, repo.Name, pr.Base.Ref)
 	if err != nil {
 		return fmt.Errorf("error loading RepoOwners: %w", err)
@@ -224,10 +230,11 @@ func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerC
 		return fmt.Errorf("error getting PR changes: %w", err)
 	}
 
+	weigher := newReviewerWeigher(ghc, oc, log, repo.Owner.Login, balanceReviewLoad, useWorkingHours)
 	var reviewers []string
 	var requiredReviewers []string
 	if reviewerCount != nil {
-		reviewers, requiredReviewers, err = getReviewers(oc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability)
+		reviewers, requiredReviewers, err = getReviewers(oc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability, weigher)
 		if err != nil {
 			return err
 		}
@@ -238,7 +245,7 @@ func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerC
 				// and approvers and the search might stop too early if it finds
 				// duplicates.
 				frc := fallbackReviewersClient{ownersClient: oc}
-				approvers, _, err := getReviewers(frc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability)
+				approvers, _, err := getReviewers(frc, ghc, log, pr.User.Login, changes, *reviewerCount, useStatusAvailability, weigher)
 				if err != nil {
 					return err
 				}
@@ -269,12 +276,15 @@ func handle(ghc githubClient, roc repoownersClient, log *logrus.Entry, reviewerC
 
 	if len(reviewers) > 0 {
 		log.Infof("Requesting reviews from users %s.", reviewers)
-		return ghc.RequestReview(repo.Owner.Login, repo.Name, pr.Number, reviewers)
+		if err := ghc.RequestReview(repo.Owner.Login, repo.Name, pr.Number, reviewers); err != nil {
+			return err
+		}
+		weigher.record(reviewers)
 	}
 	return nil
 }
 
-func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, author string, files []github.PullRequestChange, minReviewers int, useStatusAvailability bool) ([]string, []string, error) {
+func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, author string, files []github.PullRequestChange, minReviewers int, useStatusAvailability bool, weigher *reviewerWeigher) ([]string, []string, error) {
 	authorSet := sets.New[string](github.NormLogin(author))
 	reviewers := layeredsets.NewString()
 	requiredReviewers := sets.New[string]()
@@ -300,14 +310,14 @@ func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, autho
 			continue
 		}
 		leafReviewers = leafReviewers.Union(fileUnusedLeaves)
-		if r := findReviewer(ghc, log, useStatusAvailability, &busyReviewers, &fileUnusedLeaves); r != "" {
+		if r := findReviewer(ghc, log, useStatusAvailability, weigher, &busyReviewers, &fileUnusedLeaves); r != "" {
 			reviewers.Insert(0, r)
 		}
 	}
 	// now ensure that we request review from at least minReviewers reviewers. Favor leaf reviewers.
 	unusedLeaves := leafReviewers.Difference(reviewers.Set())
 	for reviewers.Len() < minReviewers && unusedLeaves.Len() > 0 {
-		if r := findReviewer(ghc, log, useStatusAvailability, &busyReviewers, &unusedLeaves); r != "" {
+		if r := findReviewer(ghc, log, useStatusAvailability, weigher, &busyReviewers, &unusedLeaves); r != "" {
 			reviewers.Insert(1, r)
 		}
 	}
@@ -317,7 +327,7 @@ func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, autho
 		}
 		fileReviewers := rc.Reviewers(file.Filename).Difference(authorSet)
 		for reviewers.Len() < minReviewers && fileReviewers.Len() > 0 {
-			if r := findReviewer(ghc, log, useStatusAvailability, &busyReviewers, &fileReviewers); r != "" {
+			if r := findReviewer(ghc, log, useStatusAvailability, weigher, &busyReviewers, &fileReviewers); r != "" {
 				reviewers.Insert(2, r)
 			}
 		}
@@ -326,11 +336,17 @@ func getReviewers(rc reviewersClient, ghc githubClient, log *logrus.Entry, autho
 }
 
 // findReviewer finds a reviewer from a set, potentially using status
-// availability.
-func findReviewer(ghc githubClient, log *logrus.Entry, useStatusAvailability bool, busyReviewers *sets.Set[string], targetSet *layeredsets.String) string {
+// availability and weighing the candidates.
+func findReviewer(ghc githubClient, log *logrus.Entry, useStatusAvailability bool, weigher *reviewerWeigher, busyReviewers *sets.Set[string], targetSet *layeredsets.String) string {
+	pop := targetSet.PopRandom
+	if weigher != nil {
+		pop = func() string {
+			return targetSet.PopWeighted(weigher.weight)
+		}
+	}
 	// if we don't care about status availability, just pop a target from the set
 	if !useStatusAvailability {
-		return targetSet.PopRandom()
+		return pop()
 	}
 
 	// if we do care, start looping through the candidates
@@ -339,7 +355,7 @@ func findReviewer(ghc githubClient, log *logrus.Entry, useStatusAvailability boo
 			// if there are no candidates left, then break
 			break
 		}
-		candidate := targetSet.PopRandom()
+		candidate := pop()
 		if busyReviewers.Has(candidate) {
 			// we've already verified this reviewer is busy
 			continue
@@ -19,6 +19,7 @@ package blunderbuss
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -41,9 +42,10 @@ import (
 )
 
 type fakeGitHubClient struct {
-	pr        *github.PullRequest
-	changes   []github.PullRequestChange
-	requested []string
+	pr          *github.PullRequest
+	changes     []github.PullRequestChange
+	requested   []string
+	reviewLoads map[string]int
 }
 
 func newFakeGitHubClient(pr *github.PullRequest, filesChanged []string) *fakeGitHubClient {
@@ -86,6 +88,14 @@ func (c *fakeGitHubClient) GetPullRequest(org, repo string, num int) (*github.Pu
 }
 
 func (c *fakeGitHubClient) Query(ctx context.Context, q interface{}, vars map[string]interface{}) error {
+	if lq, ok := q.(*reviewLoadQuery); ok {
+		query := string(vars["query"].(githubql.String))
+		if !strings.HasPrefix(query, "is:pr is:open archived:false org:org review-requested:") {
+			return fmt.Errorf("unexpected query %q", query)
+		}
+		lq.Search.IssueCount = githubql.Int(c.reviewLoads[strings.TrimPrefix(query, "is:pr is:open archived:false org:org review-requested:")])
+		return nil
+	}
 	sq, ok := q.(*githubAvailabilityQuery)
 	if !ok {
 		return errors.New("unexpected query type")
@@ -113,6 +123,7 @@ type fakeOwnersClient struct {
 	requiredReviewers map[string]sets.Set[string]
 	leafReviewers     map[string]sets.Set[string]
 	dirDenylist       []*regexp.Regexp
+	metadata          map[string]repoowners.UserMetadata
 }
 
 func (foc *fakeOwnersClient) AllApprovers() sets.Set[string] {
@@ -127,6 +138,11 @@ func (foc *fakeOwnersClient) AllReviewers() sets.Set[string] {
 	return sets.Set[string]{}
 }
 
+func (foc *fakeOwnersClient) Metadata(login string) (repoowners.UserMetadata, bool) {
+	metadata, ok := foc.metadata[login]
+	return metadata, ok
+}
+
 func (foc *fakeOwnersClient) Filenames() ownersconfig.Filenames {
 	return ownersconfig.FakeFilenames
 }
@@ -355,7 +371,7 @@ func TestHandleWithExcludeApproversOnlyReviewers(t *testing.T) {
 
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, true, false, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, true, false, false, false, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
@@ -397,7 +413,7 @@ func TestHandleWithoutExcludeApproversNoReviewers(t *testing.T) {
 
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, false, false, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, false, false, false, false, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
@@ -542,7 +558,7 @@ func TestHandleWithoutExcludeApproversMixed(t *testing.T) {
 		fghc := newFakeGitHubClient(&pr, tc.filesChanged)
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, false, false, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, false, false, false, false, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
@@ -877,7 +893,7 @@ func TestPopActiveReviewer(t *testing.T) {
 		fghc := newFakeGitHubClient(&pr, tc.filesChanged)
 		if err := handle(
 			fghc, froc, logrus.WithField("plugin", PluginName),
-			&tc.reviewerCount, tc.maxReviewerCount, false, true, &repo, &pr,
+			&tc.reviewerCount, tc.maxReviewerCount, false, true, false, false, &repo, &pr,
 		); err != nil {
 			t.Errorf("[%s] unexpected error from handle: %v", tc.name, err)
 			continue
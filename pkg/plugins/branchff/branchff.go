@@ -0,0 +1,311 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package branchff implements the `/branchff` command which allows members of
+// the milestone maintainers team to fast-forward release branches to the
+// source branch they were cut from.
+package branchff
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	pluginName     = "branchff"
+	confirmKeyword = "confirm"
+)
+
+var (
+	branchFFRegex    = regexp.MustCompile(`(?m)^/branchff(?:[ \t]+(.*?))?\s*$`)
+	mustBeAuthorized = "You must be a member of the [%s/%s](https://github.com/orgs/%s/teams/%s/members) GitHub team to fast-forward release branches. If you believe you should be able to issue the /branchff command, please contact your %s and have them propose you as an additional delegate for this responsibility."
+	notConfigured    = "Fast-forwarding release branches is not configured for this repository."
+)
+
+type githubClient interface {
+	CreateComment(owner, repo string, number int, comment string) error
+	RemoveLabel(owner, repo string, number int, label string) error
+	GetBranches(org, repo string, onlyProtected bool) ([]github.Branch, error)
+	GetRef(org, repo, ref string) (string, error)
+	UpdateRef(org, repo, ref, sha string, force bool) error
+	CompareCommits(org, repo, base, head string) (*github.CommitsComparison, error)
+	ListTeamMembers(org string, id int, role string) ([]github.TeamMember, error)
+	ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error)
+}
+
+func init() {
+	plugins.RegisterGenericCommentHandler(pluginName, handleGenericComment, helpProvider)
+	plugins.RegisterIssueHandler(pluginName, handleIssue, helpProvider)
+	plugins.RegisterPullRequestHandler(pluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(cfg *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The branchff plugin allows members of the milestone maintainers GitHub team to fast-forward release branches to the branch they were cut from. The plugin always comments the outcome of a dry run before it pushes.",
+		Config: func() map[string]string {
+			configMap := make(map[string]string)
+			for _, repo := range enabledRepos {
+				ff, exists := cfg.BranchFastForward.Repos[repo.String()]
+				if !exists {
+					continue
+				}
+				msg := fmt.Sprintf("Release branches matching %s are fast-forwarded to %q.", strings.Join(ff.Branches, ", "), ff.GetSourceBranch())
+				if ff.Label != "" {
+					msg += fmt.Sprintf(" Adding the %q label fast-forwards all of them.", ff.Label)
+				}
+				configMap[repo.String()] = msg
+			}
+			return configMap
+		}(),
+	}
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/branchff [confirm] [<branch>...]",
+		Description: "Fast-forwards the given release branches, or all of them, to the branch they were cut from. Without 'confirm' only the outcome of a dry run is commented.",
+		Featured:    false,
+		WhoCanUse:   "Members of the milestone maintainers GitHub team.",
+		Examples:    []string{"/branchff", "/branchff release-1.30", "/branchff confirm release-1.30"},
+	})
+	return pluginHelp, nil
+}
+
+// request is a request to fast-forward release branches.
+type request struct {
+	org, repo string
+	number    int
+	user      string
+	// branches are the release branches to fast-forward, all of them if
+	// empty.
+	branches []string
+	// confirm pushes the release branches after the dry run.
+	confirm bool
+}
+
+func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error {
+	return handleComment(pc.GitHubClient, pc.Logger, pc.PluginConfig.BranchFastForward, pc.PluginConfig.RepoMilestone, e)
+}
+
+func handleIssue(pc plugins.Agent, e github.IssueEvent) error {
+	if e.Action != github.IssueActionLabeled {
+		return nil
+	}
+	return handleLabel(pc.GitHubClient, pc.Logger, pc.PluginConfig.BranchFastForward, pc.PluginConfig.RepoMilestone, e.Repo, e.Issue.Number, e.Label.Name, e.Sender.Login)
+}
+
+func handlePullRequest(pc plugins.Agent, e github.PullRequestEvent) error {
+	if e.Action != github.PullRequestActionLabeled {
+		return nil
+	}
+	return handleLabel(pc.GitHubClient, pc.Logger, pc.PluginConfig.BranchFastForward, pc.PluginConfig.RepoMilestone, e.Repo, e.Number, e.Label.Name, e.Sender.Login)
+}
+
+func handleComment(gc githubClient, log *logrus.Entry, cfg plugins.BranchFastForward, repoMilestone map[string]plugins.Milestone, e github.GenericCommentEvent) error {
+	if e.Action != github.GenericCommentActionCreated {
+		return nil
+	}
+	match := branchFFRegex.FindStringSubmatch(e.Body)
+	if match == nil {
+		return nil
+	}
+	req := request{org: e.Repo.Owner.Login, repo: e.Repo.Name, number: e.Number, user: e.User.Login}
+	for _, arg := range strings.Fields(match[1]) {
+		if arg == confirmKeyword {
+			req.confirm = true
+			continue
+		}
+		req.branches = append(req.branches, arg)
+	}
+	return handle(gc, log, cfg, repoMilestone, req)
+}
+
+// handleLabel fast-forwards all release branches once the label of the
+// repository is added and removes the label again.
+func handleLabel(gc githubClient, log *logrus.Entry, cfg plugins.BranchFastForward, repoMilestone map[string]plugins.Milestone, repo github.Repo, number int, label, user string) error {
+	ff, exists := cfg.Repos[fmt.Sprintf("%s/%s", repo.Owner.Login, repo.Name)]
+	if !exists || ff.Label == "" || label != ff.Label {
+		return nil
+	}
+	req := request{org: repo.Owner.Login, repo: repo.Name, number: number, user: user, confirm: true}
+	if err := handle(gc, log, cfg, repoMilestone, req); err != nil {
+		return err
+	}
+	return gc.RemoveLabel(req.org, req.repo, number, label)
+}
+
+// handle comments the outcome of a dry run of fast-forwarding the release
+// branches and pushes them if the request is confirmed.
+func handle(gc githubClient, log *logrus.Entry, cfg plugins.BranchFastForward, repoMilestone map[string]plugins.Milestone, req request) error {
+	respond := func(msg string) error {
+		return gc.CreateComment(req.org, req.repo, req.number, plugins.FormatSimpleResponse(fmt.Sprintf("@%s: %s", req.user, msg)))
+	}
+
+	ff, exists := cfg.Repos[fmt.Sprintf("%s/%s", req.org, req.repo)]
+	if !exists {
+		return respond(notConfigured)
+	}
+
+	milestone, exists := repoMilestone[fmt.Sprintf("%s/%s", req.org, req.repo)]
+	if !exists {
+		// fallback default
+		milestone = repoMilestone[""]
+	}
+	authorized, err := isMaintainer(gc, milestone, req.org, req.user)
+	if err != nil {
+		return err
+	}
+	if !authorized {
+		return respond(fmt.Sprintf(mustBeAuthorized, req.org, milestone.MaintainersTeam, req.org, milestone.MaintainersTeam, milestone.MaintainersFriendlyName))
+	}
+
+	branches, err := releaseBranches(gc, ff, req)
+	if err != nil {
+		return respond(fmt.Sprintf("Cannot fast-forward release branches: %v", err))
+	}
+	source := ff.GetSourceBranch()
+	sourceSHA, err := gc.GetRef(req.org, req.repo, "heads/"+source)
+	if err != nil {
+		return fmt.Errorf("failed to get the %s branch of %s/%s: %w", source, req.org, req.repo, err)
+	}
+	var plans []plan
+	for _, branch := range branches {
+		p, err := planFastForward(gc, req.org, req.repo, branch, sourceSHA)
+		if err != nil {
+			return err
+		}
+		plans = append(plans, p)
+	}
+
+	if err := respond(formatPlans(source, sourceSHA, plans, req)); err != nil {
+		return err
+	}
+	if !req.confirm {
+		return nil
+	}
+
+	var results []string
+	for _, p := range plans {
+		if !p.fastForward() {
+			continue
+		}
+		if err := gc.UpdateRef(req.org, req.repo, "heads/"+p.branch, sourceSHA, false); err != nil {
+			log.WithError(err).WithField("branch", p.branch).Warn("Failed to fast-forward release branch.")
+			results = append(results, fmt.Sprintf("- `%s`: failed to fast-forward: %v", p.branch, err))
+			continue
+		}
+		results = append(results, fmt.Sprintf("- `%s`: fast-forwarded to %s", p.branch, sourceSHA))
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return respond(fmt.Sprintf("Fast-forwarded release branches to `%s`:\n\n%s", source, strings.Join(results, "\n")))
+}
+
+func isMaintainer(gc githubClient, milestone plugins.Milestone, org, user string) (bool, error) {
+	var maintainers []github.TeamMember
+	var err error
+	if milestone.MaintainersTeam != "" {
+		maintainers, err = gc.ListTeamMembersBySlug(org, milestone.MaintainersTeam, github.RoleAll)
+	} else {
+		maintainers, err = gc.ListTeamMembers(org, milestone.MaintainersID, github.RoleAll)
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, person := range maintainers {
+		if strings.EqualFold(person.Login, user) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// releaseBranches returns the requested release branches, or all release
+// branches of the repository if none were requested.
+func releaseBranches(gc githubClient, ff plugins.BranchFastForwardRepo, req request) ([]string, error) {
+	if len(req.branches) > 0 {
+		for _, branch := range req.branches {
+			if !ff.IsReleaseBranch(branch) {
+				return nil, fmt.Errorf("`%s` is not a release branch that may be fast-forwarded to `%s`", branch, ff.GetSourceBranch())
+			}
+		}
+		return req.branches, nil
+	}
+
+	all, err := gc.GetBranches(req.org, req.repo, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the branches of the repository: %w", err)
+	}
+	var branches []string
+	for _, branch := range all {
+		if ff.IsReleaseBranch(branch.Name) {
+			branches = append(branches, branch.Name)
+		}
+	}
+	if len(branches) == 0 {
+		return nil, fmt.Errorf("there are no release branches matching %s", strings.Join(ff.Branches, ", "))
+	}
+	sort.Strings(branches)
+	return branches, nil
+}
+
+// plan is the outcome of a dry run of fast-forwarding a release branch.
+type plan struct {
+	branch     string
+	comparison *github.CommitsComparison
+}
+
+func (p plan) fastForward() bool {
+	return p.comparison.Status == github.ComparisonStatusAhead
+}
+
+func planFastForward(gc githubClient, org, repo, branch, sourceSHA string) (plan, error) {
+	sha, err := gc.GetRef(org, repo, "heads/"+branch)
+	if err != nil {
+		return plan{}, fmt.Errorf("failed to get the %s branch of %s/%s: %w", branch, org, repo, err)
+	}
+	comparison, err := gc.CompareCommits(org, repo, sha, sourceSHA)
+	if err != nil {
+		return plan{}, fmt.Errorf("failed to compare the %s branch of %s/%s to %s: %w", branch, org, repo, sourceSHA, err)
+	}
+	return plan{branch: branch, comparison: comparison}, nil
+}
+
+func formatPlans(source, sourceSHA string, plans []plan, req request) string {
+	var lines []string
+	for _, p := range plans {
+		switch p.comparison.Status {
+		case github.ComparisonStatusIdentical:
+			lines = append(lines, fmt.Sprintf("- `%s`: already up to date", p.branch))
+		case github.ComparisonStatusAhead:
+			lines = append(lines, fmt.Sprintf("- `%s`: will be fast-forwarded by [%d commit(s)](%s)", p.branch, p.comparison.AheadBy, p.comparison.HTMLURL))
+		default:
+			lines = append(lines, fmt.Sprintf("- `%s`: cannot be fast-forwarded, it has [%d commit(s)](%s) that are not in `%s`", p.branch, p.comparison.BehindBy, p.comparison.HTMLURL, source))
+		}
+	}
+	msg := fmt.Sprintf("Dry run of fast-forwarding release branches to `%s` (%s):\n\n%s", source, sourceSHA, strings.Join(lines, "\n"))
+	if !req.confirm {
+		msg += fmt.Sprintf("\n\nComment `%s` to push the release branches.", strings.Join(append([]string{"/branchff", confirmKeyword}, req.branches...), " "))
+	}
+	return msg
+}
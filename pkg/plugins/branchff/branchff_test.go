@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+type fakeClient struct {
+	*fakegithub.FakeClient
+	branches    []github.Branch
+	refs        map[string]string
+	comparisons map[string]*github.CommitsComparison
+	updateErr   error
+	updated     []string
+}
+
+func (f *fakeClient) GetBranches(org, repo string, onlyProtected bool) ([]github.Branch, error) {
+	return f.branches, nil
+}
+
+func (f *fakeClient) GetRef(org, repo, ref string) (string, error) {
+	sha, ok := f.refs[ref]
+	if !ok {
+		return "", fmt.Errorf("ref %s does not exist", ref)
+	}
+	return sha, nil
+}
+
+func (f *fakeClient) CompareCommits(org, repo, base, head string) (*github.CommitsComparison, error) {
+	comparison, ok := f.comparisons[base+"..."+head]
+	if !ok {
+		return nil, fmt.Errorf("cannot compare %s to %s", head, base)
+	}
+	return comparison, nil
+}
+
+func (f *fakeClient) UpdateRef(org, repo, ref, sha string, force bool) error {
+	if force {
+		return errors.New("unexpected force update")
+	}
+	if f.updateErr != nil {
+		return f.updateErr
+	}
+	f.updated = append(f.updated, ref+"="+sha)
+	return nil
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		FakeClient: fakegithub.NewFakeClient(),
+		branches:   []github.Branch{{Name: "master"}, {Name: "release-1.1"}, {Name: "release-1.0"}, {Name: "release-0.9"}, {Name: "feature"}},
+		refs: map[string]string{
+			"heads/master":      "master-sha",
+			"heads/release-1.1": "master-sha",
+			"heads/release-1.0": "release-1.0-sha",
+			"heads/release-0.9": "release-0.9-sha",
+		},
+		comparisons: map[string]*github.CommitsComparison{
+			"master-sha...master-sha":      {Status: github.ComparisonStatusIdentical},
+			"release-1.0-sha...master-sha": {Status: github.ComparisonStatusAhead, AheadBy: 3, HTMLURL: "https://github.com/org/repo/compare/release-1.0-sha...master-sha"},
+			"release-0.9-sha...master-sha": {Status: github.ComparisonStatusDiverged, AheadBy: 10, BehindBy: 2, HTMLURL: "https://github.com/org/repo/compare/release-0.9-sha...master-sha"},
+		},
+	}
+}
+
+func TestHandleComment(t *testing.T) {
+	cfg := plugins.BranchFastForward{Repos: map[string]plugins.BranchFastForwardRepo{
+		"org/repo": {Branches: []string{`release-\d+\.\d+`}},
+	}}
+	repoMilestone := map[string]plugins.Milestone{"": {MaintainersTeam: "leads", MaintainersFriendlyName: "SIG Chairs"}}
+
+	testCases := []struct {
+		name             string
+		body             string
+		repo             string
+		commenter        string
+		updateErr        error
+		expectedUpdated  []string
+		expectedComments []string
+	}{
+		{
+			name:      "unrelated comment",
+			body:      "/branchffs",
+			commenter: "sig-lead",
+		},
+		{
+			name:             "repository is not configured",
+			body:             "/branchff",
+			repo:             "other",
+			commenter:        "sig-lead",
+			expectedComments: []string{notConfigured},
+		},
+		{
+			name:             "commenter is not a milestone maintainer",
+			body:             "/branchff confirm",
+			commenter:        "sig-follow",
+			expectedComments: []string{"You must be a member of the [org/leads]"},
+		},
+		{
+			name:      "dry run of all release branches",
+			body:      "/branchff",
+			commenter: "sig-lead",
+			expectedComments: []string{"Dry run of fast-forwarding release branches to `master` (master-sha):\n\n" +
+				"- `release-0.9`: cannot be fast-forwarded, it has [2 commit(s)](https://github.com/org/repo/compare/release-0.9-sha...master-sha) that are not in `master`\n" +
+				"- `release-1.0`: will be fast-forwarded by [3 commit(s)](https://github.com/org/repo/compare/release-1.0-sha...master-sha)\n" +
+				"- `release-1.1`: already up to date\n\n" +
+				"Comment `/branchff confirm` to push the release branches."},
+		},
+		{
+			name:      "dry run of a release branch",
+			body:      "Let's see.\n/branchff release-1.1",
+			commenter: "sig-lead",
+			expectedComments: []string{"- `release-1.1`: already up to date\n\n" +
+				"Comment `/branchff confirm release-1.1` to push the release branches."},
+		},
+		{
+			name:             "not a release branch",
+			body:             "/branchff confirm feature",
+			commenter:        "sig-lead",
+			expectedComments: []string{"Cannot fast-forward release branches: `feature` is not a release branch that may be fast-forwarded to `master`"},
+		},
+		{
+			name:             "source branch is not a release branch",
+			body:             "/branchff master",
+			commenter:        "sig-lead",
+			expectedComments: []string{"Cannot fast-forward release branches: `master` is not a release branch"},
+		},
+		{
+			name:            "fast-forward all release branches",
+			body:            "/branchff confirm",
+			commenter:       "SIG-Lead",
+			expectedUpdated: []string{"heads/release-1.0=master-sha"},
+			expectedComments: []string{
+				"- `release-1.0`: will be fast-forwarded by [3 commit(s)]",
+				"Fast-forwarded release branches to `master`:\n\n- `release-1.0`: fast-forwarded to master-sha",
+			},
+		},
+		{
+			name:      "fast-forward fails",
+			body:      "/branchff confirm release-1.0",
+			commenter: "sig-lead",
+			updateErr: errors.New("Update is not a fast forward"),
+			expectedComments: []string{
+				"- `release-1.0`: will be fast-forwarded by [3 commit(s)]",
+				"- `release-1.0`: failed to fast-forward: Update is not a fast forward",
+			},
+		},
+		{
+			name:             "nothing to fast-forward",
+			body:             "/branchff confirm release-1.1",
+			commenter:        "sig-lead",
+			expectedComments: []string{"- `release-1.1`: already up to date"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeClient()
+			fc.updateErr = tc.updateErr
+			repo := "repo"
+			if tc.repo != "" {
+				repo = tc.repo
+			}
+			e := github.GenericCommentEvent{
+				Action: github.GenericCommentActionCreated,
+				Body:   tc.body,
+				Number: 1,
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: repo},
+				User:   github.User{Login: tc.commenter},
+			}
+			if err := handleComment(fc, logrus.WithField("plugin", pluginName), cfg, repoMilestone, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedUpdated, fc.updated); diff != "" {
+				t.Errorf("unexpected updated refs (-want +got):\n%s", diff)
+			}
+			assertComments(t, tc.expectedComments, fc.IssueComments[1])
+		})
+	}
+}
+
+func TestHandleLabel(t *testing.T) {
+	cfg := plugins.BranchFastForward{Repos: map[string]plugins.BranchFastForwardRepo{
+		"org/repo": {Branches: []string{`release-\d+\.\d+`}, Label: "fast-forward"},
+	}}
+	repoMilestone := map[string]plugins.Milestone{"org/repo": {MaintainersTeam: "leads"}}
+
+	testCases := []struct {
+		name             string
+		label            string
+		sender           string
+		expectedUpdated  []string
+		expectedComments []string
+		expectedRemoved  []string
+	}{
+		{
+			name:   "other label",
+			label:  "lgtm",
+			sender: "sig-lead",
+		},
+		{
+			name:             "sender is not a milestone maintainer",
+			label:            "fast-forward",
+			sender:           "sig-follow",
+			expectedComments: []string{"You must be a member of the [org/leads]"},
+			expectedRemoved:  []string{"org/repo#1:fast-forward"},
+		},
+		{
+			name:            "fast-forward all release branches",
+			label:           "fast-forward",
+			sender:          "sig-lead",
+			expectedUpdated: []string{"heads/release-1.0=master-sha"},
+			expectedComments: []string{
+				"Dry run of fast-forwarding release branches to `master` (master-sha)",
+				"- `release-1.0`: fast-forwarded to master-sha",
+			},
+			expectedRemoved: []string{"org/repo#1:fast-forward"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeClient()
+			repo := github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}
+			if err := handleLabel(fc, logrus.WithField("plugin", pluginName), cfg, repoMilestone, repo, 1, tc.label, tc.sender); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedUpdated, fc.updated); diff != "" {
+				t.Errorf("unexpected updated refs (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedRemoved, fc.IssueLabelsRemoved); diff != "" {
+				t.Errorf("unexpected removed labels (-want +got):\n%s", diff)
+			}
+			assertComments(t, tc.expectedComments, fc.IssueComments[1])
+		})
+	}
+}
+
+func assertComments(t *testing.T, expected []string, comments []github.IssueComment) {
+	t.Helper()
+	if len(comments) != len(expected) {
+		t.Fatalf("expected %d comments, got %d: %v", len(expected), len(comments), comments)
+	}
+	for i, comment := range comments {
+		if !strings.Contains(comment.Body, expected[i]) {
+			t.Errorf("expected comment %d to contain %q, got %q", i, expected[i], comment.Body)
+		}
+	}
+}
+
+func TestHelpProvider(t *testing.T) {
+	cfg := &plugins.Configuration{BranchFastForward: plugins.BranchFastForward{Repos: map[string]plugins.BranchFastForwardRepo{
+		"org/repo": {Branches: []string{`release-\d+\.\d+`}, Label: "fast-forward"},
+	}}}
+	help, err := helpProvider(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(help.Commands) != 1 {
+		t.Errorf("expected 1 command, got %d", len(help.Commands))
+	}
+}
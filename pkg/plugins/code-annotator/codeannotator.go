@@ -0,0 +1,364 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package codeannotator sends the changes of pull requests to external
+// analysis services and posts the annotations they return for the changed
+// lines as check runs.
+package codeannotator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/trigger"
+)
+
+const (
+	pluginName = "code-annotator"
+	// maxAnnotations is the maximum number of annotations GitHub accepts in
+	// a single check run request.
+	maxAnnotations = 50
+
+	levelNotice  = "notice"
+	levelWarning = "warning"
+	levelFailure = "failure"
+)
+
+var annotateRe = regexp.MustCompile(`(?mi)^/annotate\s*$`)
+
+func init() {
+	plugins.RegisterPullRequestHandler(pluginName, handlePullRequest, helpProvider)
+	plugins.RegisterGenericCommentHandler(pluginName, handleGenericComment, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		CodeAnnotator: plugins.CodeAnnotator{
+			Analyzers: []plugins.Analyzer{{
+				Name:       "shellcheck",
+				Endpoint:   "http://shellcheck-analyzer.default.svc.cluster.local/analyze",
+				Repos:      []string{"org", "other-org/repo"},
+				FileRegexp: `\.sh$`,
+				Timeout:    "2m",
+			}},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", pluginName)
+	}
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		var names []string
+		for _, analyzer := range config.CodeAnnotator.AnalyzersFor(repo.Org, repo.Repo) {
+			names = append(names, analyzer.Name)
+		}
+		if len(names) > 0 {
+			configInfo[repo.String()] = fmt.Sprintf("The following analyzers annotate pull requests: %v.", names)
+		}
+	}
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The code-annotator plugin sends the changes of a pull request to the configured external analyzers and posts the annotations they return for the changed lines as check runs. Posting check runs requires Prow to authenticate as a GitHub App.",
+		Config:      configInfo,
+		Snippet:     yamlSnippet,
+	}
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/annotate",
+		Description: "Re-runs the configured analyzers on the pull request.",
+		WhoCanUse:   "Trusted users, like repository collaborators and org members, can trigger this command on a PR.",
+		Examples:    []string{"/annotate"},
+	})
+	return pluginHelp, nil
+}
+
+type githubClient interface {
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	CreateCheckRun(org, repo string, checkRun github.CheckRun) (int64, error)
+	UpdateCheckRun(org, repo string, checkRunId int64, checkRun github.CheckRun) error
+	CreateComment(org, repo string, number int, comment string) error
+}
+
+// AnalysisRequest is the payload POSTed to the endpoint of an analyzer.
+type AnalysisRequest struct {
+	Org     string        `json:"org"`
+	Repo    string        `json:"repo"`
+	Number  int           `json:"number"`
+	BaseSHA string        `json:"base_sha"`
+	HeadSHA string        `json:"head_sha"`
+	Files   []ChangedFile `json:"files"`
+}
+
+// ChangedFile is a file changed by the pull request.
+type ChangedFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+	// Patch is the unified diff of the file as returned by GitHub.
+	Patch string `json:"patch,omitempty"`
+}
+
+// AnalysisResponse is the payload an analyzer responds with.
+type AnalysisResponse struct {
+	// Conclusion is the conclusion of the check run, one of success, neutral
+	// or failure. It is derived from the annotations if unset.
+	Conclusion string `json:"conclusion,omitempty"`
+	// Summary is shown on the check run. Defaults to the number of
+	// annotations.
+	Summary     string       `json:"summary,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+// Annotation is a finding of an analyzer. Annotations on lines that were not
+// added by the pull request are dropped.
+type Annotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	// EndLine defaults to StartLine.
+	EndLine int `json:"end_line,omitempty"`
+	// Level is one of notice, warning or failure. Defaults to warning.
+	Level   string `json:"level,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
+
+func handlePullRequest(pc plugins.Agent, pe github.PullRequestEvent) error {
+	if pe.Action != github.PullRequestActionOpened &&
+		pe.Action != github.PullRequestActionReopened &&
+		pe.Action != github.PullRequestActionSynchronize {
+		return nil
+	}
+	return handle(pc.GitHubClient, pc.Logger, &pc.PluginConfig.CodeAnnotator, &pe.PullRequest)
+}
+
+func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error {
+	org, repo := e.Repo.Owner.Login, e.Repo.Name
+	return handleComment(pc.GitHubClient, func(user string) (bool, error) {
+		t := pc.PluginConfig.TriggerFor(org, repo)
+		trustedResponse, err := trigger.TrustedUser(pc.GitHubClient, t.OnlyOrgMembers, t.TrustedApps, t.TrustedOrg, user, org, repo)
+		return trustedResponse.IsTrusted, err
+	}, pc.Logger, &pc.PluginConfig.CodeAnnotator, e)
+}
+
+// handleComment re-runs the analyzers when a trusted user asks for it, as the
+// analyzers are sent the changes and may be costly to run.
+func handleComment(gc githubClient, isTrusted func(string) (bool, error), log *logrus.Entry, c *plugins.CodeAnnotator, e github.GenericCommentEvent) error {
+	if !e.IsPR || e.Action != github.GenericCommentActionCreated || !annotateRe.MatchString(e.Body) {
+		return nil
+	}
+	org, repo, user := e.Repo.Owner.Login, e.Repo.Name, e.User.Login
+	trusted, err := isTrusted(user)
+	if err != nil {
+		return fmt.Errorf("failed to check if %s is trusted: %w", user, err)
+	}
+	if !trusted {
+		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, user, "Annotating can only be requested by trusted users, like repository collaborators."))
+	}
+	pr, err := gc.GetPullRequest(org, repo, e.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request %s/%s#%d: %w", org, repo, e.Number, err)
+	}
+	return handle(gc, log, c, pr)
+}
+
+func handle(gc githubClient, log *logrus.Entry, c *plugins.CodeAnnotator, pr *github.PullRequest) error {
+	org, repo, number := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Number
+	analyzers := c.AnalyzersFor(org, repo)
+	if len(analyzers) == 0 {
+		return nil
+	}
+	changes, err := gc.GetPullRequestChanges(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get changes of %s/%s#%d: %w", org, repo, number, err)
+	}
+
+	var errs []error
+	for _, analyzer := range analyzers {
+		log := log.WithField("analyzer", analyzer.Name)
+		request := AnalysisRequest{Org: org, Repo: repo, Number: number, BaseSHA: pr.Base.SHA, HeadSHA: pr.Head.SHA}
+		addedLines := map[string]map[int]int{}
+		for _, change := range changes {
+			if change.Status == github.PullRequestFileRemoved {
+				continue
+			}
+			if analyzer.FileRe != nil && !analyzer.FileRe.MatchString(change.Filename) {
+				continue
+			}
+			added, err := plugins.AddedLines(change.Patch)
+			if err != nil {
+				log.WithError(err).WithField("file", change.Filename).Warn("Failed to parse patch.")
+				continue
+			}
+			addedLines[change.Filename] = added
+			request.Files = append(request.Files, ChangedFile{Filename: change.Filename, Status: change.Status, Patch: change.Patch})
+		}
+		if len(request.Files) == 0 {
+			continue
+		}
+
+		checkRun := github.CheckRun{
+			Name:    analyzer.Name,
+			HeadSHA: pr.Head.SHA,
+			Status:  "completed",
+		}
+		response, err := analyze(analyzer, request)
+		if err != nil {
+			log.WithError(err).Info("Analyzer failed.")
+			checkRun.Conclusion = "neutral"
+			checkRun.Output = github.CheckRunOutput{
+				Title:   fmt.Sprintf("%s could not analyze the changes", analyzer.Name),
+				Summary: fmt.Sprintf("The analyzer failed: %v", err),
+			}
+		} else {
+			annotations := filterAnnotations(response.Annotations, addedLines)
+			checkRun.Conclusion = conclusion(response.Conclusion, annotations)
+			checkRun.Output = github.CheckRunOutput{
+				Title:       fmt.Sprintf("%s found %d issue(s)", analyzer.Name, len(annotations)),
+				Summary:     response.Summary,
+				Annotations: annotations,
+			}
+			if checkRun.Output.Summary == "" {
+				checkRun.Output.Summary = checkRun.Output.Title
+			}
+		}
+		if err := postCheckRun(gc, org, repo, checkRun); err != nil {
+			errs = append(errs, fmt.Errorf("failed to post check run for analyzer %s: %w", analyzer.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// analyze sends the request to the analyzer and decodes its response.
+func analyze(analyzer plugins.Analyzer, request AnalysisRequest) (*AnalysisResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	client := &http.Client{Timeout: analyzer.TimeoutDuration}
+	resp, err := client.Post(analyzer.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response has status %q and body %q", resp.Status, string(raw))
+	}
+	var response AnalysisResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response, nil
+}
+
+// filterAnnotations converts the annotations that overlap lines added by the
+// pull request to check run annotations.
+func filterAnnotations(annotations []Annotation, addedLines map[string]map[int]int) []github.CheckRunAnnotation {
+	var filtered []github.CheckRunAnnotation
+	for _, annotation := range annotations {
+		endLine := annotation.EndLine
+		if endLine < annotation.StartLine {
+			endLine = annotation.StartLine
+		}
+		added, ok := addedLines[annotation.Path]
+		if !ok || !overlaps(added, annotation.StartLine, endLine) {
+			continue
+		}
+		level := annotation.Level
+		if level != levelNotice && level != levelFailure {
+			level = levelWarning
+		}
+		filtered = append(filtered, github.CheckRunAnnotation{
+			Path:            annotation.Path,
+			StartLine:       annotation.StartLine,
+			EndLine:         endLine,
+			AnnotationLevel: level,
+			Title:           annotation.Title,
+			Message:         annotation.Message,
+		})
+	}
+	return filtered
+}
+
+// overlaps returns whether any line in [start, end] was added. The keys of
+// added are line numbers in the new file.
+func overlaps(added map[int]int, start, end int) bool {
+	for line := range added {
+		if line >= start && line <= end {
+			return true
+		}
+	}
+	return false
+}
+
+func conclusion(requested string, annotations []github.CheckRunAnnotation) string {
+	switch requested {
+	case "success", "neutral", "failure":
+		return requested
+	}
+	result := "success"
+	for _, annotation := range annotations {
+		if annotation.AnnotationLevel == levelFailure {
+			return "failure"
+		}
+		result = "neutral"
+	}
+	return result
+}
+
+// postCheckRun creates the check run. GitHub limits the number of annotations
+// per request, so the remaining ones are added by updating it.
+func postCheckRun(gc githubClient, org, repo string, checkRun github.CheckRun) error {
+	annotations := checkRun.Output.Annotations
+	batch := func() []github.CheckRunAnnotation {
+		n := len(annotations)
+		if n > maxAnnotations {
+			n = maxAnnotations
+		}
+		next := annotations[:n]
+		annotations = annotations[n:]
+		return next
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	checkRun.StartedAt, checkRun.CompletedAt = now, now
+	checkRun.Output.Annotations = batch()
+	id, err := gc.CreateCheckRun(org, repo, checkRun)
+	if err != nil {
+		return err
+	}
+	for len(annotations) > 0 {
+		update := github.CheckRun{Output: checkRun.Output}
+		update.Output.Annotations = batch()
+		if err := gc.UpdateCheckRun(org, repo, id, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
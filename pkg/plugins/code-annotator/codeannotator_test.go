@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codeannotator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+type fakeGitHub struct {
+	pr        *github.PullRequest
+	changes   []github.PullRequestChange
+	checkRuns []github.CheckRun
+	updates   []github.CheckRun
+	comments  []string
+	prGets    int
+}
+
+func (f *fakeGitHub) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	f.prGets++
+	if f.pr == nil {
+		return nil, fmt.Errorf("not implemented")
+	}
+	return f.pr, nil
+}
+
+func (f *fakeGitHub) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	return f.changes, nil
+}
+
+func (f *fakeGitHub) CreateCheckRun(org, repo string, checkRun github.CheckRun) (int64, error) {
+	f.checkRuns = append(f.checkRuns, checkRun)
+	return int64(len(f.checkRuns)), nil
+}
+
+func (f *fakeGitHub) UpdateCheckRun(org, repo string, checkRunId int64, checkRun github.CheckRun) error {
+	f.updates = append(f.updates, checkRun)
+	return nil
+}
+
+func (f *fakeGitHub) CreateComment(org, repo string, number int, comment string) error {
+	f.comments = append(f.comments, comment)
+	return nil
+}
+
+const patch = `@@ -1,2 +1,4 @@
+ #!/bin/bash
++echo $foo
++rm -rf $dir/
+ exit 0`
+
+func TestHandle(t *testing.T) {
+	pr := &github.PullRequest{
+		Number: 5,
+		Base:   github.PullRequestBranch{SHA: "base", Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}},
+		Head:   github.PullRequestBranch{SHA: "head"},
+	}
+	changes := []github.PullRequestChange{
+		{Filename: "hack/run.sh", Status: "modified", Patch: patch},
+		{Filename: "hack/old.sh", Status: github.PullRequestFileRemoved},
+		{Filename: "main.go", Status: "added", Patch: "@@ -0,0 +1 @@\n+package main"},
+	}
+
+	testCases := []struct {
+		name              string
+		response          AnalysisResponse
+		status            int
+		expectedFiles     []string
+		expectedCheckRuns []github.CheckRun
+	}{
+		{
+			name: "annotations outside of the added lines are dropped",
+			response: AnalysisResponse{Annotations: []Annotation{
+				{Path: "hack/run.sh", StartLine: 2, Message: "Double quote to prevent globbing."},
+				{Path: "hack/run.sh", StartLine: 3, Level: "failure", Message: "Use ${var:?} to ensure this never expands to /."},
+				{Path: "hack/run.sh", StartLine: 4, Message: "Not changed."},
+				{Path: "other.sh", StartLine: 1, Message: "Not changed."},
+			}},
+			status:        http.StatusOK,
+			expectedFiles: []string{"hack/run.sh"},
+			expectedCheckRuns: []github.CheckRun{{
+				Name:       "shellcheck",
+				HeadSHA:    "head",
+				Status:     "completed",
+				Conclusion: "failure",
+				Output: github.CheckRunOutput{
+					Title:   "shellcheck found 2 issue(s)",
+					Summary: "shellcheck found 2 issue(s)",
+					Annotations: []github.CheckRunAnnotation{
+						{Path: "hack/run.sh", StartLine: 2, EndLine: 2, AnnotationLevel: "warning", Message: "Double quote to prevent globbing."},
+						{Path: "hack/run.sh", StartLine: 3, EndLine: 3, AnnotationLevel: "failure", Message: "Use ${var:?} to ensure this never expands to /."},
+					},
+				},
+			}},
+		},
+		{
+			name:          "conclusion and summary of the analyzer are used",
+			response:      AnalysisResponse{Conclusion: "success", Summary: "All good."},
+			status:        http.StatusOK,
+			expectedFiles: []string{"hack/run.sh"},
+			expectedCheckRuns: []github.CheckRun{{
+				Name:       "shellcheck",
+				HeadSHA:    "head",
+				Status:     "completed",
+				Conclusion: "success",
+				Output: github.CheckRunOutput{
+					Title:   "shellcheck found 0 issue(s)",
+					Summary: "All good.",
+				},
+			}},
+		},
+		{
+			name:          "failing analyzer results in a neutral check run",
+			status:        http.StatusInternalServerError,
+			expectedFiles: []string{"hack/run.sh"},
+			expectedCheckRuns: []github.CheckRun{{
+				Name:       "shellcheck",
+				HeadSHA:    "head",
+				Status:     "completed",
+				Conclusion: "neutral",
+				Output: github.CheckRunOutput{
+					Title:   "shellcheck could not analyze the changes",
+					Summary: `The analyzer failed: response has status "500 Internal Server Error" and body "{}\n"`,
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var files []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request AnalysisRequest
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				for _, file := range request.Files {
+					files = append(files, file.Filename)
+				}
+				w.WriteHeader(tc.status)
+				json.NewEncoder(w).Encode(tc.response)
+			}))
+			defer server.Close()
+
+			gc := &fakeGitHub{changes: changes}
+			c := &plugins.CodeAnnotator{Analyzers: []plugins.Analyzer{
+				{Name: "shellcheck", Endpoint: server.URL, Repos: []string{"org/repo"}, FileRe: regexp.MustCompile(`\.sh$`), TimeoutDuration: time.Minute},
+				{Name: "other", Endpoint: server.URL, Repos: []string{"other-org"}},
+			}}
+			if err := handle(gc, logrus.WithField("plugin", pluginName), c, pr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedFiles, files); diff != "" {
+				t.Errorf("unexpected files sent to the analyzer (-want +got):\n%s", diff)
+			}
+			for i := range gc.checkRuns {
+				gc.checkRuns[i].StartedAt, gc.checkRuns[i].CompletedAt = "", ""
+			}
+			if diff := cmp.Diff(tc.expectedCheckRuns, gc.checkRuns); diff != "" {
+				t.Errorf("unexpected check runs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPostCheckRunBatchesAnnotations(t *testing.T) {
+	var annotations []github.CheckRunAnnotation
+	for i := 0; i < 120; i++ {
+		annotations = append(annotations, github.CheckRunAnnotation{Path: "a.sh", StartLine: i + 1})
+	}
+	gc := &fakeGitHub{}
+	if err := postCheckRun(gc, "org", "repo", github.CheckRun{Output: github.CheckRunOutput{Annotations: annotations}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gc.checkRuns) != 1 || len(gc.checkRuns[0].Output.Annotations) != 50 {
+		t.Fatalf("expected one check run with 50 annotations, got %v", gc.checkRuns)
+	}
+	var batches []int
+	for _, update := range gc.updates {
+		batches = append(batches, len(update.Output.Annotations))
+	}
+	if diff := cmp.Diff([]int{50, 20}, batches); diff != "" {
+		t.Errorf("unexpected update batches (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleCommentRequiresTrustedUser(t *testing.T) {
+	testCases := []struct {
+		name             string
+		trusted          bool
+		expectedComments int
+		expectedPRGets   int
+	}{
+		{
+			name:             "untrusted user is told off",
+			expectedComments: 1,
+		},
+		{
+			name:           "trusted user re-runs the analyzers",
+			trusted:        true,
+			expectedPRGets: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := &fakeGitHub{pr: &github.PullRequest{
+				Number: 5,
+				Base:   github.PullRequestBranch{Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}},
+			}}
+			e := github.GenericCommentEvent{
+				IsPR:   true,
+				Action: github.GenericCommentActionCreated,
+				Body:   "/annotate",
+				Number: 5,
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				User:   github.User{Login: "mallory"},
+			}
+			isTrusted := func(user string) (bool, error) {
+				if user != "mallory" {
+					t.Errorf("expected the trust of the commenter to be checked, got %q", user)
+				}
+				return tc.trusted, nil
+			}
+			if err := handleComment(gc, isTrusted, logrus.WithField("plugin", pluginName), &plugins.CodeAnnotator{}, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(gc.comments) != tc.expectedComments {
+				t.Errorf("expected %d comments, got %v", tc.expectedComments, gc.comments)
+			}
+			if gc.prGets != tc.expectedPRGets {
+				t.Errorf("expected the pull request to be fetched %d times, got %d", tc.expectedPRGets, gc.prGets)
+			}
+		})
+	}
+}
@@ -20,12 +20,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"path"
 	"reflect"
 	"regexp"
 	"slices"
 	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"sigs.k8s.io/yaml"
@@ -38,6 +40,7 @@ import (
 
 	"sigs.k8s.io/prow/pkg/bugzilla"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io/providers"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/logrusutil"
@@ -46,6 +49,7 @@ import (
 
 const (
 	defaultBlunderbussReviewerCount = 2
+	defaultAnalyzerTimeout          = 2 * time.Minute
 )
 
 // Configuration is the top-level serialization target for plugin Configuration.
@@ -72,18 +76,23 @@ type Configuration struct {
 	Blunderbuss          Blunderbuss                  `json:"blunderbuss,omitempty"`
 	Bugzilla             Bugzilla                     `json:"bugzilla,omitempty"`
 	BranchCleaner        BranchCleaner                `json:"branch_cleaner,omitempty"`
+	BranchFastForward    BranchFastForward            `json:"branch_fast_forward,omitempty"`
 	Cat                  Cat                          `json:"cat,omitempty"`
 	CherryPickApproved   []CherryPickApproved         `json:"cherry_pick_approved,omitempty"`
 	CherryPickUnapproved CherryPickUnapproved         `json:"cherry_pick_unapproved,omitempty"`
+	CodeAnnotator        CodeAnnotator                `json:"code_annotator,omitempty"`
 	ConfigUpdater        ConfigUpdater                `json:"config_updater,omitempty"`
+	CustomCommands       []CustomCommand              `json:"custom_commands,omitempty"`
 	Dco                  map[string]*Dco              `json:"dco,omitempty"`
 	Golint               Golint                       `json:"golint,omitempty"`
 	Goose                Goose                        `json:"goose,omitempty"`
 	Heart                Heart                        `json:"heart,omitempty"`
 	Label                Label                        `json:"label,omitempty"`
 	Lgtm                 []Lgtm                       `json:"lgtm,omitempty"`
+	LifecycleManager     []LifecycleManager           `json:"lifecycle_manager,omitempty"`
 	Jira                 *Jira                        `json:"jira,omitempty"`
 	MilestoneApplier     map[string]BranchToMilestone `json:"milestone_applier,omitempty"`
+	ReleaseNoteCheck     []ReleaseNoteCheck           `json:"release_note_check,omitempty"`
 	RepoMilestone        map[string]Milestone         `json:"repo_milestone,omitempty"`
 	Project              ProjectConfig                `json:"project_config,omitempty"`
 	ProjectManager       ProjectManager               `json:"project_manager,omitempty"`
@@ -125,12 +134,124 @@ type Golint struct {
 	MinimumConfidence *float64 `json:"minimum_confidence,omitempty"`
 }
 
+// CodeAnnotator holds configuration for the code-annotator plugin.
+type CodeAnnotator struct {
+	// Analyzers are external analysis services that are sent the changes of
+	// pull requests and respond with annotations for the changed lines.
+	Analyzers []Analyzer `json:"analyzers,omitempty"`
+}
+
+// CustomCommand is a slash command handled by the custom-commands plugin. It
+// replies with a comment, triggers a presubmit job or both.
+type CustomCommand struct {
+	// Name is the name of the command without the leading slash, e.g. `docs`
+	// for `/docs`. It must not shadow built-in commands such as `test`.
+	Name string `json:"name"`
+	// Repos is a list of orgs (eg "o") and repositories (eg "o/r") the
+	// command is available in.
+	Repos []string `json:"repos"`
+	// Description explains the command in the plugin help.
+	Description string `json:"description,omitempty"`
+	// Permission is who can use the command, one of `anyone`, `collaborator`
+	// and `member` of the org. Defaults to `anyone`.
+	Permission string `json:"permission,omitempty"`
+	// Response is the template of the comment posted in reply to the
+	// command. For the data passed to the template see CommandInfo in
+	// pkg/plugins/custom-commands/custom-commands.go.
+	Response string `json:"response,omitempty"`
+	// Job is the name of a presubmit job of the repo that is triggered when
+	// the command is used on a PR. Commands with a job must be restricted to
+	// collaborators or members. Like `/test`, the job is only triggered if
+	// the commenter or the PR is trusted according to the trigger config of
+	// the repo, otherwise the PR needs the `ok-to-test` label.
+	Job string `json:"job,omitempty"`
+}
+
+const (
+	CustomCommandPermissionAnyone       = "anyone"
+	CustomCommandPermissionCollaborator = "collaborator"
+	CustomCommandPermissionMember       = "member"
+)
+
+var customCommandNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// reservedCustomCommandNames are commands handled by built-in plugins that
+// custom commands must not shadow.
+var reservedCustomCommandNames = sets.New[string](
+	"approve", "assign", "cc", "cherrypick", "close", "hold", "label",
+	"lgtm", "lifecycle", "ok-to-test", "override", "remove-label", "reopen",
+	"retest", "retest-required", "skip", "test", "unassign", "uncc",
+)
+
+// CustomCommandsFor returns the custom commands available in the given repo.
+func (c *Configuration) CustomCommandsFor(org, repo string) []CustomCommand {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+	var commands []CustomCommand
+	for _, command := range c.CustomCommands {
+		if slices.Contains(command.Repos, org) || slices.Contains(command.Repos, fullName) {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// Analyzer is an external analysis service used by the code-annotator plugin.
+type Analyzer struct {
+	// Name identifies the analyzer and is used as the name of the check run
+	// its annotations are posted on.
+	Name string `json:"name"`
+	// Endpoint is the URL the analysis request is POSTed to.
+	Endpoint string `json:"endpoint"`
+	// Repos is a list of orgs (eg "o") and repositories (eg "o/r") the
+	// analyzer runs for.
+	Repos []string `json:"repos"`
+	// FileRegexp restricts the changed files that are sent to the analyzer.
+	// All changed files are sent if it is unset.
+	FileRegexp string `json:"file_regexp,omitempty"`
+	// Timeout is how long to wait for the analyzer to respond. Defaults to 2m.
+	Timeout string `json:"timeout,omitempty"`
+
+	FileRe          *regexp.Regexp `json:"-"`
+	TimeoutDuration time.Duration  `json:"-"`
+}
+
+// AnalyzersFor returns the analyzers that run for the given repo.
+func (c *CodeAnnotator) AnalyzersFor(org, repo string) []Analyzer {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+	var analyzers []Analyzer
+	for _, analyzer := range c.Analyzers {
+		if repos := sets.New[string](analyzer.Repos...); repos.Has(org) || repos.Has(fullName) {
+			analyzers = append(analyzers, analyzer)
+		}
+	}
+	return analyzers
+}
+
 // Plugins maps orgOrRepo to plugins
 type Plugins map[string]OrgPlugins
 
 type OrgPlugins struct {
 	ExcludedRepos []string `json:"excluded_repos,omitempty"`
 	Plugins       []string `json:"plugins,omitempty"`
+	// DisabledPlugins opts a repo out of plugins that are enabled for its org,
+	// while it keeps inheriting all other plugins of the org. Only valid for
+	// org/repo entries.
+	DisabledPlugins []string `json:"disabled_plugins,omitempty"`
+}
+
+// EnabledFor returns the plugins enabled for the repo: the plugins of its org,
+// unless the repo is excluded or has disabled them, and those of the repo.
+func (p Plugins) EnabledFor(org, repo string) []string {
+	var enabled []string
+	orgConfig, repoConfig := p[org], p[org+"/"+repo]
+	if !slices.Contains(orgConfig.ExcludedRepos, repo) {
+		for _, plugin := range orgConfig.Plugins {
+			if !slices.Contains(repoConfig.DisabledPlugins, plugin) {
+				enabled = append(enabled, plugin)
+			}
+		}
+	}
+	return append(enabled, repoConfig.Plugins...)
 }
 
 // ExternalPlugin holds configuration for registering an external
@@ -172,6 +293,16 @@ type Blunderbuss struct {
 	// This is useful when a bot user or admin opens a PR that will be
 	// merged regardless of approvals.
 	IgnoreAuthors []string `json:"ignore_authors,omitempty"`
+
+	// BalanceReviewLoad weighs the selection of reviewers by the number of
+	// open pull requests of the org that await their review, so that
+	// reviewers with fewer pending reviews are more likely to be requested.
+	// This uses one additional token per candidate reviewer.
+	BalanceReviewLoad bool `json:"balance_review_load,omitempty"`
+	// UseWorkingHours makes reviewers less likely to be requested outside of
+	// their working hours, as declared by the timezone and working_hours of
+	// their entry in the metadata section of the OWNERS_ALIASES file.
+	UseWorkingHours bool `json:"use_working_hours,omitempty"`
 }
 
 // Owners contains configuration related to handling OWNERS files.
@@ -204,6 +335,14 @@ type Owners struct {
 	// Filenames allows configuring repos to use a separate set of filenames for
 	// any plugin that interacts with these files. Keys are in "org" or "org/repo" format.
 	Filenames map[string]ownersconfig.Filenames `json:"filenames,omitempty"`
+
+	// CodeOwnersRepos is a list of org and org/repo strings specifying the repos
+	// whose GitHub CODEOWNERS file is read in addition to their OWNERS files,
+	// e.g. while they migrate from one to the other. The owners of a CODEOWNERS
+	// rule become approvers and reviewers of the paths matching it. Teams are
+	// only used if an OWNERS_ALIASES alias of the same name, e.g. "org/team",
+	// exists, email addresses are ignored.
+	CodeOwnersRepos []string `json:"codeowners_repos,omitempty"`
 }
 
 // OwnersFilenames determines which filenames to use for OWNERS and OWNERS_ALIASES for a repo.
@@ -237,6 +376,18 @@ func (c *Configuration) MDYAMLEnabled(org, repo string) bool {
 	return false
 }
 
+// CodeOwnersEnabled returns a boolean denoting if the GitHub CODEOWNERS file of the passed
+// repo is read in addition to its OWNERS files.
+func (c *Configuration) CodeOwnersEnabled(org, repo string) bool {
+	full := fmt.Sprintf("%s/%s", org, repo)
+	for _, elem := range c.Owners.CodeOwnersRepos {
+		if elem == org || elem == full {
+			return true
+		}
+	}
+	return false
+}
+
 // SkipCollaborators returns a boolean denoting if collaborator cross-checks are enabled for
 // the passed repo. If it's true, approve and lgtm plugins rely solely on OWNERS files.
 func (c *Configuration) SkipCollaborators(org, repo string) bool {
@@ -332,6 +483,18 @@ type Approve struct {
 	// PrProcessLink is the link to the help page which explains the code review process.
 	// The default value is "https://git.k8s.io/community/contributors/guide/owners.md#the-code-review-process".
 	PrProcessLink string `json:"pr_process_link,omitempty"`
+	// CheckRun makes the plugin report the approval status of every OWNERS file
+	// touched by a PR in a check run on its head commit instead of the approval
+	// notification comment. The approved label is still managed as usual.
+	// Creating check runs requires Prow to authenticate as a GitHub App.
+	CheckRun bool `json:"check_run,omitempty"`
+	// InRepoConfigOwnership makes the inrepoconfig, i.e. the .prow.yaml file and
+	// the files in the .prow directory, approvable only by the members of the
+	// "prow-config-approvers" alias, or by the approvers of the root OWNERS file
+	// if the alias is not defined. Their approval is reported in the
+	// "approve/inrepoconfig" status of the head commit of the PR, which
+	// in_repo_config.require_approval in the Prow config relies on.
+	InRepoConfigOwnership bool `json:"in_repo_config_ownership,omitempty"`
 }
 
 var (
@@ -373,6 +536,86 @@ type Lgtm struct {
 	// StickyLgtmTeam specifies the GitHub team whose members are trusted with sticky LGTM,
 	// which eliminates the need to re-lgtm minor fixes/updates.
 	StickyLgtmTeam string `json:"trusted_team_for_sticky_lgtm,omitempty"`
+	// StickyLgtmPaths is a list of regular expressions matched against the files
+	// changed by a push to a pull request. If every file changed by the push
+	// matches one of them, e.g. because it only fixes documentation or testdata,
+	// the push does not remove LGTM.
+	//
+	// Compiles into StickyLgtmPathsRe during config load.
+	StickyLgtmPaths   []string         `json:"sticky_lgtm_paths,omitempty"`
+	StickyLgtmPathsRe []*regexp.Regexp `json:"-"`
+}
+
+func (l Lgtm) getRepos() []string {
+	return l.Repos
+}
+
+// LifecycleManager specifies the configuration of the lifecycle-manager plugin
+// for a set of repos. The plugin marks inactive issues and PRs as
+// lifecycle/stale, then as lifecycle/rotten and finally closes them.
+type LifecycleManager struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// StaleAfter is how long an issue or PR has to be inactive to be marked as
+	// lifecycle/stale. Defaults to 2160h (90 days).
+	StaleAfter string `json:"stale_after,omitempty"`
+	// RottenAfter is how long an issue or PR has to stay inactive after being
+	// marked as lifecycle/stale to be marked as lifecycle/rotten. Defaults to
+	// 720h (30 days).
+	RottenAfter string `json:"rotten_after,omitempty"`
+	// CloseAfter is how long an issue or PR has to stay inactive after being
+	// marked as lifecycle/rotten to be closed. Defaults to 720h (30 days).
+	CloseAfter string `json:"close_after,omitempty"`
+	// ExemptLabels are labels that exempt issues and PRs from the lifecycle,
+	// in addition to lifecycle/frozen.
+	ExemptLabels []string `json:"exempt_labels,omitempty"`
+	// SkipIssues makes the plugin only manage PRs.
+	SkipIssues bool `json:"skip_issues,omitempty"`
+	// SkipPullRequests makes the plugin only manage issues.
+	SkipPullRequests bool `json:"skip_pull_requests,omitempty"`
+
+	StaleAfterDuration  time.Duration `json:"-"`
+	RottenAfterDuration time.Duration `json:"-"`
+	CloseAfterDuration  time.Duration `json:"-"`
+}
+
+func (lm LifecycleManager) getRepos() []string {
+	return lm.Repos
+}
+
+const (
+	defaultLifecycleStaleAfter  = 90 * 24 * time.Hour
+	defaultLifecycleRottenAfter = 30 * 24 * time.Hour
+	defaultLifecycleCloseAfter  = 30 * 24 * time.Hour
+)
+
+// parseDurations parses the durations of the LifecycleManager into the
+// *Duration fields, empty ones are defaulted.
+func (l *LifecycleManager) parseDurations() error {
+	for _, d := range []struct {
+		name   string
+		value  string
+		def    time.Duration
+		parsed *time.Duration
+	}{
+		{name: "stale_after", value: l.StaleAfter, def: defaultLifecycleStaleAfter, parsed: &l.StaleAfterDuration},
+		{name: "rotten_after", value: l.RottenAfter, def: defaultLifecycleRottenAfter, parsed: &l.RottenAfterDuration},
+		{name: "close_after", value: l.CloseAfter, def: defaultLifecycleCloseAfter, parsed: &l.CloseAfterDuration},
+	} {
+		if d.value == "" {
+			*d.parsed = d.def
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("failed to parse lifecycle_manager %s: %q, error: %w", d.name, d.value, err)
+		}
+		if parsed <= 0 {
+			return fmt.Errorf("lifecycle_manager %s must be positive, got %q", d.name, d.value)
+		}
+		*d.parsed = parsed
+	}
+	return nil
 }
 
 // Jira holds the config for the jira plugin.
@@ -469,6 +712,21 @@ type Trigger struct {
 	IgnoreOkToTest bool `json:"ignore_ok_to_test,omitempty"`
 	// TriggerGitHubWorkflows enables workflows run by github to be triggered by prow.
 	TriggerGitHubWorkflows bool `json:"trigger_github_workflows,omitempty"`
+	// SkipExpiredWorkflowRunsComment disables the comment listing the failed
+	// GitHub workflow runs that could not be re-run, e.g. because they expired.
+	SkipExpiredWorkflowRunsComment bool `json:"skip_expired_workflow_runs_comment,omitempty"`
+	// TriggerOnCommentEdits makes trigger handle commands added to comments by
+	// editing them, e.g. when fixing a typo in a /test command. Jobs requested
+	// by the previous version of the comment are not triggered again.
+	TriggerOnCommentEdits bool `json:"trigger_on_comment_edits,omitempty"`
+	// AbortStaleRuns makes trigger abort the presubmits that are still running
+	// for previous commits of a PR when new commits are pushed to it.
+	// Defaults to true.
+	AbortStaleRuns *bool `json:"abort_stale_runs,omitempty"`
+}
+
+func (t Trigger) getRepos() []string {
+	return t.Repos
 }
 
 // Heart contains the configuration for the heart plugin.
@@ -745,6 +1003,29 @@ type CherryPickUnapproved struct {
 	Comment string `json:"comment,omitempty"`
 }
 
+// ReleaseNoteCheck specifies the configuration of the release-note-check
+// plugin for a set of repos.
+type ReleaseNoteCheck struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// Categories are the allowed values of the `category:` line of a release
+	// note block. If set, every release note needs a category. Any category
+	// is accepted otherwise.
+	Categories []string `json:"categories,omitempty"`
+	// Areas are the allowed values of the optional `area:` line of a release
+	// note block, which is a comma separated list. Any area is accepted if
+	// unset.
+	Areas []string `json:"areas,omitempty"`
+	// ArtifactPath is a GCS or S3 path, e.g. gs://bucket/release-notes, that
+	// valid release notes are written to as <org>/<repo>/<number>.json.
+	// Release notes are only reported in a check run if it is unset.
+	ArtifactPath string `json:"artifact_path,omitempty"`
+}
+
+func (rnc ReleaseNoteCheck) getRepos() []string {
+	return rnc.Repos
+}
+
 // RequireMatchingLabel is the config for the require-matching-label plugin.
 type RequireMatchingLabel struct {
 	// Org is the GitHub organization that this config applies to.
@@ -855,75 +1136,67 @@ func (r RequireMatchingLabel) Describe() string {
 // Approval configuration can be listed for a repository
 // or an organization.
 func (c *Configuration) ApproveFor(org, repo string) *Approve {
-	fullName := fmt.Sprintf("%s/%s", org, repo)
-
-	a := func() *Approve {
-		// First search for repo config
-		for _, approve := range c.Approve {
-			if !sets.New[string](approve.Repos...).Has(fullName) {
-				continue
-			}
-			return &approve
-		}
-
-		// If you don't find anything, loop again looking for an org config
-		for _, approve := range c.Approve {
-			if !sets.New[string](approve.Repos...).Has(org) {
-				continue
-			}
-			return &approve
-		}
-
-		// Return an empty config, and use plugin defaults
-		return &Approve{}
-	}()
+	// An empty config uses the plugin defaults.
+	a, _ := configFor(c.Approve, org, repo)
 	if a.CommandHelpLink == "" {
 		a.CommandHelpLink = "https://go.k8s.io/bot-commands"
 	}
 	if a.PrProcessLink == "" {
 		a.PrProcessLink = "https://git.k8s.io/community/contributors/guide/owners.md#the-code-review-process"
 	}
-	return a
+	return &a
 }
 
-// LgtmFor finds the Lgtm for a repo, if one exists
-// a trigger can be listed for the repo itself or for the
-// owning organization
-func (c *Configuration) LgtmFor(org, repo string) *Lgtm {
+// configFor returns the plugin settings listed for the repo, falling back to
+// those listed for its org. An org/repo entry overrides all settings of its
+// org, the settings aren't merged.
+func configFor[C ListableRepos](configs []C, org, repo string) (C, bool) {
 	fullName := fmt.Sprintf("%s/%s", org, repo)
-	for _, lgtm := range c.Lgtm {
-		if !sets.New[string](lgtm.Repos...).Has(fullName) {
-			continue
+	for _, name := range []string{fullName, org} {
+		for _, config := range configs {
+			if slices.Contains(config.getRepos(), name) {
+				return config, true
+			}
 		}
-		return &lgtm
 	}
-	// If you don't find anything, loop again looking for an org config
-	for _, lgtm := range c.Lgtm {
-		if !sets.New[string](lgtm.Repos...).Has(org) {
-			continue
-		}
-		return &lgtm
+	var empty C
+	return empty, false
+}
+
+// LifecycleManagerFor finds the LifecycleManager for a repo, falling back to
+// the one of the org and then to the defaults. Pass an empty repo to get the
+// configuration of an org.
+func (c *Configuration) LifecycleManagerFor(org, repo string) *LifecycleManager {
+	if lm, ok := configFor(c.LifecycleManager, org, repo); ok {
+		return &lm
 	}
-	return &Lgtm{}
+	lm := &LifecycleManager{}
+	// Parsing the defaults can't fail.
+	_ = lm.parseDurations()
+	return lm
+}
+
+// ReleaseNoteCheckFor finds the ReleaseNoteCheck for a repo, falling back to
+// the one of the org. The zero value is returned if neither exists.
+func (c *Configuration) ReleaseNoteCheckFor(org, repo string) *ReleaseNoteCheck {
+	rnc, _ := configFor(c.ReleaseNoteCheck, org, repo)
+	return &rnc
+}
+
+// LgtmFor finds the Lgtm for a repo, if one exists
+// a trigger can be listed for the repo itself or for the
+// owning organization
+func (c *Configuration) LgtmFor(org, repo string) *Lgtm {
+	lgtm, _ := configFor(c.Lgtm, org, repo)
+	return &lgtm
 }
 
 // TriggerFor finds the Trigger for a repo, if one exists
 // a trigger can be listed for the repo itself or for the
 // owning organization
 func (c *Configuration) TriggerFor(org, repo string) Trigger {
-	fullName := fmt.Sprintf("%s/%s", org, repo)
 	// Prioritize repo level triggers over org level triggers.
-	for _, trigger := range c.Triggers {
-		if !sets.NewString(trigger.Repos...).Has(fullName) {
-			continue
-		}
-		return trigger
-	}
-	// If you don't find anything, loop again looking for an org config
-	for _, trigger := range c.Triggers {
-		if !sets.NewString(trigger.Repos...).Has(org) {
-			continue
-		}
+	if trigger, ok := configFor(c.Triggers, org, repo); ok {
 		return trigger
 	}
 
@@ -938,6 +1211,12 @@ func (t *Trigger) SetDefaults() {
 	}
 }
 
+// ShouldAbortStaleRuns returns whether the presubmits still running for
+// previous commits of a PR are aborted when new commits are pushed to it.
+func (t *Trigger) ShouldAbortStaleRuns() bool {
+	return t.AbortStaleRuns == nil || *t.AbortStaleRuns
+}
+
 // DcoFor finds the Dco for a repo, if one exists
 // a Dco can be listed for the repo itself or for the
 // owning organization
@@ -1004,6 +1283,12 @@ func (c *Configuration) EnabledReposForPlugin(plugin string) (orgs, repos []stri
 			}
 		}
 	}
+	// repos can opt out of <plugin> while inheriting the other plugins of the org
+	for repo, plugins := range c.Plugins {
+		if org, _, ok := strings.Cut(repo, "/"); ok && orgExceptions[org] != nil && slices.Contains(plugins.DisabledPlugins, plugin) {
+			orgExceptions[org].Insert(repo)
+		}
+	}
 	// <plugin> plugin might be declared in both org and org/repo
 	// in that case, remove repo from org's orgExceptions despite the excluded_repo in org
 	for _, repo := range repos {
@@ -1127,12 +1412,43 @@ func validatePluginsDupes(plugins Plugins) error {
 	return utilerrors.NewAggregate(errors)
 }
 
+// validatePluginsOptOuts will return an error if plugins are disabled where it
+// has no effect: at the org level, for plugins that aren't enabled for the org,
+// for repos that are excluded from the org or that also enable the plugin.
+func validatePluginsOptOuts(plugins Plugins) error {
+	var errors []error
+	for orgRepo, repoConfig := range plugins {
+		if len(repoConfig.DisabledPlugins) == 0 {
+			continue
+		}
+		org, repo, ok := strings.Cut(orgRepo, "/")
+		if !ok {
+			errors = append(errors, fmt.Errorf("disabled_plugins can only be set for repos, not for org %s", orgRepo))
+			continue
+		}
+		orgConfig := plugins[org]
+		if slices.Contains(orgConfig.ExcludedRepos, repo) {
+			errors = append(errors, fmt.Errorf("%s disables plugins %v but is excluded from all plugins of %s", orgRepo, repoConfig.DisabledPlugins, org))
+			continue
+		}
+		for _, plugin := range repoConfig.DisabledPlugins {
+			if !slices.Contains(orgConfig.Plugins, plugin) {
+				errors = append(errors, fmt.Errorf("%s disables plugin %s which is not enabled for %s", orgRepo, plugin, org))
+			}
+			if slices.Contains(repoConfig.Plugins, plugin) {
+				errors = append(errors, fmt.Errorf("%s both enables and disables plugin %s", orgRepo, plugin))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errors)
+}
+
 // ValidatePluginsUnknown will return an error if there are any unrecognized
 // plugins configured.
 func (c *Configuration) ValidatePluginsUnknown() error {
 	var errors []error
 	for _, configuration := range c.Plugins {
-		for _, plugin := range configuration.Plugins {
+		for _, plugin := range append(append([]string{}, configuration.Plugins...), configuration.DisabledPlugins...) {
 			if _, ok := pluginHelp[plugin]; !ok {
 				errors = append(errors, fmt.Errorf("unknown plugin: %s", plugin))
 			}
@@ -1192,6 +1508,13 @@ func validateExternalPlugins(pluginMap map[string][]ExternalPlugin) error {
 	return nil
 }
 
+func validateOverride(o Override) error {
+	if o.CheckRunConclusion != "" && o.CheckRunConclusion != "success" && o.CheckRunConclusion != "neutral" {
+		return fmt.Errorf("invalid override check_run_conclusion: %q (needs to be success or neutral)", o.CheckRunConclusion)
+	}
+	return nil
+}
+
 func validateBlunderbuss(b *Blunderbuss) error {
 	if b.ReviewerCount != nil && *b.ReviewerCount < 1 {
 		return fmt.Errorf("invalid request_count: %v (needs to be positive)", *b.ReviewerCount)
@@ -1204,6 +1527,87 @@ type ConfigMapID struct {
 	Name, Namespace, Cluster string
 }
 
+func validateCustomCommands(commands []CustomCommand) error {
+	var errs []error
+	for _, command := range commands {
+		if !customCommandNameRe.MatchString(command.Name) {
+			errs = append(errs, fmt.Errorf("the custom_commands name %q must only contain letters, digits, dashes and underscores", command.Name))
+			continue
+		}
+		if reservedCustomCommandNames.Has(strings.ToLower(command.Name)) {
+			errs = append(errs, fmt.Errorf("the custom command %q shadows a built-in command", command.Name))
+		}
+		if len(command.Repos) == 0 {
+			errs = append(errs, fmt.Errorf("the custom command %q is not available in any repo", command.Name))
+		}
+		switch command.Permission {
+		case "", CustomCommandPermissionAnyone, CustomCommandPermissionCollaborator, CustomCommandPermissionMember:
+		default:
+			errs = append(errs, fmt.Errorf("the permission %q of custom command %q must be one of %s, %s and %s", command.Permission, command.Name,
+				CustomCommandPermissionAnyone, CustomCommandPermissionCollaborator, CustomCommandPermissionMember))
+		}
+		if command.Job != "" && (command.Permission == "" || command.Permission == CustomCommandPermissionAnyone) {
+			errs = append(errs, fmt.Errorf("the custom command %q triggers a job, so its permission must be %s or %s", command.Name, CustomCommandPermissionCollaborator, CustomCommandPermissionMember))
+		}
+		if command.Response == "" && command.Job == "" {
+			errs = append(errs, fmt.Errorf("the custom command %q needs a response, a job or both", command.Name))
+		}
+		if _, err := template.New(command.Name).Parse(command.Response); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse the response of custom command %q: %w", command.Name, err))
+		}
+	}
+	// A command can only be defined once per repo.
+	for i, command := range commands {
+		for _, other := range commands[:i] {
+			if !strings.EqualFold(command.Name, other.Name) {
+				continue
+			}
+			for _, repo := range command.Repos {
+				org, _, isRepo := strings.Cut(repo, "/")
+				if slices.Contains(other.Repos, repo) || (isRepo && slices.Contains(other.Repos, org)) || slices.ContainsFunc(other.Repos, func(r string) bool { return strings.HasPrefix(r, repo+"/") }) {
+					errs = append(errs, fmt.Errorf("the custom command %q is defined more than once for %s", command.Name, repo))
+				}
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateReleaseNoteCheck(configs []ReleaseNoteCheck) error {
+	var errs []error
+	for _, rnc := range configs {
+		if rnc.ArtifactPath != "" && !strings.HasPrefix(rnc.ArtifactPath, providers.GS+"://") && !strings.HasPrefix(rnc.ArtifactPath, providers.S3+"://") && !strings.HasPrefix(rnc.ArtifactPath, providers.ABS+"://") {
+			errs = append(errs, fmt.Errorf("the release_note_check artifact_path %q must start with gs://, s3:// or abs://", rnc.ArtifactPath))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateCodeAnnotator(c *CodeAnnotator) error {
+	var errs []error
+	names := sets.New[string]()
+	for _, analyzer := range c.Analyzers {
+		if analyzer.Name == "" {
+			errs = append(errs, errors.New("every code_annotator analyzer needs a name"))
+			continue
+		}
+		if names.Has(analyzer.Name) {
+			errs = append(errs, fmt.Errorf("the code_annotator analyzer %q is defined more than once", analyzer.Name))
+		}
+		names.Insert(analyzer.Name)
+		if u, err := url.Parse(analyzer.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("the endpoint %q of code_annotator analyzer %q is not a valid URL", analyzer.Endpoint, analyzer.Name))
+		}
+		if len(analyzer.Repos) == 0 {
+			errs = append(errs, fmt.Errorf("the code_annotator analyzer %q does not run for any repo", analyzer.Name))
+		}
+		if analyzer.TimeoutDuration <= 0 {
+			errs = append(errs, fmt.Errorf("the timeout of code_annotator analyzer %q must be positive", analyzer.Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func validateConfigUpdater(updater *ConfigUpdater) error {
 	updater.SetDefaults()
 	configMapKeys := map[ConfigMapID]sets.Set[string]{}
@@ -1327,6 +1731,23 @@ func validateTrigger(triggers []Trigger) error {
 	return nil
 }
 
+func validateBranchFastForward(b BranchFastForward) error {
+	for repo, config := range b.Repos {
+		if strings.Count(repo, "/") != 1 {
+			return fmt.Errorf("branch_fast_forward: repos must be of the form org/repo, got %q", repo)
+		}
+		if len(config.Branches) == 0 {
+			return fmt.Errorf("branch_fast_forward: no branches configured for %s", repo)
+		}
+		for _, expr := range config.Branches {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("branch_fast_forward: invalid branch expression %q for %s: %w", expr, repo, err)
+			}
+		}
+	}
+	return nil
+}
+
 var warnRepoMilestone time.Time
 
 func validateRepoMilestone(milestones map[string]Milestone) {
@@ -1369,6 +1790,43 @@ func compileRegexpsAndDurations(pc *Configuration) error {
 		pc.Blockades[i].BranchRe = branchRe
 	}
 
+	for i := range pc.CodeAnnotator.Analyzers {
+		analyzer := &pc.CodeAnnotator.Analyzers[i]
+		if analyzer.FileRegexp != "" {
+			fileRe, err := regexp.Compile(analyzer.FileRegexp)
+			if err != nil {
+				return fmt.Errorf("failed to compile file_regexp of code_annotator analyzer %q: %w", analyzer.Name, err)
+			}
+			analyzer.FileRe = fileRe
+		}
+		analyzer.TimeoutDuration = defaultAnalyzerTimeout
+		if analyzer.Timeout != "" {
+			timeout, err := time.ParseDuration(analyzer.Timeout)
+			if err != nil {
+				return fmt.Errorf("failed to parse timeout of code_annotator analyzer %q: %w", analyzer.Name, err)
+			}
+			analyzer.TimeoutDuration = timeout
+		}
+	}
+
+	for i := range pc.Lgtm {
+		var pathsRe []*regexp.Regexp
+		for _, path := range pc.Lgtm[i].StickyLgtmPaths {
+			re, err := regexp.Compile(path)
+			if err != nil {
+				return fmt.Errorf("failed to compile lgtm sticky_lgtm_paths regexp: %q, error: %w", path, err)
+			}
+			pathsRe = append(pathsRe, re)
+		}
+		pc.Lgtm[i].StickyLgtmPathsRe = pathsRe
+	}
+
+	for i := range pc.LifecycleManager {
+		if err := pc.LifecycleManager[i].parseDurations(); err != nil {
+			return err
+		}
+	}
+
 	commentRe, err := regexp.Compile(pc.Heart.CommentRegexp)
 	if err != nil {
 		return err
@@ -1408,15 +1866,30 @@ func (c *Configuration) Validate() error {
 	if err := validatePluginsDupes(c.Plugins); err != nil {
 		return err
 	}
+	if err := validatePluginsOptOuts(c.Plugins); err != nil {
+		return err
+	}
 	if err := validateExternalPlugins(c.ExternalPlugins); err != nil {
 		return err
 	}
 	if err := validateBlunderbuss(&c.Blunderbuss); err != nil {
 		return err
 	}
+	if err := validateOverride(c.Override); err != nil {
+		return err
+	}
 	if err := validateConfigUpdater(&c.ConfigUpdater); err != nil {
 		return err
 	}
+	if err := validateCodeAnnotator(&c.CodeAnnotator); err != nil {
+		return err
+	}
+	if err := validateReleaseNoteCheck(c.ReleaseNoteCheck); err != nil {
+		return err
+	}
+	if err := validateCustomCommands(c.CustomCommands); err != nil {
+		return err
+	}
 	if err := validateSizes(c.Size); err != nil {
 		return err
 	}
@@ -1429,10 +1902,17 @@ func (c *Configuration) Validate() error {
 	if err := validateTrigger(c.Triggers); err != nil {
 		return err
 	}
-	if err := validateRepoDupes(c.Approve); err != nil {
+	if err := validateRepoDupes("approve", c.Approve); err != nil {
 		return err
 	}
-	if err := validateRepoDupes(c.Welcome); err != nil {
+	warnRepoDupes("lgtm", c.Lgtm)
+	warnRepoDupes("lifecycle-manager", c.LifecycleManager)
+	warnRepoDupes("release-note-check", c.ReleaseNoteCheck)
+	warnRepoDupes("trigger", c.Triggers)
+	if err := validateRepoDupes("welcome", c.Welcome); err != nil {
+		return err
+	}
+	if err := validateBranchFastForward(c.BranchFastForward); err != nil {
 		return err
 	}
 	validateRepoMilestone(c.RepoMilestone)
@@ -1444,7 +1924,9 @@ type ListableRepos interface {
 	getRepos() []string
 }
 
-func validateRepoDupes[C ListableRepos](configs []C) error {
+// validateRepoDupes returns an error if an org or repo is listed in more than
+// one of the settings of a plugin, as only the first of them would apply.
+func validateRepoDupes[C ListableRepos](plugin string, configs []C) error {
 	var errs []error
 	orgs := map[string]bool{}
 	repos := map[string]bool{}
@@ -1452,12 +1934,12 @@ func validateRepoDupes[C ListableRepos](configs []C) error {
 		for _, entry := range config.getRepos() {
 			if strings.Contains(entry, "/") {
 				if repos[entry] {
-					errs = append(errs, fmt.Errorf("The repo %q is duplicated in the 'welcome' plugin configuration.", entry))
+					errs = append(errs, fmt.Errorf("The repo %q is duplicated in the '%s' plugin configuration.", entry, plugin))
 				}
 				repos[entry] = true
 			} else {
 				if orgs[entry] {
-					errs = append(errs, fmt.Errorf("The org %q is duplicated in the 'welcome' plugin configuration.", entry))
+					errs = append(errs, fmt.Errorf("The org %q is duplicated in the '%s' plugin configuration.", entry, plugin))
 				}
 				orgs[entry] = true
 			}
@@ -1466,6 +1948,15 @@ func validateRepoDupes[C ListableRepos](configs []C) error {
 	return utilerrors.NewAggregate(errs)
 }
 
+// warnRepoDupes logs the orgs and repos listed in more than one of the
+// settings of a plugin. Such configs were accepted before the settings were
+// resolved from the repo then the org, so they keep loading.
+func warnRepoDupes[C ListableRepos](plugin string, configs []C) {
+	if err := validateRepoDupes(plugin, configs); err != nil {
+		logrus.WithError(err).Warn("Only the first entry listing an org or repo applies.")
+	}
+}
+
 func (pluginConfig *ProjectConfig) GetMaintainerTeam(org string, repo string) int {
 	for orgName, orgConfig := range pluginConfig.Orgs {
 		if org == orgName {
@@ -2008,12 +2499,71 @@ func (b *BranchCleaner) IsPreservedBranch(org, repo, branch string) bool {
 	return false
 }
 
+// BranchFastForward contains the configuration for the branchff plugin.
+type BranchFastForward struct {
+	// Repos maps org/repo to the configuration of the release branches
+	// that may be fast-forwarded in the repository.
+	Repos map[string]BranchFastForwardRepo `json:"repos,omitempty"`
+}
+
+// BranchFastForwardRepo configures which release branches of a repository
+// the branchff plugin fast-forwards.
+type BranchFastForwardRepo struct {
+	// Branches are regular expressions matching the names of the release
+	// branches, e.g. `release-\d+\.\d+`. An expression must match the
+	// whole branch name.
+	Branches []string `json:"branches,omitempty"`
+	// SourceBranch is the branch that release branches are fast-forwarded
+	// to. Defaults to "master".
+	SourceBranch string `json:"source_branch,omitempty"`
+	// Label fast-forwards all release branches when a member of the milestone
+	// maintainers team adds it to an issue or pull request. The label is
+	// removed afterwards. Only the /branchff command is available if unset.
+	Label string `json:"label,omitempty"`
+}
+
+// GetSourceBranch returns the branch that release branches are
+// fast-forwarded to.
+func (b BranchFastForwardRepo) GetSourceBranch() string {
+	if b.SourceBranch == "" {
+		return "master"
+	}
+	return b.SourceBranch
+}
+
+// IsReleaseBranch checks whether the branch may be fast-forwarded.
+func (b BranchFastForwardRepo) IsReleaseBranch(branch string) bool {
+	if branch == b.GetSourceBranch() {
+		return false
+	}
+	for _, expr := range b.Branches {
+		if match, _ := regexp.MatchString("^(?:"+expr+")$", branch); match {
+			return true
+		}
+	}
+	return false
+}
+
 // Override holds options for the override plugin
 type Override struct {
 	AllowTopLevelOwners bool `json:"allow_top_level_owners,omitempty"`
 	// AllowedGitHubTeams is a map of orgs and/or repositories (eg "org" or "org/repo") to list of GitHub team slugs,
 	// members of which are allowed to override contexts
 	AllowedGitHubTeams map[string][]string `json:"allowed_github_teams,omitempty"`
+	// CheckRunConclusion is the conclusion of the check runs that override
+	// failing check runs, e.g. of GitHub Actions. Either "success" or
+	// "neutral", defaults to "success". Check runs can only be overridden
+	// if Prow authenticates as a GitHub App.
+	CheckRunConclusion string `json:"check_run_conclusion,omitempty"`
+}
+
+// GetCheckRunConclusion returns the conclusion of the check runs that
+// override failing check runs.
+func (o Override) GetCheckRunConclusion() string {
+	if o.CheckRunConclusion == "" {
+		return "success"
+	}
+	return o.CheckRunConclusion
 }
 
 func (c *Configuration) mergeFrom(other *Configuration) error {
@@ -1513,6 +1513,9 @@ orgA/repoB:
  plugins:
  - pluginCommon
  - pluginOnlyForRepoB
+orgA/repoC:
+ disabled_plugins:
+ - pluginNotForRepoB
 `)
 	var p Plugins
 	err := yaml.Unmarshal(pluginsYaml, &p)
@@ -1538,7 +1541,7 @@ orgA/repoB:
 			name:              "pluginNotForRepoB",
 			wantOrgs:          []string{"orgA"},
 			wantRepos:         nil,
-			wantExcludedRepos: map[string]sets.Set[string]{"orgA": {"orgA/repoB": {}}},
+			wantExcludedRepos: map[string]sets.Set[string]{"orgA": {"orgA/repoB": {}, "orgA/repoC": {}}},
 		},
 		{
 			name:              "pluginOnlyForRepoB",
@@ -2357,6 +2360,64 @@ func TestMergeFrom(t *testing.T) {
 	}
 }
 
+func TestValidatePluginsOptOuts(t *testing.T) {
+	testCases := []struct {
+		name           string
+		plugins        Plugins
+		expectedErrMsg string
+	}{
+		{
+			name: "repo opts out of org plugin",
+			plugins: Plugins{
+				"org":      OrgPlugins{Plugins: []string{"approve", "lgtm"}},
+				"org/repo": OrgPlugins{Plugins: []string{"woof"}, DisabledPlugins: []string{"lgtm"}},
+			},
+		},
+		{
+			name: "org disables plugins",
+			plugins: Plugins{
+				"org": OrgPlugins{Plugins: []string{"approve"}, DisabledPlugins: []string{"lgtm"}},
+			},
+			expectedErrMsg: "disabled_plugins can only be set for repos, not for org org",
+		},
+		{
+			name: "plugin not enabled for org",
+			plugins: Plugins{
+				"org":      OrgPlugins{Plugins: []string{"approve"}},
+				"org/repo": OrgPlugins{DisabledPlugins: []string{"lgtm"}},
+			},
+			expectedErrMsg: "org/repo disables plugin lgtm which is not enabled for org",
+		},
+		{
+			name: "plugin enabled and disabled",
+			plugins: Plugins{
+				"org":      OrgPlugins{Plugins: []string{"lgtm"}},
+				"org/repo": OrgPlugins{Plugins: []string{"lgtm"}, DisabledPlugins: []string{"lgtm"}},
+			},
+			expectedErrMsg: "org/repo both enables and disables plugin lgtm",
+		},
+		{
+			name: "repo is excluded",
+			plugins: Plugins{
+				"org":      OrgPlugins{Plugins: []string{"lgtm"}, ExcludedRepos: []string{"repo"}},
+				"org/repo": OrgPlugins{DisabledPlugins: []string{"lgtm"}},
+			},
+			expectedErrMsg: "org/repo disables plugins [lgtm] but is excluded from all plugins of org",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validatePluginsOptOuts(tc.plugins); err != nil {
+				errMsg = err.Error()
+			}
+			if diff := cmp.Diff(tc.expectedErrMsg, errMsg); diff != "" {
+				t.Errorf("expected error differs from result: %s", diff)
+			}
+		})
+	}
+}
+
 func TestValidatePluginsDupes(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -2416,3 +2477,218 @@ func TestValidatePluginsDupes(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateReleaseNoteCheck(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      []ReleaseNoteCheck
+		expectedErr string
+	}{
+		{
+			name:   "no artifact path",
+			config: []ReleaseNoteCheck{{Repos: []string{"org"}}},
+		},
+		{
+			name: "GCS and S3 artifact paths",
+			config: []ReleaseNoteCheck{
+				{Repos: []string{"org"}, ArtifactPath: "gs://bucket/notes"},
+				{Repos: []string{"other"}, ArtifactPath: "s3://bucket/notes"},
+			},
+		},
+		{
+			name:        "artifact path without provider",
+			config:      []ReleaseNoteCheck{{Repos: []string{"org"}, ArtifactPath: "bucket/notes"}},
+			expectedErr: `the release_note_check artifact_path "bucket/notes" must start with gs://, s3:// or abs://`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateReleaseNoteCheck(tc.config); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateBranchFastForward(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      BranchFastForward
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			config: BranchFastForward{Repos: map[string]BranchFastForwardRepo{
+				"org/repo": {Branches: []string{`release-\d+\.\d+`}},
+			}},
+		},
+		{
+			name: "org instead of repo",
+			config: BranchFastForward{Repos: map[string]BranchFastForwardRepo{
+				"org": {Branches: []string{"release"}},
+			}},
+			expectedErr: `branch_fast_forward: repos must be of the form org/repo, got "org"`,
+		},
+		{
+			name: "no branches",
+			config: BranchFastForward{Repos: map[string]BranchFastForwardRepo{
+				"org/repo": {},
+			}},
+			expectedErr: "branch_fast_forward: no branches configured for org/repo",
+		},
+		{
+			name: "invalid branch expression",
+			config: BranchFastForward{Repos: map[string]BranchFastForwardRepo{
+				"org/repo": {Branches: []string{"release-("}},
+			}},
+			expectedErr: "branch_fast_forward: invalid branch expression \"release-(\" for org/repo: error parsing regexp: missing closing ): `release-(`",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateBranchFastForward(tc.config); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestBranchFastForwardRepoIsReleaseBranch(t *testing.T) {
+	ff := BranchFastForwardRepo{Branches: []string{`release-\d+\.\d+`, "master"}}
+	for branch, expected := range map[string]bool{
+		"release-1.30":         true,
+		"release-1.30-rc":      false,
+		"feature/release-1.30": false,
+		"master":               false,
+	} {
+		if actual := ff.IsReleaseBranch(branch); actual != expected {
+			t.Errorf("expected IsReleaseBranch(%q) to be %t, got %t", branch, expected, actual)
+		}
+	}
+}
+
+func TestValidateCustomCommands(t *testing.T) {
+	testCases := []struct {
+		name        string
+		commands    []CustomCommand
+		expectedErr string
+	}{
+		{
+			name: "valid commands",
+			commands: []CustomCommand{
+				{Name: "docs", Repos: []string{"org"}, Response: "See {{.Org}}/docs."},
+				{Name: "docs", Repos: []string{"other/repo"}, Permission: CustomCommandPermissionCollaborator, Job: "pull-docs"},
+				{Name: "deploy", Repos: []string{"org/repo"}, Permission: CustomCommandPermissionMember, Job: "pull-deploy"},
+			},
+		},
+		{
+			name:        "invalid name",
+			commands:    []CustomCommand{{Name: "/docs", Repos: []string{"org"}, Response: "docs"}},
+			expectedErr: `the custom_commands name "/docs" must only contain letters, digits, dashes and underscores`,
+		},
+		{
+			name:        "built-in command is shadowed",
+			commands:    []CustomCommand{{Name: "Retest", Repos: []string{"org"}, Permission: CustomCommandPermissionMember, Job: "pull-docs"}},
+			expectedErr: `the custom command "Retest" shadows a built-in command`,
+		},
+		{
+			name:        "invalid permission",
+			commands:    []CustomCommand{{Name: "docs", Repos: []string{"org"}, Permission: "admin", Response: "docs"}},
+			expectedErr: `the permission "admin" of custom command "docs" must be one of anyone, collaborator and member`,
+		},
+		{
+			name:        "job that anyone can trigger",
+			commands:    []CustomCommand{{Name: "docs", Repos: []string{"org"}, Permission: CustomCommandPermissionAnyone, Job: "pull-docs"}},
+			expectedErr: `the custom command "docs" triggers a job, so its permission must be collaborator or member`,
+		},
+		{
+			name:        "job with the default permission",
+			commands:    []CustomCommand{{Name: "docs", Repos: []string{"org"}, Job: "pull-docs"}},
+			expectedErr: `the custom command "docs" triggers a job, so its permission must be collaborator or member`,
+		},
+		{
+			name:        "nothing to do",
+			commands:    []CustomCommand{{Name: "docs", Repos: []string{"org"}}},
+			expectedErr: `the custom command "docs" needs a response, a job or both`,
+		},
+		{
+			name:        "invalid template",
+			commands:    []CustomCommand{{Name: "docs", Repos: []string{"org"}, Response: "{{.Org"}},
+			expectedErr: `failed to parse the response of custom command "docs": template: docs:1: unclosed action`,
+		},
+		{
+			name: "command defined for a repo and its org",
+			commands: []CustomCommand{
+				{Name: "docs", Repos: []string{"org"}, Response: "docs"},
+				{Name: "docs", Repos: []string{"org/repo"}, Response: "repo docs"},
+			},
+			expectedErr: `the custom command "docs" is defined more than once for org/repo`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateCustomCommands(tc.commands); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateRepoDupes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		lgtm           []Lgtm
+		expectedErrMsg string
+	}{
+		{
+			name: "repo overrides its org",
+			lgtm: []Lgtm{{Repos: []string{"org"}}, {Repos: []string{"org/repo"}, StoreTreeHash: true}},
+		},
+		{
+			name:           "repo listed twice",
+			lgtm:           []Lgtm{{Repos: []string{"org/repo"}}, {Repos: []string{"org/repo"}, StoreTreeHash: true}},
+			expectedErrMsg: `The repo "org/repo" is duplicated in the 'lgtm' plugin configuration.`,
+		},
+		{
+			name:           "org listed twice",
+			lgtm:           []Lgtm{{Repos: []string{"org", "other"}}, {Repos: []string{"org"}}},
+			expectedErrMsg: `The org "org" is duplicated in the 'lgtm' plugin configuration.`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateRepoDupes("lgtm", tc.lgtm); err != nil {
+				errMsg = err.Error()
+			}
+			if diff := cmp.Diff(tc.expectedErrMsg, errMsg); diff != "" {
+				t.Errorf("expected error differs from result: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateAcceptsDupesOfPreviouslyValidPlugins(t *testing.T) {
+	dupes := []string{"org", "org/repo", "org", "org/repo"}
+	c := &Configuration{
+		Lgtm:             []Lgtm{{Repos: dupes[:2]}, {Repos: dupes[2:]}},
+		LifecycleManager: []LifecycleManager{{Repos: dupes[:2]}, {Repos: dupes[2:]}},
+		ReleaseNoteCheck: []ReleaseNoteCheck{{Repos: dupes[:2]}, {Repos: dupes[2:]}},
+		Triggers:         []Trigger{{Repos: dupes[:2]}, {Repos: dupes[2:]}},
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected duplicated entries to only be warned about, got error: %v", err)
+	}
+}
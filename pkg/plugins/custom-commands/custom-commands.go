@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package customcommands implements the custom-commands plugin, which handles
+// slash commands that are defined in the plugin config.
+package customcommands
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/git/v2"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/trigger"
+)
+
+// PluginName defines this plugin's registered name.
+const PluginName = "custom-commands"
+
+func init() {
+	plugins.RegisterGenericCommentHandler(PluginName, handleGenericComment, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		CustomCommands: []plugins.CustomCommand{
+			{
+				Name:        "docs",
+				Repos:       []string{"org", "org/repo"},
+				Description: "Builds a preview of the documentation.",
+				Permission:  plugins.CustomCommandPermissionCollaborator,
+				Response:    "Building a preview of the docs of #{{.Number}} for @{{.User}}.",
+				Job:         "pull-docs-preview",
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The custom-commands plugin handles slash commands that are defined in the plugin config rather than in code. A command replies with a comment rendered from a template, triggers a presubmit job on the PR or both.",
+		Snippet:     yamlSnippet,
+	}
+	seen := map[string]bool{}
+	for _, repo := range enabledRepos {
+		for _, command := range config.CustomCommandsFor(repo.Org, repo.Repo) {
+			if seen[command.Name] {
+				continue
+			}
+			seen[command.Name] = true
+			description := command.Description
+			if command.Job != "" {
+				description = strings.TrimSpace(fmt.Sprintf("%s Triggers the %s job on PRs.", description, command.Job))
+			}
+			pluginHelp.AddCommand(pluginhelp.Command{
+				Usage:       "/" + command.Name + " [arguments]",
+				Description: description,
+				WhoCanUse:   whoCanUse(command.Permission),
+				Examples:    []string{"/" + command.Name},
+			})
+		}
+	}
+	return pluginHelp, nil
+}
+
+func whoCanUse(permission string) string {
+	switch permission {
+	case plugins.CustomCommandPermissionCollaborator:
+		return "Collaborators of the repository."
+	case plugins.CustomCommandPermissionMember:
+		return "Members of the organization."
+	default:
+		return "Anyone."
+	}
+}
+
+type githubClient interface {
+	CreateComment(org, repo string, number int, comment string) error
+	IsCollaborator(org, repo, user string) (bool, error)
+	IsMember(org, user string) (bool, error)
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	GetRef(org, repo, ref string) (string, error)
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
+	BotUserChecker() (func(candidate string) bool, error)
+}
+
+// CommandInfo is the data the response template of a command is executed on.
+type CommandInfo struct {
+	Org    string
+	Repo   string
+	Number int
+	IsPR   bool
+	// User is who used the command.
+	User string
+	// Author is the author of the issue or PR.
+	Author string
+	// Args is the text after the command on the same line.
+	Args string
+}
+
+func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error {
+	tc := trigger.Client{
+		GitHubClient:  pc.GitHubClient,
+		ProwJobClient: pc.ProwJobClient,
+		Config:        pc.Config,
+		Logger:        pc.Logger,
+		GitClient:     pc.GitClient,
+	}
+	org, repo := e.Repo.Owner.Login, e.Repo.Name
+	return handle(pc.GitHubClient, tc, pc.Logger, pc.PluginConfig.CustomCommandsFor(org, repo), pc.PluginConfig.TriggerFor(org, repo), pc.GitClient, &e)
+}
+
+func handle(gc githubClient, tc trigger.Client, log *logrus.Entry, commands []plugins.CustomCommand, triggerConfig plugins.Trigger, gitClient git.ClientFactory, e *github.GenericCommentEvent) error {
+	if e.Action != github.GenericCommentActionCreated {
+		return nil
+	}
+	var errs []error
+	for _, command := range commands {
+		re := regexp.MustCompile(`(?mi)^/` + regexp.QuoteMeta(command.Name) + `(?:[ \t]+(.*?))?\s*$`)
+		match := re.FindStringSubmatch(e.Body)
+		if match == nil {
+			continue
+		}
+		if err := run(gc, tc, log.WithField("command", command.Name), command, triggerConfig, gitClient, e, match[1]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to run /%s: %w", command.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func run(gc githubClient, tc trigger.Client, log *logrus.Entry, command plugins.CustomCommand, triggerConfig plugins.Trigger, gitClient git.ClientFactory, e *github.GenericCommentEvent, args string) error {
+	org := e.Repo.Owner.Login
+	repo := e.Repo.Name
+	reply := func(msg string) error {
+		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
+	}
+
+	allowed, err := permitted(gc, command.Permission, org, repo, e.User.Login)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return reply(fmt.Sprintf("You can't use `/%s`. It can only be used by: %s", command.Name, whoCanUse(command.Permission)))
+	}
+
+	if command.Job != "" {
+		if !e.IsPR {
+			return reply(fmt.Sprintf("`/%s` can only be used on pull requests.", command.Name))
+		}
+		if e.IssueState != "open" {
+			return reply(fmt.Sprintf("`/%s` can only be used on open pull requests.", command.Name))
+		}
+		// Jobs run the code of the PR, so the same trust rules as for
+		// `/test` apply regardless of who may use the command.
+		trusted, err := trustedToTrigger(gc, triggerConfig, org, repo, e)
+		if err != nil {
+			return err
+		}
+		if !trusted {
+			return reply(fmt.Sprintf("`/%s` cannot trigger the %s job until a trusted user reviews the PR and leaves an `/ok-to-test` message.", command.Name, command.Job))
+		}
+		found, err := triggerJob(gc, tc, command.Job, gitClient, org, repo, e)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return reply(fmt.Sprintf("`/%s` is configured to trigger the %s job, but the job does not exist for this pull request.", command.Name, command.Job))
+		}
+	}
+
+	if command.Response == "" {
+		return nil
+	}
+	tmpl, err := template.New(command.Name).Parse(command.Response)
+	if err != nil {
+		return fmt.Errorf("failed to parse the response template: %w", err)
+	}
+	var msg bytes.Buffer
+	if err := tmpl.Execute(&msg, CommandInfo{
+		Org:    org,
+		Repo:   repo,
+		Number: e.Number,
+		IsPR:   e.IsPR,
+		User:   e.User.Login,
+		Author: e.IssueAuthor.Login,
+		Args:   args,
+	}); err != nil {
+		return fmt.Errorf("failed to execute the response template: %w", err)
+	}
+	log.Info("Replying to custom command.")
+	return reply(msg.String())
+}
+
+func permitted(gc githubClient, permission, org, repo, user string) (bool, error) {
+	switch permission {
+	case plugins.CustomCommandPermissionCollaborator:
+		return gc.IsCollaborator(org, repo, user)
+	case plugins.CustomCommandPermissionMember:
+		return gc.IsMember(org, user)
+	default:
+		return true, nil
+	}
+}
+
+// trustedToTrigger returns whether jobs may be triggered on the PR, which is
+// the case if either the commenter or the PR is trusted by trigger.
+func trustedToTrigger(gc githubClient, triggerConfig plugins.Trigger, org, repo string, e *github.GenericCommentEvent) (bool, error) {
+	trustedResponse, err := trigger.TrustedUser(gc, triggerConfig.OnlyOrgMembers, triggerConfig.TrustedApps, triggerConfig.TrustedOrg, e.User.Login, org, repo)
+	if err != nil {
+		return false, fmt.Errorf("error checking trust of %s: %w", e.User.Login, err)
+	}
+	if trustedResponse.IsTrusted {
+		return true, nil
+	}
+	_, trusted, err := trigger.TrustedPullRequest(gc, triggerConfig, e.IssueAuthor.Login, org, repo, e.Number, nil)
+	return trusted, err
+}
+
+// triggerJob triggers the presubmit with the given name on the PR and returns
+// whether the presubmit exists.
+func triggerJob(gc githubClient, tc trigger.Client, name string, gitClient git.ClientFactory, org, repo string, e *github.GenericCommentEvent) (bool, error) {
+	pr, err := gc.GetPullRequest(org, repo, e.Number)
+	if err != nil {
+		return false, err
+	}
+	baseSHA, err := gc.GetRef(org, repo, "heads/"+pr.Base.Ref)
+	if err != nil {
+		return false, fmt.Errorf("failed to get baseSHA: %w", err)
+	}
+	baseSHAGetter := func() (string, error) {
+		return baseSHA, nil
+	}
+	headSHAGetters := []config.RefGetter{func() (string, error) {
+		return pr.Head.SHA, nil
+	}}
+	// Like trigger, only use changes of the PR to the inrepoconfig once they
+	// are approved if the repo requires it.
+	approved, err := tc.Config.InRepoConfigApproved(gc, org, repo, e.Number, pr.Head.SHA)
+	if err != nil {
+		return false, err
+	}
+	if !approved {
+		headSHAGetters = nil
+	}
+	presubmits, err := tc.Config.GetPresubmits(gitClient, org+"/"+repo, pr.Base.Ref, baseSHAGetter, headSHAGetters...)
+	if err != nil {
+		return false, fmt.Errorf("failed to get presubmits: %w", err)
+	}
+	for _, presubmit := range presubmits {
+		if presubmit.Name != name || !presubmit.CouldRun(pr.Base.Ref) {
+			continue
+		}
+		return true, trigger.RunRequested(tc, pr, baseSHA, []config.Presubmit{presubmit}, e.GUID)
+	}
+	return false, nil
+}
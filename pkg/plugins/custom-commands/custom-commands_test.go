@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customcommands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/trigger"
+)
+
+func TestHandle(t *testing.T) {
+	commands := []plugins.CustomCommand{
+		{
+			Name:     "hello",
+			Repos:    []string{"org"},
+			Response: "Hello @{{.User}}{{if .Args}}, {{.Args}}{{end}}! This is {{.Org}}/{{.Repo}}#{{.Number}} by {{.Author}}.",
+		},
+		{
+			Name:       "preview",
+			Repos:      []string{"org/repo"},
+			Permission: plugins.CustomCommandPermissionCollaborator,
+			Response:   "Building a preview.",
+			Job:        "pull-docs-preview",
+		},
+		{
+			Name:       "deploy",
+			Repos:      []string{"org/repo"},
+			Permission: plugins.CustomCommandPermissionMember,
+			Job:        "pull-deploy",
+		},
+		{
+			Name:       "missing",
+			Repos:      []string{"org/repo"},
+			Permission: plugins.CustomCommandPermissionCollaborator,
+			Job:        "pull-missing",
+		},
+		{
+			Name:       "docs",
+			Repos:      []string{"org/repo"},
+			Permission: plugins.CustomCommandPermissionCollaborator,
+			Job:        "pull-docs-preview",
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		body             string
+		user             string
+		author           string
+		labels           []string
+		triggerConfig    plugins.Trigger
+		isPR             bool
+		action           github.GenericCommentEventAction
+		expectedComments []string
+		expectedJobs     []string
+	}{
+		{
+			name:   "response is rendered",
+			body:   "/hello world",
+			user:   "bob",
+			action: github.GenericCommentActionCreated,
+			expectedComments: []string{
+				"Hello @bob, world! This is org/repo#1 by alice.",
+			},
+		},
+		{
+			name:   "edited comments are ignored",
+			body:   "/hello",
+			user:   "bob",
+			action: github.GenericCommentActionEdited,
+		},
+		{
+			name:   "commands only match whole words",
+			body:   "/helloworld",
+			user:   "bob",
+			action: github.GenericCommentActionCreated,
+		},
+		{
+			name:             "collaborator triggers job and gets a response",
+			body:             "/preview",
+			user:             "collaborator",
+			isPR:             true,
+			action:           github.GenericCommentActionCreated,
+			expectedComments: []string{"Building a preview."},
+			expectedJobs:     []string{"pull-docs-preview"},
+		},
+		{
+			name:             "non collaborator is denied",
+			body:             "/preview",
+			user:             "bob",
+			isPR:             true,
+			action:           github.GenericCommentActionCreated,
+			expectedComments: []string{"You can't use `/preview`. It can only be used by: Collaborators of the repository."},
+		},
+		{
+			name:         "member triggers job without response",
+			body:         "/deploy",
+			user:         "member",
+			isPR:         true,
+			action:       github.GenericCommentActionCreated,
+			expectedJobs: []string{"pull-deploy"},
+		},
+		{
+			name:             "job commands only work on PRs",
+			body:             "/deploy",
+			user:             "member",
+			action:           github.GenericCommentActionCreated,
+			expectedComments: []string{"`/deploy` can only be used on pull requests."},
+		},
+		{
+			name:             "missing job is reported",
+			body:             "/missing",
+			user:             "collaborator",
+			isPR:             true,
+			action:           github.GenericCommentActionCreated,
+			expectedComments: []string{"`/missing` is configured to trigger the pull-missing job, but the job does not exist for this pull request."},
+		},
+		{
+			name:             "untrusted PR needs ok-to-test",
+			body:             "/docs",
+			user:             "collaborator",
+			author:           "mallory",
+			triggerConfig:    plugins.Trigger{OnlyOrgMembers: true},
+			isPR:             true,
+			action:           github.GenericCommentActionCreated,
+			expectedComments: []string{"`/docs` cannot trigger the pull-docs-preview job until a trusted user reviews the PR and leaves an `/ok-to-test` message."},
+		},
+		{
+			name:          "untrusted PR with ok-to-test triggers job",
+			body:          "/docs",
+			user:          "collaborator",
+			author:        "mallory",
+			triggerConfig: plugins.Trigger{OnlyOrgMembers: true},
+			labels:        []string{"org/repo#1:ok-to-test"},
+			isPR:          true,
+			action:        github.GenericCommentActionCreated,
+			expectedJobs:  []string{"pull-docs-preview"},
+		},
+		{
+			name:             "trusted commenter triggers job on untrusted PR",
+			body:             "/preview",
+			user:             "collaborator",
+			author:           "mallory",
+			isPR:             true,
+			action:           github.GenericCommentActionCreated,
+			expectedComments: []string{"Building a preview."},
+			expectedJobs:     []string{"pull-docs-preview"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.author == "" {
+				tc.author = "alice"
+			}
+			fghc := fakegithub.NewFakeClient()
+			fghc.Collaborators = []string{"collaborator"}
+			fghc.OrgMembers = map[string][]string{"org": {"member", "alice"}}
+			fghc.IssueLabelsExisting = tc.labels
+			fghc.PullRequests = map[int]*github.PullRequest{
+				1: {
+					Number: 1,
+					User:   github.User{Login: tc.author},
+					Head:   github.PullRequestBranch{SHA: "cafe"},
+					Base:   github.PullRequestBranch{Ref: "main", Repo: github.Repo{Name: "repo", Owner: github.User{Login: "org"}}},
+				},
+			}
+			cfg := &config.Config{ProwConfig: config.ProwConfig{ProwJobNamespace: "prowjobs"}}
+			if err := cfg.SetPresubmits(map[string][]config.Presubmit{
+				"org/repo": {
+					{JobBase: config.JobBase{Name: "pull-docs-preview"}, Reporter: config.Reporter{Context: "pull-docs-preview"}},
+					{JobBase: config.JobBase{Name: "pull-deploy"}, Reporter: config.Reporter{Context: "pull-deploy"}},
+				},
+			}); err != nil {
+				t.Fatalf("failed to set presubmits: %v", err)
+			}
+			prowJobClient := fake.NewSimpleClientset()
+			triggerClient := trigger.Client{
+				GitHubClient:  fghc,
+				ProwJobClient: prowJobClient.ProwV1().ProwJobs(cfg.ProwJobNamespace),
+				Config:        cfg,
+				Logger:        logrus.WithField("plugin", PluginName),
+			}
+
+			e := &github.GenericCommentEvent{
+				Action:      tc.action,
+				Body:        tc.body,
+				User:        github.User{Login: tc.user},
+				IssueAuthor: github.User{Login: tc.author},
+				IsPR:        tc.isPR,
+				IssueState:  "open",
+				Number:      1,
+				Repo:        github.Repo{Name: "repo", Owner: github.User{Login: "org"}},
+			}
+			if err := handle(fghc, triggerClient, logrus.WithField("plugin", PluginName), commands, tc.triggerConfig, nil, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			comments := fghc.IssueComments[1]
+			if len(comments) != len(tc.expectedComments) {
+				t.Fatalf("expected %d comments, got %d: %v", len(tc.expectedComments), len(comments), comments)
+			}
+			for i, expected := range tc.expectedComments {
+				if !strings.Contains(comments[i].Body, expected) {
+					t.Errorf("expected comment to contain %q, got %q", expected, comments[i].Body)
+				}
+			}
+
+			jobs, err := prowJobClient.ProwV1().ProwJobs("prowjobs").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list prowjobs: %v", err)
+			}
+			var jobNames []string
+			for _, job := range jobs.Items {
+				jobNames = append(jobNames, job.Spec.Job)
+			}
+			if diff := cmp.Diff(tc.expectedJobs, jobNames); diff != "" {
+				t.Errorf("unexpected jobs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
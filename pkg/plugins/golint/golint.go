@@ -190,7 +190,7 @@ func problemsInFiles(r git.RepoClient, files map[string]string) (map[string]map[
 			}
 			lintErrorComments = append(lintErrorComments, newComment)
 		}
-		al, err := AddedLines(patch)
+		al, err := plugins.AddedLines(patch)
 		if err != nil {
 			lintErrorComments = append(lintErrorComments,
 				github.DraftReviewComment{
@@ -331,86 +331,3 @@ func numProblems(ps map[string]map[int]lint.Problem) int {
 	}
 	return num
 }
-
-// AddedLines returns line numbers that were added in the patch, along with
-// their line in the patch itself as a map from line to patch line.
-// https://www.gnu.org/software/diffutils/manual/diffutils.html#Detailed-Unified
-// GitHub omits the ---/+++ lines since that information is in the
-// PullRequestChange object.
-func AddedLines(patch string) (map[int]int, error) {
-	result := make(map[int]int)
-	if patch == "" {
-		return result, nil
-	}
-	lines := strings.Split(patch, "\n")
-	for i := 0; i < len(lines); i++ {
-		// dodge the "\ No newline at end of file" line
-		if lines[i] == "\\ No newline at end of file" {
-			continue
-		}
-		_, oldLen, newLine, newLen, err := parseHunkLine(lines[i])
-		if err != nil {
-			return nil, fmt.Errorf("couldn't parse hunk on line %d in patch %s: %w", i, patch, err)
-		}
-		oldAdd := 0
-		newAdd := 0
-		for oldAdd < oldLen || newAdd < newLen {
-			i++
-			if i >= len(lines) {
-				return nil, fmt.Errorf("invalid patch: %s", patch)
-			}
-			switch lines[i][0] {
-			case ' ':
-				oldAdd++
-				newAdd++
-			case '-':
-				oldAdd++
-			case '+':
-				result[newLine+newAdd] = i
-				newAdd++
-			default:
-				return nil, fmt.Errorf("bad prefix on line %d in patch %s", i, patch)
-			}
-		}
-	}
-	return result, nil
-}
-
-// Matches the hunk line in unified diffs. These are of the form:
-// @@ -l,s +l,s @@ section head
-// We need to extract the four numbers, but the command and s is optional.
-// See https://en.wikipedia.org/wiki/Diff_utility#Unified_format
-var hunkRe = regexp.MustCompile(`^@@ -(\d+),?(\d+)? \+(\d+),?(\d+)? @@.*`)
-
-func parseHunkLine(hunk string) (oldLine, oldLength, newLine, newLength int, err error) {
-	if !hunkRe.MatchString(hunk) {
-		err = fmt.Errorf("invalid hunk line: %s", hunk)
-		return
-	}
-	matches := hunkRe.FindStringSubmatch(hunk)
-	oldLine, err = strconv.Atoi(matches[1])
-	if err != nil {
-		return
-	}
-	if matches[2] != "" {
-		oldLength, err = strconv.Atoi(matches[2])
-		if err != nil {
-			return
-		}
-	} else {
-		oldLength = 1
-	}
-	newLine, err = strconv.Atoi(matches[3])
-	if err != nil {
-		return
-	}
-	if matches[4] != "" {
-		newLength, err = strconv.Atoi(matches[4])
-		if err != nil {
-			return
-		}
-	} else {
-		newLength = 1
-	}
-	return
-}
@@ -56,6 +56,10 @@ func configInfoStickyLgtmTeam(team string) string {
 	return fmt.Sprintf(`Commits from "%s" do not remove LGTM.`, team)
 }
 
+func configInfoStickyLgtmPaths(paths []string) string {
+	return fmt.Sprintf(`Pushes that only change files matching %s do not remove LGTM.`, strings.Join(paths, ", "))
+}
+
 type commentPruner interface {
 	PruneComments(shouldPrune func(github.IssueComment) bool)
 }
@@ -87,6 +91,10 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 			configInfoStrings = append(configInfoStrings, "<li>"+configInfoStickyLgtmTeam(opts.StickyLgtmTeam)+"</li>")
 			isConfigured = true
 		}
+		if len(opts.StickyLgtmPaths) > 0 {
+			configInfoStrings = append(configInfoStrings, "<li>"+configInfoStickyLgtmPaths(opts.StickyLgtmPaths)+"</li>")
+			isConfigured = true
+		}
 		configInfoStrings = append(configInfoStrings, "</ul>")
 		if isConfigured {
 			configInfo[repo.String()] = strings.Join(configInfoStrings, "\n")
@@ -98,6 +106,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 				Repos:            []string{"kubernetes/test-infra"},
 				ReviewActsAsLgtm: true,
 				StickyLgtmTeam:   "team1",
+				StickyLgtmPaths:  []string{`^docs/`, `/testdata/`},
 				StoreTreeHash:    true,
 			},
 		},
@@ -137,8 +146,13 @@ type githubClient interface {
 	ListTeams(org string) ([]github.Team, error)
 	ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error)
 	RequestReview(org, repo string, number int, logins []string) error
+	CompareCommits(org, repo, base, head string) (*github.CommitsComparison, error)
 }
 
+// maxComparisonFiles is the number of files up to which GitHub lists the files
+// of a comparison.
+const maxComparisonFiles = 300
+
 // reviewCtx contains information about each review event
 type reviewCtx struct {
 	author, issueAuthor, body, htmlURL string
@@ -432,6 +446,16 @@ func handlePullRequest(log *logrus.Entry, gc githubClient, config *plugins.Confi
 		return nil
 	}
 
+	if len(opts.StickyLgtmPathsRe) > 0 && pe.Before != "" && pe.After != "" {
+		filenames, err := getPushedFiles(gc, org, repo, pe.Before, pe.After)
+		if err != nil {
+			log.WithError(err).Error("Failed to get the files changed by the push.")
+		} else if onlyStickyPaths(filenames, opts.StickyLgtmPathsRe) {
+			log.Info("Keeping LGTM label as the push only changes files matching sticky_lgtm_paths.")
+			return nil
+		}
+	}
+
 	if opts.StoreTreeHash {
 		// Check if we have a tree-hash comment
 		var lastLgtmTreeHash string
@@ -519,6 +543,50 @@ func loadRepoOwners(gc githubClient, ownersClient repoowners.Interface, org, rep
 	return ownersClient.LoadRepoOwners(org, repo, pr.Base.Ref)
 }
 
+// onlyStickyPaths returns whether all the changed files match one of the
+// sticky_lgtm_paths.
+func onlyStickyPaths(filenames []string, pathsRe []*regexp.Regexp) bool {
+	if len(filenames) == 0 {
+		return false
+	}
+	for _, filename := range filenames {
+		var matched bool
+		for _, re := range pathsRe {
+			if re.MatchString(filename) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// getPushedFiles returns the files changed by a push from the before to the
+// after commit. Pushes that don't fast-forward, like rebases, may change
+// anything, so they are refused.
+func getPushedFiles(gc githubClient, org, repo, before, after string) ([]string, error) {
+	comparison, err := gc.CompareCommits(org, repo, before, after)
+	if err != nil {
+		return nil, err
+	}
+	if comparison.Status != github.ComparisonStatusAhead {
+		return nil, fmt.Errorf("%s is %s of %s", after, comparison.Status, before)
+	}
+	// GitHub lists at most 300 files in a comparison, the others may be
+	// anything.
+	if len(comparison.Files) >= maxComparisonFiles {
+		return nil, fmt.Errorf("the push changes more than %d files", maxComparisonFiles)
+	}
+	var filenames []string
+	for _, file := range comparison.Files {
+		filenames = append(filenames, file.Filename)
+	}
+	return filenames, nil
+}
+
 // getChangedFiles returns all the changed files for the provided pull request.
 func getChangedFiles(gc githubClient, org, repo string, number int) ([]string, error) {
 	changes, err := gc.GetPullRequestChanges(org, repo, number)
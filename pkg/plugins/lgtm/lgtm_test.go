@@ -89,6 +89,10 @@ func (f *fakeRepoOwners) AllReviewers() sets.Set[string] {
 	return sets.Set[string]{}
 }
 
+func (f *fakeRepoOwners) Metadata(login string) (repoowners.UserMetadata, bool) {
+	return repoowners.UserMetadata{}, false
+}
+
 func (f *fakeRepoOwners) Filenames() ownersconfig.Filenames {
 	return ownersconfig.FakeFilenames
 }
@@ -852,6 +856,9 @@ func TestHandlePullRequest(t *testing.T) {
 		IssueLabelsRemoved []string
 		issueComments      map[int][]github.IssueComment
 		trustedTeam        string
+		stickyPaths        []string
+		pushed             []string
+		pushStatus         github.ComparisonStatus
 
 		expectNoComments bool
 
@@ -1144,11 +1151,109 @@ func TestHandlePullRequest(t *testing.T) {
 			},
 			expectNoComments: false,
 		},
+		{
+			name: "pr_synchronize, only sticky paths changed, keep label",
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+					Head: github.PullRequestBranch{
+						SHA: SHA,
+					},
+				},
+			},
+			stickyPaths:      []string{`^docs/`, `/testdata/`},
+			pushed:           []string{"docs/README.md", "pkg/foo/testdata/bar.yaml"},
+			pushStatus:       github.ComparisonStatusAhead,
+			expectNoComments: true,
+		},
+		{
+			name: "pr_synchronize, sticky paths and other files changed, remove label",
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+					Head: github.PullRequestBranch{
+						SHA: SHA,
+					},
+				},
+			},
+			IssueLabelsRemoved: []string{LGTMLabel},
+			issueComments: map[int][]github.IssueComment{
+				101: {
+					{
+						Body: removeLGTMLabelNoti,
+						User: github.User{Login: fakegithub.Bot},
+					},
+				},
+			},
+			stickyPaths:      []string{`^docs/`, `/testdata/`},
+			pushed:           []string{"docs/README.md", "pkg/foo/foo.go"},
+			pushStatus:       github.ComparisonStatusAhead,
+			expectNoComments: false,
+		},
+		{
+			name: "pr_synchronize, rebase onto only sticky paths changed, remove label",
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+					Head: github.PullRequestBranch{
+						SHA: SHA,
+					},
+				},
+			},
+			IssueLabelsRemoved: []string{LGTMLabel},
+			issueComments: map[int][]github.IssueComment{
+				101: {
+					{
+						Body: removeLGTMLabelNoti,
+						User: github.User{Login: fakegithub.Bot},
+					},
+				},
+			},
+			stickyPaths:      []string{`^docs/`, `/testdata/`},
+			pushed:           []string{"docs/README.md"},
+			pushStatus:       github.ComparisonStatusDiverged,
+			expectNoComments: false,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			fakeGitHub := fakegithub.NewFakeClient()
 			fakeGitHub.IssueComments = c.issueComments
+			if c.pushStatus != "" {
+				c.event.Before, c.event.After = "before", SHA
+				comparison := &github.CommitsComparison{Status: c.pushStatus}
+				for _, filename := range c.pushed {
+					comparison.Files = append(comparison.Files, github.CommitFile{Filename: filename})
+				}
+				fakeGitHub.CommitsComparisons = map[string]*github.CommitsComparison{"before..." + SHA: comparison}
+			}
 			fakeGitHub.PullRequests = map[int]*github.PullRequest{
 				101: {
 					Base: github.PullRequestBranch{
@@ -1168,10 +1273,14 @@ func TestHandlePullRequest(t *testing.T) {
 			fakeGitHub.Commits[SHA] = commit
 			pc := &plugins.Configuration{}
 			pc.Lgtm = append(pc.Lgtm, plugins.Lgtm{
-				Repos:          []string{"kubernetes/kubernetes"},
-				StoreTreeHash:  true,
-				StickyLgtmTeam: c.trustedTeam,
+				Repos:           []string{"kubernetes/kubernetes"},
+				StoreTreeHash:   true,
+				StickyLgtmTeam:  c.trustedTeam,
+				StickyLgtmPaths: c.stickyPaths,
 			})
+			if err := pc.Validate(); err != nil {
+				t.Fatalf("invalid config: %v", err)
+			}
 			err := handlePullRequest(
 				logrus.WithField("plugin", "approve"),
 				fakeGitHub,
@@ -1391,11 +1500,12 @@ func TestHelpProvider(t *testing.T) {
 						ReviewActsAsLgtm: true,
 						StoreTreeHash:    true,
 						StickyLgtmTeam:   "team1",
+						StickyLgtmPaths:  []string{`^docs/`},
 					},
 				},
 			},
 			enabledRepos:       enabledRepos,
-			configInfoIncludes: []string{configInfoReviewActsAsLgtm, configInfoStoreTreeHash, configInfoStickyLgtmTeam("team1")},
+			configInfoIncludes: []string{configInfoReviewActsAsLgtm, configInfoStoreTreeHash, configInfoStickyLgtmTeam("team1"), configInfoStickyLgtmPaths([]string{`^docs/`})},
 		},
 	}
 	for _, c := range cases {
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lifecyclemanager implements the lifecycle-manager plugin, which
+// periodically marks inactive issues and PRs as stale, then as rotten and
+// finally closes them.
+package lifecyclemanager
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// PluginName defines this plugin's registered name.
+const PluginName = "lifecycle-manager"
+
+func init() {
+	plugins.RegisterPeriodicHandler(PluginName, handlePeriodic, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		lm := config.LifecycleManagerFor(repo.Org, repo.Repo)
+		configInfo[repo.String()] = fmt.Sprintf("Issues and PRs are marked as %s after %s of inactivity, as %s after %s more and closed after another %s.",
+			labels.LifecycleStale, humanDuration(lm.StaleAfterDuration), labels.LifecycleRotten, humanDuration(lm.RottenAfterDuration), humanDuration(lm.CloseAfterDuration))
+		if len(lm.ExemptLabels) > 0 {
+			configInfo[repo.String()] += fmt.Sprintf("<br>Issues and PRs with one of the labels %s are exempt.", strings.Join(lm.ExemptLabels, ", "))
+		}
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		LifecycleManager: []plugins.LifecycleManager{
+			{
+				Repos:        []string{"org", "org/repo"},
+				StaleAfter:   "2160h",
+				RottenAfter:  "720h",
+				CloseAfter:   "720h",
+				ExemptLabels: []string{"help wanted"},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: fmt.Sprintf("The lifecycle-manager plugin periodically marks issues and PRs without activity as '%s', then as '%s' and finally closes them. Issues and PRs labeled '%s' are exempt. It only runs if hook is started with --periodic-plugins-interval.",
+			labels.LifecycleStale, labels.LifecycleRotten, labels.LifecycleFrozen),
+		Config:  configInfo,
+		Snippet: yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	CreateComment(org, repo string, number int, comment string) error
+	CloseIssueAsNotPlanned(org, repo string, number int) error
+	ClosePullRequest(org, repo string, number int) error
+}
+
+func handlePeriodic(pc plugins.Agent, org string) error {
+	return handle(pc.Logger, pc.GitHubClient, pc.PluginConfig, org, time.Now())
+}
+
+// scope is a set of repos that share a configuration and are searched
+// together.
+type scope struct {
+	// query restricts the search to the repos of the scope.
+	query  string
+	config *plugins.LifecycleManager
+}
+
+// scopes returns the scopes of the org. Repos that enable the plugin or have
+// their own configuration are searched on their own, the rest of the org is
+// searched at once if the plugin is enabled for the whole org.
+func scopes(cfg *plugins.Configuration, org string) []scope {
+	orgs, repos, orgExceptions := cfg.EnabledReposForPlugin(PluginName)
+	orgEnabled := slices.Contains(orgs, org)
+	ownScope := sets.New[string]()
+	for _, repo := range repos {
+		if strings.HasPrefix(repo, org+"/") {
+			ownScope.Insert(repo)
+		}
+	}
+	if orgEnabled {
+		for _, lm := range cfg.LifecycleManager {
+			for _, repo := range lm.Repos {
+				if strings.HasPrefix(repo, org+"/") && !orgExceptions[org].Has(repo) {
+					ownScope.Insert(repo)
+				}
+			}
+		}
+	}
+
+	var result []scope
+	for _, repo := range sets.List(ownScope) {
+		_, name, _ := strings.Cut(repo, "/")
+		result = append(result, scope{query: "repo:" + repo, config: cfg.LifecycleManagerFor(org, name)})
+	}
+	if orgEnabled {
+		query := []string{"org:" + org}
+		for _, repo := range sets.List(ownScope.Union(orgExceptions[org])) {
+			query = append(query, "-repo:"+repo)
+		}
+		result = append(result, scope{query: strings.Join(query, " "), config: cfg.LifecycleManagerFor(org, "")})
+	}
+	return result
+}
+
+func handle(log *logrus.Entry, ghc githubClient, cfg *plugins.Configuration, org string, now time.Time) error {
+	var errs []error
+	for _, s := range scopes(cfg, org) {
+		errs = append(errs, manage(log.WithField("scope", s.query), ghc, org, s, now)...)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// stage moves the issues and PRs matching filter that were inactive for the
+// given duration to the next stage of their lifecycle.
+type stage struct {
+	name     string
+	filter   string
+	inactive time.Duration
+	act      func(ghc githubClient, org, repo string, issue github.Issue, lm *plugins.LifecycleManager) error
+}
+
+func manage(log *logrus.Entry, ghc githubClient, org string, s scope, now time.Time) []error {
+	lm := s.config
+	base := []string{s.query, "is:open", "archived:false", labelFilter("-", labels.LifecycleFrozen)}
+	for _, label := range lm.ExemptLabels {
+		base = append(base, labelFilter("-", label))
+	}
+	switch {
+	case lm.SkipIssues && lm.SkipPullRequests:
+		return nil
+	case lm.SkipIssues:
+		base = append(base, "is:pr")
+	case lm.SkipPullRequests:
+		base = append(base, "is:issue")
+	}
+
+	// Every stage only matches issues and PRs the previous stages can't have
+	// changed, so nothing moves through several stages in one run even if
+	// the search index lags behind.
+	stages := []stage{
+		{name: "close", filter: labelFilter("", labels.LifecycleRotten), inactive: lm.CloseAfterDuration, act: closeInactive},
+		{name: "rotten", filter: labelFilter("", labels.LifecycleStale) + " " + labelFilter("-", labels.LifecycleRotten), inactive: lm.RottenAfterDuration, act: markRotten},
+		{name: "stale", filter: labelFilter("-", labels.LifecycleStale) + " " + labelFilter("-", labels.LifecycleRotten), inactive: lm.StaleAfterDuration, act: markStale},
+	}
+	var errs []error
+	for _, st := range stages {
+		query := strings.Join(append(slices.Clone(base), st.filter, "updated:<"+now.Add(-st.inactive).UTC().Format(time.RFC3339)), " ")
+		issues, err := ghc.FindIssuesWithOrg(org, query, "updated", true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to search for issues to %s with query %q: %w", st.name, query, err))
+			continue
+		}
+		for _, issue := range issues {
+			repo, err := repoFromHTMLURL(issue.HTMLURL)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			log.WithFields(logrus.Fields{github.RepoLogField: repo, github.PrLogField: issue.Number, "stage": st.name}).Info("Moving inactive issue to the next lifecycle stage.")
+			if err := st.act(ghc, org, repo, issue, lm); err != nil {
+				errs = append(errs, fmt.Errorf("failed to %s %s/%s#%d: %w", st.name, org, repo, issue.Number, err))
+			}
+		}
+	}
+	return errs
+}
+
+func labelFilter(prefix, label string) string {
+	return fmt.Sprintf(`%slabel:"%s"`, prefix, label)
+}
+
+// repoFromHTMLURL returns the repo of an issue or PR from its URL, which looks
+// like https://github.com/org/repo/issues/1. Search results don't include it
+// otherwise.
+func repoFromHTMLURL(htmlURL string) (string, error) {
+	u, err := url.Parse(htmlURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse issue URL %q: %w", htmlURL, err)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 {
+		return "", fmt.Errorf("unexpected issue URL %q", htmlURL)
+	}
+	return parts[len(parts)-3], nil
+}
+
+func kind(issue github.Issue) string {
+	if issue.IsPullRequest() {
+		return "PR"
+	}
+	return "issue"
+}
+
+func markStale(ghc githubClient, org, repo string, issue github.Issue, lm *plugins.LifecycleManager) error {
+	if err := ghc.AddLabel(org, repo, issue.Number, labels.LifecycleStale); err != nil {
+		return err
+	}
+	return ghc.CreateComment(org, repo, issue.Number, plugins.FormatSimpleResponse(fmt.Sprintf(
+		"This %s has not been updated in %s and is now marked as stale. It will be marked as rotten after %s and closed after another %s without activity.\n\n"+
+			"- Mark it as fresh with `/remove-lifecycle stale`\n"+
+			"- Exempt it from the lifecycle with `/lifecycle frozen`\n"+
+			"- Close it with `/close` if it is no longer relevant",
+		kind(issue), humanDuration(lm.StaleAfterDuration), humanDuration(lm.RottenAfterDuration), humanDuration(lm.CloseAfterDuration))))
+}
+
+func markRotten(ghc githubClient, org, repo string, issue github.Issue, lm *plugins.LifecycleManager) error {
+	if err := ghc.AddLabel(org, repo, issue.Number, labels.LifecycleRotten); err != nil {
+		return err
+	}
+	if err := ghc.RemoveLabel(org, repo, issue.Number, labels.LifecycleStale); err != nil {
+		return err
+	}
+	return ghc.CreateComment(org, repo, issue.Number, plugins.FormatSimpleResponse(fmt.Sprintf(
+		"This %s has not been updated in %s since it was marked as stale and is now marked as rotten. It will be closed after another %s without activity.\n\n"+
+			"- Mark it as fresh with `/remove-lifecycle rotten`\n"+
+			"- Exempt it from the lifecycle with `/lifecycle frozen`\n"+
+			"- Close it with `/close` if it is no longer relevant",
+		kind(issue), humanDuration(lm.RottenAfterDuration), humanDuration(lm.CloseAfterDuration))))
+}
+
+func closeInactive(ghc githubClient, org, repo string, issue github.Issue, lm *plugins.LifecycleManager) error {
+	if err := ghc.CreateComment(org, repo, issue.Number, plugins.FormatSimpleResponse(fmt.Sprintf(
+		"This %s has not been updated in %s since it was marked as rotten and is now closed.\n\n"+
+			"Reopen it with `/reopen` if it is still relevant.",
+		kind(issue), humanDuration(lm.CloseAfterDuration)))); err != nil {
+		return err
+	}
+	if issue.IsPullRequest() {
+		return ghc.ClosePullRequest(org, repo, issue.Number)
+	}
+	return ghc.CloseIssueAsNotPlanned(org, repo, issue.Number)
+}
+
+// humanDuration formats durations of whole days in days, which is how the
+// lifecycle is usually configured.
+func humanDuration(d time.Duration) string {
+	day := 24 * time.Hour
+	if d >= day && d%day == 0 {
+		if d == day {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.String()
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecyclemanager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+type fakeGitHub struct {
+	// results maps a search filter to the issues of queries containing it.
+	results map[string][]github.Issue
+	queries []string
+	actions []string
+}
+
+func (f *fakeGitHub) FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error) {
+	if sort != "updated" || !asc {
+		return nil, fmt.Errorf("unexpected sort %q, asc %t", sort, asc)
+	}
+	f.queries = append(f.queries, query)
+	for filter, issues := range f.results {
+		if strings.Contains(query, filter) {
+			return issues, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeGitHub) AddLabel(org, repo string, number int, label string) error {
+	f.actions = append(f.actions, fmt.Sprintf("%s/%s#%d: add %s", org, repo, number, label))
+	return nil
+}
+
+func (f *fakeGitHub) RemoveLabel(org, repo string, number int, label string) error {
+	f.actions = append(f.actions, fmt.Sprintf("%s/%s#%d: remove %s", org, repo, number, label))
+	return nil
+}
+
+func (f *fakeGitHub) CreateComment(org, repo string, number int, comment string) error {
+	f.actions = append(f.actions, fmt.Sprintf("%s/%s#%d: comment", org, repo, number))
+	return nil
+}
+
+func (f *fakeGitHub) CloseIssueAsNotPlanned(org, repo string, number int) error {
+	f.actions = append(f.actions, fmt.Sprintf("%s/%s#%d: close issue", org, repo, number))
+	return nil
+}
+
+func (f *fakeGitHub) ClosePullRequest(org, repo string, number int) error {
+	f.actions = append(f.actions, fmt.Sprintf("%s/%s#%d: close PR", org, repo, number))
+	return nil
+}
+
+func TestHandle(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	const (
+		closeFilter  = ` label:"lifecycle/rotten" updated:`
+		rottenFilter = ` label:"lifecycle/stale" -label:"lifecycle/rotten" updated:`
+		staleFilter  = `-label:"lifecycle/stale" -label:"lifecycle/rotten" updated:`
+	)
+	issue := func(repo string, number int, pr bool) github.Issue {
+		i := github.Issue{Number: number, HTMLURL: fmt.Sprintf("https://github.com/org/%s/issues/%d", repo, number)}
+		if pr {
+			i.PullRequest = &struct{}{}
+		}
+		return i
+	}
+
+	testCases := []struct {
+		name            string
+		plugins         plugins.Plugins
+		config          []plugins.LifecycleManager
+		results         map[string][]github.Issue
+		expectedQueries []string
+		expectedActions []string
+	}{
+		{
+			name:    "org with defaults moves items to the next stage",
+			plugins: plugins.Plugins{"org": {Plugins: []string{PluginName}}},
+			results: map[string][]github.Issue{
+				closeFilter:  {issue("a", 1, false), issue("b", 2, true)},
+				rottenFilter: {issue("a", 3, false)},
+				staleFilter:  {issue("b", 4, true)},
+			},
+			expectedQueries: []string{
+				`org:org is:open archived:false -label:"lifecycle/frozen" label:"lifecycle/rotten" updated:<2026-05-02T12:00:00Z`,
+				`org:org is:open archived:false -label:"lifecycle/frozen" label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-05-02T12:00:00Z`,
+				`org:org is:open archived:false -label:"lifecycle/frozen" -label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-03-03T12:00:00Z`,
+			},
+			expectedActions: []string{
+				"org/a#1: comment",
+				"org/a#1: close issue",
+				"org/b#2: comment",
+				"org/b#2: close PR",
+				"org/a#3: add lifecycle/rotten",
+				"org/a#3: remove lifecycle/stale",
+				"org/a#3: comment",
+				"org/b#4: add lifecycle/stale",
+				"org/b#4: comment",
+			},
+		},
+		{
+			name:    "repo with its own configuration is searched separately",
+			plugins: plugins.Plugins{"org": {Plugins: []string{PluginName}, ExcludedRepos: []string{"excluded"}}},
+			config: []plugins.LifecycleManager{{
+				Repos:        []string{"org/custom"},
+				StaleAfter:   "24h",
+				RottenAfter:  "24h",
+				CloseAfter:   "24h",
+				ExemptLabels: []string{"help wanted"},
+				SkipIssues:   true,
+			}},
+			expectedQueries: []string{
+				`repo:org/custom is:open archived:false -label:"lifecycle/frozen" -label:"help wanted" is:pr label:"lifecycle/rotten" updated:<2026-05-31T12:00:00Z`,
+				`repo:org/custom is:open archived:false -label:"lifecycle/frozen" -label:"help wanted" is:pr label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-05-31T12:00:00Z`,
+				`repo:org/custom is:open archived:false -label:"lifecycle/frozen" -label:"help wanted" is:pr -label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-05-31T12:00:00Z`,
+				`org:org -repo:org/custom -repo:org/excluded is:open archived:false -label:"lifecycle/frozen" label:"lifecycle/rotten" updated:<2026-05-02T12:00:00Z`,
+				`org:org -repo:org/custom -repo:org/excluded is:open archived:false -label:"lifecycle/frozen" label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-05-02T12:00:00Z`,
+				`org:org -repo:org/custom -repo:org/excluded is:open archived:false -label:"lifecycle/frozen" -label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-03-03T12:00:00Z`,
+			},
+		},
+		{
+			name:    "only enabled repos are searched if the org isn't enabled",
+			plugins: plugins.Plugins{"org/repo": {Plugins: []string{PluginName}}, "other/repo": {Plugins: []string{PluginName}}},
+			config: []plugins.LifecycleManager{{
+				Repos:            []string{"org"},
+				SkipPullRequests: true,
+			}},
+			expectedQueries: []string{
+				`repo:org/repo is:open archived:false -label:"lifecycle/frozen" is:issue label:"lifecycle/rotten" updated:<2026-05-02T12:00:00Z`,
+				`repo:org/repo is:open archived:false -label:"lifecycle/frozen" is:issue label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-05-02T12:00:00Z`,
+				`repo:org/repo is:open archived:false -label:"lifecycle/frozen" is:issue -label:"lifecycle/stale" -label:"lifecycle/rotten" updated:<2026-03-03T12:00:00Z`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &plugins.Configuration{Plugins: tc.plugins, LifecycleManager: tc.config}
+			if err := cfg.Validate(); err != nil {
+				t.Fatalf("invalid config: %v", err)
+			}
+			fghc := &fakeGitHub{results: tc.results}
+			if err := handle(logrus.WithField("plugin", PluginName), fghc, cfg, "org", now); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedQueries, fghc.queries); diff != "" {
+				t.Errorf("unexpected queries (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedActions, fghc.actions); diff != "" {
+				t.Errorf("unexpected actions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type failingGitHub struct {
+	fakeGitHub
+}
+
+func (f *failingGitHub) FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error) {
+	return nil, errors.New("injected error")
+}
+
+func TestHandleSearchError(t *testing.T) {
+	cfg := &plugins.Configuration{Plugins: plugins.Plugins{"org": {Plugins: []string{PluginName}}}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	err := handle(logrus.WithField("plugin", PluginName), &failingGitHub{}, cfg, "org", time.Now())
+	if err == nil || !strings.Contains(err.Error(), "injected error") {
+		t.Errorf("expected the search error to be returned, got %v", err)
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	for d, expected := range map[time.Duration]string{
+		24 * time.Hour:      "1 day",
+		90 * 24 * time.Hour: "90 days",
+		36 * time.Hour:      "36h0m0s",
+		time.Hour:           "1h0m0s",
+	} {
+		if actual := humanDuration(d); actual != expected {
+			t.Errorf("humanDuration(%s): expected %q, got %q", d, expected, actual)
+		}
+	}
+}
@@ -41,7 +41,8 @@ import (
 const pluginName = "override"
 
 var (
-	overrideRe = regexp.MustCompile(`(?mi)^/override( ([^\r\n]+))?[\r\n]?$`)
+	overrideRe     = regexp.MustCompile(`(?mi)^/override( ([^\r\n]+))?[\r\n]?$`)
+	overrideListRe = regexp.MustCompile(`(?mi)^/override-list\s*$`)
 )
 
 type Context struct {
@@ -188,6 +189,13 @@ func helpProvider(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhel
 		WhoCanUse:   whoCanUse(overrideConfig, "", ""),
 		Examples:    []string{"/override pull-repo-whatever", "/override \"test / Unit Tests\"", "/override ci/circleci", "/override deleted-job other-job"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/override-list",
+		Description: "Lists the failed github status contexts, check runs and jobs of the PR that the commenter may override.",
+		Featured:    false,
+		WhoCanUse:   whoCanUse(overrideConfig, "", ""),
+		Examples:    []string{"/override-list"},
+	})
 	return pluginHelp, nil
 }
 
@@ -299,6 +307,18 @@ func formatList(list []string) string {
 	return strings.Join(lines, "\n")
 }
 
+// formatOverridable lists contexts the way they are passed to /override.
+func formatOverridable(list []string) string {
+	var quoted []string
+	for _, item := range list {
+		if strings.Contains(item, " ") {
+			item = fmt.Sprintf("%q", item)
+		}
+		quoted = append(quoted, item)
+	}
+	return formatList(quoted)
+}
+
 type descriptionAndState struct {
 	description string
 	state       string
@@ -328,8 +348,9 @@ func handle(oc overrideClient, log *logrus.Entry, e *github.GenericCommentEvent,
 	}
 
 	mat := overrideRe.FindAllStringSubmatch(e.Body, -1)
-	if len(mat) == 0 {
-		return nil // no /override commands given in the comment
+	listRequested := overrideListRe.MatchString(e.Body)
+	if len(mat) == 0 && !listRequested {
+		return nil // no /override or /override-list commands given in the comment
 	}
 
 	org := e.Repo.Owner.Login
@@ -458,6 +479,23 @@ func handle(oc overrideClient, log *logrus.Entry, e *github.GenericCommentEvent,
 		}
 	}
 
+	if listRequested {
+		resp := "There are no failed contexts/checkruns to override."
+		if contexts.Len() > 0 {
+			resp = fmt.Sprintf(`%s may override the following failed contexts/checkruns:
+%s
+
+Pass them to /override exactly as listed, contexts with spaces have to be quoted.`, user, formatOverridable(sets.List(contexts)))
+		}
+		log.Debug(resp)
+		if err := oc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, user, resp)); err != nil {
+			return err
+		}
+		if len(mat) == 0 {
+			return nil
+		}
+	}
+
 	if unknown := overrides.Difference(contexts); unknown.Len() > 0 {
 		resp := fmt.Sprintf(`/override requires failed status contexts, check run or a prowjob name to operate on.
 The following unknown contexts/checkruns were given:
@@ -537,7 +575,7 @@ If you are trying to override a checkrun that has a space in it, you must put a
 					Name:       checkrun.Context,
 					HeadSHA:    sha,
 					Status:     "completed",
-					Conclusion: "success",
+					Conclusion: options.GetCheckRunConclusion(),
 					Output: github.CheckRunOutput{
 						Title:   fmt.Sprintf("Prow override - %s", checkrun.Context),
 						Summary: fmt.Sprintf("Prow has received override command for the %s checkrun.", checkrun.Context),
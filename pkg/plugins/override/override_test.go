@@ -72,6 +72,10 @@ func (foc *fakeOwnersClient) AllReviewers() sets.Set[string] {
 	return sets.Set[string]{}
 }
 
+func (foc *fakeOwnersClient) Metadata(login string) (repoowners.UserMetadata, bool) {
+	return repoowners.UserMetadata{}, false
+}
+
 func (foc *fakeOwnersClient) Filenames() ownersconfig.Filenames {
 	return ownersconfig.FakeFilenames
 }
@@ -231,7 +235,7 @@ func (c *fakeClient) CreateCheckRun(org, repo string, checkRun github.CheckRun)
 				HeadSHA:     checkrun.HeadSHA,
 				CompletedAt: checkrun.CompletedAt,
 				Status:      "completed",
-				Conclusion:  "success",
+				Conclusion:  checkRun.Conclusion,
 				Output: github.CheckRunOutput{
 					Title:   fmt.Sprintf("Prow override - %s", checkrun.Name),
 					Summary: fmt.Sprintf("Prow has received override command for the %s checkrun.", checkrun.Name),
@@ -418,6 +422,86 @@ func TestHandle(t *testing.T) {
 			},
 			usesAppsAuth: true,
 		},
+		{
+			name:    "override checkrun as neutral",
+			comment: "/override failure-checkrun",
+			checkruns: &github.CheckRunList{
+				CheckRuns: []github.CheckRun{
+					{Name: "failure-checkrun", CompletedAt: "1800 BC", Conclusion: "failure"},
+				},
+			},
+			expected: []github.Status{},
+			expectedCheckRuns: &github.CheckRunList{
+				CheckRuns: []github.CheckRun{
+					{Name: "failure-checkrun", CompletedAt: "1800 BC", Conclusion: "failure"},
+					{Name: "failure-checkrun", CompletedAt: "1800 BC", Status: "completed", Conclusion: "neutral", Output: github.CheckRunOutput{
+						Title:   fmt.Sprintf("Prow override - %s", "failure-checkrun"),
+						Summary: fmt.Sprintf("Prow has received override command for the %s checkrun.", "failure-checkrun"),
+					}},
+				},
+			},
+			options:       plugins.Override{CheckRunConclusion: "neutral"},
+			checkComments: []string{"on behalf of " + adminUser + ": failure-checkrun"},
+			usesAppsAuth:  true,
+		},
+		{
+			name:    "list overridable contexts",
+			comment: "/override-list",
+			contexts: []github.Status{
+				{Context: "broken-test", State: github.StatusFailure},
+				{Context: "passing-test", State: github.StatusSuccess},
+			},
+			presubmits: []config.Presubmit{
+				{
+					JobBase:  config.JobBase{Name: "pull-broken-test"},
+					Reporter: config.Reporter{Context: "broken-test"},
+				},
+			},
+			checkruns: &github.CheckRunList{
+				CheckRuns: []github.CheckRun{
+					{Name: "incomplete-checkrun"},
+					{Name: "test / Unit Tests", CompletedAt: "1800 BC", Conclusion: "failure"},
+				},
+			},
+			expected: []github.Status{
+				{Context: "broken-test", State: github.StatusFailure},
+				{Context: "passing-test", State: github.StatusSuccess},
+			},
+			expectedCheckRuns: &github.CheckRunList{
+				CheckRuns: []github.CheckRun{
+					{Name: "incomplete-checkrun"},
+					{Name: "test / Unit Tests", CompletedAt: "1800 BC", Conclusion: "failure"},
+				},
+			},
+			checkComments: []string{adminUser + " may override the following failed contexts/checkruns:\n - `broken-test`\n - `pull-broken-test`\n - `\"test / Unit Tests\"`\n"},
+			usesAppsAuth:  true,
+		},
+		{
+			name:          "list without failed contexts",
+			comment:       "/override-list",
+			contexts:      []github.Status{{Context: "passing-test", State: github.StatusSuccess}},
+			expected:      []github.Status{{Context: "passing-test", State: github.StatusSuccess}},
+			checkComments: []string{"There are no failed contexts/checkruns to override."},
+		},
+		{
+			name:          "unauthorized user cannot list contexts",
+			comment:       "/override-list",
+			user:          "rando",
+			contexts:      []github.Status{{Context: "broken-test", State: github.StatusFailure}},
+			expected:      []github.Status{{Context: "broken-test", State: github.StatusFailure}},
+			checkComments: []string{"rando unauthorized: /override is restricted to Repo administrators"},
+		},
+		{
+			name:    "list and override in the same comment",
+			comment: "/override-list\n/override broken-test",
+			contexts: []github.Status{
+				{Context: "broken-test", State: github.StatusFailure},
+			},
+			expected: []github.Status{
+				{Context: "broken-test", Description: description(adminUser), State: github.StatusSuccess},
+			},
+			checkComments: []string{"may override the following failed contexts/checkruns", "on behalf of " + adminUser},
+		},
 		{
 			name:    "successfully override unknown context with special characters derived from checkruns",
 			comment: `/override "test / Unit Tests"`,
@@ -1313,10 +1397,14 @@ func TestHelpProvider(t *testing.T) {
 		switch {
 		case help == nil:
 			t.Errorf("%s: expected a valid plugin help object, got nil", tc.name)
-		case len(help.Commands) != 1:
-			t.Errorf("%s: expected a single command from plugin help, got: %v", tc.name, help.Commands)
-		case help.Commands[0].WhoCanUse != tc.expectedWho:
-			t.Errorf("%s: expected a single command with WhoCanUse set to %s, got %s instead", tc.name, tc.expectedWho, help.Commands[0].WhoCanUse)
+		case len(help.Commands) != 2:
+			t.Errorf("%s: expected /override and /override-list commands from plugin help, got: %v", tc.name, help.Commands)
+		default:
+			for _, command := range help.Commands {
+				if command.WhoCanUse != tc.expectedWho {
+					t.Errorf("%s: expected %s with WhoCanUse set to %s, got %s instead", tc.name, command.Usage, tc.expectedWho, command.WhoCanUse)
+				}
+			}
 		}
 	}
 }
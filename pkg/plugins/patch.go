@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AddedLines returns line numbers that were added in the patch, along with
+// their line in the patch itself as a map from line to patch line.
+// https://www.gnu.org/software/diffutils/manual/diffutils.html#Detailed-Unified
+// GitHub omits the ---/+++ lines since that information is in the
+// PullRequestChange object.
+func AddedLines(patch string) (map[int]int, error) {
+	result := make(map[int]int)
+	if patch == "" {
+		return result, nil
+	}
+	lines := strings.Split(patch, "\n")
+	for i := 0; i < len(lines); i++ {
+		// dodge the "\ No newline at end of file" line
+		if lines[i] == "\\ No newline at end of file" {
+			continue
+		}
+		_, oldLen, newLine, newLen, err := parseHunkLine(lines[i])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse hunk on line %d in patch %s: %w", i, patch, err)
+		}
+		oldAdd := 0
+		newAdd := 0
+		for oldAdd < oldLen || newAdd < newLen {
+			i++
+			if i >= len(lines) {
+				return nil, fmt.Errorf("invalid patch: %s", patch)
+			}
+			switch lines[i][0] {
+			case ' ':
+				oldAdd++
+				newAdd++
+			case '-':
+				oldAdd++
+			case '+':
+				result[newLine+newAdd] = i
+				newAdd++
+			default:
+				return nil, fmt.Errorf("bad prefix on line %d in patch %s", i, patch)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Matches the hunk line in unified diffs. These are of the form:
+// @@ -l,s +l,s @@ section head
+// We need to extract the four numbers, but the command and s is optional.
+// See https://en.wikipedia.org/wiki/Diff_utility#Unified_format
+var hunkRe = regexp.MustCompile(`^@@ -(\d+),?(\d+)? \+(\d+),?(\d+)? @@.*`)
+
+func parseHunkLine(hunk string) (oldLine, oldLength, newLine, newLength int, err error) {
+	if !hunkRe.MatchString(hunk) {
+		err = fmt.Errorf("invalid hunk line: %s", hunk)
+		return
+	}
+	matches := hunkRe.FindStringSubmatch(hunk)
+	oldLine, err = strconv.Atoi(matches[1])
+	if err != nil {
+		return
+	}
+	if matches[2] != "" {
+		oldLength, err = strconv.Atoi(matches[2])
+		if err != nil {
+			return
+		}
+	} else {
+		oldLength = 1
+	}
+	newLine, err = strconv.Atoi(matches[3])
+	if err != nil {
+		return
+	}
+	if matches[4] != "" {
+		newLength, err = strconv.Atoi(matches[4])
+		if err != nil {
+			return
+		}
+	} else {
+		newLength = 1
+	}
+	return
+}
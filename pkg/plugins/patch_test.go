@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import "testing"
+
+func TestAddedLines(t *testing.T) {
+	var testcases = []struct {
+		patch string
+		lines map[int]int
+		err   bool
+	}{
+		{
+			patch: "@@ -0,0 +1,5 @@\n+package bar\n+\n+func Qux() error {\n+   return nil\n+}",
+			lines: map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5},
+		},
+		{
+			patch: "@@ -29,12 +29,14 @@ import (\n \t\"github.com/sirupsen/logrus\"\n \t\"sigs.k8s.io/yaml\"\n \n+\t\"sigs.k8s.io/prow/pkg/config\"\n \t\"sigs.k8s.io/prow/pkg/jenkins\"\n \t\"sigs.k8s.io/prow/pkg/kube\"\n \t\"sigs.k8s.io/prow/pkg/plank\"\n )\n \n var (\n+\tconfigPath   = flag.String(\"config-path\", \"/etc/config/config\", \"Path to config.yaml.\")\n \tbuildCluster = flag.String(\"build-cluster\", \"\", \"Path to file containing a YAML-marshalled kube.Cluster object. If empty, uses the local cluster.\")\n \n \tjenkinsURL       = flag.String(\"jenkins-url\", \"\", \"Jenkins URL\")\n@@ -47,18 +49,22 @@ var objReg = regexp.MustCompile(`^[\\w-]+$`)\n \n func main() {\n \tflag.Parse()\n-\n \tlogrus.SetFormatter(&logrus.JSONFormatter{})\n \n-\tkc, err := kube.NewClientInCluster(kube.ProwNamespace)\n+\tconfigAgent := &config.Agent{}\n+\tif err := configAgent.Start(*configPath); err != nil {\n+\t\tlogrus.WithError(err).Fatal(\"Error starting config agent.\")\n+\t}\n+\n+\tkc, err := kube.NewClientInCluster(configAgent.Config().ProwJobNamespace)\n \tif err != nil {\n \t\tlogrus.WithError(err).Fatal(\"Error getting client.\")\n \t}\n \tvar pkc *kube.Client\n \tif *buildCluster == \"\" {\n-\t\tpkc = kc.Namespace(kube.TestPodNamespace)\n+\t\tpkc = kc.Namespace(configAgent.Config().PodNamespace)\n \t} else {\n-\t\tpkc, err = kube.NewClientFromFile(*buildCluster, kube.TestPodNamespace)\n+\t\tpkc, err = kube.NewClientFromFile(*buildCluster, configAgent.Config().PodNamespace)\n \t\tif err != nil {\n \t\t\tlogrus.WithError(err).Fatal(\"Error getting kube client to build cluster.\")\n \t\t}",
+			lines: map[int]int{4: 32, 11: 39, 23: 54, 24: 55, 25: 56, 26: 57, 27: 58, 28: 59, 35: 65, 38: 67},
+		},
+		{
+			patch: "@@ -1 +0,0 @@\n-such",
+		},
+		{
+			patch: "@@ -1,3 +0,0 @@\n-such\n-a\n-doge",
+		},
+		{
+			patch: "@@ -0,0 +1 @@\n+wow",
+			lines: map[int]int{1: 1},
+		},
+		{
+			patch: "@@ -0,0 +1 @@\n+wow\n\\ No newline at end of file",
+			lines: map[int]int{1: 1},
+		},
+		{
+			patch: "@@ -1 +1 @@\n-doge\n+wow",
+			lines: map[int]int{2: 1},
+		},
+		{
+			patch: "something strange",
+			err:   true,
+		},
+		{
+			patch: "@@ -a,3 +0,0 @@\n-wow",
+			err:   true,
+		},
+		{
+			patch: "@@ -1 +1 @@",
+			err:   true,
+		},
+		{
+			patch: "",
+		},
+	}
+	for _, tc := range testcases {
+		als, err := AddedLines(tc.patch)
+		if err == nil == tc.err {
+			t.Errorf("For patch %s\nExpected error %v, got error %v", tc.patch, tc.err, err)
+			continue
+		}
+		if len(als) != len(tc.lines) {
+			t.Errorf("For patch %s\nAdded lines has wrong length. Got %v, expected %v", tc.patch, als, tc.lines)
+		}
+		for pl, l := range tc.lines {
+			if als[l] != pl {
+				t.Errorf("For patch %s\nExpected added line %d to be %d, but got %d", tc.patch, l, pl, als[l])
+			}
+		}
+	}
+}
@@ -26,12 +26,11 @@ import (
 	"path"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
-	"k8s.io/apimachinery/pkg/util/sets"
-
 	"sigs.k8s.io/prow/pkg/genyaml"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -47,6 +46,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/jira"
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/repoowners"
@@ -64,6 +64,9 @@ var (
 	reviewEventHandlers        = map[string]ReviewEventHandler{}
 	reviewCommentEventHandlers = map[string]ReviewCommentEventHandler{}
 	statusEventHandlers        = map[string]StatusEventHandler{}
+	periodicHandlers           = map[string]PeriodicHandler{}
+
+	projectV2ItemEventHandlers = map[string]ProjectV2ItemEventHandler{}
 	// CommentMap is used by many plugins for printing help messages defined in
 	// config.go.
 	CommentMap, _ = genyaml.NewCommentMap(func(dir string) (string, error) { return "", nil }, nil)
@@ -170,6 +173,15 @@ func RegisterReviewCommentEventHandler(name string, fn ReviewCommentEventHandler
 	reviewCommentEventHandlers[name] = fn
 }
 
+// ProjectV2ItemEventHandler defines the function contract for a github.ProjectV2ItemEvent handler.
+type ProjectV2ItemEventHandler func(Agent, github.ProjectV2ItemEvent) error
+
+// RegisterProjectV2ItemEventHandler registers a plugin's github.ProjectV2ItemEvent handler.
+func RegisterProjectV2ItemEventHandler(name string, fn ProjectV2ItemEventHandler, help HelpProvider) {
+	pluginHelp[name] = help
+	projectV2ItemEventHandlers[name] = fn
+}
+
 // GenericCommentHandler defines the function contract for a github.GenericCommentEvent handler.
 type GenericCommentHandler func(Agent, github.GenericCommentEvent) error
 
@@ -179,6 +191,17 @@ func RegisterGenericCommentHandler(name string, fn GenericCommentHandler, help H
 	genericCommentHandlers[name] = fn
 }
 
+// PeriodicHandler defines the function contract for a handler that hook runs
+// periodically rather than in response to an event. It is called once for
+// every org the plugin is enabled on, or enabled on repos of.
+type PeriodicHandler func(agent Agent, org string) error
+
+// RegisterPeriodicHandler registers a plugin's periodic handler.
+func RegisterPeriodicHandler(name string, fn PeriodicHandler, help HelpProvider) {
+	pluginHelp[name] = help
+	periodicHandlers[name] = fn
+}
+
 type PluginGitHubClient interface {
 	github.Client
 	Query(ctx context.Context, q interface{}, vars map[string]interface{}) error
@@ -194,6 +217,8 @@ type Agent struct {
 	SlackClient               *slack.Client
 	BugzillaClient            bugzilla.Client
 	JiraClient                jira.Client
+	// Opener reads and writes objects in GCS or S3.
+	Opener io.Opener
 
 	OwnersClient repoowners.Interface
 
@@ -232,6 +257,7 @@ func NewAgent(configAgent *config.Agent, pluginConfigAgent *ConfigAgent, clientA
 		OwnersClient:              clientAgent.OwnersClient.WithFields(logger.Data).WithGitHubClient(gitHubClient).ForPlugin(plugin),
 		BugzillaClient:            clientAgent.BugzillaClient.WithFields(logger.Data).ForPlugin(plugin),
 		JiraClient:                jiraClient,
+		Opener:                    clientAgent.Opener,
 		Metrics:                   metrics,
 		Config:                    prowConfig,
 		PluginConfig:              pluginConfig,
@@ -277,6 +303,7 @@ type ClientAgent struct {
 	OwnersClient              repoowners.Interface
 	BugzillaClient            bugzilla.Client
 	JiraClient                jira.Client
+	Opener                    io.Opener
 }
 
 // ConfigAgent contains the agent mutex and the Agent configuration.
@@ -527,17 +554,41 @@ func (pa *ConfigAgent) PushEventHandlers(owner, repo string) map[string]PushEven
 	return hs
 }
 
-// getPlugins returns a list of plugins that are enabled on a given (org, repository).
-func (pa *ConfigAgent) getPlugins(owner, repo string) []string {
-	var plugins []string
+// ProjectV2ItemEventHandlers returns a map of plugin names to handlers for the
+// org. Projects belong to orgs, so the plugins that are enabled on the org or
+// on any of its repos are returned.
+func (pa *ConfigAgent) ProjectV2ItemEventHandlers(org string) map[string]ProjectV2ItemEventHandler {
+	pa.mut.Lock()
+	defer pa.mut.Unlock()
 
-	fullName := fmt.Sprintf("%s/%s", owner, repo)
-	if !sets.NewString(pa.configuration.Plugins[owner].ExcludedRepos...).Has(repo) {
-		plugins = append(plugins, pa.configuration.Plugins[owner].Plugins...)
+	hs := map[string]ProjectV2ItemEventHandler{}
+	for p, h := range projectV2ItemEventHandlers {
+		orgs, repos, _ := pa.configuration.EnabledReposForPlugin(p)
+		if slices.Contains(orgs, org) || slices.ContainsFunc(repos, func(repo string) bool { return strings.HasPrefix(repo, org+"/") }) {
+			hs[p] = h
+		}
 	}
-	plugins = append(plugins, pa.configuration.Plugins[fullName].Plugins...)
+	return hs
+}
+
+// PeriodicHandlers returns a map of plugin names to periodic handlers for
+// the plugins that are enabled on at least one org or repo.
+func (pa *ConfigAgent) PeriodicHandlers() map[string]PeriodicHandler {
+	pa.mut.Lock()
+	defer pa.mut.Unlock()
 
-	return plugins
+	hs := map[string]PeriodicHandler{}
+	for p, h := range periodicHandlers {
+		if orgs, repos, _ := pa.configuration.EnabledReposForPlugin(p); len(orgs) > 0 || len(repos) > 0 {
+			hs[p] = h
+		}
+	}
+	return hs
+}
+
+// getPlugins returns a list of plugins that are enabled on a given (org, repository).
+func (pa *ConfigAgent) getPlugins(owner, repo string) []string {
+	return pa.configuration.Plugins.EnabledFor(owner, repo)
 }
 
 // EventsForPlugin returns the registered events for the passed plugin.
@@ -567,6 +618,12 @@ func EventsForPlugin(name string) []string {
 	if _, ok := genericCommentHandlers[name]; ok {
 		events = append(events, "GenericCommentEvent (any event for user text)")
 	}
+	if _, ok := periodicHandlers[name]; ok {
+		events = append(events, "periodic")
+	}
+	if _, ok := projectV2ItemEventHandlers[name]; ok {
+		events = append(events, "projects_v2_item")
+	}
 	return events
 }
 
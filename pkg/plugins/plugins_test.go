@@ -25,6 +25,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/github"
 )
 
 func TestEnsureEmbed(t *testing.T) {
@@ -196,6 +198,16 @@ func TestGetPlugins(t *testing.T) {
 			repo:            "repo",
 			expectedPlugins: []string{"plugin3"},
 		},
+		{
+			name: "Plugins disabled for org/repo should not be returned for a org/repo query",
+			pluginMap: Plugins{
+				"org1":      {Plugins: []string{"plugin1", "plugin2"}},
+				"org1/repo": {Plugins: []string{"plugin3"}, DisabledPlugins: []string{"plugin1"}},
+			},
+			owner:           "org1",
+			repo:            "repo",
+			expectedPlugins: []string{"plugin2", "plugin3"},
+		},
 		{
 			name: "Plugins for org1/repo should not be returned for org2/repo query",
 			pluginMap: Plugins{
@@ -227,6 +239,39 @@ func TestGetPlugins(t *testing.T) {
 	}
 }
 
+func TestProjectV2ItemEventHandlers(t *testing.T) {
+	for _, p := range []string{"plugin1", "plugin2", "plugin3"} {
+		projectV2ItemEventHandlers[p] = func(Agent, github.ProjectV2ItemEvent) error { return nil }
+	}
+	t.Cleanup(func() { projectV2ItemEventHandlers = map[string]ProjectV2ItemEventHandler{} })
+
+	pa := ConfigAgent{configuration: &Configuration{Plugins: Plugins{
+		"org1":      {Plugins: []string{"plugin1"}},
+		"org1/repo": {Plugins: []string{"plugin2"}},
+		"org2/repo": {Plugins: []string{"plugin3"}},
+		"org3":      {Plugins: []string{"other"}},
+	}}}
+
+	var testcases = []struct {
+		org             string
+		expectedPlugins []string
+	}{
+		{org: "org1", expectedPlugins: []string{"plugin1", "plugin2"}},
+		{org: "org2", expectedPlugins: []string{"plugin3"}},
+		{org: "org3"},
+		{org: "org"},
+	}
+	for _, tc := range testcases {
+		var plugins []string
+		for p := range pa.ProjectV2ItemEventHandlers(tc.org) {
+			plugins = append(plugins, p)
+		}
+		if diff := cmp.Diff(tc.expectedPlugins, plugins, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("%s: actual plugins differ from expected: %s", tc.org, diff)
+		}
+	}
+}
+
 func TestLoad(t *testing.T) {
 	t.Parallel()
 
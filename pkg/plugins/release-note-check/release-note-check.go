@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package releasenotecheck implements the release-note-check plugin, which
+// validates the release-note block of PRs and publishes the parsed release
+// note for changelog tooling.
+package releasenotecheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/releasenote"
+)
+
+const (
+	// PluginName defines this plugin's registered name.
+	PluginName = "release-note-check"
+
+	checkRunName = "release-note"
+
+	actionRequiredNote = "action required"
+)
+
+var (
+	metadataRe = regexp.MustCompile(`(?i)^(category|areas?):\s*(.*)$`)
+	noneRe     = regexp.MustCompile(`(?i)^\W*(NONE|NO)\W*$`)
+)
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		rnc := config.ReleaseNoteCheckFor(repo.Org, repo.Repo)
+		var info []string
+		if len(rnc.Categories) > 0 {
+			info = append(info, fmt.Sprintf("Release notes need one of the categories %s.", strings.Join(rnc.Categories, ", ")))
+		}
+		if len(rnc.Areas) > 0 {
+			info = append(info, fmt.Sprintf("Release notes can have the areas %s.", strings.Join(rnc.Areas, ", ")))
+		}
+		if rnc.ArtifactPath != "" {
+			info = append(info, fmt.Sprintf("Valid release notes are written to %s.", rnc.ArtifactPath))
+		}
+		if len(info) > 0 {
+			configInfo[repo.String()] = strings.Join(info, "<br>")
+		}
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		ReleaseNoteCheck: []plugins.ReleaseNoteCheck{
+			{
+				Repos:        []string{"org/repo"},
+				Categories:   []string{"feature", "bug", "documentation"},
+				Areas:        []string{"api", "cli"},
+				ArtifactPath: "gs://bucket/release-notes",
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: "The release-note-check plugin validates the 'release-note' block in the body of PRs and reports the parsed release note in the '" + checkRunName + "' check run. " +
+			"The block can start with a 'category:' line and an 'area:' line listing comma separated areas, followed by the release note itself. " +
+			"Valid release notes are additionally written as JSON to a GCS or S3 bucket if configured, so changelog tooling can consume them without parsing PR bodies. " +
+			"The plugin requires Prow to authenticate as a GitHub App.\n\n" +
+			"```release-note\ncategory: feature\narea: api, cli\nAdd the --output flag.\n```",
+		Config:  configInfo,
+		Snippet: yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
+	CreateCheckRun(org, repo string, checkRun github.CheckRun) (int64, error)
+	UpdateCheckRun(org, repo string, checkRunId int64, checkRun github.CheckRun) error
+}
+
+// ReleaseNote is a parsed release note. It is written to the artifact path
+// as JSON.
+type ReleaseNote struct {
+	Org     string `json:"org"`
+	Repo    string `json:"repo"`
+	Number  int    `json:"number"`
+	URL     string `json:"url"`
+	Author  string `json:"author"`
+	BaseRef string `json:"base_ref"`
+	HeadSHA string `json:"head_sha"`
+	// Merged is set once the PR is merged, the release note is final then.
+	Merged bool `json:"merged"`
+	// None is set if the PR does not warrant a release note.
+	None           bool     `json:"none"`
+	ActionRequired bool     `json:"action_required"`
+	Category       string   `json:"category,omitempty"`
+	Areas          []string `json:"areas,omitempty"`
+	Text           string   `json:"text,omitempty"`
+}
+
+func handlePullRequest(pc plugins.Agent, pe github.PullRequestEvent) error {
+	return handle(pc.Logger, pc.GitHubClient, pc.Opener, pc.PluginConfig.ReleaseNoteCheckFor(pe.Repo.Owner.Login, pe.Repo.Name), &pe)
+}
+
+func handle(log *logrus.Entry, ghc githubClient, opener io.Opener, rnc *plugins.ReleaseNoteCheck, pe *github.PullRequestEvent) error {
+	switch pe.Action {
+	case github.PullRequestActionOpened, github.PullRequestActionReopened, github.PullRequestActionEdited, github.PullRequestActionSynchronize:
+	case github.PullRequestActionClosed:
+		if !pe.PullRequest.Merged {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	pr := pe.PullRequest
+	org := pr.Base.Repo.Owner.Login
+	repo := pr.Base.Repo.Name
+	note, problems := parse(pr.Body, rnc)
+	note.Org = org
+	note.Repo = repo
+	note.Number = pr.Number
+	note.URL = pr.HTMLURL
+	note.Author = pr.User.Login
+	note.BaseRef = pr.Base.Ref
+	note.HeadSHA = pr.Head.SHA
+	note.Merged = pr.Merged
+
+	// The check run of a merged PR can't change anymore, so only publish the
+	// final release note.
+	if !pr.Merged {
+		if err := reportCheckRun(ghc, org, repo, note, problems); err != nil {
+			return fmt.Errorf("failed to report release note check run: %w", err)
+		}
+	}
+	if len(problems) > 0 || rnc.ArtifactPath == "" {
+		return nil
+	}
+	if opener == nil {
+		return fmt.Errorf("cannot write release note to %s: no storage client configured", rnc.ArtifactPath)
+	}
+	content, err := json.Marshal(note)
+	if err != nil {
+		return fmt.Errorf("failed to marshal release note: %w", err)
+	}
+	path := fmt.Sprintf("%s/%s/%s/%d.json", strings.TrimSuffix(rnc.ArtifactPath, "/"), org, repo, pr.Number)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := io.WriteContent(ctx, log, opener, path, content); err != nil {
+		return fmt.Errorf("failed to write release note to %s: %w", path, err)
+	}
+	return nil
+}
+
+// parse parses the release-note block of the PR body and returns the
+// problems that make it invalid.
+func parse(body string, rnc *plugins.ReleaseNoteCheck) (ReleaseNote, []string) {
+	var note ReleaseNote
+	block := releasenote.GetReleaseNote(body)
+	if block == "" {
+		return note, []string{"The PR description has no `release-note` block."}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(block, "\r\n", "\n"), "\n")
+	var problems []string
+	var hasCategory bool
+	for len(lines) > 0 {
+		match := metadataRe.FindStringSubmatch(strings.TrimSpace(lines[0]))
+		if match == nil {
+			break
+		}
+		lines = lines[1:]
+		value := strings.TrimSpace(match[2])
+		if strings.EqualFold(match[1], "category") {
+			hasCategory = true
+			note.Category = value
+			continue
+		}
+		for _, area := range strings.Split(value, ",") {
+			if area = strings.TrimSpace(area); area != "" {
+				note.Areas = append(note.Areas, area)
+			}
+		}
+	}
+	note.Text = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	if noneRe.MatchString(note.Text) {
+		return ReleaseNote{None: true}, nil
+	}
+	if note.Text == "" {
+		problems = append(problems, "The `release-note` block has no release note.")
+	}
+	note.ActionRequired = strings.Contains(strings.ToLower(note.Text), actionRequiredNote)
+	if len(rnc.Categories) > 0 {
+		switch {
+		case !hasCategory || note.Category == "":
+			problems = append(problems, fmt.Sprintf("The release note needs a `category:` line with one of: %s.", strings.Join(rnc.Categories, ", ")))
+		case !slices.Contains(rnc.Categories, note.Category):
+			problems = append(problems, fmt.Sprintf("The category %q is unknown, it must be one of: %s.", note.Category, strings.Join(rnc.Categories, ", ")))
+		}
+	}
+	if len(rnc.Areas) > 0 {
+		for _, area := range note.Areas {
+			if !slices.Contains(rnc.Areas, area) {
+				problems = append(problems, fmt.Sprintf("The area %q is unknown, it must be one of: %s.", area, strings.Join(rnc.Areas, ", ")))
+			}
+		}
+	}
+	return note, problems
+}
+
+// reportCheckRun reports the release note of the PR in a check run on its
+// head commit, updating the check run of a previous evaluation of the same
+// commit.
+func reportCheckRun(ghc githubClient, org, repo string, note ReleaseNote, problems []string) error {
+	checkRun := github.CheckRun{
+		Name:       checkRunName,
+		HeadSHA:    note.HeadSHA,
+		DetailsURL: note.URL,
+		Status:     "completed",
+		Conclusion: "success",
+		Output:     checkRunOutput(note, problems),
+	}
+	if len(problems) > 0 {
+		checkRun.Conclusion = "failure"
+	}
+	checkRun.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+
+	existing, err := ghc.ListCheckRuns(org, repo, note.HeadSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list check runs: %w", err)
+	}
+	for _, cr := range existing.CheckRuns {
+		if cr.Name != checkRunName {
+			continue
+		}
+		if cr.Conclusion == checkRun.Conclusion && cr.Output.Title == checkRun.Output.Title && cr.Output.Summary == checkRun.Output.Summary && cr.Output.Text == checkRun.Output.Text {
+			return nil
+		}
+		return ghc.UpdateCheckRun(org, repo, cr.ID, checkRun)
+	}
+	checkRun.StartedAt = checkRun.CompletedAt
+	_, err = ghc.CreateCheckRun(org, repo, checkRun)
+	return err
+}
+
+func checkRunOutput(note ReleaseNote, problems []string) github.CheckRunOutput {
+	if len(problems) > 0 {
+		var summary []string
+		for _, problem := range problems {
+			summary = append(summary, "- "+problem)
+		}
+		return github.CheckRunOutput{
+			Title:   "The release note is invalid",
+			Summary: strings.Join(summary, "\n"),
+			Text:    "Edit the `release-note` block in the PR description, for example:\n\n````\n```release-note\ncategory: <category>\narea: <area>, <area>\n<release note>\n```\n````",
+		}
+	}
+	if note.None {
+		return github.CheckRunOutput{
+			Title:   "No release note",
+			Summary: "The PR does not warrant a release note.",
+		}
+	}
+
+	title := "Release note"
+	if note.ActionRequired {
+		title = "Release note with action required"
+	}
+	summary := []string{"| Category | Areas | Action required |", "| --- | --- | --- |",
+		fmt.Sprintf("| %s | %s | %t |", note.Category, strings.Join(note.Areas, ", "), note.ActionRequired), "", note.Text}
+	// The parsed release note is included as JSON so tooling can read it
+	// from the check run as well.
+	raw, _ := json.MarshalIndent(note, "", "  ")
+	return github.CheckRunOutput{
+		Title:   title,
+		Summary: strings.Join(summary, "\n"),
+		Text:    "```json\n" + string(raw) + "\n```",
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasenotecheck
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+func TestParse(t *testing.T) {
+	rnc := &plugins.ReleaseNoteCheck{
+		Categories: []string{"feature", "bug"},
+		Areas:      []string{"api", "cli"},
+	}
+	testCases := []struct {
+		name             string
+		body             string
+		config           *plugins.ReleaseNoteCheck
+		expectedNote     ReleaseNote
+		expectedProblems []string
+	}{
+		{
+			name:             "no block",
+			body:             "Fixes #1",
+			config:           rnc,
+			expectedProblems: []string{"The PR description has no `release-note` block."},
+		},
+		{
+			name:         "none",
+			body:         "```release-note\nNONE\n```",
+			config:       rnc,
+			expectedNote: ReleaseNote{None: true},
+		},
+		{
+			name:   "valid release note with category and areas",
+			body:   "```release-note\r\nCategory: feature\r\narea: api, cli\r\nAdd the --output flag.\r\n```",
+			config: rnc,
+			expectedNote: ReleaseNote{
+				Category: "feature",
+				Areas:    []string{"api", "cli"},
+				Text:     "Add the --output flag.",
+			},
+		},
+		{
+			name:   "action required",
+			body:   "```release-note\ncategory: bug\nAction required: the --output flag was removed.\n```",
+			config: rnc,
+			expectedNote: ReleaseNote{
+				Category:       "bug",
+				ActionRequired: true,
+				Text:           "Action required: the --output flag was removed.",
+			},
+		},
+		{
+			name:   "unknown category and area",
+			body:   "```release-note\ncategory: chore\narea: api, docs\nSomething changed.\n```",
+			config: rnc,
+			expectedNote: ReleaseNote{
+				Category: "chore",
+				Areas:    []string{"api", "docs"},
+				Text:     "Something changed.",
+			},
+			expectedProblems: []string{
+				`The category "chore" is unknown, it must be one of: feature, bug.`,
+				`The area "docs" is unknown, it must be one of: api, cli.`,
+			},
+		},
+		{
+			name:         "missing category",
+			body:         "```release-note\nSomething changed.\n```",
+			config:       rnc,
+			expectedNote: ReleaseNote{Text: "Something changed."},
+			expectedProblems: []string{
+				"The release note needs a `category:` line with one of: feature, bug.",
+			},
+		},
+		{
+			name:         "missing release note",
+			body:         "```release-note\ncategory: bug\n```",
+			config:       rnc,
+			expectedNote: ReleaseNote{Category: "bug"},
+			expectedProblems: []string{
+				"The `release-note` block has no release note.",
+			},
+		},
+		{
+			name:   "anything goes without configured categories and areas",
+			body:   "```release-note\ncategory: chore\narea: anything\nSomething changed.\n```",
+			config: &plugins.ReleaseNoteCheck{},
+			expectedNote: ReleaseNote{
+				Category: "chore",
+				Areas:    []string{"anything"},
+				Text:     "Something changed.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			note, problems := parse(tc.body, tc.config)
+			if diff := cmp.Diff(tc.expectedNote, note); diff != "" {
+				t.Errorf("unexpected release note (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedProblems, problems); diff != "" {
+				t.Errorf("unexpected problems (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	const sha = "0bd3ed50c88cd53a09316bf7a298f900e9371652"
+	validBody := "```release-note\ncategory: feature\nAdd the --output flag.\n```"
+	testCases := []struct {
+		name               string
+		action             github.PullRequestEventAction
+		body               string
+		merged             bool
+		existing           []github.CheckRun
+		expectedConclusion string
+		expectedCheckRuns  int
+		expectedArtifact   *ReleaseNote
+	}{
+		{
+			name:               "valid release note is reported and written",
+			action:             github.PullRequestActionOpened,
+			body:               validBody,
+			expectedConclusion: "success",
+			expectedCheckRuns:  1,
+			expectedArtifact: &ReleaseNote{
+				Org: "org", Repo: "repo", Number: 1, URL: "https://github.com/org/repo/pull/1", Author: "alice", BaseRef: "main", HeadSHA: sha,
+				Category: "feature", Text: "Add the --output flag.",
+			},
+		},
+		{
+			name:               "invalid release note fails the check run of the previous evaluation",
+			action:             github.PullRequestActionEdited,
+			body:               "```release-note\nAdd the --output flag.\n```",
+			existing:           []github.CheckRun{{ID: 3, Name: checkRunName, HeadSHA: sha, Conclusion: "success"}},
+			expectedConclusion: "failure",
+			expectedCheckRuns:  1,
+		},
+		{
+			name:   "merged PR only writes the final release note",
+			action: github.PullRequestActionClosed,
+			body:   validBody,
+			merged: true,
+			expectedArtifact: &ReleaseNote{
+				Org: "org", Repo: "repo", Number: 1, URL: "https://github.com/org/repo/pull/1", Author: "alice", BaseRef: "main", HeadSHA: sha, Merged: true,
+				Category: "feature", Text: "Add the --output flag.",
+			},
+		},
+		{
+			name:   "closed PR is ignored",
+			action: github.PullRequestActionClosed,
+			body:   validBody,
+		},
+		{
+			name:   "labeling is ignored",
+			action: github.PullRequestActionLabeled,
+			body:   validBody,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fghc := fakegithub.NewFakeClient()
+			if tc.existing != nil {
+				fghc.CheckRuns = map[string][]github.CheckRun{sha: tc.existing}
+			}
+			opener := &fakeopener.FakeOpener{}
+			pe := &github.PullRequestEvent{
+				Action: tc.action,
+				PullRequest: github.PullRequest{
+					Number:  1,
+					HTMLURL: "https://github.com/org/repo/pull/1",
+					Body:    tc.body,
+					Merged:  tc.merged,
+					User:    github.User{Login: "alice"},
+					Base:    github.PullRequestBranch{Ref: "main", Repo: github.Repo{Name: "repo", Owner: github.User{Login: "org"}}},
+					Head:    github.PullRequestBranch{SHA: sha},
+				},
+			}
+			rnc := &plugins.ReleaseNoteCheck{Categories: []string{"feature"}, ArtifactPath: "gs://bucket/notes/"}
+			if err := handle(logrus.WithField("plugin", PluginName), fghc, opener, rnc, pe); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			checkRuns := fghc.CheckRuns[sha]
+			if len(checkRuns) != tc.expectedCheckRuns {
+				t.Fatalf("expected %d check runs, got %d: %v", tc.expectedCheckRuns, len(checkRuns), checkRuns)
+			}
+			if tc.expectedCheckRuns > 0 && checkRuns[0].Conclusion != tc.expectedConclusion {
+				t.Errorf("expected conclusion %q, got %q", tc.expectedConclusion, checkRuns[0].Conclusion)
+			}
+
+			buf, written := opener.Buffer["gs://bucket/notes/org/repo/1.json"]
+			if tc.expectedArtifact == nil {
+				if written {
+					t.Errorf("expected no artifact, got %s", buf.String())
+				}
+				return
+			}
+			if !written {
+				t.Fatalf("expected an artifact to be written, got %v", opener.Buffer)
+			}
+			var note ReleaseNote
+			if err := json.Unmarshal(buf.Bytes(), &note); err != nil {
+				t.Fatalf("failed to unmarshal artifact: %v", err)
+			}
+			if diff := cmp.Diff(*tc.expectedArtifact, note); diff != "" {
+				t.Errorf("unexpected artifact (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -357,7 +357,7 @@ func ensureNoRelNoteNeededLabel(gc githubClient, log *logrus.Entry, pr *github.P
 // determineReleaseNoteLabel returns the label to be added based on the contents of the 'release-note'
 // section of a PR's body text, as well as the set of PR's labels.
 func determineReleaseNoteLabel(body string, prLabels sets.Set[string]) string {
-	composedReleaseNote := strings.ToLower(strings.TrimSpace(getReleaseNote(body)))
+	composedReleaseNote := strings.ToLower(strings.TrimSpace(GetReleaseNote(body)))
 	hasNoneNoteInPRBody := noneRe.MatchString(composedReleaseNote)
 	hasDeprecationLabel := prLabels.Has(labels.DeprecationLabel)
 
@@ -379,9 +379,9 @@ func determineReleaseNoteLabel(body string, prLabels sets.Set[string]) string {
 	}
 }
 
-// getReleaseNote returns the release note from a PR body
+// GetReleaseNote returns the release note from a PR body
 // assumes that the PR body followed the PR template
-func getReleaseNote(body string) string {
+func GetReleaseNote(body string) string {
 	potentialMatch := noteMatcherRE.FindStringSubmatch(body)
 	if potentialMatch == nil {
 		return ""
@@ -488,7 +488,7 @@ func editReleaseNote(gc githubClient, log *logrus.Entry, ic github.IssueCommentE
 		)
 	}
 
-	newNote := getReleaseNote(ic.Comment.Body)
+	newNote := GetReleaseNote(ic.Comment.Body)
 	if newNote == "" {
 		return gc.CreateComment(
 			org, repo, ic.Issue.Number,
@@ -602,7 +602,7 @@ func TestGetReleaseNote(t *testing.T) {
 	}
 
 	for testNum, test := range tests {
-		calculatedReleaseNote := getReleaseNote(test.body)
+		calculatedReleaseNote := GetReleaseNote(test.body)
 		if test.expectedReleaseNote != calculatedReleaseNote {
 			t.Errorf("Test %v: Expected %v as the release note, got %v", testNum, test.expectedReleaseNote, calculatedReleaseNote)
 		}
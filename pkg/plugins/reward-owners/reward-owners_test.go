@@ -73,6 +73,10 @@ func (f *fakeRepoOwners) AllReviewers() sets.Set[string] {
 	return ownersBySha[f.sha]
 }
 
+func (f *fakeRepoOwners) Metadata(login string) (repoowners.UserMetadata, bool) {
+	return repoowners.UserMetadata{}, false
+}
+
 var ownersBySha = map[string]sets.Set[string]{
 	"base":         sets.New[string]("alice", "bob"),
 	"add cole":     sets.New[string]("alice", "bob", "cole"),
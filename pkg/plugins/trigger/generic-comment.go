@@ -18,6 +18,7 @@ package trigger
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/prow/pkg/kube"
@@ -35,13 +36,29 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 	repo := gc.Repo.Name
 	number := gc.Number
 	commentAuthor := gc.User.Login
-	// Only take action when a comment is first created,
+	// Only take action when a comment is first created (or edited, if enabled),
 	// when it belongs to a PR,
 	// and the PR is open.
-	if gc.Action != github.GenericCommentActionCreated || !gc.IsPR || gc.IssueState != "open" {
+	edited := gc.Action == github.GenericCommentActionEdited && trigger.TriggerOnCommentEdits
+	if (gc.Action != github.GenericCommentActionCreated && !edited) || !gc.IsPR || gc.IssueState != "open" {
 		return nil
 	}
 
+	body := gc.Body
+	if edited {
+		// Only act on the lines added by the edit, so the jobs requested by the
+		// previous version of the comment are not triggered again.
+		if gc.PreviousBody == "" {
+			c.Logger.Debug("Edited comment has no previous body, skipping.")
+			return nil
+		}
+		body = addedLines(gc.PreviousBody, gc.Body)
+		if body == "" {
+			c.Logger.Debug("Edit of comment didn't add any lines, skipping.")
+			return nil
+		}
+	}
+
 	// Skip bot comments.
 	botUserChecker, err := c.GitHubClient.BotUserChecker()
 	if err != nil {
@@ -54,17 +71,22 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 	}
 
 	refGetter := config.NewRefGetterForGitHubPullRequest(c.GitHubClient, org, repo, number)
-	presubmits := getPresubmits(c.Logger, c.GitClient, c.Config, org+"/"+repo, refGetter.BaseSHA, refGetter.HeadSHA)
+	headSHAGetters, err := c.prHeadSHAGetters(org, repo, number, refGetter.HeadSHA)
+	if err != nil {
+		return err
+	}
+	presubmits := getPresubmits(c.Logger, c.GitClient, c.Config, org+"/"+repo, refGetter.BaseSHA, headSHAGetters...)
 
 	// Skip comments not germane to this plugin
-	if !pjutil.RetestRe.MatchString(gc.Body) &&
-		!pjutil.RetestRequiredRe.MatchString(gc.Body) &&
-		!pjutil.OkToTestRe.MatchString(gc.Body) &&
-		!pjutil.TestAllRe.MatchString(gc.Body) &&
-		!pjutil.MayNeedHelpComment(gc.Body) {
+	if !pjutil.RetestRe.MatchString(body) &&
+		!pjutil.RetestRequiredRe.MatchString(body) &&
+		!pjutil.TestRequiredRe.MatchString(body) &&
+		!pjutil.OkToTestRe.MatchString(body) &&
+		!pjutil.TestAllRe.MatchString(body) &&
+		!pjutil.MayNeedHelpComment(body) {
 		matched := false
 		for _, presubmit := range presubmits {
-			matched = matched || presubmit.TriggerMatches(gc.Body)
+			matched = matched || presubmit.TriggerMatches(body)
 			if matched {
 				break
 			}
@@ -105,7 +127,7 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 			return err
 		}
 	}
-	isOkToTest := HonorOkToTest(trigger) && pjutil.OkToTestRe.MatchString(gc.Body)
+	isOkToTest := HonorOkToTest(trigger) && pjutil.OkToTestRe.MatchString(body)
 	if isOkToTest && !github.HasLabel(labels.OkToTest, l) {
 		if err := c.GitHubClient.AddLabel(org, repo, number, labels.OkToTest); err != nil {
 			return err
@@ -126,48 +148,154 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 		return err
 	}
 
-	toTest, err := FilterPresubmits(HonorOkToTest(trigger), c.GitHubClient, gc.Body, pr, presubmits, c.Logger)
+	toTest, err := FilterPresubmits(HonorOkToTest(trigger), c.GitHubClient, body, pr, presubmits, c.Logger)
 	if err != nil {
 		return err
 	}
-	if needsHelp, note := pjutil.ShouldRespondWithHelp(gc.Body, len(toTest)); needsHelp {
+	if pjutil.TestRequiredRe.MatchString(body) {
+		required, err := filterRequiredPresubmits(c, pr, refGetter, presubmits)
+		if err != nil {
+			return err
+		}
+		if len(required) == 0 {
+			resp := "All contexts required to merge this PR are passing or pending, there are no jobs to run."
+			c.Logger.Infof("Commenting \"%s\".", resp)
+			if err := c.GitHubClient.CreateComment(org, repo, number, plugins.FormatResponseRaw(gc.Body, gc.HTMLURL, commentAuthor, resp)); err != nil {
+				return err
+			}
+		}
+		toTest = appendPresubmits(toTest, required)
+	}
+	if needsHelp, note := pjutil.ShouldRespondWithHelp(body, len(toTest)); needsHelp {
 		return addHelpComment(c.GitHubClient, gc.Body, org, repo, pr.Base.Ref, pr.Number, presubmits, gc.HTMLURL, commentAuthor, note, c.Logger)
 	}
+	toTest = pjutil.AddDependencies(toTest, presubmits, pr.Base.Ref)
 	// we want to be able to track re-tests separately from the general body of tests
 	additionalLabels := map[string]string{}
-	if pjutil.RetestRe.MatchString(gc.Body) || pjutil.RetestRequiredRe.MatchString(gc.Body) {
+	if pjutil.RetestRe.MatchString(body) || pjutil.RetestRequiredRe.MatchString(body) || pjutil.TestRequiredRe.MatchString(body) {
 		additionalLabels[kube.RetestLabel] = "true"
 	}
-	// run failed github actions
-	if trigger.TriggerGitHubWorkflows && (pjutil.RetestRe.MatchString(gc.Body) || pjutil.TestAllRe.MatchString(gc.Body)) {
+	runErr := RunRequestedWithLabels(c, pr, baseSHA, toTest, gc.GUID, additionalLabels)
+	// run failed github actions, after the jobs were created so that the
+	// GitHub calls needed for that don't delay them
+	if trigger.TriggerGitHubWorkflows && (pjutil.RetestRe.MatchString(body) || pjutil.TestAllRe.MatchString(body) || pjutil.TestRequiredRe.MatchString(body)) {
 		headSHA, err := refGetter.HeadSHA()
 		if err != nil {
 			c.Logger.Warnf("headSHA unavailable, failed github actions for pr will not be triggered: %v", pr)
 		} else {
-			failedRuns, err := c.GitHubClient.GetFailedActionRunsByHeadBranch(org, repo, pr.Head.Ref, headSHA)
-			if err != nil {
-				c.Logger.Errorf("%v: unable to get failed github action runs for branch %v", err, pr.Head.Ref)
-			} else {
-				for _, run := range failedRuns {
-					log := c.Logger.WithFields(logrus.Fields{
-						"runID":   run.ID,
-						"runName": run.Name,
-						"org":     org,
-						"repo":    repo,
-					})
-					runID := run.ID
-					go func() {
-						if err := c.GitHubClient.TriggerFailedGitHubWorkflow(org, repo, runID); err != nil {
-							log.Errorf("attempt to trigger github run failed: %v", err)
-						} else {
-							log.Infof("successfully triggered action run")
-						}
-					}()
-				}
+			rerunFailedWorkflows(c, trigger, gc, pr, headSHA)
+		}
+	}
+	return runErr
+}
+
+// filterRequiredPresubmits returns the presubmits producing the contexts that
+// Tide requires to merge the PR and that are failing or missing.
+func filterRequiredPresubmits(c Client, pr *github.PullRequest, refGetter *config.RefGetterForGitHubPullRequest, presubmits []config.Presubmit) ([]config.Presubmit, error) {
+	org, repo, branch := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Base.Ref
+	headSHA, err := refGetter.HeadSHA()
+	if err != nil {
+		return nil, err
+	}
+	contextPolicy, err := c.Config.GetTideContextPolicy(c.GitClient, org, repo, branch, refGetter.BaseSHA, headSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the required contexts: %w", err)
+	}
+	combinedStatus, err := c.GitHubClient.GetCombinedStatus(org, repo, headSHA)
+	if err != nil {
+		return nil, err
+	}
+	failedContexts, allContexts := getContexts(combinedStatus)
+
+	required := sets.New[string](contextPolicy.RequiredContexts...).Insert(contextPolicy.RequiredIfPresentContexts...)
+	blocking := failedContexts.Intersection(required)
+	blocking.Insert(contextPolicy.MissingRequiredContexts(sets.List(allContexts))...)
+	c.Logger.WithField("contexts", sets.List(blocking)).Info("Using test-required filter.")
+
+	changes := config.NewGitHubDeferredChangedFilesProvider(c.GitHubClient, org, repo, pr.Number)
+	return pjutil.FilterPresubmits(pjutil.NewTestRequiredFilter(blocking), changes, branch, presubmits, c.Logger)
+}
+
+// appendPresubmits appends the presubmits that are not part of toTest yet.
+func appendPresubmits(toTest, presubmits []config.Presubmit) []config.Presubmit {
+	names := sets.New[string]()
+	for _, ps := range toTest {
+		names.Insert(ps.Name)
+	}
+	for _, ps := range presubmits {
+		if !names.Has(ps.Name) {
+			toTest = append(toTest, ps)
+			names.Insert(ps.Name)
+		}
+	}
+	return toTest
+}
+
+// rerunFailedWorkflows re-runs the failed GitHub workflow runs of the head of
+// the PR. Runs that GitHub doesn't permit to re-run, e.g. because they expired,
+// are listed in a comment unless the repo disabled it.
+func rerunFailedWorkflows(c Client, trigger plugins.Trigger, gc github.GenericCommentEvent, pr *github.PullRequest, headSHA string) {
+	org, repo := gc.Repo.Owner.Login, gc.Repo.Name
+	failedRuns, err := c.GitHubClient.GetFailedActionRunsByHeadBranch(org, repo, pr.Head.Ref, headSHA)
+	if err != nil {
+		c.Logger.Errorf("%v: unable to get failed github action runs for branch %v", err, pr.Head.Ref)
+		return
+	}
+	rerun := c.GitHubClient.TriggerFailedGitHubWorkflow
+	if info, err := c.GitHubClient.GetServerInfo(org); err != nil {
+		c.Logger.WithError(err).Warn("Failed to get GitHub server info, assuming it can re-run failed jobs.")
+	} else if !info.Supports(github.CapabilityRerunFailedJobs) {
+		c.Logger.Infof("GitHub Enterprise Server %s can't re-run only the failed jobs of workflow runs, re-running all their jobs instead.", info.Version)
+		rerun = c.GitHubClient.TriggerGitHubWorkflow
+	}
+	var notRerunnable []github.WorkflowRun
+	for _, run := range failedRuns {
+		log := c.Logger.WithFields(logrus.Fields{
+			"runID":   run.ID,
+			"runName": run.Name,
+			"org":     org,
+			"repo":    repo,
+		})
+		if err := rerun(org, repo, run.ID); err != nil {
+			if github.IsForbidden(err) {
+				notRerunnable = append(notRerunnable, run)
 			}
+			log.Errorf("attempt to trigger github run failed: %v", err)
+			continue
+		}
+		log.Infof("successfully triggered action run")
+	}
+	if len(notRerunnable) == 0 || trigger.SkipExpiredWorkflowRunsComment {
+		return
+	}
+
+	var runs []string
+	for _, run := range notRerunnable {
+		if run.HTMLURL == "" {
+			runs = append(runs, "- "+run.Name)
+			continue
+		}
+		runs = append(runs, fmt.Sprintf("- [%s](%s)", run.Name, run.HTMLURL))
+	}
+	resp := fmt.Sprintf("The following failed GitHub workflow runs could not be re-run, most likely because they expired or re-running them is not permitted:\n\n%s\n\nPush a new commit to run them again.", strings.Join(runs, "\n"))
+	if err := c.GitHubClient.CreateComment(org, repo, pr.Number, plugins.FormatResponseRaw(gc.Body, gc.HTMLURL, gc.User.Login, resp)); err != nil {
+		c.Logger.WithError(err).Error("Failed to comment about the workflow runs that could not be re-run.")
+	}
+}
+
+// addedLines returns the lines of body that are not part of previous.
+func addedLines(previous, body string) string {
+	existing := sets.New[string]()
+	for _, line := range strings.Split(previous, "\n") {
+		existing.Insert(strings.TrimSpace(line))
+	}
+	var added []string
+	for _, line := range strings.Split(body, "\n") {
+		if !existing.Has(strings.TrimSpace(line)) {
+			added = append(added, line)
 		}
 	}
-	return RunRequestedWithLabels(c, pr, baseSHA, toTest, gc.GUID, additionalLabels)
+	return strings.Join(added, "\n")
 }
 
 func HonorOkToTest(trigger plugins.Trigger) bool {
@@ -58,10 +58,15 @@ type testcase struct {
 	AddedLabels    []string
 	RemovedLabels  []string
 	StartsExactly  string
+	StartsAll      []string
 	Presubmits     map[string][]config.Presubmit
 	IssueLabels    []string
 	IgnoreOkToTest bool
 	AddedComment   string
+
+	Edited                bool
+	PreviousBody          string
+	TriggerOnCommentEdits bool
 }
 
 func TestHandleGenericComment(t *testing.T) {
@@ -1042,6 +1047,113 @@ func TestHandleGenericComment(t *testing.T) {
 				"```\n/command_foo\n```\n```\n/rerun_command\n```\n\n" +
 				"Use `/test all` to run all jobs.",
 		},
+		{
+			name:          "/test-required runs the failing required job",
+			Author:        "trusted-member",
+			Body:          "/test-required",
+			State:         "open",
+			IsPR:          true,
+			ShouldBuild:   true,
+			StartsExactly: "pull-jib",
+		},
+		{
+			name:   "/test-required runs missing required jobs but not failing optional ones",
+			Author: "trusted-member",
+			Body:   "/test-required",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase:   config.JobBase{Name: "missing"},
+						AlwaysRun: true,
+						Reporter:  config.Reporter{Context: "pull-missing"},
+					},
+					{
+						JobBase:   config.JobBase{Name: "jib"},
+						AlwaysRun: true,
+						Optional:  true,
+						Reporter:  config.Reporter{Context: "pull-jib"},
+					},
+					{
+						JobBase:   config.JobBase{Name: "jub"},
+						AlwaysRun: true,
+						Reporter:  config.Reporter{Context: "pull-jub"},
+					},
+				},
+			},
+			ShouldBuild:   true,
+			StartsExactly: "pull-missing",
+		},
+		{
+			name:   "/test-required without failing or missing required contexts explains that",
+			Author: "trusted-member",
+			Body:   "/test-required",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase:   config.JobBase{Name: "jub"},
+						AlwaysRun: true,
+						Reporter:  config.Reporter{Context: "pull-jub"},
+					},
+				},
+			},
+			AddedComment: "All contexts required to merge this PR are passing or pending, there are no jobs to run.",
+		},
+		{
+			name:         "Edited comment is ignored by default",
+			Author:       "trusted-member",
+			Body:         "/test jib",
+			PreviousBody: "/test jb",
+			State:        "open",
+			IsPR:         true,
+			Edited:       true,
+		},
+		{
+			name:                  "Edited comment with a fixed /test command triggers the job",
+			Author:                "trusted-member",
+			Body:                  "/test jib",
+			PreviousBody:          "/test jb",
+			State:                 "open",
+			IsPR:                  true,
+			Edited:                true,
+			TriggerOnCommentEdits: true,
+			ShouldBuild:           true,
+			StartsExactly:         "pull-jib",
+		},
+		{
+			name:                  "Edited comment only triggers the jobs added by the edit",
+			Author:                "trusted-member",
+			Body:                  "/test job\n/test jib",
+			PreviousBody:          "/test job",
+			State:                 "open",
+			IsPR:                  true,
+			Edited:                true,
+			TriggerOnCommentEdits: true,
+			ShouldBuild:           true,
+			StartsExactly:         "pull-jib",
+		},
+		{
+			name:                  "Edit that doesn't touch the commands doesn't trigger jobs again",
+			Author:                "trusted-member",
+			Body:                  "Lets try again\n/test jib",
+			PreviousBody:          "Let's try again\n/test jib",
+			State:                 "open",
+			IsPR:                  true,
+			Edited:                true,
+			TriggerOnCommentEdits: true,
+		},
+		{
+			name:                  "Edited comment without previous body is ignored",
+			Author:                "trusted-member",
+			Body:                  "/test jib",
+			State:                 "open",
+			IsPR:                  true,
+			Edited:                true,
+			TriggerOnCommentEdits: true,
+		},
 		{
 			name:         "/test with no target results in a help message",
 			Author:       "trusted-member",
@@ -1075,6 +1187,31 @@ func TestHandleGenericComment(t *testing.T) {
 			ShouldBuild:   true,
 			StartsExactly: "pull-jib",
 		},
+		{
+			name:   "/test of a job also triggers its dependencies",
+			Author: "trusted-member",
+			Body:   "/test e2e",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase:      config.JobBase{Name: "build"},
+						Reporter:     config.Reporter{Context: "pull-build"},
+						Trigger:      `(?m)^/test (?:.*? )?build(?: .*?)?$`,
+						RerunCommand: "/test build",
+					},
+					{
+						JobBase:      config.JobBase{Name: "e2e", DependsOn: []string{"build"}},
+						Reporter:     config.Reporter{Context: "pull-e2e"},
+						Trigger:      `(?m)^/test (?:.*? )?e2e(?: .*?)?$`,
+						RerunCommand: "/test e2e",
+					},
+				},
+			},
+			ShouldBuild: true,
+			StartsAll:   []string{"pull-build", "pull-e2e"},
+		},
 		{
 			name:         "/test with unknown target results in a help message",
 			Author:       "trusted-member",
@@ -1326,22 +1463,28 @@ func TestHandleGenericComment(t *testing.T) {
 				t.Fatalf("%s: failed to set presubmits: %v", tc.name, err)
 			}
 
+			action := github.GenericCommentActionCreated
+			if tc.Edited {
+				action = github.GenericCommentActionEdited
+			}
 			event := github.GenericCommentEvent{
-				Action: github.GenericCommentActionCreated,
+				Action: action,
 				Repo: github.Repo{
 					Owner:    github.User{Login: "org"},
 					Name:     "repo",
 					FullName: "org/repo",
 				},
-				Body:        tc.Body,
-				User:        github.User{Login: tc.Author},
-				IssueAuthor: github.User{Login: tc.PRAuthor},
-				IssueState:  tc.State,
-				IsPR:        tc.IsPR,
+				Body:         tc.Body,
+				User:         github.User{Login: tc.Author},
+				IssueAuthor:  github.User{Login: tc.PRAuthor},
+				IssueState:   tc.State,
+				IsPR:         tc.IsPR,
+				PreviousBody: tc.PreviousBody,
 			}
 
 			trigger := plugins.Trigger{
-				IgnoreOkToTest: tc.IgnoreOkToTest,
+				IgnoreOkToTest:        tc.IgnoreOkToTest,
+				TriggerOnCommentEdits: tc.TriggerOnCommentEdits,
 			}
 			trigger.SetDefaults()
 
@@ -1379,6 +1522,9 @@ func validate(t *testing.T, actions []clienttesting.Action, g *fakegithub.FakeCl
 	if tc.StartsExactly != "" && (startedContexts.Len() != 1 || !startedContexts.Has(tc.StartsExactly)) {
 		t.Errorf("didn't build expected context %v, instead built %v", tc.StartsExactly, startedContexts)
 	}
+	if tc.StartsAll != nil && !startedContexts.Equal(sets.New[string](tc.StartsAll...)) {
+		t.Errorf("didn't build expected contexts %v, instead built %v", tc.StartsAll, sets.List(startedContexts))
+	}
 	if !reflect.DeepEqual(g.IssueLabelsAdded, tc.AddedLabels) {
 		t.Errorf("expected %q to be added, got %q", tc.AddedLabels, g.IssueLabelsAdded)
 	}
@@ -1481,3 +1627,86 @@ func TestRetestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestRerunFailedWorkflows(t *testing.T) {
+	runs := []github.WorkflowRun{
+		{ID: 1, Name: "lint", HTMLURL: "https://github.com/org/repo/actions/runs/1"},
+		{ID: 2, Name: "unit", HTMLURL: "https://github.com/org/repo/actions/runs/2"},
+		{ID: 3, Name: "e2e"},
+	}
+	testCases := []struct {
+		name              string
+		errors            map[int]error
+		skipComment       bool
+		serverInfo        *github.ServerInfo
+		expectedTriggered []int
+		expectedRerun     []int
+		expectedComment   string
+	}{
+		{
+			name:              "all runs are re-run",
+			expectedTriggered: []int{1, 2, 3},
+		},
+		{
+			name:              "expired runs are listed in a comment",
+			errors:            map[int]error{2: github.NewForbidden(), 3: github.NewForbidden()},
+			expectedTriggered: []int{1},
+			expectedComment:   "could not be re-run, most likely because they expired or re-running them is not permitted:\n\n- [unit](https://github.com/org/repo/actions/runs/2)\n- e2e\n\nPush a new commit to run them again.",
+		},
+		{
+			name:              "other errors are not commented about",
+			errors:            map[int]error{2: fmt.Errorf("connection reset")},
+			expectedTriggered: []int{1, 3},
+		},
+		{
+			name:              "comment can be disabled",
+			errors:            map[int]error{2: github.NewForbidden()},
+			skipComment:       true,
+			expectedTriggered: []int{1, 3},
+		},
+		{
+			name:          "whole runs are re-run on GitHub Enterprise Server versions that can't re-run failed jobs",
+			serverInfo:    &github.ServerInfo{Enterprise: true, Version: "3.4.2"},
+			expectedRerun: []int{1, 2, 3},
+		},
+		{
+			name:              "failed jobs are re-run on newer GitHub Enterprise Server versions",
+			serverInfo:        &github.ServerInfo{Enterprise: true, Version: "3.12.0"},
+			expectedTriggered: []int{1, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := fakegithub.NewFakeClient()
+			g.FailedActionRuns = runs
+			g.TriggerFailedGitHubWorkflowErrors = tc.errors
+			g.ServerInfo = tc.serverInfo
+			c := Client{GitHubClient: g, Logger: logrus.WithField("plugin", PluginName)}
+			gc := github.GenericCommentEvent{
+				Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				Body: "/retest",
+				User: github.User{Login: "trusted-member"},
+			}
+			pr := &github.PullRequest{Number: 5, Head: github.PullRequestBranch{Ref: "feature", SHA: "cafe"}}
+
+			rerunFailedWorkflows(c, plugins.Trigger{SkipExpiredWorkflowRunsComment: tc.skipComment}, gc, pr, "cafe")
+
+			if !reflect.DeepEqual(g.TriggeredFailedGitHubWorkflows, tc.expectedTriggered) {
+				t.Errorf("expected runs %v to be triggered, got %v", tc.expectedTriggered, g.TriggeredFailedGitHubWorkflows)
+			}
+			if !reflect.DeepEqual(g.TriggeredGitHubWorkflows, tc.expectedRerun) {
+				t.Errorf("expected runs %v to be re-run entirely, got %v", tc.expectedRerun, g.TriggeredGitHubWorkflows)
+			}
+			comments := g.IssueComments[5]
+			if tc.expectedComment == "" {
+				if len(comments) != 0 {
+					t.Errorf("expected no comments, got %v", comments)
+				}
+				return
+			}
+			if len(comments) != 1 || !strings.Contains(comments[0].Body, tc.expectedComment) {
+				t.Errorf("expected a comment containing %q, got %v", tc.expectedComment, comments)
+			}
+		})
+	}
+}
@@ -60,7 +60,11 @@ func handlePR(c Client, trigger plugins.Trigger, pr github.PullRequestEvent) err
 		return pr.PullRequest.Head.SHA, nil
 	}
 
-	presubmits := getPresubmits(c.Logger, c.GitClient, c.Config, org+"/"+repo, baseSHAGetter, headSHAGetter)
+	headSHAGetters, err := c.prHeadSHAGetters(org, repo, num, headSHAGetter)
+	if err != nil {
+		return err
+	}
+	presubmits := getPresubmits(c.Logger, c.GitClient, c.Config, org+"/"+repo, baseSHAGetter, headSHAGetters...)
 	if len(presubmits) == 0 {
 		return nil
 	}
@@ -120,8 +124,10 @@ func handlePR(c Client, trigger plugins.Trigger, pr github.PullRequestEvent) err
 		}
 	case github.PullRequestActionSynchronize:
 		var errs []error
-		if err := abortAllJobs(c, &pr.PullRequest); err != nil {
-			errs = append(errs, fmt.Errorf("failed to abort jobs: %w", err))
+		if trigger.ShouldAbortStaleRuns() {
+			if err := abortStaleJobs(c, &pr.PullRequest); err != nil {
+				errs = append(errs, fmt.Errorf("failed to abort jobs: %w", err))
+			}
 		}
 		return utilerrors.NewAggregate(append(errs, buildAllIfTrusted(c, trigger, pr, baseSHA, presubmits)))
 	case github.PullRequestActionLabeled:
@@ -167,6 +173,25 @@ func handlePR(c Client, trigger plugins.Trigger, pr github.PullRequestEvent) err
 }
 
 func abortAllJobs(c Client, pr *github.PullRequest) error {
+	return abortJobs(c, pr, func(prowapi.ProwJob) bool { return true })
+}
+
+// abortStaleJobs aborts the jobs of the PR that don't test its current head.
+func abortStaleJobs(c Client, pr *github.PullRequest) error {
+	return abortJobs(c, pr, func(job prowapi.ProwJob) bool {
+		if job.Spec.Refs == nil {
+			return true
+		}
+		for _, pull := range job.Spec.Refs.Pulls {
+			if pull.Number == pr.Number {
+				return pull.SHA != pr.Head.SHA
+			}
+		}
+		return true
+	})
+}
+
+func abortJobs(c Client, pr *github.PullRequest, shouldAbort func(prowapi.ProwJob) bool) error {
 	selector, err := labelSelectorForPR(pr)
 	if err != nil {
 		return fmt.Errorf("failed to construct label selector: %w", err)
@@ -180,7 +205,7 @@ func abortAllJobs(c Client, pr *github.PullRequest) error {
 	var errs []error
 	for _, job := range jobs.Items {
 		// Do not abort jobs that already completed
-		if job.Complete() {
+		if job.Complete() || !shouldAbort(job) {
 			continue
 		}
 		job.Status.State = prowapi.AbortedState
@@ -360,5 +385,5 @@ func buildAll(c Client, pr *github.PullRequest, eventGUID string, baseSHA string
 	if err != nil {
 		return err
 	}
-	return RunRequested(c, pr, baseSHA, toTest, eventGUID)
+	return RunRequested(c, pr, baseSHA, pjutil.AddDependencies(toTest, presubmits, branch), eventGUID)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
@@ -141,6 +142,8 @@ func TestHandlePullRequest(t *testing.T) {
 		prIsDraft        bool
 		eventSender      string
 		jobToAbort       *prowapi.ProwJob
+		jobToKeep        *prowapi.ProwJob
+		noAbortStaleRuns bool
 		issueLabelsAdded []string
 	}{
 		{
@@ -417,6 +420,16 @@ func TestHandlePullRequest(t *testing.T) {
 			ShouldBuild: true,
 			jobToAbort:  jobToAbort,
 		},
+		{
+			name: "Keep old jobs on push if aborting stale runs is disabled",
+
+			Author:           "t",
+			HasOkToTest:      true,
+			prAction:         github.PullRequestActionSynchronize,
+			ShouldBuild:      true,
+			jobToKeep:        jobToAbort,
+			noAbortStaleRuns: true,
+		},
 	}
 	for _, tc := range testcases {
 		t.Logf("running scenario %q", tc.name)
@@ -495,6 +508,9 @@ func TestHandlePullRequest(t *testing.T) {
 				TrustedOrg:     "org",
 				OnlyOrgMembers: true,
 			}
+			if tc.noAbortStaleRuns {
+				trigger.AbortStaleRuns = ptr.To(false)
+			}
 			trigger.SetDefaults()
 			if err := handlePR(c, trigger, pr); err != nil {
 				t.Fatalf("Didn't expect error: %s", err)
@@ -529,6 +545,15 @@ func TestHandlePullRequest(t *testing.T) {
 					t.Errorf("expected job %s to not be set to complete.", tc.jobToAbort.Name)
 				}
 			}
+			if tc.jobToKeep != nil {
+				pj, err := fakeProwJobClient.ProwV1().ProwJobs("namespace").Get(context.Background(), tc.jobToKeep.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("failed to get prowjob: %v", err)
+				}
+				if pj.Status.State == prowapi.AbortedState {
+					t.Errorf("expected job %s to not be aborted", tc.jobToKeep.Name)
+				}
+			}
 			if cmp.Diff(tc.issueLabelsAdded, g.IssueLabelsAdded) != "" {
 				t.Errorf("expected added issue labels %v to match %v", tc.issueLabelsAdded, g.IssueLabelsAdded)
 			}
@@ -631,3 +656,49 @@ func TestAbortAllJobs(t *testing.T) {
 		})
 	}
 }
+
+func TestAbortStaleJobs(t *testing.T) {
+	const org, repo, number = "org", "repo", 1
+	pj := func(name, sha string) *prowapi.ProwJob {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kube.OrgLabel:         org,
+					kube.RepoLabel:        repo,
+					kube.PullLabel:        strconv.Itoa(number),
+					kube.ProwJobTypeLabel: string(prowapi.PresubmitJob),
+				},
+			},
+			Spec: prowapi.ProwJobSpec{
+				Type: prowapi.PresubmitJob,
+				Refs: &prowapi.Refs{Org: org, Repo: repo, Pulls: []prowapi.Pull{{Number: number, SHA: sha}}},
+			},
+			Status: prowapi.ProwJobStatus{State: prowapi.PendingState},
+		}
+	}
+	pjClient := fake.NewSimpleClientset(pj("old", "old-sha"), pj("new", "new-sha"))
+	client := Client{
+		ProwJobClient: pjClient.ProwV1().ProwJobs(""),
+		Logger:        logrus.NewEntry(logrus.New()),
+	}
+	pr := &github.PullRequest{
+		Base:   github.PullRequestBranch{Repo: github.Repo{Owner: github.User{Login: org}, Name: repo}},
+		Head:   github.PullRequestBranch{SHA: "new-sha"},
+		Number: number,
+	}
+
+	if err := abortStaleJobs(client, pr); err != nil {
+		t.Fatalf("error calling abortStaleJobs: %v", err)
+	}
+
+	for name, expected := range map[string]prowapi.ProwJobState{"old": prowapi.AbortedState, "new": prowapi.PendingState} {
+		pj, err := pjClient.ProwV1().ProwJobs("").Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get prowjob: %v", err)
+		}
+		if pj.Status.State != expected {
+			t.Errorf("expected job %s to be in state %s, got %s", name, expected, pj.Status.State)
+		}
+	}
+}
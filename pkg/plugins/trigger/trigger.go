@@ -86,6 +86,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 			org = trigger.TrustedOrg
 		}
 		configInfo[repo.String()] = fmt.Sprintf("The trusted GitHub organization for this repository is %q.", org)
+		if trigger.TriggerOnCommentEdits {
+			configInfo[repo.String()] += " Commands added to comments by editing them are handled as well."
+		}
 	}
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
 		Triggers: []plugins.Trigger{
@@ -108,6 +111,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 <br>Presubmit jobs are run automatically on pull requests that are trusted and not in a draft state with file changes matching the file filters and targeting a branch matching the branch filters.
 <br>A pull request is considered trusted if the author is a member of the 'trusted organization' for the repository or if such a member has left an '/ok-to-test' command on the PR.
 <br>Trigger will not automatically start jobs for a PR in draft state, and if a PR is changed to draft it cancels pending jobs.
+<br>When new commits are pushed to a PR, trigger aborts the jobs still running for its previous commits unless 'abort_stale_runs' is disabled.
 <br>If jobs are not run automatically for a PR because it is not trusted or is in draft state, a trusted user can still start jobs manually via the '/test' command.
 <br>The '/retest' command can be used to rerun jobs that have reported failure.
 <br>Trigger starts postsubmit jobs when commits are pushed if the filters on the job match files and branches affected by that push.`,
@@ -135,6 +139,13 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
 		Examples:    []string{"/retest"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/test-required",
+		Description: "Runs the jobs producing the contexts required to merge the PR that are failing or missing.",
+		Featured:    true,
+		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
+		Examples:    []string{"/test-required"},
+	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/test ?",
 		Description: "List available test job(s) for a trusted PR.",
@@ -161,6 +172,7 @@ type githubClient interface {
 	RemoveLabel(org, repo string, number int, label string) error
 	TriggerGitHubWorkflow(org, repo string, id int) error
 	TriggerFailedGitHubWorkflow(org, repo string, id int) error
+	GetServerInfo(org string) (*github.ServerInfo, error)
 	DeleteStaleComments(org, repo string, number int, comments []github.IssueComment, isStale func(github.IssueComment) bool) error
 	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
 }
@@ -340,8 +352,8 @@ func runRequested(c Client, pr *github.PullRequest, baseSHA string, requestedJob
 	return utilerrors.NewAggregate(errors)
 }
 
-func getPresubmits(log *logrus.Entry, gc git.ClientFactory, cfg *config.Config, orgRepo string, baseSHAGetter, headSHAGetter config.RefGetter) []config.Presubmit {
-	presubmits, err := cfg.GetPresubmits(gc, orgRepo, "", baseSHAGetter, headSHAGetter)
+func getPresubmits(log *logrus.Entry, gc git.ClientFactory, cfg *config.Config, orgRepo string, baseSHAGetter config.RefGetter, headSHAGetters ...config.RefGetter) []config.Presubmit {
+	presubmits, err := cfg.GetPresubmits(gc, orgRepo, "", baseSHAGetter, headSHAGetters...)
 	if err != nil {
 		// Fall back to static presubmits to avoid deadlocking when a presubmit is used to verify
 		// inrepoconfig. Tide will still respect errors here and not merge.
@@ -351,6 +363,29 @@ func getPresubmits(log *logrus.Entry, gc git.ClientFactory, cfg *config.Config,
 	return presubmits
 }
 
+// prHeadSHAGetters returns the getters of the heads whose inrepoconfig is
+// loaded for the PR. If the repo requires approval of inrepoconfig changes,
+// the inrepoconfig of the base is used until the changes are approved at the
+// head of the PR.
+func (c Client) prHeadSHAGetters(org, repo string, number int, headSHAGetter config.RefGetter) ([]config.RefGetter, error) {
+	if !c.Config.InRepoConfigRequiresApproval(org + "/" + repo) {
+		return []config.RefGetter{headSHAGetter}, nil
+	}
+	headSHA, err := headSHAGetter()
+	if err != nil {
+		return nil, err
+	}
+	approved, err := c.Config.InRepoConfigApproved(c.GitHubClient, org, repo, number, headSHA)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		c.Logger.Info("PR changes the inrepoconfig without approval at its head, using the inrepoconfig of the base.")
+		return nil, nil
+	}
+	return []config.RefGetter{headSHAGetter}, nil
+}
+
 func getPostsubmits(log *logrus.Entry, gc git.ClientFactory, cfg *config.Config, orgRepo string, baseSHAGetter config.RefGetter) []config.Postsubmit {
 	postsubmits, err := cfg.GetPostsubmits(gc, orgRepo, "", baseSHAGetter)
 	if err != nil {
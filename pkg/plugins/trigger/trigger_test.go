@@ -533,6 +533,66 @@ func TestGetPresubmits(t *testing.T) {
 	}
 }
 
+func TestPRHeadSHAGetters(t *testing.T) {
+	yes := true
+	testCases := []struct {
+		name            string
+		requireApproval bool
+		changes         []github.PullRequestChange
+		statuses        []github.Status
+		expectHead      bool
+	}{
+		{
+			name:       "head is used if approval is not required",
+			changes:    []github.PullRequestChange{{Filename: ".prow/jobs.yaml"}},
+			expectHead: true,
+		},
+		{
+			name:            "head is used if the inrepoconfig is unchanged",
+			requireApproval: true,
+			changes:         []github.PullRequestChange{{Filename: "main.go"}},
+			expectHead:      true,
+		},
+		{
+			name:            "head is not used if the inrepoconfig changed without approval",
+			requireApproval: true,
+			changes:         []github.PullRequestChange{{Filename: "main.go"}, {Filename: ".prow/jobs.yaml"}},
+		},
+		{
+			name:            "head is not used if the approval of the inrepoconfig is pending",
+			requireApproval: true,
+			changes:         []github.PullRequestChange{{Filename: ".prow.yaml"}},
+			statuses:        []github.Status{{Context: config.InRepoConfigApprovedContext, State: github.StatusPending}},
+		},
+		{
+			name:            "head is used if the changed inrepoconfig is approved at the head",
+			requireApproval: true,
+			changes:         []github.PullRequestChange{{Filename: ".prow.yaml"}},
+			statuses:        []github.Status{{Context: config.InRepoConfigApprovedContext, State: github.StatusSuccess}},
+			expectHead:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fghc := fakegithub.NewFakeClient()
+			fghc.PullRequestChanges = map[int][]github.PullRequestChange{1: tc.changes}
+			fghc.CombinedStatuses = map[string]*github.CombinedStatus{"head": {SHA: "head", Statuses: tc.statuses}}
+			cfg := &config.Config{}
+			if tc.requireApproval {
+				cfg.InRepoConfig.RequireApproval = map[string]*bool{"org/repo": &yes}
+			}
+			c := Client{GitHubClient: fghc, Config: cfg, Logger: logrus.WithField("plugin", PluginName)}
+			getters, err := c.prHeadSHAGetters("org", "repo", 1, func() (string, error) { return "head", nil })
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if usesHead := len(getters) == 1; usesHead != tc.expectHead {
+				t.Errorf("expected the head to be used: %t, got %d getters", tc.expectHead, len(getters))
+			}
+		})
+	}
+}
+
 func TestGetPostsubmits(t *testing.T) {
 	const orgRepo = "my-org/my-repo"
 
@@ -36,7 +36,6 @@ import (
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/plugins"
-	"sigs.k8s.io/prow/pkg/plugins/golint"
 	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
 	"sigs.k8s.io/prow/pkg/plugins/trigger"
 	"sigs.k8s.io/prow/pkg/repoowners"
@@ -395,7 +394,7 @@ func parseOwnersFile(oc ownersClient, path string, c github.PullRequestChange, l
 				// we're sure it will convert as it passed the regexp already
 				absoluteLineNumber, _ := strconv.Atoi(lineNumberMatches[1])
 				// we need to convert it to a line number relative to the patch
-				al, err := golint.AddedLines(c.Patch)
+				al, err := plugins.AddedLines(c.Patch)
 				if err != nil {
 					log.WithError(err).Errorf("Failed to compute added lines in %s: %v", c.Filename, err)
 				} else if val, ok := al[absoluteLineNumber]; ok {
@@ -230,6 +230,10 @@ func (foc *fakeOwnersClient) AllReviewers() sets.Set[string] {
 	return sets.Set[string]{}
 }
 
+func (foc *fakeOwnersClient) Metadata(login string) (repoowners.UserMetadata, bool) {
+	return repoowners.UserMetadata{}, false
+}
+
 func (foc *fakeOwnersClient) Filenames() ownersconfig.Filenames {
 	return ownersconfig.FakeFilenames
 }
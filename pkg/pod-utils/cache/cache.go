@@ -0,0 +1,373 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+
+	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+)
+
+const (
+	// dataDir is the directory of the cache volume holding the cached
+	// directories of the test containers.
+	dataDir = "data"
+	// keyFile records the scoped key of the cache, so sidecar doesn't need
+	// to render the key template again.
+	keyFile = "key"
+	// restoredFile marks caches that were restored from blob storage and
+	// don't need to be saved again.
+	restoredFile = "restored"
+)
+
+// Options configures restoring and saving the cache of a job.
+type Options struct {
+	// KeyTemplate is rendered to the key the cache is stored under.
+	KeyTemplate string `json:"key_template"`
+	// Storage is the blob storage path caches are stored under.
+	Storage string `json:"storage"`
+	// Dir is the directory the cache volume is mounted at.
+	Dir string `json:"dir"`
+	// WorkDir is the directory the globs of hashFiles are relative to.
+	WorkDir string `json:"work_dir,omitempty"`
+}
+
+// SubPath returns the sub path of the cache volume that is mounted at the
+// given path of the test containers.
+func SubPath(p string) string {
+	sum := sha256.Sum256([]byte(path.Clean(p)))
+	return path.Join(dataDir, hex.EncodeToString(sum[:8]))
+}
+
+// ParseKeyTemplate parses a key template, resolving hashFiles relative to
+// the given directory.
+func ParseKeyTemplate(text, workDir string) (*template.Template, error) {
+	return template.New("key").Funcs(template.FuncMap{
+		"hashFiles": func(patterns ...string) (string, error) {
+			return hashFiles(workDir, patterns...)
+		},
+	}).Parse(text)
+}
+
+// hashFiles returns the hash of the content of the files matching the
+// globs, or an empty string if no file matches.
+func hashFiles(dir string, patterns ...string) (string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return "", fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+	sort.Strings(files)
+	h := sha256.New()
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("could not hash %s: %w", file, err)
+		}
+		fileHash := sha256.Sum256(content)
+		h.Write(fileHash[:])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Key renders the key of the cache for the job.
+func (o Options) Key(spec *downwardapi.JobSpec) (string, error) {
+	tmpl, err := ParseKeyTemplate(o.KeyTemplate, o.WorkDir)
+	if err != nil {
+		return "", fmt.Errorf("could not parse key template: %w", err)
+	}
+	var key strings.Builder
+	if err := tmpl.Execute(&key, spec); err != nil {
+		return "", fmt.Errorf("could not render key template: %w", err)
+	}
+	k := strings.TrimSpace(key.String())
+	if k == "" {
+		return "", errors.New("key template rendered an empty key")
+	}
+	return k, nil
+}
+
+// Scope returns the namespace the caches of the job are stored in. Caches
+// are separated by job type and base ref, so the caches saved by presubmits,
+// which run code that was not reviewed yet, are never restored by trusted
+// jobs like postsubmits and periodics, even if the key template renders
+// the same key for both.
+func Scope(spec *downwardapi.JobSpec) string {
+	scope := string(spec.Type)
+	if spec.Refs != nil && spec.Refs.BaseRef != "" {
+		scope = path.Join(scope, spec.Refs.BaseRef)
+	}
+	return scope
+}
+
+func (o Options) archivePath(scopedKey string) string {
+	return strings.TrimSuffix(o.Storage, "/") + "/" + scopedKey + ".tar.gz"
+}
+
+// Restore unpacks the cache of the job from blob storage into the cache
+// volume. A missing cache is not an error, sidecar saves it once the job
+// passed.
+func (o Options) Restore(ctx context.Context, opener pkgio.Opener, spec *downwardapi.JobSpec) error {
+	key, err := o.Key(spec)
+	if err != nil {
+		return err
+	}
+	key = path.Join(Scope(spec), key)
+	if err := os.WriteFile(filepath.Join(o.Dir, keyFile), []byte(key), 0644); err != nil {
+		return fmt.Errorf("could not record cache key: %w", err)
+	}
+	log := logrus.WithField("path", o.archivePath(key))
+
+	reader, err := opener.Reader(ctx, o.archivePath(key))
+	if pkgio.IsNotExist(err) {
+		log.Info("No cache found.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not open cache: %w", err)
+	}
+	defer pkgio.LogClose(reader)
+	if err := unarchive(reader, filepath.Join(o.Dir, dataDir)); err != nil {
+		return fmt.Errorf("could not unpack cache: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(o.Dir, restoredFile), nil, 0644); err != nil {
+		return fmt.Errorf("could not mark cache as restored: %w", err)
+	}
+	log.Info("Restored cache.")
+	return nil
+}
+
+// Save packs the cache volume into blob storage unless it was restored
+// from there. Caches are never overwritten, the first run saving a key in
+// the scope of the job wins.
+func (o Options) Save(ctx context.Context, opener pkgio.Opener) error {
+	if _, err := os.Stat(filepath.Join(o.Dir, restoredFile)); err == nil {
+		logrus.Info("Cache was restored, not saving it.")
+		return nil
+	}
+	key, err := os.ReadFile(filepath.Join(o.Dir, keyFile))
+	if os.IsNotExist(err) {
+		logrus.Info("Cache key was not determined, not saving the cache.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read cache key: %w", err)
+	}
+
+	doesNotExist := true
+	writer, err := opener.Writer(ctx, o.archivePath(string(key)), pkgio.WriterOptions{PreconditionDoesNotExist: &doesNotExist})
+	if err != nil {
+		return fmt.Errorf("could not open cache for writing: %w", err)
+	}
+	if err := archive(filepath.Join(o.Dir, dataDir), writer); err != nil {
+		writer.Close()
+		return fmt.Errorf("could not pack cache: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("could not save cache: %w", err)
+	}
+	logrus.WithField("path", o.archivePath(string(key))).Info("Saved cache.")
+	return nil
+}
+
+// archive writes the content of dir to the writer as a gzipped tarball.
+func archive(dir string, w io.Writer) error {
+	zipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(zipWriter)
+
+	if err := filepath.Walk(dir, func(absPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if absPath == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if absPath == dir {
+			return nil
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink == os.ModeSymlink {
+			if link, err = os.Readlink(absPath); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return fmt.Errorf("could not create tar header: %w", err)
+		}
+		relPath, err := filepath.Rel(dir, absPath)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("could not write tar header: %w", err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(absPath)
+		if err != nil {
+			return fmt.Errorf("could not open source file: %w", err)
+		}
+		defer file.Close()
+		if _, err := io.Copy(tarWriter, file); err != nil {
+			return fmt.Errorf("could not tar file: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("could not walk cached files: %w", err)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("could not close tar writer: %w", err)
+	}
+	return zipWriter.Close()
+}
+
+// unarchive unpacks the gzipped tarball into dir. Symlinks in the archive
+// are resolved before anything is written through them, so that a chain of
+// symlinks which each look local cannot be used to write outside of dir.
+func unarchive(r io.Reader, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("could not create directory: %w", err)
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return fmt.Errorf("could not resolve %s: %w", dir, err)
+	}
+	zipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("could not read archive: %w", err)
+	}
+	defer zipReader.Close()
+	tarReader := tar.NewReader(zipReader)
+
+	for {
+		entry, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not read archive: %w", err)
+		}
+		if !filepath.IsLocal(entry.Name) {
+			return fmt.Errorf("archive contains invalid name %q", entry.Name)
+		}
+		parent, err := resolveWithin(root, filepath.Dir(filepath.Join(root, filepath.FromSlash(entry.Name))))
+		if err != nil {
+			return fmt.Errorf("archive contains invalid entry %q: %w", entry.Name, err)
+		}
+		abs := filepath.Join(parent, filepath.Base(entry.Name))
+		// Directories are created writable regardless of their mode, as
+		// caches like the Go module cache contain read-only directories.
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return fmt.Errorf("could not create directory: %w", err)
+		}
+		switch entry.Typeflag {
+		case tar.TypeDir:
+			if _, err := resolveWithin(root, abs); err != nil {
+				return fmt.Errorf("archive contains invalid entry %q: %w", entry.Name, err)
+			}
+			if err := os.MkdirAll(abs, 0755); err != nil {
+				return fmt.Errorf("could not create directory: %w", err)
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(entry.Linkname) {
+				return fmt.Errorf("archive contains symlink %q pointing outside of it", entry.Name)
+			}
+			if _, err := resolveWithin(root, filepath.Join(parent, entry.Linkname)); err != nil {
+				return fmt.Errorf("archive contains symlink %q pointing outside of it", entry.Name)
+			}
+			if err := os.Symlink(entry.Linkname, abs); err != nil {
+				return fmt.Errorf("could not create symlink: %w", err)
+			}
+		case tar.TypeReg:
+			// A regular file replaces a symlink of the same name instead of
+			// being written through it.
+			if info, err := os.Lstat(abs); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(abs); err != nil {
+					return fmt.Errorf("could not replace symlink %s: %w", abs, err)
+				}
+			}
+			file, err := os.OpenFile(abs, os.O_RDWR|os.O_CREATE|os.O_TRUNC, entry.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(file, tarReader)
+			if closeErr := file.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return fmt.Errorf("could not write %s: %w", abs, err)
+			}
+		default:
+			logrus.WithField("name", entry.Name).Warn("Skipping unsupported entry of cache.")
+		}
+	}
+}
+
+// resolveWithin resolves the symlinks in the existing part of path and
+// returns the result if it is within root.
+func resolveWithin(root, path string) (string, error) {
+	existing, missing := filepath.Clean(path), ""
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		if existing == root || existing == filepath.Dir(existing) {
+			return "", fmt.Errorf("%s does not exist", root)
+		}
+		missing = filepath.Join(filepath.Base(existing), missing)
+		existing = filepath.Dir(existing)
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s: %w", existing, err)
+	}
+	resolved = filepath.Join(resolved, missing)
+	if rel, err := filepath.Rel(root, resolved); err != nil || (rel != "." && !filepath.IsLocal(rel)) {
+		return "", fmt.Errorf("%s resolves to %s outside of %s", path, resolved, root)
+	}
+	return resolved, nil
+}
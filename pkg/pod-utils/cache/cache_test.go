@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+)
+
+func TestKey(t *testing.T) {
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "go.sum"), []byte("sum"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec := &downwardapi.JobSpec{Job: "job", Refs: &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main"}}
+	sumHash, err := hashFiles(workDir, "go.sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name        string
+		template    string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "job spec fields",
+			template: "{{.Job}}-{{.Refs.Org}}-{{.Refs.BaseRef}}",
+			expected: "job-org-main",
+		},
+		{
+			name:     "hashed files",
+			template: `{{.Job}}-{{hashFiles "go.sum"}}`,
+			expected: "job-" + sumHash,
+		},
+		{
+			name:     "globs matching no file hash to nothing",
+			template: `{{.Job}}-{{hashFiles "*.lock"}}`,
+			expected: "job-",
+		},
+		{
+			name:        "empty key",
+			template:    " ",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid template",
+			template:    "{{.Job",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := Options{KeyTemplate: tc.template, WorkDir: workDir}.Key(spec)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if key != tc.expected {
+				t.Errorf("expected key %q, got %q", tc.expected, key)
+			}
+		})
+	}
+}
+
+func TestHashFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"a.sum": "a", "b.sum": "b"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	all, err := hashFiles(dir, "*.sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := hashFiles(dir, "a.sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reordered, err := hashFiles(dir, "b.sum", "a.sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all == a {
+		t.Error("expected the hash to depend on all matched files")
+	}
+	if all != reordered {
+		t.Errorf("expected the hash to not depend on the order of the globs, got %q and %q", all, reordered)
+	}
+}
+
+func TestSaveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	opener := &fakeopener.FakeOpener{}
+	spec := &downwardapi.JobSpec{Job: "job", Type: prowapi.PostsubmitJob, Refs: &prowapi.Refs{BaseRef: "main"}}
+	newOptions := func() Options {
+		return Options{KeyTemplate: "{{.Job}}", Storage: "gs://bucket/caches/", Dir: t.TempDir()}
+	}
+
+	saving := newOptions()
+	if err := saving.Restore(ctx, opener, spec); err != nil {
+		t.Fatalf("failed to restore missing cache: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(saving.Dir, restoredFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected missing cache to not be marked as restored, got %v", err)
+	}
+	modDir := filepath.Join(saving.Dir, SubPath("/root/go/pkg/mod"), "example.com", "mod@v1")
+	if err := os.MkdirAll(modDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "go.mod"), []byte("module example.com/mod"), 0444); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("mod@v1", filepath.Join(modDir, "..", "latest")); err != nil {
+		t.Fatal(err)
+	}
+	// Like the Go module cache, cached directories can be read-only.
+	if err := os.Chmod(modDir, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(modDir, 0755) })
+	if err := saving.Save(ctx, opener); err != nil {
+		t.Fatalf("failed to save cache: %v", err)
+	}
+	if _, ok := opener.Buffer["gs://bucket/caches/postsubmit/main/job.tar.gz"]; !ok {
+		t.Fatalf("expected the cache to be saved at gs://bucket/caches/postsubmit/main/job.tar.gz, got %v", opener.Buffer)
+	}
+
+	// Presubmits rendering the same key don't restore the caches of trusted
+	// jobs, nor could they overwrite them.
+	presubmit := newOptions()
+	presubmitSpec := &downwardapi.JobSpec{Job: "job", Type: prowapi.PresubmitJob, Refs: &prowapi.Refs{BaseRef: "main"}}
+	if err := presubmit.Restore(ctx, opener, presubmitSpec); err != nil {
+		t.Fatalf("failed to restore missing cache: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(presubmit.Dir, restoredFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected presubmit to not restore the cache of postsubmits, got %v", err)
+	}
+
+	restoring := newOptions()
+	if err := restoring.Restore(ctx, opener, spec); err != nil {
+		t.Fatalf("failed to restore cache: %v", err)
+	}
+	restoredModDir := filepath.Join(restoring.Dir, SubPath("/root/go/pkg/mod"), "example.com")
+	content, err := os.ReadFile(filepath.Join(restoredModDir, "latest", "go.mod"))
+	if err != nil {
+		t.Fatalf("failed to read restored file: %v", err)
+	}
+	if string(content) != "module example.com/mod" {
+		t.Errorf("expected restored content %q, got %q", "module example.com/mod", content)
+	}
+
+	// Restored caches are not saved again.
+	opener.WriteError = os.ErrPermission
+	if err := restoring.Save(ctx, opener); err != nil {
+		t.Errorf("expected restored cache to not be saved, got %v", err)
+	}
+}
+
+func TestScope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     *downwardapi.JobSpec
+		expected string
+	}{
+		{
+			name:     "presubmit",
+			spec:     &downwardapi.JobSpec{Type: prowapi.PresubmitJob, Refs: &prowapi.Refs{BaseRef: "release/1.0"}},
+			expected: "presubmit/release/1.0",
+		},
+		{
+			name:     "postsubmit",
+			spec:     &downwardapi.JobSpec{Type: prowapi.PostsubmitJob, Refs: &prowapi.Refs{BaseRef: "main"}},
+			expected: "postsubmit/main",
+		},
+		{
+			name:     "periodic without refs",
+			spec:     &downwardapi.JobSpec{Type: prowapi.PeriodicJob},
+			expected: "periodic",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := Scope(tc.spec); actual != tc.expected {
+				t.Errorf("expected scope %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSaveWithoutKey(t *testing.T) {
+	opener := &fakeopener.FakeOpener{WriteError: os.ErrPermission}
+	if err := (Options{Dir: t.TempDir(), Storage: "gs://bucket"}).Save(context.Background(), opener); err != nil {
+		t.Errorf("expected cache without key to not be saved, got %v", err)
+	}
+}
+
+func TestUnarchiveRejectsEscapingEntries(t *testing.T) {
+	dir := t.TempDir()
+	opener := &fakeopener.FakeOpener{}
+	if err := os.Symlink("/etc", filepath.Join(dir, "etc")); err != nil {
+		t.Fatal(err)
+	}
+	w, _ := opener.Writer(context.Background(), "archive")
+	if err := archive(dir, w); err != nil {
+		t.Fatalf("failed to archive: %v", err)
+	}
+	r, _ := opener.Reader(context.Background(), "archive")
+	if err := unarchive(r, t.TempDir()); err == nil {
+		t.Error("expected symlink pointing outside of the archive to be rejected")
+	}
+}
+
+func TestUnarchiveRejectsChainedSymlinks(t *testing.T) {
+	var buf bytes.Buffer
+	zipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(zipWriter)
+	// Each symlink points to a local path on its own, but together they
+	// resolve to the parent of the cache directory.
+	for _, header := range []*tar.Header{
+		{Name: "d", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "d/y", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		{Name: "d/y/z", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		{Name: "d/y/z/w", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		{Name: "d/y/z/w/key", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len("pwned"))},
+	} {
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tarWriter.Write([]byte("pwned")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "cache", "data")
+	if err := unarchive(&buf, dir); err == nil {
+		t.Error("expected chained symlinks pointing outside of the archive to be rejected")
+	}
+	for _, path := range []string{filepath.Join(parent, "key"), filepath.Join(parent, "cache", "key")} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to not be written, got %v", path, err)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"os/exec"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -238,6 +239,12 @@ func (g *gitCtx) commandsForBaseRef(refs prowapi.Refs, gitUserName, gitUserEmail
 	if cookiePath != "" && refs.SkipSubmodules {
 		commands = append(commands, g.gitCommand("config", "http.cookiefile", cookiePath))
 	}
+	if len(refs.SparseCheckout) > 0 {
+		// the sparse checkout needs to be set up before anything is checked
+		// out so that files outside of it are never written to disk
+		args := append([]string{"sparse-checkout", "set", "--cone", "--"}, refs.SparseCheckout...)
+		commands = append(commands, g.gitCommand(args...))
+	}
 
 	var depthArgs []string
 	if d := refs.CloneDepth; d > 0 {
@@ -363,12 +370,33 @@ func (g *gitCtx) commandsForPullRefs(refs prowapi.Refs, fakeTimestamp int) []run
 
 	// unless the user specifically asks us not to, init submodules
 	if !refs.SkipSubmodules {
-		commands = append(commands, g.gitCommand("submodule", "update", "--init", "--recursive"))
+		// submodules with a depth of their own are updated first, so the
+		// update of the remaining ones below leaves them alone
+		var submodules []string
+		for submodule := range refs.SubmoduleDepths {
+			submodules = append(submodules, submodule)
+		}
+		sort.Strings(submodules)
+		for _, submodule := range submodules {
+			args := append(submoduleUpdateArgs(refs.SubmoduleDepths[submodule]), "--", submodule)
+			commands = append(commands, g.gitCommand(args...))
+		}
+		commands = append(commands, g.gitCommand(submoduleUpdateArgs(refs.SubmoduleDepth)...))
 	}
 
 	return commands
 }
 
+// submoduleUpdateArgs returns the arguments to initialize and update
+// submodules recursively, cloning them with the given depth if positive.
+func submoduleUpdateArgs(depth int) []string {
+	args := []string{"submodule", "update", "--init", "--recursive"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	return args
+}
+
 type retryCommand struct {
 	runnable
 	retries []time.Duration
@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/initupload"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/clone"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
@@ -45,21 +46,25 @@ import (
 )
 
 const (
-	logMountName            = "logs"
-	logMountPath            = "/logs"
-	artifactsEnv            = "ARTIFACTS"
-	artifactsPath           = logMountPath + "/artifacts"
-	codeMountName           = "code"
-	codeMountPath           = "/home/prow/go"
-	gopathEnv               = "GOPATH"
-	toolsMountName          = "tools"
-	toolsMountPath          = "/tools"
-	gcsCredentialsMountName = "gcs-credentials"
-	gcsCredentialsMountPath = "/secrets/gcs"
-	s3CredentialsMountName  = "s3-credentials"
-	s3CredentialsMountPath  = "/secrets/s3-storage"
-	outputMountName         = "output"
-	outputMountPath         = "/output"
+	logMountName              = "logs"
+	logMountPath              = "/logs"
+	artifactsEnv              = "ARTIFACTS"
+	artifactsPath             = logMountPath + "/artifacts"
+	codeMountName             = "code"
+	codeMountPath             = "/home/prow/go"
+	gopathEnv                 = "GOPATH"
+	toolsMountName            = "tools"
+	toolsMountPath            = "/tools"
+	gcsCredentialsMountName   = "gcs-credentials"
+	gcsCredentialsMountPath   = "/secrets/gcs"
+	s3CredentialsMountName    = "s3-credentials"
+	s3CredentialsMountPath    = "/secrets/s3-storage"
+	azureCredentialsMountName = "azure-credentials"
+	azureCredentialsMountPath = "/secrets/azure-storage"
+	outputMountName           = "output"
+	outputMountPath           = "/output"
+	cacheMountName            = "cache"
+	cacheMountPath            = "/cache"
 )
 
 // Labels returns a string slice with label consts from kube.
@@ -69,7 +74,7 @@ func Labels() []string {
 
 // VolumeMounts returns a string set with *MountName consts in it.
 func VolumeMounts(dc *prowapi.DecorationConfig) sets.Set[string] {
-	ret := sets.New[string](logMountName, codeMountName, toolsMountName, gcsCredentialsMountName, s3CredentialsMountName)
+	ret := sets.New[string](logMountName, codeMountName, toolsMountName, gcsCredentialsMountName, s3CredentialsMountName, azureCredentialsMountName)
 	if dc == nil {
 		return ret
 	}
@@ -77,6 +82,9 @@ func VolumeMounts(dc *prowapi.DecorationConfig) sets.Set[string] {
 	if dc.OauthTokenSecret != nil {
 		ret.Insert(dc.OauthTokenSecret.Name)
 	}
+	if dc.Cache != nil {
+		ret.Insert(cacheMountName)
+	}
 	for _, sshKeySecret := range dc.SSHKeySecrets {
 		ret.Insert(sshKeySecret)
 	}
@@ -522,6 +530,20 @@ func markerFile(log coreapi.VolumeMount, prefix string) string {
 	return filepath.Join(log.MountPath, fmt.Sprintf("%s-marker.txt", prefix))
 }
 
+func stepsFile(log coreapi.VolumeMount, prefix string) string {
+	if prefix == "" {
+		return filepath.Join(log.MountPath, "steps.json")
+	}
+	return filepath.Join(log.MountPath, fmt.Sprintf("%s-steps.json", prefix))
+}
+
+func usageFile(log coreapi.VolumeMount, prefix string) string {
+	if prefix == "" {
+		return filepath.Join(log.MountPath, "usage.json")
+	}
+	return filepath.Join(log.MountPath, fmt.Sprintf("%s-usage.json", prefix))
+}
+
 func metadataFile(log coreapi.VolumeMount, prefix string) string {
 	ad := artifactsDir(log)
 	if prefix == "" {
@@ -539,7 +561,7 @@ func entrypointLocation(tools coreapi.VolumeMount) string {
 }
 
 // InjectEntrypoint will make the entrypoint binary in the tools volume the container's entrypoint, which will output to the log volume.
-func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration, prefix, previousMarker string, propagateErrorCode bool, exitZero bool, log, tools coreapi.VolumeMount) (*wrapper.Options, error) {
+func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration, prefix, previousMarker string, propagateErrorCode bool, exitZero bool, steps []prowapi.EntrypointStep, resourceUsage *prowapi.ResourceUsageConfig, log, tools coreapi.VolumeMount) (*wrapper.Options, error) {
 	wrapperOptions := &wrapper.Options{
 		Args:          append(c.Command, c.Args...),
 		ContainerName: c.Name,
@@ -547,6 +569,28 @@ func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration,
 		MarkerFile:    markerFile(log, prefix),
 		MetadataFile:  metadataFile(log, prefix),
 	}
+	var entrypointSteps []entrypoint.Step
+	if len(steps) > 0 {
+		if len(wrapperOptions.Args) > 0 {
+			return nil, fmt.Errorf("container %s cannot specify command and/or args when running steps", c.Name)
+		}
+		for _, step := range steps {
+			entrypointSteps = append(entrypointSteps, entrypoint.Step{
+				Name:      step.Name,
+				Args:      step.Command,
+				Timeout:   step.Timeout.Get(),
+				AlwaysRun: step.AlwaysRun,
+			})
+		}
+		wrapperOptions.StepsFile = stepsFile(log, prefix)
+	}
+	var usageInterval time.Duration
+	var usageGPU bool
+	if resourceUsage != nil {
+		wrapperOptions.UsageFile = usageFile(log, prefix)
+		usageInterval = resourceUsage.Interval.Get()
+		usageGPU = resourceUsage.GPU
+	}
 	// TODO(fejta): use flags
 	entrypointConfigEnv, err := entrypoint.Encode(entrypoint.Options{
 		ArtifactDir:        artifactsDir(log),
@@ -556,6 +600,9 @@ func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration,
 		PropagateErrorCode: propagateErrorCode,
 		AlwaysZero:         exitZero,
 		PreviousMarker:     previousMarker,
+		Steps:              entrypointSteps,
+		UsageInterval:      usageInterval,
+		UsageGPU:           usageGPU,
 	})
 	if err != nil {
 		return nil, err
@@ -626,14 +673,69 @@ func BlobStorageOptions(dc prowapi.DecorationConfig, localMode bool) ([]coreapi.
 		})
 		opt.StorageClientOptions.S3CredentialsFile = fmt.Sprintf("%s/service-account.json", s3CredentialsMountPath)
 	}
+	if dc.AzureCredentialsSecret != nil && *dc.AzureCredentialsSecret != "" {
+		volumes = append(volumes, coreapi.Volume{
+			Name: azureCredentialsMountName,
+			VolumeSource: coreapi.VolumeSource{
+				Secret: &coreapi.SecretVolumeSource{
+					SecretName: *dc.AzureCredentialsSecret,
+				},
+			},
+		})
+		mounts = append(mounts, coreapi.VolumeMount{
+			Name:      azureCredentialsMountName,
+			MountPath: azureCredentialsMountPath,
+		})
+		opt.StorageClientOptions.AzureCredentialsFile = fmt.Sprintf("%s/service-account.json", azureCredentialsMountPath)
+	}
 
 	return volumes, mounts, opt
 }
 
-func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, cloneLogMount *coreapi.VolumeMount, outputMount *coreapi.VolumeMount, encodedJobSpec string) (*coreapi.Container, error) {
+// CacheOptions returns the options of the pod utilities for the cache of the
+// job, or nil if the job doesn't cache anything.
+func CacheOptions(dc prowapi.DecorationConfig, refs []prowapi.Refs, localMode bool) *cache.Options {
+	// Caches are stored in blob storage, there is nothing to restore them
+	// from in local mode.
+	if dc.Cache == nil || localMode {
+		return nil
+	}
+	opts := &cache.Options{
+		KeyTemplate: dc.Cache.KeyTemplate,
+		Storage:     dc.Cache.Storage,
+		Dir:         cacheMountPath,
+	}
+	if opts.Storage == "" && dc.GCSConfiguration != nil {
+		bucket := dc.GCSConfiguration.Bucket
+		if !strings.Contains(bucket, "://") {
+			bucket = "gs://" + bucket
+		}
+		opts.Storage = bucket + "/" + path.Join(dc.GCSConfiguration.PathPrefix, "caches")
+	}
+	if len(refs) > 0 {
+		opts.WorkDir = DetermineWorkDir(codeMountPath, refs)
+	}
+	return opts
+}
+
+// CacheMountAndVolume returns the canonical volume and mount used to share the cache of the job.
+func CacheMountAndVolume() (coreapi.VolumeMount, coreapi.Volume) {
+	return coreapi.VolumeMount{
+		Name:      cacheMountName,
+		MountPath: cacheMountPath,
+	}, coreapi.Volume{
+		Name: cacheMountName,
+		VolumeSource: coreapi.VolumeSource{
+			EmptyDir: &coreapi.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, cloneLogMount *coreapi.VolumeMount, outputMount *coreapi.VolumeMount, cacheOptions *cache.Options, encodedJobSpec string) (*coreapi.Container, error) {
 	// TODO(fejta): remove encodedJobSpec
 	initUploadOptions := initupload.Options{
 		Options: &gcsOptions,
+		Cache:   cacheOptions,
 	}
 	var mounts []coreapi.VolumeMount
 	if cloneLogMount != nil {
@@ -644,6 +746,15 @@ func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options,
 	if outputMount != nil {
 		mounts = append(mounts, *outputMount)
 	}
+	if cacheOptions != nil {
+		cacheMount, _ := CacheMountAndVolume()
+		mounts = append(mounts, cacheMount)
+		if cacheOptions.WorkDir != "" {
+			// The key of the cache can depend on files of the cloned refs.
+			codeMount, _ := CodeMountAndVolume()
+			mounts = append(mounts, codeMount)
+		}
+	}
 	// TODO(fejta): use flags
 	initUploadConfigEnv, err := initupload.Encode(initUploadOptions)
 	if err != nil {
@@ -744,8 +855,10 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 		cloneLogMount = &logMount
 	}
 
+	cacheOptions := CacheOptions(*pj.Spec.DecorationConfig, refs, localMode)
+
 	encodedJobSpec := rawEnv[downwardapi.JobSpecEnv]
-	initUpload, err := InitUpload(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, cloneLogMount, outputMount, encodedJobSpec)
+	initUpload, err := InitUpload(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, cloneLogMount, outputMount, cacheOptions, encodedJobSpec)
 	if err != nil {
 		return fmt.Errorf("create initupload container: %w", err)
 	}
@@ -779,12 +892,16 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 	var secretVolumeMounts []coreapi.VolumeMount
 	var wrappers []wrapper.Options
 
+	steps := pj.Spec.DecorationConfig.Steps
+	if len(steps) > 0 && len(spec.Containers) > 1 {
+		return fmt.Errorf("steps can only be run by jobs with a single test container")
+	}
 	for i, container := range spec.Containers {
 		prefix := container.Name
 		if len(spec.Containers) == 1 {
 			prefix = ""
 		}
-		wrapperOptions, err := InjectEntrypoint(&spec.Containers[i], pj.Spec.DecorationConfig.Timeout.Get(), pj.Spec.DecorationConfig.GracePeriod.Get(), prefix, previous, propagateErrorCode, exitZero, logMount, toolsMount)
+		wrapperOptions, err := InjectEntrypoint(&spec.Containers[i], pj.Spec.DecorationConfig.Timeout.Get(), pj.Spec.DecorationConfig.GracePeriod.Get(), prefix, previous, propagateErrorCode, exitZero, steps, pj.Spec.DecorationConfig.ResourceUsage, logMount, toolsMount)
 		if err != nil {
 			return fmt.Errorf("wrap container: %w", err)
 		}
@@ -798,7 +915,7 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 
 	ignoreInterrupts := pj.Spec.DecorationConfig.UploadIgnoresInterrupts != nil && *pj.Spec.DecorationConfig.UploadIgnoresInterrupts
 
-	sidecar, err := Sidecar(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, logMount, outputMount, encodedJobSpec, !RequirePassingEntries, ignoreInterrupts, secretVolumeMounts, wrappers...)
+	sidecar, err := Sidecar(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, logMount, outputMount, cacheOptions, encodedJobSpec, !RequirePassingEntries, ignoreInterrupts, secretVolumeMounts, wrappers...)
 	if err != nil {
 		return fmt.Errorf("create sidecar: %w", err)
 	}
@@ -808,6 +925,19 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 	if outputVolume != nil {
 		spec.Volumes = append(spec.Volumes, *outputVolume)
 	}
+	if pj.Spec.DecorationConfig.Cache != nil {
+		_, cacheVolume := CacheMountAndVolume()
+		for i := range spec.Containers {
+			for _, p := range pj.Spec.DecorationConfig.Cache.Paths {
+				spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, coreapi.VolumeMount{
+					Name:      cacheMountName,
+					MountPath: p,
+					SubPath:   cache.SubPath(p),
+				})
+			}
+		}
+		spec.Volumes = append(spec.Volumes, cacheVolume)
+	}
 
 	if len(refs) > 0 {
 		for i, container := range spec.Containers {
@@ -876,7 +1006,7 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 		// a reasonable value, as the overall grace period for the Pod must encompass both the time taken
 		// to gracefully terminate the test process *and* the time taken to process and upload the resulting
 		// artifacts to the cloud. As a reasonable rule of thumb, assume a 80/20 split between these tasks.
-		gracePeriodSeconds := int64(pj.Spec.DecorationConfig.GracePeriod.Seconds()) * 5 / 4
+		gracePeriodSeconds := int64(terminationGracePeriod(pj.Spec.DecorationConfig.GracePeriod.Get(), steps).Seconds()) * 5 / 4
 		spec.TerminationGracePeriodSeconds = &gracePeriodSeconds
 	}
 
@@ -898,12 +1028,32 @@ func DetermineWorkDir(baseDir string, refs []prowapi.Refs) string {
 	return clone.PathForRefs(baseDir, refs[0])
 }
 
+// terminationGracePeriod returns how long the entrypoint may take to finish
+// once it is interrupted: the grace period of the running command, plus the
+// time the steps that always run may take afterwards. Those are bound by
+// their timeout or, without one, the grace period, and may need another
+// grace period to terminate when they exceed it.
+func terminationGracePeriod(gracePeriod time.Duration, steps []prowapi.EntrypointStep) time.Duration {
+	total := gracePeriod
+	for _, step := range steps {
+		if !step.AlwaysRun {
+			continue
+		}
+		stepTimeout := step.Timeout.Get()
+		if stepTimeout == 0 {
+			stepTimeout = gracePeriod
+		}
+		total += stepTimeout + gracePeriod
+	}
+	return total
+}
+
 const (
 	// RequirePassingEntries causes sidecar to return an error if any entry fails. Otherwise it exits cleanly so long as it can complete.
 	RequirePassingEntries = true
 )
 
-func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, logMount coreapi.VolumeMount, outputMount *coreapi.VolumeMount, encodedJobSpec string, requirePassingEntries, ignoreInterrupts bool, secretVolumeMounts []coreapi.VolumeMount, wrappers ...wrapper.Options) (*coreapi.Container, error) {
+func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, logMount coreapi.VolumeMount, outputMount *coreapi.VolumeMount, cacheOptions *cache.Options, encodedJobSpec string, requirePassingEntries, ignoreInterrupts bool, secretVolumeMounts []coreapi.VolumeMount, wrappers ...wrapper.Options) (*coreapi.Container, error) {
 	var secretVolumePaths []string
 	for _, volumeMount := range secretVolumeMounts {
 		secretVolumePaths = append(secretVolumePaths, volumeMount.MountPath)
@@ -919,11 +1069,13 @@ func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blo
 		censoringOptions.ExcludeDirectories = config.CensoringOptions.ExcludeDirectories
 	}
 	sidecarConfigEnv, err := sidecar.Encode(sidecar.Options{
-		GcsOptions:       &gcsOptions,
-		Entries:          wrappers,
-		EntryError:       requirePassingEntries,
-		IgnoreInterrupts: ignoreInterrupts,
-		CensoringOptions: censoringOptions,
+		GcsOptions:        &gcsOptions,
+		Entries:           wrappers,
+		EntryError:        requirePassingEntries,
+		IgnoreInterrupts:  ignoreInterrupts,
+		CensoringOptions:  censoringOptions,
+		Cache:             cacheOptions,
+		LogUploadInterval: config.LogUploadInterval.Get(),
 	})
 
 	if err != nil {
@@ -935,6 +1087,10 @@ func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blo
 	if outputMount != nil {
 		mounts = append(mounts, *outputMount)
 	}
+	if cacheOptions != nil {
+		cacheMount, _ := CacheMountAndVolume()
+		mounts = append(mounts, cacheMount)
+	}
 
 	container := &coreapi.Container{
 		Name:  sidecarName,
@@ -1423,7 +1423,7 @@ func TestSidecar(t *testing.T) {
 			container, err := Sidecar(
 				testCase.config, testCase.gcsOptions,
 				testCase.blobStorageMounts, testCase.logMount, testCase.outputMount,
-				testCase.encodedJobSpec,
+				nil, testCase.encodedJobSpec,
 				testCase.requirePassingEntries, testCase.ignoreInterrupts,
 				testCase.secretVolumeMounts, testCase.wrappers...,
 			)
@@ -1709,6 +1709,125 @@ func TestDecorate(t *testing.T) {
 			},
 			rawEnv: map[string]string{"custom": "env"},
 		},
+		{
+			name: "cache",
+			spec: &coreapi.PodSpec{
+				Containers: []coreapi.Container{
+					{Name: "test", Command: []string{"/bin/ls"}, Args: []string{"-l", "-a"}},
+				},
+				ServiceAccountName: "tester",
+			},
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Hour},
+						UtilityImages: &prowapi.UtilityImages{
+							CloneRefs:  "cloneimage",
+							InitUpload: "initimage",
+							Entrypoint: "entrypointimage",
+							Sidecar:    "sidecarimage",
+						},
+						GCSConfiguration: &prowapi.GCSConfiguration{
+							Bucket:       "bucket",
+							PathStrategy: "single",
+							DefaultOrg:   "org",
+							DefaultRepo:  "repo",
+						},
+						GCSCredentialsSecret: &gCSCredentialsSecret,
+						Cache: &prowapi.CacheConfiguration{
+							KeyTemplate: `{{.Job}}-{{hashFiles "go.sum"}}`,
+							Paths:       []string{"/root/go/pkg/mod", "/root/.cache/go-build"},
+						},
+					},
+					Refs: &prowapi.Refs{
+						Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcd1234",
+						Pulls: []prowapi.Pull{{Number: 1, SHA: "aksdjhfkds"}},
+					},
+				},
+			},
+			rawEnv: map[string]string{"custom": "env"},
+		},
+		{
+			name: "steps",
+			spec: &coreapi.PodSpec{
+				Containers: []coreapi.Container{
+					{Name: "test", Image: "tester"},
+				},
+				ServiceAccountName: "tester",
+			},
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Hour},
+						UtilityImages: &prowapi.UtilityImages{
+							CloneRefs:  "cloneimage",
+							InitUpload: "initimage",
+							Entrypoint: "entrypointimage",
+							Sidecar:    "sidecarimage",
+						},
+						GCSConfiguration: &prowapi.GCSConfiguration{
+							Bucket:       "bucket",
+							PathStrategy: "single",
+							DefaultOrg:   "org",
+							DefaultRepo:  "repo",
+						},
+						GCSCredentialsSecret: &gCSCredentialsSecret,
+						Steps: []prowapi.EntrypointStep{
+							{Name: "setup", Command: []string{"make", "setup"}},
+							{Name: "test", Command: []string{"make", "test"}, Timeout: &prowapi.Duration{Duration: 30 * time.Second}},
+							{Name: "teardown", Command: []string{"make", "teardown"}, AlwaysRun: true},
+						},
+					},
+					Refs: &prowapi.Refs{
+						Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcd1234",
+						Pulls: []prowapi.Pull{{Number: 1, SHA: "aksdjhfkds"}},
+					},
+				},
+			},
+			rawEnv: map[string]string{"custom": "env"},
+		},
+		{
+			name: "resource usage",
+			spec: &coreapi.PodSpec{
+				Containers: []coreapi.Container{
+					{Name: "test", Image: "tester", Command: []string{"/bin/thing"}, Args: []string{"some", "args"}},
+					{Name: "gpu", Image: "tester", Command: []string{"/bin/train"}},
+				},
+				ServiceAccountName: "tester",
+			},
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Hour},
+						UtilityImages: &prowapi.UtilityImages{
+							CloneRefs:  "cloneimage",
+							InitUpload: "initimage",
+							Entrypoint: "entrypointimage",
+							Sidecar:    "sidecarimage",
+						},
+						GCSConfiguration: &prowapi.GCSConfiguration{
+							Bucket:       "bucket",
+							PathStrategy: "single",
+							DefaultOrg:   "org",
+							DefaultRepo:  "repo",
+						},
+						GCSCredentialsSecret: &gCSCredentialsSecret,
+						ResourceUsage: &prowapi.ResourceUsageConfig{
+							Interval: &prowapi.Duration{Duration: 5 * time.Second},
+							GPU:      true,
+						},
+					},
+					Refs: &prowapi.Refs{
+						Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcd1234",
+						Pulls: []prowapi.Pull{{Number: 1, SHA: "aksdjhfkds"}},
+					},
+				},
+			},
+			rawEnv: map[string]string{"custom": "env"},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -53,7 +53,7 @@ const retryCount = 4
 // Upload uploads all the data in the uploadTargets map to blob storage in parallel.
 // The map is keyed on blob storage path under the bucket.
 // Files with an extension in the compressFileTypes list will be compressed prior to uploading
-func Upload(ctx context.Context, bucket, gcsCredentialsFile, s3CredentialsFile string, compressFileTypes []string, uploadTargets map[string]UploadFunc) error {
+func Upload(ctx context.Context, bucket, gcsCredentialsFile, s3CredentialsFile, azureCredentialsFile string, compressFileTypes []string, uploadTargets map[string]UploadFunc) error {
 	parsedBucket, err := url.Parse(bucket)
 	if err != nil {
 		return fmt.Errorf("cannot parse bucket name %s: %w", bucket, err)
@@ -62,7 +62,7 @@ func Upload(ctx context.Context, bucket, gcsCredentialsFile, s3CredentialsFile s
 		parsedBucket.Scheme = providers.GS
 	}
 
-	opener, err := pkgio.NewOpener(ctx, gcsCredentialsFile, s3CredentialsFile)
+	opener, err := pkgio.NewOpener(ctx, gcsCredentialsFile, s3CredentialsFile, azureCredentialsFile)
 	if err != nil {
 		return fmt.Errorf("new opener: %w", err)
 	}
@@ -84,7 +84,7 @@ func shouldCompressFileType(dest string, compressFileTypes sets.Set[string]) boo
 // LocalExport copies all of the data in the uploadTargets map to local files in parallel. The map
 // is keyed on file path under the exportDir.
 func LocalExport(ctx context.Context, exportDir string, uploadTargets map[string]UploadFunc) error {
-	opener, err := pkgio.NewOpener(ctx, "", "")
+	opener, err := pkgio.NewOpener(ctx, "", "", "")
 	if err != nil {
 		return fmt.Errorf("new opener: %w", err)
 	}
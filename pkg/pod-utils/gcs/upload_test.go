@@ -153,7 +153,7 @@ func TestUploadNewReaderFunc(t *testing.T) {
 			readerFunc, readerFuncMeta := newReaderFunc(testCase.readerFuncOpts)
 			uploadTargets[path.Base(f.Name())] = DataUpload(readerFunc)
 			bucket := fmt.Sprintf("%s://%s", providers.File, path.Dir(f.Name()))
-			err = Upload(context.TODO(), bucket, "", "", testCase.compressFileTypes, uploadTargets)
+			err = Upload(context.TODO(), bucket, "", "", "", testCase.compressFileTypes, uploadTargets)
 			if testCase.isErrExpected && err == nil {
 				t.Errorf("error expected but got nil")
 			}
@@ -280,7 +280,7 @@ func TestUploadWithRetries(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			err := Upload(ctx, "", "", "", []string{}, uploadFuncs)
+			err := Upload(ctx, "", "", "", "", []string{}, uploadFuncs)
 
 			isErrExpected := false
 			for _, currentTestState := range currentTestStates {
@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+)
+
+const (
+	// MaxFailures is the maximum number of failed tests listed in a Summary.
+	// The counts always cover all tests.
+	MaxFailures = 100
+	// MaxMessageLength is the maximum length of a failure message.
+	MaxMessageLength = 1000
+)
+
+var (
+	// JUnitPattern matches the junit artifacts that are summarized, the
+	// same convention the junit lens uses.
+	JUnitPattern = regexp.MustCompile(`^junit.*\.xml$`)
+	// GoTestPattern matches artifacts holding the output of `go test -json`.
+	GoTestPattern = regexp.MustCompile(`^go-test.*\.json$`)
+)
+
+// Summary is the content of results.json.
+type Summary struct {
+	// Total is the number of test cases found.
+	Total int `json:"total"`
+	// Passed is the number of test cases that passed.
+	Passed int `json:"passed"`
+	// Failed is the number of test cases that failed or errored.
+	Failed int `json:"failed"`
+	// Skipped is the number of test cases that were skipped.
+	Skipped int `json:"skipped"`
+	// DurationSeconds is the sum of the durations of all test cases.
+	DurationSeconds float64 `json:"duration_seconds"`
+	// Failures lists up to MaxFailures failed test cases.
+	Failures []TestCase `json:"failures,omitempty"`
+	// FailuresTruncated is true if more test cases failed than are listed.
+	FailuresTruncated bool `json:"failures_truncated,omitempty"`
+	// Sources are the artifacts, relative to the artifact directory, the
+	// summary was assembled from.
+	Sources []string `json:"sources"`
+	// Errors are the artifacts that could not be parsed, mapped to the error.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// TestCase identifies a single test.
+type TestCase struct {
+	// Name of the test.
+	Name string `json:"name"`
+	// Suite is the junit classname or the go package of the test.
+	Suite string `json:"suite,omitempty"`
+	// DurationSeconds is how long the test ran.
+	DurationSeconds float64 `json:"duration_seconds"`
+	// Message is the truncated failure message, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// Empty returns true if no test results were found.
+func (s *Summary) Empty() bool {
+	return s.Total == 0 && len(s.Errors) == 0
+}
+
+func (s *Summary) record(tc TestCase, passed, skipped bool) {
+	s.Total++
+	s.DurationSeconds += tc.DurationSeconds
+	switch {
+	case skipped:
+		s.Skipped++
+	case passed:
+		s.Passed++
+	default:
+		s.Failed++
+		if len(s.Failures) < MaxFailures {
+			s.Failures = append(s.Failures, tc)
+		} else {
+			s.FailuresTruncated = true
+		}
+	}
+}
+
+// Summarize walks the artifact directories and assembles a Summary from
+// every junit and go test artifact it finds. Paths that are not directories
+// are ignored.
+func Summarize(dirs ...string) (*Summary, error) {
+	summary := &Summary{Sources: []string{}}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			var parse func([]byte, *Summary) error
+			switch name := d.Name(); {
+			case JUnitPattern.MatchString(name):
+				parse = parseJUnit
+			case GoTestPattern.MatchString(name):
+				parse = parseGoTest
+			default:
+				return nil
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				rel = path
+			}
+			raw, err := os.ReadFile(path)
+			if err == nil {
+				err = parse(raw, summary)
+			}
+			if err != nil {
+				if summary.Errors == nil {
+					summary.Errors = map[string]string{}
+				}
+				summary.Errors[rel] = err.Error()
+				return nil
+			}
+			summary.Sources = append(summary.Sources, rel)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", dir, err)
+		}
+	}
+	sort.Strings(summary.Sources)
+	return summary, nil
+}
+
+func parseJUnit(raw []byte, summary *Summary) error {
+	suites, err := junit.Parse(raw)
+	if err != nil {
+		return err
+	}
+	var record func(suite junit.Suite)
+	record = func(suite junit.Suite) {
+		for _, child := range suite.Suites {
+			record(child)
+		}
+		for _, result := range suite.Results {
+			failed := result.Failure != nil || result.Errored != nil
+			tc := TestCase{
+				Name:            result.Name,
+				Suite:           result.ClassName,
+				DurationSeconds: result.Time,
+			}
+			if failed {
+				tc.Message = result.Message(MaxMessageLength)
+			}
+			summary.record(tc, !failed, !failed && result.Skipped != nil)
+		}
+	}
+	for _, suite := range suites.Suites {
+		record(suite)
+	}
+	return nil
+}
+
+// goTestEvent is a line of `go test -json` output, see `go doc test2json`.
+type goTestEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+func parseGoTest(raw []byte, summary *Summary) error {
+	output := map[string]*bytes.Buffer{}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var event goTestEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return fmt.Errorf("invalid go test event: %w", err)
+		}
+		if event.Test == "" {
+			continue
+		}
+		key := event.Package + "." + event.Test
+		switch event.Action {
+		case "output":
+			if output[key] == nil {
+				output[key] = &bytes.Buffer{}
+			}
+			if output[key].Len() < MaxMessageLength {
+				output[key].WriteString(event.Output)
+			}
+		case "pass", "fail", "skip":
+			tc := TestCase{
+				Name:            event.Test,
+				Suite:           event.Package,
+				DurationSeconds: event.Elapsed,
+			}
+			if event.Action == "fail" && output[key] != nil {
+				tc.Message = output[key].String()
+				if len(tc.Message) > MaxMessageLength {
+					tc.Message = strings.ToValidUTF8(tc.Message[:MaxMessageLength], "")
+				}
+			}
+			delete(output, key)
+			summary.record(tc, event.Action == "pass", event.Action == "skip")
+		}
+	}
+	return scanner.Err()
+}
+
+// Parse decodes the content of a results.json artifact.
+func Parse(raw []byte) (*Summary, error) {
+	var summary Summary
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal results: %w", err)
+	}
+	return &summary, nil
+}
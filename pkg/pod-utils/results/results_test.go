@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		expected *Summary
+	}{
+		{
+			name:     "no artifacts",
+			expected: &Summary{Sources: []string{}},
+		},
+		{
+			name: "junit with nested suites",
+			files: map[string]string{
+				"junit_01.xml": `<testsuites>
+  <testsuite name="outer">
+    <testsuite name="inner">
+      <testcase name="nested" classname="pkg" time="1"/>
+    </testsuite>
+    <testcase name="passed" classname="pkg" time="2"/>
+    <testcase name="failed" classname="pkg" time="3"><failure message="boom">stack</failure></testcase>
+    <testcase name="errored" classname="pkg" time="0.5"><error message="oops"/></testcase>
+    <testcase name="skipped" classname="pkg"><skipped/></testcase>
+  </testsuite>
+</testsuites>`,
+				"build-log.txt": "not a test result",
+			},
+			expected: &Summary{
+				Total:           5,
+				Passed:          2,
+				Failed:          2,
+				Skipped:         1,
+				DurationSeconds: 6.5,
+				Failures: []TestCase{
+					{Name: "failed", Suite: "pkg", DurationSeconds: 3, Message: "boom\nstack"},
+					{Name: "errored", Suite: "pkg", DurationSeconds: 0.5, Message: "oops"},
+				},
+				Sources: []string{"junit_01.xml"},
+			},
+		},
+		{
+			name: "go test json in a subdirectory",
+			files: map[string]string{
+				"unit/go-test.json": `{"Action":"run","Package":"example.com/a","Test":"TestA"}
+{"Action":"output","Package":"example.com/a","Test":"TestA","Output":"a_test.go:10: wrong\n"}
+{"Action":"fail","Package":"example.com/a","Test":"TestA","Elapsed":1.5}
+{"Action":"pass","Package":"example.com/a","Test":"TestB","Elapsed":0.5}
+{"Action":"skip","Package":"example.com/a","Test":"TestC"}
+{"Action":"fail","Package":"example.com/a","Elapsed":2}
+`,
+			},
+			expected: &Summary{
+				Total:           3,
+				Passed:          1,
+				Failed:          1,
+				Skipped:         1,
+				DurationSeconds: 2,
+				Failures: []TestCase{
+					{Name: "TestA", Suite: "example.com/a", DurationSeconds: 1.5, Message: "a_test.go:10: wrong\n"},
+				},
+				Sources: []string{"unit/go-test.json"},
+			},
+		},
+		{
+			name: "unparseable artifacts are reported",
+			files: map[string]string{
+				"junit_bad.xml":    `<nottestsuite/>`,
+				"go-test-bad.json": `not json`,
+			},
+			expected: &Summary{
+				Sources: []string{},
+				Errors: map[string]string{
+					"junit_bad.xml":    `bad element name: {"" "nottestsuite"}`,
+					"go-test-bad.json": "invalid go test event: invalid character 'o' in literal null (expecting 'u')",
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+			actual, err := Summarize(dir, filepath.Join(dir, "missing"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected summary (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummarizeTruncatesFailures(t *testing.T) {
+	var cases strings.Builder
+	for i := 0; i < MaxFailures+5; i++ {
+		fmt.Fprintf(&cases, `<testcase name="test-%d"><failure message="%s"/></testcase>`, i, strings.Repeat("x", 2*MaxMessageLength))
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "junit.xml"), []byte("<testsuite>"+cases.String()+"</testsuite>"), 0644); err != nil {
+		t.Fatalf("failed to write junit: %v", err)
+	}
+
+	summary, err := Summarize(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Failed != MaxFailures+5 {
+		t.Errorf("expected %d failures to be counted, got %d", MaxFailures+5, summary.Failed)
+	}
+	if len(summary.Failures) != MaxFailures || !summary.FailuresTruncated {
+		t.Errorf("expected %d listed failures and truncation, got %d (truncated: %t)", MaxFailures, len(summary.Failures), summary.FailuresTruncated)
+	}
+	if length := len(summary.Failures[0].Message); length > MaxMessageLength+len("...") {
+		t.Errorf("expected messages to be truncated, got length %d", length)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// MaxErrorSnippetLength is the maximum length of the error snippet of a
+// TerminationMessage, which keeps the message well below the 4096 bytes
+// kubernetes retains of termination messages.
+const MaxErrorSnippetLength = 1024
+
+// TerminationMessage is the termination message sidecar writes for its
+// container, so that the ProwJob controller can record why the job failed
+// in the status of the ProwJob.
+type TerminationMessage struct {
+	FailureReason prowapi.FailureReason `json:"failure_reason,omitempty"`
+	TestSummary   *prowapi.TestSummary  `json:"test_summary,omitempty"`
+	ErrorSnippet  string                `json:"error_snippet,omitempty"`
+}
+
+// Empty returns true if the message holds no information.
+func (m *TerminationMessage) Empty() bool {
+	return m.FailureReason == "" && m.TestSummary == nil && m.ErrorSnippet == ""
+}
+
+// NewTerminationMessage assembles the termination message of a job from the
+// summary of its test results, which may be nil, and whether any of its
+// commands failed. failedLog is the tail of the log of the first command
+// that failed, it is used as error snippet if no test failed.
+func NewTerminationMessage(summary *Summary, commandFailed bool, failedLog string) TerminationMessage {
+	var message TerminationMessage
+	if summary != nil && summary.Total > 0 {
+		message.TestSummary = &prowapi.TestSummary{
+			Total:   summary.Total,
+			Passed:  summary.Passed,
+			Failed:  summary.Failed,
+			Skipped: summary.Skipped,
+		}
+	}
+	switch {
+	case summary != nil && summary.Failed > 0:
+		message.FailureReason = prowapi.TestsFailedReason
+		if len(summary.Failures) > 0 {
+			failure := summary.Failures[0]
+			snippet := failure.Name
+			if failure.Message != "" {
+				snippet += ": " + failure.Message
+			}
+			message.ErrorSnippet = truncateSnippet(snippet, false)
+		}
+	case commandFailed:
+		message.FailureReason = prowapi.CommandFailedReason
+		message.ErrorSnippet = truncateSnippet(strings.TrimSpace(failedLog), true)
+	}
+	return message
+}
+
+// truncateSnippet shortens snippet to MaxErrorSnippetLength, keeping its
+// end instead of its beginning if tail is set.
+func truncateSnippet(snippet string, tail bool) string {
+	if len(snippet) <= MaxErrorSnippetLength {
+		return snippet
+	}
+	if tail {
+		return strings.ToValidUTF8("..."+snippet[len(snippet)-MaxErrorSnippetLength+3:], "")
+	}
+	return strings.ToValidUTF8(snippet[:MaxErrorSnippetLength-3]+"...", "")
+}
+
+// ParseTerminationMessage decodes a termination message written by sidecar.
+// It fails for any other content, e.g. the tail of the logs kubernetes uses
+// as termination message if the container failed without writing one.
+func ParseTerminationMessage(raw string) (*TerminationMessage, error) {
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	var message TerminationMessage
+	if err := decoder.Decode(&message); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal termination message: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("termination message has trailing content")
+	}
+	return &message, nil
+}
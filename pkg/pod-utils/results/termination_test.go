@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestNewTerminationMessage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		summary       *Summary
+		commandFailed bool
+		failedLog     string
+		expected      TerminationMessage
+	}{
+		{
+			name: "passed without tests",
+		},
+		{
+			name:    "passed with tests",
+			summary: &Summary{Total: 3, Passed: 2, Skipped: 1},
+			expected: TerminationMessage{
+				TestSummary: &prowapi.TestSummary{Total: 3, Passed: 2, Skipped: 1},
+			},
+		},
+		{
+			name: "failed tests",
+			summary: &Summary{Total: 2, Passed: 1, Failed: 1, Failures: []TestCase{
+				{Name: "TestBad", Message: "expected 1, got 2"},
+			}},
+			commandFailed: true,
+			failedLog:     "FAIL",
+			expected: TerminationMessage{
+				FailureReason: prowapi.TestsFailedReason,
+				TestSummary:   &prowapi.TestSummary{Total: 2, Passed: 1, Failed: 1},
+				ErrorSnippet:  "TestBad: expected 1, got 2",
+			},
+		},
+		{
+			name:          "failed command",
+			summary:       &Summary{Total: 1, Passed: 1},
+			commandFailed: true,
+			failedLog:     "compiling\nmain.go:1: syntax error\n",
+			expected: TerminationMessage{
+				FailureReason: prowapi.CommandFailedReason,
+				TestSummary:   &prowapi.TestSummary{Total: 1, Passed: 1},
+				ErrorSnippet:  "compiling\nmain.go:1: syntax error",
+			},
+		},
+		{
+			name:          "long log is truncated at the beginning",
+			commandFailed: true,
+			failedLog:     strings.Repeat("a", MaxErrorSnippetLength) + "error",
+			expected: TerminationMessage{
+				FailureReason: prowapi.CommandFailedReason,
+				ErrorSnippet:  "..." + strings.Repeat("a", MaxErrorSnippetLength-8) + "error",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewTerminationMessage(tc.summary, tc.commandFailed, tc.failedLog)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected termination message (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseTerminationMessage(t *testing.T) {
+	testCases := []struct {
+		name        string
+		raw         string
+		expected    *TerminationMessage
+		expectError bool
+	}{
+		{
+			name: "termination message",
+			raw:  `{"failure_reason":"tests_failed","test_summary":{"total":1,"passed":0,"failed":1,"skipped":0},"error_snippet":"TestBad"}`,
+			expected: &TerminationMessage{
+				FailureReason: prowapi.TestsFailedReason,
+				TestSummary:   &prowapi.TestSummary{Total: 1, Failed: 1},
+				ErrorSnippet:  "TestBad",
+			},
+		},
+		{
+			name:        "plain logs",
+			raw:         "could not upload artifacts",
+			expectError: true,
+		},
+		{
+			name:        "json logs",
+			raw:         `{"level":"error","msg":"could not upload artifacts"}`,
+			expectError: true,
+		},
+		{
+			name:        "several json objects",
+			raw:         "{}\n{}",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseTerminationMessage(tc.raw)
+			if err != nil && !tc.expectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && tc.expectError {
+				t.Fatal("expected error, got none")
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected termination message (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultCgroupRoot is where the cgroup filesystem of the container is mounted.
+	DefaultCgroupRoot = "/sys/fs/cgroup"
+	// DefaultInterval is how often usage is sampled if no interval is configured.
+	DefaultInterval = 10 * time.Second
+	// MaxSamples is the maximum number of samples kept per container. Once
+	// reached, every other sample is dropped so long jobs keep a timeline of
+	// their whole run at a coarser resolution.
+	MaxSamples = 2000
+
+	// unlimitedMemory is the cgroup v1 memory limit of unlimited cgroups,
+	// rounded down to the page size.
+	unlimitedMemory = 1 << 62
+	mebibyte        = 1 << 20
+)
+
+// Report is the content of usage.json.
+type Report struct {
+	Containers []Usage `json:"containers"`
+}
+
+// Usage is the resource usage of a test container over the run of the job.
+type Usage struct {
+	// Container is the name of the test container.
+	Container string `json:"container,omitempty"`
+	// CPULimitCores is the cpu limit of the container, zero if it has none.
+	CPULimitCores float64 `json:"cpu_limit_cores,omitempty"`
+	// MemoryLimitBytes is the memory limit of the container, zero if it has none.
+	MemoryLimitBytes int64 `json:"memory_limit_bytes,omitempty"`
+	// Samples are ordered by time.
+	Samples []Sample `json:"samples"`
+}
+
+// Sample is the usage of a container at one point in time.
+type Sample struct {
+	Time time.Time `json:"time"`
+	// CPUCores is the average number of cores used since the previous sample.
+	CPUCores float64 `json:"cpu_cores"`
+	// MemoryBytes is the working set of the container, the memory the
+	// kubelet compares to its limit.
+	MemoryBytes int64 `json:"memory_bytes"`
+	// GPUs is the usage of each GPU visible to the container.
+	GPUs []GPUSample `json:"gpus,omitempty"`
+}
+
+// GPUSample is the usage of a GPU at one point in time.
+type GPUSample struct {
+	UtilizationPercent float64 `json:"utilization_percent"`
+	MemoryBytes        int64   `json:"memory_bytes"`
+}
+
+// Sampler samples the usage of a cgroup. Both cgroup v1 and v2 are
+// supported.
+type Sampler struct {
+	root string
+	gpu  bool
+	now  func() time.Time
+
+	usage    Usage
+	lastCPU  time.Duration
+	lastTime time.Time
+	// queryGPUs returns the usage of the GPUs, nvidia-smi by default.
+	queryGPUs func() ([]GPUSample, error)
+}
+
+// NewSampler returns a sampler of the cgroup mounted at root. GPU usage is
+// sampled as well if gpu is set.
+func NewSampler(root string, gpu bool) *Sampler {
+	return &Sampler{
+		root:      root,
+		gpu:       gpu,
+		now:       time.Now,
+		queryGPUs: nvidiaSMI,
+	}
+}
+
+// Run samples the usage every interval until the context is cancelled and
+// returns the samples. Failing to sample is logged, not fatal, as usage is
+// only informational.
+func (s *Sampler) Run(ctx context.Context, interval time.Duration) Usage {
+	s.usage.CPULimitCores, s.usage.MemoryLimitBytes = s.limits()
+	if err := s.Sample(); err != nil {
+		logrus.WithError(err).Warn("Failed to sample resource usage, not sampling it.")
+		return s.usage
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return s.usage
+		case <-ticker.C:
+		}
+		if err := s.Sample(); err != nil {
+			logrus.WithError(err).Debug("Failed to sample resource usage.")
+		}
+	}
+}
+
+// Sample records the current usage. The first sample only records the cpu
+// time the later ones are relative to.
+func (s *Sampler) Sample() error {
+	cpu, err := s.cpuUsage()
+	if err != nil {
+		return fmt.Errorf("cpu usage: %w", err)
+	}
+	memory, err := s.memoryUsage()
+	if err != nil {
+		return fmt.Errorf("memory usage: %w", err)
+	}
+	now := s.now()
+	first := s.lastTime.IsZero()
+	elapsed := now.Sub(s.lastTime)
+	delta := cpu - s.lastCPU
+	s.lastCPU, s.lastTime = cpu, now
+	if first || elapsed <= 0 {
+		return nil
+	}
+	sample := Sample{
+		Time:        now,
+		CPUCores:    delta.Seconds() / elapsed.Seconds(),
+		MemoryBytes: memory,
+	}
+	if s.gpu {
+		if sample.GPUs, err = s.queryGPUs(); err != nil {
+			logrus.WithError(err).Debug("Failed to sample GPU usage.")
+		}
+	}
+	s.usage.Samples = append(s.usage.Samples, sample)
+	if len(s.usage.Samples) > MaxSamples {
+		s.usage.Samples = thin(s.usage.Samples)
+	}
+	return nil
+}
+
+// thin drops every other sample, keeping the latest one.
+func thin(samples []Sample) []Sample {
+	var kept []Sample
+	for i := (len(samples) - 1) % 2; i < len(samples); i += 2 {
+		kept = append(kept, samples[i])
+	}
+	return kept
+}
+
+func (s *Sampler) v2() bool {
+	_, err := os.Stat(filepath.Join(s.root, "cgroup.controllers"))
+	return err == nil
+}
+
+func (s *Sampler) cpuUsage() (time.Duration, error) {
+	if s.v2() {
+		usec, err := readStat(filepath.Join(s.root, "cpu.stat"), "usage_usec")
+		return time.Duration(usec) * time.Microsecond, err
+	}
+	ns, err := readInt(filepath.Join(s.root, "cpuacct", "cpuacct.usage"))
+	return time.Duration(ns), err
+}
+
+// memoryUsage returns the working set, the usage without the inactive file
+// cache that the kernel reclaims before running out of memory.
+func (s *Sampler) memoryUsage() (int64, error) {
+	var usage, inactive int64
+	var err error
+	if s.v2() {
+		if usage, err = readInt(filepath.Join(s.root, "memory.current")); err != nil {
+			return 0, err
+		}
+		inactive, err = readStat(filepath.Join(s.root, "memory.stat"), "inactive_file")
+	} else {
+		if usage, err = readInt(filepath.Join(s.root, "memory", "memory.usage_in_bytes")); err != nil {
+			return 0, err
+		}
+		inactive, err = readStat(filepath.Join(s.root, "memory", "memory.stat"), "total_inactive_file")
+	}
+	if err != nil || inactive > usage {
+		return usage, nil
+	}
+	return usage - inactive, nil
+}
+
+// limits returns the cpu and memory limit of the cgroup, zero if unlimited
+// or unknown.
+func (s *Sampler) limits() (float64, int64) {
+	var cores float64
+	var memory int64
+	if s.v2() {
+		if raw, err := os.ReadFile(filepath.Join(s.root, "cpu.max")); err == nil {
+			if fields := strings.Fields(string(raw)); len(fields) == 2 {
+				quota, quotaErr := strconv.ParseFloat(fields[0], 64)
+				period, periodErr := strconv.ParseFloat(fields[1], 64)
+				if quotaErr == nil && periodErr == nil && period > 0 {
+					cores = quota / period
+				}
+			}
+		}
+		memory, _ = readInt(filepath.Join(s.root, "memory.max"))
+		return cores, memory
+	}
+	quota, quotaErr := readInt(filepath.Join(s.root, "cpu", "cpu.cfs_quota_us"))
+	period, periodErr := readInt(filepath.Join(s.root, "cpu", "cpu.cfs_period_us"))
+	if quotaErr == nil && periodErr == nil && quota > 0 && period > 0 {
+		cores = float64(quota) / float64(period)
+	}
+	if memory, _ = readInt(filepath.Join(s.root, "memory", "memory.limit_in_bytes")); memory >= unlimitedMemory {
+		memory = 0
+	}
+	return cores, memory
+}
+
+// readInt reads a file holding a single integer. An error is returned if
+// the file holds "max" instead.
+func readInt(path string) (int64, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+}
+
+// readStat reads the value of a key from a flat keyed cgroup file such as
+// cpu.stat or memory.stat.
+func readStat(path, key string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == key {
+			return strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("%s not found in %s", key, path)
+}
+
+func nvidiaSMI() ([]GPUSample, error) {
+	out, err := exec.Command("nvidia-smi", "--query-gpu=utilization.gpu,memory.used", "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseNvidiaSMI(out)
+}
+
+// parseNvidiaSMI parses the utilization in percent and the used memory in
+// MiB of each GPU, one GPU per line.
+func parseNvidiaSMI(out []byte) ([]GPUSample, error) {
+	var gpus []GPUSample
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected nvidia-smi output %q", line)
+		}
+		utilization, err := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse utilization of %q: %w", line, err)
+		}
+		memory, err := strconv.ParseInt(strings.TrimSpace(fields[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse memory of %q: %w", line, err)
+		}
+		gpus = append(gpus, GPUSample{UtilizationPercent: utilization, MemoryBytes: memory * mebibyte})
+	}
+	return gpus, nil
+}
+
+// Write writes the usage to the given path.
+func Write(path string, usage Usage) error {
+	raw, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0644)
+}
+
+// Read reads the usage written to the given path. It returns nil if the
+// path does not exist, as usage is not sampled if the container did not
+// run.
+func Read(path string) (*Usage, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var usage Usage
+	if err := json.Unmarshal(raw, &usage); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// Parse parses the content of usage.json.
+func Parse(raw []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(raw, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
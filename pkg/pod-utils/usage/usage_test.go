@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestSampler(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		before, after map[string]string
+		expectedUsage Usage
+	}{
+		{
+			name: "cgroup v2",
+			before: map[string]string{
+				"cgroup.controllers": "cpu memory",
+				"cpu.stat":           "usage_usec 1000000\nuser_usec 800000\n",
+				"cpu.max":            "200000 100000\n",
+				"memory.current":     "1000\n",
+				"memory.stat":        "anon 600\ninactive_file 100\n",
+				"memory.max":         "4096\n",
+			},
+			after: map[string]string{
+				"cpu.stat":       "usage_usec 16000000\nuser_usec 12000000\n",
+				"memory.current": "3000\n",
+				"memory.stat":    "anon 2000\ninactive_file 500\n",
+			},
+			expectedUsage: Usage{
+				CPULimitCores:    2,
+				MemoryLimitBytes: 4096,
+				Samples:          []Sample{{Time: start.Add(10 * time.Second), CPUCores: 1.5, MemoryBytes: 2500}},
+			},
+		},
+		{
+			name: "cgroup v2 without limits",
+			before: map[string]string{
+				"cgroup.controllers": "cpu memory",
+				"cpu.stat":           "usage_usec 0\n",
+				"cpu.max":            "max 100000\n",
+				"memory.current":     "1000\n",
+				"memory.stat":        "inactive_file 0\n",
+				"memory.max":         "max\n",
+			},
+			after: map[string]string{
+				"cpu.stat": "usage_usec 5000000\n",
+			},
+			expectedUsage: Usage{
+				Samples: []Sample{{Time: start.Add(10 * time.Second), CPUCores: 0.5, MemoryBytes: 1000}},
+			},
+		},
+		{
+			name: "cgroup v1",
+			before: map[string]string{
+				"cpuacct/cpuacct.usage":        "0\n",
+				"cpu/cpu.cfs_quota_us":         "50000\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+				"memory/memory.usage_in_bytes": "2048\n",
+				"memory/memory.stat":           "cache 1024\ntotal_inactive_file 1024\n",
+				"memory/memory.limit_in_bytes": "9223372036854771712\n",
+			},
+			after: map[string]string{
+				"cpuacct/cpuacct.usage": "2500000000\n",
+			},
+			expectedUsage: Usage{
+				CPULimitCores: 0.5,
+				Samples:       []Sample{{Time: start.Add(10 * time.Second), CPUCores: 0.25, MemoryBytes: 1024}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tc.before)
+			now := start
+			s := NewSampler(root, false)
+			s.now = func() time.Time { return now }
+			s.usage.CPULimitCores, s.usage.MemoryLimitBytes = s.limits()
+			if err := s.Sample(); err != nil {
+				t.Fatalf("failed to take first sample: %v", err)
+			}
+			writeFiles(t, root, tc.after)
+			now = now.Add(10 * time.Second)
+			if err := s.Sample(); err != nil {
+				t.Fatalf("failed to take second sample: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedUsage, s.usage); diff != "" {
+				t.Errorf("usage differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSamplerGPU(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"cgroup.controllers": "cpu memory",
+		"cpu.stat":           "usage_usec 0\n",
+		"memory.current":     "0\n",
+	})
+	now := time.Now()
+	s := NewSampler(root, true)
+	s.now = func() time.Time { return now }
+	s.queryGPUs = func() ([]GPUSample, error) {
+		return parseNvidiaSMI([]byte("87, 1024\n3, 0\n"))
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.Sample(); err != nil {
+			t.Fatalf("failed to sample: %v", err)
+		}
+		now = now.Add(time.Second)
+	}
+	expected := []GPUSample{{UtilizationPercent: 87, MemoryBytes: 1024 * mebibyte}, {UtilizationPercent: 3}}
+	if len(s.usage.Samples) != 1 {
+		t.Fatalf("expected one sample, got %d", len(s.usage.Samples))
+	}
+	if diff := cmp.Diff(expected, s.usage.Samples[0].GPUs); diff != "" {
+		t.Errorf("GPU usage differs from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestThin(t *testing.T) {
+	var samples []Sample
+	for i := 0; i < 5; i++ {
+		samples = append(samples, Sample{MemoryBytes: int64(i)})
+	}
+	var kept []int64
+	for _, sample := range thin(samples) {
+		kept = append(kept, sample.MemoryBytes)
+	}
+	if diff := cmp.Diff([]int64{0, 2, 4}, kept); diff != "" {
+		t.Errorf("kept samples differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	if usage, err := Read(path); err != nil || usage != nil {
+		t.Fatalf("expected no usage and no error for missing file, got %v and %v", usage, err)
+	}
+	usage := Usage{CPULimitCores: 1, Samples: []Sample{{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), CPUCores: 0.5, MemoryBytes: 10}}}
+	if err := Write(path, usage); err != nil {
+		t.Fatalf("failed to write usage: %v", err)
+	}
+	read, err := Read(path)
+	if err != nil {
+		t.Fatalf("failed to read usage: %v", err)
+	}
+	if diff := cmp.Diff(&usage, read); diff != "" {
+		t.Errorf("read usage differs from written usage (-want +got):\n%s", diff)
+	}
+}
@@ -57,6 +57,17 @@ type Options struct {
 	// Prow will parse the file and merge it into
 	// the `metadata` field in finished.json
 	MetadataFile string `json:"metadata_file"`
+
+	// StepsFile will be written with the results of the
+	// steps run by the entrypoint, if it runs any.
+	// Prow will merge it into the `metadata` field in
+	// finished.json
+	StepsFile string `json:"steps_file,omitempty"`
+
+	// UsageFile will be written with the resource usage of
+	// the container sampled while the test process runs, if
+	// usage is sampled. Sidecar uploads it in usage.json.
+	UsageFile string `json:"usage_file,omitempty"`
 }
 
 type MarkerResult struct {
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// natsClientInterface interfaces with the NATS client for testing reason
+type natsClientInterface interface {
+	new(ctx context.Context, trigger config.NATSTrigger) (natsClientInterface, error)
+	subscription(subject, queueGroup string, maxOutstandingMessages int) subscriptionInterface
+}
+
+// natsClient is used to interface with a new NATS connection
+type natsClient struct {
+	conn *nats.Conn
+	url  string
+}
+
+// New connects to the NATS server. The connection is drained once the
+// context is cancelled.
+func (c *natsClient) new(ctx context.Context, trigger config.NATSTrigger) (natsClientInterface, error) {
+	opts := []nats.Option{nats.Name("prow-sub")}
+	if trigger.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(trigger.CredentialsFile))
+	}
+	if trigger.TokenFile != "" {
+		// Read the token on every (re)connect, so that it can be rotated.
+		tokenFile := trigger.TokenFile
+		opts = append(opts, nats.TokenHandler(func() string {
+			token, err := os.ReadFile(tokenFile)
+			if err != nil {
+				logrus.WithError(err).WithField("token-file", tokenFile).Error("Failed to read NATS token.")
+			}
+			return strings.TrimSpace(string(token))
+		}))
+	}
+	conn, err := nats.Connect(trigger.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		if err := conn.Drain(); err != nil {
+			logrus.WithError(err).Warn("Failed to drain NATS connection.")
+		}
+	}()
+	return &natsClient{conn: conn, url: trigger.URL}, nil
+}
+
+// Subscription creates a reference to a subject in a queue group via the NATS connection.
+func (c *natsClient) subscription(subject, queueGroup string, maxOutstandingMessages int) subscriptionInterface {
+	return &natsSubscription{
+		conn:                   c.conn,
+		url:                    c.url,
+		subject:                subject,
+		queueGroup:             queueGroup,
+		maxOutstandingMessages: maxOutstandingMessages,
+	}
+}
+
+type natsSubscription struct {
+	conn                   *nats.Conn
+	url                    string
+	subject                string
+	queueGroup             string
+	maxOutstandingMessages int
+}
+
+func (s *natsSubscription) string() string {
+	return fmt.Sprintf("%s/%s", s.url, s.subject)
+}
+
+// receive handles up to maxOutstandingMessages messages of the subject at a
+// time until the context is cancelled.
+func (s *natsSubscription) receive(ctx context.Context, f func(context.Context, messageInterface)) error {
+	sub, err := s.conn.QueueSubscribeSync(s.subject, s.queueGroup)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	outstanding := make(chan struct{}, s.maxOutstandingMessages)
+	for {
+		msg, err := sub.NextMsgWithContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		outstanding <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-outstanding
+				wg.Done()
+			}()
+			f(ctx, newNATSMessage(msg))
+		}()
+	}
+}
+
+type natsMessage struct {
+	msg        *nats.Msg
+	attributes map[string]string
+}
+
+func newNATSMessage(msg *nats.Msg) *natsMessage {
+	m := &natsMessage{msg: msg, attributes: map[string]string{}}
+	for name := range msg.Header {
+		m.attributes[name] = msg.Header.Get(name)
+	}
+	if eventType, ok := m.attributes[ProwEventTypeAttribute]; ok {
+		m.attributes[ProwEventType] = eventType
+	}
+	return m
+}
+
+func (m *natsMessage) getAttributes() map[string]string {
+	return m.attributes
+}
+
+func (m *natsMessage) getPayload() []byte {
+	return m.msg.Data
+}
+
+// getID returns the ID that publishers may set for deduplication.
+func (m *natsMessage) getID() string {
+	return m.msg.Header.Get(nats.MsgIdHdr)
+}
+
+// Core NATS delivers messages at most once, so there is nothing to
+// acknowledge.
+func (m *natsMessage) ack()  {}
+func (m *natsMessage) nack() {}
@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestNewNATSMessage(t *testing.T) {
+	msg := newNATSMessage(&nats.Msg{
+		Subject: "prow.jobs",
+		Data:    []byte(`{"name":"job"}`),
+		Header: nats.Header{
+			ProwEventTypeAttribute: []string{PostsubmitProwJobEvent},
+			nats.MsgIdHdr:          []string{"nats-id"},
+		},
+	})
+	if msg.getID() != "nats-id" {
+		t.Errorf("expected ID nats-id, got %q", msg.getID())
+	}
+	if string(msg.getPayload()) != `{"name":"job"}` {
+		t.Errorf("unexpected payload %q", msg.getPayload())
+	}
+	if eventType := msg.getAttributes()[ProwEventType]; eventType != PostsubmitProwJobEvent {
+		t.Errorf("expected event type %s, got %q", PostsubmitProwJobEvent, eventType)
+	}
+}
@@ -33,12 +33,17 @@ import (
 type configToWatch struct {
 	config.PubSubTriggers
 	config.PubsubSubscriptions
+	config.SQSTriggers
+	config.NATSTriggers
 }
 
-// PullServer listen to Pull Pub/Sub subscriptions and handle them.
+// PullServer listen to Pull Pub/Sub subscriptions, SQS queues and NATS
+// subjects and handle them.
 type PullServer struct {
 	Subscriber *Subscriber
 	Client     pubsubClientInterface
+	SQSClient  sqsClientInterface
+	NATSClient natsClientInterface
 }
 
 // NewPullServer creates a new PullServer
@@ -46,6 +51,8 @@ func NewPullServer(s *Subscriber) *PullServer {
 	return &PullServer{
 		Subscriber: s,
 		Client:     &pubSubClient{},
+		SQSClient:  &sqsClient{},
+		NATSClient: &natsClient{},
 	}
 }
 
@@ -106,11 +113,11 @@ func (c *pubSubClient) subscription(id string, maxOutstandingMessages int) subsc
 	}
 }
 
-// handlePulls pull for Pub/Sub subscriptions and handle them.
-func (s *PullServer) handlePulls(ctx context.Context, projectSubscriptions config.PubSubTriggers) (*errgroup.Group, context.Context, error) {
+// handlePulls pull for Pub/Sub subscriptions, SQS queues and NATS subjects and handle them.
+func (s *PullServer) handlePulls(ctx context.Context, triggers configToWatch) (*errgroup.Group, context.Context, error) {
 	// Since config might change we need be able to cancel the current run
 	errGroup, derivedCtx := errgroup.WithContext(ctx)
-	for _, topics := range projectSubscriptions {
+	for _, topics := range triggers.PubSubTriggers {
 		project, subscriptions, allowedClusters := topics.Project, topics.Topics, topics.AllowedClusters
 		client, err := s.Client.new(ctx, project)
 		if err != nil {
@@ -122,36 +129,71 @@ func (s *PullServer) handlePulls(ctx context.Context, projectSubscriptions confi
 				"subscription": sub.string(),
 				"project":      project,
 			})
-			errGroup.Go(func() error {
-				logger.Info("Listening for subscription")
-				defer logger.Warn("Stopped Listening for subscription")
-				err := sub.receive(derivedCtx, func(ctx context.Context, msg messageInterface) {
-					if err = s.Subscriber.handleMessage(msg, sub.string(), allowedClusters); err != nil {
-						s.Subscriber.Metrics.ACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
-					} else {
-						s.Subscriber.Metrics.NACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
-					}
-					msg.ack()
-				})
-				if err != nil {
-					if errors.Is(derivedCtx.Err(), context.Canceled) {
-						logger.WithError(err).Debug("Exiting as context cancelled")
-						return nil
-					}
-					if strings.Contains(err.Error(), "code = PermissionDenied") {
-						logger.WithError(err).Warn("Seems like missing permission.")
-						return nil
-					}
-					logger.WithError(err).Error("Failed to listen for subscription")
-					return err
-				}
-				return nil
+			s.listen(derivedCtx, errGroup, sub, logger, allowedClusters)
+		}
+	}
+	for _, trigger := range triggers.SQSTriggers {
+		client, err := s.SQSClient.new(ctx, trigger)
+		if err != nil {
+			return errGroup, derivedCtx, err
+		}
+		for _, queueURL := range trigger.Queues {
+			sub := client.subscription(queueURL, trigger.MaxOutstandingMessages)
+			logger := logrus.WithFields(logrus.Fields{
+				"subscription": sub.string(),
+				"region":       trigger.Region,
 			})
+			s.listen(derivedCtx, errGroup, sub, logger, trigger.AllowedClusters)
+		}
+	}
+	for _, trigger := range triggers.NATSTriggers {
+		// The connection is closed once the current run is cancelled.
+		client, err := s.NATSClient.new(derivedCtx, trigger)
+		if err != nil {
+			return errGroup, derivedCtx, err
+		}
+		for _, subject := range trigger.Subjects {
+			sub := client.subscription(subject, trigger.QueueGroup, trigger.MaxOutstandingMessages)
+			logger := logrus.WithFields(logrus.Fields{
+				"subscription": sub.string(),
+				"queue-group":  trigger.QueueGroup,
+			})
+			s.listen(derivedCtx, errGroup, sub, logger, trigger.AllowedClusters)
 		}
 	}
 	return errGroup, derivedCtx, nil
 }
 
+// listen handles the messages of the subscription in the errGroup until the
+// context is cancelled.
+func (s *PullServer) listen(derivedCtx context.Context, errGroup *errgroup.Group, sub subscriptionInterface, logger *logrus.Entry, allowedClusters []string) {
+	errGroup.Go(func() error {
+		logger.Info("Listening for subscription")
+		defer logger.Warn("Stopped Listening for subscription")
+		err := sub.receive(derivedCtx, func(ctx context.Context, msg messageInterface) {
+			if err := s.Subscriber.handleMessage(msg, sub.string(), allowedClusters); err != nil {
+				s.Subscriber.Metrics.ACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
+			} else {
+				s.Subscriber.Metrics.NACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
+			}
+			msg.ack()
+		})
+		if err != nil {
+			if errors.Is(derivedCtx.Err(), context.Canceled) {
+				logger.WithError(err).Debug("Exiting as context cancelled")
+				return nil
+			}
+			if strings.Contains(err.Error(), "code = PermissionDenied") {
+				logger.WithError(err).Warn("Seems like missing permission.")
+				return nil
+			}
+			logger.WithError(err).Error("Failed to listen for subscription")
+			return err
+		}
+		return nil
+	})
+}
+
 // Run will block listening to all subscriptions and return once the context is cancelled
 // or one of the subscription has a unrecoverable error.
 func (s *PullServer) Run(ctx context.Context) error {
@@ -168,8 +210,10 @@ func (s *PullServer) Run(ctx context.Context) error {
 	currentConfig := configToWatch{
 		s.Subscriber.ConfigAgent.Config().PubSubTriggers,
 		s.Subscriber.ConfigAgent.Config().PubSubSubscriptions,
+		s.Subscriber.ConfigAgent.Config().SQSTriggers,
+		s.Subscriber.ConfigAgent.Config().NATSTriggers,
 	}
-	errGroup, derivedCtx, err := s.handlePulls(ctx, currentConfig.PubSubTriggers)
+	errGroup, derivedCtx, err := s.handlePulls(ctx, currentConfig)
 	if err != nil {
 		return err
 	}
@@ -188,6 +232,8 @@ func (s *PullServer) Run(ctx context.Context) error {
 			newConfig := configToWatch{
 				event.After.PubSubTriggers,
 				event.After.PubSubSubscriptions,
+				event.After.SQSTriggers,
+				event.After.NATSTriggers,
 			}
 			logrus.Info("Received new config")
 			if !reflect.DeepEqual(currentConfig, newConfig) {
@@ -195,7 +241,7 @@ func (s *PullServer) Run(ctx context.Context) error {
 				// Making sure the current thread finishes before starting a new one.
 				errGroup.Wait()
 				// Starting a new thread with new config
-				errGroup, derivedCtx, err = s.handlePulls(ctx, newConfig.PubSubTriggers)
+				errGroup, derivedCtx, err = s.handlePulls(ctx, newConfig)
 				if err != nil {
 					return err
 				}
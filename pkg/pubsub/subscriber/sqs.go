@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+const (
+	// sqsMaxMessages is the maximum number of messages SQS returns at once.
+	sqsMaxMessages = 10
+	// sqsWaitTimeSeconds is how long SQS waits for messages to arrive before
+	// returning none, which is the maximum that long polling allows.
+	sqsWaitTimeSeconds = 20
+)
+
+// sqsClientInterface interfaces with the AWS SQS client for testing reason
+type sqsClientInterface interface {
+	new(ctx context.Context, trigger config.SQSTrigger) (sqsClientInterface, error)
+	subscription(queueURL string, maxOutstandingMessages int) subscriptionInterface
+}
+
+// sqsAPI are the SQS operations needed to receive messages.
+type sqsAPI interface {
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error)
+}
+
+// sqsClient is used to interface with a new AWS SQS Client
+type sqsClient struct {
+	client sqsAPI
+}
+
+// New creates new AWS SQS Client
+func (c *sqsClient) new(ctx context.Context, trigger config.SQSTrigger) (sqsClientInterface, error) {
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(trigger.Region)}
+	if trigger.CredentialsFile != "" {
+		opts = append(opts, awsconfig.WithSharedCredentialsFiles([]string{trigger.CredentialsFile}))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqsClient{client: sqs.NewFromConfig(cfg)}, nil
+}
+
+// Subscription creates a reference to an existing queue via the AWS SQS Client.
+func (c *sqsClient) subscription(queueURL string, maxOutstandingMessages int) subscriptionInterface {
+	return &sqsSubscription{
+		client:                 c.client,
+		queueURL:               queueURL,
+		maxOutstandingMessages: maxOutstandingMessages,
+	}
+}
+
+type sqsSubscription struct {
+	client                 sqsAPI
+	queueURL               string
+	maxOutstandingMessages int
+}
+
+func (s *sqsSubscription) string() string {
+	return s.queueURL
+}
+
+// receive long polls the queue and handles the messages of each poll
+// concurrently until the context is cancelled.
+func (s *sqsSubscription) receive(ctx context.Context, f func(context.Context, messageInterface)) error {
+	maxMessages := min(s.maxOutstandingMessages, sqsMaxMessages)
+	for {
+		out, err := s.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(s.queueURL),
+			MaxNumberOfMessages:   int32(maxMessages),
+			WaitTimeSeconds:       sqsWaitTimeSeconds,
+			MessageAttributeNames: []string{"All"},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		var wg sync.WaitGroup
+		for _, msg := range out.Messages {
+			wg.Add(1)
+			go func(msg types.Message) {
+				defer wg.Done()
+				f(ctx, newSQSMessage(s.client, s.queueURL, msg))
+			}(msg)
+		}
+		wg.Wait()
+	}
+}
+
+type sqsMessage struct {
+	client        sqsAPI
+	queueURL      string
+	receiptHandle *string
+	id            string
+	attributes    map[string]string
+	payload       []byte
+}
+
+// snsNotification is the envelope of a message that SNS delivers to a
+// subscribed queue without raw message delivery.
+type snsNotification struct {
+	Type              string `json:"Type"`
+	MessageID         string `json:"MessageId"`
+	Message           string `json:"Message"`
+	MessageAttributes map[string]struct {
+		Type  string `json:"Type"`
+		Value string `json:"Value"`
+	} `json:"MessageAttributes"`
+}
+
+func newSQSMessage(client sqsAPI, queueURL string, msg types.Message) *sqsMessage {
+	m := &sqsMessage{
+		client:        client,
+		queueURL:      queueURL,
+		receiptHandle: msg.ReceiptHandle,
+		id:            aws.ToString(msg.MessageId),
+		attributes:    map[string]string{},
+		payload:       []byte(aws.ToString(msg.Body)),
+	}
+	for name, value := range msg.MessageAttributes {
+		if value.StringValue != nil {
+			m.attributes[name] = *value.StringValue
+		}
+	}
+
+	var notification snsNotification
+	if err := json.Unmarshal(m.payload, &notification); err == nil && notification.Type == "Notification" {
+		m.id = notification.MessageID
+		m.payload = []byte(notification.Message)
+		for name, value := range notification.MessageAttributes {
+			m.attributes[name] = value.Value
+		}
+	}
+
+	if eventType, ok := m.attributes[ProwEventTypeAttribute]; ok {
+		m.attributes[ProwEventType] = eventType
+	}
+	return m
+}
+
+func (m *sqsMessage) getAttributes() map[string]string {
+	return m.attributes
+}
+
+func (m *sqsMessage) getPayload() []byte {
+	return m.payload
+}
+
+func (m *sqsMessage) getID() string {
+	return m.id
+}
+
+// ack deletes the message from the queue.
+func (m *sqsMessage) ack() {
+	if _, err := m.client.DeleteMessage(context.Background(), &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(m.queueURL),
+		ReceiptHandle: m.receiptHandle,
+	}); err != nil {
+		logrus.WithError(err).WithField("sqs-id", m.id).Warn("Failed to delete message from queue.")
+	}
+}
+
+// nack makes the message visible in the queue again right away, so that it
+// is redelivered.
+func (m *sqsMessage) nack() {
+	if _, err := m.client.ChangeMessageVisibility(context.Background(), &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(m.queueURL),
+		ReceiptHandle:     m.receiptHandle,
+		VisibilityTimeout: 0,
+	}); err != nil {
+		logrus.WithError(err).WithField("sqs-id", m.id).Warn("Failed to return message to queue.")
+	}
+}
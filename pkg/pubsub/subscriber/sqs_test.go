@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeSQS struct {
+	lock     sync.Mutex
+	messages []types.Message
+	deleted  []string
+	returned []string
+}
+
+func (f *fakeSQS) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if len(f.messages) == 0 {
+		// Wait like a long poll on an empty queue.
+		f.lock.Unlock()
+		<-ctx.Done()
+		f.lock.Lock()
+		return nil, ctx.Err()
+	}
+	n := min(int(params.MaxNumberOfMessages), len(f.messages))
+	out := &sqs.ReceiveMessageOutput{Messages: f.messages[:n]}
+	f.messages = f.messages[n:]
+	return out, nil
+}
+
+func (f *fakeSQS) DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.deleted = append(f.deleted, aws.ToString(params.ReceiptHandle))
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func (f *fakeSQS) ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.returned = append(f.returned, aws.ToString(params.ReceiptHandle))
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+func TestNewSQSMessage(t *testing.T) {
+	testCases := []struct {
+		name               string
+		msg                types.Message
+		expectedID         string
+		expectedPayload    string
+		expectedAttributes map[string]string
+	}{
+		{
+			name: "message sent to the queue",
+			msg: types.Message{
+				MessageId: aws.String("sqs-id"),
+				Body:      aws.String(`{"name":"job"}`),
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					ProwEventTypeAttribute: {DataType: aws.String("String"), StringValue: aws.String(PeriodicProwJobEvent)},
+				},
+			},
+			expectedID:      "sqs-id",
+			expectedPayload: `{"name":"job"}`,
+			expectedAttributes: map[string]string{
+				ProwEventTypeAttribute: PeriodicProwJobEvent,
+				ProwEventType:          PeriodicProwJobEvent,
+			},
+		},
+		{
+			name: "notification delivered by SNS",
+			msg: types.Message{
+				MessageId: aws.String("sqs-id"),
+				Body: aws.String(`{
+  "Type": "Notification",
+  "MessageId": "sns-id",
+  "TopicArn": "arn:aws:sns:us-east-1:123456789012:prow",
+  "Message": "{\"name\":\"job\"}",
+  "MessageAttributes": {"Prow-Event-Type": {"Type": "String", "Value": "prow.k8s.io/pubsub.PresubmitProwJobEvent"}}
+}`),
+			},
+			expectedID:      "sns-id",
+			expectedPayload: `{"name":"job"}`,
+			expectedAttributes: map[string]string{
+				ProwEventTypeAttribute: PresubmitProwJobEvent,
+				ProwEventType:          PresubmitProwJobEvent,
+			},
+		},
+		{
+			name: "message without event type",
+			msg: types.Message{
+				MessageId: aws.String("sqs-id"),
+				Body:      aws.String(`{"name":"job"}`),
+			},
+			expectedID:         "sqs-id",
+			expectedPayload:    `{"name":"job"}`,
+			expectedAttributes: map[string]string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := newSQSMessage(&fakeSQS{}, "queue", tc.msg)
+			if msg.getID() != tc.expectedID {
+				t.Errorf("expected ID %q, got %q", tc.expectedID, msg.getID())
+			}
+			if string(msg.getPayload()) != tc.expectedPayload {
+				t.Errorf("expected payload %q, got %q", tc.expectedPayload, msg.getPayload())
+			}
+			if diff := cmp.Diff(tc.expectedAttributes, msg.getAttributes()); diff != "" {
+				t.Errorf("unexpected attributes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSQSSubscriptionReceive(t *testing.T) {
+	client := &fakeSQS{messages: []types.Message{
+		{MessageId: aws.String("1"), ReceiptHandle: aws.String("handle-1"), Body: aws.String("{}")},
+		{MessageId: aws.String("2"), ReceiptHandle: aws.String("handle-2"), Body: aws.String("{}")},
+		{MessageId: aws.String("3"), ReceiptHandle: aws.String("handle-3"), Body: aws.String("{}")},
+	}}
+	sub := (&sqsClient{client: client}).subscription("queue", 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var lock sync.Mutex
+	var received []string
+	err := sub.receive(ctx, func(ctx context.Context, msg messageInterface) {
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, msg.getID())
+		if msg.getID() == "2" {
+			msg.nack()
+		} else {
+			msg.ack()
+		}
+		if len(received) == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("expected receive to stop with the context, got %v", err)
+	}
+	if len(received) != 3 {
+		t.Errorf("expected 3 messages to be received, got %v", received)
+	}
+	if diff := cmp.Diff([]string{"handle-2"}, client.returned); diff != "" {
+		t.Errorf("unexpected messages returned to the queue (-want +got):\n%s", diff)
+	}
+	if len(client.deleted) != 2 {
+		t.Errorf("expected 2 messages to be deleted, got %v", client.deleted)
+	}
+}
@@ -38,6 +38,10 @@ const (
 	PeriodicProwJobEvent   = "prow.k8s.io/pubsub.PeriodicProwJobEvent"
 	PresubmitProwJobEvent  = "prow.k8s.io/pubsub.PresubmitProwJobEvent"
 	PostsubmitProwJobEvent = "prow.k8s.io/pubsub.PostsubmitProwJobEvent"
+
+	// ProwEventTypeAttribute holds the event type in messages from SQS, SNS
+	// and NATS, whose attribute and header names cannot contain slashes.
+	ProwEventTypeAttribute = "Prow-Event-Type"
 )
 
 // ProwJobEvent contains the minimum information required to start a ProwJob.
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repoowners
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+)
+
+// codeOwnersLocations are the paths GitHub looks up the CODEOWNERS file of a
+// repository at, in order of precedence.
+var codeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+func isCodeOwnersFile(path string) bool {
+	for _, location := range codeOwnersLocations {
+		if path == location {
+			return true
+		}
+	}
+	return false
+}
+
+// codeOwnersRule is a rule of a CODEOWNERS file translated to the directory it
+// applies to and a regexp of the paths relative to that directory, like the
+// filters of an OWNERS file. A nil regexp applies to the whole directory.
+type codeOwnersRule struct {
+	dir    string
+	re     *regexp.Regexp
+	owners []string
+}
+
+// parseCodeOwners parses the content of a CODEOWNERS file. Rules that cannot be
+// translated are skipped and reported in the returned error.
+func parseCodeOwners(b []byte) ([]codeOwnersRule, error) {
+	var rules []codeOwnersRule
+	var errs []error
+	for i, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var owners []string
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+		dir, re, err := codeOwnersPatternToRegexp(fields[0])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", i+1, err))
+			continue
+		}
+		rules = append(rules, codeOwnersRule{dir: dir, re: re, owners: owners})
+	}
+	return rules, utilerrors.NewAggregate(errs)
+}
+
+// codeOwnersPatternToRegexp translates a CODEOWNERS pattern to the directory
+// it applies to and a regexp of the paths relative to that directory.
+// Patterns follow the rules of gitignore files, except for negation and
+// character ranges, which GitHub does not support either.
+func codeOwnersPatternToRegexp(pattern string) (string, *regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]\\") {
+		return "", nil, fmt.Errorf("unsupported pattern %q", pattern)
+	}
+	// Patterns with a separator at the beginning or in the middle are
+	// relative to the root of the repository, others match at any depth.
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" || pattern == "*" || pattern == "**" {
+		return baseDirConvention, nil, nil
+	}
+
+	parts := strings.Split(pattern, "/")
+	var dir []string
+	if anchored {
+		for len(parts) > 0 && !strings.ContainsAny(parts[0], "*?") {
+			dir = append(dir, parts[0])
+			parts = parts[1:]
+		}
+	}
+	if len(parts) == 0 {
+		return strings.Join(dir, "/"), nil, nil
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("(^|/)")
+	}
+	glob := strings.Join(parts, "/")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	// A pattern ending in a name may match a directory and thereby
+	// everything below it, while a pattern ending in a wildcard only matches
+	// the entries of the directory it is in.
+	if strings.ContainsAny(parts[len(parts)-1], "*?") {
+		expr.WriteString("$")
+	} else {
+		expr.WriteString("(/.*)?$")
+	}
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return strings.Join(dir, "/"), re, nil
+}
+
+// loadCodeOwners adds the owners of the CODEOWNERS file of the repository, if
+// any, as approvers and reviewers to those of the OWNERS files. Teams are
+// only added if an alias of the same name, e.g. "org/team", exists, and
+// email addresses are ignored.
+func (o *RepoOwners) loadCodeOwners() {
+	var path string
+	for _, location := range codeOwnersLocations {
+		if _, err := os.Stat(filepath.Join(o.baseDir, location)); err == nil {
+			path = filepath.Join(o.baseDir, location)
+			break
+		}
+	}
+	if path == "" {
+		return
+	}
+	log := o.log.WithField("path", path)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.WithError(err).Warn("Failed to read CODEOWNERS file.")
+		return
+	}
+	rules, err := parseCodeOwners(b)
+	if err != nil {
+		log.WithError(err).Warn("Skipped invalid rules of CODEOWNERS file.")
+	}
+
+rules:
+	for _, rule := range rules {
+		for _, re := range o.dirDenylist {
+			if re.MatchString(rule.dir) {
+				continue rules
+			}
+		}
+		logins := sets.New[string]()
+		for _, owner := range rule.owners {
+			if !strings.HasPrefix(owner, "@") {
+				continue
+			}
+			login := github.NormLogin(owner)
+			if strings.Contains(login, "/") && o.ExpandAlias(login) == nil {
+				log.WithField("team", login).Debug("Ignoring CODEOWNERS team without an alias.")
+				continue
+			}
+			logins.Insert(login)
+		}
+		if logins.Len() == 0 {
+			continue
+		}
+		logins = o.ExpandAliases(logins)
+		for _, people := range []map[string]map[*regexp.Regexp]sets.Set[string]{o.approvers, o.reviewers} {
+			if people[rule.dir] == nil {
+				people[rule.dir] = make(map[*regexp.Regexp]sets.Set[string])
+			}
+			people[rule.dir][rule.re] = people[rule.dir][rule.re].Union(logins)
+		}
+	}
+}
+
+// GenerateCodeOwners generates a CODEOWNERS file from the OWNERS files of the
+// repository checked out at baseDir, so that GitHub requests reviews from the
+// same people as the approve and blunderbuss plugins. Every directory is owned
+// by its approvers, including those inherited from parent directories, as
+// CODEOWNERS rules do not inherit owners. Approvers of regexp filters of OWNERS
+// files are left out as filters cannot be translated to patterns.
+func GenerateCodeOwners(baseDir string, filenames ownersconfig.Filenames, log *logrus.Entry) ([]byte, error) {
+	aliases := loadAliasesFrom(baseDir, filenames.OwnersAliases, log)
+	o, err := loadOwnersFrom(baseDir, false, false, aliases, nil, filenames, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OWNERS files: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Generated from %s files, do not edit.\n", filenames.Owners)
+	for _, path := range sets.List(sets.KeySet(o.approvers)) {
+		if _, ok := o.approvers[path][nil]; !ok {
+			continue
+		}
+		pattern := "*"
+		if path != baseDirConvention {
+			pattern = "/" + path + "/"
+		}
+		var owners []string
+		for _, login := range sets.List(o.Approvers(path).Set()) {
+			owners = append(owners, "@"+login)
+		}
+		fmt.Fprintf(&buf, "%s %s\n", pattern, strings.Join(owners, " "))
+	}
+	return buf.Bytes(), nil
+}
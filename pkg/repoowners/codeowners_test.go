@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repoowners
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/git/localgit"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+)
+
+func TestCodeOwnersPatternToRegexp(t *testing.T) {
+	testCases := []struct {
+		pattern     string
+		expectedDir string
+		expectedRe  string
+		matches     []string
+		mismatches  []string
+		expectedErr bool
+	}{
+		{
+			pattern: "*",
+		},
+		{
+			pattern:     "/docs/",
+			expectedDir: "docs",
+		},
+		{
+			pattern:     "/build/logs",
+			expectedDir: "build/logs",
+		},
+		{
+			pattern:    "*.js",
+			expectedRe: `(^|/)[^/]*\.js$`,
+			matches:    []string{"a.js", "src/a.js"},
+			mismatches: []string{"a.jsx", "a.js/b"},
+		},
+		{
+			pattern:    "apps/",
+			expectedRe: `(^|/)apps(/.*)?$`,
+			matches:    []string{"apps", "apps/a.go", "src/apps/a/b.go"},
+			mismatches: []string{"myapps/a.go"},
+		},
+		{
+			pattern:     "/docs/*",
+			expectedDir: "docs",
+			expectedRe:  `^[^/]*$`,
+			matches:     []string{"a.md"},
+			mismatches:  []string{"build/a.md"},
+		},
+		{
+			pattern:     "/src/**/test?",
+			expectedDir: "src",
+			expectedRe:  `^(.*/)?test[^/]$`,
+			matches:     []string{"tests", "a/b/testa"},
+			mismatches:  []string{"test", "a/tests/b"},
+		},
+		{
+			pattern:    "**/logs",
+			expectedRe: `^(.*/)?logs(/.*)?$`,
+			matches:    []string{"logs", "build/logs", "build/logs/a.log"},
+		},
+		{
+			pattern:     "!a.js",
+			expectedErr: true,
+		},
+		{
+			pattern:     "*.[ch]",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			dir, re, err := codeOwnersPatternToRegexp(tc.pattern)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if dir != tc.expectedDir {
+				t.Errorf("expected directory %q, got %q", tc.expectedDir, dir)
+			}
+			var expr string
+			if re != nil {
+				expr = re.String()
+			}
+			if expr != tc.expectedRe {
+				t.Fatalf("expected regexp %q, got %q", tc.expectedRe, expr)
+			}
+			for _, path := range tc.matches {
+				if !re.MatchString(path) {
+					t.Errorf("expected %q to match %s", path, expr)
+				}
+			}
+			for _, path := range tc.mismatches {
+				if re.MatchString(path) {
+					t.Errorf("expected %q not to match %s", path, expr)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCodeOwners(t *testing.T) {
+	rules, err := parseCodeOwners([]byte(`# Comment
+*       @global-owner # inline comment
+
+/docs/  @Doc-Owner docs@example.com
+!a.js   @nobody
+`))
+	if err == nil || err.Error() != `line 5: unsupported pattern "!a.js"` {
+		t.Errorf("expected the negated pattern to be reported, got %v", err)
+	}
+	expected := []codeOwnersRule{
+		{dir: "", owners: []string{"@global-owner"}},
+		{dir: "docs", owners: []string{"@Doc-Owner", "docs@example.com"}},
+	}
+	if diff := cmp.Diff(expected, rules, cmp.AllowUnexported(codeOwnersRule{})); diff != "" {
+		t.Errorf("unexpected rules (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadRepoOwnersCodeOwners(t *testing.T) {
+	files := map[string][]byte{
+		"OWNERS": []byte(`approvers:
+- cjwagner`),
+		"src/OWNERS": []byte(`approvers:
+- alice`),
+		".github/CODEOWNERS": []byte(`* @maggie
+/src/ @bob @org/best-approvers
+*.md @mml docs@example.com @org/unknown-team
+`),
+		// GitHub only reads the CODEOWNERS file of the .github directory.
+		"CODEOWNERS":     []byte(`* @ignored`),
+		"OWNERS_ALIASES": []byte("aliases:\n  org/best-approvers:\n  - carl"),
+	}
+
+	for _, enabled := range []bool{true, false} {
+		client, cleanup, err := getTestClient(files, false, true, false, false, nil, nil, nil, nil, localgit.NewV2)
+		if err != nil {
+			t.Fatalf("Error creating test client: %v.", err)
+		}
+		defer cleanup()
+		client.codeOwnersEnabled = func(org, repo string) bool {
+			return enabled
+		}
+
+		owners, err := client.LoadRepoOwners("org", "repo", defaultBranch)
+		if err != nil {
+			t.Fatalf("Unexpected error loading RepoOwners: %v.", err)
+		}
+		expected := map[string]sets.Set[string]{
+			"README.md":  sets.New[string]("cjwagner", "maggie", "mml"),
+			"src/a.go":   sets.New[string]("cjwagner", "maggie", "alice", "bob", "carl"),
+			"src/doc.md": sets.New[string]("cjwagner", "maggie", "alice", "bob", "carl", "mml"),
+		}
+		if !enabled {
+			expected = map[string]sets.Set[string]{
+				"README.md":  sets.New[string]("cjwagner"),
+				"src/a.go":   sets.New[string]("cjwagner", "alice"),
+				"src/doc.md": sets.New[string]("cjwagner", "alice"),
+			}
+		}
+		for path, approvers := range expected {
+			if actual := owners.Approvers(path).Set(); !actual.Equal(approvers) {
+				t.Errorf("CODEOWNERS enabled %t: expected approvers of %s to be %v, got %v", enabled, path, sets.List(approvers), sets.List(actual))
+			}
+			if actual := owners.Reviewers(path).Set(); enabled && !actual.IsSuperset(approvers.Difference(sets.New[string]("cjwagner", "alice"))) {
+				t.Errorf("expected reviewers of %s to contain the code owners, got %v", path, sets.List(actual))
+			}
+		}
+	}
+}
+
+func TestGenerateCodeOwners(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"OWNERS":                 "approvers:\n- cjwagner\nreviewers:\n- bob",
+		"OWNERS_ALIASES":         "aliases:\n  best-approvers:\n  - carl\n  - alice",
+		"src/OWNERS":             "approvers:\n- best-approvers",
+		"src/conformance/OWNERS": "options:\n  no_parent_owners: true\napprovers:\n- mml",
+		"src/labels/OWNERS":      "labels:\n- area/labels",
+		"re/OWNERS":              "filters:\n  \"\\\\.go$\":\n    approvers:\n    - maggie",
+	} {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := GenerateCodeOwners(dir, ownersconfig.FakeFilenames, logrus.WithField("test", t.Name()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `# Generated from OWNERS files, do not edit.
+* @cjwagner
+/src/ @alice @carl @cjwagner
+/src/conformance/ @mml
+`
+	if diff := cmp.Diff(expected, string(b)); diff != "" {
+		t.Errorf("unexpected CODEOWNERS (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repoowners
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// defaultWorkingHours are the working hours of users with a time zone but
+// without working hours.
+const defaultWorkingHours = "09:00-17:00"
+
+// UserMetadata contains hints about a user that plugins may take into account
+// when picking people from OWNERS files. It is declared in the metadata
+// section of the OWNERS_ALIASES file:
+/*
+	metadata:
+	  alice:
+	    timezone: Europe/Berlin
+	    working_hours: "08:00-16:00"
+*/
+type UserMetadata struct {
+	// TimeZone is the IANA time zone of the user, e.g. "America/New_York".
+	TimeZone string `json:"timezone,omitempty"`
+	// WorkingHours is the range of the day the user usually works in, in the
+	// time zone of the user, e.g. "09:00-17:00". The range may span midnight.
+	// Defaults to 09:00-17:00 if a time zone is set.
+	WorkingHours string `json:"working_hours,omitempty"`
+}
+
+// IsWorkingHours returns whether t is within the working hours of the user.
+// Users without a time zone are considered to always be working.
+func (m UserMetadata) IsWorkingHours(t time.Time) (bool, error) {
+	if m.TimeZone == "" {
+		return true, nil
+	}
+	loc, err := time.LoadLocation(m.TimeZone)
+	if err != nil {
+		return true, fmt.Errorf("invalid time zone %q: %w", m.TimeZone, err)
+	}
+	workingHours := m.WorkingHours
+	if workingHours == "" {
+		workingHours = defaultWorkingHours
+	}
+	start, end, err := parseWorkingHours(workingHours)
+	if err != nil {
+		return true, err
+	}
+
+	t = t.In(loc)
+	minute := t.Hour()*60 + t.Minute()
+	if start <= end {
+		return start <= minute && minute < end, nil
+	}
+	return minute >= start || minute < end, nil
+}
+
+// parseWorkingHours parses a range like "09:00-17:00" into the minutes of the
+// day it starts and ends at.
+func parseWorkingHours(workingHours string) (int, int, error) {
+	from, to, ok := strings.Cut(workingHours, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid working hours %q: expected a range like %q", workingHours, defaultWorkingHours)
+	}
+	var minutes []int
+	for _, s := range []string{from, to} {
+		t, err := time.Parse("15:04", strings.TrimSpace(s))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid working hours %q: %w", workingHours, err)
+		}
+		minutes = append(minutes, t.Hour()*60+t.Minute())
+	}
+	return minutes[0], minutes[1], nil
+}
+
+// ParseUserMetadata will unmarshal the metadata section of an OWNERS_ALIASES
+// file's content. Returns an error if the content cannot be unmarshalled.
+func ParseUserMetadata(b []byte) (map[string]UserMetadata, error) {
+	config := &struct {
+		Data map[string]UserMetadata `json:"metadata,omitempty"`
+	}{}
+	if err := yaml.Unmarshal(b, config); err != nil {
+		return nil, err
+	}
+
+	var result map[string]UserMetadata
+	for login, metadata := range config.Data {
+		if result == nil {
+			result = make(map[string]UserMetadata, len(config.Data))
+		}
+		result[github.NormLogin(login)] = metadata
+	}
+	return result, nil
+}
+
+func loadUserMetadataFrom(baseDir, filename string, log *logrus.Entry) map[string]UserMetadata {
+	path := filepath.Join(baseDir, filename)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		// Missing alias files are already reported when loading aliases.
+		return nil
+	}
+	result, err := ParseUserMetadata(b)
+	if err != nil {
+		log.WithError(err).Errorf("Failed to unmarshal user metadata from %q.", path)
+	}
+	return result
+}
+
+// Metadata returns the metadata of a user, if any.
+func (o *RepoOwners) Metadata(login string) (UserMetadata, bool) {
+	metadata, ok := o.metadata[github.NormLogin(login)]
+	return metadata, ok
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repoowners
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseUserMetadata(t *testing.T) {
+	metadata, err := ParseUserMetadata([]byte(`aliases:
+  team:
+  - alice
+metadata:
+  Alice:
+    timezone: Europe/Berlin
+  "@bob":
+    timezone: America/New_York
+    working_hours: "10:00-18:00"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]UserMetadata{
+		"alice": {TimeZone: "Europe/Berlin"},
+		"bob":   {TimeZone: "America/New_York", WorkingHours: "10:00-18:00"},
+	}
+	if diff := cmp.Diff(expected, metadata); diff != "" {
+		t.Errorf("unexpected metadata (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsWorkingHours(t *testing.T) {
+	// 22:30 UTC is 23:30 in Berlin in winter and 07:30 in Tokyo.
+	now := time.Date(2026, time.January, 5, 22, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		metadata    UserMetadata
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name:     "no time zone",
+			metadata: UserMetadata{WorkingHours: "09:00-10:00"},
+			expected: true,
+		},
+		{
+			name:     "outside of default working hours",
+			metadata: UserMetadata{TimeZone: "Europe/Berlin"},
+		},
+		{
+			name:     "within working hours spanning midnight",
+			metadata: UserMetadata{TimeZone: "Europe/Berlin", WorkingHours: "22:00-02:00"},
+			expected: true,
+		},
+		{
+			name:     "within working hours",
+			metadata: UserMetadata{TimeZone: "Asia/Tokyo", WorkingHours: "07:00 - 15:00"},
+			expected: true,
+		},
+		{
+			name:     "end of working hours is exclusive",
+			metadata: UserMetadata{TimeZone: "UTC", WorkingHours: "14:30-22:30"},
+		},
+		{
+			name:        "invalid time zone",
+			metadata:    UserMetadata{TimeZone: "Europe/Atlantis"},
+			expected:    true,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid working hours",
+			metadata:    UserMetadata{TimeZone: "UTC", WorkingHours: "9 to 5"},
+			expected:    true,
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.metadata.IsWorkingHours(now)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
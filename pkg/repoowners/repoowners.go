@@ -136,6 +136,10 @@ func (entry cacheEntry) matchesMDYAML(mdYAML bool) bool {
 	return entry.owners.enableMDYAML == mdYAML
 }
 
+func (entry cacheEntry) matchesCodeOwners(codeOwners bool) bool {
+	return entry.owners.enableCodeOwners == codeOwners
+}
+
 func (entry cacheEntry) fullyLoaded() bool {
 	return entry.sha != "" && entry.aliases != nil && entry.owners != nil
 }
@@ -170,6 +174,13 @@ type delegate struct {
 	ownersDirDenylist func() *prowConf.OwnersDirDenylist
 	filenames         ownersconfig.Resolver
 
+	// codeOwnersEnabled determines whether the CODEOWNERS file of a repo is
+	// ingested in addition to its OWNERS files.
+	codeOwnersEnabled func(org, repo string) bool
+
+	// remote resolves OWNERS instead of the local cache if set.
+	remote OwnersGetter
+
 	cache *cache
 }
 
@@ -219,6 +230,7 @@ func NewClient(
 	skipCollaborators func(org, repo string) bool,
 	ownersDirDenylist func() *prowConf.OwnersDirDenylist,
 	filenames ownersconfig.Resolver,
+	codeOwnersEnabled func(org, repo string) bool,
 ) *Client {
 	return &Client{
 		logger: logrus.WithField("client", "repoowners"),
@@ -231,10 +243,38 @@ func NewClient(
 			skipCollaborators: skipCollaborators,
 			ownersDirDenylist: ownersDirDenylist,
 			filenames:         filenames,
+			codeOwnersEnabled: codeOwnersEnabled,
 		},
 	}
 }
 
+// NewRemoteClient is the constructor for a Client that resolves OWNERS through
+// a shared service like Moonraker instead of cloning and caching repos itself.
+// OWNERS are still filtered by the collaborators of a repo locally.
+func NewRemoteClient(
+	remote OwnersGetter,
+	ghc github.Client,
+	skipCollaborators func(org, repo string) bool,
+) *Client {
+	return &Client{
+		logger: logrus.WithField("client", "repoowners"),
+		ghc:    ghc,
+		delegate: &delegate{
+			skipCollaborators: skipCollaborators,
+			remote:            remote,
+		},
+	}
+}
+
+// OwnersGetter resolves the OWNERS of a repo at a commit without filtering
+// them by the collaborators of the repo. If updateCache is set, the OWNERS
+// are cached as the latest ones of the base branch.
+type OwnersGetter interface {
+	GetRepoOwners(org, repo, base, sha string, updateCache bool) (*RepoOwners, error)
+}
+
+var _ OwnersGetter = &Client{}
+
 // RepoAliases defines groups of people to be used in OWNERS files
 type RepoAliases map[string]sets.Set[string]
 
@@ -257,6 +297,7 @@ type RepoOwner interface {
 	AllOwners() sets.Set[string]
 	AllApprovers() sets.Set[string]
 	AllReviewers() sets.Set[string]
+	Metadata(login string) (UserMetadata, bool)
 }
 
 var _ RepoOwner = &RepoOwners{}
@@ -276,6 +317,9 @@ type RepoOwners struct {
 	dirDenylist  []*regexp.Regexp
 	filenames    ownersconfig.Filenames
 
+	enableCodeOwners bool
+	metadata         map[string]UserMetadata
+
 	log *logrus.Entry
 }
 
@@ -306,10 +350,8 @@ func (c *Client) LoadRepoOwners(org, repo, base string) (RepoOwner, error) {
 func (c *Client) LoadRepoOwnersSha(org, repo, base, sha string, updateCache bool) (RepoOwner, error) {
 	c.used = true
 	log := c.logger.WithFields(logrus.Fields{"org": org, "repo": repo, "base": base, "sha": sha})
-	cloneRef := fmt.Sprintf("%s/%s", org, repo)
-	fullName := fmt.Sprintf("%s:%s", cloneRef, base)
 
-	entry, err := c.cacheEntryFor(org, repo, base, cloneRef, fullName, sha, updateCache, log)
+	unfiltered, err := c.GetRepoOwners(org, repo, base, sha, updateCache)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +360,7 @@ func (c *Client) LoadRepoOwnersSha(org, repo, base, sha string, updateCache bool
 	if c.skipCollaborators(org, repo) {
 		log.WithField("duration", time.Since(start).String()).Debugf("Completed c.skipCollaborators(%s, %s)", org, repo)
 		log.Debugf("Skipping collaborator checks for %s/%s", org, repo)
-		return entry.owners, nil
+		return unfiltered, nil
 	}
 	log.WithField("duration", time.Since(start).String()).Debugf("Completed c.skipCollaborators(%s, %s)", org, repo)
 
@@ -330,17 +372,35 @@ func (c *Client) LoadRepoOwnersSha(org, repo, base, sha string, updateCache bool
 	log.WithField("duration", time.Since(start).String()).Debugf("Completed ghc.ListCollaborators(%s, %s)", org, repo)
 	if err != nil {
 		log.WithError(err).Errorf("Failed to list collaborators while loading RepoOwners. Skipping collaborator filtering.")
-		owners = entry.owners
+		owners = unfiltered
 	} else {
 		start = time.Now()
-		owners = entry.owners.filterCollaborators(collaborators)
+		owners = unfiltered.filterCollaborators(collaborators)
 		log.WithField("duration", time.Since(start).String()).Debugf("Completed owners.filterCollaborators(collaborators)")
 	}
 	return owners, nil
 }
 
+// GetRepoOwners returns the OWNERS of the repo at the given SHA without
+// filtering them by the collaborators of the repo.
+func (c *Client) GetRepoOwners(org, repo, base, sha string, updateCache bool) (*RepoOwners, error) {
+	if c.remote != nil {
+		return c.remote.GetRepoOwners(org, repo, base, sha, updateCache)
+	}
+
+	log := c.logger.WithFields(logrus.Fields{"org": org, "repo": repo, "base": base, "sha": sha})
+	cloneRef := fmt.Sprintf("%s/%s", org, repo)
+	fullName := fmt.Sprintf("%s:%s", cloneRef, base)
+	entry, err := c.cacheEntryFor(org, repo, base, cloneRef, fullName, sha, updateCache, log)
+	if err != nil {
+		return nil, err
+	}
+	return entry.owners, nil
+}
+
 func (c *Client) cacheEntryFor(org, repo, base, cloneRef, fullName, sha string, setEntry bool, log *logrus.Entry) (cacheEntry, error) {
 	mdYaml := c.mdYAMLEnabled(org, repo)
+	codeOwners := c.codeOwnersEnabled(org, repo)
 	lockStart := time.Now()
 	defer func() {
 		log.WithField("duration", time.Since(lockStart).String()).Debug("Locked section of loadRepoOwners completed")
@@ -348,7 +408,7 @@ func (c *Client) cacheEntryFor(org, repo, base, cloneRef, fullName, sha string,
 	entry, ok, entryLock := c.cache.getEntry(fullName)
 	defer entryLock.Unlock()
 	filenames := c.filenames(org, repo)
-	if !ok || entry.sha != sha || entry.owners == nil || !entry.matchesMDYAML(mdYaml) {
+	if !ok || entry.sha != sha || entry.owners == nil || !entry.matchesMDYAML(mdYaml) || !entry.matchesCodeOwners(codeOwners) {
 		start := time.Now()
 		gitRepo, err := c.git.ClientFor(org, repo)
 		if err != nil {
@@ -357,7 +417,7 @@ func (c *Client) cacheEntryFor(org, repo, base, cloneRef, fullName, sha string,
 		log.WithField("duration", time.Since(start).String()).Debugf("Completed git.ClientFor(%s, %s)", org, repo)
 		defer gitRepo.Clean()
 
-		reusable := entry.fullyLoaded() && entry.matchesMDYAML(mdYaml)
+		reusable := entry.fullyLoaded() && entry.matchesMDYAML(mdYaml) && entry.matchesCodeOwners(codeOwners)
 		// In most sha changed cases, the files associated with the owners are unchanged.
 		// The cached entry can continue to be used, so need do git diff
 		if reusable {
@@ -371,7 +431,8 @@ func (c *Client) cacheEntryFor(org, repo, base, cloneRef, fullName, sha string,
 			for _, change := range changes {
 				if mdYaml && strings.HasSuffix(change, ".md") ||
 					strings.HasSuffix(change, filenames.OwnersAliases) ||
-					strings.HasSuffix(change, filenames.Owners) {
+					strings.HasSuffix(change, filenames.Owners) ||
+					codeOwners && isCodeOwnersFile(change) {
 					reusable = false
 					log.WithField("duration", time.Since(start).String()).Debugf("Completed owners change verification loop")
 					break
@@ -409,11 +470,11 @@ func (c *Client) cacheEntryFor(org, repo, base, cloneRef, fullName, sha string,
 			log.WithField("duration", time.Since(start).String()).Debugf("Completed dirIgnorelist loading")
 
 			start = time.Now()
-			entry.owners, err = loadOwnersFrom(gitRepo.Directory(), mdYaml, entry.aliases, dirIgnorelist, filenames, log)
+			entry.owners, err = loadOwnersFrom(gitRepo.Directory(), mdYaml, codeOwners, entry.aliases, dirIgnorelist, filenames, log)
 			if err != nil {
 				return cacheEntry{}, fmt.Errorf("failed to load RepoOwners for %s: %w", fullName, err)
 			}
-			log.WithField("duration", time.Since(start).String()).Debugf("Completed loadOwnersFrom(%s, %t, entry.aliases, dirIgnorelist, log)", gitRepo.Directory(), mdYaml)
+			log.WithField("duration", time.Since(start).String()).Debugf("Completed loadOwnersFrom(%s, %t, %t, entry.aliases, dirIgnorelist, log)", gitRepo.Directory(), mdYaml, codeOwners)
 			entry.sha = sha
 			if setEntry {
 				c.cache.setEntry(fullName, entry)
@@ -479,7 +540,7 @@ func loadAliasesFrom(baseDir, filename string, log *logrus.Entry) RepoAliases {
 	return result
 }
 
-func loadOwnersFrom(baseDir string, mdYaml bool, aliases RepoAliases, dirIgnorelist []*regexp.Regexp, filenames ownersconfig.Filenames, log *logrus.Entry) (*RepoOwners, error) {
+func loadOwnersFrom(baseDir string, mdYaml, codeOwners bool, aliases RepoAliases, dirIgnorelist []*regexp.Regexp, filenames ownersconfig.Filenames, log *logrus.Entry) (*RepoOwners, error) {
 	o := &RepoOwners{
 		RepoAliases:  aliases,
 		baseDir:      baseDir,
@@ -487,6 +548,9 @@ func loadOwnersFrom(baseDir string, mdYaml bool, aliases RepoAliases, dirIgnorel
 		filenames:    filenames,
 		log:          log,
 
+		enableCodeOwners: codeOwners,
+		metadata:         loadUserMetadataFrom(baseDir, filenames.OwnersAliases, log),
+
 		approvers:         make(map[string]map[*regexp.Regexp]sets.Set[string]),
 		reviewers:         make(map[string]map[*regexp.Regexp]sets.Set[string]),
 		requiredReviewers: make(map[string]map[*regexp.Regexp]sets.Set[string]),
@@ -496,7 +560,13 @@ func loadOwnersFrom(baseDir string, mdYaml bool, aliases RepoAliases, dirIgnorel
 		dirDenylist: dirIgnorelist,
 	}
 
-	return o, filepath.Walk(o.baseDir, o.walkFunc)
+	if err := filepath.Walk(o.baseDir, o.walkFunc); err != nil {
+		return o, err
+	}
+	if codeOwners {
+		o.loadCodeOwners()
+	}
+	return o, nil
 }
 
 // by default, github's api doesn't root the project directory at "/" and instead uses the empty string for the base dir
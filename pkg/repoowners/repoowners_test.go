@@ -283,6 +283,9 @@ labels:
 					}
 				},
 				filenames: ownersconfig.FakeResolver,
+				codeOwnersEnabled: func(org, repo string) bool {
+					return false
+				},
 			},
 		},
 		// Clean up function
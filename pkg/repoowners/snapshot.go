@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repoowners
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+)
+
+// Snapshot is the serializable form of the parsed OWNERS of a repository. It
+// allows a service like Moonraker to resolve OWNERS once and share them with
+// other components.
+type Snapshot struct {
+	Aliases map[string][]string `json:"aliases,omitempty"`
+	// Approvers, Reviewers, RequiredReviewers and Labels are keyed by path
+	// and the regexp of the filter they belong to, which is empty if they
+	// apply to the whole path.
+	Approvers         map[string]map[string][]string `json:"approvers,omitempty"`
+	Reviewers         map[string]map[string][]string `json:"reviewers,omitempty"`
+	RequiredReviewers map[string]map[string][]string `json:"required_reviewers,omitempty"`
+	Labels            map[string]map[string][]string `json:"labels,omitempty"`
+	Options           map[string]dirOptions          `json:"options,omitempty"`
+
+	EnableMDYAML     bool                   `json:"enable_mdyaml,omitempty"`
+	EnableCodeOwners bool                   `json:"enable_codeowners,omitempty"`
+	DirDenylist      []string               `json:"dir_denylist,omitempty"`
+	Filenames        ownersconfig.Filenames `json:"filenames"`
+
+	Metadata map[string]UserMetadata `json:"metadata,omitempty"`
+}
+
+// Snapshot returns the serializable form of the OWNERS.
+func (o *RepoOwners) Snapshot() Snapshot {
+	s := Snapshot{
+		Approvers:         snapshotEntries(o.approvers),
+		Reviewers:         snapshotEntries(o.reviewers),
+		RequiredReviewers: snapshotEntries(o.requiredReviewers),
+		Labels:            snapshotEntries(o.labels),
+		Options:           o.options,
+		EnableMDYAML:      o.enableMDYAML,
+		EnableCodeOwners:  o.enableCodeOwners,
+		Filenames:         o.filenames,
+		Metadata:          o.metadata,
+	}
+	if o.RepoAliases != nil {
+		s.Aliases = make(map[string][]string, len(o.RepoAliases))
+		for alias, logins := range o.RepoAliases {
+			s.Aliases[alias] = sets.List(logins)
+		}
+	}
+	for _, re := range o.dirDenylist {
+		s.DirDenylist = append(s.DirDenylist, re.String())
+	}
+	return s
+}
+
+// RepoOwners returns the OWNERS the snapshot was taken of.
+func (s Snapshot) RepoOwners(log *logrus.Entry) (*RepoOwners, error) {
+	o := &RepoOwners{
+		enableMDYAML:     s.EnableMDYAML,
+		enableCodeOwners: s.EnableCodeOwners,
+		filenames:        s.Filenames,
+		options:          s.Options,
+		metadata:         s.Metadata,
+		log:              log,
+	}
+	if o.options == nil {
+		o.options = make(map[string]dirOptions)
+	}
+	if s.Aliases != nil {
+		o.RepoAliases = make(RepoAliases, len(s.Aliases))
+		for alias, logins := range s.Aliases {
+			o.RepoAliases[alias] = sets.New[string](logins...)
+		}
+	}
+	for _, pattern := range s.DirDenylist {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OWNERS dir denylist regexp %q: %w", pattern, err)
+		}
+		o.dirDenylist = append(o.dirDenylist, re)
+	}
+
+	var err error
+	if o.approvers, err = restoreEntries(s.Approvers); err != nil {
+		return nil, err
+	}
+	if o.reviewers, err = restoreEntries(s.Reviewers); err != nil {
+		return nil, err
+	}
+	if o.requiredReviewers, err = restoreEntries(s.RequiredReviewers); err != nil {
+		return nil, err
+	}
+	if o.labels, err = restoreEntries(s.Labels); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func snapshotEntries(entries map[string]map[*regexp.Regexp]sets.Set[string]) map[string]map[string][]string {
+	result := make(map[string]map[string][]string, len(entries))
+	for path, reMap := range entries {
+		result[path] = make(map[string][]string, len(reMap))
+		for re, values := range reMap {
+			var pattern string
+			if re != nil {
+				pattern = re.String()
+			}
+			// Filters of different files may share the same regexp, e.g.
+			// those of an OWNERS and a CODEOWNERS file.
+			result[path][pattern] = sets.List(sets.New[string](result[path][pattern]...).Union(values))
+		}
+	}
+	return result
+}
+
+func restoreEntries(entries map[string]map[string][]string) (map[string]map[*regexp.Regexp]sets.Set[string], error) {
+	result := make(map[string]map[*regexp.Regexp]sets.Set[string], len(entries))
+	for path, patterns := range entries {
+		result[path] = make(map[*regexp.Regexp]sets.Set[string], len(patterns))
+		for pattern, values := range patterns {
+			var re *regexp.Regexp
+			if pattern != "" {
+				var err error
+				if re, err = regexp.Compile(pattern); err != nil {
+					return nil, fmt.Errorf("invalid regexp %q of %q: %w", pattern, path, err)
+				}
+			}
+			result[path][re] = sets.New[string](values...)
+		}
+	}
+	return result, nil
+}
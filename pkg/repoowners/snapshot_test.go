@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repoowners
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/git/localgit"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	client, cleanup, err := getTestClient(testFiles, true, true, true, false, nil, nil, nil, nil, localgit.NewV2)
+	if err != nil {
+		t.Fatalf("Error creating test client: %v.", err)
+	}
+	defer cleanup()
+	owners, err := client.LoadRepoOwners("org", "repo", defaultBranch)
+	if err != nil {
+		t.Fatalf("Unexpected error loading RepoOwners: %v.", err)
+	}
+	expected := owners.(*RepoOwners)
+
+	b, err := json.Marshal(expected.Snapshot())
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling snapshot: %v.", err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		t.Fatalf("Unexpected error unmarshaling snapshot: %v.", err)
+	}
+	actual, err := snapshot.RepoOwners(logrus.WithField("test", t.Name()))
+	if err != nil {
+		t.Fatalf("Unexpected error restoring RepoOwners: %v.", err)
+	}
+
+	for _, path := range []string{"foo", "src/file", "src/dir/file.go", "src/dir/conformance/file", "re/a/file.md", "re/a/file.go", "docs/file.md", "vendor/file"} {
+		if e, a := expected.Approvers(path).Set(), actual.Approvers(path).Set(); !e.Equal(a) {
+			t.Errorf("Expected approvers of %s to be %v, got %v.", path, sets.List(e), sets.List(a))
+		}
+		if e, a := expected.Reviewers(path).Set(), actual.Reviewers(path).Set(); !e.Equal(a) {
+			t.Errorf("Expected reviewers of %s to be %v, got %v.", path, sets.List(e), sets.List(a))
+		}
+		if e, a := expected.RequiredReviewers(path), actual.RequiredReviewers(path); !e.Equal(a) {
+			t.Errorf("Expected required reviewers of %s to be %v, got %v.", path, sets.List(e), sets.List(a))
+		}
+		if e, a := expected.FindLabelsForFile(path), actual.FindLabelsForFile(path); !e.Equal(a) {
+			t.Errorf("Expected labels of %s to be %v, got %v.", path, sets.List(e), sets.List(a))
+		}
+		if e, a := expected.IsNoParentOwners(path), actual.IsNoParentOwners(path); e != a {
+			t.Errorf("Expected no_parent_owners of %s to be %t, got %t.", path, e, a)
+		}
+	}
+	if e, a := expected.AllOwners(), actual.AllOwners(); !e.Equal(a) {
+		t.Errorf("Expected all owners to be %v, got %v.", sets.List(e), sets.List(a))
+	}
+}
+
+type fakeOwnersGetter struct {
+	owners *RepoOwners
+	err    error
+	calls  []string
+}
+
+func (f *fakeOwnersGetter) GetRepoOwners(org, repo, base, sha string, updateCache bool) (*RepoOwners, error) {
+	f.calls = append(f.calls, org+"/"+repo+":"+base+"@"+sha)
+	return f.owners, f.err
+}
+
+func TestRemoteClient(t *testing.T) {
+	unfiltered := &RepoOwners{
+		approvers: map[string]map[*regexp.Regexp]sets.Set[string]{
+			"": regexpAll("cjwagner", "not-a-collaborator"),
+		},
+		log: logrus.WithField("test", t.Name()),
+	}
+	testCases := []struct {
+		name              string
+		skipCollaborators bool
+		err               error
+		expected          sets.Set[string]
+	}{
+		{
+			name:     "owners are filtered by collaborators",
+			expected: sets.New[string]("cjwagner"),
+		},
+		{
+			name:              "collaborators are skipped",
+			skipCollaborators: true,
+			expected:          sets.New[string]("cjwagner", "not-a-collaborator"),
+		},
+		{
+			name: "remote fails",
+			err:  errors.New("unavailable"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			remote := &fakeOwnersGetter{owners: unfiltered, err: tc.err}
+			client := NewRemoteClient(remote, nil, func(org, repo string) bool { return tc.skipCollaborators })
+			client.ghc = &fakeGitHubClient{Collaborators: []string{"cjwagner"}, ref: "sha"}
+
+			owners, err := client.LoadRepoOwners("org", "repo", defaultBranch)
+			if (err != nil) != (tc.err != nil) {
+				t.Fatalf("Expected error %v, got %v.", tc.err, err)
+			}
+			if expected := []string{"org/repo:master@sha"}; len(remote.calls) != 1 || remote.calls[0] != expected[0] {
+				t.Errorf("Expected remote calls %v, got %v.", expected, remote.calls)
+			}
+			if tc.err != nil {
+				return
+			}
+			if actual := owners.Approvers("file").Set(); !actual.Equal(tc.expected) {
+				t.Errorf("Expected approvers %v, got %v.", sets.List(tc.expected), sets.List(actual))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/plank"
+)
+
+// clusterStatusRefreshInterval is how long the build cluster statuses
+// reported by plank are trusted before they are read again.
+const clusterStatusRefreshInterval = time.Minute
+
+// clusterStatuses caches the build cluster status file plank writes
+// periodically to blob storage.
+type clusterStatuses struct {
+	opener io.Opener
+
+	lock     sync.Mutex
+	location string
+	fetched  time.Time
+	statuses map[string]plank.ClusterStatus
+}
+
+// healthy returns a function telling whether a cluster is reachable
+// according to the status file at location. Every cluster is considered
+// healthy when there is no status file to read.
+func (c *clusterStatuses) healthy(ctx context.Context, location string, log *logrus.Entry) func(string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.opener == nil || location == "" {
+		c.statuses = nil
+	} else if location != c.location || time.Since(c.fetched) > clusterStatusRefreshInterval {
+		statuses, err := c.read(ctx, location)
+		if err != nil {
+			// Keep going with the statuses we know about, if any.
+			log.WithError(err).Warn("Failed to read build cluster statuses.")
+		} else {
+			c.statuses = statuses
+		}
+		c.location = location
+		c.fetched = time.Now()
+	}
+
+	statuses := c.statuses
+	return func(cluster string) bool {
+		return statuses == nil || statuses[cluster] == plank.ClusterStatusReachable
+	}
+}
+
+func (c *clusterStatuses) read(ctx context.Context, location string) (map[string]plank.ClusterStatus, error) {
+	reader, err := c.opener.Reader(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", location, err)
+	}
+	defer reader.Close()
+	b, err := stdio.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", location, err)
+	}
+	statuses := map[string]plank.ClusterStatus{}
+	if err := json.Unmarshal(b, &statuses); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", location, err)
+	}
+	return statuses, nil
+}
@@ -18,7 +18,9 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -29,18 +31,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/scheduler/strategy"
 )
 
 const ControllerName = "scheduler"
 
-func Add(mgr controllerruntime.Manager, cfg config.Getter, numWorkers int) error {
+// noClusterAvailableRequeueInterval is how long a ProwJob selecting its
+// cluster by labels waits before a new attempt when no cluster is available.
+const noClusterAvailableRequeueInterval = 30 * time.Second
+
+func Add(mgr controllerruntime.Manager, cfg config.Getter, opener io.Opener, numWorkers int) error {
 	predicates := predicate.NewPredicateFuncs(func(object client.Object) bool {
 		pj, isPJ := object.(*prowv1.ProwJob)
 		return isPJ && pj.Status.State == prowv1.SchedulingState
 	})
 
-	reconciler := NewReconciler(mgr.GetClient(), cfg, strategy.Get)
+	reconciler := NewReconciler(mgr.GetClient(), cfg, strategy.Get, opener)
 	if err := controllerruntime.NewControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&prowv1.ProwJob{}).
@@ -61,6 +68,7 @@ type Reconciler struct {
 	log         *logrus.Entry
 	cfg         config.Getter
 	strategy    StrategyGetter
+	statuses    *clusterStatuses
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -90,6 +98,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("schedule prowjob %s: %w", request.Name, err)
 	}
+
+	// The cluster may be a label selector, either configured on the job or
+	// resulting from the strategy above: resolve it to an actual cluster.
+	if config.IsClusterSelector(result.Cluster) {
+		result, err = r.selectCluster(ctx, pj, result.Cluster, log)
+		if errors.Is(err, strategy.ErrNoClusterAvailable) {
+			log.WithError(err).Info("Waiting for a cluster to become available")
+			return reconcile.Result{RequeueAfter: noClusterAvailableRequeueInterval}, nil
+		}
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("select cluster for prowjob %s: %w", request.Name, err)
+		}
+	}
 	log.WithField("cluster", result.Cluster).Info("Cluster assigned")
 
 	// Don't mess the cache up
@@ -104,12 +125,30 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return reconcile.Result{}, nil
 }
 
-func NewReconciler(pjClient client.Client, cfg config.Getter, strtgy StrategyGetter) *Reconciler {
+// selectCluster picks one of the clusters matching selector by means of
+// the ClusterSelection strategy.
+func (r *Reconciler) selectCluster(ctx context.Context, pj *prowv1.ProwJob, selector string, log *logrus.Entry) (strategy.Result, error) {
+	cfg := r.cfg()
+	var selection config.ClusterSelectionScheduling
+	if cfg.Scheduler.ClusterSelection != nil {
+		selection = *cfg.Scheduler.ClusterSelection
+	}
+	healthy := r.statuses.healthy(ctx, cfg.Plank.BuildClusterStatusFile, log)
+
+	selecting := pj.DeepCopy()
+	selecting.Spec.Cluster = selector
+	return strategy.NewClusterSelection(selection, r.pjClient, pj.Namespace, healthy).Schedule(ctx, selecting)
+}
+
+// NewReconciler creates a scheduler Reconciler. The opener is used to read
+// the build cluster statuses plank reports, and may be nil.
+func NewReconciler(pjClient client.Client, cfg config.Getter, strtgy StrategyGetter, opener io.Opener) *Reconciler {
 	return &Reconciler{
 		pjClient:    pjClient,
 		passthrough: &strategy.Passthrough{},
 		log:         logrus.NewEntry(logrus.StandardLogger()).WithField("controller", ControllerName),
 		cfg:         cfg,
 		strategy:    strtgy,
+		statuses:    &clusterStatuses{opener: opener},
 	}
 }
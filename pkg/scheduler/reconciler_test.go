@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
@@ -76,7 +77,9 @@ func TestReconcile(t *testing.T) {
 		cluster         string
 		schedulingError error
 		clientErrors    map[string]error
+		cfg             config.Config
 		wantPJ          *prowv1.ProwJob
+		wantResult      reconcile.Result
 		wantError       error
 	}{
 		{
@@ -157,6 +160,47 @@ func TestReconcile(t *testing.T) {
 				Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
 			},
 		},
+		{
+			name: "Select a cluster by labels",
+			pj: &prowv1.ProwJob{
+				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "1"},
+				Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent},
+			},
+			request: reconcile.Request{NamespacedName: types.NamespacedName{Name: "pj", Namespace: "ns"}},
+			cluster: "arch=arm64",
+			cfg: config.Config{ProwConfig: config.ProwConfig{Scheduler: config.Scheduler{
+				ClusterSelection: &config.ClusterSelectionScheduling{Clusters: map[string]config.SelectableCluster{
+					"amd64": {Labels: map[string]string{"arch": "amd64"}},
+					"arm64": {Labels: map[string]string{"arch": "arm64"}},
+				}},
+			}}},
+			wantPJ: &prowv1.ProwJob{
+				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "2"},
+				Spec:       prowv1.ProwJobSpec{Cluster: "arm64", Agent: prowv1.KubernetesAgent},
+				Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+			},
+		},
+		{
+			name: "No cluster matches the labels then wait",
+			pj: &prowv1.ProwJob{
+				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "1"},
+				Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent, Cluster: "arch=s390x"},
+				Status:     prowv1.ProwJobStatus{State: prowv1.SchedulingState},
+			},
+			request: reconcile.Request{NamespacedName: types.NamespacedName{Name: "pj", Namespace: "ns"}},
+			cluster: "arch=s390x",
+			cfg: config.Config{ProwConfig: config.ProwConfig{Scheduler: config.Scheduler{
+				ClusterSelection: &config.ClusterSelectionScheduling{Clusters: map[string]config.SelectableCluster{
+					"arm64": {Labels: map[string]string{"arch": "arm64"}},
+				}},
+			}}},
+			wantPJ: &prowv1.ProwJob{
+				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "1"},
+				Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent, Cluster: "arch=s390x"},
+				Status:     prowv1.ProwJobStatus{State: prowv1.SchedulingState},
+			},
+			wantResult: reconcile.Result{RequeueAfter: 30 * time.Second},
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -173,11 +217,11 @@ func TestReconcile(t *testing.T) {
 			pjClient := builder.Build()
 
 			r := scheduler.NewReconciler(pjClient,
-				func() *config.Config { return nil },
+				func() *config.Config { return &tc.cfg },
 				func(_ *config.Config, _ *logrus.Entry) strategy.Interface {
 					return &fakeStrategy{cluster: tc.cluster, err: tc.schedulingError}
-				})
-			_, err := r.Reconcile(context.TODO(), tc.request)
+				}, nil)
+			result, err := r.Reconcile(context.TODO(), tc.request)
 
 			if tc.wantError != nil && err != nil {
 				if tc.wantError.Error() != err.Error() {
@@ -192,6 +236,10 @@ func TestReconcile(t *testing.T) {
 				return
 			}
 
+			if diff := cmp.Diff(tc.wantResult, result); diff != "" {
+				t.Errorf("Unexpected result: %s", diff)
+			}
+
 			pjs := prowv1.ProwJobList{}
 			if err := pjClient.List(context.TODO(), &pjs); err != nil {
 				// It's just not supposed to happen
@@ -298,7 +346,7 @@ func TestConfigHotReload(t *testing.T) {
 
 			var cfg *config.Config
 			pjClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.pjs...).Build()
-			reconciler := scheduler.NewReconciler(pjClient, func() *config.Config { return cfg }, strategy.Get)
+			reconciler := scheduler.NewReconciler(pjClient, func() *config.Config { return cfg }, strategy.Get, nil)
 
 			for i := range tc.configs {
 				cfg = &tc.configs[i]
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// ErrNoClusterAvailable is returned when none of the clusters matching
+// a cluster selector is able to run a ProwJob at the moment.
+var ErrNoClusterAvailable = errors.New("no cluster available")
+
+// ClusterSelection is a strategy that resolves the label selector a ProwJob
+// uses in place of a cluster name. Out of the configured clusters whose labels
+// match the selector and which are healthy, it picks the one running the
+// fewest ProwJobs relative to its capacity.
+type ClusterSelection struct {
+	cfg       config.ClusterSelectionScheduling
+	pjClient  ctrlruntimeclient.Reader
+	namespace string
+	healthy   func(cluster string) bool
+}
+
+var _ Interface = &ClusterSelection{}
+
+// NewClusterSelection creates a new ClusterSelection strategy. The load of
+// the clusters is computed from the ProwJobs in namespace, whereas healthy
+// tells whether a cluster can currently run ProwJobs.
+func NewClusterSelection(cfg config.ClusterSelectionScheduling, pjClient ctrlruntimeclient.Reader, namespace string, healthy func(cluster string) bool) *ClusterSelection {
+	return &ClusterSelection{
+		cfg:       cfg,
+		pjClient:  pjClient,
+		namespace: namespace,
+		healthy:   healthy,
+	}
+}
+
+// Schedule selects a cluster for a ProwJob whose cluster is a label selector.
+// Any other ProwJob keeps the cluster it has been assigned to.
+func (cs *ClusterSelection) Schedule(ctx context.Context, pj *prowv1.ProwJob) (Result, error) {
+	if !config.IsClusterSelector(pj.Spec.Cluster) {
+		return Result{Cluster: pj.Spec.Cluster}, nil
+	}
+	selector, err := labels.Parse(pj.Spec.Cluster)
+	if err != nil {
+		return Result{}, fmt.Errorf("parse cluster selector %q: %w", pj.Spec.Cluster, err)
+	}
+
+	candidates := sets.New[string]()
+	for name, cluster := range cs.cfg.Clusters {
+		if selector.Matches(labels.Set(cluster.Labels)) && cs.healthy(name) {
+			candidates.Insert(name)
+		}
+	}
+	if candidates.Len() == 0 {
+		return Result{}, fmt.Errorf("%w matching %q", ErrNoClusterAvailable, pj.Spec.Cluster)
+	}
+
+	load, err := cs.load(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var selected string
+	for _, name := range sets.List(candidates) {
+		// Compare load[name]/capacity[name] to load[selected]/capacity[selected]
+		// without dividing. Ties go to the cluster that sorts first.
+		if selected == "" || load[name]*cs.cfg.Clusters[selected].GetCapacity() < load[selected]*cs.cfg.Clusters[name].GetCapacity() {
+			selected = name
+		}
+	}
+	return Result{Cluster: selected}, nil
+}
+
+// load counts the ProwJobs that are running, or about to, on each cluster.
+func (cs *ClusterSelection) load(ctx context.Context) (map[string]int, error) {
+	pjs := &prowv1.ProwJobList{}
+	if err := cs.pjClient.List(ctx, pjs, ctrlruntimeclient.InNamespace(cs.namespace)); err != nil {
+		return nil, fmt.Errorf("list prowjobs: %w", err)
+	}
+	load := map[string]int{}
+	for _, pj := range pjs.Items {
+		if pj.Status.State == prowv1.TriggeredState || pj.Status.State == prowv1.PendingState {
+			load[pj.Spec.Cluster]++
+		}
+	}
+	return load, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/scheduler/strategy"
+)
+
+func TestClusterSelection(t *testing.T) {
+	clusters := map[string]config.SelectableCluster{
+		"amd64-a": {Labels: map[string]string{"arch": "amd64"}},
+		"amd64-b": {Labels: map[string]string{"arch": "amd64"}, Capacity: 3},
+		"arm64":   {Labels: map[string]string{"arch": "arm64", "gpu": "true"}},
+	}
+	running := func(name, cluster string, state prowv1.ProwJobState) client.Object {
+		return &prowv1.ProwJob{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       prowv1.ProwJobSpec{Cluster: cluster},
+			Status:     prowv1.ProwJobStatus{State: state},
+		}
+	}
+
+	for _, tc := range []struct {
+		name         string
+		cluster      string
+		pjs          []client.Object
+		unhealthy    []string
+		wantDecision strategy.Result
+		wantErr      error
+	}{
+		{
+			name:         "Not a selector, do not replace",
+			cluster:      "a-cluster",
+			wantDecision: strategy.Result{Cluster: "a-cluster"},
+		},
+		{
+			name:         "Select the only matching cluster",
+			cluster:      "arch=arm64,gpu=true",
+			wantDecision: strategy.Result{Cluster: "arm64"},
+		},
+		{
+			name:         "Ties go to the first cluster by name",
+			cluster:      "arch=amd64",
+			wantDecision: strategy.Result{Cluster: "amd64-a"},
+		},
+		{
+			name:    "Select the least loaded cluster relative to its capacity",
+			cluster: "arch=amd64",
+			pjs: []client.Object{
+				running("a", "amd64-a", prowv1.PendingState),
+				running("b", "amd64-b", prowv1.PendingState),
+				running("c", "amd64-b", prowv1.TriggeredState),
+			},
+			wantDecision: strategy.Result{Cluster: "amd64-b"},
+		},
+		{
+			name:    "Completed jobs do not count",
+			cluster: "arch=amd64",
+			pjs: []client.Object{
+				running("a", "amd64-a", prowv1.SuccessState),
+				running("b", "amd64-b", prowv1.PendingState),
+			},
+			wantDecision: strategy.Result{Cluster: "amd64-a"},
+		},
+		{
+			name:         "Skip unhealthy clusters",
+			cluster:      "arch=amd64",
+			unhealthy:    []string{"amd64-a"},
+			wantDecision: strategy.Result{Cluster: "amd64-b"},
+		},
+		{
+			name:      "No healthy cluster",
+			cluster:   "arch=arm64",
+			unhealthy: []string{"arm64"},
+			wantErr:   strategy.ErrNoClusterAvailable,
+		},
+		{
+			name:    "No matching cluster",
+			cluster: "arch=s390x",
+			wantErr: strategy.ErrNoClusterAvailable,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pjClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.pjs...).Build()
+			healthy := func(cluster string) bool {
+				for _, unhealthy := range tc.unhealthy {
+					if cluster == unhealthy {
+						return false
+					}
+				}
+				return true
+			}
+			selection := strategy.NewClusterSelection(config.ClusterSelectionScheduling{Clusters: clusters}, pjClient, "ns", healthy)
+
+			d, err := selection.Schedule(context.TODO(), &prowv1.ProwJob{Spec: prowv1.ProwJobSpec{Cluster: tc.cluster}})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Expected error %v but got %v", tc.wantErr, err)
+			}
+
+			if diff := cmp.Diff(tc.wantDecision, d); diff != "" {
+				t.Errorf("Unexpected decisions: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// secretsManagerAPI are the Secrets Manager operations needed to read and
+// update a secret.
+type secretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+	PutSecretValue(ctx context.Context, params *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error)
+}
+
+// awsStore stores the secret as the string value of a secret in AWS Secrets
+// Manager.
+type awsStore struct {
+	client   secretsManagerAPI
+	secretID string
+}
+
+func newAWSStore(ctx context.Context, region, secretID string) (Store, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS SDK config: %w", err)
+	}
+	return &awsStore{
+		client:   secretsmanager.NewFromConfig(cfg),
+		secretID: secretID,
+	}, nil
+}
+
+func (s *awsStore) Get(ctx context.Context) ([]byte, error) {
+	out, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(s.secretID)})
+	if err != nil {
+		return nil, s.wrapErr("GetSecretValue", err)
+	}
+	return []byte(aws.ToString(out.SecretString)), nil
+}
+
+func (s *awsStore) Update(ctx context.Context, value []byte) error {
+	_, err := s.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(s.secretID),
+		SecretString: aws.String(string(value)),
+	})
+	if err != nil {
+		return s.wrapErr("PutSecretValue", err)
+	}
+	return nil
+}
+
+func (s *awsStore) wrapErr(action string, err error) error {
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return fmt.Errorf("secret %s: %w", s.secretID, ErrNotFound)
+	}
+	return fmt.Errorf("%s %s: %w", action, s.secretID, err)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// gcpStore stores the secret as the latest version of a secret in GCP Secret
+// Manager.
+type gcpStore struct {
+	client *secretmanager.Client
+	name   string
+}
+
+func newGCPStore(ctx context.Context, project, name string) (Store, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCP Secret Manager client: %w", err)
+	}
+	return &gcpStore{client: client, name: fmt.Sprintf("projects/%s/secrets/%s", project, name)}, nil
+}
+
+func (s *gcpStore) Get(ctx context.Context) ([]byte, error) {
+	result, err := s.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: s.name + "/versions/latest",
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("secret %s: %w", s.name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to access secret %s: %w", s.name, err)
+	}
+	return result.Payload.Data, nil
+}
+
+func (s *gcpStore) Update(ctx context.Context, value []byte) error {
+	if _, err := s.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent:  s.name,
+		Payload: &secretmanagerpb.SecretPayload{Data: value},
+	}); err != nil {
+		return fmt.Errorf("failed to add a version to secret %s: %w", s.name, err)
+	}
+	return nil
+}
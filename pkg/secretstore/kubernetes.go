@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type kubernetesStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+	key       string
+}
+
+// NewKubernetesStore returns the store of a key of a Kubernetes Secret.
+func NewKubernetesStore(client kubernetes.Interface, namespace, name, key string) Store {
+	return &kubernetesStore{client: client, namespace: namespace, name: name, key: key}
+}
+
+func (s *kubernetesStore) Get(ctx context.Context) ([]byte, error) {
+	sec, err := s.client.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("secret %s/%s: %w", s.namespace, s.name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting secret %s/%s: %w", s.namespace, s.name, err)
+	}
+	value, ok := sec.Data[s.key]
+	if !ok {
+		return nil, fmt.Errorf("error getting key %q from the secret %s/%s", s.key, s.namespace, s.name)
+	}
+	return value, nil
+}
+
+func (s *kubernetesStore) Update(ctx context.Context, value []byte) error {
+	sec := &corev1.Secret{}
+	sec.Name = s.name
+	sec.Namespace = s.namespace
+	sec.StringData = map[string]string{s.key: string(value)}
+	if _, err := s.client.CoreV1().Secrets(s.namespace).Update(ctx, sec, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating the secret: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretstore reads and writes secrets kept in a Kubernetes Secret or
+// in the secret manager of a cloud provider, for installations whose policies
+// don't allow keeping some secrets in Kubernetes.
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/interrupts"
+)
+
+// Backend is where a secret is stored.
+type Backend string
+
+const (
+	// BackendKubernetes stores the secret in a key of a Kubernetes Secret.
+	BackendKubernetes Backend = "kubernetes"
+	// BackendGCP stores the secret in GCP Secret Manager.
+	BackendGCP Backend = "gcp"
+	// BackendAWS stores the secret in AWS Secrets Manager.
+	BackendAWS Backend = "aws"
+	// BackendVault stores the secret in a key of a secret in the KV version 2
+	// secrets engine of HashiCorp Vault.
+	BackendVault Backend = "vault"
+)
+
+var backends = []Backend{BackendKubernetes, BackendGCP, BackendAWS, BackendVault}
+
+// Store reads and writes a secret.
+type Store interface {
+	// Get returns the value of the secret.
+	Get(ctx context.Context) ([]byte, error)
+	// Update replaces the value of the secret.
+	Update(ctx context.Context, value []byte) error
+}
+
+// ErrNotFound is returned by Get if the secret doesn't exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Options selects the backend of a secret and configures how to access it.
+type Options struct {
+	Backend string
+
+	// GCPProject is the GCP project of the secret in GCP Secret Manager.
+	GCPProject string
+	// AWSRegion is the AWS region of the secret in AWS Secrets Manager.
+	AWSRegion string
+	// VaultAddress is the address of the Vault server.
+	VaultAddress string
+	// VaultTokenPath is the path to the file containing the Vault token.
+	VaultTokenPath string
+	// VaultMount is the mount path of the KV version 2 secrets engine.
+	VaultMount string
+}
+
+// AddFlags adds the flags to select the backend of the secret and configure
+// how to access it.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Backend, "secret-backend", string(BackendKubernetes), fmt.Sprintf("Where the secret is stored, one of %q.", backends))
+	fs.StringVar(&o.GCPProject, "gcp-project", "", "GCP project of the secret in GCP Secret Manager, required with --secret-backend=gcp.")
+	fs.StringVar(&o.AWSRegion, "aws-region", "", "AWS region of the secret in AWS Secrets Manager, required with --secret-backend=aws.")
+	fs.StringVar(&o.VaultAddress, "vault-address", "", "Address of the Vault server, required with --secret-backend=vault.")
+	fs.StringVar(&o.VaultTokenPath, "vault-token-path", "", "Path to the file containing the Vault token, required with --secret-backend=vault.")
+	fs.StringVar(&o.VaultMount, "vault-kv-mount", "secret", "Mount path of the KV version 2 secrets engine in Vault.")
+}
+
+// Validate validates the options.
+func (o *Options) Validate(_ bool) error {
+	switch Backend(o.Backend) {
+	case BackendKubernetes:
+	case BackendGCP:
+		if o.GCPProject == "" {
+			return errors.New("--gcp-project is required with --secret-backend=gcp")
+		}
+	case BackendAWS:
+		if o.AWSRegion == "" {
+			return errors.New("--aws-region is required with --secret-backend=aws")
+		}
+	case BackendVault:
+		if o.VaultAddress == "" {
+			return errors.New("--vault-address is required with --secret-backend=vault")
+		}
+		if o.VaultTokenPath == "" {
+			return errors.New("--vault-token-path is required with --secret-backend=vault")
+		}
+		if o.VaultMount == "" {
+			return errors.New("--vault-kv-mount must not be empty")
+		}
+	default:
+		return fmt.Errorf("--secret-backend must be one of %q, got %q", backends, o.Backend)
+	}
+	return nil
+}
+
+// Store returns the store of the secret with the given name in the secret
+// manager of the selected backend. The key selects the value within a Vault
+// secret and is ignored by the other secret managers, which store a single
+// value per secret. Kubernetes Secrets are opened with NewKubernetesStore.
+func (o *Options) Store(ctx context.Context, name, key string) (Store, error) {
+	switch Backend(o.Backend) {
+	case BackendGCP:
+		return newGCPStore(ctx, o.GCPProject, name)
+	case BackendAWS:
+		return newAWSStore(ctx, o.AWSRegion, name)
+	case BackendVault:
+		return newVaultStore(o.VaultAddress, o.VaultTokenPath, o.VaultMount, name, key), nil
+	default:
+		return nil, fmt.Errorf("backend %q has no secret manager", o.Backend)
+	}
+}
+
+// Poll reads the secret and then re-reads it every interval until Prow is
+// interrupted. The returned function returns the latest value that was read.
+func Poll(ctx context.Context, store Store, interval time.Duration) (func() []byte, error) {
+	value, err := store.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var lock sync.RWMutex
+	interrupts.TickLiteral(func() {
+		newValue, err := store.Get(ctx)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to reload secret.")
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		value = newValue
+	}, interval)
+	return func() []byte {
+		lock.RLock()
+		defer lock.RUnlock()
+		return value
+	}, nil
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		options   Options
+		expectErr bool
+	}{
+		{
+			name:    "kubernetes",
+			options: Options{Backend: "kubernetes"},
+		},
+		{
+			name:      "unknown backend",
+			options:   Options{Backend: "azure"},
+			expectErr: true,
+		},
+		{
+			name:    "gcp",
+			options: Options{Backend: "gcp", GCPProject: "project"},
+		},
+		{
+			name:      "gcp without project",
+			options:   Options{Backend: "gcp"},
+			expectErr: true,
+		},
+		{
+			name:    "aws",
+			options: Options{Backend: "aws", AWSRegion: "us-east-1"},
+		},
+		{
+			name:      "aws without region",
+			options:   Options{Backend: "aws"},
+			expectErr: true,
+		},
+		{
+			name:    "vault",
+			options: Options{Backend: "vault", VaultAddress: "https://vault", VaultTokenPath: "/token", VaultMount: "secret"},
+		},
+		{
+			name:      "vault without token",
+			options:   Options{Backend: "vault", VaultAddress: "https://vault", VaultMount: "secret"},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.options.Validate(false)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error: %t, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestKubernetesStore(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "hmac"},
+		Data:       map[string][]byte{"token": []byte("old")},
+	})
+	store := NewKubernetesStore(client, "ns", "hmac", "token")
+	value, err := store.Get(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(value) != "old" {
+		t.Errorf("expected the old value, got %q", value)
+	}
+	if err := store.Update(context.Background(), []byte("new")); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	sec, err := client.CoreV1().Secrets("ns").Get(context.Background(), "hmac", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"token": "new"}, sec.StringData); diff != "" {
+		t.Errorf("unexpected secret data (-want +got):\n%s", diff)
+	}
+
+	if _, err := NewKubernetesStore(client, "ns", "missing", "token").Get(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestVaultStore(t *testing.T) {
+	type secret struct {
+		data    map[string]string
+		version int
+	}
+	secrets := map[string]*secret{
+		"/v1/kv/data/prow/hmac": {data: map[string]string{"token": "old", "other": "value"}, version: 3},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		s, ok := secrets[r.URL.Path]
+		switch r.Method {
+		case http.MethodGet:
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     s.data,
+					"metadata": map[string]int{"version": s.version},
+				},
+			})
+		case http.MethodPost:
+			var req struct {
+				Options struct {
+					CAS int `json:"cas"`
+				} `json:"options"`
+				Data map[string]string `json:"data"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if (ok && req.Options.CAS != s.version) || (!ok && req.Options.CAS != 0) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if !ok {
+				s = &secret{}
+				secrets[r.URL.Path] = s
+			}
+			s.data = req.Data
+			s.version++
+		}
+	}))
+	defer server.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte("vault-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+
+	store := newVaultStore(server.URL, tokenPath, "kv", "prow/hmac", "token")
+	value, err := store.Get(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(value) != "old" {
+		t.Errorf("expected the old value, got %q", value)
+	}
+	if err := store.Update(context.Background(), []byte("new")); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"token": "new", "other": "value"}, secrets["/v1/kv/data/prow/hmac"].data); diff != "" {
+		t.Errorf("expected the other keys to be kept (-want +got):\n%s", diff)
+	}
+
+	missing := newVaultStore(server.URL, tokenPath, "kv", "prow/missing", "token")
+	if _, err := missing.Get(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if err := missing.Update(context.Background(), []byte("created")); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"token": "created"}, secrets["/v1/kv/data/prow/missing"].data); diff != "" {
+		t.Errorf("unexpected created secret (-want +got):\n%s", diff)
+	}
+}
+
+type fakeSecretsManager struct {
+	secrets map[string]string
+}
+
+func (f *fakeSecretsManager) GetSecretValue(_ context.Context, params *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	value, ok := f.secrets[aws.ToString(params.SecretId)]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Secrets Manager can't find the specified secret.")}
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(value)}, nil
+}
+
+func (f *fakeSecretsManager) PutSecretValue(_ context.Context, params *secretsmanager.PutSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error) {
+	if _, ok := f.secrets[aws.ToString(params.SecretId)]; !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Secrets Manager can't find the specified secret.")}
+	}
+	f.secrets[aws.ToString(params.SecretId)] = aws.ToString(params.SecretString)
+	return &secretsmanager.PutSecretValueOutput{}, nil
+}
+
+func TestAWSStore(t *testing.T) {
+	client := &fakeSecretsManager{secrets: map[string]string{"prow-hmac": "old"}}
+	newStore := func(secretID string) Store {
+		return &awsStore{client: client, secretID: secretID}
+	}
+	store := newStore("prow-hmac")
+	value, err := store.Get(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(value) != "old" {
+		t.Errorf("expected the old value, got %q", value)
+	}
+	if err := store.Update(context.Background(), []byte("new")); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	if client.secrets["prow-hmac"] != "new" {
+		t.Errorf("expected the secret to be updated, got %q", client.secrets["prow-hmac"])
+	}
+	if _, err := newStore("missing").Get(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// vaultStore stores the secret in a key of a secret in the KV version 2
+// secrets engine of Vault.
+//
+// See https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2
+type vaultStore struct {
+	client    *http.Client
+	address   string
+	tokenPath string
+	mount     string
+	name      string
+	key       string
+}
+
+func newVaultStore(address, tokenPath, mount, name, key string) Store {
+	return &vaultStore{
+		client:    &http.Client{Timeout: time.Minute},
+		address:   strings.TrimSuffix(address, "/"),
+		tokenPath: tokenPath,
+		mount:     strings.Trim(mount, "/"),
+		name:      strings.Trim(name, "/"),
+		key:       key,
+	}
+}
+
+type vaultSecret struct {
+	Data     map[string]string `json:"data"`
+	Metadata struct {
+		Version int `json:"version"`
+	} `json:"metadata"`
+}
+
+func (s *vaultStore) Get(ctx context.Context) ([]byte, error) {
+	secret, err := s.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[s.key]
+	if !ok {
+		return nil, fmt.Errorf("error getting key %q from the secret %s/%s", s.key, s.mount, s.name)
+	}
+	return []byte(value), nil
+}
+
+// Update replaces the value of the key, keeping the other keys of the secret.
+// The write fails if the secret changed since it was read.
+func (s *vaultStore) Update(ctx context.Context, value []byte) error {
+	secret, err := s.read(ctx)
+	if errors.Is(err, ErrNotFound) {
+		secret, err = &vaultSecret{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string]string{}
+	}
+	secret.Data[s.key] = string(value)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"options": map[string]int{"cas": secret.Metadata.Version},
+		"data":    secret.Data,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.do(ctx, http.MethodPost, body)
+	return err
+}
+
+func (s *vaultStore) read(ctx context.Context) (*vaultSecret, error) {
+	content, err := s.do(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data vaultSecret `json:"data"`
+	}
+	if err := json.Unmarshal(content, &resp); err != nil {
+		return nil, fmt.Errorf("error unmarshaling secret %s/%s: %w", s.mount, s.name, err)
+	}
+	return &resp.Data, nil
+}
+
+func (s *vaultStore) do(ctx context.Context, method string, body []byte) ([]byte, error) {
+	token, err := os.ReadFile(s.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Vault token: %w", err)
+	}
+	url := fmt.Sprintf("%s/v1/%s/data/%s", s.address, s.mount, s.name)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", strings.TrimSpace(string(token)))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: error reading response: %w", method, url, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("secret %s/%s: %w", s.mount, s.name, ErrNotFound)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("%s %s: status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(content)))
+	}
+	return content, nil
+}
@@ -67,27 +67,15 @@ func (o Options) censor() error {
 		errLock.Unlock()
 	}()
 
-	secrets, err := loadSecrets(o.CensoringOptions.SecretDirectories, o.CensoringOptions.IniFilenames)
+	censorer, bufferSize, err := newCensorer(*o.CensoringOptions)
 	if err != nil {
 		// TODO(petr-muller): This return makes the censoring mechanism fragile, single failure in `loadSecrets`
 		// will prevent us from censoring all other secrets that were successfully loaded. Alternatively,
 		// we could be more strict and just bail out at our callsite in run.go:preUpload() instead of just
 		// emitting a warning there. But failing fast combined with just warning about the failure is not
 		// a sound approach for a secret-censoring mechanism.
-		return fmt.Errorf("could not load secrets: %w", err)
+		return err
 	}
-	logrus.WithField("secrets", len(secrets)).Debug("Loaded secrets to censor.")
-	censorer := secretutil.NewCensorer()
-	censorer.RefreshBytes(secrets...)
-
-	bufferSize := defaultBufferSize
-	if o.CensoringOptions.CensoringBufferSize != nil {
-		bufferSize = *o.CensoringOptions.CensoringBufferSize
-	}
-	if largest := censorer.LargestSecret(); 2*largest > bufferSize {
-		bufferSize = 2 * largest
-	}
-	logrus.WithField("buffer_size", bufferSize).Debug("Determined censoring buffer size.")
 	censorFile := fileCensorer(sem, errors, censorer, bufferSize)
 	censor := func(file string) {
 		censorFile(wg, file)
@@ -155,6 +143,28 @@ func (o Options) censor() error {
 	return kerrors.NewAggregate(errs)
 }
 
+// newCensorer loads the secrets to censor and determines the size of the
+// buffer needed to censor them.
+func newCensorer(options CensoringOptions) (secretutil.Censorer, int, error) {
+	secrets, err := loadSecrets(options.SecretDirectories, options.IniFilenames)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not load secrets: %w", err)
+	}
+	logrus.WithField("secrets", len(secrets)).Debug("Loaded secrets to censor.")
+	censorer := secretutil.NewCensorer()
+	censorer.RefreshBytes(secrets...)
+
+	bufferSize := defaultBufferSize
+	if options.CensoringBufferSize != nil {
+		bufferSize = *options.CensoringBufferSize
+	}
+	if largest := censorer.LargestSecret(); 2*largest > bufferSize {
+		bufferSize = 2 * largest
+	}
+	logrus.WithField("buffer_size", bufferSize).Debug("Determined censoring buffer size.")
+	return censorer, bufferSize, nil
+}
+
 func shouldCensor(options CensoringOptions, path string) (bool, error) {
 	for _, glob := range options.ExcludeDirectories {
 		found, err := zglob.Match(glob, path)
@@ -477,6 +487,16 @@ func censor(input io.ReadCloser, output io.WriteCloser, censorer secretutil.Cens
 	return nil
 }
 
+func nopWriteCloser(w io.Writer) io.WriteCloser {
+	return &nopCloser{Writer: w}
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
 // loadSecrets loads all files under the paths into memory
 func loadSecrets(paths, iniFilenames []string) ([][]byte, error) {
 	var secrets [][]byte
@@ -78,16 +78,6 @@ func TestCensor(t *testing.T) {
 
 }
 
-func nopWriteCloser(w io.Writer) io.WriteCloser {
-	return &nopCloser{Writer: w}
-}
-
-type nopCloser struct {
-	io.Writer
-}
-
-func (nopCloser) Close() error { return nil }
-
 const inputDir = "testdata/input"
 
 func copyTestData(t *testing.T) string {
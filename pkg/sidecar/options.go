@@ -21,8 +21,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/prow/pkg/gcsupload"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 )
 
@@ -83,6 +85,16 @@ type Options struct {
 	// CensoringOptions are options that pertain to censoring output before upload.
 	CensoringOptions *CensoringOptions `json:"censoring_options,omitempty"`
 
+	// LogUploadInterval is how often the build logs of the entries are
+	// uploaded while they run, so they can be viewed from blob storage
+	// before the job finished. If unset, build logs are uploaded once all
+	// entries finished.
+	LogUploadInterval time.Duration `json:"log_upload_interval,omitempty"`
+
+	// Cache configures saving the cache of the job after all entries passed.
+	// If unspecified, no cache is saved.
+	Cache *cache.Options `json:"cache,omitempty"`
+
 	// SecretDirectories is deprecated, use censoring_options.secret_directories instead.
 	SecretDirectories []string `json:"secret_directories,omitempty"`
 	// CensoringConcurrency is deprecated, use censoring_options.censoring_concurrency instead.
@@ -150,6 +162,10 @@ func (o *Options) Validate() error {
 		o.CensoringOptions = &opts
 	}
 
+	if o.LogUploadInterval < 0 {
+		return fmt.Errorf("log upload interval %s is negative", o.LogUploadInterval)
+	}
+
 	ents := o.entries()
 	if len(ents) == 0 {
 		return errors.New("no wrapper.Option entries")
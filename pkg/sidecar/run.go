@@ -25,6 +25,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -32,13 +33,17 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/prow/pkg/flagutil"
+	pkgio "sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+	"sigs.k8s.io/prow/pkg/secretutil"
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/entrypoint"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
+	"sigs.k8s.io/prow/pkg/pod-utils/usage"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 
 	testgridmetadata "github.com/GoogleCloudPlatform/testgrid/metadata"
@@ -137,9 +142,21 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 		}
 	}()
 
+	var streaming sync.WaitGroup
+	if o.LogUploadInterval > 0 {
+		streaming.Add(1)
+		go func() {
+			defer streaming.Done()
+			o.streamLogs(ctx, spec, entries)
+		}()
+	}
+
 	passed, aborted, failures := wait(ctx, entries)
 
 	cancel()
+	// A streamed upload still in flight must not overwrite the complete
+	// build logs uploaded below.
+	streaming.Wait()
 	// If we are being asked to terminate by the kubelet but we have
 	// seen the test process exit cleanly, we need a chance to upload
 	// artifacts to GCS. The only valid way for this program to exit
@@ -151,11 +168,120 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 
 	buildLogs := logReadersFuncs(entries)
 	metadata := combineMetadata(entries)
-	return failures, o.doUpload(context.Background(), spec, passed, aborted, metadata, buildLogs, logFile, &once)
+	err = o.doUpload(context.Background(), spec, passed, aborted, metadata, buildLogs, logFile, &once)
+
+	if passed && o.Cache != nil {
+		// The cache only speeds up later runs, so failing to save it doesn't
+		// fail the job.
+		if err := o.saveCache(context.Background()); err != nil {
+			logrus.WithError(err).Warn("Failed to save cache.")
+		}
+	}
+	return failures, err
+}
+
+func (o Options) saveCache(ctx context.Context) error {
+	if o.GcsOptions.DryRun {
+		logrus.WithField("storage", o.Cache.Storage).Info("Would save cache")
+		return nil
+	}
+	opener, err := pkgio.NewOpener(ctx, o.GcsOptions.StorageClientOptions.GCSCredentialsFile, o.GcsOptions.StorageClientOptions.S3CredentialsFile, o.GcsOptions.StorageClientOptions.AzureCredentialsFile)
+	if err != nil {
+		return fmt.Errorf("new opener: %w", err)
+	}
+	return o.Cache.Save(ctx, opener)
+}
+
+// streamLogs uploads the build logs of the entries every LogUploadInterval
+// until the context is cancelled, so the logs of running jobs can be viewed
+// from blob storage. Logs are only uploaded again once they grew.
+func (o Options) streamLogs(ctx context.Context, spec *downwardapi.JobSpec, entries []wrapper.Options) {
+	var censorer secretutil.Censorer
+	var bufferSize int
+	if o.CensoringOptions != nil {
+		var err error
+		if censorer, bufferSize, err = newCensorer(*o.CensoringOptions); err != nil {
+			// Uncensored logs must never be uploaded, so wait for the logs to
+			// be censored once all entries finished instead.
+			logrus.WithError(err).Warn("Failed to set up censoring, not streaming build logs.")
+			return
+		}
+	}
+	// Artifacts are only uploaded once all entries finished.
+	gcsOptions := *o.GcsOptions
+	gcsOptions.Items = nil
+
+	uploaded := map[string]int64{}
+	ticker := time.NewTicker(o.LogUploadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		uploadTargets := map[string]gcs.UploadFunc{}
+		sizes := map[string]int64{}
+		for _, opt := range entries {
+			name := buildLogName(entries, opt)
+			info, err := os.Stat(opt.ProcessLog)
+			if err != nil || info.Size() == uploaded[name] {
+				continue
+			}
+			sizes[name] = info.Size()
+			uploadTargets[name] = gcs.DataUpload(censoredReader(opt.ProcessLog, censorer, bufferSize))
+		}
+		if len(uploadTargets) == 0 {
+			continue
+		}
+		if err := gcsOptions.Run(ctx, spec, uploadTargets); err != nil {
+			if ctx.Err() == nil {
+				logrus.WithError(err).Warn("Failed to upload build logs.")
+			}
+			continue
+		}
+		for name, size := range sizes {
+			uploaded[name] = size
+		}
+	}
+}
+
+// censoredReader returns a ReaderFunc reading the file at the given path,
+// censored by the censorer if set.
+func censoredReader(path string, censorer secretutil.Censorer, bufferSize int) gcs.ReaderFunc {
+	return func() (io.ReadCloser, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		if censorer == nil {
+			return f, nil
+		}
+		r, w := io.Pipe()
+		go func() {
+			// censor closes its output, so close the pipe here instead to
+			// hand any error to the reader rather than ending the log early
+			w.CloseWithError(censor(f, nopWriteCloser(w), censorer, bufferSize))
+		}()
+		return r, nil
+	}
 }
 
 const errorKey = "sidecar-errors"
 
+// stepsKey is the metadata key holding the results of the steps run by the entrypoint.
+const stepsKey = "steps"
+
+// buildLogName returns the name the build log of the given entry is uploaded
+// as.
+func buildLogName(entries []wrapper.Options, opt wrapper.Options) string {
+	if len(entries) > 1 {
+		return fmt.Sprintf("%s-build-log.txt", opt.ContainerName)
+	}
+	return "build-log.txt"
+}
+
 func logReadersFuncs(entries []wrapper.Options) map[string]gcs.ReaderFunc {
 	readerFuncs := make(map[string]gcs.ReaderFunc)
 	for _, opt := range entries {
@@ -170,11 +296,7 @@ func logReadersFuncs(entries []wrapper.Options) map[string]gcs.ReaderFunc {
 				return log, nil
 			}
 		}
-		buildLog := "build-log.txt"
-		if len(entries) > 1 {
-			buildLog = fmt.Sprintf("%s-build-log.txt", opt.ContainerName)
-		}
-		readerFuncs[buildLog] = f
+		readerFuncs[buildLogName(entries, opt)] = f
 	}
 	return readerFuncs
 }
@@ -182,8 +304,17 @@ func logReadersFuncs(entries []wrapper.Options) map[string]gcs.ReaderFunc {
 func combineMetadata(entries []wrapper.Options) map[string]interface{} {
 	errors := map[string]error{}
 	metadata := map[string]interface{}{}
+	var steps []interface{}
 	for i, opt := range entries {
 		ent := nameEntry(i, opt)
+		if opt.StepsFile != "" {
+			results, err := readStepResults(opt.StepsFile)
+			if err != nil {
+				logrus.WithError(err).Errorf("Failed to read step results from %s", opt.StepsFile)
+				errors[ent] = err
+			}
+			steps = append(steps, results...)
+		}
 		metadataFile := opt.MetadataFile
 		if _, err := os.Stat(metadataFile); err != nil {
 			if !os.IsNotExist(err) {
@@ -210,12 +341,57 @@ func combineMetadata(entries []wrapper.Options) map[string]interface{} {
 			metadata[k] = v // TODO(fejta): consider deeper merge
 		}
 	}
+	if len(steps) > 0 {
+		metadata[stepsKey] = steps
+	}
 	if len(errors) > 0 {
 		metadata[errorKey] = errors
 	}
 	return metadata
 }
 
+// readStepResults reads the results of the steps run by the entrypoint,
+// which do not exist if it did not run any.
+func readStepResults(stepsFile string) ([]interface{}, error) {
+	raw, err := os.ReadFile(stepsFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var results []interface{}
+	if err := json.Unmarshal(raw, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// resourceUsage collects the resource usage sampled by the entrypoint in the
+// test containers. It returns nil if usage was not sampled.
+func resourceUsage(entries []wrapper.Options) *usage.Report {
+	var report usage.Report
+	for _, opt := range entries {
+		if opt.UsageFile == "" {
+			continue
+		}
+		u, err := usage.Read(opt.UsageFile)
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to read resource usage from %s", opt.UsageFile)
+			continue
+		}
+		if u == nil {
+			continue
+		}
+		u.Container = opt.ContainerName
+		report.Containers = append(report.Containers, *u)
+	}
+	if len(report.Containers) == 0 {
+		return nil
+	}
+	return &report
+}
+
 // preUpload performs steps required before actual upload
 func (o Options) preUpload() {
 	if o.DeprecatedWrapperOptions != nil {
@@ -297,9 +473,100 @@ func (o Options) doUpload(ctx context.Context, spec *downwardapi.JobSpec, passed
 		uploadTargets[prowv1.FinishedStatusFile] = gcs.DataUpload(newReader)
 	}
 
+	summary, err := results.Summarize(o.GcsOptions.Items...)
+	if err != nil {
+		logrus.WithError(err).Warn("Could not summarize test results")
+		summary = nil
+	} else if !summary.Empty() {
+		summaryData, err := json.Marshal(summary)
+		if err != nil {
+			logrus.WithError(err).Warn("Could not marshal test results summary")
+		} else {
+			uploadTargets[prowv1.ResultsSummaryFile] = gcs.DataUpload(func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(summaryData)), nil
+			})
+		}
+	}
+
+	if report := resourceUsage(o.entries()); report != nil {
+		usageData, err := json.Marshal(report)
+		if err != nil {
+			logrus.WithError(err).Warn("Could not marshal resource usage")
+		} else {
+			uploadTargets[prowv1.ResourceUsageFile] = gcs.DataUpload(func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(usageData)), nil
+			})
+		}
+	}
+
+	o.writeTerminationMessage(summary, !passed && !aborted)
+
 	if err := o.GcsOptions.Run(ctx, spec, uploadTargets); err != nil {
 		return fmt.Errorf("failed to upload to GCS: %w", err)
 	}
 
 	return nil
 }
+
+// terminationMessagePath is where kubernetes reads the termination message
+// of the sidecar container from.
+var terminationMessagePath = "/dev/termination-log"
+
+// writeTerminationMessage records the test summary and why the job failed as
+// termination message, so the ProwJob controller can add them to the status
+// of the ProwJob without parsing the build log.
+func (o Options) writeTerminationMessage(summary *results.Summary, commandFailed bool) {
+	var failedLog string
+	if commandFailed {
+		failedLog = o.failedLogTail()
+	}
+	message := results.NewTerminationMessage(summary, commandFailed, failedLog)
+	if message.Empty() {
+		return
+	}
+	raw, err := json.Marshal(message)
+	if err != nil {
+		logrus.WithError(err).Warn("Could not marshal termination message")
+		return
+	}
+	// The kubelet creates the file, it is missing if we don't run in a pod.
+	f, err := os.OpenFile(terminationMessagePath, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		logrus.WithError(err).Debug("Not writing termination message")
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(raw); err != nil {
+		logrus.WithError(err).Warn("Could not write termination message")
+	}
+}
+
+// failedLogTail returns the end of the build log of the first entry that
+// failed. Build logs are censored by then.
+func (o Options) failedLogTail() string {
+	for _, entry := range o.entries() {
+		raw, err := os.ReadFile(entry.MarkerFile)
+		if err != nil {
+			continue
+		}
+		if code, err := strconv.Atoi(strings.TrimSpace(string(raw))); err != nil || code == 0 || code == entrypoint.PreviousErrorCode {
+			continue
+		}
+		f, err := os.Open(entry.ProcessLog)
+		if err != nil {
+			return ""
+		}
+		defer f.Close()
+		if info, err := f.Stat(); err == nil && info.Size() > results.MaxErrorSnippetLength {
+			if _, err := f.Seek(info.Size()-results.MaxErrorSnippetLength, io.SeekStart); err != nil {
+				return ""
+			}
+		}
+		tail, err := io.ReadAll(f)
+		if err != nil {
+			return ""
+		}
+		return string(tail)
+	}
+	return ""
+}
@@ -31,10 +31,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/prow/pkg/entrypoint"
 	"sigs.k8s.io/prow/pkg/gcsupload"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
+	"sigs.k8s.io/prow/pkg/pod-utils/usage"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -383,10 +386,69 @@ func TestCombineMetadata(t *testing.T) {
 	}
 }
 
+func TestCombineMetadataSteps(t *testing.T) {
+	tmpDir := t.TempDir()
+	metadataFile := path.Join(tmpDir, "metadata.json")
+	if err := os.WriteFile(metadataFile, []byte(`{"hello": "world"}`), 0600); err != nil {
+		t.Fatalf("could not create metadata: %v", err)
+	}
+	stepsFile := path.Join(tmpDir, "steps.json")
+	if err := os.WriteFile(stepsFile, []byte(`[{"name": "test", "exit_code": 1}, {"name": "teardown", "exit_code": 0}]`), 0600); err != nil {
+		t.Fatalf("could not create step results: %v", err)
+	}
+
+	actual := combineMetadata([]wrapper.Options{{MetadataFile: metadataFile, StepsFile: stepsFile}})
+	expected := map[string]interface{}{
+		"hello": "world",
+		stepsKey: []interface{}{
+			map[string]interface{}{"name": "test", "exit_code": 1.0},
+			map[string]interface{}{"name": "teardown", "exit_code": 0.0},
+		},
+	}
+	if !equality.Semantic.DeepEqual(expected, actual) {
+		t.Errorf("maps do not match:\n%s", diff.ObjectReflectDiff(expected, actual))
+	}
+
+	missing := combineMetadata([]wrapper.Options{{MetadataFile: metadataFile, StepsFile: path.Join(tmpDir, "missing.json")}})
+	if _, ok := missing[stepsKey]; ok {
+		t.Errorf("expected no step results when the steps file is missing, got %v", missing[stepsKey])
+	}
+	if _, ok := missing[errorKey]; ok {
+		t.Errorf("expected no errors when the steps file is missing, got %v", missing[errorKey])
+	}
+}
+
 func name(idx int) string {
 	return nameEntry(idx, wrapper.Options{})
 }
 
+func TestResourceUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+	sampled := usage.Usage{
+		CPULimitCores: 2,
+		Samples:       []usage.Sample{{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), CPUCores: 1.5, MemoryBytes: 1024}},
+	}
+	testFile := path.Join(tmpDir, "test-usage.json")
+	if err := usage.Write(testFile, sampled); err != nil {
+		t.Fatalf("could not write usage: %v", err)
+	}
+
+	report := resourceUsage([]wrapper.Options{
+		{ContainerName: "test", UsageFile: testFile},
+		{ContainerName: "missing", UsageFile: path.Join(tmpDir, "missing-usage.json")},
+		{ContainerName: "unsampled"},
+	})
+	sampled.Container = "test"
+	expected := &usage.Report{Containers: []usage.Usage{sampled}}
+	if diff := cmp.Diff(expected, report); diff != "" {
+		t.Errorf("resource usage differs from expected (-want +got):\n%s", diff)
+	}
+
+	if report := resourceUsage([]wrapper.Options{{ContainerName: "unsampled"}}); report != nil {
+		t.Errorf("expected no resource usage if it was not sampled, got %v", report)
+	}
+}
+
 func TestLogReaders(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -574,3 +636,221 @@ func TestSideCarLogsUpload(t *testing.T) {
 	}
 
 }
+
+func TestStreamLogs(t *testing.T) {
+	secretDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(secretDir, "password"), []byte("hunter2"), 0600); err != nil {
+		t.Fatalf("Unable to write secret: %v", err)
+	}
+	processLog := filepath.Join(t.TempDir(), "process-log.txt")
+	if err := os.WriteFile(processLog, []byte("logging in with hunter2\n"), 0600); err != nil {
+		t.Fatalf("Unable to write process log: %v", err)
+	}
+
+	localOutputDir := t.TempDir()
+	options := Options{
+		GcsOptions: &gcsupload.Options{
+			Items: []string{t.TempDir()},
+			GCSConfiguration: &prowapi.GCSConfiguration{
+				PathStrategy:   prowapi.PathStrategyExplicit,
+				Bucket:         "bucket",
+				LocalOutputDir: localOutputDir,
+			},
+		},
+		Entries:           []wrapper.Options{{ProcessLog: processLog}},
+		CensoringOptions:  &CensoringOptions{SecretDirectories: []string{secretDir}},
+		LogUploadInterval: 10 * time.Millisecond,
+	}
+	spec := &downwardapi.JobSpec{
+		Job:     "job",
+		Type:    prowapi.PeriodicJob,
+		BuildID: "build",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		options.streamLogs(ctx, spec, options.entries())
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	expectUploaded := func(expected string) {
+		t.Helper()
+		var actual string
+		for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+			raw, err := os.ReadFile(filepath.Join(localOutputDir, "build-log.txt"))
+			if actual = string(raw); err == nil && actual == expected {
+				return
+			}
+		}
+		t.Fatalf("expected build log %q to be uploaded, got %q", expected, actual)
+	}
+	expectUploaded("logging in with XXXXXXX\n")
+
+	f, err := os.OpenFile(processLog, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("Unable to open process log: %v", err)
+	}
+	if _, err := f.WriteString("done\n"); err != nil {
+		t.Fatalf("Unable to append to process log: %v", err)
+	}
+	f.Close()
+	expectUploaded("logging in with XXXXXXX\ndone\n")
+}
+
+func TestResultsSummaryUpload(t *testing.T) {
+	logFile, err := os.CreateTemp(t.TempDir(), "sidecar-logs*.txt")
+	if err != nil {
+		t.Fatalf("Unable to create log file: %v", err)
+	}
+	var once sync.Once
+
+	artifactsDir := t.TempDir()
+	junitXML := `<testsuite><testcase name="good"/><testcase name="bad"><failure message="boom"/></testcase></testsuite>`
+	if err := os.WriteFile(filepath.Join(artifactsDir, "junit_01.xml"), []byte(junitXML), 0644); err != nil {
+		t.Fatalf("Unable to write junit artifact: %v", err)
+	}
+
+	localOutputDir := t.TempDir()
+	options := Options{
+		GcsOptions: &gcsupload.Options{
+			Items: []string{artifactsDir},
+			GCSConfiguration: &prowapi.GCSConfiguration{
+				PathStrategy:   prowapi.PathStrategyExplicit,
+				Bucket:         "bucket",
+				LocalOutputDir: localOutputDir,
+			},
+		},
+	}
+	spec := &downwardapi.JobSpec{
+		Job:     "job",
+		Type:    prowapi.PeriodicJob,
+		BuildID: "build",
+	}
+
+	if err := options.doUpload(context.Background(), spec, false, false, nil, nil, logFile, &once); err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(localOutputDir, prowapi.ResultsSummaryFile))
+	if err != nil {
+		t.Fatalf("Unable to read results summary: %v", err)
+	}
+	summary, err := results.Parse(raw)
+	if err != nil {
+		t.Fatalf("Unable to parse results summary: %v", err)
+	}
+	if summary.Total != 2 || summary.Passed != 1 || summary.Failed != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if len(summary.Failures) != 1 || summary.Failures[0].Name != "bad" {
+		t.Errorf("expected failure of test bad, got %+v", summary.Failures)
+	}
+}
+
+func TestTerminationMessage(t *testing.T) {
+	testCases := []struct {
+		name       string
+		junit      string
+		markerCode string
+		passed     bool
+		aborted    bool
+		expected   *results.TerminationMessage
+	}{
+		{
+			name:       "passed without tests",
+			markerCode: "0",
+			passed:     true,
+		},
+		{
+			name:       "failed tests",
+			junit:      `<testsuite><testcase name="good"/><testcase name="bad"><failure message="boom"/></testcase></testsuite>`,
+			markerCode: "1",
+			expected: &results.TerminationMessage{
+				FailureReason: prowapi.TestsFailedReason,
+				TestSummary:   &prowapi.TestSummary{Total: 2, Passed: 1, Failed: 1},
+				ErrorSnippet:  "bad: boom",
+			},
+		},
+		{
+			name:       "failed command",
+			markerCode: "2",
+			expected: &results.TerminationMessage{
+				FailureReason: prowapi.CommandFailedReason,
+				ErrorSnippet:  "building\nbuild failed",
+			},
+		},
+		{
+			name:       "aborted",
+			markerCode: strconv.Itoa(entrypoint.AbortedErrorCode),
+			aborted:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			artifactsDir := filepath.Join(dir, "artifacts")
+			if err := os.Mkdir(artifactsDir, 0755); err != nil {
+				t.Fatalf("Unable to create artifacts dir: %v", err)
+			}
+			if tc.junit != "" {
+				if err := os.WriteFile(filepath.Join(artifactsDir, "junit.xml"), []byte(tc.junit), 0644); err != nil {
+					t.Fatalf("Unable to write junit artifact: %v", err)
+				}
+			}
+			entry := wrapper.Options{
+				ProcessLog: filepath.Join(dir, "process-log.txt"),
+				MarkerFile: filepath.Join(dir, "marker-file.txt"),
+			}
+			if err := os.WriteFile(entry.ProcessLog, []byte("building\nbuild failed\n"), 0644); err != nil {
+				t.Fatalf("Unable to write process log: %v", err)
+			}
+			if err := os.WriteFile(entry.MarkerFile, []byte(tc.markerCode), 0644); err != nil {
+				t.Fatalf("Unable to write marker file: %v", err)
+			}
+			messagePath := filepath.Join(dir, "termination-log")
+			if err := os.WriteFile(messagePath, nil, 0644); err != nil {
+				t.Fatalf("Unable to create termination log: %v", err)
+			}
+			originalPath := terminationMessagePath
+			terminationMessagePath = messagePath
+			defer func() { terminationMessagePath = originalPath }()
+
+			options := Options{
+				GcsOptions: &gcsupload.Options{Items: []string{artifactsDir}},
+				Entries:    []wrapper.Options{entry},
+			}
+			options.writeTerminationMessage(summarize(t, artifactsDir), !tc.passed && !tc.aborted)
+
+			raw, err := os.ReadFile(messagePath)
+			if err != nil {
+				t.Fatalf("Unable to read termination log: %v", err)
+			}
+			if tc.expected == nil {
+				if len(raw) != 0 {
+					t.Errorf("expected no termination message, got %s", raw)
+				}
+				return
+			}
+			actual, err := results.ParseTerminationMessage(string(raw))
+			if err != nil {
+				t.Fatalf("Unable to parse termination message: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(tc.expected, actual) {
+				t.Errorf("unexpected termination message: %s", diff.ObjectReflectDiff(tc.expected, actual))
+			}
+		})
+	}
+}
+
+func summarize(t *testing.T, dir string) *results.Summary {
+	summary, err := results.Summarize(dir)
+	if err != nil {
+		t.Fatalf("Unable to summarize results: %v", err)
+	}
+	return summary
+}
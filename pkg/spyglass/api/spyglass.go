@@ -92,3 +92,30 @@ type LensRequest struct {
 	// TODO: Replace with something proper or avoid needing this
 	LensIndex int `json:"index"`
 }
+
+// MetadataPath is the path below the endpoint of a remote lens on which it
+// serves its LensMetadata, see deck.spyglass.lens_discovery.
+const MetadataPath = "/metadata"
+
+// LensMetadata describes a remote lens, so Deck can register it without it
+// being listed in its config.
+type LensMetadata struct {
+	// Name is the name of the lens. It must be unique across all lenses.
+	Name string `json:"name"`
+	// Title is the human-readable title of the lens. Defaults to the name.
+	Title string `json:"title,omitempty"`
+	// Priority is used for lens ordering, lowest priority first.
+	Priority uint `json:"priority,omitempty"`
+	// HideTitle defines if the title is hidden after the lens loads.
+	HideTitle bool `json:"hide_title,omitempty"`
+	// RequiredFiles is a list of regexes of file paths that must all be
+	// present for the lens to appear.
+	RequiredFiles []string `json:"required_files"`
+	// OptionalFiles is a list of regexes of file paths that are provided to
+	// the lens if present.
+	OptionalFiles []string `json:"optional_files,omitempty"`
+	// StaticRoot is the endpoint for static resources of the lens.
+	StaticRoot string `json:"static_root,omitempty"`
+	// Config is passed to the lens with every request.
+	Config json.RawMessage `json:"config,omitempty"`
+}
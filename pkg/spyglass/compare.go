@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+// Comparison is the output of a lens comparing the artifacts of two runs.
+type Comparison struct {
+	Name  string
+	Title string
+	// ArtifactsA and ArtifactsB are the artifacts the lens compared.
+	ArtifactsA []string
+	ArtifactsB []string
+	// Diff is the rendered output of the lens.
+	Diff template.HTML
+
+	priority uint
+}
+
+// Compare renders the differences between the artifacts of the runs at srcA
+// and srcB with every configured lens that supports it. Lenses only compare
+// runs if their required files are present in both. resourceDir is the
+// directory that contains the resources of all lenses.
+func (sg *Spyglass) Compare(ctx context.Context, srcA, srcB, resourceDir string) ([]Comparison, error) {
+	var names [2][]string
+	for i, src := range []*string{&srcA, &srcB} {
+		*src = strings.TrimSuffix(*src, "/")
+		realPath, err := sg.ResolveSymlink(*src)
+		if err != nil {
+			return nil, fmt.Errorf("error when resolving real path %s: %w", *src, err)
+		}
+		*src = realPath
+		names[i], err = sg.ListArtifacts(ctx, *src)
+		if err != nil {
+			return nil, fmt.Errorf("error listing artifacts of %s: %w", *src, err)
+		}
+	}
+
+	spyglassConfig := sg.config().Deck.Spyglass
+	seen := sets.New[string]()
+	var comparisons []Comparison
+	for _, lfc := range spyglassConfig.Lenses {
+		if seen.Has(lfc.Lens.Name) {
+			continue
+		}
+		lens, err := lenses.GetLens(lfc.Lens.Name)
+		if err != nil {
+			continue
+		}
+		diffLens, ok := lens.(lenses.DiffLens)
+		if !ok {
+			continue
+		}
+		matchesA, okA := LensArtifacts(lfc, spyglassConfig.RegexCache, names[0])
+		matchesB, okB := LensArtifacts(lfc, spyglassConfig.RegexCache, names[1])
+		if !okA || !okB {
+			continue
+		}
+		seen.Insert(lfc.Lens.Name)
+
+		artifactsA, err := sg.FetchArtifacts(ctx, srcA, "", spyglassConfig.SizeLimit, matchesA)
+		if err != nil {
+			logrus.WithError(err).WithField("src", srcA).WithField("lens", lfc.Lens.Name).Warn("Failed to fetch artifacts for comparison.")
+			continue
+		}
+		artifactsB, err := sg.FetchArtifacts(ctx, srcB, "", spyglassConfig.SizeLimit, matchesB)
+		if err != nil {
+			logrus.WithError(err).WithField("src", srcB).WithField("lens", lfc.Lens.Name).Warn("Failed to fetch artifacts for comparison.")
+			continue
+		}
+		title := lens.Config().Title
+		if lfc.RemoteConfig != nil && lfc.RemoteConfig.Title != "" {
+			title = lfc.RemoteConfig.Title
+		}
+		comparisons = append(comparisons, Comparison{
+			Name:       lfc.Lens.Name,
+			Title:      title,
+			ArtifactsA: matchesA,
+			ArtifactsB: matchesB,
+			// The lenses are part of Prow and escape the content of the artifacts.
+			Diff:     template.HTML(diffLens.Diff(artifactsA, artifactsB, lenses.ResourceDirForLens(resourceDir, lfc.Lens.Name), lfc.Lens.Config, spyglassConfig)),
+			priority: lens.Config().Priority,
+		})
+	}
+	sort.SliceStable(comparisons, func(i, j int) bool { return comparisons[i].priority < comparisons[j].priority })
+	return comparisons, nil
+}
+
+// LensArtifacts returns the artifacts the lens should be given and whether
+// all of its required files are present.
+func LensArtifacts(lfc config.LensFileConfig, regexCache map[string]*regexp.Regexp, artifactNames []string) ([]string, bool) {
+	matches := sets.Set[string]{}
+	for _, re := range lfc.RequiredFiles {
+		found := false
+		for _, a := range artifactNames {
+			if regexCache[re].MatchString(a) {
+				matches.Insert(a)
+				found = true
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	for _, re := range lfc.OptionalFiles {
+		for _, a := range artifactNames {
+			if regexCache[re].MatchString(a) {
+				matches.Insert(a)
+			}
+		}
+	}
+	return sets.List(matches), true
+}
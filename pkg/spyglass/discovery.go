@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+)
+
+// LensDiscoverer fetches the metadata of the remote lenses configured under
+// deck.spyglass.lens_discovery and exposes them as if they were configured
+// under deck.spyglass.lenses.
+type LensDiscoverer struct {
+	conf   config.Getter
+	client *http.Client
+
+	mut sync.Mutex
+	// discovered holds the last successfully discovered lens by endpoint.
+	discovered map[string]config.LensFileConfig
+	// generation is incremented whenever discovered changes.
+	generation int
+
+	cachedBase       *config.Config
+	cachedGeneration int
+	cached           *config.Config
+}
+
+// NewLensDiscoverer returns a LensDiscoverer for the lenses configured in cfg.
+func NewLensDiscoverer(cfg config.Getter) *LensDiscoverer {
+	return &LensDiscoverer{
+		conf:       cfg,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		discovered: map[string]config.LensFileConfig{},
+	}
+}
+
+// Sync fetches the metadata of all configured lenses. If the metadata of a
+// lens can not be fetched, the previously discovered one is kept.
+func (d *LensDiscoverer) Sync() {
+	var endpoints []string
+	if discovery := d.conf().Deck.Spyglass.LensDiscovery; discovery != nil {
+		endpoints = discovery.Endpoints
+	}
+
+	discovered := map[string]config.LensFileConfig{}
+	d.mut.Lock()
+	for _, endpoint := range endpoints {
+		if lfc, ok := d.discovered[endpoint]; ok {
+			discovered[endpoint] = lfc
+		}
+	}
+	d.mut.Unlock()
+
+	for _, endpoint := range endpoints {
+		lfc, err := d.discover(endpoint)
+		if err != nil {
+			logrus.WithError(err).WithField("endpoint", endpoint).Warn("Couldn't discover remote lens.")
+			continue
+		}
+		discovered[endpoint] = *lfc
+	}
+
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	d.discovered = discovered
+	d.generation++
+}
+
+func (d *LensDiscoverer) discover(endpoint string) (*config.LensFileConfig, error) {
+	resp, err := d.client.Get(strings.TrimSuffix(endpoint, "/") + api.MetadataPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata request failed with status %q: %s", resp.Status, string(body))
+	}
+	var metadata api.LensMetadata
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return lensFileConfigFromMetadata(endpoint, metadata)
+}
+
+func lensFileConfigFromMetadata(endpoint string, metadata api.LensMetadata) (*config.LensFileConfig, error) {
+	if metadata.Name == "" {
+		return nil, errors.New("the metadata has no name")
+	}
+	if len(metadata.RequiredFiles) == 0 {
+		return nil, fmt.Errorf("lens %q requires no files", metadata.Name)
+	}
+	for _, re := range append(append([]string{}, metadata.RequiredFiles...), metadata.OptionalFiles...) {
+		if _, err := regexp.Compile(re); err != nil {
+			return nil, fmt.Errorf("lens %q has an invalid file regexp: %w", metadata.Name, err)
+		}
+	}
+	parsedEndpoint, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url %q for remote lens %q: %w", endpoint, metadata.Name, err)
+	}
+	title := metadata.Title
+	if title == "" {
+		title = metadata.Name
+	}
+	priority, hideTitle := metadata.Priority, metadata.HideTitle
+	return &config.LensFileConfig{
+		RequiredFiles: metadata.RequiredFiles,
+		OptionalFiles: metadata.OptionalFiles,
+		Lens: config.LensConfig{
+			Name:   metadata.Name,
+			Config: metadata.Config,
+		},
+		RemoteConfig: &config.LensRemoteConfig{
+			Endpoint:       endpoint,
+			ParsedEndpoint: parsedEndpoint,
+			StaticRoot:     metadata.StaticRoot,
+			Title:          title,
+			Priority:       &priority,
+			HideTitle:      &hideTitle,
+		},
+	}, nil
+}
+
+// Config returns the config with the discovered lenses appended to
+// deck.spyglass.lenses. Lenses are referenced by their index, so the
+// discovered ones are sorted by name to keep the order stable.
+func (d *LensDiscoverer) Config() *config.Config {
+	base := d.conf()
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	if len(d.discovered) == 0 {
+		return base
+	}
+	if d.cachedBase == base && d.cachedGeneration == d.generation {
+		return d.cached
+	}
+
+	configured := map[string]bool{}
+	for _, lfc := range base.Deck.Spyglass.Lenses {
+		configured[lfc.Lens.Name] = true
+	}
+	var discovered []config.LensFileConfig
+	for endpoint, lfc := range d.discovered {
+		if configured[lfc.Lens.Name] {
+			logrus.WithField("endpoint", endpoint).WithField("lens", lfc.Lens.Name).Debug("Ignoring discovered lens with the name of a configured lens.")
+			continue
+		}
+		configured[lfc.Lens.Name] = true
+		discovered = append(discovered, lfc)
+	}
+	sort.Slice(discovered, func(i, j int) bool { return discovered[i].Lens.Name < discovered[j].Lens.Name })
+
+	merged := *base
+	merged.Deck.Spyglass.Lenses = append(append([]config.LensFileConfig{}, base.Deck.Spyglass.Lenses...), discovered...)
+	merged.Deck.Spyglass.RegexCache = make(map[string]*regexp.Regexp, len(base.Deck.Spyglass.RegexCache))
+	for re, compiled := range base.Deck.Spyglass.RegexCache {
+		merged.Deck.Spyglass.RegexCache[re] = compiled
+	}
+	for _, lfc := range discovered {
+		for _, re := range append(append([]string{}, lfc.RequiredFiles...), lfc.OptionalFiles...) {
+			if _, ok := merged.Deck.Spyglass.RegexCache[re]; !ok {
+				// Already validated when the lens was discovered.
+				merged.Deck.Spyglass.RegexCache[re] = regexp.MustCompile(re)
+			}
+		}
+	}
+
+	d.cachedBase, d.cachedGeneration, d.cached = base, d.generation, &merged
+	return d.cached
+}
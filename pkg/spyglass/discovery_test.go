@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+)
+
+func TestLensDiscoverer(t *testing.T) {
+	metadata := map[string]*api.LensMetadata{
+		"/flakes/metadata":   {Name: "flakes", Title: "Flaky Tests", Priority: 5, RequiredFiles: []string{`^artifacts/flakes\.json$`}},
+		"/buildlog/metadata": {Name: "buildlog", RequiredFiles: []string{`.*`}},
+		"/broken/metadata":   {Name: "broken", RequiredFiles: []string{`(`}},
+	}
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m, ok := metadata[r.URL.Path]
+		if !ok || !healthy {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(m)
+	}))
+	defer server.Close()
+
+	base := &config.Config{ProwConfig: config.ProwConfig{Deck: config.Deck{Spyglass: config.Spyglass{
+		Lenses: []config.LensFileConfig{
+			{RequiredFiles: []string{`build-log\.txt`}, Lens: config.LensConfig{Name: "buildlog"}},
+		},
+		RegexCache: map[string]*regexp.Regexp{`build-log\.txt`: regexp.MustCompile(`build-log\.txt`)},
+		LensDiscovery: &config.LensDiscovery{Endpoints: []string{
+			server.URL + "/flakes",
+			server.URL + "/buildlog/",
+			server.URL + "/broken",
+			server.URL + "/missing",
+		}},
+	}}}}
+	d := NewLensDiscoverer(func() *config.Config { return base })
+
+	if cfg := d.Config(); cfg != base {
+		t.Error("expected the config to be returned as is before anything was discovered")
+	}
+
+	check := func() {
+		t.Helper()
+		cfg := d.Config()
+		var names []string
+		for _, lfc := range cfg.Deck.Spyglass.Lenses {
+			names = append(names, lfc.Lens.Name)
+		}
+		if diff := cmp.Diff([]string{"buildlog", "flakes"}, names); diff != "" {
+			t.Fatalf("unexpected lenses (-want +got):\n%s", diff)
+		}
+		flakes := cfg.Deck.Spyglass.Lenses[1]
+		if flakes.RemoteConfig.Endpoint != server.URL+"/flakes" || flakes.RemoteConfig.ParsedEndpoint == nil {
+			t.Errorf("unexpected endpoint %q", flakes.RemoteConfig.Endpoint)
+		}
+		if flakes.RemoteConfig.Title != "Flaky Tests" || *flakes.RemoteConfig.Priority != 5 {
+			t.Errorf("unexpected remote config %+v", flakes.RemoteConfig)
+		}
+		if cfg.Deck.Spyglass.RegexCache[`^artifacts/flakes\.json$`] == nil {
+			t.Error("expected the regexp of the discovered lens to be compiled")
+		}
+		if len(base.Deck.Spyglass.Lenses) != 1 || len(base.Deck.Spyglass.RegexCache) != 1 {
+			t.Error("the base config must not be modified")
+		}
+	}
+
+	d.Sync()
+	check()
+
+	// Lenses that can't be reached keep their last known metadata.
+	healthy = false
+	d.Sync()
+	check()
+
+	// Lenses that are no longer configured are dropped.
+	base.Deck.Spyglass.LensDiscovery.Endpoints = nil
+	d.Sync()
+	if cfg := d.Config(); len(cfg.Deck.Spyglass.Lenses) != 1 {
+		t.Errorf("expected only the configured lens, got %v", cfg.Deck.Spyglass.Lenses)
+	}
+}
@@ -279,20 +279,42 @@ async function loadLine(artifact: string, line: number): Promise<boolean> {
   return false;
 }
 
+// defaultArtifact returns the name of the first log on the page.
+function defaultArtifact(): string|null {
+  const content = document.querySelector<HTMLElement>('.loglines');
+  if (!content) {
+    return null;
+  }
+  return content.id.replace(/-content$/, '');
+}
+
 // parseHash extracts an artifact and line range.
 //
 // Expects URL fragment to be any of the following forms:
 // * <empty>
 // * single line: #artifact:5
 // * range of lines: #artifact:5-12.
+// * single line of the first log: #L5
+// * range of lines of the first log: #L5-L12 or #L5-12
 function parseHash(): [string, number, number]|null {
   const hash = location.hash.substr(1);
-  const colonPos = hash.lastIndexOf(':');
-  if (colonPos === -1) {
-    return null;
+  let artifact: string|null;
+  let lineRange: string;
+  const githubStyle = /^L(\d+)(?:-L?(\d+))?$/.exec(hash);
+  if (githubStyle) {
+    artifact = defaultArtifact();
+    if (artifact === null) {
+      return null;
+    }
+    lineRange = githubStyle[2] ? `${githubStyle[1]}-${githubStyle[2]}` : githubStyle[1];
+  } else {
+    const colonPos = hash.lastIndexOf(':');
+    if (colonPos === -1) {
+      return null;
+    }
+    artifact = hash.substring(0, colonPos);
+    lineRange = hash.substring(colonPos + 1);
   }
-  const artifact = hash.substring(0, colonPos);
-  const lineRange = hash.substring(colonPos + 1);
   const hyphenPos = lineRange.lastIndexOf('-');
 
   let startNum;
@@ -394,7 +416,70 @@ function clipLine(lineEl: HTMLDivElement) {
   lineEl.insertAdjacentElement("afterbegin", pin);
 }
 
+// errorNavFor returns the error navigation of the log currently linked to,
+// or the one of the first log.
+function errorNavFor(artifact: string|null): HTMLElement|null {
+  for (const nav of Array.from(document.querySelectorAll<HTMLElement>('.error-nav'))) {
+    if (artifact === null || nav.dataset.artifact === artifact) {
+      return nav;
+    }
+  }
+  return document.querySelector<HTMLElement>('.error-nav');
+}
+
+// jumpToError links to the next (direction > 0) or previous (direction < 0)
+// highlighted line relative to the currently linked line.
+function jumpToError(nav: HTMLElement, direction: number): void {
+  const {artifact, errorLines} = nav.dataset;
+  const lines = (errorLines || '').split(',').map(Number).filter((n) => !isNaN(n) && n > 0);
+  if (!artifact || lines.length === 0) {
+    return;
+  }
+  const result = parseHash();
+  let target: number|undefined;
+  if (result === null || result[0] !== artifact) {
+    target = direction > 0 ? lines[0] : lines[lines.length - 1];
+  } else {
+    const [, start, end] = result;
+    if (direction > 0) {
+      target = lines.find((n) => n > end);
+    } else {
+      target = lines.slice().reverse().find((n) => n < start);
+    }
+  }
+  if (target === undefined) {
+    return;
+  }
+  location.hash = `#${artifact}:${target}`;
+}
+
+function handleErrorKey(e: KeyboardEvent): void {
+  if (e.altKey || e.ctrlKey || e.metaKey) {
+    return;
+  }
+  const target = e.target as HTMLElement;
+  if (target && (target.tagName === 'INPUT' || target.tagName === 'TEXTAREA' || target.isContentEditable)) {
+    return;
+  }
+  let direction: number;
+  if (e.key === 'n' || e.key === 'j') {
+    direction = 1;
+  } else if (e.key === 'p' || e.key === 'k' || e.key === 'N') {
+    direction = -1;
+  } else {
+    return;
+  }
+  const result = parseHash();
+  const nav = errorNavFor(result ? result[0] : null);
+  if (!nav) {
+    return;
+  }
+  e.preventDefault();
+  jumpToError(nav, direction);
+}
+
 window.addEventListener('hashchange', () => handleHash());
+window.addEventListener('keydown', handleErrorKey);
 
 window.addEventListener('load', () => {
   const shown = document.getElementsByClassName("shown");
@@ -415,6 +500,11 @@ window.addEventListener('load', () => {
     button.addEventListener('click', handleAnalyze);
   }
 
+  for (const nav of Array.from(document.querySelectorAll<HTMLElement>('.error-nav'))) {
+    nav.querySelector<HTMLButtonElement>('.prev-error')!.addEventListener('click', () => jumpToError(nav, -1));
+    nav.querySelector<HTMLButtonElement>('.next-error')!.addEventListener('click', () => jumpToError(nav, 1));
+  }
+
   for (const container of Array.from(document.querySelectorAll<HTMLElement>('.loglines'))) {
     container.addEventListener('click', handleLineLink, {capture: true});
   }
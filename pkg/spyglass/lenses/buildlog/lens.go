@@ -32,6 +32,7 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	prowconfig "sigs.k8s.io/prow/pkg/config"
 	pkgio "sigs.k8s.io/prow/pkg/io"
@@ -45,6 +46,7 @@ const (
 	priority        = 10
 	neighborLines   = 5 // number of "important" lines to be displayed in either direction
 	minLinesSkipped = 5
+	maxErrorLines   = 1000 // number of highlighted lines that can be navigated to
 )
 
 var defaultHighlightLineLengthMax = 10000 // Default maximum length of a line worth highlighting
@@ -75,6 +77,7 @@ type parsedConfig struct {
 }
 
 var _ api.Lens = Lens{}
+var _ lenses.DiffLens = Lens{}
 
 // Lens implements the build lens.
 type Lens struct{}
@@ -161,6 +164,26 @@ type LogArtifactView struct {
 	ShowRawLog   bool
 	CanSave      bool
 	CanAnalyze   bool
+	// ErrorLines are the numbers of the highlighted lines, which can be
+	// navigated to even if they are hidden.
+	ErrorLines []int
+}
+
+// FirstError returns the number of the first highlighted line, or 0.
+func (v LogArtifactView) FirstError() int {
+	if len(v.ErrorLines) == 0 {
+		return 0
+	}
+	return v.ErrorLines[0]
+}
+
+// ErrorLinesAttr returns the highlighted line numbers as a comma-separated list.
+func (v LogArtifactView) ErrorLinesAttr() string {
+	numbers := make([]string, 0, len(v.ErrorLines))
+	for _, n := range v.ErrorLines {
+		numbers = append(numbers, strconv.Itoa(n))
+	}
+	return strings.Join(numbers, ",")
 }
 
 // buildLogsView holds each log file view
@@ -255,7 +278,9 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 				start, end = resp.Min, resp.Max
 			}
 		}
-		av.LineGroups = groupLines(&artifact, start, end, highlightLines(lines, 0, &artifact, conf.highlightRegex, conf.highlightLengthMax)...)
+		logLines := highlightLines(lines, 0, &artifact, conf.highlightRegex, conf.highlightLengthMax)
+		av.ErrorLines = errorLines(logLines)
+		av.LineGroups = groupLines(&artifact, start, end, logLines...)
 		av.ViewAll = true
 		av.CanSave = canSave(a.CanonicalLink())
 		av.CanAnalyze = analyze
@@ -265,6 +290,100 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 	return executeTemplate(resourceDir, "body", buildLogsView)
 }
 
+// volatileRE matches parts of log lines that differ between runs even if
+// nothing changed, like timestamps and hashes.
+var volatileRE = regexp.MustCompile(`\d{4}-\d\d-\d\d[T ]\d\d:\d\d:\d\d(\.\d+)?Z?|\d\d:\d\d:\d\d(\.\d+)?|\b0x[0-9a-fA-F]+\b|\b[0-9a-f]{12,}\b|\(\d+(\.\d+)?m?s\)`)
+
+// maxDiffLines is the maximum number of lines shown for each side of a diff.
+const maxDiffLines = 100
+
+// DiffLine is a highlighted line that only appears in one of the compared logs.
+type DiffLine struct {
+	Number int
+	Text   string
+}
+
+// LogDiffView holds the comparison of a log between two runs.
+type LogDiffView struct {
+	ArtifactName string
+	LinesA       int
+	LinesB       int
+	MissingA     bool
+	MissingB     bool
+	// New are highlighted lines of run b that don't appear in run a.
+	New []DiffLine
+	// Resolved are highlighted lines of run a that don't appear in run b.
+	Resolved  []DiffLine
+	Truncated bool
+}
+
+// Diff lists the highlighted lines that only appear in the log of one of the runs.
+func (lens Lens) Diff(a, b []api.Artifact, resourceDir string, rawConfig json.RawMessage, spyglassConfig prowconfig.Spyglass) string {
+	conf := getConfig(rawConfig)
+	logs := map[string]*[2][]string{}
+	var names []string
+	for i, artifacts := range [][]api.Artifact{a, b} {
+		for _, artifact := range artifacts {
+			lines, err := logLinesAll(artifact)
+			if err != nil {
+				logrus.WithError(err).Info("Error reading log.")
+				continue
+			}
+			if logs[artifact.JobPath()] == nil {
+				logs[artifact.JobPath()] = &[2][]string{}
+				names = append(names, artifact.JobPath())
+			}
+			logs[artifact.JobPath()][i] = lines
+		}
+	}
+
+	var views []LogDiffView
+	for _, name := range names {
+		views = append(views, diffLogs(name, logs[name][0], logs[name][1], &conf))
+	}
+	return executeTemplate(resourceDir, "diff", views)
+}
+
+func diffLogs(name string, a, b []string, conf *parsedConfig) LogDiffView {
+	view := LogDiffView{
+		ArtifactName: name,
+		LinesA:       len(a),
+		LinesB:       len(b),
+		MissingA:     a == nil,
+		MissingB:     b == nil,
+	}
+	if view.MissingA || view.MissingB {
+		return view
+	}
+	view.New, view.Truncated = onlyIn(b, a, conf)
+	var truncated bool
+	view.Resolved, truncated = onlyIn(a, b, conf)
+	view.Truncated = view.Truncated || truncated
+	return view
+}
+
+// onlyIn returns the highlighted lines of lines that don't appear in other.
+func onlyIn(lines, other []string, conf *parsedConfig) ([]DiffLine, bool) {
+	seen := sets.New[string]()
+	for _, line := range other {
+		seen.Insert(volatileRE.ReplaceAllString(line, ""))
+	}
+	var result []DiffLine
+	for i, line := range lines {
+		if len(line) > conf.highlightLengthMax || !conf.highlightRegex.MatchString(line) {
+			continue
+		}
+		if seen.Has(volatileRE.ReplaceAllString(line, "")) {
+			continue
+		}
+		if len(result) == maxDiffLines {
+			return result, true
+		}
+		result = append(result, DiffLine{Number: i + 1, Text: line})
+	}
+	return result, false
+}
+
 func canSave(link string) bool {
 	return strings.Contains(link, pkgio.GSAnonHost) || strings.Contains(link, pkgio.GSCookieHost)
 }
@@ -527,6 +646,21 @@ func highlightLines(lines []string, startLine int, artifact *string, highlightRe
 	return logLines
 }
 
+// errorLines returns the numbers of the first maxErrorLines highlighted lines.
+func errorLines(logLines []LogLine) []int {
+	var numbers []int
+	for _, line := range logLines {
+		if !line.Highlighted {
+			continue
+		}
+		if len(numbers) == maxErrorLines {
+			break
+		}
+		numbers = append(numbers, line.Number)
+	}
+	return numbers
+}
+
 // breaks lines into important/unimportant groups
 func groupLines(artifact *string, start, end int, logLines ...LogLine) []LineGroup {
 	// show highlighted lines and their neighboring lines
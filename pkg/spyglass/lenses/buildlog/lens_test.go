@@ -294,6 +294,77 @@ func TestGroupLines(t *testing.T) {
 
 func pstr(s string) *string { return &s }
 
+func TestErrorLines(t *testing.T) {
+	artifact := "build-log.txt"
+	lines := []string{
+		"Running tests",
+		"ERROR: something broke",
+		"still running",
+		"--- FAIL: TestFoo",
+		"done",
+	}
+	view := LogArtifactView{ErrorLines: errorLines(highlightLines(lines, 0, &artifact, defaultErrRE, defaultHighlightLineLengthMax))}
+	if diff := cmp.Diff([]int{2, 4}, view.ErrorLines); diff != "" {
+		t.Errorf("unexpected error lines (-want +got):\n%s", diff)
+	}
+	if got := view.FirstError(); got != 2 {
+		t.Errorf("expected the first error on line 2, got %d", got)
+	}
+	if got := view.ErrorLinesAttr(); got != "2,4" {
+		t.Errorf("expected error lines attribute %q, got %q", "2,4", got)
+	}
+
+	var many []string
+	for i := 0; i < maxErrorLines+10; i++ {
+		many = append(many, "ERROR: again")
+	}
+	if got := len(errorLines(highlightLines(many, 0, &artifact, defaultErrRE, defaultHighlightLineLengthMax))); got != maxErrorLines {
+		t.Errorf("expected at most %d error lines, got %d", maxErrorLines, got)
+	}
+	if got := (LogArtifactView{}).FirstError(); got != 0 {
+		t.Errorf("expected no first error, got %d", got)
+	}
+}
+
+func TestDiffLogs(t *testing.T) {
+	conf := &parsedConfig{highlightRegex: defaultErrRE, highlightLengthMax: defaultHighlightLineLengthMax}
+	a := []string{
+		"I0102 15:04:05.000000 starting",
+		"2024-01-02T15:04:05Z ERROR: flaky network (3.2s)",
+		"--- FAIL: TestOld",
+	}
+	b := []string{
+		"I0102 16:00:00.000000 starting",
+		"2024-05-06T07:08:09Z ERROR: flaky network (1.5s)",
+		"panic: runtime error at 0xdeadbeef",
+		"--- FAIL: TestNew",
+	}
+
+	got := diffLogs("build-log.txt", a, b, conf)
+	want := LogDiffView{
+		ArtifactName: "build-log.txt",
+		LinesA:       3,
+		LinesB:       4,
+		New:          []DiffLine{{Number: 3, Text: "panic: runtime error at 0xdeadbeef"}, {Number: 4, Text: "--- FAIL: TestNew"}},
+		Resolved:     []DiffLine{{Number: 3, Text: "--- FAIL: TestOld"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected diff view (-want +got):\n%s", diff)
+	}
+
+	if got := diffLogs("build-log.txt", nil, b, conf); !got.MissingA || got.New != nil {
+		t.Errorf("expected a log only present in run b, got %+v", got)
+	}
+
+	var many []string
+	for i := 0; i < maxDiffLines+1; i++ {
+		many = append(many, fmt.Sprintf("ERROR: failure %c%d", 'a'+i%26, i))
+	}
+	if got := diffLogs("build-log.txt", []string{}, many, conf); len(got.New) != maxDiffLines || !got.Truncated {
+		t.Errorf("expected %d truncated lines, got %d (truncated: %t)", maxDiffLines, len(got.New), got.Truncated)
+	}
+}
+
 func TestBody(t *testing.T) {
 	const (
 		anonLink   = "https://storage.googleapis.com/bucket/object/build-log.txt"
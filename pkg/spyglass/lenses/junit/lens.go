@@ -24,9 +24,12 @@ import (
 	"html/template"
 	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	"github.com/hashicorp/golang-lru/simplelru"
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/prow/pkg/config"
@@ -52,12 +55,202 @@ type testStatus string
 // Lens is the implementation of a JUnit-rendering Spyglass lens.
 type Lens struct{}
 
+var _ lenses.DiffLens = Lens{}
+
 type JVD struct {
 	NumTests int
 	Passed   []TestResult
 	Failed   []TestResult
 	Skipped  []TestResult
 	Flaky    []TestResult
+	// Hidden holds the number of results of each section that aren't
+	// included, because they are loaded on demand.
+	Hidden map[string]int
+}
+
+// The sections of the lens, in the order they are rendered in.
+const (
+	failedSection  = "failed"
+	flakySection   = "flaky"
+	passedSection  = "passed"
+	skippedSection = "skipped"
+)
+
+var sections = []string{failedSection, flakySection, passedSection, skippedSection}
+
+// jvdCacheBytes is the total size of the reports whose parsed results are
+// kept for the callbacks that load more results or search them, so that they
+// don't download and parse the whole report again. The lens is meant for large
+// reports, so the cache is bounded by their size instead of their number.
+const jvdCacheBytes = 200e6
+
+// jvdCacheSize is the maximum number of parsed reports kept, however small.
+const jvdCacheSize = 100
+
+var jvdCache = newSizedJvdCache(jvdCacheSize, jvdCacheBytes)
+
+type sizedJvd struct {
+	jvd  JVD
+	size int64
+}
+
+// sizedJvdCache is an LRU cache of parsed reports that is bounded by the
+// total size of the reports.
+type sizedJvdCache struct {
+	lock     sync.Mutex
+	lru      *simplelru.LRU
+	maxBytes int64
+	bytes    int64
+}
+
+func newSizedJvdCache(size int, maxBytes int64) *sizedJvdCache {
+	c := &sizedJvdCache{maxBytes: maxBytes}
+	c.lru, _ = simplelru.NewLRU(size, func(_, value interface{}) {
+		c.bytes -= value.(sizedJvd).size
+	})
+	return c
+}
+
+func (c *sizedJvdCache) Get(key string) (JVD, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok := c.lru.Get(key)
+	if !ok {
+		return JVD{}, false
+	}
+	return value.(sizedJvd).jvd, true
+}
+
+// Add adds the parsed results of reports of the given size, evicting the
+// least recently used results until the cache is within its bounds. Results
+// of reports larger than the cache are not kept.
+func (c *sizedJvdCache) Add(key string, jvd JVD, size int64) {
+	if size > c.maxBytes {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.lru.Contains(key) {
+		return
+	}
+	c.lru.Add(key, sizedJvd{jvd: jvd, size: size})
+	c.bytes += size
+	for c.bytes > c.maxBytes {
+		c.lru.RemoveOldest()
+	}
+}
+
+func (c *sizedJvdCache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lru.Purge()
+}
+
+// pageSize is the number of results of each section that are rendered at once.
+// Reports can contain hundreds of thousands of tests which freeze the browser
+// if they are rendered at once.
+const pageSize = 100
+
+func (jvd JVD) section(section string) []TestResult {
+	switch section {
+	case failedSection:
+		return jvd.Failed
+	case flakySection:
+		return jvd.Flaky
+	case passedSection:
+		return jvd.Passed
+	case skippedSection:
+		return jvd.Skipped
+	}
+	return nil
+}
+
+func (jvd *JVD) setSection(section string, results []TestResult) {
+	switch section {
+	case failedSection:
+		jvd.Failed = results
+	case flakySection:
+		jvd.Flaky = results
+	case passedSection:
+		jvd.Passed = results
+	case skippedSection:
+		jvd.Skipped = results
+	}
+}
+
+// Total returns the number of results in the section, including hidden ones.
+func (jvd JVD) Total(section string) int {
+	return len(jvd.section(section)) + jvd.Hidden[section]
+}
+
+// Rows returns the results of the section to render.
+func (jvd JVD) Rows(section string) Rows {
+	results := jvd.section(section)
+	return Rows{
+		Section:   section,
+		Tests:     results,
+		Offset:    len(results),
+		Remaining: jvd.Hidden[section],
+	}
+}
+
+// Rows holds a page of the results of a section.
+type Rows struct {
+	Section string
+	Tests   []TestResult
+	// Offset is the offset of the next page.
+	Offset int
+	// Remaining is the number of results after this page.
+	Remaining int
+}
+
+// filter returns the results whose class or name contain query, ignoring case.
+func filter(results []TestResult, query string) []TestResult {
+	if query == "" {
+		return results
+	}
+	query = strings.ToLower(query)
+	var filtered []TestResult
+	for _, result := range results {
+		test := result.Junit[0]
+		if strings.Contains(strings.ToLower(test.ClassName+": "+test.Name), query) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// page returns the results of the section matching query from offset on, up
+// to pageSize.
+func (jvd JVD) page(section, query string, offset int) Rows {
+	results := filter(jvd.section(section), query)
+	if offset > len(results) {
+		offset = len(results)
+	}
+	end := offset + pageSize
+	if end > len(results) {
+		end = len(results)
+	}
+	return Rows{
+		Section:   section,
+		Tests:     results[offset:end],
+		Offset:    end,
+		Remaining: len(results) - end,
+	}
+}
+
+// firstPage returns a copy of jvd which only contains the first page of each
+// section.
+func (jvd JVD) firstPage() JVD {
+	paged := JVD{NumTests: jvd.NumTests, Hidden: map[string]int{}}
+	for _, section := range sections {
+		rows := jvd.page(section, "", 0)
+		paged.setSection(section, rows.Tests)
+		if rows.Remaining > 0 {
+			paged.Hidden[section] = rows.Remaining
+		}
+	}
+	return paged
 }
 
 // Config returns the lens's configuration.
@@ -82,9 +275,50 @@ func (lens Lens) Header(artifacts []api.Artifact, resourceDir string, config jso
 	return buf.String()
 }
 
-// Callback does nothing.
+type callbackRequest struct {
+	// Section to load, all sections are loaded if empty.
+	Section string `json:"section"`
+	Offset  int    `json:"offset"`
+	Query   string `json:"query"`
+}
+
+type callbackSection struct {
+	HTML  string `json:"html"`
+	Total int    `json:"total"`
+}
+
+// Callback renders a page of results of a section, or the first page of every
+// section when searching. The response maps the section to its rendered rows.
 func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
-	return ""
+	var request callbackRequest
+	if err := json.Unmarshal([]byte(data), &request); err != nil {
+		return fmt.Sprintf("failed to unmarshal request: %v", err)
+	}
+	requested := sections
+	if request.Section != "" {
+		requested = []string{request.Section}
+	}
+
+	junitTemplate, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+		return fmt.Sprintf("Failed to load template file: %v", err)
+	}
+	jvd := lens.getCachedJvd(artifacts)
+	response := map[string]callbackSection{}
+	for _, section := range requested {
+		rows := jvd.page(section, request.Query, request.Offset)
+		var buf bytes.Buffer
+		if err := junitTemplate.ExecuteTemplate(&buf, "rows", rows); err != nil {
+			logrus.WithError(err).Error("Error executing template.")
+		}
+		response[section] = callbackSection{HTML: buf.String(), Total: rows.Offset + rows.Remaining}
+	}
+	out, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Sprintf("failed to marshal response: %v", err)
+	}
+	return string(out)
 }
 
 type JunitResult struct {
@@ -121,7 +355,7 @@ type TestResult struct {
 
 // Body renders the <body> for JUnit tests
 func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
-	jvd := lens.getJvd(artifacts)
+	jvd := lens.getCachedJvd(artifacts).firstPage()
 
 	junitTemplate, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
 	if err != nil {
@@ -137,6 +371,84 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 	return buf.String()
 }
 
+// JunitDiff holds the comparison of the junit results of two runs.
+type JunitDiff struct {
+	NumTestsA int
+	NumTestsB int
+	// NewlyFailing are tests that fail in run b but didn't fail in run a.
+	NewlyFailing []TestResult
+	// Fixed are tests that failed in run a and pass in run b.
+	Fixed []TestResult
+	// StillFailing are tests that fail in both runs.
+	StillFailing []TestResult
+}
+
+// Diff renders which tests started or stopped failing between two runs.
+func (lens Lens) Diff(a, b []api.Artifact, resourceDir string, data json.RawMessage, spyglassConfig config.Spyglass) string {
+	jvdA, jvdB := lens.getJvd(a), lens.getJvd(b)
+	diff := diffJvds(jvdA, jvdB)
+
+	junitTemplate, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+		return fmt.Sprintf("Failed to load template file: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := junitTemplate.ExecuteTemplate(&buf, "diff", diff); err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+	}
+	return buf.String()
+}
+
+func diffJvds(a, b JVD) JunitDiff {
+	key := func(test TestResult) string {
+		return test.Junit[0].ClassName + "\x00" + test.Junit[0].Name
+	}
+	failedA := map[string]bool{}
+	for _, test := range a.Failed {
+		failedA[key(test)] = true
+	}
+	failedB := map[string]bool{}
+	diff := JunitDiff{NumTestsA: a.NumTests, NumTestsB: b.NumTests}
+	for _, test := range b.Failed {
+		failedB[key(test)] = true
+		if failedA[key(test)] {
+			diff.StillFailing = append(diff.StillFailing, test)
+		} else {
+			diff.NewlyFailing = append(diff.NewlyFailing, test)
+		}
+	}
+	for _, test := range append(append([]TestResult{}, b.Passed...), b.Flaky...) {
+		if failedA[key(test)] && !failedB[key(test)] {
+			diff.Fixed = append(diff.Fixed, test)
+		}
+	}
+	return diff
+}
+
+// getCachedJvd returns the parsed results of the artifacts, which are only
+// parsed again once their links or sizes change, e.g. while the job runs.
+func (lens Lens) getCachedJvd(artifacts []api.Artifact) JVD {
+	var keys []string
+	var totalSize int64
+	for _, artifact := range artifacts {
+		size, err := artifact.Size()
+		if err != nil {
+			return lens.getJvd(artifacts)
+		}
+		keys = append(keys, fmt.Sprintf("%s:%d", artifact.CanonicalLink(), size))
+		totalSize += size
+	}
+	sort.Strings(keys)
+	key := strings.Join(keys, "\n")
+	if jvd, ok := jvdCache.Get(key); ok {
+		return jvd
+	}
+	jvd := lens.getJvd(artifacts)
+	jvdCache.Add(key, jvd, totalSize)
+	return jvd
+}
+
 func (lens Lens) getJvd(artifacts []api.Artifact) JVD {
 	type testResults struct {
 		// Group results based on their full path name
@@ -159,42 +471,23 @@ func (lens Lens) getJvd(artifacts []api.Artifact) JVD {
 				link: artifact.CanonicalLink(),
 				path: artifact.JobPath(),
 			}
-			var contents []byte
-			contents, result.err = artifact.ReadAll()
-			if result.err != nil {
-				logrus.WithError(result.err).WithField("artifact", artifact.CanonicalLink()).Warn("Error reading artifact")
-				resultChan <- result
-				return
-			}
-			var suites *junit.Suites
-			suites, result.err = junit.Parse(contents)
+			result.err = readJunit(artifact, func(suite string, test junit.Result) {
+				// There are cases where multiple entries of exactly the same
+				// testcase in a single junit result file, this could result
+				// from reruns of test cases by `go test --count=N` where N>1.
+				// Deduplicate them here in this case, and classify a test as being
+				// flaky if it both succeeded and failed
+				k := testIdentifier{suite, test.ClassName, test.Name}
+				groups[k] = append(groups[k], JunitResult{Result: test})
+				if len(groups[k]) == 1 {
+					testsSequence = append(testsSequence, k)
+				}
+			})
 			if result.err != nil {
 				logrus.WithError(result.err).WithField("artifact", artifact.CanonicalLink()).Info("Error parsing junit file.")
 				resultChan <- result
 				return
 			}
-			var record func(suite junit.Suite)
-			record = func(suite junit.Suite) {
-				for _, subSuite := range suite.Suites {
-					record(subSuite)
-				}
-
-				for _, test := range suite.Results {
-					// There are cases where multiple entries of exactly the same
-					// testcase in a single junit result file, this could result
-					// from reruns of test cases by `go test --count=N` where N>1.
-					// Deduplicate them here in this case, and classify a test as being
-					// flaky if it both succeeded and failed
-					k := testIdentifier{suite.Name, test.ClassName, test.Name}
-					groups[k] = append(groups[k], JunitResult{Result: test})
-					if len(groups[k]) == 1 {
-						testsSequence = append(testsSequence, k)
-					}
-				}
-			}
-			for _, suite := range suites.Suites {
-				record(suite)
-			}
 			for _, identifier := range testsSequence {
 				result.junit = append(result.junit, groups[identifier])
 			}
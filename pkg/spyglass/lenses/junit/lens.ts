@@ -51,10 +51,75 @@ const addStdoutStderrOpeners = (): void => {
   }
 };
 
+interface SectionPage {
+  html: string;
+  total: number;
+}
+
+let query = '';
+
+// Rows are rendered in pages, the remaining ones are loaded from the server.
+const addLoadMoreButtons = (): void => {
+  const rows = document.querySelectorAll<HTMLTableRowElement>('tr.load-more');
+  for (const row of Array.from(rows)) {
+    const button = row.querySelector('button')!;
+    button.onclick = async () => {
+      button.disabled = true;
+      const {section, offset} = row.dataset;
+      const response = await spyglass.request(JSON.stringify({section, offset: Number(offset), query}));
+      const pages: {[section: string]: SectionPage} = JSON.parse(response);
+      row.outerHTML = pages[section!].html;
+      rowsAdded();
+    };
+  }
+};
+
+const search = async (): Promise<void> => {
+  const response = await spyglass.request(JSON.stringify({query}));
+  const pages: {[section: string]: SectionPage} = JSON.parse(response);
+  for (const section of Object.keys(pages)) {
+    const tbody = document.getElementById(`${section}-tbody`);
+    const count = document.getElementById(`${section}-count`);
+    if (!tbody || !count) {
+      continue;
+    }
+    tbody.innerHTML = pages[section].html;
+    count.innerText = String(pages[section].total);
+  }
+  rowsAdded();
+};
+
+const addSearch = (): void => {
+  const input = document.getElementById('junit-search') as HTMLInputElement | null;
+  if (!input) {
+    return;
+  }
+  let timeout: number | undefined;
+  input.oninput = () => {
+    window.clearTimeout(timeout);
+    timeout = window.setTimeout(() => {
+      if (input.value === query) {
+        return;
+      }
+      query = input.value;
+      search();
+    }, 300);
+  };
+};
+
+const rowsAdded = (): void => {
+  addTestExpanders();
+  addStdoutStderrOpeners();
+  addLoadMoreButtons();
+  spyglass.contentUpdated();
+};
+
 const loaded = (): void => {
   addTestExpanders();
   addStdoutStderrOpeners();
   addSectionExpanders();
+  addLoadMoreButtons();
+  addSearch();
 };
 
 window.addEventListener('DOMContentLoaded', loaded);
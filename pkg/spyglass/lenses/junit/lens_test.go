@@ -20,6 +20,7 @@ package junit
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"html/template"
 	"io"
 	"strings"
@@ -45,6 +46,7 @@ type FakeArtifact struct {
 	path      string
 	content   []byte
 	sizeLimit int64
+	reads     int
 }
 
 func (fa *FakeArtifact) JobPath() string {
@@ -68,6 +70,7 @@ func (fa *FakeArtifact) UpdateMetadata(map[string]string) error {
 }
 
 func (fa *FakeArtifact) ReadAt(b []byte, off int64) (int, error) {
+	fa.reads++
 	r := bytes.NewReader(fa.content)
 	return r.ReadAt(b, off)
 }
@@ -947,3 +950,182 @@ func TestTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffJvds(t *testing.T) {
+	result := func(name string) TestResult {
+		return TestResult{Junit: []JunitResult{{Result: junit.Result{Name: name, ClassName: "class"}}}}
+	}
+	a := JVD{
+		NumTests: 4,
+		Passed:   []TestResult{result("stable"), result("breaks")},
+		Failed:   []TestResult{result("fixed"), result("broken")},
+	}
+	b := JVD{
+		NumTests: 5,
+		Passed:   []TestResult{result("stable"), result("fixed")},
+		Failed:   []TestResult{result("breaks"), result("broken"), result("new")},
+	}
+	names := func(results []TestResult) []string {
+		var names []string
+		for _, r := range results {
+			names = append(names, r.Junit[0].Name)
+		}
+		return names
+	}
+
+	diff := diffJvds(a, b)
+	if diff.NumTestsA != 4 || diff.NumTestsB != 5 {
+		t.Errorf("unexpected number of tests %d and %d", diff.NumTestsA, diff.NumTestsB)
+	}
+	if d := cmp.Diff([]string{"breaks", "new"}, names(diff.NewlyFailing)); d != "" {
+		t.Errorf("unexpected newly failing tests (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff([]string{"fixed"}, names(diff.Fixed)); d != "" {
+		t.Errorf("unexpected fixed tests (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff([]string{"broken"}, names(diff.StillFailing)); d != "" {
+		t.Errorf("unexpected still failing tests (-want +got):\n%s", d)
+	}
+}
+
+func TestParseJunit(t *testing.T) {
+	longOutput := strings.Repeat("a", maxOutputLength) + strings.Repeat("b", maxOutputLength)
+	contents := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+	<testsuite name="outer">
+		<testcase classname="c" name="first"><system-out>` + longOutput + `</system-out></testcase>
+		<testsuite name="inner">
+			<testcase classname="c" name="second"><failure message="boom"/></testcase>
+		</testsuite>
+		<testcase classname="c" name="third"/>
+	</testsuite>
+</testsuites>`
+
+	type recorded struct {
+		suite, name string
+		output      int
+	}
+	var got []recorded
+	err := readJunit(&FakeArtifact{content: []byte(contents), sizeLimit: 500e6}, func(suite string, result junit.Result) {
+		r := recorded{suite: suite, name: result.Name}
+		if result.Output != nil {
+			r.output = len(*result.Output)
+		}
+		got = append(got, r)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []recorded{
+		{suite: "outer", name: "first", output: len(*truncateOutput(&longOutput))},
+		{suite: "inner", name: "second"},
+		{suite: "outer", name: "third"},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(recorded{})); diff != "" {
+		t.Errorf("unexpected test cases (-want +got):\n%s", diff)
+	}
+	if want[0].output >= len(longOutput) {
+		t.Errorf("expected the output to be truncated, got %d bytes", want[0].output)
+	}
+
+	if err := readJunit(&FakeArtifact{content: []byte("<testsuite><testcase>"), sizeLimit: 500e6}, func(string, junit.Result) {}); err == nil {
+		t.Error("expected an error for truncated xml")
+	}
+}
+
+func TestPagination(t *testing.T) {
+	var jvd JVD
+	for i := 0; i < pageSize+20; i++ {
+		jvd.Passed = append(jvd.Passed, TestResult{Junit: []JunitResult{{Result: junit.Result{ClassName: "class", Name: fmt.Sprintf("Test%03d", i)}}}})
+	}
+	jvd.Failed = jvd.Passed[:2]
+	jvd.NumTests = len(jvd.Passed) + len(jvd.Failed)
+
+	page := jvd.firstPage()
+	if len(page.Passed) != pageSize || len(page.Failed) != 2 {
+		t.Errorf("expected %d passed and 2 failed results, got %d and %d", pageSize, len(page.Passed), len(page.Failed))
+	}
+	if page.Total(passedSection) != pageSize+20 || page.Total(failedSection) != 2 {
+		t.Errorf("unexpected totals %d and %d", page.Total(passedSection), page.Total(failedSection))
+	}
+	if rows := page.Rows(passedSection); rows.Offset != pageSize || rows.Remaining != 20 {
+		t.Errorf("unexpected rows offset %d and remaining %d", rows.Offset, rows.Remaining)
+	}
+
+	if rows := jvd.page(passedSection, "", pageSize); len(rows.Tests) != 20 || rows.Remaining != 0 {
+		t.Errorf("expected the last 20 results, got %d with %d remaining", len(rows.Tests), rows.Remaining)
+	}
+	rows := jvd.page(passedSection, "TEST01", 0)
+	var names []string
+	for _, test := range rows.Tests {
+		names = append(names, test.Junit[0].Name)
+	}
+	want := []string{"Test010", "Test011", "Test012", "Test013", "Test014", "Test015", "Test016", "Test017", "Test018", "Test019"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("unexpected search results (-want +got):\n%s", diff)
+	}
+
+	tmpl, err := template.ParseFiles("template.html")
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "body", page); err != nil {
+		t.Fatalf("failed to execute template: %v", err)
+	}
+	if !strings.Contains(buf.String(), `data-section="passed" data-offset="100"`) || !strings.Contains(buf.String(), "Show 20 more") {
+		t.Errorf("expected a button to load more passed tests, got %s", buf.String())
+	}
+}
+
+func TestGetCachedJvd(t *testing.T) {
+	jvdCache.Purge()
+	artifact := &FakeArtifact{
+		path:      "junit.xml",
+		content:   []byte(`<testsuite><testcase classname="class" name="TestA"/></testsuite>`),
+		sizeLimit: 500e6,
+	}
+	lens := Lens{}
+	if jvd := lens.getCachedJvd([]api.Artifact{artifact}); jvd.NumTests != 1 {
+		t.Fatalf("expected 1 test, got %d", jvd.NumTests)
+	}
+	reads := artifact.reads
+	if jvd := lens.getCachedJvd([]api.Artifact{artifact}); jvd.NumTests != 1 {
+		t.Fatalf("expected 1 cached test, got %d", jvd.NumTests)
+	}
+	if artifact.reads != reads {
+		t.Errorf("expected the cached results not to read the artifact again, got %d reads after %d", artifact.reads, reads)
+	}
+
+	artifact.content = []byte(`<testsuite><testcase classname="class" name="TestA"/><testcase classname="class" name="TestB"/></testsuite>`)
+	if jvd := lens.getCachedJvd([]api.Artifact{artifact}); jvd.NumTests != 2 {
+		t.Errorf("expected the grown artifact to be parsed again with 2 tests, got %d", jvd.NumTests)
+	}
+}
+
+func TestSizedJvdCache(t *testing.T) {
+	cache := newSizedJvdCache(10, 100)
+	cache.Add("a", JVD{NumTests: 1}, 40)
+	cache.Add("b", JVD{NumTests: 2}, 40)
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	// Adding c exceeds the size of the cache, so the least recently used b is
+	// evicted.
+	cache.Add("c", JVD{NumTests: 3}, 40)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+	cache.Add("huge", JVD{NumTests: 4}, 101)
+	if _, ok := cache.Get("huge"); ok {
+		t.Error("expected results of a report larger than the cache not to be cached")
+	}
+	if cache.bytes != 80 {
+		t.Errorf("expected 80 cached bytes, got %d", cache.bytes)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+const (
+	// readChunkSize is the size of the ranges read from the artifact.
+	readChunkSize = 1 << 20
+	// maxOutputLength is the maximum length of the stdout and stderr kept for
+	// each test case, the middle of longer outputs is dropped.
+	maxOutputLength = 64 << 10
+)
+
+// readJunit stream-parses the junit artifact and calls record for every test
+// case, so that the file never has to be held in memory as a whole.
+func readJunit(artifact api.Artifact, record func(suite string, result junit.Result)) error {
+	size, err := artifact.Size()
+	if err != nil {
+		return fmt.Errorf("failed to get artifact size: %w", err)
+	}
+	err = parseJunit(bufio.NewReaderSize(io.NewSectionReader(artifact, 0, size), readChunkSize), record)
+	if !errors.Is(err, lenses.ErrGzipOffsetRead) {
+		return err
+	}
+	// Compressed artifacts can only be read as a whole. The error is returned
+	// by the first read, so nothing has been recorded yet.
+	contents, err := artifact.ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read artifact: %w", err)
+	}
+	return parseJunit(bytes.NewReader(contents), record)
+}
+
+// parseJunit calls record for every <testcase/> with the name of the innermost
+// <testsuite/> it is part of.
+func parseJunit(r io.Reader, record func(suite string, result junit.Result)) error {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		switch charset {
+		case "UTF-8", "utf8", "":
+			return input, nil
+		default:
+			return nil, fmt.Errorf("unknown charset: %s", charset)
+		}
+	}
+	var suites []string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "testsuite":
+				var name string
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "name" {
+						name = attr.Value
+					}
+				}
+				suites = append(suites, name)
+			case "testcase":
+				var result junit.Result
+				if err := decoder.DecodeElement(&result, &element); err != nil {
+					return err
+				}
+				result.Output = truncateOutput(result.Output)
+				result.Error = truncateOutput(result.Error)
+				var suite string
+				if len(suites) > 0 {
+					suite = suites[len(suites)-1]
+				}
+				record(suite, result)
+			}
+		case xml.EndElement:
+			if element.Name.Local == "testsuite" && len(suites) > 0 {
+				suites = suites[:len(suites)-1]
+			}
+		}
+	}
+}
+
+func truncateOutput(output *string) *string {
+	if output == nil || len(*output) <= maxOutputLength {
+		return output
+	}
+	s := *output
+	half := maxOutputLength / 2
+	truncated := fmt.Sprintf("%s\n\n... %d bytes truncated ...\n\n%s", s[:half], len(s)-maxOutputLength, s[len(s)-half:])
+	return &truncated
+}
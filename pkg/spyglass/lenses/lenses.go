@@ -76,6 +76,15 @@ type Lens interface {
 	Callback(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string
 }
 
+// DiffLens is implemented by lenses that can compare the artifacts of two runs
+// of a job, e.g. the last passing and the first failing one.
+type DiffLens interface {
+	Lens
+	// Diff returns a string that is injected into the comparison page, describing
+	// what changed between the artifacts of run a and run b.
+	Diff(a, b []api.Artifact, resourceDir string, config json.RawMessage, spyglassConfig config.Spyglass) string
+}
+
 // ResourceDirForLens returns the path to a lens's public resource directory.
 func ResourceDirForLens(baseDir, name string) string {
 	return filepath.Join(baseDir, name)
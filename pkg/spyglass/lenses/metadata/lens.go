@@ -36,6 +36,7 @@ import (
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	k8sreporter "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes"
+	"sigs.k8s.io/prow/pkg/pod-utils/results"
 	"sigs.k8s.io/prow/pkg/spyglass/api"
 	"sigs.k8s.io/prow/pkg/spyglass/lenses"
 )
@@ -93,6 +94,8 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 		Elapsed      time.Duration
 		Hint         string
 		Metadata     map[string]interface{}
+		// Results summarizes the test results, if the job uploaded them.
+		Results *results.Summary
 	}
 	metadataViewData := MetadataViewData{}
 	started := metadata.Started{}
@@ -129,6 +132,13 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 			} else {
 				logrus.Debug("Empty finished.json")
 			}
+		case prowv1.ResultsSummaryFile:
+			var summary results.Summary
+			if err := json.Unmarshal(read, &summary); err != nil {
+				logrus.WithError(err).Infof("Failed to decode %s", prowv1.ResultsSummaryFile)
+			} else {
+				metadataViewData.Results = &summary
+			}
 		case "podinfo.json":
 			metadataViewData.Hint = hintFromPodInfo(read)
 		case prowv1.ProwJobFile:
@@ -73,6 +73,17 @@ func TestCheckTimestamps(t *testing.T) {
 			expectedSubstrings: []string{`Test started`, `after 16m40s`, `more info`},
 			err:                nil,
 		},
+		{
+			name: "test results are summarized",
+			artifacts: []api.Artifact{
+				startedJson, finishedJsonNormal, &FakeArtifact{
+					Path:    "results.json",
+					Content: []byte(`{"total":12,"passed":9,"failed":2,"skipped":1,"duration_seconds":3.5,"sources":["junit.xml"]}`),
+				},
+			},
+			expectedSubstrings: []string{`12 tests: <span class="passed">9 passed</span>, <span class="failed">2 failed</span>, 1 skipped.`},
+			err:                nil,
+		},
 		{
 			name: "negative duration triggers user-facing warning",
 			artifacts: []api.Artifact{
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podinfo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	k8sreporter "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes"
+)
+
+var (
+	// Messages of the kubelet image events, e.g.
+	// `Pulling image "golang:1.22"`
+	// `Successfully pulled image "golang:1.22" in 8.113s (8.113s including waiting)`
+	// `Container image "golang:1.22" already present on machine`
+	pullingImageRe = regexp.MustCompile(`^Pulling image "([^"]+)"`)
+	pulledImageRe  = regexp.MustCompile(`^Successfully pulled image "([^"]+)" in ([0-9.]+[a-zµ]+)`)
+	cachedImageRe  = regexp.MustCompile(`^Container image "([^"]+)" already present on machine`)
+	failedImageRe  = regexp.MustCompile(`^Failed to pull image "([^"]+)"`)
+)
+
+// infraEventReasons are event reasons that indicate a problem with the build
+// cluster rather than with the test.
+var infraEventReasons = map[string]string{
+	"FailedScheduling":       "The pod could not be scheduled",
+	"FailedMount":            "A volume could not be mounted",
+	"FailedAttachVolume":     "A volume could not be attached",
+	"FailedCreatePodSandBox": "The pod sandbox could not be created",
+	"NodeNotReady":           "The node became not ready",
+	"Preempted":              "The pod was preempted",
+	"Evicted":                "The pod was evicted",
+	"OOMKilling":             "The node ran out of memory",
+}
+
+// diagnostics summarizes what happened to the pod outside of the test itself.
+type diagnostics struct {
+	Node *k8sreporter.NodeReport
+	// SchedulingDelay is the time between the creation of the pod and its
+	// scheduling, if known.
+	SchedulingDelay time.Duration
+	ImagePulls      []imagePull
+	// OOMKilled lists the containers that were killed for exceeding their
+	// memory limit.
+	OOMKilled []string
+	// InfraIssues are human readable hints that the job failed due to the
+	// build cluster rather than the test.
+	InfraIssues []string
+	// FailedContainers lists containers that exited with a non-zero code.
+	FailedContainers []string
+}
+
+type imagePull struct {
+	Image    string
+	Duration string
+	Cached   bool
+	Failed   bool
+}
+
+func diagnose(report k8sreporter.PodReport) diagnostics {
+	var d diagnostics
+	d.Node = report.Node
+	if d.Node == nil && report.Pod != nil && report.Pod.Spec.NodeName != "" {
+		d.Node = &k8sreporter.NodeReport{Name: report.Pod.Spec.NodeName}
+	}
+
+	issues := map[string]string{}
+	pulls := map[string]*imagePull{}
+	pull := func(image string) *imagePull {
+		if pulls[image] == nil {
+			pulls[image] = &imagePull{Image: image}
+		}
+		return pulls[image]
+	}
+	for _, event := range report.Events {
+		if description, ok := infraEventReasons[event.Reason]; ok {
+			issues[event.Reason] = fmt.Sprintf("%s: %s", description, event.Message)
+		}
+		if m := pullingImageRe.FindStringSubmatch(event.Message); m != nil {
+			pull(m[1])
+		} else if m := pulledImageRe.FindStringSubmatch(event.Message); m != nil {
+			pull(m[1]).Duration = m[2]
+		} else if m := cachedImageRe.FindStringSubmatch(event.Message); m != nil {
+			pull(m[1]).Cached = true
+		} else if m := failedImageRe.FindStringSubmatch(event.Message); m != nil {
+			pull(m[1]).Failed = true
+			issues["ImagePull/"+m[1]] = fmt.Sprintf("The image %s could not be pulled: %s", m[1], event.Message)
+		}
+	}
+	for _, p := range pulls {
+		d.ImagePulls = append(d.ImagePulls, *p)
+	}
+	sort.Slice(d.ImagePulls, func(i, j int) bool { return d.ImagePulls[i].Image < d.ImagePulls[j].Image })
+
+	if pod := report.Pod; pod != nil {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue && !pod.CreationTimestamp.IsZero() {
+				d.SchedulingDelay = condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time)
+			}
+		}
+		if pod.Status.Reason == "Evicted" {
+			issues["Evicted"] = fmt.Sprintf("%s: %s", infraEventReasons["Evicted"], pod.Status.Message)
+		}
+		for _, status := range append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+			for _, terminated := range []*v1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+				if terminated != nil && terminated.Reason == "OOMKilled" {
+					d.OOMKilled = append(d.OOMKilled, status.Name)
+					break
+				}
+			}
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 && terminated.Reason != "OOMKilled" {
+				d.FailedContainers = append(d.FailedContainers, status.Name)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(issues))
+	for key := range issues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		d.InfraIssues = append(d.InfraIssues, issues[key])
+	}
+	return d
+}
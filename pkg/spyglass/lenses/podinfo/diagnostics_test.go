@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8sreporter "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes"
+)
+
+func TestDiagnose(t *testing.T) {
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		report   k8sreporter.PodReport
+		expected diagnostics
+	}{
+		{
+			name:     "empty report",
+			expected: diagnostics{},
+		},
+		{
+			name: "test failure on a healthy node",
+			report: k8sreporter.PodReport{
+				Pod: &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+					Spec:       v1.PodSpec{NodeName: "node-1"},
+					Status: v1.PodStatus{
+						Conditions: []v1.PodCondition{
+							{Type: v1.PodScheduled, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(created.Add(90 * time.Second))},
+						},
+						ContainerStatuses: []v1.ContainerStatus{
+							{Name: "test", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}},
+							{Name: "sidecar", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}},
+						},
+					},
+				},
+				Events: []v1.Event{
+					{Reason: "Pulling", Message: `Pulling image "golang:1.22"`},
+					{Reason: "Pulled", Message: `Successfully pulled image "golang:1.22" in 8.113s (8.113s including waiting)`},
+					{Reason: "Pulled", Message: `Container image "gcr.io/k8s-prow/sidecar:v1" already present on machine`},
+				},
+				Node: &k8sreporter.NodeReport{Name: "node-1", Zone: "us-central1-b"},
+			},
+			expected: diagnostics{
+				Node:            &k8sreporter.NodeReport{Name: "node-1", Zone: "us-central1-b"},
+				SchedulingDelay: 90 * time.Second,
+				ImagePulls: []imagePull{
+					{Image: "gcr.io/k8s-prow/sidecar:v1", Cached: true},
+					{Image: "golang:1.22", Duration: "8.113s"},
+				},
+				FailedContainers: []string{"test"},
+			},
+		},
+		{
+			name: "infrastructure problems",
+			report: k8sreporter.PodReport{
+				Pod: &v1.Pod{
+					Spec: v1.PodSpec{NodeName: "node-2"},
+					Status: v1.PodStatus{
+						Reason:  "Evicted",
+						Message: "The node was low on resource: ephemeral-storage.",
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name:                 "test",
+								State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+								LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+							},
+						},
+					},
+				},
+				Events: []v1.Event{
+					{Reason: "FailedScheduling", Message: "0/3 nodes are available: 3 Insufficient cpu."},
+					{Reason: "Failed", Message: `Failed to pull image "example.com/missing:v1": not found`},
+				},
+			},
+			expected: diagnostics{
+				Node:      &k8sreporter.NodeReport{Name: "node-2"},
+				OOMKilled: []string{"test"},
+				ImagePulls: []imagePull{
+					{Image: "example.com/missing:v1", Failed: true},
+				},
+				InfraIssues: []string{
+					"The pod was evicted: The node was low on resource: ephemeral-storage.",
+					"The pod could not be scheduled: 0/3 nodes are available: 3 Insufficient cpu.",
+					`The image example.com/missing:v1 could not be pulled: Failed to pull image "example.com/missing:v1": not found`,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, diagnose(tc.report)); diff != "" {
+				t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
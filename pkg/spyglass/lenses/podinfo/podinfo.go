@@ -162,13 +162,15 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 	}
 
 	t := struct {
-		PodReport  k8sreporter.PodReport
-		PodLink    string
-		Containers []containerInfo
+		PodReport   k8sreporter.PodReport
+		PodLink     string
+		Diagnostics diagnostics
+		Containers  []containerInfo
 	}{
-		PodReport:  p,
-		PodLink:    podLink,
-		Containers: append(assembleContainers(p.Pod.Spec.InitContainers, p.Pod.Status.InitContainerStatuses), assembleContainers(p.Pod.Spec.Containers, p.Pod.Status.ContainerStatuses)...),
+		PodReport:   p,
+		PodLink:     podLink,
+		Diagnostics: diagnose(p),
+		Containers:  append(assembleContainers(p.Pod.Spec.InitContainers, p.Pod.Status.InitContainerStatuses), assembleContainers(p.Pod.Spec.Containers, p.Pod.Status.ContainerStatuses)...),
 	}
 
 	var buf bytes.Buffer
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resourceusage provides a Spyglass lens that charts the resource
+// usage of the test containers recorded in usage.json.
+package resourceusage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/pod-utils/usage"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+const (
+	name     = "resourceusage"
+	title    = "Resource Usage"
+	priority = 25
+
+	// chartWidth and chartHeight are the dimensions of the coordinate
+	// system of the charts, which are scaled to the width of the page.
+	chartWidth  = 800
+	chartHeight = 200
+)
+
+func init() {
+	lenses.RegisterLens(Lens{})
+}
+
+// Lens is the implementation of a resource usage charting Spyglass lens.
+type Lens struct{}
+
+// Config returns the lens's configuration.
+func (lens Lens) Config() lenses.LensConfig {
+	return lenses.LensConfig{
+		Name:     name,
+		Title:    title,
+		Priority: priority,
+	}
+}
+
+// Header renders the content of <head> from template.html.
+func (lens Lens) Header(artifacts []api.Artifact, resourceDir string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	t, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		return fmt.Sprintf("<!-- FAILED LOADING HEADER: %v -->", err)
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "header", nil); err != nil {
+		return fmt.Sprintf("<!-- FAILED EXECUTING HEADER TEMPLATE: %v -->", err)
+	}
+	return buf.String()
+}
+
+// Callback does nothing.
+func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	return ""
+}
+
+// Body renders a chart of the cpu, memory and GPU usage of each container.
+func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	if len(artifacts) != 1 {
+		logrus.WithField("artifacts", len(artifacts)).Error("resourceusage Body() called without exactly one artifact.")
+		return "Expected a single usage.json file."
+	}
+	raw, err := artifacts[0].ReadAll()
+	if err != nil {
+		logrus.WithError(err).Warn("Couldn't read a usage file that should exist.")
+		return fmt.Sprintf("Failed to read usage.json: %v", err)
+	}
+	report, err := usage.Parse(raw)
+	if err != nil {
+		logrus.WithError(err).Info("Error unmarshalling usage.json")
+		return fmt.Sprintf("Couldn't unmarshal usage.json: %v", err)
+	}
+
+	t, err := template.ParseFiles(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		logrus.WithError(err).Error("Error loading template.")
+		return fmt.Sprintf("Failed to load template file: %v", err)
+	}
+	var containers []container
+	for _, u := range report.Containers {
+		containers = append(containers, containerCharts(u))
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "body", containers); err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+	}
+	return buf.String()
+}
+
+type container struct {
+	Name   string
+	Charts []chart
+}
+
+type chart struct {
+	Title string
+	// Points is the polyline of the samples in chart coordinates.
+	Points string
+	// Samples is the JSON list of the x coordinate and the formatted value
+	// of each sample, used to show the value under the cursor.
+	Samples string
+	// LimitY is the y coordinate of the limit, if there is one.
+	LimitY   float64
+	HasLimit bool
+
+	Peak    string
+	Average string
+	Limit   string
+	Start   string
+	End     string
+}
+
+// series is the usage of one resource over time.
+type series struct {
+	title  string
+	values []float64
+	limit  float64
+	format func(float64) string
+}
+
+func containerCharts(u usage.Usage) container {
+	c := container{Name: u.Container}
+	if len(u.Samples) == 0 {
+		return c
+	}
+	cpu := series{title: "CPU", limit: u.CPULimitCores, format: formatCores}
+	memory := series{title: "Memory", limit: float64(u.MemoryLimitBytes), format: formatBytes}
+	var gpuCount int
+	for _, sample := range u.Samples {
+		cpu.values = append(cpu.values, sample.CPUCores)
+		memory.values = append(memory.values, float64(sample.MemoryBytes))
+		gpuCount = max(gpuCount, len(sample.GPUs))
+	}
+	var gpus []series
+	for i := 0; i < gpuCount; i++ {
+		utilization := series{title: fmt.Sprintf("GPU %d utilization", i), limit: 100, format: formatPercent}
+		gpuMemory := series{title: fmt.Sprintf("GPU %d memory", i), format: formatBytes}
+		for _, sample := range u.Samples {
+			// GPUs missing from a sample count as unused.
+			var gpu usage.GPUSample
+			if i < len(sample.GPUs) {
+				gpu = sample.GPUs[i]
+			}
+			utilization.values = append(utilization.values, gpu.UtilizationPercent)
+			gpuMemory.values = append(gpuMemory.values, float64(gpu.MemoryBytes))
+		}
+		gpus = append(gpus, utilization, gpuMemory)
+	}
+	for _, s := range append([]series{cpu, memory}, gpus...) {
+		c.Charts = append(c.Charts, s.chart(u.Samples))
+	}
+	return c
+}
+
+type samplePoint struct {
+	X     float64 `json:"x"`
+	Label string  `json:"label"`
+}
+
+func (s series) chart(samples []usage.Sample) chart {
+	start, end := samples[0].Time, samples[len(samples)-1].Time
+	duration := end.Sub(start).Seconds()
+	var peak, sum float64
+	for _, v := range s.values {
+		peak = math.Max(peak, v)
+		sum += v
+	}
+	top := math.Max(peak, s.limit) * 1.1
+	if top == 0 {
+		top = 1
+	}
+
+	c := chart{
+		Title:   s.title,
+		Peak:    s.format(peak),
+		Average: s.format(sum / float64(len(s.values))),
+		Start:   start.Format(time.RFC3339),
+		End:     end.Format(time.RFC3339),
+	}
+	if s.limit > 0 {
+		c.HasLimit = true
+		c.LimitY = y(s.limit, top)
+		c.Limit = s.format(s.limit)
+	}
+	var points []string
+	var labels []samplePoint
+	for i, v := range s.values {
+		x := 0.0
+		if duration > 0 {
+			x = samples[i].Time.Sub(start).Seconds() / duration * chartWidth
+		}
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y(v, top)))
+		labels = append(labels, samplePoint{X: x, Label: fmt.Sprintf("%s at %s", s.format(v), samples[i].Time.Format(time.RFC3339))})
+	}
+	c.Points = strings.Join(points, " ")
+	if raw, err := json.Marshal(labels); err == nil {
+		c.Samples = string(raw)
+	}
+	return c
+}
+
+// y returns the y coordinate of a value in a chart showing values up to top.
+func y(value, top float64) float64 {
+	return math.Round((chartHeight-value/top*chartHeight)*10) / 10
+}
+
+func formatCores(cores float64) string {
+	return fmt.Sprintf("%.2f cores", cores)
+}
+
+func formatPercent(percent float64) string {
+	return fmt.Sprintf("%.0f%%", percent)
+}
+
+func formatBytes(bytes float64) string {
+	for _, unit := range []string{"B", "KiB", "MiB", "GiB"} {
+		if bytes < 1024 {
+			return fmt.Sprintf("%.1f %s", bytes, unit)
+		}
+		bytes /= 1024
+	}
+	return fmt.Sprintf("%.1f TiB", bytes)
+}
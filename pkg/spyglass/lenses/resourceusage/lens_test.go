@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceusage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/pod-utils/usage"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/fake"
+)
+
+func TestContainerCharts(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := usage.Usage{
+		Container:        "test",
+		CPULimitCores:    2,
+		MemoryLimitBytes: 4 << 30,
+		Samples: []usage.Sample{
+			{Time: start, CPUCores: 1, MemoryBytes: 1 << 30},
+			{Time: start.Add(time.Minute), CPUCores: 2, MemoryBytes: 2 << 30, GPUs: []usage.GPUSample{{UtilizationPercent: 50, MemoryBytes: 1 << 20}}},
+		},
+	}
+
+	c := containerCharts(u)
+	if c.Name != "test" {
+		t.Errorf("expected container name test, got %q", c.Name)
+	}
+	var titles []string
+	for _, chart := range c.Charts {
+		titles = append(titles, chart.Title)
+	}
+	if diff := cmp.Diff([]string{"CPU", "Memory", "GPU 0 utilization", "GPU 0 memory"}, titles); diff != "" {
+		t.Fatalf("charts differ from expected (-want +got):\n%s", diff)
+	}
+
+	expectedCPU := chart{
+		Title:    "CPU",
+		Points:   "0.0,109.1 800.0,18.2",
+		Samples:  `[{"x":0,"label":"1.00 cores at 2026-01-01T00:00:00Z"},{"x":800,"label":"2.00 cores at 2026-01-01T00:01:00Z"}]`,
+		LimitY:   18.2,
+		HasLimit: true,
+		Peak:     "2.00 cores",
+		Average:  "1.50 cores",
+		Limit:    "2.00 cores",
+		Start:    "2026-01-01T00:00:00Z",
+		End:      "2026-01-01T00:01:00Z",
+	}
+	if diff := cmp.Diff(expectedCPU, c.Charts[0]); diff != "" {
+		t.Errorf("cpu chart differs from expected (-want +got):\n%s", diff)
+	}
+	if memory := c.Charts[1]; memory.Peak != "2.0 GiB" || memory.Limit != "4.0 GiB" {
+		t.Errorf("expected memory peak of 2.0 GiB and limit of 4.0 GiB, got %q and %q", memory.Peak, memory.Limit)
+	}
+	if gpu := c.Charts[2]; gpu.Average != "25%" {
+		t.Errorf("expected GPU missing from a sample to count as unused, got average %q", gpu.Average)
+	}
+	if gpuMemory := c.Charts[3]; gpuMemory.HasLimit {
+		t.Errorf("expected no limit for GPU memory, got %q", gpuMemory.Limit)
+	}
+}
+
+func TestBody(t *testing.T) {
+	artifact := &fake.Artifact{
+		Path:    "usage.json",
+		Content: []byte(`{"containers":[{"container":"test","samples":[{"time":"2026-01-01T00:00:00Z","cpu_cores":0.5,"memory_bytes":1024}]},{"container":"idle","samples":[]}]}`),
+	}
+	body := Lens{}.Body([]api.Artifact{artifact}, ".", "", nil, config.Spyglass{})
+	for _, expected := range []string{"<h4>test</h4>", "peak 0.50 cores", "peak 1.0 KiB", "<h4>idle</h4>", "No usage was sampled."} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected body to contain %q, got:\n%s", expected, body)
+		}
+	}
+
+	invalid := &fake.Artifact{Path: "usage.json", Content: []byte("{")}
+	if body := (Lens{}).Body([]api.Artifact{invalid}, ".", "", nil, config.Spyglass{}); !strings.HasPrefix(body, "Couldn't unmarshal usage.json") {
+		t.Errorf("expected unmarshalling error, got %q", body)
+	}
+}
@@ -0,0 +1,41 @@
+interface SamplePoint {
+  x: number;
+  label: string;
+}
+
+// nearest returns the sample closest to the x coordinate.
+const nearest = (samples: SamplePoint[], x: number): SamplePoint | undefined => {
+  let best: SamplePoint | undefined;
+  for (const sample of samples) {
+    if (!best || Math.abs(sample.x - x) < Math.abs(best.x - x)) {
+      best = sample;
+    }
+  }
+  return best;
+};
+
+const addCursor = (plot: SVGSVGElement): void => {
+  const samples: SamplePoint[] = JSON.parse(plot.dataset.samples || '[]');
+  const cursor = plot.querySelector<SVGLineElement>('line.usage-cursor')!;
+  const value = plot.parentElement!.querySelector<HTMLSpanElement>('span.usage-value')!;
+  plot.addEventListener('mousemove', (event) => {
+    const box = plot.getBoundingClientRect();
+    const x = (event.clientX - box.left) / box.width * plot.viewBox.baseVal.width;
+    const sample = nearest(samples, x);
+    if (!sample) {
+      return;
+    }
+    cursor.setAttribute('x1', String(sample.x));
+    cursor.setAttribute('x2', String(sample.x));
+    cursor.classList.remove('hidden');
+    value.textContent = sample.label;
+  });
+  plot.addEventListener('mouseleave', () => {
+    cursor.classList.add('hidden');
+    value.textContent = '';
+  });
+};
+
+window.addEventListener('DOMContentLoaded', () => {
+  document.querySelectorAll<SVGSVGElement>('svg.usage-plot').forEach(addCursor);
+});
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/io/providers"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+)
+
+// ArtifactsExpiredError is returned when the artifacts of a run are gone
+// because of the retention policy of their bucket.
+type ArtifactsExpiredError struct {
+	Bucket string
+	// Policy is the description of the retention policy of the bucket.
+	Policy string
+}
+
+func (e *ArtifactsExpiredError) Error() string {
+	msg := fmt.Sprintf("the artifacts of this run are no longer available in bucket %s", e.Bucket)
+	if e.Policy != "" {
+		msg += ": " + e.Policy
+	}
+	return msg
+}
+
+// ResolveArchived returns the source of the artifacts of the run at src, which
+// has no artifacts. If the bucket has a retention policy configured, its
+// archive bucket is consulted. If the artifacts can't be found there either
+// and the ProwJob of the run is complete or gone, an *ArtifactsExpiredError is
+// returned. src must already be resolved with ResolveSymlink.
+func (sg *Spyglass) ResolveArchived(ctx context.Context, src string) (string, error) {
+	keyType, key, err := splitSrc(src)
+	if err != nil {
+		return "", fmt.Errorf("error parsing src: %w", err)
+	}
+	if keyType == prowKeyType {
+		return src, nil
+	}
+	bucket, path, _ := strings.Cut(key, "/")
+	retention, ok := sg.config().Deck.Spyglass.ArtifactRetention[bucket]
+	if !ok {
+		return src, nil
+	}
+
+	if archive := retention.ArchiveBucket; archive != "" {
+		storageType := keyType
+		if storageType == api.GCSKeyType {
+			storageType = providers.GS
+		}
+		archiveKey := fmt.Sprintf("%s://%s/%s", storageType, archive, path)
+		names, err := sg.StorageArtifactFetcher.artifacts(ctx, archiveKey)
+		if err != nil {
+			logrus.WithError(err).WithField("key", archiveKey).Warn("Failed to list archived artifacts.")
+		}
+		if len(names) > 0 {
+			return fmt.Sprintf("%s/%s/%s", keyType, archive, path), nil
+		}
+	}
+	// Runs that didn't upload their artifacts yet have no artifacts either.
+	job, err := sg.prowJob(src)
+	if err != nil || (job != nil && !job.Complete()) {
+		return src, nil
+	}
+	return "", &ArtifactsExpiredError{Bucket: bucket, Policy: retention.Policy}
+}
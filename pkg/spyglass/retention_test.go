@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestResolveArchived(t *testing.T) {
+	testCases := []struct {
+		name        string
+		src         string
+		expected    string
+		expectedErr *ArtifactsExpiredError
+	}{
+		{
+			name:     "bucket without retention",
+			src:      "gs/multi-container-one-log/logs/job/404",
+			expected: "gs/multi-container-one-log/logs/job/404",
+		},
+		{
+			name:     "prowjob key",
+			src:      "prowjob/job/123",
+			expected: "prowjob/job/123",
+		},
+		{
+			name:     "artifacts in the archive",
+			src:      "gcs/test-bucket/logs/archived-run/1",
+			expected: "gcs/archive-bucket/logs/archived-run/1",
+		},
+		{
+			name:        "artifacts expired",
+			src:         "gs/test-bucket/logs/archived-run/2",
+			expectedErr: &ArtifactsExpiredError{Bucket: "test-bucket", Policy: "Artifacts are archived after 30 days and deleted after a year."},
+		},
+		{
+			name:     "pending job didn't upload artifacts yet",
+			src:      "gs/test-bucket/logs/archived-run/3",
+			expected: "gs/test-bucket/logs/archived-run/3",
+		},
+		{
+			name:        "artifacts of complete job expired",
+			src:         "gs/test-bucket/logs/archived-run/4",
+			expectedErr: &ArtifactsExpiredError{Bucket: "test-bucket", Policy: "Artifacts are archived after 30 days and deleted after a year."},
+		},
+	}
+
+	c := fca{c: config.Config{ProwConfig: config.ProwConfig{Deck: config.Deck{Spyglass: config.Spyglass{
+		ArtifactRetention: map[string]config.ArtifactRetention{
+			"test-bucket": {ArchiveBucket: "archive-bucket", Policy: "Artifacts are archived after 30 days and deleted after a year."},
+		},
+	}}}}}
+	sg := New(context.Background(), fakeJa, c.Config, io.NewGCSOpener(fakeGCSServer.Client()), false)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sg.ResolveArchived(context.Background(), tc.src)
+			if tc.expectedErr != nil {
+				var expired *ArtifactsExpiredError
+				if !errors.As(err, &expired) || *expired != *tc.expectedErr {
+					t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/prow/pkg/deck/jobs"
 	pkgio "sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/io/providers"
+	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
 	"sigs.k8s.io/prow/pkg/spyglass/api"
 	"sigs.k8s.io/prow/pkg/spyglass/lenses"
@@ -275,10 +276,30 @@ func (sg *Spyglass) JobPath(src string) (string, error) {
 // ProwJob returns a link and state to the YAML for the job specified in src.
 // If no job is found, it returns empty strings and nil error.
 func (sg *Spyglass) ProwJob(src string) (string, string, prowapi.ProwJobState, error) {
+	job, err := sg.prowJob(src)
+	if err != nil || job == nil {
+		return "", "", "", err
+	}
+	return job.Spec.Job, job.Name, job.Status.State, nil
+}
+
+// FailureClassification returns the failure classification of the job that
+// produced the artifacts at src, or nil if it has none.
+func (sg *Spyglass) FailureClassification(src string) (*pjutil.Classification, error) {
+	job, err := sg.prowJob(src)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	return pjutil.GetFailureClassification(job), nil
+}
+
+// prowJob returns the job that produced the artifacts at src, or nil if it
+// can't be found.
+func (sg *Spyglass) prowJob(src string) (*prowapi.ProwJob, error) {
 	src = strings.TrimSuffix(src, "/")
 	keyType, key, err := splitSrc(src)
 	if err != nil {
-		return "", "", "", fmt.Errorf("error parsing src: %v", src)
+		return nil, fmt.Errorf("error parsing src: %v", src)
 	}
 	split := strings.Split(key, "/")
 	var jobName string
@@ -286,13 +307,13 @@ func (sg *Spyglass) ProwJob(src string) (string, string, prowapi.ProwJobState, e
 	switch keyType {
 	case prowKeyType:
 		if len(split) < 2 {
-			return "", "", "", fmt.Errorf("invalid key %s: expected <job-name>/<build-id>", key)
+			return nil, fmt.Errorf("invalid key %s: expected <job-name>/<build-id>", key)
 		}
 		jobName = split[0]
 		buildID = split[1]
 	default:
 		if len(split) < 4 {
-			return "", "", "", fmt.Errorf("invalid key %s: expected <bucket-name>/<log-type>/.../<job-name>/<build-id>", key)
+			return nil, fmt.Errorf("invalid key %s: expected <bucket-name>/<log-type>/.../<job-name>/<build-id>", key)
 		}
 		jobName = split[len(split)-2]
 		buildID = split[len(split)-1]
@@ -300,11 +321,11 @@ func (sg *Spyglass) ProwJob(src string) (string, string, prowapi.ProwJobState, e
 	job, err := sg.jobAgent.GetProwJob(jobName, buildID)
 	if err != nil {
 		if jobs.IsErrProwJobNotFound(err) {
-			return "", "", "", nil
+			return nil, nil
 		}
-		return "", "", "", err
+		return nil, err
 	}
-	return job.Spec.Job, job.Name, job.Status.State, nil
+	return &job, nil
 }
 
 // RunPath returns the path to the directory for the job run specified in src.
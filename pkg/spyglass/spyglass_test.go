@@ -156,6 +156,11 @@ test/e2e/e2e.go:137 BeforeSuite on Node 1 failed test/e2e/e2e.go:137
 			Name:       "logs/job/123/test-1-build-log.txt",
 			Content:    []byte("this log exists in gcs!"),
 		},
+		{
+			BucketName: "archive-bucket",
+			Name:       "logs/archived-run/1/build-log.txt",
+			Content:    []byte("this log was archived"),
+		},
 	})
 	defer fakeGCSServer.Stop()
 	kc := fkc{
@@ -217,6 +222,27 @@ test/e2e/e2e.go:137 BeforeSuite on Node 1 failed test/e2e/e2e.go:137
 				BuildID: "123",
 			},
 		},
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Job:   "archived-run",
+			},
+			Status: prowapi.ProwJobStatus{
+				State:   prowapi.PendingState,
+				BuildID: "3",
+			},
+		},
+		prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{
+				Agent: prowapi.KubernetesAgent,
+				Job:   "archived-run",
+			},
+			Status: prowapi.ProwJobStatus{
+				State:          prowapi.SuccessState,
+				CompletionTime: &metav1.Time{},
+				BuildID:        "4",
+			},
+		},
 	}
 	fakeJa = jobs.NewJobAgent(context.Background(), kc, false, true, []string{}, map[string]jobs.PodLogClient{kube.DefaultClusterAlias: fpkc("clusterA"), "trusted": fpkc("clusterB")}, fca{}.Config)
 	fakeJa.Start()
@@ -469,7 +469,7 @@ RU5EIFBSSVZBVEUgS0VZLS0tLS1cbgo=`)
 			// (because deck crashed on gcsClient creation)
 			var actual string
 			cfg := createConfigGetter("test-bucket")
-			opener, err := io.NewOpener(context.Background(), path, "")
+			opener, err := io.NewOpener(context.Background(), path, "", "")
 			if err == nil {
 				af := NewStorageArtifactFetcher(opener, cfg, tc.useCookie)
 				actual, err = af.signURL(context.Background(), "gs://foo/bar/stuff")
@@ -38,7 +38,7 @@ import (
 )
 
 // NewController constructs a new controller to reconcile stauses on config change
-func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitDenylistAll sets.Set[string], opener io.Opener, configOpts configflagutil.ConfigOptions, statusURI string, prowJobClient prowv1.ProwJobInterface, githubClient github.Client, pluginAgent *plugins.ConfigAgent) *Controller {
+func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitDenylistAll, allowlist sets.Set[string], opener io.Opener, configOpts configflagutil.ConfigOptions, statusURI, reportURI string, reportOnly bool, prowJobClient prowv1.ProwJobInterface, githubClient github.Client, pluginAgent *plugins.ConfigAgent) *Controller {
 	sc := &statusController{
 		logger:     logrus.WithField("client", "statusController"),
 		opener:     opener,
@@ -50,6 +50,10 @@ func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitD
 		continueOnError:           continueOnError,
 		addedPresubmitDenylist:    addedPresubmitDenylist,
 		addedPresubmitDenylistAll: addedPresubmitDenylistAll,
+		allowlist:                 allowlist,
+		opener:                    opener,
+		reportURI:                 reportURI,
+		reportOnly:                reportOnly,
 		prowJobTriggerer: &kubeProwJobTriggerer{
 			prowJobClient: prowJobClient,
 			githubClient:  githubClient,
@@ -124,6 +128,7 @@ func (t *kubeProwJobTriggerer) runAndSkip(pr *github.PullRequest, requestedJobs
 type githubClient interface {
 	GetPullRequests(org, repo string) ([]github.PullRequest, error)
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetPullRequestsData(org, repo string, numbers []int) (map[int]github.PullRequestData, error)
 }
 
 type trustedChecker interface {
@@ -154,6 +159,16 @@ type Controller struct {
 	statusMigrator            statusMigrator
 	trustedChecker            trustedChecker
 	statusClient              statusClient
+
+	// allowlist restricts reconciling to the orgs and repos in it, if set.
+	allowlist sets.Set[string]
+	opener    io.Opener
+	// reportURI is where to write the report of the statuses the latest
+	// config change affects, if set.
+	reportURI string
+	// reportOnly makes the controller only report the statuses config changes
+	// affect instead of reconciling them.
+	reportOnly bool
 }
 
 // Run monitors the incoming configuration changes to determine when statuses need to be
@@ -170,6 +185,14 @@ func (c *Controller) Run(ctx context.Context) {
 		case change := <-changes:
 			start := time.Now()
 			log := logrus.WithField("old_config_revision", change.Before.ConfigVersionSHA).WithField("config_revision", change.After.ConfigVersionSHA)
+			if c.reportURI != "" || c.reportOnly {
+				c.writeReport(c.report(change, log), log)
+			}
+			if c.reportOnly {
+				// The state isn't saved either, so that running without
+				// reportOnly later reconciles the reported changes.
+				continue
+			}
 			if err := c.reconcile(change, log); err != nil {
 				log.WithError(err).Error("Error reconciling statuses.")
 			}
@@ -208,6 +231,14 @@ func (c *Controller) reconcile(delta config.Delta, log *logrus.Entry) error {
 	return utilerrors.NewAggregate(errors)
 }
 
+// skipped returns whether the statuses of the org/repo must not be reconciled.
+func (c *Controller) skipped(org, orgrepo string) bool {
+	if c.addedPresubmitDenylistAll.Has(org) || c.addedPresubmitDenylistAll.Has(orgrepo) {
+		return true
+	}
+	return c.allowlist.Len() > 0 && !c.allowlist.Has(org) && !c.allowlist.Has(orgrepo)
+}
+
 func (c *Controller) triggerNewPresubmits(addedPresubmits map[string][]config.Presubmit, log *logrus.Entry) error {
 	var triggerErrors []error
 	for orgrepo, presubmits := range addedPresubmits {
@@ -221,8 +252,7 @@ func (c *Controller) triggerNewPresubmits(addedPresubmits map[string][]config.Pr
 		}
 
 		org, repo := parts[0], parts[1]
-		if c.addedPresubmitDenylist.Has(org) || c.addedPresubmitDenylist.Has(orgrepo) ||
-			c.addedPresubmitDenylistAll.Has(org) || c.addedPresubmitDenylistAll.Has(orgrepo) {
+		if c.addedPresubmitDenylist.Has(org) || c.addedPresubmitDenylist.Has(orgrepo) || c.skipped(org, orgrepo) {
 			continue
 		}
 		prs, err := c.githubClient.GetPullRequests(org, repo)
@@ -233,6 +263,7 @@ func (c *Controller) triggerNewPresubmits(addedPresubmits map[string][]config.Pr
 			}
 			continue
 		}
+		prsData := c.pullRequestsData(org, repo, prs, presubmits, log)
 		for _, pr := range prs {
 			if pr.Mergable != nil && !*pr.Mergable {
 				// the PR cannot be merged as it is, so the user will need to update the PR (and trigger
@@ -249,6 +280,10 @@ func (c *Controller) triggerNewPresubmits(addedPresubmits map[string][]config.Pr
 			}, "inline-filter")
 			org, repo, number, branch := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Number, pr.Base.Ref
 			changes := config.NewGitHubDeferredChangedFilesProvider(c.githubClient, org, repo, number)
+			if data, ok := prsData[number]; ok && data.HeadSHA == pr.Head.SHA {
+				files := data.Files
+				changes = func() ([]string, error) { return files, nil }
+			}
 			logger := log.WithFields(logrus.Fields{"org": org, "repo": repo, "number": number, "branch": branch})
 			toTrigger, err := pjutil.FilterPresubmits(filter, changes, branch, presubmits, logger)
 			if err != nil {
@@ -266,6 +301,38 @@ func (c *Controller) triggerNewPresubmits(addedPresubmits map[string][]config.Pr
 	return utilerrors.NewAggregate(triggerErrors)
 }
 
+// pullRequestsData gets the files changed by the mergeable pull requests at
+// once if any of the presubmits could run against them, instead of getting
+// them one by one for every pull request. If that fails, they are gotten one
+// by one.
+func (c *Controller) pullRequestsData(org, repo string, prs []github.PullRequest, presubmits []config.Presubmit, log *logrus.Entry) map[int]github.PullRequestData {
+	var couldRunAgainstChanges bool
+	for _, presubmit := range presubmits {
+		if presubmit.RegexpChangeMatcher.CouldRun() {
+			couldRunAgainstChanges = true
+			break
+		}
+	}
+	if !couldRunAgainstChanges {
+		return nil
+	}
+	var numbers []int
+	for _, pr := range prs {
+		if pr.Mergable == nil || *pr.Mergable {
+			numbers = append(numbers, pr.Number)
+		}
+	}
+	if len(numbers) == 0 {
+		return nil
+	}
+	data, err := c.githubClient.GetPullRequestsData(org, repo, numbers)
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{"org": org, "repo": repo}).Warn("Failed to get the files changed by the pull requests at once.")
+		return nil
+	}
+	return data
+}
+
 func (c *Controller) triggerIfTrusted(org, repo string, pr github.PullRequest, toTrigger []config.Presubmit) error {
 	trusted, err := c.trustedChecker.trustedPullRequest(pr.User.Login, org, repo, pr.Number)
 	if err != nil {
@@ -296,7 +363,7 @@ func (c *Controller) retireRemovedContexts(retiredPresubmits map[string][]config
 			continue
 		}
 		org, repo := parts[0], parts[1]
-		if c.addedPresubmitDenylistAll.Has(org) || c.addedPresubmitDenylistAll.Has(orgrepo) {
+		if c.skipped(org, orgrepo) {
 			continue
 		}
 		for _, presubmit := range presubmits {
@@ -326,7 +393,7 @@ func (c *Controller) updateMigratedContexts(migrations map[string][]presubmitMig
 			continue
 		}
 		org, repo := parts[0], parts[1]
-		if c.addedPresubmitDenylistAll.Has(org) || c.addedPresubmitDenylistAll.Has(orgrepo) {
+		if c.skipped(org, orgrepo) {
 			continue
 		}
 		for _, migration := range migrations {
@@ -698,6 +698,8 @@ type fakeGitHubClient struct {
 	prs     map[orgRepo][]github.PullRequest
 	refs    map[orgRepo]map[string]string
 	changes map[orgRepo]map[int][]github.PullRequestChange
+
+	bulkQueries int
 }
 
 func (c *fakeGitHubClient) GetPullRequests(org, repo string) ([]github.PullRequest, error) {
@@ -716,6 +718,27 @@ func (c *fakeGitHubClient) GetPullRequestChanges(org, repo string, number int) (
 	return c.changes[key][number], nil
 }
 
+func (c *fakeGitHubClient) GetPullRequestsData(org, repo string, numbers []int) (map[int]github.PullRequestData, error) {
+	key := orgRepo{org: org, repo: repo}
+	if changes, exist := c.changeErrors[key]; exist && changes.HasAny(numbers...) {
+		return nil, errors.New("failed to get changes")
+	}
+	c.bulkQueries++
+	requested := sets.New(numbers...)
+	data := map[int]github.PullRequestData{}
+	for _, pr := range c.prs[key] {
+		if !requested.Has(pr.Number) {
+			continue
+		}
+		var files []string
+		for _, change := range c.changes[key][pr.Number] {
+			files = append(files, change.Filename)
+		}
+		data[pr.Number] = github.PullRequestData{Number: pr.Number, HeadSHA: pr.Head.SHA, Files: files}
+	}
+	return data, nil
+}
+
 func (c *fakeGitHubClient) GetRef(org, repo, ref string) (string, error) {
 	key := orgRepo{org: org, repo: repo}
 	if refs, exist := c.refErrors[key]; exist && refs.Has(ref) {
@@ -953,6 +976,31 @@ func TestControllerReconcile(t *testing.T) {
 				return controller, checker
 			},
 		},
+		{
+			name: "org outside of the allowlist skips creation, retire and migrate",
+			generator: func() (Controller, func(*testing.T)) {
+				fpjt := newfakeProwJobTriggerer()
+				fghc := newFakeGitHubClient(orgRepoKey)
+				fghc.prs[orgRepoKey] = []github.PullRequest{pr}
+				fghc.refs[orgRepoKey]["heads/"+pr.Base.Ref] = baseSha
+				fsm := newFakeMigrator(orgRepoKey)
+				ftc := newFakeTrustedChecker(orgRepoKey)
+				ftc.trusted[orgRepoKey][prAuthorKey] = true
+				controller := Controller{
+					continueOnError:  true,
+					allowlist:        sets.New[string]("other-org", "org/other-repo"),
+					prowJobTriggerer: &fpjt,
+					githubClient:     &fghc,
+					statusMigrator:   &fsm,
+					trustedChecker:   &ftc,
+				}
+				checker := func(t *testing.T) {
+					checkTriggerer(t, fpjt, map[prKey]sets.Set[string]{})
+					checkMigrator(t, fsm, map[orgRepo]sets.Set[string]{orgRepoKey: sets.New[string]()}, map[orgRepo]migrationSet{orgRepoKey: {}})
+				}
+				return controller, checker
+			},
+		},
 		{
 			name: "ignored all org/repo skips creation, retire and migrate",
 			generator: func() (Controller, func(*testing.T)) {
@@ -1205,6 +1253,54 @@ func TestControllerReconcile(t *testing.T) {
 	}
 }
 
+func TestPullRequestsData(t *testing.T) {
+	key := orgRepo{org: "org", repo: "repo"}
+	notMergeable := false
+	prs := []github.PullRequest{
+		{Number: 1, Head: github.PullRequestBranch{SHA: "sha1"}},
+		{Number: 2, Head: github.PullRequestBranch{SHA: "sha2"}, Mergable: &notMergeable},
+	}
+	testCases := []struct {
+		name         string
+		presubmits   []config.Presubmit
+		changeErrors sets.Set[int]
+		expected     map[int]github.PullRequestData
+		expectedBulk int
+	}{
+		{
+			name:       "presubmits that always run don't need the changes",
+			presubmits: []config.Presubmit{{AlwaysRun: true}},
+		},
+		{
+			name:         "changes of the mergeable PRs are gotten at once",
+			presubmits:   []config.Presubmit{{AlwaysRun: true}, {RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "foo"}}},
+			expected:     map[int]github.PullRequestData{1: {Number: 1, HeadSHA: "sha1", Files: []string{"foo"}}},
+			expectedBulk: 1,
+		},
+		{
+			name:         "failing to get the changes at once is not fatal",
+			presubmits:   []config.Presubmit{{RegexpChangeMatcher: config.RegexpChangeMatcher{SkipIfOnlyChanged: "foo"}}},
+			changeErrors: sets.New(1),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fghc := newFakeGitHubClient(key)
+			fghc.prs[key] = prs
+			fghc.changes = map[orgRepo]map[int][]github.PullRequestChange{key: {1: {{Filename: "foo"}}, 2: {{Filename: "bar"}}}}
+			fghc.changeErrors = map[orgRepo]sets.Set[int]{key: tc.changeErrors}
+			controller := Controller{githubClient: &fghc}
+			data := controller.pullRequestsData("org", "repo", prs, tc.presubmits, logrusEntry())
+			if diff := cmp.Diff(tc.expected, data); diff != "" {
+				t.Errorf("Unexpected pull requests data (-want +got):\n%s", diff)
+			}
+			if fghc.bulkQueries != tc.expectedBulk {
+				t.Errorf("Expected %d bulk queries, got %d", tc.expectedBulk, fghc.bulkQueries)
+			}
+		})
+	}
+}
+
 func logrusEntry() *logrus.Entry {
 	return logrus.NewEntry(logrus.StandardLogger())
 }
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// Report lists the statuses that a config change affects on the pull requests
+// of every repo.
+type Report struct {
+	OldConfigRevision string                 `json:"old_config_revision,omitempty"`
+	ConfigRevision    string                 `json:"config_revision,omitempty"`
+	Repos             map[string]*RepoReport `json:"repos,omitempty"`
+}
+
+// RepoReport lists the statuses that a config change affects on the pull
+// requests of a repo.
+type RepoReport struct {
+	// Triggered are the contexts of the added blocking presubmits, which are
+	// triggered on the pull requests.
+	Triggered []string `json:"triggered,omitempty"`
+	// Retired are the contexts of the removed presubmits.
+	Retired []string `json:"retired,omitempty"`
+	// Migrated are the contexts of the blocking presubmits whose context changed.
+	Migrated []ContextMigration `json:"migrated,omitempty"`
+}
+
+// ContextMigration is a context that is moved to another one.
+type ContextMigration struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// report returns the statuses that reconciling the config change affects,
+// leaving out the repos that are not reconciled.
+func (c *Controller) report(delta config.Delta, log *logrus.Entry) *Report {
+	report := &Report{
+		OldConfigRevision: delta.Before.ConfigVersionSHA,
+		ConfigRevision:    delta.After.ConfigVersionSHA,
+		Repos:             map[string]*RepoReport{},
+	}
+	repoReport := func(orgrepo string, triggered bool) *RepoReport {
+		parts := strings.SplitN(orgrepo, "/", 2)
+		if len(parts) != 2 {
+			return nil
+		}
+		org := parts[0]
+		if c.skipped(org, orgrepo) || triggered && (c.addedPresubmitDenylist.Has(org) || c.addedPresubmitDenylist.Has(orgrepo)) {
+			return nil
+		}
+		if _, ok := report.Repos[orgrepo]; !ok {
+			report.Repos[orgrepo] = &RepoReport{}
+		}
+		return report.Repos[orgrepo]
+	}
+
+	added, _ := addedBlockingPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)
+	for orgrepo, presubmits := range added {
+		for _, presubmit := range presubmits {
+			if r := repoReport(orgrepo, true); r != nil {
+				r.Triggered = append(r.Triggered, presubmit.Context)
+			}
+		}
+	}
+	removed, _ := removedPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)
+	for orgrepo, presubmits := range removed {
+		for _, presubmit := range presubmits {
+			if r := repoReport(orgrepo, false); r != nil {
+				r.Retired = append(r.Retired, presubmit.Context)
+			}
+		}
+	}
+	migrated, _ := migratedBlockingPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)
+	for orgrepo, migrations := range migrated {
+		for _, migration := range migrations {
+			if r := repoReport(orgrepo, false); r != nil {
+				r.Migrated = append(r.Migrated, ContextMigration{From: migration.from.Context, To: migration.to.Context})
+			}
+		}
+	}
+
+	for _, r := range report.Repos {
+		sort.Strings(r.Triggered)
+		sort.Strings(r.Retired)
+		sort.Slice(r.Migrated, func(i, j int) bool { return r.Migrated[i].From < r.Migrated[j].From })
+	}
+	return report
+}
+
+// writeReport logs the report and writes it to the report URI, if set.
+func (c *Controller) writeReport(report *Report, log *logrus.Entry) {
+	log.WithField("report", report).Info("Identified the statuses the config change affects.")
+	if c.reportURI == "" {
+		return
+	}
+	content, err := json.Marshal(report)
+	if err != nil {
+		log.WithError(err).Error("Error marshaling report.")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := io.WriteContent(ctx, log, c.opener, c.reportURI, content); err != nil {
+		log.WithError(err).WithField("path", c.reportURI).Error("Error writing report.")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusreconciler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+)
+
+func TestReport(t *testing.T) {
+	oldConfigData := `presubmits:
+  "org/repo":
+  - name: required-job
+    context: required-job
+    always_run: true
+  - name: other-required-job
+    context: other-required-job
+    always_run: true
+  "org/other-repo":
+  - name: required-job
+    context: required-job
+    always_run: true
+  "other-org/repo":
+  - name: required-job
+    context: required-job
+    always_run: true`
+	newConfigData := `presubmits:
+  "org/repo":
+  - name: other-required-job
+    context: new-context
+    always_run: true
+  - name: new-required-job
+    context: new-required-context
+    always_run: true
+  "org/other-repo":
+  - name: new-required-job
+    context: new-required-context
+    always_run: true
+  "other-org/repo": []`
+
+	var oldConfig, newConfig config.Config
+	if err := yaml.Unmarshal([]byte(oldConfigData), &oldConfig); err != nil {
+		t.Fatalf("could not unmarshal old config: %v", err)
+	}
+	if err := yaml.Unmarshal([]byte(newConfigData), &newConfig); err != nil {
+		t.Fatalf("could not unmarshal new config: %v", err)
+	}
+	oldConfig.ConfigVersionSHA, newConfig.ConfigVersionSHA = "old", "new"
+	delta := config.Delta{Before: oldConfig, After: newConfig}
+
+	testCases := []struct {
+		name     string
+		c        Controller
+		expected *Report
+	}{
+		{
+			name: "all repos are reported",
+			expected: &Report{
+				OldConfigRevision: "old",
+				ConfigRevision:    "new",
+				Repos: map[string]*RepoReport{
+					"org/repo": {
+						Triggered: []string{"new-required-context"},
+						Retired:   []string{"required-job"},
+						Migrated:  []ContextMigration{{From: "other-required-job", To: "new-context"}},
+					},
+					"org/other-repo": {
+						Triggered: []string{"new-required-context"},
+						Retired:   []string{"required-job"},
+					},
+					"other-org/repo": {
+						Retired: []string{"required-job"},
+					},
+				},
+			},
+		},
+		{
+			name: "only allowlisted repos that aren't denylisted are reported",
+			c: Controller{
+				addedPresubmitDenylist:    sets.New[string]("org/repo"),
+				addedPresubmitDenylistAll: sets.New[string]("org/other-repo"),
+				allowlist:                 sets.New[string]("org"),
+			},
+			expected: &Report{
+				OldConfigRevision: "old",
+				ConfigRevision:    "new",
+				Repos: map[string]*RepoReport{
+					"org/repo": {
+						Retired:  []string{"required-job"},
+						Migrated: []ContextMigration{{From: "other-required-job", To: "new-context"}},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opener := &fakeopener.FakeOpener{}
+			tc.c.opener = opener
+			tc.c.reportURI = "gs://bucket/report.json"
+			report := tc.c.report(delta, logrusEntry())
+			if diff := cmp.Diff(tc.expected, report); diff != "" {
+				t.Errorf("unexpected report (-want +got):\n%s", diff)
+			}
+
+			tc.c.writeReport(report, logrusEntry())
+			var written Report
+			if err := json.Unmarshal(opener.Buffer["gs://bucket/report.json"].Bytes(), &written); err != nil {
+				t.Fatalf("could not unmarshal written report: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, &written); diff != "" {
+				t.Errorf("unexpected written report (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -31,7 +31,8 @@ const throttlerGlobalKey = "*"
 type Throttler struct {
 	ticker   map[string]*time.Ticker
 	throttle map[string]chan time.Time
-	slow     map[string]*int32 // Helps log once when requests start/stop being throttled
+	slow     map[string]*int32        // Helps log once when requests start/stop being throttled
+	stop     map[string]chan struct{} // Stops the goroutine refilling the throttle
 	lock     sync.RWMutex
 }
 
@@ -119,26 +120,42 @@ func (t *Throttler) Throttle(hourlyTokens, burst int, orgs ...string) error {
 			delete(t.slow, org)
 			t.ticker[org].Stop()
 			delete(t.ticker, org)
+			close(t.stop[org])
+			delete(t.stop, org)
 		}
 		return nil
 	}
+	if previous, throttled := t.ticker[org]; throttled { // Throttle changed, e.g. at runtime
+		previous.Stop()
+		close(t.stop[org])
+	}
 	period := time.Hour / time.Duration(hourlyTokens) // Duration between token refills
 	ticker := time.NewTicker(period)
 	throttle := make(chan time.Time, burst)
 	for i := 0; i < burst; i++ { // Fill up the channel
 		throttle <- time.Now()
 	}
+	stop := make(chan struct{})
 	go func() {
 		// Before refilling, wait the amount of time it would have taken to refill the burst channel.
 		// This prevents granting too many tokens in the first hour due to the initial burst.
 		for i := 0; i < burst; i++ {
-			<-ticker.C
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
 		}
 		// Refill the channel
-		for t := range ticker.C {
+		for {
 			select {
-			case throttle <- t:
-			default:
+			case t := <-ticker.C:
+				select {
+				case throttle <- t:
+				default:
+				}
+			case <-stop:
+				return
 			}
 		}
 	}()
@@ -159,5 +176,10 @@ func (t *Throttler) Throttle(hourlyTokens, burst int, orgs ...string) error {
 	var i int32
 	t.slow[org] = &i
 
+	if t.stop == nil {
+		t.stop = map[string]chan struct{}{}
+	}
+	t.stop[org] = stop
+
 	return nil
 }
@@ -171,3 +171,28 @@ func TestThrottle(t *testing.T) {
 		})
 	}
 }
+
+func TestThrottleStopsPreviousRefill(t *testing.T) {
+	var throttler Throttler
+	if err := throttler.Throttle(3600, 1); err != nil {
+		t.Fatalf("failed to throttle: %v", err)
+	}
+	previous := throttler.stop[throttlerGlobalKey]
+	if err := throttler.Throttle(7200, 2); err != nil {
+		t.Fatalf("failed to change throttle: %v", err)
+	}
+	select {
+	case <-previous:
+	default:
+		t.Error("expected the refill of the previous throttle to be stopped")
+	}
+	current := throttler.stop[throttlerGlobalKey]
+	if err := throttler.Throttle(0, 0); err != nil {
+		t.Fatalf("failed to disable throttle: %v", err)
+	}
+	select {
+	case <-current:
+	default:
+		t.Error("expected the refill of the disabled throttle to be stopped")
+	}
+}
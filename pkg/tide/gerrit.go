@@ -99,9 +99,11 @@ func NewGerritController(
 	cfgAgent *config.Agent,
 	gc git.ClientFactory,
 	maxRecordsPerPool int,
+	maxRecordAge time.Duration,
 	opener io.Opener,
 	historyURI,
-	statusURI string,
+	statusURI,
+	poolsURI string,
 	logger *logrus.Entry,
 	configOptions configflagutil.ConfigOptions,
 	cookieFilePath string,
@@ -110,7 +112,7 @@ func NewGerritController(
 	if logger == nil {
 		logger = logrus.NewEntry(logrus.StandardLogger())
 	}
-	hist, err := history.New(maxRecordsPerPool, opener, historyURI)
+	hist, err := history.New(maxRecordsPerPool, maxRecordAge, opener, historyURI)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing history client from %q: %w", historyURI, err)
 	}
@@ -142,6 +144,8 @@ func NewGerritController(
 	if err != nil {
 		return nil, err
 	}
+	syncCtrl.opener, syncCtrl.poolsPath = opener, poolsURI
+	syncCtrl.loadPools()
 	return &Controller{syncCtrl: syncCtrl}, nil
 }
 
@@ -35,8 +35,13 @@ import (
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/report"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/plugins/approve"
+	"sigs.k8s.io/prow/pkg/plugins/releasenote"
+	"sigs.k8s.io/prow/pkg/repoowners"
 	"sigs.k8s.io/prow/pkg/tide/blockers"
 
+	lru "github.com/hashicorp/golang-lru"
 	githubql "github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
 )
@@ -59,11 +64,29 @@ type GitHubProvider struct {
 	ghc                githubClient
 	gc                 git.ClientFactory
 	usesGitHubAppsAuth bool
+	// deployments caches the successful deployments of head commits to an
+	// environment, so that they are only checked until they succeeded.
+	deployments *lru.Cache
+	// ownersClient loads the OWNERS to determine the approvers of PRs for
+	// the merge commit templates.
+	ownersClient ownersClient
 
 	*mergeChecker
 	logger *logrus.Entry
 }
 
+type ownersClient interface {
+	LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error)
+}
+
+// deploymentCacheSize is the number of successful deployments that are cached.
+const deploymentCacheSize = 5000
+
+// deploymentKey identifies the deployments of a commit to an environment.
+type deploymentKey struct {
+	org, repo, sha, environment string
+}
+
 func newGitHubProvider(
 	logger *logrus.Entry,
 	ghc githubClient,
@@ -72,12 +95,15 @@ func newGitHubProvider(
 	mergeChecker *mergeChecker,
 	usesGitHubAppsAuth bool,
 ) *GitHubProvider {
+	// The size is positive, so creating the cache can't fail.
+	deployments, _ := lru.New(deploymentCacheSize)
 	return &GitHubProvider{
 		logger:             logger,
 		ghc:                ghc,
 		gc:                 gc,
 		cfg:                cfg,
 		usesGitHubAppsAuth: usesGitHubAppsAuth,
+		deployments:        deployments,
 		mergeChecker:       mergeChecker,
 	}
 }
@@ -114,6 +140,8 @@ func (gi *GitHubProvider) Query() (map[string]CodeReviewCommon, error) {
 			queries = map[string]string{"": query.Query()}
 		}
 
+		requireResolvedConversations := query.RequireResolvedConversations
+		deploymentEnvironment := query.RequiredDeploymentEnvironment
 		for org, q := range queries {
 			org, q, i := org, q, i
 			wg.Add(1)
@@ -127,6 +155,10 @@ func (gi *GitHubProvider) Query() (map[string]CodeReviewCommon, error) {
 				}
 				tideMetrics.queryResults.WithLabelValues(strconv.Itoa(i), org, resultString).Inc()
 
+				if deploymentEnvironment != "" {
+					results = gi.deployedPRs(results, deploymentEnvironment)
+				}
+
 				lock.Lock()
 				defer lock.Unlock()
 				if err != nil && len(results) == 0 {
@@ -139,6 +171,10 @@ func (gi *GitHubProvider) Query() (map[string]CodeReviewCommon, error) {
 				}
 
 				for _, pr := range results {
+					if requireResolvedConversations && pr.unresolvedReviewThreads() > 0 {
+						gi.logger.WithFields(pr.logFields()).Debug("Ignoring PR with unresolved review conversations.")
+						continue
+					}
 					crc := CodeReviewCommonFromPullRequest(&pr)
 					prs[prKey(crc)] = *crc
 				}
@@ -150,6 +186,61 @@ func (gi *GitHubProvider) Query() (map[string]CodeReviewCommon, error) {
 	return prs, utilerrors.NewAggregate(errs)
 }
 
+// deployedPRs returns the PRs whose head commit was successfully deployed
+// to the environment. PRs whose deployments can't be checked are dropped.
+func (gi *GitHubProvider) deployedPRs(prs []PullRequest, environment string) []PullRequest {
+	var deployed []PullRequest
+	for _, pr := range prs {
+		log := gi.logger.WithFields(pr.logFields()).WithField("environment", environment)
+		ok, err := gi.deployed(string(pr.Repository.Owner.Login), string(pr.Repository.Name), string(pr.HeadRefOID), environment)
+		if err != nil {
+			log.WithError(err).Warn("Failed to check the deployments of the PR, ignoring it.")
+			continue
+		}
+		if !ok {
+			log.Debug("Ignoring PR without a successful deployment.")
+			continue
+		}
+		deployed = append(deployed, pr)
+	}
+	return deployed
+}
+
+// deployed tells whether the most recent deployment of sha to the environment
+// succeeded. A deployment that a newer one has since marked inactive still
+// counts, as otherwise deploying one PR would evict all others from the pool.
+// Successful deployments are cached, as they are checked on every sync.
+func (gi *GitHubProvider) deployed(org, repo, sha, environment string) (bool, error) {
+	key := deploymentKey{org: org, repo: repo, sha: sha, environment: environment}
+	if gi.deployments != nil && gi.deployments.Contains(key) {
+		return true, nil
+	}
+	deployments, err := gi.ghc.ListDeployments(org, repo, sha, environment)
+	if err != nil {
+		return false, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	if len(deployments) == 0 {
+		return false, nil
+	}
+	statuses, err := gi.ghc.ListDeploymentStatuses(org, repo, deployments[0].ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list statuses of deployment %d: %w", deployments[0].ID, err)
+	}
+	for _, status := range statuses {
+		if status.State == github.DeploymentStateInactive {
+			continue
+		}
+		if status.State != github.DeploymentStateSuccess {
+			return false, nil
+		}
+		if gi.deployments != nil {
+			gi.deployments.Add(key, struct{}{})
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 func (gi *GitHubProvider) GetRef(org, repo, ref string) (string, error) {
 	return gi.ghc.GetRef(org, repo, ref)
 }
@@ -217,10 +308,11 @@ func (gi *GitHubProvider) prepareMergeDetails(commitTemplates config.TideMergeCo
 		MergeMethod: string(mergeMethod),
 	}
 
+	data := &mergeCommitTemplateData{CodeReviewCommon: pr, ghc: gi.ghc, ownersClient: gi.ownersClient, logger: gi.logger}
 	if commitTemplates.Title != nil {
 		var b bytes.Buffer
 
-		if err := commitTemplates.Title.Execute(&b, pr); err != nil {
+		if err := commitTemplates.Title.Execute(&b, data); err != nil {
 			gi.logger.Errorf("error executing commit title template: %v", err)
 		} else {
 			ghMergeDetails.CommitTitle = b.String()
@@ -230,7 +322,7 @@ func (gi *GitHubProvider) prepareMergeDetails(commitTemplates config.TideMergeCo
 	if commitTemplates.Body != nil {
 		var b bytes.Buffer
 
-		if err := commitTemplates.Body.Execute(&b, pr); err != nil {
+		if err := commitTemplates.Body.Execute(&b, data); err != nil {
 			gi.logger.Errorf("error executing commit body template: %v", err)
 		} else {
 			ghMergeDetails.CommitMessage = b.String()
@@ -240,6 +332,70 @@ func (gi *GitHubProvider) prepareMergeDetails(commitTemplates config.TideMergeCo
 	return ghMergeDetails
 }
 
+// mergeCommitTemplateData is passed to the merge commit templates. It embeds
+// the PR, so templates can use its fields like {{ .Number }}, and adds data
+// that isn't part of the PR like {{ .Approvers }} and {{ .ReleaseNote }}.
+type mergeCommitTemplateData struct {
+	CodeReviewCommon
+
+	ghc          githubClient
+	ownersClient ownersClient
+	logger       *logrus.Entry
+	// approvers are only listed if a template uses them.
+	approvers []string
+	listed    bool
+}
+
+// ReleaseNote returns the content of the release-note block of the PR body,
+// or an empty string if there is none.
+func (d *mergeCommitTemplateData) ReleaseNote() string {
+	return releasenote.GetReleaseNote(d.Body)
+}
+
+// Approvers returns the sorted logins of the approvers of the PR like the
+// approve plugin with its default settings determines them: the users that
+// approved the PR and are approvers of at least one of its files in OWNERS.
+func (d *mergeCommitTemplateData) Approvers() ([]string, error) {
+	if d.listed {
+		return d.approvers, nil
+	}
+	if d.ownersClient == nil {
+		return nil, errors.New("no OWNERS client to determine the approvers")
+	}
+	owners, err := d.ownersClient.LoadRepoOwners(d.Org, d.Repo, d.BaseRefName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OWNERS: %w", err)
+	}
+	changes, err := d.ghc.GetPullRequestChanges(d.Org, d.Repo, d.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+	var filenames []string
+	for _, change := range changes {
+		filenames = append(filenames, change.Filename)
+	}
+	isBot, err := d.ghc.BotUserChecker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the bot user checker: %w", err)
+	}
+	comments, err := d.ghc.ListIssueComments(d.Org, d.Repo, d.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	reviewComments, err := d.ghc.ListPullRequestComments(d.Org, d.Repo, d.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review comments: %w", err)
+	}
+	reviews, err := d.ghc.ListReviews(d.Org, d.Repo, d.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	d.approvers = approve.OwnersApprovers(d.logger.WithFields(d.logFields()), owners, &plugins.Approve{}, d.Number, d.AuthorLogin, filenames, isBot, comments, reviewComments, reviews)
+	d.listed = true
+	return d.approvers, nil
+}
+
 func (gi *GitHubProvider) mergePRs(sp subpool, prs []CodeReviewCommon, dontUpdateStatus *threadSafePRSet) ([]CodeReviewCommon, error) {
 	var merged []CodeReviewCommon
 	var failed []int
@@ -407,6 +563,10 @@ func (gi *GitHubProvider) GetChangedFiles(org, repo string, number int) ([]strin
 	return files, nil
 }
 
+func (gi *GitHubProvider) getPullRequestsData(org, repo string, numbers []int) (map[int]github.PullRequestData, error) {
+	return gi.ghc.GetPullRequestsData(org, repo, numbers)
+}
+
 func (gi *GitHubProvider) refsForJob(sp subpool, prs []CodeReviewCommon) (prowapi.Refs, error) {
 	refs := prowapi.Refs{
 		Org:     sp.org,
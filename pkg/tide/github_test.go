@@ -28,13 +28,55 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/git/types"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/layeredsets"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+	"sigs.k8s.io/prow/pkg/repoowners"
 )
 
+// fakeRepoOwners has a single root OWNERS file listing the approvers.
+type fakeRepoOwners struct {
+	repoowners.RepoOwner
+	approvers []string
+}
+
+func (f fakeRepoOwners) Approvers(path string) layeredsets.String {
+	return layeredsets.NewString(f.approvers...)
+}
+
+func (f fakeRepoOwners) LeafApprovers(path string) sets.Set[string] {
+	return sets.New(f.approvers...)
+}
+
+func (f fakeRepoOwners) FindApproverOwnersForFile(path string) string {
+	return ""
+}
+
+func (f fakeRepoOwners) IsNoParentOwners(path string) bool {
+	return false
+}
+
+func (f fakeRepoOwners) IsAutoApproveUnownedSubfolders(directory string) bool {
+	return false
+}
+
+func (f fakeRepoOwners) Filenames() ownersconfig.Filenames {
+	return ownersconfig.FakeFilenames
+}
+
+type fakeOwnersClient struct {
+	owners repoowners.RepoOwner
+}
+
+func (f fakeOwnersClient) LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error) {
+	return f.owners, nil
+}
+
 func TestSearch(t *testing.T) {
 	const q = "random search string"
 	now := time.Now()
@@ -187,11 +229,15 @@ func TestPrepareMergeDetails(t *testing.T) {
 		Title:      "my commit title",
 		Body:       "my commit body",
 	}
+	prWithReleaseNote := pr
+	prWithReleaseNote.Body = "my commit body\n\n```release-note\nAdded a feature.\n```\n"
 
 	testCases := []struct {
 		name        string
 		tpl         config.TideMergeCommitTemplate
 		pr          PullRequest
+		reviews     []github.Review
+		comments    []github.IssueComment
 		mergeMethod types.PullRequestMergeType
 		expected    github.MergeDetails
 	}{{
@@ -255,6 +301,57 @@ func TestPrepareMergeDetails(t *testing.T) {
 			SHA:         "SHA",
 			MergeMethod: "merge",
 		},
+	}, {
+		name: "Commit template uses release note",
+		tpl: config.TideMergeCommitTemplate{
+			Body: getTemplate("CommitBody", "{{ with .ReleaseNote }}Release-Note: {{ . }}{{ end }}"),
+		},
+		pr:          prWithReleaseNote,
+		mergeMethod: "squash",
+		expected: github.MergeDetails{
+			SHA:           "SHA",
+			MergeMethod:   "squash",
+			CommitMessage: "Release-Note: Added a feature.",
+		},
+	}, {
+		name: "Commit template without release note",
+		tpl: config.TideMergeCommitTemplate{
+			Body: getTemplate("CommitBody", "{{ .Title }}{{ with .ReleaseNote }} Release-Note: {{ . }}{{ end }}"),
+		},
+		pr:          pr,
+		mergeMethod: "squash",
+		expected: github.MergeDetails{
+			SHA:           "SHA",
+			MergeMethod:   "squash",
+			CommitMessage: "my commit title",
+		},
+	}, {
+		name: "Commit template uses approvers",
+		tpl: config.TideMergeCommitTemplate{
+			Title: getTemplate("CommitTitle", "{{ .Title }} (#{{ .Number }})"),
+			Body:  getTemplate("CommitBody", "{{ range .Approvers }}Approved-by: {{ . }}\n{{ end }}"),
+		},
+		pr: pr,
+		reviews: []github.Review{
+			{User: github.User{Login: "carol"}, State: github.ReviewStateApproved},
+			{User: github.User{Login: "dave"}, State: github.ReviewStateApproved},
+			{User: github.User{Login: "dave"}, State: github.ReviewStateDismissed},
+			{User: github.User{Login: "erin"}, State: github.ReviewStateCommented},
+		},
+		comments: []github.IssueComment{
+			{User: github.User{Login: "bob"}, Body: "/approve"},
+			{User: github.User{Login: "alice"}, Body: "/lgtm\n/approve no-issue"},
+			{User: github.User{Login: "frank"}, Body: "/approve"},
+			{User: github.User{Login: "frank"}, Body: "/approve cancel"},
+			{User: github.User{Login: "grace"}, Body: "/approve"},
+		},
+		mergeMethod: "squash",
+		expected: github.MergeDetails{
+			SHA:           "SHA",
+			MergeMethod:   "squash",
+			CommitTitle:   "my commit title (#1)",
+			CommitMessage: "Approved-by: alice\nApproved-by: bob\nApproved-by: carol\n",
+		},
 	}}
 
 	for _, test := range testCases {
@@ -263,9 +360,15 @@ func TestPrepareMergeDetails(t *testing.T) {
 			cfgAgent := &config.Agent{}
 			cfgAgent.Set(cfg)
 			provider := &GitHubProvider{
-				cfg:    cfgAgent.Config,
-				ghc:    &fgc{},
-				logger: logrus.WithContext(context.Background()),
+				cfg: cfgAgent.Config,
+				ghc: &fgc{
+					reviews:       map[int][]github.Review{1: test.reviews},
+					issueComments: map[int][]github.IssueComment{1: test.comments},
+					changes:       map[int][]github.PullRequestChange{1: {{Filename: "pkg/foo.go"}}},
+				},
+				// dave and grace approved the PR but are no approvers in OWNERS.
+				ownersClient: fakeOwnersClient{owners: fakeRepoOwners{approvers: []string{"alice", "bob", "carol", "erin", "frank"}}},
+				logger:       logrus.WithContext(context.Background()),
 			}
 
 			actual := provider.prepareMergeDetails(test.tpl, *CodeReviewCommonFromPullRequest(&test.pr), test.mergeMethod)
@@ -44,6 +44,9 @@ type History struct {
 	logs map[string]*recordLog
 	sync.Mutex
 	logSizeLimit int
+	// maxAge is how long records are retained, zero retains them until they
+	// are pushed out by newer records.
+	maxAge time.Duration
 
 	opener opener
 	path   string
@@ -122,11 +125,13 @@ type Record struct {
 	TenantIDs []string       `json:"tenantids"`
 }
 
-// New creates a new History struct with the specified recordLog size limit.
-func New(maxRecordsPerKey int, opener io.Opener, path string) (*History, error) {
+// New creates a new History struct with the specified recordLog size limit
+// and retention.
+func New(maxRecordsPerKey int, maxAge time.Duration, opener io.Opener, path string) (*History, error) {
 	hist := &History{
 		logs:         map[string]*recordLog{},
 		logSizeLimit: maxRecordsPerKey,
+		maxAge:       maxAge,
 		opener:       opener,
 		path:         path,
 	}
@@ -139,6 +144,7 @@ func New(maxRecordsPerKey int, opener io.Opener, path string) (*History, error)
 		if err != nil {
 			return nil, err
 		}
+		hist.prune()
 		logrus.WithFields(logrus.Fields{
 			"duration": time.Since(start).String(),
 			"path":     hist.path,
@@ -186,8 +192,39 @@ func (h *History) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// prune drops the records that are older than the retention and the pools
+// that have no records left.
+func (h *History) prune() {
+	if h.maxAge <= 0 {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	cutoff := now().Add(-h.maxAge)
+	for key, log := range h.logs {
+		records := log.toSlice()
+		// Records are sorted from newest to oldest.
+		keep := sort.Search(len(records), func(i int) bool {
+			return records[i].Time.Before(cutoff)
+		})
+		if keep == len(records) {
+			continue
+		}
+		if keep == 0 {
+			delete(h.logs, key)
+			continue
+		}
+		h.logs[key] = newRecordLog(h.logSizeLimit)
+		for i := keep - 1; i >= 0; i-- {
+			h.logs[key].add(records[i])
+		}
+	}
+}
+
 // Flush writes the action history to persistent storage if configured to do so.
+// Records older than the retention are dropped first.
 func (h *History) Flush() {
+	h.prune()
 	if h.path == "" {
 		return
 	}
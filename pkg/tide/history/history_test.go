@@ -23,10 +23,12 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/util/diff"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
@@ -54,7 +56,7 @@ func TestHistory(t *testing.T) {
 		}
 	}
 
-	hist, err := New(logSizeLimit, nil, "")
+	hist, err := New(logSizeLimit, 0, nil, "")
 	if err != nil {
 		t.Fatalf("Failed to create history client: %v", err)
 	}
@@ -144,6 +146,50 @@ func TestHistory(t *testing.T) {
 	}
 }
 
+func TestHistoryRetention(t *testing.T) {
+	nowTime := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+	oldNow := now
+	now = func() time.Time { return nowTime }
+	defer func() { now = oldNow }()
+
+	obj := &testOpener{content: `{` +
+		`"o/r:old":[{"time":"2026-03-02T09:00:00Z","action":"MERGE"}],` +
+		`"o/r:b":[{"time":"2026-03-02T11:30:00Z","action":"MERGE2"},{"time":"2026-03-02T10:30:00Z","action":"MERGE1"}]}`}
+	logs, err := readHistory(3, obj, fakePath)
+	if err != nil {
+		t.Fatalf("Unexpected error reading history: %v.", err)
+	}
+	hist := &History{logs: logs, logSizeLimit: 3, maxAge: 2 * time.Hour, opener: obj, path: fakePath}
+	hist.prune()
+	expected := map[string][]*Record{
+		"o/r:b": {
+			{Time: time.Date(2026, time.March, 2, 11, 30, 0, 0, time.UTC), Action: "MERGE2"},
+			{Time: time.Date(2026, time.March, 2, 10, 30, 0, 0, time.UTC), Action: "MERGE1"},
+		},
+	}
+	if diff := cmp.Diff(expected, hist.AllRecords()); diff != "" {
+		t.Errorf("Unexpected records after loading history (-want +got):\n%s", diff)
+	}
+
+	// Records expire while Tide is running.
+	nowTime = nowTime.Add(time.Hour)
+	hist.Record("o/r:b", "MERGE3", "", "", nil, nil)
+	obj.closed = false
+	hist.Flush()
+	expected = map[string][]*Record{
+		"o/r:b": {
+			{Time: nowTime, Action: "MERGE3"},
+			{Time: time.Date(2026, time.March, 2, 11, 30, 0, 0, time.UTC), Action: "MERGE2"},
+		},
+	}
+	if diff := cmp.Diff(expected, hist.AllRecords()); diff != "" {
+		t.Errorf("Unexpected records after flushing history (-want +got):\n%s", diff)
+	}
+	if !strings.Contains(obj.content, "MERGE2") || strings.Contains(obj.content, "MERGE1") {
+		t.Errorf("Expected expired records not to be written, got %s", obj.content)
+	}
+}
+
 const fakePath = "/some/random/path"
 
 type testOpener struct {
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+// failedTideJobsByPullIndexName is the name of the index of failed ProwJobs
+// that Tide triggered, by the PRs they tested. Use the
+// failedTideJobsByPullIndexKey function to get the correct value.
+const failedTideJobsByPullIndexName = "tide-failed-jobs-by-pull"
+
+func failedTideJobsByPullIndexKey(org, repo string, number int, headSHA string) string {
+	return fmt.Sprintf("%s/%s#%d@%s", org, repo, number, headSHA)
+}
+
+// failedTideJobsByPullIndexFunc indexes failed presubmit and single-PR batch
+// ProwJobs that were triggered by Tide under the PR they tested. Batches of
+// several PRs are not indexed, as their failure can't be attributed to one of
+// the PRs and would use up the budget of all of them.
+func failedTideJobsByPullIndexFunc(obj ctrlruntimeclient.Object) []string {
+	pj := obj.(*prowapi.ProwJob)
+	if pj.Labels[kube.CreatedByTideLabel] != "true" || pj.Spec.Refs == nil {
+		return nil
+	}
+	if pj.Spec.Type != prowapi.PresubmitJob && pj.Spec.Type != prowapi.BatchJob {
+		return nil
+	}
+	if pj.Status.State != prowapi.FailureState && pj.Status.State != prowapi.ErrorState {
+		return nil
+	}
+	if len(pj.Spec.Refs.Pulls) != 1 {
+		return nil
+	}
+	pull := pj.Spec.Refs.Pulls[0]
+	return []string{failedTideJobsByPullIndexKey(pj.Spec.Refs.Org, pj.Spec.Refs.Repo, pull.Number, pull.SHA)}
+}
+
+// retestBudget returns the retest budget of a PR and how many times the tests
+// Tide triggered for it failed within the window of the budget, counting the
+// job that failed the most often. limited is false if the budget of the PR
+// is unlimited.
+func retestBudget(ctx context.Context, client ctrlruntimeclient.Reader, cfg *config.Config, pr *CodeReviewCommon, now time.Time) (budget config.TideRetestBudget, failures int, limited bool, err error) {
+	budget, limited = cfg.Tide.RetestBudget(config.OrgRepo{Org: pr.Org, Repo: pr.Repo})
+	if !limited || hasAllLabels(*pr, []string{budget.GetLabel()}) {
+		return budget, 0, false, nil
+	}
+
+	pjs := &prowapi.ProwJobList{}
+	if err := client.List(ctx,
+		pjs,
+		ctrlruntimeclient.MatchingFields{failedTideJobsByPullIndexName: failedTideJobsByPullIndexKey(pr.Org, pr.Repo, pr.Number, pr.HeadRefOID)},
+		ctrlruntimeclient.InNamespace(cfg.ProwJobNamespace),
+	); err != nil {
+		return budget, 0, true, fmt.Errorf("failed to list failed ProwJobs: %w", err)
+	}
+
+	failuresByJob := map[string]int{}
+	for _, pj := range pjs.Items {
+		if now.Sub(pj.Status.StartTime.Time) > budget.GetWindow() {
+			continue
+		}
+		failuresByJob[pj.Spec.Job]++
+		failures = max(failures, failuresByJob[pj.Spec.Job])
+	}
+	return budget, failures, true, nil
+}
+
+// withinRetestBudget determines whether Tide may trigger tests for the PR.
+// Tide re-triggers tests after every failure, so the budget is exhausted once
+// the tests failed more often than the budget allows retests.
+func (c *syncController) withinRetestBudget(log *logrus.Entry, pr *CodeReviewCommon) bool {
+	budget, failures, limited, err := retestBudget(c.ctx, c.prowJobClient, c.config(), pr, time.Now())
+	if err != nil {
+		log.WithError(err).WithFields(pr.logFields()).Warn("Failed to determine the retest budget of the PR, ignoring the budget.")
+		return true
+	}
+	if limited && failures > budget.MaxRetests {
+		log.WithFields(pr.logFields()).WithField("failures", failures).Info("Retest budget of the PR is exhausted, not triggering tests.")
+		return false
+	}
+	return true
+}
+
+// retestBudgetStatus returns the status description of a PR whose retest
+// budget is exhausted, or the retests it used of its budget to append to the
+// description of a PR that is being retested otherwise.
+func retestBudgetStatus(budget config.TideRetestBudget, failures int) (string, bool) {
+	if failures > budget.MaxRetests {
+		return fmt.Sprintf(statusNotInPool, fmt.Sprintf(" Retest budget of %d exhausted, needs /override or %s label.", budget.MaxRetests, budget.GetLabel())), true
+	}
+	if failures > 0 {
+		return fmt.Sprintf(" (retest %d of %d)", failures, budget.MaxRetests), false
+	}
+	return "", false
+}
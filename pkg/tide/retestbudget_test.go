@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestRetestBudget(t *testing.T) {
+	now := time.Now()
+	pj := func(name, job string, jobType prowapi.ProwJobType, state prowapi.ProwJobState, age time.Duration, byTide bool, pulls ...prowapi.Pull) runtime.Object {
+		labels := map[string]string{}
+		if byTide {
+			labels[kube.CreatedByTideLabel] = "true"
+		}
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec: prowapi.ProwJobSpec{
+				Job:  job,
+				Type: jobType,
+				Refs: &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", Pulls: pulls},
+			},
+			Status: prowapi.ProwJobStatus{
+				State:     state,
+				StartTime: metav1.NewTime(now.Add(-age)),
+			},
+		}
+	}
+	pull := prowapi.Pull{Number: 1, SHA: "head"}
+	otherPull := prowapi.Pull{Number: 2, SHA: "other-head"}
+	oldPull := prowapi.Pull{Number: 1, SHA: "old-head"}
+
+	testCases := []struct {
+		name             string
+		budgets          map[string]config.TideRetestBudget
+		labels           []string
+		pjs              []runtime.Object
+		expectedFailures int
+		expectedLimited  bool
+		expectedWithin   bool
+	}{
+		{
+			name:           "no budget",
+			pjs:            []runtime.Object{pj("a", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, pull)},
+			expectedWithin: true,
+		},
+		{
+			name:    "unlimited budget overrides the global one",
+			budgets: map[string]config.TideRetestBudget{"*": {MaxRetests: 1}, "org/repo": {MaxRetests: -1}},
+			pjs: []runtime.Object{
+				pj("a", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, pull),
+				pj("b", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, pull),
+			},
+			expectedWithin: true,
+		},
+		{
+			name:    "failures of serial and single-PR batch runs of the same job are counted",
+			budgets: map[string]config.TideRetestBudget{"org": {MaxRetests: 2}},
+			pjs: []runtime.Object{
+				pj("a", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, pull),
+				pj("b", "unit", prowapi.BatchJob, prowapi.ErrorState, time.Hour, true, pull),
+				pj("c", "e2e", prowapi.BatchJob, prowapi.FailureState, time.Hour, true, pull),
+			},
+			expectedFailures: 2,
+			expectedLimited:  true,
+			expectedWithin:   true,
+		},
+		{
+			name:    "budget is exhausted",
+			budgets: map[string]config.TideRetestBudget{"*": {MaxRetests: 1}},
+			pjs: []runtime.Object{
+				pj("a", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, pull),
+				pj("b", "unit", prowapi.BatchJob, prowapi.FailureState, time.Hour, true, pull),
+			},
+			expectedFailures: 2,
+			expectedLimited:  true,
+		},
+		{
+			name:    "failures outside the window, of other commits, not by Tide, of batches of several PRs, or other runs are not counted",
+			budgets: map[string]config.TideRetestBudget{"*": {MaxRetests: 1, Window: &metav1.Duration{Duration: 2 * time.Hour}}},
+			pjs: []runtime.Object{
+				pj("a", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, pull),
+				pj("b", "unit", prowapi.PresubmitJob, prowapi.FailureState, 3*time.Hour, true, pull),
+				pj("c", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, oldPull),
+				pj("d", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, false, pull),
+				pj("e", "unit", prowapi.PresubmitJob, prowapi.SuccessState, time.Hour, true, pull),
+				pj("f", "unit", prowapi.BatchJob, prowapi.FailureState, time.Hour, true, otherPull),
+				pj("g", "unit", prowapi.BatchJob, prowapi.FailureState, time.Hour, true, pull, otherPull),
+			},
+			expectedFailures: 1,
+			expectedLimited:  true,
+			expectedWithin:   true,
+		},
+		{
+			name:    "label lifts the budget",
+			budgets: map[string]config.TideRetestBudget{"*": {MaxRetests: 0}},
+			labels:  []string{"tide/ignore-retest-budget"},
+			pjs: []runtime.Object{
+				pj("a", "unit", prowapi.PresubmitJob, prowapi.FailureState, time.Hour, true, pull),
+			},
+			expectedWithin: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{ProwConfig: config.ProwConfig{
+				ProwJobNamespace: "default",
+				Tide:             config.Tide{RetestBudgetMap: tc.budgets},
+			}}
+			pr := PullRequest{Number: 1, HeadRefOID: "head"}
+			pr.Repository.Owner.Login = "org"
+			pr.Repository.Name = "repo"
+			for _, label := range tc.labels {
+				pr.Labels.Nodes = append(pr.Labels.Nodes, struct{ Name githubql.String }{Name: githubql.String(label)})
+			}
+			crc := CodeReviewCommonFromPullRequest(&pr)
+
+			ctx := context.Background()
+			mgr := newFakeManager(t, ctx, tc.pjs...)
+			_, failures, limited, err := retestBudget(ctx, mgr.GetClient(), cfg, crc, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if failures != tc.expectedFailures || limited != tc.expectedLimited {
+				t.Errorf("expected %d failures and limited %t, got %d failures and limited %t", tc.expectedFailures, tc.expectedLimited, failures, limited)
+			}
+
+			c := &syncController{ctx: ctx, prowJobClient: mgr.GetClient(), config: func() *config.Config { return cfg }, logger: logrus.WithField("test", tc.name)}
+			if within := c.withinRetestBudget(c.logger, crc); within != tc.expectedWithin {
+				t.Errorf("expected within budget %t, got %t", tc.expectedWithin, within)
+			}
+		})
+	}
+}
+
+func TestRetestBudgetStatus(t *testing.T) {
+	testCases := []struct {
+		failures          int
+		expectedStatus    string
+		expectedExhausted bool
+	}{
+		{
+			failures: 0,
+		},
+		{
+			failures:       2,
+			expectedStatus: " (retest 2 of 2)",
+		},
+		{
+			failures:          3,
+			expectedStatus:    "Not mergeable. Retest budget of 2 exhausted, needs /override or tide/ignore-retest-budget label.",
+			expectedExhausted: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d failures", tc.failures), func(t *testing.T) {
+			status, exhausted := retestBudgetStatus(config.TideRetestBudget{MaxRetests: 2}, tc.failures)
+			if status != tc.expectedStatus || exhausted != tc.expectedExhausted {
+				t.Errorf("expected %q (exhausted %t), got %q (exhausted %t)", tc.expectedStatus, tc.expectedExhausted, status, exhausted)
+			}
+		})
+	}
+}
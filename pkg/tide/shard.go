@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// Shard identifies the disjoint subset of orgs and repos a Tide replica is
+// responsible for. Either Name is set, selecting a shard from tide.shards, or
+// Count is positive and orgs are distributed across replicas by hashing.
+type Shard struct {
+	Name  string
+	Index int
+	Count int
+}
+
+// Enabled returns true if the replica only handles a subset of the queries.
+func (s Shard) Enabled() bool {
+	return s.Name != "" || s.Count > 0
+}
+
+// ID is a stable identifier for the shard, used to name its coordination lease.
+func (s Shard) ID() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return fmt.Sprintf("%d-of-%d", s.Index, s.Count)
+}
+
+func (s Shard) ownsOrgByHash(org string) bool {
+	h := fnv.New32a()
+	h.Write([]byte(org))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// filterQueries restricts the queries to the orgs and repos owned by the shard.
+// Queries that end up without any org or repo are dropped.
+func (s Shard) filterQueries(tide *config.Tide) config.TideQueries {
+	var shardRepos []string
+	if s.Name != "" {
+		shard, _ := tide.Shard(s.Name)
+		shardRepos = shard.Repos
+	}
+	owns := func(repo config.OrgRepo) bool {
+		if s.Name != "" {
+			return tide.ShardFor(repo) == s.Name
+		}
+		return s.ownsOrgByHash(repo.Org)
+	}
+
+	var filtered config.TideQueries
+	for _, query := range tide.Queries {
+		excluded := sets.New[string](query.ExcludedRepos...)
+		repos := sets.New[string]()
+		var orgs []string
+		for _, org := range query.Orgs {
+			if owns(config.OrgRepo{Org: org}) {
+				orgs = append(orgs, org)
+				continue
+			}
+			// The org belongs to another shard, but some of its repos may
+			// be explicitly assigned to this one.
+			for _, repo := range shardRepos {
+				if orgRepo := config.NewOrgRepo(repo); orgRepo.Org == org && !excluded.Has(repo) {
+					repos.Insert(repo)
+				}
+			}
+		}
+		for _, repo := range query.Repos {
+			if owns(*config.NewOrgRepo(repo)) {
+				repos.Insert(repo)
+			}
+		}
+		if len(orgs) == 0 && repos.Len() == 0 {
+			continue
+		}
+
+		orgSet := sets.New[string](orgs...)
+		var excludedRepos []string
+		for _, repo := range query.ExcludedRepos {
+			if orgSet.Has(config.NewOrgRepo(repo).Org) {
+				excludedRepos = append(excludedRepos, repo)
+			}
+		}
+		if s.Name != "" {
+			// Repos of owned orgs that are explicitly assigned to another shard
+			// must not be merged by this one.
+			for _, shard := range tide.Shards {
+				if shard.Name == s.Name {
+					continue
+				}
+				for _, repo := range shard.Repos {
+					if orgSet.Has(config.NewOrgRepo(repo).Org) && !excluded.Has(repo) {
+						excludedRepos = append(excludedRepos, repo)
+					}
+				}
+			}
+		}
+
+		query.Orgs = orgs
+		query.Repos = nil
+		if repos.Len() > 0 {
+			query.Repos = sets.List(repos)
+		}
+		query.ExcludedRepos = excludedRepos
+		filtered = append(filtered, query)
+	}
+	return filtered
+}
+
+// ShardedConfig returns a config.Getter whose Tide queries only cover the orgs
+// and repos owned by the shard. Everything else in the config is unchanged.
+// The filtered config is recomputed only when the underlying config changes.
+func ShardedConfig(cfg config.Getter, shard Shard) config.Getter {
+	if !shard.Enabled() {
+		return cfg
+	}
+	var lock sync.Mutex
+	var source, sharded *config.Config
+	return func() *config.Config {
+		current := cfg()
+		lock.Lock()
+		defer lock.Unlock()
+		if current != source {
+			copied := *current
+			copied.Tide.Queries = shard.filterQueries(&current.Tide)
+			source, sharded = current, &copied
+		}
+		return sharded
+	}
+}
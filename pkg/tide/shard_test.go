@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tide
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestShardFilterQueries(t *testing.T) {
+	shards := []config.TideShard{
+		{Name: "k8s", Orgs: []string{"kubernetes"}},
+		{Name: "sigs", Orgs: []string{"kubernetes-sigs"}, Repos: []string{"kubernetes/test-infra"}},
+	}
+	queries := config.TideQueries{
+		{
+			Orgs:          []string{"kubernetes", "kubernetes-sigs"},
+			ExcludedRepos: []string{"kubernetes/kops", "kubernetes-sigs/kind"},
+			Labels:        []string{"lgtm"},
+		},
+		{
+			Repos:  []string{"kubernetes-sigs/prow"},
+			Labels: []string{"approved"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		shard    Shard
+		expected config.TideQueries
+	}{
+		{
+			name:  "explicit shard owning an org excludes repos of other shards",
+			shard: Shard{Name: "k8s"},
+			expected: config.TideQueries{
+				{
+					Orgs:          []string{"kubernetes"},
+					ExcludedRepos: []string{"kubernetes/kops", "kubernetes/test-infra"},
+					Labels:        []string{"lgtm"},
+				},
+			},
+		},
+		{
+			name:  "explicit shard gets its repos from orgs owned by other shards",
+			shard: Shard{Name: "sigs"},
+			expected: config.TideQueries{
+				{
+					Orgs:          []string{"kubernetes-sigs"},
+					Repos:         []string{"kubernetes/test-infra"},
+					ExcludedRepos: []string{"kubernetes-sigs/kind"},
+					Labels:        []string{"lgtm"},
+				},
+				{
+					Repos:  []string{"kubernetes-sigs/prow"},
+					Labels: []string{"approved"},
+				},
+			},
+		},
+		{
+			name:  "unknown shard owns nothing",
+			shard: Shard{Name: "unknown"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tide := &config.Tide{TideGitHubConfig: config.TideGitHubConfig{Queries: queries, Shards: shards}}
+			actual := tc.shard.filterQueries(tide)
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected queries (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShardFilterQueriesByHash(t *testing.T) {
+	orgs := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	tide := &config.Tide{TideGitHubConfig: config.TideGitHubConfig{Queries: config.TideQueries{{Orgs: orgs, Repos: []string{"x/y"}}}}}
+
+	const count = 3
+	owners := map[string]int{}
+	for i := 0; i < count; i++ {
+		for _, query := range (Shard{Index: i, Count: count}).filterQueries(tide) {
+			for _, org := range query.Orgs {
+				owners[org]++
+			}
+			for _, repo := range query.Repos {
+				owners[repo]++
+			}
+		}
+	}
+	for _, key := range append(orgs, "x/y") {
+		if owners[key] != 1 {
+			t.Errorf("expected %q to be owned by exactly one shard, got %d", key, owners[key])
+		}
+	}
+}
+
+func TestShardedConfig(t *testing.T) {
+	cfg := &config.Config{ProwConfig: config.ProwConfig{Tide: config.Tide{TideGitHubConfig: config.TideGitHubConfig{
+		Queries: config.TideQueries{{Orgs: []string{"kubernetes", "kubernetes-sigs"}}},
+		Shards: []config.TideShard{
+			{Name: "k8s", Orgs: []string{"kubernetes"}},
+			{Name: "sigs", Orgs: []string{"kubernetes-sigs"}},
+		},
+	}}}}
+	getter := func() *config.Config { return cfg }
+
+	if unsharded := ShardedConfig(getter, Shard{}); unsharded() != cfg {
+		t.Error("expected an unsharded config to be returned unchanged")
+	}
+
+	sharded := ShardedConfig(getter, Shard{Name: "k8s"})
+	first := sharded()
+	if diff := cmp.Diff(config.TideQueries{{Orgs: []string{"kubernetes"}}}, first.Tide.Queries); diff != "" {
+		t.Errorf("unexpected queries (-want +got):\n%s", diff)
+	}
+	if second := sharded(); second != first {
+		t.Error("expected the sharded config to be cached while the config is unchanged")
+	}
+	if len(cfg.Tide.Queries[0].Orgs) != 2 {
+		t.Error("the underlying config must not be modified")
+	}
+}
@@ -260,6 +260,15 @@ func requirementDiff(pr *PullRequest, q *config.TideQuery, cc contextChecker) (s
 			desc = " PullRequest is missing sufficient approving GitHub review(s)"
 		}
 	}
+
+	if q.RequireResolvedConversations {
+		if unresolved := pr.unresolvedReviewThreads(); unresolved > 0 {
+			diff += unresolved
+			if desc == "" {
+				desc = fmt.Sprintf(" Has %d unresolved review conversation(s).", unresolved)
+			}
+		}
+	}
 	return desc, diff
 }
 
@@ -316,6 +325,16 @@ func (sc *statusController) expectedStatus(log *logrus.Entry, queryMap *config.Q
 		var minDiff string
 		for _, q := range queryMap.ForRepo(repo) {
 			diff, diffCount := requirementDiff(pr, &q, cc)
+			// Only poll the Deployments API once everything else is in place.
+			if env := q.RequiredDeploymentEnvironment; diffCount == 0 && env != "" {
+				deployed, err := sc.ghProvider.deployed(crc.Org, crc.Repo, crc.HeadRefOID, env)
+				if err != nil {
+					log.WithError(err).WithField("environment", env).Warn("Failed to check the deployments of the PR.")
+				}
+				if !deployed {
+					diff, diffCount = fmt.Sprintf(" Needs a successful deployment to %s.", env), 1
+				}
+			}
 			if diffCount == 0 {
 				hasFulfilledQuery = true
 				break
@@ -355,7 +374,18 @@ func (sc *statusController) expectedStatus(log *logrus.Entry, queryMap *config.Q
 		passingUpToDateContexts = append(passingUpToDateContexts, pj.Spec.Context)
 	}
 	if diff := cc.MissingRequiredContexts(passingUpToDateContexts); len(diff) > 0 {
-		return github.StatePending, retestingStatus(diff), nil
+		budget, failures, limited, err := retestBudget(context.Background(), sc.pjClient, sc.config(), crc, time.Now())
+		if err != nil {
+			log.WithError(err).Warn("Failed to determine the retest budget of the PR.")
+		}
+		if !limited {
+			return github.StatePending, retestingStatus(diff), nil
+		}
+		budgetStatus, exhausted := retestBudgetStatus(budget, failures)
+		if exhausted {
+			return github.StatePending, budgetStatus, nil
+		}
+		return github.StatePending, retestingStatus(diff) + budgetStatus, nil
 	}
 	return github.StatusSuccess, statusInPool, nil
 }
@@ -68,6 +68,8 @@ func TestExpectedStatus(t *testing.T) {
 		displayAllTideQueries bool
 		additionalTideQueries []config.TideQuery
 		hasApprovingReview    bool
+		reviewThreadsResolved []bool
+		deploymentStates      []string
 		singleQuery           bool
 
 		state string
@@ -732,6 +734,59 @@ func TestExpectedStatus(t *testing.T) {
 			state: github.StatusSuccess,
 			desc:  "In merge pool.",
 		},
+		{
+			name:                  "Unresolved review conversations",
+			additionalTideQueries: []config.TideQuery{{Orgs: []string{""}, RequireResolvedConversations: true}},
+			inPool:                false,
+			reviewThreadsResolved: []bool{true, false, false},
+
+			state: github.StatusPending,
+			desc:  "Not mergeable. Has 2 unresolved review conversation(s).",
+		},
+		{
+			name:                  "Required review conversations are resolved",
+			additionalTideQueries: []config.TideQuery{{Orgs: []string{""}, RequireResolvedConversations: true}},
+			inPool:                false,
+			reviewThreadsResolved: []bool{true, true},
+
+			state: github.StatusSuccess,
+			desc:  "In merge pool.",
+		},
+		{
+			name:                  "Required deployment is missing",
+			additionalTideQueries: []config.TideQuery{{Orgs: []string{""}, RequiredDeploymentEnvironment: "staging"}},
+			inPool:                false,
+
+			state: github.StatusPending,
+			desc:  "Not mergeable. Needs a successful deployment to staging.",
+		},
+		{
+			name:                  "Required deployment failed",
+			additionalTideQueries: []config.TideQuery{{Orgs: []string{""}, RequiredDeploymentEnvironment: "staging"}},
+			inPool:                false,
+			deploymentStates:      []string{github.DeploymentStateFailure, github.DeploymentStateInProgress},
+
+			state: github.StatusPending,
+			desc:  "Not mergeable. Needs a successful deployment to staging.",
+		},
+		{
+			name:                  "Required deployment succeeded",
+			additionalTideQueries: []config.TideQuery{{Orgs: []string{""}, RequiredDeploymentEnvironment: "staging"}},
+			inPool:                false,
+			deploymentStates:      []string{github.DeploymentStateInactive, github.DeploymentStateSuccess},
+
+			state: github.StatusSuccess,
+			desc:  "In merge pool.",
+		},
+		{
+			name:                  "Required deployment is not checked while other requirements are missing",
+			labels:                []string{},
+			additionalTideQueries: []config.TideQuery{{Orgs: []string{""}, Labels: []string{"lgtm"}, RequiredDeploymentEnvironment: "staging"}},
+			inPool:                false,
+
+			state: github.StatusPending,
+			desc:  "Not mergeable. Needs lgtm label.",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -809,6 +864,9 @@ func TestExpectedStatus(t *testing.T) {
 			if tc.hasApprovingReview {
 				pr.ReviewDecision = githubql.PullRequestReviewDecisionApproved
 			}
+			for _, resolved := range tc.reviewThreadsResolved {
+				pr.ReviewThreads.Nodes = append(pr.ReviewThreads.Nodes, struct{ IsResolved githubql.Boolean }{IsResolved: githubql.Boolean(resolved)})
+			}
 			var pool map[string]CodeReviewCommon
 			if tc.inPool {
 				pool = map[string]CodeReviewCommon{"#0": {}}
@@ -834,10 +892,19 @@ func TestExpectedStatus(t *testing.T) {
 			ctx := context.Background()
 			mgr := newFakeManager(t, ctx, tc.prowJobs...)
 
+			ghc := &fgc{}
+			if len(tc.deploymentStates) > 0 {
+				ghc.deployments = map[string][]github.Deployment{"head": {{ID: 1, Environment: "staging"}}}
+				ghc.deploymentStatuses = map[int][]github.DeploymentStatus{}
+				for _, state := range tc.deploymentStates {
+					ghc.deploymentStatuses[1] = append(ghc.deploymentStatuses[1], github.DeploymentStatus{State: state})
+				}
+			}
+
 			sc, err := newStatusController(
 				ctx,
 				logrus.NewEntry(logrus.StandardLogger()),
-				nil,
+				ghc,
 				mgr,
 				nil,
 				ca.Config,
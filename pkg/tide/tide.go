@@ -45,6 +45,8 @@ import (
 	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/plugins/ownersconfig"
+	"sigs.k8s.io/prow/pkg/repoowners"
 	"sigs.k8s.io/prow/pkg/tide/blockers"
 	"sigs.k8s.io/prow/pkg/tide/history"
 	_ "sigs.k8s.io/prow/pkg/version"
@@ -58,13 +60,18 @@ type githubClient interface {
 	GetCombinedStatus(org, repo, ref string) (*github.CombinedStatus, error)
 	ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
 	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetPullRequestsData(org, repo string, numbers []int) (map[int]github.PullRequestData, error)
 	GetRef(string, string, string) (string, error)
 	GetRepo(owner, name string) (github.FullRepo, error)
 	Merge(string, string, int, github.MergeDetails) error
 	QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error
 	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	ListReviews(org, repo string, number int) ([]github.Review, error)
+	ListPullRequestComments(org, repo string, number int) ([]github.ReviewComment, error)
 	BotUserChecker() (func(candidate string) bool, error)
 	DeleteComment(org, repo string, id int) error
+	ListDeployments(org, repo, sha, environment string) ([]github.Deployment, error)
+	ListDeploymentStatuses(org, repo string, id int) ([]github.DeploymentStatus, error)
 }
 
 type contextChecker interface {
@@ -92,6 +99,11 @@ type syncController struct {
 
 	History *history.History
 
+	// opener and poolsPath are where a snapshot of the pools is persisted
+	// to, so that they can be served right after a restart.
+	opener    io.Opener
+	poolsPath string
+
 	// Shared fields with status controller
 	statusUpdate *statusUpdate
 }
@@ -331,16 +343,18 @@ func NewController(
 	cfg config.Getter,
 	gc git.ClientFactory,
 	maxRecordsPerPool int,
+	maxRecordAge time.Duration,
 	opener io.Opener,
 	historyURI,
-	statusURI string,
+	statusURI,
+	poolsURI string,
 	logger *logrus.Entry,
 	usesGitHubAppsAuth bool,
 ) (*Controller, error) {
 	if logger == nil {
 		logger = logrus.NewEntry(logrus.StandardLogger())
 	}
-	hist, err := history.New(maxRecordsPerPool, opener, historyURI)
+	hist, err := history.New(maxRecordsPerPool, maxRecordAge, opener, historyURI)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing history client from %q: %w", historyURI, err)
 	}
@@ -361,13 +375,32 @@ func NewController(
 	go sc.run()
 
 	provider := newGitHubProvider(logger, ghcSync, gc, cfg, mergeChecker, usesGitHubAppsAuth)
+	provider.ownersClient = newOwnersClient(gc, ghcSync, cfg)
 	syncCtrl, err := newSyncController(ctx, logger, mgr, provider, cfg, gc, hist, usesGitHubAppsAuth, statusUpdate)
 	if err != nil {
 		return nil, err
 	}
+	syncCtrl.opener, syncCtrl.poolsPath = opener, poolsURI
+	syncCtrl.loadPools()
 	return &Controller{syncCtrl: syncCtrl, statusCtrl: sc}, nil
 }
 
+// newOwnersClient returns a client loading the OWNERS of repos with the
+// default settings of the plugins.
+func newOwnersClient(gc git.ClientFactory, ghc github.Client, cfg config.Getter) *repoowners.Client {
+	disabled := func(org, repo string) bool { return false }
+	ownersDirDenylist := func() *config.OwnersDirDenylist {
+		if l := cfg().OwnersDirDenylist; l != nil {
+			return l
+		}
+		return &config.OwnersDirDenylist{}
+	}
+	filenames := func(org, repo string) ownersconfig.Filenames {
+		return ownersconfig.Filenames{Owners: ownersconfig.DefaultOwnersFile, OwnersAliases: ownersconfig.DefaultOwnersAliasesFile}
+	}
+	return repoowners.NewClient(gc, ghc, disabled, disabled, ownersDirDenylist, filenames, disabled)
+}
+
 func newStatusController(
 	ctx context.Context,
 	logger *logrus.Entry,
@@ -446,6 +479,9 @@ func setupSyncControllerIndexes(ctx context.Context, indexer ctrlruntimeclient.F
 	if err := indexer.IndexField(ctx, &prowapi.ProwJob{}, nonFailedBatchByNameBaseAndPullsIndexName, nonFailedBatchByNameBaseAndPullsIndexFunc); err != nil {
 		return fmt.Errorf("failed to add index for non failed batches: %w", err)
 	}
+	if err := indexer.IndexField(ctx, &prowapi.ProwJob{}, failedTideJobsByPullIndexName, failedTideJobsByPullIndexFunc); err != nil {
+		return fmt.Errorf("failed to add index for failed jobs by pull: %w", err)
+	}
 	return nil
 }
 
@@ -554,9 +590,61 @@ func (c *syncController) Sync() error {
 	c.m.Unlock()
 
 	c.History.Flush()
+	c.flushPools(pools)
 	return utilerrors.NewAggregate(queryErrors)
 }
 
+// loadPools restores the pools from the last snapshot if one is configured,
+// so that they are served until the first sync finishes.
+func (c *syncController) loadPools() {
+	if c.poolsPath == "" {
+		return
+	}
+	log := c.logger.WithField("path", c.poolsPath)
+	b, err := io.ReadContent(c.ctx, log, c.opener, c.poolsPath)
+	if io.IsNotExist(err) {
+		log.Debug("No pools snapshot exists yet.")
+		return
+	}
+	if err != nil {
+		log.WithError(err).Warn("Cannot read pools snapshot.")
+		return
+	}
+	var pools []Pool
+	if err := json.Unmarshal(b, &pools); err != nil {
+		log.WithError(err).Warn("Cannot unmarshal pools snapshot.")
+		return
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	// A sync may have finished in the meantime.
+	if c.pools == nil {
+		c.pools = pools
+		log.Debugf("Restored %d pools from snapshot.", len(pools))
+	}
+}
+
+// flushPools writes a snapshot of the pools to persistent storage if
+// configured to do so.
+func (c *syncController) flushPools(pools []Pool) {
+	if c.poolsPath == "" {
+		return
+	}
+	log := c.logger.WithField("path", c.poolsPath)
+	b, err := json.Marshal(pools)
+	if err != nil {
+		log.WithError(err).Error("Cannot marshal pools snapshot.")
+		return
+	}
+	// Writes of large snapshots finish within seconds, the timeout only
+	// evicts writes that hang.
+	ctx, cancel := context.WithTimeout(c.ctx, 30*time.Second)
+	defer cancel()
+	if err := io.WriteContent(ctx, log, c.opener, c.poolsPath, b); err != nil {
+		log.WithError(err).Error("Error flushing pools snapshot.")
+	}
+}
+
 func (c *syncController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c.m.Lock()
 	defer c.m.Unlock()
@@ -1145,7 +1233,7 @@ func (c *syncController) pickBatch(sp subpool, cc map[int]contextChecker, newBat
 	for _, pr := range sp.prs {
 		// c.isRetestEligible appends `Commits` into the passed in PullRequest
 		// struct, which is used later to avoid repeatedly looking up on GitHub.
-		if c.isRetestEligible(sp.log, &pr, cc[pr.Number]) {
+		if c.isRetestEligible(sp.log, &pr, cc[pr.Number]) && c.withinRetestBudget(sp.log, &pr) {
 			candidates = append(candidates, pr)
 		}
 	}
@@ -1453,7 +1541,13 @@ func (c *syncController) takeAction(sp subpool, batchPending, successes, pending
 	}
 	// If we have no serial jobs pending or successful, trigger one.
 	if len(missings) > 0 && len(pendings) == 0 && len(successes) == 0 {
-		if ok, pr := pickHighestPriorityPR(sp.log, missings, sp.cc, c.isRetestEligible, c.config().Tide.Priority); ok {
+		var withinBudget []CodeReviewCommon
+		for _, pr := range missings {
+			if c.withinRetestBudget(sp.log, &pr) {
+				withinBudget = append(withinBudget, pr)
+			}
+		}
+		if ok, pr := pickHighestPriorityPR(sp.log, withinBudget, sp.cc, c.isRetestEligible, c.config().Tide.Priority); ok {
 			return Trigger, []CodeReviewCommon{pr}, c.trigger(sp, missingSerialTests[pr.Number], []CodeReviewCommon{pr})
 		}
 	}
@@ -1522,6 +1616,53 @@ func (c *changedFilesAgent) prChanges(pr *CodeReviewCommon) config.ChangedFilesP
 	}
 }
 
+// bulkChangedFilesGetter is implemented by providers that can query the files
+// changed by many PRs at once.
+type bulkChangedFilesGetter interface {
+	getPullRequestsData(org, repo string, numbers []int) (map[int]github.PullRequestData, error)
+}
+
+// prefetch queries the files changed by the PRs that are not cached yet at
+// once, if the provider supports it, instead of querying them one by one when
+// they are needed. Failing to prefetch them is not fatal as they are queried
+// one by one then.
+func (c *changedFilesAgent) prefetch(log *logrus.Entry, org, repo string, prs []CodeReviewCommon) {
+	getter, ok := c.provider.(bulkChangedFilesGetter)
+	if !ok {
+		return
+	}
+	var numbers []int
+	c.RLock()
+	for _, pr := range prs {
+		cacheKey := changeCacheKey{org: org, repo: repo, number: pr.Number, sha: pr.HeadRefOID}
+		if _, ok := c.changeCache[cacheKey]; ok {
+			continue
+		}
+		if _, ok := c.nextChangeCache[cacheKey]; ok {
+			continue
+		}
+		numbers = append(numbers, pr.Number)
+	}
+	c.RUnlock()
+	if len(numbers) < 2 {
+		return
+	}
+
+	data, err := getter.getPullRequestsData(org, repo, numbers)
+	if err != nil {
+		log.WithError(err).Warn("Failed to prefetch the files changed by PRs.")
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	for _, prData := range data {
+		// If the PR changed in the meantime, the files are queried again
+		// when they are needed as the SHA doesn't match.
+		cacheKey := changeCacheKey{org: org, repo: repo, number: prData.Number, sha: prData.HeadSHA}
+		c.nextChangeCache[cacheKey] = append([]string{}, prData.Files...)
+	}
+}
+
 func (c *changedFilesAgent) batchChanges(prs []CodeReviewCommon) config.ChangedFilesProvider {
 	return func() ([]string, error) {
 		result := sets.Set[string]{}
@@ -1552,6 +1693,21 @@ func refGetterFactory(ref string) config.RefGetter {
 	}
 }
 
+// presubmitsCouldRunAgainstChanges tells whether the presubmits of the repo
+// could depend on the files changed by PRs. The presubmits of repos with
+// inrepoconfig could always depend on them.
+func presubmitsCouldRunAgainstChanges(cfg *config.Config, orgRepo string) bool {
+	if cfg.InRepoConfigEnabled(orgRepo) {
+		return true
+	}
+	for _, ps := range cfg.GetPresubmitsStatic(orgRepo) {
+		if ps.RegexpChangeMatcher.CouldRun() {
+			return true
+		}
+	}
+	return false
+}
+
 // presubmitsByPull creates a map pr -> requiredPresubmits and will filter out all PRs
 // where we failed to find out the required presubmits (can happen if inrepoconfig is enabled).
 func (c *syncController) presubmitsByPull(sp *subpool) (map[int][]config.Presubmit, error) {
@@ -1560,6 +1716,10 @@ func (c *syncController) presubmitsByPull(sp *subpool) (map[int][]config.Presubm
 	// filtered PRs contains all PRs for which we were able to get the presubmits
 	var filteredPRs []CodeReviewCommon
 
+	if presubmitsCouldRunAgainstChanges(c.config(), sp.org+"/"+sp.repo) {
+		c.changedFiles.prefetch(sp.log, sp.org, sp.repo, sp.prs)
+	}
+
 	for _, pr := range sp.prs {
 		log := c.logger.WithField("base-sha", sp.sha).WithFields(pr.logFields())
 		requireManuallyTriggeredJobs := requireManuallyTriggeredJobs(c.config(), sp.org, sp.repo, pr.BaseRefName)
@@ -1870,6 +2030,9 @@ type PullRequest struct {
 	Body      githubql.String
 	Title     githubql.String
 	UpdatedAt githubql.DateTime
+
+	// ReviewThreads are needed for queries that require resolved conversations.
+	ReviewThreads ReviewThreads `graphql:"reviewThreads(first: 100)"`
 }
 
 func (pr *PullRequest) logFields() logrus.Fields {
@@ -1888,6 +2051,24 @@ type Labels struct {
 	}
 }
 
+type ReviewThreads struct {
+	Nodes []struct {
+		IsResolved githubql.Boolean
+	}
+}
+
+// unresolvedReviewThreads returns the number of unresolved review threads of
+// the PR.
+func (pr *PullRequest) unresolvedReviewThreads() int {
+	var unresolved int
+	for _, thread := range pr.ReviewThreads.Nodes {
+		if !thread.IsResolved {
+			unresolved++
+		}
+	}
+	return unresolved
+}
+
 type Milestone struct {
 	Title githubql.String
 }
@@ -24,6 +24,7 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
@@ -55,6 +56,7 @@ import (
 	"sigs.k8s.io/prow/pkg/git/types"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/testutil"
 	"sigs.k8s.io/prow/pkg/tide/history"
@@ -708,11 +710,18 @@ type fgc struct {
 	mergeErrs     map[int]error
 	queryCalls    int
 	issueComments map[int][]github.IssueComment
+	reviews       map[int][]github.Review
+	// changes overrides the changed files of PRs.
+	changes map[int][]github.PullRequestChange
 
 	expectedSHA          string
 	skipExpectedShaCheck bool
 	combinedStatus       map[string]string
 	checkRuns            *github.CheckRunList
+
+	// deployments maps head SHAs to their deployments.
+	deployments        map[string][]github.Deployment
+	deploymentStatuses map[int][]github.DeploymentStatus
 }
 
 func (f *fgc) GetRepo(o, r string) (github.FullRepo, error) {
@@ -802,6 +811,9 @@ func (f *fgc) ListCheckRuns(org, repo, ref string) (*github.CheckRunList, error)
 }
 
 func (f *fgc) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	if changes, ok := f.changes[number]; ok {
+		return changes, nil
+	}
 	if number != 100 {
 		return nil, nil
 	}
@@ -813,14 +825,46 @@ func (f *fgc) GetPullRequestChanges(org, repo string, number int) ([]github.Pull
 		nil
 }
 
+func (f *fgc) GetPullRequestsData(org, repo string, numbers []int) (map[int]github.PullRequestData, error) {
+	data := map[int]github.PullRequestData{}
+	for _, number := range numbers {
+		if number == 100 {
+			data[number] = github.PullRequestData{Number: number, HeadSHA: "sha", Files: []string{"CHANGED"}}
+		}
+	}
+	return data, nil
+}
+
 func (f *fgc) ListIssueComments(org, repo string, number int) ([]github.IssueComment, error) {
 	return f.issueComments[number], nil
 }
 
+func (f *fgc) ListReviews(org, repo string, number int) ([]github.Review, error) {
+	return f.reviews[number], nil
+}
+
+func (f *fgc) ListPullRequestComments(org, repo string, number int) ([]github.ReviewComment, error) {
+	return nil, nil
+}
+
 func (f *fgc) BotUserChecker() (func(candidate string) bool, error) {
 	return func(candidate string) bool { return candidate == "foo-bot" }, nil
 }
 
+func (f *fgc) ListDeployments(org, repo, sha, environment string) ([]github.Deployment, error) {
+	var deployments []github.Deployment
+	for _, deployment := range f.deployments[sha] {
+		if deployment.Environment == environment {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments, nil
+}
+
+func (f *fgc) ListDeploymentStatuses(org, repo string, id int) ([]github.DeploymentStatus, error) {
+	return f.deploymentStatuses[id], nil
+}
+
 func (f *fgc) DeleteComment(org, repo string, id int) error {
 	for issue, ics := range f.issueComments {
 		for j := len(ics) - 1; j >= 0; j-- {
@@ -2078,7 +2122,7 @@ func TestServeHTTP(t *testing.T) {
 		Context:     githubql.String("coverage/coveralls"),
 		Description: githubql.String("Coverage increased (+0.1%) to 27.599%"),
 	}}
-	hist, err := history.New(100, nil, "")
+	hist, err := history.New(100, 0, nil, "")
 	if err != nil {
 		t.Fatalf("Failed to create history client: %v", err)
 	}
@@ -2111,6 +2155,50 @@ func TestServeHTTP(t *testing.T) {
 	}
 }
 
+func TestPoolsSnapshot(t *testing.T) {
+	opener, err := io.NewOpener(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("Failed to create opener: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "pools.json")
+	pr := testPR("org", "repo", "main", 1, githubql.MergeableStateMergeable)
+	pools := []Pool{
+		{
+			Org:        "org",
+			Repo:       "repo",
+			Branch:     "main",
+			SuccessPRs: []CodeReviewCommon{*CodeReviewCommonFromPullRequest(pr)},
+			Action:     Merge,
+			Target:     []CodeReviewCommon{*CodeReviewCommonFromPullRequest(pr)},
+			TenantIDs:  []string{"tenant"},
+		},
+	}
+	newController := func() *syncController {
+		return &syncController{ctx: context.Background(), logger: logrus.WithField("test", t.Name()), opener: opener, poolsPath: path}
+	}
+
+	c := newController()
+	c.loadPools()
+	if c.pools != nil {
+		t.Errorf("Expected no pools to be restored without a snapshot, got %v.", c.pools)
+	}
+
+	newController().flushPools(pools)
+	c = newController()
+	c.loadPools()
+	if diff := cmp.Diff(pools, c.pools); diff != "" {
+		t.Errorf("Restored pools differ from the snapshot (-want +got):\n%s", diff)
+	}
+
+	// Pools of a finished sync are not overwritten.
+	c = newController()
+	c.pools = []Pool{}
+	c.loadPools()
+	if len(c.pools) != 0 {
+		t.Errorf("Expected pools of a sync not to be overwritten, got %v.", c.pools)
+	}
+}
+
 func testPR(org, repo, branch string, number int, mergeable githubql.MergeableState) *PullRequest {
 	pr := PullRequest{
 		Number:     githubql.Int(number),
@@ -2262,7 +2350,7 @@ func TestSync(t *testing.T) {
 					},
 				},
 			})
-			hist, err := history.New(100, nil, "")
+			hist, err := history.New(100, 0, nil, "")
 			if err != nil {
 				t.Fatalf("Failed to create history client: %v", err)
 			}
@@ -3246,6 +3334,9 @@ func TestPresubmitsByPull(t *testing.T) {
 			prs: []CodeReviewCommon{
 				{Number: 1, HeadRefOID: "1"},
 			},
+			// The files changed by the PRs are prefetched as inrepoconfig
+			// presubmits could run against them.
+			expectedChangeCache: map[changeCacheKey][]string{{number: 100, sha: "sha"}: {"CHANGED"}},
 			expectedPresubmits: map[int][]config.Presubmit{
 				1: {
 					{AlwaysRun: true, Reporter: config.Reporter{Context: "always"}},
@@ -3271,6 +3362,9 @@ func TestPresubmitsByPull(t *testing.T) {
 			prs: []CodeReviewCommon{
 				{Number: 1, HeadRefOID: "1"},
 			},
+			// The files changed by the PRs are prefetched as inrepoconfig
+			// presubmits could run against them.
+			expectedChangeCache: map[changeCacheKey][]string{{number: 100, sha: "sha"}: {"CHANGED"}},
 			expectedPresubmits: map[int][]config.Presubmit{
 				100: {
 					{AlwaysRun: true, Reporter: config.Reporter{Context: "always"}},
@@ -3938,6 +4032,62 @@ func TestPresubmitsForBatch(t *testing.T) {
 	}
 }
 
+func TestChangedFilesAgentPrefetch(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prs         []CodeReviewCommon
+		changeCache map[changeCacheKey][]string
+		expected    map[changeCacheKey][]string
+	}{
+		{
+			name: "files changed by uncached PRs are prefetched",
+			prs: []CodeReviewCommon{
+				{Org: "org", Repo: "repo", Number: 100, HeadRefOID: "sha"},
+				{Org: "org", Repo: "repo", Number: 101, HeadRefOID: "sha"},
+			},
+			expected: map[changeCacheKey][]string{
+				{org: "org", repo: "repo", number: 100, sha: "sha"}: {"CHANGED"},
+			},
+		},
+		{
+			name: "a single uncached PR is not prefetched",
+			prs: []CodeReviewCommon{
+				{Org: "org", Repo: "repo", Number: 100, HeadRefOID: "sha"},
+				{Org: "org", Repo: "repo", Number: 101, HeadRefOID: "sha"},
+			},
+			changeCache: map[changeCacheKey][]string{
+				{org: "org", repo: "repo", number: 101, sha: "sha"}: {"FILE"},
+			},
+			expected: map[changeCacheKey][]string{},
+		},
+		{
+			name: "PR changed since it was listed",
+			prs: []CodeReviewCommon{
+				{Org: "org", Repo: "repo", Number: 100, HeadRefOID: "old-sha"},
+				{Org: "org", Repo: "repo", Number: 101, HeadRefOID: "sha"},
+			},
+			expected: map[changeCacheKey][]string{
+				{org: "org", repo: "repo", number: 100, sha: "sha"}: {"CHANGED"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			log := logrus.WithField("test", tc.name)
+			changedFiles := &changedFilesAgent{
+				provider:        newGitHubProvider(log, &fgc{}, nil, nil, nil, false),
+				changeCache:     tc.changeCache,
+				nextChangeCache: map[changeCacheKey][]string{},
+			}
+			changedFiles.prefetch(log, "org", "repo", tc.prs)
+			if diff := cmp.Diff(tc.expected, changedFiles.nextChangeCache, cmp.AllowUnexported(changeCacheKey{})); diff != "" {
+				t.Errorf("Unexpected cached changes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestChangedFilesAgentBatchChanges(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -4475,6 +4625,157 @@ func TestQueryShardsByOrgWhenAppsAuthIsEnabledOnly(t *testing.T) {
 	}
 }
 
+func TestQueryRequireResolvedConversations(t *testing.T) {
+	t.Parallel()
+
+	resolved := testPR("org", "repo", "A", 1, githubql.MergeableStateMergeable)
+	resolved.ReviewThreads.Nodes = append(resolved.ReviewThreads.Nodes, struct{ IsResolved githubql.Boolean }{IsResolved: true})
+	unresolved := testPR("org", "repo", "A", 2, githubql.MergeableStateMergeable)
+	unresolved.ReviewThreads.Nodes = append(unresolved.ReviewThreads.Nodes, struct{ IsResolved githubql.Boolean }{IsResolved: true}, struct{ IsResolved githubql.Boolean }{IsResolved: false})
+
+	testCases := []struct {
+		name                         string
+		requireResolvedConversations bool
+		expected                     []string
+	}{
+		{
+			name:     "unresolved conversations are ignored by default",
+			expected: []string{prKey(CodeReviewCommonFromPullRequest(resolved)), prKey(CodeReviewCommonFromPullRequest(unresolved))},
+		},
+		{
+			name:                         "PRs with unresolved conversations are excluded",
+			requireResolvedConversations: true,
+			expected:                     []string{prKey(CodeReviewCommonFromPullRequest(resolved))},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &GitHubProvider{
+				cfg: func() *config.Config {
+					return &config.Config{ProwConfig: config.ProwConfig{Tide: config.Tide{
+						TideGitHubConfig: config.TideGitHubConfig{Queries: []config.TideQuery{{Orgs: []string{"org"}, RequireResolvedConversations: tc.requireResolvedConversations}}}}}}
+				},
+				ghc:    &fgc{prs: map[string][]PullRequest{"": {*resolved, *unresolved}}},
+				logger: logrus.WithField("test", tc.name),
+			}
+
+			prs, err := provider.Query()
+			if err != nil {
+				t.Fatalf("query() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, sets.List(sets.KeySet(prs))); diff != "" {
+				t.Errorf("unexpected PRs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQueryRequiredDeploymentEnvironment(t *testing.T) {
+	t.Parallel()
+
+	var prs []PullRequest
+	for i := 1; i <= 5; i++ {
+		pr := testPR("org", "repo", "A", i, githubql.MergeableStateMergeable)
+		pr.HeadRefOID = githubql.String(fmt.Sprintf("sha%d", i))
+		prs = append(prs, *pr)
+	}
+	ghc := &fgc{
+		prs: map[string][]PullRequest{"": prs},
+		deployments: map[string][]github.Deployment{
+			"sha1": {{ID: 1, Environment: "staging"}},
+			"sha2": {{ID: 3, Environment: "staging"}, {ID: 2, Environment: "staging"}},
+			"sha3": {{ID: 4, Environment: "production"}},
+			"sha4": {{ID: 5, Environment: "staging"}},
+		},
+		deploymentStatuses: map[int][]github.DeploymentStatus{
+			1: {{State: github.DeploymentStateInactive}, {State: github.DeploymentStateSuccess}, {State: github.DeploymentStateInProgress}},
+			2: {{State: github.DeploymentStateSuccess}},
+			3: {{State: github.DeploymentStateFailure}},
+			4: {{State: github.DeploymentStateSuccess}},
+			5: {{State: github.DeploymentStateInProgress}},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		environment string
+		expected    []int
+	}{
+		{
+			name:     "deployments are ignored by default",
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:        "only PRs whose latest deployment succeeded are kept",
+			environment: "staging",
+			expected:    []int{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &GitHubProvider{
+				cfg: func() *config.Config {
+					return &config.Config{ProwConfig: config.ProwConfig{Tide: config.Tide{
+						TideGitHubConfig: config.TideGitHubConfig{Queries: []config.TideQuery{{Orgs: []string{"org"}, RequiredDeploymentEnvironment: tc.environment}}}}}}
+				},
+				ghc:    ghc,
+				logger: logrus.WithField("test", tc.name),
+			}
+
+			prs, err := provider.Query()
+			if err != nil {
+				t.Fatalf("query() failed: %v", err)
+			}
+			var numbers []int
+			for _, pr := range prs {
+				numbers = append(numbers, pr.Number)
+			}
+			sort.Ints(numbers)
+			if diff := cmp.Diff(tc.expected, numbers); diff != "" {
+				t.Errorf("unexpected PRs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeployedCachesSuccessfulDeployments(t *testing.T) {
+	t.Parallel()
+
+	ghc := &fgc{
+		deployments: map[string][]github.Deployment{
+			"deployed": {{ID: 1, Environment: "staging"}},
+			"failed":   {{ID: 2, Environment: "staging"}},
+		},
+		deploymentStatuses: map[int][]github.DeploymentStatus{
+			1: {{State: github.DeploymentStateSuccess}},
+			2: {{State: github.DeploymentStateFailure}},
+		},
+	}
+	provider := newGitHubProvider(logrus.WithField("test", t.Name()), ghc, nil, nil, nil, false)
+	check := func(sha string, expected bool) {
+		t.Helper()
+		deployed, err := provider.deployed("org", "repo", sha, "staging")
+		if err != nil {
+			t.Fatalf("deployed() failed: %v", err)
+		}
+		if deployed != expected {
+			t.Errorf("expected %s to be deployed: %t, got %t", sha, expected, deployed)
+		}
+	}
+	check("deployed", true)
+	check("failed", false)
+
+	// Only successful deployments are cached.
+	ghc.deploymentStatuses = map[int][]github.DeploymentStatus{
+		1: {{State: github.DeploymentStateFailure}},
+		2: {{State: github.DeploymentStateSuccess}},
+	}
+	check("deployed", true)
+	check("failed", true)
+}
+
 func TestPickBatchPrefersBatchesWithPreexistingJobs(t *testing.T) {
 	t.Parallel()
 	const org, repo = "org", "repo"
@@ -4971,7 +5272,7 @@ func TestBatchPickingConsidersPRThatIsCurrentlyBeingSeriallyRetested(t *testing.
 	ctx := context.Background()
 	mgr := newFakeManager(t, ctx)
 	log := logrus.WithField("test", t.Name())
-	history, err := history.New(1, nil, "")
+	history, err := history.New(1, 0, nil, "")
 	if err != nil {
 		t.Fatalf("failed to construct history: %v", err)
 	}
@@ -5254,7 +5555,7 @@ func TestSerialRetestingConsidersPRThatIsCurrentlyBeingSRetested(t *testing.T) {
 	ctx := context.Background()
 	mgr := newFakeManager(t, ctx)
 	log := logrus.WithField("test", t.Name())
-	history, err := history.New(1, nil, "")
+	history, err := history.New(1, 0, nil, "")
 	if err != nil {
 		t.Fatalf("failed to construct history: %v", err)
 	}